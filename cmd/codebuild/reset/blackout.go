@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+)
+
+// blackoutMaxActivity is the most events recorded for a blackout.  One event
+// is enough to block the account, the rest are to help look into it.
+const blackoutMaxActivity = 25
+
+// blackoutMaxPages is the most pages of CloudTrail events checked in each
+// region.  The reset makes a lot of calls itself, which are skipped.
+const blackoutMaxPages = 50
+
+// serviceLinkedRolePath is the path of roles which AWS services use to act
+// on behalf of the account
+const serviceLinkedRolePath = ":role/aws-service-role/"
+
+// blackoutEvent is the part of a CloudTrail event which identifies who made
+// the call
+type blackoutEvent struct {
+	EventName    string `json:"eventName"`
+	EventSource  string `json:"eventSource"`
+	UserIdentity struct {
+		Type           string `json:"type"`
+		Arn            string `json:"arn"`
+		AccountID      string `json:"accountId"`
+		SessionContext struct {
+			SessionIssuer struct {
+				Arn string `json:"arn"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+}
+
+// principalArn is the role of the calls made with a role session, or else
+// the user which made them.  Calls from other accounts, like assuming a role,
+// are made by the other account.
+func (e *blackoutEvent) principalArn() string {
+	if e.UserIdentity.SessionContext.SessionIssuer.Arn != "" {
+		return e.UserIdentity.SessionContext.SessionIssuer.Arn
+	}
+	if e.UserIdentity.Arn == "" && e.UserIdentity.AccountID != "" {
+		return accountRootArn(e.UserIdentity.AccountID)
+	}
+	return e.UserIdentity.Arn
+}
+
+func accountRootArn(accountID string) string {
+	return "arn:aws:iam::" + accountID + ":root"
+}
+
+type findBlackoutActivityInput struct {
+	accountID string
+	// parentAccountID is DCE's account, which assumes the admin role
+	parentAccountID string
+	adminRoleArn    string
+	regions         []string
+	// ignoredPrincipals are principals outside DCE which are expected to
+	// make calls in accounts, eg. security scanners
+	ignoredPrincipals []string
+	// cloudTrail returns a client of the account's CloudTrail events
+	cloudTrail func(region string) cloudtrailiface.CloudTrailAPI
+	since      time.Time
+}
+
+// findBlackoutActivity looks up CloudTrail for calls made in the account since
+// the given time, by anyone but DCE's account, its admin role and AWS services
+func findBlackoutActivity(input *findBlackoutActivityInput) ([]*db.AccountBlackoutActivity, error) {
+	ignored := map[string]bool{
+		input.adminRoleArn:                    true,
+		accountRootArn(input.parentAccountID): true,
+	}
+	for _, principal := range input.ignoredPrincipals {
+		ignored[principal] = true
+	}
+
+	activity := []*db.AccountBlackoutActivity{}
+	for _, region := range input.regions {
+		pages := 0
+		err := input.cloudTrail(region).LookupEventsPages(&cloudtrail.LookupEventsInput{
+			StartTime: aws.Time(input.since),
+		}, func(out *cloudtrail.LookupEventsOutput, lastPage bool) bool {
+			pages++
+			for _, e := range out.Events {
+				event := blackoutEvent{}
+				if json.Unmarshal([]byte(aws.StringValue(e.CloudTrailEvent)), &event) != nil {
+					continue
+				}
+				principal := event.principalArn()
+				if event.UserIdentity.Type == "AWSService" || ignored[principal] ||
+					strings.Contains(principal, serviceLinkedRolePath) {
+					continue
+				}
+				activity = append(activity, &db.AccountBlackoutActivity{
+					PrincipalArn: principal,
+					EventName:    event.EventName,
+					EventSource:  event.EventSource,
+					Region:       region,
+					EventTime:    aws.TimeValue(e.EventTime).Unix(),
+				})
+				if len(activity) >= blackoutMaxActivity {
+					return false
+				}
+			}
+			if pages >= blackoutMaxPages && !lastPage {
+				log.Printf("Account %s has more than %d pages of events in %s, only the first were checked for a blackout",
+					input.accountID, blackoutMaxPages, region)
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up CloudTrail events in %s: %s", region, err)
+		}
+		if len(activity) >= blackoutMaxActivity {
+			break
+		}
+	}
+	return activity, nil
+}
+
+// blackoutSince is the start of the period checked for activity: when the
+// account was last modified before its reset, which is when its lease ended
+// or its reset was requested, or when its last blackout was acknowledged
+func blackoutSince(account *db.Account) time.Time {
+	since := account.LastModifiedOn
+	if account.Blackout != nil && account.Blackout.AcknowledgedOn != nil &&
+		*account.Blackout.AcknowledgedOn > since {
+		since = *account.Blackout.AcknowledgedOn
+	}
+	return time.Unix(since, 0)
+}
+
+// recordBlackout keeps the account NotReady until the activity is
+// acknowledged, and alerts operators and the account's contact so they can
+// look into it
+func recordBlackout(dbSvc db.DBer, alertSvc alertiface.Servicer, router routingiface.Servicer, accountID string, since time.Time, activity []*db.AccountBlackoutActivity) error {
+	log.Printf("Found %d calls by principals outside DCE in account %s since %s", len(activity), accountID, since.UTC())
+	_, err := dbSvc.RecordAccountBlackout(accountID, &db.AccountBlackout{
+		DetectedOn: time.Now().Unix(),
+		Since:      since.Unix(),
+		Activity:   activity,
+	})
+	if err != nil {
+		return err
+	}
+
+	principals := []string{}
+	seen := map[string]bool{}
+	for _, a := range activity {
+		if !seen[a.PrincipalArn] {
+			seen[a.PrincipalArn] = true
+			principals = append(principals, a.PrincipalArn)
+		}
+	}
+	summary := fmt.Sprintf("DCE found activity by principals outside DCE while account %s was reset", accountID)
+	err = alertSvc.Trigger(&alert.Alert{
+		Type:      alert.TypeResetBlackout,
+		AccountID: accountID,
+		Summary:   summary,
+		Details: map[string]string{
+			"principals": strings.Join(principals, ","),
+			"since":      since.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to trigger the blackout alert for account %s: %s", accountID, err)
+	}
+	routeAccountEvent(router, dbSvc, accountID, &routing.Event{
+		Type:    routing.EventResetBlackout,
+		Summary: summary,
+		Text: fmt.Sprintf("Principals outside DCE called the account since %s: %s",
+			since.UTC().Format(time.RFC3339), strings.Join(principals, ", ")),
+	})
+	return nil
+}
+
+// checkBlackout looks for activity by principals outside DCE since the
+// account's reset began, using its admin role, and records any it finds.
+// Returns whether the account has a blackout which isn't acknowledged.
+func checkBlackout(svc *service, account *db.Account) (bool, error) {
+	config := svc.config()
+	since := blackoutSince(account)
+
+	childSession, err := svc.tokenService().NewSession(svc.awsSession(), config.accountAdminRoleARN)
+	if err != nil {
+		return false, err
+	}
+	activity, err := findBlackoutActivity(&findBlackoutActivityInput{
+		accountID:         config.childAccountID,
+		parentAccountID:   config.parentAccountID,
+		adminRoleArn:      config.accountAdminRoleARN,
+		regions:           config.nukeRegions,
+		ignoredPrincipals: config.blackoutIgnoredPrincipals,
+		cloudTrail: func(region string) cloudtrailiface.CloudTrailAPI {
+			return cloudtrail.New(childSession, aws.NewConfig().WithRegion(region))
+		},
+		since: since,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(activity) == 0 {
+		// An earlier blackout which isn't acknowledged still blocks the account
+		if account.Blackout.IsBlocking() {
+			return true, nil
+		}
+		err = svc.alertService().Resolve(alert.TypeResetBlackout, config.childAccountID)
+		if err != nil {
+			log.Printf("Failed to resolve the blackout alert for account %s: %s", config.childAccountID, err)
+		}
+		return false, nil
+	}
+
+	err = recordBlackout(svc.db(), svc.alertService(), svc.router(), config.childAccountID, since, activity)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/alert"
+	alertMocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/db/mocks"
+	"github.com/Optum/dce/pkg/routing"
+	routingMocks "github.com/Optum/dce/pkg/routing/routingiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeCloudTrail returns pages of CloudTrail events
+type fakeCloudTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	pages [][]string
+	err   error
+	input *cloudtrail.LookupEventsInput
+}
+
+func (f *fakeCloudTrail) LookupEventsPages(input *cloudtrail.LookupEventsInput, fn func(*cloudtrail.LookupEventsOutput, bool) bool) error {
+	f.input = input
+	for i, page := range f.pages {
+		out := &cloudtrail.LookupEventsOutput{}
+		for _, e := range page {
+			out.Events = append(out.Events, &cloudtrail.Event{
+				CloudTrailEvent: aws.String(e),
+				EventTime:       aws.Time(time.Unix(1000, 0)),
+			})
+		}
+		if !fn(out, i == len(f.pages)-1) {
+			break
+		}
+	}
+	return f.err
+}
+
+func roleEvent(roleArn string) string {
+	return fmt.Sprintf(`{"eventName": "RunInstances", "eventSource": "ec2.amazonaws.com",
+		"userIdentity": {"type": "AssumedRole", "sessionContext": {"sessionIssuer": {"arn": %q}}}}`, roleArn)
+}
+
+func TestFindBlackoutActivity(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       [][]string
+		ignored     []string
+		err         error
+		expActivity []*db.AccountBlackoutActivity
+		expErr      error
+	}{
+		{
+			name: "no activity",
+			pages: [][]string{{
+				roleEvent("arn:aws:iam::111:role/AdminRole"),
+				`{"eventName": "AssumeRole", "userIdentity": {"type": "AWSAccount", "accountId": "999"}}`,
+				`{"eventName": "Decrypt", "userIdentity": {"type": "AWSService", "invokedBy": "s3.amazonaws.com"}}`,
+				roleEvent("arn:aws:iam::111:role/aws-service-role/ops.apigateway.amazonaws.com/AWSServiceRoleForAPIGateway"),
+			}},
+			expActivity: []*db.AccountBlackoutActivity{},
+		},
+		{
+			name: "activity by the principal role",
+			pages: [][]string{
+				{roleEvent("arn:aws:iam::111:role/AdminRole")},
+				{roleEvent("arn:aws:iam::111:role/PrincipalRole")},
+			},
+			expActivity: []*db.AccountBlackoutActivity{
+				{
+					PrincipalArn: "arn:aws:iam::111:role/PrincipalRole",
+					EventName:    "RunInstances",
+					EventSource:  "ec2.amazonaws.com",
+					Region:       "us-east-1",
+					EventTime:    1000,
+				},
+			},
+		},
+		{
+			name: "activity by another account",
+			pages: [][]string{{
+				`{"eventName": "AssumeRole", "eventSource": "sts.amazonaws.com", "userIdentity": {"type": "AWSAccount", "accountId": "222"}}`,
+			}},
+			expActivity: []*db.AccountBlackoutActivity{
+				{
+					PrincipalArn: "arn:aws:iam::222:root",
+					EventName:    "AssumeRole",
+					EventSource:  "sts.amazonaws.com",
+					Region:       "us-east-1",
+					EventTime:    1000,
+				},
+			},
+		},
+		{
+			name:        "ignored principal",
+			pages:       [][]string{{roleEvent("arn:aws:iam::111:role/SecurityScanner")}},
+			ignored:     []string{"arn:aws:iam::111:role/SecurityScanner"},
+			expActivity: []*db.AccountBlackoutActivity{},
+		},
+		{
+			name:   "CloudTrail failure",
+			err:    errors.New("throttled"),
+			expErr: errors.New("failed to look up CloudTrail events in us-east-1: throttled"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trail := &fakeCloudTrail{pages: tt.pages, err: tt.err}
+			since := time.Unix(500, 0)
+
+			activity, err := findBlackoutActivity(&findBlackoutActivityInput{
+				accountID:         "111",
+				parentAccountID:   "999",
+				adminRoleArn:      "arn:aws:iam::111:role/AdminRole",
+				regions:           []string{"us-east-1"},
+				ignoredPrincipals: tt.ignored,
+				cloudTrail: func(region string) cloudtrailiface.CloudTrailAPI {
+					return trail
+				},
+				since: since,
+			})
+
+			assert.Equal(t, tt.expErr, err)
+			assert.Equal(t, tt.expActivity, activity)
+			assert.Equal(t, since, aws.TimeValue(trail.input.StartTime))
+		})
+	}
+}
+
+func TestBlackoutSince(t *testing.T) {
+	assert.Equal(t, time.Unix(500, 0), blackoutSince(&db.Account{LastModifiedOn: 500}))
+	assert.Equal(t, time.Unix(600, 0), blackoutSince(&db.Account{
+		LastModifiedOn: 500,
+		Blackout:       &db.AccountBlackout{AcknowledgedOn: aws.Int64(600)},
+	}))
+}
+
+func TestRecordBlackout(t *testing.T) {
+	activity := []*db.AccountBlackoutActivity{
+		{PrincipalArn: "arn:aws:iam::111:role/PrincipalRole", EventName: "RunInstances"},
+		{PrincipalArn: "arn:aws:iam::111:role/PrincipalRole", EventName: "CreateBucket"},
+	}
+	dbSvc := &mocks.DBer{}
+	dbSvc.On("RecordAccountBlackout", "111", mock.MatchedBy(func(b *db.AccountBlackout) bool {
+		return b.Since == 500 && len(b.Activity) == 2 && b.IsBlocking()
+	})).Return(&db.Account{}, nil)
+	alertSvc := &alertMocks.Servicer{}
+	alertSvc.On("Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+		return a.Type == alert.TypeResetBlackout && a.AccountID == "111" &&
+			a.Details["principals"] == "arn:aws:iam::111:role/PrincipalRole"
+	})).Return(nil)
+	dbSvc.On("GetAccount", "111").Return(&db.Account{ID: "111"}, nil)
+	router := &routingMocks.Servicer{}
+	router.On("Enabled").Return(true)
+	router.On("Route", mock.MatchedBy(func(e *routing.Event) bool {
+		return e.Type == routing.EventResetBlackout && e.Attributes["accountId"] == "111" && e.Contact == nil
+	})).Return(nil)
+
+	err := recordBlackout(dbSvc, alertSvc, router, "111", time.Unix(500, 0), activity)
+
+	assert.Nil(t, err)
+	dbSvc.AssertExpectations(t)
+	alertSvc.AssertExpectations(t)
+	router.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/db"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// startCooldown records when the account can be leased again, once the
+// billing of the resources the reset deleted has settled and their deletion
+// is consistent.  The account still becomes Ready after its reset, but isn't
+// leased until its cooldown passes.  Accounts which aren't NotReady aren't
+// made Ready by the reset, so they don't cool down.
+func startCooldown(dbSvc db.DBer, accountID string, cooldown time.Duration, now time.Time) error {
+	readyAt := now.Add(cooldown).Unix()
+	log.Printf("Account %s is cooling down, and can be leased at %s", accountID, time.Unix(readyAt, 0).UTC().Format(time.RFC3339))
+	_, err := dbSvc.RecordAccountReadyAt(accountID, readyAt)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		log.Printf("Account %s isn't NotReady, and won't cool down", accountID)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/db/mocks"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartCooldown(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name   string
+		retErr error
+		expErr error
+	}{
+		{name: "records when the account can be leased"},
+		{
+			name:   "ignores accounts which aren't NotReady",
+			retErr: awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil),
+		},
+		{
+			name:   "fails when the cooldown can't be recorded",
+			retErr: errors.New("failure"),
+			expErr: errors.New("failure"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbSvc := &mocks.DBer{}
+			dbSvc.On("RecordAccountReadyAt", "111", int64(1600003600)).Return(&db.Account{}, tt.retErr)
+
+			err := startCooldown(dbSvc, "111", time.Hour, now)
+
+			assert.Equal(t, tt.expErr, err)
+			dbSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+)
+
+// leakMaxResources is the most resources recorded after a reset, so the
+// account record stays small.  An account with more has a bigger problem
+// than a gap in the aws-nuke configuration.
+const leakMaxResources = 100
+
+// findResetLeaks compares the resources found in the account after its reset
+// with those found after its last reset.  Resources found after both survived
+// another reset in a row, and the rest start counting from one.  The ignored
+// ARNs are DCE's own resources, which resets keep on purpose.
+func findResetLeaks(prev *db.AccountResetLeaks, resources map[string][]string, ignored map[string]bool, now int64) *db.AccountResetLeaks {
+	survived := map[string]*db.AccountResetLeak{}
+	if prev != nil {
+		for _, r := range prev.Resources {
+			survived[r.ARN] = r
+		}
+	}
+
+	regions := []string{}
+	for region := range resources {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	leaks := &db.AccountResetLeaks{
+		CheckedOn: now,
+		Resources: []*db.AccountResetLeak{},
+	}
+	for _, region := range regions {
+		arns := append([]string{}, resources[region]...)
+		sort.Strings(arns)
+		for _, arn := range arns {
+			if ignored[arn] {
+				continue
+			}
+			if len(leaks.Resources) >= leakMaxResources {
+				leaks.Truncated = true
+				return leaks
+			}
+			leak := &db.AccountResetLeak{
+				ARN:         arn,
+				Region:      region,
+				FirstSeenOn: now,
+				Resets:      1,
+			}
+			if last, ok := survived[arn]; ok {
+				leak.FirstSeenOn = last.FirstSeenOn
+				leak.Resets = last.Resets + 1
+			}
+			leaks.Resources = append(leaks.Resources, leak)
+		}
+	}
+	return leaks
+}
+
+// trackResetLeaks finds the resources which survived the account's reset,
+// using its admin role, and records how many resets in a row each survived
+func trackResetLeaks(svc *service) error {
+	config := svc.config()
+
+	acct, err := svc.db().GetAccount(config.childAccountID)
+	if err != nil {
+		return err
+	}
+	if acct == nil {
+		return fmt.Errorf("account %s does not exist", config.childAccountID)
+	}
+
+	childSession, err := svc.tokenService().NewSession(svc.awsSession(), config.accountAdminRoleARN)
+	if err != nil {
+		return err
+	}
+	inventory := captureSnapshot(&captureSnapshotInput{
+		accountID: config.childAccountID,
+		regions:   config.nukeRegions,
+		tagging: func(region string) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+			return resourcegroupstaggingapi.New(childSession, aws.NewConfig().WithRegion(region))
+		},
+		now: time.Now(),
+	})
+	// An incomplete inventory would start resources it missed counting again
+	if len(inventory.Errors) > 0 {
+		return fmt.Errorf("failed to find the resources which survived the reset: %s", strings.Join(inventory.Errors, "; "))
+	}
+
+	leaks := findResetLeaks(acct.ResetLeaks, inventory.Resources, map[string]bool{
+		config.accountAdminRoleARN: true,
+		"arn:aws:iam::" + config.childAccountID + ":role/" + config.accountPrincipalRoleName:     true,
+		"arn:aws:iam::" + config.childAccountID + ":policy/" + config.accountPrincipalPolicyName: true,
+	}, inventory.CapturedOn)
+	leaked := 0
+	for _, r := range leaks.Resources {
+		if r.Resets >= account.LeakMinResets {
+			leaked++
+		}
+	}
+	log.Printf("%d resources survived the reset of account %s, %d of them at least %d resets in a row",
+		len(leaks.Resources), config.childAccountID, leaked, account.LeakMinResets)
+
+	_, err = svc.db().RecordAccountResetLeaks(config.childAccountID, leaks)
+	return err
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindResetLeaks(t *testing.T) {
+	t.Run("should count the resets in a row each resource survived", func(t *testing.T) {
+		prev := &db.AccountResetLeaks{
+			CheckedOn: 100,
+			Resources: []*db.AccountResetLeak{
+				{ARN: "arn:aws:sns:us-east-1:111:topic", Region: "us-east-1", FirstSeenOn: 50, Resets: 2},
+				{ARN: "arn:aws:sqs:us-east-1:111:deleted", Region: "us-east-1", FirstSeenOn: 100, Resets: 1},
+			},
+		}
+
+		leaks := findResetLeaks(prev, map[string][]string{
+			"us-west-2": {"arn:aws:sns:us-west-2:111:new"},
+			"us-east-1": {
+				"arn:aws:sns:us-east-1:111:topic",
+				"arn:aws:iam::111:role/AdminRole",
+			},
+		}, map[string]bool{"arn:aws:iam::111:role/AdminRole": true}, 200)
+
+		assert.Equal(t, &db.AccountResetLeaks{
+			CheckedOn: 200,
+			Resources: []*db.AccountResetLeak{
+				{ARN: "arn:aws:sns:us-east-1:111:topic", Region: "us-east-1", FirstSeenOn: 50, Resets: 3},
+				{ARN: "arn:aws:sns:us-west-2:111:new", Region: "us-west-2", FirstSeenOn: 200, Resets: 1},
+			},
+		}, leaks)
+	})
+
+	t.Run("should record nothing when the reset deleted everything", func(t *testing.T) {
+		leaks := findResetLeaks(nil, map[string][]string{"us-east-1": {}}, nil, 200)
+
+		assert.Equal(t, &db.AccountResetLeaks{CheckedOn: 200, Resources: []*db.AccountResetLeak{}}, leaks)
+	})
+
+	t.Run("should truncate the resources recorded", func(t *testing.T) {
+		arns := []string{}
+		for i := 0; i < leakMaxResources+1; i++ {
+			arns = append(arns, fmt.Sprintf("arn:aws:sns:us-east-1:111:topic-%03d", i))
+		}
+
+		leaks := findResetLeaks(nil, map[string][]string{"us-east-1": arns}, nil, 200)
+
+		assert.Len(t, leaks.Resources, leakMaxResources)
+		assert.True(t, leaks.Truncated)
+	})
+}
@@ -3,18 +3,29 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
-	"os"
 	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountmanager/accountmanageriface"
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
+	"github.com/Optum/dce/pkg/arn"
 	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/event"
 	"github.com/Optum/dce/pkg/reset"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
 	"github.com/avast/retry-go"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -23,6 +34,30 @@ import (
 // main will run through the reset process for an account which involves using
 // aws-nuke
 func main() {
+	err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run resets the account, and returns the error which failed the reset.  It
+// returns instead of exiting, so the diagnostics are captured on failures.
+func run() error {
+	// Capture diagnostics while the reset runs, and once it's done
+	diag, err := diagnostics.NewFromEnv("reset")
+	if err != nil {
+		log.Printf("Diagnostics are disabled: %s", err)
+	} else {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		diag.Start(ctx)
+		defer func() {
+			if err := diag.Capture(); err != nil {
+				log.Printf("Failed to capture diagnostics: %s", err)
+			}
+		}()
+	}
+
 	// Initialize a service container
 	svc := &service{}
 	config := svc.config()
@@ -31,11 +66,68 @@ func main() {
 	//get current Account ID
 	caller, err := tokenService.Client.GetCallerIdentity(&sts.GetCallerIdentityInput{})
 	if err != nil {
-		log.Fatalf("Failed to get code build account information: %s\n", err)
+		return errors.Wrap(err, "Failed to get code build account information")
 	}
 	_config.parentAccountID = *caller.Account
 
+	// Cleanups only remove the resources the principal asked for, and leave
+	// the account and its lease as they are
+	if config.isCleanup() {
+		err = nukeAccount(svc, !config.isNukeEnabled)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to clean up account %s for lease %s", config.childAccountID, config.cleanupLeaseID)
+		}
+		log.Printf("%s  :  Cleanup Success\n", config.childAccountID)
+		return nil
+	}
+
+	// Fail before the account is changed when the reset profile is unknown
+	err = account.ValidateResetProfile(config.resetProfile.String())
+	if err != nil {
+		err = errors.Wrapf(err, "Invalid reset profile %q", config.resetProfile)
+		reportReset(svc, config.childAccountID, err)
+		return errors.Wrapf(err, "Failed to reset account %s", config.childAccountID)
+	}
+
+	// The period checked for activity by principals outside DCE starts
+	// before the reset changes the account
+	var account *db.Account
+	if config.isBlackoutEnabled {
+		account, err = svc.db().GetAccount(config.childAccountID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get account %s", config.childAccountID)
+		}
+		if account == nil {
+			return errors.Errorf("Failed to get account %s: account not found", config.childAccountID)
+		}
+	}
+
+	// Stop shipping the account's logs to the logging account, so the reset
+	// and whatever happens to the account next isn't logged against the
+	// lease.  The next lease configures it again, so a failure doesn't hold
+	// up the reset.
+	err = removeLogAggregation(svc.accountManager(), config.childAccountID, config.accountAdminRoleARN)
+	if err != nil {
+		log.Printf("Failed to remove the log aggregation of account %s: %s", config.childAccountID, err)
+	}
+
+	// Remove the budget mirroring the lease budget.  The next lease replaces
+	// it, so a failure doesn't hold up the reset either.
+	err = removeLeaseBudget(svc.accountManager(), config.childAccountID, config.accountAdminRoleARN)
+	if err != nil {
+		log.Printf("Failed to remove the lease budget of account %s: %s", config.childAccountID, err)
+	}
+
 	if config.isNukeEnabled {
+		// Keep a record of what the reset deletes.  The reset goes ahead
+		// without one, so a snapshot failure doesn't leave the account NotReady.
+		if config.isSnapshotEnabled {
+			err = snapshotAccount(svc)
+			if err != nil {
+				log.Printf("Failed to snapshot account %s before its reset: %s", config.childAccountID, err)
+			}
+		}
+
 		// Execute aws-nuke, to delete all resources from the account
 		err = nukeAccount(
 			svc,
@@ -43,27 +135,173 @@ func main() {
 			!config.isNukeEnabled,
 		)
 		if err != nil {
-			log.Fatalf("Failed to execute aws-nuke on account %s: %s\n", config.childAccountID, err)
+			// Closed and suspended accounts are taken out of the pool,
+			// instead of failing every reset
+			unreachable, reason := checkUnreachable(tokenService, svc.organizations(), config.childAccountID, config.accountAdminRoleARN)
+			if unreachable {
+				err = markUnreachable(svc.db(), svc.alertService(), svc.router(), config.childAccountID, reason)
+				if err != nil {
+					return errors.Wrapf(err, "Failed to mark account %s unreachable", config.childAccountID)
+				}
+				log.Printf("Account %s is unreachable, and won't be reset again: %s", config.childAccountID, reason)
+				return nil
+			}
+			reportReset(svc, config.childAccountID, err)
+			return errors.Wrapf(err, "Failed to execute aws-nuke on account %s", config.childAccountID)
 		}
 		log.Printf("%s  :  Nuke Success\n", config.childAccountID)
+
+		// Keep track of resources which survive resets, which point to gaps
+		// in the aws-nuke configuration.  Failures don't hold up the reset.
+		// Resets with other profiles leave resources in place on purpose.
+		if config.isLeakTrackingEnabled && config.isFullReset() {
+			err = trackResetLeaks(svc)
+			if err != nil {
+				log.Printf("Failed to track the resources which survived the reset of account %s: %s", config.childAccountID, err)
+			}
+		}
 	} else {
 		log.Println("INFO: aws-nuke is disabled and will not remove " +
 			"any resources and cannot set back the state of the DCE child account " +
 			"Please set 'RESET_NUKE_TOGGLE' to not 'true' to enable aws-nuke.")
 	}
 
+	// Accounts used by principals outside DCE while they were reset stay
+	// NotReady, until the activity is acknowledged
+	if config.isBlackoutEnabled {
+		blocked, err := checkBlackout(svc, account)
+		if err != nil {
+			reportReset(svc, config.childAccountID, err)
+			return errors.Wrapf(err, "Failed to check account %s for a blackout", config.childAccountID)
+		}
+		if blocked {
+			reportReset(svc, config.childAccountID, nil)
+			log.Printf("Account %s has a blackout, and will stay NotReady until it's acknowledged", config.childAccountID)
+			return nil
+		}
+	}
+
+	// Accounts cool down after their reset before they're leased again
+	if config.cooldownMinutes > 0 {
+		err = startCooldown(svc.db(), config.childAccountID, time.Duration(config.cooldownMinutes)*time.Minute, time.Now())
+		if err != nil {
+			reportReset(svc, config.childAccountID, err)
+			return errors.Wrapf(err, "Failed to start the cooldown of account %s", config.childAccountID)
+		}
+	}
+
 	// Update the DB with Account/Lease statuses
-	err = updateDBPostReset(svc.db(), svc.snsService(), config.childAccountID, common.RequireEnv("RESET_COMPLETE_TOPIC_ARN"))
+	err = updateDBPostReset(svc.db(), svc.snsService(), svc.resetCompletedEvent(), config.childAccountID, common.RequireEnv("RESET_COMPLETE_TOPIC_ARN"))
+	reportReset(svc, config.childAccountID, err)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update the DB post-reset for account %s", config.childAccountID)
+	}
+	return nil
+}
+
+// removeLogAggregation removes the trail and event rules which ship the
+// account's logs to the logging account
+func removeLogAggregation(manager accountmanageriface.Servicer, accountID string, adminRoleArn string) error {
+	roleArn, err := arn.NewFromArn(adminRoleArn)
 	if err != nil {
-		log.Fatalf("Failed to update the DB post-reset for account %s:  %s", config.childAccountID, err)
+		return err
+	}
+	return manager.DeleteLogAggregation(&account.Account{
+		ID:           aws.String(accountID),
+		AdminRoleArn: roleArn,
+	})
+}
+
+// removeLeaseBudget removes the budget created in the account at the start
+// of the lease
+func removeLeaseBudget(manager accountmanageriface.Servicer, accountID string, adminRoleArn string) error {
+	roleArn, err := arn.NewFromArn(adminRoleArn)
+	if err != nil {
+		return err
+	}
+	return manager.DeleteLeaseBudget(&account.Account{
+		ID:           aws.String(accountID),
+		AdminRoleArn: roleArn,
+	})
+}
+
+// reportReset updates the reset alert of the account, and routes failures to
+// the channels of the matching notification routing rules
+func reportReset(svc *service, accountID string, resetErr error) {
+	alertReset(svc.alertService(), accountID, resetErr)
+	if resetErr != nil {
+		routeResetFailure(svc.router(), svc.db(), accountID, resetErr)
+	}
+}
+
+// routeResetFailure sends the failed reset to the channels of the routing
+// rules it matches, and to the account's contact
+func routeResetFailure(router routingiface.Servicer, dbSvc db.DBer, accountID string, resetErr error) {
+	routeAccountEvent(router, dbSvc, accountID, &routing.Event{
+		Type:    routing.EventResetFailed,
+		Summary: fmt.Sprintf("DCE failed to reset account %s", accountID),
+		Text:    resetErr.Error(),
+	})
+}
+
+// routeAccountEvent sends an event about the account to the channels of the
+// routing rules it matches, and to the account's contact.  Rules filter on
+// the account ID and the account's metadata, eg. its pool.  Failures are
+// logged, as the reset has already recorded the event.
+func routeAccountEvent(router routingiface.Servicer, dbSvc db.DBer, accountID string, event *routing.Event) {
+	if !router.Enabled() {
+		return
+	}
+
+	event.Attributes = map[string]string{"accountId": accountID}
+	account, err := dbSvc.GetAccount(accountID)
+	if err != nil {
+		log.Printf("Failed to look up the metadata of account %s for routing: %s", accountID, err)
+	} else if account != nil {
+		event.Attributes = routing.Attributes(event.Attributes, account.Metadata)
+		if account.Contact != nil {
+			event.Contact = &routing.Contact{
+				Email:             account.Contact.Email,
+				Team:              account.Contact.Team,
+				EscalationChannel: account.Contact.EscalationChannel,
+			}
+		}
+	}
+
+	err = router.Route(event)
+	if err != nil {
+		log.Printf("Failed to route the %s event of account %s: %s", event.Type, accountID, err)
+	}
+}
+
+// alertReset opens an incident for the account when its reset failed, and
+// resolves it once a reset succeeds.  A failed reset is not retried, so the
+// account stays NotReady until it is reset again.
+func alertReset(alertSvc alertiface.Servicer, accountID string, resetErr error) {
+	var err error
+	if resetErr != nil {
+		err = alertSvc.Trigger(&alert.Alert{
+			Type:      alert.TypeResetFailed,
+			AccountID: accountID,
+			Summary:   fmt.Sprintf("DCE failed to reset account %s", accountID),
+			Details: map[string]string{
+				"error": resetErr.Error(),
+			},
+		})
+	} else {
+		err = alertSvc.Resolve(alert.TypeResetFailed, accountID)
+	}
+	if err != nil {
+		log.Printf("Failed to update the reset alert for account %s: %s", accountID, err)
 	}
 }
 
 // updateDBPostReset changes any leases for the Account
 // from "Status=ResetLock" to "Status=Active"
 // Also, if the account was set as "Status=NotReady",
-// will update to "Status=Ready"
-func updateDBPostReset(dbSvc db.DBer, snsSvc common.Notificationer, accountID string, snsTopicArn string) error {
+// will update to "Status=Ready", unless the account is warming up
+// If resetCompleted is not nil, a ResetCompleted event is published to it
+func updateDBPostReset(dbSvc db.DBer, snsSvc common.Notificationer, resetCompleted event.Publisher, accountID string, snsTopicArn string) error {
 
 	// If the Account.Status=NotReady, change it back to Status=Ready
 	log.Printf("Setting Account Status from NotReady to Ready: %s", accountID)
@@ -81,10 +319,25 @@ func updateDBPostReset(dbSvc db.DBer, snsSvc common.Notificationer, accountID st
 		if err != nil {
 			return err
 		}
+
+		// Accounts which are warming up stay NotReady, and their warm-up
+		// continues once it sees the reset finished
+		if account != nil && account.IsWarmingUp() {
+			log.Printf("Account %s is warming up, and will be Ready once its warm-up succeeds", accountID)
+			account, err = dbSvc.RecordAccountWarmUpReset(accountID)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	log.Printf("Notifying Reset Topic that the account is complete for: %s", accountID)
-	snsMessage, err := common.PrepareSNSMessageJSON(account)
+	envelope, err := event.NewEnvelope(event.ResetCompletedType, account)
+	if err != nil {
+		log.Printf("Failed to create SNS account-created message for %s: %s", accountID, err)
+		return err
+	}
+	snsMessage, err := common.PrepareSNSMessageJSON(envelope)
 	if err != nil {
 		log.Printf("Failed to create SNS account-created message for %s: %s", accountID, err)
 		return err
@@ -95,6 +348,14 @@ func updateDBPostReset(dbSvc db.DBer, snsSvc common.Notificationer, accountID st
 		log.Print("Issue in publishing message: %s" + err.Error())
 		return err
 	}
+
+	if resetCompleted != nil {
+		err = resetCompleted.Publish(account)
+		if err != nil {
+			log.Printf("Failed to publish ResetCompleted event for %s: %s", accountID, err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -104,18 +365,24 @@ func nukeAccount(svc *service, isDryRun bool) error {
 
 	config := svc.config()
 
-	// Create the file
-	configFile := fmt.Sprintf("/tmp/nuke-config-%s.yml", config.childAccountID)
-	f, err := os.Create(configFile)
+	var rendered bytes.Buffer
+	err := generateNukeConfig(svc, &rendered)
 	if err != nil {
-		log.Fatalf("Failed to create file %s: %s", configFile, err)
 		return err
 	}
-	err = generateNukeConfig(svc, f)
+
+	nukeConfig, err := narrowNukeConfig(config, rendered.Bytes())
 	if err != nil {
 		return err
 	}
 
+	// Create the file
+	configFile := fmt.Sprintf("/tmp/nuke-config-%s.yml", config.childAccountID)
+	err = ioutil.WriteFile(configFile, nukeConfig, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create file %s", configFile)
+	}
+
 	// Print the contents of the config file, for logging/debugging
 	/*
 		#nosec CWE-22: This value is derived from env vars. I.e. it is not populated with data from external users.
@@ -133,7 +400,7 @@ func nukeAccount(svc *service, isDryRun bool) error {
 	// Configure the NukeAccountInput
 	nukeAccountInput := reset.NukeAccountInput{
 		ChildAccountID: config.childAccountID,
-		RoleName:       config.accountAdminRoleName,
+		RoleName:       config.nukeRoleName(),
 		ConfigPath:     configFile,
 		NoDryRun:       !isDryRun,
 		Token:          svc.tokenService(),
@@ -155,6 +422,23 @@ func nukeAccount(svc *service, isDryRun bool) error {
 	return nil
 }
 
+// narrowNukeConfig leaves the resource types the reset profile keeps, and
+// for cleanups, only the resource types and regions asked for
+func narrowNukeConfig(config *serviceConfig, rendered []byte) ([]byte, error) {
+	log.Printf("Using the %s reset profile", config.resetProfile)
+	nukeConfig, err := reset.ApplyProfile(rendered, config.resetProfile)
+	if err != nil {
+		return nil, err
+	}
+	if !config.isCleanup() {
+		return nukeConfig, nil
+	}
+
+	log.Printf("Cleaning up %v in regions %v for lease %s",
+		config.cleanupResourceTypes, config.cleanupRegions, config.cleanupLeaseID)
+	return reset.ApplyCleanup(nukeConfig, config.cleanupResourceTypes, config.cleanupRegions)
+}
+
 func generateNukeConfig(svc *service, f io.Writer) error {
 	config := svc.config()
 
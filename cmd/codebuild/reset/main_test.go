@@ -7,9 +7,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Optum/dce/pkg/account"
+	managerMocks "github.com/Optum/dce/pkg/accountmanager/accountmanageriface/mocks"
+	"github.com/Optum/dce/pkg/alert"
+	alertMocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
 	commonMocks "github.com/Optum/dce/pkg/common/mocks"
 	"github.com/Optum/dce/pkg/db"
 	"github.com/Optum/dce/pkg/db/mocks"
+	eventMocks "github.com/Optum/dce/pkg/event/mocks"
+	"github.com/Optum/dce/pkg/routing"
+	routingMocks "github.com/Optum/dce/pkg/routing/routingiface/mocks"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,20 +49,40 @@ func TestResetPipeline(t *testing.T) {
 
 					assert.Equal(t, msgDefault, msgBody, "SNS default/Body should  match")
 
-					// Check that we're sending the account object
-					assert.Equal(t, "", msgBody["Id"])
+					// Check that we're sending the account object in a versioned envelope
+					assert.Equal(t, "ResetCompleted", msgBody["type"])
+					assert.Equal(t, "1", msgBody["version"])
+					assert.Equal(t, "", msgBody["data"].(map[string]interface{})["Id"])
 
 					return true
 				}), true,
 			).Return(aws.String("mock message"), nil)
 			defer snsSvc.AssertExpectations(t)
 
-			err := updateDBPostReset(dbSvc, snsSvc, "111", "Topic")
+			err := updateDBPostReset(dbSvc, snsSvc, nil, "111", "Topic")
 			dbSvc.AssertNumberOfCalls(t, "TransitionLeaseStatus", 0)
 			dbSvc.AssertNumberOfCalls(t, "TransitionAccountStatus", 1)
 			require.Nil(t, err)
 		})
 
+		t.Run("Should publish a ResetCompleted event", func(t *testing.T) {
+			dbSvc := &mocks.DBer{}
+			snsSvc := &commonMocks.Notificationer{}
+			resetCompleted := &eventMocks.Publisher{}
+			defer resetCompleted.AssertExpectations(t)
+
+			acct := &db.Account{ID: "111", AccountStatus: db.Ready}
+			dbSvc.
+				On("TransitionAccountStatus", "111", db.NotReady, db.Ready).
+				Return(acct, nil)
+			snsSvc.On("PublishMessage", mock.Anything, mock.Anything, true).
+				Return(aws.String("mock message"), nil)
+			resetCompleted.On("Publish", acct).Return(nil)
+
+			err := updateDBPostReset(dbSvc, snsSvc, resetCompleted, "111", "Topic")
+			require.Nil(t, err)
+		})
+
 		t.Run("Should not change account status of Leased accounts", func(t *testing.T) {
 			dbSvc := &mocks.DBer{}
 			snsSvc := &commonMocks.Notificationer{}
@@ -86,20 +113,41 @@ func TestResetPipeline(t *testing.T) {
 
 					assert.Equal(t, msgDefault, msgBody, "SNS default/Body should  match")
 
-					// Check that we're sending the account object
-					assert.Equal(t, "", msgBody["Id"])
+					// Check that we're sending the account object in a versioned envelope
+					assert.Equal(t, "ResetCompleted", msgBody["type"])
+					assert.Equal(t, "1", msgBody["version"])
+					assert.Equal(t, "", msgBody["data"].(map[string]interface{})["Id"])
 
 					return true
 				}), true,
 			).Return(aws.String("mock message"), nil)
 			defer snsSvc.AssertExpectations(t)
 
-			err := updateDBPostReset(dbSvc, snsSvc, "111", "Topic")
+			err := updateDBPostReset(dbSvc, snsSvc, nil, "111", "Topic")
 			dbSvc.AssertNumberOfCalls(t, "TransitionLeaseStatus", 0)
 			dbSvc.AssertNumberOfCalls(t, "TransitionAccountStatus", 1)
 			require.Nil(t, err)
 		})
 
+		t.Run("Should record the reset of accounts which are warming up", func(t *testing.T) {
+			dbSvc := &mocks.DBer{}
+			snsSvc := &commonMocks.Notificationer{}
+			defer dbSvc.AssertExpectations(t)
+
+			// Accounts which are warming up can't transition to Ready
+			acct := &db.Account{ID: "111", AccountStatus: db.NotReady, WarmUp: &db.AccountWarmUp{Status: "InProgress"}}
+			dbSvc.
+				On("TransitionAccountStatus", "111", db.NotReady, db.Ready).
+				Return(nil, &db.StatusTransitionError{})
+			dbSvc.On("GetAccount", "111").Return(acct, nil)
+			dbSvc.On("RecordAccountWarmUpReset", "111").Return(acct, nil)
+			snsSvc.On("PublishMessage", mock.Anything, mock.Anything, true).
+				Return(aws.String("mock message"), nil)
+
+			err := updateDBPostReset(dbSvc, snsSvc, nil, "111", "Topic")
+			require.Nil(t, err)
+		})
+
 		t.Run("Should handle DB errors (TransitionAccountStatus)", func(t *testing.T) {
 			snsSvc := &commonMocks.Notificationer{}
 			dbSvc := &mocks.DBer{}
@@ -113,7 +161,7 @@ func TestResetPipeline(t *testing.T) {
 				On("TransitionAccountStatus", "111", db.NotReady, db.Ready).
 				Return(nil, errors.New("test error"))
 
-			err := updateDBPostReset(dbSvc, snsSvc, "111", "Topic")
+			err := updateDBPostReset(dbSvc, snsSvc, nil, "111", "Topic")
 			dbSvc.AssertNumberOfCalls(t, "TransitionLeaseStatus", 0)
 			dbSvc.AssertNumberOfCalls(t, "TransitionAccountStatus", 1)
 			require.Equal(t, errors.New("test error"), err)
@@ -154,3 +202,147 @@ func unmarshal(t *testing.T, jsonStr string) map[string]interface{} {
 
 	return data
 }
+
+func TestAlertReset(t *testing.T) {
+	t.Run("Should trigger an alert for the account when the reset fails", func(t *testing.T) {
+		alertSvc := &alertMocks.Servicer{}
+		alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+
+		alertReset(alertSvc, "111", errors.New("nuke failed"))
+
+		alertSvc.AssertCalled(t, "Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+			return a.Type == alert.TypeResetFailed &&
+				a.AccountID == "111" &&
+				a.Details["error"] == "nuke failed"
+		}))
+		alertSvc.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should resolve the alert for the account when the reset succeeds", func(t *testing.T) {
+		alertSvc := &alertMocks.Servicer{}
+		alertSvc.On("Resolve", alert.TypeResetFailed, "111").Return(nil)
+
+		alertReset(alertSvc, "111", nil)
+
+		alertSvc.AssertExpectations(t)
+		alertSvc.AssertNotCalled(t, "Trigger", mock.Anything)
+	})
+}
+
+func TestRouteResetFailure(t *testing.T) {
+	t.Run("Should route the failure with the account's metadata", func(t *testing.T) {
+		router := &routingMocks.Servicer{}
+		router.On("Enabled").Return(true)
+		router.On("Route", mock.AnythingOfType("*routing.Event")).Return(nil)
+		dbSvc := &mocks.DBer{}
+		dbSvc.On("GetAccount", "111").Return(&db.Account{ID: "111", Metadata: map[string]interface{}{"pool": "ml"}}, nil)
+
+		routeResetFailure(router, dbSvc, "111", errors.New("nuke failed"))
+
+		router.AssertCalled(t, "Route", &routing.Event{
+			Type:       routing.EventResetFailed,
+			Attributes: map[string]string{"accountId": "111", "pool": "ml"},
+			Summary:    "DCE failed to reset account 111",
+			Text:       "nuke failed",
+		})
+	})
+
+	t.Run("Should route the failure to the account's contact", func(t *testing.T) {
+		router := &routingMocks.Servicer{}
+		router.On("Enabled").Return(true)
+		router.On("Route", mock.AnythingOfType("*routing.Event")).Return(nil)
+		dbSvc := &mocks.DBer{}
+		dbSvc.On("GetAccount", "111").Return(&db.Account{ID: "111", Contact: &db.AccountContact{
+			Team:              "ml-platform",
+			EscalationChannel: "#ml-oncall",
+		}}, nil)
+
+		routeResetFailure(router, dbSvc, "111", errors.New("nuke failed"))
+
+		router.AssertCalled(t, "Route", mock.MatchedBy(func(e *routing.Event) bool {
+			return e.Contact.Team == "ml-platform" && e.Contact.EscalationChannel == "#ml-oncall"
+		}))
+	})
+
+	t.Run("Should route the failure when the account can't be looked up", func(t *testing.T) {
+		router := &routingMocks.Servicer{}
+		router.On("Enabled").Return(true)
+		router.On("Route", mock.AnythingOfType("*routing.Event")).Return(nil)
+		dbSvc := &mocks.DBer{}
+		dbSvc.On("GetAccount", "111").Return(nil, errors.New("throttled"))
+
+		routeResetFailure(router, dbSvc, "111", errors.New("nuke failed"))
+
+		router.AssertCalled(t, "Route", mock.MatchedBy(func(e *routing.Event) bool {
+			return len(e.Attributes) == 1 && e.Attributes["accountId"] == "111"
+		}))
+	})
+
+	t.Run("Should do nothing when routing is disabled", func(t *testing.T) {
+		router := &routingMocks.Servicer{}
+		router.On("Enabled").Return(false)
+		dbSvc := &mocks.DBer{}
+
+		routeResetFailure(router, dbSvc, "111", errors.New("nuke failed"))
+
+		router.AssertNotCalled(t, "Route", mock.Anything)
+		dbSvc.AssertNotCalled(t, "GetAccount", mock.Anything)
+	})
+}
+
+func TestRemoveLogAggregation(t *testing.T) {
+	manager := &managerMocks.Servicer{}
+	manager.On("DeleteLogAggregation", mock.MatchedBy(func(a *account.Account) bool {
+		return *a.ID == "111111111111" &&
+			a.AdminRoleArn.String() == "arn:aws:iam::111111111111:role/AdminRole"
+	})).Return(nil)
+
+	err := removeLogAggregation(manager, "111111111111", "arn:aws:iam::111111111111:role/AdminRole")
+	require.Nil(t, err)
+	manager.AssertExpectations(t)
+}
+
+func TestRemoveLeaseBudget(t *testing.T) {
+	manager := &managerMocks.Servicer{}
+	manager.On("DeleteLeaseBudget", mock.MatchedBy(func(a *account.Account) bool {
+		return *a.ID == "111111111111" &&
+			a.AdminRoleArn.String() == "arn:aws:iam::111111111111:role/AdminRole"
+	})).Return(nil)
+
+	err := removeLeaseBudget(manager, "111111111111", "arn:aws:iam::111111111111:role/AdminRole")
+	require.Nil(t, err)
+	manager.AssertExpectations(t)
+}
+
+func TestNarrowNukeConfig(t *testing.T) {
+	rendered := []byte(`
+regions:
+  - global
+  - us-east-1
+  - us-west-1
+accounts:
+  "123456789012":
+    filters:
+      IAMRole:
+        - AdminRole
+`)
+
+	t.Run("should leave resets to the profile", func(t *testing.T) {
+		nukeConfig, err := narrowNukeConfig(&serviceConfig{resetProfile: account.ResetProfileFull}, rendered)
+		require.Nil(t, err)
+		assert.NotContains(t, string(nukeConfig), "targets")
+	})
+
+	t.Run("should narrow cleanups to their resource types and regions", func(t *testing.T) {
+		nukeConfig, err := narrowNukeConfig(&serviceConfig{
+			resetProfile:         account.ResetProfileFull,
+			cleanupLeaseID:       "lease1",
+			cleanupResourceTypes: []string{"EC2Instance"},
+			cleanupRegions:       []string{"us-west-1"},
+		}, rendered)
+		require.Nil(t, err)
+		assert.Contains(t, string(nukeConfig), "targets:\n  - EC2Instance")
+		assert.Contains(t, string(nukeConfig), "regions:\n- us-west-1\n")
+		assert.Contains(t, string(nukeConfig), "- AdminRole")
+	})
+}
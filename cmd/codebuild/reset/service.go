@@ -3,14 +3,31 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountmanager"
+	"github.com/Optum/dce/pkg/accountmanager/accountmanageriface"
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/alert"
 	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/email"
+	"github.com/Optum/dce/pkg/event"
+	"github.com/Optum/dce/pkg/routing"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/ses"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/caarlos0/env"
 )
 
 // Declare singleton instances of each service
@@ -22,6 +39,10 @@ var (
 	_s3Service    *common.S3
 	_snsService   *common.SNS
 	_db           *db.DB
+	_alertService *alert.Service
+	_router       *routing.Service
+	_orgService   organizationsiface.OrganizationsAPI
+	_manager      accountmanageriface.Servicer
 )
 
 // service struct holds all the services to be used by
@@ -44,6 +65,28 @@ type serviceConfig struct {
 	nukeTemplateDefault string
 	nukeTemplateBucket  string
 	nukeTemplateKey     string
+	// resetProfile narrows the resource types the nuke template removes
+	resetProfile account.ResetProfile
+
+	isSnapshotEnabled   bool
+	snapshotBucket      string
+	snapshotPrefix      string
+	snapshotS3Manifests bool
+	snapshotMaxKeys     int64
+
+	isBlackoutEnabled         bool
+	blackoutIgnoredPrincipals []string
+
+	cooldownMinutes int
+
+	isLeakTrackingEnabled bool
+
+	// cleanupResourceTypes and cleanupRegions turn the reset into a cleanup
+	// of a leased account, which only removes resources of those types, in
+	// those regions, and doesn't end the lease
+	cleanupLeaseID       string
+	cleanupResourceTypes []string
+	cleanupRegions       []string
 }
 
 func (svc *service) config() *serviceConfig {
@@ -64,11 +107,59 @@ func (svc *service) config() *serviceConfig {
 		nukeTemplateBucket:  common.RequireEnv("RESET_NUKE_TEMPLATE_BUCKET"),
 		nukeTemplateKey:     common.RequireEnv("RESET_NUKE_TEMPLATE_KEY"),
 		nukeRegions:         common.RequireEnvStringSlice("RESET_NUKE_REGIONS", ","),
+		// Accounts can be reset with a different profile than the pool's
+		resetProfile: account.ResetProfile(common.GetEnv("RESET_PROFILE",
+			common.GetEnv("RESET_DEFAULT_PROFILE", account.ResetProfileFull.String()))),
+
+		isSnapshotEnabled:   os.Getenv("RESET_SNAPSHOT_ENABLED") == "true",
+		snapshotBucket:      os.Getenv("RESET_SNAPSHOT_BUCKET"),
+		snapshotPrefix:      common.GetEnv("RESET_SNAPSHOT_PREFIX", "snapshots"),
+		snapshotS3Manifests: os.Getenv("RESET_SNAPSHOT_S3_MANIFESTS") == "true",
+		snapshotMaxKeys:     int64(common.GetEnvInt("RESET_SNAPSHOT_MAX_KEYS", 1000)),
+
+		isBlackoutEnabled: os.Getenv("RESET_BLACKOUT_ENABLED") == "true",
+		blackoutIgnoredPrincipals: strings.FieldsFunc(os.Getenv("RESET_BLACKOUT_IGNORED_PRINCIPALS"),
+			func(r rune) bool { return r == ',' }),
+
+		cooldownMinutes: common.GetEnvInt("RESET_COOLDOWN_MINUTES", 0),
+
+		isLeakTrackingEnabled: os.Getenv("RESET_LEAK_TRACKING_ENABLED") == "true",
+
+		cleanupLeaseID:       os.Getenv("RESET_CLEANUP_LEASE_ID"),
+		cleanupResourceTypes: splitEnv("RESET_CLEANUP_RESOURCE_TYPES"),
+		cleanupRegions:       splitEnv("RESET_CLEANUP_REGIONS"),
 	}
 
 	return _config
 }
 
+// isFullReset is true when the reset removes everything the nuke template
+// allows
+func (c *serviceConfig) isFullReset() bool {
+	return c.resetProfile == account.ResetProfileFull
+}
+
+// isCleanup is true when the build cleans up a leased account, instead of
+// resetting it
+func (c *serviceConfig) isCleanup() bool {
+	return c.cleanupLeaseID != ""
+}
+
+// nukeRoleName is the role aws-nuke assumes in the account.  Cleanups run as
+// the principal of the lease, so they can't remove more than the principal
+// could.
+func (c *serviceConfig) nukeRoleName() string {
+	if c.isCleanup() {
+		return c.accountPrincipalRoleName
+	}
+	return c.accountAdminRoleName
+}
+
+// splitEnv splits a comma separated env var, which may be empty
+func splitEnv(key string) []string {
+	return strings.FieldsFunc(os.Getenv(key), func(r rune) bool { return r == ',' })
+}
+
 // setConfig overrides the configuration used by the service struct.
 // should only be used for testing
 func (svc *service) setConfig(config *serviceConfig) {
@@ -79,7 +170,8 @@ func (svc *service) awsSession() *session.Session {
 	if _awsSession != nil {
 		return _awsSession
 	}
-	_awsSession, err := session.NewSession()
+	var err error
+	_awsSession, err = common.SharedSession("")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -100,8 +192,8 @@ func (svc *service) tokenService() *common.STS {
 func (svc *service) s3Service() *common.S3 {
 	if _s3Service == nil {
 		_s3Service = &common.S3{
-			Client:  s3.New(svc.awsSession()),
-			Manager: s3manager.NewDownloader(svc.awsSession()),
+			Client:  s3.New(svc.awsSession(), common.EndpointConfig("S3")),
+			Manager: s3manager.NewDownloaderWithClient(s3.New(svc.awsSession(), common.EndpointConfig("S3"))),
 		}
 	}
 	return _s3Service
@@ -118,12 +210,125 @@ func (svc *service) db() *db.DB {
 	return _db
 }
 
+func (svc *service) alertService() *alert.Service {
+	if _alertService != nil {
+		return _alertService
+	}
+	_alertService, err := alert.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize Alert Service:  %s", err)
+	}
+	return _alertService
+}
+
+// router returns the notification routing service, which is disabled unless
+// NOTIFICATION_ROUTING_TABLE is configured
+func (svc *service) router() *routing.Service {
+	if _router != nil {
+		return _router
+	}
+	var err error
+	_router, err = routing.NewFromEnv(svc.db().Client, ssm.New(svc.awsSession()),
+		&email.SESEmailService{SES: ses.New(svc.awsSession(), common.EndpointConfig("SES"))}, svc.alertService())
+	if err != nil {
+		log.Fatalf("Failed to initialize Routing Service:  %s", err)
+	}
+	return _router
+}
+
+func (svc *service) organizations() organizationsiface.OrganizationsAPI {
+	if _orgService == nil {
+		_orgService = organizations.New(svc.awsSession())
+	}
+	return _orgService
+}
+
+// accountManager returns the account manager, configured from the
+// environment like the API's
+func (svc *service) accountManager() accountmanageriface.Servicer {
+	if _manager != nil {
+		return _manager
+	}
+	managerConfig := accountmanager.ServiceConfig{}
+	err := env.Parse(&managerConfig)
+	if err != nil {
+		log.Fatalf("Failed to load the Account Manager configuration:  %s", err)
+	}
+	_manager, err = accountmanager.NewService(accountmanager.NewServiceInput{
+		Session: svc.awsSession(),
+		Sts:     svc.tokenService().Client,
+		Config:  managerConfig,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize Account Manager:  %s", err)
+	}
+	return _manager
+}
+
 func (svc *service) snsService() *common.SNS {
 	if _snsService == nil {
 		_snsService = &common.SNS{
-			Client: sns.New(svc.awsSession()),
+			Client: sns.New(svc.awsSession(), common.EndpointConfig("SNS")),
 		}
 	}
 
 	return _snsService
 }
+
+// resetCompletedEvent returns a publisher for the ResetCompleted EventBridge
+// event, event sink, activity feed and event archive, or nil if none of
+// EVENT_BUS_NAME, EVENT_SINK_DRIVER, EVENT_TABLE_NAME and EVENT_ARCHIVE_BUCKET
+// are configured
+func (svc *service) resetCompletedEvent() event.Publisher {
+	publishers := event.Publishers{}
+
+	busName := os.Getenv("EVENT_BUS_NAME")
+	if busName != "" {
+		publisher, err := event.NewEventBridgeEvent(eventbridge.New(svc.awsSession()), busName, event.ResetCompletedType)
+		if err != nil {
+			log.Fatalf("Failed to initialize EventBridge publisher: %s", err)
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	sinkDriver := os.Getenv("EVENT_SINK_DRIVER")
+	if sinkDriver != "" {
+		publisher, err := event.NewSinkEvent(kinesis.New(svc.awsSession()), sinkDriver,
+			os.Getenv("EVENT_SINK_TARGET"), os.Getenv("EVENT_SINK_AUTH"), event.ResetCompletedType)
+		if err != nil {
+			log.Fatalf("Failed to initialize event sink publisher: %s", err)
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	tableName := os.Getenv("EVENT_TABLE_NAME")
+	if tableName != "" {
+		activitySvc, err := activity.NewService(activity.NewServiceInput{
+			DynamoDB:      dynamodb.New(svc.awsSession(), common.EndpointConfig("DynamoDB")),
+			TableName:     tableName,
+			RetentionDays: common.GetEnvInt("EVENT_RETENTION_DAYS", 90),
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize activity feed: %s", err)
+		}
+		publisher, err := event.NewActivityEvent(activitySvc, event.ResetCompletedType, "reset")
+		if err != nil {
+			log.Fatalf("Failed to initialize activity feed publisher: %s", err)
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	archiveBucket := os.Getenv("EVENT_ARCHIVE_BUCKET")
+	if archiveBucket != "" {
+		publisher, err := event.NewS3ArchiveEvent(s3.New(svc.awsSession(), common.EndpointConfig("S3")), archiveBucket, event.ResetCompletedType)
+		if err != nil {
+			log.Fatalf("Failed to initialize event archive publisher: %s", err)
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	if len(publishers) == 0 {
+		return nil
+	}
+	return publishers
+}
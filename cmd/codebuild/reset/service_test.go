@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Optum/dce/pkg/account"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +55,46 @@ func TestService(t *testing.T) {
 
 			// Check toggle env vars
 			require.Equal(t, true, config.isNukeEnabled)
+
+			// Resets default to the full profile
+			require.Equal(t, account.ResetProfileFull, config.resetProfile)
+		})
+
+		t.Run("should prefer the account's reset profile to the pool's", func(t *testing.T) {
+			_ = os.Setenv("RESET_DEFAULT_PROFILE", "compute-only")
+			_ = os.Setenv("RESET_PROFILE", "data-preserving")
+			defer os.Unsetenv("RESET_DEFAULT_PROFILE")
+			defer os.Unsetenv("RESET_PROFILE")
+
+			svc := &service{}
+			svc.setConfig(nil)
+			require.Equal(t, account.ResetProfileDataPreserving, svc.config().resetProfile)
+			require.False(t, svc.config().isFullReset())
+
+			_ = os.Unsetenv("RESET_PROFILE")
+			svc.setConfig(nil)
+			require.Equal(t, account.ResetProfileComputeOnly, svc.config().resetProfile)
+			svc.setConfig(nil)
+		})
+
+		t.Run("should configure cleanups as the principal", func(t *testing.T) {
+			_ = os.Setenv("RESET_CLEANUP_LEASE_ID", "lease1")
+			_ = os.Setenv("RESET_CLEANUP_RESOURCE_TYPES", "EC2Instance,EC2Volume")
+			defer os.Unsetenv("RESET_CLEANUP_LEASE_ID")
+			defer os.Unsetenv("RESET_CLEANUP_RESOURCE_TYPES")
+
+			svc := &service{}
+			svc.setConfig(nil)
+			require.True(t, svc.config().isCleanup())
+			require.Equal(t, []string{"EC2Instance", "EC2Volume"}, svc.config().cleanupResourceTypes)
+			require.Empty(t, svc.config().cleanupRegions)
+			require.Equal(t, "RESET_ACCOUNT_PRINCIPAL_ROLE_NAME_VAL", svc.config().nukeRoleName())
+
+			_ = os.Unsetenv("RESET_CLEANUP_LEASE_ID")
+			svc.setConfig(nil)
+			require.False(t, svc.config().isCleanup())
+			require.Equal(t, "RESET_ACCOUNT_ADMIN_ROLE_NAME_VAL", svc.config().nukeRoleName())
+			svc.setConfig(nil)
 		})
 
 		t.Run("should be a singleton", func(t *testing.T) {
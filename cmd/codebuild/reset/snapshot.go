@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// accountSnapshot is an inventory of an account, captured before the account
+// is reset, so there is some record of what the reset deleted
+type accountSnapshot struct {
+	AccountID  string `json:"accountId"`
+	CapturedOn int64  `json:"capturedOn"`
+	// Resources are the ARNs of the resources in the account, by region.
+	// Only resources which have, or once had, tags are listed.
+	Resources map[string][]string `json:"resources"`
+	// Buckets are the manifests of the S3 buckets in the account
+	Buckets []bucketManifest `json:"buckets,omitempty"`
+	// Errors are the parts of the inventory which couldn't be captured
+	Errors []string `json:"errors,omitempty"`
+}
+
+// bucketManifest lists the objects of an S3 bucket
+type bucketManifest struct {
+	Name      string   `json:"name"`
+	Region    string   `json:"region"`
+	Keys      []string `json:"keys"`
+	Truncated bool     `json:"truncated,omitempty"`
+}
+
+type captureSnapshotInput struct {
+	accountID string
+	regions   []string
+	// tagging returns a client of the account's Resource Groups Tagging API
+	tagging func(region string) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	// s3 returns a client of the account's S3 buckets
+	s3 func(region string) s3iface.S3API
+	// manifests toggles listing the objects of the account's S3 buckets
+	manifests bool
+	// maxKeys is the most objects listed per bucket
+	maxKeys int64
+	now     time.Time
+}
+
+// captureSnapshot takes an inventory of the account.  Failures are recorded in
+// the snapshot, so one inaccessible region or bucket doesn't lose the rest.
+func captureSnapshot(input *captureSnapshotInput) *accountSnapshot {
+	snapshot := &accountSnapshot{
+		AccountID:  input.accountID,
+		CapturedOn: input.now.Unix(),
+		Resources:  map[string][]string{},
+	}
+
+	for _, region := range input.regions {
+		arns := []string{}
+		err := input.tagging(region).GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{},
+			func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+				for _, resource := range page.ResourceTagMappingList {
+					arns = append(arns, aws.StringValue(resource.ResourceARN))
+				}
+				return true
+			})
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("resources in %s: %s", region, err))
+			continue
+		}
+		snapshot.Resources[region] = arns
+	}
+
+	if input.manifests {
+		snapshot.Buckets = captureBucketManifests(input, snapshot)
+	}
+	return snapshot
+}
+
+func captureBucketManifests(input *captureSnapshotInput, snapshot *accountSnapshot) []bucketManifest {
+	// Buckets are global, but their objects are listed in their own region
+	s3Svc := input.s3("us-east-1")
+	buckets, err := s3Svc.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("buckets: %s", err))
+		return nil
+	}
+
+	manifests := []bucketManifest{}
+	for _, bucket := range buckets.Buckets {
+		name := aws.StringValue(bucket.Name)
+		location, err := s3Svc.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: bucket.Name})
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("bucket %s: %s", name, err))
+			continue
+		}
+		// Buckets in us-east-1 have no location constraint
+		region := aws.StringValue(location.LocationConstraint)
+		if region == "" {
+			region = "us-east-1"
+		}
+
+		manifest := bucketManifest{Name: name, Region: region, Keys: []string{}}
+		err = input.s3(region).ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: bucket.Name},
+			func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+				for _, object := range page.Contents {
+					if int64(len(manifest.Keys)) >= input.maxKeys {
+						manifest.Truncated = true
+						return false
+					}
+					manifest.Keys = append(manifest.Keys, aws.StringValue(object.Key))
+				}
+				return true
+			})
+		if err != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("bucket %s: %s", name, err))
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests
+}
+
+// saveSnapshot writes the snapshot to S3, and returns its key.  Snapshots are
+// expired by the bucket's lifecycle rules.
+func saveSnapshot(s3Svc s3iface.S3API, bucket string, prefix string, snapshot *accountSnapshot) (string, error) {
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("%s/%s/%s.json", prefix, snapshot.AccountID,
+		time.Unix(snapshot.CapturedOn, 0).UTC().Format("20060102T150405Z"))
+	_, err = s3Svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// snapshotAccount captures an inventory of the account being reset, using its
+// admin role, and saves it to S3
+func snapshotAccount(svc *service) error {
+	config := svc.config()
+
+	childSession, err := svc.tokenService().NewSession(svc.awsSession(), config.accountAdminRoleARN)
+	if err != nil {
+		return err
+	}
+
+	snapshot := captureSnapshot(&captureSnapshotInput{
+		accountID: config.childAccountID,
+		regions:   config.nukeRegions,
+		tagging: func(region string) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+			return resourcegroupstaggingapi.New(childSession, aws.NewConfig().WithRegion(region))
+		},
+		s3: func(region string) s3iface.S3API {
+			return s3.New(childSession, aws.NewConfig().WithRegion(region))
+		},
+		manifests: config.snapshotS3Manifests,
+		maxKeys:   config.snapshotMaxKeys,
+		now:       time.Now(),
+	})
+	for _, e := range snapshot.Errors {
+		log.Printf("Snapshot of account %s is incomplete: %s", config.childAccountID, e)
+	}
+
+	key, err := saveSnapshot(s3.New(svc.awsSession(), common.EndpointConfig("S3")),
+		config.snapshotBucket, config.snapshotPrefix, snapshot)
+	if err != nil {
+		return err
+	}
+	log.Printf("Saved snapshot of account %s to s3://%s/%s", config.childAccountID, config.snapshotBucket, key)
+	return nil
+}
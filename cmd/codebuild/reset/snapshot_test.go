@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureSnapshot(t *testing.T) {
+	taggingSvcs := map[string]*awsMocks.ResourceGroupsTaggingAPI{
+		"us-east-1": {},
+		"us-west-2": {},
+	}
+	taggingSvcs["us-east-1"].On("GetResourcesPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String("arn:aws:ec2:us-east-1:111:instance/i-1")},
+				},
+			}, true)
+		}).
+		Return(nil)
+	taggingSvcs["us-west-2"].On("GetResourcesPages", mock.Anything, mock.Anything).
+		Return(errors.New("AccessDenied"))
+
+	s3Svc := &awsMocks.S3API{}
+	s3Svc.On("ListBuckets", mock.Anything).Return(&s3.ListBucketsOutput{
+		Buckets: []*s3.Bucket{{Name: aws.String("data")}},
+	}, nil)
+	s3Svc.On("GetBucketLocation", mock.Anything).Return(&s3.GetBucketLocationOutput{}, nil)
+	s3Svc.On("ListObjectsV2Pages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*s3.ListObjectsV2Output, bool) bool)
+			fn(&s3.ListObjectsV2Output{
+				Contents: []*s3.Object{{Key: aws.String("a")}, {Key: aws.String("b")}, {Key: aws.String("c")}},
+			}, true)
+		}).
+		Return(nil)
+
+	snapshot := captureSnapshot(&captureSnapshotInput{
+		accountID: "111",
+		regions:   []string{"us-east-1", "us-west-2"},
+		tagging: func(region string) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+			return taggingSvcs[region]
+		},
+		s3: func(region string) s3iface.S3API {
+			return s3Svc
+		},
+		manifests: true,
+		maxKeys:   2,
+		now:       time.Unix(1600000000, 0),
+	})
+
+	assert.Equal(t, &accountSnapshot{
+		AccountID:  "111",
+		CapturedOn: 1600000000,
+		Resources: map[string][]string{
+			"us-east-1": {"arn:aws:ec2:us-east-1:111:instance/i-1"},
+		},
+		Buckets: []bucketManifest{
+			{Name: "data", Region: "us-east-1", Keys: []string{"a", "b"}, Truncated: true},
+		},
+		Errors: []string{"resources in us-west-2: AccessDenied"},
+	}, snapshot)
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	snapshot := &accountSnapshot{
+		AccountID:  "111",
+		CapturedOn: 1600000000,
+		Resources:  map[string][]string{},
+	}
+
+	var input *s3.PutObjectInput
+	s3Svc := &awsMocks.S3API{}
+	s3Svc.On("PutObject", mock.Anything).
+		Run(func(args mock.Arguments) {
+			input = args.Get(0).(*s3.PutObjectInput)
+		}).
+		Return(&s3.PutObjectOutput{}, nil)
+
+	key, err := saveSnapshot(s3Svc, "artifacts", "snapshots", snapshot)
+	require.Nil(t, err)
+	assert.Equal(t, "snapshots/111/20200913T122640Z.json", key)
+	assert.Equal(t, "artifacts", *input.Bucket)
+	assert.Equal(t, key, *input.Key)
+
+	body, err := ioutil.ReadAll(input.Body)
+	require.Nil(t, err)
+	saved := &accountSnapshot{}
+	require.Nil(t, json.Unmarshal(body, saved))
+	assert.Equal(t, snapshot, saved)
+}
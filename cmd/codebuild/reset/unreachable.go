@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// unreachableStatuses are the AWS Organizations statuses of accounts which
+// were closed or suspended.  PENDING_CLOSURE is newer than this SDK.
+var unreachableStatuses = map[string]bool{
+	organizations.AccountStatusSuspended: true,
+	"PENDING_CLOSURE":                    true,
+}
+
+// checkUnreachable gets whether the account can't be reset because it was
+// closed or suspended.  That's only the case when its admin role can't be
+// assumed, and AWS Organizations reports it closed or suspended, so a
+// misconfigured role doesn't take an account out of the pool for good.
+// Accounts outside the organization are never found unreachable.
+func checkUnreachable(tokenSvc common.TokenService, orgSvc organizationsiface.OrganizationsAPI, accountID string, adminRoleArn string) (bool, string) {
+	_, err := tokenSvc.AssumeRole(&sts.AssumeRoleInput{
+		RoleArn:         aws.String(adminRoleArn),
+		RoleSessionName: aws.String("DCEReachable" + accountID),
+	})
+	if err == nil {
+		return false, ""
+	}
+	assumeErr := err
+
+	res, err := orgSvc.DescribeAccount(&organizations.DescribeAccountInput{
+		AccountId: aws.String(accountID),
+	})
+	if err != nil {
+		log.Printf("Failed to get the organization status of account %s: %s", accountID, err)
+		return false, ""
+	}
+	status := aws.StringValue(res.Account.Status)
+	if !unreachableStatuses[status] {
+		return false, ""
+	}
+	return true, fmt.Sprintf("account is %s in AWS Organizations, and its admin role can't be assumed: %s", status, assumeErr)
+}
+
+// markUnreachable takes the account out of the pool, so it isn't reset again,
+// and alerts operators and the account's contact so it can be deleted or
+// replaced
+func markUnreachable(dbSvc db.DBer, alertSvc alertiface.Servicer, router routingiface.Servicer, accountID string, reason string) error {
+	log.Printf("Setting Account Status from NotReady to Unreachable: %s", accountID)
+	_, err := dbSvc.TransitionAccountStatus(accountID, db.NotReady, db.Unreachable)
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("DCE account %s is unreachable, and was taken out of the account pool", accountID)
+	err = alertSvc.Trigger(&alert.Alert{
+		Type:      alert.TypeAccountUnreachable,
+		AccountID: accountID,
+		Summary:   summary,
+		Details: map[string]string{
+			"reason": reason,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to trigger the unreachable alert for account %s: %s", accountID, err)
+	}
+	routeAccountEvent(router, dbSvc, accountID, &routing.Event{
+		Type:    routing.EventAccountUnreachable,
+		Summary: summary,
+		Text:    reason,
+	})
+	// The reset isn't going to be retried, so neither is the failure
+	err = alertSvc.Resolve(alert.TypeResetFailed, accountID)
+	if err != nil {
+		log.Printf("Failed to resolve the reset alert for account %s: %s", accountID, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Optum/dce/pkg/alert"
+	alertMocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	commonMocks "github.com/Optum/dce/pkg/common/mocks"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/db/mocks"
+	"github.com/Optum/dce/pkg/routing"
+	routingMocks "github.com/Optum/dce/pkg/routing/routingiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckUnreachable(t *testing.T) {
+	tests := []struct {
+		name           string
+		assumeErr      error
+		orgStatus      string
+		orgErr         error
+		expUnreachable bool
+	}{
+		{name: "admin role can be assumed"},
+		{name: "suspended account", assumeErr: errors.New("AccessDenied"), orgStatus: "SUSPENDED", expUnreachable: true},
+		{name: "closing account", assumeErr: errors.New("AccessDenied"), orgStatus: "PENDING_CLOSURE", expUnreachable: true},
+		{name: "misconfigured admin role", assumeErr: errors.New("AccessDenied"), orgStatus: "ACTIVE"},
+		{name: "account outside the organization", assumeErr: errors.New("AccessDenied"), orgErr: errors.New("AccountNotFoundException")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenSvc := &commonMocks.TokenService{}
+			tokenSvc.On("AssumeRole", mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+				return *input.RoleArn == "arn:aws:iam::111:role/AdminRole"
+			})).Return(&sts.AssumeRoleOutput{}, tt.assumeErr)
+
+			orgSvc := &awsMocks.OrganizationsAPI{}
+			orgSvc.On("DescribeAccount", &organizations.DescribeAccountInput{AccountId: aws.String("111")}).
+				Return(&organizations.DescribeAccountOutput{
+					Account: &organizations.Account{Status: aws.String(tt.orgStatus)},
+				}, tt.orgErr)
+
+			unreachable, reason := checkUnreachable(tokenSvc, orgSvc, "111", "arn:aws:iam::111:role/AdminRole")
+
+			assert.Equal(t, tt.expUnreachable, unreachable)
+			if tt.expUnreachable {
+				assert.Contains(t, reason, tt.orgStatus)
+			}
+			if tt.assumeErr == nil {
+				orgSvc.AssertNotCalled(t, "DescribeAccount", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMarkUnreachable(t *testing.T) {
+	dbSvc := &mocks.DBer{}
+	dbSvc.On("TransitionAccountStatus", "111", db.NotReady, db.Unreachable).Return(&db.Account{}, nil)
+	alertSvc := &alertMocks.Servicer{}
+	alertSvc.On("Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+		return a.Type == alert.TypeAccountUnreachable && a.AccountID == "111" && a.Details["reason"] == "closed"
+	})).Return(nil)
+	alertSvc.On("Resolve", alert.TypeResetFailed, "111").Return(nil)
+	dbSvc.On("GetAccount", "111").Return(&db.Account{ID: "111", Contact: &db.AccountContact{Email: "ml@example.com"}}, nil)
+	router := &routingMocks.Servicer{}
+	router.On("Enabled").Return(true)
+	router.On("Route", mock.MatchedBy(func(e *routing.Event) bool {
+		return e.Type == routing.EventAccountUnreachable && e.Text == "closed" &&
+			e.Contact.Email == "ml@example.com"
+	})).Return(nil)
+
+	err := markUnreachable(dbSvc, alertSvc, router, "111", "closed")
+
+	assert.Nil(t, err)
+	dbSvc.AssertExpectations(t)
+	alertSvc.AssertExpectations(t)
+	router.AssertExpectations(t)
+}
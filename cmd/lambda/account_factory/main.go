@@ -0,0 +1,157 @@
+// Package main creates new AWS accounts when the account pool runs low, with
+// AWS Organizations or the Control Tower Account Factory, and adds them to
+// the pool once they are created
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountfactory"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// registrationWindow is how long after an account is created it is added to
+// the pool, so accounts which are removed from the pool are not added back
+const registrationWindow = 24 * time.Hour
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	now      = time.Now
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithAccountFactoryService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+// handler adds newly created accounts to the pool, and requests more
+// accounts when there are fewer than the minimum. Accounts which are being
+// created or reset count towards the minimum.
+func handler(ctx context.Context) error {
+	factorySvc := services.AccountFactoryService()
+	if !factorySvc.Enabled() {
+		log.Printf("No account factory is configured")
+		return nil
+	}
+
+	requests, err := factorySvc.ListRequests()
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	errs := []error{}
+	for _, request := range requests {
+		switch request.State {
+		case accountfactory.RequestInProgress:
+			pending++
+		case accountfactory.RequestFailed:
+			log.Printf("Account request %s failed: %s", request.Name, request.Reason)
+		case accountfactory.RequestSucceeded:
+			if now().Sub(request.CompletedOn) > registrationWindow {
+				continue
+			}
+			err := registerAccount(request)
+			if err != nil {
+				// Try again on the next run
+				log.Printf("Failed to add account %s to the pool: %s", request.AccountID, err)
+				errs = append(errs, err)
+				pending++
+			}
+		}
+	}
+
+	available, err := countAvailableAccounts()
+	if err != nil {
+		return err
+	}
+
+	missing := factorySvc.MinReadyAccounts() - available - pending
+	log.Printf("%d accounts are available and %d are being created, %d more are needed", available, pending, missing)
+	if missing > 0 {
+		names, err := factorySvc.CreateAccounts(missing)
+		for _, name := range names {
+			log.Printf("Requested account %s", name)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to replenish the account pool", errs)
+	}
+	return nil
+}
+
+// registerAccount adds a created account to the pool, unless it has been added already
+func registerAccount(request *accountfactory.Request) error {
+	_, err := services.AccountService().Create(&account.Account{
+		ID:           &request.AccountID,
+		AdminRoleArn: services.AccountFactoryService().AdminRoleArn(request.AccountID),
+		Metadata: map[string]interface{}{
+			"accountFactoryRequest": request.Name,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, errors.NewAlreadyExists("account", request.AccountID)) {
+			return nil
+		}
+		return err
+	}
+	log.Printf("Added account %s (%s) to the pool", request.AccountID, request.Name)
+	return nil
+}
+
+// countAvailableAccounts counts the Ready accounts, and the NotReady accounts
+// which are being reset
+func countAvailableAccounts() (int, error) {
+	count := 0
+	for _, status := range []account.Status{account.StatusReady, account.StatusNotReady} {
+		err := services.AccountService().ListPages(&account.Account{
+			Status: status.StatusPtr(),
+		}, func(accounts *account.Accounts) bool {
+			count += len(*accounts)
+			return true
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/accountfactory"
+	accountfactorymocks "github.com/Optum/dce/pkg/accountfactory/accountfactoryiface/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountFactory(t *testing.T) {
+	currentTime := time.Date(2020, 1, 2, 15, 0, 0, 0, time.UTC)
+	now = func() time.Time { return currentTime }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name       string
+		minReady   int
+		requests   []*accountfactory.Request
+		available  int
+		createErr  error
+		expCreated []string
+		expCreate  int
+		expErr     string
+	}{
+		{
+			name:     "should add created accounts to the pool",
+			minReady: 3,
+			requests: []*accountfactory.Request{
+				{Name: "dce-1", State: accountfactory.RequestSucceeded, AccountID: "111111111111", CompletedOn: currentTime.Add(-time.Hour)},
+				{Name: "dce-2", State: accountfactory.RequestSucceeded, AccountID: "222222222222", CompletedOn: currentTime.Add(-time.Minute)},
+				{Name: "dce-3", State: accountfactory.RequestSucceeded, AccountID: "333333333333", CompletedOn: currentTime.Add(-48 * time.Hour)},
+				{Name: "dce-4", State: accountfactory.RequestInProgress},
+				{Name: "dce-5", State: accountfactory.RequestFailed, Reason: "EMAIL_ALREADY_EXISTS"},
+			},
+			available:  2,
+			expCreated: []string{"111111111111", "222222222222"},
+		},
+		{
+			name:      "should request accounts when the pool is low",
+			minReady:  5,
+			requests:  []*accountfactory.Request{{Name: "dce-1", State: accountfactory.RequestInProgress}},
+			available: 1,
+			expCreate: 3,
+		},
+		{
+			name: "should retry accounts which failed to be added",
+			requests: []*accountfactory.Request{
+				{Name: "dce-1", State: accountfactory.RequestSucceeded, AccountID: "444444444444", CompletedOn: currentTime},
+			},
+			minReady:   1,
+			expCreated: []string{"444444444444"},
+			expErr:     "failed to replenish the account pool: role not assumable",
+		},
+		{
+			name:      "should fail when accounts can't be requested",
+			minReady:  2,
+			available: 1,
+			createErr: fmt.Errorf("limit exceeded"),
+			expCreate: 1,
+			expErr:    "failed to replenish the account pool: limit exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factorySvc := &accountfactorymocks.Servicer{}
+			factorySvc.On("Enabled").Return(true)
+			factorySvc.On("MinReadyAccounts").Return(tt.minReady)
+			factorySvc.On("ListRequests").Return(tt.requests, nil)
+			factorySvc.On("CreateAccounts", mock.Anything).Return([]string{"dce-6"}, tt.createErr)
+			factorySvc.On("AdminRoleArn", mock.Anything).Return(func(accountID string) *arn.ARN {
+				return arn.New("aws", "iam", "", accountID, "role/OrganizationAccountAccessRole")
+			})
+
+			accountSvc := &accountmocks.Servicer{}
+			accountSvc.On("Create", mock.MatchedBy(func(a *account.Account) bool { return *a.ID == "111111111111" })).
+				Return(&account.Account{}, nil)
+			accountSvc.On("Create", mock.MatchedBy(func(a *account.Account) bool { return *a.ID == "222222222222" })).
+				Return(nil, errors.NewAlreadyExists("account", "222222222222"))
+			accountSvc.On("Create", mock.MatchedBy(func(a *account.Account) bool { return *a.ID == "444444444444" })).
+				Return(nil, fmt.Errorf("role not assumable"))
+			accountSvc.On("ListPages", &account.Account{Status: account.StatusReady.StatusPtr()}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					accounts := make(account.Accounts, tt.available)
+					args.Get(1).(func(*account.Accounts) bool)(&accounts)
+				}).
+				Return(nil)
+			accountSvc.On("ListPages", &account.Account{Status: account.StatusNotReady.StatusPtr()}, mock.Anything).
+				Return(nil)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(accountSvc).WithService(factorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			services = svcBldr
+
+			err = handler(context.TODO())
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			for _, id := range tt.expCreated {
+				accountSvc.AssertCalled(t, "Create", mock.MatchedBy(func(a *account.Account) bool {
+					return *a.ID == id &&
+						a.AdminRoleArn.String() == "arn:aws:iam::"+id+":role/OrganizationAccountAccessRole"
+				}))
+			}
+			accountSvc.AssertNumberOfCalls(t, "Create", len(tt.expCreated))
+			if tt.expCreate > 0 {
+				factorySvc.AssertCalled(t, "CreateAccounts", tt.expCreate)
+			} else {
+				factorySvc.AssertNotCalled(t, "CreateAccounts", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAccountFactoryDisabled(t *testing.T) {
+	factorySvc := &accountfactorymocks.Servicer{}
+	factorySvc.On("Enabled").Return(false)
+	accountSvc := &accountmocks.Servicer{}
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(accountSvc).WithService(factorySvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	err = handler(context.TODO())
+
+	assert.Nil(t, err)
+	factorySvc.AssertNotCalled(t, "ListRequests")
+}
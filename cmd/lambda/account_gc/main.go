@@ -0,0 +1,249 @@
+// Package main garbage collects accounts which are stuck NotReady or
+// Unreachable.  Their remediation is retried once, and accounts which are
+// still stuck are quarantined and reported in a digest email, so they don't
+// count towards the account pool.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// StuckHours is how long an account can be NotReady or Unreachable
+	// before its remediation is retried, and how long after the retry it's
+	// quarantined
+	StuckHours int `env:"ACCOUNT_GC_STUCK_HOURS" envDefault:"72"`
+	// DigestEmails are sent the accounts which were quarantined
+	DigestEmails []string `env:"ACCOUNT_GC_DIGEST_EMAILS" envDefault:"" envSeparator:","`
+}
+
+type gcResult struct {
+	Checked     int `json:"checked"`
+	Retried     int `json:"retried"`
+	Quarantined int `json:"quarantined"`
+	Failed      int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	dbSvc    db.DBer
+	stsSvc   stsiface.STSAPI
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithNotificationService().
+		WithSTS().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+	if err := services.Config.GetService(&stsSvc); err != nil {
+		log.Fatalf("Failed to get the STS service: %s", err)
+	}
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*gcResult, error) {
+	if dbSvc == nil {
+		dbService, err := db.NewFromEnv()
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to initialize the DB service", err)
+		}
+		dbSvc = dbService
+	}
+
+	result := &gcResult{}
+	now := time.Now()
+	stuckBefore := now.Add(-time.Duration(settings.StuckHours) * time.Hour).Unix()
+
+	stuck := []*account.Account{}
+	for _, status := range []account.Status{account.StatusNotReady, account.StatusUnreachable} {
+		err := services.AccountService().ListPages(&account.Account{
+			Status: status.StatusPtr(),
+		}, func(accounts *account.Accounts) bool {
+			for i := range *accounts {
+				a := (*accounts)[i]
+				if isStuck(&a, stuckBefore) {
+					stuck = append(stuck, &a)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check every account before failing, so one failure doesn't hold up the rest
+	quarantined := []notification.Account{}
+	errs := []error{}
+	for _, a := range stuck {
+		result.Checked++
+		var err error
+		if a.Remediation == nil {
+			err = retryRemediation(a, now)
+			if err == nil {
+				result.Retried++
+			}
+		} else {
+			var q *notification.Account
+			q, err = quarantine(a, now)
+			if q != nil {
+				result.Quarantined++
+				quarantined = append(quarantined, *q)
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to garbage collect account %s: %s", aws.StringValue(a.ID), err)
+			result.Failed++
+			errs = append(errs, err)
+		}
+	}
+
+	if len(quarantined) > 0 {
+		err := sendDigest(quarantined)
+		if err != nil {
+			log.Printf("Failed to send the quarantine digest: %s", err)
+			errs = append(errs, err)
+		}
+	}
+
+	log.Printf("Checked %d stuck accounts: retried %d, quarantined %d, failed %d",
+		result.Checked, result.Retried, result.Quarantined, result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to garbage collect accounts", errs)
+	}
+	return result, nil
+}
+
+// isStuck is true when the account hasn't changed since stuckBefore, or its
+// remediation was retried before then and it still isn't Ready
+func isStuck(a *account.Account, stuckBefore int64) bool {
+	if a.Remediation != nil {
+		return a.Remediation.AttemptedOn <= stuckBefore
+	}
+	return a.LastModifiedOn != nil && *a.LastModifiedOn <= stuckBefore
+}
+
+// retryRemediation resets a NotReady account again.  Unreachable accounts are
+// reset once their admin role can be assumed again, eg. after the account was
+// reopened.  The retry is recorded either way, so the account is quarantined
+// if it's still stuck later.
+func retryRemediation(a *account.Account, now time.Time) error {
+	status := db.AccountStatus(a.Status.String())
+	if status == db.Unreachable && a.AdminRoleArn != nil {
+		_, err := stsSvc.AssumeRole(&sts.AssumeRoleInput{
+			RoleArn:         aws.String(a.AdminRoleArn.String()),
+			RoleSessionName: aws.String("DCEReachable" + *a.ID),
+		})
+		if err != nil {
+			log.Printf("Account %s is still unreachable: %s", *a.ID, err)
+		} else {
+			log.Printf("Account %s is reachable again, resetting it", *a.ID)
+			_, err = dbSvc.TransitionAccountStatus(*a.ID, db.Unreachable, db.NotReady)
+			if err != nil {
+				return err
+			}
+			status = db.NotReady
+		}
+	}
+	if status == db.NotReady {
+		log.Printf("Retrying the reset of account %s, which has been stuck NotReady", *a.ID)
+		_, err := services.AccountService().Reset(*a.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := dbSvc.RecordAccountRemediation(*a.ID, status, &db.AccountRemediation{
+		Status:      db.AccountStatus(a.Status.String()),
+		AttemptedOn: now.Unix(),
+	})
+	return ignoreStatusChanged(*a.ID, err)
+}
+
+// quarantine takes an account which is still stuck after its remediation was
+// retried out of the account pool.  It returns the account to report in the
+// digest, or nil if it's no longer stuck.
+func quarantine(a *account.Account, now time.Time) (*notification.Account, error) {
+	status := db.AccountStatus(a.Status.String())
+	log.Printf("Quarantining account %s, which is still %s after its remediation was retried", *a.ID, status)
+	quarantinedOn := now.Unix()
+	_, err := dbSvc.RecordAccountRemediation(*a.ID, status, &db.AccountRemediation{
+		Status:        db.AccountStatus(a.Remediation.Status.String()),
+		AttemptedOn:   a.Remediation.AttemptedOn,
+		QuarantinedOn: &quarantinedOn,
+	})
+	if err != nil {
+		return nil, ignoreStatusChanged(*a.ID, err)
+	}
+	_, err = dbSvc.TransitionAccountStatus(*a.ID, status, db.Quarantined)
+	if err != nil {
+		return nil, err
+	}
+	return &notification.Account{
+		ID:            *a.ID,
+		Status:        string(status),
+		RetriedOn:     time.Unix(a.Remediation.AttemptedOn, 0),
+		QuarantinedOn: now,
+	}, nil
+}
+
+// ignoreStatusChanged ignores the failure to record the remediation of an
+// account whose status changed since it was listed, eg. when its reset
+// finished.  It's checked again on the next run.
+func ignoreStatusChanged(accountID string, err error) error {
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		log.Printf("Account %s is no longer stuck", accountID)
+		return nil
+	}
+	return err
+}
+
+// sendDigest emails the accounts which were quarantined to operators
+func sendDigest(quarantined []notification.Account) error {
+	_, err := services.NotificationService().Send(notification.TemplateQuarantineDigest, settings.DigestEmails, &notification.Data{
+		Accounts: quarantined,
+	})
+	return err
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("account_gc", handler))
+}
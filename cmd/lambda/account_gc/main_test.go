@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/db"
+	dbmocks "github.com/Optum/dce/pkg/db/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const hour = 60 * 60
+
+func TestIsStuck(t *testing.T) {
+	now := time.Now().Unix()
+	stuckBefore := now - 72*hour
+
+	tests := []struct {
+		name     string
+		acct     *account.Account
+		expStuck bool
+	}{
+		{
+			name:     "unchanged for longer than the stuck hours",
+			acct:     &account.Account{LastModifiedOn: aws.Int64(now - 100*hour)},
+			expStuck: true,
+		},
+		{
+			name: "changed recently",
+			acct: &account.Account{LastModifiedOn: aws.Int64(now - hour)},
+		},
+		{
+			name: "retried recently",
+			acct: &account.Account{
+				LastModifiedOn: aws.Int64(now - 100*hour),
+				Remediation:    &account.Remediation{AttemptedOn: now - hour},
+			},
+		},
+		{
+			name: "retried longer than the stuck hours ago",
+			acct: &account.Account{
+				LastModifiedOn: aws.Int64(now - hour),
+				Remediation:    &account.Remediation{AttemptedOn: now - 100*hour},
+			},
+			expStuck: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expStuck, isStuck(tt.acct, stuckBefore))
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	settings.StuckHours = 72
+	settings.DigestEmails = []string{"ops@example.com"}
+	now := time.Now().Unix()
+	old := now - 100*hour
+
+	adminRole := func(id string) *arn.ARN {
+		return arn.New("aws", "iam", "", id, "role/AdminRole")
+	}
+	notReady := account.Accounts{
+		{ID: aws.String("111111111111"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: &old},
+		{ID: aws.String("222222222222"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: aws.Int64(now)},
+		{ID: aws.String("333333333333"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: aws.Int64(now),
+			Remediation: &account.Remediation{Status: account.StatusNotReady, AttemptedOn: old}},
+		{ID: aws.String("444444444444"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: &old},
+	}
+	unreachable := account.Accounts{
+		{ID: aws.String("555555555555"), Status: account.StatusUnreachable.StatusPtr(), LastModifiedOn: &old,
+			AdminRoleArn: adminRole("555555555555")},
+		{ID: aws.String("666666666666"), Status: account.StatusUnreachable.StatusPtr(), LastModifiedOn: &old,
+			AdminRoleArn: adminRole("666666666666")},
+	}
+
+	accountSvc := &accountmocks.Servicer{}
+	accountSvc.On("ListPages", &account.Account{Status: account.StatusNotReady.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*account.Accounts) bool)(&notReady)
+		}).
+		Return(nil)
+	accountSvc.On("ListPages", &account.Account{Status: account.StatusUnreachable.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*account.Accounts) bool)(&unreachable)
+		}).
+		Return(nil)
+	accountSvc.On("Reset", "111111111111").Return(&notReady[0], nil)
+	accountSvc.On("Reset", "444444444444").Return(&notReady[3], nil)
+	accountSvc.On("Reset", "666666666666").Return(&unreachable[1], nil)
+
+	mockSTS := &awsmocks.STSAPI{}
+	mockSTS.On("AssumeRole", mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+		return *input.RoleArn == "arn:aws:iam::555555555555:role/AdminRole"
+	})).Return(nil, awserr.New("AccessDenied", "account is suspended", nil))
+	mockSTS.On("AssumeRole", mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+		return *input.RoleArn == "arn:aws:iam::666666666666:role/AdminRole"
+	})).Return(&sts.AssumeRoleOutput{}, nil)
+
+	dbSvcMock := &dbmocks.DBer{}
+	dbSvcMock.On("RecordAccountRemediation", "111111111111", db.NotReady, &db.AccountRemediation{Status: db.NotReady, AttemptedOn: now}).
+		Return(&db.Account{}, nil)
+	// The reset of this account finished before its retry was recorded
+	dbSvcMock.On("RecordAccountRemediation", "444444444444", db.NotReady, mock.Anything).
+		Return(nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "failed", nil))
+	dbSvcMock.On("RecordAccountRemediation", "555555555555", db.Unreachable, &db.AccountRemediation{Status: db.Unreachable, AttemptedOn: now}).
+		Return(&db.Account{}, nil)
+	dbSvcMock.On("TransitionAccountStatus", "666666666666", db.Unreachable, db.NotReady).Return(&db.Account{}, nil)
+	dbSvcMock.On("RecordAccountRemediation", "666666666666", db.NotReady, &db.AccountRemediation{Status: db.Unreachable, AttemptedOn: now}).
+		Return(&db.Account{}, nil)
+	dbSvcMock.On("RecordAccountRemediation", "333333333333", db.NotReady, &db.AccountRemediation{Status: db.NotReady, AttemptedOn: old, QuarantinedOn: &now}).
+		Return(&db.Account{}, nil)
+	dbSvcMock.On("TransitionAccountStatus", "333333333333", db.NotReady, db.Quarantined).Return(&db.Account{}, nil)
+
+	notificationSvc := &notificationmocks.Servicer{}
+	notificationSvc.On("Send", notification.TemplateQuarantineDigest, []string{"ops@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return len(data.Accounts) == 1 &&
+				data.Accounts[0].ID == "333333333333" &&
+				data.Accounts[0].Status == "NotReady" &&
+				data.Accounts[0].RetriedOn.Unix() == old
+		})).
+		Return(&notification.Email{}, nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(accountSvc).WithService(notificationSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+	stsSvc = mockSTS
+	dbSvc = dbSvcMock
+
+	result, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &gcResult{Checked: 5, Retried: 4, Quarantined: 1}, result)
+	accountSvc.AssertExpectations(t)
+	accountSvc.AssertNotCalled(t, "Reset", "222222222222")
+	accountSvc.AssertNotCalled(t, "Reset", "555555555555")
+	dbSvcMock.AssertExpectations(t)
+	notificationSvc.AssertExpectations(t)
+}
+
+func TestHandlerFailure(t *testing.T) {
+	settings.StuckHours = 72
+	old := time.Now().Unix() - 100*hour
+
+	accounts := account.Accounts{
+		{ID: aws.String("111111111111"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: &old},
+		{ID: aws.String("222222222222"), Status: account.StatusNotReady.StatusPtr(), LastModifiedOn: &old},
+	}
+	accountSvc := &accountmocks.Servicer{}
+	accountSvc.On("ListPages", &account.Account{Status: account.StatusNotReady.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*account.Accounts) bool)(&accounts)
+		}).
+		Return(nil)
+	accountSvc.On("ListPages", &account.Account{Status: account.StatusUnreachable.StatusPtr()}, mock.Anything).Return(nil)
+	accountSvc.On("Reset", "111111111111").Return(nil, fmt.Errorf("queue unavailable"))
+	accountSvc.On("Reset", "222222222222").Return(&accounts[1], nil)
+
+	dbSvcMock := &dbmocks.DBer{}
+	dbSvcMock.On("RecordAccountRemediation", "222222222222", db.NotReady, mock.Anything).Return(&db.Account{}, nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(accountSvc).WithService(&notificationmocks.Servicer{})
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+	dbSvc = dbSvcMock
+
+	result, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &gcResult{Checked: 2, Retried: 1, Failed: 1}, result)
+	dbSvcMock.AssertNotCalled(t, "RecordAccountRemediation", "111111111111", mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/alert"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -10,10 +11,21 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"log"
+	"strconv"
 )
 
+type configuration struct {
+	// ReadyPoolLowThreshold warns operators when fewer accounts than this are
+	// Ready.  Disabled when 0
+	ReadyPoolLowThreshold int `env:"READY_POOL_LOW_THRESHOLD" envDefault:"0"`
+	// ReadyPoolLowPercent warns operators when less than this percentage of
+	// the leasable accounts are Ready.  Disabled when 0
+	ReadyPoolLowPercent float64 `env:"READY_POOL_LOW_PERCENT" envDefault:"0"`
+}
+
 var (
 	Services *config.ServiceBuilder
+	settings = &configuration{}
 )
 
 func initConfig() {
@@ -21,6 +33,9 @@ func initConfig() {
 
 	// load up the values into the various settings...
 	cfgBldr := &config.ConfigurationBuilder{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
 	_ = cfgBldr.
 		WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").
 		Build()
@@ -29,6 +44,8 @@ func initConfig() {
 	_, err := svcBuilder.
 		WithAccountService().
 		WithCloudWatchService().
+		WithAlertService().
+		WithEventService().
 		Build()
 	if err != nil {
 		errorMessage := fmt.Sprintf("Failed to initialize account service: %s", err)
@@ -87,6 +104,73 @@ func publishMetrics(namespace string, countMetrics CountMetric) {
 	}
 }
 
+// alertReadyPool opens an incident when there are no Ready accounts left to
+// lease, and resolves it once accounts are Ready again
+func alertReadyPool(ready CountMetric) {
+	var err error
+	if ready.count == 0 {
+		err = Services.AlertService().Trigger(&alert.Alert{
+			Type:     alert.TypeReadyPoolExhausted,
+			Severity: alert.SeverityCritical,
+			Summary:  "DCE has no Ready accounts to lease",
+		})
+	} else {
+		err = Services.AlertService().Resolve(alert.TypeReadyPoolExhausted, "")
+	}
+	if err != nil {
+		log.Printf("Failed to update the ready pool exhausted alert: %s", err)
+	}
+}
+
+// isReadyPoolLow checks whether the Ready accounts are below either of the
+// configured thresholds
+func isReadyPoolLow(pool account.Pool) bool {
+	if settings.ReadyPoolLowThreshold > 0 && pool.Ready < settings.ReadyPoolLowThreshold {
+		return true
+	}
+	if settings.ReadyPoolLowPercent > 0 && pool.Leasable() > 0 &&
+		float64(pool.Ready)*100/float64(pool.Leasable()) < settings.ReadyPoolLowPercent {
+		return true
+	}
+	return false
+}
+
+// alertReadyPoolLow warns operators, and publishes an AccountPoolLow event,
+// each time the pool is checked while the Ready accounts are low, so
+// accounts can be added before leases start failing.  The alert is resolved
+// once there are enough Ready accounts again.
+func alertReadyPoolLow(pool account.Pool) {
+	if !isReadyPoolLow(pool) {
+		err := Services.AlertService().Resolve(alert.TypeReadyPoolLow, "")
+		if err != nil {
+			log.Printf("Failed to resolve the ready pool low alert: %s", err)
+		}
+		return
+	}
+
+	log.Printf("Ready account pool is low: %d of %d leasable accounts are Ready", pool.Ready, pool.Leasable())
+	err := Services.AlertService().Trigger(&alert.Alert{
+		Type:     alert.TypeReadyPoolLow,
+		Severity: alert.SeverityWarning,
+		Summary:  fmt.Sprintf("DCE is running low on Ready accounts: %d of %d leasable accounts are Ready", pool.Ready, pool.Leasable()),
+		Details: map[string]string{
+			"ready":       strconv.Itoa(pool.Ready),
+			"notReady":    strconv.Itoa(pool.NotReady),
+			"leased":      strconv.Itoa(pool.Leased),
+			"orphaned":    strconv.Itoa(pool.Orphaned),
+			"unreachable": strconv.Itoa(pool.Unreachable),
+			"quarantined": strconv.Itoa(pool.Quarantined),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to trigger the ready pool low alert: %s", err)
+	}
+	err = Services.EventService().AccountPoolLow(&pool)
+	if err != nil {
+		log.Printf("Failed to publish the account pool low event: %s", err)
+	}
+}
+
 // Handler - Handle the lambda function
 func Handler(_ events.CloudWatchEvent) {
 	log.Printf("Initializing account pool metrics lambda")
@@ -96,21 +180,39 @@ func Handler(_ events.CloudWatchEvent) {
 	NotReady := getMetric(account.StatusNotReady)
 	Leased := getMetric(account.StatusLeased)
 	Orphaned := getMetric(account.StatusOrphaned)
+	Unreachable := getMetric(account.StatusUnreachable)
+	Quarantined := getMetric(account.StatusQuarantined)
 
 	log.Println("Found ", Ready.count, Ready.name, " accounts")
 	log.Println("Found ", NotReady.count, NotReady.name, " accounts")
 	log.Println("Found ", Leased.count, Leased.name, " accounts")
 	log.Println("Found ", Orphaned.count, Orphaned.name, " accounts")
+	log.Println("Found ", Unreachable.count, Unreachable.name, " accounts")
+	log.Println("Found ", Quarantined.count, Quarantined.name, " accounts")
 
 	publishMetrics("DCE/AccountPool", Ready)
 	publishMetrics("DCE/AccountPool", NotReady)
 	publishMetrics("DCE/AccountPool", Leased)
 	publishMetrics("DCE/AccountPool", Orphaned)
+	publishMetrics("DCE/AccountPool", Unreachable)
+	publishMetrics("DCE/AccountPool", Quarantined)
 
 	log.Println("Published ReadyAccount Metric: ", float64(Ready.count))
 	log.Println("Published NotReadyAccounts Metric: ", float64(NotReady.count))
 	log.Println("Published LeasedAccounts Metric: ", float64(Leased.count))
 	log.Println("Published OrphanedAccounts Metric: ", float64(Orphaned.count))
+	log.Println("Published UnreachableAccounts Metric: ", float64(Unreachable.count))
+	log.Println("Published QuarantinedAccounts Metric: ", float64(Quarantined.count))
+
+	alertReadyPool(Ready)
+	alertReadyPoolLow(account.Pool{
+		Ready:       Ready.count,
+		NotReady:    NotReady.count,
+		Leased:      Leased.count,
+		Orphaned:    Orphaned.count,
+		Unreachable: Unreachable.count,
+		Quarantined: Quarantined.count,
+	})
 
 	log.Print("Account pool metrics lambda complete")
 }
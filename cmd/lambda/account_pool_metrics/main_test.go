@@ -3,8 +3,11 @@ package main
 import (
 	"github.com/Optum/dce/pkg/account"
 	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/alert"
+	alertMocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
 	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
 	"github.com/Optum/dce/pkg/config"
+	eventMocks "github.com/Optum/dce/pkg/event/eventiface/mocks"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -94,3 +97,126 @@ func TestPublishMetrics(t *testing.T) {
 		publishMetrics(namespace, countMetric1)
 	})
 }
+
+func TestAlertReadyPool(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		readyCount int
+		expTrigger bool
+	}{
+		{
+			name:       "trigger alert when there are no ready accounts",
+			readyCount: 0,
+			expTrigger: true,
+		},
+		{
+			name:       "resolve alert when there are ready accounts",
+			readyCount: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			alertSvc := alertMocks.Servicer{}
+			alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+			alertSvc.On("Resolve", alert.TypeReadyPoolExhausted, "").Return(nil)
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+			svcBldr.Config.WithService(&alertSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			// act
+			alertReadyPool(CountMetric{name: "Ready", count: tt.readyCount})
+
+			// assert
+			if tt.expTrigger {
+				alertSvc.AssertCalled(t, "Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+					return a.Type == alert.TypeReadyPoolExhausted && a.AccountID == ""
+				}))
+				alertSvc.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+			} else {
+				alertSvc.AssertCalled(t, "Resolve", alert.TypeReadyPoolExhausted, "")
+				alertSvc.AssertNotCalled(t, "Trigger", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAlertReadyPoolLow(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		threshold  int
+		percent    float64
+		pool       account.Pool
+		expTrigger bool
+	}{
+		{
+			name:       "warn when fewer accounts than the threshold are ready",
+			threshold:  5,
+			pool:       account.Pool{Ready: 4, NotReady: 1, Leased: 95},
+			expTrigger: true,
+		},
+		{
+			name:      "resolve when the threshold accounts are ready",
+			threshold: 5,
+			pool:      account.Pool{Ready: 5, Leased: 95},
+		},
+		{
+			name:       "warn when less than the percentage of leasable accounts are ready",
+			percent:    10,
+			pool:       account.Pool{Ready: 9, NotReady: 1, Leased: 90, Orphaned: 20},
+			expTrigger: true,
+		},
+		{
+			name:    "orphaned accounts aren't leasable",
+			percent: 10,
+			pool:    account.Pool{Ready: 10, Leased: 90, Orphaned: 20},
+		},
+		{
+			name: "resolve when no threshold is set",
+			pool: account.Pool{Leased: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			settings.ReadyPoolLowThreshold = tt.threshold
+			settings.ReadyPoolLowPercent = tt.percent
+			alertSvc := alertMocks.Servicer{}
+			alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+			alertSvc.On("Resolve", alert.TypeReadyPoolLow, "").Return(nil)
+			eventSvc := eventMocks.Servicer{}
+			eventSvc.On("AccountPoolLow", &tt.pool).Return(nil)
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+			svcBldr.Config.WithService(&alertSvc).WithService(&eventSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			// act
+			alertReadyPoolLow(tt.pool)
+
+			// assert
+			if tt.expTrigger {
+				alertSvc.AssertCalled(t, "Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+					return a.Type == alert.TypeReadyPoolLow && a.Severity == alert.SeverityWarning
+				}))
+				eventSvc.AssertCalled(t, "AccountPoolLow", &tt.pool)
+				alertSvc.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+			} else {
+				alertSvc.AssertCalled(t, "Resolve", alert.TypeReadyPoolLow, "")
+				alertSvc.AssertNotCalled(t, "Trigger", mock.Anything)
+				eventSvc.AssertNotCalled(t, "AccountPoolLow", mock.Anything)
+			}
+		})
+	}
+}
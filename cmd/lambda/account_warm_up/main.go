@@ -0,0 +1,375 @@
+// Package main runs the individual steps of the account warm-up Step
+// Functions state machine, which prepares newly added accounts before they
+// become Ready
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/event"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// Steps which are run by the state machine, besides the configured warm-up
+// steps
+const (
+	StepRecordExecution = "RecordExecution"
+	StepComplete        = "Complete"
+	StepRecordFailure   = "RecordFailure"
+)
+
+// Messages recorded on steps which are waiting on something else.  Steps
+// use them to tell whether they've already started their work.
+const (
+	messageApplyingBaseline = "waiting for the baseline stack"
+	messageResetQueued      = "waiting for the account reset"
+)
+
+type configuration struct {
+	Debug             string `env:"DEBUG" envDefault:"false"`
+	Region            string `env:"AWS_CURRENT_REGION" envDefault:"us-east-1"`
+	BaselineS3Bucket  string `env:"ARTIFACTS_BUCKET" envDefault:"DefaultArtifactBucket"`
+	BaselineS3Key     string `env:"ACCOUNT_WARM_UP_BASELINE_S3_KEY" envDefault:""`
+	BaselineStackName string `env:"ACCOUNT_WARM_UP_BASELINE_STACK_NAME" envDefault:"dce-baseline"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	// newCloudFormation creates a CloudFormation client in the account, using
+	// its admin role
+	newCloudFormation = cloudFormationForRole
+	now               = time.Now
+)
+
+// workflowInput is passed to each step by the state machine
+type workflowInput struct {
+	Step         string          `json:"step"`
+	ExecutionArn string          `json:"executionArn"`
+	Event        json.RawMessage `json:"event"`
+	// Error is the error caught by the state machine, for the RecordFailure step
+	Error *workflowError `json:"error,omitempty"`
+}
+
+type workflowError struct {
+	Error string `json:"Error"`
+	Cause string `json:"Cause"`
+}
+
+// StepIncompleteError is returned when a step is waiting on something else
+// to finish. The state machine retries steps which fail with this error for
+// longer than other errors, so its name must not change.
+type StepIncompleteError struct {
+	message string
+}
+
+func (e *StepIncompleteError) Error() string {
+	return e.message
+}
+
+// warmUpSteps run the configured warm-up steps, returning a message to
+// record on the step when it succeeds
+var warmUpSteps = map[string]func(acct *account.Account) (string, error){
+	account.WarmUpStepCreatePrincipalRole: createPrincipalRole,
+	account.WarmUpStepApplyBaseline:       applyBaseline,
+	account.WarmUpStepInitialReset:        initialReset,
+	account.WarmUpStepVerifyHealth:        verifyHealth,
+}
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountManagerService().
+		WithAccountService().
+		WithStorageService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+func handler(ctx context.Context, input workflowInput) error {
+	var data account.Account
+	_, err := event.Unmarshal(input.Event, &data)
+	if err != nil {
+		return err
+	}
+	if data.ID == nil {
+		return errors.NewValidation("account", fmt.Errorf("the account ID is required"))
+	}
+
+	acct, err := services.AccountService().Get(*data.ID)
+	if err != nil {
+		return err
+	}
+	if acct.WarmUp == nil {
+		return errors.NewConflict("account", *data.ID, fmt.Errorf("account isn't warming up"))
+	}
+
+	log.Printf("Running warm-up step %q for account %q", input.Step, *data.ID)
+	switch input.Step {
+	case StepRecordExecution:
+		acct.WarmUp.ExecutionArn = &input.ExecutionArn
+		return services.AccountService().Save(acct)
+	case StepComplete:
+		return complete(acct)
+	case StepRecordFailure:
+		return recordFailure(acct, input.Error)
+	}
+
+	run, ok := warmUpSteps[input.Step]
+	if !ok || !acct.WarmUp.HasStep(input.Step) {
+		return errors.NewBadRequest(fmt.Sprintf("unknown step %q", input.Step))
+	}
+	return runStep(acct, input.Step, run)
+}
+
+// runStep runs a warm-up step, and records its status on the account.
+// Steps which already succeeded aren't run again, so the state machine can
+// safely retry them.
+func runStep(acct *account.Account, name string, run func(acct *account.Account) (string, error)) error {
+	step := acct.WarmUp.Step(name)
+	if step.Status == account.WarmUpStatusSucceeded {
+		return nil
+	}
+	if step.Status != account.WarmUpStatusInProgress {
+		acct.WarmUp.SetStep(name, account.WarmUpStatusInProgress, "", now().Unix())
+		err := services.AccountService().Save(acct)
+		if err != nil {
+			return err
+		}
+	}
+
+	message, err := run(acct)
+	if err != nil {
+		incomplete, ok := err.(*StepIncompleteError)
+		if !ok {
+			// Failures are recorded by the RecordFailure step, once the
+			// state machine stops retrying
+			return err
+		}
+		saveErr := saveStep(*acct.ID, name, account.WarmUpStatusInProgress, incomplete.message)
+		if saveErr != nil {
+			return saveErr
+		}
+		return err
+	}
+	return saveStep(*acct.ID, name, account.WarmUpStatusSucceeded, message)
+}
+
+// saveStep records the status of a step on the latest version of the
+// account, since steps may change the account while they run
+func saveStep(accountID string, name string, status account.WarmUpStatus, message string) error {
+	acct, err := services.AccountService().Get(accountID)
+	if err != nil {
+		return err
+	}
+	step := acct.WarmUp.Step(name)
+	if step != nil && step.Status == status && step.Message == message {
+		return nil
+	}
+	acct.WarmUp.SetStep(name, status, message, now().Unix())
+	return services.AccountService().Save(acct)
+}
+
+// createPrincipalRole creates the role and policy principals use to access
+// the account
+func createPrincipalRole(acct *account.Account) (string, error) {
+	return "", services.AccountService().UpsertPrincipalAccess(acct)
+}
+
+// applyBaseline deploys the baseline CloudFormation template to the account,
+// and waits for the stack to finish
+func applyBaseline(acct *account.Account) (string, error) {
+	if settings.BaselineS3Key == "" {
+		return "no baseline template is configured", nil
+	}
+
+	cfnSvc, err := newCloudFormation(acct.AdminRoleArn.String())
+	if err != nil {
+		return "", errors.NewInternalServer("failed to create a CloudFormation client", err)
+	}
+
+	// Start creating or updating the stack the first time the step runs
+	if acct.WarmUp.Step(account.WarmUpStepApplyBaseline).Message != messageApplyingBaseline {
+		err = deployBaseline(cfnSvc)
+		if err != nil {
+			return "", err
+		}
+		return "", &StepIncompleteError{message: messageApplyingBaseline}
+	}
+
+	out, err := cfnSvc.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(settings.BaselineStackName),
+	})
+	if err != nil {
+		return "", errors.NewInternalServer("failed to describe the baseline stack", err)
+	}
+	if len(out.Stacks) == 0 {
+		return "", errors.NewConflict("account", *acct.ID, fmt.Errorf("baseline stack %q is missing", settings.BaselineStackName))
+	}
+
+	stack := out.Stacks[0]
+	status := aws.StringValue(stack.StackStatus)
+	switch {
+	case status == cloudformation.StackStatusCreateComplete || status == cloudformation.StackStatusUpdateComplete:
+		return fmt.Sprintf("baseline stack %s is %s", aws.StringValue(stack.StackId), status), nil
+	case strings.HasSuffix(status, "_IN_PROGRESS") && !strings.Contains(status, "ROLLBACK"):
+		return "", &StepIncompleteError{message: messageApplyingBaseline}
+	}
+	return "", errors.NewConflict("account", *acct.ID,
+		fmt.Errorf("baseline stack %q is %s: %s", settings.BaselineStackName, status, aws.StringValue(stack.StackStatusReason)))
+}
+
+// deployBaseline creates the baseline stack, or updates it when the account
+// already has one (eg. it was in the pool before)
+func deployBaseline(cfnSvc cloudformationiface.CloudFormationAPI) error {
+	var storager common.Storager
+	err := services.Config.GetService(&storager)
+	if err != nil {
+		return errors.NewInternalServer("failed to get the storage service", err)
+	}
+	template, err := storager.GetObject(settings.BaselineS3Bucket, settings.BaselineS3Key)
+	if err != nil {
+		return errors.NewInternalServer("failed to get the baseline template", err)
+	}
+
+	capabilities := aws.StringSlice([]string{cloudformation.CapabilityCapabilityNamedIam})
+	_, err = cfnSvc.CreateStack(&cloudformation.CreateStackInput{
+		StackName:    aws.String(settings.BaselineStackName),
+		TemplateBody: aws.String(template),
+		Capabilities: capabilities,
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudformation.ErrCodeAlreadyExistsException {
+		_, err = cfnSvc.UpdateStack(&cloudformation.UpdateStackInput{
+			StackName:    aws.String(settings.BaselineStackName),
+			TemplateBody: aws.String(template),
+			Capabilities: capabilities,
+		})
+		if aerr, ok := err.(awserr.Error); ok && strings.Contains(aerr.Message(), "No updates are to be performed") {
+			return nil
+		}
+	}
+	if err != nil {
+		return errors.NewInternalServer("failed to deploy the baseline stack", err)
+	}
+	return nil
+}
+
+// initialReset queues a reset of the account, and waits for it to finish.
+// The reset records when it finishes on the warm-up, since the account stays
+// NotReady.
+func initialReset(acct *account.Account) (string, error) {
+	if acct.WarmUp.ResetCompletedOn != nil {
+		return "", nil
+	}
+	if acct.WarmUp.Step(account.WarmUpStepInitialReset).Message != messageResetQueued {
+		_, err := services.AccountService().Reset(*acct.ID)
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", &StepIncompleteError{message: messageResetQueued}
+}
+
+// verifyHealth checks the account can be managed by DCE and used by principals
+func verifyHealth(acct *account.Account) (string, error) {
+	err := services.AccountManager().ValidateAccess(acct.AdminRoleArn)
+	if err != nil {
+		return "", err
+	}
+	if acct.PrincipalRoleArn == nil || acct.PrincipalPolicyHash == nil {
+		return "", errors.NewConflict("account", *acct.ID, fmt.Errorf("principal role and policy haven't been created"))
+	}
+	if acct.Status == nil || *acct.Status != account.StatusNotReady {
+		return "", errors.NewConflict("account", *acct.ID, fmt.Errorf("account should be NotReady while warming up"))
+	}
+	return "", nil
+}
+
+// complete makes the account Ready, once every step succeeded and its first
+// reset finished
+func complete(acct *account.Account) error {
+	if acct.WarmUp.IsDone() {
+		return nil
+	}
+	// Accounts are reset when they're added, even when the warm-up doesn't
+	// have an InitialReset step
+	if acct.WarmUp.ResetCompletedOn == nil {
+		return &StepIncompleteError{message: messageResetQueued}
+	}
+	if !acct.WarmUp.Complete() {
+		return errors.NewConflict("account", *acct.ID, fmt.Errorf("not every warm-up step succeeded"))
+	}
+	acct.Status = account.StatusReady.StatusPtr()
+	err := services.AccountService().Save(acct)
+	if err != nil {
+		return err
+	}
+	log.Printf("Account %q finished warming up, and is Ready", *acct.ID)
+	return nil
+}
+
+// recordFailure marks the step which was running as failed, so the account
+// stays NotReady
+func recordFailure(acct *account.Account, cause *workflowError) error {
+	message := "the warm-up failed"
+	if cause != nil {
+		message = fmt.Sprintf("%s: %s", cause.Error, cause.Cause)
+	}
+	log.Printf("Warm-up of account %q failed: %s", *acct.ID, message)
+
+	name := acct.WarmUp.RunningStep()
+	if name == "" {
+		acct.WarmUp.Status = account.WarmUpStatusFailed
+	} else {
+		acct.WarmUp.SetStep(name, account.WarmUpStatusFailed, message, now().Unix())
+	}
+	return services.AccountService().Save(acct)
+}
+
+func cloudFormationForRole(roleArn string) (cloudformationiface.CloudFormationAPI, error) {
+	awsSession, err := common.SharedSession(settings.Region)
+	if err != nil {
+		return nil, err
+	}
+	tokenSvc := common.STS{Client: sts.New(awsSession)}
+	roleSession, err := tokenSvc.NewSession(awsSession, roleArn)
+	if err != nil {
+		return nil, err
+	}
+	return cloudformation.New(roleSession), nil
+}
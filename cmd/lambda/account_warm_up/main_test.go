@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	amMocks "github.com/Optum/dce/pkg/accountmanager/accountmanageriface/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	commonMocks "github.com/Optum/dce/pkg/common/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var accountEvent = []byte("{\"type\":\"AccountCreated\",\"version\":\"1\",\"data\":{\"id\":\"123456789012\"}}")
+
+func warmingUpAccount(steps ...string) *account.Account {
+	return &account.Account{
+		ID:                  aws.String("123456789012"),
+		Status:              account.StatusNotReady.StatusPtr(),
+		AdminRoleArn:        arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+		PrincipalRoleArn:    arn.New("aws", "iam", "", "123456789012", "role/DCEPrincipal"),
+		PrincipalPolicyHash: aws.String("hash"),
+		WarmUp:              account.NewWarmUp(steps),
+	}
+}
+
+// setup registers mocks for the services used by the steps.  Every Get of
+// the account returns the same record, so tests can check its final state.
+func setup(t *testing.T, acct *account.Account) (*accountMocks.Servicer, *amMocks.Servicer, *commonMocks.Storager, *awsMocks.CloudFormationAPI) {
+	acctSvc := &accountMocks.Servicer{}
+	acctSvc.On("Get", "123456789012").Return(acct, nil)
+	acctSvc.On("Save", acct).Return(nil)
+	managerSvc := &amMocks.Servicer{}
+	storager := &commonMocks.Storager{}
+	cfnSvc := &awsMocks.CloudFormationAPI{}
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(acctSvc).WithService(managerSvc).WithService(storager)
+	_, err := svcBldr.Build()
+	require.Nil(t, err)
+	services = svcBldr
+
+	settings = &configuration{
+		BaselineS3Bucket:  "artifacts",
+		BaselineStackName: "dce-baseline",
+	}
+	newCloudFormation = func(roleArn string) (cloudformationiface.CloudFormationAPI, error) {
+		assert.Equal(t, "arn:aws:iam::123456789012:role/AdminRole", roleArn)
+		return cfnSvc, nil
+	}
+	return acctSvc, managerSvc, storager, cfnSvc
+}
+
+func assertIncomplete(t *testing.T, err error) {
+	_, ok := err.(*StepIncompleteError)
+	assert.True(t, ok, "expected a StepIncompleteError, got %q", err)
+}
+
+func TestRecordExecution(t *testing.T) {
+	acct := warmingUpAccount(account.WarmUpSteps...)
+	acctSvc, _, _, _ := setup(t, acct)
+
+	err := handler(context.TODO(), workflowInput{
+		Step:         StepRecordExecution,
+		ExecutionArn: "arn:aws:states:us-east-1:123456789012:execution:account-warm-up:abc",
+		Event:        accountEvent,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "arn:aws:states:us-east-1:123456789012:execution:account-warm-up:abc", *acct.WarmUp.ExecutionArn)
+	acctSvc.AssertCalled(t, "Save", acct)
+}
+
+func TestCreatePrincipalRole(t *testing.T) {
+	acct := warmingUpAccount(account.WarmUpStepCreatePrincipalRole)
+	acctSvc, _, _, _ := setup(t, acct)
+	acctSvc.On("UpsertPrincipalAccess", acct).Return(nil)
+
+	err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepCreatePrincipalRole, Event: accountEvent})
+	require.Nil(t, err)
+	assert.Equal(t, account.WarmUpStatusSucceeded, acct.WarmUp.Step(account.WarmUpStepCreatePrincipalRole).Status)
+
+	t.Run("should not run steps which already succeeded", func(t *testing.T) {
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepCreatePrincipalRole, Event: accountEvent})
+		require.Nil(t, err)
+		acctSvc.AssertNumberOfCalls(t, "UpsertPrincipalAccess", 1)
+	})
+
+	t.Run("should leave failed steps in progress for the state machine to retry", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepCreatePrincipalRole)
+		acctSvc, _, _, _ := setup(t, acct)
+		acctSvc.On("UpsertPrincipalAccess", acct).Return(errors.NewInternalServer("failure", nil))
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepCreatePrincipalRole, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewInternalServer("failure", nil)))
+		assert.Equal(t, account.WarmUpStatusInProgress, acct.WarmUp.Step(account.WarmUpStepCreatePrincipalRole).Status)
+	})
+}
+
+func TestApplyBaseline(t *testing.T) {
+	t.Run("should succeed without a baseline template", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepApplyBaseline)
+		setup(t, acct)
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		require.Nil(t, err)
+		step := acct.WarmUp.Step(account.WarmUpStepApplyBaseline)
+		assert.Equal(t, account.WarmUpStatusSucceeded, step.Status)
+		assert.Equal(t, "no baseline template is configured", step.Message)
+	})
+
+	t.Run("should create the baseline stack, and wait for it", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepApplyBaseline)
+		_, _, storager, cfnSvc := setup(t, acct)
+		settings.BaselineS3Key = "baseline.yml"
+		storager.On("GetObject", "artifacts", "baseline.yml").Return("template", nil)
+		cfnSvc.On("CreateStack", mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return *input.StackName == "dce-baseline" && *input.TemplateBody == "template"
+		})).Return(&cloudformation.CreateStackOutput{}, nil)
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		assertIncomplete(t, err)
+		assert.Equal(t, messageApplyingBaseline, acct.WarmUp.Step(account.WarmUpStepApplyBaseline).Message)
+
+		cfnSvc.On("DescribeStacks", mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []*cloudformation.Stack{{StackStatus: aws.String(cloudformation.StackStatusCreateInProgress)}},
+		}, nil).Once()
+		err = handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		assertIncomplete(t, err)
+
+		cfnSvc.On("DescribeStacks", mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []*cloudformation.Stack{{StackId: aws.String("stack"), StackStatus: aws.String(cloudformation.StackStatusCreateComplete)}},
+		}, nil).Once()
+		err = handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		require.Nil(t, err)
+		assert.Equal(t, account.WarmUpStatusSucceeded, acct.WarmUp.Step(account.WarmUpStepApplyBaseline).Status)
+		cfnSvc.AssertNumberOfCalls(t, "CreateStack", 1)
+	})
+
+	t.Run("should update an existing baseline stack", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepApplyBaseline)
+		_, _, storager, cfnSvc := setup(t, acct)
+		settings.BaselineS3Key = "baseline.yml"
+		storager.On("GetObject", "artifacts", "baseline.yml").Return("template", nil)
+		cfnSvc.On("CreateStack", mock.Anything).
+			Return(nil, awserr.New(cloudformation.ErrCodeAlreadyExistsException, "exists", nil))
+		cfnSvc.On("UpdateStack", mock.Anything).
+			Return(nil, awserr.New("ValidationError", "No updates are to be performed.", nil))
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		assertIncomplete(t, err)
+		cfnSvc.AssertCalled(t, "UpdateStack", mock.Anything)
+	})
+
+	t.Run("should fail when the baseline stack rolls back", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepApplyBaseline)
+		acct.WarmUp.SetStep(account.WarmUpStepApplyBaseline, account.WarmUpStatusInProgress, messageApplyingBaseline, 0)
+		_, _, _, cfnSvc := setup(t, acct)
+		settings.BaselineS3Key = "baseline.yml"
+		cfnSvc.On("DescribeStacks", mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []*cloudformation.Stack{{
+				StackStatus:       aws.String(cloudformation.StackStatusRollbackInProgress),
+				StackStatusReason: aws.String("bucket exists"),
+			}},
+		}, nil)
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewConflict("account", "123456789012",
+			fmt.Errorf("baseline stack \"dce-baseline\" is ROLLBACK_IN_PROGRESS: bucket exists"))), "%q", err)
+	})
+}
+
+func TestInitialReset(t *testing.T) {
+	acct := warmingUpAccount(account.WarmUpStepInitialReset)
+	acctSvc, _, _, _ := setup(t, acct)
+	acctSvc.On("Reset", "123456789012").Return(acct, nil)
+
+	// The reset is queued once, and the step waits until it finishes
+	err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepInitialReset, Event: accountEvent})
+	assertIncomplete(t, err)
+	err = handler(context.TODO(), workflowInput{Step: account.WarmUpStepInitialReset, Event: accountEvent})
+	assertIncomplete(t, err)
+	acctSvc.AssertNumberOfCalls(t, "Reset", 1)
+	assert.Equal(t, messageResetQueued, acct.WarmUp.Step(account.WarmUpStepInitialReset).Message)
+
+	acct.WarmUp.ResetCompletedOn = aws.Int64(1)
+	err = handler(context.TODO(), workflowInput{Step: account.WarmUpStepInitialReset, Event: accountEvent})
+	require.Nil(t, err)
+	assert.Equal(t, account.WarmUpStatusSucceeded, acct.WarmUp.Step(account.WarmUpStepInitialReset).Status)
+}
+
+func TestVerifyHealth(t *testing.T) {
+	t.Run("should succeed when the account is accessible", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepVerifyHealth)
+		_, managerSvc, _, _ := setup(t, acct)
+		managerSvc.On("ValidateAccess", acct.AdminRoleArn).Return(nil)
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepVerifyHealth, Event: accountEvent})
+		require.Nil(t, err)
+	})
+
+	t.Run("should fail without a principal role", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepVerifyHealth)
+		acct.PrincipalPolicyHash = nil
+		_, managerSvc, _, _ := setup(t, acct)
+		managerSvc.On("ValidateAccess", acct.AdminRoleArn).Return(nil)
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepVerifyHealth, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewConflict("account", "123456789012",
+			fmt.Errorf("principal role and policy haven't been created"))))
+	})
+}
+
+func TestComplete(t *testing.T) {
+	acct := warmingUpAccount(account.WarmUpStepVerifyHealth)
+	acct.WarmUp.SetStep(account.WarmUpStepVerifyHealth, account.WarmUpStatusSucceeded, "", 0)
+	setup(t, acct)
+
+	// Waits for the reset queued when the account was added
+	err := handler(context.TODO(), workflowInput{Step: StepComplete, Event: accountEvent})
+	assertIncomplete(t, err)
+	assert.Equal(t, account.StatusNotReady, *acct.Status)
+
+	acct.WarmUp.ResetCompletedOn = aws.Int64(1)
+	err = handler(context.TODO(), workflowInput{Step: StepComplete, Event: accountEvent})
+	require.Nil(t, err)
+	assert.Equal(t, account.StatusReady, *acct.Status)
+	assert.Equal(t, account.WarmUpStatusSucceeded, acct.WarmUp.Status)
+
+	t.Run("should not make the account ready when a step didn't succeed", func(t *testing.T) {
+		acct := warmingUpAccount(account.WarmUpStepVerifyHealth)
+		acct.WarmUp.ResetCompletedOn = aws.Int64(1)
+		setup(t, acct)
+
+		err := handler(context.TODO(), workflowInput{Step: StepComplete, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewConflict("account", "123456789012",
+			fmt.Errorf("not every warm-up step succeeded"))))
+		assert.Equal(t, account.StatusNotReady, *acct.Status)
+	})
+}
+
+func TestRecordFailure(t *testing.T) {
+	acct := warmingUpAccount(account.WarmUpSteps...)
+	acct.WarmUp.SetStep(account.WarmUpStepApplyBaseline, account.WarmUpStatusInProgress, messageApplyingBaseline, 0)
+	setup(t, acct)
+
+	err := handler(context.TODO(), workflowInput{
+		Step:  StepRecordFailure,
+		Event: accountEvent,
+		Error: &workflowError{Error: "StepIncompleteError", Cause: "waiting for the baseline stack"},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, account.WarmUpStatusFailed, acct.WarmUp.Status)
+	step := acct.WarmUp.Step(account.WarmUpStepApplyBaseline)
+	assert.Equal(t, account.WarmUpStatusFailed, step.Status)
+	assert.Equal(t, "StepIncompleteError: waiting for the baseline stack", step.Message)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	t.Run("should fail on steps which aren't configured", func(t *testing.T) {
+		setup(t, warmingUpAccount(account.WarmUpStepVerifyHealth))
+
+		err := handler(context.TODO(), workflowInput{Step: account.WarmUpStepApplyBaseline, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewBadRequest("unknown step \"ApplyBaseline\"")))
+	})
+
+	t.Run("should fail on accounts which aren't warming up", func(t *testing.T) {
+		acct := warmingUpAccount()
+		acct.WarmUp = nil
+		setup(t, acct)
+
+		err := handler(context.TODO(), workflowInput{Step: StepComplete, Event: accountEvent})
+		assert.True(t, errors.Is(err, errors.NewConflict("account", "123456789012", fmt.Errorf("account isn't warming up"))))
+	})
+}
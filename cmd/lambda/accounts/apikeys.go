@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/apikey"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+// ListAPIKeys - Returns the API keys, without their secrets
+func ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := Services.APIKeyService().List()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, keys)
+}
+
+// GetAPIKey - Returns an API key by ID, without its secret
+func GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	key, err := Services.APIKeyService().Get(mux.Vars(r)["keyId"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, key)
+}
+
+// CreateAPIKey - Adds an API key with scopes.  The key is only returned here.
+func CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	data := &apikey.APIKey{}
+	err := json.NewDecoder(r.Body).Decode(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	key, err := Services.APIKeyService().Create(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusCreated, key)
+}
+
+// DeleteAPIKey - Revokes an API key
+func DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	key, err := Services.APIKeyService().Delete(mux.Vars(r)["keyId"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, key)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/apikey"
+	"github.com/Optum/dce/pkg/apikey/apikeyiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenAPIKeys(t *testing.T) {
+	key := &apikey.APIKey{
+		ID:        "key-1",
+		Name:      "ci",
+		Scopes:    []apikey.Scope{apikey.ScopeLeasesWrite},
+		CreatedOn: 100,
+	}
+	created := *key
+	created.Key = "key-1.secret"
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		setup     func(apiKeySvc *mocks.Servicer)
+		expStatus int
+		expKey    *apikey.APIKey
+	}{
+		{
+			name:   "When listing. Then the keys are returned.",
+			method: http.MethodGet,
+			path:   "/system/api-keys",
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("List").Return([]*apikey.APIKey{key}, nil)
+			},
+			expStatus: http.StatusOK,
+		},
+		{
+			name:   "When getting a key. Then it's returned.",
+			method: http.MethodGet,
+			path:   "/system/api-keys/key-1",
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("Get", "key-1").Return(key, nil)
+			},
+			expStatus: http.StatusOK,
+			expKey:    key,
+		},
+		{
+			name:   "When creating a key. Then it's returned with its secret.",
+			method: http.MethodPost,
+			path:   "/system/api-keys",
+			body:   `{"name": "ci", "scopes": ["leases:write"]}`,
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("Create", &apikey.APIKey{
+					Name:   "ci",
+					Scopes: []apikey.Scope{apikey.ScopeLeasesWrite},
+				}).Return(&created, nil)
+			},
+			expStatus: http.StatusCreated,
+			expKey:    &created,
+		},
+		{
+			name:   "When creating an invalid key. Then a bad request is returned.",
+			method: http.MethodPost,
+			path:   "/system/api-keys",
+			body:   `{"name": "ci", "scopes": ["usage:write"]}`,
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("Create", mock.Anything).Return(nil, errors.NewValidation("api key", fmt.Errorf("scopes: invalid.")))
+			},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "When the body is invalid. Then a bad request is returned.",
+			method:    http.MethodPost,
+			path:      "/system/api-keys",
+			body:      `{"scopes": "leases:write"}`,
+			setup:     func(apiKeySvc *mocks.Servicer) {},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "When revoking a key. Then it's deleted.",
+			method: http.MethodDelete,
+			path:   "/system/api-keys/key-1",
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("Delete", "key-1").Return(key, nil)
+			},
+			expStatus: http.StatusOK,
+			expKey:    key,
+		},
+		{
+			name:   "When revoking a missing key. Then not found is returned.",
+			method: http.MethodDelete,
+			path:   "/system/api-keys/missing",
+			setup: func(apiKeySvc *mocks.Servicer) {
+				apiKeySvc.On("Delete", "missing").Return(nil, errors.NewNotFound("api key", "missing"))
+			},
+			expStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			apiKeySvc := &mocks.Servicer{}
+			tt.setup(apiKeySvc)
+
+			svcBldr.Config.WithService(apiKeySvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: tt.method,
+				Path:       tt.path,
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			apiKeySvc.AssertExpectations(t)
+			if tt.expKey == nil {
+				return
+			}
+
+			body := &apikey.APIKey{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expKey, body)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+type acknowledgeBlackoutRequest struct {
+	AcknowledgedBy string `json:"acknowledgedBy"`
+}
+
+// AcknowledgeBlackout acknowledges the activity found while an account was
+// reset, and resets it again, so it can become Ready
+func AcknowledgeBlackout(w http.ResponseWriter, r *http.Request) {
+	accountID := mux.Vars(r)["accountId"]
+
+	// The request body is optional
+	req := &acknowledgeBlackoutRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil && err != io.EOF {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	account, err := Services.AccountService().AcknowledgeBlackout(accountID, req.AcknowledgedBy)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, account)
+}
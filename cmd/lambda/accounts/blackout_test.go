@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcknowledgeBlackout(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name              string
+		expResp           response
+		accountID         string
+		reqBody           string
+		expAcknowledgedBy string
+		retAccount        *account.Account
+		retErr            error
+	}{
+		{
+			name:              "success",
+			accountID:         "123456789012",
+			reqBody:           "{\"acknowledgedBy\": \"jdoe\"}",
+			expAcknowledgedBy: "jdoe",
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"id\":\"123456789012\"}\n",
+			},
+			retAccount: &account.Account{ID: ptrString("123456789012")},
+		},
+		{
+			name:      "success without body",
+			accountID: "123456789012",
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"id\":\"123456789012\"}\n",
+			},
+			retAccount: &account.Account{ID: ptrString("123456789012")},
+		},
+		{
+			name:      "no blackout",
+			accountID: "123456789012",
+			expResp: response{
+				StatusCode: 409,
+				Body:       "{\"error\":{\"message\":\"operation cannot be fulfilled on account \\\"123456789012\\\": no blackout\",\"code\":\"ConflictError\"}}\n",
+			},
+			retErr: errors.NewConflict("account", "123456789012", fmt.Errorf("no blackout")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST",
+				fmt.Sprintf("http://example.com/accounts/%s/blackout/acknowledge", tt.accountID),
+				strings.NewReader(tt.reqBody))
+
+			r = mux.SetURLVars(r, map[string]string{
+				"accountId": tt.accountID,
+			})
+			w := httptest.NewRecorder()
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("AcknowledgeBlackout", tt.accountID, tt.expAcknowledgedBy).Return(
+				tt.retAccount, tt.retErr,
+			)
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			AcknowledgeBlackout(w, r)
+
+			resp := w.Result()
+			body, err := ioutil.ReadAll(resp.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expResp.Body, string(body))
+		})
+	}
+}
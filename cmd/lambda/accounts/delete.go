@@ -1,23 +1,57 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/api"
 	"github.com/gorilla/mux"
 )
 
+// deleteAccountDryRun is returned instead of deleting an account, when
+// deleting it is a dry run
+type deleteAccountDryRun struct {
+	DryRun  bool             `json:"dryRun"`
+	Account *account.Account `json:"account"`
+	Actions []string         `json:"actions"`
+}
+
 // DeleteAccount - Deletes the account
 func DeleteAccount(w http.ResponseWriter, r *http.Request) {
 
 	accountID := mux.Vars(r)["accountId"]
 
+	dryRun, err := api.ParseDryRun(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
 	acct, err := Services.AccountService().Get(accountID)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
 
+	if dryRun {
+		err = Services.AccountService().ValidateDelete(acct)
+		if err != nil {
+			api.WriteAPIErrorResponse(w, err)
+			return
+		}
+		api.WriteAPIResponse(w, http.StatusOK, deleteAccountDryRun{
+			DryRun:  true,
+			Account: acct,
+			Actions: []string{
+				fmt.Sprintf("Remove account %s from the account pool", accountID),
+				fmt.Sprintf("Delete the principal role %s and its policy", acct.PrincipalRoleArn.String()),
+				fmt.Sprintf("Reset account %s", accountID),
+			},
+		})
+		return
+	}
+
 	err = Services.AccountService().Delete(acct)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
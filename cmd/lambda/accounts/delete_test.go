@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/Optum/dce/pkg/account/accountiface/mocks"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/testutil"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -109,3 +111,64 @@ func TestWhenDelete(t *testing.T) {
 	}
 
 }
+
+func TestWhenDeleteDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      string
+		validateErr error
+		expStatus   int
+		expBody     string
+	}{
+		{
+			name:      "When the account can be deleted. Then what would happen is returned.",
+			dryRun:    "true",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:        "When the account is leased. Then a conflict error is returned.",
+			dryRun:      "true",
+			validateErr: errors.NewConflict("account", "123456789012", fmt.Errorf("status: must not be leased")),
+			expStatus:   http.StatusConflict,
+			expBody:     "{\"error\":{\"message\":\"operation cannot be fulfilled on account \\\"123456789012\\\": status: must not be leased\",\"code\":\"ConflictError\"}}\n",
+		},
+		{
+			name:      "When dryRun is invalid. Then a bad request error is returned.",
+			dryRun:    "maybe",
+			expStatus: http.StatusBadRequest,
+			expBody:   "{\"error\":{\"message\":\"invalid value for dryRun: \\\"maybe\\\"\",\"code\":\"ClientError\"}}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acct := testutil.NewAccountBuilder().Build()
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("Get", testutil.DefaultAccountID).Return(acct, nil)
+			accountSvc.On("ValidateDelete", acct).Return(tt.validateErr)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodDelete,
+				Path:                  "/accounts/" + testutil.DefaultAccountID,
+				QueryStringParameters: map[string]string{"dryRun": tt.dryRun},
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expBody != "" {
+				assert.Equal(t, tt.expBody, resp.Body)
+			} else {
+				assert.Contains(t, resp.Body, "\"dryRun\":true")
+				assert.Contains(t, resp.Body, "Reset account 123456789012")
+			}
+			accountSvc.AssertNotCalled(t, "Delete", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/identity"
+)
+
+// bootstrapIdentitiesResponse is returned by the identity bootstrap endpoint
+type bootstrapIdentitiesResponse struct {
+	Identities []*identity.Provisioned `json:"identities"`
+}
+
+// BootstrapIdentities - Creates the Cognito users and groups, and maps the
+// IAM roles, which the API's authorization expects.  Identities which already
+// exist are linked instead, so it's safe to call again.
+func BootstrapIdentities(w http.ResponseWriter, r *http.Request) {
+	input := &identity.Bootstrap{}
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	provisioned, err := Services.IdentityService().Bootstrap(input)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, bootstrapIdentitiesResponse{Identities: provisioned})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/identity"
+	"github.com/Optum/dce/pkg/identity/identityiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenBootstrapIdentities(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		provisioned    []*identity.Provisioned
+		bootstrapErr   error
+		expStatus      int
+		expBootstrap   *identity.Bootstrap
+		expIdentities  []*identity.Provisioned
+		expNoBootstrap bool
+	}{
+		{
+			name: "When users are given. Then they're provisioned.",
+			body: `{ "users": [{ "username": "jdoe", "email": "jdoe@example.com", "role": "Admin" }] }`,
+			provisioned: []*identity.Provisioned{
+				{Kind: identity.KindUser, Name: "jdoe", Action: identity.ActionCreated},
+			},
+			expStatus: http.StatusOK,
+			expBootstrap: &identity.Bootstrap{
+				Users: []identity.User{{Username: "jdoe", Email: "jdoe@example.com", Role: "Admin"}},
+			},
+			expIdentities: []*identity.Provisioned{
+				{Kind: identity.KindUser, Name: "jdoe", Action: identity.ActionCreated},
+			},
+		},
+		{
+			name:         "When the identities are invalid. Then a bad request is returned.",
+			body:         `{ "users": [{ "username": "jdoe", "role": "Owner" }] }`,
+			bootstrapErr: errors.NewValidation("identity", nil),
+			expStatus:    http.StatusBadRequest,
+			expBootstrap: &identity.Bootstrap{
+				Users: []identity.User{{Username: "jdoe", Role: "Owner"}},
+			},
+		},
+		{
+			name:           "When the body isn't JSON. Then a bad request is returned.",
+			body:           `users`,
+			expStatus:      http.StatusBadRequest,
+			expNoBootstrap: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			identitySvc := mocks.Servicer{}
+			identitySvc.On("Bootstrap", mock.Anything).Return(tt.provisioned, tt.bootstrapErr)
+			svcBldr.Config.WithService(&identitySvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/system/identities",
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expNoBootstrap {
+				identitySvc.AssertNotCalled(t, "Bootstrap", mock.Anything)
+				return
+			}
+			identitySvc.AssertCalled(t, "Bootstrap", tt.expBootstrap)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			res := bootstrapIdentitiesResponse{}
+			err = json.Unmarshal([]byte(resp.Body), &res)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expIdentities, res.Identities)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/errors"
+)
+
+const (
+	importStatusCreated = "Created"
+	importStatusValid   = "Valid"
+	importStatusFailed  = "Failed"
+)
+
+// importAccountsRequest is the JSON body accepted by the import endpoint
+type importAccountsRequest struct {
+	Accounts []*account.Account `json:"accounts"`
+}
+
+// importAccountResult is the outcome of importing a single account
+type importAccountResult struct {
+	Row     int              `json:"row"`
+	ID      *string          `json:"id"`
+	Status  string           `json:"status"`
+	Error   string           `json:"error,omitempty"`
+	Account *account.Account `json:"account,omitempty"`
+}
+
+// importAccountsResponse is returned by the import endpoint
+type importAccountsResponse struct {
+	DryRun  bool                   `json:"dryRun"`
+	Created int                    `json:"created"`
+	Failed  int                    `json:"failed"`
+	Results []*importAccountResult `json:"results"`
+}
+
+// ImportAccounts - Creates many accounts from a single request. Each account
+// is handled independently, and the result of each is returned in the response.
+// Accepts either a JSON body or a CSV body with "id" and "adminRoleArn" columns.
+func ImportAccounts(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := api.ParseDryRun(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	var accounts []*account.Account
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		accounts, err = parseAccountsCSV(r.Body)
+	} else {
+		req := importAccountsRequest{}
+		err = json.NewDecoder(r.Body).Decode(&req)
+		accounts = req.Accounts
+	}
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest(fmt.Sprintf("invalid request parameters: %s", err)))
+		return
+	}
+
+	if len(accounts) == 0 {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("at least one account is required"))
+		return
+	}
+	if len(accounts) > Settings.ImportMaxAccounts {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest(fmt.Sprintf("a maximum of %d accounts may be imported per request", Settings.ImportMaxAccounts)))
+		return
+	}
+
+	res := importAccountsResponse{
+		DryRun:  dryRun,
+		Results: []*importAccountResult{},
+	}
+	seen := map[string]bool{}
+	for i, a := range accounts {
+		result := importAccount(a, dryRun, seen)
+		result.Row = i + 1
+		if result.Status == importStatusFailed {
+			res.Failed++
+		} else if result.Status == importStatusCreated {
+			res.Created++
+		}
+		res.Results = append(res.Results, result)
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, res)
+}
+
+func importAccount(a *account.Account, dryRun bool, seen map[string]bool) *importAccountResult {
+	if a == nil {
+		return &importAccountResult{
+			Status: importStatusFailed,
+			Error:  "account must not be empty",
+		}
+	}
+
+	result := &importAccountResult{ID: a.ID}
+	if a.ID != nil {
+		if seen[*a.ID] {
+			result.Status = importStatusFailed
+			result.Error = fmt.Sprintf("account %q is duplicated in the request", *a.ID)
+			return result
+		}
+		seen[*a.ID] = true
+	}
+
+	if dryRun {
+		err := Services.AccountService().ValidateCreate(a)
+		if err != nil {
+			result.Status = importStatusFailed
+			result.Error = err.Error()
+			return result
+		}
+		result.Status = importStatusValid
+		return result
+	}
+
+	created, err := Services.AccountService().Create(a)
+	if err != nil {
+		result.Status = importStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = importStatusCreated
+	result.Account = created
+	return result
+}
+
+// parseAccountsCSV reads accounts from a CSV document. The first row must be a
+// header containing at least the "id" and "adminRoleArn" columns.
+func parseAccountsCSV(body io.Reader) ([]*account.Account, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %s", err)
+	}
+	idCol, roleCol := -1, -1
+	for i, h := range header {
+		switch strings.TrimSpace(h) {
+		case "id":
+			idCol = i
+		case "adminRoleArn":
+			roleCol = i
+		}
+	}
+	if idCol < 0 || roleCol < 0 {
+		return nil, fmt.Errorf("CSV header must contain \"id\" and \"adminRoleArn\" columns")
+	}
+
+	accounts := []*account.Account{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CSV: %s", err)
+		}
+
+		a := &account.Account{}
+		if id := strings.TrimSpace(record[idCol]); id != "" {
+			a.ID = &id
+		}
+		if role := strings.TrimSpace(record[roleCol]); role != "" {
+			roleArn, err := arn.NewFromArn(role)
+			if err != nil {
+				return nil, fmt.Errorf("invalid adminRoleArn %q: %s", role, err)
+			}
+			a.AdminRoleArn = roleArn
+		}
+		accounts = append(accounts, a)
+	}
+
+	return accounts, nil
+}
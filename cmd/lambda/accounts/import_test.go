@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenImport(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		request       events.APIGatewayProxyRequest
+		expStatus     int
+		expResults    []string
+		createErr     error
+		validateErr   error
+		expCreateCall bool
+	}{
+		{
+			name: "When given a JSON body. Then each account is created.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Body:       `{ "accounts": [{ "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }, { "id": "210987654321", "adminRoleArn": "arn:aws:iam::210987654321:role/AdminRoleArn" }] }`,
+			},
+			expStatus:     http.StatusOK,
+			expResults:    []string{importStatusCreated, importStatusCreated},
+			expCreateCall: true,
+		},
+		{
+			name: "When given a CSV body. Then each account is created.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Headers:    map[string]string{"Content-Type": "text/csv"},
+				Body:       "id,adminRoleArn\n123456789012,arn:aws:iam::123456789012:role/AdminRoleArn\n",
+			},
+			expStatus:     http.StatusOK,
+			expResults:    []string{importStatusCreated},
+			expCreateCall: true,
+		},
+		{
+			name: "When dry run is requested. Then accounts are only validated.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodPost,
+				Path:                  "/accounts/import",
+				QueryStringParameters: map[string]string{"dryRun": "true"},
+				Body:                  `{ "accounts": [{ "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }] }`,
+			},
+			expStatus:  http.StatusOK,
+			expResults: []string{importStatusValid},
+		},
+		{
+			name: "When dry run validation fails. Then the row is reported as failed.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodPost,
+				Path:                  "/accounts/import",
+				QueryStringParameters: map[string]string{"dryRun": "true"},
+				Body:                  `{ "accounts": [{ "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }] }`,
+			},
+			validateErr: errors.NewAlreadyExists("account", "123456789012"),
+			expStatus:   http.StatusOK,
+			expResults:  []string{importStatusFailed},
+		},
+		{
+			name: "When an account is duplicated. Then only the first is created.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Body:       `{ "accounts": [{ "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }, { "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }] }`,
+			},
+			expStatus:     http.StatusOK,
+			expResults:    []string{importStatusCreated, importStatusFailed},
+			expCreateCall: true,
+		},
+		{
+			name: "When create fails. Then the row is reported as failed.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Body:       `{ "accounts": [{ "id": "123456789012", "adminRoleArn": "arn:aws:iam::123456789012:role/AdminRoleArn" }] }`,
+			},
+			createErr:     errors.NewInternalServer("failure", nil),
+			expStatus:     http.StatusOK,
+			expResults:    []string{importStatusFailed},
+			expCreateCall: true,
+		},
+		{
+			name: "When no accounts are given. Then a bad request is returned.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Body:       `{ "accounts": [] }`,
+			},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name: "When the CSV is missing columns. Then a bad request is returned.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/accounts/import",
+				Headers:    map[string]string{"Content-Type": "text/csv"},
+				Body:       "id\n123456789012\n",
+			},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name: "When dry run is not a boolean. Then a bad request is returned.",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodPost,
+				Path:                  "/accounts/import",
+				QueryStringParameters: map[string]string{"dryRun": "maybe"},
+				Body:                  `{ "accounts": [{ "id": "123456789012" }] }`,
+			},
+			expStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("Create", mock.AnythingOfType("*account.Account")).Return(
+				&account.Account{}, tt.createErr,
+			)
+			accountSvc.On("ValidateCreate", mock.AnythingOfType("*account.Account")).Return(tt.validateErr)
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), tt.request)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			res := importAccountsResponse{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&res)
+			assert.Nil(t, err)
+			statuses := []string{}
+			for _, r := range res.Results {
+				statuses = append(statuses, r.Status)
+			}
+			assert.Equal(t, tt.expResults, statuses)
+			if tt.expCreateCall {
+				accountSvc.AssertCalled(t, "Create", mock.AnythingOfType("*account.Account"))
+			} else {
+				accountSvc.AssertNotCalled(t, "Create", mock.AnythingOfType("*account.Account"))
+			}
+		})
+	}
+
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/api/response"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/gorilla/schema"
 )
 
@@ -14,20 +17,61 @@ import (
 func GetAccounts(w http.ResponseWriter, r *http.Request) {
 	// Fetch the accounts.
 
+	format, err := api.ExportFormat(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
 	var decoder = schema.NewDecoder()
 
 	query := &account.Account{}
-	err := decoder.Decode(query, r.URL.Query())
+	err = decoder.Decode(query, r.URL.Query())
 	if err != nil {
 		response.WriteRequestValidationError(w, fmt.Sprintf("Error parsing query params: %s", err))
 		return
 	}
 
-	accounts, err := Services.AccountService().List(query)
+	if format != "" {
+		exportAccounts(w, query, format)
+		return
+	}
+
+	// Accounts are encoded a page at a time, so large limits don't hold
+	// every account in memory. The body is buffered because the Link header
+	// depends on the last page.
+	body := &bytes.Buffer{}
+	stream := api.NewJSONArrayWriter(body)
+	limit := query.Limit
+	var lastModifiedOn int64
+	err = api.ReadPages(aws.Int64Value(limit), func(pageLimit int64) (bool, error) {
+		if pageLimit > 0 {
+			query.Limit = &pageLimit
+		}
+		accounts, err := Services.AccountService().List(query)
+		if err != nil || accounts == nil {
+			return false, err
+		}
+		for _, a := range *accounts {
+			if err := stream.Write(a); err != nil {
+				return false, err
+			}
+			if aws.Int64Value(a.LastModifiedOn) > lastModifiedOn {
+				lastModifiedOn = *a.LastModifiedOn
+			}
+		}
+		return query.NextID != nil, nil
+	})
+	if err == nil {
+		err = stream.Close()
+	}
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
+	if limit != nil {
+		query.Limit = limit
+	}
 
 	if query.NextID != nil {
 		nextURL, err := api.BuildNextURL(baseRequest, query)
@@ -37,6 +81,46 @@ func GetAccounts(w http.ResponseWriter, r *http.Request) {
 		}
 		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextURL.String()))
 	}
-	api.WriteAPIResponse(w, http.StatusOK, accounts)
+	api.SetLastModified(w, lastModifiedOn)
+	w.WriteHeader(http.StatusOK)
+	_, err = body.WriteTo(w)
+	if err != nil {
+		log.Printf("error writing accounts: %s", err)
+	}
+}
+
+// accountExportColumns are the columns of CSV exports of accounts
+var accountExportColumns = []string{
+	"id",
+	"accountStatus",
+	"adminRoleArn",
+	"principalRoleArn",
+	"readyAt",
+	"lastLeasedBy",
+	"createdOn",
+	"lastModifiedOn",
+	"metadata",
+}
 
+// exportAccounts writes every account matching the query, rather than a page
+// of them, as CSV or JSON
+func exportAccounts(w http.ResponseWriter, query *account.Account, format string) {
+	query.Limit = aws.Int64(api.StreamPageSize)
+	query.NextID = nil
+	Exporter.Export(w, "accounts", format, accountExportColumns, func(write func(item interface{}) error) error {
+		var writeErr error
+		err := Services.AccountService().ListPages(query, func(accounts *account.Accounts) bool {
+			for _, a := range *accounts {
+				writeErr = write(a)
+				if writeErr != nil {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return writeErr
+	})
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/gorilla/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -123,3 +125,78 @@ func TestGetAccounts(t *testing.T) {
 	}
 
 }
+
+func TestGetAccountsInPages(t *testing.T) {
+	defer func(size int64) { api.StreamPageSize = size }(api.StreamPageSize)
+	api.StreamPageSize = 2
+
+	r := httptest.NewRequest("GET", "http://example.com/accounts?limit=5", nil)
+	baseRequest = url.URL{Scheme: "https", Host: "example.com", Path: "unit/accounts"}
+	w := httptest.NewRecorder()
+
+	pages := map[string]*account.Accounts{
+		"":             {{ID: ptrString("111111111111")}, {ID: ptrString("222222222222")}},
+		"222222222222": {{ID: ptrString("333333333333")}, {ID: ptrString("444444444444")}},
+		"444444444444": {{ID: ptrString("555555555555")}},
+	}
+	limits := []int64{}
+	accountSvc := mocks.Servicer{}
+	accountSvc.On("List", mock.Anything).Return(func(query *account.Account) *account.Accounts {
+		limits = append(limits, *query.Limit)
+		page := pages[aws.StringValue(query.NextID)]
+		query.NextID = (*page)[len(*page)-1].ID
+		return page
+	}, nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(&accountSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	Services = svcBldr
+
+	GetAccounts(w, r)
+
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "[{\"id\":\"111111111111\"},{\"id\":\"222222222222\"},{\"id\":\"333333333333\"},{\"id\":\"444444444444\"},{\"id\":\"555555555555\"}]\n", string(body))
+	assert.Equal(t, []int64{2, 2, 1}, limits)
+	assert.Equal(t, "<https://example.com/unit/accounts?limit=5&nextId=555555555555>; rel=\"next\"", w.Header().Get("Link"))
+}
+
+func TestExportAccounts(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/accounts?format=csv&status=Ready&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	accountSvc := mocks.Servicer{}
+	accountSvc.On("ListPages", mock.MatchedBy(func(query *account.Account) bool {
+		return query.Status.String() == "Ready" && *query.Limit == api.StreamPageSize
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*account.Accounts) bool)
+			fn(&account.Accounts{{ID: ptrString("111111111111"), Status: account.StatusReady.StatusPtr()}})
+			fn(&account.Accounts{{ID: ptrString("222222222222"), Status: account.StatusReady.StatusPtr(),
+				Metadata: map[string]interface{}{"pool": "ml"}}})
+		}).
+		Return(nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(&accountSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	Services = svcBldr
+	Exporter = &api.Exporter{MaxInlineBytes: 1000}
+
+	GetAccounts(w, r)
+
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id,accountStatus,adminRoleArn,principalRoleArn,readyAt,lastLeasedBy,createdOn,lastModifiedOn,metadata\n"+
+		"111111111111,Ready,,,,,,,\n"+
+		"222222222222,Ready,,,,,,,\"{\"\"pool\"\":\"\"ml\"\"}\"\n", string(body))
+	assert.Empty(t, w.Header().Get("Link"))
+}
@@ -30,6 +30,11 @@ type accountControllerConfiguration struct {
 	Tags                        []*iam.Tag
 	ResetQueueURL               string   `env:"RESET_SQS_URL" envDefault:"DefaultResetSQSUrl"`
 	AllowedRegions              []string `env:"ALLOWED_REGIONS" envDefault:"us-east-1"`
+	ImportMaxAccounts           int      `env:"IMPORT_MAX_ACCOUNTS" envDefault:"20"`
+	ResetStuckThresholdMinutes  int      `env:"RESET_STUCK_THRESHOLD_MINUTES" envDefault:"120"`
+	ResetEventSourceMappingID   string   `env:"RESET_EVENT_SOURCE_MAPPING_ID" envDefault:""`
+	SimulationResetHours        float64  `env:"POOL_SIMULATION_RESET_HOURS" envDefault:"1"`
+	SimulationResetConcurrency  int      `env:"POOL_SIMULATION_RESET_CONCURRENCY" envDefault:"20"`
 }
 
 var (
@@ -38,6 +43,12 @@ var (
 	Services *config.ServiceBuilder
 	// Settings - the configuration settings for the controller
 	Settings *accountControllerConfiguration
+	// Exporter writes the exports of the accounts list
+	Exporter *api.Exporter
+	// apiKeyMiddleware limits requests made with API keys to their scopes
+	apiKeyMiddleware api.APIKeyMiddleware
+	// apiCache adds caching headers to the accounts list and status
+	apiCache api.Cache
 )
 
 var (
@@ -57,7 +68,175 @@ func init() {
 			"GET",
 			"/accounts",
 			api.EmptyQueryString,
-			GetAccounts,
+			apiCache.Handler(GetAccounts),
+		},
+		api.Route{
+			"GetSystemStatus",
+			"GET",
+			"/system/status",
+			api.EmptyQueryString,
+			apiCache.Handler(GetSystemStatus),
+		},
+		api.Route{
+			"GetResetPipeline",
+			"GET",
+			"/system/reset",
+			api.EmptyQueryString,
+			GetResetPipeline,
+		},
+		api.Route{
+			"PauseResetPipeline",
+			"POST",
+			"/system/reset/pause",
+			api.EmptyQueryString,
+			PauseResetPipeline,
+		},
+		api.Route{
+			"ResumeResetPipeline",
+			"POST",
+			"/system/reset/resume",
+			api.EmptyQueryString,
+			ResumeResetPipeline,
+		},
+		api.Route{
+			"SimulatePool",
+			"POST",
+			"/system/simulation",
+			api.EmptyQueryString,
+			SimulatePool,
+		},
+		api.Route{
+			"ListAPIKeys",
+			"GET",
+			"/system/api-keys",
+			api.EmptyQueryString,
+			ListAPIKeys,
+		},
+		api.Route{
+			"CreateAPIKey",
+			"POST",
+			"/system/api-keys",
+			api.EmptyQueryString,
+			CreateAPIKey,
+		},
+		api.Route{
+			"GetAPIKey",
+			"GET",
+			"/system/api-keys/{keyId}",
+			api.EmptyQueryString,
+			GetAPIKey,
+		},
+		api.Route{
+			"DeleteAPIKey",
+			"DELETE",
+			"/system/api-keys/{keyId}",
+			api.EmptyQueryString,
+			DeleteAPIKey,
+		},
+		api.Route{
+			"ListNotificationRoutes",
+			"GET",
+			"/system/notification-routes",
+			api.EmptyQueryString,
+			ListNotificationRoutes,
+		},
+		api.Route{
+			"CreateNotificationRoute",
+			"POST",
+			"/system/notification-routes",
+			api.EmptyQueryString,
+			CreateNotificationRoute,
+		},
+		api.Route{
+			"GetNotificationRoute",
+			"GET",
+			"/system/notification-routes/{ruleId}",
+			api.EmptyQueryString,
+			GetNotificationRoute,
+		},
+		api.Route{
+			"UpdateNotificationRoute",
+			"PUT",
+			"/system/notification-routes/{ruleId}",
+			api.EmptyQueryString,
+			UpdateNotificationRoute,
+		},
+		api.Route{
+			"DeleteNotificationRoute",
+			"DELETE",
+			"/system/notification-routes/{ruleId}",
+			api.EmptyQueryString,
+			DeleteNotificationRoute,
+		},
+		api.Route{
+			"GetPoolContact",
+			"GET",
+			"/system/pool-contact",
+			api.EmptyQueryString,
+			GetPoolContact,
+		},
+		api.Route{
+			"UpdatePoolContact",
+			"PUT",
+			"/system/pool-contact",
+			api.EmptyQueryString,
+			UpdatePoolContact,
+		},
+		api.Route{
+			"DeletePoolContact",
+			"DELETE",
+			"/system/pool-contact",
+			api.EmptyQueryString,
+			DeletePoolContact,
+		},
+		api.Route{
+			"GetSettings",
+			"GET",
+			"/settings",
+			api.EmptyQueryString,
+			GetSettings,
+		},
+		api.Route{
+			"UpdateSettings",
+			"PUT",
+			"/settings",
+			api.EmptyQueryString,
+			UpdateSettings,
+		},
+		api.Route{
+			"BootstrapIdentities",
+			"POST",
+			"/system/identities",
+			api.EmptyQueryString,
+			BootstrapIdentities,
+		},
+		api.Route{
+			"ImportAccounts",
+			"POST",
+			"/accounts/import",
+			api.EmptyQueryString,
+			ImportAccounts,
+		},
+		api.Route{
+			"AcknowledgeBlackout",
+			"POST",
+			"/accounts/{accountId}/blackout/acknowledge",
+			api.EmptyQueryString,
+			AcknowledgeBlackout,
+		},
+		api.Route{
+			"MoveAccount",
+			"POST",
+			"/accounts/{accountId}/move",
+			api.EmptyQueryString,
+			MoveAccount,
+		},
+		api.Route{
+			"UpdateServiceQuotas",
+			"PUT",
+			"/accounts/{accountId}/quotas",
+			api.EmptyQueryString,
+			UpdateServiceQuotas,
 		},
 		api.Route{
 			"GetAccountByID",
@@ -90,6 +269,8 @@ func init() {
 	}
 	r := api.NewRouter(accountRoutes)
 	muxLambda = gorillamux.New(r)
+	apiKeyMiddleware.GorillaMuxAdapter = muxLambda
+	r.Use(apiKeyMiddleware.Middleware)
 }
 
 // initConfig configures package-level variables
@@ -100,6 +281,16 @@ func initConfig() {
 	if err := cfgBldr.Unmarshal(Settings); err != nil {
 		log.Fatalf("Could not load configuration: %s", err.Error())
 	}
+	Exporter = &api.Exporter{}
+	if err := cfgBldr.Unmarshal(Exporter); err != nil {
+		log.Fatalf("Could not load export configuration: %s", err.Error())
+	}
+	if err := cfgBldr.Unmarshal(&apiKeyMiddleware); err != nil {
+		log.Fatalf("Could not load api key configuration: %s", err.Error())
+	}
+	if err := cfgBldr.Unmarshal(&apiCache); err != nil {
+		log.Fatalf("Could not load api cache configuration: %s", err.Error())
+	}
 
 	// load up the values into the various settings...
 	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
@@ -110,10 +301,21 @@ func initConfig() {
 
 	_, err = svcBldr.
 		WithAccountService().
+		WithSQS().
+		WithLambda().
+		WithIdentityService().
+		WithRoutingService().
+		WithAPIKeyService().
+		WithSettingsService().
+		WithS3().
 		Build()
 	if err != nil {
 		panic(err)
 	}
+	if err := svcBldr.Config.GetService(&Exporter.S3); err != nil {
+		panic(err)
+	}
+	apiKeyMiddleware.APIKeyService = svcBldr.APIKeyService()
 
 	Services = svcBldr
 
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+type moveAccountRequest struct {
+	Pool                 string `json:"pool"`
+	OrganizationalUnitID string `json:"organizationalUnitId"`
+	Reason               string `json:"reason"`
+	MovedBy              string `json:"movedBy"`
+}
+
+// MoveAccount moves an account to another pool, and optionally to another
+// organizational unit, keeping its history
+func MoveAccount(w http.ResponseWriter, r *http.Request) {
+	accountID := mux.Vars(r)["accountId"]
+
+	req := &moveAccountRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	result, err := Services.AccountService().Move(accountID, &account.Move{
+		Pool:                 req.Pool,
+		OrganizationalUnitID: req.OrganizationalUnitID,
+		Reason:               req.Reason,
+		MovedBy:              req.MovedBy,
+	})
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, result)
+}
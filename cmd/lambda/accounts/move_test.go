@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMoveAccount(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name       string
+		expResp    response
+		reqBody    string
+		expMove    *account.Move
+		retAccount *account.Account
+		retErr     error
+	}{
+		{
+			name:    "success",
+			reqBody: "{\"pool\": \"gpu\", \"organizationalUnitId\": \"ou-ab12-cdef3456\", \"reason\": \"needs GPU quotas\", \"movedBy\": \"jdoe\"}",
+			expMove: &account.Move{
+				Pool:                 "gpu",
+				OrganizationalUnitID: "ou-ab12-cdef3456",
+				Reason:               "needs GPU quotas",
+				MovedBy:              "jdoe",
+			},
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"id\":\"123456789012\",\"pool\":\"gpu\"}\n",
+			},
+			retAccount: &account.Account{ID: ptrString("123456789012"), Pool: ptrString("gpu")},
+		},
+		{
+			name:    "invalid body",
+			reqBody: "{\"pool\": ",
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+		{
+			name:    "leased",
+			reqBody: "{\"pool\": \"gpu\"}",
+			expMove: &account.Move{Pool: "gpu"},
+			expResp: response{
+				StatusCode: 409,
+				Body:       "{\"error\":{\"message\":\"operation cannot be fulfilled on account \\\"123456789012\\\": must not be leased\",\"code\":\"ConflictError\"}}\n",
+			},
+			retErr: errors.NewConflict("account", "123456789012", fmt.Errorf("must not be leased")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST",
+				"http://example.com/accounts/123456789012/move",
+				strings.NewReader(tt.reqBody))
+
+			r = mux.SetURLVars(r, map[string]string{
+				"accountId": "123456789012",
+			})
+			w := httptest.NewRecorder()
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("Move", "123456789012", tt.expMove).Return(
+				tt.retAccount, tt.retErr,
+			)
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			MoveAccount(w, r)
+
+			resp := w.Result()
+			body, err := ioutil.ReadAll(resp.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expResp.Body, string(body))
+			if tt.expMove == nil {
+				accountSvc.AssertNotCalled(t, "Move", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+type updateServiceQuotasRequest struct {
+	ServiceQuotas []account.ServiceQuota `json:"serviceQuotas"`
+}
+
+// UpdateServiceQuotas replaces the service quota increases recorded for an
+// account, eg. once a quota increase is approved
+func UpdateServiceQuotas(w http.ResponseWriter, r *http.Request) {
+	accountID := mux.Vars(r)["accountId"]
+
+	req := &updateServiceQuotasRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	account, err := Services.AccountService().UpdateServiceQuotas(accountID, req.ServiceQuotas)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, account)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateServiceQuotas(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	quotas := []account.ServiceQuota{
+		{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-east-1", Value: 32},
+	}
+	tests := []struct {
+		name       string
+		expResp    response
+		reqBody    string
+		expQuotas  []account.ServiceQuota
+		retAccount *account.Account
+		retErr     error
+	}{
+		{
+			name:      "success",
+			reqBody:   "{\"serviceQuotas\": [{\"serviceCode\": \"ec2\", \"quotaCode\": \"L-417A185B\", \"region\": \"us-east-1\", \"value\": 32}]}",
+			expQuotas: quotas,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"id\":\"123456789012\",\"serviceQuotas\":[{\"serviceCode\":\"ec2\",\"quotaCode\":\"L-417A185B\",\"region\":\"us-east-1\",\"value\":32}]}\n",
+			},
+			retAccount: &account.Account{ID: ptrString("123456789012"), ServiceQuotas: quotas},
+		},
+		{
+			name:    "invalid quota",
+			reqBody: "{\"serviceQuotas\": [{\"serviceCode\": \"ec2\", \"quotaCode\": \"P instances\", \"value\": 32}]}",
+			expQuotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "P instances", Value: 32},
+			},
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"account validation error: serviceQuotas: \\\"P instances\\\" must be a quota code, eg. L-417A185B.\",\"code\":\"RequestValidationError\"}}\n",
+			},
+			retErr: errors.NewValidation("account", fmt.Errorf("serviceQuotas: \"P instances\" must be a quota code, eg. L-417A185B.")),
+		},
+		{
+			name:    "invalid body",
+			reqBody: "{\"serviceQuotas\": \"ec2\"}",
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PUT",
+				"http://example.com/accounts/123456789012/quotas",
+				strings.NewReader(tt.reqBody))
+
+			r = mux.SetURLVars(r, map[string]string{
+				"accountId": "123456789012",
+			})
+			w := httptest.NewRecorder()
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("UpdateServiceQuotas", "123456789012", tt.expQuotas).Return(
+				tt.retAccount, tt.retErr,
+			)
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			UpdateServiceQuotas(w, r)
+
+			resp := w.Result()
+			body, err := ioutil.ReadAll(resp.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expResp.Body, string(body))
+			if tt.expQuotas == nil {
+				accountSvc.AssertNotCalled(t, "UpdateServiceQuotas", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
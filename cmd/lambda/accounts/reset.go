@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/reset/pipeline"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// GetResetPipeline - Returns whether the reset pipeline is paused
+func GetResetPipeline(w http.ResponseWriter, r *http.Request) {
+	p, err := resetPipeline()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	status, err := p.Status()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	api.WriteAPIResponse(w, http.StatusOK, status)
+}
+
+// PauseResetPipeline - Stops accounts from being reset, until the pipeline is
+// resumed.  Accounts waiting to be reset are kept in the reset queue.
+func PauseResetPipeline(w http.ResponseWriter, r *http.Request) {
+	p, err := resetPipeline()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	status, err := p.Pause()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	log.Printf("Paused the reset pipeline")
+	api.WriteAPIResponse(w, http.StatusOK, status)
+}
+
+// ResumeResetPipeline - Resets the accounts waiting in the reset queue again
+func ResumeResetPipeline(w http.ResponseWriter, r *http.Request) {
+	p, err := resetPipeline()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	status, err := p.Resume()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	log.Printf("Resumed the reset pipeline")
+	api.WriteAPIResponse(w, http.StatusOK, status)
+}
+
+func resetPipeline() (*pipeline.Pipeline, error) {
+	var lambdaSvc lambdaiface.LambdaAPI
+	if err := Services.Config.GetService(&lambdaSvc); err != nil {
+		return nil, errors.NewInternalServer("unable to get the Lambda service", err)
+	}
+	return pipeline.New(lambdaSvc, Settings.ResetEventSourceMappingID)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/reset/pipeline"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhenPauseResetPipeline(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		mappingID string
+		state     string
+		updateErr error
+		expStatus int
+		expBody   pipeline.Status
+	}{
+		{
+			name:      "When the pipeline is paused. Then it's disabled.",
+			path:      "/system/reset/pause",
+			mappingID: "mapping-id",
+			state:     "Disabling",
+			expStatus: http.StatusOK,
+			expBody:   pipeline.Status{Paused: true, State: "Disabling"},
+		},
+		{
+			name:      "When the pipeline is resumed. Then it's enabled.",
+			path:      "/system/reset/resume",
+			mappingID: "mapping-id",
+			state:     "Enabling",
+			expStatus: http.StatusOK,
+			expBody:   pipeline.Status{Paused: false, State: "Enabling"},
+		},
+		{
+			name:      "When the mapping isn't configured. Then an error is returned.",
+			path:      "/system/reset/pause",
+			expStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "When the mapping can't be updated. Then an error is returned.",
+			path:      "/system/reset/pause",
+			mappingID: "mapping-id",
+			updateErr: fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			lambdaSvc := awsMocks.LambdaAPI{}
+			lambdaSvc.On("UpdateEventSourceMapping", &lambda.UpdateEventSourceMappingInput{
+				UUID:    aws.String("mapping-id"),
+				Enabled: aws.Bool(tt.path == "/system/reset/resume"),
+			}).Return(&lambda.EventSourceMappingConfiguration{
+				State: aws.String(tt.state),
+			}, tt.updateErr)
+
+			svcBldr.Config.WithService(&lambdaSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+			Settings.ResetEventSourceMappingID = tt.mappingID
+			defer func() { Settings.ResetEventSourceMappingID = "" }()
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       tt.path,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			body := pipeline.Status{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expBody, body)
+		})
+	}
+}
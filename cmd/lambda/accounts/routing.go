@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/gorilla/mux"
+)
+
+// ListNotificationRoutes - Returns the notification routing rules
+func ListNotificationRoutes(w http.ResponseWriter, r *http.Request) {
+	rules, err := Services.RoutingService().List()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, rules)
+}
+
+// GetNotificationRoute - Returns a notification routing rule by ID
+func GetNotificationRoute(w http.ResponseWriter, r *http.Request) {
+	rule, err := Services.RoutingService().Get(mux.Vars(r)["ruleId"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, rule)
+}
+
+// CreateNotificationRoute - Adds a rule routing an event type to channels
+func CreateNotificationRoute(w http.ResponseWriter, r *http.Request) {
+	data := &routing.Rule{}
+	err := json.NewDecoder(r.Body).Decode(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	rule, err := Services.RoutingService().Create(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusCreated, rule)
+}
+
+// UpdateNotificationRoute - Replaces a notification routing rule
+func UpdateNotificationRoute(w http.ResponseWriter, r *http.Request) {
+	data := &routing.Rule{}
+	err := json.NewDecoder(r.Body).Decode(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	rule, err := Services.RoutingService().Update(mux.Vars(r)["ruleId"], data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, rule)
+}
+
+// DeleteNotificationRoute - Removes a notification routing rule
+func DeleteNotificationRoute(w http.ResponseWriter, r *http.Request) {
+	rule, err := Services.RoutingService().Delete(mux.Vars(r)["ruleId"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, rule)
+}
+
+// GetPoolContact - Returns the account pool's contact
+func GetPoolContact(w http.ResponseWriter, r *http.Request) {
+	contact, err := Services.RoutingService().GetPoolContact()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	if contact == nil {
+		api.WriteAPIErrorResponse(w, errors.NewNotFound("contact", "pool"))
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, contact)
+}
+
+// UpdatePoolContact - Changes the account pool's contact, who is sent the
+// reset failures and health alerts of accounts without a contact
+func UpdatePoolContact(w http.ResponseWriter, r *http.Request) {
+	data := &routing.Contact{}
+	err := json.NewDecoder(r.Body).Decode(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	contact, err := Services.RoutingService().UpdatePoolContact(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, contact)
+}
+
+// DeletePoolContact - Removes the account pool's contact
+func DeletePoolContact(w http.ResponseWriter, r *http.Request) {
+	err := Services.RoutingService().DeletePoolContact()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusNoContent, nil)
+}
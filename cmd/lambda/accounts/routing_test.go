@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenNotificationRoutes(t *testing.T) {
+	rule := &routing.Rule{
+		ID:        "rule-1",
+		EventType: routing.EventBudgetThreshold,
+		Filter:    map[string]string{"pool": "ml"},
+		Channels:  []routing.Channel{{Type: routing.ChannelSlack, Target: "#ml-infra"}},
+	}
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		setup     func(routingSvc *mocks.Servicer)
+		expStatus int
+		expRule   *routing.Rule
+	}{
+		{
+			name:   "When listing. Then the rules are returned.",
+			method: http.MethodGet,
+			path:   "/system/notification-routes",
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("List").Return([]*routing.Rule{rule}, nil)
+			},
+			expStatus: http.StatusOK,
+		},
+		{
+			name:   "When getting a rule. Then it's returned.",
+			method: http.MethodGet,
+			path:   "/system/notification-routes/rule-1",
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Get", "rule-1").Return(rule, nil)
+			},
+			expStatus: http.StatusOK,
+			expRule:   rule,
+		},
+		{
+			name:   "When getting a missing rule. Then not found is returned.",
+			method: http.MethodGet,
+			path:   "/system/notification-routes/missing",
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Get", "missing").Return(nil, errors.NewNotFound("routing rule", "missing"))
+			},
+			expStatus: http.StatusNotFound,
+		},
+		{
+			name:   "When creating a rule. Then it's created.",
+			method: http.MethodPost,
+			path:   "/system/notification-routes",
+			body:   `{"eventType": "BudgetThreshold", "filter": {"pool": "ml"}, "channels": [{"type": "slack", "target": "#ml-infra"}]}`,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Create", &routing.Rule{
+					EventType: routing.EventBudgetThreshold,
+					Filter:    map[string]string{"pool": "ml"},
+					Channels:  []routing.Channel{{Type: routing.ChannelSlack, Target: "#ml-infra"}},
+				}).Return(rule, nil)
+			},
+			expStatus: http.StatusCreated,
+			expRule:   rule,
+		},
+		{
+			name:   "When creating an invalid rule. Then a bad request is returned.",
+			method: http.MethodPost,
+			path:   "/system/notification-routes",
+			body:   `{"eventType": "LeaseCreated"}`,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Create", mock.Anything).Return(nil, errors.NewValidation("routing rule", fmt.Errorf("eventType: must be a valid value.")))
+			},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "When the body is invalid. Then a bad request is returned.",
+			method:    http.MethodPut,
+			path:      "/system/notification-routes/rule-1",
+			body:      `{"channels": "#ml-infra"}`,
+			setup:     func(routingSvc *mocks.Servicer) {},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "When updating a rule. Then it's updated.",
+			method: http.MethodPut,
+			path:   "/system/notification-routes/rule-1",
+			body:   `{"eventType": "BudgetThreshold", "filter": {"pool": "ml"}, "channels": [{"type": "slack", "target": "#ml-infra"}]}`,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Update", "rule-1", mock.AnythingOfType("*routing.Rule")).Return(rule, nil)
+			},
+			expStatus: http.StatusOK,
+			expRule:   rule,
+		},
+		{
+			name:   "When deleting a rule. Then it's deleted.",
+			method: http.MethodDelete,
+			path:   "/system/notification-routes/rule-1",
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("Delete", "rule-1").Return(rule, nil)
+			},
+			expStatus: http.StatusOK,
+			expRule:   rule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			routingSvc := &mocks.Servicer{}
+			tt.setup(routingSvc)
+
+			svcBldr.Config.WithService(routingSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: tt.method,
+				Path:       tt.path,
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			routingSvc.AssertExpectations(t)
+			if tt.expRule == nil {
+				return
+			}
+
+			body := &routing.Rule{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expRule, body)
+		})
+	}
+}
+
+func TestWhenPoolContact(t *testing.T) {
+	contact := &routing.Contact{Email: "cloud@example.com", Team: "cloud", EscalationChannel: "#cloud-oncall"}
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		setup      func(routingSvc *mocks.Servicer)
+		expStatus  int
+		expContact *routing.Contact
+	}{
+		{
+			name:   "When getting the contact. Then it's returned.",
+			method: http.MethodGet,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("GetPoolContact").Return(contact, nil)
+			},
+			expStatus:  http.StatusOK,
+			expContact: contact,
+		},
+		{
+			name:   "When the pool has no contact. Then not found is returned.",
+			method: http.MethodGet,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("GetPoolContact").Return(nil, nil)
+			},
+			expStatus: http.StatusNotFound,
+		},
+		{
+			name:   "When updating the contact. Then it's updated.",
+			method: http.MethodPut,
+			body:   `{"email": "cloud@example.com", "team": "cloud", "escalationChannel": "#cloud-oncall"}`,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("UpdatePoolContact", contact).Return(contact, nil)
+			},
+			expStatus:  http.StatusOK,
+			expContact: contact,
+		},
+		{
+			name:      "When the body is invalid. Then a bad request is returned.",
+			method:    http.MethodPut,
+			body:      `{"email": ["cloud@example.com"]}`,
+			setup:     func(routingSvc *mocks.Servicer) {},
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "When deleting the contact. Then it's deleted.",
+			method: http.MethodDelete,
+			setup: func(routingSvc *mocks.Servicer) {
+				routingSvc.On("DeletePoolContact").Return(nil)
+			},
+			expStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			routingSvc := &mocks.Servicer{}
+			tt.setup(routingSvc)
+
+			svcBldr.Config.WithService(routingSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: tt.method,
+				Path:       "/system/pool-contact",
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			routingSvc.AssertExpectations(t)
+			if tt.expContact == nil {
+				return
+			}
+
+			body := &routing.Contact{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expContact, body)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/settings"
+)
+
+// GetSettings - Returns the system-wide settings
+func GetSettings(w http.ResponseWriter, r *http.Request) {
+	current, err := Services.SettingsService().Get()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, current)
+}
+
+// UpdateSettings - Replaces the system-wide settings, which the Lambdas
+// apply within a minute
+func UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	data := &settings.Settings{}
+	err := json.NewDecoder(r.Body).Decode(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	updated, err := Services.SettingsService().Update(data)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, updated)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/settings"
+	"github.com/Optum/dce/pkg/settings/settingsiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenSettings(t *testing.T) {
+	current := &settings.Settings{
+		MaxLeaseBudgetAmount:                   250,
+		BudgetNotificationThresholdPercentiles: []float64{75, 100},
+		FeatureFlags:                           map[string]bool{"slackBudgetNotifications": true},
+		LastModifiedOn:                         1583053200,
+	}
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		setup       func(settingsSvc *mocks.Servicer)
+		expStatus   int
+		expSettings *settings.Settings
+	}{
+		{
+			name:   "When getting the settings. Then they're returned.",
+			method: http.MethodGet,
+			setup: func(settingsSvc *mocks.Servicer) {
+				settingsSvc.On("Get").Return(current, nil)
+			},
+			expStatus:   http.StatusOK,
+			expSettings: current,
+		},
+		{
+			name:   "When updating the settings. Then they're updated.",
+			method: http.MethodPut,
+			body:   `{"maxLeaseBudgetAmount": 250, "budgetNotificationThresholdPercentiles": [75, 100], "featureFlags": {"slackBudgetNotifications": true}}`,
+			setup: func(settingsSvc *mocks.Servicer) {
+				settingsSvc.On("Update", &settings.Settings{
+					MaxLeaseBudgetAmount:                   250,
+					BudgetNotificationThresholdPercentiles: []float64{75, 100},
+					FeatureFlags:                           map[string]bool{"slackBudgetNotifications": true},
+				}).Return(current, nil)
+			},
+			expStatus:   http.StatusOK,
+			expSettings: current,
+		},
+		{
+			name:   "When the settings changed since they were read. Then a conflict is returned.",
+			method: http.MethodPut,
+			body:   `{"maxLeaseBudgetAmount": 250, "lastModifiedOn": 1583000000}`,
+			setup: func(settingsSvc *mocks.Servicer) {
+				settingsSvc.On("Update", mock.AnythingOfType("*settings.Settings")).
+					Return(nil, errors.NewConflict("settings", "system", nil))
+			},
+			expStatus: http.StatusConflict,
+		},
+		{
+			name:      "When the body is invalid. Then a bad request is returned.",
+			method:    http.MethodPut,
+			body:      `{"featureFlags": ["slackBudgetNotifications"]}`,
+			setup:     func(settingsSvc *mocks.Servicer) {},
+			expStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			settingsSvc := &mocks.Servicer{}
+			tt.setup(settingsSvc)
+
+			svcBldr.Config.WithService(settingsSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: tt.method,
+				Path:       "/settings",
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			settingsSvc.AssertExpectations(t)
+			if tt.expSettings == nil {
+				return
+			}
+
+			body := &settings.Settings{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expSettings, body)
+		})
+	}
+}
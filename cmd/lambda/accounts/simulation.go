@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/simulation"
+)
+
+// SimulatePool - Projects how the account pool behaves under the given lease
+// demand, from its current size and reset throughput, for capacity planning.
+// Nothing is changed.
+func SimulatePool(w http.ResponseWriter, r *http.Request) {
+	input := &simulation.Input{}
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	pool := simulation.Pool{
+		ResetHours:       Settings.SimulationResetHours,
+		ResetConcurrency: Settings.SimulationResetConcurrency,
+	}
+	counts := map[account.Status]*int{
+		account.StatusReady:    &pool.Ready,
+		account.StatusLeased:   &pool.Leased,
+		account.StatusNotReady: &pool.NotReady,
+	}
+	for status, count := range counts {
+		count := count
+		err := Services.AccountService().ListPages(&account.Account{
+			Status: status.StatusPtr(),
+		}, func(accounts *account.Accounts) bool {
+			*count += len(*accounts)
+			return true
+		})
+		if err != nil {
+			api.WriteAPIErrorResponse(w, err)
+			return
+		}
+	}
+
+	result, err := simulation.Run(input, pool, time.Now())
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/simulation"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenSimulatePool(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		listErr   error
+		expStatus int
+		expPool   simulation.Pool
+		expDays   int
+	}{
+		{
+			name:      "When demand is given. Then the current pool is simulated.",
+			body:      `{"requestsPerDay": 2, "averageLeaseDays": 7, "days": 14}`,
+			expStatus: http.StatusOK,
+			expPool:   simulation.Pool{Ready: 2, Leased: 1, NotReady: 1, ResetHours: 1, ResetConcurrency: 20},
+			expDays:   14,
+		},
+		{
+			name:      "When the pool is overridden. Then the overrides are simulated.",
+			body:      `{"requestsPerDay": 2, "averageLeaseDays": 7, "poolSize": 10, "resetHours": 3, "resetConcurrency": 2}`,
+			expStatus: http.StatusOK,
+			expPool:   simulation.Pool{Ready: 8, Leased: 1, NotReady: 1, ResetHours: 3, ResetConcurrency: 2},
+			expDays:   90,
+		},
+		{
+			name:      "When demand is missing. Then a bad request is returned.",
+			body:      `{"averageLeaseDays": 7}`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "When the body is invalid. Then a bad request is returned.",
+			body:      `{"requestsPerDay": "many"}`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "When listing accounts fails. Then an error is returned.",
+			body:      `{"requestsPerDay": 2, "averageLeaseDays": 7}`,
+			listErr:   fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accounts := map[account.Status]account.Accounts{
+				account.StatusReady:    {{ID: aws.String("111111111111")}, {ID: aws.String("222222222222")}},
+				account.StatusLeased:   {{ID: aws.String("333333333333")}},
+				account.StatusNotReady: {{ID: aws.String("444444444444")}},
+			}
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("ListPages", mock.AnythingOfType("*account.Account"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					query := args.Get(0).(*account.Account)
+					fn := args.Get(1).(func(*account.Accounts) bool)
+					page := accounts[*query.Status]
+					fn(&page)
+				}).
+				Return(tt.listErr)
+
+			svcBldr.Config.WithService(&accountSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/system/simulation",
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			body := simulation.Result{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expPool, body.Pool)
+			assert.Len(t, body.Days, tt.expDays)
+		})
+	}
+}
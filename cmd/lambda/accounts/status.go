@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+// systemStatus summarizes the health of the account pool
+type systemStatus struct {
+	Accounts             map[string]int `json:"accounts"`
+	ResetQueueDepth      int64          `json:"resetQueueDepth"`
+	OldestResetAccountID *string        `json:"oldestResetAccountId"`
+	OldestResetStartedOn *int64         `json:"oldestResetStartedOn"`
+	StuckResets          []string       `json:"stuckResets"`
+	ResetPaused          *bool          `json:"resetPaused,omitempty"`
+	// LeakingAccounts have resources which survived several resets in a row
+	LeakingAccounts []string `json:"leakingAccounts"`
+}
+
+// GetSystemStatus - Returns the number of accounts in each status, the depth of
+// the reset queue, accounts which appear to be stuck resetting, and accounts
+// with resources which their resets don't delete
+func GetSystemStatus(w http.ResponseWriter, r *http.Request) {
+	status := systemStatus{
+		Accounts:        map[string]int{},
+		StuckResets:     []string{},
+		LeakingAccounts: []string{},
+	}
+
+	stuckBefore := time.Now().Add(-time.Duration(Settings.ResetStuckThresholdMinutes) * time.Minute).Unix()
+
+	// Count the accounts of each status, and read the queue depth, at the same time
+	statuses := []account.Status{
+		account.StatusReady,
+		account.StatusNotReady,
+		account.StatusLeased,
+		account.StatusOrphaned,
+		account.StatusUnreachable,
+		account.StatusQuarantined,
+	}
+	counts := make([]int, len(statuses))
+	leaking := make([][]string, len(statuses))
+	pool := common.NewWorkerPool(r.Context(), len(statuses)+2)
+	for i, s := range statuses {
+		i, s := i, s
+		pool.Submit(func(ctx context.Context) error {
+			query := &account.Account{
+				Status: s.StatusPtr(),
+			}
+			return Services.AccountService().ListPages(query, func(accounts *account.Accounts) bool {
+				counts[i] += len(*accounts)
+				for _, a := range *accounts {
+					if len(a.ResetLeaks.Leaked()) > 0 {
+						leaking[i] = append(leaking[i], *a.ID)
+					}
+				}
+				if s != account.StatusNotReady {
+					return true
+				}
+				for _, a := range *accounts {
+					if a.LastModifiedOn == nil {
+						continue
+					}
+					if status.OldestResetStartedOn == nil || *a.LastModifiedOn < *status.OldestResetStartedOn {
+						status.OldestResetStartedOn = aws.Int64(*a.LastModifiedOn)
+						status.OldestResetAccountID = aws.String(*a.ID)
+					}
+					if *a.LastModifiedOn < stuckBefore {
+						status.StuckResets = append(status.StuckResets, *a.ID)
+					}
+				}
+				return true
+			})
+		})
+	}
+	pool.Submit(func(ctx context.Context) error {
+		depth, err := resetQueueDepth()
+		status.ResetQueueDepth = depth
+		return err
+	})
+	if Settings.ResetEventSourceMappingID != "" {
+		pool.Submit(func(ctx context.Context) error {
+			p, err := resetPipeline()
+			if err != nil {
+				return err
+			}
+			pipelineStatus, err := p.Status()
+			if err != nil {
+				return err
+			}
+			status.ResetPaused = aws.Bool(pipelineStatus.Paused)
+			return nil
+		})
+	}
+	if errs := pool.Wait(); len(errs) > 0 {
+		api.WriteAPIErrorResponse(w, errs[0])
+		return
+	}
+
+	for i, s := range statuses {
+		status.Accounts[s.String()] = counts[i]
+		status.LeakingAccounts = append(status.LeakingAccounts, leaking[i]...)
+	}
+	sort.Strings(status.StuckResets)
+	sort.Strings(status.LeakingAccounts)
+
+	api.WriteAPIResponse(w, http.StatusOK, status)
+}
+
+// resetQueueDepth gets the approximate number of accounts waiting to be reset
+func resetQueueDepth() (int64, error) {
+	var sqsSvc sqsiface.SQSAPI
+	if err := Services.Config.GetService(&sqsSvc); err != nil {
+		return 0, errors.NewInternalServer("unable to get the SQS service", err)
+	}
+	attrName := sqs.QueueAttributeNameApproximateNumberOfMessages
+	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(Settings.ResetQueueURL),
+		AttributeNames: []*string{aws.String(attrName)},
+	})
+	if err != nil {
+		return 0, errors.NewInternalServer("unable to get the reset queue depth", err)
+	}
+
+	var depth int64
+	if v, ok := out.Attributes[attrName]; ok && v != nil {
+		depth, _ = strconv.ParseInt(*v, 10, 64)
+	}
+	return depth, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenGetSystemStatus(t *testing.T) {
+	now := time.Now().Unix()
+	stale := time.Now().Add(-3 * time.Hour).Unix()
+
+	tests := []struct {
+		name      string
+		accounts  map[account.Status]account.Accounts
+		listErr   error
+		queueErr  error
+		expStatus int
+		expBody   systemStatus
+	}{
+		{
+			name: "When accounts exist. Then counts, stuck resets and leaking accounts are returned.",
+			accounts: map[account.Status]account.Accounts{
+				account.StatusReady: {
+					{ID: aws.String("111111111111"), LastModifiedOn: &now},
+					{ID: aws.String("444444444444"), LastModifiedOn: &now, ResetLeaks: &account.ResetLeaks{
+						Resources: []*account.ResetLeak{{ARN: "arn:aws:sns:us-east-1:444444444444:topic", Resets: 1}},
+					}},
+				},
+				account.StatusLeased: {
+					{ID: aws.String("555555555555"), LastModifiedOn: &now, ResetLeaks: &account.ResetLeaks{
+						Resources: []*account.ResetLeak{{ARN: "arn:aws:sns:us-east-1:555555555555:topic", Resets: 2}},
+					}},
+				},
+				account.StatusNotReady: {
+					{ID: aws.String("222222222222"), LastModifiedOn: &now},
+					{ID: aws.String("333333333333"), LastModifiedOn: &stale},
+				},
+			},
+			expStatus: http.StatusOK,
+			expBody: systemStatus{
+				Accounts: map[string]int{
+					"Ready":       2,
+					"NotReady":    2,
+					"Leased":      1,
+					"Orphaned":    0,
+					"Unreachable": 0,
+					"Quarantined": 0,
+				},
+				ResetQueueDepth:      4,
+				OldestResetAccountID: aws.String("333333333333"),
+				OldestResetStartedOn: &stale,
+				StuckResets:          []string{"333333333333"},
+				LeakingAccounts:      []string{"555555555555"},
+			},
+		},
+		{
+			name:      "When listing accounts fails. Then an error is returned.",
+			listErr:   fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "When the queue depth can't be read. Then an error is returned.",
+			queueErr:  fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := mocks.Servicer{}
+			accountSvc.On("ListPages", mock.AnythingOfType("*account.Account"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					query := args.Get(0).(*account.Account)
+					fn := args.Get(1).(func(*account.Accounts) bool)
+					accounts := tt.accounts[*query.Status]
+					fn(&accounts)
+				}).
+				Return(tt.listErr)
+
+			sqsSvc := awsMocks.SQSAPI{}
+			sqsSvc.On("GetQueueAttributes", mock.AnythingOfType("*sqs.GetQueueAttributesInput")).Return(
+				&sqs.GetQueueAttributesOutput{
+					Attributes: map[string]*string{
+						sqs.QueueAttributeNameApproximateNumberOfMessages: aws.String("4"),
+					},
+				}, tt.queueErr,
+			)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&sqsSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Path:       "/system/status",
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			body := systemStatus{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expBody, body)
+		})
+	}
+}
@@ -33,6 +33,7 @@ func UpdateAccountByID(w http.ResponseWriter, r *http.Request) {
 		validation.Field(&newAccount.CreatedOn, validation.By(isNil)),
 		validation.Field(&newAccount.PrincipalRoleArn, validation.By(isNil)),
 		validation.Field(&newAccount.PrincipalPolicyHash, validation.By(isNil)),
+		validation.Field(&newAccount.PrincipalPolicyCustomization, validation.By(isNil)),
 	)
 	if err != nil {
 		api.WriteAPIErrorResponse(w,
@@ -0,0 +1,164 @@
+// Package main archives leases which ended a while ago.  Each lease is
+// written to S3 as a JSON object, partitioned by the day it ended so it can be
+// queried with Athena, and replaced in DynamoDB with a small index record.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// Bucket is the S3 bucket leases are archived to
+	Bucket string `env:"LEASE_ARCHIVE_BUCKET" envDefault:""`
+	// AfterDays is how long after it ends a lease is archived
+	AfterDays int `env:"LEASE_ARCHIVE_AFTER_DAYS" envDefault:"90"`
+	// MaxLeases is the most leases archived in one run, so a large backlog
+	// is archived over several runs
+	MaxLeases int `env:"LEASE_ARCHIVE_MAX_LEASES" envDefault:"500"`
+}
+
+type archiveResult struct {
+	Archived int `json:"archived"`
+	Failed   int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	s3Svc    s3iface.S3API
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		WithS3().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+	if err := services.Config.GetService(&s3Svc); err != nil {
+		log.Fatalf("Failed to get the S3 service: %s", err)
+	}
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*archiveResult, error) {
+	result := &archiveResult{}
+	if settings.Bucket == "" {
+		return nil, errors.NewInternalServer("LEASE_ARCHIVE_BUCKET is not set", nil)
+	}
+	endedBefore := time.Now().AddDate(0, 0, -settings.AfterDays).Unix()
+
+	toArchive := []*lease.Lease{}
+	err := services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusInactive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for i := range *leases {
+			l := (*leases)[i]
+			if isArchivable(&l, endedBefore) {
+				toArchive = append(toArchive, &l)
+			}
+		}
+		return len(toArchive) < settings.MaxLeases
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(toArchive) > settings.MaxLeases {
+		toArchive = toArchive[:settings.MaxLeases]
+	}
+
+	// Archive every lease before failing, so one failure doesn't hold up the rest
+	errs := []error{}
+	for _, l := range toArchive {
+		err := archive(l)
+		if err != nil {
+			log.Printf("Failed to archive lease %s: %s", aws.StringValue(l.ID), err)
+			result.Failed++
+			errs = append(errs, err)
+			continue
+		}
+		result.Archived++
+	}
+
+	log.Printf("Archived %d leases which ended before %s, failed %d",
+		result.Archived, time.Unix(endedBefore, 0).UTC().Format(time.RFC3339), result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to archive leases", errs)
+	}
+	return result, nil
+}
+
+// isArchivable is true for Inactive leases which ended before the given time,
+// and haven't been archived yet
+func isArchivable(l *lease.Lease, endedBefore int64) bool {
+	return !l.IsArchived() && l.StatusModifiedOn != nil && *l.StatusModifiedOn <= endedBefore
+}
+
+// archiveKey is where a lease is archived, partitioned by the day it ended,
+// eg. leases/ended=2020-01-02/<lease ID>.json
+func archiveKey(l *lease.Lease) string {
+	ended := time.Unix(aws.Int64Value(l.StatusModifiedOn), 0).UTC()
+	return fmt.Sprintf("leases/ended=%s/%s.json", ended.Format("2006-01-02"), aws.StringValue(l.ID))
+}
+
+// archive writes the lease to S3, then replaces it with its index record.
+// A lease which fails to be replaced is archived again on the next run, which
+// overwrites the same object.
+func archive(l *lease.Lease) error {
+	// Athena reads one JSON record per line
+	body, err := json.Marshal(l)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to marshal lease %s", aws.StringValue(l.ID)), err)
+	}
+
+	key := archiveKey(l)
+	_, err = s3Svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(settings.Bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(append(body, '\n')),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to write lease %s to the archive", aws.StringValue(l.ID)), err)
+	}
+
+	_, err = services.LeaseService().Archive(l, key)
+	return err
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("archive_leases", handler))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const day = 24 * 60 * 60
+
+func TestArchiveKey(t *testing.T) {
+	l := &lease.Lease{
+		ID:               aws.String("lease-1"),
+		StatusModifiedOn: aws.Int64(time.Date(2020, 1, 2, 23, 0, 0, 0, time.UTC).Unix()),
+	}
+	assert.Equal(t, "leases/ended=2020-01-02/lease-1.json", archiveKey(l))
+}
+
+func TestHandler(t *testing.T) {
+	settings.Bucket = "dce-lease-archive"
+	settings.AfterDays = 90
+	now := time.Now().Unix()
+
+	ended := func(id string, daysAgo int64) lease.Lease {
+		return lease.Lease{
+			ID:               aws.String(id),
+			AccountID:        aws.String("123456789012"),
+			PrincipalID:      aws.String(id + "-principal"),
+			Status:           lease.StatusInactive.StatusPtr(),
+			StatusModifiedOn: aws.Int64(now - daysAgo*day),
+			LastModifiedOn:   aws.Int64(now - daysAgo*day),
+		}
+	}
+	archived := ended("archived", 200)
+	archived.ArchivedOn = aws.Int64(now - 100*day)
+
+	tests := []struct {
+		name        string
+		maxLeases   int
+		putErr      error
+		expResult   *archiveResult
+		expErr      bool
+		expArchived []string
+	}{
+		{
+			name:        "archives leases which ended before the archive period",
+			maxLeases:   500,
+			expResult:   &archiveResult{Archived: 2},
+			expArchived: []string{"old-1", "old-2"},
+		},
+		{
+			name:        "archives at most the max leases",
+			maxLeases:   1,
+			expResult:   &archiveResult{Archived: 1},
+			expArchived: []string{"old-1"},
+		},
+		{
+			name:      "doesn't replace leases which failed to be written to S3",
+			maxLeases: 500,
+			putErr:    fmt.Errorf("access denied"),
+			expResult: &archiveResult{Failed: 2},
+			expErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.MaxLeases = tt.maxLeases
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("ListPages", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(*lease.Leases) bool)
+				fn(&lease.Leases{ended("old-1", 100), ended("recent", 10), archived, ended("old-2", 91)})
+			})
+			leaseSvc.On("Archive", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("string")).Return(&lease.Lease{}, nil)
+			svcBldr.Config.WithService(leaseSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			services = svcBldr
+
+			s3Mock := &awsmocks.S3API{}
+			s3Mock.On("PutObject", mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+				body, _ := ioutil.ReadAll(input.Body)
+				return *input.Bucket == "dce-lease-archive" && body[len(body)-1] == '\n'
+			})).Return(&s3.PutObjectOutput{}, tt.putErr)
+			s3Svc = s3Mock
+
+			result, err := handler(context.TODO(), events.CloudWatchEvent{})
+			assert.Equal(t, tt.expErr, err != nil)
+			if tt.expErr {
+				assert.True(t, errors.HTTPCodeForError(err) >= 500)
+			}
+			assert.Equal(t, tt.expResult, result)
+			leaseSvc.AssertNumberOfCalls(t, "Archive", len(tt.expArchived))
+			for _, id := range tt.expArchived {
+				leaseSvc.AssertCalled(t, "Archive", mock.MatchedBy(func(l *lease.Lease) bool {
+					return *l.ID == id
+				}), mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "leases/ended=") && strings.HasSuffix(key, "/"+id+".json")
+				}))
+			}
+		})
+	}
+}
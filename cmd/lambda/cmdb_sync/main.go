@@ -0,0 +1,162 @@
+// Package main pushes the ownership and status of DCE accounts to an
+// external CMDB, and pulls authoritative tags, such as cost centers, from the
+// CMDB onto the account metadata
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/cmdb"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		WithCMDBService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+// handler syncs every account with the CMDB. Accounts which fail to sync
+// are retried on the next run.
+func handler(ctx context.Context) error {
+	cmdbSvc := services.CMDBService()
+	if !cmdbSvc.Enabled() {
+		log.Printf("No CMDB is configured")
+		return nil
+	}
+
+	leases, err := activeLeases()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	err = services.AccountService().ListPages(&account.Account{}, func(accounts *account.Accounts) bool {
+		for _, acct := range *accounts {
+			err := syncAccount(&acct, leases[*acct.ID])
+			if err != nil {
+				log.Printf("Failed to sync account %s with the CMDB: %s", *acct.ID, err)
+				errs = append(errs, err)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to sync accounts with the CMDB", errs)
+	}
+	return nil
+}
+
+// activeLeases gets the active leases, by account ID
+func activeLeases() (map[string]*lease.Lease, error) {
+	leases := map[string]*lease.Lease{}
+	err := services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+	}, func(page *lease.Leases) bool {
+		for i := range *page {
+			l := (*page)[i]
+			leases[*l.AccountID] = &l
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// syncAccount pushes an account to the CMDB, and updates the account metadata
+// with the tags pulled from the CMDB when they have changed
+func syncAccount(acct *account.Account, activeLease *lease.Lease) error {
+	record := &cmdb.Record{
+		AccountID: *acct.ID,
+	}
+	if acct.Status != nil {
+		record.AccountStatus = acct.Status.String()
+	}
+	if activeLease != nil {
+		record.LeaseID = aws.StringValue(activeLease.ID)
+		record.LeaseStatus = activeLease.Status.String()
+		record.PrincipalID = aws.StringValue(activeLease.PrincipalID)
+		if activeLease.BudgetAmount != nil {
+			record.BudgetAmount = *activeLease.BudgetAmount
+		}
+		if activeLease.ExpiresOn != nil {
+			record.ExpiresOn = *activeLease.ExpiresOn
+		}
+	}
+
+	err := services.CMDBService().Push(record)
+	if err != nil {
+		return err
+	}
+
+	tags, err := services.CMDBService().Pull(*acct.ID)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]interface{}{}
+	for tag, value := range tags {
+		if current, ok := acct.Metadata[tag]; !ok || fmt.Sprint(current) != value {
+			metadata[tag] = value
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	_, err = services.AccountService().Update(*acct.ID, &account.Account{
+		Metadata: metadata,
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Updated account %s with CMDB tags %v", *acct.ID, metadata)
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/cmdb"
+	cmdbmocks "github.com/Optum/dce/pkg/cmdb/cmdbiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCMDBSync(t *testing.T) {
+	accounts := account.Accounts{
+		{
+			ID:       aws.String("111111111111"),
+			Status:   account.StatusLeased.StatusPtr(),
+			Metadata: map[string]interface{}{"CostCenter": "CC-1"},
+		},
+		{
+			ID:       aws.String("222222222222"),
+			Status:   account.StatusReady.StatusPtr(),
+			Metadata: map[string]interface{}{"CostCenter": "CC-2"},
+		},
+		{
+			ID:     aws.String("333333333333"),
+			Status: account.StatusReady.StatusPtr(),
+		},
+	}
+	leases := lease.Leases{
+		{
+			ID:           aws.String("abc"),
+			AccountID:    aws.String("111111111111"),
+			PrincipalID:  aws.String("jdoe"),
+			Status:       lease.StatusActive.StatusPtr(),
+			BudgetAmount: aws.Float64(500),
+			ExpiresOn:    aws.Int64(1580000000),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		pushErr    error
+		pullErr    error
+		expUpdates map[string]map[string]interface{}
+		expErr     string
+	}{
+		{
+			name: "should push accounts and update changed tags",
+			expUpdates: map[string]map[string]interface{}{
+				"222222222222": {"CostCenter": "CC-3"},
+			},
+		},
+		{
+			name:    "should fail when accounts can't be pushed",
+			pushErr: fmt.Errorf("cmdb unavailable"),
+			expErr:  "failed to sync accounts with the CMDB: cmdb unavailable; cmdb unavailable; cmdb unavailable",
+		},
+		{
+			name:    "should fail when tags can't be pulled",
+			pullErr: fmt.Errorf("cmdb unavailable"),
+			expErr:  "failed to sync accounts with the CMDB: cmdb unavailable; cmdb unavailable; cmdb unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdbSvc := &cmdbmocks.Servicer{}
+			cmdbSvc.On("Enabled").Return(true)
+			cmdbSvc.On("Push", mock.Anything).Return(tt.pushErr)
+			cmdbSvc.On("Pull", "111111111111").Return(map[string]string{"CostCenter": "CC-1"}, tt.pullErr)
+			cmdbSvc.On("Pull", "222222222222").Return(map[string]string{"CostCenter": "CC-3"}, tt.pullErr)
+			cmdbSvc.On("Pull", "333333333333").Return(map[string]string{}, tt.pullErr)
+
+			accountSvc := &accountmocks.Servicer{}
+			accountSvc.On("ListPages", &account.Account{}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(func(*account.Accounts) bool)(&accounts)
+				}).
+				Return(nil)
+			accountSvc.On("Update", mock.Anything, mock.Anything).Return(&account.Account{}, nil)
+
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(func(*lease.Leases) bool)(&leases)
+				}).
+				Return(nil)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(accountSvc).WithService(leaseSvc).WithService(cmdbSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			services = svcBldr
+
+			err = handler(context.TODO())
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			cmdbSvc.AssertCalled(t, "Push", &cmdb.Record{
+				AccountID:     "111111111111",
+				AccountStatus: "Leased",
+				LeaseID:       "abc",
+				LeaseStatus:   "Active",
+				PrincipalID:   "jdoe",
+				BudgetAmount:  500,
+				ExpiresOn:     1580000000,
+			})
+			for id, metadata := range tt.expUpdates {
+				accountSvc.AssertCalled(t, "Update", id, &account.Account{Metadata: metadata})
+			}
+			accountSvc.AssertNumberOfCalls(t, "Update", len(tt.expUpdates))
+		})
+	}
+}
+
+func TestCMDBSyncDisabled(t *testing.T) {
+	cmdbSvc := &cmdbmocks.Servicer{}
+	cmdbSvc.On("Enabled").Return(false)
+	accountSvc := &accountmocks.Servicer{}
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(accountSvc).WithService(cmdbSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	err = handler(context.TODO())
+
+	assert.Nil(t, err)
+	accountSvc.AssertNotCalled(t, "ListPages", mock.Anything, mock.Anything)
+}
@@ -5,21 +5,60 @@ import (
 	"github.com/Optum/dce/pkg/api/response"
 	"html/template"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
+// RedirectURIParam is the query parameter used by the CLI to receive
+// credentials on a local listener instead of copying them by hand
+const RedirectURIParam = "redirect_uri"
+
+// StateParam is the query parameter the CLI passes with a redirect_uri.  It's
+// sent back with the credentials, so the CLI only accepts credentials from
+// the sign-in it started.
+const StateParam = "state"
+
+// statePattern is what a state must look like: at least 16 URL-safe
+// characters, so it can't be guessed
+var statePattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{16,128}$`)
+
+// authPageData is rendered into the auth page template
+type authPageData struct {
+	*credentialsWebPageConfig
+	CLIRedirectURI string
+	CLIState       string
+}
+
 func GetAuthPage(w http.ResponseWriter, r *http.Request) {
 	lp := filepath.Join("views", "index.html")
 
+	state, err := parseCLIState(r.FormValue(StateParam), r.FormValue(RedirectURIParam) != "")
+	if err != nil {
+		response.WriteRequestValidationError(w, err.Error())
+		return
+	}
+	redirectURI, err := parseCLIRedirectURI(r.FormValue(RedirectURIParam))
+	if err != nil {
+		response.WriteRequestValidationError(w, err.Error())
+		return
+	}
+
 	tmpl, err := template.ParseFiles(lp)
 	if err != nil {
 		errorMessage := fmt.Sprintf("Failed to load web page: %s", err)
 		log.Print(errorMessage)
 		response.WriteServerErrorWithResponse(w, errorMessage)
 	}
-	if err := tmpl.Execute(w, Settings); err != nil {
+	data := authPageData{
+		credentialsWebPageConfig: Settings,
+		CLIRedirectURI:           redirectURI,
+		CLIState:                 state,
+	}
+	if err := tmpl.Execute(w, data); err != nil {
 		errorMessage := fmt.Sprintf("Failed to load web page: %s", err)
 		log.Print(errorMessage)
 		response.WriteServerErrorWithResponse(w, errorMessage)
@@ -47,3 +86,39 @@ func GetAuthPageAssets(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", contentType)
 	sp.ServeHTTP(w, r)
 }
+
+// parseCLIRedirectURI makes sure credentials are only ever handed off to a
+// listener on the user's own machine
+func parseCLIRedirectURI(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %s", RedirectURIParam, err)
+	}
+	if u.Scheme != "http" {
+		return "", fmt.Errorf("invalid %s: scheme must be http", RedirectURIParam)
+	}
+	host := u.Hostname()
+	ip := net.ParseIP(host)
+	if host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("invalid %s: host must be a loopback address", RedirectURIParam)
+	}
+	return u.String(), nil
+}
+
+// parseCLIState makes sure a redirect_uri comes with a state, which the CLI
+// checks the credentials it receives against
+func parseCLIState(raw string, redirect bool) (string, error) {
+	if !redirect {
+		return "", nil
+	}
+	if raw == "" {
+		return "", fmt.Errorf("missing %s: required with a %s", StateParam, RedirectURIParam)
+	}
+	if !statePattern.MatchString(raw) {
+		return "", fmt.Errorf("invalid %s: must be 16 to 128 letters, digits, '.', '_', '~' and '-'", StateParam)
+	}
+	return raw, nil
+}
@@ -87,6 +87,113 @@ func TestGetAuth(t *testing.T) {
 	})
 }
 
+func TestGetAuthRedirectURI(t *testing.T) {
+	Settings = &credentialsWebPageConfig{}
+
+	t.Run("When redirect_uri is a loopback address then render it into the page", func(t *testing.T) {
+		mockRequest := events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/auth",
+			QueryStringParameters: map[string]string{
+				"redirect_uri": "http://127.0.0.1:8999/callback",
+				"state":        "c2lnbi1pbi1zdGF0ZQ",
+			},
+		}
+
+		actualResponse, err := Handler(context.TODO(), mockRequest)
+		require.Nil(t, err)
+
+		require.Equal(t, 200, actualResponse.StatusCode)
+		require.Contains(t, actualResponse.Body, `http:\/\/127.0.0.1:8999\/callback`, "JS-escaped redirect URI is rendered")
+		require.Contains(t, actualResponse.Body, `var CLI_STATE = "c2lnbi1pbi1zdGF0ZQ"`, "State is rendered")
+	})
+
+	t.Run("When redirect_uri has no state then respond with a 400", func(t *testing.T) {
+		mockRequest := events.APIGatewayProxyRequest{
+			HTTPMethod:            http.MethodGet,
+			Path:                  "/auth",
+			QueryStringParameters: map[string]string{"redirect_uri": "http://127.0.0.1:8999/callback"},
+		}
+
+		actualResponse, err := Handler(context.TODO(), mockRequest)
+		require.Nil(t, err)
+
+		require.Equal(t, 400, actualResponse.StatusCode)
+	})
+
+	t.Run("When redirect_uri is not a loopback address then respond with a 400", func(t *testing.T) {
+		mockRequest := events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/auth",
+			QueryStringParameters: map[string]string{
+				"redirect_uri": "https://example.com/callback",
+				"state":        "c2lnbi1pbi1zdGF0ZQ",
+			},
+		}
+
+		actualResponse, err := Handler(context.TODO(), mockRequest)
+		require.Nil(t, err)
+
+		require.Equal(t, 400, actualResponse.StatusCode)
+	})
+}
+
+func TestParseCLIRedirectURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", raw: "", want: ""},
+		{name: "localhost", raw: "http://localhost:8080/", want: "http://localhost:8080/"},
+		{name: "ipv4 loopback", raw: "http://127.0.0.1:8080", want: "http://127.0.0.1:8080"},
+		{name: "ipv6 loopback", raw: "http://[::1]:8080", want: "http://[::1]:8080"},
+		{name: "https", raw: "https://localhost:8080", wantErr: true},
+		{name: "remote host", raw: "http://example.com", wantErr: true},
+		{name: "lookalike host", raw: "http://localhost.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCLIRedirectURI(tt.raw)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCLIState(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		redirect bool
+		want     string
+		wantErr  bool
+	}{
+		{name: "no redirect", raw: "", want: ""},
+		{name: "ignored without a redirect", raw: "c2lnbi1pbi1zdGF0ZQ", want: ""},
+		{name: "url-safe", raw: "c2lnbi1pbi1zdGF0ZQ_-.~", redirect: true, want: "c2lnbi1pbi1zdGF0ZQ_-.~"},
+		{name: "missing", raw: "", redirect: true, wantErr: true},
+		{name: "too short", raw: "abc123", redirect: true, wantErr: true},
+		{name: "not url-safe", raw: "c2lnbi1pbi1zdGF0ZQ\"+alert(1)", redirect: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCLIState(tt.raw, tt.redirect)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func readFile(path string) string {
 	file, err := os.Open(path)
 	if err != nil {
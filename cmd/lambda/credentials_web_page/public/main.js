@@ -3,10 +3,17 @@ new Vue({
   data:{
       auth: null,
       jwt: "",
+      refreshToken: "",
       decodedJwt: "",
       encodedCreds: ""
   },
   mounted() {
+    // The Cognito redirect drops our query string, so remember where the CLI
+    // is listening before signing in
+    if (CLI_REDIRECT_URI) {
+      sessionStorage.setItem("cliRedirectUri", CLI_REDIRECT_URI)
+      sessionStorage.setItem("cliState", CLI_STATE)
+    }
     this.initCognitoSDK();
     let curUrl = window.location.href;
     this.auth.parseCognitoWebResponse(curUrl);
@@ -44,6 +51,7 @@ new Vue({
       processSession(session) {
           if (session) {
             this.jwt = session.getIdToken().getJwtToken();
+            this.refreshToken = session.getRefreshToken().getToken();
             var payload = this.jwt.split('.')[1];
             this.decodedJwt = JSON.parse(atob(payload));
           }
@@ -53,6 +61,10 @@ new Vue({
         AWS.config.update({region:AWS_CURRENT_REGION});
         var logins = {}
         localStorage.clear();
+        var cliRedirectUri = sessionStorage.getItem("cliRedirectUri")
+        var cliState = sessionStorage.getItem("cliState")
+        sessionStorage.removeItem("cliRedirectUri")
+        sessionStorage.removeItem("cliState")
         logins[USER_POOL_PROVIDER_NAME] = this.jwt
         AWS.config.credentials = new AWS.CognitoIdentityCredentials({
             IdentityPoolId: IDENTITY_POOL_ID,
@@ -70,11 +82,36 @@ new Vue({
               stsCreds.secretAccessKey = AWS.config.credentials.secretAccessKey
               stsCreds.sessionToken = AWS.config.credentials.sessionToken
               stsCreds.expireTime = this.decodedJwt.exp
+              if (cliRedirectUri && cliState) {
+                // Only the CLI which started the sign-in gets the refresh
+                // token, in the body of a POST, so it's kept out of URLs and
+                // the browser history
+                stsCreds.refreshToken = self.refreshToken
+                self.postToCLI(cliRedirectUri, {
+                  code: btoa(JSON.stringify(stsCreds)),
+                  state: cliState
+                })
+                return
+              }
               self.encodedCreds = btoa(JSON.stringify(stsCreds))
               document.getElementById("credentialscontainer").innerHTML += self.encodedCreds + "&#13;&#10;";
           }
         });
       },
+      postToCLI(redirectUri, fields) {
+          var form = document.createElement("form")
+          form.method = "POST"
+          form.action = redirectUri
+          for (var name in fields) {
+            var input = document.createElement("input")
+            input.type = "hidden"
+            input.name = name
+            input.value = fields[name]
+            form.appendChild(input)
+          }
+          document.body.appendChild(form)
+          form.submit()
+      },
       copyToClipboard() {
           var copyTextarea = document.querySelector('.js-copytextarea');
           copyTextarea.focus();
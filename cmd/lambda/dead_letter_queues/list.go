@@ -0,0 +1,118 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/gorilla/mux"
+)
+
+// deadLetterQueueSummary describes a DLQ and how many messages it holds
+type deadLetterQueueSummary struct {
+	Name         string `json:"name"`
+	MessageCount int64  `json:"messageCount"`
+}
+
+// deadLetterMessage is a message which failed processing
+type deadLetterMessage struct {
+	ID            string `json:"id"`
+	Body          string `json:"body"`
+	FailureReason string `json:"failureReason,omitempty"`
+	ErrorCode     string `json:"errorCode,omitempty"`
+	ReceiveCount  int64  `json:"receiveCount"`
+	SentOn        int64  `json:"sentOn"`
+}
+
+// ListDeadLetterQueues - Returns each DLQ with the number of messages in it
+func ListDeadLetterQueues(w http.ResponseWriter, r *http.Request) {
+	sqsSvc, err := sqsService()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	attrName := sqs.QueueAttributeNameApproximateNumberOfMessages
+	summaries := []*deadLetterQueueSummary{}
+	for _, q := range deadLetterQueues() {
+		out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(q.URL),
+			AttributeNames: []*string{aws.String(attrName)},
+		})
+		if err != nil {
+			api.WriteAPIErrorResponse(w,
+				errors.NewInternalServer("unable to get the depth of dead letter queue "+q.Name, err))
+			return
+		}
+		summary := &deadLetterQueueSummary{Name: q.Name}
+		if v, ok := out.Attributes[attrName]; ok && v != nil {
+			summary.MessageCount, _ = strconv.ParseInt(*v, 10, 64)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, summaries)
+}
+
+// ListDeadLetterMessages - Returns up to 10 messages from a DLQ, along with the
+// reason they failed when it is known. Messages are left on the queue.
+func ListDeadLetterMessages(w http.ResponseWriter, r *http.Request) {
+	q, err := getDeadLetterQueue(mux.Vars(r)["queueName"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	sqsSvc, err := sqsService()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	// A visibility timeout of 0 leaves the messages available to be replayed
+	out, err := sqsSvc.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(q.URL),
+		MaxNumberOfMessages:   aws.Int64(10),
+		VisibilityTimeout:     aws.Int64(0),
+		AttributeNames:        []*string{aws.String(sqs.QueueAttributeNameAll)},
+		MessageAttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+	})
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewInternalServer("unable to receive messages from dead letter queue "+q.Name, err))
+		return
+	}
+
+	messages := []*deadLetterMessage{}
+	for _, msg := range out.Messages {
+		messages = append(messages, newDeadLetterMessage(msg))
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, messages)
+}
+
+// newDeadLetterMessage reads the failure details Lambda adds to messages in
+// its DLQs.  Messages moved by an SQS redrive policy have no failure reason.
+func newDeadLetterMessage(msg *sqs.Message) *deadLetterMessage {
+	m := &deadLetterMessage{
+		ID:   aws.StringValue(msg.MessageId),
+		Body: aws.StringValue(msg.Body),
+	}
+	if attr, ok := msg.MessageAttributes["ErrorMessage"]; ok {
+		m.FailureReason = aws.StringValue(attr.StringValue)
+	}
+	if attr, ok := msg.MessageAttributes["ErrorCode"]; ok {
+		m.ErrorCode = aws.StringValue(attr.StringValue)
+	}
+	if v, ok := msg.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]; ok {
+		m.ReceiveCount, _ = strconv.ParseInt(aws.StringValue(v), 10, 64)
+	}
+	if v, ok := msg.Attributes[sqs.MessageSystemAttributeNameSentTimestamp]; ok {
+		sentOn, _ := strconv.ParseInt(aws.StringValue(v), 10, 64)
+		m.SentOn = sentOn / 1000
+	}
+	return m
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenListDeadLetterQueues(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		sqsErr    error
+		expStatus int
+		expBody   []*deadLetterQueueSummary
+	}{
+		{
+			name:      "When the queues exist. Then the depth of each is returned.",
+			expStatus: http.StatusOK,
+			expBody: []*deadLetterQueueSummary{
+				{Name: "reset", MessageCount: 3},
+				{Name: "update-principal-policy", MessageCount: 3},
+			},
+		},
+		{
+			name:      "When the depth can't be read. Then an error is returned.",
+			sqsErr:    fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			sqsSvc := awsMocks.SQSAPI{}
+			sqsSvc.On("GetQueueAttributes", mock.AnythingOfType("*sqs.GetQueueAttributesInput")).Return(
+				&sqs.GetQueueAttributesOutput{
+					Attributes: map[string]*string{
+						sqs.QueueAttributeNameApproximateNumberOfMessages: aws.String("3"),
+					},
+				}, tt.sqsErr,
+			)
+			svcBldr.Config.WithService(&sqsSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Path:       "/dlq",
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			body := []*deadLetterQueueSummary{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expBody, body)
+		})
+	}
+}
+
+func TestWhenListDeadLetterMessages(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		path      string
+		messages  []*sqs.Message
+		sqsErr    error
+		expStatus int
+		expBody   []*deadLetterMessage
+	}{
+		{
+			name: "When a Lambda DLQ has messages. Then the failure reason is returned.",
+			path: "/dlq/update-principal-policy/messages",
+			messages: []*sqs.Message{
+				{
+					MessageId: aws.String("1"),
+					Body:      aws.String("{}"),
+					Attributes: map[string]*string{
+						sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("2"),
+						sqs.MessageSystemAttributeNameSentTimestamp:           aws.String("1561149393000"),
+					},
+					MessageAttributes: map[string]*sqs.MessageAttributeValue{
+						"ErrorMessage": {StringValue: aws.String("account not found")},
+						"ErrorCode":    {StringValue: aws.String("200")},
+					},
+				},
+			},
+			expStatus: http.StatusOK,
+			expBody: []*deadLetterMessage{
+				{
+					ID:            "1",
+					Body:          "{}",
+					FailureReason: "account not found",
+					ErrorCode:     "200",
+					ReceiveCount:  2,
+					SentOn:        1561149393,
+				},
+			},
+		},
+		{
+			name:      "When the DLQ is empty. Then an empty list is returned.",
+			path:      "/dlq/reset/messages",
+			expStatus: http.StatusOK,
+			expBody:   []*deadLetterMessage{},
+		},
+		{
+			name:      "When the DLQ doesn't exist. Then not found is returned.",
+			path:      "/dlq/unknown/messages",
+			expStatus: http.StatusNotFound,
+		},
+		{
+			name:      "When messages can't be received. Then an error is returned.",
+			path:      "/dlq/reset/messages",
+			sqsErr:    fmt.Errorf("failure"),
+			expStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			sqsSvc := awsMocks.SQSAPI{}
+			sqsSvc.On("ReceiveMessage", mock.AnythingOfType("*sqs.ReceiveMessageInput")).Return(
+				&sqs.ReceiveMessageOutput{Messages: tt.messages}, tt.sqsErr,
+			)
+			svcBldr.Config.WithService(&sqsSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Path:       tt.path,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			body := []*deadLetterMessage{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expBody, body)
+		})
+	}
+}
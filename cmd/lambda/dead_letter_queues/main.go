@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
+)
+
+type deadLetterQueueConfiguration struct {
+	Debug                         string `env:"DEBUG" envDefault:"false"`
+	ResetQueueURL                 string `env:"RESET_SQS_URL" envDefault:"DefaultResetSQSUrl"`
+	ResetDLQURL                   string `env:"RESET_DLQ_URL" envDefault:"DefaultResetDLQUrl"`
+	UpdatePrincipalPolicyDLQURL   string `env:"UPDATE_PRINCIPAL_POLICY_DLQ_URL" envDefault:"DefaultUpdatePrincipalPolicyDLQUrl"`
+	UpdatePrincipalPolicyFunction string `env:"UPDATE_PRINCIPAL_POLICY_FUNCTION_NAME" envDefault:"DefaultUpdatePrincipalPolicyFunction"`
+}
+
+var (
+	muxLambda *gorillamux.GorillaMuxAdapter
+	// Services handles the configuration of the AWS services
+	Services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	Settings *deadLetterQueueConfiguration
+)
+
+func init() {
+	initConfig()
+
+	log.Println("Cold start; creating router for /dlq")
+	dlqRoutes := api.Routes{
+		api.Route{
+			Name:        "ListDeadLetterQueues",
+			Method:      "GET",
+			Pattern:     "/dlq",
+			Queries:     api.EmptyQueryString,
+			HandlerFunc: ListDeadLetterQueues,
+		},
+		api.Route{
+			Name:        "ListDeadLetterMessages",
+			Method:      "GET",
+			Pattern:     "/dlq/{queueName}/messages",
+			Queries:     api.EmptyQueryString,
+			HandlerFunc: ListDeadLetterMessages,
+		},
+		api.Route{
+			Name:        "ReplayDeadLetterMessages",
+			Method:      "POST",
+			Pattern:     "/dlq/{queueName}/replay",
+			Queries:     api.EmptyQueryString,
+			HandlerFunc: ReplayDeadLetterMessages,
+		},
+	}
+	r := api.NewRouter(dlqRoutes)
+	muxLambda = gorillamux.New(r)
+}
+
+// initConfig configures package-level variables
+// loaded from env vars.
+func initConfig() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	Settings = &deadLetterQueueConfiguration{}
+	if err := cfgBldr.Unmarshal(Settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithSQS().
+		WithLambda().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	Services = svcBldr
+}
+
+// Handler - Handle the lambda function
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return muxLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	// Send Lambda requests to the router
+	lambda.Start(Handler)
+}
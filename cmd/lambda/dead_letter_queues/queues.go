@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+const (
+	resetQueueName                 = "reset"
+	updatePrincipalPolicyQueueName = "update-principal-policy"
+)
+
+// deadLetterQueue is a DLQ which messages can be replayed from
+type deadLetterQueue struct {
+	Name string
+	URL  string
+	// replay sends the message back to where it originally failed
+	replay func(msg *sqs.Message) error
+}
+
+// deadLetterQueues returns all of the DLQs managed by DCE
+func deadLetterQueues() []*deadLetterQueue {
+	return []*deadLetterQueue{
+		{
+			Name:   resetQueueName,
+			URL:    Settings.ResetDLQURL,
+			replay: replayToQueue(Settings.ResetQueueURL),
+		},
+		{
+			Name:   updatePrincipalPolicyQueueName,
+			URL:    Settings.UpdatePrincipalPolicyDLQURL,
+			replay: replayToFunction(Settings.UpdatePrincipalPolicyFunction),
+		},
+	}
+}
+
+// getDeadLetterQueue returns the DLQ with the given name
+func getDeadLetterQueue(name string) (*deadLetterQueue, error) {
+	for _, q := range deadLetterQueues() {
+		if q.Name == name {
+			return q, nil
+		}
+	}
+	return nil, errors.NewNotFound("dead letter queue", name)
+}
+
+// replayToQueue replays messages from a DLQ fed by an SQS redrive policy
+// by sending them back to the source queue
+func replayToQueue(queueURL string) func(msg *sqs.Message) error {
+	return func(msg *sqs.Message) error {
+		sqsSvc, err := sqsService()
+		if err != nil {
+			return err
+		}
+		_, err = sqsSvc.SendMessage(&sqs.SendMessageInput{
+			QueueUrl:    aws.String(queueURL),
+			MessageBody: msg.Body,
+		})
+		if err != nil {
+			return errors.NewInternalServer("unable to send message to sqs", err)
+		}
+		return nil
+	}
+}
+
+// replayToFunction replays messages from a Lambda function's DLQ by
+// invoking the function asynchronously with the original event
+func replayToFunction(functionName string) func(msg *sqs.Message) error {
+	return func(msg *sqs.Message) error {
+		lambdaSvc, err := lambdaService()
+		if err != nil {
+			return err
+		}
+		_, err = lambdaSvc.Invoke(&lambda.InvokeInput{
+			FunctionName:   aws.String(functionName),
+			InvocationType: aws.String(lambda.InvocationTypeEvent),
+			Payload:        []byte(aws.StringValue(msg.Body)),
+		})
+		if err != nil {
+			return errors.NewInternalServer("unable to invoke lambda function "+functionName, err)
+		}
+		return nil
+	}
+}
+
+func sqsService() (sqsiface.SQSAPI, error) {
+	var sqsSvc sqsiface.SQSAPI
+	if err := Services.Config.GetService(&sqsSvc); err != nil {
+		return nil, errors.NewInternalServer("unable to get the SQS service", err)
+	}
+	return sqsSvc, nil
+}
+
+func lambdaService() (lambdaiface.LambdaAPI, error) {
+	var lambdaSvc lambdaiface.LambdaAPI
+	if err := Services.Config.GetService(&lambdaSvc); err != nil {
+		return nil, errors.NewInternalServer("unable to get the Lambda service", err)
+	}
+	return lambdaSvc, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/gorilla/mux"
+)
+
+const (
+	replayStatusReplayed = "Replayed"
+	replayStatusNotFound = "NotFound"
+	replayStatusFailed   = "Failed"
+
+	// replayMaxReceives limits how many batches are read from the DLQ
+	// while looking for the requested messages
+	replayMaxReceives = 10
+	// replayVisibilityTimeout hides messages from other receives while
+	// looking through the DLQ
+	replayVisibilityTimeout = 30
+)
+
+// replayRequest is the body accepted by the replay endpoint
+type replayRequest struct {
+	MessageIDs []string `json:"messageIds"`
+}
+
+// replayResult is the outcome of replaying a single message
+type replayResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// replayResponse is returned by the replay endpoint
+type replayResponse struct {
+	Results []*replayResult `json:"results"`
+}
+
+// ReplayDeadLetterMessages - Sends the requested messages back to where they
+// originally failed, and removes them from the DLQ
+func ReplayDeadLetterMessages(w http.ResponseWriter, r *http.Request) {
+	q, err := getDeadLetterQueue(mux.Vars(r)["queueName"])
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	req := replayRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest(fmt.Sprintf("invalid request parameters: %s", err)))
+		return
+	}
+	if len(req.MessageIDs) == 0 {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("at least one message ID is required"))
+		return
+	}
+
+	sqsSvc, err := sqsService()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	results := map[string]*replayResult{}
+	for _, id := range req.MessageIDs {
+		results[id] = &replayResult{
+			ID:     id,
+			Status: replayStatusNotFound,
+		}
+	}
+
+	// Messages we look at but don't replay are made visible again once we're done
+	release := []*sqs.Message{}
+	defer releaseMessages(sqsSvc, q, &release)
+
+	remaining := len(results)
+	for i := 0; i < replayMaxReceives && remaining > 0; i++ {
+		out, err := sqsSvc.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(q.URL),
+			MaxNumberOfMessages: aws.Int64(10),
+			VisibilityTimeout:   aws.Int64(replayVisibilityTimeout),
+		})
+		if err != nil {
+			api.WriteAPIErrorResponse(w,
+				errors.NewInternalServer("unable to receive messages from dead letter queue "+q.Name, err))
+			return
+		}
+		if len(out.Messages) == 0 {
+			break
+		}
+
+		for _, msg := range out.Messages {
+			result, ok := results[aws.StringValue(msg.MessageId)]
+			if !ok || result.Status != replayStatusNotFound {
+				release = append(release, msg)
+				continue
+			}
+			remaining--
+
+			err := replayMessage(sqsSvc, q, msg)
+			if err != nil {
+				result.Status = replayStatusFailed
+				result.Error = err.Error()
+				release = append(release, msg)
+				continue
+			}
+			result.Status = replayStatusReplayed
+		}
+	}
+
+	res := replayResponse{Results: []*replayResult{}}
+	for _, id := range req.MessageIDs {
+		res.Results = append(res.Results, results[id])
+	}
+	api.WriteAPIResponse(w, http.StatusOK, res)
+}
+
+// replayMessage replays the message and removes it from the DLQ
+func replayMessage(sqsSvc sqsiface.SQSAPI, q *deadLetterQueue, msg *sqs.Message) error {
+	err := q.replay(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(q.URL),
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	if err != nil {
+		return errors.NewInternalServer("message was replayed but could not be removed from dead letter queue "+q.Name, err)
+	}
+	return nil
+}
+
+// releaseMessages makes messages visible on the DLQ again
+func releaseMessages(sqsSvc sqsiface.SQSAPI, q *deadLetterQueue, msgs *[]*sqs.Message) {
+	for _, msg := range *msgs {
+		_, _ = sqsSvc.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(q.URL),
+			ReceiptHandle:     msg.ReceiptHandle,
+			VisibilityTimeout: aws.Int64(0),
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWhenReplayDeadLetterMessages(t *testing.T) {
+
+	messages := []*sqs.Message{
+		{
+			MessageId:     aws.String("1"),
+			ReceiptHandle: aws.String("handle-1"),
+			Body:          aws.String("{\"id\":\"123456789012\"}"),
+		},
+		{
+			MessageId:     aws.String("2"),
+			ReceiptHandle: aws.String("handle-2"),
+			Body:          aws.String("{\"id\":\"210987654321\"}"),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		replayErr    error
+		expStatus    int
+		expResults   []string
+		expSendCalls int
+		expInvokes   int
+		expDeletes   int
+		expReleases  int
+	}{
+		{
+			name:         "When a reset message is replayed. Then it is sent to the reset queue.",
+			path:         "/dlq/reset/replay",
+			body:         `{ "messageIds": ["1", "3"] }`,
+			expStatus:    http.StatusOK,
+			expResults:   []string{replayStatusReplayed, replayStatusNotFound},
+			expSendCalls: 1,
+			expDeletes:   1,
+			expReleases:  1,
+		},
+		{
+			name:        "When a Lambda message is replayed. Then the function is invoked.",
+			path:        "/dlq/update-principal-policy/replay",
+			body:        `{ "messageIds": ["2"] }`,
+			expStatus:   http.StatusOK,
+			expResults:  []string{replayStatusReplayed},
+			expInvokes:  1,
+			expDeletes:  1,
+			expReleases: 1,
+		},
+		{
+			name:         "When the replay fails. Then the message is left on the queue.",
+			path:         "/dlq/reset/replay",
+			body:         `{ "messageIds": ["1"] }`,
+			replayErr:    fmt.Errorf("failure"),
+			expStatus:    http.StatusOK,
+			expResults:   []string{replayStatusFailed},
+			expSendCalls: 1,
+			expReleases:  2,
+		},
+		{
+			name:      "When no message IDs are given. Then a bad request is returned.",
+			path:      "/dlq/reset/replay",
+			body:      `{ "messageIds": [] }`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "When the DLQ doesn't exist. Then not found is returned.",
+			path:      "/dlq/unknown/replay",
+			body:      `{ "messageIds": ["1"] }`,
+			expStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			sqsSvc := awsMocks.SQSAPI{}
+			sqsSvc.On("ReceiveMessage", mock.AnythingOfType("*sqs.ReceiveMessageInput")).Return(
+				&sqs.ReceiveMessageOutput{Messages: messages}, nil,
+			).Once()
+			sqsSvc.On("ReceiveMessage", mock.AnythingOfType("*sqs.ReceiveMessageInput")).Return(
+				&sqs.ReceiveMessageOutput{}, nil,
+			)
+			sqsSvc.On("SendMessage", mock.AnythingOfType("*sqs.SendMessageInput")).Return(
+				&sqs.SendMessageOutput{}, tt.replayErr,
+			)
+			sqsSvc.On("DeleteMessage", mock.AnythingOfType("*sqs.DeleteMessageInput")).Return(
+				&sqs.DeleteMessageOutput{}, nil,
+			)
+			sqsSvc.On("ChangeMessageVisibility", mock.AnythingOfType("*sqs.ChangeMessageVisibilityInput")).Return(
+				&sqs.ChangeMessageVisibilityOutput{}, nil,
+			)
+			lambdaSvc := awsMocks.LambdaAPI{}
+			lambdaSvc.On("Invoke", mock.AnythingOfType("*lambda.InvokeInput")).Return(
+				&lambda.InvokeOutput{}, tt.replayErr,
+			)
+			svcBldr.Config.WithService(&sqsSvc).WithService(&lambdaSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       tt.path,
+				Body:       tt.body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode)
+			if tt.expStatus != http.StatusOK {
+				return
+			}
+
+			res := replayResponse{}
+			err = json.NewDecoder(strings.NewReader(resp.Body)).Decode(&res)
+			assert.Nil(t, err)
+			statuses := []string{}
+			for _, r := range res.Results {
+				statuses = append(statuses, r.Status)
+			}
+			assert.Equal(t, tt.expResults, statuses)
+			sqsSvc.AssertNumberOfCalls(t, "SendMessage", tt.expSendCalls)
+			sqsSvc.AssertNumberOfCalls(t, "DeleteMessage", tt.expDeletes)
+			sqsSvc.AssertNumberOfCalls(t, "ChangeMessageVisibility", tt.expReleases)
+			lambdaSvc.AssertNumberOfCalls(t, "Invoke", tt.expInvokes)
+		})
+	}
+}
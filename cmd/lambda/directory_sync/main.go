@@ -0,0 +1,270 @@
+// Package main offboards principals who have been deactivated in the
+// directory, such as Okta or Azure AD.  Their active leases are ended, the
+// credentials issued for them revoked, and their manager notified.
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/directory"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// oktaDeactivatedEventTypes are the Okta System Log events of users being
+// deactivated or suspended
+var oktaDeactivatedEventTypes = map[string]bool{
+	"user.lifecycle.deactivate": true,
+	"user.lifecycle.suspend":    true,
+}
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// OffboardingEmails are notified when a principal is offboarded, as well
+	// as the principal's manager
+	OffboardingEmails []string `env:"DIRECTORY_OFFBOARDING_EMAILS" envDefault:"" envSeparator:","`
+}
+
+// offboardingEvent reports principals who were deactivated by the SSO
+// integration.  The Lambda is also run on a schedule, with an event which
+// reports none, to check the principal of every active lease.
+type offboardingEvent struct {
+	// PrincipalIDs are set when the Lambda is invoked directly
+	PrincipalIDs []string `json:"principalIds"`
+	// Detail is an Okta System Log event, forwarded by Okta's EventBridge
+	// log stream
+	Detail struct {
+		EventType string `json:"eventType"`
+		Target    []struct {
+			Type        string `json:"type"`
+			AlternateID string `json:"alternateId"`
+		} `json:"target"`
+	} `json:"detail"`
+}
+
+// principalIDs are the principals the event reports as deactivated
+func (e *offboardingEvent) principalIDs() []string {
+	principalIDs := append([]string{}, e.PrincipalIDs...)
+	if oktaDeactivatedEventTypes[e.Detail.EventType] {
+		for _, target := range e.Detail.Target {
+			if target.Type == "User" && target.AlternateID != "" {
+				principalIDs = append(principalIDs, target.AlternateID)
+			}
+		}
+	}
+	return principalIDs
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithAccountManagerService().
+		WithLeaseService().
+		WithDirectoryService().
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+// handler offboards the principals reported by the event, or when it reports
+// none, checks the principal of each active lease in the directory.
+//
+// When checking every lease, principals are only offboarded when they're
+// deactivated, not when they're missing from the directory, so a
+// misconfigured directory can't end every lease.  Reported principals are
+// offboarded unless the directory has them as active, as they may have been
+// deleted rather than deactivated.
+func handler(ctx context.Context, event offboardingEvent) error {
+	directorySvc := services.DirectoryService()
+	reported := event.principalIDs()
+	if len(reported) == 0 && !directorySvc.Enabled() {
+		log.Printf("No directory is configured")
+		return nil
+	}
+
+	leasesByPrincipal, err := activeLeasesByPrincipal(reported)
+	if err != nil {
+		return err
+	}
+	principalIDs := []string{}
+	for principalID := range leasesByPrincipal {
+		principalIDs = append(principalIDs, principalID)
+	}
+	sort.Strings(principalIDs)
+
+	errs := []error{}
+	for _, principalID := range principalIDs {
+		user, err := directorySvc.GetUser(principalID)
+		if err != nil {
+			log.Printf("Failed to look up principal %s: %s", principalID, err)
+			errs = append(errs, err)
+			continue
+		}
+		if user != nil && user.Active {
+			if len(reported) > 0 {
+				log.Printf("Principal %s was reported as deactivated, but is active in the directory", principalID)
+			}
+			continue
+		}
+		if user == nil && len(reported) == 0 {
+			log.Printf("Principal %s is not in the directory", principalID)
+			continue
+		}
+
+		errs = append(errs, offboard(principalID, user, leasesByPrincipal[principalID])...)
+	}
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to sync leases with the directory", errs)
+	}
+	return nil
+}
+
+// activeLeasesByPrincipal gets the active leases of the given principals, or
+// of every principal when none are given
+func activeLeasesByPrincipal(principalIDs []string) (map[string]lease.Leases, error) {
+	leasesByPrincipal := map[string]lease.Leases{}
+	if len(principalIDs) == 0 {
+		err := services.LeaseService().ListPages(&lease.Lease{
+			Status: lease.StatusActive.StatusPtr(),
+		}, func(leases *lease.Leases) bool {
+			for _, l := range *leases {
+				leasesByPrincipal[*l.PrincipalID] = append(leasesByPrincipal[*l.PrincipalID], l)
+			}
+			return true
+		})
+		return leasesByPrincipal, err
+	}
+
+	for _, principalID := range principalIDs {
+		leases, err := services.LeaseService().List(&lease.Lease{
+			PrincipalID: aws.String(principalID),
+			Status:      lease.StatusActive.StatusPtr(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(*leases) == 0 {
+			log.Printf("Principal %s has no active leases", principalID)
+			continue
+		}
+		leasesByPrincipal[principalID] = *leases
+	}
+	return leasesByPrincipal, nil
+}
+
+// offboard revokes the credentials issued for each of the principal's leases,
+// ends them, then notifies the principal's manager.  Every lease is
+// offboarded before the errors are returned, so one failure doesn't leave the
+// rest of the principal's accounts open.
+func offboard(principalID string, user *directory.User, leases lease.Leases) []error {
+	errs := []error{}
+	ended := []notification.Lease{}
+	revokedBefore := time.Now()
+	for _, l := range leases {
+		// Credentials are revoked first, as they'd otherwise work until they
+		// expire, or the account is reset
+		log.Printf("Revoking the credentials of lease %s of deactivated principal %s", *l.ID, principalID)
+		err := revokeSessions(*l.AccountID, revokedBefore)
+		if err != nil {
+			log.Printf("Failed to revoke the credentials of lease %s: %s", *l.ID, err)
+			errs = append(errs, err)
+		}
+
+		log.Printf("Ending lease %s of deactivated principal %s", *l.ID, principalID)
+		_, err = services.LeaseService().End(*l.ID, lease.StatusReasonPrincipalDeactivated)
+		if err != nil {
+			log.Printf("Failed to end lease %s: %s", *l.ID, err)
+			errs = append(errs, err)
+			continue
+		}
+		ended = append(ended, notification.Lease{
+			ID:          aws.StringValue(l.ID),
+			AccountID:   aws.StringValue(l.AccountID),
+			PrincipalID: principalID,
+			Notes:       aws.StringValue(l.Notes),
+		})
+	}
+	if len(ended) == 0 {
+		return errs
+	}
+
+	err := notify(principalID, user, ended)
+	if err != nil {
+		log.Printf("Failed to notify the manager of principal %s: %s", principalID, err)
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// revokeSessions denies the sessions of the account's principal role issued
+// before the given time
+func revokeSessions(accountID string, issuedBefore time.Time) error {
+	account, err := services.AccountService().Get(accountID)
+	if err != nil {
+		return err
+	}
+	return services.AccountManager().RevokePrincipalSessions(account, issuedBefore)
+}
+
+// notify sends the principal's manager, and the offboarding emails, the leases
+// which were ended
+func notify(principalID string, user *directory.User, leases []notification.Lease) error {
+	to := append([]string{}, settings.OffboardingEmails...)
+	data := &notification.Data{
+		Principal: notification.Principal{ID: principalID},
+		Leases:    leases,
+	}
+
+	var managerErr error
+	if user != nil {
+		data.Principal.DisplayName = user.DisplayName
+		data.Principal.Email = user.Email
+		manager, err := services.DirectoryService().GetManager(user)
+		if err != nil {
+			// The offboarding emails are still notified
+			managerErr = err
+		} else if manager != nil {
+			to = append(to, manager.Email)
+		}
+	}
+
+	_, err := services.NotificationService().Send(notification.TemplatePrincipalOffboarded, to, data)
+	if err != nil {
+		return err
+	}
+	return managerErr
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	accountmanagermocks "github.com/Optum/dce/pkg/accountmanager/accountmanageriface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/directory"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ptrString(s string) *string {
+	return &s
+}
+
+type testServices struct {
+	lease          *leasemocks.Servicer
+	directory      *directorymocks.Servicer
+	accountManager *accountmanagermocks.Servicer
+	notification   *notificationmocks.Servicer
+}
+
+func newTestServices(t *testing.T, directoryEnabled bool) *testServices {
+	settings = &configuration{OffboardingEmails: []string{"security@example.com"}}
+	svcs := &testServices{
+		lease:          &leasemocks.Servicer{},
+		directory:      &directorymocks.Servicer{},
+		accountManager: &accountmanagermocks.Servicer{},
+		notification:   &notificationmocks.Servicer{},
+	}
+	svcs.lease.On("End", mock.Anything, lease.StatusReasonPrincipalDeactivated).Return(&lease.Lease{}, nil)
+	svcs.directory.On("Enabled").Return(directoryEnabled)
+	svcs.accountManager.On("RevokePrincipalSessions", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("time.Time")).Return(nil)
+	svcs.notification.On("Send", notification.TemplatePrincipalOffboarded, mock.Anything, mock.AnythingOfType("*notification.Data")).
+		Return(&notification.Email{}, nil)
+
+	accountSvc := &accountmocks.Servicer{}
+	accountSvc.On("Get", mock.AnythingOfType("string")).Return(func(ID string) *account.Account {
+		return &account.Account{ID: &ID}
+	}, nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(svcs.lease).WithService(svcs.directory).WithService(accountSvc).
+		WithService(svcs.accountManager).WithService(svcs.notification)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+	return svcs
+}
+
+func TestDirectorySync(t *testing.T) {
+	activeLeases := lease.Leases{
+		{ID: ptrString("lease-1"), AccountID: ptrString("111111111111"), PrincipalID: ptrString("active@example.com")},
+		{ID: ptrString("lease-2"), AccountID: ptrString("222222222222"), PrincipalID: ptrString("deactivated@example.com")},
+		{ID: ptrString("lease-3"), AccountID: ptrString("333333333333"), PrincipalID: ptrString("deactivated@example.com")},
+		{ID: ptrString("lease-4"), AccountID: ptrString("444444444444"), PrincipalID: ptrString("missing@example.com")},
+		{ID: ptrString("lease-5"), AccountID: ptrString("555555555555"), PrincipalID: ptrString("error@example.com")},
+	}
+	deactivated := &directory.User{ID: "u2", UserName: "deactivated@example.com", DisplayName: "Jane Doe", Active: false}
+
+	svcs := newTestServices(t, true)
+	svcs.lease.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*lease.Leases) bool)
+			fn(&activeLeases)
+		}).
+		Return(nil)
+	svcs.directory.On("GetUser", "active@example.com").Return(&directory.User{Active: true}, nil)
+	svcs.directory.On("GetUser", "deactivated@example.com").Return(deactivated, nil).Once()
+	svcs.directory.On("GetUser", "missing@example.com").Return(nil, nil)
+	svcs.directory.On("GetUser", "error@example.com").Return(nil, fmt.Errorf("unavailable"))
+	svcs.directory.On("GetManager", deactivated).Return(&directory.User{Email: "boss@example.com", Active: true}, nil)
+
+	err := handler(context.TODO(), offboardingEvent{})
+
+	assert.EqualError(t, err, "failed to sync leases with the directory: unavailable")
+	svcs.lease.AssertCalled(t, "End", "lease-2", lease.StatusReasonPrincipalDeactivated)
+	svcs.lease.AssertCalled(t, "End", "lease-3", lease.StatusReasonPrincipalDeactivated)
+	svcs.lease.AssertNumberOfCalls(t, "End", 2)
+	svcs.accountManager.AssertNumberOfCalls(t, "RevokePrincipalSessions", 2)
+	svcs.accountManager.AssertCalled(t, "RevokePrincipalSessions", &account.Account{ID: ptrString("222222222222")}, mock.Anything)
+	svcs.accountManager.AssertCalled(t, "RevokePrincipalSessions", &account.Account{ID: ptrString("333333333333")}, mock.Anything)
+	svcs.notification.AssertNumberOfCalls(t, "Send", 1)
+	svcs.notification.AssertCalled(t, "Send", notification.TemplatePrincipalOffboarded,
+		[]string{"security@example.com", "boss@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Principal.ID == "deactivated@example.com" && data.Principal.DisplayName == "Jane Doe" &&
+				len(data.Leases) == 2 && data.Leases[0].AccountID == "222222222222"
+		}))
+	svcs.directory.AssertExpectations(t)
+}
+
+func TestDirectorySyncReported(t *testing.T) {
+	oktaEvent := offboardingEvent{}
+	err := json.Unmarshal([]byte(`{
+		"source": "aws.partner/okta.com/example/dce",
+		"detail-type": "SystemLog",
+		"detail": {
+			"eventType": "user.lifecycle.deactivate",
+			"target": [{"type": "User", "alternateId": "deleted@example.com"}]
+		}
+	}`), &oktaEvent)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		event    offboardingEvent
+		enabled  bool
+		user     *directory.User
+		expEnded bool
+		expTo    []string
+	}{
+		{
+			name:     "should offboard a principal reported by Okta who is missing from the directory",
+			event:    oktaEvent,
+			enabled:  true,
+			expEnded: true,
+			expTo:    []string{"security@example.com"},
+		},
+		{
+			name:     "should offboard a reported principal without a directory",
+			event:    offboardingEvent{PrincipalIDs: []string{"deleted@example.com"}},
+			expEnded: true,
+			expTo:    []string{"security@example.com"},
+		},
+		{
+			name:    "shouldn't offboard a reported principal who is active in the directory",
+			event:   offboardingEvent{PrincipalIDs: []string{"deleted@example.com"}},
+			enabled: true,
+			user:    &directory.User{Active: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcs := newTestServices(t, tt.enabled)
+			svcs.lease.On("List", &lease.Lease{
+				PrincipalID: ptrString("deleted@example.com"),
+				Status:      lease.StatusActive.StatusPtr(),
+			}).Return(&lease.Leases{
+				{ID: ptrString("lease-1"), AccountID: ptrString("111111111111"), PrincipalID: ptrString("deleted@example.com")},
+			}, nil)
+			svcs.directory.On("GetUser", "deleted@example.com").Return(tt.user, nil)
+
+			err := handler(context.TODO(), tt.event)
+
+			assert.Nil(t, err)
+			if tt.expEnded {
+				svcs.lease.AssertCalled(t, "End", "lease-1", lease.StatusReasonPrincipalDeactivated)
+				svcs.accountManager.AssertNumberOfCalls(t, "RevokePrincipalSessions", 1)
+				svcs.notification.AssertCalled(t, "Send", notification.TemplatePrincipalOffboarded, tt.expTo, mock.Anything)
+			} else {
+				svcs.lease.AssertNotCalled(t, "End", mock.Anything, mock.Anything)
+				svcs.accountManager.AssertNotCalled(t, "RevokePrincipalSessions", mock.Anything, mock.Anything)
+				svcs.notification.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDirectorySyncIgnoresOtherOktaEvents(t *testing.T) {
+	event := offboardingEvent{}
+	event.Detail.EventType = "user.session.start"
+	event.Detail.Target = append(event.Detail.Target, struct {
+		Type        string `json:"type"`
+		AlternateID string `json:"alternateId"`
+	}{Type: "User", AlternateID: "jdoe@example.com"})
+
+	assert.Empty(t, event.principalIDs())
+}
+
+func TestDirectorySyncDisabled(t *testing.T) {
+	svcs := newTestServices(t, false)
+
+	err := handler(context.TODO(), offboardingEvent{})
+
+	assert.Nil(t, err)
+	svcs.lease.AssertNotCalled(t, "ListPages", mock.Anything, mock.Anything)
+}
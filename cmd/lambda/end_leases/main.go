@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// BatchSize is how many leases are ended at a time
+	BatchSize int `env:"END_LEASES_BATCH_SIZE" envDefault:"100"`
+}
+
+// endLeasesEvent lists the leases to end.  When no leases are listed, every
+// active lease which has expired is ended.  When DryRun is set, the leases are
+// checked and listed but not ended.
+type endLeasesEvent struct {
+	LeaseIDs []string `json:"leaseIds"`
+	Reason   string   `json:"reason"`
+	DryRun   bool     `json:"dryRun"`
+}
+
+type endLeasesResult struct {
+	Ended  int  `json:"ended"`
+	Failed int  `json:"failed"`
+	DryRun bool `json:"dryRun,omitempty"`
+	// WouldEnd are the IDs of the leases a dry run would have ended
+	WouldEnd []string `json:"wouldEnd,omitempty"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+	if settings.BatchSize <= 0 {
+		log.Fatalf("END_LEASES_BATCH_SIZE must be positive, got %d", settings.BatchSize)
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func handler(ctx context.Context, event endLeasesEvent) (*endLeasesResult, error) {
+	result := &endLeasesResult{DryRun: event.DryRun}
+	var errs []error
+
+	endBatch := func(leases []*lease.Lease, reason lease.StatusReason) {
+		if len(leases) == 0 {
+			return
+		}
+		if event.DryRun {
+			for _, l := range leases {
+				err := services.LeaseService().ValidateEnd(l)
+				if err != nil {
+					result.Failed++
+					errs = append(errs, err)
+					continue
+				}
+				result.WouldEnd = append(result.WouldEnd, *l.ID)
+			}
+			return
+		}
+		ended, err := services.LeaseService().EndBatch(leases, reason)
+		result.Ended += len(ended)
+		result.Failed += len(leases) - len(ended)
+		if err != nil {
+			log.Printf("Failed to end leases: %s", err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(event.LeaseIDs) > 0 {
+		reason := lease.StatusReasonDestroyed
+		if event.Reason != "" {
+			reason = lease.StatusReason(event.Reason)
+		}
+		leases := []*lease.Lease{}
+		for _, id := range event.LeaseIDs {
+			l, err := services.LeaseService().Get(id)
+			if err != nil {
+				result.Failed++
+				errs = append(errs, err)
+				continue
+			}
+			leases = append(leases, l)
+			if len(leases) == settings.BatchSize {
+				endBatch(leases, reason)
+				leases = []*lease.Lease{}
+			}
+		}
+		endBatch(leases, reason)
+	} else {
+		now := time.Now().Unix()
+		expired := []*lease.Lease{}
+		err := services.LeaseService().ListPages(&lease.Lease{
+			Status: lease.StatusActive.StatusPtr(),
+		}, func(leases *lease.Leases) bool {
+			for i := range *leases {
+				l := (*leases)[i]
+				if l.ExpiresOn != nil && *l.ExpiresOn <= now {
+					expired = append(expired, &l)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		// End the leases once they're all listed, so the pages being
+		// read aren't changed as the leases are ended
+		for start := 0; start < len(expired); start += settings.BatchSize {
+			end := start + settings.BatchSize
+			if end > len(expired) {
+				end = len(expired)
+			}
+			endBatch(expired[start:end], lease.StatusReasonExpired)
+		}
+	}
+
+	if event.DryRun {
+		log.Printf("Dry run would end %d leases, and fail to end %d leases", len(result.WouldEnd), result.Failed)
+	} else {
+		log.Printf("Ended %d leases, failed to end %d leases", result.Ended, result.Failed)
+	}
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("error when ending leases", errs)
+	}
+	return result, nil
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("end_leases", handler))
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLease(id string, expiresOn int64) lease.Lease {
+	return *testutil.NewLeaseBuilder().WithID(id).ExpiresOn(expiresOn).Build()
+}
+
+func withLeaseService(t *testing.T, leaseSvc *leasemocks.Servicer) {
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+}
+
+func TestEndExpiredLeases(t *testing.T) {
+	now := time.Now().Unix()
+	leases := lease.Leases{
+		newLease("expired-1", now-60),
+		newLease("active", now+3600),
+		newLease("expired-2", now-3600),
+		newLease("expired-3", now),
+	}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&leases)
+		}).
+		Return(nil)
+	// The leases are ended two at a time
+	leaseSvc.On("EndBatch", mock.Anything, lease.StatusReasonExpired).
+		Return(func(leases []*lease.Lease, reason lease.StatusReason) []*lease.Lease {
+			if *leases[0].ID == "expired-3" {
+				return []*lease.Lease{}
+			}
+			return leases
+		}, func(leases []*lease.Lease, reason lease.StatusReason) error {
+			if *leases[0].ID == "expired-3" {
+				return errors.NewConflict("lease", "expired-3", fmt.Errorf("modified"))
+			}
+			return nil
+		})
+	withLeaseService(t, leaseSvc)
+	settings.BatchSize = 2
+
+	result, err := handler(context.TODO(), endLeasesEvent{})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &endLeasesResult{Ended: 2, Failed: 1}, result)
+	leaseSvc.AssertNumberOfCalls(t, "EndBatch", 2)
+	batch := leaseSvc.Calls[1].Arguments.Get(0).([]*lease.Lease)
+	assert.Equal(t, "expired-1", *batch[0].ID)
+	assert.Equal(t, "expired-2", *batch[1].ID)
+}
+
+func TestEndListedLeases(t *testing.T) {
+	first := newLease("first", 0)
+	second := newLease("second", 0)
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("Get", "first").Return(&first, nil)
+	leaseSvc.On("Get", "second").Return(&second, nil)
+	leaseSvc.On("Get", "missing").Return(nil, errors.NewNotFound("lease", "missing"))
+	leaseSvc.On("EndBatch", []*lease.Lease{&first, &second}, lease.StatusReasonDestroyed).
+		Return([]*lease.Lease{&first, &second}, nil)
+	withLeaseService(t, leaseSvc)
+	settings.BatchSize = 100
+
+	result, err := handler(context.TODO(), endLeasesEvent{
+		LeaseIDs: []string{"first", "missing", "second"},
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &endLeasesResult{Ended: 2, Failed: 1}, result)
+	leaseSvc.AssertExpectations(t)
+}
+
+func TestEndLeasesDryRun(t *testing.T) {
+	now := time.Now().Unix()
+	leases := lease.Leases{
+		newLease("expired-1", now-60),
+		newLease("active", now+3600),
+		newLease("expired-2", now-3600),
+	}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&leases)
+		}).
+		Return(nil)
+	leaseSvc.On("ValidateEnd", mock.MatchedBy(func(l *lease.Lease) bool { return *l.ID == "expired-1" })).
+		Return(nil)
+	leaseSvc.On("ValidateEnd", mock.MatchedBy(func(l *lease.Lease) bool { return *l.ID == "expired-2" })).
+		Return(errors.NewConflict("lease", "expired-2", fmt.Errorf("leaseStatus: must be active lease")))
+	withLeaseService(t, leaseSvc)
+	settings.BatchSize = 100
+
+	result, err := handler(context.TODO(), endLeasesEvent{DryRun: true})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &endLeasesResult{Failed: 1, DryRun: true, WouldEnd: []string{"expired-1"}}, result)
+	leaseSvc.AssertNotCalled(t, "EndBatch", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/api/response"
+	"github.com/gorilla/schema"
+)
+
+// ListEvents - Returns the activity feed, newest first
+func ListEvents(w http.ResponseWriter, r *http.Request) {
+	var decoder = schema.NewDecoder()
+
+	query := &activity.Query{}
+	err := decoder.Decode(query, r.URL.Query())
+	if err != nil {
+		response.WriteRequestValidationError(w, fmt.Sprintf("Error parsing query params: %s", err))
+		return
+	}
+
+	events, err := Services.ActivityService().List(query)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	// The next page covers the same period, so it's the same as the period
+	// of this page when no since or until was given
+	if query.NextDay != nil {
+		nextURL, err := api.BuildNextURL(baseRequest, query)
+		if err != nil {
+			api.WriteAPIErrorResponse(w, err)
+			return
+		}
+		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextURL.String()))
+	}
+	api.WriteAPIResponse(w, http.StatusOK, events)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/activity/activityiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListEvents(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name      string
+		rawQuery  string
+		expQuery  *activity.Query
+		expResp   response
+		expLink   string
+		retEvents *activity.Events
+		nextDay   *string
+		nextID    *string
+		retErr    error
+	}{
+		{
+			name:     "list events",
+			rawQuery: "resource=lease&resourceId=abc&since=100",
+			expQuery: &activity.Query{
+				Resource:   aws.String("lease"),
+				ResourceID: aws.String("abc"),
+				Since:      aws.Int64(100),
+			},
+			expResp: response{
+				StatusCode: 200,
+				Body:       "[{\"id\":\"1\",\"type\":\"LeaseCreated\",\"resource\":\"lease\",\"resourceId\":\"abc\",\"timestamp\":200,\"data\":{\"id\":\"abc\"}}]\n",
+			},
+			retEvents: &activity.Events{
+				{
+					ID:         "1",
+					Type:       "LeaseCreated",
+					Resource:   "lease",
+					ResourceID: "abc",
+					Timestamp:  200,
+					Data:       []byte("{\"id\":\"abc\"}"),
+				},
+			},
+		},
+		{
+			name:     "list paged events",
+			rawQuery: "type=LeaseEnded&limit=1",
+			expQuery: &activity.Query{
+				Type:  aws.String("LeaseEnded"),
+				Limit: aws.Int64(1),
+			},
+			expResp: response{
+				StatusCode: 200,
+				Body:       "[]\n",
+			},
+			retEvents: &activity.Events{},
+			nextDay:   aws.String("2020-01-02"),
+			nextID:    aws.String("2"),
+			expLink:   "<https://example.com/unit/events?limit=1&nextDay=2020-01-02&nextId=2&type=LeaseEnded>; rel=\"next\"",
+		},
+		{
+			name:     "invalid query",
+			rawQuery: "since=yesterday",
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"code\":\"RequestValidationError\",\"message\":\"Error parsing query params: schema: error converting value for \\\"since\\\"\"}}",
+			},
+		},
+		{
+			name:     "invalid period",
+			rawQuery: "since=200&until=100",
+			expQuery: &activity.Query{
+				Since: aws.Int64(200),
+				Until: aws.Int64(100),
+			},
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"activity validation error: since: must be before until.\",\"code\":\"RequestValidationError\"}}\n",
+			},
+			retErr: errors.NewValidation("activity", fmt.Errorf("since: must be before until.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://example.com/events?"+tt.rawQuery, nil)
+
+			baseRequest = url.URL{}
+			baseRequest.Scheme = "https"
+			baseRequest.Host = "example.com"
+			baseRequest.Path = fmt.Sprintf("%s%s", "unit", "/events")
+			w := httptest.NewRecorder()
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			activitySvc := mocks.Servicer{}
+			activitySvc.On("List", mock.MatchedBy(func(query *activity.Query) bool {
+				if !assert.Equal(t, tt.expQuery, query) {
+					return false
+				}
+				query.NextDay = tt.nextDay
+				query.NextID = tt.nextID
+				return true
+			})).Return(tt.retEvents, tt.retErr)
+			svcBldr.Config.WithService(&activitySvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			ListEvents(w, r)
+
+			resp := w.Result()
+			body, err := ioutil.ReadAll(resp.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expResp.Body, string(body))
+			assert.Equal(t, tt.expLink, w.Header().Get("Link"))
+		})
+	}
+}
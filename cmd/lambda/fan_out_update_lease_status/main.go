@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"log"
+	"time"
 
+	"github.com/Optum/dce/pkg/awsiface"
+	"github.com/Optum/dce/pkg/budget"
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
-	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go/aws"
 	lambdaSDK "github.com/aws/aws-sdk-go/service/lambda"
@@ -17,6 +21,28 @@ import (
 type configuration struct {
 	Debug         string `env:"DEBUG" envDefault:"false"`
 	LeaseFunction string `env:"UPDATE_LEASE_STATUS_FUNCTION_NAME" envDefault:"UpdateLeaseStatusFunction"`
+	// Concurrency is how many leases the lambda is invoked for at a time
+	Concurrency int `env:"FAN_OUT_CONCURRENCY" envDefault:"10"`
+	// SkipExpiredLeases leaves expired leases to the end_leases lambda,
+	// which ends them in bulk
+	SkipExpiredLeases bool `env:"SKIP_EXPIRED_LEASES" envDefault:"false"`
+	// BatchCostExplorer collects the spend of every lease account with
+	// batched Cost Explorer calls in the master account, instead of a call
+	// in each account
+	BatchCostExplorer         bool `env:"BATCH_COST_EXPLORER" envDefault:"false"`
+	CostExplorerBatchSize     int  `env:"COST_EXPLORER_BATCH_SIZE" envDefault:"100"`
+	CostExplorerMinIntervalMs int  `env:"COST_EXPLORER_MIN_INTERVAL_MS" envDefault:"200"`
+	CostExplorerMaxIntervalMs int  `env:"COST_EXPLORER_MAX_INTERVAL_MS" envDefault:"30000"`
+	CostExplorerMaxAttempts   int  `env:"COST_EXPLORER_MAX_ATTEMPTS" envDefault:"6"`
+	// BatchSize is how many Active leases each invocation checks.  When there
+	// are more, the lambda invokes itself to check the next batch, so large
+	// deployments aren't checked in one run that times out.
+	BatchSize int64 `env:"FAN_OUT_BATCH_SIZE" envDefault:"500"`
+	// FanOutFunction is the name of this lambda, which it invokes itself with
+	FanOutFunction string `env:"AWS_LAMBDA_FUNCTION_NAME" envDefault:"FanOutUpdateLeaseStatusFunction"`
+	// CheckIntervalMinutes is how long after a lease's budget was checked it's
+	// checked again.  Zero checks every lease on every run.
+	CheckIntervalMinutes int64 `env:"BUDGET_CHECK_INTERVAL_MINUTES" envDefault:"0"`
 }
 
 var (
@@ -39,10 +65,13 @@ func init() {
 	}
 	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
 
-	_, err = svcBldr.
+	svcBldr.
 		WithLambda().
-		WithLeaseService().
-		Build()
+		WithLeaseService()
+	if settings.BatchCostExplorer {
+		svcBldr.WithCostExplorer()
+	}
+	_, err = svcBldr.Build()
 	if err != nil {
 		panic(err)
 	}
@@ -51,10 +80,27 @@ func init() {
 
 }
 
-func handler(cloudWatchEvent events.CloudWatchEvent) error {
+// leaseStatusEvent is the lease the update_lease_status lambda is invoked
+// with, and the spend of its account today when it was collected in a batch
+type leaseStatusEvent struct {
+	lease.Lease
+	TodaySpend *float64 `json:"todaySpend,omitempty"`
+}
+
+// fanOutEvent is the scheduled event the lambda is triggered with, or the
+// batch of leases to continue from when it invokes itself
+type fanOutEvent struct {
+	NextAccountID   *string `json:"nextAccountId,omitempty"`
+	NextPrincipalID *string `json:"nextPrincipalId,omitempty"`
+}
+
+func handler(event fanOutEvent) error {
 
 	query := &lease.Lease{
-		Status: lease.StatusActive.StatusPtr(),
+		Status:          lease.StatusActive.StatusPtr(),
+		Limit:           aws.Int64(settings.BatchSize),
+		NextAccountID:   event.NextAccountID,
+		NextPrincipalID: event.NextPrincipalID,
 	}
 
 	var lambdaSvc lambdaiface.LambdaAPI
@@ -63,39 +109,77 @@ func handler(cloudWatchEvent events.CloudWatchEvent) error {
 		return err
 	}
 
+	// List the batch of leases first, so the spend of their accounts can be
+	// collected together
+	now := time.Now().Unix()
+	checkedSince := now - settings.CheckIntervalMinutes*60
+	page, err := services.LeaseService().List(query)
+	if err != nil {
+		return err
+	}
+	leases := []lease.Lease{}
+	for _, ls := range *page {
+		if settings.SkipExpiredLeases && ls.ExpiresOn != nil && *ls.ExpiresOn <= now {
+			continue
+		}
+		// Leave leases which were checked recently until they're due
+		if settings.CheckIntervalMinutes > 0 && ls.LastCheckedOn != nil && *ls.LastCheckedOn > checkedSince {
+			continue
+		}
+		leases = append(leases, ls)
+	}
+	log.Printf("Checking %d of %d Active leases in the batch", len(leases), len(*page))
+
 	var errs []error
+	// Continue with the next batch in another invocation, so each invocation
+	// checks a bounded number of leases
+	if query.NextAccountID != nil && query.NextPrincipalID != nil {
+		err = invokeNextBatch(lambdaSvc, fanOutEvent{
+			NextAccountID:   query.NextAccountID,
+			NextPrincipalID: query.NextPrincipalID,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	spend := map[string]float64{}
+	if settings.BatchCostExplorer {
+		spend = accountSpend(leases)
+	}
 
-	err = services.LeaseService().ListPages(query,
-		func(leases *lease.Leases) bool {
-			for _, ls := range *leases {
-				leaseJSON, err := json.Marshal(&ls)
-				// save any errors to handle later
-				if err != nil {
-					errs = append(errs, err)
-					continue
-				}
-				// Invoke the fan_out_update_lease_status lambda
-				log.Printf("Invoking lambda %s with lease %s @ %s",
-					settings.LeaseFunction, *ls.PrincipalID, *ls.AccountID)
-				_, err = lambdaSvc.Invoke(&lambdaSDK.InvokeInput{
-					FunctionName:   aws.String(settings.LeaseFunction),
-					InvocationType: aws.String("Event"),
-					Payload:        leaseJSON,
-				})
-				// save any errors to handle later
-				if err != nil {
-					log.Printf("Failed to invoke lambda %s with lease %s @ %s: %s",
-						settings.LeaseFunction, *ls.PrincipalID, *ls.AccountID, err)
-					errs = append(errs, err)
-					continue
-				}
+	// Invoke the lambda for several leases at a time
+	pool := common.NewWorkerPool(context.Background(), settings.Concurrency)
+	for _, ls := range leases {
+		event := leaseStatusEvent{Lease: ls}
+		if todaySpend, ok := spend[*ls.AccountID]; ok {
+			event.TodaySpend = aws.Float64(todaySpend)
+		}
+		leaseJSON, err := json.Marshal(&event)
+		// save any errors to handle later
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		principalID, accountID := *ls.PrincipalID, *ls.AccountID
+		pool.Submit(func(ctx context.Context) error {
+			// Invoke the fan_out_update_lease_status lambda
+			log.Printf("Invoking lambda %s with lease %s @ %s",
+				settings.LeaseFunction, principalID, accountID)
+			_, err := lambdaSvc.Invoke(&lambdaSDK.InvokeInput{
+				FunctionName:   aws.String(settings.LeaseFunction),
+				InvocationType: aws.String("Event"),
+				Payload:        leaseJSON,
+			})
+			if err != nil {
+				log.Printf("Failed to invoke lambda %s with lease %s @ %s: %s",
+					settings.LeaseFunction, principalID, accountID, err)
 			}
-			return true //always continue
-		},
-	)
-	if err != nil {
-		return err
+			return err
+		})
 	}
+	// save any errors to handle later
+	errs = append(errs, pool.Wait()...)
 
 	if len(errs) > 0 {
 		return errors.NewMultiError("error when processing accounts", errs)
@@ -103,6 +187,69 @@ func handler(cloudWatchEvent events.CloudWatchEvent) error {
 	return nil
 }
 
+// invokeNextBatch invokes this lambda asynchronously, to check the batch of
+// leases after the event's keys
+func invokeNextBatch(lambdaSvc lambdaiface.LambdaAPI, event fanOutEvent) error {
+	payload, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+	log.Printf("Invoking lambda %s with the leases after %s @ %s",
+		settings.FanOutFunction, *event.NextPrincipalID, *event.NextAccountID)
+	_, err = lambdaSvc.Invoke(&lambdaSDK.InvokeInput{
+		FunctionName:   aws.String(settings.FanOutFunction),
+		InvocationType: aws.String("Event"),
+		Payload:        payload,
+	})
+	if err != nil {
+		log.Printf("Failed to invoke lambda %s with the leases after %s @ %s: %s",
+			settings.FanOutFunction, *event.NextPrincipalID, *event.NextAccountID, err)
+	}
+	return err
+}
+
+// accountSpend collects today's spend of the lease accounts with batched
+// Cost Explorer calls. Only accounts with an active lease are included.  When
+// it fails, the update_lease_status lambda gets the spend of each account.
+func accountSpend(leases []lease.Lease) map[string]float64 {
+	var costExplorer awsiface.CostExplorerAPI
+	err := services.Config.GetService(&costExplorer)
+	if err != nil {
+		log.Printf("Failed to get the Cost Explorer service: %s", err)
+		return map[string]float64{}
+	}
+
+	seen := map[string]bool{}
+	accountIDs := []string{}
+	for _, ls := range leases {
+		if !seen[*ls.AccountID] {
+			seen[*ls.AccountID] = true
+			accountIDs = append(accountIDs, *ls.AccountID)
+		}
+	}
+	if len(accountIDs) == 0 {
+		return map[string]float64{}
+	}
+
+	spender := &budget.LinkedAccountSpend{
+		CostExplorer: costExplorer,
+		Pacer: budget.NewPacer(
+			time.Duration(settings.CostExplorerMinIntervalMs)*time.Millisecond,
+			time.Duration(settings.CostExplorerMaxIntervalMs)*time.Millisecond,
+		),
+		BatchSize:   settings.CostExplorerBatchSize,
+		MaxAttempts: settings.CostExplorerMaxAttempts,
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	spend, err := spender.CalculateSpendByAccount(accountIDs, today, today.AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("Failed to collect the spend of %d accounts, the spend of each account will be checked on its own: %s", len(accountIDs), err)
+		return map[string]float64{}
+	}
+	log.Printf("Collected the spend of %d accounts", len(accountIDs))
+	return spend
+}
+
 // Start the Lambda Handler
 func main() {
 	lambda.Start(handler)
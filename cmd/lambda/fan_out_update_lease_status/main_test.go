@@ -1,19 +1,22 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/data/dataiface/mocks"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
-	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
 	lambdaSDK "github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func ptrString(s string) *string {
@@ -26,11 +29,13 @@ func TestLambdaHandler(t *testing.T) {
 		input *lambdaSDK.InvokeInput
 		err   error
 	}
+	expiresOn := time.Now().Unix() + 3600
 	tests := []struct {
 		name         string
 		retLeases    *lease.Leases
 		retLeasesErr error
 		retLambda    []lambdaInvoke
+		skipExpired  bool
 		expErr       error
 	}{
 		{
@@ -64,6 +69,33 @@ func TestLambdaHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "when skipping expired leases. Only unexpired leases are checked",
+			retLeases: &lease.Leases{
+				{
+					ID:          ptrString("abc-123"),
+					AccountID:   ptrString("123456789012"),
+					PrincipalID: ptrString("TestUser1"),
+					ExpiresOn:   aws.Int64(expiresOn),
+				},
+				{
+					ID:          ptrString("def-456"),
+					AccountID:   ptrString("123456789013"),
+					PrincipalID: ptrString("TestUser2"),
+					ExpiresOn:   aws.Int64(1),
+				},
+			},
+			skipExpired: true,
+			retLambda: []lambdaInvoke{
+				{
+					input: &lambdaSDK.InvokeInput{
+						FunctionName:   aws.String("UpdateLeaseStatusFunction"),
+						InvocationType: aws.String("Event"),
+						Payload:        []byte(fmt.Sprintf("{\"accountId\":\"123456789012\",\"principalId\":\"TestUser1\",\"id\":\"abc-123\",\"expiresOn\":%d}", expiresOn)),
+					},
+				},
+			},
+		},
 		{
 			name:         "when getting leases fails return the failure",
 			retLeases:    nil,
@@ -129,6 +161,7 @@ func TestLambdaHandler(t *testing.T) {
 			dataSvc := mocks.LeaseData{}
 			dataSvc.On("List", &lease.Lease{
 				Status: lease.StatusActive.StatusPtr(),
+				Limit:  aws.Int64(500),
 			}).Return(tt.retLeases, tt.retLeasesErr)
 			lambdaSvc := awsMocks.LambdaAPI{}
 			for _, m := range tt.retLambda {
@@ -147,9 +180,11 @@ func TestLambdaHandler(t *testing.T) {
 				services = svcBldr
 			}
 
-			err = handler(events.CloudWatchEvent{})
+			settings.SkipExpiredLeases = tt.skipExpired
+			err = handler(fanOutEvent{})
 
 			lambdaSvc.AssertExpectations(t)
+			lambdaSvc.AssertNumberOfCalls(t, "Invoke", len(tt.retLambda))
 
 			if err != nil {
 				log.Printf("%+s", err.Error())
@@ -159,3 +194,173 @@ func TestLambdaHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestLambdaHandlerBatchCostExplorer(t *testing.T) {
+	leases := &lease.Leases{
+		{
+			ID:          ptrString("abc-123"),
+			AccountID:   ptrString("123456789012"),
+			PrincipalID: ptrString("TestUser1"),
+		},
+		{
+			ID:          ptrString("def-456"),
+			AccountID:   ptrString("123456789012"),
+			PrincipalID: ptrString("TestUser2"),
+		},
+		{
+			ID:          ptrString("ghi-789"),
+			AccountID:   ptrString("123456789013"),
+			PrincipalID: ptrString("TestUser3"),
+		},
+	}
+
+	dataSvc := mocks.LeaseData{}
+	dataSvc.On("List", &lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+		Limit:  aws.Int64(500),
+	}).Return(leases, nil)
+	// The spend of both accounts is collected in one call
+	costExplorer := awsMocks.CostExplorerAPI{}
+	costExplorer.On("GetCostAndUsage", mock.MatchedBy(func(input *costexplorer.GetCostAndUsageInput) bool {
+		return len(input.Filter.Dimensions.Values) == 2
+	})).Return(&costexplorer.GetCostAndUsageOutput{
+		ResultsByTime: []*costexplorer.ResultByTime{{
+			Groups: []*costexplorer.Group{{
+				Keys: aws.StringSlice([]string{"123456789012"}),
+				Metrics: map[string]*costexplorer.MetricValue{
+					"UnblendedCost": {Amount: aws.String("12.5"), Unit: aws.String("USD")},
+				},
+			}},
+		}},
+	}, nil).Once()
+	lambdaSvc := awsMocks.LambdaAPI{}
+	lambdaSvc.On("Invoke", mock.Anything).Return(nil, nil)
+
+	leaseSvc := lease.NewService(lease.NewServiceInput{
+		DataSvc: &dataSvc,
+	})
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(&lambdaSvc).WithService(&costExplorer)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+	settings.BatchCostExplorer = true
+	defer func() { settings.BatchCostExplorer = false }()
+
+	err = handler(fanOutEvent{})
+
+	assert.Nil(t, err)
+	costExplorer.AssertExpectations(t)
+	payloads := []string{}
+	for _, call := range lambdaSvc.Calls {
+		payloads = append(payloads, string(call.Arguments[0].(*lambdaSDK.InvokeInput).Payload))
+	}
+	assert.ElementsMatch(t, []string{
+		"{\"accountId\":\"123456789012\",\"principalId\":\"TestUser1\",\"id\":\"abc-123\",\"todaySpend\":12.5}",
+		"{\"accountId\":\"123456789012\",\"principalId\":\"TestUser2\",\"id\":\"def-456\",\"todaySpend\":12.5}",
+		"{\"accountId\":\"123456789013\",\"principalId\":\"TestUser3\",\"id\":\"ghi-789\",\"todaySpend\":0}",
+	}, payloads)
+}
+
+func TestLambdaHandlerNextBatch(t *testing.T) {
+	dataSvc := mocks.LeaseData{}
+	dataSvc.On("List", &lease.Lease{
+		Status:          lease.StatusActive.StatusPtr(),
+		Limit:           aws.Int64(500),
+		NextAccountID:   ptrString("123456789011"),
+		NextPrincipalID: ptrString("TestUser0"),
+	}).Run(func(args mock.Arguments) {
+		// There are more leases after this batch
+		query := args.Get(0).(*lease.Lease)
+		query.NextAccountID = ptrString("123456789012")
+		query.NextPrincipalID = ptrString("TestUser1")
+	}).Return(&lease.Leases{
+		{
+			ID:          ptrString("abc-123"),
+			AccountID:   ptrString("123456789012"),
+			PrincipalID: ptrString("TestUser1"),
+		},
+	}, nil)
+	lambdaSvc := awsMocks.LambdaAPI{}
+	// The next batch is checked by another invocation
+	lambdaSvc.On("Invoke", &lambdaSDK.InvokeInput{
+		FunctionName:   aws.String("FanOutUpdateLeaseStatusFunction"),
+		InvocationType: aws.String("Event"),
+		Payload:        []byte("{\"nextAccountId\":\"123456789012\",\"nextPrincipalId\":\"TestUser1\"}"),
+	}).Return(nil, nil)
+	lambdaSvc.On("Invoke", &lambdaSDK.InvokeInput{
+		FunctionName:   aws.String("UpdateLeaseStatusFunction"),
+		InvocationType: aws.String("Event"),
+		Payload:        []byte("{\"accountId\":\"123456789012\",\"principalId\":\"TestUser1\",\"id\":\"abc-123\"}"),
+	}).Return(nil, nil)
+
+	leaseSvc := lease.NewService(lease.NewServiceInput{
+		DataSvc: &dataSvc,
+	})
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(&lambdaSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	err = handler(fanOutEvent{
+		NextAccountID:   ptrString("123456789011"),
+		NextPrincipalID: ptrString("TestUser0"),
+	})
+
+	assert.Nil(t, err)
+	lambdaSvc.AssertExpectations(t)
+	lambdaSvc.AssertNumberOfCalls(t, "Invoke", 2)
+}
+
+func TestLambdaHandlerCheckInterval(t *testing.T) {
+	now := time.Now().Unix()
+	dataSvc := mocks.LeaseData{}
+	dataSvc.On("List", &lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+		Limit:  aws.Int64(500),
+	}).Return(&lease.Leases{
+		{
+			ID:            ptrString("abc-123"),
+			AccountID:     ptrString("123456789012"),
+			PrincipalID:   ptrString("TestUser1"),
+			LastCheckedOn: aws.Int64(now - 60),
+		},
+		{
+			ID:            ptrString("def-456"),
+			AccountID:     ptrString("123456789013"),
+			PrincipalID:   ptrString("TestUser2"),
+			LastCheckedOn: aws.Int64(now - 3600),
+		},
+		{
+			ID:          ptrString("ghi-789"),
+			AccountID:   ptrString("123456789014"),
+			PrincipalID: ptrString("TestUser3"),
+		},
+	}, nil)
+	lambdaSvc := awsMocks.LambdaAPI{}
+	lambdaSvc.On("Invoke", mock.Anything).Return(nil, nil)
+
+	leaseSvc := lease.NewService(lease.NewServiceInput{
+		DataSvc: &dataSvc,
+	})
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(&lambdaSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+	settings.CheckIntervalMinutes = 30
+	defer func() { settings.CheckIntervalMinutes = 0 }()
+
+	err = handler(fanOutEvent{})
+
+	assert.Nil(t, err)
+	// The lease checked a minute ago isn't due
+	leaseIDs := []string{}
+	for _, call := range lambdaSvc.Calls {
+		event := leaseStatusEvent{}
+		assert.Nil(t, json.Unmarshal(call.Arguments[0].(*lambdaSDK.InvokeInput).Payload, &event))
+		leaseIDs = append(leaseIDs, *event.ID)
+	}
+	assert.ElementsMatch(t, []string{"def-456", "ghi-789"}, leaseIDs)
+}
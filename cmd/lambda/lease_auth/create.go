@@ -26,6 +26,9 @@ type CreateController struct {
 	ConsoleURL    string
 	FederationURL string
 	UserDetailer  api.UserDetailer
+	// SessionDuration is how many seconds the credentials and console
+	// session last, or the STS default of an hour when 0
+	SessionDuration int64
 }
 
 // Call - function to return a specific AWS Lease record to the request
@@ -55,7 +58,7 @@ func (controller CreateController) Call(ctx context.Context, req *events.APIGate
 	// Get the User Information
 	user := controller.UserDetailer.GetUser(&req.RequestContext)
 	if user.Role != api.AdminGroupName {
-		if lease.PrincipalID != user.Username {
+		if lease.PrincipalID != user.Username && !lease.IsSharedWith(user.Username) {
 			log.Printf("User (%s) doesn't have access to lease %s", user.Username, leaseID)
 			return response.NotFoundError(), nil
 		}
@@ -78,6 +81,11 @@ func (controller CreateController) Call(ctx context.Context, req *events.APIGate
 	}
 
 	log.Printf("Assuming Role: %s", account.PrincipalRoleArn)
+	// The role session is named after the caller, so CloudTrail attributes
+	// the actions of principals the lease is shared with to them
+	if user.Username != "" && user.Username != lease.PrincipalID {
+		log.Printf("User (%s) is getting credentials for lease %s of %s", user.Username, leaseID, lease.PrincipalID)
+	}
 	roleSessionName := user.Username
 	if roleSessionName == "" {
 		roleSessionName = lease.PrincipalID
@@ -86,6 +94,9 @@ func (controller CreateController) Call(ctx context.Context, req *events.APIGate
 		RoleArn:         &account.PrincipalRoleArn,
 		RoleSessionName: aws.String(roleSessionName),
 	}
+	if controller.SessionDuration > 0 {
+		assumeRoleInputs.DurationSeconds = aws.Int64(controller.SessionDuration)
+	}
 	assumeRoleOutput, err := controller.TokenService.AssumeRole(
 		&assumeRoleInputs,
 	)
@@ -105,6 +116,9 @@ func (controller CreateController) Call(ctx context.Context, req *events.APIGate
 		SessionToken:    *assumeRoleOutput.Credentials.SessionToken,
 		ConsoleURL:      consoleURL,
 	}
+	if assumeRoleOutput.Credentials.Expiration != nil {
+		result.ExpiresOn = assumeRoleOutput.Credentials.Expiration.Unix()
+	}
 	return response.CreateAPIGatewayJSONResponse(http.StatusCreated, result), nil
 }
 
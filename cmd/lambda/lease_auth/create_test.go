@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Optum/dce/pkg/api"
 	apiMocks "github.com/Optum/dce/pkg/api/mocks"
@@ -47,6 +48,7 @@ func TestGetLeaseAuth(t *testing.T) {
 			leaseStatus      db.LeaseStatus
 			userName         string
 			userRole         string
+			sharedWith       []string
 		}{
 			{
 				name:      "WorkingPath",
@@ -195,6 +197,30 @@ func TestGetLeaseAuth(t *testing.T) {
 				userRole:         api.AdminGroupName,
 				principalRoleArn: "arn:aws:iam::Account123:role/Principal",
 			},
+			{
+				name:      "LeaseSharedWithUser",
+				accountID: "Account123",
+				leaseID:   "LeaseABC",
+				expectedResponse: &events.APIGatewayProxyResponse{
+					StatusCode: 201,
+					Headers: map[string]string{
+						"Content-Type":                "application/json",
+						"Access-Control-Allow-Origin": "*",
+					},
+					Body: fmt.Sprintf(
+						`{"accessKeyId":"ExampleKey","secretAccessKey":"ExampleSecret","sessionToken":"ExampleSession","consoleUrl":"%s"}`,
+						fmt.Sprintf(
+							`%s?Action=login\u0026Destination=%s\u0026Issuer=DCE\u0026SigninToken=ExampleSigninToken`,
+							federationURL,
+							url.QueryEscape(consoleURL)),
+					),
+				},
+				leaseStatus:      db.Active,
+				userName:         "TestUser",
+				userRole:         api.UserGroupName,
+				sharedWith:       []string{"TestUser"},
+				principalRoleArn: "arn:aws:iam::Account123:role/Principal",
+			},
 			{
 				name:            "UserHasNoAccessToLease",
 				leaseID:         "Lease987",
@@ -245,7 +271,9 @@ func TestGetLeaseAuth(t *testing.T) {
 					expectedLease = &db.Lease{
 						ID:          tt.leaseID,
 						AccountID:   tt.accountID,
+						PrincipalID: "Owner",
 						LeaseStatus: tt.leaseStatus,
+						SharedWith:  tt.sharedWith,
 					}
 					mockRequest = events.APIGatewayProxyRequest{
 						HTTPMethod: http.MethodGet,
@@ -307,4 +335,72 @@ func TestGetLeaseAuth(t *testing.T) {
 
 	})
 
+	t.Run("When the session duration is limited", func(t *testing.T) {
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			fmt.Fprintf(rw, `{"SigninToken":"ExampleSigninToken"}`)
+		}))
+		defer server.Close()
+
+		mockRequest := events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/leases/LeaseABC/auth",
+			PathParameters: map[string]string{
+				"id": "LeaseABC",
+			},
+		}
+		mockDb := mocks.DBer{}
+		mockDb.On("GetLeaseByID", "LeaseABC").Return(&db.Lease{
+			ID:          "LeaseABC",
+			AccountID:   "Account123",
+			PrincipalID: "TestUser",
+			LeaseStatus: db.Active,
+		}, nil)
+		mockDb.On("GetAccount", "Account123").Return(&db.Account{
+			ID:               "Account123",
+			AccountStatus:    db.Leased,
+			PrincipalRoleArn: "arn:aws:iam::Account123:role/Principal",
+		}, nil)
+
+		expiration := time.Unix(1583056800, 0)
+		mockToken := commonMocks.TokenService{}
+		mockToken.On("AssumeRole",
+			&sts.AssumeRoleInput{
+				RoleArn:         aws.String("arn:aws:iam::Account123:role/Principal"),
+				RoleSessionName: aws.String("TestUser"),
+				DurationSeconds: aws.Int64(900),
+			},
+		).Return(
+			&sts.AssumeRoleOutput{
+				Credentials: &sts.Credentials{
+					AccessKeyId:     aws.String("ExampleKey"),
+					SecretAccessKey: aws.String("ExampleSecret"),
+					SessionToken:    aws.String("ExampleSession"),
+					Expiration:      &expiration,
+				},
+			}, nil,
+		)
+
+		mockUserDetailer := apiMocks.UserDetailer{}
+		mockUserDetailer.On("GetUser", &mockRequest.RequestContext).Return(&api.User{
+			Role:     api.AdminGroupName,
+			Username: "TestUser",
+		})
+
+		controller := CreateController{
+			Dao:             &mockDb,
+			TokenService:    &mockToken,
+			ConsoleURL:      fmt.Sprintf("%s/console", server.URL),
+			FederationURL:   fmt.Sprintf("%s/federation", server.URL),
+			UserDetailer:    &mockUserDetailer,
+			SessionDuration: 900,
+		}
+
+		actualResponse, err := controller.Call(context.TODO(), &mockRequest)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusCreated, actualResponse.StatusCode)
+		require.Contains(t, actualResponse.Body, `"expiresOn":1583056800`)
+		mockToken.AssertExpectations(t)
+	})
+
 }
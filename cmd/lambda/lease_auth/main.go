@@ -8,9 +8,11 @@ import (
 	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/principal"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/caarlos0/env"
 
 	"github.com/aws/aws-lambda-go/lambda"
 )
@@ -28,21 +30,17 @@ func main() {
 	// Create the Token Service
 	awsSession := newAWSSession()
 	tokenSvc := common.STS{Client: sts.New(awsSession)}
-	cognitoSvc := cognitoidentityprovider.New(awsSession)
-	userDetails := &api.UserDetails{
-		CognitoUserPoolID:        common.RequireEnv("COGNITO_USER_POOL_ID"),
-		RolesAttributesAdminName: common.RequireEnv("COGNITO_ROLES_ATTRIBUTE_ADMIN_NAME"),
-		CognitoClient:            cognitoSvc,
-	}
+	userDetails := newUserDetailer(awsSession)
 
 	router := &api.Router{
 		ResourceName: "/auth",
 		CreateController: CreateController{
-			Dao:           dao,
-			TokenService:  tokenSvc,
-			FederationURL: federationURL,
-			ConsoleURL:    consoleURL,
-			UserDetailer:  userDetails,
+			Dao:             dao,
+			TokenService:    tokenSvc,
+			FederationURL:   federationURL,
+			ConsoleURL:      consoleURL,
+			UserDetailer:    userDetails,
+			SessionDuration: int64(common.GetEnvInt("LEASE_AUTH_SESSION_DURATION", 0)),
 		},
 		UserDetails: userDetails,
 	}
@@ -50,6 +48,43 @@ func main() {
 	lambda.Start(router.Route)
 }
 
+// newUserDetailer creates the user detailer of the configured auth provider
+func newUserDetailer(awsSession *session.Session) api.UserDetailer {
+	authConfig := &api.AuthConfig{}
+	err := env.Parse(authConfig)
+	if err != nil {
+		log.Fatalf("Failed to parse the auth provider config: %s", err)
+	}
+
+	var cognitoUserDetails *api.UserDetails
+	if authConfig.Provider == api.AuthProviderCognito || authConfig.Provider == "" {
+		cognitoUserDetails = &api.UserDetails{
+			CognitoUserPoolID:        common.RequireEnv("COGNITO_USER_POOL_ID"),
+			RolesAttributesAdminName: common.RequireEnv("COGNITO_ROLES_ATTRIBUTE_ADMIN_NAME"),
+			CognitoClient:            cognitoidentityprovider.New(awsSession),
+		}
+	}
+
+	userDetails, err := authConfig.NewUserDetailer(cognitoUserDetails)
+	if err != nil {
+		log.Fatalf("Failed to create the user detailer: %s", err)
+	}
+
+	principalIDFormatInput := principal.NewIDFormatInput{}
+	err = env.Parse(&principalIDFormatInput)
+	if err != nil {
+		log.Fatalf("Failed to parse the principal ID format: %s", err)
+	}
+	principalIDFormat, err := principal.NewIDFormat(principalIDFormatInput)
+	if err != nil {
+		log.Fatalf("Failed to create the principal ID format: %s", err)
+	}
+	return &api.PrincipalUserDetails{
+		UserDetailer: userDetails,
+		Format:       principalIDFormat,
+	}
+}
+
 func newDBer() db.DBer {
 	dao, err := db.NewFromEnv()
 	if err != nil {
@@ -61,7 +96,7 @@ func newDBer() db.DBer {
 }
 
 func newAWSSession() *session.Session {
-	awsSession, err := session.NewSession()
+	awsSession, err := common.SharedSession("")
 	if err != nil {
 		errorMessage := fmt.Sprintf("Failed to create AWS session: %s", err)
 		log.Fatal(errorMessage)
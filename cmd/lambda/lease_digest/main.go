@@ -0,0 +1,281 @@
+// Package main emails a daily or weekly digest of leases to each principal,
+// and of every lease to admins
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// Period is how often digests are sent, daily or weekly.  Digests list
+	// the leases expiring in the next period, and reset in the last.
+	Period string `env:"LEASE_DIGEST_PERIOD" envDefault:"weekly"`
+	// AdminEmails are sent the digest of every lease
+	AdminEmails []string `env:"LEASE_DIGEST_ADMIN_EMAILS" envDefault:"" envSeparator:","`
+	// PrincipalsEnabled sends each principal the digest of their leases
+	PrincipalsEnabled bool `env:"LEASE_DIGEST_PRINCIPALS_ENABLED" envDefault:"true"`
+}
+
+type leaseDigestResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+// periodDays are the days covered by each digest period
+var periodDays = map[string]int{
+	"daily":  1,
+	"weekly": 7,
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	usageSvc usage.DBer
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+// digest is the leases summarized for a principal, or for admins
+type digest struct {
+	principalID string
+	to          []string
+	data        notification.Data
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*leaseDigestResult, error) {
+	days, ok := periodDays[settings.Period]
+	if !ok {
+		return nil, errors.NewValidation("lease digest", fmt.Errorf("period must be daily or weekly, not %q", settings.Period))
+	}
+	result := &leaseDigestResult{}
+	now := time.Now()
+	periodStart := now.AddDate(0, 0, -days)
+	periodEnd := now.AddDate(0, 0, days)
+
+	active := []*lease.Lease{}
+	err := services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for i := range *leases {
+			l := (*leases)[i]
+			active = append(active, &l)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The accounts of leases which ended are reset
+	ended := []*lease.Lease{}
+	err = services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusInactive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for i := range *leases {
+			l := (*leases)[i]
+			if aws.Int64Value(l.StatusModifiedOn) >= periodStart.Unix() {
+				ended = append(ended, &l)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spend, err := leaseSpend(active, now)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &digest{to: settings.AdminEmails}
+	digests := map[string]*digest{}
+	principalDigest := func(l *lease.Lease) *digest {
+		principalID := aws.StringValue(l.PrincipalID)
+		d, ok := digests[principalID]
+		if !ok {
+			d = &digest{principalID: principalID}
+			digests[principalID] = d
+		}
+		if l.BudgetNotificationEmails != nil {
+			d.to = appendUnique(d.to, *l.BudgetNotificationEmails...)
+		}
+		return d
+	}
+	for _, l := range active {
+		summary := leaseSummary(l)
+		summary.ActualSpend = spend[aws.StringValue(l.ID)]
+		expiring := l.ExpiresOn != nil && *l.ExpiresOn <= periodEnd.Unix()
+		for _, d := range []*digest{admin, principalDigest(l)} {
+			d.data.Leases = append(d.data.Leases, summary)
+			d.data.TotalSpend += summary.ActualSpend
+			if expiring {
+				d.data.ExpiringLeases = append(d.data.ExpiringLeases, summary)
+			}
+		}
+	}
+	for _, l := range ended {
+		summary := leaseSummary(l)
+		for _, d := range []*digest{admin, principalDigest(l)} {
+			d.data.EndedLeases = append(d.data.EndedLeases, summary)
+		}
+	}
+
+	// Send every digest before failing, so one failure doesn't hold up the rest
+	sends := []*digest{}
+	if settings.PrincipalsEnabled {
+		for _, d := range digests {
+			d.data.Principal = notification.Principal{ID: d.principalID}
+			sends = append(sends, d)
+		}
+		sort.Slice(sends, func(i, j int) bool { return sends[i].principalID < sends[j].principalID })
+	}
+	if len(admin.to) > 0 {
+		sends = append(sends, admin)
+	}
+	errs := []error{}
+	for _, d := range sends {
+		d.data.Period = settings.Period
+		_, err := services.NotificationService().Send(notification.TemplateLeaseDigest, d.to, &d.data)
+		if err != nil {
+			log.Printf("Failed to send the lease digest for %q: %s", d.principalID, err)
+			result.Failed++
+			errs = append(errs, err)
+			continue
+		}
+		result.Sent++
+	}
+
+	log.Printf("Sent %d lease digests, failed to send %d", result.Sent, result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to send lease digests", errs)
+	}
+	return result, nil
+}
+
+// leaseSummary gets the lease as it's shown in a digest
+func leaseSummary(l *lease.Lease) notification.Lease {
+	summary := notification.Lease{
+		ID:             aws.StringValue(l.ID),
+		AccountID:      aws.StringValue(l.AccountID),
+		PrincipalID:    aws.StringValue(l.PrincipalID),
+		Status:         l.Status.String(),
+		BudgetAmount:   aws.Float64Value(l.BudgetAmount),
+		BudgetCurrency: aws.StringValue(l.BudgetCurrency),
+		ExpiresOn:      time.Unix(aws.Int64Value(l.ExpiresOn), 0),
+	}
+	if l.StatusReason != nil {
+		summary.StatusReason = string(*l.StatusReason)
+	}
+	return summary
+}
+
+// leaseSpend sums the spend of each lease since its budget period began,
+// which is when its status last changed, by lease ID
+func leaseSpend(leases []*lease.Lease, now time.Time) (map[string]float64, error) {
+	spend := map[string]float64{}
+	if len(leases) == 0 {
+		return spend, nil
+	}
+
+	startDate := now.Unix()
+	for _, l := range leases {
+		if start := budgetStart(l); start < startDate {
+			startDate = start
+		}
+	}
+
+	usageDB, err := usageService()
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	records, err := usageDB.GetUsageByDateRange(time.Unix(startDate, 0), now)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get usage", err)
+	}
+
+	for _, l := range leases {
+		cost := usage.LeaseCostOf(records, aws.StringValue(l.AccountID), aws.StringValue(l.PrincipalID), budgetStart(l), now.Unix())
+		spend[aws.StringValue(l.ID)] = cost.CostAmount
+	}
+	return spend, nil
+}
+
+func budgetStart(l *lease.Lease) int64 {
+	if l.StatusModifiedOn != nil {
+		return *l.StatusModifiedOn
+	}
+	return aws.Int64Value(l.CreatedOn)
+}
+
+func appendUnique(values []string, add ...string) []string {
+	for _, a := range add {
+		found := false
+		for _, v := range values {
+			if v == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, a)
+		}
+	}
+	return values
+}
+
+func usageService() (usage.DBer, error) {
+	if usageSvc != nil {
+		return usageSvc, nil
+	}
+	usageService, err := usage.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	usageSvc = usageService
+	return usageSvc, nil
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("lease_digest", handler))
+}
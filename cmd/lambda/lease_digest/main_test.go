@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/testutil"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const day = 24 * 60 * 60
+
+func TestHandler(t *testing.T) {
+	settings.Period = "weekly"
+	settings.AdminEmails = []string{"admins@example.com"}
+	settings.PrincipalsEnabled = true
+	now := time.Now().Unix()
+
+	expiring := testutil.NewLeaseBuilder().WithID("expiring").WithAccountID("111111111111").
+		WithPrincipalID("jdoe").WithBudgetNotificationEmails("jdoe@example.com").ExpiresOn(now + 2*day).Build()
+	expiring.StatusModifiedOn = aws.Int64(now - 3*day)
+	later := testutil.NewLeaseBuilder().WithID("later").WithAccountID("222222222222").
+		WithPrincipalID("jdoe").WithBudgetNotificationEmails("jdoe@example.com", "team@example.com").ExpiresOn(now + 20*day).Build()
+	later.StatusModifiedOn = aws.Int64(now - 3*day)
+	active := lease.Leases{*expiring, *later}
+
+	ended := testutil.NewLeaseBuilder().WithID("ended").WithAccountID("333333333333").
+		WithPrincipalID("asmith").WithBudgetNotificationEmails("asmith@example.com").
+		Inactive(lease.StatusReasonExpired).Build()
+	ended.StatusModifiedOn = aws.Int64(now - day)
+	endedLongAgo := testutil.NewLeaseBuilder().WithID("ended-long-ago").WithPrincipalID("bjones").
+		Inactive(lease.StatusReasonExpired).Build()
+	inactive := lease.Leases{*ended, *endedLongAgo}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&active)
+		}).
+		Return(nil)
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusInactive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&inactive)
+		}).
+		Return(nil)
+
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return([]*usage.Usage{
+		{AccountID: aws.String("111111111111"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(now - 2*day), CostAmount: aws.Float64(10)},
+		{AccountID: aws.String("111111111111"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(now - day), CostAmount: aws.Float64(5)},
+		{AccountID: aws.String("222222222222"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(now - day), CostAmount: aws.Float64(2.5)},
+		// Spend of an earlier lease of the account isn't counted
+		{AccountID: aws.String("222222222222"), PrincipalID: aws.String("asmith"), StartDate: aws.Int64(now - day), CostAmount: aws.Float64(100)},
+	}, nil)
+	usageSvc = usageSvcMock
+
+	notificationSvc := &notificationmocks.Servicer{}
+	notificationSvc.On("Send", notification.TemplateLeaseDigest, []string{"jdoe@example.com", "team@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Principal.ID == "jdoe" && data.Period == "weekly" &&
+				len(data.Leases) == 2 && data.TotalSpend == 17.5 &&
+				len(data.ExpiringLeases) == 1 && data.ExpiringLeases[0].ID == "expiring" &&
+				data.ExpiringLeases[0].ActualSpend == 15 &&
+				len(data.EndedLeases) == 0
+		})).
+		Return(&notification.Email{}, nil)
+	notificationSvc.On("Send", notification.TemplateLeaseDigest, []string{"asmith@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Principal.ID == "asmith" && len(data.Leases) == 0 &&
+				len(data.EndedLeases) == 1 && data.EndedLeases[0].StatusReason == "Expired"
+		})).
+		Return(nil, errors.NewInternalServer("failed to send LeaseDigest email", fmt.Errorf("ses")))
+	notificationSvc.On("Send", notification.TemplateLeaseDigest, []string{"admins@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Principal.ID == "" && len(data.Leases) == 2 && data.TotalSpend == 17.5 &&
+				len(data.ExpiringLeases) == 1 && len(data.EndedLeases) == 1
+		})).
+		Return(&notification.Email{}, nil)
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(notificationSvc)
+	_, err := svcBldr.Build()
+	require.Nil(t, err)
+	services = svcBldr
+
+	result, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &leaseDigestResult{Sent: 2, Failed: 1}, result)
+	notificationSvc.AssertExpectations(t)
+	notificationSvc.AssertNumberOfCalls(t, "Send", 3)
+
+	t.Run("should only send the admin digest", func(t *testing.T) {
+		settings.PrincipalsEnabled = false
+		defer func() { settings.PrincipalsEnabled = true }()
+
+		result, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, &leaseDigestResult{Sent: 1}, result)
+	})
+
+	t.Run("should fail on an unknown period", func(t *testing.T) {
+		settings.Period = "monthly"
+		defer func() { settings.Period = "weekly" }()
+
+		_, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+		assert.NotNil(t, err)
+	})
+}
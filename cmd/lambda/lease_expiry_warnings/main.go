@@ -0,0 +1,202 @@
+// Package main warns lease holders that their lease is about to expire, a
+// configurable number of hours before it does
+package main
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// WarningHours are how many hours before a lease expires warnings are
+	// sent, eg. 72, 24 and 1 hours before
+	WarningHours []int64 `env:"EXPIRY_WARNING_HOURS" envDefault:"72,24,1" envSeparator:","`
+	// ExtensionURL links to where a lease can be extended.  "{leaseId}",
+	// "{accountId}" and "{principalId}" are replaced with those of the lease.
+	// No link is sent when it's empty.
+	ExtensionURL string `env:"EXPIRY_WARNING_EXTENSION_URL" envDefault:""`
+}
+
+type expiryWarningsResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*expiryWarningsResult, error) {
+	result := &expiryWarningsResult{}
+	now := time.Now().Unix()
+
+	due := []*lease.Lease{}
+	err := services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for i := range *leases {
+			l := (*leases)[i]
+			if dueWarning(&l, now) > 0 {
+				due = append(due, &l)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Leases already lasting the maximum lease duration can't be extended
+	var maxDuration int64
+	if settings.ExtensionURL != "" && len(due) > 0 {
+		durations, err := services.LeaseService().GetDurations()
+		if err != nil {
+			return nil, err
+		}
+		maxDuration = durations.MaxLeaseDuration
+	}
+
+	// Send every warning before failing, so one failure doesn't hold up the rest
+	errs := []error{}
+	for _, l := range due {
+		err := sendWarning(l, now, maxDuration)
+		if err != nil {
+			log.Printf("Failed to send expiry warning for lease %s: %s", aws.StringValue(l.ID), err)
+			result.Failed++
+			errs = append(errs, err)
+			continue
+		}
+		result.Sent++
+	}
+
+	log.Printf("Sent %d expiry warnings, failed to send %d", result.Sent, result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to send expiry warnings", errs)
+	}
+	return result, nil
+}
+
+// dueWarning gets the warning due for a lease, as the hours before the lease
+// expires it's sent, or 0 if none is due.  Only the latest warning is due, so
+// a lease created shortly before it expires doesn't get every warning at once.
+func dueWarning(l *lease.Lease, now int64) int64 {
+	if l.ExpiresOn == nil || *l.ExpiresOn <= now {
+		return 0
+	}
+	remaining := *l.ExpiresOn - now
+
+	var due int64
+	for _, hours := range settings.WarningHours {
+		if hours > 0 && remaining <= hours*60*60 && (due == 0 || hours < due) {
+			due = hours
+		}
+	}
+	if due == 0 {
+		return 0
+	}
+	for _, sent := range l.ExpiryWarningsSent {
+		if sent <= due {
+			return 0
+		}
+	}
+	return due
+}
+
+// sendWarning emails the lease's notification addresses, and records the
+// warning on the lease so it isn't sent again
+func sendWarning(l *lease.Lease, now int64, maxDuration int64) error {
+	hoursBefore := dueWarning(l, now)
+	remaining := *l.ExpiresOn - now
+
+	data := &notification.Data{
+		Lease: notification.Lease{
+			ID:             aws.StringValue(l.ID),
+			AccountID:      aws.StringValue(l.AccountID),
+			PrincipalID:    aws.StringValue(l.PrincipalID),
+			Status:         l.Status.String(),
+			BudgetAmount:   aws.Float64Value(l.BudgetAmount),
+			BudgetCurrency: aws.StringValue(l.BudgetCurrency),
+			ExpiresOn:      time.Unix(*l.ExpiresOn, 0),
+		},
+		// Round up, so a lease isn't said to expire in 0 hours
+		HoursRemaining: int((remaining + 60*60 - 1) / (60 * 60)),
+		ExtensionURL:   extensionURL(l, maxDuration),
+	}
+	if l.StatusReason != nil {
+		data.Lease.StatusReason = string(*l.StatusReason)
+	}
+	var to []string
+	if l.BudgetNotificationEmails != nil {
+		to = *l.BudgetNotificationEmails
+	}
+
+	log.Printf("Sending %dh expiry warning for lease %s @ %s", hoursBefore, data.Lease.PrincipalID, data.Lease.AccountID)
+	_, err := services.NotificationService().Send(notification.TemplateExpiryWarning, to, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = services.LeaseService().RecordExpiryWarning(*l.ID, hoursBefore)
+	return err
+}
+
+// extensionURL gets the link to extend a lease, or an empty link if there is
+// nowhere to extend leases or the lease already lasts the maximum duration
+func extensionURL(l *lease.Lease, maxDuration int64) string {
+	if settings.ExtensionURL == "" {
+		return ""
+	}
+	if l.CreatedOn != nil && *l.ExpiresOn-*l.CreatedOn >= maxDuration {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{leaseId}", url.PathEscape(aws.StringValue(l.ID)),
+		"{accountId}", url.PathEscape(aws.StringValue(l.AccountID)),
+		"{principalId}", url.PathEscape(aws.StringValue(l.PrincipalID)),
+	).Replace(settings.ExtensionURL)
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("lease_expiry_warnings", handler))
+}
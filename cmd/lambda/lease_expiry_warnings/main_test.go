@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/testutil"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const hour = 60 * 60
+
+func TestDueWarning(t *testing.T) {
+	settings.WarningHours = []int64{72, 24, 1}
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		expiresOn int64
+		sent      []int64
+		expDue    int64
+	}{
+		{name: "not due yet", expiresOn: now + 100*hour},
+		{name: "first warning", expiresOn: now + 70*hour, expDue: 72},
+		{name: "first warning sent", expiresOn: now + 70*hour, sent: []int64{72}},
+		{name: "second warning", expiresOn: now + 20*hour, sent: []int64{72}, expDue: 24},
+		{name: "only the latest warning", expiresOn: now + 30*60, expDue: 1},
+		{name: "last warning sent", expiresOn: now + 30*60, sent: []int64{1}},
+		{name: "expired", expiresOn: now - hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := testutil.NewLeaseBuilder().ExpiresOn(tt.expiresOn).Build()
+			l.ExpiryWarningsSent = tt.sent
+			assert.Equal(t, tt.expDue, dueWarning(l, now))
+		})
+	}
+}
+
+func TestExtensionURL(t *testing.T) {
+	settings.ExtensionURL = "https://dce.example.com/leases/{leaseId}/extend?principal={principalId}"
+
+	short := testutil.NewLeaseBuilder().WithPrincipalID("j doe").ExpiresOn(testutil.FixedTime + 24*hour).Build()
+	assert.Equal(t, "https://dce.example.com/leases/00000000-0000-0000-0000-000000000001/extend?principal=j%20doe", extensionURL(short, 7*24*hour))
+
+	maxed := testutil.NewLeaseBuilder().ExpiresOn(testutil.FixedTime + 7*24*hour).Build()
+	assert.Equal(t, "", extensionURL(maxed, 7*24*hour), "leases already at the max period can't be extended")
+
+	settings.ExtensionURL = ""
+	assert.Equal(t, "", extensionURL(short, 7*24*hour))
+}
+
+func TestHandler(t *testing.T) {
+	settings.WarningHours = []int64{72, 24, 1}
+	settings.ExtensionURL = ""
+	now := time.Now().Unix()
+
+	leases := lease.Leases{
+		*testutil.NewLeaseBuilder().WithID("due").ExpiresOn(now + 20*hour).
+			WithBudgetNotificationEmails("jdoe@example.com").Build(),
+		*testutil.NewLeaseBuilder().WithID("later").ExpiresOn(now + 100*hour).Build(),
+		*testutil.NewLeaseBuilder().WithID("failing").ExpiresOn(now + 30*60).Build(),
+	}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&leases)
+		}).
+		Return(nil)
+	leaseSvc.On("RecordExpiryWarning", "due", int64(24)).Return(&leases[0], nil)
+
+	notificationSvc := &notificationmocks.Servicer{}
+	notificationSvc.On("Send", notification.TemplateExpiryWarning, []string{"jdoe@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Lease.ID == "due" && data.HoursRemaining == 20
+		})).
+		Return(&notification.Email{}, nil)
+	notificationSvc.On("Send", notification.TemplateExpiryWarning, mock.Anything,
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Lease.ID == "failing"
+		})).
+		Return(nil, errors.NewInternalServer("failed to send ExpiryWarning email", fmt.Errorf("ses")))
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(notificationSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	result, err := handler(context.TODO(), events.CloudWatchEvent{})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &expiryWarningsResult{Sent: 1, Failed: 1}, result)
+	leaseSvc.AssertExpectations(t)
+	leaseSvc.AssertNumberOfCalls(t, "RecordExpiryWarning", 1)
+	notificationSvc.AssertNumberOfCalls(t, "Send", 2)
+}
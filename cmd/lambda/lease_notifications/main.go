@@ -0,0 +1,189 @@
+// Package main sends the lease created and lease ended emails, read from the
+// lease table stream
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// Emails are the lease emails to send
+	Emails []string `env:"LEASE_NOTIFICATION_EMAILS" envDefault:"LeaseCreated,LeaseEnded" envSeparator:","`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("lease_notifications", handler))
+}
+
+func handler(ctx context.Context, event events.DynamoDBEvent) error {
+	// Send every email before failing, so one failure doesn't hold up the rest
+	errs := []error{}
+	for _, record := range event.Records {
+		name := leaseTemplate(record)
+		if name == "" || !enabled(name) {
+			continue
+		}
+
+		newImage := record.Change.NewImage
+		data := &notification.Data{
+			Lease: leaseFromImage(newImage),
+		}
+		log.Printf("Sending %s email for lease %s @ %s", name, data.Lease.PrincipalID, data.Lease.AccountID)
+		_, err := services.NotificationService().Send(name, stringListAttr(newImage, "BudgetNotificationEmails"), data)
+		if err != nil {
+			log.Printf("Failed to send %s email for lease %s: %s", name, data.Lease.ID, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to send lease emails", errs)
+	}
+	return nil
+}
+
+// leaseTemplate gets the email to send for a lease table change, or an empty
+// template if the change doesn't need one
+func leaseTemplate(record events.DynamoDBEventRecord) notification.Template {
+	if record.EventName == string(events.DynamoDBOperationTypeRemove) {
+		return ""
+	}
+	newStatus := stringAttr(record.Change.NewImage, "LeaseStatus")
+	oldStatus := stringAttr(record.Change.OldImage, "LeaseStatus")
+	if newStatus == oldStatus {
+		return ""
+	}
+
+	switch {
+	case newStatus == string(lease.StatusActive):
+		return notification.TemplateLeaseCreated
+	case newStatus == string(lease.StatusInactive) && oldStatus == string(lease.StatusActive):
+		return notification.TemplateLeaseEnded
+	}
+	return ""
+}
+
+func enabled(name notification.Template) bool {
+	for _, email := range settings.Emails {
+		if email == string(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func leaseFromImage(image map[string]events.DynamoDBAttributeValue) notification.Lease {
+	return notification.Lease{
+		ID:             stringAttr(image, "Id"),
+		AccountID:      stringAttr(image, "AccountId"),
+		PrincipalID:    stringAttr(image, "PrincipalId"),
+		Status:         stringAttr(image, "LeaseStatus"),
+		StatusReason:   stringAttr(image, "LeaseStatusReason"),
+		BudgetAmount:   numberAttr(image, "BudgetAmount"),
+		BudgetCurrency: stringAttr(image, "BudgetCurrency"),
+		ExpiresOn:      time.Unix(int64(numberAttr(image, "ExpiresOn")), 0),
+		Notes:          stringAttr(image, "Notes"),
+		Metadata:       stringMapAttr(image, "Metadata"),
+	}
+}
+
+func stringAttr(image map[string]events.DynamoDBAttributeValue, name string) string {
+	value, ok := image[name]
+	if !ok || value.DataType() != events.DataTypeString {
+		return ""
+	}
+	return value.String()
+}
+
+func numberAttr(image map[string]events.DynamoDBAttributeValue, name string) float64 {
+	value, ok := image[name]
+	if !ok || value.DataType() != events.DataTypeNumber {
+		return 0
+	}
+	number, err := strconv.ParseFloat(value.Number(), 64)
+	if err != nil {
+		log.Printf("Invalid %s number %q: %s", name, value.Number(), err)
+		return 0
+	}
+	return number
+}
+
+func stringListAttr(image map[string]events.DynamoDBAttributeValue, name string) []string {
+	value, ok := image[name]
+	if !ok {
+		return nil
+	}
+	if value.DataType() == events.DataTypeStringSet {
+		return value.StringSet()
+	}
+	if value.DataType() != events.DataTypeList {
+		return nil
+	}
+	list := []string{}
+	for _, item := range value.List() {
+		if item.DataType() == events.DataTypeString {
+			list = append(list, item.String())
+		}
+	}
+	return list
+}
+
+// stringMapAttr gets the text values of a map.  Compressed maps are left out,
+// as they're only compressed when they're too large for an email anyway.
+func stringMapAttr(image map[string]events.DynamoDBAttributeValue, name string) map[string]string {
+	value, ok := image[name]
+	if !ok || value.DataType() != events.DataTypeMap {
+		return nil
+	}
+	values := map[string]string{}
+	for key, item := range value.Map() {
+		if item.DataType() == events.DataTypeString {
+			values[key] = item.String()
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/notification"
+	notificationMocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func streamEvent(eventName string, oldImage string, newImage string) string {
+	return fmt.Sprintf(`{"Records": [{"eventName": "%s", "dynamodb": {"OldImage": %s, "NewImage": %s}}]}`,
+		eventName, oldImage, newImage)
+}
+
+func TestLeaseNotifications(t *testing.T) {
+	activeLease := `{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"},
+		"LeaseStatus": {"S": "Active"}, "BudgetAmount": {"N": "100"}, "BudgetCurrency": {"S": "USD"}, "ExpiresOn": {"N": "1583325000"},
+		"BudgetNotificationEmails": {"L": [{"S": "jdoe@example.com"}]}}`
+	endedLease := `{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"},
+		"LeaseStatus": {"S": "Inactive"}, "LeaseStatusReason": {"S": "Expired"}, "BudgetAmount": {"N": "100"}, "BudgetCurrency": {"S": "USD"},
+		"ExpiresOn": {"N": "1583325000"}, "BudgetNotificationEmails": {"SS": ["jdoe@example.com"]}}`
+	expLease := notification.Lease{
+		ID:             "abc",
+		AccountID:      "123456789012",
+		PrincipalID:    "jdoe",
+		Status:         "Active",
+		BudgetAmount:   100,
+		BudgetCurrency: "USD",
+		ExpiresOn:      time.Unix(1583325000, 0),
+	}
+	expEndedLease := expLease
+	expEndedLease.Status = "Inactive"
+	expEndedLease.StatusReason = "Expired"
+	notedLease := `{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"},
+		"LeaseStatus": {"S": "Inactive"}, "LeaseStatusReason": {"S": "Expired"}, "BudgetAmount": {"N": "100"}, "BudgetCurrency": {"S": "USD"},
+		"ExpiresOn": {"N": "1583325000"}, "BudgetNotificationEmails": {"SS": ["jdoe@example.com"]},
+		"Notes": {"S": "Snapshot the database first"}, "Metadata": {"M": {"purpose": {"S": "load testing"}, "size": {"N": "3"}}}}`
+	expNotedLease := expEndedLease
+	expNotedLease.Notes = "Snapshot the database first"
+	expNotedLease.Metadata = map[string]string{"purpose": "load testing"}
+
+	tests := []struct {
+		name        string
+		event       string
+		emails      []string
+		sendErr     error
+		expTemplate notification.Template
+		expLease    notification.Lease
+		expErr      bool
+	}{
+		{
+			name:        "should send an email when a lease is created",
+			event:       streamEvent("INSERT", `{}`, activeLease),
+			expTemplate: notification.TemplateLeaseCreated,
+			expLease:    expLease,
+		},
+		{
+			name:        "should send an email when a lease ends",
+			event:       streamEvent("MODIFY", activeLease, endedLease),
+			expTemplate: notification.TemplateLeaseEnded,
+			expLease:    expEndedLease,
+		},
+		{
+			name:        "should include the notes and metadata of the lease",
+			event:       streamEvent("MODIFY", activeLease, notedLease),
+			expTemplate: notification.TemplateLeaseEnded,
+			expLease:    expNotedLease,
+		},
+		{
+			name:  "should not send an email when a lease is updated",
+			event: streamEvent("MODIFY", activeLease, activeLease),
+		},
+		{
+			name:  "should not send an email when a lease is deleted",
+			event: streamEvent("REMOVE", endedLease, `{}`),
+		},
+		{
+			name:   "should not send disabled emails",
+			event:  streamEvent("INSERT", `{}`, activeLease),
+			emails: []string{"LeaseEnded"},
+		},
+		{
+			name:        "should fail when the email can't be sent",
+			event:       streamEvent("INSERT", `{}`, activeLease),
+			sendErr:     fmt.Errorf("throttled"),
+			expTemplate: notification.TemplateLeaseCreated,
+			expLease:    expLease,
+			expErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationSvc := notificationMocks.Servicer{}
+			notificationSvc.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.sendErr)
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+			svcBldr.Config.WithService(&notificationSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				services = svcBldr
+			}
+			settings = &configuration{Emails: []string{"LeaseCreated", "LeaseEnded"}}
+			if tt.emails != nil {
+				settings.Emails = tt.emails
+			}
+
+			input := events.DynamoDBEvent{}
+			err = json.Unmarshal([]byte(tt.event), &input)
+			assert.Nil(t, err)
+
+			err = handler(context.TODO(), input)
+
+			assert.Equal(t, tt.expErr, err != nil, "unexpected error: %v", err)
+			if tt.expTemplate != "" {
+				notificationSvc.AssertCalled(t, "Send", tt.expTemplate, []string{"jdoe@example.com"}, &notification.Data{Lease: tt.expLease})
+			} else {
+				notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
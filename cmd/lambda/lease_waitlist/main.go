@@ -0,0 +1,184 @@
+// Package main leases Ready accounts to the lease requests on the waitlist,
+// oldest first, and notifies their principals
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/Optum/dce/pkg/waitlist"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type configuration struct {
+	Debug                 string `env:"DEBUG" envDefault:"false"`
+	PrincipalBudgetPeriod string `env:"PRINCIPAL_BUDGET_PERIOD" envDefault:"Weekly"`
+}
+
+type waitlistResult struct {
+	Allocated int `json:"allocated"`
+	Waiting   int `json:"waiting"`
+	Dropped   int `json:"dropped"`
+	Failed    int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	usageSvc usage.DBer
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		WithDirectoryService().
+		WithNotificationService().
+		WithWaitlistService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*waitlistResult, error) {
+	result := &waitlistResult{}
+	entries, err := services.WaitlistService().List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Later requests may still be leased an account when an earlier one
+	// fails, or the account allocation policy doesn't allow it any
+	errs := []error{}
+	for _, entry := range entries {
+		err := allocate(entry, result)
+		if err != nil {
+			log.Printf("Failed to lease an account to %s from the waitlist: %s", entry.PrincipalID, err)
+			result.Failed++
+			errs = append(errs, err)
+		}
+	}
+
+	log.Printf("Leased accounts to %d of %d waiting requests: %d still waiting, dropped %d, failed %d",
+		result.Allocated, len(entries), result.Waiting, result.Dropped, result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to lease accounts to the waitlist", errs)
+	}
+	return result, nil
+}
+
+// allocate leases a Ready account to a waiting request, the same as POST
+// /leases.  Requests which can no longer be leased an account, eg. because
+// the principal has since been leased one, are taken off the waitlist.
+func allocate(entry *waitlist.Entry, result *waitlistResult) error {
+	usageDB, err := usageService()
+	if err != nil {
+		return errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	allocator := lease.NewAllocator(lease.NewAllocatorInput{
+		LeaseSvc:              services.LeaseService(),
+		AccountSvc:            services.AccountService(),
+		DirectorySvc:          services.DirectoryService(),
+		UsageSvc:              usageDB,
+		PrincipalBudgetPeriod: settings.PrincipalBudgetPeriod,
+	})
+
+	// The request was authorized, and accepted the terms of use, when it was
+	// added to the waitlist
+	newLease := entry.Lease
+	newLease.PrincipalID = aws.String(entry.PrincipalID)
+	newLease.RequestedOn = aws.Int64(entry.CreatedOn)
+	leaseCreated, err := allocator.Allocate(&newLease, entry.Requester(), false)
+	if err != nil {
+		if errors.HTTPCodeForError(err) >= http.StatusInternalServerError {
+			return err
+		}
+		log.Printf("Dropping the lease request of %s from the waitlist: %s", entry.PrincipalID, err)
+		result.Dropped++
+		return services.WaitlistService().Remove(entry.PrincipalID)
+	}
+	if leaseCreated == nil {
+		result.Waiting++
+		return nil
+	}
+	result.Allocated++
+
+	err = services.WaitlistService().Remove(entry.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Leased account %s to %s from the waitlist", aws.StringValue(leaseCreated.AccountID), entry.PrincipalID)
+	err = notify(entry, leaseCreated)
+	if err != nil {
+		// The lease was created, so it isn't retried
+		log.Printf("Failed to notify %s of lease %s: %s", entry.PrincipalID, aws.StringValue(leaseCreated.ID), err)
+	}
+	return nil
+}
+
+// notify tells the principal their lease is ready
+func notify(entry *waitlist.Entry, l *lease.Lease) error {
+	data := &notification.Data{
+		Lease: notification.Lease{
+			ID:             aws.StringValue(l.ID),
+			AccountID:      aws.StringValue(l.AccountID),
+			PrincipalID:    aws.StringValue(l.PrincipalID),
+			Status:         l.Status.String(),
+			BudgetAmount:   aws.Float64Value(l.BudgetAmount),
+			BudgetCurrency: aws.StringValue(l.BudgetCurrency),
+			ExpiresOn:      time.Unix(aws.Int64Value(l.ExpiresOn), 0),
+		},
+		WaitedSince: time.Unix(entry.CreatedOn, 0),
+	}
+	var to []string
+	if l.BudgetNotificationEmails != nil {
+		to = *l.BudgetNotificationEmails
+	}
+	_, err := services.NotificationService().Send(notification.TemplateWaitlistAllocated, to, data)
+	return err
+}
+
+func usageService() (usage.DBer, error) {
+	if usageSvc != nil {
+		return usageSvc, nil
+	}
+	usageService, err := usage.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	usageSvc = usageService
+	return usageSvc, nil
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("lease_waitlist", handler))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/usage"
+	usagemocks "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/Optum/dce/pkg/waitlist"
+	waitlistmocks "github.com/Optum/dce/pkg/waitlist/waitlistiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+	readyAccount := func() *account.Account {
+		return &account.Account{ID: aws.String("123456789012"), Status: account.StatusReady.StatusPtr()}
+	}
+
+	tests := []struct {
+		name        string
+		account     *account.Account
+		createErr   error
+		expResult   *waitlistResult
+		expErr      bool
+		expRemove   bool
+		expNotified bool
+	}{
+		{
+			name:      "no Ready accounts",
+			expResult: &waitlistResult{Waiting: 1},
+		},
+		{
+			name:        "leases a Ready account",
+			account:     readyAccount(),
+			expResult:   &waitlistResult{Allocated: 1},
+			expRemove:   true,
+			expNotified: true,
+		},
+		{
+			name:      "drops a request which can't be leased an account",
+			account:   readyAccount(),
+			createErr: errors.NewValidation("lease", fmt.Errorf("principal budget is spent")),
+			expResult: &waitlistResult{Dropped: 1},
+			expRemove: true,
+		},
+		{
+			name:      "keeps a request when the lease fails to be created",
+			account:   readyAccount(),
+			createErr: errors.NewInternalServer("failed to create the lease", nil),
+			expResult: &waitlistResult{Failed: 1},
+			expErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			entry := &waitlist.Entry{
+				PrincipalID: "jdoe",
+				Lease:       lease.Lease{BudgetAmount: aws.Float64(100), BudgetCurrency: aws.String("USD")},
+				CreatedOn:   1000,
+				Position:    1,
+			}
+			leaseCreated := &lease.Lease{
+				ID:                       aws.String("lease-1"),
+				AccountID:                aws.String("123456789012"),
+				PrincipalID:              aws.String("jdoe"),
+				Status:                   lease.StatusActive.StatusPtr(),
+				BudgetNotificationEmails: &[]string{"jdoe@example.com"},
+			}
+
+			waitlistSvc := &waitlistmocks.Servicer{}
+			waitlistSvc.On("List").Return([]*waitlist.Entry{entry}, nil)
+			waitlistSvc.On("Remove", "jdoe").Return(nil)
+			accountSvc := &accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+			accountSvc.On("GetReadyAccount", "jdoe").Return(tt.account, nil)
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(&lease.Leases{}, nil)
+			leaseSvc.On("CreateWithQuota", mock.MatchedBy(func(l *lease.Lease) bool {
+				return *l.PrincipalID == "jdoe" && *l.AccountID == "123456789012" && *l.RequestedOn == 1000
+			}), 10.0, (*lease.Quota)(nil)).Return(leaseCreated, tt.createErr)
+			directorySvc := &directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+			notificationSvc := &notificationmocks.Servicer{}
+			notificationSvc.On("Send", notification.TemplateWaitlistAllocated, []string{"jdoe@example.com"},
+				mock.AnythingOfType("*notification.Data")).Return(&notification.Email{}, nil)
+			usageDB := &usagemocks.DBer{}
+			usageDB.On("GetUsageByPrincipal", mock.Anything, "jdoe").Return([]*usage.Usage{
+				{CostAmount: aws.Float64(10)},
+			}, nil)
+			usageSvc = usageDB
+
+			svcBldr.Config.WithService(waitlistSvc).WithService(accountSvc).WithService(leaseSvc).
+				WithService(directorySvc).WithService(notificationSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			services = svcBldr
+
+			result, err := handler(context.TODO(), events.CloudWatchEvent{})
+			assert.Equal(t, tt.expErr, err != nil)
+			assert.Equal(t, tt.expResult, result)
+			if tt.expRemove {
+				waitlistSvc.AssertCalled(t, "Remove", "jdoe")
+			} else {
+				waitlistSvc.AssertNotCalled(t, "Remove", mock.Anything)
+			}
+			if tt.expNotified {
+				notificationSvc.AssertNumberOfCalls(t, "Send", 1)
+			} else {
+				notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
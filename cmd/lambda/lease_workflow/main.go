@@ -0,0 +1,155 @@
+// Package main runs the individual steps of the lease provisioning and
+// teardown Step Functions state machines
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/event"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// Steps which may be run by the lease state machines
+const (
+	StepRecordExecution          = "RecordExecution"
+	StepConfigurePrincipalAccess = "ConfigurePrincipalAccess"
+	StepVerifyAccountLocked      = "VerifyAccountLocked"
+	StepVerifyAccountReady       = "VerifyAccountReady"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+// workflowInput is passed to each step by the state machine
+type workflowInput struct {
+	Step         string          `json:"step"`
+	Workflow     string          `json:"workflow"`
+	ExecutionArn string          `json:"executionArn"`
+	Event        json.RawMessage `json:"event"`
+}
+
+// StepIncompleteError is returned when a step is waiting on something else
+// to finish. The state machines retry steps which fail with this error for
+// longer than other errors, so its name must not change.
+type StepIncompleteError struct {
+	message string
+}
+
+func (e *StepIncompleteError) Error() string {
+	return e.message
+}
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+func handler(ctx context.Context, input workflowInput) error {
+	var data lease.Lease
+	_, err := event.Unmarshal(input.Event, &data)
+	if err != nil {
+		return err
+	}
+	if data.ID == nil || data.AccountID == nil {
+		return errors.NewValidation("lease", fmt.Errorf("the lease ID and account ID are required"))
+	}
+
+	log.Printf("Running step %q of workflow %q for lease %q", input.Step, input.Workflow, *data.ID)
+	switch input.Step {
+	case StepRecordExecution:
+		_, err = services.LeaseService().RecordExecution(*data.ID, input.Workflow, input.ExecutionArn)
+		return err
+	case StepConfigurePrincipalAccess:
+		return configurePrincipalAccess(*data.AccountID)
+	case StepVerifyAccountLocked:
+		return verifyAccountLocked(*data.AccountID)
+	case StepVerifyAccountReady:
+		return verifyAccountReady(*data.AccountID)
+	}
+	return errors.NewBadRequest(fmt.Sprintf("unknown step %q", input.Step))
+}
+
+// configurePrincipalAccess makes sure the principal role and policy are in
+// place in the leased account
+func configurePrincipalAccess(accountID string) error {
+	acct, err := services.AccountService().Get(accountID)
+	if err != nil {
+		return err
+	}
+	return services.AccountService().UpsertPrincipalAccess(acct)
+}
+
+// verifyAccountLocked makes sure the account is no longer leased, so the
+// principal can't use it while it is being reset
+func verifyAccountLocked(accountID string) error {
+	acct, err := services.AccountService().Get(accountID)
+	if err != nil {
+		return err
+	}
+	if acct.Status != nil && *acct.Status == account.StatusLeased {
+		return &StepIncompleteError{
+			message: fmt.Sprintf("account %q is still leased", accountID),
+		}
+	}
+	return nil
+}
+
+// verifyAccountReady checks the account has been reset and returned to the pool
+func verifyAccountReady(accountID string) error {
+	acct, err := services.AccountService().Get(accountID)
+	if err != nil {
+		return err
+	}
+	if acct.Status == nil {
+		return errors.NewConflict("account", accountID, fmt.Errorf("account has no status"))
+	}
+
+	switch *acct.Status {
+	case account.StatusNotReady:
+		return &StepIncompleteError{
+			message: fmt.Sprintf("account %q is still being reset", accountID),
+		}
+	case account.StatusOrphaned:
+		return errors.NewConflict("account", accountID, fmt.Errorf("account was orphaned while being reset"))
+	}
+	// The account may already have been leased again after its reset finished
+	return nil
+}
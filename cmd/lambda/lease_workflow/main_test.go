@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leaseMocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaseWorkflow(t *testing.T) {
+	leaseEvent := []byte("{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"id\":\"abc\",\"accountId\":\"123456789012\",\"principalId\":\"user1\"}}")
+	executionArn := "arn:aws:states:us-east-1:123456789012:execution:lease-provision:abc"
+
+	tests := []struct {
+		name          string
+		input         workflowInput
+		getAcct       *account.Account
+		getErr        error
+		upsertErr     error
+		recordErr     error
+		expErr        error
+		expIncomplete bool
+	}{
+		{
+			name: "should record the execution on the lease",
+			input: workflowInput{
+				Step:         StepRecordExecution,
+				Workflow:     "provision",
+				ExecutionArn: executionArn,
+				Event:        leaseEvent,
+			},
+		},
+		{
+			name: "should fail when the execution can't be recorded",
+			input: workflowInput{
+				Step:         StepRecordExecution,
+				Workflow:     "provision",
+				ExecutionArn: executionArn,
+				Event:        leaseEvent,
+			},
+			recordErr: errors.NewNotFound("lease", "abc"),
+			expErr:    errors.NewNotFound("lease", "abc"),
+		},
+		{
+			name: "should configure principal access",
+			input: workflowInput{
+				Step:  StepConfigurePrincipalAccess,
+				Event: leaseEvent,
+			},
+			getAcct: &account.Account{ID: ptrString("123456789012")},
+		},
+		{
+			name: "should fail when principal access can't be configured",
+			input: workflowInput{
+				Step:  StepConfigurePrincipalAccess,
+				Event: leaseEvent,
+			},
+			getAcct:   &account.Account{ID: ptrString("123456789012")},
+			upsertErr: errors.NewInternalServer("failure", fmt.Errorf("error")),
+			expErr:    errors.NewInternalServer("failure", fmt.Errorf("error")),
+		},
+		{
+			name: "should be incomplete while the account is leased",
+			input: workflowInput{
+				Step:  StepVerifyAccountLocked,
+				Event: leaseEvent,
+			},
+			getAcct:       &account.Account{ID: ptrString("123456789012"), Status: account.StatusLeased.StatusPtr()},
+			expIncomplete: true,
+		},
+		{
+			name: "should be locked once the account is not ready",
+			input: workflowInput{
+				Step:  StepVerifyAccountLocked,
+				Event: leaseEvent,
+			},
+			getAcct: &account.Account{ID: ptrString("123456789012"), Status: account.StatusNotReady.StatusPtr()},
+		},
+		{
+			name: "should be incomplete while the account is resetting",
+			input: workflowInput{
+				Step:  StepVerifyAccountReady,
+				Event: leaseEvent,
+			},
+			getAcct:       &account.Account{ID: ptrString("123456789012"), Status: account.StatusNotReady.StatusPtr()},
+			expIncomplete: true,
+		},
+		{
+			name: "should be ready once the account is ready",
+			input: workflowInput{
+				Step:  StepVerifyAccountReady,
+				Event: leaseEvent,
+			},
+			getAcct: &account.Account{ID: ptrString("123456789012"), Status: account.StatusReady.StatusPtr()},
+		},
+		{
+			name: "should fail when the account is orphaned",
+			input: workflowInput{
+				Step:  StepVerifyAccountReady,
+				Event: leaseEvent,
+			},
+			getAcct: &account.Account{ID: ptrString("123456789012"), Status: account.StatusOrphaned.StatusPtr()},
+			expErr:  errors.NewConflict("account", "123456789012", fmt.Errorf("account was orphaned while being reset")),
+		},
+		{
+			name: "should fail on an unknown step",
+			input: workflowInput{
+				Step:  "Unknown",
+				Event: leaseEvent,
+			},
+			expErr: errors.NewBadRequest("unknown step \"Unknown\""),
+		},
+		{
+			name: "should fail when the lease is missing",
+			input: workflowInput{
+				Step:  StepVerifyAccountReady,
+				Event: []byte("{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{}}"),
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("the lease ID and account ID are required")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			acctServiceMock := accountMocks.Servicer{}
+			acctServiceMock.On("Get", "123456789012").Return(tt.getAcct, tt.getErr)
+			acctServiceMock.On("UpsertPrincipalAccess", tt.getAcct).Return(tt.upsertErr)
+
+			leaseServiceMock := leaseMocks.Servicer{}
+			leaseServiceMock.On("RecordExecution", "abc", "provision", executionArn).Return(&lease.Lease{}, tt.recordErr)
+
+			svcBldr.Config.WithService(&acctServiceMock).WithService(&leaseServiceMock)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				services = svcBldr
+			}
+
+			err = handler(context.TODO(), tt.input)
+			if tt.expIncomplete {
+				_, ok := err.(*StepIncompleteError)
+				assert.True(t, ok, "expected a StepIncompleteError, got %q", err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+		})
+	}
+}
+
+func ptrString(s string) *string {
+	return &s
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+type suppressLeaseAlertsRequest struct {
+	Until  int64  `json:"until"`
+	Reason string `json:"reason"`
+}
+
+// SuppressLeaseAlerts acknowledges the budget alerts of an Active lease until
+// a given time, eg. for an expected spike in spend during a load test.  Only
+// admins may suppress alerts.
+func SuppressLeaseAlerts(w http.ResponseWriter, r *http.Request) {
+	leaseID := mux.Vars(r)["leaseID"]
+
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if !authorizeAlertSuppression(w, user, leaseID) {
+		return
+	}
+
+	// Deserialize the request JSON as an request object
+	req := &suppressLeaseAlertsRequest{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	updated, err := Services.LeaseService().SuppressAlerts(leaseID, &lease.AlertSuppression{
+		Until:        req.Until,
+		Reason:       req.Reason,
+		SuppressedBy: user.Username,
+	})
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, updated)
+}
+
+// DeleteLeaseAlertSuppression lifts the suppression of a lease's budget
+// alerts before it ends
+func DeleteLeaseAlertSuppression(w http.ResponseWriter, r *http.Request) {
+	leaseID := mux.Vars(r)["leaseID"]
+
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if !authorizeAlertSuppression(w, user, leaseID) {
+		return
+	}
+
+	updated, err := Services.LeaseService().SuppressAlerts(leaseID, nil)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, updated)
+}
+
+func authorizeAlertSuppression(w http.ResponseWriter, user *api.User, leaseID string) bool {
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to suppress the alerts of lease [%s], but was not authorized",
+				user.Username, user.Role, leaseID)))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuppressLeaseAlerts(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name           string
+		user           *api.User
+		method         string
+		reqBody        string
+		expResp        response
+		expSuppression *lease.AlertSuppression
+		expSuppress    bool
+	}{
+		{
+			name: "When an admin suppresses alerts service returns a success",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			method:  http.MethodPut,
+			reqBody: `{"until": 2000, "reason": "load test"}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user1\",\"alertSuppression\":{\"until\":2000,\"reason\":\"load test\",\"suppressedBy\":\"admin1\",\"suppressedOn\":1000}}\n",
+			},
+			expSuppression: &lease.AlertSuppression{Until: 2000, Reason: "load test", SuppressedBy: "admin1"},
+			expSuppress:    true,
+		},
+		{
+			name: "When an admin lifts the suppression service returns a success",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			method: http.MethodDelete,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user1\"}\n",
+			},
+			expSuppress: true,
+		},
+		{
+			name: "When a user suppresses alerts service returns 401",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			method:  http.MethodPut,
+			reqBody: `{"until": 2000, "reason": "load test"}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to suppress the alerts of lease [abc123], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+		{
+			name: "When the request isn't JSON service returns 400",
+			user: &api.User{
+				Role: api.AdminGroupName,
+			},
+			method:  http.MethodPut,
+			reqBody: `2000`,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("SuppressAlerts", "abc123", mock.MatchedBy(func(s *lease.AlertSuppression) bool {
+				return s != nil
+			})).Return(&lease.Lease{
+				PrincipalID: ptrString("user1"),
+				AlertSuppression: &lease.AlertSuppression{
+					Until:        2000,
+					Reason:       "load test",
+					SuppressedBy: "admin1",
+					SuppressedOn: 1000,
+				},
+			}, nil)
+			leaseSvc.On("SuppressAlerts", "abc123", (*lease.AlertSuppression)(nil)).Return(&lease.Lease{
+				PrincipalID: ptrString("user1"),
+			}, nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: "/leases/abc123/alert-suppression", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expSuppress {
+				leaseSvc.AssertCalled(t, "SuppressAlerts", "abc123", tt.expSuppression)
+			} else {
+				leaseSvc.AssertNotCalled(t, "SuppressAlerts", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
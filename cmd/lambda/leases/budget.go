@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gorilla/mux"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/usage"
+)
+
+type updateLeaseBudgetRequest struct {
+	BudgetAmount float64 `json:"budgetAmount"`
+	Reason       string  `json:"reason"`
+}
+
+// UpdateLeaseBudget raises or lowers the budget of an Active lease.  Only
+// admins may change budgets, including requests signed with IAM credentials,
+// such as from an approval workflow.
+func UpdateLeaseBudget(w http.ResponseWriter, r *http.Request) {
+	leaseID := mux.Vars(r)["leaseID"]
+
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to change the budget of lease [%s], but was not authorized",
+				user.Username, user.Role, leaseID)))
+		return
+	}
+
+	// Deserialize the request JSON as an request object
+	req := &updateLeaseBudgetRequest{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	existing, err := Services.LeaseService().Get(leaseID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	// The budget notifications already sent are recalculated from the
+	// lease's spend so far
+	spend, err := leaseSpend(existing, time.Now())
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	updated, err := Services.LeaseService().UpdateBudget(leaseID, &lease.BudgetChange{
+		Amount:    req.BudgetAmount,
+		Reason:    req.Reason,
+		ChangedBy: user.Username,
+	}, spend)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, updated)
+}
+
+// leaseSpend sums the daily usage of a lease since its budget period began,
+// which is when its status last changed
+func leaseSpend(l *lease.Lease, now time.Time) (float64, error) {
+	usageDB, err := usageService()
+	if err != nil {
+		return 0, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	startDate := aws.Int64Value(l.StatusModifiedOn)
+	if startDate == 0 {
+		startDate = aws.Int64Value(l.CreatedOn)
+	}
+	records, err := usageDB.GetUsageByPrincipal(time.Unix(startDate, 0), aws.StringValue(l.PrincipalID))
+	if err != nil {
+		return 0, errors.NewInternalServer("failed to get usage", err)
+	}
+	cost := usage.LeaseCostOf(records, aws.StringValue(l.AccountID), aws.StringValue(l.PrincipalID), startDate, now.Unix())
+	return cost.CostAmount, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateLeaseBudget(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name      string
+		user      *api.User
+		reqBody   string
+		expResp   response
+		expChange *lease.BudgetChange
+	}{
+		{
+			name: "When an admin changes the budget service returns a success",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			reqBody: `{"budgetAmount": 200, "reason": "load test"}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user1\",\"budgetAmount\":200}\n",
+			},
+			expChange: &lease.BudgetChange{Amount: 200, Reason: "load test", ChangedBy: "admin1"},
+		},
+		{
+			name: "When a user changes the budget service returns 401",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"budgetAmount": 200}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to change the budget of lease [abc123], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+		{
+			name: "When the request isn't JSON service returns 400",
+			user: &api.User{
+				Role: api.AdminGroupName,
+			},
+			reqBody: `200`,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("Get", "abc123").Return(&lease.Lease{
+				AccountID:        ptrString("123456789012"),
+				PrincipalID:      ptrString("user1"),
+				StatusModifiedOn: aws.Int64(1000),
+			}, nil)
+			leaseSvc.On("UpdateBudget", "abc123", mock.AnythingOfType("*lease.BudgetChange"), 30.0).Return(&lease.Lease{
+				PrincipalID:  ptrString("user1"),
+				BudgetAmount: aws.Float64(200),
+			}, nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			// Only the usage of the lease's account counts toward its spend
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByPrincipal", mock.Anything, "user1").Return([]*usage.Usage{
+				{AccountID: aws.String("123456789012"), PrincipalID: aws.String("user1"), StartDate: aws.Int64(86400), CostAmount: aws.Float64(10)},
+				{AccountID: aws.String("123456789012"), PrincipalID: aws.String("user1"), StartDate: aws.Int64(2 * 86400), CostAmount: aws.Float64(20)},
+				{AccountID: aws.String("210987654321"), PrincipalID: aws.String("user1"), StartDate: aws.Int64(2 * 86400), CostAmount: aws.Float64(50)},
+			}, nil)
+			usageSvc = usageSvcMock
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodPut, Path: "/leases/abc123/budget", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expChange != nil {
+				leaseSvc.AssertCalled(t, "UpdateBudget", "abc123", tt.expChange, 30.0)
+			} else {
+				leaseSvc.AssertNotCalled(t, "UpdateBudget", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
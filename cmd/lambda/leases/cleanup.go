@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+	"github.com/gorilla/mux"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+type cleanupLeaseRequest struct {
+	ResourceTypes []string `json:"resourceTypes"`
+	Regions       []string `json:"regions"`
+}
+
+// CleanupLease removes resources of some types, in some regions, from the
+// account of an Active lease, without ending the lease.  The account reset
+// build runs the cleanup as the principal of the lease, with the reset's
+// filters, so only resources the principal could remove are removed.
+func CleanupLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := mux.Vars(r)["leaseID"]
+
+	// Deserialize the request JSON as an request object
+	req := &cleanupLeaseRequest{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	existing, err := Services.LeaseService().Get(leaseID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	// If user is not an admin, they can't clean up the accounts of other users
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	err = user.Authorize(*existing.PrincipalID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	if existing.Status == nil || *existing.Status != lease.StatusActive {
+		api.WriteAPIErrorResponse(w,
+			errors.NewConflict("lease", leaseID, fmt.Errorf("only the accounts of active leases can be cleaned up")))
+		return
+	}
+	cleanup := &lease.Cleanup{
+		ResourceTypes: req.ResourceTypes,
+		Regions:       req.Regions,
+		RequestedBy:   user.Username,
+	}
+	err = cleanup.Validate()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	acct, err := Services.AccountService().Get(*existing.AccountID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	var codeBuildSvc codebuildiface.CodeBuildAPI
+	if err := Services.Config.GetService(&codeBuildSvc); err != nil {
+		api.WriteAPIErrorResponse(w, errors.NewInternalServer("failed to get the code build service", err))
+		return
+	}
+
+	buildEnvironmentVars := []*codebuild.EnvironmentVariable{
+		{
+			Name:  aws.String("RESET_ACCOUNT"),
+			Value: acct.ID,
+		},
+		{
+			Name:  aws.String("RESET_ACCOUNT_ADMIN_ROLE_NAME"),
+			Value: acct.AdminRoleArn.IAMResourceName(),
+		},
+		{
+			Name:  aws.String("RESET_ACCOUNT_PRINCIPAL_ROLE_NAME"),
+			Value: acct.PrincipalRoleArn.IAMResourceName(),
+		},
+		{
+			Name:  aws.String("RESET_CLEANUP_LEASE_ID"),
+			Value: aws.String(leaseID),
+		},
+		{
+			Name:  aws.String("RESET_CLEANUP_RESOURCE_TYPES"),
+			Value: aws.String(strings.Join(cleanup.ResourceTypes, ",")),
+		},
+		{
+			Name:  aws.String("RESET_CLEANUP_REGIONS"),
+			Value: aws.String(strings.Join(cleanup.Regions, ",")),
+		},
+	}
+	// The cleanup never removes more than a reset of the account would
+	if acct.ResetProfile != nil {
+		buildEnvironmentVars = append(buildEnvironmentVars, &codebuild.EnvironmentVariable{
+			Name:  aws.String("RESET_PROFILE"),
+			Value: aws.String(acct.ResetProfile.String()),
+		})
+	}
+
+	log.Printf("Triggering cleanup build %s for lease %s of account %s", Settings.BuildName, leaseID, *acct.ID)
+	build, err := codeBuildSvc.StartBuild(&codebuild.StartBuildInput{
+		EnvironmentVariablesOverride: buildEnvironmentVars,
+		ProjectName:                  aws.String(Settings.BuildName),
+	})
+	if err != nil {
+		api.WriteAPIErrorResponse(w, errors.NewInternalServer("failed to start the cleanup build", err))
+		return
+	}
+	if build.Build != nil {
+		cleanup.BuildID = aws.StringValue(build.Build.Id)
+	}
+
+	updated, err := Services.LeaseService().RecordCleanup(leaseID, cleanup)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusAccepted, updated.LastCleanup)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanupLease(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name       string
+		user       *api.User
+		reqBody    string
+		status     lease.Status
+		expResp    response
+		expCleanup bool
+	}{
+		{
+			name: "When a user cleans up their account service returns a success",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"resourceTypes": ["EC2Instance"], "regions": ["us-east-1"]}`,
+			status:  lease.StatusActive,
+			expResp: response{
+				StatusCode: 202,
+				Body:       "{\"resourceTypes\":[\"EC2Instance\"],\"regions\":[\"us-east-1\"],\"requestedBy\":\"user1\",\"requestedOn\":1000,\"buildId\":\"reset:1\"}\n",
+			},
+			expCleanup: true,
+		},
+		{
+			name: "When a user cleans up another user's account service returns 401",
+			user: &api.User{
+				Username: "user2",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"resourceTypes": ["EC2Instance"]}`,
+			status:  lease.StatusActive,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user2] with role: [User] attempted to act on a lease for [user1], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+		{
+			name: "When the lease is inactive service returns 409",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"resourceTypes": ["EC2Instance"]}`,
+			status:  lease.StatusInactive,
+			expResp: response{
+				StatusCode: 409,
+				Body:       "{\"error\":{\"message\":\"operation cannot be fulfilled on lease \\\"abc123\\\": only the accounts of active leases can be cleaned up\",\"code\":\"ConflictError\"}}\n",
+			},
+		},
+		{
+			name: "When there are no resource types service returns 400",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"regions": ["us-east-1"]}`,
+			status:  lease.StatusActive,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"cleanup validation error: resourceTypes: cannot be blank.\",\"code\":\"RequestValidationError\"}}\n",
+			},
+		},
+		{
+			name: "When the request isn't JSON service returns 400",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `EC2Instance`,
+			status:  lease.StatusActive,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("Get", "abc123").Return(&lease.Lease{
+				PrincipalID: ptrString("user1"),
+				AccountID:   ptrString("123456789012"),
+				Status:      tt.status.StatusPtr(),
+			}, nil)
+			leaseSvc.On("RecordCleanup", "abc123", mock.AnythingOfType("*lease.Cleanup")).Return(
+				func(ID string, cleanup *lease.Cleanup) *lease.Lease {
+					recorded := *cleanup
+					recorded.RequestedOn = 1000
+					return &lease.Lease{LastCleanup: &recorded}
+				}, nil)
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("Get", "123456789012").Return(&account.Account{
+				ID:               ptrString("123456789012"),
+				AdminRoleArn:     arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+				PrincipalRoleArn: arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+			}, nil)
+			codeBuildSvc := awsMocks.CodeBuildAPI{}
+			codeBuildSvc.On("StartBuild", mock.Anything).Return(&codebuild.StartBuildOutput{
+				Build: &codebuild.Build{Id: aws.String("reset:1")},
+			}, nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			svcBldr.Config.WithService(&accountSvc)
+			svcBldr.Config.WithService(&codeBuildSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodPost, Path: "/leases/abc123/cleanup", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expCleanup {
+				codeBuildSvc.AssertCalled(t, "StartBuild", mock.MatchedBy(func(input *codebuild.StartBuildInput) bool {
+					env := map[string]string{}
+					for _, v := range input.EnvironmentVariablesOverride {
+						env[*v.Name] = *v.Value
+					}
+					return env["RESET_ACCOUNT"] == "123456789012" &&
+						env["RESET_ACCOUNT_PRINCIPAL_ROLE_NAME"] == "PrincipalRole" &&
+						env["RESET_CLEANUP_RESOURCE_TYPES"] == "EC2Instance" &&
+						env["RESET_CLEANUP_REGIONS"] == "us-east-1"
+				}))
+			} else {
+				codeBuildSvc.AssertNotCalled(t, "StartBuild", mock.Anything)
+				leaseSvc.AssertNotCalled(t, "RecordCleanup", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -3,18 +3,14 @@ package main
 import (
 	"encoding/json"
 	"github.com/Optum/dce/pkg/api"
+	"log"
 	"net/http"
-	"time"
 
-	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/alert"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 )
 
-const (
-	Weekly = "WEEKLY"
-)
-
 // CreateLease - Function to validate the lease request and create lease
 func CreateLease(w http.ResponseWriter, r *http.Request) {
 	// Deserialize the request JSON as an request object
@@ -28,103 +24,64 @@ func CreateLease(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// if principalId is missing, then throw an error
-	if newLease.PrincipalID == nil {
-		api.WriteAPIErrorResponse(w,
-			errors.NewBadRequest("invalid request parameters: missing principalId"))
-		return
-	}
+	// The lease is requested now.  Only leases from the waitlist were
+	// requested earlier.
+	newLease.RequestedOn = nil
 
-	// If user is not an admin, they can't create leases for other users
-	user := r.Context().Value(api.User{}).(*api.User)
-	err = user.Authorize(*newLease.PrincipalID)
+	usageDB, err := usageService()
 	if err != nil {
-		api.WriteAPIErrorResponse(w, err)
+		api.WriteAPIErrorResponse(w, errors.NewInternalServer("failed to initialize the usage service", err))
 		return
 	}
-
-	// Get the First available Ready Account
-	query := &account.Account{
-		Status: account.StatusReady.StatusPtr(),
-	}
-
-	accounts, err := Services.AccountService().List(query)
+	allocator := lease.NewAllocator(lease.NewAllocatorInput{
+		LeaseSvc:              Services.LeaseService(),
+		AccountSvc:            Services.AccountService(),
+		DirectorySvc:          Services.DirectoryService(),
+		UsageSvc:              usageDB,
+		PrincipalIDFormat:     PrincipalIDFormat,
+		PrincipalBudgetPeriod: Settings.PrincipalBudgetPeriod,
+	})
+
+	// Check the request the same as Slack and the waitlist do, and lease it
+	// the Ready Account which best meets its requirements.  Principals may
+	// ask for the account they last leased, to keep its quota increases,
+	// when it's still Ready.
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	leaseCreated, err := allocator.Request(newLease, user, r.URL.Query().Get("sameAccount") == "true")
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
-	if (accounts == nil) || (accounts != nil && len(*accounts) == 0) {
+	if leaseCreated == nil && !newLease.AccountRequirements().IsEmpty() {
+		// Other accounts may be Ready, so the pool isn't exhausted
+		if r.URL.Query().Get("waitlist") == "true" && Services.WaitlistService().Enabled() {
+			waitForAccount(w, newLease, user)
+			return
+		}
 		api.WriteAPIErrorResponse(w,
-			errors.NewInternalServer("No Available accounts at this moment", nil))
-		return
-	}
-	availableAccount := (*accounts)[0]
-
-	// Get user principal's current spend
-	usageStartTime := getBeginningOfCurrentBillingPeriod(Settings.PrincipalBudgetPeriod)
-	usageRecords, err := usageSvc.GetUsageByPrincipal(usageStartTime, *newLease.PrincipalID)
-	if err != nil {
-		api.WriteAPIErrorResponse(w, err)
-		return
-	}
-
-	// Group by PrincipalID to get sum of total spent for current billing period
-	spent := 0.0
-	for _, usageItem := range usageRecords {
-		spent = spent + *usageItem.CostAmount
-	}
-
-	// Check if an inactive lease already exists with same principal id and account id
-	// if an inactive lease exists, then get the lastModifiedOn value from it
-	queryLeases := &lease.Lease{}
-	queryLeases.AccountID = availableAccount.ID
-	queryLeases.PrincipalID = newLease.PrincipalID
-	queryLeases.Status = lease.StatusInactive.StatusPtr()
-
-	foundLeases, err := Services.LeaseService().List(queryLeases)
-	if err != nil {
-		api.WriteAPIErrorResponse(w, err)
+			errors.NewInternalServer("No Available accounts meeting the requirements at this moment", nil))
 		return
 	}
+	if leaseCreated == nil {
+		err = Services.AlertService().Trigger(&alert.Alert{
+			Type:     alert.TypeReadyPoolExhausted,
+			Severity: alert.SeverityCritical,
+			Summary:  "DCE has no Ready accounts to lease",
+			Details:  map[string]string{"principalId": *newLease.PrincipalID},
+		})
+		if err != nil {
+			log.Printf("Failed to send the ready pool exhausted alert: %s", err)
+		}
 
-	// Since we are using primary key to query, the number of leases that match the query should be one
-	if foundLeases != nil && len(*foundLeases) == 1 {
-		newLease.LastModifiedOn = (*foundLeases)[0].LastModifiedOn
-		newLease.CreatedOn = (*foundLeases)[0].CreatedOn
-	} else {
-		newLease.LastModifiedOn = nil
-	}
-
-	// Create lease
-	newLease.AccountID = availableAccount.ID
-	leaseCreated, err := Services.LeaseService().Create(newLease, spent)
-	if err != nil {
-		api.WriteAPIErrorResponse(w, err)
-		return
-	}
-
-	// Mark the account as Status=Leased
-	availableAccount.Status = account.StatusLeased.StatusPtr()
-	_, err = Services.AccountService().Update(*availableAccount.ID, &availableAccount)
-	if err != nil {
-		api.WriteAPIErrorResponse(w, err)
+		// Wait for an account instead of failing, when asked to
+		if r.URL.Query().Get("waitlist") == "true" && Services.WaitlistService().Enabled() {
+			waitForAccount(w, newLease, user)
+			return
+		}
+		api.WriteAPIErrorResponse(w,
+			errors.NewInternalServer("No Available accounts at this moment", nil))
 		return
 	}
 
 	api.WriteAPIResponse(w, http.StatusCreated, leaseCreated)
 }
-
-// getBeginningOfCurrentBillingPeriod returns starts of the billing period based on budget period
-func getBeginningOfCurrentBillingPeriod(input string) time.Time {
-	currentTime := time.Now()
-	if input == Weekly {
-
-		for currentTime.Weekday() != time.Sunday { // iterate back to Sunday
-			currentTime = currentTime.AddDate(0, 0, -1)
-		}
-
-		return time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, time.UTC)
-	}
-
-	return time.Date(currentTime.Year(), currentTime.Month(), 1, 0, 0, 0, 0, time.UTC)
-}
@@ -10,8 +10,12 @@ import (
 	"github.com/Optum/dce/pkg/api"
 
 	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/alert"
+	alertmocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
 	apiMocks "github.com/Optum/dce/pkg/api/mocks"
 	"github.com/Optum/dce/pkg/config"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
 	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
@@ -36,11 +40,9 @@ func TestWhenCreateSuccess(t *testing.T) {
 		request              events.APIGatewayProxyRequest
 		retLease             *lease.Lease
 		retAccounts          *account.Accounts
-		retAccount           *account.Account
 		getExistingLeases    *lease.Leases
 		getExistingLeasesErr error
 		retListErr           error
-		retUpdateErr         error
 		retCreateErr         error
 	}{
 		{
@@ -65,15 +67,10 @@ func TestWhenCreateSuccess(t *testing.T) {
 					Status: account.StatusReady.StatusPtr(),
 				},
 			},
-			retAccount: &account.Account{
-				ID:     ptrString("1234567890"),
-				Status: account.StatusReady.StatusPtr(),
-			},
 			retLease:             &lease.Lease{},
 			getExistingLeases:    nil,
 			getExistingLeasesErr: nil,
 			retListErr:           nil,
-			retUpdateErr:         nil,
 			retCreateErr:         nil,
 		},
 		{
@@ -98,10 +95,6 @@ func TestWhenCreateSuccess(t *testing.T) {
 					Status: account.StatusReady.StatusPtr(),
 				},
 			},
-			retAccount: &account.Account{
-				ID:     ptrString("1234567890"),
-				Status: account.StatusReady.StatusPtr(),
-			},
 			retLease: &lease.Lease{},
 			getExistingLeases: &lease.Leases{
 				lease.Lease{
@@ -113,7 +106,6 @@ func TestWhenCreateSuccess(t *testing.T) {
 			},
 			getExistingLeasesErr: nil,
 			retListErr:           nil,
-			retUpdateErr:         nil,
 			retCreateErr:         nil,
 		},
 	}
@@ -124,25 +116,27 @@ func TestWhenCreateSuccess(t *testing.T) {
 			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
 
 			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
 			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
 
 			userDetailSvc := apiMocks.UserDetailer{}
 			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
 
-			accountSvc.On("List", mock.Anything).Return(
-				tt.retAccounts, tt.retListErr,
-			)
-			accountSvc.On("Update", mock.Anything, mock.Anything).Return(
-				tt.retAccount, tt.retUpdateErr,
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(
+				firstAccount(tt.retAccounts), tt.retListErr,
 			)
 			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(
 				tt.getExistingLeases, tt.getExistingLeasesErr,
 			)
-			leaseSvc.On("Create", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(
 				tt.retLease, tt.retCreateErr,
 			)
 
-			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithEnv("PrincipalBudgetPeriod", "PRINCIPAL_BUDGET_PERIOD", "Weekly").WithService(&userDetailSvc)
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithEnv("PrincipalBudgetPeriod", "PRINCIPAL_BUDGET_PERIOD", "Weekly").WithService(&userDetailSvc).WithService(&directorySvc)
 			_, err := svcBldr.Build()
 
 			assert.Nil(t, err)
@@ -176,10 +170,9 @@ func TestWhenCreateError(t *testing.T) {
 		request      events.APIGatewayProxyRequest
 		retLease     *lease.Lease
 		retAccounts  *account.Accounts
-		retAccount   *account.Account
 		retListErr   error
-		retUpdateErr error
 		retCreateErr error
+		expAlert     bool
 	}{
 		{
 			name: "When principalId is missing. Then a client error is returned.",
@@ -199,7 +192,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     nil,
 			retListErr:   nil,
-			retUpdateErr: nil,
 			retCreateErr: nil,
 		},
 		{
@@ -226,7 +218,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     &lease.Lease{},
 			retListErr:   nil,
-			retUpdateErr: nil,
 			retCreateErr: nil,
 		},
 		{
@@ -253,7 +244,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     &lease.Lease{},
 			retListErr:   nil,
-			retUpdateErr: nil,
 			retCreateErr: nil,
 		},
 		{
@@ -274,7 +264,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     nil,
 			retListErr:   fmt.Errorf("failure"),
-			retUpdateErr: nil,
 			retCreateErr: nil,
 		},
 		{
@@ -296,35 +285,8 @@ func TestWhenCreateError(t *testing.T) {
 			retAccounts:  &account.Accounts{},
 			retLease:     nil,
 			retListErr:   nil,
-			retUpdateErr: nil,
-			retCreateErr: nil,
-		},
-		{
-			name: "When updating account status to leased fails. Then an internal server error is returned.",
-			user: &api.User{
-				Username: "admin1",
-				Role:     api.AdminGroupName,
-			},
-			request: events.APIGatewayProxyRequest{
-				HTTPMethod: http.MethodPost,
-				Path:       "/leases",
-				Body:       "{ \"principalId\": \"User1\", \"budgetAmount\": 200.00 }",
-			},
-			expResp: events.APIGatewayProxyResponse{
-				StatusCode:        http.StatusInternalServerError,
-				Body:              "{\"error\":{\"message\":\"unknown error\",\"code\":\"ServerError\"}}\n",
-				MultiValueHeaders: standardHeaders,
-			},
-			retAccounts: &account.Accounts{
-				account.Account{
-					ID:     ptrString("1234567890"),
-					Status: account.StatusReady.StatusPtr(),
-				},
-			},
-			retLease:     nil,
-			retListErr:   nil,
-			retUpdateErr: fmt.Errorf("failure"),
 			retCreateErr: nil,
+			expAlert:     true,
 		},
 		{
 			name: "When getting principal spend fails. Then an internal server error is returned.",
@@ -350,7 +312,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     nil,
 			retListErr:   nil,
-			retUpdateErr: nil,
 			retCreateErr: nil,
 		},
 		{
@@ -377,7 +338,6 @@ func TestWhenCreateError(t *testing.T) {
 			},
 			retLease:     nil,
 			retListErr:   nil,
-			retUpdateErr: nil,
 			retCreateErr: fmt.Errorf("Error"),
 		},
 	}
@@ -388,22 +348,26 @@ func TestWhenCreateError(t *testing.T) {
 			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
 
 			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
 			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
 
 			userDetailSvc := apiMocks.UserDetailer{}
 			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
 
-			accountSvc.On("List", mock.Anything).Return(
-				tt.retAccounts, tt.retListErr,
-			)
-			accountSvc.On("Update", mock.Anything, mock.Anything).Return(
-				tt.retAccount, tt.retUpdateErr,
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(
+				firstAccount(tt.retAccounts), tt.retListErr,
 			)
-			leaseSvc.On("Create", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(
 				tt.retLease, tt.retCreateErr,
 			)
+			alertSvc := alertmocks.Servicer{}
+			alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
 
-			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithEnv("PrincipalBudgetPeriod", "PRINCIPAL_BUDGET_PERIOD", "Weekly").WithService(&userDetailSvc)
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithEnv("PrincipalBudgetPeriod", "PRINCIPAL_BUDGET_PERIOD", "Weekly").WithService(&userDetailSvc).WithService(&alertSvc).WithService(&directorySvc)
 			_, err := svcBldr.Build()
 
 			assert.Nil(t, err)
@@ -416,7 +380,374 @@ func TestWhenCreateError(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, tt.expResp, resp)
+			if tt.expAlert {
+				alertSvc.AssertCalled(t, "Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+					return a.Type == alert.TypeReadyPoolExhausted
+				}))
+			} else {
+				alertSvc.AssertNotCalled(t, "Trigger", mock.Anything)
+			}
+		})
+	}
+
+}
+
+func TestCreateWithDirectory(t *testing.T) {
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return(nil, nil)
+
+	quota := &lease.Quota{MaxLeaseBudgetAmount: 5000}
+	inactive := errors.NewValidation("lease", fmt.Errorf("principal User1 is not an active directory user"))
+	tests := []struct {
+		name      string
+		quota     *lease.Quota
+		quotaErr  error
+		expStatus int
+		expQuota  *lease.Quota
+	}{
+		{
+			name:      "should create the lease with the quota of the user's groups",
+			quota:     quota,
+			expStatus: http.StatusCreated,
+			expQuota:  quota,
+		},
+		{
+			name:      "should reject principals who aren't active directory users",
+			quotaErr:  inactive,
+			expStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "admin1", Role: api.AdminGroupName})
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return([]string{"Engineering", "Data"})
+			readyAccount := &account.Account{ID: ptrString("1234567890"), Status: account.StatusReady.StatusPtr()}
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(readyAccount, nil)
+			accountSvc.On("GetReadyAccountWithRequirements", mock.Anything, mock.Anything).Return(readyAccount, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(&lease.Lease{}, nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", "User1").Return(tt.quota, tt.quotaErr)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&userDetailSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+			usageSvc = usageSvcMock
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/leases",
+				Body:       "{ \"principalId\": \"User1\", \"budgetAmount\": 2000.00 }",
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode, resp.Body)
+			if tt.expQuota != nil {
+				leaseSvc.AssertCalled(t, "CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 0.0, tt.expQuota)
+			} else {
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+				assert.Contains(t, resp.Body, "principal User1 is not an active directory user")
+			}
 		})
 	}
+}
+
+func TestCreateWithLeaseGroups(t *testing.T) {
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return(nil, nil)
+
+	tests := []struct {
+		name      string
+		user      *api.User
+		expStatus int
+	}{
+		{
+			name:      "should allow members of the lease groups",
+			user:      &api.User{Username: "User1", Role: api.UserGroupName, Groups: []string{"Sales", "Data"}},
+			expStatus: http.StatusCreated,
+		},
+		{
+			name:      "should allow admins",
+			user:      &api.User{Username: "admin1", Role: api.AdminGroupName},
+			expStatus: http.StatusCreated,
+		},
+		{
+			name:      "should reject users outside the lease groups",
+			user:      &api.User{Username: "User1", Role: api.UserGroupName, Groups: []string{"Sales"}},
+			expStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return([]string{"Engineering", "Data"})
+			readyAccount := &account.Account{ID: ptrString("1234567890"), Status: account.StatusReady.StatusPtr()}
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(readyAccount, nil)
+			accountSvc.On("GetReadyAccountWithRequirements", mock.Anything, mock.Anything).Return(readyAccount, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(&lease.Lease{}, nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&userDetailSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+			usageSvc = usageSvcMock
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/leases",
+				Body:       "{ \"principalId\": \"User1\", \"budgetAmount\": 200.00 }",
+			})
 
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode, resp.Body)
+			if tt.expStatus == http.StatusUnauthorized {
+				assert.Contains(t, resp.Body, "User [User1] is not a member of the groups allowed to create leases: [Engineering, Data]")
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+			}
+			// Users are only leased accounts from the pools of their groups
+			if tt.expStatus == http.StatusCreated && tt.user.Role == api.UserGroupName {
+				accountSvc.AssertCalled(t, "GetReadyAccountWithRequirements", "User1", mock.MatchedBy(func(r *account.Requirements) bool {
+					return r.Groups != nil && len(*r.Groups) == 2 && (*r.Groups)[1] == "Data"
+				}))
+			}
+		})
+	}
+}
+
+func TestCreateWithSameAccount(t *testing.T) {
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return(nil, nil)
+
+	lastLeased := &account.Account{ID: ptrString("1111111111"), Status: account.StatusReady.StatusPtr(), LastLeasedBy: ptrString("User1")}
+	other := &account.Account{ID: ptrString("2222222222"), Status: account.StatusReady.StatusPtr()}
+	tests := []struct {
+		name         string
+		sameAccount  bool
+		lastLeased   *account.Account
+		expAccountID string
+	}{
+		{
+			name:         "should lease the account last leased to the principal",
+			sameAccount:  true,
+			lastLeased:   lastLeased,
+			expAccountID: "1111111111",
+		},
+		{
+			name:         "should lease another account when the last leased one isn't available",
+			sameAccount:  true,
+			expAccountID: "2222222222",
+		},
+		{
+			name:         "should lease any account without asking for the same one",
+			expAccountID: "2222222222",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "User1", Role: api.UserGroupName})
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+			accountSvc.On("GetLastLeasedAccount", "User1", &account.Requirements{}).Return(tt.lastLeased, nil)
+			accountSvc.On("GetReadyAccount", "User1").Return(other, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			leaseSvc.On("CreateWithQuota", mock.MatchedBy(func(l *lease.Lease) bool {
+				return *l.AccountID == tt.expAccountID
+			}), mock.Anything, mock.Anything).Return(&lease.Lease{}, nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&userDetailSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+			usageSvc = usageSvcMock
+
+			request := events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/leases",
+				Body:       "{ \"principalId\": \"User1\", \"budgetAmount\": 200.00 }",
+			}
+			if tt.sameAccount {
+				request.QueryStringParameters = map[string]string{"sameAccount": "true"}
+			}
+			resp, err := Handler(context.TODO(), request)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode, resp.Body)
+			accountSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			leaseSvc.AssertExpectations(t)
+			if !tt.sameAccount {
+				accountSvc.AssertNotCalled(t, "GetLastLeasedAccount", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateWithRequirements(t *testing.T) {
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return(nil, nil)
+
+	requirements := &account.Requirements{
+		Pool:          ptrString("gpu"),
+		Regions:       []string{"ap-east-1"},
+		ServiceQuotas: []account.ServiceQuota{{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32}},
+	}
+	tests := []struct {
+		name       string
+		body       string
+		retAccount *account.Account
+		expStatus  int
+	}{
+		{
+			name:       "should lease an account meeting the requirements",
+			retAccount: &account.Account{ID: ptrString("1234567890"), Status: account.StatusReady.StatusPtr()},
+			expStatus:  http.StatusCreated,
+		},
+		{
+			name:      "should fail without alerting when no account meets the requirements",
+			expStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "should fail on invalid requirements",
+			body:      "{ \"principalId\": \"User1\", \"budgetAmount\": 200.00, \"requirements\": {\"regions\": [\"moon-1\"]} }",
+			expStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "User1", Role: api.UserGroupName})
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+			accountSvc.On("GetReadyAccountWithRequirements", "User1", requirements).Return(tt.retAccount, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(&lease.Lease{}, nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&userDetailSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+			usageSvc = usageSvcMock
+
+			body := tt.body
+			if body == "" {
+				body = "{ \"principalId\": \"User1\", \"budgetAmount\": 200.00, \"requirements\": {\"pool\": \"gpu\", \"regions\": [\"ap-east-1\"]}, \"requiredQuotas\": [{\"serviceCode\": \"ec2\", \"quotaCode\": \"L-417A185B\", \"value\": 32}] }"
+			}
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/leases",
+				Body:       body,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, resp.StatusCode, resp.Body)
+			accountSvc.AssertNotCalled(t, "GetReadyAccount", mock.Anything)
+			if tt.retAccount == nil {
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expStatus == http.StatusInternalServerError {
+				assert.Contains(t, resp.Body, "No Available accounts meeting the requirements at this moment")
+			}
+		})
+	}
+}
+
+func TestCreateWithPolicyCustomization(t *testing.T) {
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return(nil, nil)
+
+	cfgBldr := &config.ConfigurationBuilder{}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	userDetailSvc := apiMocks.UserDetailer{}
+	userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "admin1", Role: api.AdminGroupName})
+
+	customization := &account.PolicyCustomization{Add: []string{"Bedrock"}}
+	accountSvc := accountmocks.Servicer{}
+	accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+	accountSvc.On("GetReadyAccount", mock.Anything).Return(&account.Account{ID: ptrString("1234567890"), Status: account.StatusReady.StatusPtr()}, nil)
+
+	leaseSvc := leasemocks.Servicer{}
+	leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), mock.Anything).Return(nil)
+	leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+	leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), mock.Anything, mock.Anything).Return(&lease.Lease{
+		PolicyCustomization: customization,
+	}, nil)
+
+	directorySvc := directorymocks.Servicer{}
+	directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+
+	svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&userDetailSvc).WithService(&directorySvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	Services = svcBldr
+	usageSvc = usageSvcMock
+
+	resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodPost,
+		Path:       "/leases",
+		Body:       "{ \"principalId\": \"User1\", \"policyCustomization\": { \"add\": [\"Bedrock\"] } }",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode, resp.Body)
+	leaseSvc.AssertCalled(t, "CreateWithQuota", mock.MatchedBy(func(l *lease.Lease) bool {
+		return assert.ObjectsAreEqual(customization, l.PolicyCustomization)
+	}), mock.Anything, mock.Anything)
+	accountSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// firstAccount is the account leased from a list of Ready accounts
+func firstAccount(accounts *account.Accounts) *account.Account {
+	if accounts == nil || len(*accounts) == 0 {
+		return nil
+	}
+	return &(*accounts)[0]
 }
@@ -2,17 +2,38 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
+	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/gorilla/mux"
 )
 
+// deleteLeaseDryRun is returned instead of ending a lease, when ending it is a
+// dry run
+type deleteLeaseDryRun struct {
+	DryRun  bool         `json:"dryRun"`
+	Lease   *lease.Lease `json:"lease"`
+	Actions []string     `json:"actions"`
+}
+
 // DeleteLeaseByID - Deletes the given lease by Lease ID
 func DeleteLeaseByID(w http.ResponseWriter, r *http.Request) {
 	leaseID := mux.Vars(r)["leaseID"]
+	dryRun, err := api.ParseDryRun(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	resetProfile, err := parseResetProfile(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
 	_lease, err := Services.LeaseService().Get(leaseID)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
@@ -20,14 +41,19 @@ func DeleteLeaseByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	//If user is not an admin, they can't delete leases for other users
-	user := r.Context().Value(api.User{}).(*api.User)
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
 	err = user.Authorize(*_lease.PrincipalID)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
 
-	deletedLease, err := Services.LeaseService().Delete(leaseID)
+	if dryRun {
+		writeDeleteLeaseDryRun(w, _lease, resetProfile)
+		return
+	}
+
+	deletedLease, err := Services.LeaseService().DeleteWithResetProfile(leaseID, resetProfile)
 
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
@@ -40,10 +66,21 @@ func DeleteLeaseByID(w http.ResponseWriter, r *http.Request) {
 // DeleteLease - Deletes the given lease
 func DeleteLease(w http.ResponseWriter, r *http.Request) {
 
+	dryRun, err := api.ParseDryRun(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	resetProfile, err := parseResetProfile(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
 	// Deserialize the request JSON as an request object
 	queryLease := &lease.Lease{}
 	decoder := json.NewDecoder(r.Body)
-	err := decoder.Decode(queryLease)
+	err = decoder.Decode(queryLease)
 	if err != nil {
 		api.WriteAPIErrorResponse(w,
 			errors.NewBadRequest("invalid request parameters"))
@@ -61,9 +98,11 @@ func DeleteLease(w http.ResponseWriter, r *http.Request) {
 			errors.NewBadRequest("invalid request parameters: missing PrincipalID"))
 		return
 	}
+	principalID := PrincipalIDFormat.Normalize(*queryLease.PrincipalID)
+	queryLease.PrincipalID = &principalID
 
 	// If user is not an admin, they can't delete leases for other users
-	user := r.Context().Value(api.User{}).(*api.User)
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
 	err = user.Authorize(*queryLease.PrincipalID)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
@@ -76,7 +115,12 @@ func DeleteLease(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deletedLease, err := Services.LeaseService().Delete(*lease.ID)
+	if dryRun {
+		writeDeleteLeaseDryRun(w, lease, resetProfile)
+		return
+	}
+
+	deletedLease, err := Services.LeaseService().DeleteWithResetProfile(*lease.ID, resetProfile)
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
@@ -84,3 +128,38 @@ func DeleteLease(w http.ResponseWriter, r *http.Request) {
 
 	api.WriteAPIResponse(w, http.StatusOK, deletedLease)
 }
+
+// parseResetProfile gets the reset profile the account of the lease is reset
+// with, eg. compute-only, or "" when the pool's is used
+func parseResetProfile(r *http.Request) (string, error) {
+	resetProfile := r.URL.Query().Get("resetProfile")
+	err := account.ValidateResetProfile(resetProfile)
+	if err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("resetProfile %s", err))
+	}
+	return resetProfile, nil
+}
+
+// writeDeleteLeaseDryRun checks a lease can be ended, and writes what ending
+// it would do without ending it
+func writeDeleteLeaseDryRun(w http.ResponseWriter, l *lease.Lease, resetProfile string) {
+	err := Services.LeaseService().ValidateEnd(l)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	reset := fmt.Sprintf("Reset account %s", *l.AccountID)
+	if resetProfile != "" {
+		reset = fmt.Sprintf("Reset account %s with the %s reset profile", *l.AccountID, resetProfile)
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, deleteLeaseDryRun{
+		DryRun: true,
+		Lease:  l,
+		Actions: []string{
+			fmt.Sprintf("End lease %s with reason %q", *l.ID, lease.StatusReasonDestroyed),
+			reset,
+		},
+	})
+}
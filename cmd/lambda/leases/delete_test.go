@@ -124,7 +124,7 @@ func TestDeleteLeaseByLeaseID(t *testing.T) {
 			leaseSvc.On("Get", tt.leaseID).Return(
 				tt.expLease, tt.getErr,
 			)
-			leaseSvc.On("Delete", tt.leaseID).Return(
+			leaseSvc.On("DeleteWithResetProfile", tt.leaseID, "").Return(
 				tt.expLease, tt.getErr,
 			)
 
@@ -363,7 +363,7 @@ func TestDeleteLeaseByPrincipalIDAndAccountID(t *testing.T) {
 				tt.getLease, tt.getErr,
 			)
 
-			leaseSvc.On("Delete", *tt.expLease.ID).Return(
+			leaseSvc.On("DeleteWithResetProfile", *tt.expLease.ID, "").Return(
 				tt.expLease, tt.getErr,
 			)
 			userDetailSvc := apiMocks.UserDetailer{}
@@ -395,3 +395,84 @@ func TestDeleteLeaseByPrincipalIDAndAccountID(t *testing.T) {
 	}
 
 }
+
+func TestDeleteLeaseDryRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         *api.User
+		resetProfile string
+		validateErr  error
+		expStatus    int
+		expBody      string
+	}{
+		{
+			name:      "admin checks the lease can be ended",
+			user:      &api.User{Username: "admin1", Role: api.AdminGroupName},
+			expStatus: http.StatusOK,
+			expBody:   "{\"dryRun\":true,\"lease\":{\"accountId\":\"123456789012\",\"principalId\":\"principal\",\"id\":\"abc123\",\"leaseStatus\":\"Active\"},\"actions\":[\"End lease abc123 with reason \\\"Destroyed\\\"\",\"Reset account 123456789012\"]}\n",
+		},
+		{
+			name:         "admin checks the lease can be ended with a reset profile",
+			user:         &api.User{Username: "admin1", Role: api.AdminGroupName},
+			resetProfile: "compute-only",
+			expStatus:    http.StatusOK,
+			expBody:      "{\"dryRun\":true,\"lease\":{\"accountId\":\"123456789012\",\"principalId\":\"principal\",\"id\":\"abc123\",\"leaseStatus\":\"Active\"},\"actions\":[\"End lease abc123 with reason \\\"Destroyed\\\"\",\"Reset account 123456789012 with the compute-only reset profile\"]}\n",
+		},
+		{
+			name:         "invalid reset profile",
+			user:         &api.User{Username: "admin1", Role: api.AdminGroupName},
+			resetProfile: "everything",
+			expStatus:    http.StatusBadRequest,
+			expBody:      "{\"error\":{\"message\":\"resetProfile must be one of full, compute-only, data-preserving\",\"code\":\"ClientError\"}}\n",
+		},
+		{
+			name:        "lease already ended",
+			user:        &api.User{Username: "admin1", Role: api.AdminGroupName},
+			validateErr: errors.NewConflict("lease", "abc123", fmt.Errorf("leaseStatus: must be active lease")),
+			expStatus:   http.StatusConflict,
+			expBody:     "{\"error\":{\"message\":\"operation cannot be fulfilled on lease \\\"abc123\\\": leaseStatus: must be active lease\",\"code\":\"ConflictError\"}}\n",
+		},
+		{
+			name:      "user cannot dry run ending other users lease",
+			user:      &api.User{Username: "user1", Role: api.UserGroupName},
+			expStatus: http.StatusUnauthorized,
+			expBody:   "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to act on a lease for [principal], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activeLease := &lease.Lease{
+				ID:          ptrString("abc123"),
+				Status:      lease.StatusActive.StatusPtr(),
+				PrincipalID: ptrString("principal"),
+				AccountID:   ptrString("123456789012"),
+			}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("Get", "abc123").Return(activeLease, nil)
+			leaseSvc.On("ValidateEnd", activeLease).Return(tt.validateErr)
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+
+			actualResponse, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				Path:                  "/leases/abc123",
+				HTTPMethod:            http.MethodDelete,
+				QueryStringParameters: map[string]string{"dryRun": "true", "resetProfile": tt.resetProfile},
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, actualResponse.StatusCode)
+			assert.Equal(t, tt.expBody, actualResponse.Body)
+			leaseSvc.AssertNotCalled(t, "DeleteWithResetProfile", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// GetLeaseDurations returns the default and maximum durations of leases
+func GetLeaseDurations(w http.ResponseWriter, r *http.Request) {
+	durations, err := Services.LeaseService().GetDurations()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, durations)
+}
+
+// UpdateLeaseDurations changes the default and maximum durations of leases
+// from the account pool.  Only admins may change them.
+func UpdateLeaseDurations(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to change the lease durations, but was not authorized",
+				user.Username, user.Role)))
+		return
+	}
+
+	// Deserialize the request JSON as an request object
+	pool := &lease.Durations{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(pool)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	durations, err := Services.LeaseService().UpdatePoolDurations(pool)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, durations)
+}
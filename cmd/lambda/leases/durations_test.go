@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaseDurations(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	system := lease.Durations{DefaultLeaseDuration: 604800, MaxLeaseDuration: 604800}
+	pool := &lease.Durations{DefaultLeaseDuration: 86400, MaxLeaseDuration: 259200}
+	tests := []struct {
+		name      string
+		user      *api.User
+		method    string
+		reqBody   string
+		expResp   response
+		expUpdate *lease.Durations
+	}{
+		{
+			name: "When a user gets the durations service returns them",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			method: http.MethodGet,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"defaultLeaseDuration\":604800,\"maxLeaseDuration\":604800,\"system\":{\"defaultLeaseDuration\":604800,\"maxLeaseDuration\":604800}}\n",
+			},
+		},
+		{
+			name: "When an admin changes the durations service returns a success",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			method:  http.MethodPut,
+			reqBody: `{"defaultLeaseDuration": 86400, "maxLeaseDuration": 259200}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"defaultLeaseDuration\":86400,\"maxLeaseDuration\":259200,\"system\":{\"defaultLeaseDuration\":604800,\"maxLeaseDuration\":604800},\"pool\":{\"defaultLeaseDuration\":86400,\"maxLeaseDuration\":259200}}\n",
+			},
+			expUpdate: pool,
+		},
+		{
+			name: "When a user changes the durations service returns 401",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			method:  http.MethodPut,
+			reqBody: `{"maxLeaseDuration": 259200}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to change the lease durations, but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("GetDurations").Return(&lease.LeaseDurations{
+				Durations: system,
+				System:    system,
+			}, nil)
+			leaseSvc.On("UpdatePoolDurations", mock.AnythingOfType("*lease.Durations")).Return(&lease.LeaseDurations{
+				Durations: *pool,
+				System:    system,
+				Pool:      pool,
+			}, nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: "/system/lease-durations", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expUpdate != nil {
+				leaseSvc.AssertCalled(t, "UpdatePoolDurations", tt.expUpdate)
+			} else {
+				leaseSvc.AssertNotCalled(t, "UpdatePoolDurations", mock.Anything)
+			}
+		})
+	}
+}
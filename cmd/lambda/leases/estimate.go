@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// estimateMinSimilarLeases is the fewest similar leases an estimate is based
+// on. Estimates are based on all past leases when there are fewer.
+const estimateMinSimilarLeases = 5
+
+// EstimateLeaseCost - Estimates the range a lease request is expected to
+// cost, from the spend of past leases in the pool.  The request is the same
+// as for creating a lease, and the lease lasts until its expiresOn.
+func EstimateLeaseCost(w http.ResponseWriter, r *http.Request) {
+	template := &lease.Lease{}
+	err := json.NewDecoder(r.Body).Decode(template)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	// Only members of the lease groups of the pool may lease its accounts
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	err = user.AuthorizeGroups(Services.AccountService().PoolLeaseGroups(template.AccountRequirements().Pool))
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	if template.ExpiresOn == nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters: missing expiresOn"))
+		return
+	}
+	now := time.Now()
+	duration := time.Unix(*template.ExpiresOn, 0).Sub(now)
+	if duration <= 0 {
+		api.WriteAPIErrorResponse(w,
+			errors.NewValidation("lease", fmt.Errorf("expiresOn: must be in the future")))
+		return
+	}
+
+	costs, similar, err := pastLeaseCosts(template.PolicyCustomization, now)
+	if err != nil {
+		log.Printf("Failed to get the cost of past leases: %s", err)
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	var estimate *usage.Estimate
+	if len(similar) >= estimateMinSimilarLeases {
+		estimate = usage.NewEstimate(duration.Hours()/24, similar, Settings.BudgetCurrency)
+		estimate.SimilarLeases = true
+	} else {
+		estimate = usage.NewEstimate(duration.Hours()/24, costs, Settings.BudgetCurrency)
+	}
+	api.WriteAPIResponse(w, http.StatusOK, estimate)
+}
+
+// pastLeaseCosts gets the cost of the leases which ended within the
+// estimate lookback period, and of those which are similar to the template
+func pastLeaseCosts(customization *account.PolicyCustomization, now time.Time) ([]usage.LeaseCost, []usage.LeaseCost, error) {
+	lookbackStart := now.AddDate(0, 0, -Settings.LeaseEstimateLookbackDays)
+
+	usageDB, err := usageService()
+	if err != nil {
+		return nil, nil, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	records, err := usageDB.GetUsageByDateRange(lookbackStart, now)
+	if err != nil {
+		return nil, nil, errors.NewInternalServer("failed to get usage", err)
+	}
+
+	// Spend recorded in another currency, before the budget currency was
+	// changed, can't be compared
+	budgetRecords := []*usage.Usage{}
+	for _, u := range records {
+		if aws.StringValue(u.CostCurrency) == Settings.BudgetCurrency {
+			budgetRecords = append(budgetRecords, u)
+		}
+	}
+
+	all := []usage.LeaseCost{}
+	similar := []usage.LeaseCost{}
+	err = Services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusInactive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for _, l := range *leases {
+			if l.CreatedOn == nil || l.StatusModifiedOn == nil || *l.CreatedOn < lookbackStart.Unix() {
+				continue
+			}
+			cost := usage.LeaseCostOf(budgetRecords, aws.StringValue(l.AccountID), aws.StringValue(l.PrincipalID),
+				*l.CreatedOn, *l.StatusModifiedOn)
+			all = append(all, cost)
+			if isSimilarCustomization(l.PolicyCustomization, customization) {
+				similar = append(similar, cost)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return all, similar, nil
+}
+
+// isSimilarCustomization is true when two leases customize the principal
+// policy with the same statements
+func isSimilarCustomization(a *account.PolicyCustomization, b *account.PolicyCustomization) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() && b.IsEmpty()
+	}
+	return reflect.DeepEqual(sortedSids(a.Add), sortedSids(b.Add)) &&
+		reflect.DeepEqual(sortedSids(a.Remove), sortedSids(b.Remove))
+}
+
+func sortedSids(sids []string) []string {
+	sorted := append([]string{}, sids...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateLeaseCost(t *testing.T) {
+	now := time.Now()
+	day := int64(86400)
+	started := now.Unix() - 10*day
+	ended := started + 2*day
+
+	// Ten past leases of two days, five of which added the same statement.
+	// Leases with the statement spent 10 a day, and the others 1 a day.
+	leases := lease.Leases{}
+	records := []*usage.Usage{}
+	for i := 0; i < 10; i++ {
+		accountID := fmt.Sprintf("1234567890%02d", i)
+		daily := 1.0
+		var customization *account.PolicyCustomization
+		if i < 5 {
+			daily = 10
+			customization = &account.PolicyCustomization{Add: []string{"AllowSageMaker"}}
+		}
+		leases = append(leases, lease.Lease{
+			AccountID:           aws.String(accountID),
+			PrincipalID:         aws.String("jdoe"),
+			CreatedOn:           aws.Int64(started),
+			StatusModifiedOn:    aws.Int64(ended),
+			PolicyCustomization: customization,
+		})
+		for d := int64(0); d < 2; d++ {
+			records = append(records, &usage.Usage{
+				AccountID:    aws.String(accountID),
+				PrincipalID:  aws.String("jdoe"),
+				StartDate:    aws.Int64(started - started%day + d*day),
+				CostAmount:   aws.Float64(daily),
+				CostCurrency: aws.String("USD"),
+			})
+		}
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		expCode     int
+		expEstimate *usage.Estimate
+	}{
+		{
+			name:    "should estimate from similar leases",
+			body:    fmt.Sprintf(`{"expiresOn": %d, "policyCustomization": {"add": ["AllowSageMaker"]}}`, now.Unix()+7*day),
+			expCode: http.StatusOK,
+			expEstimate: &usage.Estimate{
+				CostLow: 70, CostExpected: 70, CostHigh: 70, CostCurrency: "USD", SampleSize: 5, SimilarLeases: true,
+			},
+		},
+		{
+			name:    "should estimate from all leases when too few are similar",
+			body:    fmt.Sprintf(`{"expiresOn": %d, "policyCustomization": {"add": ["AllowEMR"]}}`, now.Unix()+7*day),
+			expCode: http.StatusOK,
+			expEstimate: &usage.Estimate{
+				CostLow: 7, CostExpected: 7, CostHigh: 70, CostCurrency: "USD", SampleSize: 10,
+			},
+		},
+		{
+			name:    "should require expiresOn",
+			body:    `{"budgetAmount": 100}`,
+			expCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should reject leases which already expired",
+			body:    fmt.Sprintf(`{"expiresOn": %d}`, now.Unix()-day),
+			expCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusInactive.StatusPtr()}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(func(*lease.Leases) bool)(&leases)
+				}).
+				Return(nil)
+
+			userDetailSvc := &apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "jdoe", Role: api.UserGroupName})
+
+			accountSvc := &accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return(nil)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(leaseSvc).WithService(userDetailSvc).WithService(accountSvc)
+			_, err := svcBldr.Build()
+			require.Nil(t, err)
+			Services = svcBldr
+
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return(records, nil)
+			usageSvc = usageSvcMock
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Path:       "/leases/estimate",
+				Body:       tt.body,
+			})
+			require.Nil(t, err)
+			assert.Equal(t, tt.expCode, resp.StatusCode, resp.Body)
+			if tt.expEstimate == nil {
+				return
+			}
+
+			estimate := &usage.Estimate{}
+			require.Nil(t, json.Unmarshal([]byte(resp.Body), estimate))
+			assert.InDelta(t, 7, estimate.DurationDays, 0.01)
+			tt.expEstimate.DurationDays = estimate.DurationDays
+			assert.Equal(t, tt.expEstimate, estimate)
+		})
+	}
+}
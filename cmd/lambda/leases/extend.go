@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+)
+
+type extendLeaseRequest struct {
+	ExpiresOn int64 `json:"expiresOn"`
+}
+
+// ExtendLease changes when an Active lease expires, up to the maximum lease
+// duration from when it was created
+func ExtendLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := mux.Vars(r)["leaseID"]
+
+	// Deserialize the request JSON as an request object
+	req := &extendLeaseRequest{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(req)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	existing, err := Services.LeaseService().Get(leaseID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	// If user is not an admin, they can't extend leases for other users
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	err = user.Authorize(*existing.PrincipalID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	extended, err := Services.LeaseService().Extend(leaseID, req.ExpiresOn)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, extended)
+}
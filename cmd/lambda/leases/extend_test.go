@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExtendLease(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name      string
+		user      *api.User
+		reqBody   string
+		expResp   response
+		expExtend bool
+	}{
+		{
+			name: "When a user extends their lease service returns a success",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"expiresOn": 2000}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user1\",\"expiresOn\":2000}\n",
+			},
+			expExtend: true,
+		},
+		{
+			name: "When a user extends another user's lease service returns 401",
+			user: &api.User{
+				Username: "user2",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"expiresOn": 2000}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user2] with role: [User] attempted to act on a lease for [user1], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+		{
+			name: "When the request isn't JSON service returns 400",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `2000`,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("Get", "abc123").Return(&lease.Lease{
+				PrincipalID: ptrString("user1"),
+				ExpiresOn:   aws.Int64(1000),
+			}, nil)
+			leaseSvc.On("Extend", "abc123", int64(2000)).Return(&lease.Lease{
+				PrincipalID: ptrString("user1"),
+				ExpiresOn:   aws.Int64(2000),
+			}, nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodPost, Path: "/leases/abc123/extend", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expExtend {
+				leaseSvc.AssertCalled(t, "Extend", "abc123", int64(2000))
+			} else {
+				leaseSvc.AssertNotCalled(t, "Extend", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
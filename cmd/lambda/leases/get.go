@@ -20,10 +20,11 @@ func GetLeaseByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//If user is not an admin, they can't get leases for other users
-	user := r.Context().Value(api.User{}).(*api.User)
+	//If user is not an admin, they can't get leases for other users, unless
+	//the lease is shared with them
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
 	err = user.Authorize(*lease.PrincipalID)
-	if err != nil {
+	if err != nil && !lease.IsSharedWith(user.Username) {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
@@ -80,6 +80,23 @@ func TestGetLeaseByID(t *testing.T) {
 			},
 			retErr: nil,
 		},
+		{
+			name: "When user Get lease shared with them service returns a success",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			leaseID: "abc123",
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user2\",\"sharedWith\":[\"user1\"]}\n",
+			},
+			retLease: &lease.Lease{
+				PrincipalID: ptrString("user2"),
+				SharedWith:  []string{"user1"},
+			},
+			retErr: nil,
+		},
 		{
 			name: "When Get lease service returns a failure",
 			user: &api.User{
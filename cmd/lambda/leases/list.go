@@ -1,38 +1,83 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"log"
+	"net/http"
+
 	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/api/response"
 	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/gorilla/schema"
-	"net/http"
 )
 
 // GetLeases - Returns leases
 func GetLeases(w http.ResponseWriter, r *http.Request) {
 	// Fetch the leases.
 
+	format, err := api.ExportFormat(r)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
 	var decoder = schema.NewDecoder()
 
 	query := &lease.Lease{}
-	err := decoder.Decode(query, r.URL.Query())
+	err = decoder.Decode(query, r.URL.Query())
 	if err != nil {
 		response.WriteRequestValidationError(w, fmt.Sprintf("Error parsing query params: %s", err))
 		return
 	}
 	// If user is not an admin, they may only list their own leases
-	user := r.Context().Value(api.User{}).(*api.User)
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
 	if user.Role != api.AdminGroupName {
 		usersPrincipalID := user.Username
 		query.PrincipalID = &usersPrincipalID
 	}
 
-	leases, err := Services.LeaseService().List(query)
+	if format != "" {
+		exportLeases(w, query, format)
+		return
+	}
+
+	// Leases are encoded a page at a time, so large limits don't hold
+	// every lease in memory. The body is buffered because the Link header
+	// depends on the last page.
+	body := &bytes.Buffer{}
+	stream := api.NewJSONArrayWriter(body)
+	limit := query.Limit
+	var lastModifiedOn int64
+	err = api.ReadPages(aws.Int64Value(limit), func(pageLimit int64) (bool, error) {
+		if pageLimit > 0 {
+			query.Limit = &pageLimit
+		}
+		leases, err := Services.LeaseService().List(query)
+		if err != nil || leases == nil {
+			return false, err
+		}
+		for _, l := range *leases {
+			if err := stream.Write(l); err != nil {
+				return false, err
+			}
+			if aws.Int64Value(l.LastModifiedOn) > lastModifiedOn {
+				lastModifiedOn = *l.LastModifiedOn
+			}
+		}
+		return query.NextAccountID != nil && query.NextPrincipalID != nil, nil
+	})
+	if err == nil {
+		err = stream.Close()
+	}
 	if err != nil {
 		api.WriteAPIErrorResponse(w, err)
 		return
 	}
+	if limit != nil {
+		query.Limit = limit
+	}
 
 	if query.NextAccountID != nil && query.NextPrincipalID != nil {
 		nextURL, err := api.BuildNextURL(baseRequest, query)
@@ -42,6 +87,50 @@ func GetLeases(w http.ResponseWriter, r *http.Request) {
 		}
 		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextURL.String()))
 	}
-	api.WriteAPIResponse(w, http.StatusOK, leases)
+	api.SetLastModified(w, lastModifiedOn)
+	w.WriteHeader(http.StatusOK)
+	_, err = body.WriteTo(w)
+	if err != nil {
+		log.Printf("error writing leases: %s", err)
+	}
+}
+
+// leaseExportColumns are the columns of CSV exports of leases
+var leaseExportColumns = []string{
+	"id",
+	"accountId",
+	"principalId",
+	"leaseStatus",
+	"leaseStatusReason",
+	"budgetAmount",
+	"budgetCurrency",
+	"createdOn",
+	"expiresOn",
+	"leaseStatusModifiedOn",
+	"notes",
+	"metadata",
+}
 
+// exportLeases writes every lease matching the query, rather than a page of
+// them, as CSV or JSON
+func exportLeases(w http.ResponseWriter, query *lease.Lease, format string) {
+	query.Limit = aws.Int64(api.StreamPageSize)
+	query.NextAccountID = nil
+	query.NextPrincipalID = nil
+	Exporter.Export(w, "leases", format, leaseExportColumns, func(write func(item interface{}) error) error {
+		var writeErr error
+		err := Services.LeaseService().ListPages(query, func(leases *lease.Leases) bool {
+			for _, l := range *leases {
+				writeErr = write(l)
+				if writeErr != nil {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return writeErr
+	})
 }
@@ -31,6 +31,7 @@ func TestAdminGetLeases(t *testing.T) {
 		user            *api.User
 		expResp         response
 		expLink         string
+		expLastModified string
 		query           *lease.Lease
 		retLeases       *lease.Leases
 		retErr          error
@@ -73,6 +74,29 @@ func TestAdminGetLeases(t *testing.T) {
 			expLink:         "</leases?limit=1&nextAccountId=234567890123&nextPrincipalId=User2>; rel=\"next\"",
 			retErr:          nil,
 		},
+		{
+			name: "admin gets when the latest lease was modified",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			query: &lease.Lease{},
+			expResp: response{
+				StatusCode: 200,
+				Body:       "[{\"principalId\":\"User1\",\"lastModifiedOn\":1570000000},{\"principalId\":\"User2\",\"lastModifiedOn\":1580000000}]\n",
+			},
+			retLeases: &lease.Leases{
+				lease.Lease{
+					PrincipalID:    ptrString("User1"),
+					LastModifiedOn: ptr64(1570000000),
+				},
+				lease.Lease{
+					PrincipalID:    ptrString("User2"),
+					LastModifiedOn: ptr64(1580000000),
+				},
+			},
+			expLastModified: "Sun, 26 Jan 2020 00:53:20 GMT",
+		},
 		{
 			name: "user gets empty list when no leases",
 			user: &api.User{
@@ -200,6 +224,67 @@ func TestAdminGetLeases(t *testing.T) {
 			if tt.expLink != "" {
 				assert.Equal(t, tt.expLink, actualResponse.MultiValueHeaders["Link"][0])
 			}
+			if tt.expLastModified != "" {
+				assert.Equal(t, tt.expLastModified, actualResponse.MultiValueHeaders["Last-Modified"][0])
+			}
+			if tt.expResp.StatusCode == http.StatusOK {
+				assert.Equal(t, "private, no-cache", actualResponse.MultiValueHeaders["Cache-Control"][0])
+				assert.NotEmpty(t, actualResponse.MultiValueHeaders["Etag"])
+			}
+		})
+	}
+}
+
+func TestExportLeases(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *api.User
+		expPrincipalID *string
+	}{
+		{
+			name: "should export every lease for admins",
+			user: &api.User{Username: "admin1", Role: api.AdminGroupName},
+		},
+		{
+			name:           "should export their own leases for users",
+			user:           &api.User{Username: "user1", Role: api.UserGroupName},
+			expPrincipalID: ptrString("user1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("ListPages", mock.MatchedBy(func(query *lease.Lease) bool {
+				return assert.ObjectsAreEqual(tt.expPrincipalID, query.PrincipalID)
+			}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(*lease.Leases) bool)
+					fn(&lease.Leases{{ID: ptrString("abc"), AccountID: ptrString("123456789012"), PrincipalID: ptrString("user1")}})
+				}).
+				Return(nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			Services = svcBldr
+			Exporter = &api.Exporter{MaxInlineBytes: 1000}
+
+			mockRequest := events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Path:       "/leases",
+				Headers:    map[string]string{"Accept": "text/csv"},
+			}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, actualResponse.StatusCode)
+			assert.Equal(t, "id,accountId,principalId,leaseStatus,leaseStatusReason,budgetAmount,budgetCurrency,createdOn,expiresOn,leaseStatusModifiedOn,notes,metadata\n"+
+				"abc,123456789012,user1,,,,,,,,,\n", actualResponse.Body)
+			leaseSvc.AssertExpectations(t)
 		})
 	}
 }
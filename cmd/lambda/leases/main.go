@@ -9,6 +9,7 @@ import (
 
 	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/principal"
 	"github.com/Optum/dce/pkg/usage"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -26,6 +27,22 @@ type leaseControllerConfiguration struct {
 	MaxLeaseBudgetAmount     float64 `env:"MAX_LEASE_BUDGET_AMOUNT" defaultEnv:"1000.00"`
 	MaxLeasePeriod           int64   `env:"MAX_LEASE_PERIOD" defaultEnv:"704800"`
 	DefaultLeaseLengthInDays int     `env:"DEFAULT_LEASE_LENGTH_IN_DAYS" defaultEnv:"7"`
+	// BudgetCurrency is the currency spend is recorded in
+	BudgetCurrency string `env:"BUDGET_CURRENCY" envDefault:"USD"`
+	// LeaseEstimateLookbackDays is how far back leases are used to estimate
+	// the cost of new leases
+	LeaseEstimateLookbackDays int `env:"LEASE_ESTIMATE_LOOKBACK_DAYS" envDefault:"30"`
+	// SavingsBaselineProvisioningHours and SavingsBaselineAccountLifetimeDays
+	// are how long it took to get an account, and how long accounts were kept,
+	// before DCE.  The savings report is measured against them.
+	SavingsBaselineProvisioningHours   float64 `env:"SAVINGS_PROVISIONING_HOURS" envDefault:"72"`
+	SavingsBaselineAccountLifetimeDays float64 `env:"SAVINGS_ACCOUNT_LIFETIME_DAYS" envDefault:"90"`
+	// BuildName is the account reset build, which runs cleanups of leased
+	// accounts
+	BuildName string `env:"RESET_BUILD_NAME" envDefault:"ResetCodeBuild"`
+	// MaxLeasesPerPrincipal is how many Active leases a principal may have
+	// at once
+	MaxLeasesPerPrincipal int `env:"MAX_LEASES_PER_PRINCIPAL" envDefault:"1"`
 }
 
 var (
@@ -35,6 +52,11 @@ var (
 	Services *config.ServiceBuilder
 	// Settings - the configuration settings for the controller
 	Settings *leaseControllerConfiguration
+	// Exporter writes the exports of the leases list
+	Exporter *api.Exporter
+	// PrincipalIDFormat normalizes and validates the principal IDs of
+	// requests
+	PrincipalIDFormat *principal.IDFormat
 )
 
 var (
@@ -44,6 +66,10 @@ var (
 	//cognitoUserPoolId        string
 	//cognitoAdminName         string
 	userDetailsMiddleware api.UserDetailsMiddleware
+	// apiKeyMiddleware limits requests made with API keys to their scopes
+	apiKeyMiddleware api.APIKeyMiddleware
+	// apiCache adds caching headers to the lease lists and reports
+	apiCache api.Cache
 )
 
 func init() {
@@ -56,7 +82,35 @@ func init() {
 			"GET",
 			"/leases",
 			api.EmptyQueryString,
-			GetLeases,
+			apiCache.Handler(GetLeases),
+		},
+		api.Route{
+			"GetLeaseWaitlist",
+			"GET",
+			"/leases/waitlist",
+			api.EmptyQueryString,
+			apiCache.Handler(GetLeaseWaitlist),
+		},
+		api.Route{
+			"DeleteLeaseWaitlistEntry",
+			"DELETE",
+			"/leases/waitlist/{principalID}",
+			api.EmptyQueryString,
+			DeleteLeaseWaitlistEntry,
+		},
+		api.Route{
+			"GetLeaseTerms",
+			"GET",
+			"/leases/terms",
+			api.EmptyQueryString,
+			GetLeaseTerms,
+		},
+		api.Route{
+			"GetLeaseSavings",
+			"GET",
+			"/leases/savings",
+			api.EmptyQueryString,
+			apiCache.Handler(GetLeaseSavings),
 		},
 		api.Route{
 			"GetLeaseByID",
@@ -65,6 +119,48 @@ func init() {
 			api.EmptyQueryString,
 			GetLeaseByID,
 		},
+		api.Route{
+			"UpdateLeaseByID",
+			"PATCH",
+			"/leases/{leaseID}",
+			api.EmptyQueryString,
+			UpdateLeaseByID,
+		},
+		api.Route{
+			"UpdateLeaseBudget",
+			"PUT",
+			"/leases/{leaseID}/budget",
+			api.EmptyQueryString,
+			UpdateLeaseBudget,
+		},
+		api.Route{
+			"ExtendLease",
+			"POST",
+			"/leases/{leaseID}/extend",
+			api.EmptyQueryString,
+			ExtendLease,
+		},
+		api.Route{
+			"CleanupLease",
+			"POST",
+			"/leases/{leaseID}/cleanup",
+			api.EmptyQueryString,
+			CleanupLease,
+		},
+		api.Route{
+			"SuppressLeaseAlerts",
+			"PUT",
+			"/leases/{leaseID}/alert-suppression",
+			api.EmptyQueryString,
+			SuppressLeaseAlerts,
+		},
+		api.Route{
+			"DeleteLeaseAlertSuppression",
+			"DELETE",
+			"/leases/{leaseID}/alert-suppression",
+			api.EmptyQueryString,
+			DeleteLeaseAlertSuppression,
+		},
 		api.Route{
 			"DeleteLeaseByID",
 			"DELETE",
@@ -86,11 +182,49 @@ func init() {
 			api.EmptyQueryString,
 			CreateLease,
 		},
+		api.Route{
+			"EstimateLeaseCost",
+			"POST",
+			"/leases/estimate",
+			api.EmptyQueryString,
+			EstimateLeaseCost,
+		},
+		api.Route{
+			"GetLeaseDurations",
+			"GET",
+			"/system/lease-durations",
+			api.EmptyQueryString,
+			GetLeaseDurations,
+		},
+		api.Route{
+			"UpdateLeaseDurations",
+			"PUT",
+			"/system/lease-durations",
+			api.EmptyQueryString,
+			UpdateLeaseDurations,
+		},
+		api.Route{
+			"UpdateLeaseTerms",
+			"PUT",
+			"/system/lease-terms",
+			api.EmptyQueryString,
+			UpdateLeaseTerms,
+		},
+		api.Route{
+			"DeleteLeaseTerms",
+			"DELETE",
+			"/system/lease-terms",
+			api.EmptyQueryString,
+			DeleteLeaseTerms,
+		},
 	}
 	r := api.NewRouter(leasesRoutes)
 	muxLambda = gorillamux.New(r)
 	userDetailsMiddleware = api.UserDetailsMiddleware{}
 	r.Use(userDetailsMiddleware.Middleware)
+	// After the user details, so a key's user replaces the caller's
+	apiKeyMiddleware.GorillaMuxAdapter = muxLambda
+	r.Use(apiKeyMiddleware.Middleware)
 }
 
 // initConfig configures package-level variables
@@ -101,9 +235,28 @@ func initConfig() {
 	if err := cfgBldr.Unmarshal(Settings); err != nil {
 		log.Fatalf("Could not load configuration: %s", err.Error())
 	}
+	Exporter = &api.Exporter{}
+	if err := cfgBldr.Unmarshal(Exporter); err != nil {
+		log.Fatalf("Could not load export configuration: %s", err.Error())
+	}
+	if err := cfgBldr.Unmarshal(&apiKeyMiddleware); err != nil {
+		log.Fatalf("Could not load api key configuration: %s", err.Error())
+	}
+	if err := cfgBldr.Unmarshal(&apiCache); err != nil {
+		log.Fatalf("Could not load api cache configuration: %s", err.Error())
+	}
+	principalIDFormatInput := principal.NewIDFormatInput{}
+	if err := cfgBldr.Unmarshal(&principalIDFormatInput); err != nil {
+		log.Fatalf("Could not load principal ID configuration: %s", err.Error())
+	}
+	principalIDFormat, err := principal.NewIDFormat(principalIDFormatInput)
+	if err != nil {
+		log.Fatalf("Could not create the principal ID format: %s", err.Error())
+	}
+	PrincipalIDFormat = principalIDFormat
 
 	// load up the values into the various settings...
-	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	err = cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
 	if err != nil {
 		log.Printf("Error: %+v", err)
 	}
@@ -113,10 +266,20 @@ func initConfig() {
 		WithLeaseService().
 		WithAccountService().
 		WithUserDetailer().
+		WithAlertService().
+		WithDirectoryService().
+		WithWaitlistService().
+		WithAPIKeyService().
+		WithS3().
+		WithCodeBuild().
 		Build()
 	if err != nil {
 		panic(err)
 	}
+	if err := svcBldr.Config.GetService(&Exporter.S3); err != nil {
+		panic(err)
+	}
+	apiKeyMiddleware.APIKeyService = svcBldr.APIKeyService()
 
 	Services = svcBldr
 }
@@ -136,15 +299,20 @@ func Handler(_ context.Context, req events.APIGatewayProxyRequest) (events.APIGa
 	return muxLambda.Proxy(req)
 }
 
-func main() {
-
+// usageService creates the usage service the first time it's needed, so
+// only requests which create leases pay for it
+func usageService() (usage.DBer, error) {
+	if usageSvc != nil {
+		return usageSvc, nil
+	}
 	usageService, err := usage.NewFromEnv()
 	if err != nil {
-		errorMessage := fmt.Sprintf("Failed to initialize usage service: %s", err)
-		log.Fatal(errorMessage)
+		return nil, err
 	}
-
 	usageSvc = usageService
+	return usageSvc, nil
+}
 
+func main() {
 	lambda.Start(Handler)
 }
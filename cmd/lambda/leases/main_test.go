@@ -86,6 +86,9 @@ func TestList(t *testing.T) {
 		assert.Nil(t, err)
 
 		expectedResponse := MockAPIResponse(http.StatusOK, "[{\"accountId\":\"123456789\",\"principalId\":\"test\",\"id\":\"unique-id\",\"leaseStatus\":\"Active\",\"lastModifiedOn\":1561149393}]\n")
+		expectedResponse.MultiValueHeaders["Cache-Control"] = []string{"private, no-cache"}
+		expectedResponse.MultiValueHeaders["Etag"] = []string{"\"722345486dd6b70aa0655122659fc063\""}
+		expectedResponse.MultiValueHeaders["Last-Modified"] = []string{"Fri, 21 Jun 2019 20:36:33 GMT"}
 		assert.Equal(t, expectedResponse, actualResponse)
 	})
 
@@ -127,6 +130,9 @@ func TestList(t *testing.T) {
 		assert.Nil(t, err)
 
 		expectedResponse := MockAPIResponse(http.StatusOK, "[{\"accountId\":\"123456789\",\"principalId\":\"test\",\"id\":\"unique-id\",\"leaseStatus\":\"Active\",\"lastModifiedOn\":1561149393}]\n")
+		expectedResponse.MultiValueHeaders["Cache-Control"] = []string{"private, no-cache"}
+		expectedResponse.MultiValueHeaders["Etag"] = []string{"\"722345486dd6b70aa0655122659fc063\""}
+		expectedResponse.MultiValueHeaders["Last-Modified"] = []string{"Fri, 21 Jun 2019 20:36:33 GMT"}
 		assert.Equal(t, expectedResponse, actualResponse)
 	})
 
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/usage"
+)
+
+// savingsMaxDays is the longest period a savings report may cover
+const savingsMaxDays = 366
+
+// GetLeaseSavings - Returns what the account pool saved in a period, versus
+// how accounts were managed before DCE: the accounts reclaimed, the idle
+// spend prevented, and the time taken to provision leases.  Only admins may
+// see it.
+func GetLeaseSavings(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to get the savings report, but was not authorized",
+				user.Username, user.Role)))
+		return
+	}
+
+	now := time.Now().UTC()
+
+	// Reports default to the current month
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := r.FormValue("startDate"); v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			api.WriteAPIErrorResponse(w,
+				errors.NewBadRequest(fmt.Sprintf("invalid request parameters: startDate: %s", err)))
+			return
+		}
+		startDate = time.Unix(i, 0)
+	}
+	endDate := now
+	if v := r.FormValue("endDate"); v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			api.WriteAPIErrorResponse(w,
+				errors.NewBadRequest(fmt.Sprintf("invalid request parameters: endDate: %s", err)))
+			return
+		}
+		endDate = time.Unix(i, 0)
+	}
+	if endDate.Before(startDate) {
+		api.WriteAPIErrorResponse(w,
+			errors.NewValidation("savings report", fmt.Errorf("startDate: must be before the endDate")))
+		return
+	}
+	if endDate.Sub(startDate) > savingsMaxDays*24*time.Hour {
+		api.WriteAPIErrorResponse(w,
+			errors.NewValidation("savings report", fmt.Errorf("endDate: reports can cover at most %d days", savingsMaxDays)))
+		return
+	}
+
+	report, err := savingsReport(startDate, endDate)
+	if err != nil {
+		log.Printf("Failed to get the savings report: %s", err)
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	api.WriteAPIResponse(w, http.StatusOK, report)
+}
+
+// savingsReport measures the savings of the leases which were provisioned
+// or ended in the period
+func savingsReport(startDate time.Time, endDate time.Time) (*usage.SavingsReport, error) {
+	leases := []usage.SavingsLease{}
+	err := Services.LeaseService().ListPages(&lease.Lease{}, func(page *lease.Leases) bool {
+		for i := range *page {
+			leases = append(leases, (*page)[i].SavingsLease())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usageDB, err := usageService()
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	records, err := usageDB.GetUsageByDateRange(usage.SavingsUsageStartDate(leases, startDate, endDate), endDate)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get usage", err)
+	}
+
+	baseline := usage.SavingsBaseline{
+		ProvisioningHours:   Settings.SavingsBaselineProvisioningHours,
+		AccountLifetimeDays: Settings.SavingsBaselineAccountLifetimeDays,
+	}
+	return usage.NewSavingsReport(leases, records, baseline, startDate, endDate, Settings.BudgetCurrency), nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLeaseSavings(t *testing.T) {
+	day := int64(86400)
+	startDate := time.Now().Unix() - 30*day
+	endDate := time.Now().Unix()
+
+	// A lease reclaimed after 10 days, which spent 2 a day, and a lease
+	// which waited 6 hours for an account
+	leases := lease.Leases{
+		{
+			AccountID:        aws.String("123456789012"),
+			PrincipalID:      aws.String("jdoe"),
+			Status:           lease.StatusInactive.StatusPtr(),
+			StatusReason:     lease.StatusReasonStale.StatusReasonPtr(),
+			CreatedOn:        aws.Int64(startDate - 5*day),
+			StatusModifiedOn: aws.Int64(startDate + 5*day),
+		},
+		{
+			AccountID:     aws.String("123456789013"),
+			PrincipalID:   aws.String("asmith"),
+			Status:        lease.StatusActive.StatusPtr(),
+			CreatedOn:     aws.Int64(startDate - 100*day),
+			RequestedOn:   aws.Int64(startDate + day),
+			ProvisionedOn: aws.Int64(startDate + day + 6*3600),
+		},
+	}
+	records := []*usage.Usage{}
+	for d := int64(0); d < 10; d++ {
+		records = append(records, &usage.Usage{
+			AccountID:    aws.String("123456789012"),
+			PrincipalID:  aws.String("jdoe"),
+			StartDate:    aws.Int64(startDate - 5*day + d*day),
+			CostAmount:   aws.Float64(2),
+			CostCurrency: aws.String("USD"),
+		})
+	}
+
+	tests := []struct {
+		name      string
+		role      string
+		query     map[string]string
+		expCode   int
+		expReport *usage.SavingsReport
+	}{
+		{
+			name:    "should report savings versus the baseline",
+			role:    api.AdminGroupName,
+			query:   map[string]string{"startDate": fmt.Sprint(startDate), "endDate": fmt.Sprint(endDate)},
+			expCode: http.StatusOK,
+			expReport: &usage.SavingsReport{
+				StartDate:                startDate,
+				EndDate:                  endDate,
+				Baseline:                 usage.SavingsBaseline{ProvisioningHours: 72, AccountLifetimeDays: 90},
+				AccountsReclaimed:        1,
+				ReclaimedByReason:        map[string]int{"Stale": 1},
+				IdleSpendPrevented:       160,
+				CostCurrency:             "USD",
+				LeasesProvisioned:        1,
+				AverageProvisioningHours: 6,
+				ProvisioningHoursSaved:   66,
+			},
+		},
+		{
+			name:    "should only report to admins",
+			role:    api.UserGroupName,
+			expCode: http.StatusUnauthorized,
+		},
+		{
+			name:    "should reject a period which ends before it starts",
+			role:    api.AdminGroupName,
+			query:   map[string]string{"startDate": fmt.Sprint(endDate), "endDate": fmt.Sprint(startDate)},
+			expCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("ListPages", &lease.Lease{}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(func(*lease.Leases) bool)(&leases)
+				}).
+				Return(nil)
+
+			userDetailSvc := &apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "jdoe", Role: tt.role})
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(leaseSvc).WithService(userDetailSvc)
+			_, err := svcBldr.Build()
+			require.Nil(t, err)
+			Services = svcBldr
+			Settings.SavingsBaselineProvisioningHours = 72
+			Settings.SavingsBaselineAccountLifetimeDays = 90
+
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByDateRange", time.Unix(startDate-5*day, 0), mock.Anything).Return(records, nil)
+			usageSvc = usageSvcMock
+
+			resp, err := Handler(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				Path:                  "/leases/savings",
+				QueryStringParameters: tt.query,
+			})
+			require.Nil(t, err)
+			assert.Equal(t, tt.expCode, resp.StatusCode, resp.Body)
+			if tt.expReport == nil {
+				return
+			}
+
+			report := &usage.SavingsReport{}
+			require.Nil(t, json.Unmarshal([]byte(resp.Body), report))
+			assert.Equal(t, tt.expReport, report)
+		})
+	}
+}
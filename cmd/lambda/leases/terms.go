@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// GetLeaseTerms returns the terms of use lease requests must accept
+func GetLeaseTerms(w http.ResponseWriter, r *http.Request) {
+	terms, err := Services.LeaseService().GetTerms()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	if terms == nil {
+		api.WriteAPIErrorResponse(w, errors.NewNotFound("lease terms", "current"))
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, terms)
+}
+
+// UpdateLeaseTerms changes the terms of use lease requests must accept.  Only
+// admins may change them.
+func UpdateLeaseTerms(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to change the lease terms, but was not authorized",
+				user.Username, user.Role)))
+		return
+	}
+
+	// Deserialize the request JSON as an request object
+	terms := &lease.Terms{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(terms)
+	if err != nil {
+		api.WriteAPIErrorResponse(w,
+			errors.NewBadRequest("invalid request parameters"))
+		return
+	}
+
+	updated, err := Services.LeaseService().UpdateTerms(terms)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, updated)
+}
+
+// DeleteLeaseTerms stops requiring lease requests to accept terms of use.
+// Only admins may delete them.
+func DeleteLeaseTerms(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	if user.Role != api.AdminGroupName {
+		api.WriteAPIErrorResponse(w, errors.NewUnathorizedError(
+			fmt.Sprintf("User [%s] with role: [%s] attempted to delete the lease terms, but was not authorized",
+				user.Username, user.Role)))
+		return
+	}
+
+	err := Services.LeaseService().DeleteTerms()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaseTerms(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	admin := &api.User{Username: "admin1", Role: api.AdminGroupName}
+	user := &api.User{Username: "user1", Role: api.UserGroupName}
+	terms := &lease.Terms{Version: "2", Text: "Don't store production data.", UpdatedOn: 100}
+	tests := []struct {
+		name      string
+		user      *api.User
+		method    string
+		path      string
+		reqBody   string
+		terms     *lease.Terms
+		expResp   response
+		expUpdate *lease.Terms
+		expDelete bool
+	}{
+		{
+			name:   "When a user gets the terms service returns them",
+			user:   user,
+			method: http.MethodGet,
+			path:   "/leases/terms",
+			terms:  terms,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"version\":\"2\",\"text\":\"Don't store production data.\",\"updatedOn\":100}\n",
+			},
+		},
+		{
+			name:   "When there are no terms service returns 404",
+			user:   user,
+			method: http.MethodGet,
+			path:   "/leases/terms",
+			expResp: response{
+				StatusCode: 404,
+				Body:       "{\"error\":{\"message\":\"lease terms \\\"current\\\" not found\",\"code\":\"NotFoundError\"}}\n",
+			},
+		},
+		{
+			name:    "When an admin changes the terms service returns them",
+			user:    admin,
+			method:  http.MethodPut,
+			path:    "/system/lease-terms",
+			reqBody: `{"version": "2", "text": "Don't store production data."}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"version\":\"2\",\"text\":\"Don't store production data.\",\"updatedOn\":100}\n",
+			},
+			expUpdate: &lease.Terms{Version: "2", Text: "Don't store production data."},
+		},
+		{
+			name:    "When a user changes the terms service returns 401",
+			user:    user,
+			method:  http.MethodPut,
+			path:    "/system/lease-terms",
+			reqBody: `{"version": "3", "text": "Anything goes."}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to change the lease terms, but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+		},
+		{
+			name:   "When an admin deletes the terms service returns no content",
+			user:   admin,
+			method: http.MethodDelete,
+			path:   "/system/lease-terms",
+			expResp: response{
+				StatusCode: 204,
+				Body:       "",
+			},
+			expDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("GetTerms").Return(tt.terms, nil)
+			leaseSvc.On("UpdateTerms", mock.AnythingOfType("*lease.Terms")).Return(terms, nil)
+			leaseSvc.On("DeleteTerms").Return(nil)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: tt.path, Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expUpdate != nil {
+				leaseSvc.AssertCalled(t, "UpdateTerms", tt.expUpdate)
+			} else {
+				leaseSvc.AssertNotCalled(t, "UpdateTerms", mock.Anything)
+			}
+			if tt.expDelete {
+				leaseSvc.AssertCalled(t, "DeleteTerms")
+			} else {
+				leaseSvc.AssertNotCalled(t, "DeleteTerms")
+			}
+		})
+	}
+}
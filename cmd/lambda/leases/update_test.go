@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateLeaseByID(t *testing.T) {
+
+	type response struct {
+		StatusCode int
+		Body       string
+	}
+	tests := []struct {
+		name      string
+		user      *api.User
+		reqBody   string
+		expResp   response
+		getLease  *lease.Lease
+		retLease  *lease.Lease
+		retErr    error
+		expUpdate bool
+	}{
+		{
+			name: "When user updates the notes of their lease service returns a success",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"notes": "Delete the stack first", "metadata": {"purpose": "load testing"}}`,
+			expResp: response{
+				StatusCode: 200,
+				Body:       "{\"principalId\":\"user1\",\"metadata\":{\"purpose\":\"load testing\"},\"notes\":\"Delete the stack first\"}\n",
+			},
+			getLease: &lease.Lease{
+				PrincipalID: ptrString("user1"),
+			},
+			retLease: &lease.Lease{
+				PrincipalID: ptrString("user1"),
+				Metadata:    map[string]interface{}{"purpose": "load testing"},
+				Notes:       ptrString("Delete the stack first"),
+			},
+			expUpdate: true,
+		},
+		{
+			name: "When user updates the lease of another user service returns 401",
+			user: &api.User{
+				Username: "user1",
+				Role:     api.UserGroupName,
+			},
+			reqBody: `{"notes": "mine now"}`,
+			expResp: response{
+				StatusCode: 401,
+				Body:       "{\"error\":{\"message\":\"User [user1] with role: [User] attempted to act on a lease for [user2], but was not authorized\",\"code\":\"UnauthorizedError\"}}\n",
+			},
+			getLease: &lease.Lease{
+				PrincipalID: ptrString("user2"),
+			},
+		},
+		{
+			name: "When the request isn't JSON service returns 400",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			reqBody: `notes`,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"invalid request parameters\",\"code\":\"ClientError\"}}\n",
+			},
+		},
+		{
+			name: "When the update is invalid service returns 400",
+			user: &api.User{
+				Username: "admin1",
+				Role:     api.AdminGroupName,
+			},
+			reqBody: `{"budgetAmount": 1000}`,
+			expResp: response{
+				StatusCode: 400,
+				Body:       "{\"error\":{\"message\":\"lease validation error: budgetAmount: must be empty.\",\"code\":\"RequestValidationError\"}}\n",
+			},
+			getLease: &lease.Lease{
+				PrincipalID: ptrString("user1"),
+			},
+			retErr:    errors.NewValidation("lease", fmt.Errorf("budgetAmount: must be empty.")),
+			expUpdate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("Get", "abc123").Return(tt.getLease, nil)
+			leaseSvc.On("Update", "abc123", mock.AnythingOfType("*lease.Lease")).Return(tt.retLease, tt.retErr)
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			svcBldr.Config.WithService(&userDetailSvc)
+			svcBldr.Config.WithService(&leaseSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodPatch, Path: "/leases/abc123", Body: tt.reqBody}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expResp.StatusCode, actualResponse.StatusCode)
+			assert.Equal(t, tt.expResp.Body, actualResponse.Body)
+			if tt.expUpdate {
+				leaseSvc.AssertCalled(t, "Update", "abc123", mock.Anything)
+			} else {
+				leaseSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/waitlist"
+)
+
+// waitForAccount puts a lease request on the waitlist, to be leased the next
+// account which becomes Ready
+func waitForAccount(w http.ResponseWriter, newLease *lease.Lease, user *api.User) {
+	// The lease couldn't be created once an account is Ready
+	activeLeases, err := Services.LeaseService().List(&lease.Lease{
+		PrincipalID: newLease.PrincipalID,
+		Status:      lease.StatusActive.StatusPtr(),
+	})
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+	maxActive := Settings.MaxLeasesPerPrincipal
+	if maxActive < 1 {
+		maxActive = 1
+	}
+	if activeLeases != nil && len(*activeLeases) >= maxActive {
+		if maxActive > 1 {
+			api.WriteAPIErrorResponse(w, lease.ActiveLeasesExceeded(*newLease.PrincipalID, maxActive))
+			return
+		}
+		api.WriteAPIErrorResponse(w, errors.NewConflict("lease", *newLease.PrincipalID,
+			fmt.Errorf("principal %s already has an Active lease", *newLease.PrincipalID)))
+		return
+	}
+
+	entry, err := Services.WaitlistService().Add(newLease, user)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusAccepted, entry)
+}
+
+// GetLeaseWaitlist lists the lease requests waiting for an account, oldest
+// first.  Users only see their own request.
+func GetLeaseWaitlist(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+
+	entries, err := Services.WaitlistService().List()
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	visible := []*waitlist.Entry{}
+	for _, entry := range entries {
+		if user.Authorize(entry.PrincipalID) == nil {
+			visible = append(visible, entry)
+		}
+	}
+
+	api.WriteAPIResponse(w, http.StatusOK, visible)
+}
+
+// DeleteLeaseWaitlistEntry takes a principal's lease request off the waitlist
+func DeleteLeaseWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	principalID := mux.Vars(r)["principalID"]
+
+	user := r.Context().Value(api.UserCtxKey).(*api.User)
+	err := user.Authorize(principalID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	err = Services.WaitlistService().Remove(principalID)
+	if err != nil {
+		api.WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	api.WriteAPIResponse(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	alertmocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
+	"github.com/Optum/dce/pkg/api"
+	apiMocks "github.com/Optum/dce/pkg/api/mocks"
+	"github.com/Optum/dce/pkg/config"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/waitlist"
+	waitlistmocks "github.com/Optum/dce/pkg/waitlist/waitlistiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateLeaseWaitlist(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		query        map[string]string
+		activeLeases *lease.Leases
+		maxLeases    int
+		expStatus    int
+		expBody      string
+		expAdd       bool
+	}{
+		{
+			name:      "When asked to wait for an account the request is added to the waitlist",
+			query:     map[string]string{"waitlist": "true"},
+			expStatus: http.StatusAccepted,
+			expBody:   "{\"principalId\":\"user1\",\"lease\":{\"principalId\":\"user1\"},\"requestedBy\":\"user1\",\"createdOn\":1000,\"expiresOn\":2000,\"position\":3}\n",
+			expAdd:    true,
+		},
+		{
+			name:      "When not asked to wait for an account the request fails",
+			expStatus: http.StatusInternalServerError,
+			expBody:   "{\"error\":{\"message\":\"No Available accounts at this moment\",\"code\":\"ServerError\"}}\n",
+		},
+		{
+			name:         "When the principal already has an Active lease the request fails",
+			query:        map[string]string{"waitlist": "true"},
+			activeLeases: &lease.Leases{{ID: ptrString("abc123")}},
+			expStatus:    http.StatusConflict,
+			expBody:      "{\"error\":{\"message\":\"operation cannot be fulfilled on lease \\\"user1\\\": principal user1 already has an Active lease\",\"code\":\"ConflictError\"}}\n",
+		},
+		{
+			name:         "When the principal may have another Active lease the request is added to the waitlist",
+			query:        map[string]string{"waitlist": "true"},
+			activeLeases: &lease.Leases{{ID: ptrString("abc123")}},
+			maxLeases:    2,
+			expStatus:    http.StatusAccepted,
+			expBody:      "{\"principalId\":\"user1\",\"lease\":{\"principalId\":\"user1\"},\"requestedBy\":\"user1\",\"createdOn\":1000,\"expiresOn\":2000,\"position\":3}\n",
+			expAdd:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxLeases := Settings.MaxLeasesPerPrincipal
+			Settings.MaxLeasesPerPrincipal = tt.maxLeases
+			defer func() { Settings.MaxLeasesPerPrincipal = maxLeases }()
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(&api.User{Username: "user1", Role: api.UserGroupName})
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+			accountSvc.On("GetReadyAccount", "user1").Return(nil, nil)
+			alertSvc := alertmocks.Servicer{}
+			alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(nil, nil)
+			leaseSvc := mocks.Servicer{}
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(tt.activeLeases, nil)
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), "user1").Return(nil)
+			waitlistSvc := waitlistmocks.Servicer{}
+			waitlistSvc.On("Enabled").Return(true)
+			waitlistSvc.On("Add", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*api.User")).Return(&waitlist.Entry{
+				PrincipalID: "user1",
+				Lease:       lease.Lease{PrincipalID: ptrString("user1")},
+				RequestedBy: "user1",
+				CreatedOn:   1000,
+				ExpiresOn:   2000,
+				Position:    3,
+			}, nil)
+
+			svcBldr.Config.WithService(&userDetailSvc).WithService(&accountSvc).WithService(&alertSvc).
+				WithService(&directorySvc).WithService(&leaseSvc).WithService(&waitlistSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodPost,
+				Path:                  "/leases",
+				QueryStringParameters: tt.query,
+				Body:                  "{\"principalId\": \"user1\"}",
+			}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, actualResponse.StatusCode)
+			assert.Equal(t, tt.expBody, actualResponse.Body)
+			if tt.expAdd {
+				waitlistSvc.AssertCalled(t, "Add", mock.AnythingOfType("*lease.Lease"), mock.MatchedBy(func(u *api.User) bool {
+					return u.Username == "user1"
+				}))
+			} else {
+				waitlistSvc.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetLeaseWaitlist(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		user    *api.User
+		expBody string
+	}{
+		{
+			name:    "When an admin lists the waitlist every request is returned",
+			user:    &api.User{Username: "admin1", Role: api.AdminGroupName},
+			expBody: "[{\"principalId\":\"user1\",\"lease\":{},\"createdOn\":1000,\"expiresOn\":2000,\"position\":1},{\"principalId\":\"user2\",\"lease\":{},\"createdOn\":1001,\"expiresOn\":2001,\"position\":2}]\n",
+		},
+		{
+			name:    "When a user lists the waitlist only their request is returned",
+			user:    &api.User{Username: "user2", Role: api.UserGroupName},
+			expBody: "[{\"principalId\":\"user2\",\"lease\":{},\"createdOn\":1001,\"expiresOn\":2001,\"position\":2}]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			waitlistSvc := waitlistmocks.Servicer{}
+			waitlistSvc.On("List").Return([]*waitlist.Entry{
+				{PrincipalID: "user1", CreatedOn: 1000, ExpiresOn: 2000, Position: 1},
+				{PrincipalID: "user2", CreatedOn: 1001, ExpiresOn: 2001, Position: 2},
+			}, nil)
+			svcBldr.Config.WithService(&userDetailSvc).WithService(&waitlistSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/leases/waitlist"}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, actualResponse.StatusCode)
+			assert.Equal(t, tt.expBody, actualResponse.Body)
+		})
+	}
+}
+
+func TestDeleteLeaseWaitlistEntry(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		user      *api.User
+		principal string
+		removeErr error
+		expStatus int
+		expRemove bool
+	}{
+		{
+			name:      "When a user leaves the waitlist service returns 204",
+			user:      &api.User{Username: "user1", Role: api.UserGroupName},
+			principal: "user1",
+			expStatus: http.StatusNoContent,
+			expRemove: true,
+		},
+		{
+			name:      "When a user removes another principal service returns 401",
+			user:      &api.User{Username: "user1", Role: api.UserGroupName},
+			principal: "user2",
+			expStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "When the principal isn't waiting service returns 404",
+			user:      &api.User{Username: "admin1", Role: api.AdminGroupName},
+			principal: "user2",
+			removeErr: errors.NewNotFound("waitlist", "user2"),
+			expStatus: http.StatusNotFound,
+			expRemove: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			userDetailSvc := apiMocks.UserDetailer{}
+			userDetailSvc.On("GetUser", mock.Anything).Return(tt.user)
+			waitlistSvc := waitlistmocks.Servicer{}
+			waitlistSvc.On("Remove", tt.principal).Return(tt.removeErr)
+			svcBldr.Config.WithService(&userDetailSvc).WithService(&waitlistSvc)
+			_, err := svcBldr.Build()
+
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+
+			mockRequest := events.APIGatewayProxyRequest{HTTPMethod: http.MethodDelete, Path: "/leases/waitlist/" + tt.principal}
+			actualResponse, err := Handler(context.TODO(), mockRequest)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expStatus, actualResponse.StatusCode)
+			if tt.expRemove {
+				waitlistSvc.AssertCalled(t, "Remove", tt.principal)
+			} else {
+				waitlistSvc.AssertNotCalled(t, "Remove", mock.Anything)
+			}
+		})
+	}
+}
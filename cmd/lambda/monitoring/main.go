@@ -0,0 +1,64 @@
+// Package main creates or updates the standard DCE CloudWatch alarms and
+// dashboard. It is invoked by the deploy script each time DCE is deployed.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithMonitoringService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+func handler(ctx context.Context) error {
+	monitoringSvc := services.MonitoringService()
+	if !monitoringSvc.Enabled() {
+		log.Printf("Monitoring is not enabled")
+		return nil
+	}
+
+	err := monitoringSvc.Provision()
+	if err != nil {
+		return err
+	}
+	log.Printf("Created the CloudWatch alarms and dashboard")
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/config"
+	monitoringmocks "github.com/Optum/dce/pkg/monitoring/monitoringiface/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitoring(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		provisionErr error
+		expProvision bool
+		expErr       string
+	}{
+		{
+			name:         "should provision the alarms and dashboard",
+			enabled:      true,
+			expProvision: true,
+		},
+		{
+			name:         "should fail when the alarms can't be provisioned",
+			enabled:      true,
+			provisionErr: fmt.Errorf("failed to create alarm dce-api-5xx-test"),
+			expProvision: true,
+			expErr:       "failed to create alarm dce-api-5xx-test",
+		},
+		{
+			name: "should do nothing when monitoring is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitoringSvc := &monitoringmocks.Servicer{}
+			monitoringSvc.On("Enabled").Return(tt.enabled)
+			monitoringSvc.On("Provision").Return(tt.provisionErr)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(monitoringSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			services = svcBldr
+
+			err = handler(context.TODO())
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			if tt.expProvision {
+				monitoringSvc.AssertCalled(t, "Provision")
+			} else {
+				monitoringSvc.AssertNotCalled(t, "Provision")
+			}
+		})
+	}
+}
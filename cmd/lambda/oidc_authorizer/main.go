@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/oidc"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/caarlos0/env"
+)
+
+// verifier verifies the bearer tokens of API requests
+type verifier interface {
+	Verify(token string) (*oidc.Identity, error)
+}
+
+var tokenVerifier verifier
+
+// userDetails decides which users are admins, from their groups
+var userDetails *api.OIDCUserDetails
+
+// userPaths are the paths of the API users who aren't admins may invoke, the
+// same as Cognito users
+var userPaths = []string{"leases", "leases/*", "usage", "usage/*", "auth", "auth/*"}
+
+// errUnauthorized makes API Gateway respond 401 Unauthorized
+var errUnauthorized = errors.New("Unauthorized")
+
+// handler authorizes API requests with the OIDC provider's tokens.  Admins
+// may invoke the whole API, and other users only the leases, usage and auth
+// endpoints.  The identity of the token's user is passed to the API lambdas
+// in the authorizer context, and read by the oidc user detailer.
+func handler(event events.APIGatewayCustomAuthorizerRequest) (events.APIGatewayCustomAuthorizerResponse, error) {
+	// The header is "Bearer <token>"
+	fields := strings.Fields(event.AuthorizationToken)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+		return events.APIGatewayCustomAuthorizerResponse{}, errUnauthorized
+	}
+
+	identity, err := tokenVerifier.Verify(fields[1])
+	if err != nil {
+		log.Printf("Denied request to %s: %s", event.MethodArn, err)
+		return events.APIGatewayCustomAuthorizerResponse{}, errUnauthorized
+	}
+
+	return events.APIGatewayCustomAuthorizerResponse{
+		PrincipalID: identity.Username,
+		PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+			Version: "2012-10-17",
+			Statement: []events.IAMPolicyStatement{
+				{
+					Action:   []string{"execute-api:Invoke"},
+					Effect:   "Allow",
+					Resource: apiArns(event.MethodArn, userDetails.IsAdmin(identity.Groups)),
+				},
+			},
+		},
+		Context: map[string]interface{}{
+			"username": identity.Username,
+			"groups":   strings.Join(identity.Groups, ","),
+		},
+	}, nil
+}
+
+// apiArns allows every method of the API's stage the user may invoke, so the
+// policy API Gateway caches for the token applies to the user's other
+// requests too, eg. for admins
+// arn:aws:execute-api:us-east-1:123456789012:abc123/api/GET/leases becomes
+// arn:aws:execute-api:us-east-1:123456789012:abc123/api/*, and for users
+// arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/leases and the
+// other user paths
+func apiArns(methodArn string, admin bool) []string {
+	parts := strings.SplitN(methodArn, "/", 3)
+	if len(parts) < 2 {
+		return []string{methodArn}
+	}
+	stage := parts[0] + "/" + parts[1]
+	if admin {
+		return []string{stage + "/*"}
+	}
+	arns := []string{}
+	for _, path := range userPaths {
+		arns = append(arns, stage+"/*/"+path)
+	}
+	return arns
+}
+
+func main() {
+	v, err := oidc.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize the oidc verifier: %s", err)
+	}
+	tokenVerifier = v
+
+	authConfig := &api.AuthConfig{}
+	err = env.Parse(authConfig)
+	if err != nil {
+		log.Fatalf("Failed to parse the auth provider config: %s", err)
+	}
+	userDetails = &api.OIDCUserDetails{AdminGroups: authConfig.OIDCAdminGroups}
+
+	lambda.Start(handler)
+}
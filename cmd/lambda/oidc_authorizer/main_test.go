@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/oidc"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubVerifier struct {
+	token    string
+	identity *oidc.Identity
+	err      error
+}
+
+func (s *stubVerifier) Verify(token string) (*oidc.Identity, error) {
+	s.token = token
+	return s.identity, s.err
+}
+
+func TestHandler(t *testing.T) {
+	methodArn := "arn:aws:execute-api:us-east-1:123456789012:abc123/api/GET/leases/123"
+
+	tests := []struct {
+		name     string
+		header   string
+		identity *oidc.Identity
+		err      error
+		expToken string
+		expRes   events.APIGatewayCustomAuthorizerResponse
+		expErr   error
+	}{
+		{
+			name:     "should allow admins every endpoint",
+			header:   "Bearer abc.def.ghi",
+			identity: &oidc.Identity{Username: "jdoe@example.com", Groups: []string{"developers", "dce-admins"}},
+			expToken: "abc.def.ghi",
+			expRes: events.APIGatewayCustomAuthorizerResponse{
+				PrincipalID: "jdoe@example.com",
+				PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+					Version: "2012-10-17",
+					Statement: []events.IAMPolicyStatement{
+						{
+							Action:   []string{"execute-api:Invoke"},
+							Effect:   "Allow",
+							Resource: []string{"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*"},
+						},
+					},
+				},
+				Context: map[string]interface{}{
+					"username": "jdoe@example.com",
+					"groups":   "developers,dce-admins",
+				},
+			},
+		},
+		{
+			name:     "should only allow users the leases, usage and auth endpoints",
+			header:   "Bearer abc.def.ghi",
+			identity: &oidc.Identity{Username: "jdoe@example.com", Groups: []string{"developers"}},
+			expToken: "abc.def.ghi",
+			expRes: events.APIGatewayCustomAuthorizerResponse{
+				PrincipalID: "jdoe@example.com",
+				PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+					Version: "2012-10-17",
+					Statement: []events.IAMPolicyStatement{
+						{
+							Action: []string{"execute-api:Invoke"},
+							Effect: "Allow",
+							Resource: []string{
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/leases",
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/leases/*",
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/usage",
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/usage/*",
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/auth",
+								"arn:aws:execute-api:us-east-1:123456789012:abc123/api/*/auth/*",
+							},
+						},
+					},
+				},
+				Context: map[string]interface{}{
+					"username": "jdoe@example.com",
+					"groups":   "developers",
+				},
+			},
+		},
+		{
+			name:     "should deny invalid tokens",
+			header:   "Bearer abc.def.ghi",
+			err:      errors.NewUnathorizedError("the token has expired"),
+			expToken: "abc.def.ghi",
+			expErr:   errUnauthorized,
+		},
+		{
+			name:   "should deny requests without a token",
+			header: "Bearer ",
+			expErr: errUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubVerifier{identity: tt.identity, err: tt.err}
+			tokenVerifier = stub
+			userDetails = &api.OIDCUserDetails{AdminGroups: []string{"dce-admins"}}
+
+			res, err := handler(events.APIGatewayCustomAuthorizerRequest{
+				Type:               "TOKEN",
+				AuthorizationToken: tt.header,
+				MethodArn:          methodArn,
+			})
+
+			assert.Equal(t, tt.expErr, err)
+			assert.Equal(t, tt.expRes, res)
+			assert.Equal(t, tt.expToken, stub.token)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package main publishes the domain events waiting in the outbox.  Lease and
+// account changes write their events to the outbox in the same transaction
+// as the change, and this Lambda publishes them on a schedule, so an event is
+// only published for a change which was written, and isn't lost when
+// publishing fails.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/event/eventiface"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/outbox/outboxiface"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+var (
+	outboxSvc outboxiface.Servicer
+	eventSvc  eventiface.Servicer
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithOutboxService().
+		WithEventService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	outboxSvc = svcBldr.OutboxService()
+	if err := svcBldr.Config.GetService(&eventSvc); err != nil {
+		log.Fatalf("Failed to get the event service: %s", err)
+	}
+}
+
+// Handler drains the outbox
+func Handler(ctx context.Context, event events.CloudWatchEvent) error {
+	published, err := outboxSvc.Drain(publish)
+	log.Printf("Published %d events from the outbox", published)
+	return err
+}
+
+// publish publishes an outbox entry with the event service method of its
+// type
+func publish(entry *outbox.Entry) error {
+	switch entry.Type {
+	case outbox.TypeAccountCreate, outbox.TypeAccountReset:
+		new := &account.Account{}
+		err := entry.Decode(nil, new)
+		if err != nil {
+			return err
+		}
+		if entry.Type == outbox.TypeAccountCreate {
+			return eventSvc.AccountCreate(new)
+		}
+		return eventSvc.AccountReset(new)
+	case outbox.TypeLeaseCreate, outbox.TypeLeaseEnd, outbox.TypeLeaseUpdate:
+		old := &lease.Lease{}
+		new := &lease.Lease{}
+		err := entry.Decode(old, new)
+		if err != nil {
+			return err
+		}
+		switch entry.Type {
+		case outbox.TypeLeaseCreate:
+			return eventSvc.LeaseCreate(new)
+		case outbox.TypeLeaseEnd:
+			return eventSvc.LeaseEnd(new)
+		}
+		return eventSvc.LeaseUpdate(old, new)
+	}
+	return fmt.Errorf("unknown outbox event type %q", entry.Type)
+}
+
+func main() {
+	lambda.Start(Handler)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	eventmocks "github.com/Optum/dce/pkg/event/eventiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
+	outboxmocks "github.com/Optum/dce/pkg/outbox/outboxiface/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublish(t *testing.T) {
+	oldLease := &lease.Lease{ID: aws.String("lease-1"), Status: lease.StatusActive.StatusPtr()}
+	newLease := &lease.Lease{ID: aws.String("lease-1"), Status: lease.StatusInactive.StatusPtr()}
+	newAccount := &account.Account{ID: aws.String("123456789012"), Status: account.StatusNotReady.StatusPtr()}
+
+	tests := []struct {
+		name      string
+		eventType string
+		old       interface{}
+		new       interface{}
+		expMethod string
+		expErr    bool
+	}{
+		{
+			name:      "lease update",
+			eventType: outbox.TypeLeaseUpdate,
+			old:       oldLease,
+			new:       newLease,
+			expMethod: "LeaseUpdate",
+		},
+		{
+			name:      "lease end",
+			eventType: outbox.TypeLeaseEnd,
+			new:       newLease,
+			expMethod: "LeaseEnd",
+		},
+		{
+			name:      "account reset",
+			eventType: outbox.TypeAccountReset,
+			new:       newAccount,
+			expMethod: "AccountReset",
+		},
+		{
+			name:      "unknown type",
+			eventType: "AccountTeleport",
+			new:       newAccount,
+			expErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventSvcMock := &eventmocks.Servicer{}
+			eventSvcMock.On("LeaseUpdate", oldLease, newLease).Return(nil)
+			eventSvcMock.On("LeaseEnd", newLease).Return(nil)
+			// The principal policy ARN is derived when the account is read
+			eventSvcMock.On("AccountReset", mock.MatchedBy(func(a *account.Account) bool {
+				return *a.ID == "123456789012" && *a.Status == account.StatusNotReady
+			})).Return(nil)
+			eventSvc = eventSvcMock
+
+			entry, err := outbox.NewEntry(tt.eventType, "id", tt.old, tt.new)
+			assert.Nil(t, err)
+
+			err = publish(entry)
+			assert.Equal(t, tt.expErr, err != nil)
+			if tt.expMethod != "" {
+				eventSvcMock.AssertNumberOfCalls(t, tt.expMethod, 1)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	outboxSvcMock := &outboxmocks.Servicer{}
+	outboxSvcMock.On("Drain", mock.Anything).Return(2, fmt.Errorf("failed to publish"))
+	outboxSvc = outboxSvcMock
+
+	err := Handler(context.TODO(), events.CloudWatchEvent{})
+	assert.NotNil(t, err)
+	outboxSvcMock.AssertExpectations(t)
+}
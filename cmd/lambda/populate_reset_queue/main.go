@@ -7,13 +7,18 @@ import (
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/event/eventiface"
+	"github.com/Optum/dce/pkg/reset/pipeline"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 )
 
 type configuration struct {
 	Debug         string `env:"DEBUG" envDefault:"false"`
 	ResetQueueURL string `env:"RESET_SQS_URL" envDefault:"SqsUrl"`
+	// ResetEventSourceMappingID is checked to skip enqueuing accounts while
+	// the reset pipeline is paused
+	ResetEventSourceMappingID string `env:"RESET_EVENT_SOURCE_MAPPING_ID" envDefault:""`
 }
 
 var (
@@ -38,6 +43,7 @@ func init() {
 
 	_, err = svcBldr.
 		WithEventService().
+		WithLambda().
 		WithAccountService().
 		Build()
 	if err != nil {
@@ -51,6 +57,28 @@ func init() {
 // Handler is the base handler function for the lambda
 func Handler(cloudWatchEvent events.CloudWatchEvent) error {
 
+	// Accounts aren't enqueued again while the reset pipeline is paused, so
+	// they aren't reset several times over when it's resumed.  They're still
+	// NotReady, so they're enqueued on the first run after it's resumed.
+	if settings.ResetEventSourceMappingID != "" {
+		var lambdaSvc lambdaiface.LambdaAPI
+		if err := services.Config.GetService(&lambdaSvc); err != nil {
+			return err
+		}
+		p, err := pipeline.New(lambdaSvc, settings.ResetEventSourceMappingID)
+		if err != nil {
+			return err
+		}
+		status, err := p.Status()
+		if err != nil {
+			return err
+		}
+		if status.Paused {
+			log.Printf("The reset pipeline is paused; not enqueuing accounts")
+			return nil
+		}
+	}
+
 	query := &account.Account{
 		Status: account.StatusNotReady.StatusPtr(),
 	}
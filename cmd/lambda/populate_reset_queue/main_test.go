@@ -7,11 +7,14 @@ import (
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/account/mocks"
 	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
 	eventMocks "github.com/Optum/dce/pkg/event/eventiface/mocks"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -137,3 +140,29 @@ func TestPopulateResetQeue(t *testing.T) {
 		})
 	}
 }
+
+func TestPopulateResetQueueWhilePaused(t *testing.T) {
+	cfgBldr := &config.ConfigurationBuilder{}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	lambdaSvc := &awsMocks.LambdaAPI{}
+	lambdaSvc.On("GetEventSourceMapping", &lambda.GetEventSourceMappingInput{
+		UUID: aws.String("mapping-id"),
+	}).Return(&lambda.EventSourceMappingConfiguration{
+		State: aws.String("Disabled"),
+	}, nil)
+
+	mocksEvent := &eventMocks.Servicer{}
+
+	svcBldr.Config.WithService(mocksEvent).WithService(lambdaSvc)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	settings.ResetEventSourceMappingID = "mapping-id"
+	defer func() { settings.ResetEventSourceMappingID = "" }()
+
+	err = Handler(events.CloudWatchEvent{})
+	assert.Nil(t, err)
+	mocksEvent.AssertNotCalled(t, "AccountReset", mock.Anything)
+}
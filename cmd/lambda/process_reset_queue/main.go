@@ -4,12 +4,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
 	"github.com/Optum/dce/pkg/errors"
+	dceEvent "github.com/Optum/dce/pkg/event"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -75,12 +76,19 @@ func handler(ctx context.Context, sqsEvent events.SQSEvent) error {
 func processMessage(codeBuildSvc codebuildiface.CodeBuildAPI, event events.SQSMessage) error {
 
 	acct := account.Account{}
-	if err := json.Unmarshal([]byte(event.Body), &acct); err != nil {
+	if _, err := dceEvent.Unmarshal([]byte(event.Body), &acct); err != nil {
 		return errors.NewInternalServer("unexpected error unmarshaling sqs message", err)
 	}
 
 	log.Printf("Start Account: %s\nMessage ID: %s\n", *acct.ID, event.MessageId)
 
+	// Closed and suspended accounts can't be reset, and quarantined accounts
+	// aren't reset again
+	if acct.Status != nil && (*acct.Status == account.StatusUnreachable || *acct.Status == account.StatusQuarantined) {
+		log.Printf("Skipping reset of %s account %s", *acct.Status, *acct.ID)
+		return nil
+	}
+
 	buildEnvironmentVars := []*codebuild.EnvironmentVariable{
 		{
 			Name:  aws.String("RESET_ACCOUNT"),
@@ -95,6 +103,13 @@ func processMessage(codeBuildSvc codebuildiface.CodeBuildAPI, event events.SQSMe
 			Value: acct.PrincipalRoleArn.IAMResourceName(),
 		},
 	}
+	// Accounts without a reset profile are reset with the pool's
+	if acct.ResetProfile != nil {
+		buildEnvironmentVars = append(buildEnvironmentVars, &codebuild.EnvironmentVariable{
+			Name:  aws.String("RESET_PROFILE"),
+			Value: aws.String(acct.ResetProfile.String()),
+		})
+	}
 
 	// Trigger Code Pipeline
 	log.Printf("Triggering Reset Build %s for Account %s\n", settings.BuildName, *acct.ID)
@@ -111,5 +126,5 @@ func processMessage(codeBuildSvc codebuildiface.CodeBuildAPI, event events.SQSMe
 
 // Start the Lambda Handler
 func main() {
-	lambda.Start(handler)
+	lambda.StartHandler(diagnostics.LambdaHandler("process_reset_queue", handler))
 }
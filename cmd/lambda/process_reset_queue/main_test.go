@@ -10,6 +10,7 @@ import (
 	"github.com/Optum/dce/pkg/errors"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/service/codebuild"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,10 +19,11 @@ import (
 // provided into the reset queue and transition the finance lock if necessary
 func TestProcessResetQueue(t *testing.T) {
 	tests := []struct {
-		name         string
-		input        events.SQSEvent
-		expErr       error
-		codeBuildErr error
+		name            string
+		input           events.SQSEvent
+		expErr          error
+		expResetProfile string
+		codeBuildErr    error
 	}{
 		{
 			name: "should send account to code build",
@@ -33,6 +35,50 @@ func TestProcessResetQueue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should send enveloped account to code build",
+			input: events.SQSEvent{
+				Records: []events.SQSMessage{
+					{
+						Body: "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"id\":\"123456789012\",\"adminRoleArn\":\"arn:aws:iam::123456789012:role/AdminRole\",\"principalRoleArn\":\"arn:aws:iam::123456789012:role/PrincipalRole\",\"accountStatus\":\"NotReady\"}}\n",
+					},
+				},
+			},
+		},
+		{
+			name: "should send the reset profile to code build",
+			input: events.SQSEvent{
+				Records: []events.SQSMessage{
+					{
+						Body: "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"id\":\"123456789012\",\"adminRoleArn\":\"arn:aws:iam::123456789012:role/AdminRole\",\"principalRoleArn\":\"arn:aws:iam::123456789012:role/PrincipalRole\",\"accountStatus\":\"NotReady\",\"resetProfile\":\"data-preserving\"}}\n",
+					},
+				},
+			},
+			expResetProfile: "data-preserving",
+		},
+		{
+			name: "should skip unreachable accounts",
+			input: events.SQSEvent{
+				Records: []events.SQSMessage{
+					{
+						Body: "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"id\":\"123456789012\",\"adminRoleArn\":\"arn:aws:iam::123456789012:role/AdminRole\",\"principalRoleArn\":\"arn:aws:iam::123456789012:role/PrincipalRole\",\"accountStatus\":\"Unreachable\"}}\n",
+					},
+				},
+			},
+			// Would fail if a build was started
+			codeBuildErr: fmt.Errorf("error"),
+		},
+		{
+			name: "should fail on a newer version",
+			input: events.SQSEvent{
+				Records: []events.SQSMessage{
+					{
+						Body: "{\"type\":\"AccountResetRequested\",\"version\":\"99\",\"data\":{\"id\":\"123456789012\"}}\n",
+					},
+				},
+			},
+			expErr: errors.NewInternalServer("unexpected error unmarshaling sqs message", fmt.Errorf("unsupported version")),
+		},
 		{
 			name: "should fail on parse err",
 			input: events.SQSEvent{
@@ -75,6 +121,16 @@ func TestProcessResetQueue(t *testing.T) {
 
 			err = handler(context.TODO(), tt.input)
 			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expResetProfile != "" {
+				mocksCodeBuild.AssertCalled(t, "StartBuild", mock.MatchedBy(func(input *codebuild.StartBuildInput) bool {
+					for _, v := range input.EnvironmentVariablesOverride {
+						if *v.Name == "RESET_PROFILE" {
+							return *v.Value == tt.expResetProfile
+						}
+					}
+					return false
+				}))
+			}
 
 		})
 	}
@@ -0,0 +1,145 @@
+// Package main exposes the DCE metrics in the Prometheus text format, either
+// at /metrics behind an Application Load Balancer, or by pushing them to a
+// Prometheus Pushgateway on a schedule
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/metrics"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+)
+
+type configuration struct {
+	Debug          string `env:"DEBUG" envDefault:"false"`
+	ResetBuildName string `env:"RESET_BUILD_NAME" envDefault:"ResetCodeBuild"`
+	// PushgatewayURL is the Pushgateway to push metrics to, when invoked on
+	// a schedule
+	PushgatewayURL string `env:"PROMETHEUS_PUSHGATEWAY_URL" envDefault:""`
+	PushJob        string `env:"PROMETHEUS_PUSH_JOB" envDefault:"dce"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings   *configuration
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		WithCodeBuild().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+// handler serves a load balancer request, or pushes the metrics when invoked
+// by a scheduled event
+func handler(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	request := events.ALBTargetGroupRequest{}
+	err := json.Unmarshal(event, &request)
+	if err == nil && request.RequestContext.ELB.TargetGroupArn != "" {
+		return serveMetrics(request), nil
+	}
+	return nil, pushMetrics()
+}
+
+func serveMetrics(request events.ALBTargetGroupRequest) events.ALBTargetGroupResponse {
+	if request.Path != "/metrics" {
+		return textResponse(http.StatusNotFound, "not found")
+	}
+	if request.HTTPMethod != http.MethodGet {
+		return textResponse(http.StatusMethodNotAllowed, "method not allowed")
+	}
+
+	families, err := collector().Collect()
+	if err != nil {
+		log.Printf("Failed to collect metrics: %s", err)
+		return textResponse(http.StatusInternalServerError, "failed to collect metrics")
+	}
+	body := &bytes.Buffer{}
+	err = metrics.WritePrometheus(body, families)
+	if err != nil {
+		log.Printf("Failed to write metrics: %s", err)
+		return textResponse(http.StatusInternalServerError, "failed to write metrics")
+	}
+
+	return events.ALBTargetGroupResponse{
+		StatusCode:        http.StatusOK,
+		StatusDescription: statusDescription(http.StatusOK),
+		Headers:           map[string]string{"Content-Type": metrics.ContentType},
+		Body:              body.String(),
+	}
+}
+
+func pushMetrics() error {
+	if settings.PushgatewayURL == "" {
+		return fmt.Errorf("no Pushgateway URL is configured")
+	}
+
+	families, err := collector().Collect()
+	if err != nil {
+		return err
+	}
+	log.Printf("Pushing metrics to %s", settings.PushgatewayURL)
+	return metrics.Push(httpClient, settings.PushgatewayURL, settings.PushJob, families)
+}
+
+func collector() *metrics.Collector {
+	var codeBuildSvc codebuildiface.CodeBuildAPI
+	if err := services.Config.GetService(&codeBuildSvc); err != nil {
+		panic(err)
+	}
+
+	return &metrics.Collector{
+		AccountSvc:     services.AccountService(),
+		LeaseSvc:       services.LeaseService(),
+		CodeBuild:      codeBuildSvc,
+		ResetBuildName: settings.ResetBuildName,
+	}
+}
+
+func textResponse(status int, body string) events.ALBTargetGroupResponse {
+	return events.ALBTargetGroupResponse{
+		StatusCode:        status,
+		StatusDescription: statusDescription(status),
+		Headers:           map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+		Body:              body,
+	}
+}
+
+func statusDescription(status int) string {
+	return fmt.Sprintf("%d %s", status, http.StatusText(status))
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	leaseMocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/metrics"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const expMetrics = `# HELP dce_accounts Accounts in the account pool, by status.
+# TYPE dce_accounts gauge
+dce_accounts{status="Leased"} 0
+dce_accounts{status="NotReady"} 0
+dce_accounts{status="Orphaned"} 0
+dce_accounts{status="Quarantined"} 0
+dce_accounts{status="Ready"} 2
+dce_accounts{status="Unreachable"} 0
+# HELP dce_leases Leases, by status.
+# TYPE dce_leases gauge
+dce_leases{status="Active"} 0
+dce_leases{status="Inactive"} 0
+# HELP dce_leases_over_budget Leases ended for going over budget, by reason.
+# TYPE dce_leases_over_budget gauge
+dce_leases_over_budget{reason="OverBudget"} 0
+dce_leases_over_budget{reason="OverCategoryBudget"} 0
+dce_leases_over_budget{reason="OverPrincipalBudget"} 0
+`
+
+func setupServices() {
+	accountSvc := accountMocks.Servicer{}
+	accountSvc.On("ListPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*account.Accounts) bool)
+			fn(&account.Accounts{
+				{Status: account.StatusReady.StatusPtr()},
+				{Status: account.StatusReady.StatusPtr()},
+			})
+		}).
+		Return(nil)
+	leaseSvc := leaseMocks.Servicer{}
+	leaseSvc.On("ListPages", mock.Anything, mock.Anything).Return(nil)
+
+	cfgBldr := &config.ConfigurationBuilder{}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+	svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&awsMocks.CodeBuildAPI{})
+	_, err := svcBldr.Build()
+	if err != nil {
+		panic(err)
+	}
+	services = svcBldr
+	settings.ResetBuildName = ""
+}
+
+func TestServeMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		expStatus int
+		expBody   string
+	}{
+		{
+			name:      "should serve the metrics",
+			method:    http.MethodGet,
+			path:      "/metrics",
+			expStatus: http.StatusOK,
+			expBody:   expMetrics,
+		},
+		{
+			name:      "should not serve other paths",
+			method:    http.MethodGet,
+			path:      "/",
+			expStatus: http.StatusNotFound,
+			expBody:   "not found",
+		},
+		{
+			name:      "should only allow GET",
+			method:    http.MethodPost,
+			path:      "/metrics",
+			expStatus: http.StatusMethodNotAllowed,
+			expBody:   "method not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupServices()
+
+			event, err := json.Marshal(events.ALBTargetGroupRequest{
+				HTTPMethod: tt.method,
+				Path:       tt.path,
+				RequestContext: events.ALBTargetGroupRequestContext{
+					ELB: events.ELBContext{TargetGroupArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/dce/abc"},
+				},
+			})
+			assert.Nil(t, err)
+
+			res, err := handler(context.TODO(), event)
+			assert.Nil(t, err)
+
+			response := res.(events.ALBTargetGroupResponse)
+			assert.Equal(t, tt.expStatus, response.StatusCode)
+			assert.Equal(t, tt.expBody, response.Body)
+			if tt.expStatus == http.StatusOK {
+				assert.Equal(t, metrics.ContentType, response.Headers["Content-Type"])
+			}
+		})
+	}
+}
+
+func TestPushMetrics(t *testing.T) {
+	setupServices()
+
+	var pushedPath, pushedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		pushedPath = r.URL.Path
+		pushedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	settings.PushgatewayURL = server.URL
+	settings.PushJob = "dce-test"
+	defer func() { settings.PushgatewayURL = "" }()
+
+	res, err := handler(context.TODO(), json.RawMessage(`{"source": "aws.events", "detail-type": "Scheduled Event"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	assert.Equal(t, "/metrics/job/dce-test", pushedPath)
+	assert.Equal(t, expMetrics, pushedBody)
+}
+
+func TestPushMetricsNotConfigured(t *testing.T) {
+	setupServices()
+
+	_, err := handler(context.TODO(), json.RawMessage(`{"source": "aws.events"}`))
+	assert.EqualError(t, err, "no Pushgateway URL is configured")
+}
@@ -0,0 +1,290 @@
+// Package main sends DCE metrics and lifecycle events to a monitoring
+// service, such as Datadog. Metrics are collected on a schedule, and events
+// are read from the account and lease table streams and from reset builds
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/metrics"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+)
+
+const (
+	// scheduledEvent is the detail type of scheduled CloudWatch events
+	scheduledEvent = "Scheduled Event"
+	// codeBuildStateChange is the detail type of CodeBuild build state change events
+	codeBuildStateChange = "CodeBuild Build State Change"
+)
+
+type configuration struct {
+	Debug          string `env:"DEBUG" envDefault:"false"`
+	AccountDB      string `env:"ACCOUNT_DB" envDefault:"Accounts"`
+	LeaseDB        string `env:"LEASE_DB" envDefault:"Leases"`
+	ResetBuildName string `env:"RESET_BUILD_NAME" envDefault:"ResetCodeBuild"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+// publishEvent is either a batch of DynamoDB stream records, or a CloudWatch event
+type publishEvent struct {
+	Records    []events.DynamoDBEventRecord `json:"Records"`
+	DetailType string                       `json:"detail-type"`
+	Detail     json.RawMessage              `json:"detail"`
+}
+
+// buildStateChange is the part of a CodeBuild build state change event used by DCE
+type buildStateChange struct {
+	BuildStatus           string `json:"build-status"`
+	ProjectName           string `json:"project-name"`
+	BuildID               string `json:"build-id"`
+	AdditionalInformation struct {
+		Environment struct {
+			EnvironmentVariables []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"environment-variables"`
+		} `json:"environment"`
+	} `json:"additional-information"`
+}
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		WithCodeBuild().
+		WithMetricsService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("publish_metrics", handler))
+}
+
+func handler(ctx context.Context, input publishEvent) error {
+	switch input.DetailType {
+	case scheduledEvent:
+		return sendMetrics()
+	case codeBuildStateChange:
+		event, err := resetEvent(input.Detail)
+		if err != nil || event == nil {
+			return err
+		}
+		return sendEvents([]*metrics.Event{event})
+	}
+
+	lifecycleEvents := []*metrics.Event{}
+	for _, record := range input.Records {
+		event := streamEvent(record)
+		if event != nil {
+			lifecycleEvents = append(lifecycleEvents, event)
+		}
+	}
+	return sendEvents(lifecycleEvents)
+}
+
+func sendMetrics() error {
+	var codeBuildSvc codebuildiface.CodeBuildAPI
+	if err := services.Config.GetService(&codeBuildSvc); err != nil {
+		return err
+	}
+	collector := &metrics.Collector{
+		AccountSvc:     services.AccountService(),
+		LeaseSvc:       services.LeaseService(),
+		CodeBuild:      codeBuildSvc,
+		ResetBuildName: settings.ResetBuildName,
+	}
+
+	families, err := collector.Collect()
+	if err != nil {
+		return err
+	}
+	return services.MetricsService().SendMetrics(families)
+}
+
+// sendEvents sends every event before failing, so one failure doesn't hold up the rest
+func sendEvents(lifecycleEvents []*metrics.Event) error {
+	errs := []error{}
+	for _, event := range lifecycleEvents {
+		log.Printf("Sending %s event: %s", event.Name, event.Title)
+		err := services.MetricsService().SendEvent(event)
+		if err != nil {
+			log.Printf("Failed to send %s event: %s", event.Name, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to send events", errs)
+	}
+	return nil
+}
+
+// resetEvent creates an event for a finished reset build
+func resetEvent(detail json.RawMessage) (*metrics.Event, error) {
+	build := &buildStateChange{}
+	err := json.Unmarshal(detail, build)
+	if err != nil {
+		return nil, errors.NewValidation("event", fmt.Errorf("invalid build state change: %s", err))
+	}
+	if build.ProjectName != settings.ResetBuildName {
+		return nil, nil
+	}
+
+	accountID := ""
+	for _, v := range build.AdditionalInformation.Environment.EnvironmentVariables {
+		if v.Name == "RESET_ACCOUNT" {
+			accountID = v.Value
+		}
+	}
+	tags := map[string]string{
+		"accountId": accountID,
+	}
+
+	switch build.BuildStatus {
+	case "SUCCEEDED":
+		return &metrics.Event{
+			Name:      "ResetSucceeded",
+			Title:     fmt.Sprintf("Account %s reset", accountID),
+			Text:      fmt.Sprintf("Reset build %s succeeded", build.BuildID),
+			AlertType: metrics.EventSuccess,
+			Tags:      tags,
+		}, nil
+	case "FAILED":
+		return &metrics.Event{
+			Name:      "ResetFailed",
+			Title:     fmt.Sprintf("Account %s reset failed", accountID),
+			Text:      fmt.Sprintf("Reset build %s failed", build.BuildID),
+			AlertType: metrics.EventError,
+			Tags:      tags,
+		}, nil
+	}
+	return nil, nil
+}
+
+// streamEvent creates an event for an account or lease status change, or
+// returns nil if the status didn't change
+func streamEvent(record events.DynamoDBEventRecord) *metrics.Event {
+	if record.EventName == string(events.DynamoDBOperationTypeRemove) {
+		return nil
+	}
+	newImage := record.Change.NewImage
+	oldImage := record.Change.OldImage
+
+	switch tableName(record.EventSourceArn) {
+	case settings.AccountDB:
+		accountID := stringAttr(newImage, "Id")
+		status := stringAttr(newImage, "AccountStatus")
+		oldStatus := stringAttr(oldImage, "AccountStatus")
+		if status == oldStatus {
+			return nil
+		}
+		alertType := metrics.EventInfo
+		if status == string(account.StatusOrphaned) || status == string(account.StatusUnreachable) || status == string(account.StatusQuarantined) {
+			alertType = metrics.EventWarning
+		}
+		text := fmt.Sprintf("Account %s was added as %s", accountID, status)
+		if oldStatus != "" {
+			text = fmt.Sprintf("Account %s changed from %s to %s", accountID, oldStatus, status)
+		}
+		return &metrics.Event{
+			Name:      "AccountStatusChanged",
+			Title:     fmt.Sprintf("Account %s is %s", accountID, status),
+			Text:      text,
+			AlertType: alertType,
+			Tags: map[string]string{
+				"accountId": accountID,
+				"status":    status,
+			},
+		}
+	case settings.LeaseDB:
+		status := stringAttr(newImage, "LeaseStatus")
+		oldStatus := stringAttr(oldImage, "LeaseStatus")
+		if status == oldStatus {
+			return nil
+		}
+		accountID := stringAttr(newImage, "AccountId")
+		principalID := stringAttr(newImage, "PrincipalId")
+		tags := map[string]string{
+			"leaseId":     stringAttr(newImage, "Id"),
+			"accountId":   accountID,
+			"principalId": principalID,
+		}
+
+		switch status {
+		case string(lease.StatusActive):
+			return &metrics.Event{
+				Name:  "LeaseCreated",
+				Title: fmt.Sprintf("Lease created for %s", principalID),
+				Text:  fmt.Sprintf("Principal %s leased account %s", principalID, accountID),
+				Tags:  tags,
+			}
+		case string(lease.StatusInactive):
+			reason := stringAttr(newImage, "LeaseStatusReason")
+			tags["reason"] = reason
+			alertType := metrics.EventInfo
+			if reason == string(lease.StatusReasonOverBudget) || reason == string(lease.StatusReasonOverPrincipalBudget) ||
+				reason == string(lease.StatusReasonOverCategoryBudget) {
+				alertType = metrics.EventWarning
+			}
+			return &metrics.Event{
+				Name:      "LeaseEnded",
+				Title:     fmt.Sprintf("Lease ended for %s (%s)", principalID, reason),
+				Text:      fmt.Sprintf("The lease of account %s by principal %s ended: %s", accountID, principalID, reason),
+				AlertType: alertType,
+				Tags:      tags,
+			}
+		}
+	}
+	return nil
+}
+
+// tableName gets the table name from a stream ARN, eg.
+// arn:aws:dynamodb:us-east-1:123456789012:table/Accounts/stream/2020-01-01T00:00:00.000
+func tableName(streamArn string) string {
+	parts := strings.Split(streamArn, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func stringAttr(image map[string]events.DynamoDBAttributeValue, name string) string {
+	value, ok := image[name]
+	if !ok || value.DataType() != events.DataTypeString {
+		return ""
+	}
+	return value.String()
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	leaseMocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/metrics"
+	metricsMocks "github.com/Optum/dce/pkg/metrics/metricsiface/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const accountsStreamArn = "arn:aws:dynamodb:us-east-1:123456789012:table/Accounts/stream/2020-01-01T00:00:00.000"
+const leasesStreamArn = "arn:aws:dynamodb:us-east-1:123456789012:table/Leases/stream/2020-01-01T00:00:00.000"
+
+func streamRecords(streamArn string, oldImage string, newImage string) string {
+	return fmt.Sprintf(`{"Records": [{"eventName": "MODIFY", "eventSourceARN": "%s", "dynamodb": {"OldImage": %s, "NewImage": %s}}]}`,
+		streamArn, oldImage, newImage)
+}
+
+func setupServices(metricsSvc *metricsMocks.Servicer) {
+	accountSvc := accountMocks.Servicer{}
+	accountSvc.On("ListPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*account.Accounts) bool)
+			fn(&account.Accounts{{Status: account.StatusReady.StatusPtr()}})
+		}).
+		Return(nil)
+	leaseSvc := leaseMocks.Servicer{}
+	leaseSvc.On("ListPages", mock.Anything, mock.Anything).Return(nil)
+
+	cfgBldr := &config.ConfigurationBuilder{}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+	svcBldr.Config.
+		WithService(&accountSvc).
+		WithService(&leaseSvc).
+		WithService(&awsMocks.CodeBuildAPI{}).
+		WithService(metricsSvc)
+	_, err := svcBldr.Build()
+	if err != nil {
+		panic(err)
+	}
+	services = svcBldr
+}
+
+func TestSendMetrics(t *testing.T) {
+	metricsSvc := &metricsMocks.Servicer{}
+	metricsSvc.On("SendMetrics", mock.Anything).Return(nil)
+	setupServices(metricsSvc)
+	settings.ResetBuildName = ""
+
+	err := handler(context.TODO(), publishEvent{DetailType: "Scheduled Event"})
+
+	assert.Nil(t, err)
+	metricsSvc.AssertCalled(t, "SendMetrics", mock.MatchedBy(func(families []*metrics.Family) bool {
+		return len(families) == 3 && families[0].Name == "dce_accounts"
+	}))
+	settings.ResetBuildName = "ResetCodeBuild"
+}
+
+func TestSendEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		sendErr  error
+		expEvent *metrics.Event
+		expErr   bool
+	}{
+		{
+			name: "should send an event when an account is orphaned",
+			event: streamRecords(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Leased"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Orphaned"}}`),
+			expEvent: &metrics.Event{
+				Name:      "AccountStatusChanged",
+				Title:     "Account 123456789012 is Orphaned",
+				Text:      "Account 123456789012 changed from Leased to Orphaned",
+				AlertType: metrics.EventWarning,
+				Tags:      map[string]string{"accountId": "123456789012", "status": "Orphaned"},
+			},
+		},
+		{
+			name: "should not send an event when the account status is unchanged",
+			event: streamRecords(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Ready"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Ready"}}`),
+		},
+		{
+			name: "should send an event when a lease is created",
+			event: streamRecords(leasesStreamArn, `{}`,
+				`{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"}, "LeaseStatus": {"S": "Active"}}`),
+			expEvent: &metrics.Event{
+				Name:  "LeaseCreated",
+				Title: "Lease created for jdoe",
+				Text:  "Principal jdoe leased account 123456789012",
+				Tags:  map[string]string{"leaseId": "abc", "accountId": "123456789012", "principalId": "jdoe"},
+			},
+		},
+		{
+			name: "should send a warning when a lease is over budget",
+			event: streamRecords(leasesStreamArn,
+				`{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"}, "LeaseStatus": {"S": "Active"}}`,
+				`{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"}, "LeaseStatus": {"S": "Inactive"},
+				  "LeaseStatusReason": {"S": "OverBudget"}}`),
+			expEvent: &metrics.Event{
+				Name:      "LeaseEnded",
+				Title:     "Lease ended for jdoe (OverBudget)",
+				Text:      "The lease of account 123456789012 by principal jdoe ended: OverBudget",
+				AlertType: metrics.EventWarning,
+				Tags:      map[string]string{"leaseId": "abc", "accountId": "123456789012", "principalId": "jdoe", "reason": "OverBudget"},
+			},
+		},
+		{
+			name: "should send an event when a reset build fails",
+			event: `{"detail-type": "CodeBuild Build State Change", "source": "aws.codebuild", "detail": {
+				"build-status": "FAILED", "project-name": "ResetCodeBuild", "build-id": "build:1",
+				"additional-information": {"environment": {"environment-variables": [{"name": "RESET_ACCOUNT", "value": "123456789012", "type": "PLAINTEXT"}]}}}}`,
+			expEvent: &metrics.Event{
+				Name:      "ResetFailed",
+				Title:     "Account 123456789012 reset failed",
+				Text:      "Reset build build:1 failed",
+				AlertType: metrics.EventError,
+				Tags:      map[string]string{"accountId": "123456789012"},
+			},
+		},
+		{
+			name: "should not send an event for other builds",
+			event: `{"detail-type": "CodeBuild Build State Change", "source": "aws.codebuild", "detail": {
+				"build-status": "FAILED", "project-name": "Other"}}`,
+		},
+		{
+			name: "should fail when the event can't be sent",
+			event: streamRecords(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "NotReady"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Ready"}}`),
+			sendErr: fmt.Errorf("unavailable"),
+			expEvent: &metrics.Event{
+				Name:      "AccountStatusChanged",
+				Title:     "Account 123456789012 is Ready",
+				Text:      "Account 123456789012 changed from NotReady to Ready",
+				AlertType: metrics.EventInfo,
+				Tags:      map[string]string{"accountId": "123456789012", "status": "Ready"},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metricsSvc := &metricsMocks.Servicer{}
+			metricsSvc.On("SendEvent", mock.Anything).Return(tt.sendErr)
+			setupServices(metricsSvc)
+
+			input := publishEvent{}
+			err := json.Unmarshal([]byte(tt.event), &input)
+			assert.Nil(t, err)
+
+			err = handler(context.TODO(), input)
+
+			assert.Equal(t, tt.expErr, err != nil, "unexpected error: %v", err)
+			if tt.expEvent != nil {
+				metricsSvc.AssertCalled(t, "SendEvent", tt.expEvent)
+			} else {
+				metricsSvc.AssertNotCalled(t, "SendEvent", mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package main emails program owners a monthly report of what the account
+// pool saved, versus how accounts were managed before DCE
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type configuration struct {
+	Debug string `env:"DEBUG" envDefault:"false"`
+	// Emails are sent the savings report
+	Emails []string `env:"SAVINGS_REPORT_EMAILS" envDefault:"" envSeparator:","`
+	// BudgetCurrency is the currency spend is recorded in
+	BudgetCurrency string `env:"BUDGET_CURRENCY" envDefault:"USD"`
+	// BaselineProvisioningHours and BaselineAccountLifetimeDays are how long
+	// it took to get an account, and how long accounts were kept, before DCE
+	BaselineProvisioningHours   float64 `env:"SAVINGS_PROVISIONING_HOURS" envDefault:"72"`
+	BaselineAccountLifetimeDays float64 `env:"SAVINGS_ACCOUNT_LIFETIME_DAYS" envDefault:"90"`
+}
+
+type savingsReportResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	usageSvc usage.DBer
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+// handler reports the savings of the last calendar month.  It runs at the
+// start of each month.
+func handler(ctx context.Context, event events.CloudWatchEvent) (*savingsReportResult, error) {
+	result := &savingsReportResult{}
+	if len(settings.Emails) == 0 {
+		log.Print("No emails are configured for the savings report")
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	endDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+	startDate := time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	leases := []usage.SavingsLease{}
+	err := services.LeaseService().ListPages(&lease.Lease{}, func(page *lease.Leases) bool {
+		for i := range *page {
+			leases = append(leases, (*page)[i].SavingsLease())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usageDB, err := usageService()
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	records, err := usageDB.GetUsageByDateRange(usage.SavingsUsageStartDate(leases, startDate, endDate), endDate)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get usage", err)
+	}
+
+	baseline := usage.SavingsBaseline{
+		ProvisioningHours:   settings.BaselineProvisioningHours,
+		AccountLifetimeDays: settings.BaselineAccountLifetimeDays,
+	}
+	report := usage.NewSavingsReport(leases, records, baseline, startDate, endDate, settings.BudgetCurrency)
+
+	_, err = services.NotificationService().Send(notification.TemplateSavingsReport, settings.Emails, &notification.Data{
+		Savings: savings(report),
+	})
+	if err != nil {
+		log.Printf("Failed to send the savings report: %s", err)
+		result.Failed++
+		return result, err
+	}
+	result.Sent++
+
+	log.Printf("Sent the savings report from %s to %s: %d accounts reclaimed, %.2f %s idle spend prevented",
+		startDate, endDate, report.AccountsReclaimed, report.IdleSpendPrevented, report.CostCurrency)
+	return result, nil
+}
+
+// savings gets the report as it's shown in an email
+func savings(report *usage.SavingsReport) notification.Savings {
+	return notification.Savings{
+		StartDate:                   time.Unix(report.StartDate, 0).UTC(),
+		EndDate:                     time.Unix(report.EndDate, 0).UTC(),
+		AccountsReclaimed:           report.AccountsReclaimed,
+		ReclaimedByReason:           report.ReclaimedByReason,
+		IdleSpendPrevented:          report.IdleSpendPrevented,
+		CostCurrency:                report.CostCurrency,
+		LeasesProvisioned:           report.LeasesProvisioned,
+		AverageProvisioningHours:    report.AverageProvisioningHours,
+		ProvisioningHoursSaved:      report.ProvisioningHoursSaved,
+		BaselineProvisioningHours:   report.Baseline.ProvisioningHours,
+		BaselineAccountLifetimeDays: report.Baseline.AccountLifetimeDays,
+	}
+}
+
+func usageService() (usage.DBer, error) {
+	if usageSvc != nil {
+		return usageSvc, nil
+	}
+	usageService, err := usage.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	usageSvc = usageService
+	return usageSvc, nil
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("savings_report", handler))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	settings.BudgetCurrency = "USD"
+	settings.BaselineProvisioningHours = 72
+	settings.BaselineAccountLifetimeDays = 90
+
+	// The report covers the last calendar month
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	provisionedOn := monthStart.Unix() + 3600
+	leases := lease.Leases{
+		{
+			AccountID:        aws.String("123456789012"),
+			PrincipalID:      aws.String("jdoe"),
+			Status:           lease.StatusInactive.StatusPtr(),
+			StatusReason:     lease.StatusReasonExpired.StatusReasonPtr(),
+			CreatedOn:        aws.Int64(provisionedOn - 7200),
+			RequestedOn:      aws.Int64(provisionedOn - 7200),
+			ProvisionedOn:    aws.Int64(provisionedOn),
+			StatusModifiedOn: aws.Int64(provisionedOn + 10*86400),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		emails    []string
+		sendErr   error
+		expResult *savingsReportResult
+		expErr    bool
+	}{
+		{
+			name:      "should email the savings report",
+			emails:    []string{"owners@example.com"},
+			expResult: &savingsReportResult{Sent: 1},
+		},
+		{
+			name:      "should fail when the report can't be sent",
+			emails:    []string{"owners@example.com"},
+			sendErr:   fmt.Errorf("throttled"),
+			expResult: &savingsReportResult{Failed: 1},
+			expErr:    true,
+		},
+		{
+			name:      "should send nothing without emails",
+			expResult: &savingsReportResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.Emails = tt.emails
+
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("ListPages", &lease.Lease{}, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(func(*lease.Leases) bool)(&leases)
+				}).
+				Return(nil)
+
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return([]*usage.Usage{
+				{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(provisionedOn), CostAmount: aws.Float64(50), CostCurrency: aws.String("USD")},
+			}, nil)
+			usageSvc = usageSvcMock
+
+			notificationSvc := &notificationmocks.Servicer{}
+			notificationSvc.On("Send", notification.TemplateSavingsReport, []string{"owners@example.com"},
+				mock.MatchedBy(func(data *notification.Data) bool {
+					return data.Savings.StartDate.Equal(monthStart) &&
+						data.Savings.AccountsReclaimed == 1 &&
+						data.Savings.ReclaimedByReason["Expired"] == 1 &&
+						data.Savings.IdleSpendPrevented == 400 &&
+						data.Savings.LeasesProvisioned == 1 &&
+						data.Savings.AverageProvisioningHours == 2 &&
+						data.Savings.ProvisioningHoursSaved == 70
+				})).
+				Return(&notification.Email{}, tt.sendErr)
+
+			svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+			svcBldr.Config.WithService(leaseSvc).WithService(notificationSvc)
+			_, err := svcBldr.Build()
+			require.Nil(t, err)
+			services = svcBldr
+
+			result, err := handler(context.TODO(), events.CloudWatchEvent{})
+			assert.Equal(t, tt.expErr, err != nil, "%s", err)
+			assert.Equal(t, tt.expResult, result)
+			if len(tt.emails) == 0 {
+				notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
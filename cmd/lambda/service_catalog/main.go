@@ -0,0 +1,202 @@
+// Package main is the CloudFormation custom resource behind the DCE Service
+// Catalog product. Provisioning the product creates a lease for the user who
+// provisioned it, and terminating the product ends the lease. Leases are
+// created through the DCE API, so they are checked the same as POST /leases.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/client"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+)
+
+// provisioningPrincipalTag is the tag Service Catalog adds to provisioned
+// stacks, with the ARN of the user who provisioned the product
+const provisioningPrincipalTag = "aws:servicecatalog:provisioningPrincipalArn"
+
+type configuration struct {
+	Debug          string `env:"DEBUG" envDefault:"false"`
+	APIURL         string `env:"DCE_API_URL" envDefault:""`
+	Region         string `env:"AWS_CURRENT_REGION" envDefault:"us-east-1"`
+	BudgetCurrency string `env:"BUDGET_CURRENCY" envDefault:"USD"`
+}
+
+// leaseClient is the part of the DCE API client used to manage leases
+type leaseClient interface {
+	CreateLease(input client.CreateLeaseInput) (*lease.Lease, error)
+	GetLease(id string) (*lease.Lease, error)
+	DeleteLease(id string) (*lease.Lease, error)
+}
+
+var (
+	// Settings - the configuration settings for the controller
+	settings  *configuration
+	leases    leaseClient
+	cfnClient cloudformationiface.CloudFormationAPI
+	now       = time.Now
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Region: aws.String(settings.Region),
+	}))
+	apiClient, err := client.NewClient(client.NewClientInput{
+		BaseURL:     settings.APIURL,
+		Region:      settings.Region,
+		Credentials: awsSession.Config.Credentials,
+	})
+	if err != nil {
+		log.Fatalf("Could not create the DCE API client: %s", err)
+	}
+
+	leases = apiClient
+	cfnClient = cloudformation.New(awsSession)
+}
+
+func main() {
+	lambda.Start(cfn.LambdaWrap(handler))
+}
+
+// handler creates a lease when the product is provisioned, and ends it when
+// the product is terminated. The physical resource ID is the lease ID.
+func handler(ctx context.Context, event cfn.Event) (string, map[string]interface{}, error) {
+	switch event.RequestType {
+	case cfn.RequestCreate:
+		return createLease(event)
+	case cfn.RequestUpdate:
+		return event.PhysicalResourceID, nil,
+			fmt.Errorf("leases can't be updated, terminate the product and provision a new one instead")
+	case cfn.RequestDelete:
+		return event.PhysicalResourceID, nil, endLease(event.PhysicalResourceID)
+	}
+	return event.PhysicalResourceID, nil, fmt.Errorf("unknown request type %q", event.RequestType)
+}
+
+func createLease(event cfn.Event) (string, map[string]interface{}, error) {
+	principalID, err := provisioningPrincipal(event.StackID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	budgetAmount, err := strconv.ParseFloat(property(event, "BudgetAmount"), 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid BudgetAmount %q", property(event, "BudgetAmount"))
+	}
+	input := client.CreateLeaseInput{
+		PrincipalID:    principalID,
+		BudgetAmount:   budgetAmount,
+		BudgetCurrency: settings.BudgetCurrency,
+		Metadata: map[string]interface{}{
+			"serviceCatalogStackId": event.StackID,
+		},
+	}
+	if days := property(event, "LeaseDays"); days != "" {
+		leaseDays, err := strconv.Atoi(days)
+		if err != nil || leaseDays < 1 {
+			return "", nil, fmt.Errorf("invalid LeaseDays %q", days)
+		}
+		input.ExpiresOn = now().AddDate(0, 0, leaseDays).Unix()
+	}
+	for _, email := range strings.Split(property(event, "NotificationEmails"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			input.BudgetNotificationEmails = append(input.BudgetNotificationEmails, email)
+		}
+	}
+
+	created, err := leases.CreateLease(input)
+	if err != nil {
+		return "", nil, err
+	}
+	log.Printf("Created lease %s of account %s for %s", *created.ID, *created.AccountID, principalID)
+
+	data := map[string]interface{}{
+		"AccountId":   *created.AccountID,
+		"PrincipalId": principalID,
+	}
+	if created.ExpiresOn != nil {
+		data["ExpiresOn"] = time.Unix(*created.ExpiresOn, 0).UTC().Format(time.RFC3339)
+	}
+	return *created.ID, data, nil
+}
+
+// endLease ends the lease, unless it has ended already. Leases which were
+// never created, because provisioning failed, are ignored.
+func endLease(leaseID string) error {
+	existing, err := leases.GetLease(leaseID)
+	if client.IsNotFound(err) {
+		log.Printf("Lease %s does not exist", leaseID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.Status != nil && *existing.Status == lease.StatusInactive {
+		log.Printf("Lease %s has already ended", leaseID)
+		return nil
+	}
+
+	_, err = leases.DeleteLease(leaseID)
+	if err != nil {
+		return err
+	}
+	log.Printf("Ended lease %s", leaseID)
+	return nil
+}
+
+// provisioningPrincipal gets the principal ID of the user who provisioned the
+// product. This is the role session name of an assumed role, such as a
+// federated user, or the name of an IAM user.
+func provisioningPrincipal(stackID string) (string, error) {
+	out, err := cfnClient.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackID),
+	})
+	if err != nil {
+		return "", errors.NewInternalServer(fmt.Sprintf("failed to describe stack %s", stackID), err)
+	}
+
+	for _, stack := range out.Stacks {
+		for _, tag := range stack.Tags {
+			if aws.StringValue(tag.Key) != provisioningPrincipalTag {
+				continue
+			}
+			principalArn, err := arn.Parse(aws.StringValue(tag.Value))
+			if err != nil {
+				return "", fmt.Errorf("invalid provisioning principal %q", aws.StringValue(tag.Value))
+			}
+			parts := strings.Split(principalArn.Resource, "/")
+			return parts[len(parts)-1], nil
+		}
+	}
+	return "", fmt.Errorf("stack %s was not provisioned by Service Catalog", stackID)
+}
+
+// property gets a custom resource property. CloudFormation passes every
+// property as a string.
+func property(event cfn.Event, name string) string {
+	value, ok := event.ResourceProperties[name]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/client"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockLeaseClient struct {
+	mock.Mock
+}
+
+func (m *mockLeaseClient) CreateLease(input client.CreateLeaseInput) (*lease.Lease, error) {
+	args := m.Called(input)
+	out, _ := args.Get(0).(*lease.Lease)
+	return out, args.Error(1)
+}
+
+func (m *mockLeaseClient) GetLease(id string) (*lease.Lease, error) {
+	args := m.Called(id)
+	out, _ := args.Get(0).(*lease.Lease)
+	return out, args.Error(1)
+}
+
+func (m *mockLeaseClient) DeleteLease(id string) (*lease.Lease, error) {
+	args := m.Called(id)
+	out, _ := args.Get(0).(*lease.Lease)
+	return out, args.Error(1)
+}
+
+const stackID = "arn:aws:cloudformation:us-east-1:123456789012:stack/SC-123456789012-pp-abc/1"
+
+func stackWithPrincipal(principalArn string) *cloudformation.DescribeStacksOutput {
+	stack := &cloudformation.Stack{}
+	if principalArn != "" {
+		stack.Tags = []*cloudformation.Tag{
+			{Key: aws.String("aws:servicecatalog:productArn"), Value: aws.String("arn:aws:catalog:us-east-1:123456789012:product/prod-1")},
+			{Key: aws.String(provisioningPrincipalTag), Value: aws.String(principalArn)},
+		}
+	}
+	return &cloudformation.DescribeStacksOutput{Stacks: []*cloudformation.Stack{stack}}
+}
+
+func TestCreate(t *testing.T) {
+	currentTime := time.Date(2020, 1, 2, 15, 0, 0, 0, time.UTC)
+	now = func() time.Time { return currentTime }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name         string
+		principalArn string
+		properties   map[string]interface{}
+		createErr    error
+		expInput     *client.CreateLeaseInput
+		expData      map[string]interface{}
+		expErr       string
+	}{
+		{
+			name:         "should lease an account to a federated user",
+			principalArn: "arn:aws:sts::123456789012:assumed-role/Developers/jdoe",
+			properties: map[string]interface{}{
+				"BudgetAmount":       "100",
+				"LeaseDays":          "7",
+				"NotificationEmails": "jdoe@example.com, team@example.com",
+			},
+			expInput: &client.CreateLeaseInput{
+				PrincipalID:              "jdoe",
+				BudgetAmount:             100,
+				BudgetCurrency:           "USD",
+				BudgetNotificationEmails: []string{"jdoe@example.com", "team@example.com"},
+				ExpiresOn:                currentTime.AddDate(0, 0, 7).Unix(),
+				Metadata:                 map[string]interface{}{"serviceCatalogStackId": stackID},
+			},
+			expData: map[string]interface{}{
+				"AccountId":   "111111111111",
+				"PrincipalId": "jdoe",
+				"ExpiresOn":   "2020-01-09T15:00:00Z",
+			},
+		},
+		{
+			name:         "should lease an account to an IAM user",
+			principalArn: "arn:aws:iam::123456789012:user/engineering/jdoe",
+			properties:   map[string]interface{}{"BudgetAmount": "50", "NotificationEmails": ""},
+			expInput: &client.CreateLeaseInput{
+				PrincipalID:    "jdoe",
+				BudgetAmount:   50,
+				BudgetCurrency: "USD",
+				Metadata:       map[string]interface{}{"serviceCatalogStackId": stackID},
+			},
+			expData: map[string]interface{}{
+				"AccountId":   "111111111111",
+				"PrincipalId": "jdoe",
+				"ExpiresOn":   "2020-01-09T15:00:00Z",
+			},
+		},
+		{
+			name:       "should fail when the stack was not provisioned by Service Catalog",
+			properties: map[string]interface{}{"BudgetAmount": "50"},
+			expErr:     "stack " + stackID + " was not provisioned by Service Catalog",
+		},
+		{
+			name:         "should fail on an invalid budget",
+			principalArn: "arn:aws:iam::123456789012:user/jdoe",
+			properties:   map[string]interface{}{"BudgetAmount": "lots"},
+			expErr:       `invalid BudgetAmount "lots"`,
+		},
+		{
+			name:         "should fail on an invalid lease duration",
+			principalArn: "arn:aws:iam::123456789012:user/jdoe",
+			properties:   map[string]interface{}{"BudgetAmount": "50", "LeaseDays": "0"},
+			expErr:       `invalid LeaseDays "0"`,
+		},
+		{
+			name:         "should fail when the API rejects the lease",
+			principalArn: "arn:aws:iam::123456789012:user/jdoe",
+			properties:   map[string]interface{}{"BudgetAmount": "5000"},
+			createErr:    &client.APIError{StatusCode: http.StatusBadRequest, Code: "RequestValidationError", Message: "budget is too large"},
+			expErr:       "400 RequestValidationError: budget is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfnMock := &awsmocks.CloudFormationAPI{}
+			cfnMock.On("DescribeStacks", &cloudformation.DescribeStacksInput{StackName: aws.String(stackID)}).
+				Return(stackWithPrincipal(tt.principalArn), nil)
+			cfnClient = cfnMock
+
+			leaseMock := &mockLeaseClient{}
+			leaseMock.On("CreateLease", mock.Anything).Return(&lease.Lease{
+				ID:        aws.String("abc"),
+				AccountID: aws.String("111111111111"),
+				ExpiresOn: aws.Int64(currentTime.AddDate(0, 0, 7).Unix()),
+			}, tt.createErr)
+			leases = leaseMock
+
+			id, data, err := handler(context.TODO(), cfn.Event{
+				RequestType:        cfn.RequestCreate,
+				StackID:            stackID,
+				ResourceProperties: tt.properties,
+			})
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "abc", id)
+			assert.Equal(t, tt.expData, data)
+			leaseMock.AssertCalled(t, "CreateLease", *tt.expInput)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		lease     *lease.Lease
+		getErr    error
+		deleteErr error
+		expDelete bool
+		expErr    string
+	}{
+		{
+			name:      "should end an active lease",
+			lease:     &lease.Lease{ID: aws.String("abc"), Status: lease.StatusActive.StatusPtr()},
+			expDelete: true,
+		},
+		{
+			name:  "should ignore a lease which has ended",
+			lease: &lease.Lease{ID: aws.String("abc"), Status: lease.StatusInactive.StatusPtr()},
+		},
+		{
+			name:   "should ignore a lease which was never created",
+			getErr: &client.APIError{StatusCode: http.StatusNotFound, Code: "NotFoundError", Message: "lease not found"},
+		},
+		{
+			name:      "should fail when the lease can't be ended",
+			lease:     &lease.Lease{ID: aws.String("abc"), Status: lease.StatusActive.StatusPtr()},
+			deleteErr: fmt.Errorf("unavailable"),
+			expDelete: true,
+			expErr:    "unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseMock := &mockLeaseClient{}
+			leaseMock.On("GetLease", "abc").Return(tt.lease, tt.getErr)
+			leaseMock.On("DeleteLease", "abc").Return(tt.lease, tt.deleteErr)
+			leases = leaseMock
+
+			id, _, err := handler(context.TODO(), cfn.Event{
+				RequestType:        cfn.RequestDelete,
+				StackID:            stackID,
+				PhysicalResourceID: "abc",
+			})
+
+			assert.Equal(t, "abc", id)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			if tt.expDelete {
+				leaseMock.AssertCalled(t, "DeleteLease", "abc")
+			} else {
+				leaseMock.AssertNotCalled(t, "DeleteLease", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	id, _, err := handler(context.TODO(), cfn.Event{
+		RequestType:        cfn.RequestUpdate,
+		PhysicalResourceID: "abc",
+	})
+
+	assert.Equal(t, "abc", id)
+	assert.EqualError(t, err, "leases can't be updated, terminate the product and provision a new one instead")
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/slack"
+)
+
+const commandUsage = "Usage:\n" +
+	"• `lease [budget] [days] [notes]` - lease an account, eg. `lease 100 3 load testing`\n" +
+	"• `end` - end your active lease\n" +
+	"• `status` - show your active lease"
+
+// SlashCommand handles DCE slash commands, eg. `/dce lease 100 3`.
+// The principal ID of the lease is the Slack user name.
+func SlashCommand(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		api.WriteAPIResponse(w, http.StatusOK, ephemeral("Invalid slash command"))
+		return
+	}
+	userID := r.PostForm.Get("user_id")
+	// Leases are recorded with the normalized principal ID, the same as API
+	// requests
+	principalID := PrincipalIDFormat.Normalize(r.PostForm.Get("user_name"))
+	args := strings.Fields(r.PostForm.Get("text"))
+
+	var msg *slack.Message
+	switch {
+	case len(args) > 0 && args[0] == "lease":
+		msg = requestLease(userID, principalID, args[1:])
+	case len(args) > 0 && args[0] == "end":
+		msg = endLease(principalID)
+	case len(args) > 0 && args[0] == "status":
+		msg = leaseStatus(principalID)
+	default:
+		msg = ephemeral(commandUsage)
+	}
+	api.WriteAPIResponse(w, http.StatusOK, msg)
+}
+
+func requestLease(userID string, principalID string, args []string) *slack.Message {
+	req := &leaseRequest{
+		PrincipalID: principalID,
+		SlackUserID: userID,
+	}
+	if len(args) > 0 {
+		budget, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || budget <= 0 {
+			return ephemeral(fmt.Sprintf("Invalid budget %q\n%s", args[0], commandUsage))
+		}
+		req.BudgetAmount = &budget
+	}
+	if len(args) > 1 {
+		days, err := strconv.Atoi(args[1])
+		if err != nil || days <= 0 {
+			return ephemeral(fmt.Sprintf("Invalid number of days %q\n%s", args[1], commandUsage))
+		}
+		expiresOn := time.Now().AddDate(0, 0, days).Unix()
+		req.ExpiresOn = &expiresOn
+	}
+	if len(args) > 2 {
+		req.Notes = strings.Join(args[2:], " ")
+	}
+
+	// Budget notifications are sent to the user's email, which
+	// is also used to send them as direct messages
+	user, err := slackSvc.GetUser(userID)
+	if err != nil {
+		log.Printf("Failed to look up Slack user %s: %s", userID, err)
+	} else if user.Profile.Email != "" {
+		req.NotificationEmails = []string{user.Profile.Email}
+	}
+
+	// The current terms of use, when there are any, are shown with a button
+	// to accept them and submit the request
+	terms, err := Services.LeaseService().GetTerms()
+	if err != nil {
+		return ephemeral(fmt.Sprintf("Failed to get the terms of use: %s", err))
+	}
+	if terms != nil {
+		msg, err := termsMessage(req, terms)
+		if err != nil {
+			return ephemeral(fmt.Sprintf("Failed to request a lease: %s", err))
+		}
+		return msg
+	}
+	return submitLease(req)
+}
+
+// submitLease asks the approvers to approve a lease request, when approvals
+// are required, or else creates the lease
+func submitLease(req *leaseRequest) *slack.Message {
+	if Settings.ApprovalChannel != "" {
+		err := requestApproval(req)
+		if err != nil {
+			log.Printf("Failed to request approval for a lease for %s: %s", req.PrincipalID, err)
+			return ephemeral(fmt.Sprintf("Failed to request approval: %s", err))
+		}
+		return ephemeral("Your lease request has been sent for approval")
+	}
+
+	created, err := createLease(req)
+	if err != nil {
+		return ephemeral(fmt.Sprintf("Failed to create a lease: %s", err))
+	}
+	return ephemeral(fmt.Sprintf("%s is ready", describeLease(created)))
+}
+
+// termsMessage shows the terms of use a lease request must accept, with a
+// button to accept them and submit the request
+func termsMessage(req *leaseRequest, terms *lease.Terms) (*slack.Message, error) {
+	accepted := *req
+	accepted.TermsVersion = terms.Version
+	value, err := json.Marshal(accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("Leases must accept version %s of the terms of use:\n\n%s", terms.Version, terms.Text)
+	return &slack.Message{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+		Blocks: []*slack.Block{
+			slack.NewSectionBlock(text),
+			slack.NewActionsBlock("lease_terms",
+				slack.NewButton(actionAcceptTerms, "Accept and request lease", string(value), "primary"),
+			),
+		},
+	}, nil
+}
+
+// requestApproval asks the approvers to approve or deny a lease request
+func requestApproval(req *leaseRequest) error {
+	value, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf("<@%s> has requested a lease for `%s`", req.SlackUserID, req.PrincipalID)
+	if req.BudgetAmount != nil {
+		text += fmt.Sprintf(" with a budget of %.2f %s", *req.BudgetAmount, Settings.BudgetCurrency)
+	}
+	if req.ExpiresOn != nil {
+		text += fmt.Sprintf(", until <!date^%d^{date_short_pretty}|%s>",
+			*req.ExpiresOn, time.Unix(*req.ExpiresOn, 0).UTC().Format(time.RFC1123))
+	}
+	if req.Notes != "" {
+		text += fmt.Sprintf("\n>%s", req.Notes)
+	}
+
+	return slackSvc.PostMessage(&slack.Message{
+		Channel: Settings.ApprovalChannel,
+		Text:    text,
+		Blocks: []*slack.Block{
+			slack.NewSectionBlock(text),
+			slack.NewActionsBlock("lease_approval",
+				slack.NewButton(actionApproveLease, "Approve", string(value), "primary"),
+				slack.NewButton(actionDenyLease, "Deny", string(value), "danger"),
+			),
+		},
+	})
+}
+
+func endLease(principalID string) *slack.Message {
+	activeLease, err := getActiveLease(principalID)
+	if err != nil {
+		return ephemeral(fmt.Sprintf("Failed to find your lease: %s", err))
+	}
+	if activeLease == nil {
+		return ephemeral("You don't have an active lease")
+	}
+
+	ended, err := Services.LeaseService().Delete(*activeLease.ID)
+	if err != nil {
+		return ephemeral(fmt.Sprintf("Failed to end your lease: %s", err))
+	}
+	return ephemeral(fmt.Sprintf("Lease `%s` has ended, and account `%s` will be reset", *ended.ID, *ended.AccountID))
+}
+
+func leaseStatus(principalID string) *slack.Message {
+	activeLease, err := getActiveLease(principalID)
+	if err != nil {
+		return ephemeral(fmt.Sprintf("Failed to find your lease: %s", err))
+	}
+	if activeLease == nil {
+		return ephemeral("You don't have an active lease")
+	}
+	return ephemeral(describeLease(activeLease))
+}
+
+func ephemeral(text string) *slack.Message {
+	return &slack.Message{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+	}
+}
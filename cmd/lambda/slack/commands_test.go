@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/directory"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/slack"
+	slackmocks "github.com/Optum/dce/pkg/slack/mocks"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSlashCommand(t *testing.T) {
+	activeLease := lease.Lease{
+		ID:           ptrString("abc"),
+		AccountID:    ptrString("123456789012"),
+		PrincipalID:  ptrString("jdoe"),
+		BudgetAmount: ptrFloat64(100),
+		Status:       lease.StatusActive.StatusPtr(),
+	}
+
+	tests := []struct {
+		name            string
+		text            string
+		approvalChannel string
+		activeLeases    *lease.Leases
+		terms           *lease.Terms
+		leaseGroups     []string
+		expText         string
+		expCreate       bool
+		expDelete       bool
+		expPost         bool
+		expPostText     string
+	}{
+		{
+			name:      "should create a lease",
+			text:      "lease 100 3",
+			expText:   "Lease `abc` for account `123456789012`, budget 100.00",
+			expCreate: true,
+		},
+		{
+			name:    "should show the terms of use to accept",
+			text:    "lease 100 3",
+			terms:   &lease.Terms{Version: "2", Text: "Don't mine bitcoin"},
+			expText: "Leases must accept version 2 of the terms of use:\n\nDon't mine bitcoin",
+		},
+		{
+			name:        "should not create a lease for users outside the lease groups",
+			text:        "lease 100 3",
+			leaseGroups: []string{"Cloud Users"},
+			expText:     "Failed to create a lease: User [jdoe] is not a member of the groups allowed to create leases",
+		},
+		{
+			name:        "should create a lease for members of the lease groups",
+			text:        "lease 100 3",
+			leaseGroups: []string{"Engineering"},
+			expText:     "Lease `abc` for account `123456789012`, budget 100.00",
+			expCreate:   true,
+		},
+		{
+			name:            "should request approval for a lease",
+			text:            "lease 100",
+			approvalChannel: "C123",
+			expText:         "Your lease request has been sent for approval",
+			expPost:         true,
+		},
+		{
+			name:            "should include notes in the approval request",
+			text:            "lease 100 3 load testing the API",
+			approvalChannel: "C123",
+			expText:         "Your lease request has been sent for approval",
+			expPost:         true,
+			expPostText:     ">load testing the API",
+		},
+		{
+			name:    "should reject an invalid budget",
+			text:    "lease lots",
+			expText: "Invalid budget \"lots\"",
+		},
+		{
+			name:         "should end the active lease",
+			text:         "end",
+			activeLeases: &lease.Leases{activeLease},
+			expText:      "Lease `abc` has ended",
+			expDelete:    true,
+		},
+		{
+			name:         "should not end a lease when there is no active lease",
+			text:         "end",
+			activeLeases: &lease.Leases{},
+			expText:      "You don't have an active lease",
+		},
+		{
+			name:         "should show the active lease",
+			text:         "status",
+			activeLeases: &lease.Leases{activeLease},
+			expText:      "Lease `abc` for account `123456789012`",
+		},
+		{
+			name:    "should show usage for unknown commands",
+			text:    "help",
+			expText: "Usage:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return(tt.leaseGroups)
+			readyAccount := &account.Account{ID: ptrString("123456789012"), Status: account.StatusReady.StatusPtr()}
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(readyAccount, nil)
+			accountSvc.On("GetReadyAccountWithRequirements", "jdoe", mock.Anything).Return(readyAccount, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("List", mock.MatchedBy(func(l *lease.Lease) bool {
+				return l.Status != nil && *l.Status == lease.StatusActive
+			})).Return(tt.activeLeases, nil)
+			leaseSvc.On("List", mock.Anything).Return(&lease.Leases{}, nil)
+			leaseSvc.On("GetTerms").Return(tt.terms, nil)
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), "jdoe").Return(nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 0.0, (*lease.Quota)(nil)).Return(&activeLease, nil)
+			leaseSvc.On("Delete", "abc").Return(&activeLease, nil)
+
+			slackSvcMock := &slackmocks.Service{}
+			slackSvcMock.On("GetUser", "U123").Return(&slack.User{ID: "U123"}, nil)
+			slackSvcMock.On("PostMessage", mock.Anything).Return(nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("GetUser", "jdoe").Return(&directory.User{UserName: "jdoe", Active: true, Groups: []string{"Engineering"}}, nil)
+			directorySvc.On("PrincipalQuota", "jdoe").Return(nil, nil)
+
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByPrincipal", mock.Anything, "jdoe").Return(nil, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+			usageSvc = usageSvcMock
+			slackSvc = slackSvcMock
+			Settings.SigningSecret = testSigningSecret
+			Settings.ApprovalChannel = tt.approvalChannel
+
+			body := url.Values{
+				"command":   {"/dce"},
+				"text":      {tt.text},
+				"user_id":   {"U123"},
+				"user_name": {"jdoe"},
+			}.Encode()
+			resp, err := Handler(context.TODO(), signedRequest("/slack/commands", body))
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			msg := slack.Message{}
+			err = json.Unmarshal([]byte(resp.Body), &msg)
+			assert.Nil(t, err)
+			assert.Equal(t, slack.ResponseTypeEphemeral, msg.ResponseType)
+			assert.True(t, strings.Contains(msg.Text, tt.expText), "expected %q to contain %q", msg.Text, tt.expText)
+
+			if tt.expCreate {
+				leaseSvc.AssertCalled(t, "CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 0.0, (*lease.Quota)(nil))
+				accountSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.terms != nil {
+				if assert.Len(t, msg.Blocks, 2) {
+					assert.Equal(t, actionAcceptTerms, msg.Blocks[1].Elements[0].ActionID)
+					assert.Contains(t, msg.Blocks[1].Elements[0].Value, `"termsVersion":"2"`)
+				}
+			}
+			if tt.expDelete {
+				leaseSvc.AssertCalled(t, "Delete", "abc")
+			} else {
+				leaseSvc.AssertNotCalled(t, "Delete", mock.Anything)
+			}
+			if tt.expPost {
+				slackSvcMock.AssertCalled(t, "PostMessage", mock.MatchedBy(func(m *slack.Message) bool {
+					return m.Channel == "C123" && len(m.Blocks) == 2 && strings.Contains(m.Text, tt.expPostText)
+				}))
+			} else {
+				slackSvcMock.AssertNotCalled(t, "PostMessage", mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Optum/dce/pkg/slack"
+)
+
+// Action IDs of the lease approval and terms of use buttons
+const (
+	actionApproveLease = "approve_lease"
+	actionDenyLease    = "deny_lease"
+	actionAcceptTerms  = "accept_terms"
+)
+
+// interactionPayload is the part of a Slack block_actions payload used by DCE
+type interactionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	ResponseURL string `json:"response_url"`
+	Actions     []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// Interaction handles button clicks on messages posted by DCE. Slack ignores
+// the response body, so results are sent to the response URL instead.
+func Interaction(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := &interactionPayload{}
+	err = json.Unmarshal([]byte(r.PostForm.Get("payload")), payload)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	for _, action := range payload.Actions {
+		switch action.ActionID {
+		case actionApproveLease, actionDenyLease:
+			respond(payload.ResponseURL, handleApproval(payload.User.ID, action.ActionID, action.Value))
+		case actionAcceptTerms:
+			respond(payload.ResponseURL, handleTermsAcceptance(payload.User.ID, action.Value))
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleApproval approves or denies a lease request, returning a message to
+// replace the approval request with
+func handleApproval(approverID string, actionID string, value string) *slack.Message {
+	if !isApprover(approverID) {
+		return ephemeral(fmt.Sprintf("<@%s> is not allowed to approve leases", approverID))
+	}
+
+	req := &leaseRequest{}
+	err := json.Unmarshal([]byte(value), req)
+	if err != nil || req.PrincipalID == "" {
+		return ephemeral("Invalid lease request")
+	}
+
+	if actionID == actionDenyLease {
+		notify(req.SlackUserID, "Your lease request was denied")
+		return &slack.Message{
+			ReplaceOriginal: true,
+			Text:            fmt.Sprintf("Lease request for `%s` was denied by <@%s>", req.PrincipalID, approverID),
+		}
+	}
+
+	created, err := createLease(req)
+	if err != nil {
+		// Leave the request in place, so it can be approved again
+		return ephemeral(fmt.Sprintf("Failed to create a lease for `%s`: %s", req.PrincipalID, err))
+	}
+	notify(req.SlackUserID, fmt.Sprintf("Your lease request was approved. %s is ready", describeLease(created)))
+	return &slack.Message{
+		ReplaceOriginal: true,
+		Text: fmt.Sprintf("Lease request for `%s` was approved by <@%s>. %s",
+			req.PrincipalID, approverID, describeLease(created)),
+	}
+}
+
+// handleTermsAcceptance submits a lease request once its requester accepts
+// the terms of use
+func handleTermsAcceptance(userID string, value string) *slack.Message {
+	req := &leaseRequest{}
+	err := json.Unmarshal([]byte(value), req)
+	if err != nil || req.PrincipalID == "" {
+		return ephemeral("Invalid lease request")
+	}
+	// Only the requester may accept the terms for their request
+	if userID != req.SlackUserID {
+		return ephemeral(fmt.Sprintf("<@%s> may not accept the terms of use for <@%s>", userID, req.SlackUserID))
+	}
+
+	msg := submitLease(req)
+	msg.ReplaceOriginal = true
+	return msg
+}
+
+// isApprover checks the Slack user may approve leases. Approvers are the
+// configured Slack users, and members of the directory approver groups, who
+// are looked up by their Slack email. When no approvers are configured,
+// anyone in the approval channel may approve them.
+func isApprover(userID string) bool {
+	directorySvc := Services.DirectoryService()
+	if len(Settings.Approvers) == 0 && !directorySvc.HasApprovers() {
+		return true
+	}
+	for _, approver := range Settings.Approvers {
+		if approver == userID {
+			return true
+		}
+	}
+	if !directorySvc.HasApprovers() {
+		return false
+	}
+
+	user, err := slackSvc.GetUser(userID)
+	if err != nil {
+		log.Printf("Failed to look up Slack user %s: %s", userID, err)
+		return false
+	}
+	if user.Profile.Email == "" {
+		return false
+	}
+	directoryUser, err := directorySvc.GetUser(user.Profile.Email)
+	if err != nil {
+		log.Printf("Failed to look up directory user %s: %s", user.Profile.Email, err)
+		return false
+	}
+	return directorySvc.IsApprover(directoryUser)
+}
+
+// notify sends a direct message to a Slack user, logging any failure
+func notify(userID string, text string) {
+	if userID == "" {
+		return
+	}
+	err := slackSvc.PostMessage(&slack.Message{Channel: userID, Text: text})
+	if err != nil {
+		log.Printf("Failed to send a Slack message to %s: %s", userID, err)
+	}
+}
+
+// respond updates the message which was interacted with, logging any failure
+func respond(responseURL string, msg *slack.Message) {
+	err := slackSvc.Respond(responseURL, msg)
+	if err != nil {
+		log.Printf("Failed to respond to a Slack interaction: %s", err)
+	}
+}
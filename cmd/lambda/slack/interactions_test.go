@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/directory"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/slack"
+	slackmocks "github.com/Optum/dce/pkg/slack/mocks"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInteraction(t *testing.T) {
+	createdLease := lease.Lease{
+		ID:          ptrString("abc"),
+		AccountID:   ptrString("123456789012"),
+		PrincipalID: ptrString("jdoe"),
+	}
+
+	tests := []struct {
+		name       string
+		actionID   string
+		approverID string
+		approvers  []string
+		// approverGroup is the directory group of the approver, when
+		// directory approver groups are configured
+		approverGroup string
+		expText       string
+		expReplace    bool
+		expCreate     bool
+		expNotify     string
+	}{
+		{
+			name:       "should create the lease when approved",
+			actionID:   actionApproveLease,
+			approverID: "U999",
+			approvers:  []string{"U999"},
+			expText:    "Lease request for `jdoe` was approved by <@U999>",
+			expReplace: true,
+			expCreate:  true,
+			expNotify:  "Your lease request was approved",
+		},
+		{
+			name:       "should not create the lease when denied",
+			actionID:   actionDenyLease,
+			approverID: "U999",
+			expText:    "Lease request for `jdoe` was denied by <@U999>",
+			expReplace: true,
+			expNotify:  "Your lease request was denied",
+		},
+		{
+			name:       "should only allow approvers to approve leases",
+			actionID:   actionApproveLease,
+			approverID: "U123",
+			approvers:  []string{"U999"},
+			expText:    "<@U123> is not allowed to approve leases",
+		},
+		{
+			name:          "should allow members of directory approver groups to approve leases",
+			actionID:      actionApproveLease,
+			approverID:    "U888",
+			approverGroup: "Cloud Admins",
+			expText:       "Lease request for `jdoe` was approved by <@U888>",
+			expReplace:    true,
+			expCreate:     true,
+			expNotify:     "Your lease request was approved",
+		},
+		{
+			name:          "should not allow other directory users to approve leases",
+			actionID:      actionApproveLease,
+			approverID:    "U888",
+			approverGroup: "Engineering",
+			expText:       "<@U888> is not allowed to approve leases",
+		},
+		{
+			name:       "should create the lease when the requester accepts the terms",
+			actionID:   actionAcceptTerms,
+			approverID: "U123",
+			expText:    "Lease `abc` for account `123456789012` is ready",
+			expReplace: true,
+			expCreate:  true,
+		},
+		{
+			name:       "should only allow the requester to accept the terms",
+			actionID:   actionAcceptTerms,
+			approverID: "U999",
+			expText:    "<@U999> may not accept the terms of use for <@U123>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+			accountSvc := accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", mock.Anything).Return(nil)
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(&account.Account{ID: ptrString("123456789012"), Status: account.StatusReady.StatusPtr()}, nil)
+
+			leaseSvc := leasemocks.Servicer{}
+			leaseSvc.On("List", mock.Anything).Return(&lease.Leases{}, nil)
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), "jdoe").Return(nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 0.0, (*lease.Quota)(nil)).Return(&createdLease, nil)
+
+			var responded *slack.Message
+			slackSvcMock := &slackmocks.Service{}
+			slackSvcMock.On("Respond", "https://hooks.slack.com/actions/T1/1/x", mock.Anything).Run(func(args mock.Arguments) {
+				responded = args.Get(1).(*slack.Message)
+			}).Return(nil)
+			slackSvcMock.On("PostMessage", mock.Anything).Return(nil)
+
+			usageSvcMock := &mockUsage.DBer{}
+			usageSvcMock.On("GetUsageByPrincipal", mock.Anything, "jdoe").Return(nil, nil)
+
+			directorySvc := directorymocks.Servicer{}
+			directorySvc.On("HasApprovers").Return(tt.approverGroup != "")
+			directoryUser := &directory.User{UserName: "approver@example.com", Active: true, Groups: []string{tt.approverGroup}}
+			directorySvc.On("GetUser", "approver@example.com").Return(directoryUser, nil)
+			directorySvc.On("IsApprover", directoryUser).Return(tt.approverGroup == "Cloud Admins")
+			directorySvc.On("GetUser", "jdoe").Return(nil, nil)
+			directorySvc.On("PrincipalQuota", "jdoe").Return(nil, nil)
+			approver := &slack.User{ID: tt.approverID}
+			approver.Profile.Email = "approver@example.com"
+			slackSvcMock.On("GetUser", tt.approverID).Return(approver, nil)
+
+			svcBldr.Config.WithService(&accountSvc).WithService(&leaseSvc).WithService(&directorySvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				Services = svcBldr
+			}
+			usageSvc = usageSvcMock
+			slackSvc = slackSvcMock
+			Settings.SigningSecret = testSigningSecret
+			Settings.Approvers = tt.approvers
+
+			payload, _ := json.Marshal(map[string]interface{}{
+				"type":         "block_actions",
+				"user":         map[string]string{"id": tt.approverID},
+				"response_url": "https://hooks.slack.com/actions/T1/1/x",
+				"actions": []map[string]string{
+					{
+						"action_id": tt.actionID,
+						"value":     `{"principalId":"jdoe","slackUserId":"U123","budgetAmount":100,"termsVersion":"2"}`,
+					},
+				},
+			})
+			body := url.Values{"payload": {string(payload)}}.Encode()
+			resp, err := Handler(context.TODO(), signedRequest("/slack/interactions", body))
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			if assert.NotNil(t, responded) {
+				assert.True(t, strings.Contains(responded.Text, tt.expText), "expected %q to contain %q", responded.Text, tt.expText)
+				assert.Equal(t, tt.expReplace, responded.ReplaceOriginal)
+			}
+			if tt.expCreate {
+				leaseSvc.AssertCalled(t, "CreateWithQuota", mock.MatchedBy(func(l *lease.Lease) bool {
+					return *l.PrincipalID == "jdoe" && *l.BudgetAmount == 100 && l.TermsAcceptance.Version == "2"
+				}), 0.0, (*lease.Quota)(nil))
+			} else {
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expNotify != "" {
+				slackSvcMock.AssertCalled(t, "PostMessage", mock.MatchedBy(func(m *slack.Message) bool {
+					return m.Channel == "U123" && strings.Contains(m.Text, tt.expNotify)
+				}))
+			} else {
+				slackSvcMock.AssertNotCalled(t, "PostMessage", mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// leaseRequest is a lease requested from Slack. When approvals are required it
+// is stored in the value of the approval buttons until an approver responds.
+type leaseRequest struct {
+	PrincipalID        string   `json:"principalId"`
+	SlackUserID        string   `json:"slackUserId"`
+	BudgetAmount       *float64 `json:"budgetAmount,omitempty"`
+	ExpiresOn          *int64   `json:"expiresOn,omitempty"`
+	NotificationEmails []string `json:"notificationEmails,omitempty"`
+	Notes              string   `json:"notes,omitempty"`
+	// TermsVersion is the version of the terms of use the requester accepted
+	TermsVersion string `json:"termsVersion,omitempty"`
+}
+
+// createLease leases a Ready account to the principal, with the same checks
+// as POST /leases
+func createLease(req *leaseRequest) (*lease.Lease, error) {
+	newLease := &lease.Lease{
+		PrincipalID:    &req.PrincipalID,
+		BudgetAmount:   req.BudgetAmount,
+		BudgetCurrency: &Settings.BudgetCurrency,
+		ExpiresOn:      req.ExpiresOn,
+	}
+	if len(req.NotificationEmails) > 0 {
+		newLease.BudgetNotificationEmails = &req.NotificationEmails
+	}
+	if req.Notes != "" {
+		newLease.Notes = &req.Notes
+	}
+	if req.TermsVersion != "" {
+		newLease.TermsAcceptance = &lease.TermsAcceptance{Version: req.TermsVersion}
+	}
+
+	user, err := requester(req.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+	allocator := lease.NewAllocator(lease.NewAllocatorInput{
+		LeaseSvc:              Services.LeaseService(),
+		AccountSvc:            Services.AccountService(),
+		DirectorySvc:          Services.DirectoryService(),
+		UsageSvc:              usageSvc,
+		PrincipalIDFormat:     PrincipalIDFormat,
+		PrincipalBudgetPeriod: Settings.PrincipalBudgetPeriod,
+	})
+	created, err := allocator.Request(newLease, user, false)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, errors.NewInternalServer("No Available accounts at this moment", nil)
+	}
+	return created, nil
+}
+
+// requester is the user a principal requests leases from Slack as.  Slack
+// users may only lease accounts for themselves, and are members of their
+// directory groups.
+func requester(principalID string) (*api.User, error) {
+	user := &api.User{
+		Username: principalID,
+		Role:     api.UserGroupName,
+	}
+	directoryUser, err := Services.DirectoryService().GetUser(principalID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryUser != nil {
+		user.Groups = directoryUser.Groups
+	}
+	return user, nil
+}
+
+// getActiveLease returns the principal's active lease, or nil if they don't have one
+func getActiveLease(principalID string) (*lease.Lease, error) {
+	leases, err := Services.LeaseService().List(&lease.Lease{
+		PrincipalID: &principalID,
+		Status:      lease.StatusActive.StatusPtr(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if leases == nil || len(*leases) == 0 {
+		return nil, nil
+	}
+	return &(*leases)[0], nil
+}
+
+// describeLease summarizes a lease for a Slack message
+func describeLease(l *lease.Lease) string {
+	description := fmt.Sprintf("Lease `%s` for account `%s`", *l.ID, *l.AccountID)
+	if l.BudgetAmount != nil {
+		currency := ""
+		if l.BudgetCurrency != nil {
+			currency = *l.BudgetCurrency
+		}
+		description += fmt.Sprintf(", budget %.2f %s", *l.BudgetAmount, currency)
+	}
+	if l.ExpiresOn != nil {
+		description += fmt.Sprintf(", expires <!date^%d^{date_short_pretty} {time}|%s>",
+			*l.ExpiresOn, time.Unix(*l.ExpiresOn, 0).UTC().Format(time.RFC1123))
+	}
+	if l.Notes != nil && *l.Notes != "" {
+		description += fmt.Sprintf("\n>%s", *l.Notes)
+	}
+	return description
+}
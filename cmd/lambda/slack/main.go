@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/slack"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
+)
+
+type slackConfiguration struct {
+	Debug                 string   `env:"DEBUG" envDefault:"false"`
+	SigningSecret         string   `env:"SLACK_SIGNING_SECRET" envDefault:""`
+	BotToken              string   `env:"SLACK_BOT_TOKEN" envDefault:""`
+	ApprovalChannel       string   `env:"SLACK_APPROVAL_CHANNEL" envDefault:""`
+	Approvers             []string `env:"SLACK_APPROVERS" envSeparator:","`
+	BudgetCurrency        string   `env:"SLACK_BUDGET_CURRENCY" envDefault:"USD"`
+	PrincipalBudgetPeriod string   `env:"PRINCIPAL_BUDGET_PERIOD" envDefault:"Weekly"`
+}
+
+var (
+	muxLambda *gorillamux.GorillaMuxAdapter
+	// Services handles the configuration of the AWS services
+	Services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	Settings *slackConfiguration
+	// PrincipalIDFormat normalizes and validates the principal IDs of
+	// requests
+	PrincipalIDFormat *principal.IDFormat
+	slackSvc          slack.Service
+	usageSvc          usage.DBer
+)
+
+func init() {
+	initConfig()
+
+	log.Println("Cold start; creating router for /slack")
+	slackRoutes := api.Routes{
+		api.Route{
+			Name:        "SlashCommand",
+			Method:      "POST",
+			Pattern:     "/slack/commands",
+			Queries:     api.EmptyQueryString,
+			HandlerFunc: SlashCommand,
+		},
+		api.Route{
+			Name:        "Interaction",
+			Method:      "POST",
+			Pattern:     "/slack/interactions",
+			Queries:     api.EmptyQueryString,
+			HandlerFunc: Interaction,
+		},
+	}
+	r := api.NewRouter(slackRoutes)
+	muxLambda = gorillamux.New(r)
+}
+
+// initConfig configures package-level variables
+// loaded from env vars.
+func initConfig() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	Settings = &slackConfiguration{}
+	if err := cfgBldr.Unmarshal(Settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	principalIDFormatInput := principal.NewIDFormatInput{}
+	if err := cfgBldr.Unmarshal(&principalIDFormatInput); err != nil {
+		log.Fatalf("Could not load principal ID configuration: %s", err.Error())
+	}
+	principalIDFormat, err := principal.NewIDFormat(principalIDFormatInput)
+	if err != nil {
+		log.Fatalf("Could not create the principal ID format: %s", err.Error())
+	}
+	PrincipalIDFormat = principalIDFormat
+
+	// load up the values into the various settings...
+	err = cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithLeaseService().
+		WithAccountService().
+		WithDirectoryService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	Services = svcBldr
+	slackSvc = slack.NewClient(slack.NewClientInput{Token: Settings.BotToken})
+}
+
+// Handler - Handle the lambda function
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Slack signs the raw request body, so it must be checked before routing
+	body := req.Body
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, nil
+		}
+		body = string(decoded)
+	}
+	err := slack.VerifyRequest(Settings.SigningSecret, req.Headers, body, time.Now())
+	if err != nil {
+		log.Printf("Rejected Slack request: %s", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusUnauthorized}, nil
+	}
+
+	return muxLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	usageService, err := usage.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize usage service: %s", err)
+	}
+	usageSvc = usageService
+
+	// Send Lambda requests to the router
+	lambda.Start(Handler)
+}
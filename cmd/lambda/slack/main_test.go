@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSigningSecret = "secret"
+
+// signedRequest creates a request signed the same way as Slack
+func signedRequest(path string, body string) events.APIGatewayProxyRequest {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSigningSecret))
+	_, _ = mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	return events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodPost,
+		Path:       path,
+		Headers: map[string]string{
+			"Content-Type":              "application/x-www-form-urlencoded",
+			"X-Slack-Request-Timestamp": timestamp,
+			"X-Slack-Signature":         "v0=" + hex.EncodeToString(mac.Sum(nil)),
+		},
+		Body: body,
+	}
+}
+
+func TestHandlerRejectsUnsignedRequests(t *testing.T) {
+	Settings.SigningSecret = testSigningSecret
+
+	req := signedRequest("/slack/commands", "text=status")
+	req.Headers["X-Slack-Signature"] = "v0=invalid"
+
+	resp, err := Handler(context.TODO(), req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrFloat64(f float64) *float64 {
+	return &f
+}
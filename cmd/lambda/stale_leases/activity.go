@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// activityMaxPages is the most pages of CloudTrail events checked in each
+// region.  Accounts with more events than that are busy, so they're assumed
+// to be in use rather than checking every event.
+const activityMaxPages = 10
+
+// newCloudTrail creates a CloudTrail client in the account, using its admin
+// role
+var newCloudTrail = cloudTrailForRole
+
+// cloudTrailEvent is the part of a CloudTrail event which identifies who
+// made the call
+type cloudTrailEvent struct {
+	UserIdentity struct {
+		SessionContext struct {
+			SessionIssuer struct {
+				Arn string `json:"arn"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+}
+
+// hasPrincipalActivity checks CloudTrail for calls made with the account's
+// principal role since the given time
+func hasPrincipalActivity(acct *account.Account, since time.Time) (bool, error) {
+	if acct.AdminRoleArn == nil || acct.PrincipalRoleArn == nil {
+		return false, errors.NewConflict("account", aws.StringValue(acct.ID), fmt.Errorf("account has no admin or principal role"))
+	}
+	principalRoleArn := acct.PrincipalRoleArn.String()
+
+	for _, region := range settings.ActivityRegions {
+		trail, err := newCloudTrail(acct.AdminRoleArn.String(), region)
+		if err != nil {
+			return false, errors.NewInternalServer("failed to create a CloudTrail client", err)
+		}
+
+		found := false
+		pages := 0
+		err = trail.LookupEventsPages(&cloudtrail.LookupEventsInput{
+			StartTime: aws.Time(since),
+		}, func(out *cloudtrail.LookupEventsOutput, lastPage bool) bool {
+			pages++
+			for _, e := range out.Events {
+				event := cloudTrailEvent{}
+				if json.Unmarshal([]byte(aws.StringValue(e.CloudTrailEvent)), &event) != nil {
+					continue
+				}
+				if event.UserIdentity.SessionContext.SessionIssuer.Arn == principalRoleArn {
+					found = true
+					return false
+				}
+			}
+			if pages >= activityMaxPages && !lastPage {
+				log.Printf("Account %s has more than %d pages of events in %s, assuming it's in use",
+					aws.StringValue(acct.ID), activityMaxPages, region)
+				found = true
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return false, errors.NewInternalServer(fmt.Sprintf("failed to look up CloudTrail events in %s", region), err)
+		}
+		if found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func cloudTrailForRole(roleArn string, region string) (cloudtrailiface.CloudTrailAPI, error) {
+	awsSession, err := common.SharedSession(settings.Region)
+	if err != nil {
+		return nil, err
+	}
+	tokenSvc := common.STS{Client: sts.New(awsSession)}
+	roleSession, err := tokenSvc.NewSession(awsSession, roleArn)
+	if err != nil {
+		return nil, err
+	}
+	return cloudtrail.New(roleSession, aws.NewConfig().WithRegion(region)), nil
+}
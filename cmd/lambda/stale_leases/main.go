@@ -0,0 +1,246 @@
+// Package main finds Active leases which haven't been used for a while,
+// notifies their principals, and optionally ends them after a grace period
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type configuration struct {
+	Debug  string `env:"DEBUG" envDefault:"false"`
+	Region string `env:"AWS_CURRENT_REGION" envDefault:"us-east-1"`
+	// IdleDays is how long a lease must go unused before it's stale
+	IdleDays int `env:"STALE_LEASE_IDLE_DAYS" envDefault:"7"`
+	// SpendThreshold is the spend over IdleDays below which a lease may be
+	// unused, as some resources cost a little even when nobody uses them
+	SpendThreshold float64 `env:"STALE_LEASE_SPEND_THRESHOLD" envDefault:"1"`
+	// GracePeriodDays is how long after its principal is notified a stale
+	// lease is ended, when AutoEnd is set
+	GracePeriodDays int  `env:"STALE_LEASE_GRACE_PERIOD_DAYS" envDefault:"3"`
+	AutoEnd         bool `env:"STALE_LEASE_AUTO_END" envDefault:"false"`
+	// ActivityRegions are the regions CloudTrail is checked for activity by
+	// the principal
+	ActivityRegions []string `env:"ALLOWED_REGIONS" envDefault:"us-east-1" envSeparator:","`
+}
+
+type staleLeasesResult struct {
+	Checked int `json:"checked"`
+	Flagged int `json:"flagged"`
+	Cleared int `json:"cleared"`
+	Ended   int `json:"ended"`
+	Failed  int `json:"failed"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+	usageSvc usage.DBer
+)
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithAccountService().
+		WithLeaseService().
+		WithNotificationService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) (*staleLeasesResult, error) {
+	result := &staleLeasesResult{}
+	now := time.Now()
+	idleSince := now.AddDate(0, 0, -settings.IdleDays)
+
+	// Leases younger than the idle period can't be stale yet
+	candidates := []*lease.Lease{}
+	err := services.LeaseService().ListPages(&lease.Lease{
+		Status: lease.StatusActive.StatusPtr(),
+	}, func(leases *lease.Leases) bool {
+		for i := range *leases {
+			l := (*leases)[i]
+			if l.CreatedOn != nil && *l.CreatedOn <= idleSince.Unix() {
+				candidates = append(candidates, &l)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	spend, err := spendSince(idleSince, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every lease before failing, so one failure doesn't hold up the rest
+	errs := []error{}
+	for _, l := range candidates {
+		result.Checked++
+		err := checkLease(l, spend[spendKey(aws.StringValue(l.AccountID), aws.StringValue(l.PrincipalID))], idleSince, now, result)
+		if err != nil {
+			log.Printf("Failed to check whether lease %s is stale: %s", aws.StringValue(l.ID), err)
+			result.Failed++
+			errs = append(errs, err)
+		}
+	}
+
+	log.Printf("Checked %d leases: flagged %d as stale, cleared %d, ended %d, failed %d",
+		result.Checked, result.Flagged, result.Cleared, result.Ended, result.Failed)
+	if len(errs) > 0 {
+		return result, errors.NewMultiError("failed to check for stale leases", errs)
+	}
+	return result, nil
+}
+
+// checkLease flags a lease which is unused, and ends it once its grace
+// period has passed.  Leases which are used again are no longer flagged.
+func checkLease(l *lease.Lease, spend float64, idleSince time.Time, now time.Time, result *staleLeasesResult) error {
+	idle := spend < settings.SpendThreshold
+	if idle {
+		acct, err := services.AccountService().Get(*l.AccountID)
+		if err != nil {
+			return err
+		}
+		active, err := hasPrincipalActivity(acct, idleSince)
+		if err != nil {
+			return err
+		}
+		idle = !active
+	}
+
+	if !idle {
+		if l.StaleSince == nil {
+			return nil
+		}
+		log.Printf("Lease %s is in use again", *l.ID)
+		_, err := services.LeaseService().RecordStale(*l.ID, false)
+		if err == nil {
+			result.Cleared++
+		}
+		return err
+	}
+
+	if l.StaleSince == nil {
+		return flagLease(l, now, result)
+	}
+
+	if settings.AutoEnd && now.Unix() >= endsOn(*l.StaleSince).Unix() {
+		log.Printf("Ending lease %s, which has been stale since %d", *l.ID, *l.StaleSince)
+		_, err := services.LeaseService().End(*l.ID, lease.StatusReasonStale)
+		if err == nil {
+			result.Ended++
+		}
+		return err
+	}
+	return nil
+}
+
+// flagLease records that the lease is stale, and notifies its principal
+func flagLease(l *lease.Lease, now time.Time, result *staleLeasesResult) error {
+	log.Printf("Lease %s for %s @ %s is stale", *l.ID, aws.StringValue(l.PrincipalID), aws.StringValue(l.AccountID))
+	_, err := services.LeaseService().RecordStale(*l.ID, true)
+	if err != nil {
+		return err
+	}
+	result.Flagged++
+
+	data := &notification.Data{
+		Lease: notification.Lease{
+			ID:             aws.StringValue(l.ID),
+			AccountID:      aws.StringValue(l.AccountID),
+			PrincipalID:    aws.StringValue(l.PrincipalID),
+			Status:         l.Status.String(),
+			BudgetAmount:   aws.Float64Value(l.BudgetAmount),
+			BudgetCurrency: aws.StringValue(l.BudgetCurrency),
+			ExpiresOn:      time.Unix(aws.Int64Value(l.ExpiresOn), 0),
+		},
+		IdleDays: settings.IdleDays,
+	}
+	if settings.AutoEnd {
+		data.EndsOn = endsOn(now.Unix())
+	}
+	var to []string
+	if l.BudgetNotificationEmails != nil {
+		to = *l.BudgetNotificationEmails
+	}
+	_, err = services.NotificationService().Send(notification.TemplateStaleLease, to, data)
+	return err
+}
+
+// endsOn is when a lease which was flagged as stale is ended
+func endsOn(staleSince int64) time.Time {
+	return time.Unix(staleSince, 0).AddDate(0, 0, settings.GracePeriodDays)
+}
+
+// spendSince sums the spend of each lease, by account and principal
+func spendSince(startDate time.Time, endDate time.Time) (map[string]float64, error) {
+	usageDB, err := usageService()
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to initialize the usage service", err)
+	}
+	records, err := usageDB.GetUsageByDateRange(startDate, endDate)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get usage", err)
+	}
+
+	spend := map[string]float64{}
+	for _, u := range records {
+		spend[spendKey(aws.StringValue(u.AccountID), aws.StringValue(u.PrincipalID))] += aws.Float64Value(u.CostAmount)
+	}
+	return spend, nil
+}
+
+func spendKey(accountID string, principalID string) string {
+	return accountID + "/" + principalID
+}
+
+func usageService() (usage.DBer, error) {
+	if usageSvc != nil {
+		return usageSvc, nil
+	}
+	usageService, err := usage.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	usageSvc = usageService
+	return usageSvc, nil
+}
+
+// Start the Lambda Handler
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("stale_leases", handler))
+}
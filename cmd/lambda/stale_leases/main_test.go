@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationmocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/testutil"
+	"github.com/Optum/dce/pkg/usage"
+	mockUsage "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const day = 24 * 60 * 60
+
+// fakeCloudTrail returns the same pages of events in every region
+type fakeCloudTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	pages [][]string
+}
+
+func (f *fakeCloudTrail) LookupEventsPages(input *cloudtrail.LookupEventsInput, fn func(*cloudtrail.LookupEventsOutput, bool) bool) error {
+	for i, page := range f.pages {
+		out := &cloudtrail.LookupEventsOutput{}
+		for _, principalArn := range page {
+			out.Events = append(out.Events, &cloudtrail.Event{
+				CloudTrailEvent: aws.String(fmt.Sprintf(`{"userIdentity": {"sessionContext": {"sessionIssuer": {"arn": %q}}}}`, principalArn)),
+			})
+		}
+		if !fn(out, i == len(f.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func withCloudTrail(pages ...[]string) {
+	newCloudTrail = func(roleArn string, region string) (cloudtrailiface.CloudTrailAPI, error) {
+		return &fakeCloudTrail{pages: pages}, nil
+	}
+}
+
+func TestHasPrincipalActivity(t *testing.T) {
+	settings.ActivityRegions = []string{"us-east-1", "us-west-2"}
+	acct := testutil.NewAccountBuilder().Leased().Build()
+	principal := acct.PrincipalRoleArn.String()
+	admin := acct.AdminRoleArn.String()
+
+	tests := []struct {
+		name      string
+		pages     [][]string
+		expActive bool
+	}{
+		{name: "no events"},
+		{name: "only admin events", pages: [][]string{{admin, admin}, {admin}}},
+		{name: "principal events", pages: [][]string{{admin}, {admin, principal}}, expActive: true},
+		{name: "too many events to check", pages: make([][]string, activityMaxPages+1), expActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withCloudTrail(tt.pages...)
+			active, err := hasPrincipalActivity(acct, time.Now())
+			require.Nil(t, err)
+			assert.Equal(t, tt.expActive, active)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	settings.IdleDays = 7
+	settings.SpendThreshold = 1
+	settings.GracePeriodDays = 3
+	settings.AutoEnd = true
+	settings.ActivityRegions = []string{"us-east-1"}
+	now := time.Now().Unix()
+
+	builder := func(id string, accountID string) *testutil.LeaseBuilder {
+		return testutil.NewLeaseBuilder().WithID(id).WithAccountID(accountID).
+			WithBudgetNotificationEmails("jdoe@example.com")
+	}
+	spending := builder("spending", "111111111111").Build()
+	idle := builder("idle", "222222222222").Build()
+	graceEnded := builder("grace-ended", "333333333333").Build()
+	graceEnded.StaleSince = aws.Int64(now - 4*day)
+	inGrace := builder("in-grace", "444444444444").Build()
+	inGrace.StaleSince = aws.Int64(now - day)
+	resumed := builder("resumed", "555555555555").Build()
+	resumed.StaleSince = aws.Int64(now - day)
+	young := builder("young", "666666666666").Build()
+	young.CreatedOn = aws.Int64(now - day)
+	leases := lease.Leases{*spending, *idle, *graceEnded, *inGrace, *resumed, *young}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("ListPages", &lease.Lease{Status: lease.StatusActive.StatusPtr()}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(func(*lease.Leases) bool)(&leases)
+		}).
+		Return(nil)
+	leaseSvc.On("RecordStale", "idle", true).Return(idle, nil)
+	leaseSvc.On("RecordStale", "resumed", false).Return(resumed, nil)
+	leaseSvc.On("End", "grace-ended", lease.StatusReasonStale).Return(graceEnded, nil)
+
+	// Only the principal of the resumed lease has used their account
+	accountSvc := &accountMocks.Servicer{}
+	accountSvc.On("Get", mock.Anything).Return(func(id string) *account.Account {
+		return testutil.NewAccountBuilder().WithID(id).Leased().Build()
+	}, nil)
+	newCloudTrail = func(roleArn string, region string) (cloudtrailiface.CloudTrailAPI, error) {
+		if roleArn == "arn:aws:iam::555555555555:role/AdminRole" {
+			return &fakeCloudTrail{pages: [][]string{{"arn:aws:iam::555555555555:role/DCEPrincipal"}}}, nil
+		}
+		return &fakeCloudTrail{}, nil
+	}
+
+	notificationSvc := &notificationmocks.Servicer{}
+	notificationSvc.On("Send", notification.TemplateStaleLease, []string{"jdoe@example.com"},
+		mock.MatchedBy(func(data *notification.Data) bool {
+			return data.Lease.ID == "idle" && data.IdleDays == 7 && !data.EndsOn.IsZero()
+		})).
+		Return(&notification.Email{}, nil)
+
+	usageSvcMock := &mockUsage.DBer{}
+	usageSvcMock.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return([]*usage.Usage{
+		{AccountID: aws.String("111111111111"), PrincipalID: aws.String(testutil.DefaultPrincipalID), CostAmount: aws.Float64(0.75)},
+		{AccountID: aws.String("111111111111"), PrincipalID: aws.String(testutil.DefaultPrincipalID), CostAmount: aws.Float64(0.75)},
+		{AccountID: aws.String("222222222222"), PrincipalID: aws.String(testutil.DefaultPrincipalID), CostAmount: aws.Float64(0.5)},
+	}, nil)
+	usageSvc = usageSvcMock
+
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+	svcBldr.Config.WithService(leaseSvc).WithService(accountSvc).WithService(notificationSvc)
+	_, err := svcBldr.Build()
+	require.Nil(t, err)
+	services = svcBldr
+
+	result, err := handler(context.TODO(), events.CloudWatchEvent{})
+	require.Nil(t, err)
+	assert.Equal(t, &staleLeasesResult{Checked: 5, Flagged: 1, Cleared: 1, Ended: 1}, result)
+	leaseSvc.AssertExpectations(t)
+	notificationSvc.AssertExpectations(t)
+	// Leases with spend aren't checked in CloudTrail
+	accountSvc.AssertNotCalled(t, "Get", "111111111111")
+}
@@ -0,0 +1,212 @@
+// Package main creates Jira or ServiceNow tickets for lifecycle events, read
+// from the account and lease table streams and from failed reset builds
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/diagnostics"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/ticket"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// codeBuildStateChange is the detail type of CodeBuild build state change events
+const codeBuildStateChange = "CodeBuild Build State Change"
+
+type configuration struct {
+	Debug          string `env:"DEBUG" envDefault:"false"`
+	AccountDB      string `env:"ACCOUNT_DB" envDefault:"Accounts"`
+	LeaseDB        string `env:"LEASE_DB" envDefault:"Leases"`
+	ResetBuildName string `env:"RESET_BUILD_NAME" envDefault:"ResetCodeBuild"`
+}
+
+var (
+	services *config.ServiceBuilder
+	// Settings - the configuration settings for the controller
+	settings *configuration
+)
+
+// ticketEvent is either a batch of DynamoDB stream records, or a CloudWatch event
+type ticketEvent struct {
+	Records    []events.DynamoDBEventRecord `json:"Records"`
+	DetailType string                       `json:"detail-type"`
+	Detail     json.RawMessage              `json:"detail"`
+}
+
+// buildStateChange is the part of a CodeBuild build state change event used by DCE
+type buildStateChange struct {
+	BuildStatus           string `json:"build-status"`
+	ProjectName           string `json:"project-name"`
+	BuildID               string `json:"build-id"`
+	AdditionalInformation struct {
+		Environment struct {
+			EnvironmentVariables []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"environment-variables"`
+		} `json:"environment"`
+	} `json:"additional-information"`
+}
+
+func init() {
+	cfgBldr := &config.ConfigurationBuilder{}
+	settings = &configuration{}
+	if err := cfgBldr.Unmarshal(settings); err != nil {
+		log.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	// load up the values into the various settings...
+	err := cfgBldr.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1").Build()
+	if err != nil {
+		log.Printf("Error: %+v", err)
+	}
+	svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+
+	_, err = svcBldr.
+		WithTicketService().
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	services = svcBldr
+}
+
+func main() {
+	lambda.StartHandler(diagnostics.LambdaHandler("ticketing", handler))
+}
+
+func handler(ctx context.Context, input ticketEvent) error {
+	tickets := []*ticket.Ticket{}
+	if input.DetailType == codeBuildStateChange {
+		t, err := resetFailedTicket(input.Detail)
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			tickets = append(tickets, t)
+		}
+	}
+	for _, record := range input.Records {
+		t := streamTicket(record)
+		if t != nil {
+			tickets = append(tickets, t)
+		}
+	}
+
+	// Submit every ticket before failing, so one failure doesn't hold up the rest
+	errs := []error{}
+	for _, t := range tickets {
+		log.Printf("Submitting %s ticket for account %s", t.Event, t.AccountID)
+		err := services.TicketService().Submit(t)
+		if err != nil {
+			log.Printf("Failed to submit %s ticket for account %s: %s", t.Event, t.AccountID, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewMultiError("failed to submit tickets", errs)
+	}
+	return nil
+}
+
+// resetFailedTicket creates a ticket for a failed reset build
+func resetFailedTicket(detail json.RawMessage) (*ticket.Ticket, error) {
+	build := &buildStateChange{}
+	err := json.Unmarshal(detail, build)
+	if err != nil {
+		return nil, errors.NewValidation("event", fmt.Errorf("invalid build state change: %s", err))
+	}
+	if build.BuildStatus != "FAILED" || build.ProjectName != settings.ResetBuildName {
+		return nil, nil
+	}
+
+	accountID := ""
+	for _, v := range build.AdditionalInformation.Environment.EnvironmentVariables {
+		if v.Name == "RESET_ACCOUNT" {
+			accountID = v.Value
+		}
+	}
+	if accountID == "" {
+		return nil, errors.NewValidation("event", fmt.Errorf("reset build %s has no RESET_ACCOUNT", build.BuildID))
+	}
+
+	return &ticket.Ticket{
+		Event:     ticket.EventResetFailed,
+		AccountID: accountID,
+		Details: map[string]string{
+			"build": build.BuildID,
+		},
+	}, nil
+}
+
+// streamTicket creates a ticket for an account or lease table change, or
+// returns nil if the change doesn't need one
+func streamTicket(record events.DynamoDBEventRecord) *ticket.Ticket {
+	if record.EventName == string(events.DynamoDBOperationTypeRemove) {
+		return nil
+	}
+	newImage := record.Change.NewImage
+	oldImage := record.Change.OldImage
+
+	switch tableName(record.EventSourceArn) {
+	case settings.AccountDB:
+		status := stringAttr(newImage, "AccountStatus")
+		if status != string(account.StatusOrphaned) || stringAttr(oldImage, "AccountStatus") == status {
+			return nil
+		}
+		return &ticket.Ticket{
+			Event:     ticket.EventAccountQuarantined,
+			AccountID: stringAttr(newImage, "Id"),
+		}
+	case settings.LeaseDB:
+		reason := stringAttr(newImage, "LeaseStatusReason")
+		if stringAttr(newImage, "LeaseStatus") != string(lease.StatusInactive) ||
+			(reason != string(lease.StatusReasonOverBudget) && reason != string(lease.StatusReasonOverPrincipalBudget) &&
+				reason != string(lease.StatusReasonOverCategoryBudget)) ||
+			stringAttr(oldImage, "LeaseStatus") == string(lease.StatusInactive) {
+			return nil
+		}
+		details := map[string]string{
+			"reason": reason,
+		}
+		if budget, ok := newImage["BudgetAmount"]; ok && budget.DataType() == events.DataTypeNumber {
+			details["budgetAmount"] = budget.Number() + " " + stringAttr(newImage, "BudgetCurrency")
+		}
+		return &ticket.Ticket{
+			Event:       ticket.EventLeaseOverBudget,
+			AccountID:   stringAttr(newImage, "AccountId"),
+			LeaseID:     stringAttr(newImage, "Id"),
+			PrincipalID: stringAttr(newImage, "PrincipalId"),
+			Details:     details,
+		}
+	}
+	return nil
+}
+
+// tableName gets the table name from a stream ARN, eg.
+// arn:aws:dynamodb:us-east-1:123456789012:table/Accounts/stream/2020-01-01T00:00:00.000
+func tableName(streamArn string) string {
+	parts := strings.Split(streamArn, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func stringAttr(image map[string]events.DynamoDBAttributeValue, name string) string {
+	value, ok := image[name]
+	if !ok || value.DataType() != events.DataTypeString {
+		return ""
+	}
+	return value.String()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/config"
+	"github.com/Optum/dce/pkg/ticket"
+	ticketMocks "github.com/Optum/dce/pkg/ticket/ticketiface/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const accountsStreamArn = "arn:aws:dynamodb:us-east-1:123456789012:table/Accounts/stream/2020-01-01T00:00:00.000"
+const leasesStreamArn = "arn:aws:dynamodb:us-east-1:123456789012:table/Leases/stream/2020-01-01T00:00:00.000"
+
+func streamEvent(streamArn string, oldImage string, newImage string) string {
+	return fmt.Sprintf(`{"Records": [{"eventName": "MODIFY", "eventSourceARN": "%s", "dynamodb": {"OldImage": %s, "NewImage": %s}}]}`,
+		streamArn, oldImage, newImage)
+}
+
+func TestTicketing(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     string
+		submitErr error
+		expTicket *ticket.Ticket
+		expErr    bool
+	}{
+		{
+			name: "should create a ticket when an account is orphaned",
+			event: streamEvent(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Leased"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Orphaned"}}`),
+			expTicket: &ticket.Ticket{
+				Event:     ticket.EventAccountQuarantined,
+				AccountID: "123456789012",
+			},
+		},
+		{
+			name: "should not create a ticket when an orphaned account is updated",
+			event: streamEvent(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Orphaned"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Orphaned"}}`),
+		},
+		{
+			name: "should create a ticket when a lease is over budget",
+			event: streamEvent(leasesStreamArn,
+				`{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"}, "LeaseStatus": {"S": "Active"}}`,
+				`{"Id": {"S": "abc"}, "AccountId": {"S": "123456789012"}, "PrincipalId": {"S": "jdoe"}, "LeaseStatus": {"S": "Inactive"},
+				  "LeaseStatusReason": {"S": "OverBudget"}, "BudgetAmount": {"N": "100"}, "BudgetCurrency": {"S": "USD"}}`),
+			expTicket: &ticket.Ticket{
+				Event:       ticket.EventLeaseOverBudget,
+				AccountID:   "123456789012",
+				LeaseID:     "abc",
+				PrincipalID: "jdoe",
+				Details: map[string]string{
+					"reason":       "OverBudget",
+					"budgetAmount": "100 USD",
+				},
+			},
+		},
+		{
+			name: "should not create a ticket when a lease expires",
+			event: streamEvent(leasesStreamArn,
+				`{"Id": {"S": "abc"}, "LeaseStatus": {"S": "Active"}}`,
+				`{"Id": {"S": "abc"}, "LeaseStatus": {"S": "Inactive"}, "LeaseStatusReason": {"S": "Expired"}}`),
+		},
+		{
+			name: "should create a ticket when a reset build fails",
+			event: `{"detail-type": "CodeBuild Build State Change", "source": "aws.codebuild", "detail": {
+				"build-status": "FAILED", "project-name": "ResetCodeBuild", "build-id": "arn:aws:codebuild:us-east-1:123456789012:build/ResetCodeBuild:1",
+				"additional-information": {"environment": {"environment-variables": [{"name": "RESET_ACCOUNT", "value": "123456789012", "type": "PLAINTEXT"}]}}}}`,
+			expTicket: &ticket.Ticket{
+				Event:     ticket.EventResetFailed,
+				AccountID: "123456789012",
+				Details: map[string]string{
+					"build": "arn:aws:codebuild:us-east-1:123456789012:build/ResetCodeBuild:1",
+				},
+			},
+		},
+		{
+			name: "should not create a ticket when a reset build succeeds",
+			event: `{"detail-type": "CodeBuild Build State Change", "source": "aws.codebuild", "detail": {
+				"build-status": "SUCCEEDED", "project-name": "ResetCodeBuild"}}`,
+		},
+		{
+			name: "should fail when the ticket can't be submitted",
+			event: streamEvent(accountsStreamArn,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Ready"}}`,
+				`{"Id": {"S": "123456789012"}, "AccountStatus": {"S": "Orphaned"}}`),
+			submitErr: fmt.Errorf("unavailable"),
+			expTicket: &ticket.Ticket{
+				Event:     ticket.EventAccountQuarantined,
+				AccountID: "123456789012",
+			},
+			expErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketSvc := ticketMocks.Servicer{}
+			ticketSvc.On("Submit", mock.AnythingOfType("*ticket.Ticket")).Return(tt.submitErr)
+
+			cfgBldr := &config.ConfigurationBuilder{}
+			svcBldr := &config.ServiceBuilder{Config: cfgBldr}
+			svcBldr.Config.WithService(&ticketSvc)
+			_, err := svcBldr.Build()
+			assert.Nil(t, err)
+			if err == nil {
+				services = svcBldr
+			}
+
+			input := ticketEvent{}
+			err = json.Unmarshal([]byte(tt.event), &input)
+			assert.Nil(t, err)
+
+			err = handler(context.TODO(), input)
+
+			assert.Equal(t, tt.expErr, err != nil, "unexpected error: %v", err)
+			if tt.expTicket != nil {
+				ticketSvc.AssertCalled(t, "Submit", tt.expTicket)
+			} else {
+				ticketSvc.AssertNotCalled(t, "Submit", mock.Anything)
+			}
+		})
+	}
+}
@@ -6,20 +6,31 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"log"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
 	"github.com/Optum/dce/pkg/awsiface"
 	"github.com/Optum/dce/pkg/budget"
 	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/db"
 	"github.com/Optum/dce/pkg/email"
 	multierrors "github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/notification/notificationiface"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
+	"github.com/Optum/dce/pkg/settings"
+	"github.com/Optum/dce/pkg/settings/settingsiface"
+	"github.com/Optum/dce/pkg/slack"
 	"github.com/Optum/dce/pkg/usage"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ses"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
 )
@@ -29,6 +40,15 @@ type leaseContext struct {
 	actualSpend float64
 }
 
+var (
+	// handlerInputOnce creates the services and settings of the handler on
+	// the first invocation, so later invocations of a warm Lambda share them
+	handlerInputOnce sync.Once
+	handlerInput     *lambdaHandlerInput
+	alertSvc         alertiface.Servicer
+	settingsSvc      settingsiface.Servicer
+)
+
 func main() {
 	lambda.Start(func(event interface{}) {
 		log.Printf("Initializing budget check")
@@ -40,51 +60,16 @@ func main() {
 		}
 		log.Printf("Checking budget for lease %s @ %s", lease.PrincipalID, lease.AccountID)
 
-		// Configure the DB service
-		dbSvc, err := db.NewFromEnv()
-		if err != nil {
-			log.Fatalf("Failed to configure DB service %s", err)
-		}
+		handlerInputOnce.Do(initHandlerInput)
+		input := *handlerInput
+		input.lease = lease
+		input.todaySpend = eventToTodaySpend(event)
+		applySettings(&input, settingsSvc)
+		// The budget service is configured with the lease account's Cost Explorer
+		input.budgetSvc = &budget.AWSBudgetService{}
 
-		// Configure the STS Token service
-		awsSession := session.Must(session.NewSession())
-		stsSvc := sts.New(awsSession)
-		tokenSvc := &common.STS{Client: stsSvc}
-
-		usageSvc, err := usage.NewFromEnv()
-		if err != nil {
-			log.Fatalf("Failed to configure Usage service %s", err)
-		}
-
-		// Configure the S3 service
-		s3Svc := &common.S3{
-			Client:  s3.New(awsSession),
-			Manager: s3manager.NewDownloader(awsSession),
-		}
-
-		err = lambdaHandler(&lambdaHandlerInput{
-			dbSvc:                                  dbSvc,
-			lease:                                  lease,
-			awsSession:                             awsSession,
-			tokenSvc:                               tokenSvc,
-			budgetSvc:                              &budget.AWSBudgetService{},
-			usageSvc:                               usageSvc,
-			sqsSvc:                                 sqs.New(awsSession),
-			snsSvc:                                 &common.SNS{Client: sns.New(awsSession)},
-			leaseLockedTopicArn:                    common.RequireEnv("LEASE_LOCKED_TOPIC_ARN"),
-			emailSvc:                               &email.SESEmailService{SES: ses.New(awsSession)},
-			s3Svc:                                  s3Svc,
-			budgetNotificationFromEmail:            common.RequireEnv("BUDGET_NOTIFICATION_FROM_EMAIL"),
-			budgetNotificationBCCEmails:            common.RequireEnvStringSlice("BUDGET_NOTIFICATION_BCC_EMAILS", ","),
-			budgetNotificationTemplatesBucket:      common.RequireEnv("BUDGET_NOTIFICATION_TEMPLATES_BUCKET"),
-			budgetNotificationTemplateHTMLKey:      common.RequireEnv("BUDGET_NOTIFICATION_TEMPLATE_HTML_KEY"),
-			budgetNotificationTemplateTextKey:      common.RequireEnv("BUDGET_NOTIFICATION_TEMPLATE_TEXT_KEY"),
-			budgetNotificationTemplateSubject:      common.RequireEnv("BUDGET_NOTIFICATION_TEMPLATE_SUBJECT"),
-			budgetNotificationThresholdPercentiles: common.RequireEnvFloatSlice("BUDGET_NOTIFICATION_THRESHOLD_PERCENTILES", ","),
-			principalBudgetAmount:                  common.RequireEnvFloat("PRINCIPAL_BUDGET_AMOUNT"),
-			principalBudgetPeriod:                  common.RequireEnv("PRINCIPAL_BUDGET_PERIOD"),
-			usageTTL:                               common.RequireEnvInt("USAGE_TTL"),
-		})
+		err = lambdaHandler(&input)
+		alertBudgetEnforcement(alertSvc, lease, err)
 		if err != nil {
 			log.Fatalf("Failed check budget: %s", err)
 		}
@@ -93,6 +78,142 @@ func main() {
 	})
 }
 
+// initHandlerInput configures the services and settings shared by every
+// invocation of the handler
+func initHandlerInput() {
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
+	if err != nil {
+		log.Fatalf("Failed to create AWS session %s", err)
+	}
+
+	// Configure the DB service
+	dbSvc, err := db.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure DB service %s", err)
+	}
+
+	// Configure the STS Token service
+	tokenSvc := &common.STS{Client: sts.New(awsSession)}
+
+	usageSvc, err := usage.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure Usage service %s", err)
+	}
+
+	// Configure the S3 service
+	s3Svc := &common.S3{
+		Client:  s3.New(awsSession, common.EndpointConfig("S3")),
+		Manager: s3manager.NewDownloaderWithClient(s3.New(awsSession, common.EndpointConfig("S3"))),
+	}
+
+	emailSvc := &email.SESEmailService{SES: ses.New(awsSession, common.EndpointConfig("SES"))}
+	notificationSvc, err := notification.NewFromEnv(s3Svc, emailSvc)
+	if err != nil {
+		log.Fatalf("Failed to configure Notification service %s", err)
+	}
+
+	alertSvc, err = alert.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure Alert service %s", err)
+	}
+
+	// Budget notifications are also sent to the channels of the matching
+	// notification routing rules
+	router, err := routing.NewFromEnv(dbSvc.Client, ssm.New(awsSession), emailSvc, alertSvc)
+	if err != nil {
+		log.Fatalf("Failed to configure Routing service %s", err)
+	}
+
+	settingsSvc, err = settings.NewFromEnv(dbSvc.Client)
+	if err != nil {
+		log.Fatalf("Failed to configure Settings service %s", err)
+	}
+
+	// Budget notifications are also sent as Slack messages, when a bot token is configured
+	var slackSvc slack.Service
+	slackBotToken := common.GetEnv("SLACK_BOT_TOKEN", "")
+	if slackBotToken != "" {
+		slackSvc = slack.NewClient(slack.NewClientInput{Token: slackBotToken})
+	}
+
+	currency, err := budget.NewCurrencyConverterFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure budget currency %s", err)
+	}
+
+	budgetCategories, err := budget.NewCategoriesFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure budget categories %s", err)
+	}
+
+	handlerInput = &lambdaHandlerInput{
+		dbSvc:                                  dbSvc,
+		currency:                               currency,
+		budgetCategories:                       budgetCategories,
+		awsSession:                             awsSession,
+		tokenSvc:                               tokenSvc,
+		usageSvc:                               usageSvc,
+		sqsSvc:                                 sqs.New(awsSession, common.EndpointConfig("SQS")),
+		snsSvc:                                 &common.SNS{Client: sns.New(awsSession, common.EndpointConfig("SNS"))},
+		leaseLockedTopicArn:                    common.RequireEnv("LEASE_LOCKED_TOPIC_ARN"),
+		notificationSvc:                        notificationSvc,
+		slackSvc:                               slackSvc,
+		router:                                 router,
+		budgetNotificationThresholdPercentiles: common.RequireEnvFloatSlice("BUDGET_NOTIFICATION_THRESHOLD_PERCENTILES", ","),
+		principalBudgetAmount:                  common.RequireEnvFloat("PRINCIPAL_BUDGET_AMOUNT"),
+		principalBudgetPeriod:                  common.RequireEnv("PRINCIPAL_BUDGET_PERIOD"),
+		usageTTL:                               common.RequireEnvInt("USAGE_TTL"),
+	}
+	if usageSvc.AggregatesEnabled() {
+		handlerInput.usageAggregator = usageSvc
+	}
+}
+
+// applySettings overrides the principal budget and budget notification
+// thresholds the Lambda was deployed with, when operators have changed them,
+// and stops sending budget notifications to Slack when its flag is off.
+// Budgets are still checked with the deployed settings when the settings
+// can't be read.
+func applySettings(input *lambdaHandlerInput, settingsSvc settingsiface.Servicer) {
+	current, err := settingsSvc.Current()
+	if err != nil {
+		log.Printf("Failed to read the settings, checking the budget with the deployed settings: %s", err)
+		return
+	}
+	if current.PrincipalBudgetAmount > 0 {
+		input.principalBudgetAmount = current.PrincipalBudgetAmount
+	}
+	if len(current.BudgetNotificationThresholdPercentiles) > 0 {
+		input.budgetNotificationThresholdPercentiles = current.BudgetNotificationThresholdPercentiles
+	}
+	if !current.Flag(settings.FlagSlackBudgetNotifications, true) {
+		input.slackSvc = nil
+	}
+}
+
+// alertBudgetEnforcement opens an incident for the lease account when its
+// budget check failed, and resolves it once a check succeeds
+func alertBudgetEnforcement(alertSvc alertiface.Servicer, lease *db.Lease, checkErr error) {
+	var err error
+	if checkErr != nil {
+		err = alertSvc.Trigger(&alert.Alert{
+			Type:      alert.TypeBudgetEnforcementFailed,
+			AccountID: lease.AccountID,
+			Summary:   fmt.Sprintf("DCE failed to check the budget of the lease for %s on account %s", lease.PrincipalID, lease.AccountID),
+			Details: map[string]string{
+				"leaseId":     lease.ID,
+				"principalId": lease.PrincipalID,
+				"error":       checkErr.Error(),
+			},
+		})
+	} else {
+		err = alertSvc.Resolve(alert.TypeBudgetEnforcementFailed, lease.AccountID)
+	}
+	if err != nil {
+		log.Printf("Failed to update the budget enforcement alert for account %s: %s", lease.AccountID, err)
+	}
+}
+
 func eventToLease(leaseEvent interface{}) (*db.Lease, error) {
 	// Convert the interface to JSON
 	mapJSON, err := json.Marshal(leaseEvent)
@@ -109,24 +230,40 @@ func eventToLease(leaseEvent interface{}) (*db.Lease, error) {
 	return &lease, nil
 }
 
+// eventToTodaySpend gets the spend of the lease account for the current date,
+// when it was collected by the fan_out_update_lease_status Lambda
+func eventToTodaySpend(leaseEvent interface{}) *float64 {
+	mapJSON, err := json.Marshal(leaseEvent)
+	if err != nil {
+		return nil
+	}
+	var spend struct {
+		TodaySpend *float64 `json:"todaySpend"`
+	}
+	err = json.Unmarshal(mapJSON, &spend)
+	if err != nil {
+		return nil
+	}
+	return spend.TodaySpend
+}
+
 type lambdaHandlerInput struct {
 	dbSvc                                  db.DBer
 	lease                                  *db.Lease
+	todaySpend                             *float64
 	awsSession                             awsiface.AwsSession
 	tokenSvc                               common.TokenService
 	budgetSvc                              budget.Service
+	currency                               budget.CurrencyConverter
+	budgetCategories                       budget.Categories
 	usageSvc                               usage.DBer
+	usageAggregator                        usage.Aggregator
 	snsSvc                                 common.Notificationer
 	leaseLockedTopicArn                    string
 	sqsSvc                                 awsiface.SQSAPI
-	emailSvc                               email.Service
-	s3Svc                                  common.Storager
-	budgetNotificationFromEmail            string
-	budgetNotificationBCCEmails            []string
-	budgetNotificationTemplatesBucket      string
-	budgetNotificationTemplateHTMLKey      string
-	budgetNotificationTemplateTextKey      string
-	budgetNotificationTemplateSubject      string
+	notificationSvc                        notificationiface.Servicer
+	slackSvc                               slack.Service
+	router                                 routingiface.Servicer
 	budgetNotificationThresholdPercentiles []float64
 	principalBudgetAmount                  float64
 	principalBudgetPeriod                  string
@@ -148,33 +285,71 @@ func lambdaHandler(input *lambdaHandlerInput) error {
 			input.lease.AccountID, input.lease.PrincipalID)
 	}
 
-	// Calculate actual spend for the lease
-	actualLeaseSpend, err := calculateLeaseSpend(&calculateSpendInput{
-		account:               account,
-		lease:                 input.lease,
-		tokenSvc:              input.tokenSvc,
-		budgetSvc:             input.budgetSvc,
-		usageSvc:              input.usageSvc,
-		awsSession:            input.awsSession,
-		principalBudgetPeriod: input.principalBudgetPeriod,
-		usageTTL:              input.usageTTL,
-	})
-	if err != nil {
-		return errors.Wrapf(err, "Failed to calculate spend for lease %s", leaseLogID)
-	}
-
-	// Calculate actual spend for the principal
-	actualPrincipalSpend, err := calculatePrincipalSpend(&calculateSpendInput{
-		account:               account,
-		lease:                 input.lease,
-		tokenSvc:              input.tokenSvc,
-		budgetSvc:             input.budgetSvc,
-		usageSvc:              input.usageSvc,
-		awsSession:            input.awsSession,
-		principalBudgetPeriod: input.principalBudgetPeriod,
-	})
-	if err != nil {
-		return errors.Wrapf(err, "Failed to calculate spend for principal %s", leaseLogID)
+	var actualLeaseSpend, actualPrincipalSpend float64
+	if input.usageAggregator != nil {
+		spend, err := calculateAggregatedSpend(&calculateSpendInput{
+			account:               account,
+			lease:                 input.lease,
+			tokenSvc:              input.tokenSvc,
+			budgetSvc:             input.budgetSvc,
+			currency:              input.currency,
+			usageAggregator:       input.usageAggregator,
+			awsSession:            input.awsSession,
+			principalBudgetPeriod: input.principalBudgetPeriod,
+			usageTTL:              input.usageTTL,
+			todaySpend:            input.todaySpend,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to calculate spend for lease %s", leaseLogID)
+		}
+		actualLeaseSpend, actualPrincipalSpend = spend.Lease, spend.Principal
+	} else {
+		// Calculate actual spend for the lease
+		actualLeaseSpend, err = calculateLeaseSpend(&calculateSpendInput{
+			account:               account,
+			lease:                 input.lease,
+			tokenSvc:              input.tokenSvc,
+			budgetSvc:             input.budgetSvc,
+			currency:              input.currency,
+			usageSvc:              input.usageSvc,
+			awsSession:            input.awsSession,
+			principalBudgetPeriod: input.principalBudgetPeriod,
+			usageTTL:              input.usageTTL,
+			todaySpend:            input.todaySpend,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to calculate spend for lease %s", leaseLogID)
+		}
+
+		// Calculate actual spend for the principal
+		actualPrincipalSpend, err = calculatePrincipalSpend(&calculateSpendInput{
+			account:               account,
+			lease:                 input.lease,
+			tokenSvc:              input.tokenSvc,
+			budgetSvc:             input.budgetSvc,
+			usageSvc:              input.usageSvc,
+			awsSession:            input.awsSession,
+			principalBudgetPeriod: input.principalBudgetPeriod,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to calculate spend for principal %s", leaseLogID)
+		}
+	}
+
+	// Calculate the spend of the lease in the categories it has budgets for
+	var categorySpend map[string]float64
+	if len(input.lease.CategoryBudgets) > 0 {
+		categorySpend, err = calculateCategorySpend(&calculateSpendInput{
+			account:    account,
+			lease:      input.lease,
+			tokenSvc:   input.tokenSvc,
+			budgetSvc:  input.budgetSvc,
+			currency:   input.currency,
+			awsSession: input.awsSession,
+		}, input.budgetCategories)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to calculate category spend for lease %s", leaseLogID)
+		}
 	}
 
 	// Defer errors until the end, so we can continue on error
@@ -182,10 +357,16 @@ func lambdaHandler(input *lambdaHandlerInput) error {
 	currentTimeEpoch := time.Now().Unix()
 
 	expired, reason := isLeaseExpired(input.lease, &leaseContext{currentTimeEpoch, actualLeaseSpend}, actualPrincipalSpend, input.principalBudgetAmount)
+	if !expired {
+		expired, reason = isOverCategoryBudget(input.lease, categorySpend)
+	}
 
 	if expired {
 		// Update the lease status with the inactive status and current end time.
 		input.lease.LeaseStatus = db.Inactive
+		// The budget overruns alarm counts this message for OverBudget,
+		// OverPrincipalBudget and OverCategoryBudget reasons. See
+		// monitoring.BudgetOverrunFilterPattern
 		log.Printf("%s.  Updating lease as ready to be reclaimed...", reason)
 		err := handleLeaseExpire(input, prevLeaseStatus, reason)
 		if err != nil {
@@ -196,14 +377,11 @@ func lambdaHandler(input *lambdaHandlerInput) error {
 	// Send notification emails, for budget thresholds
 	err = sendBudgetNotificationEmail(&sendBudgetNotificationEmailInput{
 		lease:                                  input.lease,
-		emailSvc:                               input.emailSvc,
-		s3Svc:                                  input.s3Svc,
-		budgetNotificationFromEmail:            input.budgetNotificationFromEmail,
-		budgetNotificationBCCEmails:            input.budgetNotificationBCCEmails,
-		budgetNotificationTemplatesBucket:      input.budgetNotificationTemplatesBucket,
-		budgetNotificationTemplateHTMLKey:      input.budgetNotificationTemplateHTMLKey,
-		budgetNotificationTemplateTextKey:      input.budgetNotificationTemplateTextKey,
-		budgetNotificationTemplateSubject:      input.budgetNotificationTemplateSubject,
+		account:                                account,
+		dbSvc:                                  input.dbSvc,
+		notificationSvc:                        input.notificationSvc,
+		slackSvc:                               input.slackSvc,
+		router:                                 input.router,
 		budgetNotificationThresholdPercentiles: input.budgetNotificationThresholdPercentiles,
 		actualLeaseSpend:                       actualLeaseSpend,
 		actualPrincipalSpend:                   actualPrincipalSpend,
@@ -214,11 +392,43 @@ func lambdaHandler(input *lambdaHandlerInput) error {
 		deferredErrors = append(deferredErrors, err)
 	}
 
+	// Send notification emails, for the thresholds of category budgets
+	categories := make([]string, 0, len(categorySpend))
+	for category := range categorySpend {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		err = sendCategoryBudgetNotification(&sendBudgetNotificationEmailInput{
+			lease:                                  input.lease,
+			account:                                account,
+			dbSvc:                                  input.dbSvc,
+			notificationSvc:                        input.notificationSvc,
+			slackSvc:                               input.slackSvc,
+			router:                                 input.router,
+			budgetNotificationThresholdPercentiles: input.budgetNotificationThresholdPercentiles,
+			actualLeaseSpend:                       categorySpend[category],
+		}, category)
+		if err != nil {
+			log.Printf("Failed to send the %s budget notification emails for lease %s @ %s: %s",
+				category, input.lease.PrincipalID, input.lease.AccountID, err)
+			deferredErrors = append(deferredErrors, err)
+		}
+	}
+
 	// Return deferred errors
 	if len(deferredErrors) > 0 {
 		return multierrors.NewMultiError("Budget check failed: ", deferredErrors)
 	}
 
+	// Record the check, so the fan_out_update_lease_status Lambda doesn't
+	// check the lease again until it's due.  If this fails, the lease is
+	// just checked again sooner.
+	_, err = input.dbSvc.RecordLeaseBudgetCheck(input.lease.AccountID, input.lease.PrincipalID, currentTimeEpoch)
+	if err != nil {
+		log.Printf("Failed to record the budget check of lease %s: %s", leaseLogID, err)
+	}
+
 	return nil
 }
 
@@ -237,6 +447,19 @@ func isLeaseExpired(lease *db.Lease, context *leaseContext, actualPrincipalSpend
 	return false, db.LeaseActive
 }
 
+// isOverCategoryBudget checks whether the lease's spend in a category is
+// over its category budget, when the category budget is enforced
+func isOverCategoryBudget(lease *db.Lease, categorySpend map[string]float64) (bool, db.LeaseStatusReason) {
+	for category, spend := range categorySpend {
+		categoryBudget, ok := lease.CategoryBudgets[category]
+		if ok && categoryBudget.Enforce && spend > categoryBudget.Amount {
+			log.Printf("Lease for %s @ %s is over its %s budget", lease.PrincipalID, lease.AccountID, category)
+			return true, db.LeaseOverCategoryBudget
+		}
+	}
+	return false, db.LeaseActive
+}
+
 // handleOverBudget handles the case where a lease is over budget:
 // - Sets Lease DB status to FinanceLocked
 // - Publish Lease to "lease-locked" SNS topic
@@ -1,20 +1,33 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Optum/dce/pkg/alert"
+	alertMocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
 	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/budget"
 	budgetMocks "github.com/Optum/dce/pkg/budget/mocks"
 	commonMocks "github.com/Optum/dce/pkg/common/mocks"
 	"github.com/Optum/dce/pkg/db"
 	dbMocks "github.com/Optum/dce/pkg/db/mocks"
 	"github.com/Optum/dce/pkg/email"
 	emailMocks "github.com/Optum/dce/pkg/email/mocks"
+	"github.com/Optum/dce/pkg/notification"
+	notificationMocks "github.com/Optum/dce/pkg/notification/notificationiface/mocks"
+	"github.com/Optum/dce/pkg/routing"
+	routingMocks "github.com/Optum/dce/pkg/routing/routingiface/mocks"
+	"github.com/Optum/dce/pkg/settings"
+	settingsMocks "github.com/Optum/dce/pkg/settings/settingsiface/mocks"
+	"github.com/Optum/dce/pkg/slack"
+	slackMocks "github.com/Optum/dce/pkg/slack/mocks"
 	"github.com/Optum/dce/pkg/usage"
 	usageMocks "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -66,6 +79,8 @@ has exceeded its budget of $100. Actual spend is $150
 	type checkBudgetTestInput struct {
 		budgetAmount                  float64
 		actualSpend                   float64
+		thresholdsSent                []float64
+		alertSuppression              *db.LeaseAlertSuppression
 		leaseStatus                   db.LeaseStatus
 		expectedLeaseStatusTransition db.LeaseStatus
 		shouldTransitionLeaseStatus   bool
@@ -73,6 +88,9 @@ has exceeded its budget of $100. Actual spend is $150
 		shouldSNS                     bool
 		shouldSQSReset                bool
 		shouldSendEmail               bool
+		shouldSendSlack               bool
+		aggregated                    bool
+		collectedSpend                bool
 		expectedEmailSubject          string
 		expectedEmailBodyHTML         string
 		expectedEmailBodyText         string
@@ -87,7 +105,21 @@ has exceeded its budget of $100. Actual spend is $150
 		snsSvc := &commonMocks.Notificationer{}
 		sqsSvc := &awsMocks.SQSAPI{}
 		emailSvc := &emailMocks.Service{}
+		slackSvc := &slackMocks.Service{}
 		s3Svc := &commonMocks.Storager{}
+		subjectTemplate, err := json.Marshal(map[string]string{"BudgetThreshold/subject": emailTemplateSubject})
+		require.Nil(t, err)
+		notificationSvc, err := notification.NewService(notification.NewServiceInput{
+			FromEmail:       "from@example.com",
+			BCCEmails:       []string{"bcc@example.com"},
+			Templates:       string(subjectTemplate),
+			TemplatesBucket: "artifacts-bucket",
+			TemplatesPrefix: "templates",
+			TemplateObjects: []string{"BudgetThreshold/html", "BudgetThreshold/text"},
+			Storage:         s3Svc,
+			EmailSvc:        emailSvc,
+		})
+		require.Nil(t, err)
 		input := &lambdaHandlerInput{
 			dbSvc: dbSvc,
 			lease: &db.Lease{
@@ -99,6 +131,8 @@ has exceeded its budget of $100. Actual spend is $150
 				BudgetNotificationEmails: []string{"recipA@example.com", "recipB@example.com"},
 				LeaseStatusModifiedOn:    time.Unix(100, 0).Unix(),
 				ExpiresOn:                time.Now().AddDate(0, 0, +1000).Unix(), //Make sure it expires in the distant future as we aren't testing that
+				BudgetThresholdsSent:     test.thresholdsSent,
+				AlertSuppression:         test.alertSuppression,
 			},
 			awsSession:                             &awsMocks.AwsSession{},
 			tokenSvc:                               tokenSvc,
@@ -107,14 +141,7 @@ has exceeded its budget of $100. Actual spend is $150
 			snsSvc:                                 snsSvc,
 			leaseLockedTopicArn:                    "lease-locked",
 			sqsSvc:                                 sqsSvc,
-			emailSvc:                               emailSvc,
-			s3Svc:                                  s3Svc,
-			budgetNotificationFromEmail:            "from@example.com",
-			budgetNotificationBCCEmails:            []string{"bcc@example.com"},
-			budgetNotificationTemplatesBucket:      "artifacts-bucket",
-			budgetNotificationTemplateHTMLKey:      "templates/html.tmpl",
-			budgetNotificationTemplateTextKey:      "templates/text.tmpl",
-			budgetNotificationTemplateSubject:      emailTemplateSubject,
+			notificationSvc:                        notificationSvc,
 			budgetNotificationThresholdPercentiles: []float64{75, 100},
 			principalBudgetAmount:                  1000,
 			usageTTL:                               3600,
@@ -126,21 +153,26 @@ has exceeded its budget of $100. Actual spend is $150
 				AdminRoleArn: "mock:admin:role:arn",
 			}, nil)
 
-		// Mock the TokenService
-		// Should assume Account.AdminRoleArn
-		tokenSvc.MockNewSession("mock:admin:role:arn")
-
-		// Mock the BudgetService, actualSpend=150 (over budget)
-		// Should use assumed role
-		budgetSvc.On("SetCostExplorer", mock.Anything)
 		currentTime := time.Now()
 		startDate := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, time.UTC)
 		usageEndDate := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 23, 59, 59, 0, time.UTC)
 		endDate := startDate.AddDate(0, 0, 1)
-		budgetSvc.On("CalculateTotalSpend",
-			startDate,
-			endDate,
-		).Return(test.actualSpend, nil)
+		if test.collectedSpend {
+			// The spend was collected by the fan out lambda
+			input.todaySpend = &test.actualSpend
+		} else {
+			// Mock the TokenService
+			// Should assume Account.AdminRoleArn
+			tokenSvc.MockNewSession("mock:admin:role:arn")
+
+			// Mock the BudgetService, actualSpend=150 (over budget)
+			// Should use assumed role
+			budgetSvc.On("SetCostExplorer", mock.Anything)
+			budgetSvc.On("CalculateTotalSpend",
+				startDate,
+				endDate,
+			).Return(test.actualSpend, nil)
+		}
 
 		// Expected Usage DB entry
 		inputUsage, err := usage.NewUsage(
@@ -161,6 +193,16 @@ has exceeded its budget of $100. Actual spend is $150
 		usageSvc.On("GetUsageByDateRange", budgetStartTime, usageEndDate.AddDate(0, 0, -1)).Return(nil, nil)
 		usageSvc.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return(nil, nil)
 
+		// Should read the spend from the aggregates, instead of the daily records
+		usageAggregator := &usageMocks.Aggregator{}
+		if test.aggregated {
+			input.usageAggregator = usageAggregator
+			usageAggregator.On("PutUsageAggregated", *inputUsage, usage.AggregatePeriods{
+				Lease:     budgetStartTime,
+				Principal: getBeginningOfCurrentBillingPeriod(input.principalBudgetPeriod),
+			}).Return(&usage.Spend{Lease: test.actualSpend, Principal: test.actualSpend}, nil)
+		}
+
 		// Should transition from "Active" --> "FinanceLock"
 		if test.shouldTransitionLeaseStatus {
 			dbSvc.On("TransitionLeaseStatus",
@@ -179,9 +221,9 @@ has exceeded its budget of $100. Actual spend is $150
 		// Should send a notification email
 		if test.shouldSendEmail {
 			// Mock templates in S3
-			s3Svc.On("GetObject", "artifacts-bucket", "templates/text.tmpl").
+			s3Svc.On("GetObject", "artifacts-bucket", "templates/BudgetThreshold/text.tmpl").
 				Return(emailTemplateText, nil)
-			s3Svc.On("GetObject", "artifacts-bucket", "templates/html.tmpl").
+			s3Svc.On("GetObject", "artifacts-bucket", "templates/BudgetThreshold/html.tmpl").
 				Return(emailTemplateHTML, nil)
 
 			emailSvc.On("SendEmail", &email.SendEmailInput{
@@ -192,6 +234,29 @@ has exceeded its budget of $100. Actual spend is $150
 				BodyHTML:     test.expectedEmailBodyHTML,
 				BodyText:     test.expectedEmailBodyText,
 			}).Return(nil)
+
+			// Should record the notification was sent, so it isn't sent again
+			dbSvc.On("RecordBudgetThresholdSent", "1234567890", "test-user", test.budgetAmount, mock.Anything).
+				Return(input.lease, nil)
+		}
+
+		// Should send the notification to Slack users with the lease's notification emails
+		if test.shouldSendSlack {
+			input.slackSvc = slackSvc
+			slackSvc.On("LookupUserByEmail", "recipA@example.com").
+				Return(&slack.User{ID: "U123"}, nil)
+			slackSvc.On("LookupUserByEmail", "recipB@example.com").
+				Return(nil, &slack.APIError{Method: "users.lookupByEmail", Code: "users_not_found"})
+			slackSvc.On("PostMessage", &slack.Message{
+				Channel: "U123",
+				Text:    test.expectedEmailSubject + "\n\n" + test.expectedEmailBodyText,
+			}).Return(nil)
+		}
+
+		// Should record the lease was checked, when the check succeeds
+		if test.expectedError == "" {
+			dbSvc.On("RecordLeaseBudgetCheck", "1234567890", "test-user", mock.AnythingOfType("int64")).
+				Return(input.lease, nil)
 		}
 
 		// Call Lambda handler
@@ -209,6 +274,15 @@ has exceeded its budget of $100. Actual spend is $150
 		snsSvc.AssertExpectations(t)
 		sqsSvc.AssertExpectations(t)
 		emailSvc.AssertExpectations(t)
+		slackSvc.AssertExpectations(t)
+		usageAggregator.AssertExpectations(t)
+		if test.collectedSpend {
+			budgetSvc.AssertNotCalled(t, "CalculateTotalSpend", mock.Anything, mock.Anything)
+		}
+		if test.aggregated {
+			usageSvc.AssertNotCalled(t, "PutUsage", mock.Anything)
+			usageSvc.AssertNotCalled(t, "GetUsageByDateRange", mock.Anything, mock.Anything)
+		}
 	}
 
 	t.Run("Scenario: Over Budget Lease", func(t *testing.T) {
@@ -231,6 +305,21 @@ has exceeded its budget of $100. Actual spend is $150
 		})
 	})
 
+	t.Run("Scenario: Over Threshold Lease with suppressed alerts", func(t *testing.T) {
+		checkBudgetTest(&checkBudgetTestInput{
+			// >75% of budget
+			budgetAmount: 100,
+			actualSpend:  76,
+			leaseStatus:  db.Active,
+			alertSuppression: &db.LeaseAlertSuppression{
+				Until:  time.Now().Add(time.Hour).Unix(),
+				Reason: "load test",
+			},
+			// Should not send a notification, nor record it as sent
+			shouldSendEmail: false,
+		})
+	})
+
 	t.Run("Scenario: Over Threshold Lease", func(t *testing.T) {
 		checkBudgetTest(&checkBudgetTestInput{
 			// >75% of budget
@@ -261,6 +350,75 @@ Actual spend is $76
 		})
 	})
 
+	t.Run("Scenario: Over Budget Lease with Slack notifications", func(t *testing.T) {
+		checkBudgetTest(&checkBudgetTestInput{
+			budgetAmount:                  100,
+			actualSpend:                   150,
+			leaseStatus:                   db.Active,
+			expectedLeaseStatusTransition: db.Inactive,
+			shouldTransitionLeaseStatus:   true,
+			shouldSNS:                     true,
+			shouldSQSReset:                true,
+			shouldSendEmail:               true,
+			// Should send a Slack message to the users who can be found by email
+			shouldSendSlack:       true,
+			expectedEmailSubject:  expectedOverBudgetText,
+			expectedEmailBodyHTML: expectedOverBudgetEmailHTML,
+			expectedEmailBodyText: expectedOverBudgetEmailText,
+		})
+	})
+
+	t.Run("Scenario: Over Budget Lease with usage aggregates", func(t *testing.T) {
+		checkBudgetTest(&checkBudgetTestInput{
+			budgetAmount:                  100,
+			actualSpend:                   150,
+			leaseStatus:                   db.Active,
+			expectedLeaseStatusTransition: db.Inactive,
+			shouldTransitionLeaseStatus:   true,
+			shouldSNS:                     true,
+			shouldSQSReset:                true,
+			shouldSendEmail:               true,
+			// Should get the spend from the usage aggregates
+			aggregated:            true,
+			expectedEmailSubject:  expectedOverBudgetText,
+			expectedEmailBodyHTML: expectedOverBudgetEmailHTML,
+			expectedEmailBodyText: expectedOverBudgetEmailText,
+		})
+	})
+
+	t.Run("Scenario: Over Budget Lease with collected spend", func(t *testing.T) {
+		checkBudgetTest(&checkBudgetTestInput{
+			budgetAmount:                  100,
+			actualSpend:                   150,
+			leaseStatus:                   db.Active,
+			expectedLeaseStatusTransition: db.Inactive,
+			shouldTransitionLeaseStatus:   true,
+			shouldSNS:                     true,
+			shouldSQSReset:                true,
+			shouldSendEmail:               true,
+			// Should not call Cost Explorer in the lease account
+			collectedSpend:        true,
+			expectedEmailSubject:  expectedOverBudgetText,
+			expectedEmailBodyHTML: expectedOverBudgetEmailHTML,
+			expectedEmailBodyText: expectedOverBudgetEmailText,
+		})
+	})
+
+	t.Run("Scenario: Over Threshold Lease already notified", func(t *testing.T) {
+		checkBudgetTest(&checkBudgetTestInput{
+			// >75% of budget
+			budgetAmount:   100,
+			actualSpend:    76,
+			thresholdsSent: []float64{75},
+			leaseStatus:    db.Active,
+			// Should not notify the same threshold again
+			shouldTransitionLeaseStatus: false,
+			shouldSNS:                   false,
+			shouldSQSReset:              false,
+			shouldSendEmail:             false,
+		})
+	})
+
 	t.Run("Scenario: Under Budget Lease", func(t *testing.T) {
 		checkBudgetTest(&checkBudgetTestInput{
 			// <75% of budget
@@ -376,6 +534,254 @@ func Test_isLeaseExpired(t *testing.T) {
 	}
 }
 
+func TestAlertBudgetEnforcement(t *testing.T) {
+	lease := &db.Lease{
+		ID:          "abc",
+		AccountID:   "123456789012",
+		PrincipalID: "jdoe",
+	}
+
+	t.Run("should trigger an alert for the account when the check fails", func(t *testing.T) {
+		alertSvc := &alertMocks.Servicer{}
+		alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+
+		alertBudgetEnforcement(alertSvc, lease, errors.New("budget check failed"))
+
+		alertSvc.AssertCalled(t, "Trigger", mock.MatchedBy(func(a *alert.Alert) bool {
+			return a.Type == alert.TypeBudgetEnforcementFailed &&
+				a.AccountID == "123456789012" &&
+				a.Details["error"] == "budget check failed"
+		}))
+		alertSvc.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should resolve the alert for the account when the check succeeds", func(t *testing.T) {
+		alertSvc := &alertMocks.Servicer{}
+		alertSvc.On("Resolve", alert.TypeBudgetEnforcementFailed, "123456789012").Return(nil)
+
+		alertBudgetEnforcement(alertSvc, lease, nil)
+
+		alertSvc.AssertExpectations(t)
+		alertSvc.AssertNotCalled(t, "Trigger", mock.Anything)
+	})
+}
+
+func TestApplySettings(t *testing.T) {
+	deployed := lambdaHandlerInput{
+		principalBudgetAmount:                  1000,
+		budgetNotificationThresholdPercentiles: []float64{75, 100},
+	}
+
+	t.Run("should override the deployed settings", func(t *testing.T) {
+		settingsSvc := &settingsMocks.Servicer{}
+		settingsSvc.On("Current").Return(&settings.Settings{
+			PrincipalBudgetAmount:                  2000,
+			BudgetNotificationThresholdPercentiles: []float64{50, 90, 100},
+		}, nil)
+		input := deployed
+
+		applySettings(&input, settingsSvc)
+
+		assert.Equal(t, 2000.0, input.principalBudgetAmount)
+		assert.Equal(t, []float64{50, 90, 100}, input.budgetNotificationThresholdPercentiles)
+	})
+
+	t.Run("should keep the deployed settings which aren't set", func(t *testing.T) {
+		settingsSvc := &settingsMocks.Servicer{}
+		settingsSvc.On("Current").Return(&settings.Settings{}, nil)
+		input := deployed
+
+		applySettings(&input, settingsSvc)
+
+		assert.Equal(t, deployed, input)
+	})
+
+	t.Run("should stop sending budget notifications to Slack when its flag is off", func(t *testing.T) {
+		settingsSvc := &settingsMocks.Servicer{}
+		settingsSvc.On("Current").Return(&settings.Settings{
+			FeatureFlags: map[string]bool{settings.FlagSlackBudgetNotifications: false},
+		}, nil)
+		input := deployed
+		input.slackSvc = &slackMocks.Service{}
+
+		applySettings(&input, settingsSvc)
+
+		assert.Nil(t, input.slackSvc)
+	})
+
+	t.Run("should keep the deployed settings when the settings can't be read", func(t *testing.T) {
+		settingsSvc := &settingsMocks.Servicer{}
+		settingsSvc.On("Current").Return(nil, errors.New("throttled"))
+		input := deployed
+
+		applySettings(&input, settingsSvc)
+
+		assert.Equal(t, deployed, input)
+	})
+}
+
+func TestCheckCategoryBudgets(t *testing.T) {
+	tests := []struct {
+		name            string
+		categoryBudget  db.LeaseCategoryBudget
+		sageMakerSpend  float64
+		expTransition   bool
+		expNotification bool
+		expThreshold    float64
+	}{
+		{
+			name:            "should end the lease over an enforced category budget",
+			categoryBudget:  db.LeaseCategoryBudget{Amount: 50, Enforce: true},
+			sageMakerSpend:  60,
+			expTransition:   true,
+			expNotification: true,
+			expThreshold:    100,
+		},
+		{
+			name:            "should only notify over a category budget which isn't enforced",
+			categoryBudget:  db.LeaseCategoryBudget{Amount: 50},
+			sageMakerSpend:  60,
+			expNotification: true,
+			expThreshold:    100,
+		},
+		{
+			name:            "should notify the thresholds of the category budget",
+			categoryBudget:  db.LeaseCategoryBudget{Amount: 50, NotificationThresholds: []float64{50}, Enforce: true},
+			sageMakerSpend:  30,
+			expNotification: true,
+			expThreshold:    50,
+		},
+		{
+			name:           "should not notify a threshold again",
+			categoryBudget: db.LeaseCategoryBudget{Amount: 50, Enforce: true, ThresholdsSent: []float64{75}},
+			sageMakerSpend: 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbSvc := &dbMocks.DBer{}
+			tokenSvc := &commonMocks.TokenService{}
+			budgetSvc := &budgetMocks.Service{}
+			usageSvc := &usageMocks.DBer{}
+			notificationSvc := &notificationMocks.Servicer{}
+			todaySpend := 10.0
+			input := &lambdaHandlerInput{
+				dbSvc: dbSvc,
+				lease: &db.Lease{
+					AccountID:                "123456789012",
+					PrincipalID:              "jdoe",
+					LeaseStatus:              db.Active,
+					BudgetAmount:             1000,
+					BudgetCurrency:           "USD",
+					BudgetNotificationEmails: []string{"jdoe@example.com"},
+					LeaseStatusModifiedOn:    time.Now().AddDate(0, 0, -2).Unix(),
+					ExpiresOn:                time.Now().AddDate(0, 0, 7).Unix(),
+					CategoryBudgets:          map[string]db.LeaseCategoryBudget{"ai": tt.categoryBudget},
+				},
+				todaySpend:                             &todaySpend,
+				awsSession:                             &awsMocks.AwsSession{},
+				tokenSvc:                               tokenSvc,
+				budgetSvc:                              budgetSvc,
+				budgetCategories:                       budget.Categories{"ai": {"Amazon SageMaker"}},
+				usageSvc:                               usageSvc,
+				notificationSvc:                        notificationSvc,
+				budgetNotificationThresholdPercentiles: []float64{75, 100},
+				principalBudgetAmount:                  1000,
+				usageTTL:                               3600,
+			}
+
+			dbSvc.On("GetAccount", "123456789012").Return(&db.Account{ID: "123456789012", AdminRoleArn: "mock:admin:role:arn"}, nil)
+			usageSvc.On("PutUsage", mock.Anything).Return(nil)
+			usageSvc.On("GetUsageByDateRange", mock.Anything, mock.Anything).Return(nil, nil)
+
+			// Should get the spend of each service in the lease account
+			tokenSvc.MockNewSession("mock:admin:role:arn")
+			budgetSvc.On("SetCostExplorer", mock.Anything)
+			budgetSvc.On("CalculateSpendByService", time.Unix(input.lease.LeaseStatusModifiedOn, 0), mock.AnythingOfType("time.Time")).
+				Return(map[string]float64{"Amazon SageMaker": tt.sageMakerSpend, "AWS Lambda": 5}, nil)
+
+			if tt.expTransition {
+				dbSvc.On("TransitionLeaseStatus", "123456789012", "jdoe", db.Active, db.Inactive, db.LeaseOverCategoryBudget).
+					Return(input.lease, nil)
+				dbSvc.On("TransitionAccountStatus", "123456789012", db.Leased, db.NotReady).Return(nil, nil)
+			}
+			if tt.expNotification {
+				notificationSvc.On("Send", notification.TemplateCategoryBudgetThreshold, []string{"jdoe@example.com"},
+					mock.MatchedBy(func(data *notification.Data) bool {
+						return data.Category == "ai" && data.CategoryBudgetAmount == 50 &&
+							data.ActualSpend == tt.sageMakerSpend && data.ThresholdPercentile == int(tt.expThreshold)
+					})).Return(&notification.Email{}, nil)
+				dbSvc.On("RecordCategoryThresholdSent", "123456789012", "jdoe", "ai", 50.0, tt.expThreshold).
+					Return(input.lease, nil)
+			}
+			dbSvc.On("RecordLeaseBudgetCheck", "123456789012", "jdoe", mock.AnythingOfType("int64")).
+				Return(input.lease, nil)
+
+			err := lambdaHandler(input)
+
+			require.Nil(t, err)
+			dbSvc.AssertExpectations(t)
+			budgetSvc.AssertExpectations(t)
+			notificationSvc.AssertExpectations(t)
+			if !tt.expNotification {
+				notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRouteBudgetNotification(t *testing.T) {
+	notificationSvc := &notificationMocks.Servicer{}
+	notificationSvc.On("Render", notification.TemplateBudgetThreshold, mock.AnythingOfType("*notification.Data")).
+		Return(&notification.Email{Subject: "Lease at 75% of budget", BodyText: "Actual spend is $75"}, nil)
+	router := &routingMocks.Servicer{}
+	router.On("Enabled").Return(true)
+	router.On("Route", mock.AnythingOfType("*routing.Event")).Return(errors.New("slack unavailable"))
+	input := &sendBudgetNotificationEmailInput{
+		lease: &db.Lease{
+			ID:          "abc",
+			AccountID:   "123456789012",
+			PrincipalID: "jdoe",
+			Metadata:    map[string]interface{}{"project": "forecasting", "accountId": "999999999999"},
+		},
+		account:         &db.Account{Metadata: map[string]interface{}{"pool": "ml"}},
+		notificationSvc: notificationSvc,
+		router:          router,
+	}
+
+	// The email wasn't sent, so it's rendered for routing
+	routeBudgetNotification(input, &notification.Data{ThresholdPercentile: 75}, nil)
+
+	router.AssertCalled(t, "Route", &routing.Event{
+		Type: routing.EventBudgetThreshold,
+		Attributes: map[string]string{
+			"leaseId":             "abc",
+			"accountId":           "123456789012",
+			"principalId":         "jdoe",
+			"thresholdPercentile": "75",
+			"project":             "forecasting",
+			"pool":                "ml",
+		},
+		Summary: "Lease at 75% of budget",
+		Text:    "Actual spend is $75",
+	})
+}
+
+func TestTodaysUsageConvertsCurrency(t *testing.T) {
+	usageItem, err := todaysUsage(&calculateSpendInput{
+		account:    &db.Account{ID: "123456789012"},
+		lease:      &db.Lease{AccountID: "123456789012", PrincipalID: "jdoe"},
+		currency:   &budget.StaticRate{ToCurrency: "EUR", Rate: 0.5},
+		todaySpend: aws.Float64(10),
+		usageTTL:   3600,
+	})
+	require.Nil(t, err)
+	require.NotNil(t, usageItem)
+	assert.Equal(t, 5.0, *usageItem.CostAmount)
+	assert.Equal(t, "EUR", *usageItem.CostCurrency)
+}
+
 func TestGetBeginningOfCurrentBillingPeriod(t *testing.T) {
 
 	actualOutput := getBeginningOfCurrentBillingPeriod("WEEKLY")
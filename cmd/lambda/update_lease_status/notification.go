@@ -1,27 +1,26 @@
 package main
 
 import (
-	"bytes"
-	"github.com/Optum/dce/pkg/common"
+	"fmt"
 	"github.com/Optum/dce/pkg/db"
-	"github.com/Optum/dce/pkg/email"
-	"github.com/pkg/errors"
-	"html/template"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/notification/notificationiface"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
+	"github.com/Optum/dce/pkg/slack"
 	"log"
 	"sort"
 	"strings"
+	"time"
 )
 
 type sendBudgetNotificationEmailInput struct {
 	lease                                  *db.Lease
-	emailSvc                               email.Service
-	s3Svc                                  common.Storager
-	budgetNotificationFromEmail            string
-	budgetNotificationBCCEmails            []string
-	budgetNotificationTemplatesBucket      string
-	budgetNotificationTemplateHTMLKey      string
-	budgetNotificationTemplateTextKey      string
-	budgetNotificationTemplateSubject      string
+	account                                *db.Account
+	dbSvc                                  db.DBer
+	notificationSvc                        notificationiface.Servicer
+	slackSvc                               slack.Service
+	router                                 routingiface.Servicer
 	budgetNotificationThresholdPercentiles []float64
 	actualLeaseSpend                       float64
 	actualPrincipalSpend                   float64
@@ -47,13 +46,6 @@ func sendBudgetNotificationEmail(input *sendBudgetNotificationEmailInput) error
 		return nil
 	}
 
-	if len(input.lease.BudgetNotificationEmails)+len(input.budgetNotificationBCCEmails) == 0 {
-		log.Printf("Skipping budget notification emails: "+
-			"no notification emails addressses were provided for lease %s @ %s",
-			input.lease.PrincipalID, input.lease.AccountID)
-		return nil
-	}
-
 	// if both lease budget threshold and principal budget threshold passed, notify for lease budget threshold only
 	thresholdPercentile := 0.0
 	actualSpend := 0.0
@@ -65,44 +57,188 @@ func sendBudgetNotificationEmail(input *sendBudgetNotificationEmailInput) error
 		actualSpend = input.actualPrincipalSpend
 	}
 
+	// Each threshold of the lease's budget is only notified once.  They're
+	// forgotten when the budget changes, unless the lease is still past them.
+	if isThresholdSent(input.lease.BudgetThresholdsSent, thresholdPercentile) {
+		return nil
+	}
+
+	// An admin acknowledged the lease's spend, eg. for a load test.  The
+	// threshold isn't recorded as sent, so it's notified once the
+	// suppression ends if the lease is still past it.
+	if input.lease.AlertSuppression.IsActive(time.Now().Unix()) {
+		log.Printf("Not sending the %.0f%% budget notification of lease %s @ %s, as its alerts are suppressed until %d: %s",
+			thresholdPercentile, input.lease.PrincipalID, input.lease.AccountID,
+			input.lease.AlertSuppression.Until, input.lease.AlertSuppression.Reason)
+		return nil
+	}
+
 	log.Printf("Budget notification threshold hit at %.0f%%", thresholdPercentile)
 	log.Printf("Sending budget notification emails for lease %s @ %s to %s",
 		input.lease.PrincipalID, input.lease.AccountID, strings.Join(input.lease.BudgetNotificationEmails, ","))
 
-	// Get the notification email templates from S3
-	templateText, err := input.s3Svc.GetObject(input.budgetNotificationTemplatesBucket, input.budgetNotificationTemplateTextKey)
-	if err != nil {
-		return errors.Wrapf(err, "Failed load budget notification template at s3://%s/%s",
-			input.budgetNotificationTemplatesBucket, input.budgetNotificationTemplateTextKey)
+	data := &notification.Data{
+		Lease:               notificationLease(input.lease),
+		ActualSpend:         actualSpend,
+		IsOverBudget:        actualSpend >= input.lease.BudgetAmount,
+		ThresholdPercentile: int(thresholdPercentile),
 	}
-	templateHTML, err := input.s3Svc.GetObject(input.budgetNotificationTemplatesBucket, input.budgetNotificationTemplateHTMLKey)
+	rendered, err := input.notificationSvc.Send(notification.TemplateBudgetThreshold, input.lease.BudgetNotificationEmails, data)
 	if err != nil {
-		return errors.Wrapf(err, "Failed load budget notification template at s3://%s/%s",
-			input.budgetNotificationTemplatesBucket, input.budgetNotificationTemplateHTMLKey)
-	}
-
-	return sendEmail(&sendEmailInput{
-		lease:                             input.lease,
-		emailSvc:                          input.emailSvc,
-		budgetNotificationFromEmail:       input.budgetNotificationFromEmail,
-		budgetNotificationBCCEmails:       input.budgetNotificationBCCEmails,
-		budgetNotificationTemplateHTML:    templateHTML,
-		budgetNotificationTemplateText:    templateText,
-		budgetNotificationTemplateSubject: input.budgetNotificationTemplateSubject,
-		actualSpend:                       actualSpend,
-	}, thresholdPercentile)
+		return err
+	}
+
+	if input.dbSvc != nil {
+		_, err = input.dbSvc.RecordBudgetThresholdSent(input.lease.AccountID, input.lease.PrincipalID,
+			input.lease.BudgetAmount, thresholdPercentile)
+		if err != nil {
+			log.Printf("Failed to record the %.0f%% budget notification of lease %s @ %s: %s",
+				thresholdPercentile, input.lease.PrincipalID, input.lease.AccountID, err)
+		}
+	}
+
+	if rendered != nil && input.slackSvc != nil {
+		sendSlackMessages(input.slackSvc, input.lease.BudgetNotificationEmails, rendered.Subject+"\n\n"+rendered.BodyText)
+	}
+
+	if input.router != nil && input.router.Enabled() {
+		routeBudgetNotification(input, data, rendered)
+	}
+	return nil
 }
 
-func renderTemplate(id string, templateStr string, data interface{}) (string, error) {
-	tmpl, err := template.New(id).Parse(templateStr)
+// sendCategoryBudgetNotification notifies the highest threshold of one of
+// the lease's category budgets which its spend in the category passed.
+// actualLeaseSpend of the input is the spend in the category.  Like the
+// lease budget's, each threshold is only notified once.
+func sendCategoryBudgetNotification(input *sendBudgetNotificationEmailInput, category string) error {
+	categoryBudget := input.lease.CategoryBudgets[category]
+	thresholds := categoryBudget.NotificationThresholds
+	if len(thresholds) == 0 {
+		thresholds = input.budgetNotificationThresholdPercentiles
+	}
+	thresholdPercentile := determineThresholdPercentile(&determineThresholdPercentileInput{
+		thresholdPercentiles: append([]float64{}, thresholds...),
+		budgetAmount:         categoryBudget.Amount,
+		actualSpend:          input.actualLeaseSpend,
+	})
+	if thresholdPercentile == 0 || isThresholdSent(categoryBudget.ThresholdsSent, thresholdPercentile) {
+		return nil
+	}
+
+	if input.lease.AlertSuppression.IsActive(time.Now().Unix()) {
+		log.Printf("Not sending the %.0f%% %s budget notification of lease %s @ %s, as its alerts are suppressed until %d: %s",
+			thresholdPercentile, category, input.lease.PrincipalID, input.lease.AccountID,
+			input.lease.AlertSuppression.Until, input.lease.AlertSuppression.Reason)
+		return nil
+	}
+
+	log.Printf("Sending %s budget notification at %.0f%% for lease %s @ %s to %s",
+		category, thresholdPercentile, input.lease.PrincipalID, input.lease.AccountID,
+		strings.Join(input.lease.BudgetNotificationEmails, ","))
+
+	data := &notification.Data{
+		Lease:                notificationLease(input.lease),
+		ActualSpend:          input.actualLeaseSpend,
+		IsOverBudget:         input.actualLeaseSpend >= categoryBudget.Amount,
+		ThresholdPercentile:  int(thresholdPercentile),
+		Category:             category,
+		CategoryBudgetAmount: categoryBudget.Amount,
+	}
+	rendered, err := input.notificationSvc.Send(notification.TemplateCategoryBudgetThreshold, input.lease.BudgetNotificationEmails, data)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if input.dbSvc != nil {
+		_, err = input.dbSvc.RecordCategoryThresholdSent(input.lease.AccountID, input.lease.PrincipalID,
+			category, categoryBudget.Amount, thresholdPercentile)
+		if err != nil {
+			log.Printf("Failed to record the %.0f%% %s budget notification of lease %s @ %s: %s",
+				thresholdPercentile, category, input.lease.PrincipalID, input.lease.AccountID, err)
+		}
+	}
+
+	if rendered != nil && input.slackSvc != nil {
+		sendSlackMessages(input.slackSvc, input.lease.BudgetNotificationEmails, rendered.Subject+"\n\n"+rendered.BodyText)
+	}
+
+	if input.router != nil && input.router.Enabled() {
+		routeBudgetNotification(input, data, rendered)
+	}
+	return nil
+}
+
+// notificationLease is the lease of a budget notification
+func notificationLease(lease *db.Lease) notification.Lease {
+	return notification.Lease{
+		ID:             lease.ID,
+		AccountID:      lease.AccountID,
+		PrincipalID:    lease.PrincipalID,
+		Status:         string(lease.LeaseStatus),
+		StatusReason:   string(lease.LeaseStatusReason),
+		BudgetAmount:   lease.BudgetAmount,
+		BudgetCurrency: lease.BudgetCurrency,
+		ExpiresOn:      time.Unix(lease.ExpiresOn, 0),
+	}
+}
+
+// routeBudgetNotification sends the notification to the channels of the
+// routing rules it matches.  Rules filter on the IDs of the lease, the
+// category of a category budget, and the metadata of the lease and its
+// account.  Failures are logged, as the email was sent.
+func routeBudgetNotification(input *sendBudgetNotificationEmailInput, data *notification.Data, rendered *notification.Email) {
+	template := notification.TemplateBudgetThreshold
+	if data.Category != "" {
+		template = notification.TemplateCategoryBudgetThreshold
 	}
 
-	buf := &bytes.Buffer{}
-	err = tmpl.Execute(buf, data)
+	// The email isn't rendered when the lease has no one to send it to
+	if rendered == nil {
+		var err error
+		rendered, err = input.notificationSvc.Render(template, data)
+		if err != nil {
+			log.Printf("Failed to render the budget notification of lease %s @ %s for routing: %s",
+				input.lease.PrincipalID, input.lease.AccountID, err)
+			return
+		}
+	}
 
-	return strings.TrimSpace(buf.String()), err
+	attributes := map[string]string{
+		"leaseId":             input.lease.ID,
+		"accountId":           input.lease.AccountID,
+		"principalId":         input.lease.PrincipalID,
+		"thresholdPercentile": fmt.Sprintf("%d", data.ThresholdPercentile),
+	}
+	if data.Category != "" {
+		attributes["category"] = data.Category
+	}
+	attributes = routing.Attributes(attributes, input.lease.Metadata)
+	if input.account != nil {
+		attributes = routing.Attributes(attributes, input.account.Metadata)
+	}
+
+	err := input.router.Route(&routing.Event{
+		Type:       routing.EventBudgetThreshold,
+		Attributes: attributes,
+		Summary:    rendered.Subject,
+		Text:       rendered.BodyText,
+	})
+	if err != nil {
+		log.Printf("Failed to route the budget notification of lease %s @ %s: %s",
+			input.lease.PrincipalID, input.lease.AccountID, err)
+	}
+}
+
+// isThresholdSent checks the notification for a threshold of a budget was
+// sent
+func isThresholdSent(thresholdsSent []float64, thresholdPercentile float64) bool {
+	for _, sent := range thresholdsSent {
+		if sent == thresholdPercentile {
+			return true
+		}
+	}
+	return false
 }
 
 type determineThresholdPercentileInput struct {
@@ -127,51 +263,21 @@ func determineThresholdPercentile(input *determineThresholdPercentileInput) floa
 	return thresholdPassed
 }
 
-type sendEmailInput struct {
-	lease                             *db.Lease
-	emailSvc                          email.Service
-	budgetNotificationFromEmail       string
-	budgetNotificationBCCEmails       []string
-	budgetNotificationTemplateHTML    string
-	budgetNotificationTemplateText    string
-	budgetNotificationTemplateSubject string
-	actualSpend                       float64
-}
-
-func sendEmail(input *sendEmailInput, thresholdPercentile float64) error {
-
-	// Render email templates
-	templateData := struct {
-		Lease               db.Lease
-		ActualSpend         float64
-		IsOverBudget        bool
-		ThresholdPercentile int
-	}{
-		Lease:               *input.lease,
-		ActualSpend:         input.actualSpend,
-		IsOverBudget:        input.actualSpend >= input.lease.BudgetAmount,
-		ThresholdPercentile: int(thresholdPercentile),
-	}
-	bodyHTML, err := renderTemplate("htmlEmail", input.budgetNotificationTemplateHTML, templateData)
-	if err != nil {
-		return err
-	}
-	bodyText, err := renderTemplate("textEmail", input.budgetNotificationTemplateText, templateData)
-	if err != nil {
-		return err
-	}
-
-	subject, err := renderTemplate("emailSubject", input.budgetNotificationTemplateSubject, templateData)
-	if err != nil {
-		return err
+// sendSlackMessages sends the notification as a direct message to the Slack
+// user with each email address. Failures are logged, as the email was sent.
+func sendSlackMessages(slackSvc slack.Service, emailAddresses []string, text string) {
+	for _, emailAddress := range emailAddresses {
+		if emailAddress == "" {
+			continue
+		}
+		user, err := slackSvc.LookupUserByEmail(emailAddress)
+		if err != nil {
+			log.Printf("Skipping Slack budget notification for %s: %s", emailAddress, err)
+			continue
+		}
+		err = slackSvc.PostMessage(&slack.Message{Channel: user.ID, Text: text})
+		if err != nil {
+			log.Printf("Failed to send Slack budget notification to %s: %s", emailAddress, err)
+		}
 	}
-
-	return input.emailSvc.SendEmail(&email.SendEmailInput{
-		FromAddress:  input.budgetNotificationFromEmail,
-		ToAddresses:  input.lease.BudgetNotificationEmails,
-		BCCAddresses: input.budgetNotificationBCCEmails,
-		BodyHTML:     bodyHTML,
-		BodyText:     bodyText,
-		Subject:      subject,
-	})
 }
@@ -18,38 +18,60 @@ type calculateSpendInput struct {
 	lease                 *db.Lease
 	tokenSvc              common.TokenService
 	budgetSvc             budget.Service
+	currency              budget.CurrencyConverter
 	usageSvc              usage.DBer
+	usageAggregator       usage.Aggregator
 	awsSession            awsiface.AwsSession
 	principalBudgetPeriod string
 	usageTTL              int // TTL in seconds for Usage DynamoDB records
+	// todaySpend is the spend of the lease account for the current date,
+	// when it was already collected with the spend of other accounts
+	todaySpend *float64
 }
 
-// calculateLeaseSpend calculates amount spent by User principal for current lease
-func calculateLeaseSpend(input *calculateSpendInput) (float64, error) {
-	adminRoleArn := input.account.AdminRoleArn
-	log.Printf("Assuming role %s for budget check", adminRoleArn)
-	assumedSession, err := input.tokenSvc.NewSession(input.awsSession, adminRoleArn)
-	if err != nil {
-		return 0, errors.Wrapf(err, "Failed to assume role %s", adminRoleArn)
-	}
-
-	// Configure the CostExplorer SDK for the Service
-	input.budgetSvc.SetCostExplorer(
-		costexplorer.New(assumedSession),
-	)
-
+// todaysUsage gets the spend of the lease account for the current date. No
+// usage is returned when it's invalid.
+func todaysUsage(input *calculateSpendInput) (*usage.Usage, error) {
 	//Get usage for current date and add it to Usage cache db
 	currentTime := time.Now()
 	usageStartTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, time.UTC)
 	usageEndTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 23, 59, 59, 0, time.UTC)
 
 	log.Printf("usageStart: %d and usageEnd :%d", usageStartTime.Unix(), usageEndTime.Unix())
-	todayCostAmount, err := input.budgetSvc.CalculateTotalSpend(usageStartTime, usageStartTime.AddDate(0, 0, 1))
+	var todayCostAmount float64
+	if input.todaySpend != nil {
+		log.Printf("Using the spend collected for account %s", input.account.ID)
+		todayCostAmount = *input.todaySpend
+	} else {
+		adminRoleArn := input.account.AdminRoleArn
+		log.Printf("Assuming role %s for budget check", adminRoleArn)
+		assumedSession, err := input.tokenSvc.NewSession(input.awsSession, adminRoleArn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to assume role %s", adminRoleArn)
+		}
+
+		// Configure the CostExplorer SDK for the Service
+		input.budgetSvc.SetCostExplorer(
+			costexplorer.New(assumedSession),
+		)
+
+		todayCostAmount, err = input.budgetSvc.CalculateTotalSpend(usageStartTime, usageStartTime.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to calculate spend for account %s", input.lease.AccountID)
+		}
+	}
+
+	// Cost Explorer reports costs in USD, and budgets may be in another currency
+	currency := input.currency
+	if currency == nil {
+		currency = budget.NoConversion
+	}
+	todayCostAmount, err := currency.FromUSD(todayCostAmount)
 	if err != nil {
-		return 0, errors.Wrapf(err, "Failed to calculate spend for account %s", input.lease.AccountID)
+		return nil, errors.Wrapf(err, "Failed to convert spend for account %s to %s", input.lease.AccountID, currency.Currency())
 	}
 
-	log.Printf("usage for today: %f", todayCostAmount)
+	log.Printf("usage for today: %f %s", todayCostAmount, currency.Currency())
 
 	// Write today's usage to DynamoDB
 	usageItem, err := usage.NewUsage(usage.NewUsageInput{
@@ -58,13 +80,47 @@ func calculateLeaseSpend(input *calculateSpendInput) (float64, error) {
 		PrincipalID:  input.lease.PrincipalID,
 		AccountID:    input.account.ID,
 		CostAmount:   todayCostAmount,
-		CostCurrency: "USD",
+		CostCurrency: currency.Currency(),
 		TimeToLive:   usageStartTime.Add(time.Duration(input.usageTTL) * time.Second).Unix(),
 	})
 	if err != nil {
-		return 0, nil
+		log.Printf("Invalid usage for account %s: %s", input.account.ID, err)
+		return nil, nil
+	}
+	return usageItem, nil
+}
+
+// calculateAggregatedSpend records today's usage, and gets the spend of the
+// lease and principal from their aggregates
+func calculateAggregatedSpend(input *calculateSpendInput) (*usage.Spend, error) {
+	usageItem, err := todaysUsage(input)
+	if err != nil || usageItem == nil {
+		return &usage.Spend{}, err
+	}
+
+	spend, err := input.usageAggregator.PutUsageAggregated(*usageItem, usage.AggregatePeriods{
+		// Budget period starts last time the lease was reset.
+		Lease:     time.Unix(input.lease.LeaseStatusModifiedOn, 0),
+		Principal: getBeginningOfCurrentBillingPeriod(input.principalBudgetPeriod),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to record usage for account %s", input.lease.AccountID)
 	}
 
+	log.Printf("Lease for %s @ %s has spent $%.2f of their $%.2f budget, and the principal has spent $%.2f",
+		input.lease.PrincipalID, input.lease.AccountID, spend.Lease, input.lease.BudgetAmount, spend.Principal)
+	return spend, nil
+}
+
+// calculateLeaseSpend calculates amount spent by User principal for current lease
+func calculateLeaseSpend(input *calculateSpendInput) (float64, error) {
+	usageItem, err := todaysUsage(input)
+	if err != nil || usageItem == nil {
+		return 0, err
+	}
+	todayCostAmount := *usageItem.CostAmount
+	usageEndTime := time.Unix(*usageItem.EndDate, 0).UTC()
+
 	err = input.usageSvc.PutUsage(*usageItem)
 	if err != nil {
 		return 0, nil
@@ -103,6 +159,47 @@ func calculateLeaseSpend(input *calculateSpendInput) (float64, error) {
 	return spend, nil
 }
 
+// calculateCategorySpend gets the spend of the lease in each category it has
+// a budget for, since the lease started.  Cost Explorer is called in the
+// lease account for the spend of each service, which is only needed for
+// leases with category budgets.
+func calculateCategorySpend(input *calculateSpendInput, categories budget.Categories) (map[string]float64, error) {
+	adminRoleArn := input.account.AdminRoleArn
+	assumedSession, err := input.tokenSvc.NewSession(input.awsSession, adminRoleArn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to assume role %s", adminRoleArn)
+	}
+	input.budgetSvc.SetCostExplorer(
+		costexplorer.New(assumedSession),
+	)
+
+	currentTime := time.Now()
+	startTime := time.Unix(input.lease.LeaseStatusModifiedOn, 0)
+	endTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	spendByService, err := input.budgetSvc.CalculateSpendByService(startTime, endTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to calculate spend by service for account %s", input.lease.AccountID)
+	}
+
+	currency := input.currency
+	if currency == nil {
+		currency = budget.NoConversion
+	}
+	spend := map[string]float64{}
+	for category, amount := range categories.SpendByCategory(spendByService) {
+		if _, ok := input.lease.CategoryBudgets[category]; !ok {
+			continue
+		}
+		spend[category], err = currency.FromUSD(amount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to convert spend for account %s to %s", input.lease.AccountID, currency.Currency())
+		}
+		log.Printf("Lease for %s @ %s has spent $%.2f of their $%.2f %s budget",
+			input.lease.PrincipalID, input.lease.AccountID, spend[category], input.lease.CategoryBudgets[category].Amount, category)
+	}
+	return spend, nil
+}
+
 // calculatePrincipalSpend calculates the amount spent by User principal for current billing period
 func calculatePrincipalSpend(input *calculateSpendInput) (float64, error) {
 
@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/event"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
 )
 
 type configuration struct {
 	Debug string `env:"DEBUG" envDefault:"false"`
+	// Concurrency is how many principal policies are updated at a time
+	Concurrency int `env:"POLICY_UPDATE_CONCURRENCY" envDefault:"5"`
 }
 
 var (
@@ -51,27 +55,50 @@ func main() {
 }
 
 func handler(ctx context.Context, snsEvent events.SNSEvent) error {
-	var lease lease.Lease
-
+	pool := common.NewWorkerPool(ctx, settings.Concurrency)
 	for _, record := range snsEvent.Records {
-		snsRecord := record.SNS
+		message := record.SNS.Message
+		pool.Submit(func(ctx context.Context) error {
+			return updatePrincipalPolicy(message)
+		})
+	}
 
-		err := json.Unmarshal([]byte(snsRecord.Message), &lease)
-		if err != nil {
-			log.Printf("Failed to read SNS message %s: %s", snsRecord.Message, err.Error())
-			return errors.NewInternalServer("unexpected error parsing SNS message", err)
-		}
+	errs := pool.Wait()
+	// Return a single error as is, so its cause is kept
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 1 {
+		return errors.NewMultiError("failed to update principal policies", errs)
+	}
+	return nil
+}
+
+// updatePrincipalPolicy makes sure the principal policy of the leased
+// account is up to date, that the account ships its logs to the logging
+// account for the lease, and that it has a budget mirroring the lease budget
+func updatePrincipalPolicy(message string) error {
+	var lease lease.Lease
+	_, err := event.Unmarshal([]byte(message), &lease)
+	if err != nil {
+		log.Printf("Failed to read SNS message %s: %s", message, err.Error())
+		return errors.NewInternalServer("unexpected error parsing SNS message", err)
+	}
 
-		acct, err := services.AccountService().Get(*lease.AccountID)
-		if err != nil {
-			return err
-		}
+	acct, err := services.AccountService().Get(*lease.AccountID)
+	if err != nil {
+		return err
+	}
 
-		err = services.AccountService().UpsertPrincipalAccess(acct)
-		if err != nil {
-			return err
-		}
+	err = services.AccountService().UpsertPrincipalAccess(acct)
+	if err != nil {
+		return err
+	}
 
+	err = services.AccountManager().UpsertLogAggregation(acct, aws.StringValue(lease.ID))
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return services.AccountManager().UpsertLeaseBudget(acct, &lease)
 }
@@ -7,9 +7,11 @@ import (
 
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/account/accountiface/mocks"
+	managerMocks "github.com/Optum/dce/pkg/accountmanager/accountmanageriface/mocks"
 	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -23,6 +25,8 @@ func TestUpdatePrincipalPolicy(t *testing.T) {
 		getAcct   *account.Account
 		getErr    error
 		upsertErr error
+		logsErr   error
+		budgetErr error
 		expErr    error
 	}{
 		{
@@ -38,6 +42,19 @@ func TestUpdatePrincipalPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "when valid enveloped lease provided upsert happens",
+			acctID: "123456789012",
+			input: events.SNSEvent{
+				Records: []events.SNSEventRecord{
+					{
+						SNS: events.SNSEntity{
+							Message: "{\"type\": \"LeaseCreated\", \"version\": \"1\", \"data\": {\"accountId\": \"123456789012\"}}",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "when invalid lease provided an error occurs",
 			input: events.SNSEvent{
@@ -81,6 +98,36 @@ func TestUpdatePrincipalPolicy(t *testing.T) {
 			upsertErr: errors.NewInternalServer("failure", fmt.Errorf("error")),
 			expErr:    errors.NewInternalServer("failure", fmt.Errorf("error")),
 		},
+		{
+			name:   "when valid lease provided but there is an error configuring log aggregation",
+			acctID: "123456789012",
+			input: events.SNSEvent{
+				Records: []events.SNSEventRecord{
+					{
+						SNS: events.SNSEntity{
+							Message: "{\"id\": \"lease-1\", \"accountId\": \"123456789012\"}",
+						},
+					},
+				},
+			},
+			logsErr: errors.NewInternalServer("failure", fmt.Errorf("error")),
+			expErr:  errors.NewInternalServer("failure", fmt.Errorf("error")),
+		},
+		{
+			name:   "when valid lease provided but there is an error creating the lease budget",
+			acctID: "123456789012",
+			input: events.SNSEvent{
+				Records: []events.SNSEventRecord{
+					{
+						SNS: events.SNSEntity{
+							Message: "{\"id\": \"lease-1\", \"accountId\": \"123456789012\", \"budgetAmount\": 100}",
+						},
+					},
+				},
+			},
+			budgetErr: errors.NewInternalServer("failure", fmt.Errorf("error")),
+			expErr:    errors.NewInternalServer("failure", fmt.Errorf("error")),
+		},
 	}
 
 	// Iterate through each test in the list
@@ -92,8 +139,11 @@ func TestUpdatePrincipalPolicy(t *testing.T) {
 		acctServiceMock := mocks.Servicer{}
 		acctServiceMock.On("Get", tt.acctID).Return(tt.getAcct, tt.getErr)
 		acctServiceMock.On("UpsertPrincipalAccess", tt.getAcct).Return(tt.upsertErr)
+		managerMock := managerMocks.Servicer{}
+		managerMock.On("UpsertLogAggregation", tt.getAcct, mock.Anything).Return(tt.logsErr)
+		managerMock.On("UpsertLeaseBudget", tt.getAcct, mock.AnythingOfType("*lease.Lease")).Return(tt.budgetErr)
 
-		svcBldr.Config.WithService(&acctServiceMock)
+		svcBldr.Config.WithService(&acctServiceMock).WithService(&managerMock)
 		_, err := svcBldr.Build()
 		assert.Nil(t, err)
 		if err == nil {
@@ -104,3 +154,39 @@ func TestUpdatePrincipalPolicy(t *testing.T) {
 		assert.True(t, errors.Is(err, tt.expErr))
 	}
 }
+
+func TestUpdatePrincipalPolicies(t *testing.T) {
+	svcBldr := &config.ServiceBuilder{Config: &config.ConfigurationBuilder{}}
+
+	acct := &account.Account{}
+	acctServiceMock := mocks.Servicer{}
+	acctServiceMock.On("Get", "111111111111").Return(acct, nil)
+	acctServiceMock.On("Get", "222222222222").Return(nil, errors.NewNotFound("account", "222222222222"))
+	acctServiceMock.On("Get", "333333333333").Return(nil, errors.NewNotFound("account", "333333333333"))
+	acctServiceMock.On("UpsertPrincipalAccess", acct).Return(nil)
+	managerMock := managerMocks.Servicer{}
+	managerMock.On("UpsertLogAggregation", acct, "").Return(nil)
+	managerMock.On("UpsertLeaseBudget", acct, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+	svcBldr.Config.WithService(&acctServiceMock).WithService(&managerMock)
+	_, err := svcBldr.Build()
+	assert.Nil(t, err)
+	services = svcBldr
+
+	input := events.SNSEvent{}
+	for _, id := range []string{"111111111111", "222222222222", "333333333333"} {
+		input.Records = append(input.Records, events.SNSEventRecord{
+			SNS: events.SNSEntity{
+				Message: fmt.Sprintf("{\"accountId\": \"%s\"}", id),
+			},
+		})
+	}
+
+	err = handler(context.TODO(), input)
+
+	assert.Contains(t, err.Error(), "failed to update principal policies: ")
+	assert.Contains(t, err.Error(), "account \"222222222222\" not found")
+	assert.Contains(t, err.Error(), "account \"333333333333\" not found")
+	acctServiceMock.AssertNumberOfCalls(t, "UpsertPrincipalAccess", 1)
+	managerMock.AssertNumberOfCalls(t, "UpsertLogAggregation", 1)
+}
@@ -1,13 +1,14 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Optum/dce/pkg/api"
 	"github.com/Optum/dce/pkg/api/response"
 	"github.com/Optum/dce/pkg/usage"
 )
@@ -25,34 +26,55 @@ func GetUsage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := UsageSvc.GetUsage(getUsageInput)
+	// Usage is encoded a page at a time, so large limits don't hold every
+	// record in memory. The body is buffered because the Link header depends
+	// on the last page.
+	body := &bytes.Buffer{}
+	stream := api.NewJSONArrayWriter(body)
+	var nextKeys map[string]string
+	err = api.ReadPages(getUsageInput.Limit, func(pageLimit int64) (bool, error) {
+		getUsageInput.Limit = pageLimit
+		result, err := UsageSvc.GetUsage(getUsageInput)
+		if err != nil {
+			return false, err
+		}
+
+		// Serialize them for the JSON response.
+		for _, usageItem := range result.Results {
+			err = stream.Write(response.UsageResponse{
+				PrincipalID:  *usageItem.PrincipalID,
+				AccountID:    *usageItem.AccountID,
+				StartDate:    *usageItem.StartDate,
+				EndDate:      *usageItem.EndDate,
+				CostAmount:   *usageItem.CostAmount,
+				CostCurrency: *usageItem.CostCurrency,
+				TimeToLive:   *usageItem.TimeToLive,
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+
+		nextKeys = result.NextKeys
+		getUsageInput.StartKeys = result.NextKeys
+		return len(result.NextKeys) > 0, nil
+	})
 
 	if err != nil {
 		response.WriteServerErrorWithResponse(w, fmt.Sprintf("Error querying usage: %s", err))
 		return
 	}
 
-	// Serialize them for the JSON response.
-	usageResponseItems := []response.UsageResponse{}
-	for _, usageItem := range result.Results {
-		usageResponseItems = append(usageResponseItems, response.UsageResponse{
-			PrincipalID:  *usageItem.PrincipalID,
-			AccountID:    *usageItem.AccountID,
-			StartDate:    *usageItem.StartDate,
-			EndDate:      *usageItem.EndDate,
-			CostAmount:   *usageItem.CostAmount,
-			CostCurrency: *usageItem.CostCurrency,
-			TimeToLive:   *usageItem.TimeToLive,
-		})
-	}
-
 	// If the DB result has next keys, then the URL to retrieve the next page is put into the Link header.
-	if len(result.NextKeys) > 0 {
-		nextURL := response.BuildNextURL(r, result.NextKeys, baseRequest)
+	if len(nextKeys) > 0 {
+		nextURL := response.BuildNextURL(r, nextKeys, baseRequest)
 		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextURL.String()))
 	}
 
-	err = json.NewEncoder(w).Encode(usageResponseItems)
+	err = stream.Close()
+	if err == nil {
+		_, err = body.WriteTo(w)
+	}
 	if err != nil {
 		log.Print(err)
 		response.WriteServerError(w)
@@ -8,9 +8,15 @@ import (
 	"log"
 
 	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/apikey"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/config"
 	"github.com/Optum/dce/pkg/usage"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 
 	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
 )
@@ -31,6 +37,14 @@ var (
 	// UsageSvc - Service for getting usage
 	UsageSvc    *usage.DB
 	baseRequest url.URL
+	// ReportsS3 - Client of the bucket CSV usage reports are uploaded to
+	ReportsS3     s3iface.S3API
+	ReportsBucket string
+	ReportsPrefix string
+	// apiKeyMiddleware limits requests made with API keys to their scopes
+	apiKeyMiddleware api.APIKeyMiddleware
+	// apiCache adds caching headers to the usage lists
+	apiCache api.Cache
 )
 
 func init() {
@@ -38,30 +52,39 @@ func init() {
 
 	usageRoutes := api.Routes{
 
+		api.Route{
+			"GetUsageReport",
+			"GET",
+			"/usage/report",
+			api.EmptyQueryString,
+			GetUsageReport,
+		},
 		api.Route{
 			"GetUsageByStartDateAndEndDate",
 			"GET",
 			"/usage",
 			[]string{StartDateParam, EndDateParam},
-			GetUsageByStartDateAndEndDate,
+			apiCache.Handler(GetUsageByStartDateAndEndDate),
 		},
 		api.Route{
 			"GetUsageByStartDateAndPrincipalID",
 			"GET",
 			"/usage",
 			[]string{StartDateParam, PrincipalIDParam},
-			GetUsageByStartDateAndPrincipalID,
+			apiCache.Handler(GetUsageByStartDateAndPrincipalID),
 		},
 		api.Route{
 			"GetAllUsage",
 			"GET",
 			"/usage",
 			api.EmptyQueryString,
-			GetUsage,
+			apiCache.Handler(GetUsage),
 		},
 	}
 	r := api.NewRouter(usageRoutes)
 	muxLambda = gorillamux.New(r)
+	apiKeyMiddleware.GorillaMuxAdapter = muxLambda
+	r.Use(apiKeyMiddleware.Middleware)
 }
 
 // Handler - Handle the lambda function
@@ -80,6 +103,17 @@ func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.API
 func main() {
 
 	UsageSvc = newUsage()
+	ReportsS3 = newReportsS3()
+	ReportsBucket = common.RequireEnv("ARTIFACTS_BUCKET")
+	ReportsPrefix = common.GetEnv("USAGE_REPORTS_PREFIX", "reports/usage")
+	cfgBldr := &config.ConfigurationBuilder{}
+	if err := cfgBldr.Unmarshal(&apiKeyMiddleware); err != nil {
+		log.Fatalf("Could not load api key configuration: %s", err.Error())
+	}
+	if err := cfgBldr.Unmarshal(&apiCache); err != nil {
+		log.Fatalf("Could not load api cache configuration: %s", err.Error())
+	}
+	apiKeyMiddleware.APIKeyService = newAPIKeys()
 
 	lambda.Start(Handler)
 }
@@ -93,3 +127,23 @@ func newUsage() *usage.DB {
 
 	return usageSvc
 }
+
+func newAPIKeys() *apikey.Service {
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
+	if err != nil {
+		log.Fatalf("Failed to initialize DynamoDB service: %s", err)
+	}
+	apiKeySvc, err := apikey.NewFromEnv(dynamodb.New(awsSession, common.EndpointConfig("DynamoDB")))
+	if err != nil {
+		log.Fatalf("Failed to initialize api key service: %s", err)
+	}
+	return apiKeySvc
+}
+
+func newReportsS3() s3iface.S3API {
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
+	if err != nil {
+		log.Fatalf("Failed to initialize S3 service: %s", err)
+	}
+	return s3.New(awsSession, common.EndpointConfig("S3"))
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/api/response"
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	GroupByParam = "groupBy"
+	FormatParam  = "format"
+)
+
+// reportMaxDays is the longest period a report may cover
+const reportMaxDays = 366
+
+// reportURLExpiry is how long the URL of a CSV report can be used
+const reportURLExpiry = 15 * time.Minute
+
+// reportURLResponse is the link to download a CSV report
+type reportURLResponse struct {
+	URL       string `json:"url"`
+	ExpiresOn int64  `json:"expiresOn"`
+}
+
+// GetUsageReport - Returns the spend of usage by principal, pool or month.
+// CSV reports are uploaded to S3, and a URL to download them is returned.
+func GetUsageReport(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+
+	// Reports default to the current month
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := r.FormValue(StartDateParam); v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			response.WriteRequestValidationError(w, fmt.Sprintf("Failed to parse usage start date: %s", err))
+			return
+		}
+		startDate = time.Unix(i, 0)
+	}
+	endDate := now
+	if v := r.FormValue(EndDateParam); v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			response.WriteRequestValidationError(w, fmt.Sprintf("Failed to parse usage end date: %s", err))
+			return
+		}
+		endDate = time.Unix(i, 0)
+	}
+	if endDate.Before(startDate) {
+		response.WriteRequestValidationError(w, "Usage start date must be before the end date")
+		return
+	}
+	if endDate.Sub(startDate) > reportMaxDays*24*time.Hour {
+		response.WriteRequestValidationError(w, fmt.Sprintf("Reports can cover at most %d days", reportMaxDays))
+		return
+	}
+
+	format := r.FormValue(FormatParam)
+	if format != "" && format != "json" && format != "csv" {
+		response.WriteRequestValidationError(w, "format must be json or csv")
+		return
+	}
+
+	records, err := UsageSvc.GetUsageByDateRange(startDate, endDate)
+	if err != nil {
+		log.Printf("Error getting usage from %s to %s: %s", startDate, endDate, err)
+		response.WriteServerErrorWithResponse(w, fmt.Sprintf("Error querying usage: %s", err))
+		return
+	}
+
+	report, err := usage.NewReport(r.FormValue(GroupByParam), startDate, endDate, records)
+	if err != nil {
+		response.WriteRequestValidationError(w, err.Error())
+		return
+	}
+
+	if format != "csv" {
+		api.WriteAPIResponse(w, http.StatusOK, report)
+		return
+	}
+
+	url, err := uploadReport(report, now)
+	if err != nil {
+		log.Printf("Error uploading usage report: %s", err)
+		response.WriteServerErrorWithResponse(w, "Error uploading usage report")
+		return
+	}
+	api.WriteAPIResponse(w, http.StatusOK, reportURLResponse{
+		URL:       url,
+		ExpiresOn: now.Add(reportURLExpiry).Unix(),
+	})
+}
+
+// uploadReport writes the report as CSV to the reports bucket, and signs a
+// URL to download it.  Reports are expired by the bucket's lifecycle rules.
+func uploadReport(report *usage.Report, now time.Time) (string, error) {
+	body := &bytes.Buffer{}
+	err := report.WriteCSV(body)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("usage-by-%s-%s.csv", report.GroupBy, now.Format("20060102T150405Z"))
+	key := fmt.Sprintf("%s/%s", ReportsPrefix, name)
+	_, err = ReportsS3.PutObject(&s3.PutObjectInput{
+		Bucket:             aws.String(ReportsBucket),
+		Key:                aws.String(key),
+		Body:               bytes.NewReader(body.Bytes()),
+		ContentType:        aws.String("text/csv"),
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=\"%s\"", name)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, _ := ReportsS3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(ReportsBucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(reportURLExpiry)
+}
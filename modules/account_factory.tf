@@ -0,0 +1,140 @@
+locals {
+  account_factory_count = var.account_factory_driver == "" ? 0 : 1
+}
+
+module "account_factory_lambda" {
+  source          = "./lambda"
+  name            = "account_factory-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Creates new accounts when the account pool runs low, and adds them to the pool"
+  global_tags     = var.global_tags
+  handler         = "account_factory"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    EVENT_BUS_NAME                           = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                     = local.event_archive_bucket
+    EVENT_TABLE_NAME                         = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                             = local.outbox_table
+    EVENT_RETENTION_DAYS                     = var.event_retention_days
+    EVENT_SINK_DRIVER                        = var.event_sink_driver
+    EVENT_SINK_TARGET                        = local.event_sink_target
+    EVENT_SINK_AUTH                          = var.event_sink_auth
+    DEBUG                                    = "false"
+    ACCOUNT_ID                               = local.account_id
+    NAMESPACE                                = var.namespace
+    AWS_CURRENT_REGION                       = var.aws_region
+    ACCOUNT_DB                               = aws_dynamodb_table.accounts.id
+    ARTIFACTS_BUCKET                         = aws_s3_bucket.artifacts.id
+    LEASE_DB                                 = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                       = var.status_shard_count
+    RESET_SQS_URL                            = aws_sqs_queue.account_reset.id
+    ACCOUNT_CREATED_TOPIC_ARN                = aws_sns_topic.account_created.arn
+    ACCOUNT_DELETED_TOPIC_ARN                = aws_sns_topic.account_deleted.arn
+    PRINCIPAL_ROLE_NAME                      = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                    = local.principal_policy_name
+    PRINCIPAL_IAM_DENY_TAGS                  = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                          = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION           = 14400
+    TAG_ENVIRONMENT                          = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                             = lookup(var.global_tags, "AppName")
+    PRINCIPAL_POLICY_S3_KEY                  = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY   = local.principal_policy_customizations_key
+    ACCOUNT_FACTORY_DRIVER                   = var.account_factory_driver
+    ACCOUNT_FACTORY_MIN_READY_ACCOUNTS       = var.account_factory_min_ready_accounts
+    ACCOUNT_FACTORY_MAX_ACCOUNTS_PER_RUN     = var.account_factory_max_accounts_per_run
+    ACCOUNT_FACTORY_EMAIL                    = var.account_factory_email
+    ACCOUNT_FACTORY_ADMIN_ROLE_NAME          = var.account_factory_admin_role_name
+    ACCOUNT_FACTORY_PRODUCT_ID               = var.account_factory_product_id
+    ACCOUNT_FACTORY_PROVISIONING_ARTIFACT_ID = var.account_factory_provisioning_artifact_id
+    ACCOUNT_FACTORY_ORGANIZATIONAL_UNIT      = var.account_factory_organizational_unit
+    ACCOUNT_WARM_UP_STEPS                    = local.account_warm_up_steps
+    ACCOUNT_WARM_UP_STATE_MACHINE_ARN        = join("", aws_sfn_state_machine.account_warm_up.*.id)
+  }
+}
+
+// Allow the account_factory lambda to create accounts
+// with Organizations, or the Control Tower Account Factory
+resource "aws_iam_role_policy" "account_factory" {
+  count  = local.account_factory_count
+  role   = module.account_factory_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": [
+        "organizations:CreateAccount",
+        "organizations:DescribeCreateAccountStatus",
+        "organizations:ListCreateAccountStatus",
+        "organizations:DescribeOrganization",
+        "organizations:DescribeOrganizationalUnit",
+        "organizations:ListRoots",
+        "organizations:ListParents",
+        "organizations:ListOrganizationalUnitsForParent",
+        "organizations:ListAccounts",
+        "organizations:ListAccountsForParent",
+        "organizations:MoveAccount",
+        "servicecatalog:ProvisionProduct",
+        "servicecatalog:SearchProvisionedProducts",
+        "servicecatalog:DescribeRecord",
+        "servicecatalog:DescribeProduct",
+        "servicecatalog:DescribeProvisioningParameters",
+        "servicecatalog:ListLaunchPaths",
+        "controltower:CreateManagedAccount",
+        "controltower:DescribeManagedAccount",
+        "controltower:DescribeCoreService",
+        "controltower:DescribeAccountFactoryConfig",
+        "controltower:GetAvailableUpdates",
+        "controltower:ListManagedOrganizationalUnits",
+        "controltower:DescribeManagedOrganizationalUnit",
+        "sso:GetProfile",
+        "sso:CreateProfile",
+        "sso:UpdateProfile",
+        "sso:GetSSOStatus",
+        "sso:GetTrust",
+        "sso:CreateTrust",
+        "sso:UpdateTrust",
+        "sso:GetPeregrineStatus",
+        "sso:StartPeregrine",
+        "sso:DescribeRegisteredRegions",
+        "sso:ListDirectoryAssociations",
+        "sso:ListProfiles",
+        "sso:ListProfileAssociations",
+        "sso:AssociateProfile",
+        "sso:DisassociateProfile",
+        "sso:ProvisionApplicationInstanceForAWSAccount",
+        "sso-directory:DescribeDirectory",
+        "sso-directory:SearchUsers",
+        "sso-directory:CreateUser",
+        "sso-directory:SearchGroups",
+        "iam:CreateServiceLinkedRole"
+      ],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+resource "aws_cloudwatch_event_rule" "account_factory" {
+  count               = local.account_factory_count
+  name                = "account-factory-${var.namespace}"
+  description         = "Create accounts when the account pool runs low"
+  schedule_expression = var.account_factory_schedule_expression
+}
+
+resource "aws_cloudwatch_event_target" "account_factory" {
+  count     = local.account_factory_count
+  rule      = aws_cloudwatch_event_rule.account_factory[0].name
+  target_id = "account_factory_${var.namespace}"
+  arn       = module.account_factory_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_account_factory" {
+  count         = local.account_factory_count
+  statement_id  = "AllowCloudWatchAccountFactory${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.account_factory_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.account_factory[0].arn
+}
@@ -0,0 +1,55 @@
+module "account_gc_lambda" {
+  source          = "./lambda"
+  name            = "account_gc-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Retries the remediation of accounts stuck NotReady or Unreachable, and quarantines them if they're still stuck"
+  global_tags     = var.global_tags
+  handler         = "account_gc"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, local.principal_policy_environment, {
+    EVENT_BUS_NAME           = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET     = local.event_archive_bucket
+    EVENT_TABLE_NAME         = aws_dynamodb_table.events.id
+    OUTBOX_TABLE             = local.outbox_table
+    EVENT_RETENTION_DAYS     = var.event_retention_days
+    EVENT_SINK_DRIVER        = var.event_sink_driver
+    EVENT_SINK_TARGET        = local.event_sink_target
+    EVENT_SINK_AUTH          = var.event_sink_auth
+    DEBUG                    = "false"
+    NAMESPACE                = var.namespace
+    AWS_CURRENT_REGION       = var.aws_region
+    ACCOUNT_DB               = aws_dynamodb_table.accounts.id
+    LEASE_DB                 = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT       = var.status_shard_count
+    RESET_SQS_URL            = aws_sqs_queue.account_reset.id
+    ACCOUNT_GC_STUCK_HOURS   = var.account_gc_stuck_hours
+    ACCOUNT_GC_DIGEST_EMAILS = join(",", var.account_gc_digest_emails)
+  })
+}
+
+// Allow account_gc lambda to send the quarantine digest with SES
+resource "aws_iam_role_policy" "account_gc_ses" {
+  role   = module.account_gc_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Check for stuck accounts on a timer (cloudwatch event)
+module "account_gc_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "account_gc-${var.namespace}"
+  lambda_function_arn = module.account_gc_lambda.arn
+  schedule_expression = var.account_gc_schedule_expression
+  description         = "Retries the remediation of stuck accounts, and quarantines them"
+  enabled             = var.account_gc_enabled
+}
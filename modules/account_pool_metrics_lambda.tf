@@ -12,11 +12,25 @@ module "account_pool_metrics_lambda" {
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
   environment = {
-    DEBUG              = "false"
-    ACCOUNT_ID         = local.account_id
-    NAMESPACE          = var.namespace
-    AWS_CURRENT_REGION = var.aws_region
-    ACCOUNT_DB         = aws_dynamodb_table.accounts.id
+    EVENT_BUS_NAME           = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET     = local.event_archive_bucket
+    EVENT_TABLE_NAME         = aws_dynamodb_table.events.id
+    OUTBOX_TABLE             = local.outbox_table
+    EVENT_RETENTION_DAYS     = var.event_retention_days
+    EVENT_SINK_DRIVER        = var.event_sink_driver
+    EVENT_SINK_TARGET        = local.event_sink_target
+    EVENT_SINK_AUTH          = var.event_sink_auth
+    DEBUG                    = "false"
+    ACCOUNT_ID               = local.account_id
+    NAMESPACE                = var.namespace
+    AWS_CURRENT_REGION       = var.aws_region
+    ACCOUNT_DB               = aws_dynamodb_table.accounts.id
+    STATUS_SHARD_COUNT       = var.status_shard_count
+    ALERT_DRIVER             = var.alert_driver
+    ALERT_INTEGRATION_KEY    = var.alert_integration_key
+    ALERT_API_URL            = var.alert_api_url
+    READY_POOL_LOW_THRESHOLD = var.ready_pool_low_threshold
+    READY_POOL_LOW_PERCENT   = var.ready_pool_low_percent
   }
 }
 
@@ -57,6 +71,20 @@ resource "aws_cloudwatch_metric_alarm" "orphaned_accounts" {
   insufficient_data_actions = []
 }
 
+resource "aws_cloudwatch_metric_alarm" "unreachable_accounts" {
+  count                     = local.account_pool_metrics_count
+  alarm_name                = "unreachable-accounts"
+  comparison_operator       = "GreaterThanOrEqualToThreshold"
+  evaluation_periods        = "2"
+  metric_name               = "UnreachableAccounts"
+  namespace                 = local.metrics_namespace
+  period                    = "3600"
+  statistic                 = "Average"
+  threshold                 = var.unreachable_accounts_alarm_threshold
+  alarm_description         = "Alarm for closed or suspended accounts, which should be deleted from the account pool"
+  insufficient_data_actions = []
+}
+
 resource "aws_cloudwatch_metric_alarm" "too_few_ready_accounts" {
   count                     = local.account_pool_metrics_count
   alarm_name                = "ready-accounts"
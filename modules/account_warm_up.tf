@@ -0,0 +1,164 @@
+locals {
+  account_warm_up_count = var.account_warm_up_toggle == "true" ? 1 : 0
+  account_warm_up_steps = var.account_warm_up_toggle == "true" ? join(",", var.account_warm_up_steps) : ""
+
+  # The configured steps run between recording the execution on the account,
+  # and making the account Ready
+  account_warm_up_states = concat(["RecordExecution"], var.account_warm_up_steps, ["Complete"])
+
+  # Resets and baseline stacks can take a while, so steps which are waiting
+  # on them are retried for up to 3 hours
+  account_warm_up_retry = [
+    {
+      ErrorEquals     = ["StepIncompleteError"]
+      IntervalSeconds = 300
+      MaxAttempts     = 36
+      BackoffRate     = 1
+    },
+    {
+      ErrorEquals     = ["States.ALL"]
+      IntervalSeconds = 10
+      MaxAttempts     = 3
+      BackoffRate     = 2
+    }
+  ]
+}
+
+module "account_warm_up_lambda" {
+  source          = "./lambda"
+  name            = "account_warm_up-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Runs the steps of the account warm-up state machine"
+  global_tags     = var.global_tags
+  handler         = "account_warm_up"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.principal_policy_environment, {
+    EVENT_BUS_NAME                      = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                = local.event_archive_bucket
+    EVENT_TABLE_NAME                    = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                        = local.outbox_table
+    EVENT_RETENTION_DAYS                = var.event_retention_days
+    EVENT_SINK_DRIVER                   = var.event_sink_driver
+    EVENT_SINK_TARGET                   = local.event_sink_target
+    EVENT_SINK_AUTH                     = var.event_sink_auth
+    DEBUG                               = "false"
+    NAMESPACE                           = var.namespace
+    AWS_CURRENT_REGION                  = var.aws_region
+    ACCOUNT_DB                          = aws_dynamodb_table.accounts.id
+    LEASE_DB                            = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                  = var.status_shard_count
+    RESET_SQS_URL                       = aws_sqs_queue.account_reset.id
+    ACCOUNT_WARM_UP_BASELINE_S3_KEY     = join("", aws_s3_bucket_object.account_warm_up_baseline.*.key)
+    ACCOUNT_WARM_UP_BASELINE_STACK_NAME = var.account_warm_up_baseline_stack_name
+  })
+}
+
+// CloudFormation template deployed to new accounts by the ApplyBaseline step
+resource "aws_s3_bucket_object" "account_warm_up_baseline" {
+  count  = var.account_warm_up_baseline_template == "" ? 0 : 1
+  bucket = aws_s3_bucket.artifacts.id
+  key    = "fixtures/account_warm_up/baseline.template"
+  source = var.account_warm_up_baseline_template
+  etag   = filemd5(var.account_warm_up_baseline_template)
+}
+
+resource "aws_iam_role" "account_warm_up" {
+  count = local.account_warm_up_count
+  name  = "dce-account-warm-up-${var.namespace}"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "states.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+
+  tags = var.global_tags
+}
+
+resource "aws_iam_role_policy" "account_warm_up_invoke_lambda" {
+  count  = local.account_warm_up_count
+  role   = aws_iam_role.account_warm_up[0].name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "lambda:InvokeFunction"
+      ],
+      "Resource": [
+        "${module.account_warm_up_lambda.arn}"
+      ]
+    }
+  ]
+}
+POLICY
+}
+
+# Started when an account is added, with the AccountCreated event as its
+# input.  Each step records its status on the account.  When a step fails,
+# RecordFailure marks it failed, and the account stays NotReady.
+resource "aws_sfn_state_machine" "account_warm_up" {
+  count    = local.account_warm_up_count
+  name     = "dce-account-warm-up-${var.namespace}"
+  role_arn = aws_iam_role.account_warm_up[0].arn
+  tags     = var.global_tags
+
+  definition = jsonencode({
+    Comment = "Warms up a new account before it becomes Ready"
+    StartAt = "RecordExecution"
+    States = merge({
+      for i, step in local.account_warm_up_states : step => {
+        Type     = "Task"
+        Resource = module.account_warm_up_lambda.arn
+        Parameters = {
+          step             = step
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.account_warm_up_retry
+        Catch = [
+          {
+            ErrorEquals = ["States.ALL"]
+            ResultPath  = "$.error"
+            Next        = "RecordFailure"
+          }
+        ]
+        Next = i + 1 < length(local.account_warm_up_states) ? local.account_warm_up_states[i + 1] : "Ready"
+      }
+      }, {
+      RecordFailure = {
+        Type     = "Task"
+        Resource = module.account_warm_up_lambda.arn
+        Parameters = {
+          step             = "RecordFailure"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+          "error.$"        = "$.error"
+        }
+        ResultPath = null
+        Retry      = [local.account_warm_up_retry[1]]
+        Next       = "Failed"
+      }
+      Ready = {
+        Type = "Succeed"
+      }
+      Failed = {
+        Type  = "Fail"
+        Error = "AccountWarmUpFailed"
+      }
+    })
+  })
+}
@@ -1,6 +1,9 @@
 locals {
   principal_role_name   = "DCEPrincipal${var.namespace == "prod" ? "" : "-${var.namespace}"}"
   principal_policy_name = "DCEPrincipalDefaultPolicy${var.namespace == "prod" ? "" : "-${var.namespace}"}"
+  # SSM parameter the account pool's contact is stored in, once an admin
+  # sets it with PUT /system/pool-contact
+  pool_contact_parameter = var.notification_routing_enabled ? "/${var.namespace}/accounts/pool_contact" : ""
 }
 
 module "accounts_lambda" {
@@ -13,24 +16,51 @@ module "accounts_lambda" {
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
   environment = {
-    DEBUG                          = "false"
-    ACCOUNT_ID                     = local.account_id
-    NAMESPACE                      = var.namespace
-    AWS_CURRENT_REGION             = var.aws_region
-    ACCOUNT_DB                     = aws_dynamodb_table.accounts.id
-    ARTIFACTS_BUCKET               = aws_s3_bucket.artifacts.id
-    LEASE_DB                       = aws_dynamodb_table.leases.id
-    RESET_SQS_URL                  = aws_sqs_queue.account_reset.id
-    ACCOUNT_CREATED_TOPIC_ARN      = aws_sns_topic.account_created.arn
-    ACCOUNT_DELETED_TOPIC_ARN      = aws_sns_topic.account_deleted.arn
-    PRINCIPAL_ROLE_NAME            = local.principal_role_name
-    PRINCIPAL_POLICY_NAME          = local.principal_policy_name
-    PRINCIPAL_IAM_DENY_TAGS        = join(",", var.principal_iam_deny_tags)
-    ALLOWED_REGIONS                = join(",", var.allowed_regions)
-    PRINCIPAL_MAX_SESSION_DURATION = 14400
-    TAG_ENVIRONMENT                = var.namespace == "prod" ? "PROD" : "NON-PROD"
-    TAG_APP_NAME                   = lookup(var.global_tags, "AppName")
-    PRINCIPAL_POLICY_S3_KEY        = aws_s3_bucket_object.principal_policy.key
+    EVENT_BUS_NAME                         = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                   = local.event_archive_bucket
+    EVENT_TABLE_NAME                       = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                           = local.outbox_table
+    EVENT_RETENTION_DAYS                   = var.event_retention_days
+    EVENT_SINK_DRIVER                      = var.event_sink_driver
+    EVENT_SINK_TARGET                      = local.event_sink_target
+    EVENT_SINK_AUTH                        = var.event_sink_auth
+    DEBUG                                  = "false"
+    ACCOUNT_ID                             = local.account_id
+    NAMESPACE                              = var.namespace
+    AWS_CURRENT_REGION                     = var.aws_region
+    ACCOUNT_DB                             = aws_dynamodb_table.accounts.id
+    ARTIFACTS_BUCKET                       = aws_s3_bucket.artifacts.id
+    EXPORTS_PREFIX                         = local.exports_prefix
+    LEASE_DB                               = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                     = var.status_shard_count
+    RESET_SQS_URL                          = aws_sqs_queue.account_reset.id
+    RESET_EVENT_SOURCE_MAPPING_ID          = aws_lambda_event_source_mapping.process_reset_events_from_sqs.uuid
+    POOL_SIMULATION_RESET_HOURS            = var.pool_simulation_reset_hours
+    POOL_SIMULATION_RESET_CONCURRENCY      = var.pool_simulation_reset_concurrency
+    NOTIFICATION_ROUTING_TABLE             = local.notification_routing_table
+    SETTINGS_TABLE                         = local.settings_table
+    POOL_CONTACT_PARAMETER                 = local.pool_contact_parameter
+    API_KEY_TABLE                          = local.api_key_table
+    API_CLIENT_ROLE_NAME                   = local.api_client_role_name
+    API_CACHE_MAX_AGE                      = var.api_cache_max_age
+    ACCOUNT_CREATED_TOPIC_ARN              = aws_sns_topic.account_created.arn
+    ACCOUNT_DELETED_TOPIC_ARN              = aws_sns_topic.account_deleted.arn
+    PRINCIPAL_ROLE_NAME                    = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                  = local.principal_policy_name
+    PRINCIPAL_IAM_DENY_TAGS                = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                        = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION         = 14400
+    TAG_ENVIRONMENT                        = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                           = lookup(var.global_tags, "AppName")
+    PRINCIPAL_POLICY_S3_KEY                = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY = local.principal_policy_customizations_key
+    ACCOUNT_WARM_UP_STEPS                  = local.account_warm_up_steps
+    ACCOUNT_WARM_UP_STATE_MACHINE_ARN      = join("", aws_sfn_state_machine.account_warm_up.*.id)
+    COGNITO_USER_POOL_ID                   = module.api_gateway_authorizer.user_pool_id
+    IDENTITY_ADMIN_ROLE_ARN                = module.api_gateway_authorizer.admin_role_arn
+    IDENTITY_USER_ROLE_ARN                 = module.api_gateway_authorizer.user_role_arn
+    IDENTITY_ADMIN_POLICY_ARN              = module.api_gateway_authorizer.admin_policy_arn
+    IDENTITY_USER_POLICY_ARN               = module.api_gateway_authorizer.user_policy_arn
   }
 }
 
@@ -43,3 +73,93 @@ resource "aws_sns_topic" "account_deleted" {
   name = "account-deleted-${var.namespace}"
   tags = var.global_tags
 }
+
+# Allow pausing and resuming the reset pipeline
+resource "aws_iam_role_policy" "accounts_lambda_reset_pipeline" {
+  role   = module.accounts_lambda.execution_role_name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "lambda:GetEventSourceMapping",
+        "lambda:UpdateEventSourceMapping"
+      ],
+      "Resource": [
+        "${local.reset_event_source_mapping_arn}"
+      ]
+    }
+  ]
+}
+POLICY
+}
+
+# Allow moving accounts between organizational units, when DCE is deployed
+# to the organization's management account
+resource "aws_iam_role_policy" "accounts_lambda_organizations" {
+  role   = module.accounts_lambda.execution_role_name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "organizations:ListParents",
+        "organizations:MoveAccount"
+      ],
+      "Resource": "*"
+    }
+  ]
+}
+POLICY
+}
+
+# Allow bootstrapping the Cognito users and groups, and mapping IAM roles to
+# the API policies
+resource "aws_iam_role_policy" "accounts_lambda_identities" {
+  role   = module.accounts_lambda.execution_role_name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "cognito-idp:CreateGroup",
+        "cognito-idp:UpdateGroup",
+        "cognito-idp:AdminGetUser",
+        "cognito-idp:AdminCreateUser",
+        "cognito-idp:AdminAddUserToGroup"
+      ],
+      "Resource": [
+        "${module.api_gateway_authorizer.user_pool_arn}"
+      ]
+    },
+    {
+      "Effect": "Allow",
+      "Action": "iam:PassRole",
+      "Resource": [
+        "${module.api_gateway_authorizer.admin_role_arn}",
+        "${module.api_gateway_authorizer.user_role_arn}"
+      ]
+    },
+    {
+      "Effect": "Allow",
+      "Action": "iam:AttachRolePolicy",
+      "Resource": "*",
+      "Condition": {
+        "ArnEquals": {
+          "iam:PolicyARN": [
+            "${module.api_gateway_authorizer.admin_policy_arn}",
+            "${module.api_gateway_authorizer.user_policy_arn}"
+          ]
+        }
+      }
+    }
+  ]
+}
+POLICY
+}
@@ -1,6 +1,7 @@
 locals {
   principal_policy     = var.principal_policy == "" ? "${path.module}/fixtures/policies/principal_policy.tmpl" : var.principal_policy
   artifact_bucket_name = "${local.account_id}-dce-artifacts-${var.namespace}"
+  exports_prefix       = "exports"
 }
 
 
@@ -29,6 +30,52 @@ resource "aws_s3_bucket" "artifacts" {
     enabled = true
   }
 
+  # Expire the snapshots taken before account resets
+  lifecycle_rule {
+    id      = "reset-snapshots"
+    enabled = true
+    prefix  = "${local.reset_snapshot_prefix}/"
+
+    expiration {
+      days = var.reset_snapshot_retention_days
+    }
+
+    noncurrent_version_expiration {
+      days = 1
+    }
+  }
+
+  # Expire CSV usage reports, once their download URLs have expired
+  lifecycle_rule {
+    id      = "usage-reports"
+    enabled = true
+    prefix  = "${local.usage_reports_prefix}/"
+
+    expiration {
+      days = 1
+    }
+
+    noncurrent_version_expiration {
+      days = 1
+    }
+  }
+
+  # Expire large CSV and JSON exports of the accounts and leases lists, once
+  # their download URLs have expired
+  lifecycle_rule {
+    id      = "exports"
+    enabled = true
+    prefix  = "${local.exports_prefix}/"
+
+    expiration {
+      days = 1
+    }
+
+    noncurrent_version_expiration {
+      days = 1
+    }
+  }
+
   tags = var.global_tags
 }
 
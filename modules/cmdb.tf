@@ -0,0 +1,74 @@
+locals {
+  cmdb_count = var.cmdb_push_url == "" && var.cmdb_pull_url == "" ? 0 : 1
+}
+
+module "cmdb_sync_lambda" {
+  source          = "./lambda"
+  name            = "cmdb_sync-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Pushes account ownership to an external CMDB, and pulls cost center tags back onto accounts"
+  global_tags     = var.global_tags
+  handler         = "cmdb_sync"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    EVENT_BUS_NAME                         = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                   = local.event_archive_bucket
+    EVENT_TABLE_NAME                       = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                           = local.outbox_table
+    EVENT_RETENTION_DAYS                   = var.event_retention_days
+    EVENT_SINK_DRIVER                      = var.event_sink_driver
+    EVENT_SINK_TARGET                      = local.event_sink_target
+    EVENT_SINK_AUTH                        = var.event_sink_auth
+    DEBUG                                  = "false"
+    ACCOUNT_ID                             = local.account_id
+    NAMESPACE                              = var.namespace
+    AWS_CURRENT_REGION                     = var.aws_region
+    ACCOUNT_DB                             = aws_dynamodb_table.accounts.id
+    LEASE_DB                               = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                     = var.status_shard_count
+    ARTIFACTS_BUCKET                       = aws_s3_bucket.artifacts.id
+    RESET_SQS_URL                          = aws_sqs_queue.account_reset.id
+    ACCOUNT_CREATED_TOPIC_ARN              = aws_sns_topic.account_created.arn
+    ACCOUNT_DELETED_TOPIC_ARN              = aws_sns_topic.account_deleted.arn
+    PRINCIPAL_ROLE_NAME                    = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                  = local.principal_policy_name
+    PRINCIPAL_IAM_DENY_TAGS                = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                        = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION         = 14400
+    TAG_ENVIRONMENT                        = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                           = lookup(var.global_tags, "AppName")
+    PRINCIPAL_POLICY_S3_KEY                = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY = local.principal_policy_customizations_key
+    CMDB_PUSH_URL                          = var.cmdb_push_url
+    CMDB_PUSH_METHOD                       = var.cmdb_push_method
+    CMDB_FIELDS                            = length(var.cmdb_fields) == 0 ? "" : jsonencode(var.cmdb_fields)
+    CMDB_PULL_URL                          = var.cmdb_pull_url
+    CMDB_TAGS                              = length(var.cmdb_tags) == 0 ? "" : jsonencode(var.cmdb_tags)
+    CMDB_USERNAME                          = var.cmdb_username
+    CMDB_API_TOKEN                         = var.cmdb_api_token
+  }
+}
+
+resource "aws_cloudwatch_event_rule" "cmdb_sync" {
+  count               = local.cmdb_count
+  name                = "cmdb-sync-${var.namespace}"
+  description         = "Sync accounts with the CMDB"
+  schedule_expression = var.cmdb_sync_schedule_expression
+}
+
+resource "aws_cloudwatch_event_target" "cmdb_sync" {
+  count     = local.cmdb_count
+  rule      = aws_cloudwatch_event_rule.cmdb_sync[0].name
+  target_id = "cmdb_sync_${var.namespace}"
+  arn       = module.cmdb_sync_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_cmdb_sync" {
+  count         = local.cmdb_count
+  statement_id  = "AllowCloudWatchCMDBSync${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.cmdb_sync_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.cmdb_sync[0].arn
+}
@@ -0,0 +1,40 @@
+module "dead_letter_queues_lambda" {
+  source          = "./lambda"
+  name            = "dead_letter_queues-${var.namespace}"
+  namespace       = var.namespace
+  description     = "API /dlq endpoints, for listing and replaying failed messages"
+  global_tags     = var.global_tags
+  handler         = "dead_letter_queues"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG                                 = "false"
+    NAMESPACE                             = var.namespace
+    AWS_CURRENT_REGION                    = var.aws_region
+    RESET_SQS_URL                         = aws_sqs_queue.account_reset.id
+    RESET_DLQ_URL                         = aws_sqs_queue.account_reset_dlq.id
+    UPDATE_PRINCIPAL_POLICY_DLQ_URL       = module.update_principal_policy.dlq_url
+    UPDATE_PRINCIPAL_POLICY_FUNCTION_NAME = module.update_principal_policy.name
+  }
+}
+
+# Allow failed events to be replayed to the functions they failed in
+resource "aws_iam_role_policy" "dead_letter_queues_lambda_invoke" {
+  role   = module.dead_letter_queues_lambda.execution_role_name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "lambda:InvokeFunction"
+      ],
+      "Resource": [
+        "${module.update_principal_policy.arn}"
+      ]
+    }
+  ]
+}
+POLICY
+}
@@ -0,0 +1,10 @@
+locals {
+  // Opt-in runtime diagnostics of the long-running components,
+  // which are uploaded under s3://<artifacts bucket>/diagnostics/
+  diagnostics_environment = {
+    DIAGNOSTICS_ENABLED  = var.diagnostics_enabled
+    DIAGNOSTICS_BUCKET   = aws_s3_bucket.artifacts.id
+    DIAGNOSTICS_PREFIX   = "diagnostics"
+    DIAGNOSTICS_INTERVAL = var.diagnostics_interval
+  }
+}
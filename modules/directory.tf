@@ -0,0 +1,91 @@
+locals {
+  directory_sync_count = var.directory_driver == "" ? 0 : 1
+
+  directory_environment = {
+    DIRECTORY_DRIVER          = var.directory_driver
+    DIRECTORY_URL             = var.directory_url
+    DIRECTORY_API_TOKEN       = var.directory_api_token
+    DIRECTORY_TENANT_ID       = var.directory_tenant_id
+    DIRECTORY_CLIENT_ID       = var.directory_client_id
+    DIRECTORY_CLIENT_SECRET   = var.directory_client_secret
+    DIRECTORY_GROUP_QUOTAS    = length(var.directory_group_quotas) > 0 ? jsonencode(var.directory_group_quotas) : ""
+    DIRECTORY_APPROVER_GROUPS = join(",", var.directory_approver_groups)
+  }
+
+  # Environment of the lambdas which create leases or identify principals,
+  # so every spelling of a principal's ID is recorded as one principal
+  principal_id_environment = {
+    PRINCIPAL_ID_PATTERNS      = length(var.principal_id_patterns) > 0 ? jsonencode(var.principal_id_patterns) : ""
+    PRINCIPAL_ID_NORMALIZATION = join(",", var.principal_id_normalization)
+  }
+}
+
+module "directory_sync_lambda" {
+  source          = "./lambda"
+  name            = "directory_sync-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Offboards principals deactivated in the directory"
+  global_tags     = var.global_tags
+  handler         = "directory_sync"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.directory_environment, local.principal_policy_environment, local.notification_environment, {
+    EVENT_BUS_NAME                    = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET              = local.event_archive_bucket
+    EVENT_TABLE_NAME                  = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                      = local.outbox_table
+    EVENT_RETENTION_DAYS              = var.event_retention_days
+    EVENT_SINK_DRIVER                 = var.event_sink_driver
+    EVENT_SINK_TARGET                 = local.event_sink_target
+    EVENT_SINK_AUTH                   = var.event_sink_auth
+    DEBUG                             = "false"
+    NAMESPACE                         = var.namespace
+    AWS_CURRENT_REGION                = var.aws_region
+    RESET_SQS_URL                     = aws_sqs_queue.account_reset.id
+    ACCOUNT_DB                        = aws_dynamodb_table.accounts.id
+    LEASE_DB                          = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                = var.status_shard_count
+    LEASE_ADDED_TOPIC                 = aws_sns_topic.lease_added.arn
+    LEASE_PROVISION_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_TEARDOWN_STATE_MACHINE_ARN  = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    DIRECTORY_OFFBOARDING_EMAILS      = join(",", var.directory_offboarding_emails)
+  })
+}
+
+// Allow directory_sync lambda to email the managers of offboarded principals with SES
+resource "aws_iam_role_policy" "directory_sync_ses" {
+  role   = module.directory_sync_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+resource "aws_cloudwatch_event_rule" "directory_sync" {
+  count               = local.directory_sync_count
+  name                = "directory-sync-${var.namespace}"
+  description         = "Offboard principals deactivated in the directory"
+  schedule_expression = var.directory_sync_schedule_expression
+}
+
+resource "aws_cloudwatch_event_target" "directory_sync" {
+  count     = local.directory_sync_count
+  rule      = aws_cloudwatch_event_rule.directory_sync[0].name
+  target_id = "directory_sync_${var.namespace}"
+  arn       = module.directory_sync_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_directory_sync" {
+  count         = local.directory_sync_count
+  statement_id  = "AllowCloudWatchDirectorySync${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.directory_sync_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.directory_sync[0].arn
+}
@@ -22,6 +22,16 @@ resource "aws_dynamodb_table" "accounts" {
     write_capacity  = var.accounts_table_wcu
   }
 
+  # Sharded account status, eg. "Ready#shard2". Only written
+  # when var.status_shard_count is more than 1
+  global_secondary_index {
+    name            = "AccountStatusShard"
+    hash_key        = "AccountStatusShard"
+    projection_type = "ALL"
+    read_capacity   = var.accounts_table_rcu
+    write_capacity  = var.accounts_table_wcu
+  }
+
   server_side_encryption {
     enabled = true
   }
@@ -42,6 +52,11 @@ resource "aws_dynamodb_table" "accounts" {
     type = "S"
   }
 
+  attribute {
+    name = "AccountStatusShard"
+    type = "S"
+  }
+
   tags = var.global_tags
   /*
   Other attributes:
@@ -79,6 +94,16 @@ resource "aws_dynamodb_table" "leases" {
     write_capacity  = var.leases_table_wcu
   }
 
+  # Sharded lease status, eg. "Active#shard2". Only written
+  # when var.status_shard_count is more than 1
+  global_secondary_index {
+    name            = "LeaseStatusShard"
+    hash_key        = "LeaseStatusShard"
+    projection_type = "ALL"
+    read_capacity   = var.leases_table_rcu
+    write_capacity  = var.leases_table_wcu
+  }
+
   global_secondary_index {
     name            = "LeaseId"
     hash_key        = "Id"
@@ -102,6 +127,11 @@ resource "aws_dynamodb_table" "leases" {
     type = "S"
   }
 
+  attribute {
+    name = "LeaseStatusShard"
+    type = "S"
+  }
+
   # Principal ID
   attribute {
     name = "PrincipalId"
@@ -157,3 +187,210 @@ resource "aws_dynamodb_table" "usage" {
 
   tags = var.global_tags
 }
+
+resource "aws_dynamodb_table" "usage_aggregates" {
+  name           = "UsageAggregates${local.table_suffix}"
+  read_capacity  = var.usage_table_rcu
+  write_capacity = var.usage_table_wcu
+  hash_key       = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  # Lease or principal, and the start of its budget period
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  # TTL enabled attribute
+  ttl {
+    attribute_name = "TimeToLive"
+    enabled        = true
+  }
+
+  tags = var.global_tags
+}
+
+resource "aws_dynamodb_table" "events" {
+  name           = "Events${local.table_suffix}"
+  read_capacity  = var.events_table_rcu
+  write_capacity = var.events_table_wcu
+  hash_key       = "Day"
+  range_key      = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  # Day the event was published, eg. 2020-01-02
+  attribute {
+    name = "Day"
+    type = "S"
+  }
+
+  # Time of day the event was published, and a unique suffix
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  # TTL enabled attribute
+  ttl {
+    attribute_name = "ExpiresOn"
+    enabled        = true
+  }
+
+  tags = var.global_tags
+}
+
+# Events of lease and account changes waiting to be published
+resource "aws_dynamodb_table" "outbox" {
+  count          = var.outbox_enabled ? 1 : 0
+  name           = "Outbox${local.table_suffix}"
+  read_capacity  = var.events_table_rcu
+  write_capacity = var.events_table_wcu
+  hash_key       = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  # Time the event was written, and a unique suffix
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  outbox_table = join("", aws_dynamodb_table.outbox.*.id)
+}
+
+# Lease requests waiting for a Ready account
+resource "aws_dynamodb_table" "lease_waitlist" {
+  count          = var.lease_waitlist_enabled ? 1 : 0
+  name           = "LeaseWaitlist${local.table_suffix}"
+  read_capacity  = var.leases_table_rcu
+  write_capacity = var.leases_table_wcu
+  hash_key       = "PrincipalId"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  attribute {
+    name = "PrincipalId"
+    type = "S"
+  }
+
+  # Remove requests which stopped waiting
+  ttl {
+    attribute_name = "ExpiresOn"
+    enabled        = true
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  lease_waitlist_table = join("", aws_dynamodb_table.lease_waitlist.*.id)
+}
+
+# The accounts of each principal's Active leases, so new leases can't exceed
+# max_leases_per_principal
+resource "aws_dynamodb_table" "principal_leases" {
+  name           = "PrincipalLeases${local.table_suffix}"
+  read_capacity  = var.leases_table_rcu
+  write_capacity = var.leases_table_wcu
+  hash_key       = "PrincipalId"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  attribute {
+    name = "PrincipalId"
+    type = "S"
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  principal_leases_table = aws_dynamodb_table.principal_leases.id
+}
+
+# System-wide settings operators change with the API, eg. the default budgets
+resource "aws_dynamodb_table" "settings" {
+  name           = "Settings${local.table_suffix}"
+  read_capacity  = var.leases_table_rcu
+  write_capacity = var.leases_table_wcu
+  hash_key       = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  settings_table = aws_dynamodb_table.settings.id
+}
+
+# Rules routing notifications to Slack channels, emails and alerts
+resource "aws_dynamodb_table" "notification_routing" {
+  count          = var.notification_routing_enabled ? 1 : 0
+  name           = "NotificationRouting${local.table_suffix}"
+  read_capacity  = var.leases_table_rcu
+  write_capacity = var.leases_table_wcu
+  hash_key       = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  notification_routing_table = join("", aws_dynamodb_table.notification_routing.*.id)
+}
+
+# API keys of machine clients, with the scopes of the API they may call
+resource "aws_dynamodb_table" "api_keys" {
+  count          = var.api_keys_enabled ? 1 : 0
+  name           = "ApiKeys${local.table_suffix}"
+  read_capacity  = var.leases_table_rcu
+  write_capacity = var.leases_table_wcu
+  hash_key       = "Id"
+
+  server_side_encryption {
+    enabled = true
+  }
+
+  attribute {
+    name = "Id"
+    type = "S"
+  }
+
+  tags = var.global_tags
+}
+
+locals {
+  api_key_table = join("", aws_dynamodb_table.api_keys.*.id)
+}
@@ -0,0 +1,40 @@
+module "end_leases_lambda" {
+  source          = "./lambda"
+  name            = "end_leases-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Ends leases in bulk. Ends expired leases on a schedule, or the leases listed in the invocation."
+  global_tags     = var.global_tags
+  handler         = "end_leases"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.diagnostics_environment, local.principal_policy_environment, {
+    EVENT_BUS_NAME                   = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET             = local.event_archive_bucket
+    EVENT_TABLE_NAME                 = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                     = local.outbox_table
+    EVENT_RETENTION_DAYS             = var.event_retention_days
+    EVENT_SINK_DRIVER                = var.event_sink_driver
+    EVENT_SINK_TARGET                = local.event_sink_target
+    EVENT_SINK_AUTH                  = var.event_sink_auth
+    AWS_CURRENT_REGION               = var.aws_region
+    ACCOUNT_DB                       = aws_dynamodb_table.accounts.id
+    LEASE_DB                         = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT               = var.status_shard_count
+    RESET_SQS_URL                    = aws_sqs_queue.account_reset.id
+    LEASE_TEARDOWN_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    PRINCIPAL_BUDGET_AMOUNT          = var.principal_budget_amount
+    PRINCIPAL_BUDGET_PERIOD          = var.principal_budget_period
+    END_LEASES_BATCH_SIZE            = var.end_leases_batch_size
+  })
+}
+
+// End expired leases in bulk on a timer (cloudwatch event)
+module "end_leases_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "end_leases-${var.namespace}"
+  lambda_function_arn = module.end_leases_lambda.arn
+  schedule_expression = var.end_leases_schedule_expression
+  description         = "Ends expired leases in bulk"
+  enabled             = var.bulk_end_leases_enabled
+}
@@ -0,0 +1,86 @@
+locals {
+  event_archive_count       = var.event_archive_toggle == "true" ? 1 : 0
+  event_archive_bucket_name = "${local.account_id}-dce-events-${var.namespace}"
+  # Empty when archiving is disabled
+  event_archive_bucket = local.event_archive_count > 0 ? aws_s3_bucket.event_archive[0].id : ""
+}
+
+# Configure an S3 Bucket to archive every published domain event,
+# partitioned by event type and date
+resource "aws_s3_bucket" "event_archive" {
+  count  = local.event_archive_count
+  bucket = local.event_archive_bucket_name
+
+  # Allow Terraform to destroy the bucket
+  # (so ephemeral PR environments can be torn down)
+  force_destroy = var.event_archive_retention_days == 0
+
+  # Encrypt objects by default
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = "AES256"
+      }
+    }
+  }
+
+  versioning {
+    enabled = true
+  }
+
+  # Prevent archived events from being deleted or overwritten
+  # until the retention period has passed
+  dynamic "object_lock_configuration" {
+    for_each = var.event_archive_retention_days > 0 ? [var.event_archive_retention_days] : []
+    content {
+      object_lock_enabled = "Enabled"
+      rule {
+        default_retention {
+          mode = "COMPLIANCE"
+          days = object_lock_configuration.value
+        }
+      }
+    }
+  }
+
+  tags = var.global_tags
+}
+
+resource "aws_s3_bucket_public_access_block" "event_archive" {
+  count                   = local.event_archive_count
+  bucket                  = aws_s3_bucket.event_archive[0].id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+# Enforce SSL only access to the bucket
+resource "aws_s3_bucket_policy" "event_archive_ssl_policy" {
+  count  = local.event_archive_count
+  bucket = aws_s3_bucket.event_archive[0].id
+
+  # Wait for the public access block, as both update the bucket policy
+  depends_on = [aws_s3_bucket_public_access_block.event_archive]
+
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [
+      {
+        "Sid": "DenyInsecureCommunications",
+        "Effect": "Deny",
+        "Principal": "*",
+        "Action": "s3:*",
+        "Resource": "${aws_s3_bucket.event_archive[0].arn}/*",
+        "Condition": {
+            "Bool": {
+                "aws:SecureTransport": "false"
+            }
+        }
+      }
+    ]
+}
+POLICY
+
+}
@@ -0,0 +1,16 @@
+locals {
+  # Create a Kinesis stream for the event sink, unless one is configured
+  event_sink_stream_count = var.event_sink_driver == "kinesis" && var.event_sink_target == "" ? 1 : 0
+  event_sink_target       = local.event_sink_stream_count > 0 ? aws_kinesis_stream.event_sink[0].name : var.event_sink_target
+}
+
+# Kinesis stream every domain event is mirrored to
+resource "aws_kinesis_stream" "event_sink" {
+  count            = local.event_sink_stream_count
+  name             = "dce-events-${var.namespace}"
+  shard_count      = var.event_sink_shard_count
+  retention_period = 24
+  encryption_type  = "KMS"
+  kms_key_id       = "alias/aws/kinesis"
+  tags             = var.global_tags
+}
@@ -0,0 +1,17 @@
+module "events_lambda" {
+  source          = "./lambda"
+  name            = "events-${var.namespace}"
+  namespace       = var.namespace
+  description     = "API /events endpoint, for listing the activity feed"
+  global_tags     = var.global_tags
+  handler         = "events"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG              = "false"
+    NAMESPACE          = var.namespace
+    AWS_CURRENT_REGION = var.aws_region
+    EVENT_TABLE_NAME   = aws_dynamodb_table.events.id
+    OUTBOX_TABLE       = local.outbox_table
+  }
+}
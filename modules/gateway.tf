@@ -63,7 +63,11 @@ data "template_file" "api_swagger" {
     accounts_lambda             = module.accounts_lambda.invoke_arn
     usages_lambda               = module.usage_lambda.invoke_arn
     credentials_web_page_lambda = module.credentials_web_page_lambda.invoke_arn
+    dead_letter_queues_lambda   = module.dead_letter_queues_lambda.invoke_arn
+    events_lambda               = module.events_lambda.invoke_arn
+    slack_lambda                = module.slack_lambda.invoke_arn
     namespace                   = "${var.namespace_prefix}-${var.namespace}"
+    api_security_definition     = local.api_security_definition
   }
 }
 
@@ -99,6 +103,30 @@ resource "aws_lambda_permission" "allow_api_gateway_usages_lambda" {
   source_arn    = "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*"
 }
 
+resource "aws_lambda_permission" "allow_api_gateway_dead_letter_queues_lambda" {
+  function_name = module.dead_letter_queues_lambda.arn
+  statement_id  = "AllowExecutionFromApiGateway"
+  action        = "lambda:InvokeFunction"
+  principal     = "apigateway.amazonaws.com"
+  source_arn    = "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*"
+}
+
+resource "aws_lambda_permission" "allow_api_gateway_events_lambda" {
+  function_name = module.events_lambda.arn
+  statement_id  = "AllowExecutionFromApiGateway"
+  action        = "lambda:InvokeFunction"
+  principal     = "apigateway.amazonaws.com"
+  source_arn    = "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*"
+}
+
+resource "aws_lambda_permission" "allow_api_gateway_slack_lambda" {
+  function_name = module.slack_lambda.arn
+  statement_id  = "AllowExecutionFromApiGateway"
+  action        = "lambda:InvokeFunction"
+  principal     = "apigateway.amazonaws.com"
+  source_arn    = "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*"
+}
+
 
 
 resource "aws_lambda_permission" "allow_api_gateway_credentials_web_page_lambda" {
@@ -154,3 +182,60 @@ resource "aws_iam_policy" "api_execute_admin" {
 }
 JSON
 }
+
+locals {
+  api_client_role_name = "${var.namespace_prefix}-api-client-${var.namespace}"
+  api_client_count     = var.api_keys_enabled && length(var.api_client_principals) > 0 ? 1 : 0
+}
+
+// Role for machine clients, eg. CI, to call the API with.  The API
+// rejects requests signed with it unless they have an API key, so clients
+// are limited to the scopes of their key.
+resource "aws_iam_role" "api_client" {
+  count       = local.api_client_count
+  name        = local.api_client_role_name
+  description = "Calls the ${var.namespace_prefix} API with an API key"
+  tags        = var.global_tags
+
+  assume_role_policy = <<JSON
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "AWS": ${jsonencode(var.api_client_principals)}
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+JSON
+}
+
+resource "aws_iam_role_policy" "api_client" {
+  count = local.api_client_count
+  role  = aws_iam_role.api_client[0].id
+
+  policy = <<JSON
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "execute-api:Invoke"
+      ],
+      "Resource": [
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/leases",
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/leases/*",
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/accounts",
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/accounts/*",
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/usage",
+        "${aws_api_gateway_rest_api.gateway_api.execution_arn}/*/*/usage/*"
+      ]
+    }
+  ]
+}
+JSON
+}
@@ -88,7 +88,9 @@ resource "aws_iam_role_policy" "lambda_inline" {
             "Action": [
                 "sts:AssumeRole",
                 "sts:GetCallerIdentity",
-                "events:PutEvents"
+                "events:PutEvents",
+                "kinesis:PutRecord",
+                "states:StartExecution"
             ],
             "Resource": "*"
         }
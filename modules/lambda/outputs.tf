@@ -16,3 +16,7 @@ output execution_role_name {
 output execution_role_arn {
   value = aws_iam_role.lambda_execution.arn
 }
+
+output dlq_url {
+  value = join("", aws_sqs_queue.lambda_dlq.*.id)
+}
@@ -0,0 +1,182 @@
+locals {
+  lease_archive_count       = var.lease_archive_enabled ? 1 : 0
+  lease_archive_bucket_name = "${local.account_id}-dce-lease-archive-${var.namespace}"
+  # Empty when archiving is disabled
+  lease_archive_bucket = local.lease_archive_count > 0 ? aws_s3_bucket.lease_archive[0].id : ""
+}
+
+# Configure an S3 Bucket to archive leases which ended a while ago,
+# partitioned by the day they ended
+resource "aws_s3_bucket" "lease_archive" {
+  count  = local.lease_archive_count
+  bucket = local.lease_archive_bucket_name
+
+  # Allow Terraform to destroy the bucket
+  # (so ephemeral PR environments can be torn down)
+  force_destroy = true
+
+  # Encrypt objects by default
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = "AES256"
+      }
+    }
+  }
+
+  versioning {
+    enabled = true
+  }
+
+  # Archived leases are rarely read
+  lifecycle_rule {
+    id      = "leases"
+    enabled = true
+    prefix  = "leases/"
+
+    transition {
+      days          = 30
+      storage_class = "STANDARD_IA"
+    }
+
+    noncurrent_version_expiration {
+      days = 1
+    }
+  }
+
+  tags = var.global_tags
+}
+
+resource "aws_s3_bucket_public_access_block" "lease_archive" {
+  count                   = local.lease_archive_count
+  bucket                  = aws_s3_bucket.lease_archive[0].id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+# Query archived leases with Athena, eg.
+#   SELECT * FROM leases WHERE ended >= '2020-01-01' AND principalid = 'jdoe'
+resource "aws_glue_catalog_database" "lease_archive" {
+  count = local.lease_archive_count
+  name  = "dce_lease_archive_${replace(var.namespace, "-", "_")}"
+}
+
+resource "aws_glue_catalog_table" "lease_archive" {
+  count         = local.lease_archive_count
+  name          = "leases"
+  database_name = aws_glue_catalog_database.lease_archive[0].name
+  table_type    = "EXTERNAL_TABLE"
+
+  # Project the partitions, so new days don't have to be added
+  parameters = {
+    "projection.enabled"        = "true"
+    "projection.ended.type"     = "date"
+    "projection.ended.format"   = "yyyy-MM-dd"
+    "projection.ended.range"    = "2019-01-01,NOW"
+    "projection.ended.interval" = "1"
+    "projection.ended.unit"     = "DAYS"
+    "storage.location.template" = "s3://${aws_s3_bucket.lease_archive[0].id}/leases/ended=$${ended}/"
+    "classification"            = "json"
+  }
+
+  partition_keys {
+    name = "ended"
+    type = "string"
+  }
+
+  storage_descriptor {
+    location      = "s3://${aws_s3_bucket.lease_archive[0].id}/leases/"
+    input_format  = "org.apache.hadoop.mapred.TextInputFormat"
+    output_format = "org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat"
+
+    ser_de_info {
+      serialization_library = "org.openx.data.jsonserde.JsonSerDe"
+      parameters = {
+        "ignore.malformed.json" = "true"
+      }
+    }
+
+    columns {
+      name = "id"
+      type = "string"
+    }
+    columns {
+      name = "accountid"
+      type = "string"
+    }
+    columns {
+      name = "principalid"
+      type = "string"
+    }
+    columns {
+      name = "leasestatus"
+      type = "string"
+    }
+    columns {
+      name = "leasestatusreason"
+      type = "string"
+    }
+    columns {
+      name = "createdon"
+      type = "bigint"
+    }
+    columns {
+      name = "leasestatusmodifiedon"
+      type = "bigint"
+    }
+    columns {
+      name = "expireson"
+      type = "bigint"
+    }
+    columns {
+      name = "budgetamount"
+      type = "double"
+    }
+    columns {
+      name = "budgetcurrency"
+      type = "string"
+    }
+    columns {
+      name = "budgetnotificationemails"
+      type = "array<string>"
+    }
+    columns {
+      name = "notes"
+      type = "string"
+    }
+  }
+}
+
+module "archive_leases_lambda" {
+  source          = "./lambda"
+  name            = "archive_leases-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Archives leases which ended a while ago to S3"
+  global_tags     = var.global_tags
+  handler         = "archive_leases"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.diagnostics_environment, {
+    DEBUG                    = "false"
+    NAMESPACE                = var.namespace
+    AWS_CURRENT_REGION       = var.aws_region
+    ACCOUNT_DB               = aws_dynamodb_table.accounts.id
+    LEASE_DB                 = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT       = var.status_shard_count
+    LEASE_ARCHIVE_BUCKET     = local.lease_archive_bucket
+    LEASE_ARCHIVE_AFTER_DAYS = var.lease_archive_after_days
+  })
+}
+
+// Archive leases on a timer (cloudwatch event)
+module "archive_leases_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "archive_leases-${var.namespace}"
+  lambda_function_arn = module.archive_leases_lambda.arn
+  schedule_expression = var.lease_archive_schedule_expression
+  description         = "Archives leases which ended a while ago to S3"
+  enabled             = var.lease_archive_enabled
+}
@@ -7,13 +7,15 @@ module "lease_auth_lambda" {
   handler         = "lease_auth"
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
-  environment = {
+  environment = merge(local.auth_environment, local.principal_id_environment, {
     DEBUG                              = "false"
     NAMESPACE                          = var.namespace
     AWS_CURRENT_REGION                 = var.aws_region
     ACCOUNT_DB                         = aws_dynamodb_table.accounts.id
     LEASE_DB                           = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                 = var.status_shard_count
     COGNITO_USER_POOL_ID               = module.api_gateway_authorizer.user_pool_id
     COGNITO_ROLES_ATTRIBUTE_ADMIN_NAME = var.cognito_roles_attribute_admin_name
-  }
+    LEASE_AUTH_SESSION_DURATION        = var.lease_auth_session_duration
+  })
 }
@@ -0,0 +1,53 @@
+locals {
+  // Digests are sent in the morning (UTC), weekly ones on Mondays
+  lease_digest_schedule_expression = var.lease_digest_period == "daily" ? "cron(0 8 * * ? *)" : "cron(0 8 ? * MON *)"
+}
+
+module "lease_digest_lambda" {
+  source          = "./lambda"
+  name            = "lease_digest-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Emails a digest of leases to principals and admins"
+  global_tags     = var.global_tags
+  handler         = "lease_digest"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, {
+    DEBUG                           = "false"
+    NAMESPACE                       = var.namespace
+    AWS_CURRENT_REGION              = var.aws_region
+    ACCOUNT_DB                      = aws_dynamodb_table.accounts.id
+    LEASE_DB                        = aws_dynamodb_table.leases.id
+    USAGE_CACHE_DB                  = aws_dynamodb_table.usage.id
+    STATUS_SHARD_COUNT              = var.status_shard_count
+    LEASE_DIGEST_PERIOD             = var.lease_digest_period
+    LEASE_DIGEST_ADMIN_EMAILS       = join(",", var.lease_digest_admin_emails)
+    LEASE_DIGEST_PRINCIPALS_ENABLED = var.lease_digest_principals_enabled
+  })
+}
+
+// Allow lease_digest lambda to send emails with SES
+resource "aws_iam_role_policy" "lease_digest_ses" {
+  role   = module.lease_digest_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Send the digests on a timer (cloudwatch event)
+module "lease_digest_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "lease_digest-${var.namespace}"
+  lambda_function_arn = module.lease_digest_lambda.arn
+  schedule_expression = local.lease_digest_schedule_expression
+  description         = "Emails a digest of leases to principals and admins"
+  enabled             = var.lease_digest_enabled
+}
@@ -0,0 +1,48 @@
+module "lease_expiry_warnings_lambda" {
+  source          = "./lambda"
+  name            = "lease_expiry_warnings-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Emails lease holders before their lease expires"
+  global_tags     = var.global_tags
+  handler         = "lease_expiry_warnings"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, {
+    DEBUG                          = "false"
+    NAMESPACE                      = var.namespace
+    AWS_CURRENT_REGION             = var.aws_region
+    ACCOUNT_DB                     = aws_dynamodb_table.accounts.id
+    LEASE_DB                       = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT             = var.status_shard_count
+    EXPIRY_WARNING_HOURS           = join(",", var.expiry_warning_hours)
+    EXPIRY_WARNING_EXTENSION_URL   = var.expiry_warning_extension_url
+    MAX_LEASE_PERIOD               = var.max_lease_period
+    POOL_LEASE_DURATIONS_PARAMETER = local.pool_lease_durations_parameter
+  })
+}
+
+// Allow lease_expiry_warnings lambda to send emails with SES
+resource "aws_iam_role_policy" "lease_expiry_warnings_ses" {
+  role   = module.lease_expiry_warnings_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Check for leases due a warning on a timer (cloudwatch event)
+module "lease_expiry_warnings_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "lease_expiry_warnings-${var.namespace}"
+  lambda_function_arn = module.lease_expiry_warnings_lambda.arn
+  schedule_expression = var.expiry_warnings_schedule_expression
+  description         = "Emails lease holders before their lease expires"
+  enabled             = length(var.expiry_warning_hours) > 0
+}
@@ -0,0 +1,74 @@
+module "lease_waitlist_lambda" {
+  source          = "./lambda"
+  name            = "lease_waitlist-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Leases Ready accounts to the lease requests on the waitlist"
+  global_tags     = var.global_tags
+  handler         = "lease_waitlist"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.directory_environment, local.principal_id_environment, local.notification_environment, local.diagnostics_environment, local.lease_groups_environment, {
+    EVENT_BUS_NAME                    = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET              = local.event_archive_bucket
+    EVENT_TABLE_NAME                  = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                      = local.outbox_table
+    EVENT_RETENTION_DAYS              = var.event_retention_days
+    EVENT_SINK_DRIVER                 = var.event_sink_driver
+    EVENT_SINK_TARGET                 = local.event_sink_target
+    EVENT_SINK_AUTH                   = var.event_sink_auth
+    DEBUG                             = "false"
+    NAMESPACE                         = var.namespace
+    AWS_CURRENT_REGION                = var.aws_region
+    RESET_SQS_URL                     = aws_sqs_queue.account_reset.id
+    ACCOUNT_DB                        = aws_dynamodb_table.accounts.id
+    LEASE_DB                          = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                = var.status_shard_count
+    LEASE_ADDED_TOPIC                 = aws_sns_topic.lease_added.arn
+    MAX_LEASE_BUDGET_AMOUNT           = var.max_lease_budget_amount
+    MAX_LEASE_PERIOD                  = var.max_lease_period
+    DEFAULT_LEASE_LENGTH_IN_DAYS      = var.default_lease_length_in_days
+    POOL_LEASE_DURATIONS_PARAMETER    = local.pool_lease_durations_parameter
+    LEASE_TERMS_PARAMETER             = local.lease_terms_parameter
+    PRINCIPAL_BUDGET_AMOUNT           = var.principal_budget_amount
+    PRINCIPAL_BUDGET_PERIOD           = var.principal_budget_period
+    BUDGET_CURRENCY                   = var.budget_currency
+    BUDGET_CATEGORIES                 = length(var.budget_categories) > 0 ? jsonencode(var.budget_categories) : ""
+    USAGE_CACHE_DB                    = aws_dynamodb_table.usage.id
+    USAGE_AGGREGATE_DB                = aws_dynamodb_table.usage_aggregates.id
+    LEASE_PROVISION_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_WAITLIST_TABLE              = local.lease_waitlist_table
+    LEASE_WAITLIST_MAX_WAIT_HOURS     = var.lease_waitlist_max_wait_hours
+    OPA_URL                           = var.opa_url
+    RESET_DEFAULT_PROFILE             = var.reset_profile
+    ACCOUNT_READINESS_PROBE           = var.account_readiness_probe
+    PRINCIPAL_LEASES_TABLE            = local.principal_leases_table
+    MAX_LEASES_PER_PRINCIPAL          = var.max_leases_per_principal
+    SETTINGS_TABLE                    = local.settings_table
+  })
+}
+
+// Allow lease_waitlist lambda to send emails with SES
+resource "aws_iam_role_policy" "lease_waitlist_ses" {
+  role   = module.lease_waitlist_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Lease accounts to the waitlist on a timer (cloudwatch event)
+module "lease_waitlist_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "lease_waitlist-${var.namespace}"
+  lambda_function_arn = module.lease_waitlist_lambda.arn
+  schedule_expression = var.lease_waitlist_schedule_expression
+  description         = "Leases Ready accounts to the lease requests on the waitlist"
+  enabled             = var.lease_waitlist_enabled
+}
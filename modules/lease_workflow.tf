@@ -0,0 +1,196 @@
+locals {
+  lease_workflows_count = var.lease_workflows_toggle == "true" ? 1 : 0
+
+  # Steps which are waiting on another part of DCE (eg. an account reset)
+  # fail with StepIncompleteError, and are retried for longer than other errors
+  lease_workflow_retry = [
+    {
+      ErrorEquals     = ["StepIncompleteError"]
+      IntervalSeconds = 300
+      MaxAttempts     = 12
+      BackoffRate     = 1
+    },
+    {
+      ErrorEquals     = ["States.ALL"]
+      IntervalSeconds = 10
+      MaxAttempts     = 3
+      BackoffRate     = 2
+    }
+  ]
+}
+
+module "lease_workflow_lambda" {
+  source          = "./lambda"
+  name            = "lease_workflow-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Runs the steps of the lease provisioning and teardown state machines"
+  global_tags     = var.global_tags
+  handler         = "lease_workflow"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG                                  = "false"
+    NAMESPACE                              = var.namespace
+    AWS_CURRENT_REGION                     = var.aws_region
+    ACCOUNT_DB                             = aws_dynamodb_table.accounts.id
+    LEASE_DB                               = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                     = var.status_shard_count
+    ARTIFACTS_BUCKET                       = aws_s3_bucket.artifacts.id
+    PRINCIPAL_ROLE_NAME                    = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                  = local.principal_policy_name
+    PRINCIPAL_POLICY_S3_KEY                = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY = local.principal_policy_customizations_key
+    PRINCIPAL_IAM_DENY_TAGS                = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                        = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION         = 14400
+    TAG_ENVIRONMENT                        = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                           = lookup(var.global_tags, "AppName")
+  }
+}
+
+resource "aws_iam_role" "lease_workflows" {
+  count = local.lease_workflows_count
+  name  = "dce-lease-workflows-${var.namespace}"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "states.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+
+  tags = var.global_tags
+}
+
+resource "aws_iam_role_policy" "lease_workflows_invoke_lambda" {
+  count  = local.lease_workflows_count
+  role   = aws_iam_role.lease_workflows[0].name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "lambda:InvokeFunction"
+      ],
+      "Resource": [
+        "${module.lease_workflow_lambda.arn}"
+      ]
+    }
+  ]
+}
+POLICY
+}
+
+# Started when a lease is created, with the LeaseCreated event as its input.
+# Each step is passed the event, and records the execution ARN on the lease
+resource "aws_sfn_state_machine" "lease_provision" {
+  count    = local.lease_workflows_count
+  name     = "dce-lease-provision-${var.namespace}"
+  role_arn = aws_iam_role.lease_workflows[0].arn
+  tags     = var.global_tags
+
+  definition = jsonencode({
+    Comment = "Provisions access to the account for a new lease"
+    StartAt = "RecordExecution"
+    States = {
+      RecordExecution = {
+        Type     = "Task"
+        Resource = module.lease_workflow_lambda.arn
+        Parameters = {
+          step             = "RecordExecution"
+          workflow         = "provision"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.lease_workflow_retry
+        Next       = "ConfigurePrincipalAccess"
+      }
+      ConfigurePrincipalAccess = {
+        Type     = "Task"
+        Resource = module.lease_workflow_lambda.arn
+        Parameters = {
+          step             = "ConfigurePrincipalAccess"
+          workflow         = "provision"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.lease_workflow_retry
+        Next       = "Provisioned"
+      }
+      Provisioned = {
+        Type = "Succeed"
+      }
+    }
+  })
+}
+
+# Started when a lease ends, with the LeaseEnded event as its input.
+# The account reset is already queued when the lease ends, so this waits for
+# the account to be locked and then returned to the pool
+resource "aws_sfn_state_machine" "lease_teardown" {
+  count    = local.lease_workflows_count
+  name     = "dce-lease-teardown-${var.namespace}"
+  role_arn = aws_iam_role.lease_workflows[0].arn
+  tags     = var.global_tags
+
+  definition = jsonencode({
+    Comment = "Verifies the account is locked, reset, and returned to the pool after a lease ends"
+    StartAt = "RecordExecution"
+    States = {
+      RecordExecution = {
+        Type     = "Task"
+        Resource = module.lease_workflow_lambda.arn
+        Parameters = {
+          step             = "RecordExecution"
+          workflow         = "teardown"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.lease_workflow_retry
+        Next       = "VerifyAccountLocked"
+      }
+      VerifyAccountLocked = {
+        Type     = "Task"
+        Resource = module.lease_workflow_lambda.arn
+        Parameters = {
+          step             = "VerifyAccountLocked"
+          workflow         = "teardown"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.lease_workflow_retry
+        Next       = "VerifyAccountReady"
+      }
+      VerifyAccountReady = {
+        Type     = "Task"
+        Resource = module.lease_workflow_lambda.arn
+        Parameters = {
+          step             = "VerifyAccountReady"
+          workflow         = "teardown"
+          "executionArn.$" = "$$.Execution.Id"
+          "event.$"        = "$$.Execution.Input"
+        }
+        ResultPath = null
+        Retry      = local.lease_workflow_retry
+        Next       = "Ready"
+      }
+      Ready = {
+        Type = "Succeed"
+      }
+    }
+  })
+}
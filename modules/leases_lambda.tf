@@ -1,3 +1,19 @@
+locals {
+  # SSM parameter the account pool's lease durations are stored in, once an
+  # admin sets them with PUT /system/lease-durations
+  pool_lease_durations_parameter = "/${var.namespace}/leases/pool_durations"
+  # SSM parameter the terms of use leases must accept are stored in, once an
+  # admin sets them with PUT /system/lease-terms
+  lease_terms_parameter = "/${var.namespace}/leases/terms"
+
+  # Environment of the lambdas which lease accounts, so only members of a
+  # pool's lease groups are leased its accounts
+  lease_groups_environment = {
+    LEASE_GROUPS      = join(",", var.lease_groups)
+    POOL_LEASE_GROUPS = join(",", flatten([for pool, groups in var.pool_lease_groups : [for group in groups : "${pool}:${group}"]]))
+  }
+}
+
 module "leases_lambda" {
   source          = "./lambda"
   name            = "leases-${var.namespace}"
@@ -7,23 +23,60 @@ module "leases_lambda" {
   handler         = "leases"
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
-  environment = {
+  environment = merge(local.directory_environment, local.principal_id_environment, local.principal_policy_environment, local.auth_environment, local.lease_groups_environment, {
+    EVENT_BUS_NAME                     = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET               = local.event_archive_bucket
+    EVENT_TABLE_NAME                   = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                       = local.outbox_table
+    EVENT_RETENTION_DAYS               = var.event_retention_days
+    EVENT_SINK_DRIVER                  = var.event_sink_driver
+    EVENT_SINK_TARGET                  = local.event_sink_target
+    EVENT_SINK_AUTH                    = var.event_sink_auth
     DEBUG                              = "false"
     NAMESPACE                          = var.namespace
     AWS_CURRENT_REGION                 = var.aws_region
     RESET_SQS_URL                      = aws_sqs_queue.account_reset.id
+    RESET_BUILD_NAME                   = aws_codebuild_project.reset_build.id
     ACCOUNT_DB                         = aws_dynamodb_table.accounts.id
     LEASE_DB                           = aws_dynamodb_table.leases.id
+    ARTIFACTS_BUCKET                   = aws_s3_bucket.artifacts.id
+    EXPORTS_PREFIX                     = local.exports_prefix
+    STATUS_SHARD_COUNT                 = var.status_shard_count
     LEASE_ADDED_TOPIC                  = aws_sns_topic.lease_added.arn
     DECOMMISSION_TOPIC                 = aws_sns_topic.lease_removed.arn
     COGNITO_USER_POOL_ID               = module.api_gateway_authorizer.user_pool_id
     COGNITO_ROLES_ATTRIBUTE_ADMIN_NAME = var.cognito_roles_attribute_admin_name
     MAX_LEASE_BUDGET_AMOUNT            = var.max_lease_budget_amount
     MAX_LEASE_PERIOD                   = var.max_lease_period
+    DEFAULT_LEASE_LENGTH_IN_DAYS       = var.default_lease_length_in_days
+    POOL_LEASE_DURATIONS_PARAMETER     = local.pool_lease_durations_parameter
+    LEASE_TERMS_PARAMETER              = local.lease_terms_parameter
     PRINCIPAL_BUDGET_AMOUNT            = var.principal_budget_amount
     PRINCIPAL_BUDGET_PERIOD            = var.principal_budget_period
+    BUDGET_CURRENCY                    = var.budget_currency
+    BUDGET_CATEGORIES                  = length(var.budget_categories) > 0 ? jsonencode(var.budget_categories) : ""
+    LEASE_ESTIMATE_LOOKBACK_DAYS       = var.lease_estimate_lookback_days
+    SAVINGS_PROVISIONING_HOURS         = var.savings_baseline_provisioning_hours
+    SAVINGS_ACCOUNT_LIFETIME_DAYS      = var.savings_baseline_account_lifetime_days
     USAGE_CACHE_DB                     = aws_dynamodb_table.usage.id
-  }
+    USAGE_AGGREGATE_DB                 = aws_dynamodb_table.usage_aggregates.id
+    LEASE_PROVISION_STATE_MACHINE_ARN  = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_TEARDOWN_STATE_MACHINE_ARN   = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    ALERT_DRIVER                       = var.alert_driver
+    ALERT_INTEGRATION_KEY              = var.alert_integration_key
+    ALERT_API_URL                      = var.alert_api_url
+    OPA_URL                            = var.opa_url
+    RESET_DEFAULT_PROFILE              = var.reset_profile
+    ACCOUNT_READINESS_PROBE            = var.account_readiness_probe
+    PRINCIPAL_LEASES_TABLE             = local.principal_leases_table
+    MAX_LEASES_PER_PRINCIPAL           = var.max_leases_per_principal
+    SETTINGS_TABLE                     = local.settings_table
+    LEASE_WAITLIST_TABLE               = local.lease_waitlist_table
+    LEASE_WAITLIST_MAX_WAIT_HOURS      = var.lease_waitlist_max_wait_hours
+    API_KEY_TABLE                      = local.api_key_table
+    API_CLIENT_ROLE_NAME               = local.api_client_role_name
+    API_CACHE_MAX_AGE                  = var.api_cache_max_age
+  })
 }
 
 resource "aws_sns_topic" "lease_added" {
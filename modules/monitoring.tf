@@ -0,0 +1,47 @@
+# Creates the standard CloudWatch alarms and dashboard.
+# Invoked by scripts/deploy.sh each time DCE is deployed
+module "monitoring_lambda" {
+  source          = "./lambda"
+  name            = "monitoring-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Creates or updates the standard DCE CloudWatch alarms and dashboard"
+  global_tags     = var.global_tags
+  handler         = "monitoring"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG                               = "false"
+    NAMESPACE                           = var.namespace
+    AWS_CURRENT_REGION                  = var.aws_region
+    MONITORING_ENABLED                  = var.monitoring_toggle
+    ALARM_TOPIC_ARN                     = aws_sns_topic.alarms_topic.arn
+    API_NAME                            = aws_api_gateway_rest_api.gateway_api.name
+    API_STAGE_NAME                      = aws_api_gateway_stage.api.stage_name
+    RESET_BUILD_NAME                    = aws_codebuild_project.reset_build.name
+    UPDATE_LEASE_STATUS_LOG_GROUP       = "/aws/lambda/${module.update_lease_status_lambda.name}"
+    MONITORING_MIN_READY_ACCOUNTS       = var.monitoring_min_ready_accounts
+    MONITORING_API_5XX_THRESHOLD        = var.monitoring_api_5xx_threshold
+    MONITORING_RESET_FAILURE_THRESHOLD  = var.monitoring_reset_failure_threshold
+    MONITORING_BUDGET_OVERRUN_THRESHOLD = var.monitoring_budget_overrun_threshold
+  }
+}
+
+# Allow the monitoring lambda to create alarms, dashboards and metric filters
+resource "aws_iam_role_policy" "monitoring" {
+  role   = module.monitoring_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": [
+        "cloudwatch:PutMetricAlarm",
+        "cloudwatch:PutDashboard",
+        "logs:CreateLogGroup",
+        "logs:PutMetricFilter"
+      ],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
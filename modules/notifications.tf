@@ -0,0 +1,76 @@
+locals {
+  // Custom email templates, by "<template>/<part>". The budget notification
+  // template variables are kept for existing deployments.
+  notification_templates = {
+    for id, template in merge({
+      "BudgetThreshold/subject" = var.budget_notification_template_subject
+      "BudgetThreshold/html"    = var.budget_notification_template_html
+      "BudgetThreshold/text"    = var.budget_notification_template_text
+    }, var.notification_templates) : id => template if template != ""
+  }
+
+  notification_environment = {
+    NOTIFICATION_FROM_EMAIL          = var.budget_notification_from_email
+    NOTIFICATION_BCC_EMAILS          = join(",", var.budget_notification_bcc_emails)
+    NOTIFICATION_TEMPLATES_BUCKET    = aws_s3_bucket.artifacts.id
+    NOTIFICATION_TEMPLATES_PREFIX    = "notification_templates"
+    NOTIFICATION_TEMPLATE_OBJECTS    = join(",", keys(aws_s3_bucket_object.notification_templates))
+    NOTIFICATION_BRAND_NAME          = var.notification_brand_name
+    NOTIFICATION_BRAND_LOGO_URL      = var.notification_brand_logo_url
+    NOTIFICATION_BRAND_SUPPORT_EMAIL = var.notification_brand_support_email
+    NOTIFICATION_BRAND_FOOTER        = var.notification_brand_footer
+  }
+
+  lease_notifications_count = length(var.lease_notification_emails) > 0 ? 1 : 0
+}
+
+// Upload custom email templates to S3
+// (templates may be too large to pass in as env vars)
+resource "aws_s3_bucket_object" "notification_templates" {
+  for_each = local.notification_templates
+  bucket   = aws_s3_bucket.artifacts.id
+  key      = "notification_templates/${each.key}.tmpl"
+  content  = each.value
+}
+
+module "lease_notifications_lambda" {
+  source          = "./lambda"
+  name            = "lease_notifications-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Sends lease created and lease ended emails"
+  global_tags     = var.global_tags
+  handler         = "lease_notifications"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, {
+    DEBUG                     = "false"
+    NAMESPACE                 = var.namespace
+    AWS_CURRENT_REGION        = var.aws_region
+    LEASE_NOTIFICATION_EMAILS = join(",", var.lease_notification_emails)
+  })
+}
+
+// Allow lease_notifications lambda to send emails with SES
+resource "aws_iam_role_policy" "lease_notifications_ses" {
+  role   = module.lease_notifications_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+# Lease status changes are read from the table stream,
+# so they are caught however the status was changed
+resource "aws_lambda_event_source_mapping" "lease_notifications" {
+  count             = local.lease_notifications_count
+  event_source_arn  = aws_dynamodb_table.leases.stream_arn
+  function_name     = module.lease_notifications_lambda.arn
+  starting_position = "LATEST"
+  batch_size        = 10
+}
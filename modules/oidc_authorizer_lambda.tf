@@ -0,0 +1,58 @@
+locals {
+  # Environment of the API lambdas which identify the users of requests
+  auth_environment = {
+    AUTH_PROVIDER        = var.auth_provider
+    IAM_ADMIN_PRINCIPALS = join(",", var.iam_admin_principals)
+    OIDC_ADMIN_GROUPS    = join(",", var.oidc_admin_groups)
+  }
+
+  # How API Gateway authenticates requests.  The oidc provider verifies
+  # bearer tokens with the OIDC authorizer, the others with SigV4.
+  api_security_definition = var.auth_provider == "oidc" ? jsonencode({
+    "type"                         = "apiKey"
+    "name"                         = "Authorization"
+    "in"                           = "header"
+    "x-amazon-apigateway-authtype" = "custom"
+    "x-amazon-apigateway-authorizer" = {
+      "type"                         = "token"
+      "authorizerUri"                = module.oidc_authorizer_lambda.invoke_arn
+      "authorizerResultTtlInSeconds" = 300
+      "identitySource"               = "method.request.header.Authorization"
+    }
+    }) : jsonencode({
+    "type"                         = "apiKey"
+    "name"                         = "Authorization"
+    "in"                           = "header"
+    "x-amazon-apigateway-authtype" = "awsSigv4"
+  })
+}
+
+module "oidc_authorizer_lambda" {
+  source          = "./lambda"
+  name            = "oidc_authorizer-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Authorizes API requests with the bearer tokens of an OIDC provider"
+  global_tags     = var.global_tags
+  handler         = "oidc_authorizer"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 10
+
+  environment = {
+    DEBUG               = "false"
+    NAMESPACE           = var.namespace
+    AWS_CURRENT_REGION  = var.aws_region
+    OIDC_ISSUER         = var.oidc_issuer
+    OIDC_AUDIENCE       = var.oidc_audience
+    OIDC_USERNAME_CLAIM = var.oidc_username_claim
+    OIDC_GROUPS_CLAIM   = var.oidc_groups_claim
+    OIDC_ADMIN_GROUPS   = join(",", var.oidc_admin_groups)
+  }
+}
+
+resource "aws_lambda_permission" "allow_api_gateway_oidc_authorizer_lambda" {
+  function_name = module.oidc_authorizer_lambda.arn
+  statement_id  = "AllowExecutionFromApiGateway"
+  action        = "lambda:InvokeFunction"
+  principal     = "apigateway.amazonaws.com"
+  source_arn    = "${aws_api_gateway_rest_api.gateway_api.execution_arn}/authorizers/*"
+}
@@ -0,0 +1,41 @@
+module "outbox_publisher_lambda" {
+  source          = "./lambda"
+  name            = "outbox_publisher-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Publishes the events of lease and account changes waiting in the outbox"
+  global_tags     = var.global_tags
+  handler         = "outbox_publisher"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 60
+
+  environment = {
+    EVENT_BUS_NAME                    = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET              = local.event_archive_bucket
+    EVENT_TABLE_NAME                  = aws_dynamodb_table.events.id
+    EVENT_RETENTION_DAYS              = var.event_retention_days
+    EVENT_SINK_DRIVER                 = var.event_sink_driver
+    EVENT_SINK_TARGET                 = local.event_sink_target
+    EVENT_SINK_AUTH                   = var.event_sink_auth
+    OUTBOX_TABLE                      = local.outbox_table
+    DEBUG                             = "false"
+    NAMESPACE                         = var.namespace
+    AWS_CURRENT_REGION                = var.aws_region
+    RESET_SQS_URL                     = aws_sqs_queue.account_reset.id
+    ACCOUNT_CREATED_TOPIC_ARN         = aws_sns_topic.account_created.arn
+    ACCOUNT_DELETED_TOPIC_ARN         = aws_sns_topic.account_deleted.arn
+    LEASE_ADDED_TOPIC                 = aws_sns_topic.lease_added.arn
+    LEASE_PROVISION_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_TEARDOWN_STATE_MACHINE_ARN  = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    ACCOUNT_WARM_UP_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.account_warm_up.*.id)
+  }
+}
+
+// Publish the events in the outbox on a timer (cloudwatch event)
+module "outbox_publisher_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "outbox_publisher-${var.namespace}"
+  lambda_function_arn = module.outbox_publisher_lambda.arn
+  schedule_expression = var.outbox_schedule_expression
+  description         = "Publishes the events waiting in the outbox"
+  enabled             = var.outbox_enabled
+}
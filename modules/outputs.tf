@@ -22,6 +22,10 @@ output "usage_table_arn" {
   value = aws_dynamodb_table.usage.arn
 }
 
+output "usage_aggregates_table_name" {
+  value = aws_dynamodb_table.usage_aggregates.name
+}
+
 output "sqs_reset_queue_url" {
   value = aws_sqs_queue.account_reset.id
 }
@@ -158,3 +162,39 @@ output "role_admin_policy" {
 output "codebuild_reset_name" {
   value = aws_codebuild_project.reset_build.id
 }
+
+output "prometheus_metrics_url" {
+  value = local.prometheus_alb_count > 0 ? "http://${aws_lb.prometheus_metrics[0].dns_name}/metrics" : ""
+}
+
+output "account_factory_role_arn" {
+  value = module.account_factory_lambda.execution_role_arn
+}
+
+output "event_archive_bucket" {
+  value = local.event_archive_bucket
+}
+
+output "directory_sync_lambda_arn" {
+  value = module.directory_sync_lambda.arn
+}
+
+output "lease_archive_bucket" {
+  value = local.lease_archive_bucket
+}
+
+output "service_catalog_portfolio_id" {
+  value = local.service_catalog_count > 0 ? aws_cloudformation_stack.service_catalog[0].outputs["PortfolioId"] : ""
+}
+
+output "event_sink_target" {
+  value = local.event_sink_target
+}
+
+output "policy_bundle_key" {
+  value = join("", aws_s3_bucket_object.policy_bundle.*.key)
+}
+
+output "api_client_role_arn" {
+  value = join("", aws_iam_role.api_client.*.arn)
+}
@@ -0,0 +1,10 @@
+// Bundle of rego policies for lease approval and account allocation.  The OPA
+// server at `opa_url` loads it from the artifacts bucket with its bundle
+// plugin, and DCE queries OPA when it creates leases.
+resource "aws_s3_bucket_object" "policy_bundle" {
+  count  = var.policy_bundle == "" ? 0 : 1
+  bucket = aws_s3_bucket.artifacts.id
+  key    = "policies/bundle.tar.gz"
+  source = var.policy_bundle
+  etag   = filemd5(var.policy_bundle)
+}
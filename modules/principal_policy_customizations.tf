@@ -0,0 +1,28 @@
+locals {
+  // Lambdas which end leases revert the principal policy customization of
+  // the lease, so they render the principal policy too
+  principal_policy_environment = {
+    ACCOUNT_ID                             = local.account_id
+    ARTIFACTS_BUCKET                       = aws_s3_bucket.artifacts.id
+    PRINCIPAL_ROLE_NAME                    = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                  = local.principal_policy_name
+    PRINCIPAL_POLICY_S3_KEY                = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY = local.principal_policy_customizations_key
+    PRINCIPAL_IAM_DENY_TAGS                = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                        = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION         = 14400
+    TAG_ENVIRONMENT                        = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                           = lookup(var.global_tags, "AppName")
+  }
+  principal_policy_customizations_key = join("", aws_s3_bucket_object.principal_policy_customizations.*.key)
+}
+
+// Allowlist of the statements leases may add to, or remove from, the
+// principal policy.  Leases may not customize the policy without it.
+resource "aws_s3_bucket_object" "principal_policy_customizations" {
+  count  = var.principal_policy_customizations == "" ? 0 : 1
+  bucket = aws_s3_bucket.artifacts.id
+  key    = "fixtures/policies/principal_policy_customizations.json"
+  source = var.principal_policy_customizations
+  etag   = filemd5(var.principal_policy_customizations)
+}
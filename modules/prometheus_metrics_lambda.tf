@@ -0,0 +1,96 @@
+locals {
+  prometheus_alb_count  = length(var.prometheus_alb_subnet_ids) > 0 ? 1 : 0
+  prometheus_push_count = var.prometheus_pushgateway_url == "" ? 0 : 1
+}
+
+module "prometheus_metrics_lambda" {
+  source          = "./lambda"
+  name            = "prometheus_metrics-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Exposes DCE metrics in the Prometheus format"
+  global_tags     = var.global_tags
+  handler         = "prometheus_metrics"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG                      = "false"
+    NAMESPACE                  = var.namespace
+    AWS_CURRENT_REGION         = var.aws_region
+    ACCOUNT_DB                 = aws_dynamodb_table.accounts.id
+    LEASE_DB                   = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT         = var.status_shard_count
+    RESET_BUILD_NAME           = aws_codebuild_project.reset_build.id
+    PROMETHEUS_PUSHGATEWAY_URL = var.prometheus_pushgateway_url
+    PROMETHEUS_PUSH_JOB        = "dce-${var.namespace}"
+  }
+}
+
+# Serve /metrics from a private load balancer, for Prometheus to scrape
+resource "aws_lb" "prometheus_metrics" {
+  count              = local.prometheus_alb_count
+  name               = "dce-metrics-${var.namespace}"
+  internal           = true
+  load_balancer_type = "application"
+  subnets            = var.prometheus_alb_subnet_ids
+  security_groups    = var.prometheus_alb_security_group_ids
+  tags               = var.global_tags
+}
+
+resource "aws_lb_target_group" "prometheus_metrics" {
+  count       = local.prometheus_alb_count
+  name        = "dce-metrics-${var.namespace}"
+  target_type = "lambda"
+  tags        = var.global_tags
+}
+
+resource "aws_lambda_permission" "prometheus_metrics_alb" {
+  count         = local.prometheus_alb_count
+  statement_id  = "AllowExecutionFromALB"
+  action        = "lambda:InvokeFunction"
+  function_name = module.prometheus_metrics_lambda.name
+  principal     = "elasticloadbalancing.amazonaws.com"
+  source_arn    = aws_lb_target_group.prometheus_metrics[0].arn
+}
+
+resource "aws_lb_target_group_attachment" "prometheus_metrics" {
+  count            = local.prometheus_alb_count
+  target_group_arn = aws_lb_target_group.prometheus_metrics[0].arn
+  target_id        = module.prometheus_metrics_lambda.arn
+  depends_on       = [aws_lambda_permission.prometheus_metrics_alb]
+}
+
+resource "aws_lb_listener" "prometheus_metrics" {
+  count             = local.prometheus_alb_count
+  load_balancer_arn = aws_lb.prometheus_metrics[0].arn
+  port              = 80
+  protocol          = "HTTP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.prometheus_metrics[0].arn
+  }
+}
+
+# Push metrics to a Pushgateway on a schedule
+resource "aws_cloudwatch_event_rule" "prometheus_metrics_push" {
+  count               = local.prometheus_push_count
+  name                = "prometheus-metrics-push-${var.namespace}"
+  description         = "Push DCE metrics to the Prometheus Pushgateway"
+  schedule_expression = var.prometheus_push_schedule_expression
+}
+
+resource "aws_cloudwatch_event_target" "prometheus_metrics_push" {
+  count     = local.prometheus_push_count
+  rule      = aws_cloudwatch_event_rule.prometheus_metrics_push[0].name
+  target_id = "prometheus_metrics_${var.namespace}"
+  arn       = module.prometheus_metrics_lambda.arn
+}
+
+resource "aws_lambda_permission" "prometheus_metrics_push" {
+  count         = local.prometheus_push_count
+  statement_id  = "AllowExecutionFromCloudWatch"
+  action        = "lambda:InvokeFunction"
+  function_name = module.prometheus_metrics_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.prometheus_metrics_push[0].arn
+}
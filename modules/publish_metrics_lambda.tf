@@ -0,0 +1,100 @@
+locals {
+  publish_metrics_count = var.metrics_driver == "" ? 0 : 1
+}
+
+module "publish_metrics_lambda" {
+  source          = "./lambda"
+  name            = "publish_metrics-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Sends DCE metrics and lifecycle events to a monitoring service, such as Datadog"
+  global_tags     = var.global_tags
+  handler         = "publish_metrics"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.diagnostics_environment, {
+    DEBUG              = "false"
+    NAMESPACE          = var.namespace
+    AWS_CURRENT_REGION = var.aws_region
+    ACCOUNT_DB         = aws_dynamodb_table.accounts.id
+    LEASE_DB           = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT = var.status_shard_count
+    RESET_BUILD_NAME   = aws_codebuild_project.reset_build.id
+    METRICS_DRIVER     = var.metrics_driver
+    METRICS_API_KEY    = var.metrics_api_key
+    METRICS_API_URL    = var.metrics_api_url
+    METRICS_TAGS       = join(",", var.metrics_tags)
+  })
+}
+
+# Metrics are collected on a schedule
+resource "aws_cloudwatch_event_rule" "publish_metrics" {
+  count               = local.publish_metrics_count
+  name                = "publish-metrics-${var.namespace}"
+  description         = "Send DCE metrics to the monitoring service"
+  schedule_expression = var.metrics_publish_schedule_expression
+}
+
+resource "aws_cloudwatch_event_target" "publish_metrics" {
+  count     = local.publish_metrics_count
+  rule      = aws_cloudwatch_event_rule.publish_metrics[0].name
+  target_id = "publish_metrics_${var.namespace}"
+  arn       = module.publish_metrics_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_publish_metrics" {
+  count         = local.publish_metrics_count
+  statement_id  = "AllowCloudWatchPublishMetrics${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.publish_metrics_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.publish_metrics[0].arn
+}
+
+# Account and lease status changes are read from the table streams
+resource "aws_lambda_event_source_mapping" "publish_metrics_accounts" {
+  count             = local.publish_metrics_count
+  event_source_arn  = aws_dynamodb_table.accounts.stream_arn
+  function_name     = module.publish_metrics_lambda.arn
+  starting_position = "LATEST"
+  batch_size        = 10
+}
+
+resource "aws_lambda_event_source_mapping" "publish_metrics_leases" {
+  count             = local.publish_metrics_count
+  event_source_arn  = aws_dynamodb_table.leases.stream_arn
+  function_name     = module.publish_metrics_lambda.arn
+  starting_position = "LATEST"
+  batch_size        = 10
+}
+
+# Finished resets are read from CodeBuild build state change events
+resource "aws_cloudwatch_event_rule" "publish_metrics_reset" {
+  count       = local.publish_metrics_count
+  name        = "publish-metrics-reset-${var.namespace}"
+  description = "Finished account reset builds"
+
+  event_pattern = jsonencode({
+    source      = ["aws.codebuild"]
+    detail-type = ["CodeBuild Build State Change"]
+    detail = {
+      build-status = ["SUCCEEDED", "FAILED"]
+      project-name = [aws_codebuild_project.reset_build.id]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "publish_metrics_reset" {
+  count     = local.publish_metrics_count
+  rule      = aws_cloudwatch_event_rule.publish_metrics_reset[0].name
+  target_id = "publish_metrics_${var.namespace}"
+  arn       = module.publish_metrics_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_publish_metrics_reset" {
+  count         = local.publish_metrics_count
+  statement_id  = "AllowCloudWatchPublishMetricsReset${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.publish_metrics_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.publish_metrics_reset[0].arn
+}
@@ -2,6 +2,9 @@
 resource "aws_sqs_queue" "account_reset" {
   name = "account-reset-${var.namespace}"
   tags = var.global_tags
+  # Keep accounts waiting to be reset for as long as possible,
+  # while the reset pipeline is paused
+  message_retention_seconds = 1209600
   # Visibility time out should be 6 times the Lambda timeout
   visibility_timeout_seconds = 180
   # A redrive policy that will move messages into a DLQ
@@ -33,13 +36,23 @@ module "populate_reset_queue" {
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
   environment = {
-    DEBUG              = "false"
-    NAMESPACE          = var.namespace
-    ICP_REGION         = var.aws_region
-    RESET_SQS_URL      = aws_sqs_queue.account_reset.id
-    ACCOUNT_DB         = aws_dynamodb_table.accounts.id
-    LEASE_DB           = aws_dynamodb_table.leases.id
-    AWS_CURRENT_REGION = var.aws_region
+    EVENT_BUS_NAME                = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET          = local.event_archive_bucket
+    EVENT_TABLE_NAME              = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                  = local.outbox_table
+    EVENT_RETENTION_DAYS          = var.event_retention_days
+    EVENT_SINK_DRIVER             = var.event_sink_driver
+    EVENT_SINK_TARGET             = local.event_sink_target
+    EVENT_SINK_AUTH               = var.event_sink_auth
+    DEBUG                         = "false"
+    NAMESPACE                     = var.namespace
+    ICP_REGION                    = var.aws_region
+    RESET_SQS_URL                 = aws_sqs_queue.account_reset.id
+    RESET_EVENT_SOURCE_MAPPING_ID = aws_lambda_event_source_mapping.process_reset_events_from_sqs.uuid
+    ACCOUNT_DB                    = aws_dynamodb_table.accounts.id
+    LEASE_DB                      = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT            = var.status_shard_count
+    AWS_CURRENT_REGION            = var.aws_region
   }
 }
 
@@ -79,14 +92,23 @@ module "process_reset_queue" {
   # Should be a 1/6 of the SQS queue visibility timeout
   timeout = 30
 
-  environment = {
-    DEBUG              = "false"
-    RESET_BUILD_NAME   = aws_codebuild_project.reset_build.id
-    RESET_SQS_URL      = aws_sqs_queue.account_reset.id
-    ACCOUNT_DB         = aws_dynamodb_table.accounts.id
-    LEASE_DB           = aws_dynamodb_table.leases.id
-    AWS_CURRENT_REGION = var.aws_region
-  }
+  environment = merge(local.diagnostics_environment, {
+    EVENT_BUS_NAME       = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET = local.event_archive_bucket
+    EVENT_TABLE_NAME     = aws_dynamodb_table.events.id
+    OUTBOX_TABLE         = local.outbox_table
+    EVENT_RETENTION_DAYS = var.event_retention_days
+    EVENT_SINK_DRIVER    = var.event_sink_driver
+    EVENT_SINK_TARGET    = local.event_sink_target
+    EVENT_SINK_AUTH      = var.event_sink_auth
+    DEBUG                = "false"
+    RESET_BUILD_NAME     = aws_codebuild_project.reset_build.id
+    RESET_SQS_URL        = aws_sqs_queue.account_reset.id
+    ACCOUNT_DB           = aws_dynamodb_table.accounts.id
+    LEASE_DB             = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT   = var.status_shard_count
+    AWS_CURRENT_REGION   = var.aws_region
+  })
 }
 
 resource "aws_lambda_event_source_mapping" "process_reset_events_from_sqs" {
@@ -94,6 +116,37 @@ resource "aws_lambda_event_source_mapping" "process_reset_events_from_sqs" {
   function_name    = module.process_reset_queue.arn
   batch_size       = 1
   enabled          = true
+
+  # The mapping is disabled to pause the reset pipeline,
+  # which shouldn't be undone by deployments
+  lifecycle {
+    ignore_changes = [enabled]
+  }
+}
+
+locals {
+  reset_event_source_mapping_arn = "arn:aws:lambda:${var.aws_region}:${local.account_id}:event-source-mapping:${aws_lambda_event_source_mapping.process_reset_events_from_sqs.uuid}"
+}
+
+# Allow checking whether the reset pipeline is paused
+resource "aws_iam_role_policy" "populate_reset_queue_pipeline" {
+  role   = module.populate_reset_queue.execution_role_name
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "lambda:GetEventSourceMapping"
+      ],
+      "Resource": [
+        "${local.reset_event_source_mapping_arn}"
+      ]
+    }
+  ]
+}
+POLICY
 }
 
 # Lambda code deployments are managed outside of Terraform,
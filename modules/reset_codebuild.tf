@@ -12,6 +12,8 @@
 locals {
   # https://stackoverflow.com/a/47243622
   isPr = replace(var.namespace, "pr-", "") != var.namespace
+
+  reset_snapshot_prefix = "snapshots"
 }
 
 # CodeBuild to create Azure AD Ent App for AWS Account
@@ -95,6 +97,90 @@ resource "aws_codebuild_project" "reset_build" {
       type  = "PLAINTEXT"
     }
 
+    environment_variable {
+      name  = "STATUS_SHARD_COUNT"
+      value = var.status_shard_count
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "DIAGNOSTICS_ENABLED"
+      value = var.diagnostics_enabled
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "DIAGNOSTICS_BUCKET"
+      value = aws_s3_bucket.artifacts.id
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "DIAGNOSTICS_PREFIX"
+      value = "diagnostics"
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "DIAGNOSTICS_INTERVAL"
+      value = var.diagnostics_interval
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_SNAPSHOT_ENABLED"
+      value = var.reset_snapshot_enabled
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_SNAPSHOT_BUCKET"
+      value = aws_s3_bucket.artifacts.id
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_SNAPSHOT_PREFIX"
+      value = local.reset_snapshot_prefix
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_SNAPSHOT_S3_MANIFESTS"
+      value = var.reset_snapshot_s3_manifests
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_BLACKOUT_ENABLED"
+      value = var.reset_blackout_enabled
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_BLACKOUT_IGNORED_PRINCIPALS"
+      value = join(",", var.reset_blackout_ignored_principals)
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_COOLDOWN_MINUTES"
+      value = var.account_cooldown_minutes
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_LEAK_TRACKING_ENABLED"
+      value = var.reset_leak_tracking_enabled
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "RESET_DEFAULT_PROFILE"
+      value = var.reset_profile
+      type  = "PLAINTEXT"
+    }
+
     environment_variable {
       name  = "AWS_CURRENT_REGION"
       value = var.aws_region
@@ -113,11 +199,131 @@ resource "aws_codebuild_project" "reset_build" {
       type  = "PLAINTEXT"
     }
 
+    environment_variable {
+      name  = "ALLOWED_REGIONS"
+      value = join(",", var.allowed_regions)
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "LOG_AGGREGATION_BUCKET"
+      value = var.log_aggregation_bucket
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "LOG_AGGREGATION_EVENT_BUS_ARN"
+      value = var.log_aggregation_event_bus_arn
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "LEASE_BUDGET_ALARMS_ENABLED"
+      value = var.lease_budget_alarms_enabled
+      type  = "PLAINTEXT"
+    }
+
     environment_variable {
       name  = "RESET_COMPLETE_TOPIC_ARN"
       value = aws_sns_topic.reset_complete.arn
       type  = "PLAINTEXT"
     }
+
+    environment_variable {
+      name  = "EVENT_BUS_NAME"
+      value = var.event_bus_name
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_ARCHIVE_BUCKET"
+      value = local.event_archive_bucket
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_TABLE_NAME"
+      value = aws_dynamodb_table.events.id
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "OUTBOX_TABLE"
+      value = local.outbox_table
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_RETENTION_DAYS"
+      value = var.event_retention_days
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_SINK_DRIVER"
+      value = var.event_sink_driver
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_SINK_TARGET"
+      value = local.event_sink_target
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "EVENT_SINK_AUTH"
+      value = var.event_sink_auth
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "NAMESPACE"
+      value = var.namespace
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "ALERT_DRIVER"
+      value = var.alert_driver
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "ALERT_INTEGRATION_KEY"
+      value = var.alert_integration_key
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "ALERT_API_URL"
+      value = var.alert_api_url
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "NOTIFICATION_ROUTING_TABLE"
+      value = local.notification_routing_table
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "POOL_CONTACT_PARAMETER"
+      value = local.pool_contact_parameter
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "NOTIFICATION_FROM_EMAIL"
+      value = var.budget_notification_from_email
+      type  = "PLAINTEXT"
+    }
+
+    environment_variable {
+      name  = "SLACK_BOT_TOKEN"
+      value = var.slack_bot_token
+      type  = "PLAINTEXT"
+    }
   }
 
   tags = var.global_tags
@@ -174,8 +380,13 @@ resource "aws_iam_role_policy" "codebuild_reset" {
         "dynamodb:GetItem",
         "dynamodb:Scan",
         "dynamodb:Query",
+        "dynamodb:PutItem",
         "dynamodb:UpdateItem",
-        "sns:Publish"
+        "sns:Publish",
+        "events:PutEvents",
+        "kinesis:PutRecord",
+        "organizations:DescribeAccount",
+        "ses:SendEmail"
       ]
     },
     {
@@ -191,6 +402,11 @@ resource "aws_iam_role_policy" "codebuild_reset" {
         "${aws_s3_bucket.artifacts.arn}",
         "${aws_s3_bucket.artifacts.arn}/*"
       ]
+    },
+    {
+      "Effect": "Allow",
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::${local.event_archive_bucket_name}/events/*"
     }
   ]
 }
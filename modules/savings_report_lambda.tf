@@ -0,0 +1,49 @@
+module "savings_report_lambda" {
+  source          = "./lambda"
+  name            = "savings_report-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Emails a monthly report of what the account pool saved"
+  global_tags     = var.global_tags
+  handler         = "savings_report"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 300
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, {
+    DEBUG                         = "false"
+    NAMESPACE                     = var.namespace
+    AWS_CURRENT_REGION            = var.aws_region
+    ACCOUNT_DB                    = aws_dynamodb_table.accounts.id
+    LEASE_DB                      = aws_dynamodb_table.leases.id
+    USAGE_CACHE_DB                = aws_dynamodb_table.usage.id
+    STATUS_SHARD_COUNT            = var.status_shard_count
+    BUDGET_CURRENCY               = var.budget_currency
+    SAVINGS_REPORT_EMAILS         = join(",", var.savings_report_emails)
+    SAVINGS_PROVISIONING_HOURS    = var.savings_baseline_provisioning_hours
+    SAVINGS_ACCOUNT_LIFETIME_DAYS = var.savings_baseline_account_lifetime_days
+  })
+}
+
+// Allow savings_report lambda to send emails with SES
+resource "aws_iam_role_policy" "savings_report_ses" {
+  role   = module.savings_report_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Send the report of the last month on the first of each month (UTC)
+module "savings_report_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "savings_report-${var.namespace}"
+  lambda_function_arn = module.savings_report_lambda.arn
+  schedule_expression = "cron(0 8 1 * ? *)"
+  description         = "Emails a monthly report of what the account pool saved"
+  enabled             = var.savings_report_enabled
+}
@@ -0,0 +1,228 @@
+locals {
+  service_catalog_count           = var.service_catalog_toggle == "true" ? 1 : 0
+  service_catalog_budget_currency = var.service_catalog_budget_currency != "" ? var.service_catalog_budget_currency : var.budget_currency
+
+  # Template of the Service Catalog product. Provisioning the product
+  # creates a lease for the user who provisioned it
+  service_catalog_product_template = jsonencode({
+    AWSTemplateFormatVersion = "2010-09-09"
+    Description              = "A DCE sandbox account lease"
+    Parameters = {
+      BudgetAmount = {
+        Type        = "Number"
+        Description = "Budget of the lease, in ${local.service_catalog_budget_currency}"
+        MinValue    = 1
+      }
+      LeaseDays = {
+        Type        = "Number"
+        Description = "Number of days until the lease expires"
+        Default     = var.service_catalog_default_lease_days
+        MinValue    = 1
+      }
+      NotificationEmails = {
+        Type        = "String"
+        Description = "Comma-separated email addresses to notify of budget thresholds"
+        Default     = ""
+      }
+    }
+    Resources = {
+      Lease = {
+        Type = "Custom::DCELease"
+        Properties = {
+          ServiceToken       = module.service_catalog_lambda.arn
+          BudgetAmount       = { Ref = "BudgetAmount" }
+          LeaseDays          = { Ref = "LeaseDays" }
+          NotificationEmails = { Ref = "NotificationEmails" }
+        }
+      }
+    }
+    Outputs = {
+      LeaseId = {
+        Description = "ID of the lease"
+        Value       = { Ref = "Lease" }
+      }
+      AccountId = {
+        Description = "ID of the leased AWS account"
+        Value       = { "Fn::GetAtt" = ["Lease", "AccountId"] }
+      }
+      ExpiresOn = {
+        Description = "When the lease expires"
+        Value       = { "Fn::GetAtt" = ["Lease", "ExpiresOn"] }
+      }
+      CredentialsUrl = {
+        Description = "Log in to get credentials for the leased account"
+        Value       = "${aws_api_gateway_stage.api.invoke_url}/auth"
+      }
+    }
+  })
+}
+
+module "service_catalog_lambda" {
+  source          = "./lambda"
+  name            = "service_catalog-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Creates and ends leases for the DCE Service Catalog product"
+  global_tags     = var.global_tags
+  handler         = "service_catalog"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = {
+    DEBUG              = "false"
+    NAMESPACE          = var.namespace
+    AWS_CURRENT_REGION = var.aws_region
+    DCE_API_URL        = aws_api_gateway_stage.api.invoke_url
+    BUDGET_CURRENCY    = local.service_catalog_budget_currency
+  }
+}
+
+# Allow the service_catalog lambda to call the DCE API,
+# and to look up who provisioned the product
+resource "aws_iam_role_policy_attachment" "service_catalog_api" {
+  role       = module.service_catalog_lambda.execution_role_name
+  policy_arn = aws_iam_policy.api_execute_admin.arn
+}
+
+resource "aws_iam_role_policy" "service_catalog" {
+  role   = module.service_catalog_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": "cloudformation:DescribeStacks",
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+resource "aws_s3_bucket_object" "service_catalog_product_template" {
+  count   = local.service_catalog_count
+  bucket  = aws_s3_bucket.artifacts.id
+  key     = "fixtures/service_catalog/lease_product.json"
+  content = local.service_catalog_product_template
+  etag    = md5(local.service_catalog_product_template)
+}
+
+# Service Catalog launches the product with this role,
+# so users don't need access to the DCE lambda themselves
+resource "aws_iam_role" "service_catalog_launch" {
+  count              = local.service_catalog_count
+  name               = "dce-service-catalog-launch-${var.namespace}"
+  assume_role_policy = <<JSON
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "servicecatalog.amazonaws.com"
+      },
+      "Effect": "Allow"
+    }
+  ]
+}
+JSON
+
+  tags = var.global_tags
+}
+
+resource "aws_iam_role_policy" "service_catalog_launch" {
+  count  = local.service_catalog_count
+  role   = aws_iam_role.service_catalog_launch[0].name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [
+      {
+        "Effect": "Allow",
+        "Action": [
+          "cloudformation:CreateStack",
+          "cloudformation:DeleteStack",
+          "cloudformation:DescribeStackEvents",
+          "cloudformation:DescribeStacks",
+          "cloudformation:GetTemplateSummary",
+          "cloudformation:SetStackPolicy",
+          "cloudformation:UpdateStack",
+          "cloudformation:ValidateTemplate",
+          "s3:GetObject"
+        ],
+        "Resource": "*"
+      },
+      {
+        "Effect": "Allow",
+        "Action": "lambda:InvokeFunction",
+        "Resource": "${module.service_catalog_lambda.arn}"
+      }
+    ]
+}
+POLICY
+}
+
+# The portfolio and product are created with CloudFormation,
+# as the AWS provider has no Service Catalog product resources
+resource "aws_cloudformation_stack" "service_catalog" {
+  count = local.service_catalog_count
+  name  = "dce-service-catalog-${var.namespace}"
+  tags  = var.global_tags
+
+  template_body = jsonencode({
+    AWSTemplateFormatVersion = "2010-09-09"
+    Description              = "DCE Service Catalog portfolio"
+    Resources = merge({
+      Portfolio = {
+        Type = "AWS::ServiceCatalog::Portfolio"
+        Properties = {
+          DisplayName  = "${var.service_catalog_portfolio_name} (${var.namespace})"
+          ProviderName = "DCE"
+          Description  = "Sandbox AWS accounts from DCE"
+        }
+      }
+      Product = {
+        Type = "AWS::ServiceCatalog::CloudFormationProduct"
+        Properties = {
+          Name        = var.service_catalog_product_name
+          Owner       = "DCE"
+          Description = "Lease a sandbox AWS account, which is reset when the lease ends"
+          ProvisioningArtifactParameters = [{
+            # A new version is added whenever the product template changes
+            Name = substr(md5(local.service_catalog_product_template), 0, 8)
+            Info = {
+              LoadTemplateFromURL = "https://${aws_s3_bucket.artifacts.bucket_regional_domain_name}/${aws_s3_bucket_object.service_catalog_product_template[0].key}"
+            }
+          }]
+        }
+      }
+      PortfolioProduct = {
+        Type = "AWS::ServiceCatalog::PortfolioProductAssociation"
+        Properties = {
+          PortfolioId = { Ref = "Portfolio" }
+          ProductId   = { Ref = "Product" }
+        }
+      }
+      LaunchRole = {
+        Type      = "AWS::ServiceCatalog::LaunchRoleConstraint"
+        DependsOn = "PortfolioProduct"
+        Properties = {
+          PortfolioId = { Ref = "Portfolio" }
+          ProductId   = { Ref = "Product" }
+          RoleArn     = aws_iam_role.service_catalog_launch[0].arn
+        }
+      }
+      }, {
+      for i, principal in var.service_catalog_principal_arns :
+      "Principal${i}" => {
+        Type = "AWS::ServiceCatalog::PortfolioPrincipalAssociation"
+        Properties = {
+          PortfolioId   = { Ref = "Portfolio" }
+          PrincipalARN  = principal
+          PrincipalType = "IAM"
+        }
+      }
+    })
+    Outputs = {
+      PortfolioId = { Value = { Ref = "Portfolio" } }
+      ProductId   = { Value = { Ref = "Product" } }
+    }
+  })
+}
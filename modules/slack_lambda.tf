@@ -0,0 +1,50 @@
+module "slack_lambda" {
+  source          = "./lambda"
+  name            = "slack-${var.namespace}"
+  namespace       = var.namespace
+  description     = "API /slack endpoints, for the DCE Slack app"
+  global_tags     = var.global_tags
+  handler         = "slack"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.directory_environment, local.principal_id_environment, local.principal_policy_environment, local.lease_groups_environment, {
+    EVENT_BUS_NAME                    = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET              = local.event_archive_bucket
+    EVENT_TABLE_NAME                  = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                      = local.outbox_table
+    EVENT_RETENTION_DAYS              = var.event_retention_days
+    EVENT_SINK_DRIVER                 = var.event_sink_driver
+    EVENT_SINK_TARGET                 = local.event_sink_target
+    EVENT_SINK_AUTH                   = var.event_sink_auth
+    DEBUG                             = "false"
+    NAMESPACE                         = var.namespace
+    AWS_CURRENT_REGION                = var.aws_region
+    RESET_SQS_URL                     = aws_sqs_queue.account_reset.id
+    ACCOUNT_DB                        = aws_dynamodb_table.accounts.id
+    LEASE_DB                          = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                = var.status_shard_count
+    LEASE_ADDED_TOPIC                 = aws_sns_topic.lease_added.arn
+    USAGE_CACHE_DB                    = aws_dynamodb_table.usage.id
+    USAGE_AGGREGATE_DB                = aws_dynamodb_table.usage_aggregates.id
+    MAX_LEASE_BUDGET_AMOUNT           = var.max_lease_budget_amount
+    MAX_LEASE_PERIOD                  = var.max_lease_period
+    DEFAULT_LEASE_LENGTH_IN_DAYS      = var.default_lease_length_in_days
+    POOL_LEASE_DURATIONS_PARAMETER    = local.pool_lease_durations_parameter
+    LEASE_TERMS_PARAMETER             = local.lease_terms_parameter
+    PRINCIPAL_BUDGET_AMOUNT           = var.principal_budget_amount
+    PRINCIPAL_BUDGET_PERIOD           = var.principal_budget_period
+    BUDGET_CURRENCY                   = var.budget_currency
+    SLACK_BUDGET_CURRENCY             = var.budget_currency
+    LEASE_PROVISION_STATE_MACHINE_ARN = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_TEARDOWN_STATE_MACHINE_ARN  = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    SLACK_SIGNING_SECRET              = var.slack_signing_secret
+    SLACK_BOT_TOKEN                   = var.slack_bot_token
+    SLACK_APPROVAL_CHANNEL            = var.slack_approval_channel
+    SLACK_APPROVERS                   = join(",", var.slack_approvers)
+    OPA_URL                           = var.opa_url
+    ACCOUNT_READINESS_PROBE           = var.account_readiness_probe
+    PRINCIPAL_LEASES_TABLE            = local.principal_leases_table
+    MAX_LEASES_PER_PRINCIPAL          = var.max_leases_per_principal
+    SETTINGS_TABLE                    = local.settings_table
+  })
+}
@@ -0,0 +1,50 @@
+module "stale_leases_lambda" {
+  source          = "./lambda"
+  name            = "stale_leases-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Notifies principals of leases they aren't using, and ends them after a grace period"
+  global_tags     = var.global_tags
+  handler         = "stale_leases"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+  timeout         = 900
+
+  environment = merge(local.notification_environment, local.diagnostics_environment, {
+    DEBUG                         = "false"
+    NAMESPACE                     = var.namespace
+    AWS_CURRENT_REGION            = var.aws_region
+    ACCOUNT_DB                    = aws_dynamodb_table.accounts.id
+    LEASE_DB                      = aws_dynamodb_table.leases.id
+    USAGE_CACHE_DB                = aws_dynamodb_table.usage.id
+    STATUS_SHARD_COUNT            = var.status_shard_count
+    ALLOWED_REGIONS               = join(",", var.allowed_regions)
+    STALE_LEASE_IDLE_DAYS         = var.stale_lease_idle_days
+    STALE_LEASE_SPEND_THRESHOLD   = var.stale_lease_spend_threshold
+    STALE_LEASE_GRACE_PERIOD_DAYS = var.stale_lease_grace_period_days
+    STALE_LEASE_AUTO_END          = var.stale_lease_auto_end
+  })
+}
+
+// Allow stale_leases lambda to send emails with SES
+resource "aws_iam_role_policy" "stale_leases_ses" {
+  role   = module.stale_leases_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ses:SendEmail"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
+// Check for stale leases on a timer (cloudwatch event)
+module "stale_leases_lambda_schedule" {
+  source              = "./cloudwatch_event"
+  name                = "stale_leases-${var.namespace}"
+  lambda_function_arn = module.stale_leases_lambda.arn
+  schedule_expression = var.stale_leases_schedule_expression
+  description         = "Notifies principals of leases they aren't using"
+  enabled             = var.stale_lease_detection_enabled
+}
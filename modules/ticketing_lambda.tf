@@ -0,0 +1,85 @@
+locals {
+  ticketing_count = var.ticket_driver == "" ? 0 : 1
+}
+
+module "ticketing_lambda" {
+  source          = "./lambda"
+  name            = "ticketing-${var.namespace}"
+  namespace       = var.namespace
+  description     = "Creates Jira or ServiceNow tickets for account and lease lifecycle events"
+  global_tags     = var.global_tags
+  handler         = "ticketing"
+  alarm_topic_arn = aws_sns_topic.alarms_topic.arn
+
+  environment = merge(local.diagnostics_environment, {
+    DEBUG                       = "false"
+    NAMESPACE                   = var.namespace
+    AWS_CURRENT_REGION          = var.aws_region
+    ACCOUNT_DB                  = aws_dynamodb_table.accounts.id
+    LEASE_DB                    = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT          = var.status_shard_count
+    RESET_BUILD_NAME            = aws_codebuild_project.reset_build.id
+    TICKET_DRIVER               = var.ticket_driver
+    TICKET_API_URL              = var.ticket_api_url
+    TICKET_USERNAME             = var.ticket_username
+    TICKET_API_TOKEN            = var.ticket_api_token
+    TICKET_PROJECT              = var.ticket_project
+    TICKET_ISSUE_TYPE           = var.ticket_issue_type
+    TICKET_TABLE                = var.ticket_table
+    TICKET_EVENTS               = join(",", var.ticket_events)
+    TICKET_SUMMARY_TEMPLATE     = var.ticket_summary_template
+    TICKET_DESCRIPTION_TEMPLATE = var.ticket_description_template
+    TICKET_FIELDS               = jsonencode(var.ticket_fields)
+    TICKET_LINK_BASE_URL        = aws_api_gateway_stage.api.invoke_url
+  })
+}
+
+# Account and lease status changes are read from the table streams,
+# so they are caught however the status was changed
+resource "aws_lambda_event_source_mapping" "ticketing_accounts" {
+  count             = local.ticketing_count
+  event_source_arn  = aws_dynamodb_table.accounts.stream_arn
+  function_name     = module.ticketing_lambda.arn
+  starting_position = "LATEST"
+  batch_size        = 10
+}
+
+resource "aws_lambda_event_source_mapping" "ticketing_leases" {
+  count             = local.ticketing_count
+  event_source_arn  = aws_dynamodb_table.leases.stream_arn
+  function_name     = module.ticketing_lambda.arn
+  starting_position = "LATEST"
+  batch_size        = 10
+}
+
+# Failed resets are read from CodeBuild build state change events
+resource "aws_cloudwatch_event_rule" "ticketing_reset_failed" {
+  count       = local.ticketing_count
+  name        = "ticketing-reset-failed-${var.namespace}"
+  description = "Failed account reset builds"
+
+  event_pattern = jsonencode({
+    source      = ["aws.codebuild"]
+    detail-type = ["CodeBuild Build State Change"]
+    detail = {
+      build-status = ["FAILED"]
+      project-name = [aws_codebuild_project.reset_build.id]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "ticketing_reset_failed" {
+  count     = local.ticketing_count
+  rule      = aws_cloudwatch_event_rule.ticketing_reset_failed[0].name
+  target_id = "ticketing_${var.namespace}"
+  arn       = module.ticketing_lambda.arn
+}
+
+resource "aws_lambda_permission" "allow_ticketing_reset_failed" {
+  count         = local.ticketing_count
+  statement_id  = "AllowCloudWatchTicketingResetFailed${title(var.namespace)}"
+  action        = "lambda:InvokeFunction"
+  function_name = module.ticketing_lambda.name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.ticketing_reset_failed[0].arn
+}
@@ -1,7 +1,3 @@
-locals {
-  budget_notification_templates_bucket = aws_s3_bucket.artifacts.id
-}
-
 module "fan_out_update_lease_status_lambda" {
   source          = "./lambda"
   name            = "fan_out_update_lease_status-${var.namespace}"
@@ -12,13 +8,44 @@ module "fan_out_update_lease_status_lambda" {
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
   environment = {
+    EVENT_BUS_NAME                    = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET              = local.event_archive_bucket
+    EVENT_TABLE_NAME                  = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                      = local.outbox_table
+    EVENT_RETENTION_DAYS              = var.event_retention_days
+    EVENT_SINK_DRIVER                 = var.event_sink_driver
+    EVENT_SINK_TARGET                 = local.event_sink_target
+    EVENT_SINK_AUTH                   = var.event_sink_auth
     AWS_CURRENT_REGION                = var.aws_region
     ACCOUNT_DB                        = aws_dynamodb_table.accounts.id
     LEASE_DB                          = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                = var.status_shard_count
     UPDATE_LEASE_STATUS_FUNCTION_NAME = module.update_lease_status_lambda.name
+    SKIP_EXPIRED_LEASES               = var.bulk_end_leases_enabled
+    BATCH_COST_EXPLORER               = var.batch_cost_explorer_enabled
+    COST_EXPLORER_BATCH_SIZE          = var.cost_explorer_batch_size
+    FAN_OUT_BATCH_SIZE                = var.fan_out_batch_size
+    BUDGET_CHECK_INTERVAL_MINUTES     = var.budget_check_interval_minutes
   }
 }
 
+// Allow fan_out_update_lease_status to collect the spend of the lease accounts
+// from the master account's Cost Explorer
+resource "aws_iam_role_policy" "fan_out_update_lease_status_cost_explorer" {
+  count  = var.batch_cost_explorer_enabled ? 1 : 0
+  role   = module.fan_out_update_lease_status_lambda.execution_role_name
+  policy = <<POLICY
+{
+    "Version": "2012-10-17",
+    "Statement": [{
+      "Effect": "Allow",
+      "Action": ["ce:GetCostAndUsage"],
+      "Resource": "*"
+    }]
+}
+POLICY
+}
+
 // Allow fan_out_update_lease_status to invoke the update_lease_status lambda
 resource "aws_iam_role_policy_attachment" "fan_out_update_lease_status_invoke_lambda" {
   role       = module.fan_out_update_lease_status_lambda.execution_role_name
@@ -34,37 +61,41 @@ module "update_lease_status_lambda" {
   global_tags     = var.global_tags
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
-  environment = {
+  environment = merge(local.notification_environment, {
+    EVENT_BUS_NAME                            = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                      = local.event_archive_bucket
+    EVENT_TABLE_NAME                          = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                              = local.outbox_table
+    EVENT_RETENTION_DAYS                      = var.event_retention_days
+    EVENT_SINK_DRIVER                         = var.event_sink_driver
+    EVENT_SINK_TARGET                         = local.event_sink_target
+    EVENT_SINK_AUTH                           = var.event_sink_auth
     AWS_CURRENT_REGION                        = var.aws_region
     ACCOUNT_DB                                = aws_dynamodb_table.accounts.id
     LEASE_DB                                  = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                        = var.status_shard_count
     USAGE_CACHE_DB                            = aws_dynamodb_table.usage.id
+    USAGE_AGGREGATE_DB                        = aws_dynamodb_table.usage_aggregates.id
     RESET_QUEUE_URL                           = aws_sqs_queue.account_reset.id
     LEASE_LOCKED_TOPIC_ARN                    = aws_sns_topic.lease_locked.arn
-    BUDGET_NOTIFICATION_FROM_EMAIL            = var.budget_notification_from_email
-    BUDGET_NOTIFICATION_BCC_EMAILS            = join(",", var.budget_notification_bcc_emails)
-    BUDGET_NOTIFICATION_TEMPLATES_BUCKET      = local.budget_notification_templates_bucket
-    BUDGET_NOTIFICATION_TEMPLATE_HTML_KEY     = aws_s3_bucket_object.budget_notification_template_html.key
-    BUDGET_NOTIFICATION_TEMPLATE_TEXT_KEY     = aws_s3_bucket_object.budget_notification_template_text.key
-    BUDGET_NOTIFICATION_TEMPLATE_SUBJECT      = var.budget_notification_template_subject
     BUDGET_NOTIFICATION_THRESHOLD_PERCENTILES = join(",", var.budget_notification_threshold_percentiles)
+    SETTINGS_TABLE                            = local.settings_table
     PRINCIPAL_BUDGET_AMOUNT                   = var.principal_budget_amount
     PRINCIPAL_BUDGET_PERIOD                   = var.principal_budget_period
+    BUDGET_CURRENCY                           = var.budget_currency
+    BUDGET_CATEGORIES                         = length(var.budget_categories) > 0 ? jsonencode(var.budget_categories) : ""
+    BUDGET_CURRENCY_RATES                     = jsonencode(var.budget_currency_rates)
+    BUDGET_CURRENCY_RATE_URL                  = var.budget_currency_rate_url
     USAGE_TTL                                 = var.usage_ttl
-  }
-}
-
-// Upload budget notification email templates to S3
-// (templates may be too large to pass in as env vars)
-resource "aws_s3_bucket_object" "budget_notification_template_html" {
-  bucket  = local.budget_notification_templates_bucket
-  key     = "budget_notification_templates/html.tmpl"
-  content = var.budget_notification_template_html
-}
-resource "aws_s3_bucket_object" "budget_notification_template_text" {
-  bucket  = local.budget_notification_templates_bucket
-  key     = "budget_notification_templates/text.tmpl"
-  content = var.budget_notification_template_text
+    LEASE_PROVISION_STATE_MACHINE_ARN         = join("", aws_sfn_state_machine.lease_provision.*.id)
+    LEASE_TEARDOWN_STATE_MACHINE_ARN          = join("", aws_sfn_state_machine.lease_teardown.*.id)
+    SLACK_BOT_TOKEN                           = var.slack_bot_token
+    NOTIFICATION_ROUTING_TABLE                = local.notification_routing_table
+    NAMESPACE                                 = var.namespace
+    ALERT_DRIVER                              = var.alert_driver
+    ALERT_INTEGRATION_KEY                     = var.alert_integration_key
+    ALERT_API_URL                             = var.alert_api_url
+  })
 }
 
 // Allow update_lease_status lambda to send emails with SES
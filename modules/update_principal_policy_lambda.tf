@@ -9,20 +9,34 @@ module "update_principal_policy" {
   dlq_enabled     = true
 
   environment = {
-    DEBUG                          = "false"
-    NAMESPACE                      = var.namespace
-    AWS_CURRENT_REGION             = var.aws_region
-    ACCOUNT_DB                     = aws_dynamodb_table.accounts.id
-    LEASE_DB                       = aws_dynamodb_table.leases.id
-    ARTIFACTS_BUCKET               = aws_s3_bucket.artifacts.id
-    PRINCIPAL_ROLE_NAME            = local.principal_role_name
-    PRINCIPAL_POLICY_NAME          = local.principal_policy_name
-    PRINCIPAL_POLICY_S3_KEY        = aws_s3_bucket_object.principal_policy.key
-    PRINCIPAL_IAM_DENY_TAGS        = join(",", var.principal_iam_deny_tags)
-    ALLOWED_REGIONS                = join(",", var.allowed_regions)
-    PRINCIPAL_MAX_SESSION_DURATION = 14400
-    TAG_ENVIRONMENT                = var.namespace == "prod" ? "PROD" : "NON-PROD"
-    TAG_APP_NAME                   = lookup(var.global_tags, "AppName")
+    EVENT_BUS_NAME                            = var.event_bus_name
+    EVENT_ARCHIVE_BUCKET                      = local.event_archive_bucket
+    EVENT_TABLE_NAME                          = aws_dynamodb_table.events.id
+    OUTBOX_TABLE                              = local.outbox_table
+    EVENT_RETENTION_DAYS                      = var.event_retention_days
+    EVENT_SINK_DRIVER                         = var.event_sink_driver
+    EVENT_SINK_TARGET                         = local.event_sink_target
+    EVENT_SINK_AUTH                           = var.event_sink_auth
+    DEBUG                                     = "false"
+    NAMESPACE                                 = var.namespace
+    AWS_CURRENT_REGION                        = var.aws_region
+    ACCOUNT_DB                                = aws_dynamodb_table.accounts.id
+    LEASE_DB                                  = aws_dynamodb_table.leases.id
+    STATUS_SHARD_COUNT                        = var.status_shard_count
+    ARTIFACTS_BUCKET                          = aws_s3_bucket.artifacts.id
+    PRINCIPAL_ROLE_NAME                       = local.principal_role_name
+    PRINCIPAL_POLICY_NAME                     = local.principal_policy_name
+    PRINCIPAL_POLICY_S3_KEY                   = aws_s3_bucket_object.principal_policy.key
+    PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY    = local.principal_policy_customizations_key
+    PRINCIPAL_IAM_DENY_TAGS                   = join(",", var.principal_iam_deny_tags)
+    ALLOWED_REGIONS                           = join(",", var.allowed_regions)
+    PRINCIPAL_MAX_SESSION_DURATION            = 14400
+    TAG_ENVIRONMENT                           = var.namespace == "prod" ? "PROD" : "NON-PROD"
+    TAG_APP_NAME                              = lookup(var.global_tags, "AppName")
+    LOG_AGGREGATION_BUCKET                    = var.log_aggregation_bucket
+    LOG_AGGREGATION_EVENT_BUS_ARN             = var.log_aggregation_event_bus_arn
+    LEASE_BUDGET_ALARMS_ENABLED               = var.lease_budget_alarms_enabled
+    BUDGET_NOTIFICATION_THRESHOLD_PERCENTILES = join(",", var.budget_notification_threshold_percentiles)
   }
 }
 
@@ -1,3 +1,7 @@
+locals {
+  usage_reports_prefix = "reports/usage"
+}
+
 module "usage_lambda" {
   source          = "./lambda"
   name            = "usage-${var.namespace}"
@@ -8,9 +12,14 @@ module "usage_lambda" {
   alarm_topic_arn = aws_sns_topic.alarms_topic.arn
 
   environment = {
-    DEBUG              = "false"
-    NAMESPACE          = var.namespace
-    AWS_CURRENT_REGION = var.aws_region
-    USAGE_CACHE_DB     = aws_dynamodb_table.usage.id
+    DEBUG                = "false"
+    NAMESPACE            = var.namespace
+    AWS_CURRENT_REGION   = var.aws_region
+    USAGE_CACHE_DB       = aws_dynamodb_table.usage.id
+    ARTIFACTS_BUCKET     = aws_s3_bucket.artifacts.id
+    USAGE_REPORTS_PREFIX = local.usage_reports_prefix
+    API_KEY_TABLE        = local.api_key_table
+    API_CLIENT_ROLE_NAME = local.api_client_role_name
+    API_CACHE_MAX_AGE    = var.api_cache_max_age
   }
 }
@@ -67,6 +67,35 @@ variable "reset_nuke_toggle" {
   default     = "true"
 }
 
+variable "monitoring_toggle" {
+  description = "Set to 'true' to create the standard alarms for pool capacity, reset failures, API 5XX errors and budget overruns, and their dashboard, each time DCE is deployed. Defaults to 'false'"
+  default     = "false"
+}
+
+variable "monitoring_min_ready_accounts" {
+  type        = number
+  default     = 1
+  description = "Alarm when fewer accounts are Ready to lease. Requires account_pool_metrics_toggle"
+}
+
+variable "monitoring_api_5xx_threshold" {
+  type        = number
+  default     = 5
+  description = "Alarm when the API returns at least this many 5XX errors in 5 minutes"
+}
+
+variable "monitoring_reset_failure_threshold" {
+  type        = number
+  default     = 1
+  description = "Alarm when at least this many account resets fail in 5 minutes"
+}
+
+variable "monitoring_budget_overrun_threshold" {
+  type        = number
+  default     = 1
+  description = "Alarm when at least this many leases are ended for going over budget in an hour"
+}
+
 variable "cloudwatch_dashboard_toggle" {
   description = "Set to 'true' to enable an out of the box cloudwatch dashboard. Defaults to 'false."
   default     = "false"
@@ -105,42 +134,20 @@ variable "budget_notification_bcc_emails" {
 
 variable "budget_notification_template_html" {
   type        = string
-  description = "HTML template for budget notification emails"
-  default     = <<TMPL
-<p>
-{{if .IsOverBudget}}
-Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
-has exceeded its budget of $${{.Lease.BudgetAmount}}. Actual spend is $${{.ActualSpend}}
-{{else}}
-Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
-has exceeded the {{.ThresholdPercentile}}% threshold limit for its budget of $${{.Lease.BudgetAmount}}.
-Actual spend is $${{.ActualSpend}}
-{{end}}
-</p>
-TMPL
+  description = "HTML template for budget notification emails. Defaults to the built-in BudgetThreshold template"
+  default     = ""
 }
 
 variable "budget_notification_template_text" {
   type        = string
-  description = "Text template for budget notification emails"
-  default     = <<TMPL
-{{if .IsOverBudget}}
-Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
-has exceeded its budget of $${{.Lease.BudgetAmount}}. Actual spend is $${{.ActualSpend}}
-{{else}}
-Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
-has exceeded the {{.ThresholdPercentile}}% threshold limit for its budget of $${{.Lease.BudgetAmount}}.
-Actual spend is $${{.ActualSpend}}
-{{end}}
-TMPL
+  description = "Text template for budget notification emails. Defaults to the built-in BudgetThreshold template"
+  default     = ""
 }
 
 variable "budget_notification_template_subject" {
   type        = string
-  description = "Template for budget notification email subject"
-  default     = <<SUBJ
-Lease {{if .IsOverBudget}}over budget{{else}}at {{.ThresholdPercentile}}% of budget{{end}} [{{.Lease.AccountID}}]
-SUBJ
+  description = "Template for budget notification email subject. Defaults to the built-in BudgetThreshold template"
+  default     = ""
 }
 
 variable "budget_notification_threshold_percentiles" {
@@ -149,12 +156,132 @@ variable "budget_notification_threshold_percentiles" {
   default     = [75, 100]
 }
 
+variable "notification_templates" {
+  type        = map(string)
+  description = "Custom email templates, by \"<template>/<part>\", eg. { \"LeaseCreated/html\" = \"<p>Welcome!</p>\" }"
+  default     = {}
+}
+
+variable "notification_brand_name" {
+  type        = string
+  description = "Name of the service, shown in notification emails"
+  default     = "DCE"
+}
+
+variable "notification_brand_logo_url" {
+  type        = string
+  description = "URL of a logo shown at the top of HTML notification emails"
+  default     = ""
+}
+
+variable "notification_brand_support_email" {
+  type        = string
+  description = "Email address users can contact with questions, shown in notification emails"
+  default     = ""
+}
+
+variable "notification_brand_footer" {
+  type        = string
+  description = "Text shown at the bottom of notification emails"
+  default     = ""
+}
+
+variable "lease_notification_emails" {
+  type        = list(string)
+  description = "Lease emails to send to the lease's notification emails. May include LeaseCreated and LeaseEnded"
+  default     = []
+}
+
+variable "expiry_warning_hours" {
+  type        = list(number)
+  description = "Hours before a lease expires to email warnings to the lease's notification emails, eg. [72, 24, 1]. No warnings are sent when empty"
+  default     = []
+}
+
+variable "expiry_warning_extension_url" {
+  type        = string
+  description = "Link in expiry warnings to extend the lease, eg. a page in your portal. {leaseId}, {accountId} and {principalId} are replaced with those of the lease"
+  default     = ""
+}
+
+variable "expiry_warnings_schedule_expression" {
+  type        = string
+  description = "Schedule to check for leases due an expiry warning"
+  default     = "rate(15 minutes)"
+}
+
+variable "stale_lease_detection_enabled" {
+  type        = bool
+  description = "Flag Active leases which haven't been used for stale_lease_idle_days, and notify their principals"
+  default     = false
+}
+
+variable "stale_lease_idle_days" {
+  type        = number
+  description = "Days a lease must go without spend or CloudTrail activity by its principal to be stale"
+  default     = 7
+}
+
+variable "stale_lease_spend_threshold" {
+  type        = number
+  description = "Spend over stale_lease_idle_days, in the budget currency, below which a lease may be stale"
+  default     = 1
+}
+
+variable "stale_lease_grace_period_days" {
+  type        = number
+  description = "Days after its principal is notified a stale lease is ended, when stale_lease_auto_end is set"
+  default     = 3
+}
+
+variable "stale_lease_auto_end" {
+  type        = bool
+  description = "End stale leases after the grace period. Principals are only notified when false"
+  default     = false
+}
+
+variable "stale_leases_schedule_expression" {
+  type        = string
+  description = "Schedule to check for stale leases"
+  default     = "rate(1 day)"
+}
+
+variable "lease_digest_enabled" {
+  type        = bool
+  description = "Email a digest of their leases to each principal, and of every lease to the lease_digest_admin_emails"
+  default     = false
+}
+
+variable "lease_digest_period" {
+  type        = string
+  description = "How often lease digests are sent: daily or weekly"
+  default     = "weekly"
+}
+
+variable "lease_digest_admin_emails" {
+  type        = list(string)
+  description = "Email addresses sent the digest of every lease"
+  default     = []
+}
+
+variable "lease_digest_principals_enabled" {
+  type        = bool
+  description = "Send each principal the digest of their leases. Only the admins are sent a digest when false"
+  default     = true
+}
+
 variable "principal_policy" {
   type        = string
   description = "Location of file with the policy to be attached to principal IAM users"
   default     = ""
 }
 
+variable "principal_policy_customizations" {
+  type        = string
+  description = "Location of file with the allowlist of statements leases may add to or remove from the principal policy"
+  default     = ""
+}
+
 variable "fan_out_update_lease_status_schedule_expression" {
   type        = string
   description = "Update lease status schedule"
@@ -167,6 +294,48 @@ variable "update_lease_status_enabled" {
   default     = true
 }
 
+variable "batch_cost_explorer_enabled" {
+  type        = bool
+  description = "Collect the spend of every lease account with batched Cost Explorer calls in the master account, which must be the payer account of the child accounts"
+  default     = false
+}
+
+variable "cost_explorer_batch_size" {
+  type        = number
+  description = "How many accounts the spend is collected for in each Cost Explorer call"
+  default     = 100
+}
+
+variable "fan_out_batch_size" {
+  type        = number
+  description = "How many Active leases each invocation of the fan_out_update_lease_status lambda checks. The lambda invokes itself to check the next batch."
+  default     = 500
+}
+
+variable "budget_check_interval_minutes" {
+  type        = number
+  description = "How long after the budget of a lease was checked it's checked again. Zero checks every Active lease on every scheduled run."
+  default     = 0
+}
+
+variable "bulk_end_leases_enabled" {
+  type        = bool
+  description = "End expired leases in bulk with the end_leases lambda, instead of one at a time with the update lease status lambda"
+  default     = false
+}
+
+variable "end_leases_schedule_expression" {
+  type        = string
+  description = "Schedule to end expired leases in bulk"
+  default     = "rate(1 minute)"
+}
+
+variable "end_leases_batch_size" {
+  type        = number
+  description = "How many leases the end_leases lambda ends at a time"
+  default     = 100
+}
+
 variable "namespace_prefix" {
   type    = string
   default = "dce"
@@ -192,6 +361,18 @@ variable "cognito_roles_attribute_admin_name" {
   default = "Admin"
 }
 
+variable "lease_groups" {
+  type        = list(string)
+  description = "Groups which may create leases, from the Cognito groups or `custom:roles` attribute of the user, for accounts in pools without their own `pool_lease_groups`. Any user may create leases when empty."
+  default     = []
+}
+
+variable "pool_lease_groups" {
+  type        = map(list(string))
+  description = "Groups which may lease the accounts of each pool, instead of the `lease_groups`, eg. { gpu = [\"ML Engineers\"] }"
+  default     = {}
+}
+
 variable "max_lease_budget_amount" {
   type        = number
   description = "Lease budget amount for given lease budget period"
@@ -204,6 +385,12 @@ variable "max_lease_period" {
   default     = 604800
 }
 
+variable "default_lease_length_in_days" {
+  type        = number
+  description = "Number of days leases last when they're created without an expiresOn. Leases never last longer than max_lease_period"
+  default     = 7
+}
+
 variable "principal_budget_amount" {
   type        = number
   description = "User Principal's budget amount for given principal budget period"
@@ -216,6 +403,30 @@ variable "principal_budget_period" {
   default     = "WEEKLY"
 }
 
+variable "budget_currency" {
+  type        = string
+  description = "Currency lease and principal budgets are in. Spend reported by Cost Explorer in USD is converted to this currency."
+  default     = "USD"
+}
+
+variable "budget_currency_rates" {
+  type        = map(number)
+  description = "Amount of each currency one USD buys, eg. { EUR = 0.92 }. Required when budget_currency is not USD, unless budget_currency_rate_url is set."
+  default     = {}
+}
+
+variable "budget_currency_rate_url" {
+  type        = string
+  description = "Exchange rate API responding with the rates of USD, eg. {\"rates\": {\"EUR\": 0.92}}. When set, budget_currency_rates is used only if the API fails."
+  default     = ""
+}
+
+variable "budget_categories" {
+  type        = map(list(string))
+  description = "AWS services in each category of spend leases can have a budget for, by their name in Cost Explorer, eg. { gpu = [\"Amazon Elastic Compute Cloud - Compute\"] }. Defaults to compute, storage and ai categories when empty."
+  default     = {}
+}
+
 variable "allowed_regions" {
   type = list(string)
   default = [
@@ -230,12 +441,36 @@ variable "orphaned_accounts_alarm_threshold" {
   default     = "1"
 }
 
+variable "unreachable_accounts_alarm_threshold" {
+  type        = string
+  description = "Alarm when number of unreachable (closed or suspended) accounts is greater than or equal to this threshold."
+  default     = "1"
+}
+
 variable "ready_accounts_alarm_threshold" {
   type        = string
   description = "Alarm when number of ready accounts is less than or equal to this threshold."
   default     = "20"
 }
 
+variable "ready_pool_low_threshold" {
+  type        = number
+  description = "Alert operators, and publish an AccountPoolLow event, when fewer accounts than this are Ready. Requires account_pool_metrics_toggle. Disabled when 0"
+  default     = 0
+}
+
+variable "ready_pool_low_percent" {
+  type        = number
+  description = "Alert operators, and publish an AccountPoolLow event, when less than this percentage of leasable accounts are Ready. Requires account_pool_metrics_toggle. Disabled when 0"
+  default     = 0
+}
+
+variable "lease_estimate_lookback_days" {
+  type        = number
+  default     = 30
+  description = "Number of days of past leases used to estimate the cost of new leases. Usage older than usage_ttl is deleted, so can't be used."
+}
+
 variable "usage_ttl" {
   type = number
   # 30 days
@@ -267,6 +502,96 @@ variable "leases_table_wcu" {
   description = "DynamoDB Leases table provisioned Write Capacity Units (WCUs). See https://aws.amazon.com/dynamodb/pricing/provisioned/"
 }
 
+variable "status_shard_count" {
+  type        = number
+  default     = 1
+  description = "Number of shards to spread account and lease statuses over, to avoid throttling of the status indexes in high churn deployments. Run the status shards migration after changing this."
+}
+
+variable "diagnostics_enabled" {
+  type        = bool
+  default     = false
+  description = "Log runtime stats and upload heap and goroutine profiles of the reset builds and stream consumers to the artifacts bucket, to diagnose memory growth and goroutine leaks"
+}
+
+variable "reset_snapshot_enabled" {
+  type        = bool
+  default     = false
+  description = "Save an inventory of each account's resources to the artifacts bucket before it is reset, so there is a record of what a reset deleted"
+}
+
+variable "reset_snapshot_s3_manifests" {
+  type        = bool
+  default     = false
+  description = "List the objects of each account's S3 buckets in its reset snapshot, when reset_snapshot_enabled is true"
+}
+
+variable "reset_blackout_enabled" {
+  type        = bool
+  default     = false
+  description = "Check CloudTrail for activity by principals outside DCE while each account is reset, and keep accounts with any NotReady until an admin acknowledges it"
+}
+
+variable "reset_blackout_ignored_principals" {
+  type        = list(string)
+  default     = []
+  description = "ARNs of roles and users outside DCE which are expected to make calls in accounts, eg. security scanners, and aren't reported by reset_blackout_enabled"
+}
+
+variable "reset_leak_tracking_enabled" {
+  type        = bool
+  default     = false
+  description = "Record the resources left in each account after it's reset, and how many resets in a row each survived, to find gaps in the aws-nuke configuration"
+}
+
+variable "reset_profile" {
+  type        = string
+  default     = "full"
+  description = "Resource types removed when accounts are reset, unless a lease is ended with another profile: full, compute-only or data-preserving"
+}
+
+variable "account_cooldown_minutes" {
+  type        = number
+  default     = 0
+  description = "How long after an account is reset before it can be leased again, so the billing of deleted resources settles and their deletion is consistent. Zero leases accounts as soon as they're reset."
+}
+
+variable "account_gc_enabled" {
+  type        = bool
+  default     = false
+  description = "Retry the remediation of accounts stuck NotReady or Unreachable, and quarantine them if they're still stuck"
+}
+
+variable "account_gc_stuck_hours" {
+  type        = number
+  default     = 72
+  description = "Hours an account must be NotReady or Unreachable before its remediation is retried, and after the retry before it's quarantined"
+}
+
+variable "account_gc_digest_emails" {
+  type        = list(string)
+  default     = []
+  description = "Email addresses sent a digest of the accounts which were quarantined"
+}
+
+variable "account_gc_schedule_expression" {
+  type        = string
+  default     = "rate(1 day)"
+  description = "Schedule to check for stuck accounts"
+}
+
+variable "reset_snapshot_retention_days" {
+  type        = number
+  default     = 30
+  description = "Number of days reset snapshots are kept in the artifacts bucket"
+}
+
+variable "diagnostics_interval" {
+  type        = number
+  default     = 300
+  description = "Minimum number of seconds between diagnostics captures, when diagnostics_enabled is true"
+}
+
 variable "usage_table_rcu" {
   type        = number
   default     = 5
@@ -278,3 +603,659 @@ variable "usage_table_wcu" {
   default     = 5
   description = "DynamoDB Usage table provisioned Write Capacity Units (WCUs). See https://aws.amazon.com/dynamodb/pricing/provisioned/"
 }
+
+variable "event_bus_name" {
+  type        = string
+  default     = ""
+  description = "Name of an EventBridge event bus to publish versioned DCE domain events to (eg. LeaseCreated, AccountStatusChanged, ResetCompleted). Set to \"default\" to use the account's default event bus. Publishing is disabled when empty."
+}
+
+variable "event_archive_toggle" {
+  description = "Set to 'true' to archive every published domain event to an S3 bucket, partitioned by event type and date. Defaults to 'false'"
+  default     = "false"
+}
+
+variable "event_archive_retention_days" {
+  type        = number
+  default     = 0
+  description = "Number of days archived events are locked against being deleted or overwritten, with S3 Object Lock. Events are not locked when 0. Can only be set when the archive bucket is created."
+}
+
+variable "event_retention_days" {
+  type        = number
+  default     = 90
+  description = "Number of days events are listed in the activity feed (GET /events), before DynamoDB expires them. Events are kept forever when 0"
+}
+
+variable "events_table_rcu" {
+  type        = number
+  default     = 5
+  description = "DynamoDB Events table provisioned Read Capacity Units (RCUs). See https://aws.amazon.com/dynamodb/pricing/provisioned/"
+}
+
+variable "events_table_wcu" {
+  type        = number
+  default     = 5
+  description = "DynamoDB Events table provisioned Write Capacity Units (WCUs). See https://aws.amazon.com/dynamodb/pricing/provisioned/"
+}
+
+variable "event_sink_driver" {
+  type        = string
+  default     = ""
+  description = "Mirror every published domain event to a Kinesis stream ('kinesis') or Kafka topic ('kafka'). Disabled when empty"
+}
+
+variable "event_sink_target" {
+  type        = string
+  default     = ""
+  description = "Name of the Kinesis stream, or Kafka REST Proxy URL of the topic (eg. https://kafka-rest.example.com/topics/dce-events), to mirror events to. A Kinesis stream is created when empty"
+}
+
+variable "event_sink_auth" {
+  type        = string
+  default     = ""
+  description = "Credentials for the Kafka REST Proxy, as 'username:password' or a bearer token"
+}
+
+variable "event_sink_shard_count" {
+  type        = number
+  default     = 1
+  description = "Number of shards of the Kinesis stream created for the event sink"
+}
+
+variable "lease_workflows_toggle" {
+  description = "Set to 'true' to run Step Functions state machines when leases are created and ended, recording their execution ARNs on the lease. Defaults to 'false'"
+  default     = "false"
+}
+
+variable "account_warm_up_toggle" {
+  description = "Set to 'true' to run the account_warm_up_steps on new accounts, which stay NotReady until their warm-up succeeds. Defaults to 'false'"
+  default     = "false"
+}
+
+variable "account_warm_up_steps" {
+  type        = list(string)
+  description = "Steps run, in order, to warm up new accounts: CreatePrincipalRole, ApplyBaseline, InitialReset and VerifyHealth"
+  default     = ["CreatePrincipalRole", "ApplyBaseline", "InitialReset", "VerifyHealth"]
+}
+
+variable "account_warm_up_baseline_template" {
+  type        = string
+  description = "Location of a CloudFormation template deployed to new accounts by the ApplyBaseline warm-up step. The step does nothing when empty."
+  default     = ""
+}
+
+variable "account_warm_up_baseline_stack_name" {
+  type        = string
+  description = "Name of the stack the baseline template is deployed as. Exclude its resources from the reset nuke config."
+  default     = "dce-baseline"
+}
+
+variable "slack_signing_secret" {
+  type        = string
+  default     = ""
+  description = "Signing secret of the DCE Slack app, used to verify requests to the /slack endpoints. The Slack integration is disabled when empty."
+}
+
+variable "slack_bot_token" {
+  type        = string
+  default     = ""
+  description = "Bot token of the DCE Slack app (xoxb-...). When set, budget notifications are also sent as direct messages to the Slack users with the lease's notification emails."
+}
+
+variable "slack_approval_channel" {
+  type        = string
+  default     = ""
+  description = "ID of a Slack channel where lease requests made from Slack are posted for approval. Leases requested from Slack are created immediately when empty."
+}
+
+variable "slack_approvers" {
+  type        = list(string)
+  default     = []
+  description = "Slack user IDs which may approve lease requests. Anyone in the approval channel may approve them when empty."
+}
+
+variable "alert_driver" {
+  type        = string
+  default     = ""
+  description = "On-call tool to open incidents in for operational failures, either \"pagerduty\" or \"opsgenie\". Alerting is disabled when empty."
+}
+
+variable "alert_integration_key" {
+  type        = string
+  default     = ""
+  description = "PagerDuty Events API v2 routing key, or Opsgenie API integration key"
+}
+
+variable "alert_api_url" {
+  type        = string
+  default     = ""
+  description = "Overrides the API URL of the alert driver, eg. https://api.eu.opsgenie.com for the Opsgenie EU instance"
+}
+
+variable "ticket_driver" {
+  type        = string
+  default     = ""
+  description = "Issue tracker to create tickets in for lifecycle events, either \"jira\" or \"servicenow\". Tickets are disabled when empty."
+}
+
+variable "ticket_api_url" {
+  type        = string
+  default     = ""
+  description = "URL of the Jira or ServiceNow instance, eg. https://example.atlassian.net"
+}
+
+variable "ticket_username" {
+  type        = string
+  default     = ""
+  description = "Username for the issue tracker. For Jira Cloud, this is the email of the user."
+}
+
+variable "ticket_api_token" {
+  type        = string
+  default     = ""
+  description = "Jira API token, or ServiceNow password, of the ticket user"
+}
+
+variable "ticket_project" {
+  type        = string
+  default     = ""
+  description = "Key of the Jira project to create issues in"
+}
+
+variable "ticket_issue_type" {
+  type        = string
+  default     = "Task"
+  description = "Type of the Jira issues to create"
+}
+
+variable "ticket_table" {
+  type        = string
+  default     = "incident"
+  description = "ServiceNow table to create records in"
+}
+
+variable "ticket_events" {
+  type        = list(string)
+  default     = ["AccountQuarantined", "LeaseOverBudget", "ResetFailed"]
+  description = "Lifecycle events to create tickets for"
+}
+
+variable "ticket_summary_template" {
+  type        = string
+  default     = ""
+  description = "Go template for the ticket summary. Uses a default template when empty."
+}
+
+variable "ticket_description_template" {
+  type        = string
+  default     = ""
+  description = "Go template for the ticket description. Uses a default template when empty."
+}
+
+variable "ticket_fields" {
+  type        = map(string)
+  default     = {}
+  description = "Additional fields to set on tickets, whose values are Go templates, eg. { assignment_group = \"Cloud Ops\" }"
+}
+
+variable "prometheus_alb_subnet_ids" {
+  type        = list(string)
+  default     = []
+  description = "Subnets for a private load balancer serving Prometheus metrics at /metrics. The load balancer is not created when empty."
+}
+
+variable "prometheus_alb_security_group_ids" {
+  type        = list(string)
+  default     = []
+  description = "Security groups for the Prometheus metrics load balancer"
+}
+
+variable "prometheus_pushgateway_url" {
+  type        = string
+  default     = ""
+  description = "URL of a Prometheus Pushgateway to push metrics to, eg. https://pushgateway.example.com. Metrics are not pushed when empty."
+}
+
+variable "prometheus_push_schedule_expression" {
+  type        = string
+  default     = "rate(5 minutes)"
+  description = "How often to push metrics to the Prometheus Pushgateway. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html"
+}
+
+variable "metrics_driver" {
+  type        = string
+  default     = ""
+  description = "Monitoring service to send metrics and lifecycle events to. Only \"datadog\" is supported. Disabled when empty."
+}
+
+variable "metrics_api_key" {
+  type        = string
+  default     = ""
+  description = "API key of the monitoring service"
+}
+
+variable "metrics_api_url" {
+  type        = string
+  default     = ""
+  description = "Overrides the API URL of the monitoring service, eg. https://api.datadoghq.eu for the Datadog EU site"
+}
+
+variable "metrics_tags" {
+  type        = list(string)
+  default     = []
+  description = "Tags to add to every metric and event, eg. [\"env:prod\"]"
+}
+
+variable "metrics_publish_schedule_expression" {
+  type        = string
+  default     = "rate(5 minutes)"
+  description = "How often to send metrics to the monitoring service. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html"
+}
+
+variable "directory_driver" {
+  type        = string
+  default     = ""
+  description = "Directory to look up lease principals in, either \"okta\" or \"azuread\". Disabled when empty."
+}
+
+variable "directory_url" {
+  type        = string
+  default     = ""
+  description = "Okta org URL, eg. https://example.okta.com. For Azure AD, optionally overrides the Microsoft Graph URL."
+}
+
+variable "directory_api_token" {
+  type        = string
+  default     = ""
+  description = "Okta API token"
+}
+
+variable "directory_tenant_id" {
+  type        = string
+  default     = ""
+  description = "Azure AD tenant ID"
+}
+
+variable "directory_client_id" {
+  type        = string
+  default     = ""
+  description = "Client ID of the Azure AD app registration used to call Microsoft Graph"
+}
+
+variable "directory_client_secret" {
+  type        = string
+  default     = ""
+  description = "Client secret of the Azure AD app registration used to call Microsoft Graph"
+}
+
+variable "directory_group_quotas" {
+  type        = map(map(number))
+  default     = {}
+  description = "Lease quotas by directory group, eg. { Engineering = { maxLeaseBudgetAmount = 2000, principalBudgetAmount = 5000 } }"
+}
+
+variable "directory_approver_groups" {
+  type        = list(string)
+  default     = []
+  description = "Directory groups whose members may approve lease requests in Slack"
+}
+
+variable "directory_sync_schedule_expression" {
+  type        = string
+  default     = "rate(1 hour)"
+  description = "How often to end the leases of principals deactivated in the directory. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html"
+}
+
+variable "directory_offboarding_emails" {
+  type        = list(string)
+  default     = []
+  description = "Email addresses notified when the leases of a principal deactivated in the directory are ended, as well as the principal's manager"
+}
+
+variable "account_factory_driver" {
+  type        = string
+  default     = ""
+  description = "How to create new accounts when the account pool runs low, either \"organizations\" or \"controltower\". Accounts are not created when empty"
+}
+
+variable "account_factory_min_ready_accounts" {
+  type        = number
+  default     = 0
+  description = "Number of accounts to keep in the account pool, including accounts which are being created or reset"
+}
+
+variable "account_factory_max_accounts_per_run" {
+  type        = number
+  default     = 5
+  description = "Maximum number of accounts to request at once"
+}
+
+variable "account_factory_email" {
+  type        = string
+  default     = ""
+  description = "Email address of new accounts. The account name is added to it, eg. aws+dce-prod-20200102-150405-1@example.com"
+}
+
+variable "account_factory_admin_role_name" {
+  type        = string
+  default     = ""
+  description = "Name of the role DCE uses to manage new accounts. Defaults to OrganizationAccountAccessRole, or AWSControlTowerExecution for Control Tower"
+}
+
+variable "account_factory_product_id" {
+  type        = string
+  default     = ""
+  description = "ID of the Control Tower Account Factory product in Service Catalog"
+}
+
+variable "account_factory_provisioning_artifact_id" {
+  type        = string
+  default     = ""
+  description = "ID of the Control Tower Account Factory product version in Service Catalog"
+}
+
+variable "account_factory_organizational_unit" {
+  type        = string
+  default     = ""
+  description = "Control Tower managed OU to enroll new accounts in, eg. \"Sandbox\""
+}
+
+variable "account_factory_schedule_expression" {
+  type        = string
+  default     = "rate(15 minutes)"
+  description = "How often to check the size of the account pool. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html"
+}
+
+variable "cmdb_push_url" {
+  type        = string
+  default     = ""
+  description = "URL to push account ownership and status to, as a Go template, eg. https://example.service-now.com/api/now/table/cmdb_ci_cloud_service_account/{{.AccountID}}. Pushing is disabled when empty."
+}
+
+variable "cmdb_push_method" {
+  type        = string
+  default     = "PUT"
+  description = "HTTP method used to push accounts to the CMDB"
+}
+
+variable "cmdb_fields" {
+  type        = map(string)
+  default     = {}
+  description = "Fields pushed to the CMDB, as Go templates of the account record, eg. { u_owner = \"{{.PrincipalID}}\" }. Defaults to the account and lease IDs and statuses, principal ID and namespace."
+}
+
+variable "cmdb_pull_url" {
+  type        = string
+  default     = ""
+  description = "URL of an account's CMDB record, as a Go template, to pull tags from. Pulling tags is disabled when empty."
+}
+
+variable "cmdb_tags" {
+  type        = map(string)
+  default     = {}
+  description = "Account metadata tags pulled from the CMDB, with dot-separated paths in the CMDB record, eg. { CostCenter = \"result.0.u_cost_center\" }"
+}
+
+variable "cmdb_username" {
+  type        = string
+  default     = ""
+  description = "CMDB username, for basic authentication"
+}
+
+variable "cmdb_api_token" {
+  type        = string
+  default     = ""
+  description = "CMDB password, or API token sent as a bearer token when there is no username"
+}
+
+variable "cmdb_sync_schedule_expression" {
+  type        = string
+  default     = "rate(1 hour)"
+  description = "How often to sync accounts with the CMDB. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html"
+}
+
+variable "service_catalog_toggle" {
+  description = "Set to 'true' to offer DCE leases as an AWS Service Catalog product. Defaults to 'false'"
+  default     = "false"
+}
+
+variable "service_catalog_portfolio_name" {
+  type        = string
+  default     = "DCE Sandboxes"
+  description = "Name of the Service Catalog portfolio. The namespace is added to the name"
+}
+
+variable "service_catalog_product_name" {
+  type        = string
+  default     = "DCE Sandbox Lease"
+  description = "Name of the Service Catalog product"
+}
+
+variable "service_catalog_principal_arns" {
+  type        = list(string)
+  default     = []
+  description = "ARNs of the IAM users, groups and roles which may provision the Service Catalog product"
+}
+
+variable "service_catalog_default_lease_days" {
+  type        = number
+  default     = 7
+  description = "Default number of days until leases requested from Service Catalog expire"
+}
+
+variable "service_catalog_budget_currency" {
+  type        = string
+  default     = ""
+  description = "Currency of lease budgets requested from Service Catalog. Defaults to budget_currency."
+}
+
+variable "log_aggregation_bucket" {
+  type        = string
+  default     = ""
+  description = "S3 bucket in the logging account leased accounts deliver their CloudTrail logs to. Leave empty to not deliver CloudTrail logs."
+}
+
+variable "log_aggregation_event_bus_arn" {
+  type        = string
+  default     = ""
+  description = "ARN of the event bus in the logging account leased accounts forward their CloudWatch Events to. Leave empty to not forward events."
+}
+
+variable "lease_budget_alarms_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, a budget mirroring the lease budget is created in leased accounts when the lease starts, and removed when the account is reset"
+}
+
+variable "opa_url" {
+  type        = string
+  default     = ""
+  description = "URL of the Open Policy Agent server which evaluates the lease approval and account allocation policies, eg. http://opa.internal:8181. Leave empty to not evaluate policies."
+}
+
+variable "policy_bundle" {
+  type        = string
+  default     = ""
+  description = "Location of an OPA bundle (.tar.gz) of rego policies, uploaded to the artifacts bucket for the OPA server to load"
+}
+
+variable "outbox_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, the events of lease and account changes are written to an outbox table in the same transaction as the change, and published by the outbox_publisher Lambda, so no event is lost or published for a change which failed"
+}
+
+variable "outbox_schedule_expression" {
+  type        = string
+  default     = "rate(1 minute)"
+  description = "Schedule to publish the events waiting in the outbox"
+}
+
+variable "lease_waitlist_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, lease requests made with ?waitlist=true while no accounts are Ready wait for an account on a first come first served waitlist, instead of failing"
+}
+
+variable "lease_waitlist_max_wait_hours" {
+  type        = number
+  default     = 24
+  description = "How long a lease request waits on the waitlist for an account"
+}
+
+variable "lease_waitlist_schedule_expression" {
+  type        = string
+  default     = "rate(5 minutes)"
+  description = "Schedule to lease Ready accounts to the lease requests on the waitlist"
+}
+
+variable "notification_routing_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, budget threshold notifications and reset failures are also sent to the Slack channels, emails and alerts of the routing rules managed with /system/notification-routes"
+}
+
+variable "lease_archive_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, leases which ended more than lease_archive_after_days ago are moved to an S3 bucket which can be queried with Athena, leaving a small index record in DynamoDB"
+}
+
+variable "lease_archive_after_days" {
+  type        = number
+  default     = 90
+  description = "How long after they end leases are archived"
+}
+
+variable "lease_archive_schedule_expression" {
+  type        = string
+  default     = "rate(1 day)"
+  description = "Schedule to archive leases"
+}
+
+variable "pool_simulation_reset_hours" {
+  type        = number
+  default     = 1
+  description = "How long an account takes to reset, on average, in pool simulations (POST /system/simulation)"
+}
+
+variable "pool_simulation_reset_concurrency" {
+  type        = number
+  default     = 20
+  description = "How many accounts can be reset at once, in pool simulations (POST /system/simulation). At most the CodeBuild concurrent builds limit"
+}
+
+variable "api_keys_enabled" {
+  type        = bool
+  default     = false
+  description = "If true, machine clients may call the API with API keys managed with /system/api-keys, limited to the scopes of their key"
+}
+
+variable "api_client_principals" {
+  type        = list(string)
+  default     = []
+  description = "ARNs of the IAM principals, eg. CI roles, allowed to assume the API client role. The role may only call the API with an API key"
+}
+
+variable "savings_baseline_provisioning_hours" {
+  type        = number
+  default     = 72
+  description = "How long it took to get an AWS account before DCE. The savings report (GET /leases/savings) measures the time to provision leases against it"
+}
+
+variable "savings_baseline_account_lifetime_days" {
+  type        = number
+  default     = 90
+  description = "How long AWS accounts were kept before DCE. The savings report counts the spend of reclaimed accounts over the rest of this lifetime as idle spend prevented"
+}
+
+variable "savings_report_enabled" {
+  type        = bool
+  default     = false
+  description = "Email the savings report of the last month to the savings_report_emails on the first of each month"
+}
+
+variable "savings_report_emails" {
+  type        = list(string)
+  default     = []
+  description = "Email addresses, eg. of program owners, sent the monthly savings report"
+}
+
+variable "api_cache_max_age" {
+  type        = number
+  default     = 0
+  description = "Seconds clients, eg. dashboards, may reuse the responses of the account, lease and usage lists and the pool status before revalidating them. Unchanged responses are revalidated with 304 Not Modified either way"
+}
+
+variable "auth_provider" {
+  type        = string
+  default     = "cognito"
+  description = "How API requests are authenticated and their users identified: iam (SigV4, with admins from iam_admin_principals), cognito (SigV4 with Cognito identities) or oidc (bearer tokens of the oidc_issuer)"
+}
+
+variable "iam_admin_principals" {
+  type        = list(string)
+  default     = []
+  description = "ARNs of the IAM users and roles which are DCE admins, when the auth_provider is iam. Other callers are users named after their IAM user or role session. Every caller is an admin when empty"
+}
+
+variable "oidc_issuer" {
+  type        = string
+  default     = ""
+  description = "Issuer URL of the OpenID Connect provider, eg. https://example.okta.com, when the auth_provider is oidc"
+}
+
+variable "oidc_audience" {
+  type        = string
+  default     = ""
+  description = "Client ID the OIDC provider issues DCE's tokens for"
+}
+
+variable "oidc_username_claim" {
+  type        = string
+  default     = "sub"
+  description = "Token claim of the user's principal ID, eg. email"
+}
+
+variable "oidc_groups_claim" {
+  type        = string
+  default     = "groups"
+  description = "Token claim of the user's groups"
+}
+
+variable "oidc_admin_groups" {
+  type        = list(string)
+  default     = []
+  description = "Groups of the OIDC provider whose members are DCE admins"
+}
+
+variable "principal_id_patterns" {
+  type        = list(string)
+  default     = []
+  description = "Formats principal IDs must match one of: email, iam-arn, employee-id, or regular expressions. Any principal ID is valid when empty"
+}
+
+variable "principal_id_normalization" {
+  type        = list(string)
+  default     = []
+  description = "Normalizations of principal IDs, in order, so one principal isn't recorded under several IDs: trim, lowercase or lowercase-emails"
+}
+
+variable "account_readiness_probe" {
+  type        = bool
+  default     = false
+  description = "Assume the admin and principal roles of Ready accounts before they're leased, and make accounts whose roles can't be assumed NotReady instead of leasing them"
+}
+
+variable "max_leases_per_principal" {
+  type        = number
+  default     = 1
+  description = "How many Active leases a principal may have at once"
+}
+
+variable "lease_auth_session_duration" {
+  type        = number
+  default     = 0
+  description = "How many seconds the credentials and console sessions of POST /leases/{id}/auth last, from 900 to 3600, the most AWS allows when chaining roles. AWS's default of 3600 when 0"
+}
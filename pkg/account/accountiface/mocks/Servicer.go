@@ -3,7 +3,6 @@
 package mocks
 
 import account "github.com/Optum/dce/pkg/account"
-
 import mock "github.com/stretchr/testify/mock"
 
 // Servicer is an autogenerated mock type for the Servicer type
@@ -11,6 +10,29 @@ type Servicer struct {
 	mock.Mock
 }
 
+// AcknowledgeBlackout provides a mock function with given fields: id, acknowledgedBy
+func (_m *Servicer) AcknowledgeBlackout(id string, acknowledgedBy string) (*account.Account, error) {
+	ret := _m.Called(id, acknowledgedBy)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, string) *account.Account); ok {
+		r0 = rf(id, acknowledgedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, acknowledgedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: data
 func (_m *Servicer) Create(data *account.Account) (*account.Account, error) {
 	ret := _m.Called(data)
@@ -71,6 +93,75 @@ func (_m *Servicer) Get(ID string) (*account.Account, error) {
 	return r0, r1
 }
 
+// GetLastLeasedAccount provides a mock function with given fields: principalID, requirements
+func (_m *Servicer) GetLastLeasedAccount(principalID string, requirements *account.Requirements) (*account.Account, error) {
+	ret := _m.Called(principalID, requirements)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, *account.Requirements) *account.Account); ok {
+		r0 = rf(principalID, requirements)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *account.Requirements) error); ok {
+		r1 = rf(principalID, requirements)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadyAccount provides a mock function with given fields: principalID
+func (_m *Servicer) GetReadyAccount(principalID string) (*account.Account, error) {
+	ret := _m.Called(principalID)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string) *account.Account); ok {
+		r0 = rf(principalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(principalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadyAccountWithRequirements provides a mock function with given fields: principalID, requirements
+func (_m *Servicer) GetReadyAccountWithRequirements(principalID string, requirements *account.Requirements) (*account.Account, error) {
+	ret := _m.Called(principalID, requirements)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, *account.Requirements) *account.Account); ok {
+		r0 = rf(principalID, requirements)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *account.Requirements) error); ok {
+		r1 = rf(principalID, requirements)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: query
 func (_m *Servicer) List(query *account.Account) (*account.Accounts, error) {
 	ret := _m.Called(query)
@@ -108,6 +199,45 @@ func (_m *Servicer) ListPages(query *account.Account, fn func(*account.Accounts)
 	return r0
 }
 
+// Move provides a mock function with given fields: id, move
+func (_m *Servicer) Move(id string, move *account.Move) (*account.Account, error) {
+	ret := _m.Called(id, move)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, *account.Move) *account.Account); ok {
+		r0 = rf(id, move)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *account.Move) error); ok {
+		r1 = rf(id, move)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PoolLeaseGroups provides a mock function with given fields: pool
+func (_m *Servicer) PoolLeaseGroups(pool *string) []string {
+	ret := _m.Called(pool)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(*string) []string); ok {
+		r0 = rf(pool)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // Reset provides a mock function with given fields: id
 func (_m *Servicer) Reset(id string) (*account.Account, error) {
 	ret := _m.Called(id)
@@ -131,6 +261,52 @@ func (_m *Servicer) Reset(id string) (*account.Account, error) {
 	return r0, r1
 }
 
+// ResetBatch provides a mock function with given fields: ids
+func (_m *Servicer) ResetBatch(ids []string) ([]*account.Account, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*account.Account
+	if rf, ok := ret.Get(0).(func([]string) []*account.Account); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetWithProfile provides a mock function with given fields: id, profile
+func (_m *Servicer) ResetWithProfile(id string, profile string) (*account.Account, error) {
+	ret := _m.Called(id, profile)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, string) *account.Account); ok {
+		r0 = rf(id, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: data
 func (_m *Servicer) Save(data *account.Account) error {
 	ret := _m.Called(data)
@@ -168,6 +344,29 @@ func (_m *Servicer) Update(ID string, data *account.Account) (*account.Account,
 	return r0, r1
 }
 
+// UpdateServiceQuotas provides a mock function with given fields: id, quotas
+func (_m *Servicer) UpdateServiceQuotas(id string, quotas []account.ServiceQuota) (*account.Account, error) {
+	ret := _m.Called(id, quotas)
+
+	var r0 *account.Account
+	if rf, ok := ret.Get(0).(func(string, []account.ServiceQuota) *account.Account); ok {
+		r0 = rf(id, quotas)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []account.ServiceQuota) error); ok {
+		r1 = rf(id, quotas)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertPrincipalAccess provides a mock function with given fields: data
 func (_m *Servicer) UpsertPrincipalAccess(data *account.Account) error {
 	ret := _m.Called(data)
@@ -181,3 +380,45 @@ func (_m *Servicer) UpsertPrincipalAccess(data *account.Account) error {
 
 	return r0
 }
+
+// ValidateCreate provides a mock function with given fields: data
+func (_m *Servicer) ValidateCreate(data *account.Account) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateDelete provides a mock function with given fields: data
+func (_m *Servicer) ValidateDelete(data *account.Account) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidatePolicyCustomization provides a mock function with given fields: data
+func (_m *Servicer) ValidatePolicyCustomization(data *account.PolicyCustomization) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.PolicyCustomization) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -16,14 +16,38 @@ type Servicer interface {
 	Update(ID string, data *account.Account) (*account.Account, error)
 	// Delete finds a given account and deletes it if it is not of status `Leased`. Returns the account.
 	Delete(data *account.Account) error
+	// ValidateDelete checks an account can be deleted, without deleting it
+	ValidateDelete(data *account.Account) error
 	// List Get a list of accounts based on Principal ID
 	List(query *account.Account) (*account.Accounts, error)
 	// ListPages Execute a function per page of accounts
 	ListPages(query *account.Account, fn func(*account.Accounts) bool) error
+	// GetReadyAccount returns a Ready account which can be leased to the principal, or nil when there are none
+	GetReadyAccount(principalID string) (*account.Account, error)
+	// GetReadyAccountWithRequirements returns the Ready account which best meets the requirements and can be leased to the principal, or nil when there are none
+	GetReadyAccountWithRequirements(principalID string, requirements *account.Requirements) (*account.Account, error)
+	// GetLastLeasedAccount returns the Ready account which was last leased to the principal, or nil when it can't be leased to them again or doesn't meet the requirements
+	GetLastLeasedAccount(principalID string, requirements *account.Requirements) (*account.Account, error)
+	// PoolLeaseGroups returns the groups whose members may lease the accounts of the pool, or of any pool when it's nil
+	PoolLeaseGroups(pool *string) []string
+	// ValidateCreate checks that an account could be created from the data provided without making any changes
+	ValidateCreate(data *account.Account) error
 	// Create creates a new account using the data provided. Returns the account record
 	Create(data *account.Account) (*account.Account, error)
 	// Reset initiates the Reset account process.
 	Reset(id string) (*account.Account, error)
+	// ResetWithProfile initiates the reset of the account with a reset profile, or the pool's when it's empty
+	ResetWithProfile(id string, profile string) (*account.Account, error)
+	// AcknowledgeBlackout acknowledges activity found while the account was reset, and resets it again
+	AcknowledgeBlackout(id string, acknowledgedBy string) (*account.Account, error)
+	// Move moves an account which isn't leased to another pool, and to another organizational unit when one is given
+	Move(id string, move *account.Move) (*account.Account, error)
+	// UpdateServiceQuotas replaces the service quota increases recorded for the account
+	UpdateServiceQuotas(id string, quotas []account.ServiceQuota) (*account.Account, error)
+	// ResetBatch queues resets of several accounts, which are already NotReady
+	ResetBatch(ids []string) ([]*account.Account, error)
 	// UpsertPrincipalAccess merges principal access to make sure its
 	UpsertPrincipalAccess(data *account.Account) error
+	// ValidatePolicyCustomization checks a customization of the principal policy only adds and removes allowlisted statements
+	ValidatePolicyCustomization(data *account.PolicyCustomization) error
 }
@@ -0,0 +1,32 @@
+package account
+
+// Blackout is activity found in an account while it was being reset, by
+// principals outside DCE.  Activity after a lease ends suggests someone still
+// has credentials to the account, so it stays NotReady until an admin
+// acknowledges the blackout.
+type Blackout struct {
+	// DetectedOn is when the reset found the activity
+	DetectedOn int64 `json:"detectedOn" dynamodbav:"DetectedOn"`
+	// Since is the start of the period checked for activity
+	Since    int64               `json:"since" dynamodbav:"Since"`
+	Activity []*BlackoutActivity `json:"activity" dynamodbav:"Activity"`
+	// AcknowledgedOn is when an admin acknowledged the activity, and the
+	// account was reset again.  Activity before then is ignored.
+	AcknowledgedOn *int64  `json:"acknowledgedOn,omitempty" dynamodbav:"AcknowledgedOn,omitempty"`
+	AcknowledgedBy *string `json:"acknowledgedBy,omitempty" dynamodbav:"AcknowledgedBy,omitempty"`
+}
+
+// BlackoutActivity is a CloudTrail event found during a blackout
+type BlackoutActivity struct {
+	PrincipalArn string `json:"principalArn" dynamodbav:"PrincipalArn"`
+	EventName    string `json:"eventName" dynamodbav:"EventName"`
+	EventSource  string `json:"eventSource" dynamodbav:"EventSource"`
+	Region       string `json:"region" dynamodbav:"Region"`
+	EventTime    int64  `json:"eventTime" dynamodbav:"EventTime"`
+}
+
+// IsBlocking is true until the blackout is acknowledged.  Accounts with a
+// blocking blackout aren't made Ready by their reset.
+func (b *Blackout) IsBlocking() bool {
+	return b != nil && b.AcknowledgedOn == nil
+}
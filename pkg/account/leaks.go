@@ -0,0 +1,41 @@
+package account
+
+// LeakMinResets is how many resets in a row a resource survives before it's
+// a leak.  Resources found after one reset may just be slow to delete.
+const LeakMinResets = 2
+
+// ResetLeaks are the resources found in an account after its last reset, and
+// how many resets in a row each survived.  Resources which survive every reset
+// point to a gap in the reset's aws-nuke configuration.
+type ResetLeaks struct {
+	// CheckedOn is when the resources were found, after the reset
+	CheckedOn int64        `json:"checkedOn" dynamodbav:"CheckedOn"`
+	Resources []*ResetLeak `json:"resources" dynamodbav:"Resources"`
+	// Truncated is set when there were more resources than were recorded
+	Truncated bool `json:"truncated,omitempty" dynamodbav:"Truncated,omitempty"`
+}
+
+// ResetLeak is a resource which survived the account's resets
+type ResetLeak struct {
+	ARN    string `json:"arn" dynamodbav:"ARN"`
+	Region string `json:"region" dynamodbav:"Region"`
+	// FirstSeenOn is when the resource was first found after a reset
+	FirstSeenOn int64 `json:"firstSeenOn" dynamodbav:"FirstSeenOn"`
+	// Resets is how many resets in a row the resource survived
+	Resets int `json:"resets" dynamodbav:"Resets"`
+}
+
+// Leaked returns the resources which survived at least LeakMinResets resets
+// in a row
+func (l *ResetLeaks) Leaked() []*ResetLeak {
+	leaked := []*ResetLeak{}
+	if l == nil {
+		return leaked
+	}
+	for _, r := range l.Resources {
+		if r.Resets >= LeakMinResets {
+			leaked = append(leaked, r)
+		}
+	}
+	return leaked
+}
@@ -0,0 +1,24 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetLeaksLeaked(t *testing.T) {
+	leaks := &account.ResetLeaks{
+		Resources: []*account.ResetLeak{
+			{ARN: "arn:aws:sns:us-east-1:123456789012:new", Resets: 1},
+			{ARN: "arn:aws:sns:us-east-1:123456789012:leaked", Resets: 2},
+			{ARN: "arn:aws:sns:us-east-1:123456789012:old", Resets: 5},
+		},
+	}
+
+	leaked := leaks.Leaked()
+
+	assert.Equal(t, []*account.ResetLeak{leaks.Resources[1], leaks.Resources[2]}, leaked)
+	var none *account.ResetLeaks
+	assert.Equal(t, []*account.ResetLeak{}, none.Leaked())
+}
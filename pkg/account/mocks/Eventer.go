@@ -52,6 +52,20 @@ func (_m *Eventer) AccountReset(_a0 *account.Account) error {
 	return r0
 }
 
+// AccountResetBatch provides a mock function with given fields: accounts
+func (_m *Eventer) AccountResetBatch(accounts []*account.Account) error {
+	ret := _m.Called(accounts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*account.Account) error); ok {
+		r0 = rf(accounts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AccountUpdate provides a mock function with given fields: old, new
 func (_m *Eventer) AccountUpdate(old *account.Account, new *account.Account) error {
 	ret := _m.Called(old, new)
@@ -25,6 +25,20 @@ func (_m *Manager) DeletePrincipalAccess(_a0 *account.Account) error {
 	return r0
 }
 
+// ProbeReadiness provides a mock function with given fields: _a0
+func (_m *Manager) ProbeReadiness(_a0 *account.Account) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertPrincipalAccess provides a mock function with given fields: _a0
 func (_m *Manager) UpsertPrincipalAccess(_a0 *account.Account) error {
 	ret := _m.Called(_a0)
@@ -52,3 +66,17 @@ func (_m *Manager) ValidateAccess(role *arn.ARN) error {
 
 	return r0
 }
+
+// ValidatePolicyCustomization provides a mock function with given fields: data
+func (_m *Manager) ValidatePolicyCustomization(data *account.PolicyCustomization) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.PolicyCustomization) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
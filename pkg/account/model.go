@@ -7,6 +7,7 @@ import (
 
 	"github.com/Optum/dce/pkg/arn"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/routing"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -18,12 +19,14 @@ var (
 )
 
 // ValidStatuses has the valid status options
-var ValidStatuses = [5]Status{
+var ValidStatuses = [7]Status{
 	StatusNone,
 	StatusLeased,
 	StatusNotReady,
 	StatusOrphaned,
 	StatusReady,
+	StatusUnreachable,
+	StatusQuarantined,
 }
 
 func init() {
@@ -39,6 +42,7 @@ type Account struct {
 	ID                  *string                `json:"id,omitempty" dynamodbav:"Id" schema:"id,omitempty"`                                                              // AWS Account ID
 	Status              *Status                `json:"accountStatus,omitempty" dynamodbav:"AccountStatus,omitempty" schema:"status,omitempty"`                          // Status of the AWS Account
 	LastModifiedOn      *int64                 `json:"lastModifiedOn,omitempty" dynamodbav:"LastModifiedOn" schema:"lastModifiedOn,omitempty"`                          // Last Modified Epoch Timestamp
+	Version             *int64                 `json:"version,omitempty" dynamodbav:"Version,omitempty" schema:"-"`                                                     // Number of writes of the account, which writes are conditional on
 	CreatedOn           *int64                 `json:"createdOn,omitempty"  dynamodbav:"CreatedOn,omitempty" schema:"createdOn,omitempty"`                              // Account CreatedOn
 	AdminRoleArn        *arn.ARN               `json:"adminRoleArn,omitempty"  dynamodbav:"AdminRoleArn" schema:"adminRoleArn,omitempty"`                               // Assumed by the master account, to manage this user account
 	PrincipalRoleArn    *arn.ARN               `json:"principalRoleArn,omitempty"  dynamodbav:"PrincipalRoleArn,omitempty" schema:"principalRoleArn,omitempty"`         // Assumed by principal users
@@ -47,6 +51,78 @@ type Account struct {
 	Limit               *int64                 `json:"-" dynamodbav:"-" schema:"limit,omitempty"`
 	NextID              *string                `json:"-" dynamodbav:"-" schema:"nextId,omitempty"`
 	PrincipalPolicyArn  *arn.ARN               `json:"-" dynamodbav:"-" schema:"-"`
+	// PrincipalPolicyCustomization is applied to the principal policy while the account is leased
+	PrincipalPolicyCustomization *PolicyCustomization `json:"principalPolicyCustomization,omitempty" dynamodbav:"PrincipalPolicyCustomization,omitempty" schema:"-"`
+	// WarmUp is the progress of the steps run on the account before it first becomes Ready
+	WarmUp *WarmUp `json:"warmUp,omitempty" dynamodbav:"WarmUp,omitempty" schema:"-"`
+	// Blackout is activity by principals outside DCE found while the account was reset
+	Blackout *Blackout `json:"blackout,omitempty" dynamodbav:"Blackout,omitempty" schema:"-"`
+	// ReadyAt is when the account can be leased, after its cooldown following a reset
+	ReadyAt *int64 `json:"readyAt,omitempty" dynamodbav:"ReadyAt,omitempty" schema:"-"`
+	// ResetLeaks are the resources which survived the account's last resets
+	ResetLeaks *ResetLeaks `json:"resetLeaks,omitempty" dynamodbav:"ResetLeaks,omitempty" schema:"-"`
+	// Remediation is the retry of an account which was stuck NotReady or Unreachable
+	Remediation *Remediation `json:"remediation,omitempty" dynamodbav:"Remediation,omitempty" schema:"-"`
+	// LastLeasedBy is the principal the account was last leased to, who may
+	// ask to lease the same account again
+	LastLeasedBy *string `json:"lastLeasedBy,omitempty" dynamodbav:"LastLeasedBy,omitempty" schema:"lastLeasedBy,omitempty"`
+	// ServiceQuotas are the service quota increases approved for the account
+	ServiceQuotas []ServiceQuota `json:"serviceQuotas,omitempty" dynamodbav:"ServiceQuotas,omitempty" schema:"-"`
+	// ResetProfile is the profile of the account's latest reset, when it
+	// wasn't the pool's, eg. when it was asked for by the end of a lease
+	ResetProfile *ResetProfile `json:"resetProfile,omitempty" dynamodbav:"ResetProfile,omitempty" schema:"-"`
+	// Contact owns the account, and is sent its reset failures and health
+	// alerts.  The account pool's contact is used when it's nil.
+	Contact *routing.Contact `json:"contact,omitempty" dynamodbav:"Contact,omitempty" schema:"-"`
+	// Pool groups accounts with the same capabilities, eg. "gpu", which
+	// leases may require
+	Pool *string `json:"pool,omitempty" dynamodbav:"Pool,omitempty" schema:"pool,omitempty"`
+	// Regions are the regions the account is available in, eg. the opt-in
+	// regions enabled for it.  The account is available in every region DCE
+	// allows when it's empty.
+	Regions []string `json:"regions,omitempty" dynamodbav:"Regions,omitempty" schema:"-"`
+	// Moves are the account's moves between pools, oldest first
+	Moves []Move `json:"moves,omitempty" dynamodbav:"Moves,omitempty" schema:"-"`
+}
+
+// Pool counts the accounts in the account pool by status
+type Pool struct {
+	Ready    int `json:"ready"`
+	NotReady int `json:"notReady"`
+	Leased   int `json:"leased"`
+	Orphaned int `json:"orphaned"`
+	// Unreachable accounts were closed or suspended
+	Unreachable int `json:"unreachable"`
+	// Quarantined accounts were stuck after their remediation was retried
+	Quarantined int `json:"quarantined"`
+}
+
+// PolicyCustomization changes the principal policy of a leased account.
+// Statements are named by their Sid, and must be in the allowlist of
+// customizations defined by DCE admins.
+type PolicyCustomization struct {
+	// Add are the Sids of allowlisted statements to add to the policy
+	Add []string `json:"add,omitempty" dynamodbav:"Add,omitempty"`
+	// Remove are the Sids of statements to remove from the policy
+	Remove []string `json:"remove,omitempty" dynamodbav:"Remove,omitempty"`
+}
+
+// IsEmpty is true when the customization doesn't change the policy
+func (c *PolicyCustomization) IsEmpty() bool {
+	return c == nil || (len(c.Add) == 0 && len(c.Remove) == 0)
+}
+
+// Leasable is the number of accounts which are, or will be once they are
+// reset, available to lease.  Orphaned, Unreachable and Quarantined accounts
+// aren't leasable.
+func (p Pool) Leasable() int {
+	return p.Ready + p.NotReady + p.Leased
+}
+
+// IsCoolingDown is true until the cooldown after the account's reset passes.
+// Ready accounts aren't leased while they're cooling down.
+func (a *Account) IsCoolingDown(now int64) bool {
+	return a.ReadyAt != nil && *a.ReadyAt > now
 }
 
 // Validate the account data
@@ -59,6 +135,7 @@ func (a *Account) Validate() error {
 		validation.Field(&a.CreatedOn, validateInt64...),
 		validation.Field(&a.PrincipalRoleArn, validatePrincipalRoleArn...),
 		validation.Field(&a.PrincipalPolicyHash, validatePrincipalPolicyHash...),
+		validation.Field(&a.Contact),
 	)
 	if err != nil {
 		return errors.NewValidation("account", err)
@@ -78,11 +155,25 @@ func (a *Account) UnmarshalJSON(data []byte) error {
 	a.ID = alias.ID
 	a.Status = alias.Status
 	a.LastModifiedOn = alias.LastModifiedOn
+	a.Version = alias.Version
 	a.CreatedOn = alias.CreatedOn
 	a.PrincipalRoleArn = alias.PrincipalRoleArn
 	a.AdminRoleArn = alias.AdminRoleArn
 	a.Metadata = alias.Metadata
 	a.PrincipalPolicyHash = alias.PrincipalPolicyHash
+	a.PrincipalPolicyCustomization = alias.PrincipalPolicyCustomization
+	a.WarmUp = alias.WarmUp
+	a.Blackout = alias.Blackout
+	a.ReadyAt = alias.ReadyAt
+	a.ResetLeaks = alias.ResetLeaks
+	a.Remediation = alias.Remediation
+	a.LastLeasedBy = alias.LastLeasedBy
+	a.ServiceQuotas = alias.ServiceQuotas
+	a.ResetProfile = alias.ResetProfile
+	a.Contact = alias.Contact
+	a.Pool = alias.Pool
+	a.Regions = alias.Regions
+	a.Moves = alias.Moves
 
 	if alias.ID != nil {
 		principalPolicyArn := arn.New("aws", "iam", "", *alias.ID, fmt.Sprintf("policy/%s", PrincipalPolicyName))
@@ -105,11 +196,25 @@ func (a *Account) UnmarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) e
 	a.ID = alias.ID
 	a.Status = alias.Status
 	a.LastModifiedOn = alias.LastModifiedOn
+	a.Version = alias.Version
 	a.CreatedOn = alias.CreatedOn
 	a.PrincipalRoleArn = alias.PrincipalRoleArn
 	a.AdminRoleArn = alias.AdminRoleArn
 	a.Metadata = alias.Metadata
 	a.PrincipalPolicyHash = alias.PrincipalPolicyHash
+	a.PrincipalPolicyCustomization = alias.PrincipalPolicyCustomization
+	a.WarmUp = alias.WarmUp
+	a.Blackout = alias.Blackout
+	a.ReadyAt = alias.ReadyAt
+	a.ResetLeaks = alias.ResetLeaks
+	a.Remediation = alias.Remediation
+	a.LastLeasedBy = alias.LastLeasedBy
+	a.ServiceQuotas = alias.ServiceQuotas
+	a.ResetProfile = alias.ResetProfile
+	a.Contact = alias.Contact
+	a.Pool = alias.Pool
+	a.Regions = alias.Regions
+	a.Moves = alias.Moves
 
 	if a.ID != nil {
 		principalPolicyArn := arn.New("aws", "iam", "", *alias.ID, fmt.Sprintf("policy/%s", PrincipalPolicyName))
@@ -124,6 +229,9 @@ type NewAccountInput struct {
 	AdminRoleArn      arn.ARN
 	Metadata          map[string]interface{}
 	PrincipalRoleName string
+	Contact           *routing.Contact
+	Pool              *string
+	Regions           []string
 }
 
 // NewAccount creates a new instance of account
@@ -140,6 +248,9 @@ func NewAccount(input NewAccountInput) (*Account, error) {
 		PrincipalPolicyArn: policyArn,
 		Metadata:           input.Metadata,
 		Status:             StatusNotReady.StatusPtr(),
+		Contact:            input.Contact,
+		Pool:               input.Pool,
+		Regions:            input.Regions,
 	}, nil
 }
 
@@ -160,6 +271,13 @@ const (
 	StatusLeased Status = "Leased"
 	// StatusOrphaned status
 	StatusOrphaned Status = "Orphaned"
+	// StatusUnreachable status, for accounts which were closed or suspended, so
+	// their admin role can't be assumed.  They aren't reset or leased again.
+	StatusUnreachable Status = "Unreachable"
+	// StatusQuarantined status, for accounts which were stuck NotReady or
+	// Unreachable after their remediation was retried.  They aren't reset or
+	// leased again.
+	StatusQuarantined Status = "Quarantined"
 )
 
 // String returns the string value of AccountStatus
@@ -0,0 +1,39 @@
+package account
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// maxMoveReasonLength is the longest reason of a move
+const maxMoveReasonLength = 1024
+
+// organizationalUnitPattern matches the IDs of organizational units, and of
+// the root of the organization
+var organizationalUnitPattern = regexp.MustCompile("^(ou-[0-9a-z]{4,32}-[0-9a-z]{8,32}|r-[0-9a-z]{4,32})$")
+
+// Move is a move of an account to another pool, and optionally to another
+// organizational unit of AWS Organizations.  Moves are kept in the account's
+// history, instead of the account being deleted and added to the pool again.
+type Move struct {
+	// PreviousPool and Pool are empty for accounts in no pool
+	PreviousPool string `json:"previousPool,omitempty" dynamodbav:"PreviousPool,omitempty"`
+	Pool         string `json:"pool,omitempty" dynamodbav:"Pool,omitempty"`
+	// OrganizationalUnitID is the organizational unit the account was moved
+	// to, eg. "ou-ab12-cdef3456", or empty when it wasn't moved in the
+	// organization
+	OrganizationalUnitID string `json:"organizationalUnitId,omitempty" dynamodbav:"OrganizationalUnitId,omitempty"`
+	Reason               string `json:"reason,omitempty" dynamodbav:"Reason,omitempty"`
+	MovedBy              string `json:"movedBy,omitempty" dynamodbav:"MovedBy,omitempty"`
+	MovedOn              int64  `json:"movedOn" dynamodbav:"MovedOn"`
+}
+
+// Validate the move asked for
+func (m *Move) Validate() error {
+	return validation.ValidateStruct(m,
+		validation.Field(&m.Pool, validation.Match(poolPattern).Error("must be letters, numbers, - and _")),
+		validation.Field(&m.OrganizationalUnitID, validation.Match(organizationalUnitPattern).Error("must be the ID of an organizational unit or root")),
+		validation.Field(&m.Reason, validation.RuneLength(0, maxMoveReasonLength)),
+	)
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxServiceQuotas is the most service quotas an account can record
+const maxServiceQuotas = 50
+
+var (
+	serviceCodePattern = regexp.MustCompile("^[a-z0-9-]{1,63}$")
+	quotaCodePattern   = regexp.MustCompile("^L-[0-9A-F]{8}$")
+	regionPattern      = regexp.MustCompile("^[a-z]{2}(-[a-z]+)+-[0-9]$")
+)
+
+// ServiceQuota is a service quota increase approved for the account, eg. 32
+// vCPUs of on-demand P instances.  Quotas are identified by their codes in
+// the Service Quotas console.  Resets don't change service quotas, so the
+// increases are kept for every lease of the account.
+type ServiceQuota struct {
+	// ServiceCode is the service of the quota, eg. ec2
+	ServiceCode string `json:"serviceCode" dynamodbav:"ServiceCode"`
+	// QuotaCode is the code of the quota, eg. L-417A185B
+	QuotaCode string `json:"quotaCode" dynamodbav:"QuotaCode"`
+	// Region of the quota.  Empty for global quotas.
+	Region string  `json:"region,omitempty" dynamodbav:"Region,omitempty"`
+	Value  float64 `json:"value" dynamodbav:"Value"`
+	// Name describes the quota, eg. Running On-Demand P instances
+	Name       string `json:"name,omitempty" dynamodbav:"Name,omitempty"`
+	ApprovedOn *int64 `json:"approvedOn,omitempty" dynamodbav:"ApprovedOn,omitempty"`
+	// CaseID is the support case of the quota increase request
+	CaseID string `json:"caseId,omitempty" dynamodbav:"CaseId,omitempty"`
+}
+
+func (q ServiceQuota) key() string {
+	return fmt.Sprintf("%s/%s/%s", q.ServiceCode, q.QuotaCode, q.Region)
+}
+
+// Meets is true when the quota is at least the required quota.  Required
+// quotas without a region are met by a quota in any region.
+func (q ServiceQuota) Meets(required ServiceQuota) bool {
+	return q.ServiceCode == required.ServiceCode &&
+		q.QuotaCode == required.QuotaCode &&
+		(required.Region == "" || q.Region == required.Region) &&
+		q.Value >= required.Value
+}
+
+// HasQuotas is true when the account's service quotas meet every required
+// quota
+func (a *Account) HasQuotas(required []ServiceQuota) bool {
+	for _, r := range required {
+		met := false
+		for _, q := range a.ServiceQuotas {
+			if q.Meets(r) {
+				met = true
+				break
+			}
+		}
+		if !met {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateServiceQuotas checks service quotas have valid codes, regions and
+// values, and that no quota is listed twice
+func ValidateServiceQuotas(quotas []ServiceQuota) error {
+	if len(quotas) > maxServiceQuotas {
+		return fmt.Errorf("must have at most %d quotas", maxServiceQuotas)
+	}
+	seen := map[string]bool{}
+	for _, q := range quotas {
+		if !serviceCodePattern.MatchString(q.ServiceCode) {
+			return fmt.Errorf("%q must be a service code, eg. ec2", q.ServiceCode)
+		}
+		if !quotaCodePattern.MatchString(q.QuotaCode) {
+			return fmt.Errorf("%q must be a quota code, eg. L-417A185B", q.QuotaCode)
+		}
+		if q.Region != "" && !regionPattern.MatchString(q.Region) {
+			return fmt.Errorf("%q must be a region, eg. us-east-1", q.Region)
+		}
+		if q.Value <= 0 {
+			return fmt.Errorf("%s must have a value greater than 0", q.QuotaCode)
+		}
+		if seen[q.key()] {
+			return fmt.Errorf("%s is listed more than once", q.QuotaCode)
+		}
+		seen[q.key()] = true
+	}
+	return nil
+}
+
+func isServiceQuotasValid(value interface{}) error {
+	quotas, _ := value.([]ServiceQuota)
+	return ValidateServiceQuotas(quotas)
+}
@@ -0,0 +1,110 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasQuotas(t *testing.T) {
+	acct := &account.Account{
+		ServiceQuotas: []account.ServiceQuota{
+			{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-east-1", Value: 32},
+			{ServiceCode: "sagemaker", QuotaCode: "L-1194F27D", Region: "us-west-2", Value: 4},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		required []account.ServiceQuota
+		expHas   bool
+	}{
+		{
+			name:   "should have no required quotas",
+			expHas: true,
+		},
+		{
+			name: "should have quotas at least the required value",
+			required: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-east-1", Value: 16},
+				{ServiceCode: "sagemaker", QuotaCode: "L-1194F27D", Value: 4},
+			},
+			expHas: true,
+		},
+		{
+			name: "should not have quotas under the required value",
+			required: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 64},
+			},
+		},
+		{
+			name: "should not have quotas in other regions",
+			required: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "eu-west-1", Value: 16},
+			},
+		},
+		{
+			name: "should not have other quotas",
+			required: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 16},
+				{ServiceCode: "ec2", QuotaCode: "L-DB2E81BA", Value: 16},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expHas, acct.HasQuotas(tt.required))
+		})
+	}
+}
+
+func TestValidateServiceQuotas(t *testing.T) {
+	tests := []struct {
+		name   string
+		quotas []account.ServiceQuota
+		expErr string
+	}{
+		{
+			name: "should allow valid quotas",
+			quotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-east-1", Value: 32, Name: "Running On-Demand P instances", CaseID: "12345678901"},
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-west-2", Value: 32},
+			},
+		},
+		{
+			name: "should fail on invalid service codes",
+			quotas: []account.ServiceQuota{
+				{ServiceCode: "Amazon EC2", QuotaCode: "L-417A185B", Value: 32},
+			},
+			expErr: "\"Amazon EC2\" must be a service code, eg. ec2",
+		},
+		{
+			name: "should fail on invalid regions",
+			quotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "virginia", Value: 32},
+			},
+			expErr: "\"virginia\" must be a region, eg. us-east-1",
+		},
+		{
+			name: "should fail on duplicate quotas",
+			quotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32},
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 64},
+			},
+			expErr: "L-417A185B is listed more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := account.ValidateServiceQuotas(tt.quotas)
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
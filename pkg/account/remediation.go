@@ -0,0 +1,12 @@
+package account
+
+// Remediation is the garbage collection of an account which was stuck
+// NotReady or Unreachable.  Its remediation is retried once, by resetting
+// it again, and it's quarantined when that doesn't make it Ready.
+type Remediation struct {
+	// Status is the status the account was stuck in
+	Status      Status `json:"status" dynamodbav:"Status"`
+	AttemptedOn int64  `json:"attemptedOn" dynamodbav:"AttemptedOn"`
+	// QuarantinedOn is set once the account is quarantined
+	QuarantinedOn *int64 `json:"quarantinedOn,omitempty" dynamodbav:"QuarantinedOn,omitempty"`
+}
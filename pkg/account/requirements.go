@@ -0,0 +1,175 @@
+package account
+
+import (
+	"fmt"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// maxRegions is the most regions an account, or the requirements of a lease,
+// can list
+const maxRegions = 30
+
+var poolPattern = regexp.MustCompile("^[a-zA-Z0-9_-]{1,64}$")
+
+// Requirements are what a lease needs of its account.  Ready accounts which
+// don't meet them aren't leased, and of those which do, the account with the
+// least to spare is leased, so accounts with rare capabilities, eg. GPU
+// quotas, are kept for the leases which need them.
+type Requirements struct {
+	// Pool is the pool the account must be in, eg. "gpu".  Accounts in any
+	// pool, or none, may be leased when it's nil.
+	Pool *string `json:"pool,omitempty" dynamodbav:"Pool,omitempty"`
+	// Regions are the regions the account must be available in, eg. opt-in
+	// regions like ap-east-1
+	Regions []string `json:"regions,omitempty" dynamodbav:"Regions,omitempty"`
+	// ServiceQuotas are the service quotas the account must have, eg. 32
+	// vCPUs of P instances
+	ServiceQuotas []ServiceQuota `json:"serviceQuotas,omitempty" dynamodbav:"ServiceQuotas,omitempty"`
+	// ResetProfile is the profile the account's latest reset must have been,
+	// eg. full when a lease needs an empty account
+	ResetProfile *ResetProfile `json:"resetProfile,omitempty" dynamodbav:"ResetProfile,omitempty"`
+	// Groups are the groups of the user requesting the lease, which limit
+	// the pools the account may be in to those whose lease groups they're a
+	// member of.  Accounts in any pool may be leased when it's nil, eg. to
+	// admins.  They aren't stored with the lease.
+	Groups *[]string `json:"-" dynamodbav:"-"`
+}
+
+// IsEmpty is true when any Ready account meets the requirements
+func (r *Requirements) IsEmpty() bool {
+	return r == nil || (r.Pool == nil && len(r.Regions) == 0 && len(r.ServiceQuotas) == 0 && r.ResetProfile == nil && r.Groups == nil)
+}
+
+// Validate checks the requirements are valid
+func (r *Requirements) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Pool, validation.By(isPoolValid)),
+		validation.Field(&r.Regions, validation.By(isRegionsValid)),
+		validation.Field(&r.ServiceQuotas, validation.By(isServiceQuotasValid)),
+		validation.Field(&r.ResetProfile, validation.By(isResetProfileValid)),
+	)
+}
+
+// WithQuotas returns a copy of the requirements, which also requires the
+// service quotas
+func (r *Requirements) WithQuotas(quotas []ServiceQuota) *Requirements {
+	with := Requirements{}
+	if r != nil {
+		with = *r
+	}
+	if len(quotas) > 0 {
+		with.ServiceQuotas = append(append([]ServiceQuota{}, with.ServiceQuotas...), quotas...)
+	}
+	return &with
+}
+
+// Meets is true when the account meets the requirements.  Accounts without
+// regions are available in every region DCE allows.  Accounts without a
+// reset profile were last reset with the default profile.
+func (a *Account) Meets(r *Requirements, defaultProfile ResetProfile) bool {
+	if r == nil {
+		return true
+	}
+	if r.Pool != nil && (a.Pool == nil || *a.Pool != *r.Pool) {
+		return false
+	}
+	if len(a.Regions) > 0 {
+		for _, region := range r.Regions {
+			if !contains(a.Regions, region) {
+				return false
+			}
+		}
+	}
+	if r.ResetProfile != nil {
+		profile := defaultProfile
+		if a.ResetProfile != nil {
+			profile = *a.ResetProfile
+		}
+		if profile != *r.ResetProfile {
+			return false
+		}
+	}
+	return a.HasQuotas(r.ServiceQuotas)
+}
+
+// spare counts the capabilities of the account the requirements don't need,
+// ie. its service quotas and regions, and whether it's in a pool when no
+// pool is required.  The account with the least to spare fits the
+// requirements best.
+func (a *Account) spare(r *Requirements) int {
+	if r == nil {
+		r = &Requirements{}
+	}
+	spare := 0
+	for _, q := range a.ServiceQuotas {
+		needed := false
+		for _, required := range r.ServiceQuotas {
+			if q.Meets(required) {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			spare++
+		}
+	}
+	for _, region := range a.Regions {
+		if !contains(r.Regions, region) {
+			spare++
+		}
+	}
+	if a.Pool != nil && r.Pool == nil {
+		spare++
+	}
+	return spare
+}
+
+// ValidateRegions checks regions are valid and listed once
+func ValidateRegions(regions []string) error {
+	if len(regions) > maxRegions {
+		return fmt.Errorf("must have at most %d regions", maxRegions)
+	}
+	seen := map[string]bool{}
+	for _, region := range regions {
+		if !regionPattern.MatchString(region) {
+			return fmt.Errorf("%q must be a region, eg. us-east-1", region)
+		}
+		if seen[region] {
+			return fmt.Errorf("%s is listed more than once", region)
+		}
+		seen[region] = true
+	}
+	return nil
+}
+
+func isRegionsValid(value interface{}) error {
+	regions, _ := value.([]string)
+	return ValidateRegions(regions)
+}
+
+func isPoolValid(value interface{}) error {
+	pool, _ := value.(*string)
+	if pool != nil && !poolPattern.MatchString(*pool) {
+		return fmt.Errorf("must be letters, numbers, - and _")
+	}
+	return nil
+}
+
+func isResetProfileValid(value interface{}) error {
+	profile, _ := value.(*ResetProfile)
+	if profile == nil {
+		return nil
+	}
+	return ValidateResetProfile(profile.String())
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeets(t *testing.T) {
+	gpu := aws.String("gpu")
+
+	tests := []struct {
+		name         string
+		acct         *account.Account
+		requirements *account.Requirements
+		expMeets     bool
+	}{
+		{
+			name:     "should meet no requirements",
+			acct:     &account.Account{},
+			expMeets: true,
+		},
+		{
+			name:         "should meet the pool of the account",
+			acct:         &account.Account{Pool: gpu},
+			requirements: &account.Requirements{Pool: gpu},
+			expMeets:     true,
+		},
+		{
+			name:         "should not meet other pools",
+			acct:         &account.Account{},
+			requirements: &account.Requirements{Pool: gpu},
+		},
+		{
+			name:         "should meet every region without account regions",
+			acct:         &account.Account{},
+			requirements: &account.Requirements{Regions: []string{"ap-east-1"}},
+			expMeets:     true,
+		},
+		{
+			name:         "should not meet regions the account isn't available in",
+			acct:         &account.Account{Regions: []string{"us-east-1"}},
+			requirements: &account.Requirements{Regions: []string{"us-east-1", "ap-east-1"}},
+		},
+		{
+			name:         "should meet the default reset profile without an account profile",
+			acct:         &account.Account{},
+			requirements: &account.Requirements{ResetProfile: account.ResetProfileDataPreserving.ResetProfilePtr()},
+			expMeets:     true,
+		},
+		{
+			name:         "should not meet other reset profiles",
+			acct:         &account.Account{ResetProfile: account.ResetProfileComputeOnly.ResetProfilePtr()},
+			requirements: &account.Requirements{ResetProfile: account.ResetProfileDataPreserving.ResetProfilePtr()},
+		},
+		{
+			name: "should not meet missing quotas",
+			acct: &account.Account{},
+			requirements: &account.Requirements{ServiceQuotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expMeets, tt.acct.Meets(tt.requirements, account.ResetProfileDataPreserving))
+		})
+	}
+}
+
+func TestValidateRequirements(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements *account.Requirements
+		expErr       string
+	}{
+		{
+			name: "should accept valid requirements",
+			requirements: &account.Requirements{
+				Pool:          aws.String("gpu"),
+				Regions:       []string{"us-east-1", "ap-east-1"},
+				ServiceQuotas: []account.ServiceQuota{{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32}},
+				ResetProfile:  account.ResetProfileFull.ResetProfilePtr(),
+			},
+		},
+		{
+			name:         "should fail on invalid pools",
+			requirements: &account.Requirements{Pool: aws.String("gpu pool")},
+			expErr:       "pool: must be letters, numbers, - and _.",
+		},
+		{
+			name:         "should fail on invalid regions",
+			requirements: &account.Requirements{Regions: []string{"us-east-1", "us-east-1"}},
+			expErr:       "regions: us-east-1 is listed more than once.",
+		},
+		{
+			name:         "should fail on invalid reset profiles",
+			requirements: &account.Requirements{ResetProfile: account.ResetProfile("partial").ResetProfilePtr()},
+			expErr:       "resetProfile: must be one of full, compute-only, data-preserving.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.requirements.Validate()
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResetProfile is the kind of resources a reset deletes from an account
+type ResetProfile string
+
+const (
+	// ResetProfileFull deletes every resource, the default
+	ResetProfileFull ResetProfile = "full"
+	// ResetProfileComputeOnly deletes compute, eg. instances, functions and
+	// clusters, and keeps everything else
+	ResetProfileComputeOnly ResetProfile = "compute-only"
+	// ResetProfileDataPreserving deletes everything except data stores, eg.
+	// buckets, tables and databases, and the networks and keys they need
+	ResetProfileDataPreserving ResetProfile = "data-preserving"
+)
+
+// ResetProfiles are the valid reset profiles
+var ResetProfiles = []ResetProfile{
+	ResetProfileFull,
+	ResetProfileComputeOnly,
+	ResetProfileDataPreserving,
+}
+
+// ResetProfilePtr returns a pointer to the profile
+func (p ResetProfile) ResetProfilePtr() *ResetProfile {
+	return &p
+}
+
+// String returns the profile as a string
+func (p ResetProfile) String() string {
+	return string(p)
+}
+
+// ValidateResetProfile checks the profile is one of the reset profiles.  An
+// empty profile is the pool's default.
+func ValidateResetProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+	names := make([]string, len(ResetProfiles))
+	for i, p := range ResetProfiles {
+		if string(p) == profile {
+			return nil
+		}
+		names[i] = string(p)
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(names, ", "))
+}
@@ -1,11 +1,20 @@
 package account
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/policy"
+	"github.com/aws/aws-sdk-go/aws"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/imdario/mergo"
 )
@@ -15,6 +24,12 @@ type Writer interface {
 	Write(i *Account, lastModifiedOn *int64) error
 }
 
+// OutboxWriter puts an item into the data store with the outbox entries of
+// its events, in one transaction
+type OutboxWriter interface {
+	WriteWithEvents(i *Account, lastModifiedOn *int64, entries []*outbox.Entry) error
+}
+
 // Deleter Deletes an Account from the data store
 type Deleter interface {
 	Delete(i *Account) error
@@ -54,13 +69,27 @@ type Eventer interface {
 	AccountDelete(account *Account) error
 	AccountUpdate(old *Account, new *Account) error
 	AccountReset(account *Account) error
+	AccountResetBatch(accounts []*Account) error
 }
 
 // Manager manages all the actions against an account
 type Manager interface {
 	ValidateAccess(role *arn.ARN) error
 	UpsertPrincipalAccess(account *Account) error
+	ValidatePolicyCustomization(data *PolicyCustomization) error
 	DeletePrincipalAccess(account *Account) error
+	ProbeReadiness(account *Account) error
+}
+
+// OrganizationsMover moves accounts between organizational units of AWS
+// Organizations
+type OrganizationsMover interface {
+	MoveAccount(accountID string, parentID string) error
+}
+
+// PolicyEvaluator evaluates the deployer's account allocation policy
+type PolicyEvaluator interface {
+	Evaluate(document string, input interface{}) (*policy.Decision, error)
 }
 
 // Service is a type corresponding to a Account table record
@@ -68,7 +97,19 @@ type Service struct {
 	dataSvc           ReaderWriterDeleter
 	managerSvc        Manager
 	eventSvc          Eventer
+	outboxSvc         OutboxWriter
+	policySvc         PolicyEvaluator
+	organizationsSvc  OrganizationsMover
 	principalRoleName string
+	warmUpSteps       []string
+	// defaultResetProfile is the profile of resets which weren't asked
+	// for another
+	defaultResetProfile ResetProfile
+	readinessProbe      bool
+	// leaseGroups are the groups whose members may lease the accounts of
+	// pools without their own lease groups
+	leaseGroups     []string
+	poolLeaseGroups map[string][]string
 }
 
 // Get returns an account from ID
@@ -84,6 +125,12 @@ func (a *Service) Get(ID string) (*Account, error) {
 
 // Save writes the record to the dataSvc
 func (a *Service) Save(data *Account) error {
+	return a.save(data, nil)
+}
+
+// save writes the record, and the given events of the change to the outbox
+// when there is one
+func (a *Service) save(data *Account, eventTypes []string) error {
 	var lastModifiedOn *int64
 	now := time.Now().Unix()
 	if data.LastModifiedOn == nil {
@@ -99,11 +146,19 @@ func (a *Service) Save(data *Account) error {
 	if err != nil {
 		return err
 	}
-	err = a.dataSvc.Write(data, lastModifiedOn)
-	if err != nil {
-		return err
+	if a.outboxSvc == nil || len(eventTypes) == 0 {
+		return a.dataSvc.Write(data, lastModifiedOn)
 	}
-	return nil
+
+	entries := []*outbox.Entry{}
+	for _, eventType := range eventTypes {
+		entry, err := outbox.NewEntry(eventType, *data.ID, nil, data)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	return a.outboxSvc.WriteWithEvents(data, lastModifiedOn, entries)
 }
 
 // Update the Account record in DynamoDB
@@ -112,6 +167,13 @@ func (a *Service) Update(ID string, data *Account) (*Account, error) {
 		// ID has to be empty
 		validation.Field(&data.ID, validation.NilOrNotEmpty, validation.In(ID)),
 		validation.Field(&data.AdminRoleArn, validation.By(isNilOrUsableAdminRole(a.managerSvc))),
+		validation.Field(&data.LastModifiedOn, validation.By(isNil)),
+		validation.Field(&data.Version, validation.By(isNil)),
+		validation.Field(&data.Blackout, validation.By(isNil)),
+		validation.Field(&data.ServiceQuotas, validation.By(isServiceQuotasValid)),
+		validation.Field(&data.Contact),
+		validation.Field(&data.Pool, validation.By(isPoolValid)),
+		validation.Field(&data.Regions, validation.By(isRegionsValid)),
 	)
 	if err != nil {
 		return nil, errors.NewValidation("account", err)
@@ -134,8 +196,9 @@ func (a *Service) Update(ID string, data *Account) (*Account, error) {
 	return account, nil
 }
 
-// Create creates a new account using the data provided. Returns the account record
-func (a *Service) Create(data *Account) (*Account, error) {
+// ValidateCreate checks that an account could be created from the data provided
+// without making any changes
+func (a *Service) ValidateCreate(data *Account) error {
 	// Validate the incoming record doesn't have unneeded fields
 	err := validation.ValidateStruct(data,
 		// This may be considered double validation but we are going to need the ID
@@ -143,62 +206,104 @@ func (a *Service) Create(data *Account) (*Account, error) {
 		validation.Field(&data.ID, validateID...),
 		validation.Field(&data.AdminRoleArn, validateAdminRoleArn...),
 		validation.Field(&data.LastModifiedOn, validation.By(isNil)),
+		validation.Field(&data.Version, validation.By(isNil)),
 		validation.Field(&data.Status, validation.By(isNil)),
 		validation.Field(&data.CreatedOn, validation.By(isNil)),
 		validation.Field(&data.PrincipalRoleArn, validation.By(isNil)),
 		validation.Field(&data.PrincipalPolicyHash, validation.By(isNil)),
+		validation.Field(&data.WarmUp, validation.By(isNil)),
+		validation.Field(&data.Blackout, validation.By(isNil)),
+		validation.Field(&data.LastLeasedBy, validation.By(isNil)),
+		validation.Field(&data.ServiceQuotas, validation.By(isServiceQuotasValid)),
+		validation.Field(&data.ResetProfile, validation.By(isNil)),
+		validation.Field(&data.Contact),
+		validation.Field(&data.Pool, validation.By(isPoolValid)),
+		validation.Field(&data.Regions, validation.By(isRegionsValid)),
 	)
 	if err != nil {
-		return nil, errors.NewValidation("account", err)
+		return errors.NewValidation("account", err)
 	}
 
 	// Check if account already exists
 	existingAccount, err := a.Get(*data.ID)
 	if existingAccount != nil {
-		return nil, errors.NewAlreadyExists("account", *data.ID)
+		return errors.NewAlreadyExists("account", *data.ID)
 	}
 	if err != nil {
 		if !errors.Is(err, errors.NewNotFound("account", *data.ID)) {
-			return nil, err
+			return err
 		}
 	}
 
+	return nil
+}
+
+// Create creates a new account using the data provided. Returns the account record
+func (a *Service) Create(data *Account) (*Account, error) {
+	err := a.ValidateCreate(data)
+	if err != nil {
+		return nil, err
+	}
+
 	new, err := NewAccount(NewAccountInput{
 		ID:                *data.ID,
 		AdminRoleArn:      *data.AdminRoleArn,
 		Metadata:          data.Metadata,
 		PrincipalRoleName: a.principalRoleName,
+		Contact:           data.Contact,
+		Pool:              data.Pool,
+		Regions:           data.Regions,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.UpsertPrincipalAccess(new)
-	if err != nil {
-		return nil, err
+	// Accounts which are warmed up stay NotReady until their warm-up
+	// succeeds.  Creating the principal role and resetting the account are
+	// left to the warm-up, when it has those steps.
+	if len(a.warmUpSteps) > 0 {
+		new.WarmUp = NewWarmUp(a.warmUpSteps)
+	}
+
+	if !new.WarmUp.HasStep(WarmUpStepCreatePrincipalRole) {
+		err = a.UpsertPrincipalAccess(new)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = a.Save(new)
+	eventTypes := []string{outbox.TypeAccountCreate}
+	if !new.WarmUp.HasStep(WarmUpStepInitialReset) {
+		eventTypes = append(eventTypes, outbox.TypeAccountReset)
+	}
+	err = a.save(new, eventTypes)
 	if err != nil {
 		return nil, err
 	}
 
+	// The events were written to the outbox with the account, when there
+	// is one
+	if a.outboxSvc != nil {
+		return new, nil
+	}
+
 	err = a.eventSvc.AccountCreate(new)
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.eventSvc.AccountReset(new)
-	if err != nil {
-		return nil, err
+	if !new.WarmUp.HasStep(WarmUpStepInitialReset) {
+		err = a.eventSvc.AccountReset(new)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return new, nil
 }
 
-// Delete finds a given account and deletes it if it is not of status `Leased`. Returns the account.
-func (a *Service) Delete(data *Account) error {
-
+// ValidateDelete checks an account can be deleted, without deleting it
+func (a *Service) ValidateDelete(data *Account) error {
 	err := validation.ValidateStruct(data,
 		validation.Field(&data.Status, validation.NotNil, validation.By(isAccountNotLeased)),
 		validation.Field(&data.AdminRoleArn, validation.NotNil),
@@ -207,12 +312,28 @@ func (a *Service) Delete(data *Account) error {
 	if err != nil {
 		return errors.NewConflict("account", *data.ID, err)
 	}
+	return nil
+}
+
+// Delete finds a given account and deletes it if it is not of status `Leased`. Returns the account.
+func (a *Service) Delete(data *Account) error {
+
+	err := a.ValidateDelete(data)
+	if err != nil {
+		return err
+	}
 
 	err = a.dataSvc.Delete(data)
 	if err != nil {
 		return err
 	}
 
+	// Closed, suspended or quarantined accounts may not be accessible to
+	// clean them up
+	if data.Status.String() == StatusUnreachable.String() || data.Status.String() == StatusQuarantined.String() {
+		return a.eventSvc.AccountDelete(data)
+	}
+
 	err = a.managerSvc.DeletePrincipalAccess(data)
 	if err != nil {
 		return err
@@ -261,6 +382,168 @@ func (a *Service) ListPages(query *Account, fn func(*Accounts) bool) error {
 	return nil
 }
 
+// AllocatePolicyInput is the input of the account allocation policy
+type AllocatePolicyInput struct {
+	Account     *Account `json:"account"`
+	PrincipalID string   `json:"principalId"`
+}
+
+// GetReadyAccount returns a Ready account which can be leased to the
+// principal, or nil when there are none.  Accounts cooling down after their
+// reset, or which the account allocation policy doesn't allow, are skipped.
+func (a *Service) GetReadyAccount(principalID string) (*Account, error) {
+	return a.GetReadyAccountWithRequirements(principalID, nil)
+}
+
+// GetReadyAccountWithRequirements returns the Ready account which best meets
+// the requirements and can be leased to the principal, or nil when there are
+// none.  The account with the least to spare, eg. the fewest service quotas
+// and regions the requirements don't need, fits best.
+func (a *Service) GetReadyAccountWithRequirements(principalID string, requirements *Requirements) (*Account, error) {
+	return a.getReadyAccount(&Account{
+		Status: StatusReady.StatusPtr(),
+	}, principalID, requirements)
+}
+
+// GetLastLeasedAccount returns the Ready account which was last leased to the
+// principal, or nil when it can't be leased to them again, eg. because it was
+// leased to someone else since, is being reset, or doesn't meet the
+// requirements
+func (a *Service) GetLastLeasedAccount(principalID string, requirements *Requirements) (*Account, error) {
+	return a.getReadyAccount(&Account{
+		Status:       StatusReady.StatusPtr(),
+		LastLeasedBy: &principalID,
+	}, principalID, requirements)
+}
+
+// getReadyAccount returns the account matching the query which best meets the
+// requirements, isn't cooling down, and the account allocation policy allows.
+// The pool is queried with the status, so only the pool's accounts are read.
+func (a *Service) getReadyAccount(query *Account, principalID string, requirements *Requirements) (*Account, error) {
+	if requirements != nil {
+		query.Pool = requirements.Pool
+	}
+
+	now := time.Now().Unix()
+	candidates := []*Account{}
+	err := a.ListPages(query, func(accounts *Accounts) bool {
+		for i := range *accounts {
+			acct := &(*accounts)[i]
+			if !acct.IsCoolingDown(now) && acct.Meets(requirements, a.defaultResetProfile) && a.isPoolAllowed(acct, requirements) {
+				candidates = append(candidates, acct)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Accounts with capabilities the lease doesn't need are kept for leases
+	// which do
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].spare(requirements) < candidates[j].spare(requirements)
+	})
+	for _, acct := range candidates {
+		allowed, err := a.isAllocationAllowed(acct, principalID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+		if a.isReady(acct) {
+			return acct, nil
+		}
+	}
+	return nil, nil
+}
+
+// PoolLeaseGroups returns the groups whose members may lease the accounts of
+// the pool, or of any pool when it's nil.  Any user may lease them when there
+// are none.
+func (a *Service) PoolLeaseGroups(pool *string) []string {
+	if pool != nil {
+		return a.leaseGroupsOf(*pool)
+	}
+	if len(a.leaseGroups) == 0 {
+		return nil
+	}
+	groups := append([]string{}, a.leaseGroups...)
+	for _, poolGroups := range a.poolLeaseGroups {
+		groups = append(groups, poolGroups...)
+	}
+	return groups
+}
+
+// leaseGroupsOf returns the lease groups of a pool, which are the default
+// lease groups unless the pool has its own
+func (a *Service) leaseGroupsOf(pool string) []string {
+	if groups, ok := a.poolLeaseGroups[pool]; ok {
+		return groups
+	}
+	return a.leaseGroups
+}
+
+// isPoolAllowed checks the groups of the requirements may lease accounts
+// from the account's pool.  Accounts without a pool have the default lease
+// groups.
+func (a *Service) isPoolAllowed(data *Account, requirements *Requirements) bool {
+	if requirements == nil || requirements.Groups == nil {
+		return true
+	}
+	allowed := a.leaseGroupsOf(aws.StringValue(data.Pool))
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, group := range *requirements.Groups {
+		if contains(allowed, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReady probes the account before it's handed out, when probes are
+// enabled.  Accounts failing the probe, eg. because their roles were changed
+// by hand, are made NotReady, so they aren't leased until they're reset or
+// remediated.
+func (a *Service) isReady(data *Account) bool {
+	if !a.readinessProbe {
+		return true
+	}
+	probeErr := a.managerSvc.ProbeReadiness(data)
+	if probeErr == nil {
+		return true
+	}
+
+	log.Printf("Account %s failed the readiness probe, making it NotReady: %s", *data.ID, probeErr)
+	data.Status = StatusNotReady.StatusPtr()
+	// The account isn't leased either way, eg. when it was leased by another
+	// request since it was read
+	if err := a.Save(data); err != nil {
+		log.Printf("Failed to make account %s NotReady: %s", *data.ID, err)
+	}
+	return false
+}
+
+func (a *Service) isAllocationAllowed(data *Account, principalID string) (bool, error) {
+	if a.policySvc == nil {
+		return true, nil
+	}
+	decision, err := a.policySvc.Evaluate(policy.AccountAllocate, &AllocatePolicyInput{
+		Account:     data,
+		PrincipalID: principalID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if !decision.Allow {
+		log.Printf("Account %s can't be leased to %s: %s", *data.ID, principalID, decision.Summary())
+	}
+	return decision.Allow, nil
+}
+
 func (a *Service) reset(data *Account) (*Account, error) {
 	err := validation.ValidateStruct(data,
 		validation.Field(&data.AdminRoleArn, validation.NotNil),
@@ -282,16 +565,41 @@ func (a *Service) reset(data *Account) (*Account, error) {
 // Reset initiates the Reset account process.  It will not change the status as there may
 // be many reasons why a reset is called.  Delete, Lease Ending, etc.
 func (a *Service) Reset(id string) (*Account, error) {
+	return a.ResetWithProfile(id, "")
+}
+
+// ResetWithProfile initiates the reset of the account with a reset profile,
+// eg. compute-only, instead of the pool's.  An empty profile uses the pool's.
+func (a *Service) ResetWithProfile(id string, profile string) (*Account, error) {
+	err := ValidateResetProfile(profile)
+	if err != nil {
+		return nil, errors.NewValidation("account", fmt.Errorf("resetProfile: %s.", err))
+	}
 
 	data, err := a.Get(id)
 	if err != nil {
 		return nil, err
 	}
 
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.Status, validation.By(isAccountResettable)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("account", id, err)
+	}
+
 	// Set the account status to not ready if it isn't there already
 	// because of inconsistent reads we are going to force the status to NotReady
 	// there are scenarios in high volume that we could have gotten a previous state.
 	data.Status = StatusNotReady.StatusPtr()
+	data.ResetProfile = nil
+	if profile != "" {
+		data.ResetProfile = ResetProfile(profile).ResetProfilePtr()
+	}
+	err = a.revertPolicyCustomization(data)
+	if err != nil {
+		return nil, err
+	}
 	err = a.Save(data)
 	if err != nil {
 		return nil, err
@@ -301,6 +609,192 @@ func (a *Service) Reset(id string) (*Account, error) {
 
 }
 
+// resetBatchConcurrency is how many accounts ResetBatch reads at a time
+const resetBatchConcurrency = 10
+
+// AcknowledgeBlackout records that an admin looked into the activity found
+// while the account was reset, and resets it again.  Activity before the
+// acknowledgment is ignored by the reset, so the account becomes Ready once
+// it's reset without any more.
+func (a *Service) AcknowledgeBlackout(id string, acknowledgedBy string) (*Account, error) {
+	data, err := a.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.Blackout, validation.By(isBlackoutBlocking)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("account", id, err)
+	}
+
+	now := time.Now().Unix()
+	data.Blackout.AcknowledgedOn = &now
+	if acknowledgedBy != "" {
+		data.Blackout.AcknowledgedBy = &acknowledgedBy
+	}
+	err = a.Save(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Reset(id)
+}
+
+// Move moves an account which isn't leased to another pool, and to another
+// organizational unit when one is given.  The account's roles are assumed,
+// so accounts whose roles are broken aren't moved, and its principal policy
+// is applied again.  The move is added to the account's history.
+func (a *Service) Move(id string, move *Move) (*Account, error) {
+	err := move.Validate()
+	if err != nil {
+		return nil, errors.NewValidation("move", err)
+	}
+
+	data, err := a.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	previousPool := aws.StringValue(data.Pool)
+	if previousPool == move.Pool && move.OrganizationalUnitID == "" {
+		return nil, errors.NewConflict("account", id, fmt.Errorf("already in pool %q", move.Pool))
+	}
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.Status, validation.NotNil, validation.By(isAccountNotLeased)),
+		validation.Field(&data.AdminRoleArn, validation.NotNil),
+		validation.Field(&data.PrincipalRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("account", id, err)
+	}
+
+	err = a.managerSvc.ProbeReadiness(data)
+	if err != nil {
+		return nil, errors.NewConflict("account", id, fmt.Errorf("failed to assume the account's roles: %w", err))
+	}
+
+	if move.OrganizationalUnitID != "" {
+		if a.organizationsSvc == nil {
+			return nil, errors.NewServiceUnavailable("moving accounts in AWS Organizations is not configured")
+		}
+		err = a.organizationsSvc.MoveAccount(id, move.OrganizationalUnitID)
+		if err != nil {
+			return nil, errors.NewInternalServer(
+				fmt.Sprintf("failed to move account %q to organizational unit %q", id, move.OrganizationalUnitID), err)
+		}
+	}
+
+	data.Pool = nil
+	if move.Pool != "" {
+		data.Pool = aws.String(move.Pool)
+	}
+	err = a.managerSvc.UpsertPrincipalAccess(data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Moves = append(append([]Move{}, data.Moves...), Move{
+		PreviousPool:         previousPool,
+		Pool:                 move.Pool,
+		OrganizationalUnitID: move.OrganizationalUnitID,
+		Reason:               move.Reason,
+		MovedBy:              move.MovedBy,
+		MovedOn:              time.Now().Unix(),
+	})
+	err = a.Save(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateServiceQuotas replaces the service quota increases recorded for the
+// account
+func (a *Service) UpdateServiceQuotas(id string, quotas []ServiceQuota) (*Account, error) {
+	data, err := a.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data.ServiceQuotas = quotas
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.ServiceQuotas, validation.By(isServiceQuotasValid)),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("account", err)
+	}
+
+	err = a.Save(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ResetBatch queues resets of several accounts, which are already NotReady,
+// eg. after their leases were ended in bulk.  Resets are queued in batches.
+// Returns the accounts whose resets were queued, and the errors of the others.
+func (a *Service) ResetBatch(ids []string) ([]*Account, error) {
+	accounts := make([]*Account, len(ids))
+	errs := []error{}
+	var errsLock sync.Mutex
+
+	pool := common.NewWorkerPool(context.Background(), resetBatchConcurrency)
+	for i, id := range ids {
+		i, id := i, id
+		pool.Submit(func(ctx context.Context) error {
+			data, err := a.Get(id)
+			if err == nil {
+				err = validation.ValidateStruct(data,
+					validation.Field(&data.Status, validation.By(isAccountResettable)),
+					validation.Field(&data.AdminRoleArn, validation.NotNil),
+					validation.Field(&data.PrincipalRoleArn, validation.NotNil),
+				)
+				if err != nil {
+					err = errors.NewConflict("account", id, err)
+				}
+			}
+			// Batches are reset with the pool's reset profile
+			if err == nil && (data.PrincipalPolicyCustomization != nil || data.ResetProfile != nil) {
+				data.ResetProfile = nil
+				err = a.revertPolicyCustomization(data)
+				if err == nil {
+					err = a.Save(data)
+				}
+			}
+			if err != nil {
+				errsLock.Lock()
+				errs = append(errs, err)
+				errsLock.Unlock()
+				return nil
+			}
+			accounts[i] = data
+			return nil
+		})
+	}
+	pool.Wait()
+
+	queued := []*Account{}
+	for _, data := range accounts {
+		if data != nil {
+			queued = append(queued, data)
+		}
+	}
+	if len(queued) > 0 {
+		err := a.eventSvc.AccountResetBatch(queued)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Added %d accounts to Reset Queue\n", len(queued))
+	}
+
+	if len(errs) > 0 {
+		return queued, errors.NewMultiError("failed to reset accounts", errs)
+	}
+	return queued, nil
+}
+
 // UpsertPrincipalAccess merges principal access to make sure its in sync with expectations
 func (a *Service) UpsertPrincipalAccess(data *Account) error {
 	err := validation.ValidateStruct(data,
@@ -327,20 +821,85 @@ func (a *Service) UpsertPrincipalAccess(data *Account) error {
 	return nil
 }
 
+// ValidatePolicyCustomization checks a customization of the principal policy
+// only adds and removes allowlisted statements
+func (a *Service) ValidatePolicyCustomization(data *PolicyCustomization) error {
+	return a.managerSvc.ValidatePolicyCustomization(data)
+}
+
+// revertPolicyCustomization restores the principal policy of an account,
+// once the lease which customized it has ended.  The account still has to be
+// saved.
+func (a *Service) revertPolicyCustomization(data *Account) error {
+	if data.PrincipalPolicyCustomization == nil {
+		return nil
+	}
+	data.PrincipalPolicyCustomization = nil
+	return a.managerSvc.UpsertPrincipalAccess(data)
+}
+
 // NewServiceInput Input for creating a new Service
 type NewServiceInput struct {
 	PrincipalRoleName string `env:"PRINCIPAL_ROLE_NAME" envDefault:"DCEPrincipal"`
 	DataSvc           ReaderWriterDeleter
 	ManagerSvc        Manager
 	EventSvc          Eventer
+	// OutboxSvc is optional.  When it's set, the events of new accounts are
+	// written to the outbox with them, instead of being published by
+	// EventSvc.
+	OutboxSvc OutboxWriter
+	// PolicySvc is optional, and evaluates the account allocation policy
+	PolicySvc PolicyEvaluator
+	// OrganizationsSvc is optional, and moves accounts between
+	// organizational units
+	OrganizationsSvc OrganizationsMover
+	// WarmUpSteps are run on new accounts before they become Ready.  New
+	// accounts become Ready after they are reset when empty.
+	WarmUpSteps []string `env:"ACCOUNT_WARM_UP_STEPS" envSeparator:","`
+	// DefaultResetProfile is the profile of resets which weren't asked for
+	// another, which accounts without a reset profile were last reset with
+	DefaultResetProfile string `env:"RESET_DEFAULT_PROFILE" envDefault:"full"`
+	// ReadinessProbe assumes the roles of Ready accounts before they're
+	// leased, and skips accounts whose roles can't be assumed
+	ReadinessProbe bool `env:"ACCOUNT_READINESS_PROBE" envDefault:"false"`
+	// LeaseGroups are the groups whose members may lease accounts, from the
+	// pools without their own lease groups.  Any user may lease them when
+	// it's empty.
+	LeaseGroups []string `env:"LEASE_GROUPS" envSeparator:","`
+	// PoolLeaseGroups are the lease groups of pools, as pool:group pairs,
+	// eg. gpu:ML Engineers.  Pools may have more than one group.
+	PoolLeaseGroups []string `env:"POOL_LEASE_GROUPS" envSeparator:","`
 }
 
 // NewService creates a new instance of the Service
 func NewService(input NewServiceInput) *Service {
+	defaultResetProfile := ResetProfile(input.DefaultResetProfile)
+	if defaultResetProfile == "" {
+		defaultResetProfile = ResetProfileFull
+	}
+	// Pool names can't have a colon, so the group is everything after it
+	poolLeaseGroups := map[string][]string{}
+	for _, poolGroup := range input.PoolLeaseGroups {
+		parts := strings.SplitN(poolGroup, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Printf("Ignoring pool lease group %q, which isn't a pool:group pair", poolGroup)
+			continue
+		}
+		poolLeaseGroups[parts[0]] = append(poolLeaseGroups[parts[0]], parts[1])
+	}
 	return &Service{
 		dataSvc:           input.DataSvc,
 		eventSvc:          input.EventSvc,
+		outboxSvc:         input.OutboxSvc,
 		managerSvc:        input.ManagerSvc,
+		policySvc:         input.PolicySvc,
+		organizationsSvc:  input.OrganizationsSvc,
 		principalRoleName: input.PrincipalRoleName,
+		warmUpSteps:       input.WarmUpSteps,
+
+		defaultResetProfile: defaultResetProfile,
+		readinessProbe:      input.ReadinessProbe,
+		leaseGroups:         input.LeaseGroups,
+		poolLeaseGroups:     poolLeaseGroups,
 	}
 }
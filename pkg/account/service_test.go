@@ -9,6 +9,9 @@ import (
 	"github.com/Optum/dce/pkg/account/mocks"
 	"github.com/Optum/dce/pkg/arn"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/policy"
+	"github.com/Optum/dce/pkg/routing"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -151,6 +154,220 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteUnreachable(t *testing.T) {
+	acct := &account.Account{
+		ID:               ptrString("123456789012"),
+		Status:           account.StatusUnreachable.StatusPtr(),
+		AdminRoleArn:     arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+		PrincipalRoleArn: arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+	}
+
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksRwd.On("Delete", acct).Return(nil)
+	mocksManager := &mocks.Manager{}
+	mocksEventer := &mocks.Eventer{}
+	mocksEventer.On("AccountDelete", acct).Return(nil)
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:    mocksRwd,
+			ManagerSvc: mocksManager,
+			EventSvc:   mocksEventer,
+		},
+	)
+	err := accountSvc.Delete(acct)
+
+	assert.Nil(t, err)
+	mocksManager.AssertNotCalled(t, "DeletePrincipalAccess", mock.Anything)
+	mocksEventer.AssertNotCalled(t, "AccountReset", mock.Anything)
+}
+
+func TestResetUnreachable(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksRwd.On("Get", "123456789012").Return(&account.Account{
+		ID:     ptrString("123456789012"),
+		Status: account.StatusUnreachable.StatusPtr(),
+	}, nil)
+	mocksEventer := &mocks.Eventer{}
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:  mocksRwd,
+			EventSvc: mocksEventer,
+		},
+	)
+	_, err := accountSvc.Reset("123456789012")
+
+	assert.EqualError(t, err, "operation cannot be fulfilled on account \"123456789012\": accountStatus: must not be unreachable.")
+	mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+	mocksEventer.AssertNotCalled(t, "AccountReset", mock.Anything)
+}
+
+func TestResetRevertsPolicyCustomization(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksRwd.On("Get", "123456789012").Return(&account.Account{
+		ID:                           ptrString("123456789012"),
+		Status:                       account.StatusLeased.StatusPtr(),
+		CreatedOn:                    aws.Int64(1561149393),
+		LastModifiedOn:               aws.Int64(1561149393),
+		AdminRoleArn:                 arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+		PrincipalRoleArn:             arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+		PrincipalPolicyHash:          ptrString("customized"),
+		PrincipalPolicyCustomization: &account.PolicyCustomization{Add: []string{"Bedrock"}},
+	}, nil)
+	mocksRwd.On("Write", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64")).Return(nil)
+	mocksManager := &mocks.Manager{}
+	mocksManager.On("UpsertPrincipalAccess", mock.AnythingOfType("*account.Account")).
+		Run(func(args mock.Arguments) {
+			acct := args.Get(0).(*account.Account)
+			assert.Nil(t, acct.PrincipalPolicyCustomization, "the policy should be rendered without the customization")
+			acct.PrincipalPolicyHash = ptrString("default")
+		}).Return(nil)
+	mocksEventer := &mocks.Eventer{}
+	mocksEventer.On("AccountReset", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:    mocksRwd,
+			ManagerSvc: mocksManager,
+			EventSvc:   mocksEventer,
+		},
+	)
+	result, err := accountSvc.Reset("123456789012")
+
+	assert.Nil(t, err)
+	assert.Nil(t, result.PrincipalPolicyCustomization)
+	assert.Equal(t, "default", *result.PrincipalPolicyHash)
+	mocksManager.AssertNumberOfCalls(t, "UpsertPrincipalAccess", 1)
+	mocksRwd.AssertCalled(t, "Write", mock.MatchedBy(func(acct *account.Account) bool {
+		return acct.PrincipalPolicyCustomization == nil && *acct.PrincipalPolicyHash == "default"
+	}), mock.Anything)
+}
+
+func TestResetWithProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		profile      string
+		current      *account.ResetProfile
+		expProfile   *account.ResetProfile
+		expErr       error
+		expEventCall bool
+	}{
+		{
+			name:         "should record the reset profile",
+			profile:      "data-preserving",
+			expProfile:   account.ResetProfileDataPreserving.ResetProfilePtr(),
+			expEventCall: true,
+		},
+		{
+			name:         "should clear the reset profile of the last reset",
+			profile:      "",
+			current:      account.ResetProfileComputeOnly.ResetProfilePtr(),
+			expEventCall: true,
+		},
+		{
+			name:    "should fail on unknown reset profiles",
+			profile: "everything",
+			expErr:  errors.NewValidation("account", fmt.Errorf("resetProfile: must be one of full, compute-only, data-preserving.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriterDeleter{}
+			mocksRwd.On("Get", "123456789012").Return(&account.Account{
+				ID:               ptrString("123456789012"),
+				Status:           account.StatusLeased.StatusPtr(),
+				CreatedOn:        aws.Int64(1561149393),
+				LastModifiedOn:   aws.Int64(1561149393),
+				AdminRoleArn:     arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+				PrincipalRoleArn: arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+				ResetProfile:     tt.current,
+			}, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("AccountReset", mock.AnythingOfType("*account.Account")).Return(nil)
+
+			accountSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:  mocksRwd,
+					EventSvc: mocksEventer,
+				},
+			)
+			result, err := accountSvc.ResetWithProfile("123456789012", tt.profile)
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expEventCall {
+				assert.Equal(t, tt.expProfile, result.ResetProfile)
+				mocksEventer.AssertCalled(t, "AccountReset", mock.MatchedBy(func(acct *account.Account) bool {
+					return assert.ObjectsAreEqual(tt.expProfile, acct.ResetProfile)
+				}))
+			} else {
+				mocksEventer.AssertNotCalled(t, "AccountReset", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAcknowledgeBlackout(t *testing.T) {
+	tests := []struct {
+		name     string
+		blackout *account.Blackout
+		expErr   string
+	}{
+		{
+			name:     "should acknowledge the blackout and reset the account",
+			blackout: &account.Blackout{DetectedOn: 1561149393, Since: 1561140000},
+		},
+		{
+			name:   "should fail when there's no blackout",
+			expErr: "operation cannot be fulfilled on account \"123456789012\": blackout: must have a blackout which isn't acknowledged.",
+		},
+		{
+			name:     "should fail when the blackout was acknowledged",
+			blackout: &account.Blackout{DetectedOn: 1561149393, AcknowledgedOn: aws.Int64(1561149400)},
+			expErr:   "operation cannot be fulfilled on account \"123456789012\": blackout: must have a blackout which isn't acknowledged.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriterDeleter{}
+			mocksRwd.On("Get", "123456789012").Return(&account.Account{
+				ID:               ptrString("123456789012"),
+				Status:           account.StatusNotReady.StatusPtr(),
+				CreatedOn:        aws.Int64(1561149393),
+				LastModifiedOn:   aws.Int64(1561149393),
+				AdminRoleArn:     arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+				PrincipalRoleArn: arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+				Blackout:         tt.blackout,
+			}, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("AccountReset", mock.AnythingOfType("*account.Account")).Return(nil)
+
+			accountSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:  mocksRwd,
+					EventSvc: mocksEventer,
+				},
+			)
+			result, err := accountSvc.AcknowledgeBlackout("123456789012", "admin1")
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Nil(t, err)
+			assert.NotNil(t, result.Blackout.AcknowledgedOn)
+			assert.Equal(t, "admin1", *result.Blackout.AcknowledgedBy)
+			assert.False(t, result.Blackout.IsBlocking())
+			mocksEventer.AssertCalled(t, "AccountReset", mock.Anything)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	now := time.Now().Unix()
 
@@ -236,6 +453,61 @@ func TestUpdate(t *testing.T) {
 			},
 			returnErr: nil,
 		},
+		{
+			name: "should fail validation of the version",
+			origAccount: account.Account{
+				ID:     ptrString("123456789012"),
+				Status: account.StatusReady.StatusPtr(),
+			},
+			updAccount: account.Account{
+				Version: aws.Int64(7),
+			},
+			exp: response{
+				data: nil,
+				err:  errors.NewValidation("account", fmt.Errorf("version: must be empty.")), //nolint golint
+			},
+			returnErr: nil,
+		},
+		{
+			name: "should update the contact",
+			origAccount: account.Account{
+				ID:             ptrString("123456789012"),
+				Status:         account.StatusReady.StatusPtr(),
+				AdminRoleArn:   arn.New("aws", "iam", "", "123456789012", "role/AdminRoleArn"),
+				CreatedOn:      &now,
+				LastModifiedOn: &now,
+			},
+			updAccount: account.Account{
+				Contact: &routing.Contact{Email: "ml@example.com", Team: "ml-platform"},
+			},
+			exp: response{
+				data: &account.Account{
+					ID:             ptrString("123456789012"),
+					Status:         account.StatusReady.StatusPtr(),
+					AdminRoleArn:   arn.New("aws", "iam", "", "123456789012", "role/AdminRoleArn"),
+					Contact:        &routing.Contact{Email: "ml@example.com", Team: "ml-platform"},
+					CreatedOn:      &now,
+					LastModifiedOn: &now,
+				},
+				err: nil,
+			},
+			returnErr: nil,
+		},
+		{
+			name: "should fail validation of the contact",
+			origAccount: account.Account{
+				ID:     ptrString("123456789012"),
+				Status: account.StatusReady.StatusPtr(),
+			},
+			updAccount: account.Account{
+				Contact: &routing.Contact{Email: "ml-platform"},
+			},
+			exp: response{
+				data: nil,
+				err:  errors.NewValidation("account", fmt.Errorf("contact: (email: must be a valid email address.).")), //nolint golint
+			},
+			returnErr: nil,
+		},
 		{
 			name: "should fail on save",
 			origAccount: account.Account{
@@ -461,6 +733,413 @@ func TestGetAccounts(t *testing.T) {
 
 }
 
+func TestGetReadyAccount(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		pages     []*account.Accounts
+		retErr    error
+		denied    map[string]bool
+		policyErr error
+		expID     *string
+		expErr    error
+	}{
+		{
+			name: "should skip accounts cooling down",
+			pages: []*account.Accounts{
+				{
+					account.Account{ID: aws.String("1"), ReadyAt: aws.Int64(now + 3600)},
+					account.Account{ID: aws.String("2"), ReadyAt: aws.Int64(now - 60)},
+				},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get Ready accounts from later pages",
+			pages: []*account.Accounts{
+				{
+					account.Account{ID: aws.String("1"), ReadyAt: aws.Int64(now + 3600)},
+				},
+				{
+					account.Account{ID: aws.String("2")},
+				},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get nothing when every account is cooling down",
+			pages: []*account.Accounts{
+				{
+					account.Account{ID: aws.String("1"), ReadyAt: aws.Int64(now + 3600)},
+				},
+			},
+		},
+		{
+			name: "should skip accounts the allocation policy doesn't allow",
+			pages: []*account.Accounts{
+				{
+					account.Account{ID: aws.String("1")},
+					account.Account{ID: aws.String("2")},
+				},
+			},
+			denied: map[string]bool{"1": true},
+			expID:  aws.String("2"),
+		},
+		{
+			name: "should fail when the allocation policy can't be evaluated",
+			pages: []*account.Accounts{
+				{
+					account.Account{ID: aws.String("1")},
+				},
+			},
+			policyErr: errors.NewInternalServer("failure", fmt.Errorf("original error")),
+			expErr:    errors.NewInternalServer("failure", fmt.Errorf("original error")),
+		},
+		{
+			name:   "should fail when the accounts can't be listed",
+			pages:  []*account.Accounts{nil},
+			retErr: errors.NewInternalServer("failure", fmt.Errorf("original error")),
+			expErr: errors.NewInternalServer("failure", fmt.Errorf("original error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRWD := &mocks.ReaderWriterDeleter{}
+			for i, page := range tt.pages {
+				i := i
+				mocksRWD.On("List", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+					query := args.Get(0).(*account.Account)
+					query.NextID = nil
+					if i < len(tt.pages)-1 {
+						query.NextID = aws.String("next")
+					}
+				}).Return(page, tt.retErr).Once()
+			}
+
+			mocksPolicy := &mocks.PolicyEvaluator{}
+			mocksPolicy.On("Evaluate", policy.AccountAllocate, mock.AnythingOfType("*account.AllocatePolicyInput")).Return(
+				func(document string, input interface{}) *policy.Decision {
+					if tt.policyErr != nil {
+						return nil
+					}
+					acct := input.(*account.AllocatePolicyInput)
+					assert.Equal(t, "user1", acct.PrincipalID)
+					return &policy.Decision{Allow: !tt.denied[*acct.Account.ID]}
+				},
+				tt.policyErr,
+			)
+
+			accountsSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:   mocksRWD,
+					PolicySvc: mocksPolicy,
+				},
+			)
+
+			acct, err := accountsSvc.GetReadyAccount("user1")
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expID == nil {
+				assert.Nil(t, acct)
+			} else {
+				assert.Equal(t, *tt.expID, *acct.ID)
+			}
+			mocksRWD.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetReadyAccountWithReadinessProbe(t *testing.T) {
+	newAccount := func(id string) account.Account {
+		return account.Account{
+			ID:               aws.String(id),
+			Status:           account.StatusReady.StatusPtr(),
+			AdminRoleArn:     arn.New("aws", "iam", "", id, "role/AdminRole"),
+			PrincipalRoleArn: arn.New("aws", "iam", "", id, "role/DCEPrincipal"),
+			CreatedOn:        aws.Int64(1561149393),
+			LastModifiedOn:   aws.Int64(1561149393),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		probeErrs   map[string]error
+		probe       bool
+		expID       *string
+		expNotReady []string
+	}{
+		{
+			name:        "should skip accounts failing the probe, and make them NotReady",
+			probe:       true,
+			probeErrs:   map[string]error{"111111111111": errors.NewConflict("account", "111111111111", fmt.Errorf("failed to assume role"))},
+			expID:       aws.String("222222222222"),
+			expNotReady: []string{"111111111111"},
+		},
+		{
+			name:        "should get nothing when every account fails the probe",
+			probe:       true,
+			probeErrs:   map[string]error{"111111111111": fmt.Errorf("failure"), "222222222222": fmt.Errorf("failure")},
+			expNotReady: []string{"111111111111", "222222222222"},
+		},
+		{
+			name:      "should not probe accounts when probes are disabled",
+			probeErrs: map[string]error{"111111111111": fmt.Errorf("failure")},
+			expID:     aws.String("111111111111"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRWD := &mocks.ReaderWriterDeleter{}
+			mocksRWD.On("List", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+				args.Get(0).(*account.Account).NextID = nil
+			}).Return(&account.Accounts{newAccount("111111111111"), newAccount("222222222222")}, nil)
+			notReady := []string{}
+			mocksRWD.On("Write", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64")).Run(func(args mock.Arguments) {
+				acct := args.Get(0).(*account.Account)
+				assert.Equal(t, account.StatusNotReady, *acct.Status)
+				notReady = append(notReady, *acct.ID)
+			}).Return(nil)
+
+			mocksManager := &mocks.Manager{}
+			mocksManager.On("ProbeReadiness", mock.AnythingOfType("*account.Account")).Return(func(acct *account.Account) error {
+				return tt.probeErrs[*acct.ID]
+			})
+
+			accountsSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:        mocksRWD,
+					ManagerSvc:     mocksManager,
+					ReadinessProbe: tt.probe,
+				},
+			)
+
+			acct, err := accountsSvc.GetReadyAccount("user1")
+			assert.Nil(t, err)
+			if tt.expID == nil {
+				assert.Nil(t, acct)
+			} else {
+				assert.Equal(t, *tt.expID, *acct.ID)
+			}
+			if tt.expNotReady == nil {
+				assert.Empty(t, notReady)
+				mocksManager.AssertNotCalled(t, "ProbeReadiness", mock.Anything)
+			} else {
+				assert.Equal(t, tt.expNotReady, notReady)
+			}
+		})
+	}
+}
+
+func TestGetReadyAccountWithRequirements(t *testing.T) {
+	quota := func(value float64) account.ServiceQuota {
+		return account.ServiceQuota{ServiceCode: "ec2", QuotaCode: "L-417A185B", Region: "us-east-1", Value: value}
+	}
+	gpu := aws.String("gpu")
+
+	tests := []struct {
+		name         string
+		requirements *account.Requirements
+		page         *account.Accounts
+		expPool      *string
+		expID        *string
+	}{
+		{
+			name: "should get an account with the required quotas",
+			requirements: &account.Requirements{
+				ServiceQuotas: []account.ServiceQuota{{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32}},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1")},
+				account.Account{ID: aws.String("2"), ServiceQuotas: []account.ServiceQuota{quota(8)}},
+				account.Account{ID: aws.String("3"), ServiceQuotas: []account.ServiceQuota{quota(32)}},
+			},
+			expID: aws.String("3"),
+		},
+		{
+			name: "should query the required pool",
+			requirements: &account.Requirements{
+				Pool: gpu,
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), Pool: gpu},
+			},
+			expPool: gpu,
+			expID:   aws.String("1"),
+		},
+		{
+			name: "should get an account available in the required regions",
+			requirements: &account.Requirements{
+				Regions: []string{"ap-east-1"},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), Regions: []string{"us-east-1"}},
+				account.Account{ID: aws.String("2"), Regions: []string{"us-east-1", "ap-east-1"}},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get an account last reset with the required profile",
+			requirements: &account.Requirements{
+				ResetProfile: account.ResetProfileFull.ResetProfilePtr(),
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), ResetProfile: account.ResetProfileComputeOnly.ResetProfilePtr()},
+				account.Account{ID: aws.String("2")},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get the account with the least to spare",
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), ServiceQuotas: []account.ServiceQuota{quota(32)}},
+				account.Account{ID: aws.String("2"), Pool: gpu},
+				account.Account{ID: aws.String("3"), Regions: []string{"us-east-1"}},
+				account.Account{ID: aws.String("4")},
+			},
+			expID: aws.String("4"),
+		},
+		{
+			name: "should get nothing when no account meets the requirements",
+			requirements: &account.Requirements{
+				Regions: []string{"ap-east-1"},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), Regions: []string{"us-east-1"}},
+			},
+		},
+		{
+			name: "should only get accounts from the pools of the user's groups",
+			requirements: &account.Requirements{
+				Groups: &[]string{"ML Engineers"},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1")},
+				account.Account{ID: aws.String("2"), Pool: gpu},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get accounts of pools without lease groups for members of the default lease groups",
+			requirements: &account.Requirements{
+				Groups: &[]string{"Engineering"},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), Pool: gpu},
+				account.Account{ID: aws.String("2"), Pool: aws.String("batch")},
+			},
+			expID: aws.String("2"),
+		},
+		{
+			name: "should get nothing when the user's groups may not lease any account",
+			requirements: &account.Requirements{
+				Groups: &[]string{"Sales"},
+			},
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1")},
+				account.Account{ID: aws.String("2"), Pool: gpu},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRWD := &mocks.ReaderWriterDeleter{}
+			mocksRWD.On("List", mock.MatchedBy(func(query *account.Account) bool {
+				return *query.Status == account.StatusReady && assert.ObjectsAreEqual(tt.expPool, query.Pool)
+			})).Return(tt.page, nil).Once()
+
+			accountsSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:         mocksRWD,
+					LeaseGroups:     []string{"Engineering"},
+					PoolLeaseGroups: []string{"gpu:ML Engineers"},
+				},
+			)
+
+			acct, err := accountsSvc.GetReadyAccountWithRequirements("user1", tt.requirements)
+			assert.Nil(t, err)
+			if tt.expID == nil {
+				assert.Nil(t, acct)
+			} else {
+				assert.Equal(t, *tt.expID, *acct.ID)
+			}
+			mocksRWD.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPoolLeaseGroups(t *testing.T) {
+	accountsSvc := account.NewService(account.NewServiceInput{
+		LeaseGroups:     []string{"Engineering"},
+		PoolLeaseGroups: []string{"gpu:ML Engineers", "gpu:Data Science", "invalid"},
+	})
+
+	assert.Equal(t, []string{"ML Engineers", "Data Science"}, accountsSvc.PoolLeaseGroups(aws.String("gpu")))
+	assert.Equal(t, []string{"Engineering"}, accountsSvc.PoolLeaseGroups(aws.String("batch")))
+	assert.Equal(t, []string{"Engineering", "ML Engineers", "Data Science"}, accountsSvc.PoolLeaseGroups(nil))
+
+	// Any user may lease accounts from pools without lease groups
+	accountsSvc = account.NewService(account.NewServiceInput{
+		PoolLeaseGroups: []string{"gpu:ML Engineers"},
+	})
+	assert.Nil(t, accountsSvc.PoolLeaseGroups(aws.String("batch")))
+	assert.Nil(t, accountsSvc.PoolLeaseGroups(nil))
+}
+
+func TestGetLastLeasedAccount(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name  string
+		page  *account.Accounts
+		expID *string
+	}{
+		{
+			name: "should get the Ready account last leased to the principal",
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), LastLeasedBy: aws.String("user1")},
+			},
+			expID: aws.String("1"),
+		},
+		{
+			name: "should get nothing when the account is cooling down",
+			page: &account.Accounts{
+				account.Account{ID: aws.String("1"), LastLeasedBy: aws.String("user1"), ReadyAt: aws.Int64(now + 3600)},
+			},
+		},
+		{
+			name: "should get nothing when the account isn't Ready",
+			page: &account.Accounts{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRWD := &mocks.ReaderWriterDeleter{}
+			mocksRWD.On("List", mock.MatchedBy(func(query *account.Account) bool {
+				return *query.Status == account.StatusReady && *query.LastLeasedBy == "user1"
+			})).Return(tt.page, nil).Once()
+
+			accountsSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc: mocksRWD,
+				},
+			)
+
+			acct, err := accountsSvc.GetLastLeasedAccount("user1", nil)
+			assert.Nil(t, err)
+			if tt.expID == nil {
+				assert.Nil(t, acct)
+			} else {
+				assert.Equal(t, *tt.expID, *acct.ID)
+			}
+			mocksRWD.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	now := time.Now().Unix()
 
@@ -620,6 +1299,120 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateWithWarmUp(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksManager := &mocks.Manager{}
+	mocksEventer := &mocks.Eventer{}
+
+	mocksRwd.On("Get", "123456789012").Return(nil, errors.NewNotFound("account", "123456789012"))
+	mocksRwd.On("Write", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64")).Return(nil)
+	mocksEventer.On("AccountCreate", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:           mocksRwd,
+			ManagerSvc:        mocksManager,
+			EventSvc:          mocksEventer,
+			PrincipalRoleName: "DCEPrincipal",
+			WarmUpSteps:       []string{account.WarmUpStepCreatePrincipalRole, account.WarmUpStepInitialReset},
+		},
+	)
+
+	result, err := accountSvc.Create(&account.Account{
+		ID:           ptrString("123456789012"),
+		AdminRoleArn: arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, account.StatusNotReady, *result.Status)
+	assert.Equal(t, &account.WarmUp{
+		Status: account.WarmUpStatusInProgress,
+		Steps: []*account.WarmUpStep{
+			{Name: account.WarmUpStepCreatePrincipalRole, Status: account.WarmUpStatusPending},
+			{Name: account.WarmUpStepInitialReset, Status: account.WarmUpStatusPending},
+		},
+	}, result.WarmUp)
+
+	// The principal role is created, and the account is reset, by the warm-up
+	mocksManager.AssertNotCalled(t, "UpsertPrincipalAccess", mock.Anything)
+	mocksEventer.AssertNotCalled(t, "AccountReset", mock.Anything)
+}
+
+func TestCreateWithOutbox(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksOutbox := &mocks.OutboxWriter{}
+	mocksEventer := &mocks.Eventer{}
+
+	mocksRwd.On("Get", "123456789012").Return(nil, errors.NewNotFound("account", "123456789012"))
+	mocksOutbox.On("WriteWithEvents", mock.AnythingOfType("*account.Account"), mock.AnythingOfType("*int64"),
+		mock.MatchedBy(func(entries []*outbox.Entry) bool {
+			return len(entries) == 2 &&
+				entries[0].Type == outbox.TypeAccountCreate &&
+				entries[1].Type == outbox.TypeAccountReset &&
+				entries[1].ResourceID == "123456789012"
+		})).Return(nil)
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:           mocksRwd,
+			ManagerSvc:        &mocks.Manager{},
+			EventSvc:          mocksEventer,
+			OutboxSvc:         mocksOutbox,
+			PrincipalRoleName: "DCEPrincipal",
+			WarmUpSteps:       []string{account.WarmUpStepCreatePrincipalRole},
+		},
+	)
+
+	_, err := accountSvc.Create(&account.Account{
+		ID:           ptrString("123456789012"),
+		AdminRoleArn: arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+	})
+	assert.Nil(t, err)
+	mocksOutbox.AssertExpectations(t)
+	// The events are published from the outbox
+	mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+	mocksEventer.AssertNotCalled(t, "AccountCreate", mock.Anything)
+	mocksEventer.AssertNotCalled(t, "AccountReset", mock.Anything)
+}
+
+func TestResetBatch(t *testing.T) {
+	newAccount := func(id string) *account.Account {
+		return &account.Account{
+			ID:               ptrString(id),
+			Status:           account.StatusNotReady.StatusPtr(),
+			AdminRoleArn:     arn.New("aws", "iam", "", id, "role/AdminRole"),
+			PrincipalRoleArn: arn.New("aws", "iam", "", id, "role/PrincipalRole"),
+		}
+	}
+	noRoles := newAccount("333333333333")
+	noRoles.AdminRoleArn = nil
+
+	mocksRwd := &mocks.ReaderWriterDeleter{}
+	mocksRwd.On("Get", "111111111111").Return(newAccount("111111111111"), nil)
+	mocksRwd.On("Get", "222222222222").Return(newAccount("222222222222"), nil)
+	mocksRwd.On("Get", "333333333333").Return(noRoles, nil)
+	mocksRwd.On("Get", "444444444444").Return(nil, errors.NewNotFound("account", "444444444444"))
+	mocksEventer := &mocks.Eventer{}
+	mocksEventer.On("AccountResetBatch", []*account.Account{
+		newAccount("111111111111"),
+		newAccount("222222222222"),
+	}).Return(nil)
+
+	accountSvc := account.NewService(
+		account.NewServiceInput{
+			DataSvc:  mocksRwd,
+			EventSvc: mocksEventer,
+		},
+	)
+	queued, err := accountSvc.ResetBatch([]string{"111111111111", "222222222222", "333333333333", "444444444444"})
+
+	assert.Len(t, queued, 2)
+	merr, ok := err.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Len(t, merr.Errors, 2)
+	mocksEventer.AssertExpectations(t)
+	mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
 func TestUpsertPrincipalAccess(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -712,3 +1505,105 @@ func TestUpsertPrincipalAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   account.Status
+		pool     *string
+		move     *account.Move
+		probeErr error
+		expOU    bool
+		expPool  *string
+		expErr   string
+	}{
+		{
+			name:    "should move the account to another pool",
+			status:  account.StatusReady,
+			move:    &account.Move{Pool: "gpu", Reason: "needs GPU quotas", MovedBy: "admin1"},
+			expPool: aws.String("gpu"),
+		},
+		{
+			name:   "should move the account out of its pool, and to another organizational unit",
+			status: account.StatusNotReady,
+			pool:   aws.String("gpu"),
+			move:   &account.Move{OrganizationalUnitID: "ou-ab12-cdef3456"},
+			expOU:  true,
+		},
+		{
+			name:   "should fail when the account is leased",
+			status: account.StatusLeased,
+			move:   &account.Move{Pool: "gpu"},
+			expErr: "operation cannot be fulfilled on account \"123456789012\": accountStatus: must not be leased.",
+		},
+		{
+			name:   "should fail when the account is in the pool",
+			status: account.StatusReady,
+			pool:   aws.String("gpu"),
+			move:   &account.Move{Pool: "gpu"},
+			expErr: "operation cannot be fulfilled on account \"123456789012\": already in pool \"gpu\"",
+		},
+		{
+			name:     "should fail when the account's roles can't be assumed",
+			status:   account.StatusReady,
+			move:     &account.Move{Pool: "gpu"},
+			probeErr: fmt.Errorf("access denied"),
+			expErr:   "operation cannot be fulfilled on account \"123456789012\": failed to assume the account's roles: access denied",
+		},
+		{
+			name:   "should fail when the organizational unit is invalid",
+			status: account.StatusReady,
+			move:   &account.Move{Pool: "gpu", OrganizationalUnitID: "Sandbox"},
+			expErr: "move validation error: organizationalUnitId: must be the ID of an organizational unit or root.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriterDeleter{}
+			mocksRwd.On("Get", "123456789012").Return(&account.Account{
+				ID:               ptrString("123456789012"),
+				Status:           tt.status.StatusPtr(),
+				CreatedOn:        aws.Int64(1561149393),
+				LastModifiedOn:   aws.Int64(1561149393),
+				AdminRoleArn:     arn.New("aws", "iam", "", "123456789012", "role/AdminRole"),
+				PrincipalRoleArn: arn.New("aws", "iam", "", "123456789012", "role/PrincipalRole"),
+				Pool:             tt.pool,
+			}, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*account.Account"), aws.Int64(1561149393)).Return(nil)
+			mocksManager := &mocks.Manager{}
+			mocksManager.On("ProbeReadiness", mock.AnythingOfType("*account.Account")).Return(tt.probeErr)
+			mocksManager.On("UpsertPrincipalAccess", mock.AnythingOfType("*account.Account")).Return(nil)
+			mocksOrganizations := &mocks.OrganizationsMover{}
+			mocksOrganizations.On("MoveAccount", "123456789012", "ou-ab12-cdef3456").Return(nil)
+
+			accountSvc := account.NewService(
+				account.NewServiceInput{
+					DataSvc:          mocksRwd,
+					ManagerSvc:       mocksManager,
+					OrganizationsSvc: mocksOrganizations,
+				},
+			)
+			result, err := accountSvc.Move("123456789012", tt.move)
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+				mocksOrganizations.AssertNotCalled(t, "MoveAccount", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expPool, result.Pool)
+			assert.Len(t, result.Moves, 1)
+			assert.Equal(t, aws.StringValue(tt.pool), result.Moves[0].PreviousPool)
+			assert.Equal(t, tt.move.Pool, result.Moves[0].Pool)
+			assert.NotZero(t, result.Moves[0].MovedOn)
+			mocksManager.AssertCalled(t, "UpsertPrincipalAccess", result)
+			if tt.expOU {
+				mocksOrganizations.AssertExpectations(t)
+			} else {
+				mocksOrganizations.AssertNotCalled(t, "MoveAccount", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -64,3 +64,22 @@ func isAccountNotLeased(value interface{}) error {
 	}
 	return nil
 }
+
+func isBlackoutBlocking(value interface{}) error {
+	b, _ := value.(*Blackout)
+	if !b.IsBlocking() {
+		return errors.New("must have a blackout which isn't acknowledged")
+	}
+	return nil
+}
+
+func isAccountResettable(value interface{}) error {
+	s, _ := value.(*Status)
+	if s != nil && s.String() == StatusUnreachable.String() {
+		return errors.New("must not be unreachable")
+	}
+	if s != nil && s.String() == StatusQuarantined.String() {
+		return errors.New("must not be quarantined")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package account
+
+// Steps which may be run to warm up an account, after it's added to the pool
+// and before it becomes Ready
+const (
+	// WarmUpStepCreatePrincipalRole creates the principal role and policy
+	WarmUpStepCreatePrincipalRole = "CreatePrincipalRole"
+	// WarmUpStepApplyBaseline deploys the baseline CloudFormation template
+	WarmUpStepApplyBaseline = "ApplyBaseline"
+	// WarmUpStepInitialReset resets the account, and waits for the reset
+	WarmUpStepInitialReset = "InitialReset"
+	// WarmUpStepVerifyHealth checks the account can be managed and leased
+	WarmUpStepVerifyHealth = "VerifyHealth"
+)
+
+// WarmUpSteps are the names of the steps an account may be warmed up with
+var WarmUpSteps = []string{
+	WarmUpStepCreatePrincipalRole,
+	WarmUpStepApplyBaseline,
+	WarmUpStepInitialReset,
+	WarmUpStepVerifyHealth,
+}
+
+// WarmUpStatus is the status of an account's warm-up, or of one of its steps
+type WarmUpStatus string
+
+const (
+	// WarmUpStatusPending steps haven't started
+	WarmUpStatusPending WarmUpStatus = "Pending"
+	// WarmUpStatusInProgress steps are running, or waiting on another part of DCE
+	WarmUpStatusInProgress WarmUpStatus = "InProgress"
+	// WarmUpStatusSucceeded steps are done.  Accounts whose warm-up succeeded
+	// become Ready.
+	WarmUpStatusSucceeded WarmUpStatus = "Succeeded"
+	// WarmUpStatusFailed steps failed.  Accounts whose warm-up failed stay
+	// NotReady, and aren't leased.
+	WarmUpStatusFailed WarmUpStatus = "Failed"
+)
+
+// WarmUp is the progress of the steps run on a new account before it becomes Ready
+type WarmUp struct {
+	Status WarmUpStatus  `json:"status" dynamodbav:"Status"`
+	Steps  []*WarmUpStep `json:"steps" dynamodbav:"Steps"`
+	// ExecutionArn is the Step Functions execution running the steps
+	ExecutionArn *string `json:"executionArn,omitempty" dynamodbav:"ExecutionArn,omitempty"`
+	// ResetCompletedOn is when the first reset of the account finished.  It's
+	// set by the reset, since accounts which are warming up stay NotReady
+	// after their reset.
+	ResetCompletedOn *int64 `json:"resetCompletedOn,omitempty" dynamodbav:"ResetCompletedOn,omitempty"`
+}
+
+// WarmUpStep is the status of a warm-up step
+type WarmUpStep struct {
+	Name   string       `json:"name" dynamodbav:"Name"`
+	Status WarmUpStatus `json:"status" dynamodbav:"Status"`
+	// Message explains why the step failed, or what it's waiting on
+	Message        string `json:"message,omitempty" dynamodbav:"Message,omitempty"`
+	LastModifiedOn *int64 `json:"lastModifiedOn,omitempty" dynamodbav:"LastModifiedOn,omitempty"`
+}
+
+// NewWarmUp creates a warm-up of the given steps, none of which have started
+func NewWarmUp(steps []string) *WarmUp {
+	warmUp := &WarmUp{
+		Status: WarmUpStatusInProgress,
+		Steps:  []*WarmUpStep{},
+	}
+	for _, name := range steps {
+		warmUp.Steps = append(warmUp.Steps, &WarmUpStep{
+			Name:   name,
+			Status: WarmUpStatusPending,
+		})
+	}
+	return warmUp
+}
+
+// HasStep is true when the warm-up runs the step
+func (w *WarmUp) HasStep(name string) bool {
+	return w.Step(name) != nil
+}
+
+// Step gets the status of a step, or nil when the warm-up doesn't run it
+func (w *WarmUp) Step(name string) *WarmUpStep {
+	if w == nil {
+		return nil
+	}
+	for _, s := range w.Steps {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// IsDone is true once the warm-up succeeded.  Accounts without a warm-up are
+// done.
+func (w *WarmUp) IsDone() bool {
+	return w == nil || w.Status == WarmUpStatusSucceeded
+}
+
+// SetStep records the status of a step.  The warm-up fails when any of its
+// steps fail.
+func (w *WarmUp) SetStep(name string, status WarmUpStatus, message string, now int64) {
+	s := w.Step(name)
+	if s == nil {
+		s = &WarmUpStep{Name: name}
+		w.Steps = append(w.Steps, s)
+	}
+	s.Status = status
+	s.Message = message
+	s.LastModifiedOn = &now
+
+	if status == WarmUpStatusFailed {
+		w.Status = WarmUpStatusFailed
+	}
+}
+
+// RunningStep is the name of the step which is in progress, if any
+func (w *WarmUp) RunningStep() string {
+	for _, s := range w.Steps {
+		if s.Status == WarmUpStatusInProgress {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// Complete marks the warm-up succeeded, once every step has
+func (w *WarmUp) Complete() bool {
+	for _, s := range w.Steps {
+		if s.Status != WarmUpStatusSucceeded {
+			return false
+		}
+	}
+	w.Status = WarmUpStatusSucceeded
+	return true
+}
@@ -0,0 +1,39 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUp(t *testing.T) {
+	warmUp := account.NewWarmUp([]string{account.WarmUpStepCreatePrincipalRole, account.WarmUpStepVerifyHealth})
+	assert.True(t, warmUp.HasStep(account.WarmUpStepVerifyHealth))
+	assert.False(t, warmUp.HasStep(account.WarmUpStepApplyBaseline))
+	assert.False(t, warmUp.IsDone())
+
+	warmUp.SetStep(account.WarmUpStepCreatePrincipalRole, account.WarmUpStatusSucceeded, "", 1)
+	warmUp.SetStep(account.WarmUpStepVerifyHealth, account.WarmUpStatusInProgress, "", 2)
+	assert.Equal(t, account.WarmUpStepVerifyHealth, warmUp.RunningStep())
+	assert.False(t, warmUp.Complete())
+
+	warmUp.SetStep(account.WarmUpStepVerifyHealth, account.WarmUpStatusSucceeded, "", 3)
+	assert.True(t, warmUp.Complete())
+	assert.True(t, warmUp.IsDone())
+
+	t.Run("fails when a step fails", func(t *testing.T) {
+		warmUp := account.NewWarmUp([]string{account.WarmUpStepCreatePrincipalRole})
+		warmUp.SetStep(account.WarmUpStepCreatePrincipalRole, account.WarmUpStatusFailed, "access denied", 1)
+		assert.Equal(t, account.WarmUpStatusFailed, warmUp.Status)
+		assert.Equal(t, "access denied", warmUp.Steps[0].Message)
+		assert.False(t, warmUp.Complete())
+		assert.False(t, warmUp.IsDone())
+	})
+
+	t.Run("accounts without a warm-up are done", func(t *testing.T) {
+		var warmUp *account.WarmUp
+		assert.True(t, warmUp.IsDone())
+		assert.False(t, warmUp.HasStep(account.WarmUpStepCreatePrincipalRole))
+	})
+}
@@ -0,0 +1,218 @@
+// Package accountfactory creates new AWS accounts for the account pool, with
+// AWS Organizations or the Control Tower Account Factory.
+package accountfactory
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/servicecatalog/servicecatalogiface"
+)
+
+// Driver names, used by the ACCOUNT_FACTORY_DRIVER setting
+const (
+	DriverOrganizations = "organizations"
+	DriverControlTower  = "controltower"
+)
+
+// Default admin roles, which the driver creates in new accounts
+const (
+	organizationsAdminRoleName = "OrganizationAccountAccessRole"
+	controlTowerAdminRoleName  = "AWSControlTowerExecution"
+)
+
+// RequestState is the state of an account request
+type RequestState string
+
+// Account request states
+const (
+	RequestInProgress RequestState = "InProgress"
+	RequestSucceeded  RequestState = "Succeeded"
+	RequestFailed     RequestState = "Failed"
+)
+
+// Request is a request to create an account
+type Request struct {
+	Name  string
+	State RequestState
+	// AccountID is set once the account is created
+	AccountID string
+	// Reason is why the request failed
+	Reason      string
+	CompletedOn time.Time
+}
+
+// Driver creates AWS accounts
+type Driver interface {
+	// CreateAccount starts creating an account. Accounts are created
+	// asynchronously, and tracked with ListRequests
+	CreateAccount(name string, email string) error
+	// ListRequests lists the requests for accounts with names starting
+	// with the prefix
+	ListRequests(prefix string) ([]*Request, error)
+}
+
+// NewServiceInput are the items needed to create a new account factory service
+type NewServiceInput struct {
+	// DriverName is how accounts are created, either "organizations" or
+	// "controltower". Creating accounts is disabled when empty
+	DriverName string `env:"ACCOUNT_FACTORY_DRIVER" envDefault:""`
+	// MinReadyAccounts is the number of accounts to keep in the pool,
+	// including accounts which are being created or reset
+	MinReadyAccounts int `env:"ACCOUNT_FACTORY_MIN_READY_ACCOUNTS" envDefault:"0"`
+	// MaxAccountsPerRun limits how many accounts are requested at once
+	MaxAccountsPerRun int `env:"ACCOUNT_FACTORY_MAX_ACCOUNTS_PER_RUN" envDefault:"5"`
+	// NamePrefix is added to the names of new accounts, and identifies the
+	// accounts requested by DCE. Defaults to "dce-<namespace>-"
+	NamePrefix string `env:"ACCOUNT_FACTORY_NAME_PREFIX" envDefault:""`
+	// Email is the email address of new accounts. The account name is
+	// added to it, eg. "aws+dce-prod-20200102-150405-1@example.com"
+	Email string `env:"ACCOUNT_FACTORY_EMAIL" envDefault:""`
+	// AdminRoleName is the role DCE uses to manage new accounts. Defaults to
+	// the role created by the driver
+	AdminRoleName string `env:"ACCOUNT_FACTORY_ADMIN_ROLE_NAME" envDefault:""`
+	// ProductID and ProvisioningArtifactID are the Control Tower Account
+	// Factory product in Service Catalog
+	ProductID              string `env:"ACCOUNT_FACTORY_PRODUCT_ID" envDefault:""`
+	ProvisioningArtifactID string `env:"ACCOUNT_FACTORY_PROVISIONING_ARTIFACT_ID" envDefault:""`
+	// OrganizationalUnit is the Control Tower managed OU new accounts are
+	// enrolled in
+	OrganizationalUnit string `env:"ACCOUNT_FACTORY_ORGANIZATIONAL_UNIT" envDefault:""`
+	Namespace          string `env:"NAMESPACE" envDefault:"dce"`
+	Organizations      organizationsiface.OrganizationsAPI
+	ServiceCatalog     servicecatalogiface.ServiceCatalogAPI
+	// Driver is optional, and overrides DriverName
+	Driver Driver
+}
+
+// Service creates accounts for the account pool
+type Service struct {
+	driver            Driver
+	minReadyAccounts  int
+	maxAccountsPerRun int
+	namePrefix        string
+	email             string
+	adminRoleName     string
+	now               func() time.Time
+}
+
+// Enabled is true when an account factory is configured
+func (s *Service) Enabled() bool {
+	return s.driver != nil
+}
+
+// MinReadyAccounts is the number of accounts to keep in the pool
+func (s *Service) MinReadyAccounts() int {
+	return s.minReadyAccounts
+}
+
+// ListRequests lists the requests for accounts created by DCE
+func (s *Service) ListRequests() ([]*Request, error) {
+	if s.driver == nil {
+		return []*Request{}, nil
+	}
+	requests, err := s.driver.ListRequests(s.namePrefix)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to list account requests", err)
+	}
+	return requests, nil
+}
+
+// CreateAccounts requests new accounts, up to the maximum accounts per run.
+// Returns the names of the requested accounts.
+func (s *Service) CreateAccounts(count int) ([]string, error) {
+	names := []string{}
+	if s.driver == nil {
+		return names, nil
+	}
+	if count > s.maxAccountsPerRun {
+		count = s.maxAccountsPerRun
+	}
+
+	timestamp := s.now().UTC().Format("20060102-150405")
+	for i := 1; i <= count; i++ {
+		name := fmt.Sprintf("%s%s-%d", s.namePrefix, timestamp, i)
+		err := s.driver.CreateAccount(name, s.accountEmail(name))
+		if err != nil {
+			return names, errors.NewInternalServer(fmt.Sprintf("failed to create account %s", name), err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// AdminRoleArn gets the admin role of an account created by the factory
+func (s *Service) AdminRoleArn(accountID string) *arn.ARN {
+	return arn.New("aws", "iam", "", accountID, "role/"+s.adminRoleName)
+}
+
+// accountEmail adds the account name to the email address, so each
+// account has a unique address
+func (s *Service) accountEmail(name string) string {
+	at := strings.LastIndex(s.email, "@")
+	return s.email[:at] + "+" + name + s.email[at:]
+}
+
+// NewService creates a new account factory service
+func NewService(input NewServiceInput) (*Service, error) {
+	driver := input.Driver
+	adminRoleName := input.AdminRoleName
+	if driver == nil && input.DriverName != "" {
+		switch strings.ToLower(input.DriverName) {
+		case DriverOrganizations:
+			if adminRoleName == "" {
+				adminRoleName = organizationsAdminRoleName
+			}
+			driver = NewOrganizations(input.Organizations, adminRoleName)
+		case DriverControlTower:
+			if input.ProductID == "" || input.ProvisioningArtifactID == "" || input.OrganizationalUnit == "" {
+				return nil, errors.NewValidation("accountfactory", fmt.Errorf("a product ID, provisioning artifact ID and organizational unit are required for the controltower driver"))
+			}
+			driver = NewControlTower(ControlTowerInput{
+				ServiceCatalog:         input.ServiceCatalog,
+				ProductID:              input.ProductID,
+				ProvisioningArtifactID: input.ProvisioningArtifactID,
+				OrganizationalUnit:     input.OrganizationalUnit,
+			})
+			if adminRoleName == "" {
+				adminRoleName = controlTowerAdminRoleName
+			}
+		default:
+			return nil, errors.NewValidation("accountfactory", fmt.Errorf("unknown account factory driver %q", input.DriverName))
+		}
+	}
+
+	if driver != nil {
+		if input.MinReadyAccounts <= 0 {
+			return nil, errors.NewValidation("accountfactory", fmt.Errorf("a minimum number of ready accounts is required"))
+		}
+		if input.MaxAccountsPerRun <= 0 {
+			return nil, errors.NewValidation("accountfactory", fmt.Errorf("the maximum accounts per run must be positive"))
+		}
+		if !strings.Contains(input.Email, "@") {
+			return nil, errors.NewValidation("accountfactory", fmt.Errorf("an email address is required for new accounts"))
+		}
+		if adminRoleName == "" {
+			return nil, errors.NewValidation("accountfactory", fmt.Errorf("an admin role name is required for new accounts"))
+		}
+	}
+
+	namePrefix := input.NamePrefix
+	if namePrefix == "" {
+		namePrefix = "dce-" + input.Namespace + "-"
+	}
+
+	return &Service{
+		driver:            driver,
+		minReadyAccounts:  input.MinReadyAccounts,
+		maxAccountsPerRun: input.MaxAccountsPerRun,
+		namePrefix:        namePrefix,
+		email:             input.Email,
+		adminRoleName:     adminRoleName,
+		now:               time.Now,
+	}, nil
+}
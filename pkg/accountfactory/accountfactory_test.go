@@ -0,0 +1,127 @@
+package accountfactory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDriver struct {
+	created  map[string]string
+	requests []*Request
+	err      error
+}
+
+func (d *testDriver) CreateAccount(name string, email string) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.created[name] = email
+	return nil
+}
+
+func (d *testDriver) ListRequests(prefix string) ([]*Request, error) {
+	return d.requests, d.err
+}
+
+func newTestService(t *testing.T, driver *testDriver) *Service {
+	svc, err := NewService(NewServiceInput{
+		MinReadyAccounts:  10,
+		MaxAccountsPerRun: 2,
+		Email:             "aws@example.com",
+		AdminRoleName:     "DCEAdmin",
+		Namespace:         "prod",
+		Driver:            driver,
+	})
+	assert.Nil(t, err)
+	svc.now = func() time.Time {
+		return time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	}
+	return svc
+}
+
+func TestCreateAccounts(t *testing.T) {
+	driver := &testDriver{created: map[string]string{}}
+	svc := newTestService(t, driver)
+	assert.True(t, svc.Enabled())
+	assert.Equal(t, 10, svc.MinReadyAccounts())
+
+	names, err := svc.CreateAccounts(5)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dce-prod-20200102-150405-1", "dce-prod-20200102-150405-2"}, names)
+	assert.Equal(t, map[string]string{
+		"dce-prod-20200102-150405-1": "aws+dce-prod-20200102-150405-1@example.com",
+		"dce-prod-20200102-150405-2": "aws+dce-prod-20200102-150405-2@example.com",
+	}, driver.created)
+
+	svc = newTestService(t, &testDriver{err: fmt.Errorf("limit exceeded")})
+	_, err = svc.CreateAccounts(1)
+	assert.EqualError(t, err, "failed to create account dce-prod-20200102-150405-1")
+}
+
+func TestListRequests(t *testing.T) {
+	requests := []*Request{{Name: "dce-prod-1", State: RequestInProgress}}
+	svc := newTestService(t, &testDriver{requests: requests})
+	found, err := svc.ListRequests()
+	assert.Nil(t, err)
+	assert.Equal(t, requests, found)
+
+	svc = newTestService(t, &testDriver{err: fmt.Errorf("access denied")})
+	_, err = svc.ListRequests()
+	assert.EqualError(t, err, "failed to list account requests")
+}
+
+func TestAdminRoleArn(t *testing.T) {
+	svc := newTestService(t, &testDriver{})
+	assert.Equal(t, "arn:aws:iam::123456789012:role/DCEAdmin", svc.AdminRoleArn("123456789012").String())
+
+	svc, err := NewService(NewServiceInput{DriverName: "controltower", MinReadyAccounts: 1, MaxAccountsPerRun: 1,
+		Email: "aws@example.com", ProductID: "prod-1", ProvisioningArtifactID: "pa-1", OrganizationalUnit: "Sandbox"})
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/AWSControlTowerExecution", svc.AdminRoleArn("123456789012").String())
+}
+
+func TestNewService(t *testing.T) {
+	svc, err := NewService(NewServiceInput{})
+	assert.Nil(t, err)
+	assert.False(t, svc.Enabled())
+	names, err := svc.CreateAccounts(1)
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+
+	tests := []struct {
+		name   string
+		input  NewServiceInput
+		expErr string
+	}{
+		{
+			name:   "should fail on an unknown driver",
+			input:  NewServiceInput{DriverName: "nope"},
+			expErr: `accountfactory validation error: unknown account factory driver "nope"`,
+		},
+		{
+			name:   "should require the Account Factory product",
+			input:  NewServiceInput{DriverName: "controltower", ProductID: "prod-1"},
+			expErr: "accountfactory validation error: a product ID, provisioning artifact ID and organizational unit are required for the controltower driver",
+		},
+		{
+			name:   "should require a minimum number of ready accounts",
+			input:  NewServiceInput{DriverName: "organizations", MaxAccountsPerRun: 1, Email: "aws@example.com"},
+			expErr: "accountfactory validation error: a minimum number of ready accounts is required",
+		},
+		{
+			name:   "should require an email address",
+			input:  NewServiceInput{DriverName: "organizations", MinReadyAccounts: 1, MaxAccountsPerRun: 1},
+			expErr: "accountfactory validation error: an email address is required for new accounts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(tt.input)
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+//
+
+package accountfactoryiface
+
+import (
+	"github.com/Optum/dce/pkg/accountfactory"
+	"github.com/Optum/dce/pkg/arn"
+)
+
+// Servicer creates accounts for the account pool
+type Servicer interface {
+	// Enabled is true when an account factory is configured
+	Enabled() bool
+	// MinReadyAccounts is the number of accounts to keep in the pool
+	MinReadyAccounts() int
+	// ListRequests lists the requests for accounts created by DCE
+	ListRequests() ([]*accountfactory.Request, error)
+	// CreateAccounts requests new accounts, up to the maximum accounts per run
+	CreateAccounts(count int) ([]string, error)
+	// AdminRoleArn gets the admin role of an account created by the factory
+	AdminRoleArn(accountID string) *arn.ARN
+}
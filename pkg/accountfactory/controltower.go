@@ -0,0 +1,120 @@
+package accountfactory
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/servicecatalog"
+	"github.com/aws/aws-sdk-go/service/servicecatalog/servicecatalogiface"
+)
+
+// accountIDOutput is the Account Factory product output with the account ID
+const accountIDOutput = "AccountId"
+
+// ControlTowerInput configures the Control Tower driver
+type ControlTowerInput struct {
+	ServiceCatalog servicecatalogiface.ServiceCatalogAPI
+	// ProductID and ProvisioningArtifactID are the Account Factory product
+	ProductID              string
+	ProvisioningArtifactID string
+	// OrganizationalUnit is the Control Tower managed OU new accounts are
+	// enrolled in
+	OrganizationalUnit string
+}
+
+// ControlTower creates and enrolls accounts by provisioning the Control Tower
+// Account Factory product in Service Catalog
+type ControlTower struct {
+	config ControlTowerInput
+}
+
+// NewControlTower creates a new Control Tower driver
+func NewControlTower(input ControlTowerInput) *ControlTower {
+	return &ControlTower{config: input}
+}
+
+// CreateAccount provisions an Account Factory product for the account
+func (c *ControlTower) CreateAccount(name string, email string) error {
+	_, err := c.config.ServiceCatalog.ProvisionProduct(&servicecatalog.ProvisionProductInput{
+		ProductId:              aws.String(c.config.ProductID),
+		ProvisioningArtifactId: aws.String(c.config.ProvisioningArtifactID),
+		ProvisionedProductName: aws.String(name),
+		ProvisionToken:         aws.String(name),
+		ProvisioningParameters: []*servicecatalog.ProvisioningParameter{
+			{Key: aws.String("AccountName"), Value: aws.String(name)},
+			{Key: aws.String("AccountEmail"), Value: aws.String(email)},
+			{Key: aws.String("ManagedOrganizationalUnit"), Value: aws.String(c.config.OrganizationalUnit)},
+			{Key: aws.String("SSOUserEmail"), Value: aws.String(email)},
+			{Key: aws.String("SSOUserFirstName"), Value: aws.String("DCE")},
+			{Key: aws.String("SSOUserLastName"), Value: aws.String(name)},
+		},
+	})
+	return err
+}
+
+// ListRequests lists the provisioned Account Factory products
+func (c *ControlTower) ListRequests(prefix string) ([]*Request, error) {
+	requests := []*Request{}
+	input := &servicecatalog.SearchProvisionedProductsInput{
+		AccessLevelFilter: &servicecatalog.AccessLevelFilter{
+			Key:   aws.String(servicecatalog.AccessLevelFilterKeyAccount),
+			Value: aws.String("self"),
+		},
+		Filters: map[string][]*string{
+			servicecatalog.ProvisionedProductViewFilterBySearchQuery: {aws.String("productId:" + c.config.ProductID)},
+		},
+	}
+	for {
+		page, err := c.config.ServiceCatalog.SearchProvisionedProducts(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range page.ProvisionedProducts {
+			name := aws.StringValue(product.Name)
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			request := &Request{Name: name}
+			switch aws.StringValue(product.Status) {
+			case servicecatalog.ProvisionedProductStatusAvailable:
+				request.State = RequestSucceeded
+				err = c.getAccount(aws.StringValue(product.LastRecordId), request)
+				if err != nil {
+					return nil, err
+				}
+			case servicecatalog.ProvisionedProductStatusError, servicecatalog.ProvisionedProductStatusTainted:
+				request.State = RequestFailed
+				request.Reason = aws.StringValue(product.StatusMessage)
+			default:
+				request.State = RequestInProgress
+			}
+			requests = append(requests, request)
+		}
+
+		if aws.StringValue(page.NextPageToken) == "" {
+			return requests, nil
+		}
+		input.PageToken = page.NextPageToken
+	}
+}
+
+// getAccount gets the account ID from the outputs of the last
+// provisioning record
+func (c *ControlTower) getAccount(recordID string, request *Request) error {
+	record, err := c.config.ServiceCatalog.DescribeRecord(&servicecatalog.DescribeRecordInput{
+		Id: aws.String(recordID),
+	})
+	if err != nil {
+		return err
+	}
+	if record.RecordDetail != nil && record.RecordDetail.UpdatedTime != nil {
+		request.CompletedOn = *record.RecordDetail.UpdatedTime
+	}
+	for _, output := range record.RecordOutputs {
+		if aws.StringValue(output.OutputKey) == accountIDOutput {
+			request.AccountID = aws.StringValue(output.OutputValue)
+		}
+	}
+	return nil
+}
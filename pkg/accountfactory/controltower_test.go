@@ -0,0 +1,79 @@
+package accountfactory
+
+import (
+	"testing"
+	"time"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/servicecatalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestControlTower(client *awsMocks.ServiceCatalogAPI) *ControlTower {
+	return NewControlTower(ControlTowerInput{
+		ServiceCatalog:         client,
+		ProductID:              "prod-abc",
+		ProvisioningArtifactID: "pa-abc",
+		OrganizationalUnit:     "Sandbox",
+	})
+}
+
+func TestControlTowerCreateAccount(t *testing.T) {
+	client := &awsMocks.ServiceCatalogAPI{}
+	client.On("ProvisionProduct", mock.MatchedBy(func(input *servicecatalog.ProvisionProductInput) bool {
+		params := map[string]string{}
+		for _, p := range input.ProvisioningParameters {
+			params[*p.Key] = *p.Value
+		}
+		return *input.ProductId == "prod-abc" &&
+			*input.ProvisioningArtifactId == "pa-abc" &&
+			*input.ProvisionedProductName == "dce-prod-1" &&
+			params["AccountName"] == "dce-prod-1" &&
+			params["AccountEmail"] == "aws+dce-prod-1@example.com" &&
+			params["ManagedOrganizationalUnit"] == "Sandbox"
+	})).Return(&servicecatalog.ProvisionProductOutput{}, nil)
+
+	err := newTestControlTower(client).CreateAccount("dce-prod-1", "aws+dce-prod-1@example.com")
+	assert.Nil(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestControlTowerListRequests(t *testing.T) {
+	updated := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	client := &awsMocks.ServiceCatalogAPI{}
+	client.On("SearchProvisionedProducts", mock.MatchedBy(func(input *servicecatalog.SearchProvisionedProductsInput) bool {
+		return input.PageToken == nil && *input.Filters["SearchQuery"][0] == "productId:prod-abc"
+	})).Return(&servicecatalog.SearchProvisionedProductsOutput{
+		ProvisionedProducts: []*servicecatalog.ProvisionedProductAttribute{
+			{Name: aws.String("dce-prod-1"), Status: aws.String("UNDER_CHANGE")},
+			{Name: aws.String("dce-prod-2"), Status: aws.String("AVAILABLE"), LastRecordId: aws.String("rec-2")},
+			{Name: aws.String("someone-else"), Status: aws.String("AVAILABLE")},
+		},
+		NextPageToken: aws.String("page-2"),
+	}, nil)
+	client.On("SearchProvisionedProducts", mock.MatchedBy(func(input *servicecatalog.SearchProvisionedProductsInput) bool {
+		return aws.StringValue(input.PageToken) == "page-2"
+	})).Return(&servicecatalog.SearchProvisionedProductsOutput{
+		ProvisionedProducts: []*servicecatalog.ProvisionedProductAttribute{
+			{Name: aws.String("dce-prod-3"), Status: aws.String("ERROR"), StatusMessage: aws.String("Email in use")},
+		},
+	}, nil)
+	client.On("DescribeRecord", &servicecatalog.DescribeRecordInput{Id: aws.String("rec-2")}).
+		Return(&servicecatalog.DescribeRecordOutput{
+			RecordDetail: &servicecatalog.RecordDetail{UpdatedTime: &updated},
+			RecordOutputs: []*servicecatalog.RecordOutput{
+				{OutputKey: aws.String("AccountEmail"), OutputValue: aws.String("aws+dce-prod-2@example.com")},
+				{OutputKey: aws.String("AccountId"), OutputValue: aws.String("123456789012")},
+			},
+		}, nil)
+
+	requests, err := newTestControlTower(client).ListRequests("dce-prod-")
+	assert.Nil(t, err)
+	assert.Equal(t, []*Request{
+		{Name: "dce-prod-1", State: RequestInProgress},
+		{Name: "dce-prod-2", State: RequestSucceeded, AccountID: "123456789012", CompletedOn: updated},
+		{Name: "dce-prod-3", State: RequestFailed, Reason: "Email in use"},
+	}, requests)
+}
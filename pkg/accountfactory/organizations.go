@@ -0,0 +1,98 @@
+package accountfactory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+)
+
+// Organizations creates accounts with AWS Organizations CreateAccount.
+// Accounts are created in the root of the organization.
+type Organizations struct {
+	client        organizationsiface.OrganizationsAPI
+	adminRoleName string
+}
+
+// NewOrganizations creates a new Organizations driver. The admin role is
+// created in new accounts, and trusts the organization's management account.
+func NewOrganizations(client organizationsiface.OrganizationsAPI, adminRoleName string) *Organizations {
+	return &Organizations{
+		client:        client,
+		adminRoleName: adminRoleName,
+	}
+}
+
+// CreateAccount starts creating an account in the organization
+func (o *Organizations) CreateAccount(name string, email string) error {
+	_, err := o.client.CreateAccount(&organizations.CreateAccountInput{
+		AccountName:            aws.String(name),
+		Email:                  aws.String(email),
+		RoleName:               aws.String(o.adminRoleName),
+		IamUserAccessToBilling: aws.String(organizations.IAMUserAccessToBillingDeny),
+	})
+	return err
+}
+
+// ListRequests lists the create account requests from the last 90 days
+func (o *Organizations) ListRequests(prefix string) ([]*Request, error) {
+	requests := []*Request{}
+	err := o.client.ListCreateAccountStatusPages(&organizations.ListCreateAccountStatusInput{},
+		func(page *organizations.ListCreateAccountStatusOutput, lastPage bool) bool {
+			for _, status := range page.CreateAccountStatuses {
+				name := aws.StringValue(status.AccountName)
+				if !strings.HasPrefix(name, prefix) {
+					continue
+				}
+				request := &Request{
+					Name:      name,
+					AccountID: aws.StringValue(status.AccountId),
+					Reason:    aws.StringValue(status.FailureReason),
+				}
+				switch aws.StringValue(status.State) {
+				case organizations.CreateAccountStateSucceeded:
+					request.State = RequestSucceeded
+				case organizations.CreateAccountStateFailed:
+					request.State = RequestFailed
+				default:
+					request.State = RequestInProgress
+				}
+				if status.CompletedTimestamp != nil {
+					request.CompletedOn = *status.CompletedTimestamp
+				}
+				requests = append(requests, request)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// MoveAccount moves an account to the organizational unit or root, from
+// whichever it's in.  Accounts already in it aren't moved.
+func (o *Organizations) MoveAccount(accountID string, parentID string) error {
+	parents, err := o.client.ListParents(&organizations.ListParentsInput{
+		ChildId: aws.String(accountID),
+	})
+	if err != nil {
+		return err
+	}
+	if len(parents.Parents) == 0 {
+		return fmt.Errorf("account %s isn't in the organization", accountID)
+	}
+	sourceID := aws.StringValue(parents.Parents[0].Id)
+	if sourceID == parentID {
+		return nil
+	}
+
+	_, err = o.client.MoveAccount(&organizations.MoveAccountInput{
+		AccountId:           aws.String(accountID),
+		SourceParentId:      aws.String(sourceID),
+		DestinationParentId: aws.String(parentID),
+	})
+	return err
+}
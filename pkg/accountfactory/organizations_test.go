@@ -0,0 +1,100 @@
+package accountfactory
+
+import (
+	"testing"
+	"time"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganizationsCreateAccount(t *testing.T) {
+	client := &awsMocks.OrganizationsAPI{}
+	client.On("CreateAccount", &organizations.CreateAccountInput{
+		AccountName:            aws.String("dce-prod-1"),
+		Email:                  aws.String("aws+dce-prod-1@example.com"),
+		RoleName:               aws.String("OrganizationAccountAccessRole"),
+		IamUserAccessToBilling: aws.String("DENY"),
+	}).Return(&organizations.CreateAccountOutput{}, nil)
+
+	err := NewOrganizations(client, "OrganizationAccountAccessRole").CreateAccount("dce-prod-1", "aws+dce-prod-1@example.com")
+	assert.Nil(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestOrganizationsListRequests(t *testing.T) {
+	completed := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	client := &awsMocks.OrganizationsAPI{}
+	client.On("ListCreateAccountStatusPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*organizations.ListCreateAccountStatusOutput, bool) bool)
+			fn(&organizations.ListCreateAccountStatusOutput{
+				CreateAccountStatuses: []*organizations.CreateAccountStatus{
+					{AccountName: aws.String("dce-prod-1"), State: aws.String("IN_PROGRESS")},
+					{AccountName: aws.String("dce-prod-2"), State: aws.String("SUCCEEDED"),
+						AccountId: aws.String("123456789012"), CompletedTimestamp: &completed},
+					{AccountName: aws.String("other-account"), State: aws.String("SUCCEEDED")},
+				},
+			}, false)
+			fn(&organizations.ListCreateAccountStatusOutput{
+				CreateAccountStatuses: []*organizations.CreateAccountStatus{
+					{AccountName: aws.String("dce-prod-3"), State: aws.String("FAILED"),
+						FailureReason: aws.String("EMAIL_ALREADY_EXISTS")},
+				},
+			}, true)
+		}).
+		Return(nil)
+
+	requests, err := NewOrganizations(client, "").ListRequests("dce-prod-")
+	assert.Nil(t, err)
+	assert.Equal(t, []*Request{
+		{Name: "dce-prod-1", State: RequestInProgress},
+		{Name: "dce-prod-2", State: RequestSucceeded, AccountID: "123456789012", CompletedOn: completed},
+		{Name: "dce-prod-3", State: RequestFailed, Reason: "EMAIL_ALREADY_EXISTS"},
+	}, requests)
+}
+
+func TestOrganizationsMoveAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		parentID string
+		expMove  bool
+	}{
+		{
+			name:     "should move the account to another organizational unit",
+			parentID: "ou-ab12-cdef3456",
+			expMove:  true,
+		},
+		{
+			name:     "should not move the account when it's in the organizational unit",
+			parentID: "ou-ab12-11111111",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &awsMocks.OrganizationsAPI{}
+			client.On("ListParents", &organizations.ListParentsInput{
+				ChildId: aws.String("123456789012"),
+			}).Return(&organizations.ListParentsOutput{
+				Parents: []*organizations.Parent{{Id: aws.String("ou-ab12-11111111")}},
+			}, nil)
+			client.On("MoveAccount", &organizations.MoveAccountInput{
+				AccountId:           aws.String("123456789012"),
+				SourceParentId:      aws.String("ou-ab12-11111111"),
+				DestinationParentId: aws.String("ou-ab12-cdef3456"),
+			}).Return(&organizations.MoveAccountOutput{}, nil)
+
+			err := NewOrganizations(client, "").MoveAccount("123456789012", tt.parentID)
+			assert.Nil(t, err)
+			if tt.expMove {
+				client.AssertExpectations(t)
+			} else {
+				client.AssertNotCalled(t, "MoveAccount", mock.Anything)
+			}
+		})
+	}
+}
@@ -3,15 +3,44 @@
 package mocks
 
 import account "github.com/Optum/dce/pkg/account"
-
 import arn "github.com/Optum/dce/pkg/arn"
+import lease "github.com/Optum/dce/pkg/lease"
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 
 // Servicer is an autogenerated mock type for the Servicer type
 type Servicer struct {
 	mock.Mock
 }
 
+// DeleteLeaseBudget provides a mock function with given fields: _a0
+func (_m *Servicer) DeleteLeaseBudget(_a0 *account.Account) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLogAggregation provides a mock function with given fields: _a0
+func (_m *Servicer) DeleteLogAggregation(_a0 *account.Account) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePrincipalAccess provides a mock function with given fields: _a0
 func (_m *Servicer) DeletePrincipalAccess(_a0 *account.Account) error {
 	ret := _m.Called(_a0)
@@ -26,6 +55,62 @@ func (_m *Servicer) DeletePrincipalAccess(_a0 *account.Account) error {
 	return r0
 }
 
+// ProbeReadiness provides a mock function with given fields: _a0
+func (_m *Servicer) ProbeReadiness(_a0 *account.Account) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokePrincipalSessions provides a mock function with given fields: _a0, issuedBefore
+func (_m *Servicer) RevokePrincipalSessions(_a0 *account.Account, issuedBefore time.Time) error {
+	ret := _m.Called(_a0, issuedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account, time.Time) error); ok {
+		r0 = rf(_a0, issuedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertLeaseBudget provides a mock function with given fields: _a0, _a1
+func (_m *Servicer) UpsertLeaseBudget(_a0 *account.Account, _a1 *lease.Lease) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account, *lease.Lease) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertLogAggregation provides a mock function with given fields: _a0, leaseID
+func (_m *Servicer) UpsertLogAggregation(_a0 *account.Account, leaseID string) error {
+	ret := _m.Called(_a0, leaseID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account, string) error); ok {
+		r0 = rf(_a0, leaseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertPrincipalAccess provides a mock function with given fields: _a0
 func (_m *Servicer) UpsertPrincipalAccess(_a0 *account.Account) error {
 	ret := _m.Called(_a0)
@@ -53,3 +138,17 @@ func (_m *Servicer) ValidateAccess(role *arn.ARN) error {
 
 	return r0
 }
+
+// ValidatePolicyCustomization provides a mock function with given fields: data
+func (_m *Servicer) ValidatePolicyCustomization(data *account.PolicyCustomization) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.PolicyCustomization) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
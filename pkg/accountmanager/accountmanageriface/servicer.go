@@ -3,8 +3,11 @@
 package accountmanageriface
 
 import (
+	"time"
+
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/lease"
 )
 
 // Servicer makes working with the Account Manager easier
@@ -13,6 +16,26 @@ type Servicer interface {
 	ValidateAccess(role *arn.ARN) error
 	// UpsertPrincipalAccess creates roles, policies and update them as needed
 	UpsertPrincipalAccess(account *account.Account) error
+	// ValidatePolicyCustomization checks a customization of the principal
+	// policy only adds and removes allowlisted statements
+	ValidatePolicyCustomization(data *account.PolicyCustomization) error
 	// DeletePrincipalAccess removes all the principal roles and policies
 	DeletePrincipalAccess(account *account.Account) error
+	// UpsertLogAggregation configures the account to ship its logs to the
+	// logging account during the lease
+	UpsertLogAggregation(account *account.Account, leaseID string) error
+	// DeleteLogAggregation stops the account shipping its logs to the
+	// logging account
+	DeleteLogAggregation(account *account.Account) error
+	// UpsertLeaseBudget creates a budget in the leased account mirroring
+	// the lease budget
+	UpsertLeaseBudget(account *account.Account, lease *lease.Lease) error
+	// DeleteLeaseBudget removes the lease budget from the account
+	DeleteLeaseBudget(account *account.Account) error
+	// RevokePrincipalSessions denies the principal role sessions issued
+	// before the given time
+	RevokePrincipalSessions(account *account.Account, issuedBefore time.Time) error
+	// ProbeReadiness checks the account's roles can be assumed, before it's
+	// leased
+	ProbeReadiness(account *account.Account) error
 }
@@ -0,0 +1,153 @@
+package accountmanager
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/budgets"
+	"github.com/aws/aws-sdk-go/service/budgets/budgetsiface"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// budgetCurrency is the only currency AWS Budgets tracks costs in
+const budgetCurrency = "USD"
+
+// maxBudgetSubscribers is the most subscribers AWS Budgets allows for each
+// notification
+const maxBudgetSubscribers = 10
+
+// UpsertLeaseBudget creates a budget in the leased account mirroring the
+// lease budget, so the principal can see their limit in the account.  The
+// budget alerts the lease's notification emails at each of the budget
+// notification thresholds.  A budget left over from an earlier lease is
+// replaced.  Leases budgeted in a currency other than USD are skipped, as
+// AWS Budgets only tracks USD.
+func (s *Service) UpsertLeaseBudget(account *account.Account, lease *lease.Lease) error {
+	if !s.config.LeaseBudgetAlarmsEnabled {
+		return nil
+	}
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.ID, validation.NotNil),
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+	err = validation.ValidateStruct(lease,
+		validation.Field(&lease.BudgetAmount, validation.NotNil),
+		validation.Field(&lease.ExpiresOn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("lease", err)
+	}
+	currency := aws.StringValue(lease.BudgetCurrency)
+	if currency != "" && currency != budgetCurrency {
+		log.Printf("Not creating a budget in account %s for a lease budgeted in %s", *account.ID, currency)
+		return nil
+	}
+
+	budgetsSvc := s.client.Budgets(account.AdminRoleArn)
+	input := &budgets.CreateBudgetInput{
+		AccountId: account.ID,
+		Budget: &budgets.Budget{
+			BudgetName: aws.String(s.config.LeaseBudgetAlarmName),
+			BudgetType: aws.String(budgets.BudgetTypeCost),
+			BudgetLimit: &budgets.Spend{
+				Amount: aws.String(strconv.FormatFloat(*lease.BudgetAmount, 'f', 2, 64)),
+				Unit:   aws.String(budgetCurrency),
+			},
+			// The lease is tracked as a single period, which ends with it
+			TimeUnit: aws.String(budgets.TimeUnitAnnually),
+			TimePeriod: &budgets.TimePeriod{
+				Start: aws.Time(time.Unix(aws.Int64Value(lease.CreatedOn), 0)),
+				End:   aws.Time(time.Unix(*lease.ExpiresOn, 0)),
+			},
+		},
+		NotificationsWithSubscribers: s.budgetNotifications(lease),
+	}
+
+	_, err = budgetsSvc.CreateBudget(input)
+	if isAWSErrorCode(err, budgets.ErrCodeDuplicateRecordException) {
+		err = s.deleteLeaseBudget(budgetsSvc, *account.ID)
+		if err == nil {
+			_, err = budgetsSvc.CreateBudget(input)
+		}
+	}
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to create the lease budget of account %q", *account.ID), err)
+	}
+	return nil
+}
+
+// DeleteLeaseBudget removes the budget created by UpsertLeaseBudget
+func (s *Service) DeleteLeaseBudget(account *account.Account) error {
+	if !s.config.LeaseBudgetAlarmsEnabled {
+		return nil
+	}
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.ID, validation.NotNil),
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+
+	err = s.deleteLeaseBudget(s.client.Budgets(account.AdminRoleArn), *account.ID)
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to delete the lease budget of account %q", *account.ID), err)
+	}
+	return nil
+}
+
+func (s *Service) deleteLeaseBudget(budgetsSvc budgetsiface.BudgetsAPI, accountID string) error {
+	_, err := budgetsSvc.DeleteBudget(&budgets.DeleteBudgetInput{
+		AccountId:  aws.String(accountID),
+		BudgetName: aws.String(s.config.LeaseBudgetAlarmName),
+	})
+	if err != nil && !isAWSErrorCode(err, budgets.ErrCodeNotFoundException) {
+		return err
+	}
+	return nil
+}
+
+// budgetNotifications alerts the lease's notification emails when the
+// actual spend passes each threshold
+func (s *Service) budgetNotifications(lease *lease.Lease) []*budgets.NotificationWithSubscribers {
+	subscribers := []*budgets.Subscriber{}
+	if lease.BudgetNotificationEmails != nil {
+		for _, email := range *lease.BudgetNotificationEmails {
+			if len(subscribers) == maxBudgetSubscribers {
+				break
+			}
+			subscribers = append(subscribers, &budgets.Subscriber{
+				SubscriptionType: aws.String(budgets.SubscriptionTypeEmail),
+				Address:          aws.String(email),
+			})
+		}
+	}
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	notifications := []*budgets.NotificationWithSubscribers{}
+	for _, threshold := range s.config.LeaseBudgetAlarmThresholds {
+		notifications = append(notifications, &budgets.NotificationWithSubscribers{
+			Notification: &budgets.Notification{
+				NotificationType:   aws.String(budgets.NotificationTypeActual),
+				ComparisonOperator: aws.String(budgets.ComparisonOperatorGreaterThan),
+				Threshold:          aws.Float64(threshold),
+				ThresholdType:      aws.String(budgets.ThresholdTypePercentage),
+			},
+			Subscribers: subscribers,
+		})
+	}
+	return notifications
+}
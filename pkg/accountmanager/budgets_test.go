@@ -0,0 +1,170 @@
+package accountmanager
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountmanager/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/budgets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpsertLeaseBudget(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+
+	tests := []struct {
+		name       string
+		disabled   bool
+		currency   string
+		emails     []string
+		createErrs []error
+		expCreates int
+		expDeletes int
+		expNotifs  int
+		exp        error
+	}{
+		{
+			name:     "should do nothing when lease budgets are disabled",
+			disabled: true,
+		},
+		{
+			name:       "should create the budget with a notification for each threshold",
+			emails:     []string{"jdoe@example.com"},
+			createErrs: []error{nil},
+			expCreates: 1,
+			expNotifs:  2,
+		},
+		{
+			name:       "should create the budget without notifications when there are no emails",
+			currency:   "USD",
+			createErrs: []error{nil},
+			expCreates: 1,
+		},
+		{
+			name:     "should skip leases budgeted in another currency",
+			currency: "EUR",
+		},
+		{
+			name:   "should replace a budget left over from an earlier lease",
+			emails: []string{"jdoe@example.com"},
+			createErrs: []error{
+				awserr.New(budgets.ErrCodeDuplicateRecordException, "Duplicate", nil),
+				nil,
+			},
+			expCreates: 2,
+			expDeletes: 1,
+			expNotifs:  2,
+		},
+		{
+			name:       "should fail when the budget can't be created",
+			createErrs: []error{awserr.New(budgets.ErrCodeAccessDeniedException, "Denied", nil)},
+			expCreates: 1,
+			exp: errors.NewInternalServer("failed to create the lease budget of account \"123456789012\"",
+				awserr.New(budgets.ErrCodeAccessDeniedException, "Denied", nil)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budgetsSvc := &awsMocks.BudgetsAPI{}
+			for _, createErr := range tt.createErrs {
+				budgetsSvc.On("CreateBudget", mock.MatchedBy(func(input *budgets.CreateBudgetInput) bool {
+					return *input.AccountId == "123456789012" &&
+						*input.Budget.BudgetName == "dce-lease-budget" &&
+						*input.Budget.BudgetLimit.Amount == "100.00" &&
+						input.Budget.TimePeriod.End.Unix() == 1573592058 &&
+						len(input.NotificationsWithSubscribers) == tt.expNotifs
+				})).Return(&budgets.CreateBudgetOutput{}, createErr).Once()
+			}
+			budgetsSvc.On("DeleteBudget", &budgets.DeleteBudgetInput{
+				AccountId:  aws.String("123456789012"),
+				BudgetName: aws.String("dce-lease-budget"),
+			}).Return(&budgets.DeleteBudgetOutput{}, nil)
+
+			clientSvc := &mocks.Clienter{}
+			clientSvc.On("Budgets", adminRoleArn).Return(budgetsSvc)
+
+			amSvc := Service{
+				client: clientSvc,
+				config: ServiceConfig{
+					LeaseBudgetAlarmsEnabled:   !tt.disabled,
+					LeaseBudgetAlarmName:       "dce-lease-budget",
+					LeaseBudgetAlarmThresholds: []float64{75, 100},
+				},
+			}
+
+			err := amSvc.UpsertLeaseBudget(&account.Account{
+				ID:           aws.String("123456789012"),
+				AdminRoleArn: adminRoleArn,
+			}, &lease.Lease{
+				BudgetAmount:             aws.Float64(100),
+				BudgetCurrency:           aws.String(tt.currency),
+				BudgetNotificationEmails: &tt.emails,
+				CreatedOn:                aws.Int64(1573505658),
+				ExpiresOn:                aws.Int64(1573592058),
+			})
+			assert.True(t, errors.Is(err, tt.exp), "actual error %q doesn't match expected error %q", err, tt.exp)
+			budgetsSvc.AssertNumberOfCalls(t, "CreateBudget", tt.expCreates)
+			budgetsSvc.AssertNumberOfCalls(t, "DeleteBudget", tt.expDeletes)
+		})
+	}
+}
+
+func TestDeleteLeaseBudget(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+
+	tests := []struct {
+		name      string
+		deleteErr error
+		exp       error
+	}{
+		{
+			name: "should delete the budget",
+		},
+		{
+			name:      "should succeed when there's no budget",
+			deleteErr: awserr.New(budgets.ErrCodeNotFoundException, "Not Found", nil),
+		},
+		{
+			name:      "should fail when the budget can't be deleted",
+			deleteErr: awserr.New(budgets.ErrCodeInternalErrorException, "Internal", nil),
+			exp: errors.NewInternalServer("failed to delete the lease budget of account \"123456789012\"",
+				awserr.New(budgets.ErrCodeInternalErrorException, "Internal", nil)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budgetsSvc := &awsMocks.BudgetsAPI{}
+			budgetsSvc.On("DeleteBudget", &budgets.DeleteBudgetInput{
+				AccountId:  aws.String("123456789012"),
+				BudgetName: aws.String("dce-lease-budget"),
+			}).Return(&budgets.DeleteBudgetOutput{}, tt.deleteErr)
+
+			clientSvc := &mocks.Clienter{}
+			clientSvc.On("Budgets", adminRoleArn).Return(budgetsSvc)
+
+			amSvc := Service{
+				client: clientSvc,
+				config: ServiceConfig{
+					LeaseBudgetAlarmsEnabled: true,
+					LeaseBudgetAlarmName:     "dce-lease-budget",
+				},
+			}
+
+			err := amSvc.DeleteLeaseBudget(&account.Account{
+				ID:           aws.String("123456789012"),
+				AdminRoleArn: adminRoleArn,
+			})
+			assert.True(t, errors.Is(err, tt.exp), "actual error %q doesn't match expected error %q", err, tt.exp)
+			budgetsSvc.AssertExpectations(t)
+		})
+	}
+}
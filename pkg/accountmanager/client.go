@@ -3,6 +3,12 @@ package accountmanager
 import (
 	"github.com/Optum/dce/pkg/arn"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/budgets"
+	"github.com/aws/aws-sdk-go/service/budgets/budgetsiface"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
@@ -14,6 +20,9 @@ import (
 type clienter interface {
 	Config(roleArn *arn.ARN) *aws.Config
 	IAM(roleArn *arn.ARN) iamiface.IAMAPI
+	CloudTrail(roleArn *arn.ARN, region string) cloudtrailiface.CloudTrailAPI
+	CloudWatchEvents(roleArn *arn.ARN, region string) cloudwatcheventsiface.CloudWatchEventsAPI
+	Budgets(roleArn *arn.ARN) budgetsiface.BudgetsAPI
 }
 
 // Client helps with client management testing and abstraction
@@ -56,3 +65,19 @@ func (c *client) Config(roleArn *arn.ARN) *aws.Config {
 func (c *client) IAM(roleArn *arn.ARN) iamiface.IAMAPI {
 	return iam.New(c.session, c.Config(roleArn))
 }
+
+// CloudTrail creates a new CloudTrail Client in the region
+func (c *client) CloudTrail(roleArn *arn.ARN, region string) cloudtrailiface.CloudTrailAPI {
+	return cloudtrail.New(c.session, c.Config(roleArn), aws.NewConfig().WithRegion(region))
+}
+
+// CloudWatchEvents creates a new CloudWatch Events Client in the region
+func (c *client) CloudWatchEvents(roleArn *arn.ARN, region string) cloudwatcheventsiface.CloudWatchEventsAPI {
+	return cloudwatchevents.New(c.session, c.Config(roleArn), aws.NewConfig().WithRegion(region))
+}
+
+// Budgets creates a new AWS Budgets Client.  Budgets is a global service,
+// with its endpoint in us-east-1.
+func (c *client) Budgets(roleArn *arn.ARN) budgetsiface.BudgetsAPI {
+	return budgets.New(c.session, c.Config(roleArn), aws.NewConfig().WithRegion("us-east-1"))
+}
@@ -28,3 +28,12 @@ func isAWSNoSuchEntityError(err error) bool {
 
 	return false
 }
+
+func isAWSErrorCode(err error, code string) bool {
+	aerr, ok := err.(awserr.Error)
+	if ok {
+		return aerr.Code() == code
+	}
+
+	return false
+}
@@ -209,6 +209,17 @@ func (p *principalService) buildPolicy() (*string, *string, error) {
 		return nil, nil, err
 	}
 
+	if !p.account.PrincipalPolicyCustomization.IsEmpty() {
+		allowlist, err := getPolicyAllowlist(p.storager, p.config)
+		if err != nil {
+			return nil, nil, err
+		}
+		policy, policyHash, err = customizePolicy(policy, policyHash, p.account.PrincipalPolicyCustomization, allowlist)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return &policy, &policyHash, nil
 }
 
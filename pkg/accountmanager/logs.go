@@ -0,0 +1,198 @@
+package accountmanager
+
+import (
+	"fmt"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const logAggregationTargetID = "dce-logging-account"
+
+// UpsertLogAggregation configures the account to ship its logs to the
+// logging account.  A multi-region trail delivers CloudTrail logs to
+// LogAggregationBucket, and a rule in each allowed region forwards CloudWatch
+// Events to LogAggregationEventBusArn.  Either is skipped when it isn't
+// configured.
+func (s *Service) UpsertLogAggregation(account *account.Account, leaseID string) error {
+	if !s.isLogAggregationEnabled() {
+		return nil
+	}
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.ID, validation.NotNil),
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+
+	if s.config.LogAggregationBucket != "" {
+		err = s.upsertTrail(account, leaseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.config.LogAggregationEventBusArn != "" {
+		for _, region := range s.config.AllowedRegions {
+			err = s.upsertEventForwarding(account, region)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteLogAggregation removes the trail and rules created by
+// UpsertLogAggregation, so the account stops shipping its logs
+func (s *Service) DeleteLogAggregation(account *account.Account) error {
+	if !s.isLogAggregationEnabled() {
+		return nil
+	}
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+
+	if s.config.LogAggregationBucket != "" {
+		trailSvc := s.client.CloudTrail(account.AdminRoleArn, s.trailRegion())
+		_, err = trailSvc.DeleteTrail(&cloudtrail.DeleteTrailInput{
+			Name: aws.String(s.config.LogAggregationName),
+		})
+		if err != nil && !isAWSErrorCode(err, cloudtrail.ErrCodeTrailNotFoundException) {
+			return errors.NewInternalServer("failed to delete the log aggregation trail", err)
+		}
+	}
+
+	if s.config.LogAggregationEventBusArn != "" {
+		for _, region := range s.config.AllowedRegions {
+			err = s.deleteEventForwarding(account, region)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) isLogAggregationEnabled() bool {
+	return s.config.LogAggregationBucket != "" || s.config.LogAggregationEventBusArn != ""
+}
+
+// trailRegion is the home region of the multi-region trail
+func (s *Service) trailRegion() string {
+	if len(s.config.AllowedRegions) == 0 {
+		return "us-east-1"
+	}
+	return s.config.AllowedRegions[0]
+}
+
+// upsertTrail creates the trail, or updates it when it's left over from an
+// earlier lease, and makes sure it's logging.  Logs are prefixed with the
+// lease ID, so they can be told apart from other leases of the account.
+func (s *Service) upsertTrail(account *account.Account, leaseID string) error {
+	trailSvc := s.client.CloudTrail(account.AdminRoleArn, s.trailRegion())
+
+	var prefix *string
+	if leaseID != "" {
+		prefix = aws.String(fmt.Sprintf("leases/%s", leaseID))
+	}
+
+	_, err := trailSvc.CreateTrail(&cloudtrail.CreateTrailInput{
+		Name:                       aws.String(s.config.LogAggregationName),
+		S3BucketName:               aws.String(s.config.LogAggregationBucket),
+		S3KeyPrefix:                prefix,
+		IsMultiRegionTrail:         aws.Bool(true),
+		IncludeGlobalServiceEvents: aws.Bool(true),
+		EnableLogFileValidation:    aws.Bool(true),
+	})
+	if isAWSErrorCode(err, cloudtrail.ErrCodeTrailAlreadyExistsException) {
+		_, err = trailSvc.UpdateTrail(&cloudtrail.UpdateTrailInput{
+			Name:                       aws.String(s.config.LogAggregationName),
+			S3BucketName:               aws.String(s.config.LogAggregationBucket),
+			S3KeyPrefix:                aws.String(aws.StringValue(prefix)),
+			IsMultiRegionTrail:         aws.Bool(true),
+			IncludeGlobalServiceEvents: aws.Bool(true),
+			EnableLogFileValidation:    aws.Bool(true),
+		})
+	}
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to configure the log aggregation trail of account %q", *account.ID), err)
+	}
+
+	_, err = trailSvc.StartLogging(&cloudtrail.StartLoggingInput{
+		Name: aws.String(s.config.LogAggregationName),
+	})
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to start the log aggregation trail of account %q", *account.ID), err)
+	}
+	return nil
+}
+
+// upsertEventForwarding forwards every event of the account in the region to
+// the logging account's event bus
+func (s *Service) upsertEventForwarding(account *account.Account, region string) error {
+	eventsSvc := s.client.CloudWatchEvents(account.AdminRoleArn, region)
+
+	_, err := eventsSvc.PutRule(&cloudwatchevents.PutRuleInput{
+		Name:         aws.String(s.config.LogAggregationName),
+		Description:  aws.String("Forwards events to the DCE logging account"),
+		EventPattern: aws.String(fmt.Sprintf(`{"account": [%q]}`, *account.ID)),
+		State:        aws.String(cloudwatchevents.RuleStateEnabled),
+	})
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to configure the log aggregation rule of account %q in %s", *account.ID, region), err)
+	}
+
+	out, err := eventsSvc.PutTargets(&cloudwatchevents.PutTargetsInput{
+		Rule: aws.String(s.config.LogAggregationName),
+		Targets: []*cloudwatchevents.Target{
+			{
+				Id:  aws.String(logAggregationTargetID),
+				Arn: aws.String(s.config.LogAggregationEventBusArn),
+			},
+		},
+	})
+	if err == nil && aws.Int64Value(out.FailedEntryCount) > 0 {
+		err = fmt.Errorf("%s: %s", aws.StringValue(out.FailedEntries[0].ErrorCode), aws.StringValue(out.FailedEntries[0].ErrorMessage))
+	}
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to configure the log aggregation target of account %q in %s", *account.ID, region), err)
+	}
+	return nil
+}
+
+// deleteEventForwarding removes the rule forwarding events in the region.
+// A rule's targets have to be removed before it can be deleted.
+func (s *Service) deleteEventForwarding(account *account.Account, region string) error {
+	eventsSvc := s.client.CloudWatchEvents(account.AdminRoleArn, region)
+
+	_, err := eventsSvc.RemoveTargets(&cloudwatchevents.RemoveTargetsInput{
+		Rule: aws.String(s.config.LogAggregationName),
+		Ids:  []*string{aws.String(logAggregationTargetID)},
+	})
+	if err != nil && !isAWSErrorCode(err, cloudwatchevents.ErrCodeResourceNotFoundException) {
+		return errors.NewInternalServer(fmt.Sprintf("failed to remove the log aggregation target in %s", region), err)
+	}
+
+	_, err = eventsSvc.DeleteRule(&cloudwatchevents.DeleteRuleInput{
+		Name: aws.String(s.config.LogAggregationName),
+	})
+	if err != nil && !isAWSErrorCode(err, cloudwatchevents.ErrCodeResourceNotFoundException) {
+		return errors.NewInternalServer(fmt.Sprintf("failed to delete the log aggregation rule in %s", region), err)
+	}
+	return nil
+}
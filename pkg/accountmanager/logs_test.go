@@ -0,0 +1,189 @@
+package accountmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountmanager/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpsertLogAggregation(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+
+	tests := []struct {
+		name         string
+		bucket       string
+		eventBusArn  string
+		createErr    error
+		putTargetOut *cloudwatchevents.PutTargetsOutput
+		exp          error
+	}{
+		{
+			name: "should do nothing when log aggregation is disabled",
+		},
+		{
+			name:   "should create the trail",
+			bucket: "central-logs",
+		},
+		{
+			name:      "should update the trail left over from an earlier lease",
+			bucket:    "central-logs",
+			createErr: awserr.New(cloudtrail.ErrCodeTrailAlreadyExistsException, "Already Exists", nil),
+		},
+		{
+			name:      "should fail when the trail can't be created",
+			bucket:    "central-logs",
+			createErr: awserr.New(cloudtrail.ErrCodeS3BucketDoesNotExistException, "No Bucket", nil),
+			exp: errors.NewInternalServer("failed to configure the log aggregation trail of account \"123456789012\"",
+				awserr.New(cloudtrail.ErrCodeS3BucketDoesNotExistException, "No Bucket", nil)),
+		},
+		{
+			name:         "should forward events in each region",
+			eventBusArn:  "arn:aws:events:us-east-1:999999999999:event-bus/default",
+			putTargetOut: &cloudwatchevents.PutTargetsOutput{FailedEntryCount: aws.Int64(0)},
+		},
+		{
+			name:        "should fail when events can't be forwarded",
+			eventBusArn: "arn:aws:events:us-east-1:999999999999:event-bus/default",
+			putTargetOut: &cloudwatchevents.PutTargetsOutput{
+				FailedEntryCount: aws.Int64(1),
+				FailedEntries: []*cloudwatchevents.PutTargetsResultEntry{
+					{ErrorCode: aws.String("AccessDenied"), ErrorMessage: aws.String("denied")},
+				},
+			},
+			exp: errors.NewInternalServer("failed to configure the log aggregation target of account \"123456789012\" in us-east-1",
+				fmt.Errorf("AccessDenied: denied")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trailSvc := &awsMocks.CloudTrailAPI{}
+			trailSvc.On("CreateTrail", mock.MatchedBy(func(input *cloudtrail.CreateTrailInput) bool {
+				return *input.Name == "dce-log-aggregation" &&
+					*input.S3BucketName == tt.bucket &&
+					*input.S3KeyPrefix == "leases/lease-1" &&
+					*input.IsMultiRegionTrail
+			})).Return(&cloudtrail.CreateTrailOutput{}, tt.createErr)
+			trailSvc.On("UpdateTrail", mock.AnythingOfType("*cloudtrail.UpdateTrailInput")).Return(&cloudtrail.UpdateTrailOutput{}, nil)
+			trailSvc.On("StartLogging", mock.AnythingOfType("*cloudtrail.StartLoggingInput")).Return(&cloudtrail.StartLoggingOutput{}, nil)
+
+			eventsSvc := &awsMocks.CloudWatchEventsAPI{}
+			eventsSvc.On("PutRule", mock.MatchedBy(func(input *cloudwatchevents.PutRuleInput) bool {
+				return *input.EventPattern == `{"account": ["123456789012"]}`
+			})).Return(&cloudwatchevents.PutRuleOutput{}, nil)
+			eventsSvc.On("PutTargets", mock.MatchedBy(func(input *cloudwatchevents.PutTargetsInput) bool {
+				return *input.Targets[0].Arn == tt.eventBusArn
+			})).Return(tt.putTargetOut, nil)
+
+			clientSvc := &mocks.Clienter{}
+			clientSvc.On("CloudTrail", adminRoleArn, "us-east-1").Return(trailSvc)
+			clientSvc.On("CloudWatchEvents", adminRoleArn, mock.Anything).Return(eventsSvc)
+
+			amSvc := Service{
+				client: clientSvc,
+				config: ServiceConfig{
+					AllowedRegions:            []string{"us-east-1", "us-west-2"},
+					LogAggregationBucket:      tt.bucket,
+					LogAggregationEventBusArn: tt.eventBusArn,
+					LogAggregationName:        "dce-log-aggregation",
+				},
+			}
+
+			err := amSvc.UpsertLogAggregation(&account.Account{
+				ID:           aws.String("123456789012"),
+				AdminRoleArn: adminRoleArn,
+			}, "lease-1")
+			assert.True(t, errors.Is(err, tt.exp), "actual error %q doesn't match expected error %q", err, tt.exp)
+
+			if tt.bucket == "" {
+				clientSvc.AssertNotCalled(t, "CloudTrail", mock.Anything, mock.Anything)
+			} else if tt.exp == nil {
+				trailSvc.AssertCalled(t, "StartLogging", mock.Anything)
+				if tt.createErr != nil {
+					trailSvc.AssertCalled(t, "UpdateTrail", mock.Anything)
+				}
+			}
+			if tt.eventBusArn == "" {
+				clientSvc.AssertNotCalled(t, "CloudWatchEvents", mock.Anything, mock.Anything)
+			} else if tt.exp == nil {
+				eventsSvc.AssertNumberOfCalls(t, "PutTargets", 2)
+			}
+		})
+	}
+}
+
+func TestDeleteLogAggregation(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+
+	tests := []struct {
+		name             string
+		deleteTrailErr   error
+		removeTargetsErr error
+		deleteRuleErr    error
+		exp              error
+	}{
+		{
+			name: "should delete the trail and rules",
+		},
+		{
+			name:             "should succeed when there's nothing to delete",
+			deleteTrailErr:   awserr.New(cloudtrail.ErrCodeTrailNotFoundException, "Not Found", nil),
+			removeTargetsErr: awserr.New(cloudwatchevents.ErrCodeResourceNotFoundException, "Not Found", nil),
+			deleteRuleErr:    awserr.New(cloudwatchevents.ErrCodeResourceNotFoundException, "Not Found", nil),
+		},
+		{
+			name:          "should fail when the rule can't be deleted",
+			deleteRuleErr: awserr.New(cloudwatchevents.ErrCodeInternalException, "Internal", nil),
+			exp: errors.NewInternalServer("failed to delete the log aggregation rule in us-east-1",
+				awserr.New(cloudwatchevents.ErrCodeInternalException, "Internal", nil)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trailSvc := &awsMocks.CloudTrailAPI{}
+			trailSvc.On("DeleteTrail", &cloudtrail.DeleteTrailInput{
+				Name: aws.String("dce-log-aggregation"),
+			}).Return(&cloudtrail.DeleteTrailOutput{}, tt.deleteTrailErr)
+
+			eventsSvc := &awsMocks.CloudWatchEventsAPI{}
+			eventsSvc.On("RemoveTargets", mock.AnythingOfType("*cloudwatchevents.RemoveTargetsInput")).
+				Return(&cloudwatchevents.RemoveTargetsOutput{}, tt.removeTargetsErr)
+			eventsSvc.On("DeleteRule", &cloudwatchevents.DeleteRuleInput{
+				Name: aws.String("dce-log-aggregation"),
+			}).Return(&cloudwatchevents.DeleteRuleOutput{}, tt.deleteRuleErr)
+
+			clientSvc := &mocks.Clienter{}
+			clientSvc.On("CloudTrail", adminRoleArn, "us-east-1").Return(trailSvc)
+			clientSvc.On("CloudWatchEvents", adminRoleArn, mock.Anything).Return(eventsSvc)
+
+			amSvc := Service{
+				client: clientSvc,
+				config: ServiceConfig{
+					AllowedRegions:            []string{"us-east-1", "us-west-2"},
+					LogAggregationBucket:      "central-logs",
+					LogAggregationEventBusArn: "arn:aws:events:us-east-1:999999999999:event-bus/default",
+					LogAggregationName:        "dce-log-aggregation",
+				},
+			}
+
+			err := amSvc.DeleteLogAggregation(&account.Account{
+				ID:           aws.String("123456789012"),
+				AdminRoleArn: adminRoleArn,
+			})
+			assert.True(t, errors.Is(err, tt.exp), "actual error %q doesn't match expected error %q", err, tt.exp)
+			trailSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,9 @@ package mocks
 
 import arn "github.com/Optum/dce/pkg/arn"
 import aws "github.com/aws/aws-sdk-go/aws"
+import budgetsiface "github.com/aws/aws-sdk-go/service/budgets/budgetsiface"
+import cloudtrailiface "github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+import cloudwatcheventsiface "github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
 import iamiface "github.com/aws/aws-sdk-go/service/iam/iamiface"
 import mock "github.com/stretchr/testify/mock"
 
@@ -12,6 +15,54 @@ type Clienter struct {
 	mock.Mock
 }
 
+// Budgets provides a mock function with given fields: roleArn
+func (_m *Clienter) Budgets(roleArn *arn.ARN) budgetsiface.BudgetsAPI {
+	ret := _m.Called(roleArn)
+
+	var r0 budgetsiface.BudgetsAPI
+	if rf, ok := ret.Get(0).(func(*arn.ARN) budgetsiface.BudgetsAPI); ok {
+		r0 = rf(roleArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(budgetsiface.BudgetsAPI)
+		}
+	}
+
+	return r0
+}
+
+// CloudTrail provides a mock function with given fields: roleArn, region
+func (_m *Clienter) CloudTrail(roleArn *arn.ARN, region string) cloudtrailiface.CloudTrailAPI {
+	ret := _m.Called(roleArn, region)
+
+	var r0 cloudtrailiface.CloudTrailAPI
+	if rf, ok := ret.Get(0).(func(*arn.ARN, string) cloudtrailiface.CloudTrailAPI); ok {
+		r0 = rf(roleArn, region)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudtrailiface.CloudTrailAPI)
+		}
+	}
+
+	return r0
+}
+
+// CloudWatchEvents provides a mock function with given fields: roleArn, region
+func (_m *Clienter) CloudWatchEvents(roleArn *arn.ARN, region string) cloudwatcheventsiface.CloudWatchEventsAPI {
+	ret := _m.Called(roleArn, region)
+
+	var r0 cloudwatcheventsiface.CloudWatchEventsAPI
+	if rf, ok := ret.Get(0).(func(*arn.ARN, string) cloudwatcheventsiface.CloudWatchEventsAPI); ok {
+		r0 = rf(roleArn, region)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudwatcheventsiface.CloudWatchEventsAPI)
+		}
+	}
+
+	return r0
+}
+
 // Config provides a mock function with given fields: roleArn
 func (_m *Clienter) Config(roleArn *arn.ARN) *aws.Config {
 	ret := _m.Called(roleArn)
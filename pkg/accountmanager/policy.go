@@ -0,0 +1,135 @@
+package accountmanager
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// policyAllowlist is the customizations of the principal policy which leases
+// may ask for, as defined by DCE admins
+type policyAllowlist struct {
+	// Add are the statements which may be added to the policy, by Sid
+	Add map[string]map[string]interface{} `json:"add"`
+	// Remove are the Sids of statements which may be removed from the policy
+	Remove []string `json:"remove"`
+}
+
+func (l *policyAllowlist) canRemove(sid string) bool {
+	for _, s := range l.Remove {
+		if s == sid {
+			return true
+		}
+	}
+	return false
+}
+
+// getPolicyAllowlist reads the allowlist of principal policy customizations
+// from S3.  No customizations are allowed when no allowlist is configured.
+func getPolicyAllowlist(storager common.Storager, config ServiceConfig) (*policyAllowlist, error) {
+	allowlist := &policyAllowlist{}
+	if config.S3PolicyCustomizationsKey == "" {
+		return allowlist, nil
+	}
+
+	object, err := storager.GetObject(config.S3BucketName, config.S3PolicyCustomizationsKey)
+	if err != nil {
+		return nil, errors.NewInternalServer("unexpected failure getting the principal policy customizations", err)
+	}
+	err = json.Unmarshal([]byte(object), allowlist)
+	if err != nil {
+		return nil, errors.NewInternalServer("invalid principal policy customizations", err)
+	}
+	return allowlist, nil
+}
+
+// ValidatePolicyCustomization checks a customization of the principal policy
+// only adds and removes allowlisted statements
+func (s *Service) ValidatePolicyCustomization(data *account.PolicyCustomization) error {
+	if data.IsEmpty() {
+		return nil
+	}
+
+	allowlist, err := getPolicyAllowlist(s.storager, s.config)
+	if err != nil {
+		return err
+	}
+
+	for _, sid := range data.Add {
+		if _, ok := allowlist.Add[sid]; !ok {
+			return errors.NewValidation("policyCustomization", fmt.Errorf("add: statement %q is not allowed", sid))
+		}
+	}
+	for _, sid := range data.Remove {
+		if !allowlist.canRemove(sid) {
+			return errors.NewValidation("policyCustomization", fmt.Errorf("remove: statement %q is not allowed", sid))
+		}
+	}
+	return nil
+}
+
+// customizePolicy applies a customization to a rendered principal policy.
+// Statements which are no longer allowlisted are skipped, so the admins may
+// revoke customizations of leases which already started.
+func customizePolicy(policy string, policyHash string, data *account.PolicyCustomization, allowlist *policyAllowlist) (string, string, error) {
+	document := map[string]interface{}{}
+	err := json.Unmarshal([]byte(policy), &document)
+	if err != nil {
+		return "", "", errors.NewInternalServer("unexpected error parsing the principal policy", err)
+	}
+
+	// A policy may have a single statement, instead of a list
+	statements, ok := document["Statement"].([]interface{})
+	if !ok {
+		statements = []interface{}{document["Statement"]}
+	}
+
+	removed := map[string]bool{}
+	for _, sid := range data.Remove {
+		if !allowlist.canRemove(sid) {
+			log.Printf("Statement %q may no longer be removed from the principal policy; ignoring", sid)
+			continue
+		}
+		removed[sid] = true
+	}
+
+	customized := []interface{}{}
+	for _, statement := range statements {
+		if s, ok := statement.(map[string]interface{}); ok {
+			if sid, ok := s["Sid"].(string); ok && removed[sid] {
+				continue
+			}
+		}
+		customized = append(customized, statement)
+	}
+
+	for _, sid := range data.Add {
+		statement, ok := allowlist.Add[sid]
+		if !ok {
+			log.Printf("Statement %q may no longer be added to the principal policy; ignoring", sid)
+			continue
+		}
+		added := map[string]interface{}{}
+		for k, v := range statement {
+			added[k] = v
+		}
+		added["Sid"] = sid
+		customized = append(customized, added)
+	}
+	document["Statement"] = customized
+
+	result, err := json.Marshal(document)
+	if err != nil {
+		return "", "", errors.NewInternalServer("unexpected error writing the principal policy", err)
+	}
+
+	// The hash changes with the customization, so the policy is updated when
+	// the customization is applied, and again when it is reverted
+	sum := sha256.Sum256(result)
+	return string(result), fmt.Sprintf("%s-%x", policyHash, sum[:8]), nil
+}
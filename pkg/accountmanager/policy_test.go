@@ -0,0 +1,107 @@
+package accountmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	commonMocks "github.com/Optum/dce/pkg/common/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicyAllowlist = `{
+	"add": {
+		"Bedrock": {"Effect": "Allow", "Action": ["bedrock:*"], "Resource": "*"}
+	},
+	"remove": ["DenyBedrock"]
+}`
+
+func TestValidatePolicyCustomization(t *testing.T) {
+	tests := []struct {
+		name          string
+		customization *account.PolicyCustomization
+		exp           error
+	}{
+		{
+			name: "should allow no customization",
+		},
+		{
+			name: "should allow allowlisted statements",
+			customization: &account.PolicyCustomization{
+				Add:    []string{"Bedrock"},
+				Remove: []string{"DenyBedrock"},
+			},
+		},
+		{
+			name:          "should fail on statements which may not be added",
+			customization: &account.PolicyCustomization{Add: []string{"AdministratorAccess"}},
+			exp:           errors.NewValidation("policyCustomization", fmt.Errorf("add: statement \"AdministratorAccess\" is not allowed")),
+		},
+		{
+			name:          "should fail on statements which may not be removed",
+			customization: &account.PolicyCustomization{Remove: []string{"DenyIAM"}},
+			exp:           errors.NewValidation("policyCustomization", fmt.Errorf("remove: statement \"DenyIAM\" is not allowed")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storagerSvc := &commonMocks.Storager{}
+			storagerSvc.On("GetObject", "DefaultArtifactBucket", "PolicyCustomizationsS3Key").Return(testPolicyAllowlist, nil)
+
+			config := testConfig
+			config.S3PolicyCustomizationsKey = "PolicyCustomizationsS3Key"
+			amSvc := &Service{storager: storagerSvc, config: config}
+
+			err := amSvc.ValidatePolicyCustomization(tt.customization)
+			assert.True(t, errors.Is(err, tt.exp), "actual error %+v doesn't match expected error %+v", err, tt.exp)
+		})
+	}
+
+	t.Run("should not allow customizations without an allowlist", func(t *testing.T) {
+		amSvc := &Service{storager: &commonMocks.Storager{}, config: testConfig}
+
+		err := amSvc.ValidatePolicyCustomization(&account.PolicyCustomization{Add: []string{"Bedrock"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCustomizePolicy(t *testing.T) {
+	allowlist := &policyAllowlist{}
+	require.Nil(t, json.Unmarshal([]byte(testPolicyAllowlist), allowlist))
+
+	policy := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "DoNotModifySelf", "Effect": "Deny", "Action": "iam:*", "Resource": "*"},
+			{"Sid": "DenyBedrock", "Effect": "Deny", "Action": "bedrock:*", "Resource": "*"}
+		]
+	}`
+
+	customized, hash, err := customizePolicy(policy, "etag", &account.PolicyCustomization{
+		Add:    []string{"Bedrock", "Revoked"},
+		Remove: []string{"DenyBedrock", "DoNotModifySelf"},
+	}, allowlist)
+	require.Nil(t, err)
+
+	document := struct {
+		Statement []map[string]interface{}
+	}{}
+	require.Nil(t, json.Unmarshal([]byte(customized), &document))
+	sids := []interface{}{}
+	for _, statement := range document.Statement {
+		sids = append(sids, statement["Sid"])
+	}
+	assert.Equal(t, []interface{}{"DoNotModifySelf", "Bedrock"}, sids, "only allowlisted statements should be added and removed")
+	assert.NotEqual(t, "etag", hash)
+
+	_, sameHash, err := customizePolicy(policy, "etag", &account.PolicyCustomization{
+		Add:    []string{"Bedrock"},
+		Remove: []string{"DenyBedrock"},
+	}, allowlist)
+	require.Nil(t, err)
+	assert.Equal(t, hash, sameHash, "the hash should only change with the policy")
+}
@@ -0,0 +1,62 @@
+package accountmanager
+
+import (
+	"fmt"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// probeSessionName names the role sessions of readiness probes, so they're
+// told apart from principals' in CloudTrail
+const probeSessionName = "dce-readiness-probe"
+
+// ProbeReadiness checks the account can be handed out, before it's leased.
+// The admin and principal roles are assumed, without the cached credentials
+// of earlier requests, and STS must report the sessions are in the account.
+// Roles which were deleted, or whose trust policies were changed, since the
+// account was reset fail the probe.
+func (s *Service) ProbeReadiness(account *account.Account) error {
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.ID, validation.NotNil),
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+		validation.Field(&account.PrincipalRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+
+	for _, role := range []*arn.ARN{account.AdminRoleArn, account.PrincipalRoleArn} {
+		err = s.probeRole(*account.ID, role)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeRole assumes the role, and checks its session is in the account
+func (s *Service) probeRole(accountID string, role *arn.ARN) error {
+	output, err := s.sts.AssumeRole(&sts.AssumeRoleInput{
+		RoleArn:         aws.String(role.String()),
+		RoleSessionName: aws.String(probeSessionName),
+		DurationSeconds: aws.Int64(900),
+	})
+	if err != nil {
+		return errors.NewConflict("account", accountID, fmt.Errorf("failed to assume role %q: %s", role.String(), err))
+	}
+
+	if output.AssumedRoleUser == nil {
+		return errors.NewConflict("account", accountID, fmt.Errorf("role %q has no assumed role user", role.String()))
+	}
+	session, err := arn.NewFromArn(aws.StringValue(output.AssumedRoleUser.Arn))
+	if err != nil || session.AccountID != accountID {
+		return errors.NewConflict("account", accountID,
+			fmt.Errorf("role %q assumed session %q outside the account", role.String(), aws.StringValue(output.AssumedRoleUser.Arn)))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package accountmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProbeReadiness(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+	principalRoleArn := arn.New("aws", "iam", "", "123456789012", "role/DCEPrincipal")
+
+	assumed := func(sessionArn string) *sts.AssumeRoleOutput {
+		return &sts.AssumeRoleOutput{
+			AssumedRoleUser: &sts.AssumedRoleUser{Arn: aws.String(sessionArn)},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		account         *account.Account
+		adminOutput     *sts.AssumeRoleOutput
+		principalOutput *sts.AssumeRoleOutput
+		principalErr    error
+		expErr          error
+	}{
+		{
+			name:            "should succeed when both roles are assumed in the account",
+			account:         &account.Account{ID: aws.String("123456789012"), AdminRoleArn: adminRoleArn, PrincipalRoleArn: principalRoleArn},
+			adminOutput:     assumed("arn:aws:sts::123456789012:assumed-role/AdminRole/dce-readiness-probe"),
+			principalOutput: assumed("arn:aws:sts::123456789012:assumed-role/DCEPrincipal/dce-readiness-probe"),
+		},
+		{
+			name:         "should fail when the principal role can't be assumed",
+			account:      &account.Account{ID: aws.String("123456789012"), AdminRoleArn: adminRoleArn, PrincipalRoleArn: principalRoleArn},
+			adminOutput:  assumed("arn:aws:sts::123456789012:assumed-role/AdminRole/dce-readiness-probe"),
+			principalErr: fmt.Errorf("AccessDenied"),
+			expErr: errors.NewConflict("account", "123456789012",
+				fmt.Errorf("failed to assume role \"arn:aws:iam::123456789012:role/DCEPrincipal\": AccessDenied")),
+		},
+		{
+			name:            "should fail when a role is assumed in another account",
+			account:         &account.Account{ID: aws.String("123456789012"), AdminRoleArn: adminRoleArn, PrincipalRoleArn: principalRoleArn},
+			adminOutput:     assumed("arn:aws:sts::123456789012:assumed-role/AdminRole/dce-readiness-probe"),
+			principalOutput: assumed("arn:aws:sts::210987654321:assumed-role/DCEPrincipal/dce-readiness-probe"),
+			expErr: errors.NewConflict("account", "123456789012",
+				fmt.Errorf("role \"arn:aws:iam::123456789012:role/DCEPrincipal\" assumed session \"arn:aws:sts::210987654321:assumed-role/DCEPrincipal/dce-readiness-probe\" outside the account")),
+		},
+		{
+			name:    "should fail without the principal role",
+			account: &account.Account{ID: aws.String("123456789012"), AdminRoleArn: adminRoleArn},
+			expErr:  errors.NewValidation("account", fmt.Errorf("principalRoleArn: is required.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stsSvc := &awsMocks.STSAPI{}
+			stsSvc.On("AssumeRole", mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+				return *input.RoleArn == adminRoleArn.String() && *input.RoleSessionName == "dce-readiness-probe"
+			})).Return(tt.adminOutput, nil)
+			stsSvc.On("AssumeRole", mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+				return *input.RoleArn == principalRoleArn.String()
+			})).Return(tt.principalOutput, tt.principalErr)
+
+			amSvc, err := NewService(NewServiceInput{
+				Sts: stsSvc,
+			})
+			assert.Nil(t, err)
+
+			err = amSvc.ProbeReadiness(tt.account)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+		})
+	}
+}
@@ -20,6 +20,7 @@ type ServiceConfig struct {
 	AccountID                   string   `env:"ACCOUNT_ID" envDefault:"111111111111"`
 	S3BucketName                string   `env:"ARTIFACTS_BUCKET" envDefault:"DefaultArtifactBucket"`
 	S3PolicyKey                 string   `env:"PRINCIPAL_POLICY_S3_KEY" envDefault:"DefaultPrincipalPolicyS3Key"`
+	S3PolicyCustomizationsKey   string   `env:"PRINCIPAL_POLICY_CUSTOMIZATIONS_S3_KEY" envDefault:""`
 	PrincipalIAMDenyTags        []string `env:"PRINCIPAL_IAM_DENY_TAGS" envDefault:"DefaultPrincipalIamDenyTags"`
 	PrincipalMaxSessionDuration int64    `env:"PRINCIPAL_MAX_SESSION_DURATION" envDefault:"3600"` // 3600 is the default minimum value
 	AllowedRegions              []string `env:"ALLOWED_REGIONS" envDefault:"us-east-1"`
@@ -28,13 +29,39 @@ type ServiceConfig struct {
 	TagAppName                  string   `env:"TAG_APP_NAME" envDefault:"DefaultTagAppName"`
 	PrincipalRoleDescription    string   `env:"PRINCIPAL_ROLE_DESCRIPTION" envDefault:"Role for principal users of DCE"`
 	PrincipalPolicyDescription  string   `env:"PRINCIPAL_POLICY_DESCRIPTION" envDefault:"Policy for principal users of DCE"`
-	tags                        []*iam.Tag
-	assumeRolePolicy            string
+	// LogAggregationBucket is the S3 bucket of the logging account leased
+	// accounts deliver their CloudTrail logs to
+	LogAggregationBucket string `env:"LOG_AGGREGATION_BUCKET" envDefault:""`
+	// LogAggregationEventBusArn is the event bus of the logging account
+	// leased accounts forward their CloudWatch Events to
+	LogAggregationEventBusArn string `env:"LOG_AGGREGATION_EVENT_BUS_ARN" envDefault:""`
+	// LogAggregationName names the trail and event rule in leased accounts
+	LogAggregationName string `env:"LOG_AGGREGATION_NAME" envDefault:"dce-log-aggregation"`
+	// LeaseBudgetAlarmsEnabled creates a budget in leased accounts
+	// mirroring the lease budget
+	LeaseBudgetAlarmsEnabled bool `env:"LEASE_BUDGET_ALARMS_ENABLED" envDefault:"false"`
+	// LeaseBudgetAlarmName names the budget in leased accounts
+	LeaseBudgetAlarmName string `env:"LEASE_BUDGET_ALARM_NAME" envDefault:"dce-lease-budget"`
+	// LeaseBudgetAlarmThresholds are the percents of the lease budget the
+	// budget in the leased account alerts at
+	LeaseBudgetAlarmThresholds []float64 `env:"BUDGET_NOTIFICATION_THRESHOLD_PERCENTILES" envDefault:"75,100"`
+	tags                       []*iam.Tag
+	assumeRolePolicy           string
+}
+
+// Validate the configuration of the account manager.  IAM roles' maximum
+// session duration is between an hour and 12 hours.
+func (c *ServiceConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.PrincipalMaxSessionDuration, validation.Min(int64(3600)), validation.Max(int64(43200))),
+		validation.Field(&c.LeaseBudgetAlarmThresholds, validation.Each(validation.Min(0.0))),
+	)
 }
 
 // Service manages account resources
 type Service struct {
 	client   clienter
+	sts      stsiface.STSAPI
 	storager common.Storager
 	config   ServiceConfig
 }
@@ -139,6 +166,7 @@ func NewService(input NewServiceInput) (*Service, error) {
 			session: input.Session,
 			sts:     input.Sts,
 		},
+		sts:      input.Sts,
 		storager: input.Storager,
 		config:   input.Config,
 	}
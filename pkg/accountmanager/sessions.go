@@ -0,0 +1,63 @@
+package accountmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// revokeSessionsPolicyName is the inline policy of the principal role which
+// denies its older sessions, named like the policy the IAM console adds
+const revokeSessionsPolicyName = "AWSRevokeOlderSessions"
+
+// RevokePrincipalSessions denies every session of the principal role issued
+// before the given time, so credentials already handed out to a principal
+// stop working straight away, instead of when they expire.  Sessions issued
+// afterwards, such as those of the account's next lease, aren't affected.
+// Revoking again replaces the earlier time.
+func (s *Service) RevokePrincipalSessions(account *account.Account, issuedBefore time.Time) error {
+	err := validation.ValidateStruct(account,
+		validation.Field(&account.ID, validation.NotNil),
+		validation.Field(&account.AdminRoleArn, validation.NotNil),
+		validation.Field(&account.PrincipalRoleArn, validation.NotNil),
+	)
+	if err != nil {
+		return errors.NewValidation("account", err)
+	}
+
+	policy, err := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Deny",
+				"Action":   "*",
+				"Resource": "*",
+				"Condition": map[string]interface{}{
+					"DateLessThan": map[string]string{
+						"aws:TokenIssueTime": issuedBefore.UTC().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to build the revoke sessions policy", err)
+	}
+
+	_, err = s.client.IAM(account.AdminRoleArn).PutRolePolicy(&iam.PutRolePolicyInput{
+		RoleName:       account.PrincipalRoleArn.IAMResourceName(),
+		PolicyName:     aws.String(revokeSessionsPolicyName),
+		PolicyDocument: aws.String(string(policy)),
+	})
+	if err != nil {
+		return errors.NewInternalServer(
+			fmt.Sprintf("failed to revoke the sessions of role %q", account.PrincipalRoleArn.String()), err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package accountmanager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/accountmanager/mocks"
+	"github.com/Optum/dce/pkg/arn"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokePrincipalSessions(t *testing.T) {
+	adminRoleArn := arn.New("aws", "iam", "", "123456789012", "role/AdminRole")
+	principalRoleArn := arn.New("aws", "iam", "", "123456789012", "role/DCEPrincipal")
+	issuedBefore := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		account *account.Account
+		putErr  error
+		expPut  bool
+		exp     error
+	}{
+		{
+			name: "should deny sessions issued before the time",
+			account: &account.Account{
+				ID:               aws.String("123456789012"),
+				AdminRoleArn:     adminRoleArn,
+				PrincipalRoleArn: principalRoleArn,
+			},
+			expPut: true,
+		},
+		{
+			name: "should fail when the policy can't be put",
+			account: &account.Account{
+				ID:               aws.String("123456789012"),
+				AdminRoleArn:     adminRoleArn,
+				PrincipalRoleArn: principalRoleArn,
+			},
+			putErr: awserr.New(iam.ErrCodeNoSuchEntityException, "Not Found", nil),
+			expPut: true,
+			exp: errors.NewInternalServer("failed to revoke the sessions of role \"arn:aws:iam::123456789012:role/DCEPrincipal\"",
+				awserr.New(iam.ErrCodeNoSuchEntityException, "Not Found", nil)),
+		},
+		{
+			name: "should fail without a principal role",
+			account: &account.Account{
+				ID:           aws.String("123456789012"),
+				AdminRoleArn: adminRoleArn,
+			},
+			exp: errors.NewValidation("account", fmt.Errorf("principalRoleArn: is required.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iamSvc := &awsMocks.IAM{}
+			iamSvc.On("PutRolePolicy", &iam.PutRolePolicyInput{
+				RoleName:   aws.String("DCEPrincipal"),
+				PolicyName: aws.String("AWSRevokeOlderSessions"),
+				PolicyDocument: aws.String(`{"Statement":[{"Action":"*","Condition":{"DateLessThan":{"aws:TokenIssueTime":"2020-01-02T03:04:05Z"}},` +
+					`"Effect":"Deny","Resource":"*"}],"Version":"2012-10-17"}`),
+			}).Return(&iam.PutRolePolicyOutput{}, tt.putErr)
+
+			clientSvc := &mocks.Clienter{}
+			clientSvc.On("IAM", adminRoleArn).Return(iamSvc)
+
+			amSvc := Service{client: clientSvc}
+
+			err := amSvc.RevokePrincipalSessions(tt.account, issuedBefore)
+			assert.True(t, errors.Is(err, tt.exp), "actual error %q doesn't match expected error %q", err, tt.exp)
+			if tt.expPut {
+				iamSvc.AssertExpectations(t)
+			} else {
+				iamSvc.AssertNotCalled(t, "PutRolePolicy")
+			}
+		})
+	}
+}
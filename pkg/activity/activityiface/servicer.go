@@ -0,0 +1,15 @@
+//
+
+package activityiface
+
+import (
+	"github.com/Optum/dce/pkg/activity"
+)
+
+// Servicer records and lists the activity feed
+type Servicer interface {
+	// Write records an event in the feed, as of now
+	Write(data *activity.Event) error
+	// List returns the events matching the query, newest first
+	List(query *activity.Query) (*activity.Events, error)
+}
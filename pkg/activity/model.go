@@ -0,0 +1,45 @@
+package activity
+
+import "encoding/json"
+
+// Event is a domain event recorded in the activity feed
+type Event struct {
+	// Day partitions the feed, eg. "2020-01-02"
+	Day string `json:"-" dynamodbav:"Day"`
+	// ID sorts the events of a day by when they were recorded
+	ID         string `json:"id" dynamodbav:"Id"`
+	Type       string `json:"type" dynamodbav:"Type"`
+	Resource   string `json:"resource" dynamodbav:"Resource"`
+	ResourceID string `json:"resourceId,omitempty" dynamodbav:"ResourceId,omitempty"`
+	// Actor is the DCE component which published the event, eg. the name of
+	// its Lambda function
+	Actor     string `json:"actor,omitempty" dynamodbav:"Actor,omitempty"`
+	Timestamp int64  `json:"timestamp" dynamodbav:"Timestamp"`
+	// Data is the payload of the event, as it was published
+	Data      json.RawMessage `json:"data" dynamodbav:"Data"`
+	ExpiresOn int64           `json:"-" dynamodbav:"ExpiresOn,omitempty"`
+}
+
+// Events is a list of events, newest first
+type Events []Event
+
+// Resources of events
+const (
+	ResourceAccount = "account"
+	ResourceLease   = "lease"
+	ResourcePool    = "pool"
+)
+
+// Query filters the activity feed.  Since and Until are epoch timestamps.
+type Query struct {
+	Resource   *string `json:"resource,omitempty" schema:"resource,omitempty"`
+	ResourceID *string `json:"resourceId,omitempty" schema:"resourceId,omitempty"`
+	Type       *string `json:"type,omitempty" schema:"type,omitempty"`
+	Actor      *string `json:"actor,omitempty" schema:"actor,omitempty"`
+	Since      *int64  `json:"since,omitempty" schema:"since,omitempty"`
+	Until      *int64  `json:"until,omitempty" schema:"until,omitempty"`
+	Limit      *int64  `json:"limit,omitempty" schema:"limit,omitempty"`
+	// NextDay and NextID are where the next page starts
+	NextDay *string `json:"nextDay,omitempty" schema:"nextDay,omitempty"`
+	NextID  *string `json:"nextId,omitempty" schema:"nextId,omitempty"`
+}
@@ -0,0 +1,195 @@
+package activity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+)
+
+const (
+	// dayLayout is the format of the days the feed is partitioned by
+	dayLayout = "2006-01-02"
+	// defaultPeriod is how far back the feed is listed, when there's no since
+	defaultPeriod = 7 * 24 * time.Hour
+	// maxPeriod is the longest period listed at once
+	maxPeriod = 90 * 24 * time.Hour
+	// defaultLimit and maxLimit are the number of events listed at once
+	defaultLimit = 100
+	maxLimit     = 1000
+	// maxQueries is the most DynamoDB queries made for a page.  Filters which
+	// match few events return a shorter page, with a link to the next one.
+	maxQueries = 20
+)
+
+// NewServiceInput are the items needed to create a new activity service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table events are recorded in
+	TableName string `env:"EVENT_TABLE_NAME" envDefault:"Events"`
+	// RetentionDays is how long events are kept, before DynamoDB expires
+	// them.  Events are kept forever when 0
+	RetentionDays int `env:"EVENT_RETENTION_DAYS" envDefault:"90"`
+}
+
+// Service records and lists the activity feed
+type Service struct {
+	dynamodb  dynamodbiface.DynamoDBAPI
+	tableName string
+	retention time.Duration
+	now       func() time.Time
+}
+
+// Write records an event in the feed, as of now
+func (s *Service) Write(data *Event) error {
+	now := s.now().UTC()
+	data.Timestamp = now.Unix()
+	data.Day = now.Format(dayLayout)
+	// IDs sort by time within the day
+	data.ID = fmt.Sprintf("%s-%s", now.Format("150405.000000000"), uuid.New().String())
+	if s.retention > 0 {
+		data.ExpiresOn = now.Add(s.retention).Unix()
+	}
+
+	item, err := dynamodbattribute.MarshalMap(data)
+	if err != nil {
+		return errors.NewInternalServer("unable to marshal event", err)
+	}
+	_, err = s.dynamodb.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to record event", err)
+	}
+	return nil
+}
+
+// List returns the events matching the query, newest first.  When there are
+// more, the query's NextDay and NextID are set to where the next page starts.
+func (s *Service) List(query *Query) (*Events, error) {
+	if query.Until == nil {
+		query.Until = aws.Int64(s.now().Unix())
+	}
+	if query.Since == nil {
+		query.Since = aws.Int64(*query.Until - int64(defaultPeriod.Seconds()))
+	}
+	if query.Limit == nil {
+		query.Limit = aws.Int64(defaultLimit)
+	}
+	err := validation.ValidateStruct(query,
+		validation.Field(&query.Since, validation.By(isPeriod(*query.Until))),
+		validation.Field(&query.Limit, validation.Min(int64(1)), validation.Max(int64(maxLimit))),
+		validation.Field(&query.NextDay, validation.NilOrNotEmpty, validation.Date(dayLayout)),
+		validation.Field(&query.NextID, validation.By(isNilWithout(query.NextDay))),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("activity", err)
+	}
+
+	filter := expression.Name("Timestamp").Between(expression.Value(*query.Since), expression.Value(*query.Until))
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"Type", query.Type},
+		{"Resource", query.Resource},
+		{"ResourceId", query.ResourceID},
+		{"Actor", query.Actor},
+	} {
+		if f.value != nil {
+			filter = filter.And(expression.Name(f.name).Equal(expression.Value(*f.value)))
+		}
+	}
+
+	lastDay := time.Unix(*query.Since, 0).UTC().Format(dayLayout)
+	day := time.Unix(*query.Until, 0).UTC().Format(dayLayout)
+	var startKey map[string]*dynamodb.AttributeValue
+	if query.NextDay != nil {
+		day = *query.NextDay
+		if query.NextID != nil {
+			startKey = map[string]*dynamodb.AttributeValue{
+				"Day": {S: query.NextDay},
+				"Id":  {S: query.NextID},
+			}
+		}
+	}
+	query.NextDay = nil
+	query.NextID = nil
+
+	events := Events{}
+	// Days sort as strings, so the feed is read a day at a time until the
+	// day of Since
+	for queries := 0; day >= lastDay; queries++ {
+		if queries >= maxQueries || int64(len(events)) >= *query.Limit {
+			query.NextDay = aws.String(day)
+			if startKey != nil {
+				query.NextID = startKey["Id"].S
+			}
+			break
+		}
+
+		expr, err := expression.NewBuilder().
+			WithKeyCondition(expression.Key("Day").Equal(expression.Value(day))).
+			WithFilter(filter).
+			Build()
+		if err != nil {
+			return nil, errors.NewInternalServer("unable to build query", err)
+		}
+		res, err := s.dynamodb.Query(&dynamodb.QueryInput{
+			TableName:                 aws.String(s.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ScanIndexForward:          aws.Bool(false),
+			Limit:                     aws.Int64(*query.Limit - int64(len(events))),
+			ExclusiveStartKey:         startKey,
+		})
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to query events", err)
+		}
+
+		page := Events{}
+		err = dynamodbattribute.UnmarshalListOfMaps(res.Items, &page)
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to parse events", err)
+		}
+		events = append(events, page...)
+
+		startKey = res.LastEvaluatedKey
+		if len(startKey) == 0 {
+			startKey = nil
+			day = previousDay(day)
+		}
+	}
+
+	return &events, nil
+}
+
+// previousDay returns the day before the given one
+func previousDay(day string) string {
+	t, _ := time.Parse(dayLayout, day)
+	return t.AddDate(0, 0, -1).Format(dayLayout)
+}
+
+// NewService creates a new activity service
+func NewService(input NewServiceInput) (*Service, error) {
+	if input.TableName == "" {
+		return nil, errors.NewValidation("activity", fmt.Errorf("an event table name is required"))
+	}
+
+	return &Service{
+		dynamodb:  input.DynamoDB,
+		tableName: input.TableName,
+		retention: time.Duration(input.RetentionDays) * 24 * time.Hour,
+		now:       time.Now,
+	}, nil
+}
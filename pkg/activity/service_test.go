@@ -0,0 +1,136 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(dynamo *awsMocks.DynamoDBAPI, now time.Time) *Service {
+	svc, _ := NewService(NewServiceInput{
+		DynamoDB:      dynamo,
+		TableName:     "Events",
+		RetentionDays: 90,
+	})
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2020, 1, 2, 15, 4, 5, 6, time.UTC)
+	dynamo := &awsMocks.DynamoDBAPI{}
+	dynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "Events" &&
+			*input.Item["Day"].S == "2020-01-02" &&
+			(*input.Item["Id"].S)[:17] == "150405.000000006-" &&
+			*input.Item["Type"].S == "LeaseCreated" &&
+			*input.Item["Timestamp"].N == fmt.Sprint(now.Unix()) &&
+			*input.Item["ExpiresOn"].N == fmt.Sprint(now.AddDate(0, 0, 90).Unix())
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+
+	svc := newTestService(dynamo, now)
+	err := svc.Write(&Event{
+		Type:       "LeaseCreated",
+		Resource:   ResourceLease,
+		ResourceID: "abc",
+		Data:       json.RawMessage(`{"id":"abc"}`),
+	})
+
+	assert.Nil(t, err)
+	dynamo.AssertExpectations(t)
+}
+
+func eventItem(id string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"Id":   {S: aws.String(id)},
+		"Type": {S: aws.String("LeaseCreated")},
+	}
+}
+
+func TestList(t *testing.T) {
+	now := time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)
+	day := func(d string) interface{} {
+		return mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			for _, v := range input.ExpressionAttributeValues {
+				if aws.StringValue(v.S) == d {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	t.Run("reads each day until since", func(t *testing.T) {
+		dynamo := &awsMocks.DynamoDBAPI{}
+		dynamo.On("Query", day("2020-01-02")).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{eventItem("2")},
+		}, nil).Once()
+		dynamo.On("Query", day("2020-01-01")).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{eventItem("1")},
+		}, nil).Once()
+
+		query := &Query{Since: aws.Int64(now.AddDate(0, 0, -1).Unix())}
+		events, err := newTestService(dynamo, now).List(query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(*events))
+		assert.Equal(t, "2", (*events)[0].ID)
+		assert.Nil(t, query.NextDay)
+		dynamo.AssertExpectations(t)
+	})
+
+	t.Run("returns the next page", func(t *testing.T) {
+		dynamo := &awsMocks.DynamoDBAPI{}
+		dynamo.On("Query", day("2020-01-02")).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{eventItem("2")},
+			LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+				"Day": {S: aws.String("2020-01-02")},
+				"Id":  {S: aws.String("2")},
+			},
+		}, nil).Once()
+
+		query := &Query{Limit: aws.Int64(1)}
+		events, err := newTestService(dynamo, now).List(query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(*events))
+		assert.Equal(t, "2020-01-02", aws.StringValue(query.NextDay))
+		assert.Equal(t, "2", aws.StringValue(query.NextID))
+		dynamo.AssertExpectations(t)
+	})
+
+	t.Run("starts from the next page", func(t *testing.T) {
+		dynamo := &awsMocks.DynamoDBAPI{}
+		dynamo.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return aws.StringValue(input.ExclusiveStartKey["Id"].S) == "2"
+		})).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+		query := &Query{
+			Since:   aws.Int64(now.Add(-time.Hour).Unix()),
+			NextDay: aws.String("2020-01-02"),
+			NextID:  aws.String("2"),
+		}
+		events, err := newTestService(dynamo, now).List(query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(*events))
+		assert.Nil(t, query.NextDay)
+		dynamo.AssertExpectations(t)
+	})
+
+	t.Run("rejects long periods", func(t *testing.T) {
+		query := &Query{Since: aws.Int64(now.AddDate(-1, 0, 0).Unix())}
+		_, err := newTestService(&awsMocks.DynamoDBAPI{}, now).List(query)
+
+		assert.True(t, errors.Is(err, errors.NewValidation("activity",
+			fmt.Errorf("since: must be at most 90 days before until."))))
+	})
+}
@@ -0,0 +1,37 @@
+package activity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// We don't use the internal errors package here because validation will rewrite it anyways
+
+// isNilWithout checks the value is empty unless the other value is set
+func isNilWithout(other *string) func(value interface{}) error {
+	return func(value interface{}) error {
+		s, _ := value.(*string)
+		if s != nil && other == nil {
+			return errors.New("must be empty")
+		}
+		return nil
+	}
+}
+
+// isPeriod checks the period from since to until isn't backwards or too long
+func isPeriod(until int64) func(value interface{}) error {
+	return func(value interface{}) error {
+		since, _ := value.(*int64)
+		if since == nil {
+			return nil
+		}
+		if *since > until {
+			return errors.New("must be before until")
+		}
+		if until-*since > int64(maxPeriod.Seconds()) {
+			return fmt.Errorf("must be at most %d days before until", int64(maxPeriod/(24*time.Hour)))
+		}
+		return nil
+	}
+}
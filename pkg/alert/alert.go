@@ -0,0 +1,180 @@
+// Package alert opens incidents in an on-call tool, such as PagerDuty or
+// Opsgenie, when DCE fails in a way that needs an operator.
+package alert
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/caarlos0/env"
+)
+
+// Type is the kind of operational failure an alert is for
+type Type string
+
+const (
+	// TypeResetFailed is raised when an account reset fails, leaving the
+	// account NotReady until it is reset again
+	TypeResetFailed Type = "ResetFailed"
+	// TypeReadyPoolExhausted is raised when there are no Ready accounts to lease
+	TypeReadyPoolExhausted Type = "ReadyPoolExhausted"
+	// TypeReadyPoolLow is raised when the Ready accounts fall below the
+	// configured threshold, before the pool is exhausted
+	TypeReadyPoolLow Type = "ReadyPoolLow"
+	// TypeBudgetEnforcementFailed is raised when a lease budget check errors,
+	// so the budget of the lease is not being enforced
+	TypeBudgetEnforcementFailed Type = "BudgetEnforcementFailed"
+	// TypeAccountUnreachable is raised when a reset finds an account was
+	// closed or suspended, so it's taken out of the account pool
+	TypeAccountUnreachable Type = "AccountUnreachable"
+	// TypeResetBlackout is raised when a reset finds activity by principals
+	// outside DCE, so the account stays NotReady until it's acknowledged
+	TypeResetBlackout Type = "ResetBlackout"
+)
+
+// Severity of an alert
+type Severity string
+
+const (
+	// SeverityCritical is for failures which stop DCE from leasing accounts
+	SeverityCritical Severity = "critical"
+	// SeverityError is for failures which affect a single account or lease
+	SeverityError Severity = "error"
+	// SeverityWarning is for conditions which will become failures if no
+	// one acts, such as the account pool running low
+	SeverityWarning Severity = "warning"
+)
+
+// Driver names, used by the ALERT_DRIVER setting
+const (
+	DriverPagerDuty = "pagerduty"
+	DriverOpsgenie  = "opsgenie"
+)
+
+// Alert is an operational failure to open an incident for
+type Alert struct {
+	Type Type
+	// AccountID is optional. When set, a separate incident is opened for each account.
+	AccountID string
+	Severity  Severity
+	Summary   string
+	Details   map[string]string
+}
+
+// Driver opens and closes incidents in an on-call tool. Incidents are
+// identified by a deduplication key, so triggering the same key again
+// updates the open incident instead of opening a new one.
+type Driver interface {
+	Trigger(dedupKey string, alert *Alert) error
+	Resolve(dedupKey string) error
+}
+
+// NewServiceInput are the items needed to create a new alert service
+type NewServiceInput struct {
+	// DriverName is the on-call tool to send alerts to, either "pagerduty"
+	// or "opsgenie".  Alerting is disabled when empty
+	DriverName string `env:"ALERT_DRIVER" envDefault:""`
+	// IntegrationKey is the PagerDuty routing key or the Opsgenie API key
+	IntegrationKey string `env:"ALERT_INTEGRATION_KEY" envDefault:""`
+	// APIURL overrides the API URL of the driver, eg. for the Opsgenie EU instance
+	APIURL    string `env:"ALERT_API_URL" envDefault:""`
+	Namespace string `env:"NAMESPACE" envDefault:"dce"`
+	// Driver is optional, and overrides DriverName
+	Driver Driver
+}
+
+// Service sends alerts for operational failures
+type Service struct {
+	driver    Driver
+	namespace string
+}
+
+// Trigger opens an incident for the alert, or updates the open incident
+// with the same type and account
+func (s *Service) Trigger(alert *Alert) error {
+	if s.driver == nil {
+		return nil
+	}
+	a := *alert
+	if a.Severity == "" {
+		a.Severity = SeverityError
+	}
+	details := map[string]string{
+		"namespace": s.namespace,
+		"type":      string(alert.Type),
+	}
+	if alert.AccountID != "" {
+		details["accountId"] = alert.AccountID
+	}
+	for k, v := range alert.Details {
+		details[k] = v
+	}
+	a.Details = details
+
+	err := s.driver.Trigger(s.DedupKey(a.Type, a.AccountID), &a)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to trigger %s alert", alert.Type), err)
+	}
+	return nil
+}
+
+// Resolve closes the incident for the alert type and account, if one is open
+func (s *Service) Resolve(alertType Type, accountID string) error {
+	if s.driver == nil {
+		return nil
+	}
+	err := s.driver.Resolve(s.DedupKey(alertType, accountID))
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to resolve %s alert", alertType), err)
+	}
+	return nil
+}
+
+// DedupKey is the key identifying the incident for an alert type and account,
+// eg. "dce-prod-ResetFailed-123456789012"
+func (s *Service) DedupKey(alertType Type, accountID string) string {
+	parts := []string{"dce", s.namespace, string(alertType)}
+	if accountID != "" {
+		parts = append(parts, accountID)
+	}
+	return strings.Join(parts, "-")
+}
+
+// NewService creates a new alert service
+func NewService(input NewServiceInput) (*Service, error) {
+	driver := input.Driver
+	if driver == nil && input.DriverName != "" {
+		if input.IntegrationKey == "" {
+			return nil, errors.NewValidation("alert", fmt.Errorf("an integration key is required for the %s driver", input.DriverName))
+		}
+		httpClient := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		switch strings.ToLower(input.DriverName) {
+		case DriverPagerDuty:
+			driver = NewPagerDuty(input.IntegrationKey, input.APIURL, httpClient)
+		case DriverOpsgenie:
+			driver = NewOpsgenie(input.IntegrationKey, input.APIURL, httpClient)
+		default:
+			return nil, errors.NewValidation("alert", fmt.Errorf("unknown alert driver %q", input.DriverName))
+		}
+	}
+
+	return &Service{
+		driver:    driver,
+		namespace: input.Namespace,
+	}, nil
+}
+
+// NewFromEnv creates a new alert service configured from environment variables
+func NewFromEnv() (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(input)
+}
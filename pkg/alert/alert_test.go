@@ -0,0 +1,129 @@
+package alert
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDriver struct {
+	triggered map[string]*Alert
+	resolved  []string
+	err       error
+}
+
+func (d *testDriver) Trigger(dedupKey string, alert *Alert) error {
+	d.triggered[dedupKey] = alert
+	return d.err
+}
+
+func (d *testDriver) Resolve(dedupKey string) error {
+	d.resolved = append(d.resolved, dedupKey)
+	return d.err
+}
+
+func TestTrigger(t *testing.T) {
+	driver := &testDriver{triggered: map[string]*Alert{}}
+	svc, err := NewService(NewServiceInput{Namespace: "prod", Driver: driver})
+	assert.Nil(t, err)
+
+	err = svc.Trigger(&Alert{
+		Type:      TypeResetFailed,
+		AccountID: "123456789012",
+		Summary:   "reset failed",
+		Details:   map[string]string{"error": "nuke failed"},
+	})
+	assert.Nil(t, err)
+	err = svc.Trigger(&Alert{
+		Type:     TypeReadyPoolExhausted,
+		Severity: SeverityCritical,
+		Summary:  "no accounts",
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, &Alert{
+		Type:      TypeResetFailed,
+		AccountID: "123456789012",
+		Severity:  SeverityError,
+		Summary:   "reset failed",
+		Details: map[string]string{
+			"namespace": "prod",
+			"type":      "ResetFailed",
+			"accountId": "123456789012",
+			"error":     "nuke failed",
+		},
+	}, driver.triggered["dce-prod-ResetFailed-123456789012"])
+	assert.Equal(t, SeverityCritical, driver.triggered["dce-prod-ReadyPoolExhausted"].Severity)
+}
+
+func TestResolve(t *testing.T) {
+	driver := &testDriver{triggered: map[string]*Alert{}}
+	svc, err := NewService(NewServiceInput{Namespace: "prod", Driver: driver})
+	assert.Nil(t, err)
+
+	err = svc.Resolve(TypeBudgetEnforcementFailed, "123456789012")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dce-prod-BudgetEnforcementFailed-123456789012"}, driver.resolved)
+}
+
+func TestDriverError(t *testing.T) {
+	driver := &testDriver{triggered: map[string]*Alert{}, err: fmt.Errorf("unavailable")}
+	svc, err := NewService(NewServiceInput{Namespace: "prod", Driver: driver})
+	assert.Nil(t, err)
+
+	err = svc.Trigger(&Alert{Type: TypeResetFailed, AccountID: "123456789012"})
+
+	assert.EqualError(t, err, "failed to trigger ResetFailed alert")
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NewServiceInput
+		expErr string
+		expNil bool
+	}{
+		{
+			name:   "should disable alerting without a driver",
+			input:  NewServiceInput{},
+			expNil: true,
+		},
+		{
+			name:  "should create a PagerDuty driver",
+			input: NewServiceInput{DriverName: "pagerduty", IntegrationKey: "key"},
+		},
+		{
+			name:  "should create an Opsgenie driver",
+			input: NewServiceInput{DriverName: "Opsgenie", IntegrationKey: "key"},
+		},
+		{
+			name:   "should require an integration key",
+			input:  NewServiceInput{DriverName: "pagerduty"},
+			expErr: "alert validation error: an integration key is required for the pagerduty driver",
+		},
+		{
+			name:   "should reject unknown drivers",
+			input:  NewServiceInput{DriverName: "pager", IntegrationKey: "key"},
+			expErr: "alert validation error: unknown alert driver \"pager\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := NewService(tt.input)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expNil, svc.driver == nil)
+			// A disabled service ignores alerts
+			if tt.expNil {
+				assert.Nil(t, svc.Trigger(&Alert{Type: TypeResetFailed}))
+				assert.Nil(t, svc.Resolve(TypeResetFailed, ""))
+			}
+		})
+	}
+}
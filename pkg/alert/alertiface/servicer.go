@@ -0,0 +1,15 @@
+//
+
+package alertiface
+
+import (
+	"github.com/Optum/dce/pkg/alert"
+)
+
+// Servicer sends alerts for operational failures
+type Servicer interface {
+	// Trigger opens an incident for the alert
+	Trigger(data *alert.Alert) error
+	// Resolve closes the incident for the alert type and account
+	Resolve(alertType alert.Type, accountID string) error
+}
@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultOpsgenieURL is the Opsgenie API URL. Accounts on the EU instance
+// use https://api.eu.opsgenie.com
+const DefaultOpsgenieURL = "https://api.opsgenie.com"
+
+// Opsgenie messages are truncated by Opsgenie past this length
+const opsgenieMaxMessageLength = 130
+
+// Opsgenie sends alerts to Opsgenie using the Alert API
+type Opsgenie struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+// NewOpsgenie creates an Opsgenie driver. The API key is the key of an API
+// integration. The URL defaults to DefaultOpsgenieURL when empty.
+func NewOpsgenie(apiKey string, apiURL string, httpClient *http.Client) *Opsgenie {
+	if apiURL == "" {
+		apiURL = DefaultOpsgenieURL
+	}
+	return &Opsgenie{
+		apiKey:     apiKey,
+		url:        apiURL,
+		httpClient: httpClient,
+	}
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+}
+
+// Trigger creates an Opsgenie alert. The deduplication key is used as the
+// alias, so Opsgenie adds repeated alerts to the open alert.
+func (o *Opsgenie) Trigger(dedupKey string, alert *Alert) error {
+	message := alert.Summary
+	if len(message) > opsgenieMaxMessageLength {
+		message = message[:opsgenieMaxMessageLength]
+	}
+	priority := "P2"
+	switch alert.Severity {
+	case SeverityCritical:
+		priority = "P1"
+	case SeverityWarning:
+		priority = "P3"
+	}
+	return o.send("create", "/v2/alerts", &opsgenieAlert{
+		Message:     message,
+		Alias:       dedupKey,
+		Description: alert.Summary,
+		Details:     alert.Details,
+		Tags:        []string{"dce", string(alert.Type)},
+		Source:      "DCE",
+		Priority:    priority,
+	})
+}
+
+// Resolve closes the Opsgenie alert with the deduplication key as its alias
+func (o *Opsgenie) Resolve(dedupKey string) error {
+	path := "/v2/alerts/" + url.PathEscape(dedupKey) + "/close?identifierType=alias"
+	return o.send("close", path, map[string]string{"source": "DCE"})
+}
+
+func (o *Opsgenie) send(action string, path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, o.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.apiKey)
+
+	res, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusAccepted {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("opsgenie %s failed (%s): %s", action, res.Status, resBody)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package alert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpsgenieTrigger(t *testing.T) {
+	var gotAuth, gotPath string
+	var got opsgenieAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	summary := "DCE has no Ready accounts to lease. " + strings.Repeat("x", 120)
+	driver := NewOpsgenie("api-key", server.URL, server.Client())
+	err := driver.Trigger("dce-prod-ReadyPoolExhausted", &Alert{
+		Type:     TypeReadyPoolExhausted,
+		Severity: SeverityCritical,
+		Summary:  summary,
+		Details:  map[string]string{"namespace": "prod"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "GenieKey api-key", gotAuth)
+	assert.Equal(t, "/v2/alerts", gotPath)
+	assert.Equal(t, opsgenieAlert{
+		Message:     summary[:130],
+		Alias:       "dce-prod-ReadyPoolExhausted",
+		Description: summary,
+		Details:     map[string]string{"namespace": "prod"},
+		Tags:        []string{"dce", "ReadyPoolExhausted"},
+		Source:      "DCE",
+		Priority:    "P1",
+	}, got)
+}
+
+func TestOpsgenieWarningPriority(t *testing.T) {
+	var got opsgenieAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	driver := NewOpsgenie("api-key", server.URL, server.Client())
+	err := driver.Trigger("dce-prod-ReadyPoolLow", &Alert{
+		Type:     TypeReadyPoolLow,
+		Severity: SeverityWarning,
+		Summary:  "DCE is running low on Ready accounts",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "P3", got.Priority)
+}
+
+func TestOpsgenieResolve(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	driver := NewOpsgenie("api-key", server.URL, server.Client())
+	err := driver.Resolve("dce-prod-ResetFailed-123456789012")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/v2/alerts/dce-prod-ResetFailed-123456789012/close", gotPath)
+	assert.Equal(t, "identifierType=alias", gotQuery)
+}
+
+func TestOpsgenieError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Key format is not valid!"}`))
+	}))
+	defer server.Close()
+
+	driver := NewOpsgenie("api-key", server.URL, server.Client())
+	err := driver.Trigger("dce-prod-ReadyPoolExhausted", &Alert{Type: TypeReadyPoolExhausted})
+
+	assert.EqualError(t, err, `opsgenie create failed (401 Unauthorized): {"message": "Key format is not valid!"}`)
+}
@@ -0,0 +1,93 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultPagerDutyURL is the PagerDuty Events API URL
+const DefaultPagerDutyURL = "https://events.pagerduty.com"
+
+// PagerDuty sends alerts to a PagerDuty service using the Events API v2
+type PagerDuty struct {
+	routingKey string
+	url        string
+	httpClient *http.Client
+}
+
+// NewPagerDuty creates a PagerDuty driver. The routing key is the integration
+// key of an Events API v2 integration on the service. The URL defaults to
+// DefaultPagerDutyURL when empty.
+func NewPagerDuty(routingKey string, apiURL string, httpClient *http.Client) *PagerDuty {
+	if apiURL == "" {
+		apiURL = DefaultPagerDutyURL
+	}
+	return &PagerDuty{
+		routingKey: routingKey,
+		url:        apiURL,
+		httpClient: httpClient,
+	}
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Class         string            `json:"class"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Trigger opens a PagerDuty incident
+func (p *PagerDuty) Trigger(dedupKey string, alert *Alert) error {
+	return p.send(&pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    dedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:       alert.Summary,
+			Source:        "DCE",
+			Severity:      alert.Severity,
+			Component:     alert.AccountID,
+			Class:         string(alert.Type),
+			CustomDetails: alert.Details,
+		},
+	})
+}
+
+// Resolve resolves a PagerDuty incident
+func (p *PagerDuty) Resolve(dedupKey string) error {
+	return p.send(&pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "resolve",
+		DedupKey:    dedupKey,
+	})
+}
+
+func (p *PagerDuty) send(event *pagerDutyEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	res, err := p.httpClient.Post(p.url+"/v2/enqueue", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusAccepted {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("pagerduty %s failed (%s): %s", event.EventAction, res.Status, resBody)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerDutyTrigger(t *testing.T) {
+	var gotPath string
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "dce-prod-ResetFailed-123456789012"}`))
+	}))
+	defer server.Close()
+
+	driver := NewPagerDuty("routing-key", server.URL, server.Client())
+	err := driver.Trigger("dce-prod-ResetFailed-123456789012", &Alert{
+		Type:      TypeResetFailed,
+		AccountID: "123456789012",
+		Severity:  SeverityError,
+		Summary:   "reset failed",
+		Details:   map[string]string{"error": "nuke failed"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/v2/enqueue", gotPath)
+	assert.Equal(t, map[string]interface{}{
+		"routing_key":  "routing-key",
+		"event_action": "trigger",
+		"dedup_key":    "dce-prod-ResetFailed-123456789012",
+		"payload": map[string]interface{}{
+			"summary":        "reset failed",
+			"source":         "DCE",
+			"severity":       "error",
+			"component":      "123456789012",
+			"class":          "ResetFailed",
+			"custom_details": map[string]interface{}{"error": "nuke failed"},
+		},
+	}, got)
+}
+
+func TestPagerDutyResolve(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	driver := NewPagerDuty("routing-key", server.URL, server.Client())
+	err := driver.Resolve("dce-prod-ReadyPoolExhausted")
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"routing_key":  "routing-key",
+		"event_action": "resolve",
+		"dedup_key":    "dce-prod-ReadyPoolExhausted",
+	}, got)
+}
+
+func TestPagerDutyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": "invalid event"}`))
+	}))
+	defer server.Close()
+
+	driver := NewPagerDuty("routing-key", server.URL, server.Client())
+	err := driver.Resolve("dce-prod-ReadyPoolExhausted")
+
+	assert.EqualError(t, err, `pagerduty resolve failed (400 Bad Request): {"status": "invalid event"}`)
+}
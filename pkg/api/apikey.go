@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Optum/dce/pkg/apikey/apikeyiface"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
+)
+
+// APIKeyHeader is the header machine clients send their API key in
+const APIKeyHeader = "X-Dce-Api-Key"
+
+// APIKeyMiddleware limits requests made with an API key to the key's scopes.
+// Requests signed with the API client role must have a key.
+type APIKeyMiddleware struct {
+	GorillaMuxAdapter *gorillamux.GorillaMuxAdapter
+	APIKeyService     apikeyiface.Servicer
+	// ClientRoleName is the IAM role machine clients call the API with
+	ClientRoleName string `env:"API_CLIENT_ROLE_NAME" envDefault:""`
+}
+
+// Middleware authenticates the API key of a request, and authorizes the
+// request with the key's scopes
+func (a *APIKeyMiddleware) Middleware(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(APIKeyHeader)
+		if value == "" {
+			if a.ClientRoleName != "" {
+				reqCtx, err := a.GorillaMuxAdapter.GetAPIGatewayContext(r)
+				if err != nil {
+					log.Printf("Failed to parse context object from request: %s", err)
+					WriteAPIErrorResponse(w,
+						errors.NewInternalServer("Internal server error", err),
+					)
+					return
+				}
+				if strings.Contains(reqCtx.Identity.UserArn, ":assumed-role/"+a.ClientRoleName+"/") {
+					WriteAPIErrorResponse(w,
+						errors.NewUnathorizedError(fmt.Sprintf("An api key is required in the %s header", APIKeyHeader)),
+					)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, err := a.APIKeyService.Authenticate(value)
+		if err != nil {
+			WriteAPIErrorResponse(w, err)
+			return
+		}
+		if !key.Allows(r.Method, r.URL.Path) {
+			log.Printf("API key %s (%s) attempted %s %s, but was not authorized", key.ID, key.Name, r.Method, r.URL.Path)
+			WriteAPIErrorResponse(w,
+				errors.NewUnathorizedError(fmt.Sprintf("API key [%s] is not authorized to %s %s", key.Name, r.Method, r.URL.Path)),
+			)
+			return
+		}
+
+		// Within its scopes, a key acts as an admin, eg. to lease accounts
+		// for any principal
+		user := &User{
+			Username: "apikey/" + key.Name,
+			Role:     AdminGroupName,
+		}
+		ctx := context.WithValue(r.Context(), UserCtxKey, user)
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/apikey"
+	apikeymocks "github.com/Optum/dce/pkg/apikey/apikeyiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	apiKeySvc := &apikeymocks.Servicer{}
+	apiKeySvc.On("Authenticate", "key-1.secret").
+		Return(&apikey.APIKey{ID: "key-1", Name: "ci", Scopes: []apikey.Scope{apikey.ScopeLeasesRead}}, nil)
+	apiKeySvc.On("Authenticate", "key-1.wrong").
+		Return(nil, errors.NewUnathorizedError("invalid api key"))
+
+	var user *api.User
+	r := api.NewRouter(api.Routes{
+		api.Route{"ListLeases", "GET", "/leases", api.EmptyQueryString, func(w http.ResponseWriter, r *http.Request) {
+			user, _ = r.Context().Value(api.UserCtxKey).(*api.User)
+			w.WriteHeader(http.StatusOK)
+		}},
+		api.Route{"CreateLease", "POST", "/leases", api.EmptyQueryString, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}},
+	})
+	muxLambda := gorillamux.New(r)
+	middleware := &api.APIKeyMiddleware{
+		GorillaMuxAdapter: muxLambda,
+		APIKeyService:     apiKeySvc,
+		ClientRoleName:    "dce-api-client",
+	}
+	r.Use(middleware.Middleware)
+
+	tests := []struct {
+		name    string
+		method  string
+		key     string
+		userArn string
+		expCode int
+		expUser *api.User
+	}{
+		{
+			name:    "should allow requests within the key's scopes",
+			method:  "GET",
+			key:     "key-1.secret",
+			expCode: 200,
+			expUser: &api.User{Username: "apikey/ci", Role: api.AdminGroupName},
+		},
+		{
+			name:    "should deny requests outside the key's scopes",
+			method:  "POST",
+			key:     "key-1.secret",
+			expCode: 401,
+		},
+		{
+			name:    "should deny invalid keys",
+			method:  "GET",
+			key:     "key-1.wrong",
+			expCode: 401,
+		},
+		{
+			name:    "should deny the client role without a key",
+			method:  "GET",
+			userArn: "arn:aws:sts::123456789012:assumed-role/dce-api-client/ci",
+			expCode: 401,
+		},
+		{
+			name:    "should allow other callers without a key",
+			method:  "POST",
+			userArn: "arn:aws:sts::123456789012:assumed-role/dce-admin/jdoe",
+			expCode: 201,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user = nil
+			headers := map[string]string{}
+			if tt.key != "" {
+				headers[api.APIKeyHeader] = tt.key
+			}
+			resp, err := muxLambda.Proxy(events.APIGatewayProxyRequest{
+				HTTPMethod: tt.method,
+				Path:       "/leases",
+				Headers:    headers,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Identity: events.APIGatewayRequestIdentity{UserArn: tt.userArn},
+				},
+			})
+			require.Nil(t, err)
+			assert.Equal(t, tt.expCode, resp.StatusCode)
+			assert.Equal(t, tt.expUser, user)
+		})
+	}
+}
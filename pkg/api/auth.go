@@ -0,0 +1,162 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	// AuthProviderIAM authenticates requests with IAM SigV4 signatures
+	AuthProviderIAM = "iam"
+	// AuthProviderCognito authenticates requests with Cognito identities,
+	// and is the default
+	AuthProviderCognito = "cognito"
+	// AuthProviderOIDC authenticates requests with the bearer tokens of a
+	// generic OpenID Connect provider, verified by the OIDC authorizer
+	AuthProviderOIDC = "oidc"
+)
+
+// AuthConfig selects the provider which identifies the users of API
+// requests
+type AuthConfig struct {
+	Provider string `env:"AUTH_PROVIDER" envDefault:"cognito"`
+	// IAMAdminPrincipals are the ARNs of the IAM users and roles which are
+	// admins, when the provider is iam.  Every caller is an admin when
+	// there are none.
+	IAMAdminPrincipals []string `env:"IAM_ADMIN_PRINCIPALS" envSeparator:","`
+	// OIDCAdminGroups are the groups whose members are admins, when the
+	// provider is oidc
+	OIDCAdminGroups []string `env:"OIDC_ADMIN_GROUPS" envSeparator:","`
+}
+
+// NewUserDetailer creates the user detailer of the configured provider.
+// The Cognito user detailer is only used when the provider is cognito.
+func (c *AuthConfig) NewUserDetailer(cognito *UserDetails) (UserDetailer, error) {
+	switch c.Provider {
+	case AuthProviderCognito, "":
+		if cognito == nil {
+			return nil, fmt.Errorf("the cognito auth provider requires the cognito user pool")
+		}
+		return cognito, nil
+	case AuthProviderIAM:
+		return &IAMUserDetails{AdminPrincipals: c.IAMAdminPrincipals}, nil
+	case AuthProviderOIDC:
+		return &OIDCUserDetails{AdminGroups: c.OIDCAdminGroups}, nil
+	}
+	return nil, fmt.Errorf("unknown auth provider %q, must be one of %s, %s or %s",
+		c.Provider, AuthProviderIAM, AuthProviderCognito, AuthProviderOIDC)
+}
+
+// IAMUserDetails gets user information from the IAM principal which signed
+// the request
+type IAMUserDetails struct {
+	AdminPrincipals []string
+}
+
+// GetUser returns an admin when the caller is one of the admin principals,
+// and otherwise a user named after the caller, eg. "jdoe" for the IAM user
+// "arn:aws:iam::123456789012:user/jdoe", or the session name of an assumed
+// role.  Every caller is an admin when there are no admin principals.
+func (u *IAMUserDetails) GetUser(reqCtx *events.APIGatewayProxyRequestContext) *User {
+	if len(u.AdminPrincipals) == 0 {
+		return &User{
+			Role: AdminGroupName,
+		}
+	}
+
+	arn := reqCtx.Identity.UserArn
+	if arn == "" {
+		return &User{}
+	}
+	segments := strings.Split(arn, "/")
+	user := &User{
+		Role:     UserGroupName,
+		Username: segments[len(segments)-1],
+	}
+	for _, principal := range u.AdminPrincipals {
+		if isPrincipal(arn, strings.TrimSpace(principal)) {
+			user.Role = AdminGroupName
+			break
+		}
+	}
+	return user
+}
+
+// isPrincipal is true when the caller's ARN is the principal, or a session
+// of the principal's role
+func isPrincipal(arn string, principal string) bool {
+	if principal == "" {
+		return false
+	}
+	if arn == principal {
+		return true
+	}
+	// arn:aws:iam::123456789012:role/Admin is assumed as
+	// arn:aws:sts::123456789012:assumed-role/Admin/<session>
+	parts := strings.SplitN(principal, ":", 6)
+	if len(parts) != 6 || parts[2] != "iam" || !strings.HasPrefix(parts[5], "role/") {
+		return false
+	}
+	roleSegments := strings.Split(parts[5], "/")
+	assumedRole := fmt.Sprintf("%s:%s:sts::%s:assumed-role/%s/",
+		parts[0], parts[1], parts[4], roleSegments[len(roleSegments)-1])
+	return strings.HasPrefix(arn, assumedRole)
+}
+
+// OIDCUserDetails gets user information from the context set by the OIDC
+// authorizer, after it verified the request's token
+type OIDCUserDetails struct {
+	AdminGroups []string
+}
+
+// GetUser returns the user named in the token, who is an admin when they're
+// a member of any of the admin groups
+func (u *OIDCUserDetails) GetUser(reqCtx *events.APIGatewayProxyRequestContext) *User {
+	username, _ := reqCtx.Authorizer["username"].(string)
+	if username == "" {
+		return &User{}
+	}
+	groups, _ := reqCtx.Authorizer["groups"].(string)
+	user := &User{
+		Role:     UserGroupName,
+		Username: username,
+		Groups:   splitGroups(groups),
+	}
+	if u.IsAdmin(user.Groups) {
+		user.Role = AdminGroupName
+	}
+	return user
+}
+
+// IsAdmin is true when any of the groups is an admin group
+func (u *OIDCUserDetails) IsAdmin(groups []string) bool {
+	for _, group := range groups {
+		for _, adminGroup := range u.AdminGroups {
+			if group == strings.TrimSpace(adminGroup) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PrincipalUserDetails normalizes the usernames of another user detailer
+// with the format of principal IDs, so users match the principals of their
+// leases however their identity provider spells them
+type PrincipalUserDetails struct {
+	UserDetailer UserDetailer
+	Format       *principal.IDFormat
+}
+
+// GetUser returns the user of the other user detailer, with a normalized
+// username
+func (u *PrincipalUserDetails) GetUser(reqCtx *events.APIGatewayProxyRequestContext) *User {
+	user := u.UserDetailer.GetUser(reqCtx)
+	if user != nil {
+		user.Username = u.Format.Normalize(user.Username)
+	}
+	return user
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserDetailer(t *testing.T) {
+	cognito := &UserDetails{}
+
+	userDetailer, err := (&AuthConfig{Provider: AuthProviderCognito}).NewUserDetailer(cognito)
+	require.Nil(t, err)
+	assert.Equal(t, cognito, userDetailer)
+
+	userDetailer, err = (&AuthConfig{Provider: AuthProviderIAM, IAMAdminPrincipals: []string{"arn"}}).NewUserDetailer(nil)
+	require.Nil(t, err)
+	assert.Equal(t, &IAMUserDetails{AdminPrincipals: []string{"arn"}}, userDetailer)
+
+	userDetailer, err = (&AuthConfig{Provider: AuthProviderOIDC, OIDCAdminGroups: []string{"dce-admins"}}).NewUserDetailer(nil)
+	require.Nil(t, err)
+	assert.Equal(t, &OIDCUserDetails{AdminGroups: []string{"dce-admins"}}, userDetailer)
+
+	_, err = (&AuthConfig{Provider: AuthProviderCognito}).NewUserDetailer(nil)
+	assert.EqualError(t, err, "the cognito auth provider requires the cognito user pool")
+
+	_, err = (&AuthConfig{Provider: "saml"}).NewUserDetailer(nil)
+	assert.EqualError(t, err, "unknown auth provider \"saml\", must be one of iam, cognito or oidc")
+}
+
+func TestIAMUserDetails(t *testing.T) {
+	tests := []struct {
+		name            string
+		adminPrincipals []string
+		userArn         string
+		expUser         *User
+	}{
+		{
+			name:    "should treat every caller as an admin without admin principals",
+			userArn: "arn:aws:iam::123456789012:user/jdoe",
+			expUser: &User{Role: AdminGroupName},
+		},
+		{
+			name:            "should return admin users",
+			adminPrincipals: []string{"arn:aws:iam::123456789012:user/admin"},
+			userArn:         "arn:aws:iam::123456789012:user/admin",
+			expUser:         &User{Username: "admin", Role: AdminGroupName},
+		},
+		{
+			name:            "should return the sessions of admin roles",
+			adminPrincipals: []string{"arn:aws:iam::123456789012:role/path/DCEAdmin"},
+			userArn:         "arn:aws:sts::123456789012:assumed-role/DCEAdmin/jdoe",
+			expUser:         &User{Username: "jdoe", Role: AdminGroupName},
+		},
+		{
+			name:            "should return other callers as users",
+			adminPrincipals: []string{"arn:aws:iam::123456789012:role/DCEAdmin"},
+			userArn:         "arn:aws:sts::123456789012:assumed-role/DCEAdminOther/jdoe",
+			expUser:         &User{Username: "jdoe", Role: UserGroupName},
+		},
+		{
+			name:            "should not authorize unsigned requests",
+			adminPrincipals: []string{"arn:aws:iam::123456789012:role/DCEAdmin"},
+			expUser:         &User{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userDetailer := &IAMUserDetails{AdminPrincipals: tt.adminPrincipals}
+			user := userDetailer.GetUser(&events.APIGatewayProxyRequestContext{
+				Identity: events.APIGatewayRequestIdentity{UserArn: tt.userArn},
+			})
+			assert.Equal(t, tt.expUser, user)
+		})
+	}
+}
+
+func TestOIDCUserDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		authorizer map[string]interface{}
+		expUser    *User
+	}{
+		{
+			name:       "should return members of admin groups as admins",
+			authorizer: map[string]interface{}{"username": "jdoe@example.com", "groups": "developers,dce-admins"},
+			expUser:    &User{Username: "jdoe@example.com", Role: AdminGroupName, Groups: []string{"developers", "dce-admins"}},
+		},
+		{
+			name:       "should return other users",
+			authorizer: map[string]interface{}{"username": "jdoe@example.com", "groups": "developers"},
+			expUser:    &User{Username: "jdoe@example.com", Role: UserGroupName, Groups: []string{"developers"}},
+		},
+		{
+			name:    "should not authorize requests without a username",
+			expUser: &User{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userDetailer := &OIDCUserDetails{AdminGroups: []string{"dce-admins"}}
+			user := userDetailer.GetUser(&events.APIGatewayProxyRequestContext{
+				Authorizer: tt.authorizer,
+			})
+			assert.Equal(t, tt.expUser, user)
+		})
+	}
+}
+
+func TestPrincipalUserDetails(t *testing.T) {
+	format, err := principal.NewIDFormat(principal.NewIDFormatInput{
+		Normalization: []string{principal.NormalizeLowercaseEmails},
+	})
+	assert.Nil(t, err)
+
+	userDetailer := &PrincipalUserDetails{
+		UserDetailer: &OIDCUserDetails{AdminGroups: []string{"dce-admins"}},
+		Format:       format,
+	}
+	user := userDetailer.GetUser(&events.APIGatewayProxyRequestContext{
+		Authorizer: map[string]interface{}{"username": "JDoe@Example.com", "groups": "developers"},
+	})
+	assert.Equal(t, &User{Username: "jdoe@example.com", Role: UserGroupName, Groups: []string{"developers"}}, user)
+}
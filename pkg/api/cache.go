@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cache adds HTTP caching headers to the responses of read endpoints, eg.
+// lists polled by dashboards.  Clients may reuse a response for MaxAge
+// seconds, then revalidate it with its ETag or Last-Modified date, which is
+// answered with 304 Not Modified when the response hasn't changed.
+type Cache struct {
+	// MaxAge is how long clients may reuse responses without revalidating
+	// them, in seconds.  Responses are revalidated every time when it's 0.
+	MaxAge int `env:"API_CACHE_MAX_AGE" envDefault:"0"`
+}
+
+// SetLastModified sets the Last-Modified header of a response, from the
+// latest epoch timestamp of what it returns.  Handlers wrapped by the cache
+// call it before they write the response.
+func SetLastModified(w http.ResponseWriter, lastModifiedOn int64) {
+	if lastModifiedOn > 0 {
+		w.Header().Set("Last-Modified", time.Unix(lastModifiedOn, 0).UTC().Format(http.TimeFormat))
+	}
+}
+
+// Handler adds caching headers to the successful responses of a handler.
+// Responses are buffered, to compute their ETag, except exports, which are
+// written as they're read.
+func (c *Cache) Handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cw := &cacheWriter{w: w}
+		next(cw, r)
+		if !cw.buffered() {
+			return
+		}
+
+		body := cw.body.Bytes()
+		sum := sha256.Sum256(body)
+		etag := fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:16]))
+		// Responses depend on the caller, so only the caller's client
+		// may cache them
+		w.Header().Set("Cache-Control", c.cacheControl())
+		w.Header().Set("ETag", etag)
+
+		if notModified(r, etag, w.Header().Get("Last-Modified")) {
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("error writing cached response: %s", err)
+		}
+	}
+}
+
+func (c *Cache) cacheControl() string {
+	if c.MaxAge <= 0 {
+		return "private, no-cache"
+	}
+	return fmt.Sprintf("private, max-age=%d", c.MaxAge)
+}
+
+// notModified is true when the request's conditions match the response.
+// If-Modified-Since is ignored when the request has an If-None-Match, as
+// the ETag is more precise.
+func notModified(r *http.Request, etag string, lastModified string) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, t := range strings.Split(ifNoneMatch, ",") {
+			t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+			if t == etag || t == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified == "" {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(ifModifiedSince)
+}
+
+// cacheWriter buffers successful responses, and passes errors and exports
+// straight through
+type cacheWriter struct {
+	w           http.ResponseWriter
+	body        bytes.Buffer
+	status      int
+	passThrough bool
+}
+
+func (cw *cacheWriter) Header() http.Header {
+	return cw.w.Header()
+}
+
+func (cw *cacheWriter) WriteHeader(status int) {
+	if cw.status != 0 {
+		return
+	}
+	cw.status = status
+	if status != http.StatusOK || cw.w.Header().Get("Content-Disposition") != "" {
+		cw.passThrough = true
+		cw.w.WriteHeader(status)
+	}
+}
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.passThrough {
+		return cw.w.Write(b)
+	}
+	return cw.body.Write(b)
+}
+
+// buffered is true when the response was buffered, and is still to be
+// written
+func (cw *cacheWriter) buffered() bool {
+	return cw.status != 0 && !cw.passThrough
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	list := func(w http.ResponseWriter, r *http.Request) {
+		SetLastModified(w, 1580000000)
+		WriteAPIResponse(w, http.StatusOK, []string{"a", "b"})
+	}
+	get := func(cache *Cache, handler http.HandlerFunc, headers map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/leases", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		cache.Handler(handler)(w, r)
+		return w
+	}
+
+	t.Run("should add caching headers", func(t *testing.T) {
+		w := get(&Cache{MaxAge: 30}, list, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[\"a\",\"b\"]\n", w.Body.String())
+		assert.Equal(t, "private, max-age=30", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "Sun, 26 Jan 2020 00:53:20 GMT", w.Header().Get("Last-Modified"))
+		assert.Len(t, w.Header().Get("ETag"), 34)
+	})
+
+	t.Run("should revalidate every response without a max age", func(t *testing.T) {
+		w := get(&Cache{}, list, nil)
+		assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("should not return unchanged responses", func(t *testing.T) {
+		etag := get(&Cache{}, list, nil).Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		w := get(&Cache{}, list, map[string]string{"If-None-Match": "\"other\", " + etag})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+
+		w = get(&Cache{}, list, map[string]string{"If-Modified-Since": "Sun, 26 Jan 2020 00:53:20 GMT"})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("should return changed responses", func(t *testing.T) {
+		w := get(&Cache{}, list, map[string]string{"If-None-Match": "\"other\""})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[\"a\",\"b\"]\n", w.Body.String())
+
+		w = get(&Cache{}, list, map[string]string{"If-Modified-Since": "Sat, 25 Jan 2020 00:00:00 GMT"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// The ETag wins over the date
+		w = get(&Cache{}, list, map[string]string{
+			"If-None-Match":     "\"other\"",
+			"If-Modified-Since": "Sun, 26 Jan 2020 00:53:20 GMT",
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		w := get(&Cache{MaxAge: 30}, func(w http.ResponseWriter, r *http.Request) {
+			WriteAPIErrorResponse(w, errors.NewNotFound("lease", "abc123"))
+		}, map[string]string{"If-None-Match": "*"})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("should not buffer exports", func(t *testing.T) {
+		w := get(&Cache{MaxAge: 30}, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Disposition", "attachment; filename=\"leases.csv\"")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("id\n"))
+		}, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "id\n", w.Body.String())
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
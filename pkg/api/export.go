@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// ExportFormatParam is the query parameter which exports every item matching
+// a list request, instead of a page of them
+const ExportFormatParam = "format"
+
+// Export formats
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// ExportFormat returns the format of the export requested with the format
+// query parameter, or an Accept: text/csv header, or "" when a page of items
+// is requested.  The format parameter is removed from the query, so the rest
+// can be decoded as the filter of the list.
+func ExportFormat(r *http.Request) (string, error) {
+	query := r.URL.Query()
+	format := query.Get(ExportFormatParam)
+	if format != "" {
+		query.Del(ExportFormatParam)
+		r.URL.RawQuery = query.Encode()
+		if format != ExportCSV && format != ExportJSON {
+			return "", errors.NewBadRequest("format must be json or csv")
+		}
+		return format, nil
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == "text/csv" {
+			return ExportCSV, nil
+		}
+	}
+	return "", nil
+}
+
+// CSVWriter writes items as CSV, one row at a time.  Each column is a field
+// of the JSON encoding of the items.  Text and numbers are written as they
+// are, and other values, such as metadata, as JSON.
+type CSVWriter struct {
+	w       *csv.Writer
+	columns []string
+	started bool
+}
+
+// NewCSVWriter creates a CSVWriter which writes the columns to w
+func NewCSVWriter(w io.Writer, columns []string) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), columns: columns}
+}
+
+// Write writes an item as a row
+func (c *CSVWriter) Write(item interface{}) error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		row[i] = csvValue(fields[column])
+	}
+	return c.w.Write(row)
+}
+
+// Close writes the header, if there were no rows, and flushes the rows
+func (c *CSVWriter) Close() error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *CSVWriter) writeHeader() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	return c.w.Write(c.columns)
+}
+
+func csvValue(raw json.RawMessage) string {
+	value := strings.TrimSpace(string(raw))
+	if value == "" || value == "null" {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	return value
+}
+
+// ItemWriter writes the items of an export
+type ItemWriter interface {
+	Write(item interface{}) error
+	Close() error
+}
+
+// ExportURL is the link to download an export which was uploaded to S3
+type ExportURL struct {
+	URL       string `json:"url"`
+	ExpiresOn int64  `json:"expiresOn"`
+}
+
+// Exporter writes every item matching a list request as CSV or JSON.
+// Exports larger than MaxInlineBytes are uploaded to S3 instead, and a URL to
+// download them is returned, as Lambda responses are limited to 6 MB.
+type Exporter struct {
+	S3 s3iface.S3API
+	// Bucket is where large exports are uploaded to
+	Bucket string `env:"ARTIFACTS_BUCKET" envDefault:""`
+	// Prefix is the key prefix of uploaded exports, which are expired by the
+	// bucket's lifecycle rules
+	Prefix         string        `env:"EXPORTS_PREFIX" envDefault:"exports"`
+	MaxInlineBytes int           `env:"EXPORT_MAX_INLINE_BYTES" envDefault:"5000000"`
+	URLExpiry      time.Duration `env:"EXPORT_URL_EXPIRY" envDefault:"15m"`
+}
+
+// Export writes the items read by read, which passes each item to write, as
+// the response.  name is the file name of the export, without the extension.
+func (e *Exporter) Export(w http.ResponseWriter, name string, format string, columns []string, read func(write func(item interface{}) error) error) {
+	body := &bytes.Buffer{}
+	var writer ItemWriter = NewJSONArrayWriter(body)
+	contentType := "application/json"
+	if format == ExportCSV {
+		writer = NewCSVWriter(body, columns)
+		contentType = "text/csv"
+	}
+	err := read(writer.Write)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		WriteAPIErrorResponse(w, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	fileName := fmt.Sprintf("%s-%s.%s", name, now.Format("20060102T150405Z"), format)
+	if e.MaxInlineBytes > 0 && body.Len() > e.MaxInlineBytes {
+		url, err := e.upload(body, fileName, contentType)
+		if err != nil {
+			WriteAPIErrorResponse(w, err)
+			return
+		}
+		WriteAPIResponse(w, http.StatusOK, ExportURL{
+			URL:       url,
+			ExpiresOn: now.Add(e.URLExpiry).Unix(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	w.WriteHeader(http.StatusOK)
+	_, err = body.WriteTo(w)
+	if err != nil {
+		log.Printf("error writing %s export: %s", name, err)
+	}
+}
+
+// upload writes the export to the exports bucket, and signs a URL to download
+// it.  Exports are expired by the bucket's lifecycle rules.
+func (e *Exporter) upload(body *bytes.Buffer, fileName string, contentType string) (string, error) {
+	if e.S3 == nil || e.Bucket == "" {
+		return "", errors.NewInternalServer(
+			fmt.Sprintf("export is larger than %d bytes, and no bucket is configured to upload it to", e.MaxInlineBytes), nil)
+	}
+
+	key := fmt.Sprintf("%s/%s", e.Prefix, fileName)
+	_, err := e.S3.PutObject(&s3.PutObjectInput{
+		Bucket:             aws.String(e.Bucket),
+		Key:                aws.String(key),
+		Body:               bytes.NewReader(body.Bytes()),
+		ContentType:        aws.String(contentType),
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=\"%s\"", fileName)),
+	})
+	if err != nil {
+		return "", errors.NewInternalServer("failed to upload the export", err)
+	}
+
+	req, _ := e.S3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(e.Bucket),
+		Key:    aws.String(key),
+	})
+	url, err := req.Presign(e.URLExpiry)
+	if err != nil {
+		return "", errors.NewInternalServer("failed to sign the export URL", err)
+	}
+	return url, nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type exportItem struct {
+	ID       string                 `json:"id"`
+	Amount   float64                `json:"amount,omitempty"`
+	Note     string                 `json:"note,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		accept    string
+		expFormat string
+		expQuery  string
+		expErr    string
+	}{
+		{
+			name:     "should list without a format",
+			url:      "/accounts?status=Ready",
+			accept:   "application/json",
+			expQuery: "status=Ready",
+		},
+		{
+			name:      "should export the format of the query parameter",
+			url:       "/accounts?format=csv&status=Ready",
+			expFormat: ExportCSV,
+			expQuery:  "status=Ready",
+		},
+		{
+			name:      "should export JSON",
+			url:       "/accounts?format=json",
+			accept:    "text/csv",
+			expFormat: ExportJSON,
+		},
+		{
+			name:      "should export CSV when it's accepted",
+			url:       "/accounts",
+			accept:    "text/html, text/csv;q=0.9",
+			expFormat: ExportCSV,
+		},
+		{
+			name:   "should reject other formats",
+			url:    "/accounts?format=xlsx",
+			expErr: "format must be json or csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.Header.Set("Accept", tt.accept)
+
+			format, err := ExportFormat(r)
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expFormat, format)
+			assert.Equal(t, tt.expQuery, r.URL.RawQuery)
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	t.Run("should write the columns of each item", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := NewCSVWriter(body, []string{"id", "amount", "note", "metadata"})
+
+		assert.Nil(t, writer.Write(exportItem{ID: "a", Amount: 12.5, Note: "says \"hi\", twice"}))
+		assert.Nil(t, writer.Write(exportItem{ID: "b", Metadata: map[string]interface{}{"pool": "ml"}}))
+		assert.Nil(t, writer.Close())
+
+		assert.Equal(t, "id,amount,note,metadata\n"+
+			"a,12.5,\"says \"\"hi\"\", twice\",\n"+
+			"b,,,\"{\"\"pool\"\":\"\"ml\"\"}\"\n", body.String())
+	})
+
+	t.Run("should write the header without items", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := NewCSVWriter(body, []string{"id", "amount"})
+
+		assert.Nil(t, writer.Close())
+
+		assert.Equal(t, "id,amount\n", body.String())
+	})
+}
+
+func TestExporter(t *testing.T) {
+	items := []exportItem{{ID: "a"}, {ID: "b"}}
+	read := func(write func(item interface{}) error) error {
+		for _, item := range items {
+			if err := write(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("should write small exports as the response", func(t *testing.T) {
+		exporter := &Exporter{MaxInlineBytes: 1000}
+		w := httptest.NewRecorder()
+
+		exporter.Export(w, "accounts", ExportCSV, []string{"id"}, read)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename="accounts-\d{8}T\d{6}Z\.csv"$`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id\na\nb\n", w.Body.String())
+	})
+
+	t.Run("should upload large exports to S3", func(t *testing.T) {
+		sess := session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String("us-east-1"),
+			Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		}))
+		getReq, _ := s3.New(sess).GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String("artifacts"),
+			Key:    aws.String("exports/accounts.json"),
+		})
+		s3Svc := &awsmocks.S3API{}
+		s3Svc.On("PutObject", mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+			return *input.Bucket == "artifacts" &&
+				strings.HasPrefix(*input.Key, "exports/accounts-") &&
+				strings.HasSuffix(*input.Key, ".json") &&
+				*input.ContentType == "application/json"
+		})).Return(&s3.PutObjectOutput{}, nil)
+		s3Svc.On("GetObjectRequest", mock.AnythingOfType("*s3.GetObjectInput")).Return(getReq, &s3.GetObjectOutput{})
+		exporter := &Exporter{
+			S3:             s3Svc,
+			Bucket:         "artifacts",
+			Prefix:         "exports",
+			MaxInlineBytes: 10,
+			URLExpiry:      15 * time.Minute,
+		}
+		w := httptest.NewRecorder()
+
+		exporter.Export(w, "accounts", ExportJSON, nil, read)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := ExportURL{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Contains(t, body.URL, "https://artifacts.s3.amazonaws.com/exports/accounts.json?")
+		assert.InDelta(t, time.Now().Add(15*time.Minute).Unix(), body.ExpiresOn, 5)
+		s3Svc.AssertExpectations(t)
+	})
+
+	t.Run("should fail large exports without a bucket", func(t *testing.T) {
+		exporter := &Exporter{MaxInlineBytes: 10}
+		w := httptest.NewRecorder()
+
+		exporter.Export(w, "accounts", ExportJSON, nil, read)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/gorilla/schema"
@@ -26,3 +28,20 @@ func BuildNextURL(u url.URL, i interface{}) (url.URL, error) {
 	req.RawQuery = values.Encode()
 	return req, nil
 }
+
+// DryRunParam is the query string parameter used to validate a request without
+// changing anything
+const DryRunParam = "dryRun"
+
+// ParseDryRun gets whether the request is a dry run, from its query string
+func ParseDryRun(r *http.Request) (bool, error) {
+	v := r.FormValue(DryRunParam)
+	if v == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.NewBadRequest(fmt.Sprintf("invalid value for %s: %q", DryRunParam, v))
+	}
+	return dryRun, nil
+}
@@ -24,17 +24,23 @@ func CreateLeaseResponse(lease *db.Lease) *LeaseResponse {
 // 	"BudgetNotificationEmails": ["usermsid@test.com", "managersmsid@test.com"]
 // }
 type LeaseResponse struct {
-	AccountID                string                 `json:"accountId"`
-	PrincipalID              string                 `json:"principalId"`
-	ID                       string                 `json:"id"`
-	LeaseStatus              db.LeaseStatus         `json:"leaseStatus"`
-	LeaseStatusReason        db.LeaseStatusReason   `json:"leaseStatusReason"`
-	CreatedOn                int64                  `json:"createdOn"`
-	LastModifiedOn           int64                  `json:"lastModifiedOn"`
-	BudgetAmount             float64                `json:"budgetAmount"`
-	BudgetCurrency           string                 `json:"budgetCurrency"`
-	BudgetNotificationEmails []string               `json:"budgetNotificationEmails"`
-	LeaseStatusModifiedOn    int64                  `json:"leaseStatusModifiedOn"`
-	ExpiresOn                int64                  `json:"expiresOn"`
-	Metadata                 map[string]interface{} `json:"metadata"`
+	AccountID                string                            `json:"accountId"`
+	PrincipalID              string                            `json:"principalId"`
+	ID                       string                            `json:"id"`
+	LeaseStatus              db.LeaseStatus                    `json:"leaseStatus"`
+	LeaseStatusReason        db.LeaseStatusReason              `json:"leaseStatusReason"`
+	CreatedOn                int64                             `json:"createdOn"`
+	LastModifiedOn           int64                             `json:"lastModifiedOn"`
+	Version                  int64                             `json:"version,omitempty"`
+	BudgetAmount             float64                           `json:"budgetAmount"`
+	BudgetCurrency           string                            `json:"budgetCurrency"`
+	BudgetNotificationEmails []string                          `json:"budgetNotificationEmails"`
+	LeaseStatusModifiedOn    int64                             `json:"leaseStatusModifiedOn"`
+	ExpiresOn                int64                             `json:"expiresOn"`
+	Metadata                 map[string]interface{}            `json:"metadata"`
+	BudgetThresholdsSent     []float64                         `json:"budgetThresholdsSent,omitempty"`
+	LastCheckedOn            int64                             `json:"lastCheckedOn,omitempty"`
+	SharedWith               []string                          `json:"sharedWith,omitempty"`
+	AlertSuppression         *db.LeaseAlertSuppression         `json:"alertSuppression,omitempty"`
+	CategoryBudgets          map[string]db.LeaseCategoryBudget `json:"categoryBudgets,omitempty"`
 }
@@ -7,10 +7,14 @@ package response
 // 	"secretAccessKey": "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
 // 	"sessionKey": "AQoDYXdzEJr...",
 // 	"consoleUrl": "https://aws.amazon.com/console/",
+// 	"expiresOn": 1583056800,
 // }
 type LeaseAuthResponse struct {
 	AccessKeyID     string `json:"accessKeyId"`
 	SecretAccessKey string `json:"secretAccessKey"`
 	SessionToken    string `json:"sessionToken"`
 	ConsoleURL      string `json:"consoleUrl"`
+	// ExpiresOn is when the credentials and console session expire, in
+	// epoch seconds
+	ExpiresOn int64 `json:"expiresOn,omitempty"`
 }
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// StreamPageSize is the most items read from the database at a time by list
+// handlers. Larger limits are read as several pages, so only one page of
+// items is held in memory while the response is encoded.
+var StreamPageSize int64 = 100
+
+// JSONArrayWriter encodes a JSON array one item at a time. The output is
+// the same as encoding the whole slice with a json.Encoder.
+type JSONArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+// NewJSONArrayWriter creates a JSONArrayWriter which writes to w
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return &JSONArrayWriter{w: w}
+}
+
+// Write encodes an item of the array
+func (a *JSONArrayWriter) Write(item interface{}) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	sep := ","
+	if a.count == 0 {
+		sep = "["
+	}
+	_, err = io.WriteString(a.w, sep)
+	if err != nil {
+		return err
+	}
+	_, err = a.w.Write(b)
+	if err != nil {
+		return err
+	}
+	a.count++
+	return nil
+}
+
+// Close ends the array
+func (a *JSONArrayWriter) Close() error {
+	end := "]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
+
+// ReadPages calls fetch for each page of up to StreamPageSize items, until
+// limit items were read or fetch reports there are no more pages. A limit
+// of 0 reads a single page of the default size, by calling fetch with 0.
+func ReadPages(limit int64, fetch func(pageLimit int64) (bool, error)) error {
+	if limit <= StreamPageSize {
+		_, err := fetch(limit)
+		return err
+	}
+
+	for remaining := limit; remaining > 0; remaining -= StreamPageSize {
+		pageLimit := StreamPageSize
+		if remaining < pageLimit {
+			pageLimit = remaining
+		}
+		more, err := fetch(pageLimit)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
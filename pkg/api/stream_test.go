@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONArrayWriter(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Note string `json:"note,omitempty"`
+	}
+
+	tests := []struct {
+		name  string
+		items []item
+	}{
+		{
+			name:  "should write an empty array",
+			items: []item{},
+		},
+		{
+			name:  "should write one item",
+			items: []item{{ID: "a"}},
+		},
+		{
+			name:  "should write many items",
+			items: []item{{ID: "a"}, {ID: "b", Note: "<escaped> & html"}, {ID: "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := &bytes.Buffer{}
+			err := json.NewEncoder(expected).Encode(tt.items)
+			assert.Nil(t, err)
+
+			actual := &bytes.Buffer{}
+			stream := NewJSONArrayWriter(actual)
+			for _, i := range tt.items {
+				assert.Nil(t, stream.Write(i))
+			}
+			assert.Nil(t, stream.Close())
+
+			assert.Equal(t, expected.String(), actual.String())
+		})
+	}
+}
+
+func TestReadPages(t *testing.T) {
+	defer func(size int64) { StreamPageSize = size }(StreamPageSize)
+	StreamPageSize = 10
+
+	tests := []struct {
+		name     string
+		limit    int64
+		pages    int
+		fetchErr error
+		expLimit []int64
+		expErr   string
+	}{
+		{
+			name:     "should read one page of the default size",
+			limit:    0,
+			pages:    5,
+			expLimit: []int64{0},
+		},
+		{
+			name:     "should read one page of a small limit",
+			limit:    7,
+			pages:    5,
+			expLimit: []int64{7},
+		},
+		{
+			name:     "should read pages until the limit",
+			limit:    25,
+			pages:    5,
+			expLimit: []int64{10, 10, 5},
+		},
+		{
+			name:     "should stop when there are no more pages",
+			limit:    100,
+			pages:    2,
+			expLimit: []int64{10, 10},
+		},
+		{
+			name:     "should stop on errors",
+			limit:    100,
+			pages:    5,
+			fetchErr: fmt.Errorf("failure"),
+			expLimit: []int64{10},
+			expErr:   "failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := []int64{}
+			err := ReadPages(tt.limit, func(pageLimit int64) (bool, error) {
+				limits = append(limits, pageLimit)
+				return len(limits) < tt.pages, tt.fetchErr
+			})
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expLimit, limits)
+		})
+	}
+}
@@ -20,16 +20,20 @@ type dceCtxKeyType string
 // DceCtxKey - Context Key
 const DceCtxKey dceCtxKeyType = "dce"
 
+// UserCtxKey - Context Key for the User making the request
+const UserCtxKey dceCtxKeyType = "user"
+
 // UserGroupName - Has the string to define Users
 const UserGroupName = "User"
 
 // AdminGroupName - Has a string to define Admins
 const AdminGroupName = "Admin"
 
-// User - Has the username and their role
+// User - Has the username, their role, and the groups they are a member of
 type User struct {
 	Username string
 	Role     string
+	Groups   []string
 }
 
 // Authorize returns an error if the user is not authorized to act on the principalID
@@ -42,6 +46,23 @@ func (u *User) Authorize(principalID string) error {
 	return err
 }
 
+// AuthorizeGroups returns an error if the user is not a member of any of the
+// groups.  Admins, and any user when no groups are given, are authorized.
+func (u *User) AuthorizeGroups(groups []string) error {
+	if u.Role == AdminGroupName || len(groups) == 0 {
+		return nil
+	}
+	for _, group := range groups {
+		for _, userGroup := range u.Groups {
+			if userGroup == group {
+				return nil
+			}
+		}
+	}
+	return errors.NewUnathorizedError(fmt.Sprintf("User [%s] is not a member of the groups allowed to create leases: [%s]",
+		u.Username, strings.Join(groups, ", ")))
+}
+
 // UserDetailer - used for mocking tests
 //go:generate mockery -name UserDetailer
 type UserDetailer interface {
@@ -90,6 +111,7 @@ func (u *UserDetails) GetUser(reqCtx *events.APIGatewayProxyRequestContext) *Use
 
 	for _, attribute := range users.Users[0].Attributes {
 		if *attribute.Name == "custom:roles" {
+			user.Groups = append(user.Groups, splitGroups(*attribute.Value)...)
 			if u.isUserInAdminFromList(*attribute.Value) {
 				user.Role = AdminGroupName
 				return user
@@ -97,20 +119,24 @@ func (u *UserDetails) GetUser(reqCtx *events.APIGatewayProxyRequestContext) *Use
 		}
 	}
 
-	isAdmin, err := u.isUserInAdminGroup(user.Username)
+	groups, err := u.listUserGroups(user.Username)
 	if err != nil {
 		log.Printf("Got an error when quering groups for user: %s", err)
 		return user
 	}
-	if isAdmin {
-		user.Role = AdminGroupName
-		return user
+	user.Groups = append(user.Groups, groups...)
+	for _, group := range groups {
+		if group == AdminGroupName {
+			user.Role = AdminGroupName
+			return user
+		}
 	}
 
 	return user
 }
 
-func (u *UserDetails) isUserInAdminGroup(username string) (bool, error) {
+// listUserGroups gets the names of the Cognito groups the user is a member of
+func (u *UserDetails) listUserGroups(username string) ([]string, error) {
 
 	groups, err := u.CognitoClient.AdminListGroupsForUser(&cognitoidentityprovider.AdminListGroupsForUserInput{
 		Username:   aws.String(username),
@@ -118,26 +144,37 @@ func (u *UserDetails) isUserInAdminGroup(username string) (bool, error) {
 	})
 	if err != nil {
 		log.Printf("Was not abile to query a users for its groups: %s", err)
-		return false, fmt.Errorf("Was not abile to query a users for its groups: %s", err)
+		return nil, fmt.Errorf("Was not abile to query a users for its groups: %s", err)
 	}
+	names := []string{}
 	for _, group := range groups.Groups {
-		if *group.GroupName == AdminGroupName {
-			return true, nil
-		}
+		names = append(names, *group.GroupName)
 	}
-	return false, nil
+	return names, nil
 }
 
 func (u *UserDetails) isUserInAdminFromList(groups string) bool {
 
-	for _, group := range strings.Split(groups, ",") {
-		if strings.TrimSpace(group) == u.RolesAttributesAdminName {
+	for _, group := range splitGroups(groups) {
+		if group == u.RolesAttributesAdminName {
 			return true
 		}
 	}
 	return false
 }
 
+// splitGroups splits a comma separated list of groups, such as the
+// custom:roles attribute federated from the identity provider's token
+func splitGroups(groups string) []string {
+	names := []string{}
+	for _, group := range strings.Split(groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			names = append(names, group)
+		}
+	}
+	return names
+}
+
 type UserDetailsMiddleware struct {
 	GorillaMuxAdapter *gorillamux.GorillaMuxAdapter
 	UserDetailer      UserDetailer
@@ -156,7 +193,7 @@ func (u *UserDetailsMiddleware) Middleware(next http.Handler) http.Handler {
 		}
 
 		user := u.UserDetailer.GetUser(&reqCtx)
-		ctx := context.WithValue(r.Context(), User{}, user)
+		ctx := context.WithValue(r.Context(), UserCtxKey, user)
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -370,3 +371,84 @@ func TestUser(t *testing.T) {
 		require.Equal(t, user.Role, api.UserGroupName)
 	})
 }
+
+func TestUserGroups(t *testing.T) {
+
+	mockCognitoIdp := &mocks.CognitoIdentityProviderAPI{}
+	userGetter := api.UserDetails{
+		CognitoUserPoolID:        "us_east_1-test",
+		RolesAttributesAdminName: api.AdminGroupName,
+		CognitoClient:            mockCognitoIdp,
+	}
+
+	mockCognitoIdp.On("ListUsers", mock.Anything).Return(&cognitoidentityprovider.ListUsersOutput{
+		Users: []*cognitoidentityprovider.UserType{
+			{
+				Username: aws.String("testuser"),
+				Attributes: []*cognitoidentityprovider.AttributeType{
+					{
+						Name:  aws.String("custom:roles"),
+						Value: aws.String("Engineering, Data,"),
+					},
+				},
+			},
+		},
+	}, nil)
+	mockCognitoIdp.On("AdminListGroupsForUser", mock.Anything).Return(&cognitoidentityprovider.AdminListGroupsForUserOutput{
+		Groups: []*cognitoidentityprovider.GroupType{
+			{
+				GroupName: aws.String("Users"),
+			},
+		},
+	}, nil)
+
+	user := userGetter.GetUser(&events.APIGatewayProxyRequestContext{
+		Identity: events.APIGatewayRequestIdentity{
+			CognitoIdentityPoolID:         "us_east_1-test",
+			CognitoAuthenticationProvider: "UserPoolID:CognitoSignIn:abcdef-123456",
+		},
+	})
+	require.Equal(t, api.UserGroupName, user.Role)
+	require.Equal(t, []string{"Engineering", "Data", "Users"}, user.Groups)
+}
+
+func TestAuthorizeGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   api.User
+		groups []string
+		expErr string
+	}{
+		{
+			name:   "should allow members of any of the groups",
+			user:   api.User{Username: "jdoe", Role: api.UserGroupName, Groups: []string{"Sales", "Data"}},
+			groups: []string{"Engineering", "Data"},
+		},
+		{
+			name:   "should allow admins",
+			user:   api.User{Username: "admin", Role: api.AdminGroupName},
+			groups: []string{"Engineering"},
+		},
+		{
+			name: "should allow anyone without groups",
+			user: api.User{Username: "jdoe", Role: api.UserGroupName},
+		},
+		{
+			name:   "should reject non members",
+			user:   api.User{Username: "jdoe", Role: api.UserGroupName, Groups: []string{"Sales"}},
+			groups: []string{"Engineering", "Data"},
+			expErr: "User [jdoe] is not a member of the groups allowed to create leases: [Engineering, Data]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.user.AuthorizeGroups(tt.groups)
+			if tt.expErr != "" {
+				require.EqualError(t, err, tt.expErr)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
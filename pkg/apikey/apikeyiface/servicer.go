@@ -0,0 +1,23 @@
+//
+
+package apikeyiface
+
+import (
+	"github.com/Optum/dce/pkg/apikey"
+)
+
+// Servicer manages API keys, and authenticates the requests made with them
+type Servicer interface {
+	// Enabled returns whether API keys may be used
+	Enabled() bool
+	// List returns every key
+	List() ([]*apikey.APIKey, error)
+	// Get returns a key
+	Get(ID string) (*apikey.APIKey, error)
+	// Create adds a key, returning it with its secret
+	Create(data *apikey.APIKey) (*apikey.APIKey, error)
+	// Delete revokes a key
+	Delete(ID string) (*apikey.APIKey, error)
+	// Authenticate returns the key a request was made with
+	Authenticate(value string) (*apikey.APIKey, error)
+}
@@ -0,0 +1,116 @@
+// Package apikey manages the API keys of machine clients, eg. CI systems,
+// and the scopes of the API each key may call.  Clients sign their requests
+// with the API client role, which may only call the API with a key, so they
+// aren't granted admin access to the whole API.
+package apikey
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Scope is a part of the API a key may call, as "<resource>:<access>"
+type Scope string
+
+// Scopes of the API.  Write scopes include reading the same resource.
+const (
+	ScopeLeasesRead    Scope = "leases:read"
+	ScopeLeasesWrite   Scope = "leases:write"
+	ScopeAccountsRead  Scope = "accounts:read"
+	ScopeAccountsWrite Scope = "accounts:write"
+	ScopeUsageRead     Scope = "usage:read"
+)
+
+// Scopes are the scopes keys may be granted
+var Scopes = []Scope{
+	ScopeLeasesRead,
+	ScopeLeasesWrite,
+	ScopeAccountsRead,
+	ScopeAccountsWrite,
+	ScopeUsageRead,
+}
+
+// APIKey is a key a machine client calls the API with.  Only a hash of the
+// secret is kept, so the key is only shown when it's created.
+type APIKey struct {
+	ID     string  `json:"id" dynamodbav:"Id"`
+	Name   string  `json:"name" dynamodbav:"Name"`
+	Scopes []Scope `json:"scopes" dynamodbav:"Scopes"`
+	// SecretHash is the SHA-256 hash of the key's secret
+	SecretHash string `json:"-" dynamodbav:"SecretHash"`
+	CreatedOn  int64  `json:"createdOn" dynamodbav:"CreatedOn"`
+	// ExpiresOn is when the key stops working.  Keys without one don't
+	// expire.
+	ExpiresOn *int64 `json:"expiresOn,omitempty" dynamodbav:"ExpiresOn,omitempty"`
+	// LastUsedOn is updated at most once an hour
+	LastUsedOn *int64 `json:"lastUsedOn,omitempty" dynamodbav:"LastUsedOn,omitempty"`
+	// Key is the key clients send, "<id>.<secret>".  It's only set in the
+	// response creating the key.
+	Key string `json:"key,omitempty" dynamodbav:"-"`
+}
+
+// Validate the API key
+func (k *APIKey) Validate() error {
+	err := validation.ValidateStruct(k,
+		validation.Field(&k.Name, validation.Required, validation.Length(1, 64)),
+		validation.Field(&k.Scopes, validation.Required, validation.By(isScopesValid)),
+		validation.Field(&k.ExpiresOn, validation.By(isExpiresOnValid)),
+	)
+	if err != nil {
+		return errors.NewValidation("api key", err)
+	}
+	return nil
+}
+
+func isScopesValid(value interface{}) error {
+	scopes, _ := value.([]Scope)
+	for _, scope := range scopes {
+		known := false
+		for _, s := range Scopes {
+			if scope == s {
+				known = true
+			}
+		}
+		if !known {
+			names := []string{}
+			for _, s := range Scopes {
+				names = append(names, string(s))
+			}
+			return fmt.Errorf("%q must be one of %s", scope, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func isExpiresOnValid(value interface{}) error {
+	expiresOn, _ := value.(*int64)
+	if expiresOn != nil && *expiresOn <= time.Now().Unix() {
+		return fmt.Errorf("must be in the future")
+	}
+	return nil
+}
+
+// IsExpired is true once the key's expiry has passed
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresOn != nil && *k.ExpiresOn <= now.Unix()
+}
+
+// Allows checks the key's scopes allow a request.  The resource of a request
+// is the first part of its path, eg. "leases" for /leases/{id}.  GET
+// requests read, and any other method writes.
+func (k *APIKey) Allows(method string, path string) bool {
+	resource := strings.SplitN(strings.Trim(path, "/"), "/", 2)[0]
+	read := Scope(resource + ":read")
+	write := Scope(resource + ":write")
+	for _, scope := range k.Scopes {
+		if scope == write || (scope == read && (method == http.MethodGet || method == http.MethodHead)) {
+			return true
+		}
+	}
+	return false
+}
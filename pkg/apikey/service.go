@@ -0,0 +1,244 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/caarlos0/env"
+	"github.com/google/uuid"
+)
+
+// lastUsedInterval is how often a key's LastUsedOn is updated, so each
+// request doesn't write to the table
+const lastUsedInterval = time.Hour
+
+// NewServiceInput are the items needed to create a new API key service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table of the API keys.  API keys are
+	// disabled when empty.
+	TableName string `env:"API_KEY_TABLE" envDefault:""`
+}
+
+// Service manages API keys, and authenticates the requests made with them
+type Service struct {
+	dynamodb  dynamodbiface.DynamoDBAPI
+	tableName string
+}
+
+// Enabled returns whether API keys may be used
+func (s *Service) Enabled() bool {
+	return s.tableName != ""
+}
+
+// List returns every key, ordered by creation
+func (s *Service) List() ([]*APIKey, error) {
+	if !s.Enabled() {
+		return []*APIKey{}, nil
+	}
+
+	keys := []*APIKey{}
+	var unmarshalErr error
+	err := s.dynamodb.ScanPages(&dynamodb.ScanInput{
+		TableName:      aws.String(s.tableName),
+		ConsistentRead: aws.Bool(true),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		items := []*APIKey{}
+		unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items)
+		keys = append(keys, items...)
+		return unmarshalErr == nil
+	})
+	if err == nil {
+		err = unmarshalErr
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to scan the api keys", err)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedOn != keys[j].CreatedOn {
+			return keys[i].CreatedOn < keys[j].CreatedOn
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+// Get returns a key
+func (s *Service) Get(ID string) (*APIKey, error) {
+	if !s.Enabled() {
+		return nil, errors.NewNotFound("api key", ID)
+	}
+
+	out, err := s.dynamodb.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(s.tableName),
+		Key:            map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(ID)}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to get api key %s", ID), err)
+	}
+	if len(out.Item) == 0 {
+		return nil, errors.NewNotFound("api key", ID)
+	}
+
+	key := &APIKey{}
+	err = dynamodbattribute.UnmarshalMap(out.Item, key)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to read api key %s", ID), err)
+	}
+	return key, nil
+}
+
+// Create adds a key.  The key returned is the only time its secret is
+// available.
+func (s *Service) Create(data *APIKey) (*APIKey, error) {
+	if !s.Enabled() {
+		return nil, errors.NewValidation("api key", fmt.Errorf("api keys are not enabled"))
+	}
+	err := data.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to generate api key", err)
+	}
+
+	key := APIKey{
+		ID:        uuid.New().String(),
+		Name:      data.Name,
+		Scopes:    data.Scopes,
+		CreatedOn: time.Now().Unix(),
+		ExpiresOn: data.ExpiresOn,
+	}
+	key.Key = key.ID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	key.SecretHash = hash(key.Key)
+
+	item, err := dynamodbattribute.MarshalMap(&key)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal api key", err)
+	}
+	_, err = s.dynamodb.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(Id)"),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, errors.NewConflict("api key", key.ID, fmt.Errorf("api key %s already exists", key.ID))
+		}
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to write api key %s", key.ID), err)
+	}
+	return &key, nil
+}
+
+// Delete revokes a key
+func (s *Service) Delete(ID string) (*APIKey, error) {
+	if !s.Enabled() {
+		return nil, errors.NewNotFound("api key", ID)
+	}
+
+	out, err := s.dynamodb.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:    aws.String(s.tableName),
+		Key:          map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(ID)}},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to delete api key %s", ID), err)
+	}
+	if len(out.Attributes) == 0 {
+		return nil, errors.NewNotFound("api key", ID)
+	}
+
+	key := &APIKey{}
+	err = dynamodbattribute.UnmarshalMap(out.Attributes, key)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to read api key %s", ID), err)
+	}
+	return key, nil
+}
+
+// Authenticate returns the key a request was made with.  Unknown, revoked
+// and expired keys are unauthorized.
+func (s *Service) Authenticate(value string) (*APIKey, error) {
+	unauthorized := errors.NewUnathorizedError("invalid api key")
+	if !s.Enabled() {
+		return nil, unauthorized
+	}
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, unauthorized
+	}
+
+	key, err := s.Get(parts[0])
+	if err != nil {
+		if errors.Is(err, errors.NewNotFound("api key", parts[0])) {
+			return nil, unauthorized
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(value)), []byte(key.SecretHash)) != 1 {
+		return nil, unauthorized
+	}
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, errors.NewUnathorizedError(fmt.Sprintf("api key %s has expired", key.ID))
+	}
+
+	if key.LastUsedOn == nil || now.Sub(time.Unix(*key.LastUsedOn, 0)) > lastUsedInterval {
+		key.LastUsedOn = aws.Int64(now.Unix())
+		// Failing to record the use doesn't fail the request
+		_, err = s.dynamodb.UpdateItem(&dynamodb.UpdateItemInput{
+			TableName:                 aws.String(s.tableName),
+			Key:                       map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(key.ID)}},
+			UpdateExpression:          aws.String("SET LastUsedOn = :lastUsedOn"),
+			ConditionExpression:       aws.String("attribute_exists(Id)"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":lastUsedOn": {N: aws.String(fmt.Sprint(now.Unix()))}},
+		})
+		if err != nil {
+			log.Printf("Failed to update when api key %s was last used: %s", key.ID, err)
+		}
+	}
+	return key, nil
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewService creates a new API key service
+func NewService(input NewServiceInput) *Service {
+	return &Service{
+		dynamodb:  input.DynamoDB,
+		tableName: input.TableName,
+	}
+}
+
+// NewFromEnv creates a new API key service configured from environment variables
+func NewFromEnv(dynamodbSvc dynamodbiface.DynamoDBAPI) (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	input.DynamoDB = dynamodbSvc
+	return NewService(input), nil
+}
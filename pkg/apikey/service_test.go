@@ -0,0 +1,166 @@
+package apikey
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    APIKey
+		expErr string
+	}{
+		{
+			name: "valid",
+			key:  APIKey{Name: "ci", Scopes: []Scope{ScopeLeasesWrite, ScopeUsageRead}, ExpiresOn: aws.Int64(time.Now().Unix() + 3600)},
+		},
+		{
+			name:   "no name",
+			key:    APIKey{Scopes: []Scope{ScopeLeasesRead}},
+			expErr: "api key validation error: name: cannot be blank.",
+		},
+		{
+			name:   "no scopes",
+			key:    APIKey{Name: "ci"},
+			expErr: "api key validation error: scopes: cannot be blank.",
+		},
+		{
+			name:   "unknown scope",
+			key:    APIKey{Name: "ci", Scopes: []Scope{"usage:write"}},
+			expErr: "api key validation error: scopes: \"usage:write\" must be one of leases:read, leases:write, accounts:read, accounts:write, usage:read.",
+		},
+		{
+			name:   "expired",
+			key:    APIKey{Name: "ci", Scopes: []Scope{ScopeLeasesRead}, ExpiresOn: aws.Int64(time.Now().Unix() - 60)},
+			expErr: "api key validation error: expiresOn: must be in the future.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	reader := &APIKey{Scopes: []Scope{ScopeLeasesRead, ScopeUsageRead}}
+	assert.True(t, reader.Allows("GET", "/leases"))
+	assert.True(t, reader.Allows("GET", "/leases/abc"))
+	assert.True(t, reader.Allows("GET", "/usage"))
+	assert.False(t, reader.Allows("POST", "/leases"))
+	assert.False(t, reader.Allows("GET", "/accounts"))
+
+	writer := &APIKey{Scopes: []Scope{ScopeAccountsWrite}}
+	assert.True(t, writer.Allows("POST", "/accounts"))
+	assert.True(t, writer.Allows("DELETE", "/accounts/123456789012"))
+	assert.True(t, writer.Allows("GET", "/accounts"))
+	assert.False(t, writer.Allows("GET", "/leases"))
+	// No scope covers the system routes
+	assert.False(t, writer.Allows("GET", "/system/api-keys"))
+}
+
+func TestCreate(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "ApiKeys" && *input.ConditionExpression == "attribute_not_exists(Id)" &&
+			input.Item["SecretHash"] != nil && input.Item["Key"] == nil
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "ApiKeys"})
+
+	key, err := svc.Create(&APIKey{ID: "ignored", Name: "ci", Scopes: []Scope{ScopeLeasesWrite}, SecretHash: "ignored"})
+	require.Nil(t, err)
+	assert.NotEqual(t, "ignored", key.ID)
+	assert.NotZero(t, key.CreatedOn)
+	assert.Regexp(t, "^"+key.ID+`\..{43}$`, key.Key)
+	assert.Equal(t, hash(key.Key), key.SecretHash)
+
+	_, err = svc.Create(&APIKey{Name: "ci"})
+	assert.Equal(t, 400, errors.HTTPCodeForError(err))
+
+	_, err = NewService(NewServiceInput{}).Create(&APIKey{Name: "ci", Scopes: []Scope{ScopeLeasesRead}})
+	assert.Equal(t, 400, errors.HTTPCodeForError(err))
+}
+
+func TestDelete(t *testing.T) {
+	item, _ := dynamodbattribute.MarshalMap(&APIKey{ID: "key-1", Name: "ci"})
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("DeleteItem", mock.MatchedBy(func(input *dynamodb.DeleteItemInput) bool {
+		return *input.Key["Id"].S == "key-1"
+	})).Return(&dynamodb.DeleteItemOutput{Attributes: item}, nil)
+	mockDynamo.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "ApiKeys"})
+
+	key, err := svc.Delete("key-1")
+	require.Nil(t, err)
+	assert.Equal(t, "ci", key.Name)
+
+	_, err = svc.Delete("missing")
+	assert.Equal(t, 404, errors.HTTPCodeForError(err))
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Now().Unix()
+	keys := map[string]*APIKey{
+		"key-1":   {ID: "key-1", Name: "ci", Scopes: []Scope{ScopeLeasesRead}, SecretHash: hash("key-1.secret")},
+		"recent":  {ID: "recent", Name: "ci", SecretHash: hash("recent.secret"), LastUsedOn: aws.Int64(now - 60)},
+		"expired": {ID: "expired", Name: "ci", SecretHash: hash("expired.secret"), ExpiresOn: aws.Int64(now - 60)},
+	}
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	for id, key := range keys {
+		item, _ := dynamodbattribute.MarshalMap(key)
+		id := id
+		mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+			return *input.Key["Id"].S == id
+		})).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+	}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	mockDynamo.On("UpdateItem", mock.Anything).Return(nil, fmt.Errorf("throttled"))
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "ApiKeys"})
+
+	t.Run("should return the key", func(t *testing.T) {
+		key, err := svc.Authenticate("key-1.secret")
+		require.Nil(t, err)
+		assert.Equal(t, []Scope{ScopeLeasesRead}, key.Scopes)
+		// Failing to record the use doesn't fail the request
+		mockDynamo.AssertCalled(t, "UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+			return *input.Key["Id"].S == "key-1"
+		}))
+	})
+
+	t.Run("should only record use once an hour", func(t *testing.T) {
+		_, err := svc.Authenticate("recent.secret")
+		require.Nil(t, err)
+		mockDynamo.AssertNotCalled(t, "UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+			return *input.Key["Id"].S == "recent"
+		}))
+	})
+
+	for _, value := range []string{"key-1.wrong", "missing.secret", "key-1", "", "expired.secret"} {
+		t.Run(fmt.Sprintf("should not authenticate %q", value), func(t *testing.T) {
+			_, err := svc.Authenticate(value)
+			assert.Equal(t, 401, errors.HTTPCodeForError(err))
+		})
+	}
+
+	t.Run("should not authenticate when disabled", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{}).Authenticate("key-1.secret")
+		assert.Equal(t, 401, errors.HTTPCodeForError(err))
+	})
+}
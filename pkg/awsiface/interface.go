@@ -12,17 +12,26 @@ package awsiface
 
 import (
 	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/budgets/budgetsiface"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/servicecatalog/servicecatalogiface"
 	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
@@ -52,6 +61,10 @@ type EventBridgeAPI interface {
 	eventbridgeiface.EventBridgeAPI
 }
 
+type SFNAPI interface {
+	sfniface.SFNAPI
+}
+
 type S3API interface {
 	s3iface.S3API
 }
@@ -64,6 +77,10 @@ type AwsSession interface {
 	client.ConfigProvider
 }
 
+type CloudFormationAPI interface {
+	cloudformationiface.CloudFormationAPI
+}
+
 type CloudWatchAPI interface {
 	cloudwatchiface.CloudWatchAPI
 }
@@ -80,6 +97,14 @@ type DynamoDBAPI interface {
 	dynamodbiface.DynamoDBAPI
 }
 
+type CloudTrailAPI interface {
+	cloudtrailiface.CloudTrailAPI
+}
+
+type CloudWatchLogsAPI interface {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+}
+
 type CloudWatchEventsAPI interface {
 	cloudwatcheventsiface.CloudWatchEventsAPI
 }
@@ -87,3 +112,23 @@ type CloudWatchEventsAPI interface {
 type CodeBuildAPI interface {
 	codebuildiface.CodeBuildAPI
 }
+
+type OrganizationsAPI interface {
+	organizationsiface.OrganizationsAPI
+}
+
+type ServiceCatalogAPI interface {
+	servicecatalogiface.ServiceCatalogAPI
+}
+
+type KinesisAPI interface {
+	kinesisiface.KinesisAPI
+}
+
+type ResourceGroupsTaggingAPI interface {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+type BudgetsAPI interface {
+	budgetsiface.BudgetsAPI
+}
@@ -0,0 +1,124 @@
+package budget
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/awsiface"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+)
+
+// LinkedAccountSpend gets the spend of the accounts linked to the account
+// Cost Explorer is called in, such as the organization's management account,
+// grouping many accounts into each call
+type LinkedAccountSpend struct {
+	CostExplorer awsiface.CostExplorerAPI
+	Pacer        *Pacer
+	// BatchSize is how many accounts are filtered on in each call
+	BatchSize int
+	// MaxAttempts is how many times a throttled call is tried
+	MaxAttempts int
+}
+
+// CalculateSpendByAccount gets the spend of each account between the dates.
+// Accounts without any cost in the period have a spend of 0.
+func (svc *LinkedAccountSpend) CalculateSpendByAccount(accountIDs []string, startDate time.Time, endDate time.Time) (map[string]float64, error) {
+	spend := map[string]float64{}
+	batchSize := svc.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(accountIDs)
+	}
+
+	for start := 0; start < len(accountIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(accountIDs) {
+			end = len(accountIDs)
+		}
+		batch := accountIDs[start:end]
+		for _, accountID := range batch {
+			spend[accountID] = 0
+		}
+
+		input := &costexplorer.GetCostAndUsageInput{
+			Metrics:     []*string{aws.String("UnblendedCost")},
+			Granularity: aws.String("DAILY"),
+			TimePeriod: &costexplorer.DateInterval{
+				Start: aws.String(startDate.UTC().Format("2006-01-02")),
+				End:   aws.String(endDate.UTC().Format("2006-01-02")),
+			},
+			Filter: &costexplorer.Expression{
+				Dimensions: &costexplorer.DimensionValues{
+					Key:    aws.String(costexplorer.DimensionLinkedAccount),
+					Values: aws.StringSlice(batch),
+				},
+			},
+			GroupBy: []*costexplorer.GroupDefinition{{
+				Type: aws.String(costexplorer.GroupDefinitionTypeDimension),
+				Key:  aws.String(costexplorer.DimensionLinkedAccount),
+			}},
+		}
+
+		for {
+			output, err := svc.getCostAndUsage(input)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range output.ResultsByTime {
+				for _, group := range result.Groups {
+					if len(group.Keys) == 0 || group.Metrics["UnblendedCost"] == nil {
+						continue
+					}
+					cost, err := strconv.ParseFloat(aws.StringValue(group.Metrics["UnblendedCost"].Amount), 64)
+					if err != nil {
+						return nil, err
+					}
+					spend[aws.StringValue(group.Keys[0])] += cost
+				}
+			}
+			if output.NextPageToken == nil {
+				break
+			}
+			input.NextPageToken = output.NextPageToken
+		}
+	}
+
+	return spend, nil
+}
+
+// getCostAndUsage calls Cost Explorer at the pace of the pacer, and tries
+// again when the call is throttled
+func (svc *LinkedAccountSpend) getCostAndUsage(input *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error) {
+	maxAttempts := svc.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if svc.Pacer != nil {
+			svc.Pacer.Wait()
+		}
+		var output *costexplorer.GetCostAndUsageOutput
+		output, err = svc.CostExplorer.GetCostAndUsage(input)
+		if err == nil {
+			if svc.Pacer != nil {
+				svc.Pacer.Succeeded()
+			}
+			return output, nil
+		}
+
+		awsErr, ok := err.(awserr.Error)
+		if !ok || awsErr.Code() != costexplorer.ErrCodeLimitExceededException {
+			return nil, err
+		}
+		if svc.Pacer != nil {
+			svc.Pacer.Throttled()
+			log.Printf("Cost Explorer throttled, slowing down to a call every %s", svc.Pacer.Interval())
+		}
+	}
+	return nil, fmt.Errorf("cost explorer throttled after %d attempts: %w", maxAttempts, err)
+}
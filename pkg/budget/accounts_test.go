@@ -0,0 +1,106 @@
+package budget
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func costGroup(accountID string, amount string) *costexplorer.Group {
+	return &costexplorer.Group{
+		Keys: aws.StringSlice([]string{accountID}),
+		Metrics: map[string]*costexplorer.MetricValue{
+			"UnblendedCost": {Amount: aws.String(amount), Unit: aws.String("USD")},
+		},
+	}
+}
+
+func newTestPacer() *Pacer {
+	pacer := NewPacer(0, time.Second)
+	pacer.sleep = func(time.Duration) {}
+	return pacer
+}
+
+func TestCalculateSpendByAccount(t *testing.T) {
+	accountIDs := []string{"111111111111", "222222222222", "333333333333"}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should get the spend of the accounts in batches", func(t *testing.T) {
+		costExplorer := &mocks.CostExplorerAPI{}
+		costExplorer.On("GetCostAndUsage", mock.MatchedBy(func(input *costexplorer.GetCostAndUsageInput) bool {
+			return len(input.Filter.Dimensions.Values) == 2 && input.NextPageToken == nil
+		})).Return(&costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []*costexplorer.ResultByTime{{
+				Groups: []*costexplorer.Group{costGroup("111111111111", "10.5")},
+			}},
+			NextPageToken: aws.String("next"),
+		}, nil)
+		costExplorer.On("GetCostAndUsage", mock.MatchedBy(func(input *costexplorer.GetCostAndUsageInput) bool {
+			return input.NextPageToken != nil
+		})).Return(&costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []*costexplorer.ResultByTime{{
+				Groups: []*costexplorer.Group{costGroup("111111111111", "1.5"), costGroup("222222222222", "3")},
+			}},
+		}, nil)
+		costExplorer.On("GetCostAndUsage", mock.Anything).Return(&costexplorer.GetCostAndUsageOutput{}, nil)
+
+		svc := &LinkedAccountSpend{CostExplorer: costExplorer, Pacer: newTestPacer(), BatchSize: 2}
+		spend, err := svc.CalculateSpendByAccount(accountIDs, start, start.AddDate(0, 0, 1))
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]float64{
+			"111111111111": 12,
+			"222222222222": 3,
+			"333333333333": 0,
+		}, spend)
+		costExplorer.AssertNumberOfCalls(t, "GetCostAndUsage", 3)
+		input := costExplorer.Calls[0].Arguments[0].(*costexplorer.GetCostAndUsageInput)
+		assert.Equal(t, "2020-01-01", *input.TimePeriod.Start)
+		assert.Equal(t, "LINKED_ACCOUNT", *input.GroupBy[0].Key)
+	})
+
+	t.Run("should slow down and try again when throttled", func(t *testing.T) {
+		costExplorer := &mocks.CostExplorerAPI{}
+		throttled := awserr.New(costexplorer.ErrCodeLimitExceededException, "Rate exceeded", nil)
+		costExplorer.On("GetCostAndUsage", mock.Anything).Return(nil, throttled).Twice()
+		costExplorer.On("GetCostAndUsage", mock.Anything).Return(&costexplorer.GetCostAndUsageOutput{}, nil)
+
+		pacer := newTestPacer()
+		svc := &LinkedAccountSpend{CostExplorer: costExplorer, Pacer: pacer, MaxAttempts: 3}
+		_, err := svc.CalculateSpendByAccount(accountIDs, start, start.AddDate(0, 0, 1))
+
+		assert.Nil(t, err)
+		costExplorer.AssertNumberOfCalls(t, "GetCostAndUsage", 3)
+		assert.Equal(t, 1500*time.Microsecond, pacer.Interval())
+	})
+
+	t.Run("should fail when throttled too many times", func(t *testing.T) {
+		costExplorer := &mocks.CostExplorerAPI{}
+		throttled := awserr.New(costexplorer.ErrCodeLimitExceededException, "Rate exceeded", nil)
+		costExplorer.On("GetCostAndUsage", mock.Anything).Return(nil, throttled)
+
+		svc := &LinkedAccountSpend{CostExplorer: costExplorer, Pacer: newTestPacer(), MaxAttempts: 2}
+		_, err := svc.CalculateSpendByAccount(accountIDs, start, start.AddDate(0, 0, 1))
+
+		assert.NotNil(t, err)
+		costExplorer.AssertNumberOfCalls(t, "GetCostAndUsage", 2)
+	})
+
+	t.Run("should fail on other errors", func(t *testing.T) {
+		costExplorer := &mocks.CostExplorerAPI{}
+		costExplorer.On("GetCostAndUsage", mock.Anything).Return(nil, fmt.Errorf("access denied"))
+
+		svc := &LinkedAccountSpend{CostExplorer: costExplorer, Pacer: newTestPacer(), MaxAttempts: 3}
+		_, err := svc.CalculateSpendByAccount(accountIDs, start, start.AddDate(0, 0, 1))
+
+		assert.EqualError(t, err, "access denied")
+		costExplorer.AssertNumberOfCalls(t, "GetCostAndUsage", 1)
+	})
+}
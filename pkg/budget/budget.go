@@ -14,6 +14,7 @@ import (
 //go:generate mockery -name Service
 type Service interface {
 	CalculateTotalSpend(startDate time.Time, endDate time.Time) (float64, error)
+	CalculateSpendByService(startDate time.Time, endDate time.Time) (map[string]float64, error)
 	SetCostExplorer(costExplorer awsiface.CostExplorerAPI)
 }
 
@@ -66,3 +67,44 @@ func (budgetSvc *AWSBudgetService) CalculateTotalSpend(startDate time.Time, endD
 	}
 	return totalCost, nil
 }
+
+// CalculateSpendByService gets the spend between the dates of each AWS
+// service, by its name in Cost Explorer
+func (budgetSvc *AWSBudgetService) CalculateSpendByService(startDate time.Time, endDate time.Time) (map[string]float64, error) {
+	input := &costexplorer.GetCostAndUsageInput{
+		Metrics:     []*string{aws.String("UnblendedCost")},
+		Granularity: aws.String("DAILY"),
+		TimePeriod: &costexplorer.DateInterval{
+			Start: aws.String(startDate.UTC().Format("2006-01-02")),
+			End:   aws.String(endDate.UTC().Format("2006-01-02")),
+		},
+		GroupBy: []*costexplorer.GroupDefinition{{
+			Type: aws.String(costexplorer.GroupDefinitionTypeDimension),
+			Key:  aws.String(costexplorer.DimensionService),
+		}},
+	}
+
+	spend := map[string]float64{}
+	for {
+		output, err := budgetSvc.CostExplorer.GetCostAndUsage(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range output.ResultsByTime {
+			for _, group := range result.Groups {
+				if len(group.Keys) == 0 || group.Metrics["UnblendedCost"] == nil {
+					continue
+				}
+				cost, err := strconv.ParseFloat(aws.StringValue(group.Metrics["UnblendedCost"].Amount), 64)
+				if err != nil {
+					return nil, err
+				}
+				spend[aws.StringValue(group.Keys[0])] += cost
+			}
+		}
+		if output.NextPageToken == nil {
+			return spend, nil
+		}
+		input.NextPageToken = output.NextPageToken
+	}
+}
@@ -51,3 +51,50 @@ func TestCalculateTotalSpend(t *testing.T) {
 	assert.Nil(t, err, "There should be no errors")
 	assert.Equal(t, cost, float64(150))
 }
+
+func TestCalculateSpendByService(t *testing.T) {
+	costExplorer := &mocks.CostExplorerAPI{}
+	serviceGroup := func(service string, amount string) *costexplorer.Group {
+		return &costexplorer.Group{
+			Keys: aws.StringSlice([]string{service}),
+			Metrics: map[string]*costexplorer.MetricValue{
+				"UnblendedCost": {Amount: aws.String(amount), Unit: aws.String("USD")},
+			},
+		}
+	}
+	costExplorer.On("GetCostAndUsage", &costexplorer.GetCostAndUsageInput{
+		Metrics:     []*string{aws.String("UnblendedCost")},
+		Granularity: aws.String("DAILY"),
+		TimePeriod: &costexplorer.DateInterval{
+			Start: aws.String("1970-01-01"),
+			End:   aws.String("1970-01-03"),
+		},
+		GroupBy: []*costexplorer.GroupDefinition{{
+			Type: aws.String("DIMENSION"),
+			Key:  aws.String("SERVICE"),
+		}},
+	}).Return(&costexplorer.GetCostAndUsageOutput{
+		ResultsByTime: []*costexplorer.ResultByTime{
+			{Groups: []*costexplorer.Group{
+				serviceGroup("Amazon SageMaker", "40"),
+				serviceGroup("Amazon Simple Storage Service", "1.5"),
+			}},
+			{Groups: []*costexplorer.Group{
+				serviceGroup("Amazon SageMaker", "60"),
+			}},
+		},
+	}, nil)
+
+	budgetSvc := AWSBudgetService{
+		CostExplorer: costExplorer,
+	}
+	spend, err := budgetSvc.CalculateSpendByService(
+		time.Unix(0, 0),
+		time.Unix(0, 0).Add(time.Hour*48),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]float64{
+		"Amazon SageMaker":              100,
+		"Amazon Simple Storage Service": 1.5,
+	}, spend)
+}
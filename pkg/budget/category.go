@@ -0,0 +1,102 @@
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/Optum/dce/pkg/common"
+)
+
+// Categories are the AWS services in each category of spend which leases can
+// have a budget for, eg. `{"ai": ["Amazon SageMaker", "Amazon Bedrock"]}`.
+// Services are named as they are in Cost Explorer, and may be in more than
+// one category.
+type Categories map[string][]string
+
+// DefaultCategories are used when no categories are configured
+var DefaultCategories = Categories{
+	"compute": {
+		"Amazon Elastic Compute Cloud - Compute",
+		"EC2 - Other",
+		"AWS Lambda",
+		"Amazon Elastic Container Service",
+		"Amazon Elastic Container Service for Kubernetes",
+		"AWS Batch",
+	},
+	"storage": {
+		"Amazon Simple Storage Service",
+		"Amazon Elastic File System",
+		"Amazon Elastic Block Store",
+		"Amazon FSx",
+		"AWS Backup",
+	},
+	"ai": {
+		"Amazon SageMaker",
+		"Amazon Bedrock",
+		"Amazon Comprehend",
+		"Amazon Rekognition",
+		"Amazon Textract",
+		"Amazon Transcribe",
+	},
+}
+
+var categoryNamePattern = regexp.MustCompile("^[a-z0-9_-]{1,32}$")
+
+// NewCategoriesFromEnv parses the JSON categories of the BUDGET_CATEGORIES
+// environment variable, or returns the default categories when it isn't set
+func NewCategoriesFromEnv() (Categories, error) {
+	return ParseCategories(common.GetEnv("BUDGET_CATEGORIES", ""))
+}
+
+// ParseCategories parses JSON categories, or returns the default categories
+// when there are none
+func ParseCategories(categoriesJSON string) (Categories, error) {
+	if categoriesJSON == "" {
+		return DefaultCategories, nil
+	}
+	categories := Categories{}
+	err := json.Unmarshal([]byte(categoriesJSON), &categories)
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget categories: %w", err)
+	}
+	for name, services := range categories {
+		if !categoryNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid budget category %q: must be lowercase letters, digits, - or _", name)
+		}
+		if len(services) == 0 {
+			return nil, fmt.Errorf("invalid budget category %q: must have services", name)
+		}
+	}
+	return categories, nil
+}
+
+// Has is true when the category is configured
+func (c Categories) Has(name string) bool {
+	_, ok := c[name]
+	return ok
+}
+
+// Names returns the names of the categories, in order
+func (c Categories) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SpendByCategory sums the spend of each service into the categories it's
+// in.  Spend of services in no category is left out.
+func (c Categories) SpendByCategory(spendByService map[string]float64) map[string]float64 {
+	spend := map[string]float64{}
+	for name, services := range c {
+		spend[name] = 0
+		for _, service := range services {
+			spend[name] += spendByService[service]
+		}
+	}
+	return spend
+}
@@ -0,0 +1,67 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCategories(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		expCategories Categories
+		expErr        string
+	}{
+		{
+			name:          "should default the categories",
+			json:          "",
+			expCategories: DefaultCategories,
+		},
+		{
+			name:          "should parse the categories",
+			json:          `{"gpu": ["Amazon Elastic Compute Cloud - Compute"]}`,
+			expCategories: Categories{"gpu": {"Amazon Elastic Compute Cloud - Compute"}},
+		},
+		{
+			name:   "should reject invalid names",
+			json:   `{"GPU Instances": ["Amazon Elastic Compute Cloud - Compute"]}`,
+			expErr: `invalid budget category "GPU Instances": must be lowercase letters, digits, - or _`,
+		},
+		{
+			name:   "should reject categories without services",
+			json:   `{"gpu": []}`,
+			expErr: `invalid budget category "gpu": must have services`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories, err := ParseCategories(tt.json)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expCategories, categories)
+		})
+	}
+}
+
+func TestSpendByCategory(t *testing.T) {
+	categories := Categories{
+		"compute": {"Amazon Elastic Compute Cloud - Compute", "AWS Lambda"},
+		"ai":      {"Amazon SageMaker"},
+		"storage": {"Amazon Simple Storage Service"},
+	}
+
+	spend := categories.SpendByCategory(map[string]float64{
+		"Amazon Elastic Compute Cloud - Compute": 10,
+		"AWS Lambda":                             2.5,
+		"Amazon SageMaker":                       40,
+		"AWS Key Management Service":             1,
+	})
+
+	assert.Equal(t, map[string]float64{"compute": 12.5, "ai": 40, "storage": 0}, spend)
+	assert.Equal(t, []string{"ai", "compute", "storage"}, categories.Names())
+}
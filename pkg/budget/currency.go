@@ -0,0 +1,160 @@
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Optum/dce/pkg/common"
+)
+
+// USD is the currency of costs reported by Cost Explorer
+const USD = "USD"
+
+// CurrencyConverter converts costs reported by Cost Explorer, in USD, to the
+// currency budgets are displayed and enforced in
+type CurrencyConverter interface {
+	// Currency is the currency costs are converted to
+	Currency() string
+	// FromUSD converts a cost in USD
+	FromUSD(amount float64) (float64, error)
+}
+
+// StaticRate converts costs with a fixed exchange rate
+type StaticRate struct {
+	ToCurrency string
+	// Rate is the amount of ToCurrency one USD buys
+	Rate float64
+}
+
+// Currency is the currency costs are converted to
+func (c *StaticRate) Currency() string {
+	return c.ToCurrency
+}
+
+// FromUSD converts a cost in USD
+func (c *StaticRate) FromUSD(amount float64) (float64, error) {
+	return amount * c.Rate, nil
+}
+
+// NoConversion keeps costs in USD
+var NoConversion CurrencyConverter = &StaticRate{ToCurrency: USD, Rate: 1}
+
+// rateAPITTL is how long rates from an exchange rate API are used for
+const rateAPITTL = time.Hour
+
+// RateAPI converts costs with the latest exchange rate from an API. The API
+// must respond to a GET request with the rates of USD, such as
+// `{"rates": {"EUR": 0.92, "GBP": 0.79}}`. The rate is cached for an hour,
+// and Fallback is used when the API can't be reached.
+type RateAPI struct {
+	ToCurrency string
+	URL        string
+	Client     *http.Client
+	// Fallback converts costs when the API fails, and may be nil
+	Fallback CurrencyConverter
+
+	mu        sync.Mutex
+	rate      float64
+	fetchedOn time.Time
+}
+
+// Currency is the currency costs are converted to
+func (c *RateAPI) Currency() string {
+	return c.ToCurrency
+}
+
+// FromUSD converts a cost in USD
+func (c *RateAPI) FromUSD(amount float64) (float64, error) {
+	rate, err := c.getRate()
+	if err != nil {
+		if c.Fallback == nil {
+			return 0, err
+		}
+		log.Printf("Failed to get the %s exchange rate, using the fallback rate: %s", c.ToCurrency, err)
+		return c.Fallback.FromUSD(amount)
+	}
+	return amount * rate, nil
+}
+
+func (c *RateAPI) getRate() (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetchedOn.IsZero() && time.Since(c.fetchedOn) < rateAPITTL {
+		return c.rate, nil
+	}
+
+	res, err := c.Client.Get(c.URL)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("exchange rate API responded with %s", res.Status)
+	}
+
+	body := struct {
+		Rates map[string]float64 `json:"rates"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	if err != nil {
+		return 0, fmt.Errorf("invalid exchange rate API response: %s", err)
+	}
+	rate, ok := body.Rates[c.ToCurrency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("exchange rate API has no rate for %s", c.ToCurrency)
+	}
+
+	c.rate = rate
+	c.fetchedOn = time.Now()
+	return rate, nil
+}
+
+/*
+NewCurrencyConverterFromEnv creates a CurrencyConverter configured from
+environment variables:
+
+- BUDGET_CURRENCY, the currency budgets are in. Defaults to USD.
+- BUDGET_CURRENCY_RATES, a JSON object of the amount of each currency one USD buys, eg. `{"EUR": 0.92}`
+- BUDGET_CURRENCY_RATE_URL, an exchange rate API, used instead of BUDGET_CURRENCY_RATES when set
+
+Costs are not converted when BUDGET_CURRENCY is USD.
+*/
+func NewCurrencyConverterFromEnv() (CurrencyConverter, error) {
+	currency := strings.ToUpper(common.GetEnv("BUDGET_CURRENCY", USD))
+	if currency == USD {
+		return NoConversion, nil
+	}
+
+	var static CurrencyConverter
+	rates := map[string]float64{}
+	err := json.Unmarshal([]byte(common.GetEnv("BUDGET_CURRENCY_RATES", "{}")), &rates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BUDGET_CURRENCY_RATES: %s", err)
+	}
+	if rate, ok := rates[currency]; ok {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid BUDGET_CURRENCY_RATES: the rate of %s must be positive", currency)
+		}
+		static = &StaticRate{ToCurrency: currency, Rate: rate}
+	}
+
+	url := common.GetEnv("BUDGET_CURRENCY_RATE_URL", "")
+	if url != "" {
+		return &RateAPI{
+			ToCurrency: currency,
+			URL:        url,
+			Client:     &http.Client{Timeout: 10 * time.Second},
+			Fallback:   static,
+		}, nil
+	}
+	if static == nil {
+		return nil, fmt.Errorf("no exchange rate configured for %s: set BUDGET_CURRENCY_RATES or BUDGET_CURRENCY_RATE_URL", currency)
+	}
+	return static, nil
+}
@@ -0,0 +1,63 @@
+package budget
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateAPI(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"rates": {"EUR": 0.5, "GBP": 0.25}}`))
+	}))
+	defer server.Close()
+
+	converter := &RateAPI{ToCurrency: "EUR", URL: server.URL, Client: server.Client()}
+	amount, err := converter.FromUSD(10)
+	require.Nil(t, err)
+	assert.Equal(t, 5.0, amount)
+
+	_, err = converter.FromUSD(20)
+	require.Nil(t, err)
+	assert.Equal(t, 1, calls, "the rate should be cached")
+
+	t.Run("should use the fallback rate when the API fails", func(t *testing.T) {
+		converter := &RateAPI{
+			ToCurrency: "CHF",
+			URL:        server.URL,
+			Client:     server.Client(),
+			Fallback:   &StaticRate{ToCurrency: "CHF", Rate: 0.9},
+		}
+		amount, err := converter.FromUSD(10)
+		require.Nil(t, err)
+		assert.Equal(t, 9.0, amount)
+
+		converter.Fallback = nil
+		_, err = converter.FromUSD(10)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNewCurrencyConverterFromEnv(t *testing.T) {
+	defer os.Unsetenv("BUDGET_CURRENCY")
+	defer os.Unsetenv("BUDGET_CURRENCY_RATES")
+
+	converter, err := NewCurrencyConverterFromEnv()
+	require.Nil(t, err)
+	assert.Equal(t, NoConversion, converter)
+
+	os.Setenv("BUDGET_CURRENCY", "gbp")
+	_, err = NewCurrencyConverterFromEnv()
+	assert.NotNil(t, err, "should require a rate")
+
+	os.Setenv("BUDGET_CURRENCY_RATES", `{"GBP": 0.8}`)
+	converter, err = NewCurrencyConverterFromEnv()
+	require.Nil(t, err)
+	assert.Equal(t, &StaticRate{ToCurrency: "GBP", Rate: 0.8}, converter)
+}
@@ -12,6 +12,29 @@ type Service struct {
 	mock.Mock
 }
 
+// CalculateSpendByService provides a mock function with given fields: startDate, endDate
+func (_m *Service) CalculateSpendByService(startDate time.Time, endDate time.Time) (map[string]float64, error) {
+	ret := _m.Called(startDate, endDate)
+
+	var r0 map[string]float64
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) map[string]float64); ok {
+		r0 = rf(startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]float64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculateTotalSpend provides a mock function with given fields: startDate, endDate
 func (_m *Service) CalculateTotalSpend(startDate time.Time, endDate time.Time) (float64, error) {
 	ret := _m.Called(startDate, endDate)
@@ -0,0 +1,81 @@
+package budget
+
+import (
+	"sync"
+	"time"
+)
+
+// Pacer spaces out calls to a rate limited API.  The time between calls
+// doubles each time a call is throttled, up to the maximum interval, and
+// shrinks back towards the minimum interval as calls succeed.
+type Pacer struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+// NewPacer creates a new pacer
+func NewPacer(minInterval time.Duration, maxInterval time.Duration) *Pacer {
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return &Pacer{
+		MinInterval: minInterval,
+		MaxInterval: maxInterval,
+		interval:    minInterval,
+		now:         time.Now,
+		sleep:       time.Sleep,
+	}
+}
+
+// Wait blocks until the next call may be made
+func (p *Pacer) Wait() {
+	p.mu.Lock()
+	now := p.now()
+	wait := p.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	p.next = now.Add(wait + p.interval)
+	p.mu.Unlock()
+
+	if wait > 0 {
+		p.sleep(wait)
+	}
+}
+
+// Throttled slows down the calls after one was throttled
+func (p *Pacer) Throttled() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval *= 2
+	if p.interval < time.Millisecond {
+		p.interval = time.Millisecond
+	}
+	if p.interval > p.MaxInterval {
+		p.interval = p.MaxInterval
+	}
+	p.next = p.now().Add(p.interval)
+}
+
+// Succeeded speeds the calls back up after one succeeded
+func (p *Pacer) Succeeded() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval -= p.interval / 4
+	if p.interval < p.MinInterval {
+		p.interval = p.MinInterval
+	}
+}
+
+// Interval is the current time between calls
+func (p *Pacer) Interval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.interval
+}
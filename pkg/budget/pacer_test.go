@@ -0,0 +1,42 @@
+package budget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacer(t *testing.T) {
+	pacer := NewPacer(100*time.Millisecond, time.Second)
+	now := time.Unix(0, 0)
+	slept := []time.Duration{}
+	pacer.now = func() time.Time { return now }
+	pacer.sleep = func(d time.Duration) {
+		slept = append(slept, d)
+		now = now.Add(d)
+	}
+
+	// The first call doesn't wait, the next waits the interval
+	pacer.Wait()
+	pacer.Wait()
+	assert.Equal(t, []time.Duration{100 * time.Millisecond}, slept)
+
+	// Slows down when throttled, up to the maximum
+	pacer.Throttled()
+	assert.Equal(t, 200*time.Millisecond, pacer.Interval())
+	for i := 0; i < 5; i++ {
+		pacer.Throttled()
+	}
+	assert.Equal(t, time.Second, pacer.Interval())
+	pacer.Wait()
+	assert.Equal(t, time.Second, slept[1])
+
+	// Speeds back up as calls succeed, down to the minimum
+	pacer.Succeeded()
+	assert.Equal(t, 750*time.Millisecond, pacer.Interval())
+	for i := 0; i < 20; i++ {
+		pacer.Succeeded()
+	}
+	assert.Equal(t, 100*time.Millisecond, pacer.Interval())
+}
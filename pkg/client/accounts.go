@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Optum/dce/pkg/account"
+)
+
+// CreateAccountInput are the fields used to add an account to the pool
+type CreateAccountInput struct {
+	ID           string                 `json:"id"`
+	AdminRoleArn string                 `json:"adminRoleArn"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// UpdateAccountInput are the fields which may be updated on an account
+type UpdateAccountInput struct {
+	AdminRoleArn *string                `json:"adminRoleArn,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// GetAccount returns an account by ID
+func (c *Client) GetAccount(id string) (*account.Account, error) {
+	out := &account.Account{}
+	_, err := c.do(http.MethodGet, "/accounts/"+url.PathEscape(id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAccounts returns all accounts matching the query, following pagination
+func (c *Client) ListAccounts(query url.Values) (account.Accounts, error) {
+	accounts := account.Accounts{}
+	for {
+		page := account.Accounts{}
+		resp, err := c.do(http.MethodGet, "/accounts", query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, page...)
+
+		next := nextURL(resp)
+		if next == nil {
+			return accounts, nil
+		}
+		query = next.Query()
+	}
+}
+
+// CreateAccount adds an account to the pool
+func (c *Client) CreateAccount(input CreateAccountInput) (*account.Account, error) {
+	out := &account.Account{}
+	_, err := c.do(http.MethodPost, "/accounts", nil, input, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateAccount updates an account by ID
+func (c *Client) UpdateAccount(id string, input UpdateAccountInput) (*account.Account, error) {
+	out := &account.Account{}
+	_, err := c.do(http.MethodPut, "/accounts/"+url.PathEscape(id), nil, input, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteAccount removes an account from the pool
+func (c *Client) DeleteAccount(id string) error {
+	_, err := c.do(http.MethodDelete, "/accounts/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
@@ -0,0 +1,161 @@
+// Package client is a Go client for the DCE API. Requests are signed with
+// AWS SigV4, using the same credentials accepted by the API Gateway.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	sigv4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// Client makes requests against a DCE API
+type Client struct {
+	baseURL    *url.URL
+	region     string
+	signer     *sigv4.Signer
+	httpClient *http.Client
+}
+
+// NewClientInput are the items needed to create a new client
+type NewClientInput struct {
+	// BaseURL is the DCE API URL, eg. https://abc123.execute-api.us-east-1.amazonaws.com/api
+	BaseURL string
+	// Region is the AWS region where the API is deployed
+	Region string
+	// Credentials used to sign requests
+	Credentials *credentials.Credentials
+	// HTTPClient is optional, and defaults to a client with a 60 second timeout
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new DCE API client
+func NewClient(input NewClientInput) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(input.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %s", input.BaseURL, err)
+	}
+	if input.Credentials == nil {
+		return nil, fmt.Errorf("credentials are required")
+	}
+
+	httpClient := input.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 60 * time.Second,
+		}
+	}
+
+	return &Client{
+		baseURL:    u,
+		region:     input.Region,
+		signer:     sigv4.NewSigner(input.Credentials),
+		httpClient: httpClient,
+	}, nil
+}
+
+// APIError is returned when the API responds with an error status code
+type APIError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound returns true if the error is an API "not found" error
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// do sends a signed request to the API, and decodes the JSON
+// response into out, if provided
+func (c *Client) do(method string, path string, query url.Values, in interface{}, out interface{}) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path = u.Path + path
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	var body io.ReadSeeker
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Sign also sets the request body
+	_, err = c.signer.Sign(req, body, "execute-api", c.region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %s", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode >= 400 {
+		errResp := struct {
+			Error *APIError `json:"error"`
+		}{}
+		_ = json.Unmarshal(respBody, &errResp)
+		apiErr := errResp.Error
+		if apiErr == nil {
+			apiErr = &APIError{Message: string(respBody)}
+		}
+		apiErr.StatusCode = resp.StatusCode
+		return resp, apiErr
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %s", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// nextURL returns the URL of the next page of results, found in the Link header
+func nextURL(resp *http.Response) *url.URL {
+	for _, link := range resp.Header["Link"] {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 || !strings.Contains(parts[1], `rel="next"`) {
+			continue
+		}
+		raw := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		u, err := url.Parse(raw)
+		if err == nil {
+			return u
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	c, err := NewClient(NewClientInput{
+		BaseURL:     server.URL + "/api",
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	})
+	require.Nil(t, err)
+	return c, server
+}
+
+func TestGetAccount(t *testing.T) {
+	t.Run("should sign the request and decode the account", func(t *testing.T) {
+		c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/accounts/123456789012", r.URL.Path)
+			assert.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256")
+			_, _ = fmt.Fprint(w, `{"id":"123456789012","accountStatus":"Ready"}`)
+		})
+		defer server.Close()
+
+		acct, err := c.GetAccount("123456789012")
+		assert.Nil(t, err)
+		assert.Equal(t, "123456789012", *acct.ID)
+		assert.Equal(t, "Ready", acct.Status.String())
+	})
+
+	t.Run("should return an APIError", func(t *testing.T) {
+		c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"error":{"message":"account \"123456789012\" not found","code":"NotFoundError"}}`)
+		})
+		defer server.Close()
+
+		acct, err := c.GetAccount("123456789012")
+		assert.Nil(t, acct)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, "NotFoundError", err.(*APIError).Code)
+	})
+}
+
+func TestCreateLease(t *testing.T) {
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		in := CreateLeaseInput{}
+		assert.Nil(t, json.Unmarshal(body, &in))
+		assert.Equal(t, "user1", in.PrincipalID)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id":"abc","principalId":"user1","accountId":"123456789012"}`)
+	})
+	defer server.Close()
+
+	l, err := c.CreateLease(CreateLeaseInput{
+		PrincipalID:    "user1",
+		BudgetAmount:   10,
+		BudgetCurrency: "USD",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012", *l.AccountID)
+}
+
+func TestListAccounts(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("nextId") == "" {
+			w.Header().Add("Link", fmt.Sprintf("<%s/accounts?nextId=2>; rel=\"next\"", server.URL))
+			_, _ = fmt.Fprint(w, `[{"id":"111111111111"}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id":"222222222222"}]`)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(NewClientInput{
+		BaseURL:     server.URL,
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	})
+	require.Nil(t, err)
+
+	accounts, err := c.ListAccounts(nil)
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "222222222222", *accounts[1].ID)
+}
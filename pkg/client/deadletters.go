@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// DeadLetterQueue is a queue of messages which failed processing
+type DeadLetterQueue struct {
+	Name         string `json:"name"`
+	MessageCount int64  `json:"messageCount"`
+}
+
+// DeadLetterMessage is a message which failed processing
+type DeadLetterMessage struct {
+	ID            string `json:"id"`
+	Body          string `json:"body"`
+	FailureReason string `json:"failureReason,omitempty"`
+	ErrorCode     string `json:"errorCode,omitempty"`
+	ReceiveCount  int64  `json:"receiveCount"`
+	SentOn        int64  `json:"sentOn"`
+}
+
+// ReplayResult is the outcome of replaying a single message
+type ReplayResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ListDeadLetterQueues returns each dead letter queue with the number of messages in it
+func (c *Client) ListDeadLetterQueues() ([]*DeadLetterQueue, error) {
+	out := []*DeadLetterQueue{}
+	_, err := c.do(http.MethodGet, "/dlq", nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDeadLetterMessages returns up to 10 messages from a dead letter queue
+func (c *Client) ListDeadLetterMessages(queueName string) ([]*DeadLetterMessage, error) {
+	out := []*DeadLetterMessage{}
+	_, err := c.do(http.MethodGet, "/dlq/"+url.PathEscape(queueName)+"/messages", nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplayDeadLetterMessages replays messages from a dead letter queue to where they originally failed
+func (c *Client) ReplayDeadLetterMessages(queueName string, messageIDs []string) ([]*ReplayResult, error) {
+	in := struct {
+		MessageIDs []string `json:"messageIds"`
+	}{messageIDs}
+	out := struct {
+		Results []*ReplayResult `json:"results"`
+	}{}
+	_, err := c.do(http.MethodPost, "/dlq/"+url.PathEscape(queueName)+"/replay", nil, in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Results, nil
+}
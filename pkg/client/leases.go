@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// CreateLeaseInput are the fields used to request a lease
+type CreateLeaseInput struct {
+	PrincipalID              string                 `json:"principalId"`
+	BudgetAmount             float64                `json:"budgetAmount"`
+	BudgetCurrency           string                 `json:"budgetCurrency"`
+	BudgetNotificationEmails []string               `json:"budgetNotificationEmails,omitempty"`
+	ExpiresOn                int64                  `json:"expiresOn,omitempty"`
+	Metadata                 map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// GetLease returns a lease by ID
+func (c *Client) GetLease(id string) (*lease.Lease, error) {
+	out := &lease.Lease{}
+	_, err := c.do(http.MethodGet, "/leases/"+url.PathEscape(id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListLeases returns all leases matching the query, following pagination
+func (c *Client) ListLeases(query url.Values) (lease.Leases, error) {
+	leases := lease.Leases{}
+	for {
+		page := lease.Leases{}
+		resp, err := c.do(http.MethodGet, "/leases", query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, page...)
+
+		next := nextURL(resp)
+		if next == nil {
+			return leases, nil
+		}
+		query = next.Query()
+	}
+}
+
+// CreateLease requests a new lease
+func (c *Client) CreateLease(input CreateLeaseInput) (*lease.Lease, error) {
+	out := &lease.Lease{}
+	_, err := c.do(http.MethodPost, "/leases", nil, input, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteLease ends a lease by ID
+func (c *Client) DeleteLease(id string) (*lease.Lease, error) {
+	out := &lease.Lease{}
+	_, err := c.do(http.MethodDelete, "/leases/"+url.PathEscape(id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,280 @@
+// Package cmdb synchronizes DCE accounts with an external configuration
+// management database. Account and lease ownership is pushed to the CMDB,
+// and authoritative tags, such as cost centers, are pulled back.
+package cmdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// DefaultFields are the fields pushed to the CMDB when none are configured
+var DefaultFields = map[string]string{
+	"accountId":     "{{.AccountID}}",
+	"accountStatus": "{{.AccountStatus}}",
+	"leaseId":       "{{.LeaseID}}",
+	"leaseStatus":   "{{.LeaseStatus}}",
+	"principalId":   "{{.PrincipalID}}",
+	"namespace":     "{{.Namespace}}",
+}
+
+// Record is the ownership and status of an account, pushed to the CMDB
+type Record struct {
+	AccountID     string
+	AccountStatus string
+	// LeaseID, LeaseStatus, PrincipalID, BudgetAmount and ExpiresOn are
+	// from the account's active lease, and empty when it has none
+	LeaseID      string
+	LeaseStatus  string
+	PrincipalID  string
+	BudgetAmount float64
+	ExpiresOn    int64
+	Namespace    string
+}
+
+// NewServiceInput are the items needed to create a new CMDB service
+type NewServiceInput struct {
+	// PushURL is a template of the URL to push account records to, eg.
+	// https://cmdb.example.com/api/cis/{{.AccountID}}. Pushing is disabled
+	// when empty
+	PushURL    string `env:"CMDB_PUSH_URL" envDefault:""`
+	PushMethod string `env:"CMDB_PUSH_METHOD" envDefault:"PUT"`
+	// Fields is a JSON object of the fields to push, whose values are
+	// templates, eg. {"u_owner": "{{.PrincipalID}}"}
+	Fields string `env:"CMDB_FIELDS" envDefault:""`
+	// PullURL is a template of the URL to get an account's CMDB record
+	// from. Pulling tags is disabled when empty
+	PullURL string `env:"CMDB_PULL_URL" envDefault:""`
+	// Tags is a JSON object of the tags to pull, whose values are
+	// dot-separated paths in the CMDB record, eg. {"CostCenter": "result.0.u_cost_center"}
+	Tags string `env:"CMDB_TAGS" envDefault:""`
+	// Username and APIToken are used for basic authentication. When there
+	// is no username, the API token is sent as a bearer token
+	Username  string `env:"CMDB_USERNAME" envDefault:""`
+	APIToken  string `env:"CMDB_API_TOKEN" envDefault:""`
+	Namespace string `env:"NAMESPACE" envDefault:"dce"`
+}
+
+// Service pushes records to, and pulls tags from, a CMDB
+type Service struct {
+	httpClient *http.Client
+	pushURL    *template.Template
+	pushMethod string
+	fields     map[string]*template.Template
+	pullURL    *template.Template
+	tags       map[string]string
+	username   string
+	apiToken   string
+	namespace  string
+}
+
+// Enabled is true when pushing records or pulling tags is configured
+func (s *Service) Enabled() bool {
+	return s.pushURL != nil || s.pullURL != nil
+}
+
+// Push sends the ownership and status of an account to the CMDB
+func (s *Service) Push(record *Record) error {
+	if s.pushURL == nil {
+		return nil
+	}
+	data := *record
+	data.Namespace = s.namespace
+
+	pushURL, err := execute(s.pushURL, data)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to render the CMDB URL of account %s", record.AccountID), err)
+	}
+	fields := map[string]string{}
+	for name, tmpl := range s.fields {
+		fields[name], err = execute(tmpl, data)
+		if err != nil {
+			return errors.NewInternalServer(fmt.Sprintf("failed to render the CMDB %s field of account %s", name, record.AccountID), err)
+		}
+	}
+
+	err = s.do(s.pushMethod, pushURL, fields, nil)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to push account %s to the CMDB", record.AccountID), err)
+	}
+	return nil
+}
+
+// Pull gets the tags of an account from the CMDB. Tags missing from the
+// CMDB record are left out, and no tags are returned when the account is
+// not in the CMDB.
+func (s *Service) Pull(accountID string) (map[string]string, error) {
+	tags := map[string]string{}
+	if s.pullURL == nil {
+		return tags, nil
+	}
+
+	pullURL, err := execute(s.pullURL, Record{AccountID: accountID, Namespace: s.namespace})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to render the CMDB URL of account %s", accountID), err)
+	}
+
+	var ci interface{}
+	err = s.do(http.MethodGet, pullURL, nil, &ci)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to pull account %s tags from the CMDB", accountID), err)
+	}
+	if ci == nil {
+		return tags, nil
+	}
+
+	for tag, path := range s.tags {
+		if value, ok := lookup(ci, path); ok {
+			tags[tag] = value
+		}
+	}
+	return tags, nil
+}
+
+// do sends a request to the CMDB. A 404 response leaves the result unset.
+func (s *Service) do(method string, url string, body interface{}, result interface{}) error {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(payload)
+	} else {
+		reqBody = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.apiToken)
+	} else if s.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiToken)
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if result != nil && res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("cmdb %s %s failed (%s): %s", method, req.URL.Path, res.Status, resBody)
+	}
+	if result != nil {
+		return json.NewDecoder(res.Body).Decode(result)
+	}
+	return nil
+}
+
+// lookup gets the value at a dot-separated path in a JSON document. Path
+// segments are object keys, or indexes of arrays.
+func lookup(doc interface{}, path string) (string, bool) {
+	value := doc
+	for _, segment := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[segment]
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			value = v[i]
+		default:
+			return "", false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+func execute(tmpl *template.Template, data Record) (string, error) {
+	buf := &bytes.Buffer{}
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// NewService creates a new CMDB service
+func NewService(input NewServiceInput) (*Service, error) {
+	svc := &Service{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		pushMethod: strings.ToUpper(input.PushMethod),
+		fields:     map[string]*template.Template{},
+		tags:       map[string]string{},
+		username:   input.Username,
+		apiToken:   input.APIToken,
+		namespace:  input.Namespace,
+	}
+
+	var err error
+	if input.PushURL != "" {
+		svc.pushURL, err = template.New("pushURL").Option("missingkey=error").Parse(input.PushURL)
+		if err != nil {
+			return nil, errors.NewValidation("cmdb", fmt.Errorf("invalid push URL: %s", err))
+		}
+
+		fields := DefaultFields
+		if input.Fields != "" {
+			fields = map[string]string{}
+			err = json.Unmarshal([]byte(input.Fields), &fields)
+			if err != nil {
+				return nil, errors.NewValidation("cmdb", fmt.Errorf("invalid fields: %s", err))
+			}
+		}
+		for name, field := range fields {
+			svc.fields[name], err = template.New(name).Option("missingkey=error").Parse(field)
+			if err != nil {
+				return nil, errors.NewValidation("cmdb", fmt.Errorf("invalid %s field: %s", name, err))
+			}
+		}
+	}
+
+	if input.PullURL != "" {
+		svc.pullURL, err = template.New("pullURL").Option("missingkey=error").Parse(input.PullURL)
+		if err != nil {
+			return nil, errors.NewValidation("cmdb", fmt.Errorf("invalid pull URL: %s", err))
+		}
+		if input.Tags == "" {
+			return nil, errors.NewValidation("cmdb", fmt.Errorf("tags are required to pull tags from the CMDB"))
+		}
+		err = json.Unmarshal([]byte(input.Tags), &svc.tags)
+		if err != nil {
+			return nil, errors.NewValidation("cmdb", fmt.Errorf("invalid tags: %s", err))
+		}
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,148 @@
+package cmdb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPush(t *testing.T) {
+	requests := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]string{}
+		_ = json.Unmarshal(body, &payload)
+		requests[r.Method+" "+r.URL.Path] = payload
+		if r.URL.Path == "/cis/999999999999" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		input       NewServiceInput
+		record      *Record
+		expRequests map[string]map[string]string
+		expErr      string
+	}{
+		{
+			name:   "should push the default fields",
+			input:  NewServiceInput{PushURL: server.URL + "/cis/{{.AccountID}}", PushMethod: "put"},
+			record: &Record{AccountID: "123456789012", AccountStatus: "Leased", LeaseID: "abc", LeaseStatus: "Active", PrincipalID: "jdoe"},
+			expRequests: map[string]map[string]string{
+				"PUT /cis/123456789012": {
+					"accountId":     "123456789012",
+					"accountStatus": "Leased",
+					"leaseId":       "abc",
+					"leaseStatus":   "Active",
+					"principalId":   "jdoe",
+					"namespace":     "prod",
+				},
+			},
+		},
+		{
+			name: "should push the mapped fields",
+			input: NewServiceInput{
+				PushURL:    server.URL + "/cis",
+				PushMethod: "POST",
+				Fields:     `{"u_account": "{{.AccountID}}", "u_owner": "{{with .PrincipalID}}{{.}}{{else}}unassigned{{end}}"}`,
+			},
+			record: &Record{AccountID: "123456789012", AccountStatus: "Ready"},
+			expRequests: map[string]map[string]string{
+				"POST /cis": {
+					"u_account": "123456789012",
+					"u_owner":   "unassigned",
+				},
+			},
+		},
+		{
+			name:   "should fail when the CMDB rejects the record",
+			input:  NewServiceInput{PushURL: server.URL + "/cis/{{.AccountID}}", PushMethod: "PUT"},
+			record: &Record{AccountID: "999999999999"},
+			expErr: "failed to push account 999999999999 to the CMDB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k := range requests {
+				delete(requests, k)
+			}
+			tt.input.APIToken = "secret"
+			tt.input.Namespace = "prod"
+			svc, err := NewService(tt.input)
+			assert.Nil(t, err)
+			assert.True(t, svc.Enabled())
+
+			err = svc.Push(tt.record)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expRequests, requests)
+		})
+	}
+}
+
+func TestPull(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "dce", user)
+		assert.Equal(t, "password", pass)
+		switch r.URL.Query().Get("account") {
+		case "123456789012":
+			_, _ = w.Write([]byte(`{"result": [{"u_cost_center": "CC-42", "u_department": {"value": 7}, "u_owner": ""}]}`))
+		case "404404404404":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := NewService(NewServiceInput{
+		PullURL:  server.URL + "/cis?account={{.AccountID}}",
+		Tags:     `{"CostCenter": "result.0.u_cost_center", "Department": "result.0.u_department.value", "Owner": "result.0.u_owner", "Missing": "result.1.u_cost_center"}`,
+		Username: "dce",
+		APIToken: "password",
+	})
+	assert.Nil(t, err)
+
+	tags, err := svc.Pull("123456789012")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"CostCenter": "CC-42", "Department": "7"}, tags)
+
+	tags, err = svc.Pull("404404404404")
+	assert.Nil(t, err)
+	assert.Empty(t, tags)
+
+	_, err = svc.Pull("500500500500")
+	assert.EqualError(t, err, "failed to pull account 500500500500 tags from the CMDB")
+}
+
+func TestNewService(t *testing.T) {
+	svc, err := NewService(NewServiceInput{})
+	assert.Nil(t, err)
+	assert.False(t, svc.Enabled())
+	assert.Nil(t, svc.Push(&Record{AccountID: "123456789012"}))
+	tags, err := svc.Pull("123456789012")
+	assert.Nil(t, err)
+	assert.Empty(t, tags)
+
+	_, err = NewService(NewServiceInput{PushURL: "https://cmdb.example.com", Fields: "{"})
+	assert.EqualError(t, err, "cmdb validation error: invalid fields: unexpected end of JSON input")
+
+	_, err = NewService(NewServiceInput{PushURL: "https://cmdb.example.com/{{.AccountID"})
+	assert.Contains(t, err.Error(), "cmdb validation error: invalid push URL")
+
+	_, err = NewService(NewServiceInput{PullURL: "https://cmdb.example.com"})
+	assert.EqualError(t, err, "cmdb validation error: tags are required to pull tags from the CMDB")
+}
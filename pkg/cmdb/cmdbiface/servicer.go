@@ -0,0 +1,17 @@
+//
+
+package cmdbiface
+
+import (
+	"github.com/Optum/dce/pkg/cmdb"
+)
+
+// Servicer pushes records to, and pulls tags from, a CMDB
+type Servicer interface {
+	// Enabled is true when pushing records or pulling tags is configured
+	Enabled() bool
+	// Push sends the ownership and status of an account to the CMDB
+	Push(record *cmdb.Record) error
+	// Pull gets the tags of an account from the CMDB
+	Pull(accountID string) (map[string]string, error)
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// DefaultCompressionThreshold is the size in bytes, as JSON, above which
+// attributes are compressed.  It leaves room under DynamoDB's 400KB item limit
+// for the rest of the item.
+const DefaultCompressionThreshold = 64 * 1024
+
+// compressedFlagSuffix names the attribute flagging a compressed attribute,
+// eg. "MetadataCompressed" for "Metadata"
+const compressedFlagSuffix = "Compressed"
+
+// CompressAttribute gzips an attribute of a DynamoDB item, when it's larger
+// than the threshold, and flags the item as having it compressed.  The
+// attribute is stored as binary gzipped JSON.  Attributes aren't compressed
+// when the threshold isn't positive.
+func CompressAttribute(item map[string]*dynamodb.AttributeValue, name string, threshold int) error {
+	av, ok := item[name]
+	if !ok || threshold <= 0 {
+		return nil
+	}
+
+	var value interface{}
+	err := dynamodbattribute.Unmarshal(av, &value)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal attribute %s: %w", name, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attribute %s: %w", name, err)
+	}
+	if len(data) <= threshold {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	_, err = writer.Write(data)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compress attribute %s: %w", name, err)
+	}
+
+	item[name] = &dynamodb.AttributeValue{B: buf.Bytes()}
+	item[name+compressedFlagSuffix] = &dynamodb.AttributeValue{BOOL: aws.Bool(true)}
+	return nil
+}
+
+// DecompressAttributes restores the compressed attributes of a DynamoDB item,
+// and removes their flags, so the item can be unmarshaled as usual
+func DecompressAttributes(item map[string]*dynamodb.AttributeValue) error {
+	for flag, av := range item {
+		if !strings.HasSuffix(flag, compressedFlagSuffix) || av.BOOL == nil || !*av.BOOL {
+			continue
+		}
+		name := strings.TrimSuffix(flag, compressedFlagSuffix)
+		compressed, ok := item[name]
+		if !ok || compressed.B == nil {
+			continue
+		}
+
+		reader, err := gzip.NewReader(bytes.NewReader(compressed.B))
+		if err != nil {
+			return fmt.Errorf("failed to decompress attribute %s: %w", name, err)
+		}
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to decompress attribute %s: %w", name, err)
+		}
+		var value interface{}
+		err = json.Unmarshal(data, &value)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal attribute %s: %w", name, err)
+		}
+		restored, err := dynamodbattribute.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal attribute %s: %w", name, err)
+		}
+
+		item[name] = restored
+		delete(item, flag)
+	}
+	return nil
+}
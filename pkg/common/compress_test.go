@@ -0,0 +1,68 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func metadataItem(t *testing.T, size int) map[string]*dynamodb.AttributeValue {
+	metadata, err := dynamodbattribute.Marshal(map[string]interface{}{
+		"groups": strings.Repeat("a", size),
+		"count":  3,
+		"nested": map[string]interface{}{"enabled": true},
+	})
+	require.Nil(t, err)
+	return map[string]*dynamodb.AttributeValue{
+		"Id":       {S: aws.String("123456789012")},
+		"Metadata": metadata,
+	}
+}
+
+func TestCompressAttribute(t *testing.T) {
+	t.Run("should compress and restore a large attribute", func(t *testing.T) {
+		item := metadataItem(t, 1000)
+		original := metadataItem(t, 1000)
+
+		err := CompressAttribute(item, "Metadata", 500)
+		require.Nil(t, err)
+		assert.NotNil(t, item["Metadata"].B)
+		assert.True(t, len(item["Metadata"].B) < 500)
+		assert.True(t, *item["MetadataCompressed"].BOOL)
+
+		err = DecompressAttributes(item)
+		require.Nil(t, err)
+		assert.Equal(t, original, item)
+	})
+
+	t.Run("should leave a small attribute as it is", func(t *testing.T) {
+		item := metadataItem(t, 10)
+
+		err := CompressAttribute(item, "Metadata", 500)
+		require.Nil(t, err)
+		assert.Equal(t, metadataItem(t, 10), item)
+	})
+
+	t.Run("should not compress without a threshold", func(t *testing.T) {
+		item := metadataItem(t, 1000)
+
+		err := CompressAttribute(item, "Metadata", 0)
+		require.Nil(t, err)
+		assert.Equal(t, metadataItem(t, 1000), item)
+	})
+
+	t.Run("should fail to decompress an invalid attribute", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{
+			"Metadata":           {B: []byte("not gzip")},
+			"MetadataCompressed": {BOOL: aws.Bool(true)},
+		}
+
+		err := DecompressAttributes(item)
+		assert.NotNil(t, err)
+	})
+}
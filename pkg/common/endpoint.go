@@ -0,0 +1,31 @@
+package common
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// EndpointConfig configures the client of an AWS service, such as "DynamoDB"
+// or "SQS", to use a custom endpoint, eg. LocalStack or DynamoDB Local.  The
+// endpoint is read from the AWS_ENDPOINT_URL_<SERVICE> environment variable,
+// eg. AWS_ENDPOINT_URL_DYNAMODB, or else from AWS_ENDPOINT_URL.  The AWS
+// endpoint is used when neither is set.
+func EndpointConfig(service string) *aws.Config {
+	cfg := &aws.Config{}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_" + strings.ToUpper(service))
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if endpoint == "" {
+		return cfg
+	}
+
+	cfg.Endpoint = aws.String(endpoint)
+	// Buckets can't be resolved as subdomains of a local endpoint
+	if strings.EqualFold(service, "S3") {
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	return cfg
+}
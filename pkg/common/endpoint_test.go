@@ -0,0 +1,34 @@
+package common
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointConfig(t *testing.T) {
+	defer os.Unsetenv("AWS_ENDPOINT_URL")
+	defer os.Unsetenv("AWS_ENDPOINT_URL_DYNAMODB")
+
+	t.Run("should use the AWS endpoint by default", func(t *testing.T) {
+		cfg := EndpointConfig("DynamoDB")
+		assert.Nil(t, cfg.Endpoint)
+	})
+
+	t.Run("should use the endpoint of every service", func(t *testing.T) {
+		os.Setenv("AWS_ENDPOINT_URL", "http://localhost:4566")
+
+		assert.Equal(t, "http://localhost:4566", *EndpointConfig("SQS").Endpoint)
+		s3Cfg := EndpointConfig("S3")
+		assert.Equal(t, "http://localhost:4566", *s3Cfg.Endpoint)
+		assert.True(t, *s3Cfg.S3ForcePathStyle)
+	})
+
+	t.Run("should prefer the endpoint of the service", func(t *testing.T) {
+		os.Setenv("AWS_ENDPOINT_URL_DYNAMODB", "http://localhost:8000")
+
+		assert.Equal(t, "http://localhost:8000", *EndpointConfig("DynamoDB").Endpoint)
+		assert.Equal(t, "http://localhost:4566", *EndpointConfig("SQS").Endpoint)
+	})
+}
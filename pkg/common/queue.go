@@ -1,8 +1,6 @@
 package common
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 )
 
@@ -52,12 +50,11 @@ func (queue SQSQueue) DeleteMessage(input *sqs.DeleteMessageInput) (
 // Requires env vars for:
 // - AWS_CURRENT_REGION
 func (queue SQSQueue) NewFromEnv() error {
-	awsSession, err := session.NewSession(&aws.Config{
-		Region: aws.String(RequireEnv("AWS_CURRENT_REGION"))})
+	awsSession, err := SharedSession(RequireEnv("AWS_CURRENT_REGION"))
 	if err != nil {
 		return err
 	}
-	queue.Client = sqs.New(awsSession)
+	queue.Client = sqs.New(awsSession, EndpointConfig("SQS"))
 
 	return nil
 }
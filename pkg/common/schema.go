@@ -0,0 +1,123 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+// SchemaVersionAttribute names the attribute recording the schema version a
+// DynamoDB item was written with.  Items written before items were versioned
+// don't have it, and are version 0.
+const SchemaVersionAttribute = "SchemaVersion"
+
+// ItemShim upgrades a DynamoDB item written with one schema version to the
+// next version
+type ItemShim func(item map[string]*dynamodb.AttributeValue) error
+
+// ItemSchema is the schema version items of a table are written with, and the
+// shims which upgrade items written with older versions.  During a rolling
+// upgrade, old and new versions of DCE read and write the same items: the
+// shims let the new version read items the old version wrote, and the write
+// condition stops the old version overwriting items the new version wrote,
+// and dropping the attributes it doesn't know about.
+type ItemSchema struct {
+	// Version is the schema version items are written with
+	Version int
+	// Shims upgrade items by the version they upgrade from, eg. Shims[0]
+	// upgrades unversioned items to version 1.  Versions without a shim
+	// only stamp the version.
+	Shims map[int]ItemShim
+}
+
+// AccountItemSchema is the schema of the items of the Accounts table
+var AccountItemSchema = ItemSchema{
+	// Version 2 adds the principal policy customization of the lease
+	Version: 2,
+}
+
+// LeaseItemSchema is the schema of the items of the Leases table
+var LeaseItemSchema = ItemSchema{
+	// Version 2 adds the principal policy customization
+	Version: 2,
+	Shims: map[int]ItemShim{
+		// Leases written before v0.11.0 don't record when their status changed
+		0: copyAttributeIfMissing("LastModifiedOn", "LeaseStatusModifiedOn"),
+	},
+}
+
+// UsageItemSchema is the schema of the items of the Usage table
+var UsageItemSchema = ItemSchema{
+	Version: 1,
+}
+
+// ItemVersion gets the schema version an item was written with
+func ItemVersion(item map[string]*dynamodb.AttributeValue) (int, error) {
+	av, ok := item[SchemaVersionAttribute]
+	if !ok || av.N == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(*av.N)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", SchemaVersionAttribute, *av.N, err)
+	}
+	return version, nil
+}
+
+// Stamp records the schema version on an item being written
+func (s ItemSchema) Stamp(item map[string]*dynamodb.AttributeValue) {
+	item[SchemaVersionAttribute] = &dynamodb.AttributeValue{
+		N: aws.String(strconv.Itoa(s.Version)),
+	}
+}
+
+// Decode upgrades an item read from DynamoDB to the schema version, so it can
+// be unmarshaled as usual.  Items written with a newer version are read as
+// they are, as the newer version only adds attributes.
+func (s ItemSchema) Decode(item map[string]*dynamodb.AttributeValue) error {
+	if len(item) == 0 {
+		return nil
+	}
+	version, err := ItemVersion(item)
+	if err != nil {
+		return err
+	}
+	if version > s.Version {
+		log.Printf("Reading an item written with schema version %d, newer than %d", version, s.Version)
+		return nil
+	}
+	for ; version < s.Version; version++ {
+		shim, ok := s.Shims[version]
+		if !ok {
+			continue
+		}
+		err = shim(item)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade item from schema version %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// WriteCondition only allows items to be written over items written with the
+// same or an older schema version
+func (s ItemSchema) WriteCondition() expression.ConditionBuilder {
+	return expression.Name(SchemaVersionAttribute).AttributeNotExists().
+		Or(expression.Name(SchemaVersionAttribute).LessThanEqual(expression.Value(s.Version)))
+}
+
+func copyAttributeIfMissing(from string, to string) ItemShim {
+	return func(item map[string]*dynamodb.AttributeValue) error {
+		if _, ok := item[to]; ok {
+			return nil
+		}
+		if av, ok := item[from]; ok {
+			item[to] = av
+		}
+		return nil
+	}
+}
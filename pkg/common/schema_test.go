@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemSchema(t *testing.T) {
+	schema := ItemSchema{
+		Version: 3,
+		Shims: map[int]ItemShim{
+			0: copyAttributeIfMissing("LastModifiedOn", "StatusModifiedOn"),
+			2: func(item map[string]*dynamodb.AttributeValue) error {
+				item["Upgraded"] = &dynamodb.AttributeValue{BOOL: aws.Bool(true)}
+				return nil
+			},
+		},
+	}
+
+	t.Run("should stamp the version", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{}
+		schema.Stamp(item)
+		version, err := ItemVersion(item)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, version)
+	})
+
+	t.Run("should upgrade unversioned items through every version", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{
+			"LastModifiedOn": {N: aws.String("1573592058")},
+		}
+		require.Nil(t, schema.Decode(item))
+		assert.Equal(t, "1573592058", *item["StatusModifiedOn"].N)
+		assert.True(t, *item["Upgraded"].BOOL)
+	})
+
+	t.Run("should only apply the shims of newer versions", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{
+			SchemaVersionAttribute: {N: aws.String("1")},
+			"LastModifiedOn":       {N: aws.String("1573592058")},
+		}
+		require.Nil(t, schema.Decode(item))
+		assert.NotContains(t, item, "StatusModifiedOn")
+		assert.True(t, *item["Upgraded"].BOOL)
+	})
+
+	t.Run("should read items of a newer version as they are", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{
+			SchemaVersionAttribute: {N: aws.String("4")},
+			"NewAttribute":         {S: aws.String("value")},
+		}
+		require.Nil(t, schema.Decode(item))
+		assert.Len(t, item, 2)
+	})
+
+	t.Run("should fail on an invalid version", func(t *testing.T) {
+		item := map[string]*dynamodb.AttributeValue{
+			SchemaVersionAttribute: {N: aws.String("one")},
+		}
+		assert.NotNil(t, schema.Decode(item))
+	})
+
+	t.Run("should fail when a shim fails", func(t *testing.T) {
+		failing := ItemSchema{
+			Version: 1,
+			Shims: map[int]ItemShim{
+				0: func(item map[string]*dynamodb.AttributeValue) error {
+					return fmt.Errorf("failure")
+				},
+			},
+		}
+		err := failing.Decode(map[string]*dynamodb.AttributeValue{"Id": {S: aws.String("1")}})
+		assert.EqualError(t, err, "failed to upgrade item from schema version 0: failure")
+	})
+}
+
+func TestItemSchemaWriteCondition(t *testing.T) {
+	expr, err := expression.NewBuilder().WithCondition(ItemSchema{Version: 2}.WriteCondition()).Build()
+	require.Nil(t, err)
+
+	assert.Equal(t, "(attribute_not_exists (#0)) OR (#0 <= :0)", *expr.Condition())
+	assert.Equal(t, SchemaVersionAttribute, *expr.Names()["#0"])
+	assert.Equal(t, "2", *expr.Values()[":0"].N)
+}
+
+func TestLeaseItemSchema(t *testing.T) {
+	item := map[string]*dynamodb.AttributeValue{
+		"LastModifiedOn":        {N: aws.String("1573592058")},
+		"LeaseStatusModifiedOn": {N: aws.String("1573590000")},
+	}
+	require.Nil(t, LeaseItemSchema.Decode(item))
+	assert.Equal(t, "1573590000", *item["LeaseStatusModifiedOn"].N, "the status change time shouldn't be overwritten")
+}
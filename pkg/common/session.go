@@ -0,0 +1,50 @@
+package common
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Optum/dce/pkg/fault"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	sessionsLock sync.Mutex
+	sessions     = map[string]*session.Session{}
+)
+
+// SharedSession returns an AWS session for the region, which is created the
+// first time it is needed and shared by every client in the process after
+// that.  Creating a session loads the shared config files and resolves the
+// credential chain, which is a large part of a Lambda cold start, so
+// clients should not create their own.  The region may be empty, to use the
+// default region.
+func SharedSession(region string) (*session.Session, error) {
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	if sess, ok := sessions[region]; ok {
+		return sess, nil
+	}
+
+	cfg := &aws.Config{}
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Tests may inject faults into the requests of every client
+	injector, err := fault.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if injector != nil {
+		log.Printf("Injecting the faults of %s into AWS requests", fault.EnvKey)
+		injector.Apply(&sess.Handlers)
+	}
+	sessions[region] = sess
+	return sess, nil
+}
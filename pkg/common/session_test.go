@@ -0,0 +1,43 @@
+package common
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Optum/dce/pkg/fault"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedSession(t *testing.T) {
+	east, err := SharedSession("us-east-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east-1", aws.StringValue(east.Config.Region))
+
+	again, err := SharedSession("us-east-1")
+	assert.Nil(t, err)
+	assert.True(t, east == again, "expected the session to be shared")
+
+	west, err := SharedSession("us-west-2")
+	assert.Nil(t, err)
+	assert.Equal(t, "us-west-2", aws.StringValue(west.Config.Region))
+	assert.False(t, east == west, "expected a session for each region")
+}
+
+func TestSharedSessionWithFaults(t *testing.T) {
+	defer os.Unsetenv(fault.EnvKey)
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	os.Setenv("AWS_ACCESS_KEY_ID", "id")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	os.Setenv(fault.EnvKey, `[{"service": "sts", "operation": "GetCallerIdentity", "code": "AccessDenied", "statusCode": 403}]`)
+
+	sess, err := SharedSession("eu-central-1")
+	assert.Nil(t, err)
+
+	_, err = sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "AccessDenied", err.(awserr.Error).Code())
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// StatusShard gets the sharded status key of a record, eg. "Ready#shard2".
+// Records are spread over the shards by a hash of their key, so a record
+// stays in the same shard while its status doesn't change.
+func StatusShard(status string, key string, shards int) string {
+	return StatusShardKey(status, ShardIndex(key, shards))
+}
+
+// StatusShardKey gets the sharded status key of a shard
+func StatusShardKey(status string, shard int) string {
+	return fmt.Sprintf("%s#shard%d", status, shard)
+}
+
+// StatusShardKeys gets the sharded status keys of every shard of a status
+func StatusShardKeys(status string, shards int) []string {
+	if shards < 1 {
+		shards = 1
+	}
+	keys := []string{}
+	for i := 0; i < shards; i++ {
+		keys = append(keys, StatusShardKey(status, i))
+	}
+	return keys
+}
+
+// ShardIndex gets the shard a record key belongs to
+func ShardIndex(key string, shards int) int {
+	if shards < 2 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// LeaseStatusShard gets the sharded status key of a lease, which is keyed
+// by its account and principal
+func LeaseStatusShard(status string, accountID string, principalID string, shards int) string {
+	return StatusShard(status, LeaseShardKey(accountID, principalID), shards)
+}
+
+// LeaseShardKey gets the key a lease is sharded by
+func LeaseShardKey(accountID string, principalID string) string {
+	return accountID + "/" + principalID
+}
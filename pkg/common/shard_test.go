@@ -0,0 +1,25 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusShard(t *testing.T) {
+	assert.Equal(t, "Ready#shard0", StatusShard("Ready", "123456789012", 1))
+	assert.Equal(t, "Ready#shard0", StatusShard("Ready", "123456789012", 0))
+	assert.Equal(t, StatusShard("Ready", "123456789012", 8), StatusShard("Ready", "123456789012", 8))
+	assert.Equal(t, []string{"Active#shard0", "Active#shard1", "Active#shard2"}, StatusShardKeys("Active", 3))
+	assert.Equal(t, []string{"Active#shard0"}, StatusShardKeys("Active", 0))
+
+	// Keys should be spread over every shard
+	counts := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		counts[ShardIndex(fmt.Sprintf("%012d", i), 4)]++
+	}
+	for shard, count := range counts {
+		assert.True(t, count > 150, "expected shard %d to have more than 150 of 1000 keys, got %d", shard, count)
+	}
+}
@@ -37,8 +37,16 @@ func (service STS) NewCredentials(inputClient client.ConfigProvider,
 	return stscreds.NewCredentials(inputClient, inputRole)
 }
 
+// NewSession returns a session with the credentials of the role.  A copy of
+// the base session is used when possible, which is much cheaper than
+// creating a new session.
 func (service STS) NewSession(baseSession awsiface.AwsSession, roleArn string) (awsiface.AwsSession, error) {
 	creds := service.NewCredentials(baseSession, roleArn)
+	if base, ok := baseSession.(*session.Session); ok {
+		return base.Copy(&aws.Config{
+			Credentials: creds,
+		}), nil
+	}
 	newSession, err := session.NewSession(&aws.Config{
 		Credentials: creds,
 	})
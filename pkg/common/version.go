@@ -0,0 +1,59 @@
+package common
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+// VersionAttribute names the attribute counting the writes of an account or
+// lease item.  Every write adds one to it, and writes of whole items are
+// conditional on the version the item was read at, so a writer which read
+// the item before another write fails instead of overwriting it.
+// LastModifiedOn can't be used for this, as writes within the same second
+// have the same LastModifiedOn.  Items which haven't been written since
+// writes were counted don't have it.
+const VersionAttribute = "Version"
+
+// VersionCondition only allows a whole item to be written over the version
+// it was read at.  Items read without a version are only written while they
+// still don't have one, and weren't modified since prevLastModifiedOn.  New
+// items, without a prevLastModifiedOn, are only written when they don't
+// exist yet.
+func (s ItemSchema) VersionCondition(version *int64, prevLastModifiedOn *int64) expression.ConditionBuilder {
+	if prevLastModifiedOn == nil {
+		return expression.Name("LastModifiedOn").AttributeNotExists()
+	}
+	var condition expression.ConditionBuilder
+	if version == nil {
+		condition = expression.Name(VersionAttribute).AttributeNotExists().
+			And(expression.Name("LastModifiedOn").Equal(expression.Value(*prevLastModifiedOn)))
+	} else {
+		condition = expression.Name(VersionAttribute).Equal(expression.Value(*version))
+	}
+	return condition.And(s.WriteCondition())
+}
+
+// NextVersion is the version an item read at version is written with
+func NextVersion(version *int64) *int64 {
+	next := aws.Int64Value(version) + 1
+	return &next
+}
+
+// IncrementVersion adds one to the version of an item in an update
+func IncrementVersion(update expression.UpdateBuilder) expression.UpdateBuilder {
+	return update.Add(expression.Name(VersionAttribute), expression.Value(1))
+}
+
+// IncrementVersionClause adds one to the version of an item in an update
+// written as a raw update expression.  It's appended to the update
+// expression, which must be complete otherwise, and adds the name and value
+// it uses.
+func IncrementVersionClause(updateExpression **string, names *map[string]*string, values map[string]*dynamodb.AttributeValue) {
+	if *names == nil {
+		*names = map[string]*string{}
+	}
+	(*names)["#version"] = aws.String(VersionAttribute)
+	values[":versionIncrement"] = &dynamodb.AttributeValue{N: aws.String("1")}
+	*updateExpression = aws.String(aws.StringValue(*updateExpression) + " ADD #version :versionIncrement")
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+// Task is a unit of work run by a WorkerPool.  Long running tasks should
+// stop when the context is done
+type Task func(ctx context.Context) error
+
+// WorkerPool runs tasks with bounded concurrency, and collects their errors.
+// Submit blocks while every worker is busy, so callers can submit as many
+// tasks as they have without spawning a goroutine for each.
+//
+//	pool := common.NewWorkerPool(ctx, 10)
+//	for _, item := range items {
+//		item := item
+//		pool.Submit(func(ctx context.Context) error {
+//			return process(ctx, item)
+//		})
+//	}
+//	errs := pool.Wait()
+type WorkerPool struct {
+	ctx   context.Context
+	tasks chan Task
+	wg    sync.WaitGroup
+	lock  sync.Mutex
+	errs  []error
+	// canceled is true once a task was skipped because the context is done
+	canceled bool
+}
+
+// NewWorkerPool starts a pool of workers, which run at most size tasks at
+// a time.  Tasks which are submitted after the context is done are skipped.
+func NewWorkerPool(ctx context.Context, size int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	pool := &WorkerPool{
+		ctx:   ctx,
+		tasks: make(chan Task),
+	}
+	pool.wg.Add(size)
+	for i := 0; i < size; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+// Submit queues a task, waiting for a worker to be free
+func (p *WorkerPool) Submit(task Task) {
+	select {
+	case p.tasks <- task:
+	case <-p.ctx.Done():
+		p.lock.Lock()
+		p.canceled = true
+		p.lock.Unlock()
+	}
+}
+
+// Wait waits for the submitted tasks to finish, and stops the workers.  It
+// returns the errors of the tasks which failed, in the order they failed,
+// and the context's error if any tasks were skipped.  The pool can't be
+// used after Wait.
+func (p *WorkerPool) Wait() []error {
+	close(p.tasks)
+	p.wg.Wait()
+
+	if p.canceled {
+		p.errs = append(p.errs, p.ctx.Err())
+	}
+	return p.errs
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		// The context may be done while the task was waiting for a worker
+		if p.ctx.Err() != nil {
+			p.lock.Lock()
+			p.canceled = true
+			p.lock.Unlock()
+			continue
+		}
+		err := task(p.ctx)
+		if err != nil {
+			p.lock.Lock()
+			p.errs = append(p.errs, err)
+			p.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("should run every task with bounded concurrency", func(t *testing.T) {
+		var running, maxRunning, ran int32
+		pool := NewWorkerPool(context.Background(), 3)
+		for i := 0; i < 20; i++ {
+			pool.Submit(func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&ran, 1)
+				return nil
+			})
+		}
+		errs := pool.Wait()
+
+		assert.Empty(t, errs)
+		assert.Equal(t, int32(20), ran)
+		assert.True(t, maxRunning <= 3, "ran %d tasks at once", maxRunning)
+	})
+
+	t.Run("should collect the errors of failed tasks", func(t *testing.T) {
+		pool := NewWorkerPool(context.Background(), 2)
+		for i := 0; i < 5; i++ {
+			i := i
+			pool.Submit(func(ctx context.Context) error {
+				if i%2 == 0 {
+					return fmt.Errorf("task %d failed", i)
+				}
+				return nil
+			})
+		}
+		errs := pool.Wait()
+
+		assert.ElementsMatch(t, []error{
+			fmt.Errorf("task 0 failed"),
+			fmt.Errorf("task 2 failed"),
+			fmt.Errorf("task 4 failed"),
+		}, errs)
+	})
+
+	t.Run("should skip tasks once the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var ran int32
+		pool := NewWorkerPool(ctx, 1)
+		for i := 0; i < 5; i++ {
+			pool.Submit(func(ctx context.Context) error {
+				atomic.AddInt32(&ran, 1)
+				cancel()
+				return nil
+			})
+		}
+		errs := pool.Wait()
+
+		assert.Equal(t, int32(1), ran)
+		assert.Equal(t, []error{context.Canceled}, errs)
+	})
+}
@@ -8,8 +8,13 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env"
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -18,6 +23,39 @@ import (
 // building the configuration.
 type ConfigurationError error
 
+// ConfigurationErrors are all the errors found in the configuration, so they
+// can be fixed at once, instead of one cold start at a time
+type ConfigurationErrors []error
+
+func (errs ConfigurationErrors) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(errorMessages(errs), "; "))
+}
+
+// errorMessages are the messages of the errors
+func errorMessages(errs []error) []string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return msgs
+}
+
+// Validator is implemented by configuration structures with values which
+// must be in range.  Unmarshal validates them once they're loaded.
+type Validator interface {
+	Validate() error
+}
+
+// missingServiceError is returned by GetService when no service of the type
+// was added to the configuration
+type missingServiceError struct {
+	svcType reflect.Type
+}
+
+func (e *missingServiceError) Error() string {
+	return fmt.Sprintf("no service found in configuration for key type: %s", e.svcType)
+}
+
 // GenericConfiguration is a generic structure that contains configuration
 type configurationValues struct {
 	services []interface{}
@@ -36,10 +74,117 @@ type ConfigurationBuilder struct {
 
 // Unmarshal loads configuration into the provided structure from environment variables.
 // Use the "env" tag on cfgStruct fields to indicate the corresponding environment variable to load from.
+// Every environment variable which is missing or can't be parsed is returned
+// in ConfigurationErrors, and structures which implement Validator are
+// validated.
 func (config *ConfigurationBuilder) Unmarshal(cfgStruct interface{}) error {
 	config.parsers = config.createCustomParsers()
+	if errs := envErrors(cfgStruct); len(errs) > 0 {
+		return errs
+	}
 	err := env.ParseWithFuncs(cfgStruct, config.parsers)
-	return err
+	if err != nil {
+		return err
+	}
+	if v, ok := cfgStruct.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return validationErrors(cfgStruct, err)
+		}
+	}
+	return nil
+}
+
+// validationErrors names the environment variables of the fields of
+// cfgStruct which aren't valid
+func validationErrors(cfgStruct interface{}, err error) ConfigurationErrors {
+	fieldErrs, ok := err.(validation.Errors)
+	if !ok {
+		return ConfigurationErrors{fmt.Errorf("%s: %w", reflect.TypeOf(cfgStruct).Elem(), err)}
+	}
+	names := make([]string, 0, len(fieldErrs))
+	for name := range fieldErrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	refType := reflect.TypeOf(cfgStruct).Elem()
+	var errs ConfigurationErrors
+	for _, name := range names {
+		key := name
+		if field, ok := refType.FieldByName(name); ok && field.Tag.Get("env") != "" {
+			key = strings.Split(field.Tag.Get("env"), ",")[0]
+		}
+		errs = append(errs, fmt.Errorf("environment variable %q %s", key, fieldErrs[name]))
+	}
+	return errs
+}
+
+// envErrors are the required environment variables of cfgStruct which aren't
+// set, and those which can't be parsed as their fields, named so they can be
+// found.  env only returns the parsing error.
+func envErrors(cfgStruct interface{}) ConfigurationErrors {
+	ref := reflect.ValueOf(cfgStruct)
+	if ref.Kind() != reflect.Ptr || ref.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	refType := ref.Elem().Type()
+	var errs ConfigurationErrors
+	for i := 0; i < refType.NumField(); i++ {
+		field := refType.Field(i)
+		opts := strings.Split(field.Tag.Get("env"), ",")
+		key := opts[0]
+		if key == "" {
+			continue
+		}
+		val, ok := os.LookupEnv(key)
+		if !ok {
+			for _, opt := range opts[1:] {
+				if opt == "required" {
+					errs = append(errs, fmt.Errorf("required environment variable %q is not set", key))
+				}
+			}
+			continue
+		}
+		if val == "" {
+			continue
+		}
+		vals := []string{val}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Slice {
+			sep := field.Tag.Get("envSeparator")
+			if sep == "" {
+				sep = ","
+			}
+			vals = strings.Split(val, sep)
+			fieldType = fieldType.Elem()
+		}
+		for _, v := range vals {
+			if !parses(fieldType, v) {
+				errs = append(errs, fmt.Errorf("environment variable %q is %q, which isn't a valid %s", key, val, fieldType))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// parses is whether val can be parsed as a value of the type
+func parses(t reflect.Type, val string) bool {
+	var err error
+	switch t.Kind() {
+	case reflect.Bool:
+		_, err = strconv.ParseBool(val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			_, err = time.ParseDuration(val)
+		} else {
+			_, err = strconv.ParseInt(val, 10, t.Bits())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(val, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(val, t.Bits())
+	}
+	return err == nil
 }
 
 // Dump dumps the current config into the provided structure. Config keys are matched to
@@ -141,7 +286,7 @@ func (config *ConfigurationBuilder) GetService(svcFor interface{}) error {
 			return nil
 		}
 	}
-	return ConfigurationError(fmt.Errorf("no service found in configuration for key type: %s", k))
+	return ConfigurationError(&missingServiceError{svcType: k})
 }
 
 // GetStringVal returns the value of the key as a string.
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"testing"
 
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -321,3 +322,77 @@ func TestConfigBuilder_BuildWithServiceWithError(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, expectedErr.Error(), err.Error())
 }
+
+type rangedConfig struct {
+	Shards  int      `env:"TEST_SHARDS" envDefault:"1"`
+	Enabled bool     `env:"TEST_ENABLED" envDefault:"false"`
+	Amounts []int    `env:"TEST_AMOUNTS" envDefault:"1,2"`
+	Table   string   `env:"TEST_TABLE,required"`
+	Tags    []string `env:"TEST_TAGS"`
+}
+
+func (c *rangedConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Shards, validation.Min(1), validation.Max(8)),
+	)
+}
+
+func TestConfigBuilder_UnmarshalInvalid(t *testing.T) {
+	defer os.Unsetenv("TEST_SHARDS")
+	defer os.Unsetenv("TEST_ENABLED")
+	defer os.Unsetenv("TEST_AMOUNTS")
+	defer os.Unsetenv("TEST_TABLE")
+
+	t.Run("every variable which is missing or can't be parsed is named", func(t *testing.T) {
+		os.Setenv("TEST_SHARDS", "four")
+		os.Setenv("TEST_ENABLED", "yes please")
+		os.Setenv("TEST_AMOUNTS", "1,two")
+
+		err := (&ConfigurationBuilder{}).Unmarshal(&rangedConfig{})
+		assert.Equal(t, ConfigurationErrors{
+			errors.New("environment variable \"TEST_SHARDS\" is \"four\", which isn't a valid int"),
+			errors.New("environment variable \"TEST_ENABLED\" is \"yes please\", which isn't a valid bool"),
+			errors.New("environment variable \"TEST_AMOUNTS\" is \"1,two\", which isn't a valid int"),
+			errors.New("required environment variable \"TEST_TABLE\" is not set"),
+		}, err)
+	})
+
+	t.Run("values out of range are named", func(t *testing.T) {
+		os.Setenv("TEST_SHARDS", "16")
+		os.Setenv("TEST_ENABLED", "true")
+		os.Setenv("TEST_AMOUNTS", "1,2,3")
+		os.Setenv("TEST_TABLE", "Accounts")
+
+		err := (&ConfigurationBuilder{}).Unmarshal(&rangedConfig{})
+		assert.Equal(t, "invalid configuration: environment variable \"TEST_SHARDS\" must be no greater than 8", err.Error())
+	})
+
+	t.Run("valid values are loaded", func(t *testing.T) {
+		os.Setenv("TEST_SHARDS", "4")
+
+		cfg := rangedConfig{}
+		err := (&ConfigurationBuilder{}).Unmarshal(&cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, rangedConfig{Shards: 4, Enabled: true, Amounts: []int{1, 2, 3}, Table: "Accounts"}, cfg)
+	})
+}
+
+func TestServiceBuilder_BuildReturnsEveryError(t *testing.T) {
+	bldr := &ServiceBuilder{Config: &ConfigurationBuilder{}}
+	bldr.Config.WithEnv("AWS_CURRENT_REGION", "AWS_CURRENT_REGION", "us-east-1")
+	bldr.handlers = []createrFunc{
+		func(config ConfigurationServiceBuilder) error {
+			return ConfigurationErrors{errors.New("first"), errors.New("second")}
+		},
+		func(config ConfigurationServiceBuilder) error {
+			var namer Namer
+			return bldr.Config.GetService(&namer)
+		},
+		func(config ConfigurationServiceBuilder) error {
+			return errors.New("third")
+		},
+	}
+
+	_, err := bldr.Build()
+	assert.Equal(t, "invalid configuration: first; second; third", err.Error())
+}
@@ -1,47 +1,96 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"reflect"
 	"runtime"
+	"strings"
 
 	"github.com/Optum/dce/pkg/api"
 
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
 	"github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 
 	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/account/accountiface"
+	"github.com/Optum/dce/pkg/accountfactory"
+	"github.com/Optum/dce/pkg/accountfactory/accountfactoryiface"
 	"github.com/Optum/dce/pkg/accountmanager"
 	"github.com/Optum/dce/pkg/accountmanager/accountmanageriface"
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/activity/activityiface"
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
+	"github.com/Optum/dce/pkg/apikey"
+	"github.com/Optum/dce/pkg/apikey/apikeyiface"
+	"github.com/Optum/dce/pkg/cmdb"
+	"github.com/Optum/dce/pkg/cmdb/cmdbiface"
 	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/data"
 	"github.com/Optum/dce/pkg/data/dataiface"
+	"github.com/Optum/dce/pkg/directory"
+	"github.com/Optum/dce/pkg/directory/directoryiface"
+	"github.com/Optum/dce/pkg/email"
 	"github.com/Optum/dce/pkg/event"
 	"github.com/Optum/dce/pkg/event/eventiface"
+	"github.com/Optum/dce/pkg/identity"
+	"github.com/Optum/dce/pkg/identity/identityiface"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/Optum/dce/pkg/lease/leaseiface"
+	"github.com/Optum/dce/pkg/metrics"
+	"github.com/Optum/dce/pkg/metrics/metricsiface"
+	"github.com/Optum/dce/pkg/monitoring"
+	"github.com/Optum/dce/pkg/monitoring/monitoringiface"
+	"github.com/Optum/dce/pkg/notification"
+	"github.com/Optum/dce/pkg/notification/notificationiface"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/outbox/outboxiface"
+	"github.com/Optum/dce/pkg/policy"
+	"github.com/Optum/dce/pkg/policy/policyiface"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/routing"
+	"github.com/Optum/dce/pkg/routing/routingiface"
+	"github.com/Optum/dce/pkg/settings"
+	"github.com/Optum/dce/pkg/settings/settingsiface"
+	"github.com/Optum/dce/pkg/ticket"
+	"github.com/Optum/dce/pkg/ticket/ticketiface"
+	"github.com/Optum/dce/pkg/waitlist"
+	"github.com/Optum/dce/pkg/waitlist/waitlistiface"
 
 	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/codebuild"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/servicecatalog"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -116,6 +165,24 @@ func (bldr *ServiceBuilder) WithCloudWatchEventsService() *ServiceBuilder {
 	return bldr
 }
 
+// WithEventBridge tells the builder to add an AWS EventBridge service to the `DefaultConfigurater`
+func (bldr *ServiceBuilder) WithEventBridge() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createEventBridge)
+	return bldr
+}
+
+// WithKinesis tells the builder to add an AWS Kinesis service to the `DefaultConfigurater`
+func (bldr *ServiceBuilder) WithKinesis() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createKinesis)
+	return bldr
+}
+
+// WithStepFunctions tells the builder to add an AWS Step Functions service to the `DefaultConfigurater`
+func (bldr *ServiceBuilder) WithStepFunctions() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createStepFunctions)
+	return bldr
+}
+
 // WithCognito tells the builder to add an AWS Cognito service to the `DefaultConfigurater`
 func (bldr *ServiceBuilder) WithCognito() *ServiceBuilder {
 	bldr.handlers = append(bldr.handlers, bldr.createCognito)
@@ -134,12 +201,24 @@ func (bldr *ServiceBuilder) WithSSM() *ServiceBuilder {
 	return bldr
 }
 
+// WithCostExplorer tells the builder to add an AWS Cost Explorer service to the `DefaultConfigurater`
+func (bldr *ServiceBuilder) WithCostExplorer() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createCostExplorer)
+	return bldr
+}
+
 // WithLambda tells the builder to add an AWS Lambda service to the `DefaultConfigurater`
 func (bldr *ServiceBuilder) WithLambda() *ServiceBuilder {
 	bldr.handlers = append(bldr.handlers, bldr.createLambda)
 	return bldr
 }
 
+// WithSES tells the builder to add an AWS SES service to the `DefaultConfigurater`
+func (bldr *ServiceBuilder) WithSES() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createSES)
+	return bldr
+}
+
 // WithStorageService tells the builder to add the DCE DAO (DBer) service to the `ConfigurationBuilder`
 func (bldr *ServiceBuilder) WithStorageService() *ServiceBuilder {
 	bldr.WithS3()
@@ -179,7 +258,7 @@ func (bldr *ServiceBuilder) AccountManager() accountmanageriface.Servicer {
 
 // WithAccountService tells the builder to add the Account service to the `ConfigurationBuilder`
 func (bldr *ServiceBuilder) WithAccountService() *ServiceBuilder {
-	bldr.WithAccountManagerService().WithEventService().WithAccountDataService()
+	bldr.WithAccountManagerService().WithEventService().WithAccountDataService().WithPolicyService()
 	bldr.handlers = append(bldr.handlers, bldr.createAccountService)
 	return bldr
 }
@@ -197,7 +276,7 @@ func (bldr *ServiceBuilder) AccountService() accountiface.Servicer {
 
 // WithLeaseService tells the builder to add the Account service to the `ConfigurationBuilder`
 func (bldr *ServiceBuilder) WithLeaseService() *ServiceBuilder {
-	bldr.WithLeaseDataService().WithEventService().WithAccountService()
+	bldr.WithLeaseDataService().WithEventService().WithAccountService().WithSSM().WithPolicyService().WithSettingsService()
 	bldr.handlers = append(bldr.handlers, bldr.createLeaseService)
 	return bldr
 }
@@ -215,11 +294,301 @@ func (bldr *ServiceBuilder) LeaseService() leaseiface.Servicer {
 
 // WithEventService tells the builder to add the Account service to the `ConfigurationBuilder`
 func (bldr *ServiceBuilder) WithEventService() *ServiceBuilder {
-	bldr.WithSQS().WithSNS().WithCloudWatchEventsService()
+	bldr.WithSQS().WithSNS().WithCloudWatchEventsService().WithEventBridge().WithStepFunctions().WithS3().WithKinesis().WithDynamoDB()
 	bldr.handlers = append(bldr.handlers, bldr.createEventService)
 	return bldr
 }
 
+// EventService returns the event Service for you
+func (bldr *ServiceBuilder) EventService() eventiface.Servicer {
+
+	var eventSvc eventiface.Servicer
+	if err := bldr.Config.GetService(&eventSvc); err != nil {
+		panic(err)
+	}
+
+	return eventSvc
+}
+
+// WithActivityService tells the builder to add the Activity service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithActivityService() *ServiceBuilder {
+	bldr.WithDynamoDB()
+	bldr.handlers = append(bldr.handlers, bldr.createActivityService)
+	return bldr
+}
+
+// ActivityService returns the activity Service for you
+func (bldr *ServiceBuilder) ActivityService() activityiface.Servicer {
+
+	var activitySvc activityiface.Servicer
+	if err := bldr.Config.GetService(&activitySvc); err != nil {
+		panic(err)
+	}
+
+	return activitySvc
+}
+
+// WithAlertService tells the builder to add the Alert service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithAlertService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createAlertService)
+	return bldr
+}
+
+// AlertService returns the alert Service for you
+func (bldr *ServiceBuilder) AlertService() alertiface.Servicer {
+
+	var alertSvc alertiface.Servicer
+	if err := bldr.Config.GetService(&alertSvc); err != nil {
+		panic(err)
+	}
+
+	return alertSvc
+}
+
+// WithPolicyService tells the builder to add the Policy service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithPolicyService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createPolicyService)
+	return bldr
+}
+
+// PolicyService returns the policy Service for you
+func (bldr *ServiceBuilder) PolicyService() policyiface.Servicer {
+
+	var policySvc policyiface.Servicer
+	if err := bldr.Config.GetService(&policySvc); err != nil {
+		panic(err)
+	}
+
+	return policySvc
+}
+
+// WithOutboxService tells the builder to add the Outbox service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithOutboxService() *ServiceBuilder {
+	bldr.WithDynamoDB()
+	bldr.handlers = append(bldr.handlers, bldr.createOutboxService)
+	return bldr
+}
+
+// OutboxService returns the outbox Service for you
+func (bldr *ServiceBuilder) OutboxService() outboxiface.Servicer {
+
+	var outboxSvc outboxiface.Servicer
+	if err := bldr.Config.GetService(&outboxSvc); err != nil {
+		panic(err)
+	}
+
+	return outboxSvc
+}
+
+// WithWaitlistService tells the builder to add the Waitlist service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithWaitlistService() *ServiceBuilder {
+	bldr.WithDynamoDB()
+	bldr.handlers = append(bldr.handlers, bldr.createWaitlistService)
+	return bldr
+}
+
+// WaitlistService returns the waitlist Service for you
+func (bldr *ServiceBuilder) WaitlistService() waitlistiface.Servicer {
+
+	var waitlistSvc waitlistiface.Servicer
+	if err := bldr.Config.GetService(&waitlistSvc); err != nil {
+		panic(err)
+	}
+
+	return waitlistSvc
+}
+
+// WithRoutingService tells the builder to add the notification Routing service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithRoutingService() *ServiceBuilder {
+	bldr.WithDynamoDB().WithSSM().WithSES().WithAlertService()
+	bldr.handlers = append(bldr.handlers, bldr.createRoutingService)
+	return bldr
+}
+
+// RoutingService returns the notification routing Service for you
+func (bldr *ServiceBuilder) RoutingService() routingiface.Servicer {
+
+	var routingSvc routingiface.Servicer
+	if err := bldr.Config.GetService(&routingSvc); err != nil {
+		panic(err)
+	}
+
+	return routingSvc
+}
+
+// WithAPIKeyService tells the builder to add the API key service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithAPIKeyService() *ServiceBuilder {
+	bldr.WithDynamoDB()
+	bldr.handlers = append(bldr.handlers, bldr.createAPIKeyService)
+	return bldr
+}
+
+// APIKeyService returns the API key Service for you
+func (bldr *ServiceBuilder) APIKeyService() apikeyiface.Servicer {
+
+	var apiKeySvc apikeyiface.Servicer
+	if err := bldr.Config.GetService(&apiKeySvc); err != nil {
+		panic(err)
+	}
+
+	return apiKeySvc
+}
+
+// WithSettingsService tells the builder to add the system Settings service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithSettingsService() *ServiceBuilder {
+	bldr.WithDynamoDB()
+	bldr.handlers = append(bldr.handlers, bldr.createSettingsService)
+	return bldr
+}
+
+// SettingsService returns the system Settings service for you
+func (bldr *ServiceBuilder) SettingsService() settingsiface.Servicer {
+
+	var settingsSvc settingsiface.Servicer
+	if err := bldr.Config.GetService(&settingsSvc); err != nil {
+		panic(err)
+	}
+
+	return settingsSvc
+}
+
+// WithDirectoryService tells the builder to add the Directory service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithDirectoryService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createDirectoryService)
+	return bldr
+}
+
+// DirectoryService returns the directory Service for you
+func (bldr *ServiceBuilder) DirectoryService() directoryiface.Servicer {
+
+	var directorySvc directoryiface.Servicer
+	if err := bldr.Config.GetService(&directorySvc); err != nil {
+		panic(err)
+	}
+
+	return directorySvc
+}
+
+// WithAccountFactoryService tells the builder to add the Account Factory service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithAccountFactoryService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createAccountFactoryService)
+	return bldr
+}
+
+// AccountFactoryService returns the account factory Service for you
+func (bldr *ServiceBuilder) AccountFactoryService() accountfactoryiface.Servicer {
+
+	var accountFactorySvc accountfactoryiface.Servicer
+	if err := bldr.Config.GetService(&accountFactorySvc); err != nil {
+		panic(err)
+	}
+
+	return accountFactorySvc
+}
+
+// WithCMDBService tells the builder to add the CMDB service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithCMDBService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createCMDBService)
+	return bldr
+}
+
+// CMDBService returns the CMDB Service for you
+func (bldr *ServiceBuilder) CMDBService() cmdbiface.Servicer {
+
+	var cmdbSvc cmdbiface.Servicer
+	if err := bldr.Config.GetService(&cmdbSvc); err != nil {
+		panic(err)
+	}
+
+	return cmdbSvc
+}
+
+// WithIdentityService tells the builder to add the Identity service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithIdentityService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createIdentityService)
+	return bldr
+}
+
+// IdentityService returns the identity Service for you
+func (bldr *ServiceBuilder) IdentityService() identityiface.Servicer {
+
+	var identitySvc identityiface.Servicer
+	if err := bldr.Config.GetService(&identitySvc); err != nil {
+		panic(err)
+	}
+
+	return identitySvc
+}
+
+// WithMonitoringService tells the builder to add the Monitoring service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithMonitoringService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createMonitoringService)
+	return bldr
+}
+
+// MonitoringService returns the Monitoring Service for you
+func (bldr *ServiceBuilder) MonitoringService() monitoringiface.Servicer {
+
+	var monitoringSvc monitoringiface.Servicer
+	if err := bldr.Config.GetService(&monitoringSvc); err != nil {
+		panic(err)
+	}
+
+	return monitoringSvc
+}
+
+// WithMetricsService tells the builder to add the Metrics service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithMetricsService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createMetricsService)
+	return bldr
+}
+
+// MetricsService returns the metrics Service for you
+func (bldr *ServiceBuilder) MetricsService() metricsiface.Servicer {
+
+	var metricsSvc metricsiface.Servicer
+	if err := bldr.Config.GetService(&metricsSvc); err != nil {
+		panic(err)
+	}
+
+	return metricsSvc
+}
+
+// WithTicketService tells the builder to add the Ticket service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithTicketService() *ServiceBuilder {
+	bldr.handlers = append(bldr.handlers, bldr.createTicketService)
+	return bldr
+}
+
+// TicketService returns the ticket Service for you
+func (bldr *ServiceBuilder) TicketService() ticketiface.Servicer {
+
+	var ticketSvc ticketiface.Servicer
+	if err := bldr.Config.GetService(&ticketSvc); err != nil {
+		panic(err)
+	}
+
+	return ticketSvc
+}
+
+// WithNotificationService tells the builder to add the Notification service to the `ConfigurationBuilder`
+func (bldr *ServiceBuilder) WithNotificationService() *ServiceBuilder {
+	bldr.WithStorageService().WithSES()
+	bldr.handlers = append(bldr.handlers, bldr.createNotificationService)
+	return bldr
+}
+
+// NotificationService returns the notification Service for you
+func (bldr *ServiceBuilder) NotificationService() notificationiface.Servicer {
+
+	var notificationSvc notificationiface.Servicer
+	if err := bldr.Config.GetService(&notificationSvc); err != nil {
+		panic(err)
+	}
+
+	return notificationSvc
+}
+
 func (bldr *ServiceBuilder) WithUserDetailer() *ServiceBuilder {
 	bldr.WithCognito()
 	bldr.handlers = append(bldr.handlers, bldr.createUserDetailerService)
@@ -254,13 +623,31 @@ func (bldr *ServiceBuilder) Build() (*ConfigurationBuilder, error) {
 		return bldr.Config, AWSConfigurationError(err)
 	}
 
+	// Every service is created, so all the errors in the configuration are
+	// returned at once
+	var errs ConfigurationErrors
 	for _, f := range bldr.handlers {
 		err := f(bldr.Config)
-		if err != nil {
-			log.Printf("Error while trying to execute handler: %s", runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
-			return bldr.Config, AWSConfigurationError(err)
+		if err == nil {
+			continue
+		}
+		// Services which need a service which couldn't be created are
+		// missing it, which isn't worth returning too
+		var missing *missingServiceError
+		if len(errs) > 0 && errors.As(err, &missing) {
+			continue
+		}
+		log.Printf("Error while trying to execute handler: %s", runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
+		if handlerErrs, ok := err.(ConfigurationErrors); ok {
+			errs = append(errs, handlerErrs...)
+		} else {
+			errs = append(errs, err)
 		}
 	}
+	if len(errs) > 0 {
+		log.Printf("Invalid configuration:\n - %s", strings.Join(errorMessages(errs), "\n - "))
+		return bldr.Config, AWSConfigurationError(errs)
+	}
 
 	// Setting config values from parameter store requires services to be configured first
 	if err := bldr.Config.RetrieveParameterStoreVals(); err != nil {
@@ -271,19 +658,17 @@ func (bldr *ServiceBuilder) Build() (*ConfigurationBuilder, error) {
 	return bldr.Config, nil
 }
 
+// createSession uses the session shared by the process, so the AWS clients
+// of every builder are created from the same session
 func (bldr *ServiceBuilder) createSession(config ConfigurationServiceBuilder) error {
 	var err error
 	region, err := bldr.Config.GetStringVal("AWS_CURRENT_REGION")
 	if err == nil {
 		log.Printf("Using AWS region \"%s\" to create session...", region)
-		bldr.awsSession, err = session.NewSession(
-			&aws.Config{
-				Region: aws.String(region),
-			},
-		)
+		bldr.awsSession, err = common.SharedSession(region)
 	} else {
 		log.Println("Creating AWS session using defaults...")
-		bldr.awsSession, err = session.NewSession()
+		bldr.awsSession, err = common.SharedSession("")
 	}
 	return err
 }
@@ -310,7 +695,7 @@ func (bldr *ServiceBuilder) createSNS(config ConfigurationServiceBuilder) error
 		log.Printf("Already added SNS service")
 		return nil
 	}
-	snsSvc := sns.New(bldr.awsSession)
+	snsSvc := sns.New(bldr.awsSession, common.EndpointConfig("SNS"))
 	config.WithService(snsSvc)
 	return nil
 }
@@ -323,7 +708,7 @@ func (bldr *ServiceBuilder) createSQS(config ConfigurationServiceBuilder) error
 		log.Printf("Already added SQS service")
 		return nil
 	}
-	sqsSvc := sqs.New(bldr.awsSession)
+	sqsSvc := sqs.New(bldr.awsSession, common.EndpointConfig("SQS"))
 	config.WithService(sqsSvc)
 	return nil
 }
@@ -336,7 +721,7 @@ func (bldr *ServiceBuilder) createDynamoDB(config ConfigurationServiceBuilder) e
 		log.Printf("Already added DynamoDB service")
 		return nil
 	}
-	dynamodbSvc := dynamodb.New(bldr.awsSession)
+	dynamodbSvc := dynamodb.New(bldr.awsSession, common.EndpointConfig("DynamoDB"))
 	config.WithService(dynamodbSvc)
 	return nil
 }
@@ -349,7 +734,7 @@ func (bldr *ServiceBuilder) createS3(config ConfigurationServiceBuilder) error {
 		log.Printf("Already added S3 service")
 		return nil
 	}
-	s3Svc := s3.New(bldr.awsSession)
+	s3Svc := s3.New(bldr.awsSession, common.EndpointConfig("S3"))
 	config.WithService(s3Svc)
 	return nil
 }
@@ -382,6 +767,48 @@ func (bldr *ServiceBuilder) createCloudWatchEvents(config ConfigurationServiceBu
 	return nil
 }
 
+func (bldr *ServiceBuilder) createEventBridge(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api eventbridgeiface.EventBridgeAPI
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added EventBridge service")
+		return nil
+	}
+
+	svc := eventbridge.New(bldr.awsSession)
+	config.WithService(svc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createKinesis(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api kinesisiface.KinesisAPI
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Kinesis service")
+		return nil
+	}
+
+	svc := kinesis.New(bldr.awsSession)
+	config.WithService(svc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createStepFunctions(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api sfniface.SFNAPI
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Step Functions service")
+		return nil
+	}
+
+	svc := sfn.New(bldr.awsSession)
+	config.WithService(svc)
+	return nil
+}
+
 func (bldr *ServiceBuilder) createCognito(config ConfigurationServiceBuilder) error {
 	// Don't add the service twice
 	var api cognitoidentityprovideriface.CognitoIdentityProviderAPI
@@ -424,6 +851,20 @@ func (bldr *ServiceBuilder) createSSM(config ConfigurationServiceBuilder) error
 	return nil
 }
 
+func (bldr *ServiceBuilder) createCostExplorer(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var costExplorerAPI costexploreriface.CostExplorerAPI
+	err := bldr.Config.GetService(&costExplorerAPI)
+	if err == nil {
+		log.Printf("Already added Cost Explorer service")
+		return nil
+	}
+
+	costExplorerSvc := costexplorer.New(bldr.awsSession)
+	config.WithService(costExplorerSvc)
+	return nil
+}
+
 func (bldr *ServiceBuilder) createLambda(config ConfigurationServiceBuilder) error {
 	// Don't add the service twice
 	var lambdaAPI lambdaiface.LambdaAPI
@@ -438,6 +879,20 @@ func (bldr *ServiceBuilder) createLambda(config ConfigurationServiceBuilder) err
 	return nil
 }
 
+func (bldr *ServiceBuilder) createSES(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var sesAPI sesiface.SESAPI
+	err := bldr.Config.GetService(&sesAPI)
+	if err == nil {
+		log.Printf("Already added SES service")
+		return nil
+	}
+
+	sesSvc := ses.New(bldr.awsSession, common.EndpointConfig("SES"))
+	config.WithService(sesSvc)
+	return nil
+}
+
 func (bldr *ServiceBuilder) createStorageService(config ConfigurationServiceBuilder) error {
 	// Don't add the service twice
 	var api common.Storager
@@ -448,8 +903,8 @@ func (bldr *ServiceBuilder) createStorageService(config ConfigurationServiceBuil
 	}
 
 	storageService := &common.S3{
-		Client:  s3.New(bldr.awsSession),
-		Manager: s3manager.NewDownloader(bldr.awsSession),
+		Client:  s3.New(bldr.awsSession, common.EndpointConfig("S3")),
+		Manager: s3manager.NewDownloaderWithClient(s3.New(bldr.awsSession, common.EndpointConfig("S3"))),
 	}
 
 	config.WithService(storageService)
@@ -465,24 +920,56 @@ func (bldr *ServiceBuilder) createUserDetailerService(config ConfigurationServic
 		return nil
 	}
 
-	var cognitoSvc cognitoidentityprovider.CognitoIdentityProvider
-	err = bldr.Config.GetService(&cognitoSvc)
+	authConfig := &api.AuthConfig{}
+	err = bldr.Config.Unmarshal(authConfig)
 	if err != nil {
 		return err
 	}
 
-	userDetailerImpl := &api.UserDetails{}
-	err = bldr.Config.Unmarshal(userDetailerImpl)
+	var cognitoUserDetails *api.UserDetails
+	if authConfig.Provider == api.AuthProviderCognito || authConfig.Provider == "" {
+		var cognitoSvc cognitoidentityprovider.CognitoIdentityProvider
+		err = bldr.Config.GetService(&cognitoSvc)
+		if err != nil {
+			return err
+		}
+
+		cognitoUserDetails = &api.UserDetails{}
+		err = bldr.Config.Unmarshal(cognitoUserDetails)
+		if err != nil {
+			return err
+		}
+		cognitoUserDetails.CognitoClient = &cognitoSvc
+	}
+
+	userDetailerImpl, err := authConfig.NewUserDetailer(cognitoUserDetails)
 	if err != nil {
 		return err
 	}
 
-	userDetailerImpl.CognitoClient = &cognitoSvc
+	// Usernames are normalized like the principal IDs of leases, so users
+	// match their leases
+	principalIDFormat, err := bldr.principalIDFormat()
+	if err != nil {
+		return err
+	}
 
-	config.WithService(userDetailerImpl)
+	config.WithService(&api.PrincipalUserDetails{
+		UserDetailer: userDetailerImpl,
+		Format:       principalIDFormat,
+	})
 	return nil
 }
 
+// principalIDFormat creates the configured format of principal IDs
+func (bldr *ServiceBuilder) principalIDFormat() (*principal.IDFormat, error) {
+	input := principal.NewIDFormatInput{}
+	if err := bldr.Config.Unmarshal(&input); err != nil {
+		return nil, err
+	}
+	return principal.NewIDFormat(input)
+}
+
 func (bldr *ServiceBuilder) createEventService(config ConfigurationServiceBuilder) error {
 	// Don't add the service twice
 	var api eventiface.Servicer
@@ -510,6 +997,36 @@ func (bldr *ServiceBuilder) createEventService(config ConfigurationServiceBuilde
 		return err
 	}
 
+	var ebService eventbridgeiface.EventBridgeAPI
+	err = bldr.Config.GetService(&ebService)
+	if err != nil {
+		return err
+	}
+
+	var sfnService sfniface.SFNAPI
+	err = bldr.Config.GetService(&sfnService)
+	if err != nil {
+		return err
+	}
+
+	var s3Service s3iface.S3API
+	err = bldr.Config.GetService(&s3Service)
+	if err != nil {
+		return err
+	}
+
+	var kinesisService kinesisiface.KinesisAPI
+	err = bldr.Config.GetService(&kinesisService)
+	if err != nil {
+		return err
+	}
+
+	var dynamodbService dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbService)
+	if err != nil {
+		return err
+	}
+
 	eventSvcInput := event.NewServiceInput{}
 	err = bldr.Config.Unmarshal(&eventSvcInput)
 	if err != nil {
@@ -519,6 +1036,11 @@ func (bldr *ServiceBuilder) createEventService(config ConfigurationServiceBuilde
 	eventSvcInput.SqsClient = sqsService
 	eventSvcInput.SnsClient = snsService
 	eventSvcInput.CweClient = cweService
+	eventSvcInput.EbClient = ebService
+	eventSvcInput.SfnClient = sfnService
+	eventSvcInput.S3Client = s3Service
+	eventSvcInput.KinesisClient = kinesisService
+	eventSvcInput.DynamoDBClient = dynamodbService
 	eventSvc, err := event.NewService(eventSvcInput)
 	if err != nil {
 		return err
@@ -528,9 +1050,456 @@ func (bldr *ServiceBuilder) createEventService(config ConfigurationServiceBuilde
 	return nil
 }
 
-func (bldr *ServiceBuilder) createAccountDataService(config ConfigurationServiceBuilder) error {
+func (bldr *ServiceBuilder) createActivityService(config ConfigurationServiceBuilder) error {
 	// Don't add the service twice
-	var api dataiface.AccountData
+	var api activityiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Activity service")
+		return nil
+	}
+
+	var dynamodbService dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbService)
+	if err != nil {
+		return err
+	}
+
+	activitySvcInput := activity.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&activitySvcInput)
+	if err != nil {
+		return err
+	}
+
+	activitySvcInput.DynamoDB = dynamodbService
+	activitySvc, err := activity.NewService(activitySvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(activitySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createAlertService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api alertiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Alert service")
+		return nil
+	}
+
+	alertSvcInput := alert.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&alertSvcInput)
+	if err != nil {
+		return err
+	}
+
+	alertSvc, err := alert.NewService(alertSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(alertSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createPolicyService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api policyiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Policy service")
+		return nil
+	}
+
+	policySvcInput := policy.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&policySvcInput)
+	if err != nil {
+		return err
+	}
+
+	policySvc := policy.NewService(policySvcInput)
+
+	config.WithService(policySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createOutboxService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api outboxiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Outbox service")
+		return nil
+	}
+
+	var dynamodbSvc dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbSvc)
+	if err != nil {
+		return err
+	}
+
+	outboxSvcInput := outbox.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&outboxSvcInput)
+	if err != nil {
+		return err
+	}
+	outboxSvcInput.DynamoDB = dynamodbSvc
+
+	outboxSvc := outbox.NewService(outboxSvcInput)
+
+	config.WithService(outboxSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createWaitlistService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api waitlistiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Waitlist service")
+		return nil
+	}
+
+	var dynamodbSvc dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbSvc)
+	if err != nil {
+		return err
+	}
+
+	waitlistSvcInput := waitlist.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&waitlistSvcInput)
+	if err != nil {
+		return err
+	}
+	waitlistSvcInput.DynamoDB = dynamodbSvc
+
+	waitlistSvc := waitlist.NewService(waitlistSvcInput)
+
+	config.WithService(waitlistSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createRoutingService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api routingiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Routing service")
+		return nil
+	}
+
+	var dynamodbSvc dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbSvc)
+	if err != nil {
+		return err
+	}
+
+	var ssmSvc ssmiface.SSMAPI
+	err = bldr.Config.GetService(&ssmSvc)
+	if err != nil {
+		return err
+	}
+
+	var sesSvc sesiface.SESAPI
+	err = bldr.Config.GetService(&sesSvc)
+	if err != nil {
+		return err
+	}
+
+	var alertSvc alertiface.Servicer
+	err = bldr.Config.GetService(&alertSvc)
+	if err != nil {
+		return err
+	}
+
+	routingSvcInput := routing.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&routingSvcInput)
+	if err != nil {
+		return err
+	}
+	routingSvcInput.DynamoDB = dynamodbSvc
+	routingSvcInput.SSM = ssmSvc
+	routingSvcInput.EmailSvc = &email.SESEmailService{SES: sesSvc}
+	routingSvcInput.AlertSvc = alertSvc
+
+	routingSvc := routing.NewService(routingSvcInput)
+
+	config.WithService(routingSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createAPIKeyService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api apikeyiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added API key service")
+		return nil
+	}
+
+	var dynamodbSvc dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbSvc)
+	if err != nil {
+		return err
+	}
+
+	apiKeySvcInput := apikey.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&apiKeySvcInput)
+	if err != nil {
+		return err
+	}
+	apiKeySvcInput.DynamoDB = dynamodbSvc
+
+	apiKeySvc := apikey.NewService(apiKeySvcInput)
+
+	config.WithService(apiKeySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createSettingsService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api settingsiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Settings service")
+		return nil
+	}
+
+	var dynamodbSvc dynamodbiface.DynamoDBAPI
+	err = bldr.Config.GetService(&dynamodbSvc)
+	if err != nil {
+		return err
+	}
+
+	settingsSvcInput := settings.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&settingsSvcInput)
+	if err != nil {
+		return err
+	}
+	settingsSvcInput.DynamoDB = dynamodbSvc
+
+	settingsSvc := settings.NewService(settingsSvcInput)
+
+	config.WithService(settingsSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createDirectoryService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api directoryiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Directory service")
+		return nil
+	}
+
+	directorySvcInput := directory.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&directorySvcInput)
+	if err != nil {
+		return err
+	}
+
+	directorySvc, err := directory.NewService(directorySvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(directorySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createAccountFactoryService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api accountfactoryiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Account Factory service")
+		return nil
+	}
+
+	accountFactorySvcInput := accountfactory.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&accountFactorySvcInput)
+	if err != nil {
+		return err
+	}
+
+	accountFactorySvcInput.Organizations = organizations.New(bldr.awsSession)
+	accountFactorySvcInput.ServiceCatalog = servicecatalog.New(bldr.awsSession)
+
+	accountFactorySvc, err := accountfactory.NewService(accountFactorySvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(accountFactorySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createCMDBService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api cmdbiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added CMDB service")
+		return nil
+	}
+
+	cmdbSvcInput := cmdb.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&cmdbSvcInput)
+	if err != nil {
+		return err
+	}
+
+	cmdbSvc, err := cmdb.NewService(cmdbSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(cmdbSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createIdentityService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api identityiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Identity service")
+		return nil
+	}
+
+	identitySvcInput := identity.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&identitySvcInput)
+	if err != nil {
+		return err
+	}
+
+	identitySvcInput.Cognito = cognitoidentityprovider.New(bldr.awsSession)
+	identitySvcInput.IAM = iam.New(bldr.awsSession)
+	identitySvc := identity.NewService(identitySvcInput)
+
+	config.WithService(identitySvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createMonitoringService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api monitoringiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Monitoring service")
+		return nil
+	}
+
+	monitoringSvcInput := monitoring.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&monitoringSvcInput)
+	if err != nil {
+		return err
+	}
+
+	monitoringSvcInput.CloudWatch = cloudwatch.New(bldr.awsSession)
+	monitoringSvcInput.CloudWatchLogs = cloudwatchlogs.New(bldr.awsSession)
+	monitoringSvc, err := monitoring.NewService(monitoringSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(monitoringSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createMetricsService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api metricsiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Metrics service")
+		return nil
+	}
+
+	metricsSvcInput := metrics.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&metricsSvcInput)
+	if err != nil {
+		return err
+	}
+
+	metricsSvc, err := metrics.NewService(metricsSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(metricsSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createTicketService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api ticketiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Ticket service")
+		return nil
+	}
+
+	ticketSvcInput := ticket.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&ticketSvcInput)
+	if err != nil {
+		return err
+	}
+
+	ticketSvc, err := ticket.NewService(ticketSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(ticketSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createNotificationService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api notificationiface.Servicer
+	err := bldr.Config.GetService(&api)
+	if err == nil {
+		log.Printf("Already added Notification service")
+		return nil
+	}
+
+	var storageSvc common.Storager
+	err = bldr.Config.GetService(&storageSvc)
+	if err != nil {
+		return err
+	}
+
+	var sesSvc sesiface.SESAPI
+	err = bldr.Config.GetService(&sesSvc)
+	if err != nil {
+		return err
+	}
+
+	notificationSvcInput := notification.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&notificationSvcInput)
+	if err != nil {
+		return err
+	}
+
+	notificationSvcInput.Storage = storageSvc
+	notificationSvcInput.EmailSvc = &email.SESEmailService{SES: sesSvc}
+	notificationSvc, err := notification.NewService(notificationSvcInput)
+	if err != nil {
+		return err
+	}
+
+	config.WithService(notificationSvc)
+	return nil
+}
+
+func (bldr *ServiceBuilder) createAccountDataService(config ConfigurationServiceBuilder) error {
+	// Don't add the service twice
+	var api dataiface.AccountData
 	err := bldr.Config.GetService(&api)
 	if err == nil {
 		log.Printf("Already added Account Data service")
@@ -625,6 +1594,12 @@ func (bldr *ServiceBuilder) createAccountService(config ConfigurationServiceBuil
 		return err
 	}
 
+	var policySvc policyiface.Servicer
+	err = bldr.Config.GetService(&policySvc)
+	if err != nil {
+		return err
+	}
+
 	accountSvcInput := account.NewServiceInput{}
 	err = bldr.Config.Unmarshal(&accountSvcInput)
 	if err != nil {
@@ -634,6 +1609,18 @@ func (bldr *ServiceBuilder) createAccountService(config ConfigurationServiceBuil
 	accountSvcInput.DataSvc = dataSvc
 	accountSvcInput.ManagerSvc = managerSvc
 	accountSvcInput.EventSvc = eventSvc
+	accountSvcInput.PolicySvc = policySvc
+	// The admin role name is only used to create accounts
+	accountSvcInput.OrganizationsSvc = accountfactory.NewOrganizations(organizations.New(bldr.awsSession), "")
+
+	outboxInput := outbox.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&outboxInput)
+	if err != nil {
+		return err
+	}
+	if outboxInput.TableName != "" {
+		accountSvcInput.OutboxSvc = dataSvc
+	}
 
 	accountSvc := account.NewService(accountSvcInput)
 
@@ -697,14 +1684,53 @@ func (bldr *ServiceBuilder) createLeaseService(config ConfigurationServiceBuilde
 		return err
 	}
 
+	var ssmSvc ssmiface.SSMAPI
+	err = bldr.Config.GetService(&ssmSvc)
+	if err != nil {
+		return err
+	}
+
+	var policySvc policyiface.Servicer
+	err = bldr.Config.GetService(&policySvc)
+	if err != nil {
+		return err
+	}
+
+	var settingsSvc settingsiface.Servicer
+	err = bldr.Config.GetService(&settingsSvc)
+	if err != nil {
+		return err
+	}
+
 	leaseSvcInput := lease.NewServiceInput{}
 	if err := bldr.Config.Unmarshal(&leaseSvcInput); err != nil {
 		log.Printf("Could not load configuration: %s", err.Error())
 		return err
 	}
 	leaseSvcInput.DataSvc = dataSvc
+	leaseSvcInput.BatchSvc = dataSvc
 	leaseSvcInput.EventSvc = eventSvc
 	leaseSvcInput.AccountSvc = accountSvc
+	leaseSvcInput.SSM = ssmSvc
+	leaseSvcInput.PolicySvc = policySvc
+	leaseSvcInput.SettingsSvc = settingsSvc
+	// The data service writes new leases without a quota when there's no
+	// principal leases table
+	leaseSvcInput.CreatorSvc = dataSvc
+	leaseSvcInput.PrincipalIDFormat, err = bldr.principalIDFormat()
+	if err != nil {
+		return err
+	}
+
+	outboxInput := outbox.NewServiceInput{}
+	err = bldr.Config.Unmarshal(&outboxInput)
+	if err != nil {
+		return err
+	}
+	if outboxInput.TableName != "" {
+		leaseSvcInput.OutboxSvc = dataSvc
+	}
+
 	leaseSvc := lease.NewService(
 		leaseSvcInput,
 	)
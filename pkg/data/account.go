@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -12,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	validation "github.com/go-ozzo/ozzo-validation"
 )
 
 // Account - Data Layer Struct
@@ -20,6 +23,21 @@ type Account struct {
 	TableName      string `env:"ACCOUNT_DB"`
 	ConsistentRead bool   `env:"USE_CONSISTENT_READS" envDefault:"false"`
 	Limit          int64  `env:"LIMIT" envDefault:"25"`
+	// StatusShards is the number of shards of the sharded status index
+	StatusShards int `env:"STATUS_SHARD_COUNT" envDefault:"1"`
+	// CompressionThreshold is the size in bytes above which Metadata is compressed
+	CompressionThreshold int `env:"METADATA_COMPRESSION_THRESHOLD" envDefault:"65536"`
+	// OutboxTableName is the table the events of account changes are written to
+	OutboxTableName string `env:"OUTBOX_TABLE" envDefault:""`
+}
+
+// Validate the configuration of the account data layer
+func (a *Account) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Limit, validation.Required.Error("must be no less than 1"), validation.Min(int64(1))),
+		validation.Field(&a.StatusShards, validation.Required.Error("must be no less than 1"), validation.Min(1)),
+		validation.Field(&a.CompressionThreshold, validation.Min(0)),
+	)
 }
 
 // Write the Account record in DynamoDB
@@ -27,26 +45,37 @@ type Account struct {
 // be inserted or updated
 // prevLastModifiedOn parameter is the original lastModifiedOn
 func (a *Account) Write(account *account.Account, prevLastModifiedOn *int64) error {
+	return a.WriteWithEvents(account, prevLastModifiedOn, nil)
+}
+
+// WriteWithEvents writes the Account record and the outbox entries of its events
+// in one transaction
+func (a *Account) WriteWithEvents(account *account.Account, prevLastModifiedOn *int64, entries []*outbox.Entry) error {
 
-	var expr expression.Expression
-	var err error
 	returnValue := "NONE"
-	// lastModifiedOn is nil on a create
-	if prevLastModifiedOn != nil {
-		modExpr := expression.Name("LastModifiedOn").Equal(expression.Value(prevLastModifiedOn))
-		expr, err = expression.NewBuilder().WithCondition(modExpr).Build()
-		if err != nil {
-			return errors.NewInternalServer("error building query", err)
-		}
-	} else {
-		modExpr := expression.Name("LastModifiedOn").AttributeNotExists()
-		expr, err = expression.NewBuilder().WithCondition(modExpr).Build()
-		if err != nil {
-			return errors.NewInternalServer("error building query", err)
-		}
+	// lastModifiedOn is nil on a create.  The account is written over the
+	// version it was read at, and its version goes up by one.
+	expr, err := expression.NewBuilder().WithCondition(
+		common.AccountItemSchema.VersionCondition(account.Version, prevLastModifiedOn),
+	).Build()
+	if err != nil {
+		return errors.NewInternalServer("error building query", err)
 	}
+	// The account is only given its new version once the write succeeds
+	written := *account
+	written.Version = common.NextVersion(account.Version)
 
-	putMap, _ := dynamodbattribute.Marshal(account)
+	putMap, _ := dynamodbattribute.Marshal(&written)
+	common.AccountItemSchema.Stamp(putMap.M)
+	err = common.CompressAttribute(putMap.M, "Metadata", a.CompressionThreshold)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failure compressing account %q", *account.ID), err)
+	}
+	if a.StatusShards > 1 && account.Status != nil {
+		putMap.M["AccountStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(common.StatusShard(account.Status.String(), *account.ID, a.StatusShards)),
+		}
+	}
 	input := &dynamodb.PutItemInput{
 		// Query in Lease Table
 		TableName: aws.String(a.TableName),
@@ -59,7 +88,7 @@ func (a *Account) Write(account *account.Account, prevLastModifiedOn *int64) err
 		// Return the updated record
 		ReturnValues: aws.String(returnValue),
 	}
-	err = putItem(input, a.DynamoDB)
+	err = putItemWithEvents(input, entries, a.OutboxTableName, a.DynamoDB)
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
 		if awsErr.Code() == "ConditionalCheckFailedException" {
@@ -76,6 +105,7 @@ func (a *Account) Write(account *account.Account, prevLastModifiedOn *int64) err
 		)
 	}
 
+	account.Version = written.Version
 	return nil
 }
 
@@ -133,7 +163,10 @@ func (a *Account) Get(ID string) (*account.Account, error) {
 	}
 
 	account := &account.Account{}
-	err = dynamodbattribute.UnmarshalMap(res.Item, account)
+	err = decodeItem(res.Item, common.AccountItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalMap(res.Item, account)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer(
 			fmt.Sprintf("failure unmarshaling account %q", ID),
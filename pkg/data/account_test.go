@@ -4,6 +4,7 @@ import (
 	gErrors "errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/Optum/dce/pkg/account"
@@ -261,3 +262,144 @@ func TestUpdate(t *testing.T) {
 	}
 
 }
+
+func TestAccountMetadataCompression(t *testing.T) {
+	metadata := map[string]interface{}{
+		"sso": strings.Repeat("group,", 100),
+	}
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	var stored map[string]*dynamodb.AttributeValue
+	mockDynamo.On("PutItem", mock.Anything).Return(func(input *dynamodb.PutItemInput) *dynamodb.PutItemOutput {
+		stored = input.Item
+		return &dynamodb.PutItemOutput{}
+	}, nil)
+	mockDynamo.On("GetItem", mock.Anything).Return(func(input *dynamodb.GetItemInput) *dynamodb.GetItemOutput {
+		return &dynamodb.GetItemOutput{Item: stored}
+	}, nil)
+	accountData := &Account{
+		DynamoDB:             mockDynamo,
+		TableName:            "Accounts",
+		CompressionThreshold: 100,
+	}
+
+	err := accountData.Write(&account.Account{
+		ID:             ptrString("123456789012"),
+		Status:         account.StatusReady.StatusPtr(),
+		LastModifiedOn: ptrInt64(1573592058),
+		Metadata:       metadata,
+	}, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, stored["Metadata"].B)
+	assert.True(t, *stored["MetadataCompressed"].BOOL)
+
+	acct, err := accountData.Get("123456789012")
+	assert.Nil(t, err)
+	assert.Equal(t, metadata, acct.Metadata)
+}
+
+func TestAccountSchemaVersion(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	var input *dynamodb.PutItemInput
+	mockDynamo.On("PutItem", mock.Anything).Return(func(i *dynamodb.PutItemInput) *dynamodb.PutItemOutput {
+		input = i
+		return &dynamodb.PutItemOutput{}
+	}, nil)
+	accountData := &Account{
+		DynamoDB:  mockDynamo,
+		TableName: "Accounts",
+	}
+
+	err := accountData.Write(&account.Account{
+		ID:             ptrString("123456789012"),
+		Status:         account.StatusReady.StatusPtr(),
+		LastModifiedOn: ptrInt64(1573592058),
+	}, ptrInt64(1573592000))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2", *input.Item["SchemaVersion"].N)
+	// Items written by newer versions of DCE aren't overwritten
+	assert.Contains(t, *input.ConditionExpression, "attribute_not_exists")
+	names := []string{}
+	for _, name := range input.ExpressionAttributeNames {
+		names = append(names, *name)
+	}
+	assert.Contains(t, names, "SchemaVersion")
+}
+
+func TestAccountWriteVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    *int64
+		expVersion string
+		expNames   []string
+	}{
+		{
+			name:       "should write over the version the account was read at",
+			version:    ptrInt64(3),
+			expVersion: "4",
+			expNames:   []string{"Version", "SchemaVersion"},
+		},
+		{
+			name:       "should write over the LastModifiedOn of an account read without a version",
+			expVersion: "1",
+			expNames:   []string{"Version", "LastModifiedOn", "SchemaVersion"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDynamo := &awsmocks.DynamoDBAPI{}
+			var input *dynamodb.PutItemInput
+			mockDynamo.On("PutItem", mock.Anything).Return(func(i *dynamodb.PutItemInput) *dynamodb.PutItemOutput {
+				input = i
+				return &dynamodb.PutItemOutput{}
+			}, nil)
+			accountData := &Account{
+				DynamoDB:  mockDynamo,
+				TableName: "Accounts",
+			}
+			acct := &account.Account{
+				ID:             ptrString("123456789012"),
+				Status:         account.StatusReady.StatusPtr(),
+				LastModifiedOn: ptrInt64(1573592058),
+				Version:        tt.version,
+			}
+
+			err := accountData.Write(acct, ptrInt64(1573592000))
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expVersion, *input.Item["Version"].N)
+			assert.Equal(t, tt.expVersion, strconv.FormatInt(*acct.Version, 10))
+			names := []string{}
+			for _, name := range input.ExpressionAttributeNames {
+				names = append(names, *name)
+			}
+			assert.ElementsMatch(t, tt.expNames, names)
+			if tt.version != nil {
+				assert.Contains(t, *input.ConditionExpression, "= :0")
+				assert.Equal(t, "3", *input.ExpressionAttributeValues[":0"].N)
+			}
+		})
+	}
+}
+
+func TestAccountWriteConflictKeepsVersion(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.Anything).
+		Return(nil, awserr.New("ConditionalCheckFailedException", "Message", fmt.Errorf("Bad")))
+	accountData := &Account{
+		DynamoDB:  mockDynamo,
+		TableName: "Accounts",
+	}
+	acct := &account.Account{
+		ID:             ptrString("123456789012"),
+		Status:         account.StatusReady.StatusPtr(),
+		LastModifiedOn: ptrInt64(1573592058),
+		Version:        ptrInt64(3),
+	}
+
+	err := accountData.Write(acct, ptrInt64(1573592000))
+
+	assert.NotNil(t, err)
+	// The account is still at the version it was read at
+	assert.Equal(t, int64(3), *acct.Version)
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
@@ -120,7 +121,24 @@ func (a *Account) List(query *account.Account) (*account.Accounts, error) {
 		query.Limit = &a.Limit
 	}
 
-	if query.Status != nil {
+	if query.Status != nil && a.StatusShards > 1 {
+		start := 0
+		if query.NextID != nil {
+			start = common.ShardIndex(*query.NextID, a.StatusShards)
+		}
+		shardQuery := *query
+		shardQuery.Status = nil
+		_, filters := getFiltersFromStruct(&shardQuery, nil)
+		outputs, err = queryShards(a.DynamoDB, &queryShardsInput{
+			tableName: a.TableName,
+			indexName: "AccountStatusShard",
+			shardKeys: common.StatusShardKeys(query.Status.String(), a.StatusShards)[start:],
+			startKey:  nextAccountKey(query),
+			keyNames:  []string{"Id"},
+			filters:   filters,
+			limit:     *query.Limit,
+		})
+	} else if query.Status != nil {
 		outputs, err = a.queryAccounts(query, "AccountStatus", "AccountStatus")
 	} else {
 		outputs, err = a.scanAccounts(query)
@@ -135,10 +153,23 @@ func (a *Account) List(query *account.Account) (*account.Accounts, error) {
 	}
 
 	accounts := &account.Accounts{}
-	err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, accounts)
+	err = decodeItems(outputs.items, common.AccountItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, accounts)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer("failed unmarshaling of accounts", err)
 	}
 
 	return accounts, nil
 }
+
+// nextAccountKey gets the key to start a page of accounts after
+func nextAccountKey(query *account.Account) map[string]*dynamodb.AttributeValue {
+	if query.NextID == nil {
+		return nil
+	}
+	return map[string]*dynamodb.AttributeValue{
+		"Id": {S: query.NextID},
+	}
+}
@@ -4,6 +4,7 @@ package dataiface
 
 import (
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/outbox"
 )
 
 // AccountData makes working with the Account Data Layer easier
@@ -13,6 +14,9 @@ type AccountData interface {
 	// be inserted or updated
 	// prevLastModifiedOn parameter is the original lastModifiedOn
 	Write(account *account.Account, prevLastModifiedOn *int64) error
+	// WriteWithEvents writes the Account record and the outbox entries of
+	// its events in one transaction
+	WriteWithEvents(account *account.Account, prevLastModifiedOn *int64, entries []*outbox.Entry) error
 	// Delete the Account record in DynamoDB
 	Delete(account *account.Account) error
 	// Get the Account record by ID
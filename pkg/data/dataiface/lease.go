@@ -4,6 +4,7 @@ package dataiface
 
 import (
 	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
 )
 
 // LeaseData makes working with the Lease Data Layer easier
@@ -22,4 +23,15 @@ type LeaseData interface {
 	// be inserted or updated
 	// prevLastModifiedOn parameter is the original lastModifiedOn
 	Write(lease *lease.Lease, prevLastModifiedOn *int64) error
+
+	// WriteWithEvents writes the Lease record and the outbox entries of its
+	// events in one transaction
+	WriteWithEvents(lease *lease.Lease, prevLastModifiedOn *int64, entries []*outbox.Entry) error
+
+	// Create writes a new Lease record, and sets its account Leased, in one
+	// transaction, while its principal has fewer than maxActive Active leases
+	Create(lease *lease.Lease, prevLastModifiedOn *int64, maxActive int, entries []*outbox.Entry) error
+
+	// EndBatch writes ended leases, and sets their accounts NotReady
+	EndBatch(leases []*lease.Lease, prevLastModifiedOn []*int64) []error
 }
@@ -5,6 +5,7 @@ package mocks
 import account "github.com/Optum/dce/pkg/account"
 
 import mock "github.com/stretchr/testify/mock"
+import outbox "github.com/Optum/dce/pkg/outbox"
 
 // AccountData is an autogenerated mock type for the AccountData type
 type AccountData struct {
@@ -84,3 +85,17 @@ func (_m *AccountData) Write(_a0 *account.Account, prevLastModifiedOn *int64) er
 
 	return r0
 }
+
+// WriteWithEvents provides a mock function with given fields: _a0, prevLastModifiedOn, entries
+func (_m *AccountData) WriteWithEvents(_a0 *account.Account, prevLastModifiedOn *int64, entries []*outbox.Entry) error {
+	ret := _m.Called(_a0, prevLastModifiedOn, entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Account, *int64, []*outbox.Entry) error); ok {
+		r0 = rf(_a0, prevLastModifiedOn, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
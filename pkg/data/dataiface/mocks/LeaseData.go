@@ -4,12 +4,43 @@ package mocks
 
 import lease "github.com/Optum/dce/pkg/lease"
 import mock "github.com/stretchr/testify/mock"
+import outbox "github.com/Optum/dce/pkg/outbox"
 
 // LeaseData is an autogenerated mock type for the LeaseData type
 type LeaseData struct {
 	mock.Mock
 }
 
+// Create provides a mock function with given fields: _a0, prevLastModifiedOn, maxActive, entries
+func (_m *LeaseData) Create(_a0 *lease.Lease, prevLastModifiedOn *int64, maxActive int, entries []*outbox.Entry) error {
+	ret := _m.Called(_a0, prevLastModifiedOn, maxActive, entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*lease.Lease, *int64, int, []*outbox.Entry) error); ok {
+		r0 = rf(_a0, prevLastModifiedOn, maxActive, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EndBatch provides a mock function with given fields: leases, prevLastModifiedOn
+func (_m *LeaseData) EndBatch(leases []*lease.Lease, prevLastModifiedOn []*int64) []error {
+	ret := _m.Called(leases, prevLastModifiedOn)
+
+	var r0 []error
+	if rf, ok := ret.Get(0).(func([]*lease.Lease, []*int64) []error); ok {
+		r0 = rf(leases, prevLastModifiedOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ID
 func (_m *LeaseData) Get(ID string) (*lease.Lease, error) {
 	ret := _m.Called(ID)
@@ -92,3 +123,17 @@ func (_m *LeaseData) Write(_a0 *lease.Lease, prevLastModifiedOn *int64) error {
 
 	return r0
 }
+
+// WriteWithEvents provides a mock function with given fields: _a0, prevLastModifiedOn, entries
+func (_m *LeaseData) WriteWithEvents(_a0 *lease.Lease, prevLastModifiedOn *int64, entries []*outbox.Entry) error {
+	ret := _m.Called(_a0, prevLastModifiedOn, entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*lease.Lease, *int64, []*outbox.Entry) error); ok {
+		r0 = rf(_a0, prevLastModifiedOn, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -4,6 +4,10 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -51,11 +55,72 @@ func getFiltersFromStruct(input interface{}, keyName *string) (*expression.KeyCo
 	return kb, cb
 }
 
+// decodeItems restores the compressed attributes of items read from DynamoDB,
+// and upgrades items written with older versions of their schema
+func decodeItems(items []map[string]*dynamodb.AttributeValue, schema common.ItemSchema) error {
+	for _, item := range items {
+		err := decodeItem(item, schema)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeItem restores the compressed attributes of an item read from
+// DynamoDB, and upgrades it when it was written with an older version of its
+// schema
+func decodeItem(item map[string]*dynamodb.AttributeValue, schema common.ItemSchema) error {
+	err := common.DecompressAttributes(item)
+	if err != nil {
+		return err
+	}
+	return schema.Decode(item)
+}
+
 func putItem(input *dynamodb.PutItemInput, dataInterface dynamodbiface.DynamoDBAPI) error {
 	_, err := dataInterface.PutItem(input)
 	return err
 }
 
+// putItemWithEvents puts the item and the outbox entries of its events in
+// one transaction, so the events are only kept if the item is written.  A
+// cancelled transaction returns a ConditionalCheckFailedException, as
+// putItem does when its condition fails.
+func putItemWithEvents(input *dynamodb.PutItemInput, entries []*outbox.Entry, outboxTableName string, dataInterface dynamodbiface.DynamoDBAPI) error {
+	if len(entries) == 0 {
+		return putItem(input, dataInterface)
+	}
+
+	items := []*dynamodb.TransactWriteItem{
+		{
+			Put: &dynamodb.Put{
+				TableName:                 input.TableName,
+				Item:                      input.Item,
+				ConditionExpression:       input.ConditionExpression,
+				ExpressionAttributeNames:  input.ExpressionAttributeNames,
+				ExpressionAttributeValues: input.ExpressionAttributeValues,
+			},
+		},
+	}
+	for _, entry := range entries {
+		item, err := entry.Put(outboxTableName)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	_, err := dataInterface.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+		return awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, awsErr.Message(), err)
+	}
+	return err
+}
+
 func query(input *dynamodb.QueryInput, dataInterface dynamodbiface.DynamoDBAPI) (*dynamodb.QueryOutput, error) {
 	output, err := dataInterface.Query(input)
 	return output, err
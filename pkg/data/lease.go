@@ -3,14 +3,17 @@ package data
 import (
 	"fmt"
 
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	validation "github.com/go-ozzo/ozzo-validation"
 )
 
 // Lease - Data Layer Struct
@@ -19,6 +22,26 @@ type Lease struct {
 	TableName      string `env:"LEASE_DB"`
 	ConsistentRead bool   `env:"USE_CONSISTENT_READS" envDefault:"false"`
 	Limit          int64  `env:"LIMIT" envDefault:"25"`
+	// StatusShards is the number of shards of the sharded status index
+	StatusShards int `env:"STATUS_SHARD_COUNT" envDefault:"1"`
+	// AccountTableName is the table the accounts of ended leases are updated in
+	AccountTableName string `env:"ACCOUNT_DB" envDefault:"Accounts"`
+	// CompressionThreshold is the size in bytes above which Metadata is compressed
+	CompressionThreshold int `env:"METADATA_COMPRESSION_THRESHOLD" envDefault:"65536"`
+	// OutboxTableName is the table the events of lease changes are written to
+	OutboxTableName string `env:"OUTBOX_TABLE" envDefault:""`
+	// PrincipalLeasesTableName is the table the Active leases of each
+	// principal are counted in, so new leases can't exceed their quota
+	PrincipalLeasesTableName string `env:"PRINCIPAL_LEASES_TABLE" envDefault:""`
+}
+
+// Validate the configuration of the lease data layer
+func (a *Lease) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Limit, validation.Required.Error("must be no less than 1"), validation.Min(int64(1))),
+		validation.Field(&a.StatusShards, validation.Required.Error("must be no less than 1"), validation.Min(1)),
+		validation.Field(&a.CompressionThreshold, validation.Min(0)),
+	)
 }
 
 // Write the Lease record in DynamoDB
@@ -26,35 +49,56 @@ type Lease struct {
 // be inserted or updated
 // prevLastModifiedOn parameter is the original lastModifiedOn
 func (a *Lease) Write(lease *lease.Lease, prevLastModifiedOn *int64) error {
+	return a.WriteWithEvents(lease, prevLastModifiedOn, nil)
+}
+
+// WriteWithEvents writes the Lease record and the outbox entries of its events
+// in one transaction
+func (a *Lease) WriteWithEvents(lease *lease.Lease, prevLastModifiedOn *int64, entries []*outbox.Entry) error {
+	input, version, err := a.putInput(lease, prevLastModifiedOn)
+	if err != nil {
+		return err
+	}
+	err = putItemWithEvents(input, entries, a.OutboxTableName, a.DynamoDB)
+	if err != nil {
+		return a.writeError(lease, err)
+	}
+	lease.Version = version
+	return nil
+}
 
-	var expr expression.Expression
-	var err error
+// putInput puts the lease, when it wasn't modified since it was read.
+// Returns the version the lease is written with, which the lease is only
+// given once the write succeeds.
+func (a *Lease) putInput(lease *lease.Lease, prevLastModifiedOn *int64) (*dynamodb.PutItemInput, *int64, error) {
 	returnValue := "NONE"
-	// lastModifiedOn is nil on a create
-	if prevLastModifiedOn != nil {
-		modExpr := expression.Name("LastModifiedOn").Equal(expression.Value(prevLastModifiedOn))
-		expr, err = expression.NewBuilder().WithCondition(modExpr).Build()
-		if err != nil {
-			return errors.NewInternalServer("error building query", err)
-		}
-	} else {
-		modExpr := expression.Name("LastModifiedOn").AttributeNotExists()
-		expr, err = expression.NewBuilder().WithCondition(modExpr).Build()
-		if err != nil {
-			return errors.NewInternalServer("error building query", err)
-		}
+	// lastModifiedOn is nil on a create.  The lease is written over the
+	// version it was read at, and its version goes up by one.
+	expr, err := expression.NewBuilder().WithCondition(
+		common.LeaseItemSchema.VersionCondition(lease.Version, prevLastModifiedOn),
+	).Build()
+	if err != nil {
+		return nil, nil, errors.NewInternalServer("error building query", err)
 	}
+	written := *lease
+	written.Version = common.NextVersion(lease.Version)
 
-	putMap, _ := dynamodbattribute.Marshal(lease)
-	input := &dynamodb.PutItemInput{
+	item, err := a.item(&written)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &dynamodb.PutItemInput{
 		TableName:                 aws.String(a.TableName),
-		Item:                      putMap.M,
+		Item:                      item,
 		ConditionExpression:       expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		ReturnValues:              aws.String(returnValue),
-	}
-	err = putItem(input, a.DynamoDB)
+	}, written.Version, nil
+}
+
+// writeError is the error of a failed write of the lease
+func (a *Lease) writeError(lease *lease.Lease, err error) error {
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
 		if awsErr.Code() == "ConditionalCheckFailedException" {
@@ -75,6 +119,26 @@ func (a *Lease) Write(lease *lease.Lease, prevLastModifiedOn *int64) error {
 
 }
 
+// item is the lease as a DynamoDB item, with its sharded status, its schema
+// version and its Metadata compressed when it's large
+func (a *Lease) item(lease *lease.Lease) (map[string]*dynamodb.AttributeValue, error) {
+	putMap, _ := dynamodbattribute.Marshal(lease)
+	common.LeaseItemSchema.Stamp(putMap.M)
+	if a.StatusShards > 1 && lease.Status != nil {
+		putMap.M["LeaseStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(common.LeaseStatusShard(lease.Status.String(), *lease.AccountID, *lease.PrincipalID, a.StatusShards)),
+		}
+	}
+	err := common.CompressAttribute(putMap.M, "Metadata", a.CompressionThreshold)
+	if err != nil {
+		return nil, errors.NewInternalServer(
+			fmt.Sprintf("failure compressing lease with AccountID %q and PrincipalID %q", *lease.AccountID, *lease.PrincipalID),
+			err,
+		)
+	}
+	return putMap.M, nil
+}
+
 // GetByAccountIDAndPrincipalID gets the Lease record by AccountID and PrincipalID
 func (a *Lease) GetByAccountIDAndPrincipalID(accountID string, principalID string) (*lease.Lease, error) {
 
@@ -106,7 +170,10 @@ func (a *Lease) GetByAccountIDAndPrincipalID(accountID string, principalID strin
 	}
 
 	lease := lease.Lease{}
-	err = dynamodbattribute.UnmarshalMap(res.Item, &lease)
+	err = decodeItem(res.Item, common.LeaseItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalMap(res.Item, &lease)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer(
 			fmt.Sprintf("failure unmarshaling lease with account %q and princiapl %q", accountID, principalID),
@@ -151,7 +218,10 @@ func (a *Lease) Get(leaseID string) (*lease.Lease, error) {
 	}
 
 	lease := lease.Lease{}
-	err = dynamodbattribute.UnmarshalMap(res.Items[0], &lease)
+	err = decodeItem(res.Items[0], common.LeaseItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalMap(res.Items[0], &lease)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer(
 			fmt.Sprintf("failure unmarshaling lease with id %q", leaseID),
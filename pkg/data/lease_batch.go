@@ -0,0 +1,166 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+// transactWriteMaxItems is the most items DynamoDB accepts in one transaction
+const transactWriteMaxItems = 25
+
+// leasesPerTransaction is how many leases are ended in each transaction.
+// Each lease is written with its account.
+const leasesPerTransaction = transactWriteMaxItems / 2
+
+// leasesPerOutboxTransaction is how many leases are ended in each
+// transaction when the outbox is used.  Each lease is written with its
+// account and the outbox entry of its LeaseEnd event.
+const leasesPerOutboxTransaction = transactWriteMaxItems / 3
+
+// EndBatch writes ended leases, and sets their accounts NotReady, grouping
+// several leases into each DynamoDB transaction.  prevLastModifiedOn are the
+// original lastModifiedOn of the leases.  A transaction fails as a whole when
+// any of its leases was modified, so the leases of a failed transaction are
+// then written one at a time.  Returns the error of each lease, which is nil
+// when the lease was ended.  When there's an outbox table, the LeaseEnd event
+// of each lease is written to it in the same transaction.
+func (a *Lease) EndBatch(leases []*lease.Lease, prevLastModifiedOn []*int64) []error {
+	errs := make([]error, len(leases))
+	perTransaction := leasesPerTransaction
+	if a.OutboxTableName != "" {
+		perTransaction = leasesPerOutboxTransaction
+	}
+
+	for start := 0; start < len(leases); start += perTransaction {
+		end := start + perTransaction
+		if end > len(leases) {
+			end = len(leases)
+		}
+
+		err := a.endLeases(leases[start:end], prevLastModifiedOn[start:end])
+		if err == nil {
+			continue
+		}
+		var awsErr awserr.Error
+		if end-start == 1 || !errors.As(err, &awsErr) || awsErr.Code() != dynamodb.ErrCodeTransactionCanceledException {
+			for i := start; i < end; i++ {
+				errs[i] = a.endLeaseError(leases[i], err)
+			}
+			continue
+		}
+
+		for i := start; i < end; i++ {
+			err = a.endLeases(leases[i:i+1], prevLastModifiedOn[i:i+1])
+			if err != nil {
+				errs[i] = a.endLeaseError(leases[i], err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// endLeases writes the leases and their accounts in one transaction
+func (a *Lease) endLeases(leases []*lease.Lease, prevLastModifiedOn []*int64) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	items := []*dynamodb.TransactWriteItem{}
+	// The leases are only given the versions they're written with once the
+	// transaction succeeds, as failed transactions are written again
+	versions := make([]*int64, len(leases))
+
+	for i, l := range leases {
+		expr, err := expression.NewBuilder().WithCondition(
+			common.LeaseItemSchema.VersionCondition(l.Version, prevLastModifiedOn[i]),
+		).Build()
+		if err != nil {
+			return errors.NewInternalServer("error building query", err)
+		}
+		written := *l
+		written.Version = common.NextVersion(l.Version)
+		versions[i] = written.Version
+		item, err := a.item(&written)
+		if err != nil {
+			return err
+		}
+		items = append(items, &dynamodb.TransactWriteItem{
+			Put: &dynamodb.Put{
+				TableName:                 aws.String(a.TableName),
+				Item:                      item,
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		})
+
+		// The account is set NotReady whatever its status was, as when an
+		// account is reset after its lease ended
+		update := &dynamodb.Update{
+			TableName: aws.String(a.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {S: l.AccountID},
+			},
+			UpdateExpression:    aws.String("set AccountStatus = :status, LastModifiedOn = :now"),
+			ConditionExpression: aws.String("attribute_exists(Id)"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":status": {S: aws.String(account.StatusNotReady.String())},
+				":now":    {N: aws.String(now)},
+			},
+		}
+		if a.StatusShards > 1 {
+			update.UpdateExpression = aws.String(*update.UpdateExpression + ", AccountStatusShard = :shard")
+			update.ExpressionAttributeValues[":shard"] = &dynamodb.AttributeValue{
+				S: aws.String(common.StatusShard(account.StatusNotReady.String(), *l.AccountID, a.StatusShards)),
+			}
+		}
+		common.IncrementVersionClause(&update.UpdateExpression, &update.ExpressionAttributeNames, update.ExpressionAttributeValues)
+		items = append(items, &dynamodb.TransactWriteItem{Update: update})
+
+		if a.OutboxTableName != "" {
+			entry, err := outbox.NewEntry(outbox.TypeLeaseEnd, aws.StringValue(l.ID), nil, &written)
+			if err != nil {
+				return err
+			}
+			put, err := entry.Put(a.OutboxTableName)
+			if err != nil {
+				return err
+			}
+			items = append(items, put)
+		}
+	}
+
+	_, err := a.DynamoDB.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	if err != nil {
+		return err
+	}
+	for i, l := range leases {
+		l.Version = versions[i]
+	}
+	return nil
+}
+
+func (a *Lease) endLeaseError(l *lease.Lease, err error) error {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+		return errors.NewConflict(
+			"lease",
+			*l.AccountID,
+			fmt.Errorf("unable to end lease: lease or account has been modified since request was made"))
+	}
+	return errors.NewInternalServer(
+		fmt.Sprintf("end failed for lease with AccountID %q and PrincipalID %q", *l.AccountID, *l.PrincipalID),
+		err,
+	)
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	gErrors "errors"
+	"fmt"
+	"testing"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func endedLeases(n int) ([]*lease.Lease, []*int64) {
+	leases := []*lease.Lease{}
+	prev := []*int64{}
+	for i := 0; i < n; i++ {
+		leases = append(leases, &lease.Lease{
+			ID:             ptrString(fmt.Sprintf("lease-%d", i)),
+			AccountID:      ptrString(fmt.Sprintf("%012d", i)),
+			PrincipalID:    ptrString("User1"),
+			Status:         lease.StatusInactive.StatusPtr(),
+			StatusReason:   lease.StatusReasonExpired.StatusReasonPtr(),
+			LastModifiedOn: ptrInt64(1573592058),
+		})
+		prev = append(prev, ptrInt64(1573592000))
+	}
+	return leases, prev
+}
+
+func TestLeaseEndBatch(t *testing.T) {
+	t.Run("should end leases and set their accounts NotReady in transactions", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("TransactWriteItems", mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+		leaseData := &Lease{
+			DynamoDB:         mockDynamo,
+			TableName:        "Leases",
+			AccountTableName: "Accounts",
+			StatusShards:     4,
+		}
+		leases, prev := endedLeases(30)
+
+		errs := leaseData.EndBatch(leases, prev)
+
+		assert.Equal(t, make([]error, 30), errs)
+		// 12 leases and their accounts in each transaction
+		mockDynamo.AssertNumberOfCalls(t, "TransactWriteItems", 3)
+		input := mockDynamo.Calls[0].Arguments[0].(*dynamodb.TransactWriteItemsInput)
+		assert.Len(t, input.TransactItems, 24)
+
+		put := input.TransactItems[0].Put
+		assert.Equal(t, "Leases", *put.TableName)
+		assert.Equal(t, "Inactive", *put.Item["LeaseStatus"].S)
+		assert.NotNil(t, put.Item["LeaseStatusShard"])
+		assert.Equal(t, "1573592000", *put.ExpressionAttributeValues[":0"].N)
+		assert.Equal(t, "1", *put.Item["Version"].N)
+
+		update := input.TransactItems[1].Update
+		assert.Equal(t, "Accounts", *update.TableName)
+		assert.Equal(t, "000000000000", *update.Key["Id"].S)
+		assert.Equal(t, "NotReady", *update.ExpressionAttributeValues[":status"].S)
+		assert.Equal(t, "set AccountStatus = :status, LastModifiedOn = :now, AccountStatusShard = :shard ADD #version :versionIncrement", *update.UpdateExpression)
+
+		last := mockDynamo.Calls[2].Arguments[0].(*dynamodb.TransactWriteItemsInput)
+		assert.Len(t, last.TransactItems, 12)
+	})
+
+	t.Run("should end the leases of a cancelled transaction one at a time", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		cancelled := awserr.New(dynamodb.ErrCodeTransactionCanceledException, "Transaction cancelled", nil)
+		mockDynamo.On("TransactWriteItems", mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+			return len(input.TransactItems) > 2
+		})).Return(nil, cancelled)
+		// The second lease was modified
+		mockDynamo.On("TransactWriteItems", mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+			return *input.TransactItems[1].Update.Key["Id"].S == "000000000001"
+		})).Return(nil, cancelled)
+		mockDynamo.On("TransactWriteItems", mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+		leaseData := &Lease{
+			DynamoDB:         mockDynamo,
+			TableName:        "Leases",
+			AccountTableName: "Accounts",
+		}
+		leases, prev := endedLeases(3)
+
+		errs := leaseData.EndBatch(leases, prev)
+
+		assert.Nil(t, errs[0])
+		assert.Equal(t, "operation cannot be fulfilled on lease \"000000000001\": unable to end lease: lease or account has been modified since request was made", errs[1].Error())
+		assert.Nil(t, errs[2])
+		mockDynamo.AssertNumberOfCalls(t, "TransactWriteItems", 4)
+		// The leases are written again over the version they were read at,
+		// and only given their new version once they're written
+		retry := mockDynamo.Calls[1].Arguments[0].(*dynamodb.TransactWriteItemsInput)
+		assert.Equal(t, "1", *retry.TransactItems[0].Put.Item["Version"].N)
+		assert.Equal(t, int64(1), *leases[0].Version)
+		assert.Nil(t, leases[1].Version)
+	})
+
+	t.Run("should fail every lease of a transaction which errored", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("TransactWriteItems", mock.Anything).Return(nil, gErrors.New("throttled"))
+		leaseData := &Lease{
+			DynamoDB:         mockDynamo,
+			TableName:        "Leases",
+			AccountTableName: "Accounts",
+		}
+		leases, prev := endedLeases(2)
+
+		errs := leaseData.EndBatch(leases, prev)
+
+		assert.Len(t, errs, 2)
+		for _, err := range errs {
+			assert.NotNil(t, err)
+		}
+		mockDynamo.AssertNumberOfCalls(t, "TransactWriteItems", 1)
+	})
+}
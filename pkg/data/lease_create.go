@@ -0,0 +1,117 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// Create writes a new lease, and sets its account Leased, in one DynamoDB
+// transaction, so an account is never Leased without its lease, or leased
+// twice.  The account must still be Ready.  The lease is counted against its
+// principal's quota of maxActive Active leases in the same transaction, when
+// there's a principal leases table, and the outbox entries of its events are
+// written with it.  Returns a conflict when the transaction is cancelled, eg.
+// because the account was leased by another request since it was read.
+func (a *Lease) Create(newLease *lease.Lease, prevLastModifiedOn *int64, maxActive int, entries []*outbox.Entry) error {
+	input, version, err := a.putInput(newLease, prevLastModifiedOn)
+	if err != nil {
+		return err
+	}
+	update, err := a.leaseAccount(newLease)
+	if err != nil {
+		return err
+	}
+	items := []*dynamodb.TransactWriteItem{
+		{
+			Put: &dynamodb.Put{
+				TableName:                 input.TableName,
+				Item:                      input.Item,
+				ConditionExpression:       input.ConditionExpression,
+				ExpressionAttributeNames:  input.ExpressionAttributeNames,
+				ExpressionAttributeValues: input.ExpressionAttributeValues,
+			},
+		},
+		update,
+	}
+
+	counter, err := a.quotaItem(newLease, maxActive)
+	if err != nil {
+		return err
+	}
+	if counter != nil {
+		items = append(items, counter)
+	}
+	for _, entry := range entries {
+		item, err := entry.Put(a.OutboxTableName)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	_, err = a.DynamoDB.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+		return errors.NewConflict(
+			"lease",
+			*newLease.AccountID,
+			fmt.Errorf("unable to create lease: lease or account has been modified since request was made"))
+	}
+	if err != nil {
+		return a.writeError(newLease, err)
+	}
+	newLease.Version = version
+	return nil
+}
+
+// leaseAccount sets the lease's account Leased, and remembers who leased it,
+// on the condition it's Ready.  The principal policy customization of the
+// lease is applied when the principal access is configured for the lease,
+// and reverted when the account is reset.
+func (a *Lease) leaseAccount(newLease *lease.Lease) (*dynamodb.TransactWriteItem, error) {
+	update := &dynamodb.Update{
+		TableName: aws.String(a.AccountTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Id": {S: newLease.AccountID},
+		},
+		UpdateExpression:    aws.String("set AccountStatus = :leased, LastLeasedBy = :principalId, LastModifiedOn = :now"),
+		ConditionExpression: aws.String("AccountStatus = :ready"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":leased":      {S: aws.String(account.StatusLeased.String())},
+			":ready":       {S: aws.String(account.StatusReady.String())},
+			":principalId": {S: newLease.PrincipalID},
+			":now":         {N: aws.String(strconv.FormatInt(time.Now().Unix(), 10))},
+		},
+	}
+	if a.StatusShards > 1 {
+		update.UpdateExpression = aws.String(*update.UpdateExpression + ", AccountStatusShard = :shard")
+		update.ExpressionAttributeValues[":shard"] = &dynamodb.AttributeValue{
+			S: aws.String(common.StatusShard(account.StatusLeased.String(), *newLease.AccountID, a.StatusShards)),
+		}
+	}
+	if newLease.PolicyCustomization.IsEmpty() {
+		update.UpdateExpression = aws.String(*update.UpdateExpression + " remove PrincipalPolicyCustomization")
+	} else {
+		customization, err := dynamodbattribute.Marshal(newLease.PolicyCustomization)
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to marshal the policy customization of the lease", err)
+		}
+		update.UpdateExpression = aws.String(*update.UpdateExpression + ", PrincipalPolicyCustomization = :customization")
+		update.ExpressionAttributeValues[":customization"] = customization
+	}
+	common.IncrementVersionClause(&update.UpdateExpression, &update.ExpressionAttributeNames, update.ExpressionAttributeValues)
+	return &dynamodb.TransactWriteItem{Update: update}, nil
+}
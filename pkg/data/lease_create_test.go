@@ -0,0 +1,99 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaseCreate(t *testing.T) {
+	tests := []struct {
+		name             string
+		customization    *account.PolicyCustomization
+		statusShards     int
+		dynamoErr        error
+		expUpdate        string
+		expCustomization bool
+		expErr           error
+	}{
+		{
+			name:      "should create the lease and set its account Leased",
+			expUpdate: "set AccountStatus = :leased, LastLeasedBy = :principalId, LastModifiedOn = :now remove PrincipalPolicyCustomization ADD #version :versionIncrement",
+		},
+		{
+			name:             "should apply the policy customization of the lease to its account",
+			customization:    &account.PolicyCustomization{Add: []string{"AllowSageMaker"}},
+			statusShards:     4,
+			expUpdate:        "set AccountStatus = :leased, LastLeasedBy = :principalId, LastModifiedOn = :now, AccountStatusShard = :shard, PrincipalPolicyCustomization = :customization ADD #version :versionIncrement",
+			expCustomization: true,
+		},
+		{
+			name:      "should conflict when the account isn't Ready",
+			dynamoErr: awserr.New(dynamodb.ErrCodeTransactionCanceledException, "Cancelled", nil),
+			expUpdate: "set AccountStatus = :leased, LastLeasedBy = :principalId, LastModifiedOn = :now remove PrincipalPolicyCustomization ADD #version :versionIncrement",
+			expErr: errors.NewConflict(
+				"lease",
+				"123456789012",
+				fmt.Errorf("unable to create lease: lease or account has been modified since request was made")),
+		},
+		{
+			name:      "should fail when the transaction fails",
+			dynamoErr: fmt.Errorf("failure"),
+			expUpdate: "set AccountStatus = :leased, LastLeasedBy = :principalId, LastModifiedOn = :now remove PrincipalPolicyCustomization ADD #version :versionIncrement",
+			expErr: errors.NewInternalServer(
+				"update failed for lease with AccountID \"123456789012\" and PrincipalID \"User1\"",
+				fmt.Errorf("failure")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newLease := &lease.Lease{
+				ID:                  ptrString("lease-1"),
+				AccountID:           ptrString("123456789012"),
+				PrincipalID:         ptrString("User1"),
+				Status:              lease.StatusActive.StatusPtr(),
+				LastModifiedOn:      ptrInt64(1573592058),
+				PolicyCustomization: tt.customization,
+			}
+
+			mockDynamo := &awsmocks.DynamoDBAPI{}
+			mockDynamo.On("TransactWriteItems", mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+				if len(input.TransactItems) != 2 || *input.TransactItems[0].Put.TableName != "Leases" {
+					return false
+				}
+				update := input.TransactItems[1].Update
+				_, customized := update.ExpressionAttributeValues[":customization"]
+				// The lease is new, and the account is leased over any version
+				return *input.TransactItems[0].Put.Item["Version"].N == "1" &&
+					*update.TableName == "Accounts" &&
+					*update.Key["Id"].S == "123456789012" &&
+					*update.UpdateExpression == tt.expUpdate &&
+					*update.ConditionExpression == "AccountStatus = :ready" &&
+					*update.ExpressionAttributeValues[":leased"].S == "Leased" &&
+					*update.ExpressionAttributeValues[":principalId"].S == "User1" &&
+					customized == tt.expCustomization
+			})).Return(&dynamodb.TransactWriteItemsOutput{}, tt.dynamoErr)
+
+			leaseData := &Lease{
+				DynamoDB:         mockDynamo,
+				TableName:        "Leases",
+				AccountTableName: "Accounts",
+				StatusShards:     tt.statusShards,
+			}
+
+			err := leaseData.Create(newLease, nil, 1, nil)
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mockDynamo.AssertNotCalled(t, "GetItem", mock.Anything)
+			mockDynamo.AssertExpectations(t)
+		})
+	}
+}
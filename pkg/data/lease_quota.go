@@ -0,0 +1,134 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// principalLeases is the item of a principal in the principal leases table,
+// listing the accounts of their Active leases.  Leases aren't removed when
+// they end, so the accounts are checked when the principal reaches their
+// quota, and those no longer leased to them are dropped.
+type principalLeases struct {
+	PrincipalID string   `dynamodbav:"PrincipalId"`
+	AccountIDs  []string `dynamodbav:"AccountIds"`
+	Version     int64    `dynamodbav:"Version"`
+}
+
+// quotaItem adds the new lease to its principal's item in the principal
+// leases table, while the principal has fewer than maxActive Active leases.
+// The item is only written if it wasn't changed since it was read, so
+// concurrent requests can't both exceed the quota.  Returns nil without a
+// principal leases table.
+func (a *Lease) quotaItem(newLease *lease.Lease, maxActive int) (*dynamodb.TransactWriteItem, error) {
+	if a.PrincipalLeasesTableName == "" {
+		return nil, nil
+	}
+
+	current, err := a.getPrincipalLeases(*newLease.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountIDs := []string{}
+	for _, accountID := range current.AccountIDs {
+		// The account's lease is replaced by the new lease
+		if accountID != *newLease.AccountID {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	if len(accountIDs) >= maxActive {
+		accountIDs, err = a.activeAccountIDs(*newLease.PrincipalID, accountIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(accountIDs) >= maxActive {
+		return nil, lease.ActiveLeasesExceeded(*newLease.PrincipalID, maxActive)
+	}
+
+	return a.putPrincipalLeases(current, append(accountIDs, *newLease.AccountID))
+}
+
+// getPrincipalLeases reads the principal's item, or an empty item when they
+// have none yet
+func (a *Lease) getPrincipalLeases(principalID string) (*principalLeases, error) {
+	res, err := getItem(&dynamodb.GetItemInput{
+		TableName: aws.String(a.PrincipalLeasesTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"PrincipalId": {S: aws.String(principalID)},
+		},
+		ConsistentRead: aws.Bool(true),
+	}, a.DynamoDB)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to get the leases of principal %q", principalID), err)
+	}
+
+	current := &principalLeases{PrincipalID: principalID}
+	if len(res.Item) == 0 {
+		return current, nil
+	}
+	err = dynamodbattribute.UnmarshalMap(res.Item, current)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to unmarshal the leases of principal %q", principalID), err)
+	}
+	return current, nil
+}
+
+// activeAccountIDs are the accounts whose leases to the principal are still
+// Active
+func (a *Lease) activeAccountIDs(principalID string, accountIDs []string) ([]string, error) {
+	active := []string{}
+	for _, accountID := range accountIDs {
+		res, err := getItem(&dynamodb.GetItemInput{
+			TableName: aws.String(a.TableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"AccountId":   {S: aws.String(accountID)},
+				"PrincipalId": {S: aws.String(principalID)},
+			},
+			ProjectionExpression: aws.String("LeaseStatus"),
+			ConsistentRead:       aws.Bool(true),
+		}, a.DynamoDB)
+		if err != nil {
+			return nil, errors.NewInternalServer(
+				fmt.Sprintf("get lease failed for account %q and principal %q", accountID, principalID), err)
+		}
+		status, ok := res.Item["LeaseStatus"]
+		if ok && aws.StringValue(status.S) == lease.StatusActive.String() {
+			active = append(active, accountID)
+		}
+	}
+	return active, nil
+}
+
+// putPrincipalLeases replaces the accounts of the principal's item, on the
+// condition it wasn't changed since it was read
+func (a *Lease) putPrincipalLeases(current *principalLeases, accountIDs []string) (*dynamodb.TransactWriteItem, error) {
+	item, err := dynamodbattribute.MarshalMap(&principalLeases{
+		PrincipalID: current.PrincipalID,
+		AccountIDs:  accountIDs,
+		Version:     current.Version + 1,
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to marshal the leases of principal %q", current.PrincipalID), err)
+	}
+
+	put := &dynamodb.Put{
+		TableName:           aws.String(a.PrincipalLeasesTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PrincipalId)"),
+	}
+	if current.Version > 0 {
+		put.ConditionExpression = aws.String("Version = :version")
+		put.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{
+			":version": {N: aws.String(strconv.FormatInt(current.Version, 10))},
+		}
+	}
+	return &dynamodb.TransactWriteItem{Put: put}, nil
+}
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaseCreateWithinQuota(t *testing.T) {
+	newLease := &lease.Lease{
+		ID:             ptrString("lease-3"),
+		AccountID:      ptrString("333333333333"),
+		PrincipalID:    ptrString("User1"),
+		Status:         lease.StatusActive.StatusPtr(),
+		LastModifiedOn: ptrInt64(1573592058),
+	}
+	principalItem := func(accountIDs ...string) map[string]*dynamodb.AttributeValue {
+		list := []*dynamodb.AttributeValue{}
+		for _, accountID := range accountIDs {
+			list = append(list, &dynamodb.AttributeValue{S: aws.String(accountID)})
+		}
+		return map[string]*dynamodb.AttributeValue{
+			"PrincipalId": {S: aws.String("User1")},
+			"AccountIds":  {L: list},
+			"Version":     {N: aws.String("4")},
+		}
+	}
+	leaseItem := func(status string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{"LeaseStatus": {S: aws.String(status)}}
+	}
+
+	tests := []struct {
+		name          string
+		principalItem map[string]*dynamodb.AttributeValue
+		leaseItems    map[string]map[string]*dynamodb.AttributeValue
+		dynamoErr     error
+		expAccountIDs []string
+		expCondition  string
+		expErr        error
+	}{
+		{
+			name:          "should add the first lease of a principal",
+			expAccountIDs: []string{"333333333333"},
+			expCondition:  "attribute_not_exists(PrincipalId)",
+		},
+		{
+			name:          "should add a lease below the quota",
+			principalItem: principalItem("111111111111"),
+			expAccountIDs: []string{"111111111111", "333333333333"},
+			expCondition:  "Version = :version",
+		},
+		{
+			name:          "should drop the accounts of ended leases at the quota",
+			principalItem: principalItem("111111111111", "222222222222"),
+			leaseItems: map[string]map[string]*dynamodb.AttributeValue{
+				"111111111111": leaseItem("Active"),
+				"222222222222": leaseItem("Inactive"),
+			},
+			expAccountIDs: []string{"111111111111", "333333333333"},
+			expCondition:  "Version = :version",
+		},
+		{
+			name:          "should fail when the principal has too many Active leases",
+			principalItem: principalItem("111111111111", "222222222222"),
+			leaseItems: map[string]map[string]*dynamodb.AttributeValue{
+				"111111111111": leaseItem("Active"),
+				"222222222222": leaseItem("Active"),
+			},
+			expErr: lease.ActiveLeasesExceeded("User1", 2),
+		},
+		{
+			name:          "should conflict when the principal's leases changed since they were read",
+			principalItem: principalItem("111111111111"),
+			dynamoErr:     awserr.New(dynamodb.ErrCodeTransactionCanceledException, "Cancelled", nil),
+			expAccountIDs: []string{"111111111111", "333333333333"},
+			expCondition:  "Version = :version",
+			expErr: errors.NewConflict(
+				"lease",
+				"333333333333",
+				fmt.Errorf("unable to create lease: lease or account has been modified since request was made")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDynamo := &awsmocks.DynamoDBAPI{}
+			mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+				return *input.TableName == "PrincipalLeases" && *input.ConsistentRead
+			})).Return(&dynamodb.GetItemOutput{Item: tt.principalItem}, nil)
+			for accountID, item := range tt.leaseItems {
+				accountID := accountID
+				mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+					return *input.TableName == "Leases" && *input.Key["AccountId"].S == accountID
+				})).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+			}
+			if tt.expAccountIDs != nil {
+				mockDynamo.On("TransactWriteItems", mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+					if len(input.TransactItems) != 3 || *input.TransactItems[0].Put.TableName != "Leases" ||
+						*input.TransactItems[1].Update.TableName != "Accounts" {
+						return false
+					}
+					counter := input.TransactItems[2].Put
+					accountIDs := []string{}
+					for _, accountID := range counter.Item["AccountIds"].L {
+						accountIDs = append(accountIDs, *accountID.S)
+					}
+					return *counter.TableName == "PrincipalLeases" &&
+						*counter.ConditionExpression == tt.expCondition &&
+						assert.ObjectsAreEqual(tt.expAccountIDs, accountIDs)
+				})).Return(&dynamodb.TransactWriteItemsOutput{}, tt.dynamoErr)
+			}
+
+			leaseData := &Lease{
+				DynamoDB:                 mockDynamo,
+				TableName:                "Leases",
+				AccountTableName:         "Accounts",
+				PrincipalLeasesTableName: "PrincipalLeases",
+			}
+
+			err := leaseData.Create(newLease, nil, 2, nil)
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expAccountIDs == nil {
+				mockDynamo.AssertNotCalled(t, "TransactWriteItems", mock.Anything)
+			}
+			mockDynamo.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/outbox"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
@@ -35,6 +36,8 @@ func TestGetLeaseByAccountIDAndPrincipalID(t *testing.T) {
 				PrincipalID:    ptrString("User1"),
 				Status:         lease.StatusActive.StatusPtr(),
 				LastModifiedOn: ptrInt64(1573592058),
+				// Upgraded from an unversioned item
+				StatusModifiedOn: ptrInt64(1573592058),
 			},
 			dynamoErr: nil,
 			dynamoOutput: &dynamodb.GetItemOutput{
@@ -199,6 +202,60 @@ func TestLeaseUpdate(t *testing.T) {
 
 }
 
+func TestLeaseWriteWithEvents(t *testing.T) {
+	data := &lease.Lease{
+		ID:             ptrString("lease-1"),
+		AccountID:      ptrString("123456789012"),
+		PrincipalID:    ptrString("User1"),
+		Status:         lease.StatusActive.StatusPtr(),
+		LastModifiedOn: ptrInt64(1573592058),
+	}
+	entry, err := outbox.NewEntry(outbox.TypeLeaseCreate, "lease-1", nil, data)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		dynamoErr   error
+		expectedErr error
+	}{
+		{
+			name: "should write the lease and its events in one transaction",
+		},
+		{
+			name:      "should conflict when the transaction is cancelled",
+			dynamoErr: awserr.New(dynamodb.ErrCodeTransactionCanceledException, "Cancelled", nil),
+			expectedErr: errors.NewConflict(
+				"lease",
+				"123456789012",
+				fmt.Errorf("unable to update lease: leases has been modified since request was made")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDynamo := awsmocks.DynamoDBAPI{}
+			mockDynamo.On("TransactWriteItems", mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+				return len(input.TransactItems) == 2 &&
+					*input.TransactItems[0].Put.TableName == "Leases" &&
+					*input.TransactItems[0].Put.ConditionExpression == "attribute_not_exists (#0)" &&
+					*input.TransactItems[1].Put.TableName == "Outbox" &&
+					*input.TransactItems[1].Put.Item["Id"].S == entry.ID
+			})).Return(&dynamodb.TransactWriteItemsOutput{}, tt.dynamoErr)
+
+			leaseData := &Lease{
+				DynamoDB:        &mockDynamo,
+				TableName:       "Leases",
+				OutboxTableName: "Outbox",
+			}
+
+			err := leaseData.WriteWithEvents(data, nil, []*outbox.Entry{entry})
+			assert.Truef(t, errors.Is(err, tt.expectedErr), "actual error %q doesn't match expected error %q", err, tt.expectedErr)
+			mockDynamo.AssertNotCalled(t, "PutItem", mock.Anything)
+			mockDynamo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetLeaseByID(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -217,6 +274,8 @@ func TestGetLeaseByID(t *testing.T) {
 				PrincipalID:    ptrString("User1"),
 				Status:         lease.StatusActive.StatusPtr(),
 				LastModifiedOn: ptrInt64(1573592058),
+				// Upgraded from an unversioned item
+				StatusModifiedOn: ptrInt64(1573592058),
 			},
 			dynamoErr: nil,
 			dynamoOutput: &dynamodb.QueryOutput{
@@ -1,13 +1,15 @@
 package data
 
 import (
+	"strings"
+
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
-	"strings"
 )
 
 // queryLeases for doing a query against dynamodb
@@ -126,6 +128,23 @@ func (a *Lease) List(query *lease.Lease) (*lease.Leases, error) {
 		outputs, err = a.queryLeases(query, "Id", "LeaseId")
 	} else if query.PrincipalID != nil {
 		outputs, err = a.queryLeases(query, "PrincipalId", "PrincipalId")
+	} else if query.Status != nil && a.StatusShards > 1 {
+		start := 0
+		if query.NextAccountID != nil && query.NextPrincipalID != nil {
+			start = common.ShardIndex(common.LeaseShardKey(*query.NextAccountID, *query.NextPrincipalID), a.StatusShards)
+		}
+		shardQuery := *query
+		shardQuery.Status = nil
+		_, filters := getFiltersFromStruct(&shardQuery, nil)
+		outputs, err = queryShards(a.DynamoDB, &queryShardsInput{
+			tableName: a.TableName,
+			indexName: "LeaseStatusShard",
+			shardKeys: common.StatusShardKeys(query.Status.String(), a.StatusShards)[start:],
+			startKey:  nextLeaseKey(query),
+			keyNames:  []string{"AccountId", "PrincipalId"},
+			filters:   filters,
+			limit:     *query.Limit,
+		})
 	} else if query.Status != nil {
 		outputs, err = a.queryLeases(query, "LeaseStatus", "LeaseStatus")
 	} else {
@@ -147,10 +166,24 @@ func (a *Lease) List(query *lease.Lease) (*lease.Leases, error) {
 	}
 
 	leases := &lease.Leases{}
-	err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, leases)
+	err = decodeItems(outputs.items, common.LeaseItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, leases)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer("failed unmarshal of leases", err)
 	}
 
 	return leases, nil
 }
+
+// nextLeaseKey gets the key to start a page of leases after
+func nextLeaseKey(query *lease.Lease) map[string]*dynamodb.AttributeValue {
+	if query.NextAccountID == nil || query.NextPrincipalID == nil {
+		return nil
+	}
+	return map[string]*dynamodb.AttributeValue{
+		"AccountId":   {S: query.NextAccountID},
+		"PrincipalId": {S: query.NextPrincipalID},
+	}
+}
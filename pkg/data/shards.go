@@ -0,0 +1,89 @@
+package data
+
+import (
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type queryShardsInput struct {
+	tableName string
+	// indexName is the name of the sharded status index, and its key
+	indexName string
+	// shardKeys are the shards to query, starting from the shard of startKey
+	shardKeys []string
+	// startKey is the table key of the item to start the page after
+	startKey map[string]*dynamodb.AttributeValue
+	// keyNames are the attributes of the table key
+	keyNames []string
+	filters  *expression.ConditionBuilder
+	limit    int64
+}
+
+// queryShards queries a sharded status index one shard at a time, until
+// limit items were read or every shard was queried. Items always stay in
+// the same shard, so the last evaluated key is the table key of the last
+// item, and the next page starts from its shard.
+func queryShards(client dynamodbiface.DynamoDBAPI, input *queryShardsInput) (*queryScanOutput, error) {
+	output := &queryScanOutput{}
+
+	for i, shard := range input.shardKeys {
+		bldr := expression.NewBuilder().
+			WithKeyCondition(expression.Key(input.indexName).Equal(expression.Value(shard)))
+		if input.filters != nil {
+			bldr = bldr.WithFilter(*input.filters)
+		}
+		expr, err := bldr.Build()
+		if err != nil {
+			return nil, errors.NewInternalServer("unable to build query", err)
+		}
+
+		queryInput := &dynamodb.QueryInput{
+			TableName:                 aws.String(input.tableName),
+			IndexName:                 aws.String(input.indexName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			Limit:                     aws.Int64(input.limit - int64(len(output.items))),
+		}
+		if i == 0 && input.startKey != nil {
+			startKey := map[string]*dynamodb.AttributeValue{
+				input.indexName: {S: aws.String(shard)},
+			}
+			for k, v := range input.startKey {
+				startKey[k] = v
+			}
+			queryInput.ExclusiveStartKey = startKey
+		}
+
+		res, err := client.Query(queryInput)
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to query status shards", err)
+		}
+		output.items = append(output.items, res.Items...)
+
+		if len(res.LastEvaluatedKey) > 0 {
+			output.lastEvaluatedKey = tableKey(res.LastEvaluatedKey, input.keyNames)
+			return output, nil
+		}
+		if int64(len(output.items)) >= input.limit {
+			if i < len(input.shardKeys)-1 {
+				output.lastEvaluatedKey = tableKey(output.items[len(output.items)-1], input.keyNames)
+			}
+			return output, nil
+		}
+	}
+
+	return output, nil
+}
+
+func tableKey(item map[string]*dynamodb.AttributeValue, keyNames []string) map[string]*dynamodb.AttributeValue {
+	key := map[string]*dynamodb.AttributeValue{}
+	for _, name := range keyNames {
+		key[name] = item[name]
+	}
+	return key
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAccountsSharded(t *testing.T) {
+	// Three shards of Ready accounts, whose IDs are in the shard they hash to
+	shards := map[string][]string{}
+	for _, id := range []string{"111111111111", "222222222222", "333333333333", "444444444444", "555555555555"} {
+		shard := common.StatusShard("Ready", id, 3)
+		shards[shard] = append(shards[shard], id)
+	}
+
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("Query", mock.Anything).Return(func(input *dynamodb.QueryInput) *dynamodb.QueryOutput {
+		assert.Equal(t, "AccountStatusShard", *input.IndexName)
+		shard := *input.ExpressionAttributeValues[":0"].S
+		ids := shards[shard]
+		if input.ExclusiveStartKey != nil {
+			assert.Equal(t, shard, *input.ExclusiveStartKey["AccountStatusShard"].S)
+			for i, id := range ids {
+				if id == *input.ExclusiveStartKey["Id"].S {
+					ids = ids[i+1:]
+					break
+				}
+			}
+		}
+		output := &dynamodb.QueryOutput{}
+		for i, id := range ids {
+			if int64(i) == *input.Limit {
+				output.LastEvaluatedKey = map[string]*dynamodb.AttributeValue{
+					"Id":                 {S: aws.String(ids[i-1])},
+					"AccountStatusShard": {S: aws.String(shard)},
+				}
+				break
+			}
+			output.Items = append(output.Items, map[string]*dynamodb.AttributeValue{
+				"Id":            {S: aws.String(id)},
+				"AccountStatus": {S: aws.String("Ready")},
+			})
+		}
+		return output
+	}, nil)
+
+	accountData := &Account{
+		DynamoDB:     mockDynamo,
+		TableName:    "Accounts",
+		Limit:        2,
+		StatusShards: 3,
+	}
+
+	// Every account is read once, two at a time
+	query := &account.Account{Status: account.StatusReady.StatusPtr()}
+	ids := []string{}
+	for page := 0; page < 5; page++ {
+		accounts, err := accountData.List(query)
+		assert.Nil(t, err)
+		assert.True(t, len(*accounts) <= 2)
+		for _, a := range *accounts {
+			ids = append(ids, *a.ID)
+		}
+		if query.NextID == nil {
+			break
+		}
+	}
+	assert.Nil(t, query.NextID)
+	assert.ElementsMatch(t, []string{"111111111111", "222222222222", "333333333333", "444444444444", "555555555555"}, ids)
+}
+
+func TestWriteSharded(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	accountData := &Account{DynamoDB: mockDynamo, TableName: "Accounts", StatusShards: 4}
+	err := accountData.Write(&account.Account{
+		ID:     aws.String("123456789012"),
+		Status: account.StatusReady.StatusPtr(),
+	}, nil)
+	assert.Nil(t, err)
+
+	leaseData := &Lease{DynamoDB: mockDynamo, TableName: "Leases", StatusShards: 4}
+	err = leaseData.Write(&lease.Lease{
+		AccountID:   aws.String("123456789012"),
+		PrincipalID: aws.String("jdoe"),
+		Status:      lease.StatusActive.StatusPtr(),
+	}, nil)
+	assert.Nil(t, err)
+
+	mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "Accounts" &&
+			*input.Item["AccountStatusShard"].S == common.StatusShard("Ready", "123456789012", 4)
+	}))
+	mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "Leases" &&
+			*input.Item["LeaseStatusShard"].S == common.LeaseStatusShard("Active", "123456789012", "jdoe", 4)
+	}))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/usage"
 	"github.com/aws/aws-sdk-go/aws"
@@ -84,7 +85,10 @@ func (a *Usage) GetByStartDateAndPrincipalID(startDate int64, principalID string
 	}
 
 	usg := &usage.Usage{}
-	err = dynamodbattribute.UnmarshalMap(res.Item, usg)
+	err = common.UsageItemSchema.Decode(res.Item)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalMap(res.Item, usg)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer(
 			fmt.Sprintf("failure unmarshaling usage with start date \"%d\" and princiapl %q", startDate, principalID),
@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Optum/dce/pkg/common"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/usage"
 	"github.com/aws/aws-sdk-go/aws"
@@ -150,7 +151,10 @@ func (a *Usage) List(query *usage.Usage) (*usage.Usages, error) {
 	}
 
 	usgs := &usage.Usages{}
-	err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, usgs)
+	err = decodeItems(outputs.items, common.UsageItemSchema)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalListOfMaps(outputs.items, usgs)
+	}
 	if err != nil {
 		return nil, errors.NewInternalServer("failed unmarshal of usages", err)
 	}
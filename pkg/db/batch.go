@@ -0,0 +1,152 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// batchGetMaxKeys is the most keys DynamoDB accepts in one BatchGetItem request
+const batchGetMaxKeys = 100
+
+// batchGetMaxAttempts is how many times unprocessed keys are requested,
+// before giving up
+const batchGetMaxAttempts = 5
+
+// batchGetRetryDelay is the delay before unprocessed keys are first
+// requested again.  It doubles with each attempt
+var batchGetRetryDelay = 50 * time.Millisecond
+
+// LeaseKey is the key of a lease in the lease table.  Lease IDs are only
+// in an index, which BatchGetItem can't read from.
+type LeaseKey struct {
+	AccountID   string
+	PrincipalID string
+}
+
+// GetAccountsByIDs returns the accounts with the given IDs, in the order of
+// the IDs.  Accounts which don't exist are left out, and repeated IDs only
+// return their account once.
+func (db *DB) GetAccountsByIDs(accountIDs []string) ([]*Account, error) {
+	keys := []map[string]*dynamodb.AttributeValue{}
+	seen := map[string]bool{}
+	for _, id := range accountIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		keys = append(keys, map[string]*dynamodb.AttributeValue{
+			"Id": {S: aws.String(id)},
+		})
+	}
+
+	items, err := db.batchGetItems(db.AccountTableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*Account{}
+	for _, item := range items {
+		acct, err := unmarshalAccount(item)
+		if err != nil {
+			return nil, err
+		}
+		byID[acct.ID] = acct
+	}
+
+	accounts := []*Account{}
+	for _, id := range accountIDs {
+		if acct, ok := byID[id]; ok {
+			accounts = append(accounts, acct)
+			delete(byID, id)
+		}
+	}
+	return accounts, nil
+}
+
+// GetLeasesByIDs returns the leases with the given keys, in the order of
+// the keys.  Leases which don't exist are left out, and repeated keys only
+// return their lease once.
+func (db *DB) GetLeasesByIDs(leaseKeys []LeaseKey) ([]*Lease, error) {
+	keys := []map[string]*dynamodb.AttributeValue{}
+	seen := map[LeaseKey]bool{}
+	for _, key := range leaseKeys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, map[string]*dynamodb.AttributeValue{
+			"AccountId":   {S: aws.String(key.AccountID)},
+			"PrincipalId": {S: aws.String(key.PrincipalID)},
+		})
+	}
+
+	items, err := db.batchGetItems(db.LeaseTableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[LeaseKey]*Lease{}
+	for _, item := range items {
+		lease, err := unmarshalLease(item)
+		if err != nil {
+			return nil, err
+		}
+		byKey[LeaseKey{AccountID: lease.AccountID, PrincipalID: lease.PrincipalID}] = lease
+	}
+
+	leases := []*Lease{}
+	for _, key := range leaseKeys {
+		if lease, ok := byKey[key]; ok {
+			leases = append(leases, lease)
+			delete(byKey, key)
+		}
+	}
+	return leases, nil
+}
+
+// batchGetItems gets the items with the given keys from a table, in chunks
+// of as many keys as BatchGetItem accepts.  Keys which DynamoDB doesn't
+// process, because of throttling or the size of the response, are
+// requested again with exponential backoff.  Keys must be unique.
+func (db *DB) batchGetItems(tableName string, keys []map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, error) {
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for start := 0; start < len(keys); start += batchGetMaxKeys {
+		end := start + batchGetMaxKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		requestItems := map[string]*dynamodb.KeysAndAttributes{
+			tableName: {
+				Keys:           keys[start:end],
+				ConsistentRead: aws.Bool(db.ConsistentRead),
+			},
+		}
+		delay := batchGetRetryDelay
+		for attempt := 1; len(requestItems) > 0; attempt++ {
+			if attempt > batchGetMaxAttempts {
+				return nil, fmt.Errorf("failed to get %d items from %s: keys remained unprocessed after %d attempts",
+					len(requestItems[tableName].Keys), tableName, batchGetMaxAttempts)
+			}
+			if attempt > 1 {
+				time.Sleep(delay)
+				delay *= 2
+			}
+
+			res, err := db.Client.BatchGetItem(&dynamodb.BatchGetItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, res.Responses[tableName]...)
+			requestItems = res.UnprocessedKeys
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func accountItem(id string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"Id":            {S: aws.String(id)},
+		"AccountStatus": {S: aws.String("Ready")},
+	}
+}
+
+func TestGetAccountsByIDs(t *testing.T) {
+	batchGetRetryDelay = time.Millisecond
+	defer func() { batchGetRetryDelay = 50 * time.Millisecond }()
+
+	t.Run("should get accounts in chunks, in the order of their IDs", func(t *testing.T) {
+		ids := []string{}
+		for i := 0; i < 150; i++ {
+			ids = append(ids, fmt.Sprintf("%012d", i))
+		}
+		// Duplicates are only requested once
+		ids = append(ids, "000000000001")
+
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		requested := []int{}
+		mockDynamo.On("BatchGetItem", mock.Anything).
+			Return(func(input *dynamodb.BatchGetItemInput) *dynamodb.BatchGetItemOutput {
+				keys := input.RequestItems["Accounts"].Keys
+				requested = append(requested, len(keys))
+				items := []map[string]*dynamodb.AttributeValue{}
+				// Return the items in reverse, and leave out the missing account
+				for i := len(keys) - 1; i >= 0; i-- {
+					if *keys[i]["Id"].S != "000000000002" {
+						items = append(items, accountItem(*keys[i]["Id"].S))
+					}
+				}
+				return &dynamodb.BatchGetItemOutput{
+					Responses: map[string][]map[string]*dynamodb.AttributeValue{"Accounts": items},
+				}
+			}, nil)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		accounts, err := db.GetAccountsByIDs(ids)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{100, 50}, requested)
+		assert.Len(t, accounts, 149)
+		assert.Equal(t, "000000000000", accounts[0].ID)
+		assert.Equal(t, "000000000001", accounts[1].ID)
+		assert.Equal(t, "000000000003", accounts[2].ID)
+		assert.Equal(t, "000000000149", accounts[148].ID)
+	})
+
+	t.Run("should retry unprocessed keys", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+			return len(input.RequestItems["Accounts"].Keys) == 2
+		})).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{"Accounts": {accountItem("111111111111")}},
+			UnprocessedKeys: map[string]*dynamodb.KeysAndAttributes{
+				"Accounts": {Keys: []map[string]*dynamodb.AttributeValue{{"Id": {S: aws.String("222222222222")}}}},
+			},
+		}, nil).Once()
+		mockDynamo.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+			return len(input.RequestItems["Accounts"].Keys) == 1
+		})).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{"Accounts": {accountItem("222222222222")}},
+		}, nil).Once()
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		accounts, err := db.GetAccountsByIDs([]string{"222222222222", "111111111111"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "222222222222", accounts[0].ID)
+		assert.Equal(t, "111111111111", accounts[1].ID)
+		mockDynamo.AssertNumberOfCalls(t, "BatchGetItem", 2)
+	})
+
+	t.Run("should fail when keys remain unprocessed", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("BatchGetItem", mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+			UnprocessedKeys: map[string]*dynamodb.KeysAndAttributes{
+				"Accounts": {Keys: []map[string]*dynamodb.AttributeValue{{"Id": {S: aws.String("111111111111")}}}},
+			},
+		}, nil)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		_, err := db.GetAccountsByIDs([]string{"111111111111"})
+
+		assert.EqualError(t, err, "failed to get 1 items from Accounts: keys remained unprocessed after 5 attempts")
+		mockDynamo.AssertNumberOfCalls(t, "BatchGetItem", 5)
+	})
+
+	t.Run("should fail when DynamoDB fails", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("BatchGetItem", mock.Anything).Return(nil, fmt.Errorf("throttled"))
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		_, err := db.GetAccountsByIDs([]string{"111111111111"})
+
+		assert.EqualError(t, err, "throttled")
+	})
+
+	t.Run("should not call DynamoDB without IDs", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		accounts, err := db.GetAccountsByIDs([]string{})
+
+		assert.Nil(t, err)
+		assert.Empty(t, accounts)
+		mockDynamo.AssertNotCalled(t, "BatchGetItem", mock.Anything)
+	})
+}
+
+func TestGetLeasesByIDs(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+		keys := input.RequestItems["Leases"].Keys
+		return len(keys) == 2 &&
+			*keys[0]["AccountId"].S == "111111111111" && *keys[0]["PrincipalId"].S == "jdoe" &&
+			*keys[1]["AccountId"].S == "222222222222" && *keys[1]["PrincipalId"].S == "jane" &&
+			*input.RequestItems["Leases"].ConsistentRead
+	})).Return(&dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]*dynamodb.AttributeValue{
+			"Leases": {
+				{
+					"AccountId":   {S: aws.String("222222222222")},
+					"PrincipalId": {S: aws.String("jane")},
+					"Id":          {S: aws.String("lease-2")},
+				},
+				{
+					"AccountId":   {S: aws.String("111111111111")},
+					"PrincipalId": {S: aws.String("jdoe")},
+					"Id":          {S: aws.String("lease-1")},
+				},
+			},
+		},
+	}, nil)
+
+	db := DB{Client: mockDynamo, LeaseTableName: "Leases", ConsistentRead: true}
+	leases, err := db.GetLeasesByIDs([]LeaseKey{
+		{AccountID: "111111111111", PrincipalID: "jdoe"},
+		{AccountID: "222222222222", PrincipalID: "jane"},
+		{AccountID: "111111111111", PrincipalID: "jdoe"},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, leases, 2)
+	assert.Equal(t, "lease-1", leases[0].ID)
+	assert.Equal(t, "lease-2", leases[1].ID)
+}
@@ -13,9 +13,9 @@ import (
 	guuid "github.com/google/uuid"
 
 	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
@@ -39,6 +39,12 @@ type DB struct {
 	DefaultLeaseLengthInDays int
 	// Use Consistent Reads when scanning or querying when possible.
 	ConsistentRead bool
+	// Number of shards of the AccountStatusShard and LeaseStatusShard
+	// indexes. The unsharded AccountStatus and LeaseStatus indexes are
+	// used when there is only one.
+	StatusShards int
+	// Size in bytes above which the Metadata of records is compressed
+	CompressionThreshold int
 }
 
 // The DBer interface includes all methods used by the DB struct to interact with
@@ -50,9 +56,11 @@ type DBer interface {
 	GetLease(accountID string, principalID string) (*Lease, error)
 	GetLeases(input GetLeasesInput) (GetLeasesOutput, error)
 	GetLeaseByID(leaseID string) (*Lease, error)
+	GetAccountsByIDs(accountIDs []string) ([]*Account, error)
+	GetLeasesByIDs(leaseKeys []LeaseKey) ([]*Lease, error)
 	FindAccountsByStatus(status AccountStatus) ([]*Account, error)
-	PutAccount(account Account) error
-	PutLease(lease Lease) (*Lease, error)
+	PutAccount(account Account, prevLastModifiedOn *int64) error
+	PutLease(lease Lease, prevLastModifiedOn *int64) (*Lease, error)
 	UpsertLease(lease Lease) (*Lease, error)
 	TransitionAccountStatus(accountID string, prevStatus AccountStatus, nextStatus AccountStatus) (*Account, error)
 	TransitionLeaseStatus(accountID string, principalID string, prevStatus LeaseStatus, nextStatus LeaseStatus, leaseStatusReason LeaseStatusReason) (*Lease, error)
@@ -60,6 +68,14 @@ type DBer interface {
 	FindLeasesByPrincipal(principalID string) ([]*Lease, error)
 	FindLeasesByStatus(status LeaseStatus) ([]*Lease, error)
 	UpdateAccountPrincipalPolicyHash(accountID string, prevHash string, nextHash string) (*Account, error)
+	RecordAccountWarmUpReset(accountID string) (*Account, error)
+	RecordAccountBlackout(accountID string, blackout *AccountBlackout) (*Account, error)
+	RecordAccountReadyAt(accountID string, readyAt int64) (*Account, error)
+	RecordAccountResetLeaks(accountID string, leaks *AccountResetLeaks) (*Account, error)
+	RecordAccountRemediation(accountID string, status AccountStatus, remediation *AccountRemediation) (*Account, error)
+	RecordBudgetThresholdSent(accountID string, principalID string, budgetAmount float64, thresholdPercentile float64) (*Lease, error)
+	RecordCategoryThresholdSent(accountID string, principalID string, category string, amount float64, thresholdPercentile float64) (*Lease, error)
+	RecordLeaseBudgetCheck(accountID string, principalID string, checkedOn int64) (*Lease, error)
 	OrphanAccount(accountID string) (*Account, error)
 }
 
@@ -89,27 +105,24 @@ func (db *DB) GetAccount(accountID string) (*Account, error) {
 }
 
 // GetReadyAccount returns an available account record with a
-// corresponding status of 'Ready'
+// corresponding status of 'Ready', which isn't cooling down after its reset
 func (db *DB) GetReadyAccount() (*Account, error) {
 	accounts, err := db.FindAccountsByStatus(Ready)
-	if len(accounts) < 1 {
+	if err != nil {
 		return nil, err
 	}
-	return accounts[0], err
+	now := time.Now().Unix()
+	for _, acct := range accounts {
+		if !acct.IsCoolingDown(now) {
+			return acct, nil
+		}
+	}
+	return nil, nil
 }
 
 // FindAccountsByStatus finds account by status
 func (db *DB) FindAccountsByStatus(status AccountStatus) ([]*Account, error) {
-	res, err := db.Client.Query(&dynamodb.QueryInput{
-		TableName: aws.String(db.AccountTableName),
-		IndexName: aws.String("AccountStatus"),
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":status": {
-				S: aws.String(string(status)),
-			},
-		},
-		KeyConditionExpression: aws.String("AccountStatus = :status"),
-	})
+	items, err := db.queryStatus(db.AccountTableName, "AccountStatus", string(status))
 
 	accounts := []*Account{}
 
@@ -117,7 +130,7 @@ func (db *DB) FindAccountsByStatus(status AccountStatus) ([]*Account, error) {
 		return accounts, err
 	}
 
-	for _, item := range res.Items {
+	for _, item := range items {
 		acct, err := unmarshalAccount(item)
 		if err != nil {
 			return accounts, err
@@ -285,16 +298,7 @@ func (db *DB) FindLeasesByPrincipalAndAccount(principalID string, accountID stri
 
 // FindLeasesByStatus finds leases by status
 func (db *DB) FindLeasesByStatus(status LeaseStatus) ([]*Lease, error) {
-	res, err := db.Client.Query(&dynamodb.QueryInput{
-		IndexName: aws.String("LeaseStatus"),
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":status": {
-				S: aws.String(string(status)),
-			},
-		},
-		KeyConditionExpression: aws.String("LeaseStatus = :status"),
-		TableName:              aws.String(db.LeaseTableName),
-	})
+	items, err := db.queryStatus(db.LeaseTableName, "LeaseStatus", string(status))
 
 	leases := []*Lease{}
 
@@ -302,7 +306,7 @@ func (db *DB) FindLeasesByStatus(status LeaseStatus) ([]*Lease, error) {
 		return leases, err
 	}
 
-	for _, item := range res.Items {
+	for _, item := range items {
 		lease, err := unmarshalLease(item)
 		if err != nil {
 			return leases, err
@@ -313,26 +317,58 @@ func (db *DB) FindLeasesByStatus(status LeaseStatus) ([]*Lease, error) {
 	return leases, nil
 }
 
-// PutAccount stores an account in DynamoDB
-func (db *DB) PutAccount(account Account) error {
+// PutAccount stores an account in DynamoDB.  prevLastModifiedOn is the
+// LastModifiedOn of the account when it was read, or nil for a new account.
+// The account is only written over the Version it was read at, so a
+// concurrent write of the account isn't overwritten.  A StaleItemError is
+// returned when the account was modified since.
+func (db *DB) PutAccount(account Account, prevLastModifiedOn *int64) error {
+	condition := common.AccountItemSchema.VersionCondition(readVersion(account.Version), prevLastModifiedOn)
+	account.Version++
 	item, err := dynamodbattribute.MarshalMap(account)
 	if err != nil {
 		return err
 	}
+	err = common.CompressAttribute(item, "Metadata", db.CompressionThreshold)
+	if err != nil {
+		return err
+	}
+	if db.sharded() {
+		item["AccountStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(db.accountStatusShard(account.ID, account.AccountStatus)),
+		}
+	}
+	common.AccountItemSchema.Stamp(item)
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return err
+	}
 
 	_, err = db.Client.PutItem(
 		&dynamodb.PutItemInput{
-			TableName: aws.String(db.AccountTableName),
-			Item:      item,
+			TableName:                 aws.String(db.AccountTableName),
+			Item:                      item,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
 		},
 	)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ConditionalCheckFailedException" {
+		return &StaleItemError{
+			fmt.Sprintf("unable to put account %v: account has been modified since it was read", account.ID),
+		}
+	}
 	return err
 }
 
 // PutLease writes an Lease to DynamoDB
 // Returns the previous AccountsLease if there is one - does not return
 // the lease that was added
-func (db *DB) PutLease(lease Lease) (
+// prevLastModifiedOn is the LastModifiedOn of the lease when it was read, or
+// nil for a new lease.  The lease is only written over the Version it was
+// read at, and a StaleItemError is returned when the lease was modified
+// since.
+func (db *DB) PutLease(lease Lease, prevLastModifiedOn *int64) (
 	*Lease, error) {
 
 	// apply some reasonable DEFAULTS to the lease before saving it.
@@ -344,23 +380,56 @@ func (db *DB) PutLease(lease Lease) (
 		lease.ExpiresOn = time.Now().AddDate(0, 0, db.DefaultLeaseLengthInDays).Unix()
 	}
 
+	condition := common.LeaseItemSchema.VersionCondition(readVersion(lease.Version), prevLastModifiedOn)
+	lease.Version++
 	item, err := dynamodbattribute.MarshalMap(lease)
 	if err != nil {
 		return nil, err
 	}
+	err = common.CompressAttribute(item, "Metadata", db.CompressionThreshold)
+	if err != nil {
+		return nil, err
+	}
+	if db.sharded() {
+		item["LeaseStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(db.leaseStatusShard(lease.AccountID, lease.PrincipalID, lease.LeaseStatus)),
+		}
+	}
+	common.LeaseItemSchema.Stamp(item)
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
 
 	result, err := db.Client.PutItem(
 		&dynamodb.PutItemInput{
-			TableName: aws.String(db.LeaseTableName),
-			Item:      item,
+			TableName:                 aws.String(db.LeaseTableName),
+			Item:                      item,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
 		},
 	)
 	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ConditionalCheckFailedException" {
+			return nil, &StaleItemError{
+				fmt.Sprintf("unable to put lease %v/%v: lease has been modified since it was read", lease.AccountID, lease.PrincipalID),
+			}
+		}
 		return nil, err
 	}
 	return unmarshalLease(result.Attributes)
 }
 
+// readVersion is the Version an item was read at, which is nil for items
+// which haven't been written since writes were counted
+func readVersion(version int64) *int64 {
+	if version == 0 {
+		return nil
+	}
+	return &version
+}
+
 // UpsertLease creates or updates the lease records in DynDB
 func (db *DB) UpsertLease(lease Lease) (*Lease, error) {
 	// Some basic validation of the lease
@@ -376,10 +445,17 @@ func (db *DB) UpsertLease(lease Lease) (*Lease, error) {
 	}
 
 	// Build an update expression for the lease
-	expr, err := buildUpdateExpression(&buildUpdateExpressInput{
-		obj:           lease,
-		excludeFields: []string{"AccountID", "PrincipalID"},
-	})
+	exprInput := &buildUpdateExpressInput{
+		obj:              lease,
+		excludeFields:    []string{"AccountID", "PrincipalID", "Version"},
+		incrementVersion: true,
+	}
+	if db.sharded() {
+		exprInput.setAttributes = map[string]interface{}{
+			"LeaseStatusShard": db.leaseStatusShard(lease.AccountID, lease.PrincipalID, lease.LeaseStatus),
+		}
+	}
+	expr, err := buildUpdateExpression(exprInput)
 	if err != nil {
 		return nil, errors2.Wrapf(err, "Failed to update lease %s/%s",
 			lease.PrincipalID, lease.AccountID)
@@ -416,54 +492,65 @@ func (db *DB) UpsertLease(lease Lease) (*Lease, error) {
 }
 
 // TransitionLeaseStatus updates a lease's status from prevStatus to nextStatus.
-// Will fail if the Lease was not previously set to `prevStatus`
-//
-// For example, to set a ResetLock on an account, you could call:
-//		db.TransitionLeaseStatus(accountId, principalID, Active, ResetLock)
+// Will fail if the Lease was not previously set to `prevStatus`, or if the
+// transition isn't legal, as defined in the model package.
 //
-// And to unlock the account:
-//		db.TransitionLeaseStatus(accountId, principalID, ResetLock, Active)
+// For example, to end an expired lease, you could call:
+//		db.TransitionLeaseStatus(accountId, principalID, Active, Inactive, LeaseExpired)
 func (db *DB) TransitionLeaseStatus(accountID string, principalID string, prevStatus LeaseStatus, nextStatus LeaseStatus, leaseStatusReason LeaseStatusReason) (*Lease, error) {
-	result, err := db.Client.UpdateItem(
-		&dynamodb.UpdateItemInput{
-			// Query in Lease Table
-			TableName: aws.String(db.LeaseTableName),
-			// Find Lease for the requested accountId
-			Key: map[string]*dynamodb.AttributeValue{
-				"AccountId": {
-					S: aws.String(accountID),
-				},
-				"PrincipalId": {
-					S: aws.String(principalID),
-				},
+	err := model.LeaseStatus.Validate(string(prevStatus), string(nextStatus))
+	if err != nil {
+		return nil, &StatusTransitionError{
+			fmt.Sprintf("unable to update lease status for %v/%v: %s", accountID, principalID, err),
+		}
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		// Query in Lease Table
+		TableName: aws.String(db.LeaseTableName),
+		// Find Lease for the requested accountId
+		Key: map[string]*dynamodb.AttributeValue{
+			"AccountId": {
+				S: aws.String(accountID),
 			},
-			// Set Status="Active"
-			UpdateExpression: aws.String("set LeaseStatus=:nextStatus, " +
-				"LeaseStatusReason=:nextStatusReason, " +
-				"LastModifiedOn=:lastModifiedOn, " + "LeaseStatusModifiedOn=:leaseStatusModifiedOn"),
-			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-				":prevStatus": {
-					S: aws.String(string(prevStatus)),
-				},
-				":nextStatus": {
-					S: aws.String(string(nextStatus)),
-				},
-				":nextStatusReason": {
-					S: aws.String(string(leaseStatusReason)),
-				},
-				":lastModifiedOn": {
-					N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
-				},
-				":leaseStatusModifiedOn": {
-					N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
-				},
+			"PrincipalId": {
+				S: aws.String(principalID),
 			},
-			// Only update locked records
-			ConditionExpression: aws.String("LeaseStatus = :prevStatus"),
-			// Return the updated record
-			ReturnValues: aws.String("ALL_NEW"),
 		},
-	)
+		// Set Status="Active"
+		UpdateExpression: aws.String("set LeaseStatus=:nextStatus, " +
+			"LeaseStatusReason=:nextStatusReason, " +
+			"LastModifiedOn=:lastModifiedOn, " + "LeaseStatusModifiedOn=:leaseStatusModifiedOn"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prevStatus": {
+				S: aws.String(string(prevStatus)),
+			},
+			":nextStatus": {
+				S: aws.String(string(nextStatus)),
+			},
+			":nextStatusReason": {
+				S: aws.String(string(leaseStatusReason)),
+			},
+			":lastModifiedOn": {
+				N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
+			},
+			":leaseStatusModifiedOn": {
+				N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
+			},
+		},
+		// Only update locked records
+		ConditionExpression: aws.String("LeaseStatus = :prevStatus"),
+		// Return the updated record
+		ReturnValues: aws.String("ALL_NEW"),
+	}
+	if db.sharded() {
+		input.UpdateExpression = aws.String(*input.UpdateExpression + ", LeaseStatusShard=:nextStatusShard")
+		input.ExpressionAttributeValues[":nextStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(db.leaseStatusShard(accountID, principalID, nextStatus)),
+		}
+	}
+	common.IncrementVersionClause(&input.UpdateExpression, &input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	result, err := db.Client.UpdateItem(input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			if aerr.Code() == "ConditionalCheckFailedException" {
@@ -486,38 +573,69 @@ func (db *DB) TransitionLeaseStatus(accountID string, principalID string, prevSt
 }
 
 // TransitionAccountStatus updates account status for a given accountID and
-// returns the updated record on success
+// returns the updated record on success.
+// Will fail if the transition isn't legal, as defined in the model package.
 func (db *DB) TransitionAccountStatus(accountID string, prevStatus AccountStatus, nextStatus AccountStatus) (*Account, error) {
-	result, err := db.Client.UpdateItem(
-		&dynamodb.UpdateItemInput{
-			// Query in Lease Table
-			TableName: aws.String(db.AccountTableName),
-			// Find Account for the requested accountId
-			Key: map[string]*dynamodb.AttributeValue{
-				"Id": {
-					S: aws.String(accountID),
-				},
+	err := model.AccountStatus.Validate(string(prevStatus), string(nextStatus))
+	if err != nil {
+		return nil, &StatusTransitionError{
+			fmt.Sprintf("unable to update account status for account %v: %s", accountID, err),
+		}
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		// Query in Lease Table
+		TableName: aws.String(db.AccountTableName),
+		// Find Account for the requested accountId
+		Key: map[string]*dynamodb.AttributeValue{
+			"Id": {
+				S: aws.String(accountID),
 			},
-			// Set Status=nextStatus ("READY")
-			UpdateExpression: aws.String("set AccountStatus=:nextStatus, " +
-				"LastModifiedOn=:lastModifiedOn"),
-			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-				":prevStatus": {
-					S: aws.String(string(prevStatus)),
-				},
-				":nextStatus": {
-					S: aws.String(string(nextStatus)),
-				},
-				":lastModifiedOn": {
-					N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
-				},
+		},
+		// Set Status=nextStatus ("READY")
+		UpdateExpression: aws.String("set AccountStatus=:nextStatus, " +
+			"LastModifiedOn=:lastModifiedOn"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prevStatus": {
+				S: aws.String(string(prevStatus)),
+			},
+			":nextStatus": {
+				S: aws.String(string(nextStatus)),
+			},
+			":lastModifiedOn": {
+				N: aws.String(strconv.FormatInt(time.Now().Unix(), 10)),
 			},
-			// Only update locked records
-			ConditionExpression: aws.String("AccountStatus = :prevStatus"),
-			// Return the updated record
-			ReturnValues: aws.String("ALL_NEW"),
 		},
-	)
+		// Only update locked records
+		ConditionExpression: aws.String("AccountStatus = :prevStatus"),
+		// Return the updated record
+		ReturnValues: aws.String("ALL_NEW"),
+	}
+	// Accounts which are warming up become Ready when their warm-up
+	// succeeds, rather than after their first reset
+	if nextStatus == Ready {
+		input.ConditionExpression = aws.String(*input.ConditionExpression +
+			" AND (attribute_not_exists(WarmUp) OR WarmUp.#warmUpStatus = :warmUpSucceeded)")
+		input.ExpressionAttributeNames = map[string]*string{
+			"#warmUpStatus": aws.String("Status"),
+		}
+		input.ExpressionAttributeValues[":warmUpSucceeded"] = &dynamodb.AttributeValue{
+			S: aws.String(WarmUpSucceeded),
+		}
+	}
+	if db.sharded() {
+		input.UpdateExpression = aws.String(*input.UpdateExpression + ", AccountStatusShard=:nextStatusShard")
+		input.ExpressionAttributeValues[":nextStatusShard"] = &dynamodb.AttributeValue{
+			S: aws.String(db.accountStatusShard(accountID, nextStatus)),
+		}
+	}
+	// Accounts which become Ready are no longer stuck, so the remediation of
+	// an earlier time they were stuck doesn't count against them
+	if nextStatus == Ready {
+		input.UpdateExpression = aws.String(*input.UpdateExpression + " REMOVE Remediation")
+	}
+	common.IncrementVersionClause(&input.UpdateExpression, &input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	result, err := db.Client.UpdateItem(input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			if aerr.Code() == "ConditionalCheckFailedException" {
@@ -553,7 +671,7 @@ func (db *DB) UpdateAccountPrincipalPolicyHash(accountID string, prevHash string
 	}
 	updateExpression, _ := expression.NewBuilder().WithCondition(
 		conditionExpression,
-	).WithUpdate(
+	).WithUpdate(common.IncrementVersion(
 		expression.Set(
 			expression.Name("PrincipalPolicyHash"),
 			expression.Value(nextHash),
@@ -561,7 +679,7 @@ func (db *DB) UpdateAccountPrincipalPolicyHash(accountID string, prevHash string
 			expression.Name("LastModifiedOn"),
 			expression.Value(time.Now().Unix()),
 		),
-	).Build()
+	)).Build()
 
 	result, err := db.Client.UpdateItem(
 		&dynamodb.UpdateItemInput{
@@ -605,6 +723,333 @@ func (db *DB) UpdateAccountPrincipalPolicyHash(accountID string, prevHash string
 	return unmarshalAccount(result.Attributes)
 }
 
+// RecordAccountWarmUpReset records that the first reset of an account which
+// is warming up finished, so its warm-up can continue.  LastModifiedOn and
+// Version are updated too, so concurrent writes of the warm-up can't
+// overwrite the reset.
+func (db *DB) RecordAccountWarmUpReset(accountID string) (*Account, error) {
+	now := time.Now().Unix()
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.AttributeExists(expression.Name("WarmUp")),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("WarmUp.ResetCompletedOn"),
+			expression.Value(now),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(now),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {
+					S: aws.String(accountID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAccount(result.Attributes)
+}
+
+// RecordAccountBlackout records the activity found while a NotReady account
+// was reset, replacing any earlier blackout.  LastModifiedOn and Version are
+// updated too, so the blackout can't be overwritten by a concurrent write of
+// the account.
+func (db *DB) RecordAccountBlackout(accountID string, blackout *AccountBlackout) (*Account, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name("AccountStatus").Equal(expression.Value(string(NotReady))),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("Blackout"),
+			expression.Value(blackout),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {
+					S: aws.String(accountID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAccount(result.Attributes)
+}
+
+// RecordAccountReadyAt records when a NotReady account which was reset can
+// be leased, after its cooldown.  LastModifiedOn and Version are updated too,
+// so the cooldown can't be overwritten by a concurrent write of the account.
+func (db *DB) RecordAccountReadyAt(accountID string, readyAt int64) (*Account, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name("AccountStatus").Equal(expression.Value(string(NotReady))),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("ReadyAt"),
+			expression.Value(readyAt),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {
+					S: aws.String(accountID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAccount(result.Attributes)
+}
+
+// RecordAccountResetLeaks records the resources which survived the reset of
+// a NotReady account, replacing those of its last reset.  LastModifiedOn and
+// Version are updated too, so the leaks can't be overwritten by a concurrent
+// write of the account.
+func (db *DB) RecordAccountResetLeaks(accountID string, leaks *AccountResetLeaks) (*Account, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name("AccountStatus").Equal(expression.Value(string(NotReady))),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("ResetLeaks"),
+			expression.Value(leaks),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {
+					S: aws.String(accountID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAccount(result.Attributes)
+}
+
+// RecordAccountRemediation records the remediation of an account which was
+// stuck in the status.  The account must still have the status, so an
+// account which became Ready in the meantime isn't quarantined.
+func (db *DB) RecordAccountRemediation(accountID string, status AccountStatus, remediation *AccountRemediation) (*Account, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name("AccountStatus").Equal(expression.Value(string(status))),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("Remediation"),
+			expression.Value(remediation),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AccountTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {
+					S: aws.String(accountID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAccount(result.Attributes)
+}
+
+// RecordBudgetThresholdSent records that the budget notification for the
+// threshold was sent, so it isn't sent again.  The budget is checked, so a
+// threshold of a budget which was changed since the notification isn't
+// recorded.
+func (db *DB) RecordBudgetThresholdSent(accountID string, principalID string, budgetAmount float64, thresholdPercentile float64) (*Lease, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name("BudgetAmount").Equal(expression.Value(budgetAmount)),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("BudgetThresholdsSent"),
+			expression.ListAppend(
+				expression.IfNotExists(expression.Name("BudgetThresholdsSent"), expression.Value([]float64{})),
+				expression.Value([]float64{thresholdPercentile}),
+			),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.LeaseTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"AccountId": {
+					S: aws.String(accountID),
+				},
+				"PrincipalId": {
+					S: aws.String(principalID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLease(result.Attributes)
+}
+
+// RecordCategoryThresholdSent records that the notification for the
+// threshold of a category budget was sent, so it isn't sent again.  The
+// category budget is checked, so a threshold of a budget which was changed
+// since the notification isn't recorded.
+func (db *DB) RecordCategoryThresholdSent(accountID string, principalID string, category string, amount float64, thresholdPercentile float64) (*Lease, error) {
+	// Each part of the path is substituted, so the category name is used as
+	// it is
+	categoryBudget := "CategoryBudgets." + category
+	thresholdsSent := expression.Name(categoryBudget + ".ThresholdsSent")
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.Name(categoryBudget + ".Amount").Equal(expression.Value(amount)),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			thresholdsSent,
+			expression.ListAppend(
+				expression.IfNotExists(thresholdsSent, expression.Value([]float64{})),
+				expression.Value([]float64{thresholdPercentile}),
+			),
+		).Set(
+			expression.Name("LastModifiedOn"),
+			expression.Value(time.Now().Unix()),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.LeaseTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"AccountId": {
+					S: aws.String(accountID),
+				},
+				"PrincipalId": {
+					S: aws.String(principalID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLease(result.Attributes)
+}
+
+// RecordLeaseBudgetCheck records when the budget of the lease was last
+// checked, so the lease isn't checked again until it's due.  The lease isn't
+// otherwise modified, so LastModifiedOn is left as it is, but its Version goes
+// up, so the check can't be overwritten by a concurrent write of the lease.
+func (db *DB) RecordLeaseBudgetCheck(accountID string, principalID string, checkedOn int64) (*Lease, error) {
+	updateExpression, _ := expression.NewBuilder().WithCondition(
+		expression.AttributeExists(expression.Name("AccountId")),
+	).WithUpdate(common.IncrementVersion(
+		expression.Set(
+			expression.Name("LastCheckedOn"),
+			expression.Value(checkedOn),
+		),
+	)).Build()
+
+	result, err := db.Client.UpdateItem(
+		&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.LeaseTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"AccountId": {
+					S: aws.String(accountID),
+				},
+				"PrincipalId": {
+					S: aws.String(principalID),
+				},
+			},
+			ExpressionAttributeNames:  updateExpression.Names(),
+			ExpressionAttributeValues: updateExpression.Values(),
+			UpdateExpression:          updateExpression.Update(),
+			ConditionExpression:       updateExpression.Condition(),
+			ReturnValues:              aws.String("ALL_NEW"),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLease(result.Attributes)
+}
+
 // GetLeasesInput contains the filtering criteria for the GetLeases scan.
 type GetLeasesInput struct {
 	StartKeys   map[string]string
@@ -728,7 +1173,14 @@ func (db *DB) OrphanAccount(accountID string) (*Account, error) {
 
 func unmarshalAccount(dbResult map[string]*dynamodb.AttributeValue) (*Account, error) {
 	account := Account{}
-	err := dynamodbattribute.UnmarshalMap(dbResult, &account)
+	err := common.DecompressAttributes(dbResult)
+	if err == nil {
+		err = common.AccountItemSchema.Decode(dbResult)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = dynamodbattribute.UnmarshalMap(dbResult, &account)
 
 	if err != nil {
 		return nil, err
@@ -739,7 +1191,14 @@ func unmarshalAccount(dbResult map[string]*dynamodb.AttributeValue) (*Account, e
 
 func unmarshalLease(dbResult map[string]*dynamodb.AttributeValue) (*Lease, error) {
 	lease := Lease{}
-	err := dynamodbattribute.UnmarshalMap(dbResult, &lease)
+	err := common.DecompressAttributes(dbResult)
+	if err == nil {
+		err = common.LeaseItemSchema.Decode(dbResult)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = dynamodbattribute.UnmarshalMap(dbResult, &lease)
 	if err != nil {
 		return nil, err
 	}
@@ -762,6 +1221,8 @@ func New(client *dynamodb.DynamoDB, accountTableName string, leaseTableName stri
 		LeaseTableName:           leaseTableName,
 		DefaultLeaseLengthInDays: defaultLeaseLengthInDays,
 		ConsistentRead:           false,
+		StatusShards:             1,
+		CompressionThreshold:     common.DefaultCompressionThreshold,
 	}
 }
 
@@ -774,19 +1235,19 @@ Requires env vars for:
 - LEASE_DB
 */
 func NewFromEnv() (*DB, error) {
-	awsSession, err := session.NewSession()
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
 	if err != nil {
 		return nil, err
 	}
-	return New(
-		dynamodb.New(
-			awsSession,
-			aws.NewConfig().WithRegion(common.RequireEnv("AWS_CURRENT_REGION")),
-		),
+	db := New(
+		dynamodb.New(awsSession, common.EndpointConfig("DynamoDB")),
 		common.RequireEnv("ACCOUNT_DB"),
 		common.RequireEnv("LEASE_DB"),
 		common.GetEnvInt("DEFAULT_LEASE_LENGTH_IN_DAYS", 7),
-	), nil
+	)
+	db.StatusShards = common.GetEnvInt("STATUS_SHARD_COUNT", 1)
+	db.CompressionThreshold = common.GetEnvInt("METADATA_COMPRESSION_THRESHOLD", common.DefaultCompressionThreshold)
+	return db, nil
 }
 
 type buildUpdateExpressInput struct {
@@ -798,6 +1259,10 @@ type buildUpdateExpressInput struct {
 	// Fields to include in expression
 	// (may not be used together with `excludeFields`)
 	includeFields []string
+	// Attributes to set which aren't fields of the object
+	setAttributes map[string]interface{}
+	// Add one to the Version of the item
+	incrementVersion bool
 }
 
 // buildUpdateExpression builds a DynDB update express
@@ -838,6 +1303,17 @@ func buildUpdateExpression(input *buildUpdateExpressInput) (*expression.Expressi
 		)
 	}
 
+	for name, value := range input.setAttributes {
+		updateBuilder = updateBuilder.Set(
+			expression.Name(name),
+			expression.Value(value),
+		)
+	}
+
+	if input.incrementVersion {
+		updateBuilder = common.IncrementVersion(updateBuilder)
+	}
+
 	// Compile the expression
 	expr, err := expression.NewBuilder().
 		WithUpdate(updateBuilder).
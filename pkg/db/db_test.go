@@ -2,10 +2,12 @@ package db
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -344,3 +346,96 @@ func TestGetLeaseByID(t *testing.T) {
 		})
 	}
 }
+
+func TestTransitionStatusIllegal(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	db := DB{Client: mockDynamo, AccountTableName: "Accounts", LeaseTableName: "Leases"}
+
+	_, err := db.TransitionAccountStatus("123456789012", NotReady, Leased)
+	assert.IsType(t, &StatusTransitionError{}, err)
+	assert.Equal(t, "unable to update account status for account 123456789012: "+
+		"account status can't transition from \"NotReady\" to \"Leased\"", err.Error())
+
+	_, err = db.TransitionLeaseStatus("123456789012", "jdoe", Inactive, Active, LeaseActive)
+	assert.IsType(t, &StatusTransitionError{}, err)
+
+	mockDynamo.AssertNotCalled(t, "UpdateItem", mock.Anything)
+}
+
+func TestPutAccountVersion(t *testing.T) {
+	t.Run("new accounts must not exist", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		err := db.PutAccount(Account{ID: "123456789012", AccountStatus: Ready, LastModifiedOn: 1561518000}, nil)
+		assert.Nil(t, err)
+
+		mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return *input.ConditionExpression == "attribute_not_exists (#0)" &&
+				*input.ExpressionAttributeNames["#0"] == "LastModifiedOn"
+		}))
+	})
+
+	t.Run("accounts are written over the version they were read at", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		err := db.PutAccount(Account{ID: "123456789012", AccountStatus: Ready, LastModifiedOn: 1561518000, Version: 3}, aws.Int64(1561500000))
+		assert.Nil(t, err)
+
+		mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return strings.HasPrefix(*input.ConditionExpression, "(#0 = :0) AND (") &&
+				*input.ExpressionAttributeNames["#0"] == "Version" &&
+				*input.ExpressionAttributeValues[":0"].N == "3" &&
+				*input.Item["Version"].N == "4"
+		}))
+	})
+
+	t.Run("accounts read without a version are written when not modified since they were read", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		err := db.PutAccount(Account{ID: "123456789012", AccountStatus: Ready, LastModifiedOn: 1561518000}, aws.Int64(1561500000))
+		assert.Nil(t, err)
+
+		mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return strings.HasPrefix(*input.ConditionExpression, "((attribute_not_exists (#0)) AND (#1 = :0)) AND") &&
+				*input.ExpressionAttributeNames["#0"] == "Version" &&
+				*input.ExpressionAttributeNames["#1"] == "LastModifiedOn" &&
+				*input.ExpressionAttributeValues[":0"].N == "1561500000" &&
+				*input.Item["Version"].N == "1"
+		}))
+	})
+
+	t.Run("accounts modified since they were read are stale", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.Anything).Return(
+			nil, awserr.New("ConditionalCheckFailedException", "The conditional request failed", nil),
+		)
+
+		db := DB{Client: mockDynamo, AccountTableName: "Accounts"}
+		err := db.PutAccount(Account{ID: "123456789012", AccountStatus: Ready, LastModifiedOn: 1561518000}, aws.Int64(1561500000))
+		assert.IsType(t, &StaleItemError{}, err)
+		assert.Equal(t, "unable to put account 123456789012: account has been modified since it was read", err.Error())
+	})
+}
+
+func TestPutLeaseStale(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.Anything).Return(
+		nil, awserr.New("ConditionalCheckFailedException", "The conditional request failed", nil),
+	)
+
+	db := DB{Client: mockDynamo, LeaseTableName: "Leases"}
+	_, err := db.PutLease(Lease{ID: "abc", AccountID: "123456789012", PrincipalID: "jdoe", LastModifiedOn: 1561518000}, aws.Int64(1561500000))
+	assert.IsType(t, &StaleItemError{}, err)
+	assert.Equal(t, "unable to put lease 123456789012/jdoe: lease has been modified since it was read", err.Error())
+
+	mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "Leases" &&
+			*input.ExpressionAttributeValues[":0"].N == "1561500000"
+	}))
+}
@@ -11,6 +11,16 @@ func (e *StatusTransitionError) Error() string {
 	return e.err
 }
 
+// StaleItemError is returned when an item was modified since it was read, so
+// writing it would overwrite the other change
+type StaleItemError struct {
+	err string
+}
+
+func (e *StaleItemError) Error() string {
+	return e.err
+}
+
 // AccountLeasedError is returned when a consumer attempts to delete an account that is currently at status Leased
 type AccountLeasedError struct {
 	err string
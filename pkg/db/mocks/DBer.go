@@ -125,6 +125,29 @@ func (_m *DBer) GetAccount(accountID string) (*db.Account, error) {
 	return r0, r1
 }
 
+// GetAccountsByIDs provides a mock function with given fields: accountIDs
+func (_m *DBer) GetAccountsByIDs(accountIDs []string) ([]*db.Account, error) {
+	ret := _m.Called(accountIDs)
+
+	var r0 []*db.Account
+	if rf, ok := ret.Get(0).(func([]string) []*db.Account); ok {
+		r0 = rf(accountIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(accountIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLease provides a mock function with given fields: accountID, principalID
 func (_m *DBer) GetLease(accountID string, principalID string) (*db.Lease, error) {
 	ret := _m.Called(accountID, principalID)
@@ -192,6 +215,29 @@ func (_m *DBer) GetLeases(input db.GetLeasesInput) (db.GetLeasesOutput, error) {
 	return r0, r1
 }
 
+// GetLeasesByIDs provides a mock function with given fields: leaseKeys
+func (_m *DBer) GetLeasesByIDs(leaseKeys []db.LeaseKey) ([]*db.Lease, error) {
+	ret := _m.Called(leaseKeys)
+
+	var r0 []*db.Lease
+	if rf, ok := ret.Get(0).(func([]db.LeaseKey) []*db.Lease); ok {
+		r0 = rf(leaseKeys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]db.LeaseKey) error); ok {
+		r1 = rf(leaseKeys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReadyAccount provides a mock function with given fields:
 func (_m *DBer) GetReadyAccount() (*db.Account, error) {
 	ret := _m.Called()
@@ -238,13 +284,13 @@ func (_m *DBer) OrphanAccount(accountID string) (*db.Account, error) {
 	return r0, r1
 }
 
-// PutAccount provides a mock function with given fields: account
-func (_m *DBer) PutAccount(account db.Account) error {
-	ret := _m.Called(account)
+// PutAccount provides a mock function with given fields: account, prevLastModifiedOn
+func (_m *DBer) PutAccount(account db.Account, prevLastModifiedOn *int64) error {
+	ret := _m.Called(account, prevLastModifiedOn)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(db.Account) error); ok {
-		r0 = rf(account)
+	if rf, ok := ret.Get(0).(func(db.Account, *int64) error); ok {
+		r0 = rf(account, prevLastModifiedOn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -252,13 +298,13 @@ func (_m *DBer) PutAccount(account db.Account) error {
 	return r0
 }
 
-// PutLease provides a mock function with given fields: lease
-func (_m *DBer) PutLease(lease db.Lease) (*db.Lease, error) {
-	ret := _m.Called(lease)
+// PutLease provides a mock function with given fields: lease, prevLastModifiedOn
+func (_m *DBer) PutLease(lease db.Lease, prevLastModifiedOn *int64) (*db.Lease, error) {
+	ret := _m.Called(lease, prevLastModifiedOn)
 
 	var r0 *db.Lease
-	if rf, ok := ret.Get(0).(func(db.Lease) *db.Lease); ok {
-		r0 = rf(lease)
+	if rf, ok := ret.Get(0).(func(db.Lease, *int64) *db.Lease); ok {
+		r0 = rf(lease, prevLastModifiedOn)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*db.Lease)
@@ -266,8 +312,192 @@ func (_m *DBer) PutLease(lease db.Lease) (*db.Lease, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(db.Lease) error); ok {
-		r1 = rf(lease)
+	if rf, ok := ret.Get(1).(func(db.Lease, *int64) error); ok {
+		r1 = rf(lease, prevLastModifiedOn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAccountBlackout provides a mock function with given fields: accountID, blackout
+func (_m *DBer) RecordAccountBlackout(accountID string, blackout *db.AccountBlackout) (*db.Account, error) {
+	ret := _m.Called(accountID, blackout)
+
+	var r0 *db.Account
+	if rf, ok := ret.Get(0).(func(string, *db.AccountBlackout) *db.Account); ok {
+		r0 = rf(accountID, blackout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *db.AccountBlackout) error); ok {
+		r1 = rf(accountID, blackout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAccountReadyAt provides a mock function with given fields: accountID, readyAt
+func (_m *DBer) RecordAccountReadyAt(accountID string, readyAt int64) (*db.Account, error) {
+	ret := _m.Called(accountID, readyAt)
+
+	var r0 *db.Account
+	if rf, ok := ret.Get(0).(func(string, int64) *db.Account); ok {
+		r0 = rf(accountID, readyAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(accountID, readyAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAccountRemediation provides a mock function with given fields: accountID, status, remediation
+func (_m *DBer) RecordAccountRemediation(accountID string, status db.AccountStatus, remediation *db.AccountRemediation) (*db.Account, error) {
+	ret := _m.Called(accountID, status, remediation)
+
+	var r0 *db.Account
+	if rf, ok := ret.Get(0).(func(string, db.AccountStatus, *db.AccountRemediation) *db.Account); ok {
+		r0 = rf(accountID, status, remediation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, db.AccountStatus, *db.AccountRemediation) error); ok {
+		r1 = rf(accountID, status, remediation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAccountResetLeaks provides a mock function with given fields: accountID, leaks
+func (_m *DBer) RecordAccountResetLeaks(accountID string, leaks *db.AccountResetLeaks) (*db.Account, error) {
+	ret := _m.Called(accountID, leaks)
+
+	var r0 *db.Account
+	if rf, ok := ret.Get(0).(func(string, *db.AccountResetLeaks) *db.Account); ok {
+		r0 = rf(accountID, leaks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *db.AccountResetLeaks) error); ok {
+		r1 = rf(accountID, leaks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAccountWarmUpReset provides a mock function with given fields: accountID
+func (_m *DBer) RecordAccountWarmUpReset(accountID string) (*db.Account, error) {
+	ret := _m.Called(accountID)
+
+	var r0 *db.Account
+	if rf, ok := ret.Get(0).(func(string) *db.Account); ok {
+		r0 = rf(accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordBudgetThresholdSent provides a mock function with given fields: accountID, principalID, budgetAmount, thresholdPercentile
+func (_m *DBer) RecordBudgetThresholdSent(accountID string, principalID string, budgetAmount float64, thresholdPercentile float64) (*db.Lease, error) {
+	ret := _m.Called(accountID, principalID, budgetAmount, thresholdPercentile)
+
+	var r0 *db.Lease
+	if rf, ok := ret.Get(0).(func(string, string, float64, float64) *db.Lease); ok {
+		r0 = rf(accountID, principalID, budgetAmount, thresholdPercentile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, float64, float64) error); ok {
+		r1 = rf(accountID, principalID, budgetAmount, thresholdPercentile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordCategoryThresholdSent provides a mock function with given fields: accountID, principalID, category, amount, thresholdPercentile
+func (_m *DBer) RecordCategoryThresholdSent(accountID string, principalID string, category string, amount float64, thresholdPercentile float64) (*db.Lease, error) {
+	ret := _m.Called(accountID, principalID, category, amount, thresholdPercentile)
+
+	var r0 *db.Lease
+	if rf, ok := ret.Get(0).(func(string, string, string, float64, float64) *db.Lease); ok {
+		r0 = rf(accountID, principalID, category, amount, thresholdPercentile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, float64, float64) error); ok {
+		r1 = rf(accountID, principalID, category, amount, thresholdPercentile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordLeaseBudgetCheck provides a mock function with given fields: accountID, principalID, checkedOn
+func (_m *DBer) RecordLeaseBudgetCheck(accountID string, principalID string, checkedOn int64) (*db.Lease, error) {
+	ret := _m.Called(accountID, principalID, checkedOn)
+
+	var r0 *db.Lease
+	if rf, ok := ret.Get(0).(func(string, string, int64) *db.Lease); ok {
+		r0 = rf(accountID, principalID, checkedOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(accountID, principalID, checkedOn)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -7,14 +7,112 @@ import (
 
 // Account is a type corresponding to a Account table record
 type Account struct {
-	ID                  string                 `json:"Id"`             // AWS Account ID
-	AccountStatus       AccountStatus          `json:"AccountStatus"`  // Status of the AWS Account
-	LastModifiedOn      int64                  `json:"LastModifiedOn"` // Last Modified Epoch Timestamp
+	ID                  string                 `json:"Id"`                // AWS Account ID
+	AccountStatus       AccountStatus          `json:"AccountStatus"`     // Status of the AWS Account
+	LastModifiedOn      int64                  `json:"LastModifiedOn"`    // Last Modified Epoch Timestamp
+	Version             int64                  `json:"Version,omitempty"` // Number of writes of the account, which PutAccount is conditional on
 	CreatedOn           int64                  `json:"CreatedOn"`
 	AdminRoleArn        string                 `json:"AdminRoleArn"`        // Assumed by the master account, to manage this user account
 	PrincipalRoleArn    string                 `json:"PrincipalRoleArn"`    // Assumed by principal users
 	PrincipalPolicyHash string                 `json:"PrincipalPolicyHash"` // The the hash of the policy version deployed
 	Metadata            map[string]interface{} `json:"Metadata"`            // Any org specific metadata pertaining to the account
+	// WarmUp is the progress of the steps run on a new account before it
+	// first becomes Ready
+	WarmUp *AccountWarmUp `json:"WarmUp,omitempty"`
+	// Blackout is the activity found by principals outside DCE while the
+	// account was reset
+	Blackout *AccountBlackout `json:"Blackout,omitempty"`
+	// ReadyAt is when the account can be leased, after its cooldown following
+	// a reset
+	ReadyAt *int64 `json:"ReadyAt,omitempty"`
+	// ResetLeaks are the resources which survived the account's last resets
+	ResetLeaks *AccountResetLeaks `json:"ResetLeaks,omitempty"`
+	// Remediation is the retry of an account which was stuck NotReady or
+	// Unreachable, until it becomes Ready again
+	Remediation *AccountRemediation `json:"Remediation,omitempty"`
+	// Contact owns the account, and is sent its reset failures and health
+	// alerts
+	Contact *AccountContact `json:"Contact,omitempty"`
+}
+
+// AccountContact is the owner of an account
+type AccountContact struct {
+	Email             string `json:"Email,omitempty"`
+	Team              string `json:"Team,omitempty"`
+	EscalationChannel string `json:"EscalationChannel,omitempty"`
+}
+
+// AccountWarmUp is the progress of an account's warm-up
+type AccountWarmUp struct {
+	Status string `json:"Status"`
+}
+
+// WarmUpSucceeded is the status of warm-ups which are done
+const WarmUpSucceeded = "Succeeded"
+
+// IsWarmingUp is true until the account's warm-up succeeds.  Accounts which
+// are warming up, or whose warm-up failed, aren't made Ready by their reset.
+func (a *Account) IsWarmingUp() bool {
+	return a.WarmUp != nil && a.WarmUp.Status != WarmUpSucceeded
+}
+
+// IsCoolingDown is true until the cooldown after the account's reset passes.
+// Ready accounts aren't leased while they're cooling down.
+func (a *Account) IsCoolingDown(now int64) bool {
+	return a.ReadyAt != nil && *a.ReadyAt > now
+}
+
+// AccountBlackout is activity found in an account while it was reset
+type AccountBlackout struct {
+	DetectedOn     int64                      `json:"DetectedOn"`
+	Since          int64                      `json:"Since"`
+	Activity       []*AccountBlackoutActivity `json:"Activity"`
+	AcknowledgedOn *int64                     `json:"AcknowledgedOn,omitempty"`
+	AcknowledgedBy *string                    `json:"AcknowledgedBy,omitempty"`
+}
+
+// AccountBlackoutActivity is a CloudTrail event found during a blackout
+type AccountBlackoutActivity struct {
+	PrincipalArn string `json:"PrincipalArn"`
+	EventName    string `json:"EventName"`
+	EventSource  string `json:"EventSource"`
+	Region       string `json:"Region"`
+	EventTime    int64  `json:"EventTime"`
+}
+
+// IsBlocking is true until the blackout is acknowledged.  Accounts with a
+// blocking blackout aren't made Ready by their reset.
+func (b *AccountBlackout) IsBlocking() bool {
+	return b != nil && b.AcknowledgedOn == nil
+}
+
+// AccountResetLeaks are the resources found in an account after its last
+// reset, and how many resets in a row each survived
+type AccountResetLeaks struct {
+	CheckedOn int64               `json:"CheckedOn"`
+	Resources []*AccountResetLeak `json:"Resources"`
+	// Truncated is set when there were more resources than were recorded
+	Truncated bool `json:"Truncated,omitempty"`
+}
+
+// AccountResetLeak is a resource which survived the account's resets
+type AccountResetLeak struct {
+	ARN         string `json:"ARN"`
+	Region      string `json:"Region"`
+	FirstSeenOn int64  `json:"FirstSeenOn"`
+	// Resets is how many resets in a row the resource survived
+	Resets int `json:"Resets"`
+}
+
+// AccountRemediation is the garbage collection of an account which was stuck
+// NotReady or Unreachable.  Its remediation is retried once, and it's
+// quarantined when that doesn't make it Ready.
+type AccountRemediation struct {
+	// Status is the status the account was stuck in
+	Status      AccountStatus `json:"Status"`
+	AttemptedOn int64         `json:"AttemptedOn"`
+	// QuarantinedOn is set once the account is quarantined
+	QuarantinedOn *int64 `json:"QuarantinedOn,omitempty"`
 }
 
 // Lease is a type corresponding to a Lease
@@ -27,12 +125,63 @@ type Lease struct {
 	LeaseStatusReason        LeaseStatusReason      `json:"LeaseStatusReason"`        // Reason for the status of the lease
 	CreatedOn                int64                  `json:"CreatedOn"`                // Created Epoch Timestamp
 	LastModifiedOn           int64                  `json:"LastModifiedOn"`           // Last Modified Epoch Timestamp
+	Version                  int64                  `json:"Version,omitempty"`        // Number of writes of the lease, which PutLease is conditional on
 	BudgetAmount             float64                `json:"BudgetAmount"`             // Budget Amount allocated for this lease
 	BudgetCurrency           string                 `json:"BudgetCurrency"`           // Budget currency
 	BudgetNotificationEmails []string               `json:"BudgetNotificationEmails"` // Budget notification emails
 	LeaseStatusModifiedOn    int64                  `json:"LeaseStatusModifiedOn"`    // Last Modified Epoch Timestamp
 	ExpiresOn                int64                  `json:"ExpiresOn"`                // Lease expiration time as Epoch
 	Metadata                 map[string]interface{} `json:"Metadata"`                 // Arbitrary key-value metadata to store with lease object
+	// BudgetThresholdsSent are the budget notifications sent, by percent of the budget
+	BudgetThresholdsSent []float64 `json:"BudgetThresholdsSent,omitempty"`
+	// LastCheckedOn is when the budget of the lease was last checked
+	LastCheckedOn int64 `json:"LastCheckedOn,omitempty"`
+	// SharedWith are other principals who can get credentials for the leased
+	// account
+	SharedWith []string `json:"SharedWith,omitempty"`
+	// AlertSuppression stops the budget notifications of the lease until it
+	// ends
+	AlertSuppression *LeaseAlertSuppression `json:"AlertSuppression,omitempty"`
+	// CategoryBudgets are parts of the budget for categories of spend, by
+	// category
+	CategoryBudgets map[string]LeaseCategoryBudget `json:"CategoryBudgets,omitempty"`
+}
+
+// LeaseCategoryBudget is the part of a lease's budget for a category of
+// spend, eg. AI services
+type LeaseCategoryBudget struct {
+	Amount float64 `json:"Amount"`
+	// NotificationThresholds are the percents of the amount notified, which
+	// default to the lease budget's thresholds
+	NotificationThresholds []float64 `json:"NotificationThresholds,omitempty"`
+	// Enforce ends the lease when the category budget is exceeded
+	Enforce bool `json:"Enforce"`
+	// ThresholdsSent are the notifications sent, by percent of the amount
+	ThresholdsSent []float64 `json:"ThresholdsSent,omitempty"`
+}
+
+// LeaseAlertSuppression acknowledges the budget alerts of a lease for a
+// period
+type LeaseAlertSuppression struct {
+	Until        int64  `json:"Until"`
+	Reason       string `json:"Reason"`
+	SuppressedBy string `json:"SuppressedBy,omitempty"`
+	SuppressedOn int64  `json:"SuppressedOn"`
+}
+
+// IsActive is true until the suppression ends
+func (s *LeaseAlertSuppression) IsActive(now int64) bool {
+	return s != nil && now < s.Until
+}
+
+// IsSharedWith is true when the lease is shared with the principal
+func (l *Lease) IsSharedWith(principalID string) bool {
+	for _, p := range l.SharedWith {
+		if p == principalID {
+			return true
+		}
+	}
+	return false
 }
 
 // Timestamp is a timestamp type for epoch format
@@ -58,6 +207,11 @@ const (
 	Leased AccountStatus = "Leased"
 	// Orphaned status
 	Orphaned AccountStatus = "Orphaned"
+	// Unreachable status, for accounts which were closed or suspended
+	Unreachable AccountStatus = "Unreachable"
+	// Quarantined status, for accounts which were stuck NotReady or
+	// Unreachable after their remediation was retried
+	Quarantined AccountStatus = "Quarantined"
 )
 
 // ParseAccountStatus - parses the string into an account status.
@@ -106,6 +260,8 @@ const (
 	LeaseOverBudget LeaseStatusReason = "OverBudget"
 	// LeaseOverPrincipalBudget means the lease is over its principal budgeted amount and is therefore reset/reclaimed.
 	LeaseOverPrincipalBudget LeaseStatusReason = "OverPrincipalBudget"
+	// LeaseOverCategoryBudget means the lease is over an enforced category budget, eg. for AI services, and is therefore reset/reclaimed.
+	LeaseOverCategoryBudget LeaseStatusReason = "OverCategoryBudget"
 	// LeaseDestroyed means the lease has been deleted via an API call or other user action.
 	LeaseDestroyed LeaseStatusReason = "Destroyed"
 	// LeaseActive means the lease is still active.
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// Writes to the AccountStatus and LeaseStatus indexes are concentrated on a
+// few partitions, one for each status. High churn deployments can spread
+// them over the AccountStatusShard and LeaseStatusShard indexes instead,
+// whose keys are the status and a shard number, eg. "Ready#shard2".
+
+// sharded is true when statuses are written to the sharded indexes
+func (db *DB) sharded() bool {
+	return db.StatusShards > 1
+}
+
+func (db *DB) accountStatusShard(accountID string, status AccountStatus) string {
+	return common.StatusShard(string(status), accountID, db.StatusShards)
+}
+
+func (db *DB) leaseStatusShard(accountID string, principalID string, status LeaseStatus) string {
+	return common.LeaseStatusShard(string(status), accountID, principalID, db.StatusShards)
+}
+
+// queryStatus gets the items of a table with a status. When statuses are
+// sharded, every shard is queried at once, and the items are returned in
+// shard order.
+func (db *DB) queryStatus(tableName string, statusName string, status string) ([]map[string]*dynamodb.AttributeValue, error) {
+	if !db.sharded() {
+		res, err := db.Client.Query(&dynamodb.QueryInput{
+			TableName: aws.String(tableName),
+			IndexName: aws.String(statusName),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":status": {
+					S: aws.String(status),
+				},
+			},
+			KeyConditionExpression: aws.String(statusName + " = :status"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Items, nil
+	}
+
+	shardName := statusName + "Shard"
+	shards := common.StatusShardKeys(status, db.StatusShards)
+	shardItems := make([][]map[string]*dynamodb.AttributeValue, len(shards))
+
+	pool := common.NewWorkerPool(context.Background(), len(shards))
+	for i, shard := range shards {
+		i, shard := i, shard
+		pool.Submit(func(ctx context.Context) error {
+			res, err := db.Client.Query(&dynamodb.QueryInput{
+				TableName: aws.String(tableName),
+				IndexName: aws.String(shardName),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":shard": {
+						S: aws.String(shard),
+					},
+				},
+				KeyConditionExpression: aws.String(shardName + " = :shard"),
+			})
+			if err != nil {
+				return err
+			}
+			shardItems[i] = res.Items
+			return nil
+		})
+	}
+	errs := pool.Wait()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+	for _, i := range shardItems {
+		items = append(items, i...)
+	}
+	return items, nil
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"testing"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindAccountsByStatusSharded(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("Query", mock.Anything).Return(func(input *dynamodb.QueryInput) *dynamodb.QueryOutput {
+		assert.Equal(t, "AccountStatusShard", *input.IndexName)
+		assert.Equal(t, "AccountStatusShard = :shard", *input.KeyConditionExpression)
+		shard := *input.ExpressionAttributeValues[":shard"].S
+		if shard == "Ready#shard1" {
+			return &dynamodb.QueryOutput{}
+		}
+		return &dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{accountItem(shard)},
+		}
+	}, nil)
+
+	db := DB{Client: mockDynamo, AccountTableName: "Accounts", StatusShards: 3}
+	accounts, err := db.FindAccountsByStatus(Ready)
+
+	assert.Nil(t, err)
+	mockDynamo.AssertNumberOfCalls(t, "Query", 3)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "Ready#shard0", accounts[0].ID)
+	assert.Equal(t, "Ready#shard2", accounts[1].ID)
+}
+
+func TestTransitionStatusSharded(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{
+		Attributes: accountItem("123456789012"),
+	}, nil)
+
+	db := DB{Client: mockDynamo, AccountTableName: "Accounts", LeaseTableName: "Leases", StatusShards: 4}
+	_, err := db.TransitionAccountStatus("123456789012", NotReady, Ready)
+	assert.Nil(t, err)
+	_, err = db.TransitionLeaseStatus("123456789012", "jdoe", Active, Inactive, LeaseExpired)
+	assert.Nil(t, err)
+
+	mockDynamo.AssertCalled(t, "UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return *input.TableName == "Accounts" &&
+			*input.UpdateExpression == "set AccountStatus=:nextStatus, LastModifiedOn=:lastModifiedOn, AccountStatusShard=:nextStatusShard REMOVE Remediation ADD #version :versionIncrement" &&
+			*input.ExpressionAttributeValues[":nextStatusShard"].S == common.StatusShard("Ready", "123456789012", 4)
+	}))
+	mockDynamo.AssertCalled(t, "UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return *input.TableName == "Leases" &&
+			*input.ExpressionAttributeValues[":nextStatusShard"].S == common.LeaseStatusShard("Inactive", "123456789012", "jdoe", 4)
+	}))
+}
+
+func TestPutAccountUnsharded(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	db := DB{Client: mockDynamo, AccountTableName: "Accounts", StatusShards: 1}
+	err := db.PutAccount(Account{ID: "123456789012", AccountStatus: Ready}, nil)
+	assert.Nil(t, err)
+
+	mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		_, ok := input.Item["AccountStatusShard"]
+		return !ok && aws.StringValue(input.Item["AccountStatus"].S) == "Ready"
+	}))
+}
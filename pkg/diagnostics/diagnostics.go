@@ -0,0 +1,306 @@
+// Package diagnostics is an opt-in diagnostic mode for the long-running DCE
+// components, such as the reset builds and the stream consumers. It logs
+// runtime stats, and uploads heap and goroutine profiles to S3, so memory
+// growth and goroutine leaks can be diagnosed in production. Processes which
+// can be reached over the network can also serve the pprof handlers.
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path"
+	"runtime"
+	rpprof "runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/caarlos0/env"
+)
+
+// Profiles are the pprof profiles uploaded with each capture
+var Profiles = []string{"heap", "goroutine"}
+
+// Stats are the runtime stats of a process
+type Stats struct {
+	Component      string  `json:"component"`
+	UptimeSeconds  float64 `json:"uptimeSeconds"`
+	Invocations    int64   `json:"invocations"`
+	Goroutines     int     `json:"goroutines"`
+	HeapAllocBytes uint64  `json:"heapAllocBytes"`
+	HeapSysBytes   uint64  `json:"heapSysBytes"`
+	HeapObjects    uint64  `json:"heapObjects"`
+	SysBytes       uint64  `json:"sysBytes"`
+	NumGC          uint32  `json:"numGC"`
+	GCPauseTotalMs float64 `json:"gcPauseTotalMs"`
+}
+
+// NewServiceInput are the items needed to create a new diagnostics service
+type NewServiceInput struct {
+	// Component names the process in logs and S3 keys, eg. "reset"
+	Component string
+	// Enabled turns on the diagnostic mode
+	Enabled bool `env:"DIAGNOSTICS_ENABLED" envDefault:"false"`
+	// Addr is the address the pprof handlers are served on, eg. "localhost:6060".
+	// They're not served when it's empty.
+	Addr string `env:"DIAGNOSTICS_ADDR" envDefault:""`
+	// Bucket and Prefix are where profiles are uploaded. Profiles are not
+	// uploaded when the bucket is empty.
+	Bucket string `env:"DIAGNOSTICS_BUCKET" envDefault:""`
+	Prefix string `env:"DIAGNOSTICS_PREFIX" envDefault:"diagnostics"`
+	// Interval is the minimum number of seconds between captures
+	Interval int    `env:"DIAGNOSTICS_INTERVAL" envDefault:"300"`
+	Region   string `env:"AWS_CURRENT_REGION" envDefault:"us-east-1"`
+	S3       s3iface.S3API
+}
+
+// Service captures the diagnostics of a process
+type Service struct {
+	component   string
+	enabled     bool
+	addr        string
+	bucket      string
+	prefix      string
+	interval    time.Duration
+	s3          s3iface.S3API
+	started     time.Time
+	instance    string
+	invocations int64
+	now         func() time.Time
+
+	mu          sync.Mutex
+	lastCapture time.Time
+}
+
+// NewService creates a new diagnostics service
+func NewService(input NewServiceInput) (*Service, error) {
+	if input.Enabled && input.Bucket != "" && input.S3 == nil {
+		return nil, errors.NewValidation("diagnostics", fmt.Errorf("an S3 client is required to upload profiles"))
+	}
+	if input.Interval <= 0 {
+		return nil, errors.NewValidation("diagnostics", fmt.Errorf("the interval must be positive"))
+	}
+
+	started := time.Now()
+	return &Service{
+		component: input.Component,
+		enabled:   input.Enabled,
+		addr:      input.Addr,
+		bucket:    input.Bucket,
+		prefix:    input.Prefix,
+		interval:  time.Duration(input.Interval) * time.Second,
+		s3:        input.S3,
+		started:   started,
+		// Profiles of each process are kept apart, as Lambda runs many at once
+		instance: fmt.Sprintf("%s-%d", started.UTC().Format("20060102T150405Z"), os.Getpid()),
+		now:      time.Now,
+	}, nil
+}
+
+// NewFromEnv creates a new diagnostics service configured from environment variables
+func NewFromEnv(component string) (*Service, error) {
+	input := NewServiceInput{Component: component}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	if input.Enabled && input.Bucket != "" {
+		awsSession, err := common.SharedSession(input.Region)
+		if err != nil {
+			return nil, err
+		}
+		input.S3 = s3.New(awsSession, common.EndpointConfig("S3"))
+	}
+	return NewService(input)
+}
+
+// Enabled is true when the diagnostic mode is turned on
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// ReadStats gets the runtime stats of the process
+func (s *Service) ReadStats() *Stats {
+	mem := runtime.MemStats{}
+	runtime.ReadMemStats(&mem)
+	return &Stats{
+		Component:      s.component,
+		UptimeSeconds:  s.now().Sub(s.started).Seconds(),
+		Invocations:    atomic.LoadInt64(&s.invocations),
+		Goroutines:     runtime.NumGoroutine(),
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapSysBytes:   mem.HeapSys,
+		HeapObjects:    mem.HeapObjects,
+		SysBytes:       mem.Sys,
+		NumGC:          mem.NumGC,
+		GCPauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+	}
+}
+
+// Capture logs the runtime stats, and uploads them with the profiles of the
+// process when a bucket is configured
+func (s *Service) Capture() error {
+	if !s.enabled {
+		return nil
+	}
+	s.mu.Lock()
+	s.lastCapture = s.now()
+	s.mu.Unlock()
+
+	stats, err := json.Marshal(s.ReadStats())
+	if err != nil {
+		return errors.NewInternalServer("failed to marshal runtime stats", err)
+	}
+	log.Printf("Diagnostics for %s: %s", s.component, stats)
+
+	if s.bucket == "" {
+		return nil
+	}
+	dir := path.Join(s.prefix, s.component, s.instance, s.lastCapture.UTC().Format("20060102T150405Z"))
+	err = s.upload(path.Join(dir, "stats.json"), stats)
+	if err != nil {
+		return err
+	}
+	for _, name := range Profiles {
+		buf := &bytes.Buffer{}
+		err = rpprof.Lookup(name).WriteTo(buf, 0)
+		if err != nil {
+			return errors.NewInternalServer(fmt.Sprintf("failed to write the %s profile", name), err)
+		}
+		err = s.upload(path.Join(dir, name+".pb.gz"), buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("Uploaded diagnostics for %s to s3://%s/%s", s.component, s.bucket, dir)
+	return nil
+}
+
+// CaptureIfDue captures diagnostics when the interval passed since the last
+// capture. Failures are logged, as diagnostics shouldn't fail the process.
+func (s *Service) CaptureIfDue() {
+	if !s.enabled {
+		return
+	}
+	s.mu.Lock()
+	due := s.now().Sub(s.lastCapture) >= s.interval
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+	err := s.Capture()
+	if err != nil {
+		log.Printf("Failed to capture diagnostics for %s: %s", s.component, err)
+	}
+}
+
+func (s *Service) upload(key string, body []byte) error {
+	_, err := s.s3.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to upload s3://%s/%s", s.bucket, key), err)
+	}
+	return nil
+}
+
+// Handler serves the pprof handlers under /debug/pprof/, and the runtime
+// stats at /debug/stats
+func (s *Service) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.ReadStats())
+	})
+	return mux
+}
+
+// Start serves the pprof handlers when an address is configured, and
+// captures diagnostics every interval, until ctx is done
+func (s *Service) Start(ctx context.Context) {
+	if !s.enabled {
+		return
+	}
+
+	if s.addr != "" {
+		server := &http.Server{Addr: s.addr, Handler: s.Handler()}
+		go func() {
+			log.Printf("Serving diagnostics for %s on %s", s.component, s.addr)
+			err := server.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Printf("Failed to serve diagnostics for %s: %s", s.component, err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			_ = server.Close()
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.CaptureIfDue()
+			}
+		}
+	}()
+}
+
+// LambdaHandler wraps a Lambda handler function, to capture the diagnostics
+// of the function's process after its invocations. The handler is returned
+// as is when the diagnostic mode is off.
+func LambdaHandler(component string, handlerFunc interface{}) lambda.Handler {
+	handler := lambda.NewHandler(handlerFunc)
+	svc, err := NewFromEnv(component)
+	if err != nil {
+		log.Printf("Diagnostics are disabled for %s: %s", component, err)
+		return handler
+	}
+	return svc.WrapHandler(handler)
+}
+
+// WrapHandler wraps a Lambda handler, to capture diagnostics after its invocations
+func (s *Service) WrapHandler(handler lambda.Handler) lambda.Handler {
+	if !s.enabled {
+		return handler
+	}
+	return &diagnosedHandler{svc: s, handler: handler}
+}
+
+type diagnosedHandler struct {
+	svc     *Service
+	handler lambda.Handler
+}
+
+// Invoke invokes the handler, then captures diagnostics when they're due.
+// The first capture is after the first invocation, to get a baseline.
+func (h *diagnosedHandler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	res, err := h.handler.Invoke(ctx, payload)
+	atomic.AddInt64(&h.svc.invocations, 1)
+	h.svc.CaptureIfDue()
+	return res, err
+}
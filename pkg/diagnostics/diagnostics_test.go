@@ -0,0 +1,157 @@
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, s3Svc *awsmocks.S3API, bucket string) *Service {
+	svc, err := NewService(NewServiceInput{
+		Component: "reset",
+		Enabled:   true,
+		Bucket:    bucket,
+		Prefix:    "diagnostics",
+		Interval:  300,
+		S3:        s3Svc,
+	})
+	require.Nil(t, err)
+	return svc
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("should require an S3 client to upload profiles", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{Enabled: true, Bucket: "artifacts", Interval: 300})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should require a positive interval", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{Enabled: true})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCapture(t *testing.T) {
+	t.Run("should upload the stats and profiles", func(t *testing.T) {
+		s3Svc := &awsmocks.S3API{}
+		keys := []string{}
+		s3Svc.On("PutObject", mock.Anything).Return(func(input *s3.PutObjectInput) *s3.PutObjectOutput {
+			assert.Equal(t, "artifacts", *input.Bucket)
+			keys = append(keys, *input.Key)
+			return &s3.PutObjectOutput{}
+		}, nil)
+		svc := newTestService(t, s3Svc, "artifacts")
+
+		err := svc.Capture()
+		require.Nil(t, err)
+
+		require.Len(t, keys, 3)
+		prefix := "diagnostics/reset/" + svc.instance + "/"
+		for i, name := range []string{"stats.json", "heap.pb.gz", "goroutine.pb.gz"} {
+			assert.True(t, strings.HasPrefix(keys[i], prefix), keys[i])
+			assert.True(t, strings.HasSuffix(keys[i], "/"+name), keys[i])
+		}
+	})
+
+	t.Run("should only log the stats without a bucket", func(t *testing.T) {
+		s3Svc := &awsmocks.S3API{}
+		svc := newTestService(t, s3Svc, "")
+
+		err := svc.Capture()
+		require.Nil(t, err)
+		s3Svc.AssertNotCalled(t, "PutObject", mock.Anything)
+	})
+
+	t.Run("should do nothing when disabled", func(t *testing.T) {
+		s3Svc := &awsmocks.S3API{}
+		svc := newTestService(t, s3Svc, "artifacts")
+		svc.enabled = false
+
+		err := svc.Capture()
+		require.Nil(t, err)
+		s3Svc.AssertNotCalled(t, "PutObject", mock.Anything)
+	})
+}
+
+func TestCaptureIfDue(t *testing.T) {
+	s3Svc := &awsmocks.S3API{}
+	s3Svc.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+	svc := newTestService(t, s3Svc, "artifacts")
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	svc.CaptureIfDue()
+	s3Svc.AssertNumberOfCalls(t, "PutObject", 3)
+
+	// Not due until the interval passed
+	now = now.Add(299 * time.Second)
+	svc.CaptureIfDue()
+	s3Svc.AssertNumberOfCalls(t, "PutObject", 3)
+
+	now = now.Add(time.Second)
+	svc.CaptureIfDue()
+	s3Svc.AssertNumberOfCalls(t, "PutObject", 6)
+}
+
+func TestHandler(t *testing.T) {
+	svc := newTestService(t, &awsmocks.S3API{}, "")
+	server := httptest.NewServer(svc.Handler())
+	defer server.Close()
+
+	t.Run("should serve the runtime stats", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/debug/stats")
+		require.Nil(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		stats := Stats{}
+		err = json.NewDecoder(res.Body).Decode(&stats)
+		require.Nil(t, err)
+		assert.Equal(t, "reset", stats.Component)
+		assert.True(t, stats.Goroutines > 0)
+		assert.True(t, stats.HeapAllocBytes > 0)
+	})
+
+	t.Run("should serve the pprof profiles", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/debug/pprof/goroutine?debug=1")
+		require.Nil(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
+
+func TestWrapHandler(t *testing.T) {
+	s3Svc := &awsmocks.S3API{}
+	s3Svc.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+	svc := newTestService(t, s3Svc, "artifacts")
+	handler := svc.WrapHandler(lambda.NewHandler(func(ctx context.Context, input string) (string, error) {
+		return strings.ToUpper(input), nil
+	}))
+
+	res, err := handler.Invoke(context.Background(), []byte(`"hello"`))
+	require.Nil(t, err)
+	assert.Equal(t, `"HELLO"`, string(res))
+
+	// Captures after the first invocation, then once the interval passed
+	_, err = handler.Invoke(context.Background(), []byte(`"hello"`))
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), svc.ReadStats().Invocations)
+	s3Svc.AssertNumberOfCalls(t, "PutObject", 3)
+
+	t.Run("should not wrap the handler when disabled", func(t *testing.T) {
+		svc.enabled = false
+		_, ok := svc.WrapHandler(lambda.NewHandler(func() error { return nil })).(*diagnosedHandler)
+		assert.False(t, ok)
+	})
+}
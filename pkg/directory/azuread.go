@@ -0,0 +1,188 @@
+package directory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default Azure AD URLs
+const (
+	DefaultAzureADLoginURL = "https://login.microsoftonline.com"
+	DefaultGraphURL        = "https://graph.microsoft.com"
+)
+
+// AzureADInput are the items needed to create an Azure AD driver
+type AzureADInput struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// LoginURL and GraphURL are optional, and default to DefaultAzureADLoginURL
+	// and DefaultGraphURL
+	LoginURL   string
+	GraphURL   string
+	HTTPClient *http.Client
+}
+
+// AzureAD looks up users with Microsoft Graph, authenticating as an app
+// registration with the OAuth client credentials flow. The app needs the
+// User.Read.All and GroupMember.Read.All application permissions.
+type AzureAD struct {
+	config      AzureADInput
+	mutex       sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewAzureAD creates an Azure AD driver
+func NewAzureAD(input AzureADInput) *AzureAD {
+	if input.LoginURL == "" {
+		input.LoginURL = DefaultAzureADLoginURL
+	}
+	if input.GraphURL == "" {
+		input.GraphURL = DefaultGraphURL
+	}
+	input.LoginURL = strings.TrimRight(input.LoginURL, "/")
+	input.GraphURL = strings.TrimRight(input.GraphURL, "/")
+	return &AzureAD{config: input}
+}
+
+type graphUser struct {
+	ID                string `json:"id"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	Mail              string `json:"mail"`
+	DisplayName       string `json:"displayName"`
+	AccountEnabled    bool   `json:"accountEnabled"`
+}
+
+type graphGroups struct {
+	Value []struct {
+		DisplayName string `json:"displayName"`
+	} `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// GetUser gets a user by user principal name or ID
+func (a *AzureAD) GetUser(userName string) (*User, error) {
+	u := &graphUser{}
+	found, err := a.get(a.config.GraphURL+"/v1.0/users/"+url.PathEscape(userName)+
+		"?$select=id,userPrincipalName,mail,displayName,accountEnabled", u)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	user := &User{
+		ID:          u.ID,
+		UserName:    u.UserPrincipalName,
+		Email:       u.Mail,
+		DisplayName: u.DisplayName,
+		Active:      u.AccountEnabled,
+		Groups:      []string{},
+	}
+
+	// Group memberships are paged
+	next := a.config.GraphURL + "/v1.0/users/" + url.PathEscape(u.ID) + "/memberOf/microsoft.graph.group?$select=displayName"
+	for next != "" {
+		page := &graphGroups{}
+		_, err = a.get(next, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range page.Value {
+			user.Groups = append(user.Groups, g.DisplayName)
+		}
+		next = page.NextLink
+	}
+	return user, nil
+}
+
+// GetManager gets the manager of a user.  The manager's groups aren't looked up.
+func (a *AzureAD) GetManager(user *User) (*User, error) {
+	u := &graphUser{}
+	found, err := a.get(a.config.GraphURL+"/v1.0/users/"+url.PathEscape(user.ID)+
+		"/manager?$select=id,userPrincipalName,mail,displayName,accountEnabled", u)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &User{
+		ID:          u.ID,
+		UserName:    u.UserPrincipalName,
+		Email:       u.Mail,
+		DisplayName: u.DisplayName,
+		Active:      u.AccountEnabled,
+		Groups:      []string{},
+	}, nil
+}
+
+// get reads a JSON response into out, returning false when it isn't found
+func (a *AzureAD) get(requestURL string, out interface{}) (bool, error) {
+	token, err := a.accessToken()
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := a.config.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return false, fmt.Errorf("graph GET %s failed (%s): %s", req.URL.Path, res.Status, resBody)
+	}
+	return true, json.NewDecoder(res.Body).Decode(out)
+}
+
+// accessToken gets a Microsoft Graph access token, reusing it until shortly
+// before it expires
+func (a *AzureAD) accessToken() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.token != "" && time.Now().Before(a.tokenExpiry) {
+		return a.token, nil
+	}
+
+	tokenURL := a.config.LoginURL + "/" + url.PathEscape(a.config.TenantID) + "/oauth2/v2.0/token"
+	res, err := a.config.HTTPClient.PostForm(tokenURL, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.config.ClientID},
+		"client_secret": {a.config.ClientSecret},
+		"scope":         {a.config.GraphURL + "/.default"},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return "", fmt.Errorf("azure ad token request failed (%s): %s", res.Status, resBody)
+	}
+	body := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = body.AccessToken
+	a.tokenExpiry = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - time.Minute)
+	return a.token, nil
+}
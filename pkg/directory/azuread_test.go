@@ -0,0 +1,101 @@
+package directory
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureADGetUser(t *testing.T) {
+	tokenRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tenant/oauth2/v2.0/token" {
+			tokenRequests++
+			_ = r.ParseForm()
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "client", r.PostForm.Get("client_id"))
+			_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+			return
+		}
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.URL.Path == "/v1.0/users/jdoe@example.com":
+			_, _ = w.Write([]byte(`{"id": "u1", "userPrincipalName": "jdoe@example.com", "mail": "jane.doe@example.com",
+				"displayName": "Jane Doe", "accountEnabled": true}`))
+		case r.URL.Path == "/v1.0/users/u1/manager":
+			_, _ = w.Write([]byte(`{"id": "u2", "userPrincipalName": "boss@example.com", "mail": "john.roe@example.com",
+				"displayName": "John Roe", "accountEnabled": true}`))
+		case r.URL.Path == "/v1.0/users/u1/memberOf/microsoft.graph.group" && r.URL.Query().Get("$skiptoken") == "":
+			_, _ = fmt.Fprintf(w, `{"value": [{"displayName": "Engineering"}], "@odata.nextLink": "%s/v1.0/users/u1/memberOf/microsoft.graph.group?$skiptoken=2"}`, server.URL)
+		case r.URL.Path == "/v1.0/users/u1/memberOf/microsoft.graph.group":
+			_, _ = w.Write([]byte(`{"value": [{"displayName": "Cloud Admins"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	driver := NewAzureAD(AzureADInput{
+		TenantID:     "tenant",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		LoginURL:     server.URL,
+		GraphURL:     server.URL,
+		HTTPClient:   server.Client(),
+	})
+
+	user, err := driver.GetUser("jdoe@example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, &User{
+		ID:          "u1",
+		UserName:    "jdoe@example.com",
+		Email:       "jane.doe@example.com",
+		DisplayName: "Jane Doe",
+		Active:      true,
+		Groups:      []string{"Engineering", "Cloud Admins"},
+	}, user)
+
+	manager, err := driver.GetManager(user)
+	assert.Nil(t, err)
+	assert.Equal(t, &User{
+		ID:          "u2",
+		UserName:    "boss@example.com",
+		Email:       "john.roe@example.com",
+		DisplayName: "John Roe",
+		Active:      true,
+		Groups:      []string{},
+	}, manager)
+
+	manager, err = driver.GetManager(manager)
+	assert.Nil(t, err)
+	assert.Nil(t, manager)
+
+	user, err = driver.GetUser("nobody")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+
+	// The access token is reused
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestAzureADTokenError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+	}))
+	defer server.Close()
+
+	driver := NewAzureAD(AzureADInput{
+		TenantID:   "tenant",
+		LoginURL:   server.URL,
+		GraphURL:   server.URL,
+		HTTPClient: server.Client(),
+	})
+	_, err := driver.GetUser("jdoe")
+
+	assert.EqualError(t, err, `azure ad token request failed (401 Unauthorized): {"error": "invalid_client"}`)
+}
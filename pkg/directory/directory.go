@@ -0,0 +1,226 @@
+// Package directory resolves principal IDs to users and groups in an identity
+// directory, such as Okta or Azure AD. Group memberships are used for lease
+// quotas and to find lease approvers.
+package directory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/caarlos0/env"
+)
+
+// Driver names, used by the DIRECTORY_DRIVER setting
+const (
+	DriverOkta    = "okta"
+	DriverAzureAD = "azuread"
+)
+
+// User is a user in the directory
+type User struct {
+	ID          string
+	UserName    string
+	Email       string
+	DisplayName string
+	// Active is false when the user is deactivated or suspended in the directory
+	Active bool
+	Groups []string
+}
+
+// Driver looks up users in a directory
+type Driver interface {
+	// GetUser gets a user and their groups by user name, or nil if there
+	// is no such user
+	GetUser(userName string) (*User, error)
+	// GetManager gets the manager of a user, or nil if they have none
+	GetManager(user *User) (*User, error)
+}
+
+// NewServiceInput are the items needed to create a new directory service
+type NewServiceInput struct {
+	// DriverName is the directory to look up principals in, either "okta" or
+	// "azuread". Directory lookups are disabled when empty
+	DriverName string `env:"DIRECTORY_DRIVER" envDefault:""`
+	// URL is the Okta org URL, or overrides the Microsoft Graph URL
+	URL string `env:"DIRECTORY_URL" envDefault:""`
+	// APIToken is the Okta API token
+	APIToken string `env:"DIRECTORY_API_TOKEN" envDefault:""`
+	// TenantID, ClientID and ClientSecret are the Azure AD app registration
+	// used to call Microsoft Graph
+	TenantID     string `env:"DIRECTORY_TENANT_ID" envDefault:""`
+	ClientID     string `env:"DIRECTORY_CLIENT_ID" envDefault:""`
+	ClientSecret string `env:"DIRECTORY_CLIENT_SECRET" envDefault:""`
+	// GroupQuotas are the lease quotas of directory groups, as JSON, eg.
+	// {"Engineering": {"maxLeaseBudgetAmount": 2000}}
+	GroupQuotas string `env:"DIRECTORY_GROUP_QUOTAS" envDefault:""`
+	// ApproverGroups are the directory groups whose members may approve leases
+	ApproverGroups []string `env:"DIRECTORY_APPROVER_GROUPS" envDefault:""`
+	// Driver is optional, and overrides DriverName
+	Driver Driver
+}
+
+// Service looks up principals in a directory
+type Service struct {
+	driver         Driver
+	groupQuotas    map[string]lease.Quota
+	approverGroups map[string]bool
+}
+
+// Enabled is true when a directory is configured
+func (s *Service) Enabled() bool {
+	return s.driver != nil
+}
+
+// GetUser gets the directory user of a principal, or nil if there is no
+// such user
+func (s *Service) GetUser(principalID string) (*User, error) {
+	if s.driver == nil {
+		return nil, nil
+	}
+	user, err := s.driver.GetUser(principalID)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to get directory user %s", principalID), err)
+	}
+	return user, nil
+}
+
+// GetManager gets the manager of a directory user, or nil if they have none
+func (s *Service) GetManager(user *User) (*User, error) {
+	if s.driver == nil || user == nil {
+		return nil, nil
+	}
+	manager, err := s.driver.GetManager(user)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to get the manager of directory user %s", user.UserName), err)
+	}
+	return manager, nil
+}
+
+// Quota gets the lease quota of a user's groups. When the user is in more
+// than one group with a quota, the largest limits are used. Returns nil when
+// none of the groups have a quota.
+func (s *Service) Quota(user *User) *lease.Quota {
+	var quota *lease.Quota
+	for _, group := range user.Groups {
+		groupQuota, ok := s.groupQuotas[group]
+		if !ok {
+			continue
+		}
+		if quota == nil {
+			quota = &lease.Quota{}
+		}
+		if groupQuota.PrincipalBudgetAmount > quota.PrincipalBudgetAmount {
+			quota.PrincipalBudgetAmount = groupQuota.PrincipalBudgetAmount
+		}
+		if groupQuota.MaxLeaseBudgetAmount > quota.MaxLeaseBudgetAmount {
+			quota.MaxLeaseBudgetAmount = groupQuota.MaxLeaseBudgetAmount
+		}
+	}
+	return quota
+}
+
+// PrincipalQuota checks a principal is an active directory user, and gets
+// the lease quota of their groups.  Returns nil when no directory is
+// configured, or the user's groups have no quota.
+func (s *Service) PrincipalQuota(principalID string) (*lease.Quota, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	user, err := s.GetUser(principalID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.Active {
+		return nil, errors.NewValidation("lease", fmt.Errorf("principal %s is not an active directory user", principalID))
+	}
+	return s.Quota(user), nil
+}
+
+// HasApprovers is true when approver groups are configured
+func (s *Service) HasApprovers() bool {
+	return len(s.approverGroups) > 0
+}
+
+// IsApprover checks an active user is in an approver group
+func (s *Service) IsApprover(user *User) bool {
+	if user == nil || !user.Active {
+		return false
+	}
+	for _, group := range user.Groups {
+		if s.approverGroups[group] {
+			return true
+		}
+	}
+	return false
+}
+
+// NewService creates a new directory service
+func NewService(input NewServiceInput) (*Service, error) {
+	driver := input.Driver
+	if driver == nil && input.DriverName != "" {
+		httpClient := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		switch strings.ToLower(input.DriverName) {
+		case DriverOkta:
+			if input.URL == "" || input.APIToken == "" {
+				return nil, errors.NewValidation("directory", fmt.Errorf("a URL and API token are required for the okta driver"))
+			}
+			driver = NewOkta(input.URL, input.APIToken, httpClient)
+		case DriverAzureAD:
+			if input.TenantID == "" || input.ClientID == "" || input.ClientSecret == "" {
+				return nil, errors.NewValidation("directory", fmt.Errorf("a tenant ID, client ID and client secret are required for the azuread driver"))
+			}
+			driver = NewAzureAD(AzureADInput{
+				TenantID:     input.TenantID,
+				ClientID:     input.ClientID,
+				ClientSecret: input.ClientSecret,
+				GraphURL:     input.URL,
+				HTTPClient:   httpClient,
+			})
+		default:
+			return nil, errors.NewValidation("directory", fmt.Errorf("unknown directory driver %q", input.DriverName))
+		}
+	}
+
+	groupQuotas := map[string]lease.Quota{}
+	if input.GroupQuotas != "" {
+		err := json.Unmarshal([]byte(input.GroupQuotas), &groupQuotas)
+		if err != nil {
+			return nil, errors.NewValidation("directory", fmt.Errorf("invalid group quotas: %s", err))
+		}
+	}
+
+	approverGroups := map[string]bool{}
+	for _, group := range input.ApproverGroups {
+		if group = strings.TrimSpace(group); group != "" {
+			approverGroups[group] = true
+		}
+	}
+
+	if driver == nil && (len(groupQuotas) > 0 || len(approverGroups) > 0) {
+		return nil, errors.NewValidation("directory", fmt.Errorf("a directory driver is required for group quotas and approver groups"))
+	}
+
+	return &Service{
+		driver:         driver,
+		groupQuotas:    groupQuotas,
+		approverGroups: approverGroups,
+	}, nil
+}
+
+// NewFromEnv creates a new directory service configured from environment variables
+func NewFromEnv() (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(input)
+}
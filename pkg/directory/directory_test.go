@@ -0,0 +1,189 @@
+package directory
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDriver struct {
+	users    map[string]*User
+	managers map[string]*User
+	err      error
+}
+
+func (d *testDriver) GetUser(userName string) (*User, error) {
+	return d.users[userName], d.err
+}
+
+func (d *testDriver) GetManager(user *User) (*User, error) {
+	return d.managers[user.ID], d.err
+}
+
+func TestGetUser(t *testing.T) {
+	jdoe := &User{ID: "1", UserName: "jdoe", Active: true}
+	svc, err := NewService(NewServiceInput{Driver: &testDriver{users: map[string]*User{"jdoe": jdoe}}})
+	assert.Nil(t, err)
+	assert.True(t, svc.Enabled())
+
+	user, err := svc.GetUser("jdoe")
+	assert.Nil(t, err)
+	assert.Equal(t, jdoe, user)
+
+	user, err = svc.GetUser("nobody")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+
+	svc, err = NewService(NewServiceInput{Driver: &testDriver{err: fmt.Errorf("unavailable")}})
+	assert.Nil(t, err)
+	_, err = svc.GetUser("jdoe")
+	assert.EqualError(t, err, "failed to get directory user jdoe")
+}
+
+func TestGetManager(t *testing.T) {
+	jdoe := &User{ID: "1", UserName: "jdoe", Active: true}
+	boss := &User{ID: "2", UserName: "boss", Email: "boss@example.com", Active: true}
+	svc, err := NewService(NewServiceInput{Driver: &testDriver{managers: map[string]*User{"1": boss}}})
+	assert.Nil(t, err)
+
+	manager, err := svc.GetManager(jdoe)
+	assert.Nil(t, err)
+	assert.Equal(t, boss, manager)
+
+	manager, err = svc.GetManager(boss)
+	assert.Nil(t, err)
+	assert.Nil(t, manager)
+
+	svc, err = NewService(NewServiceInput{Driver: &testDriver{err: fmt.Errorf("unavailable")}})
+	assert.Nil(t, err)
+	_, err = svc.GetManager(jdoe)
+	assert.EqualError(t, err, "failed to get the manager of directory user jdoe")
+
+	svc, err = NewService(NewServiceInput{})
+	assert.Nil(t, err)
+	manager, err = svc.GetManager(jdoe)
+	assert.Nil(t, err)
+	assert.Nil(t, manager)
+}
+
+func TestQuota(t *testing.T) {
+	svc, err := NewService(NewServiceInput{
+		Driver: &testDriver{},
+		GroupQuotas: `{
+			"Engineering": {"principalBudgetAmount": 5000, "maxLeaseBudgetAmount": 1000},
+			"Data Science": {"maxLeaseBudgetAmount": 3000}
+		}`,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, svc.Quota(&User{Groups: []string{"Sales"}}))
+	assert.Equal(t, &lease.Quota{PrincipalBudgetAmount: 5000, MaxLeaseBudgetAmount: 1000},
+		svc.Quota(&User{Groups: []string{"Engineering"}}))
+	assert.Equal(t, &lease.Quota{PrincipalBudgetAmount: 5000, MaxLeaseBudgetAmount: 3000},
+		svc.Quota(&User{Groups: []string{"Sales", "Engineering", "Data Science"}}))
+}
+
+func TestPrincipalQuota(t *testing.T) {
+	svc, err := NewService(NewServiceInput{
+		Driver: &testDriver{users: map[string]*User{
+			"jdoe":   {ID: "1", UserName: "jdoe", Active: true, Groups: []string{"Engineering"}},
+			"former": {ID: "2", UserName: "former", Active: false, Groups: []string{"Engineering"}},
+		}},
+		GroupQuotas: `{"Engineering": {"maxLeaseBudgetAmount": 1000}}`,
+	})
+	assert.Nil(t, err)
+
+	quota, err := svc.PrincipalQuota("jdoe")
+	assert.Nil(t, err)
+	assert.Equal(t, &lease.Quota{MaxLeaseBudgetAmount: 1000}, quota)
+
+	_, err = svc.PrincipalQuota("former")
+	assert.Equal(t, "lease validation error: principal former is not an active directory user", err.Error())
+	_, err = svc.PrincipalQuota("nobody")
+	assert.Equal(t, "lease validation error: principal nobody is not an active directory user", err.Error())
+
+	svc, err = NewService(NewServiceInput{})
+	assert.Nil(t, err)
+	quota, err = svc.PrincipalQuota("jdoe")
+	assert.Nil(t, err)
+	assert.Nil(t, quota)
+}
+
+func TestIsApprover(t *testing.T) {
+	svc, err := NewService(NewServiceInput{Driver: &testDriver{}, ApproverGroups: []string{"Cloud Admins", " "}})
+	assert.Nil(t, err)
+	assert.True(t, svc.HasApprovers())
+
+	assert.True(t, svc.IsApprover(&User{Active: true, Groups: []string{"Engineering", "Cloud Admins"}}))
+	assert.False(t, svc.IsApprover(&User{Active: true, Groups: []string{"Engineering"}}))
+	assert.False(t, svc.IsApprover(&User{Active: false, Groups: []string{"Cloud Admins"}}))
+	assert.False(t, svc.IsApprover(nil))
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NewServiceInput
+		expErr string
+		expNil bool
+	}{
+		{
+			name:   "should disable lookups without a driver",
+			input:  NewServiceInput{},
+			expNil: true,
+		},
+		{
+			name:  "should create an Okta driver",
+			input: NewServiceInput{DriverName: "Okta", URL: "https://example.okta.com", APIToken: "token"},
+		},
+		{
+			name:  "should create an Azure AD driver",
+			input: NewServiceInput{DriverName: "azuread", TenantID: "tenant", ClientID: "client", ClientSecret: "secret"},
+		},
+		{
+			name:   "should require an Okta API token",
+			input:  NewServiceInput{DriverName: "okta", URL: "https://example.okta.com"},
+			expErr: "directory validation error: a URL and API token are required for the okta driver",
+		},
+		{
+			name:   "should require Azure AD credentials",
+			input:  NewServiceInput{DriverName: "azuread", TenantID: "tenant"},
+			expErr: "directory validation error: a tenant ID, client ID and client secret are required for the azuread driver",
+		},
+		{
+			name:   "should reject unknown drivers",
+			input:  NewServiceInput{DriverName: "ldap"},
+			expErr: "directory validation error: unknown directory driver \"ldap\"",
+		},
+		{
+			name:   "should reject invalid group quotas",
+			input:  NewServiceInput{Driver: &testDriver{}, GroupQuotas: "{"},
+			expErr: "directory validation error: invalid group quotas: unexpected end of JSON input",
+		},
+		{
+			name:   "should require a driver for approver groups",
+			input:  NewServiceInput{ApproverGroups: []string{"Cloud Admins"}},
+			expErr: "directory validation error: a directory driver is required for group quotas and approver groups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := NewService(tt.input)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, !tt.expNil, svc.Enabled())
+			// A disabled service finds no users
+			if tt.expNil {
+				user, err := svc.GetUser("jdoe")
+				assert.Nil(t, err)
+				assert.Nil(t, user)
+			}
+		})
+	}
+}
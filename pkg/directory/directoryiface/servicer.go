@@ -0,0 +1,27 @@
+//
+
+package directoryiface
+
+import (
+	"github.com/Optum/dce/pkg/directory"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// Servicer looks up principals in a directory
+type Servicer interface {
+	// Enabled is true when a directory is configured
+	Enabled() bool
+	// GetUser gets the directory user of a principal, or nil if there is no such user
+	GetUser(principalID string) (*directory.User, error)
+	// GetManager gets the manager of a directory user, or nil if they have none
+	GetManager(user *directory.User) (*directory.User, error)
+	// Quota gets the lease quota of a user's groups, or nil if they have none
+	Quota(user *directory.User) *lease.Quota
+	// PrincipalQuota checks a principal is an active directory user, and
+	// gets the lease quota of their groups, or nil if they have none
+	PrincipalQuota(principalID string) (*lease.Quota, error)
+	// HasApprovers is true when approver groups are configured
+	HasApprovers() bool
+	// IsApprover checks an active user is in an approver group
+	IsApprover(user *directory.User) bool
+}
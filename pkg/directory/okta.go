@@ -0,0 +1,114 @@
+package directory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// oktaInactiveStatuses are the Okta user statuses of deactivated users
+var oktaInactiveStatuses = map[string]bool{
+	"SUSPENDED":     true,
+	"DEPROVISIONED": true,
+}
+
+// Okta looks up users with the Okta Users API
+type Okta struct {
+	url        string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// NewOkta creates an Okta driver for the org URL, eg. https://example.okta.com
+func NewOkta(orgURL string, apiToken string, httpClient *http.Client) *Okta {
+	return &Okta{
+		url:        strings.TrimRight(orgURL, "/"),
+		apiToken:   apiToken,
+		httpClient: httpClient,
+	}
+}
+
+type oktaUser struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Profile struct {
+		Login     string `json:"login"`
+		Email     string `json:"email"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		// ManagerID is the login or ID of the user's manager
+		ManagerID string `json:"managerId"`
+	} `json:"profile"`
+}
+
+type oktaGroup struct {
+	Profile struct {
+		Name string `json:"name"`
+	} `json:"profile"`
+}
+
+// GetUser gets a user by login, short login or ID
+func (o *Okta) GetUser(userName string) (*User, error) {
+	u := &oktaUser{}
+	found, err := o.get("/api/v1/users/"+url.PathEscape(userName), u)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	groups := []*oktaGroup{}
+	_, err = o.get("/api/v1/users/"+url.PathEscape(u.ID)+"/groups", &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{
+		ID:          u.ID,
+		UserName:    u.Profile.Login,
+		Email:       u.Profile.Email,
+		DisplayName: strings.TrimSpace(u.Profile.FirstName + " " + u.Profile.LastName),
+		Active:      !oktaInactiveStatuses[u.Status],
+		Groups:      []string{},
+	}
+	for _, g := range groups {
+		user.Groups = append(user.Groups, g.Profile.Name)
+	}
+	return user, nil
+}
+
+// GetManager gets the user named by the managerId of the user's profile
+func (o *Okta) GetManager(user *User) (*User, error) {
+	u := &oktaUser{}
+	found, err := o.get("/api/v1/users/"+url.PathEscape(user.ID), u)
+	if err != nil || !found || u.Profile.ManagerID == "" {
+		return nil, err
+	}
+	return o.GetUser(u.Profile.ManagerID)
+}
+
+// get reads a JSON response into out, returning false when it isn't found
+func (o *Okta) get(path string, out interface{}) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, o.url+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "SSWS "+o.apiToken)
+
+	res, err := o.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return false, fmt.Errorf("okta GET %s failed (%s): %s", req.URL.Path, res.Status, resBody)
+	}
+	return true, json.NewDecoder(res.Body).Decode(out)
+}
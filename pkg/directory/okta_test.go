@@ -0,0 +1,72 @@
+package directory
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOktaGetUser(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/api/v1/users/jdoe@example.com":
+			_, _ = w.Write([]byte(`{"id": "00u1", "status": "SUSPENDED", "profile": {
+				"login": "jdoe@example.com", "email": "jdoe@example.com", "firstName": "Jane", "lastName": "Doe"}}`))
+		case "/api/v1/users/00u1":
+			_, _ = w.Write([]byte(`{"id": "00u1", "status": "SUSPENDED", "profile": {"managerId": "boss@example.com"}}`))
+		case "/api/v1/users/boss@example.com":
+			_, _ = w.Write([]byte(`{"id": "00u2", "status": "ACTIVE", "profile": {
+				"login": "boss@example.com", "email": "boss@example.com", "firstName": "John", "lastName": "Roe"}}`))
+		case "/api/v1/users/00u1/groups", "/api/v1/users/00u2/groups":
+			_, _ = w.Write([]byte(`[{"profile": {"name": "Everyone"}}, {"profile": {"name": "Engineering"}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode": "E0000007"}`))
+		}
+	}))
+	defer server.Close()
+
+	driver := NewOkta(server.URL+"/", "token", server.Client())
+
+	user, err := driver.GetUser("jdoe@example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "SSWS token", gotAuth)
+	assert.Equal(t, &User{
+		ID:          "00u1",
+		UserName:    "jdoe@example.com",
+		Email:       "jdoe@example.com",
+		DisplayName: "Jane Doe",
+		Active:      false,
+		Groups:      []string{"Everyone", "Engineering"},
+	}, user)
+
+	manager, err := driver.GetManager(user)
+	assert.Nil(t, err)
+	assert.Equal(t, "boss@example.com", manager.Email)
+	assert.True(t, manager.Active)
+
+	manager, err = driver.GetManager(manager)
+	assert.Nil(t, err)
+	assert.Nil(t, manager)
+
+	user, err = driver.GetUser("nobody")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+}
+
+func TestOktaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errorCode": "E0000011"}`))
+	}))
+	defer server.Close()
+
+	driver := NewOkta(server.URL, "token", server.Client())
+	_, err := driver.GetUser("jdoe")
+
+	assert.EqualError(t, err, `okta GET /api/v1/users/jdoe failed (401 Unauthorized): {"errorCode": "E0000011"}`)
+}
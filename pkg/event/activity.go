@@ -0,0 +1,72 @@
+package event
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/activity/activityiface"
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// ActivityEvent is for recording events in the activity feed, so admins can
+// list what DCE did without searching the logs of every function
+type ActivityEvent struct {
+	activity  activityiface.Servicer
+	eventType string
+	actor     string
+}
+
+// Publish an event to the activity feed
+func (e *ActivityEvent) Publish(i interface{}) error {
+	dataJSON, err := json.Marshal(i)
+	if err != nil {
+		return errors.NewInternalServer("unable to marshal response", err)
+	}
+
+	resource, resourceID := activityResource(e.eventType, dataJSON)
+	return e.activity.Write(&activity.Event{
+		Type:       e.eventType,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Actor:      e.actor,
+		Data:       dataJSON,
+	})
+}
+
+// NewActivityEvent creates a new activity feed publisher for the given event
+// type.  The actor is the DCE component publishing the events.
+func NewActivityEvent(activitySvc activityiface.Servicer, eventType string, actor string) (*ActivityEvent, error) {
+
+	return &ActivityEvent{
+		activity:  activitySvc,
+		eventType: eventType,
+		actor:     actor,
+	}, nil
+}
+
+// activityResource is the kind and ID of the resource an event is about.
+// Updates are about their new version.
+func activityResource(eventType string, dataJSON []byte) (string, string) {
+	type ids struct {
+		ID string `json:"id"`
+	}
+	var data struct {
+		ids
+		New *ids `json:"new"`
+	}
+	_ = json.Unmarshal(dataJSON, &data)
+
+	id := data.ID
+	if data.New != nil {
+		id = data.New.ID
+	}
+	switch {
+	case eventType == AccountPoolLowType:
+		return activity.ResourcePool, ""
+	case strings.HasPrefix(eventType, "Lease"):
+		return activity.ResourceLease, id
+	default:
+		return activity.ResourceAccount, id
+	}
+}
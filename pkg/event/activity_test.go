@@ -0,0 +1,64 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/activity/activityiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActivityEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventType     string
+		event         interface{}
+		expResource   string
+		expResourceID string
+	}{
+		{
+			name:          "account",
+			eventType:     AccountCreatedType,
+			event:         &account.Account{ID: aws.String("123456789012")},
+			expResource:   activity.ResourceAccount,
+			expResourceID: "123456789012",
+		},
+		{
+			name:      "lease update",
+			eventType: LeaseUpdatedType,
+			event: updateEvent{
+				Old: &lease.Lease{ID: aws.String("abc")},
+				New: &lease.Lease{ID: aws.String("abc")},
+			},
+			expResource:   activity.ResourceLease,
+			expResourceID: "abc",
+		},
+		{
+			name:        "account pool",
+			eventType:   AccountPoolLowType,
+			event:       &account.Pool{Ready: 1},
+			expResource: activity.ResourcePool,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activitySvc := &mocks.Servicer{}
+			activitySvc.On("Write", mock.MatchedBy(func(e *activity.Event) bool {
+				return e.Type == tt.eventType && e.Resource == tt.expResource &&
+					e.ResourceID == tt.expResourceID && e.Actor == "leases" && len(e.Data) > 0
+			})).Return(nil)
+
+			activityEvent, err := NewActivityEvent(activitySvc, tt.eventType, "leases")
+			assert.Nil(t, err)
+
+			err = activityEvent.Publish(tt.event)
+			assert.Nil(t, err)
+			activitySvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// Event types published by DCE
+const (
+	AccountCreatedType        string = "AccountCreated"
+	AccountDeletedType        string = "AccountDeleted"
+	AccountUpdatedType        string = "AccountUpdated"
+	AccountStatusChangedType  string = "AccountStatusChanged"
+	AccountResetRequestedType string = "AccountResetRequested"
+	AccountPoolLowType        string = "AccountPoolLow"
+	ResetCompletedType        string = "ResetCompleted"
+	LeaseCreatedType          string = "LeaseCreated"
+	LeaseEndedType            string = "LeaseEnded"
+	LeaseUpdatedType          string = "LeaseUpdated"
+)
+
+// legacySchemaVersion is reported for payloads published before events were
+// wrapped in an Envelope
+const legacySchemaVersion string = "0"
+
+// SchemaVersions is the current schema version of each event type.
+// Bump the version of an event type whenever its payload changes in a way
+// which is not backwards compatible (eg. a field is renamed or removed), and
+// add a fixture for the new version under testdata/schemas
+var SchemaVersions = map[string]string{
+	AccountCreatedType:        "1",
+	AccountDeletedType:        "1",
+	AccountUpdatedType:        "1",
+	AccountStatusChangedType:  "1",
+	AccountResetRequestedType: "1",
+	AccountPoolLowType:        "1",
+	ResetCompletedType:        "1",
+	LeaseCreatedType:          "1",
+	LeaseEndedType:            "1",
+	LeaseUpdatedType:          "1",
+}
+
+// Envelope wraps every published event so consumers can tell which
+// type and version of payload they are handling
+type Envelope struct {
+	Type    string          `json:"type"`
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps the data in an Envelope at the current schema version
+// of the event type
+func NewEnvelope(eventType string, data interface{}) (*Envelope, error) {
+	version, ok := SchemaVersions[eventType]
+	if !ok {
+		return nil, errors.NewInternalServer(fmt.Sprintf("unknown event type %q", eventType), nil)
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal response", err)
+	}
+
+	return &Envelope{
+		Type:    eventType,
+		Version: version,
+		Data:    dataJSON,
+	}, nil
+}
+
+// Marshal returns the JSON of the data wrapped in an Envelope
+func Marshal(eventType string, data interface{}) ([]byte, error) {
+	envelope, err := NewEnvelope(eventType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeJSON, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal response", err)
+	}
+	return envelopeJSON, nil
+}
+
+// Unmarshal reads the data of an enveloped event into v and returns the
+// envelope. Payloads published before events were enveloped are read as-is
+// and reported with version "0". An error is returned if the payload is
+// newer than this version of DCE understands.
+func Unmarshal(body []byte, v interface{}) (*Envelope, error) {
+	envelope := &Envelope{}
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, errors.NewInternalServer("unable to unmarshal event", err)
+	}
+
+	if envelope.Version == "" || len(envelope.Data) == 0 {
+		envelope = &Envelope{
+			Version: legacySchemaVersion,
+			Data:    body,
+		}
+	} else if err := checkSchemaVersion(envelope); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(envelope.Data, v); err != nil {
+		return nil, errors.NewInternalServer("unable to unmarshal event data", err)
+	}
+	return envelope, nil
+}
+
+// checkSchemaVersion makes sure the envelope isn't newer than we know how to read
+func checkSchemaVersion(envelope *Envelope) error {
+	current, ok := SchemaVersions[envelope.Type]
+	if !ok {
+		return errors.NewInternalServer(fmt.Sprintf("unknown event type %q", envelope.Type), nil)
+	}
+
+	version, err := strconv.Atoi(envelope.Version)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("invalid version %q for event %q", envelope.Version, envelope.Type), err)
+	}
+	currentVersion, _ := strconv.Atoi(current)
+	if version > currentVersion {
+		return errors.NewInternalServer(
+			fmt.Sprintf("unsupported version %q for event %q, expected at most %q", envelope.Version, envelope.Type, current), nil)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/db"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+
+	type data struct {
+		Key string `json:"key"`
+	}
+
+	tests := []struct {
+		name        string
+		eventType   string
+		event       interface{}
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:      "should wrap the data in an envelope",
+			eventType: LeaseCreatedType,
+			event: data{
+				Key: "value",
+			},
+			expected: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+		},
+		{
+			name:        "should fail on an unknown event type",
+			eventType:   "Unknown",
+			event:       data{},
+			expectedErr: errors.NewInternalServer("unknown event type \"Unknown\"", nil),
+		},
+		{
+			name:        "should fail on a marshal error",
+			eventType:   LeaseCreatedType,
+			event:       math.Inf(1),
+			expectedErr: errors.NewInternalServer("unable to marshal response", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := Marshal(tt.eventType, tt.event)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+
+	type data struct {
+		Key string `json:"key"`
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		expected        data
+		expectedType    string
+		expectedVersion string
+		expectedErr     error
+	}{
+		{
+			name:            "should read enveloped data",
+			body:            "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expected:        data{Key: "value"},
+			expectedType:    LeaseCreatedType,
+			expectedVersion: "1",
+		},
+		{
+			name:            "should read legacy data",
+			body:            "{\"key\":\"value\"}",
+			expected:        data{Key: "value"},
+			expectedVersion: "0",
+		},
+		{
+			name:        "should fail on a newer version",
+			body:        "{\"type\":\"LeaseCreated\",\"version\":\"2\",\"data\":{\"key\":\"value\"}}",
+			expectedErr: errors.NewInternalServer("unsupported version \"2\" for event \"LeaseCreated\", expected at most \"1\"", nil),
+		},
+		{
+			name:        "should fail on an unknown event type",
+			body:        "{\"type\":\"Unknown\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr: errors.NewInternalServer("unknown event type \"Unknown\"", nil),
+		},
+		{
+			name:        "should fail on invalid JSON",
+			body:        "{\"key\",\"value\"}",
+			expectedErr: errors.NewInternalServer("unable to unmarshal event", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := data{}
+			envelope, err := Unmarshal([]byte(tt.body), &actual)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expectedType, envelope.Type)
+			assert.Equal(t, tt.expectedVersion, envelope.Version)
+		})
+	}
+}
+
+// TestSchemaCompatibility makes sure every event type can still read the
+// payloads of each of its published versions, and that the current version
+// still publishes every field in its fixture.  If this test fails because a
+// field was renamed or removed, bump the version in SchemaVersions and add a
+// new fixture, rather than editing an existing one.
+func TestSchemaCompatibility(t *testing.T) {
+
+	type accountUpdate struct {
+		Old *account.Account `json:"old"`
+		New *account.Account `json:"new"`
+	}
+	type leaseUpdate struct {
+		Old *lease.Lease `json:"old"`
+		New *lease.Lease `json:"new"`
+	}
+
+	payloads := map[string]func() interface{}{
+		AccountCreatedType:        func() interface{} { return &account.Account{} },
+		AccountDeletedType:        func() interface{} { return &account.Account{} },
+		AccountUpdatedType:        func() interface{} { return &accountUpdate{} },
+		AccountStatusChangedType:  func() interface{} { return &accountUpdate{} },
+		AccountResetRequestedType: func() interface{} { return &account.Account{} },
+		AccountPoolLowType:        func() interface{} { return &account.Pool{} },
+		ResetCompletedType:        func() interface{} { return &db.Account{} },
+		LeaseCreatedType:          func() interface{} { return &lease.Lease{} },
+		LeaseEndedType:            func() interface{} { return &lease.Lease{} },
+		LeaseUpdatedType:          func() interface{} { return &leaseUpdate{} },
+	}
+
+	for eventType, current := range SchemaVersions {
+		newPayload, ok := payloads[eventType]
+		require.True(t, ok, "no payload type registered for %q", eventType)
+
+		currentVersion, err := strconv.Atoi(current)
+		require.Nil(t, err)
+
+		for version := 1; version <= currentVersion; version++ {
+			t.Run(fmt.Sprintf("%s v%d", eventType, version), func(t *testing.T) {
+				fixture, err := ioutil.ReadFile(
+					filepath.Join("testdata", "schemas", fmt.Sprintf("%s.v%d.json", eventType, version)))
+				require.Nil(t, err, "missing fixture for %s v%d", eventType, version)
+
+				payload := newPayload()
+				envelope, err := Unmarshal(fixture, payload)
+				require.Nil(t, err)
+				assert.Equal(t, eventType, envelope.Type)
+				assert.Equal(t, strconv.Itoa(version), envelope.Version)
+
+				if version != currentVersion {
+					return
+				}
+
+				published, err := Marshal(eventType, payload)
+				require.Nil(t, err)
+
+				var expected, actual map[string]interface{}
+				require.Nil(t, json.Unmarshal(fixture, &expected))
+				require.Nil(t, json.Unmarshal(published, &actual))
+				assertContainsJSON(t, "", expected, actual)
+			})
+		}
+	}
+}
+
+// assertContainsJSON checks every value in expected is in actual.  New fields
+// in actual are allowed, as adding a field is backwards compatible.
+func assertContainsJSON(t *testing.T, path string, expected interface{}, actual interface{}) {
+	expectedObj, ok := expected.(map[string]interface{})
+	if !ok {
+		assert.Equal(t, expected, actual, "field %q changed", path)
+		return
+	}
+
+	actualObj, ok := actual.(map[string]interface{})
+	if !assert.True(t, ok, "field %q is no longer an object", path) {
+		return
+	}
+	for k, v := range expectedObj {
+		fieldPath := path + "." + k
+		actualVal, ok := actualObj[k]
+		if !assert.True(t, ok, "field %q was removed or renamed", fieldPath) {
+			continue
+		}
+		assertContainsJSON(t, fieldPath, v, actualVal)
+	}
+}
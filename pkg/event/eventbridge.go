@@ -0,0 +1,52 @@
+package event
+
+import (
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+)
+
+// EventBridgeEvent is for publishing events to an EventBridge bus
+type EventBridgeEvent struct {
+	eb         eventbridgeiface.EventBridgeAPI
+	busName    *string
+	detailType *string
+}
+
+// Publish an event to the bus
+func (e *EventBridgeEvent) Publish(i interface{}) error {
+	bodyJSON, err := Marshal(*e.detailType, i)
+	if err != nil {
+		return err
+	}
+
+	// Send the message
+	out, err := e.eb.PutEvents(&eventbridge.PutEventsInput{
+		Entries: []*eventbridge.PutEventsRequestEntry{
+			{
+				Detail:       aws.String(string(bodyJSON)),
+				DetailType:   e.detailType,
+				EventBusName: e.busName,
+				Source:       aws.String(cweSource),
+			},
+		},
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to publish message to EventBridge", err)
+	}
+	if out != nil && out.FailedEntryCount != nil && *out.FailedEntryCount > 0 {
+		return errors.NewInternalServer("failed to publish message to EventBridge", nil)
+	}
+	return nil
+}
+
+// NewEventBridgeEvent creates a new EventBridge publisher for the given bus and detail type
+func NewEventBridgeEvent(eb eventbridgeiface.EventBridgeAPI, busName string, detailType string) (*EventBridgeEvent, error) {
+
+	return &EventBridgeEvent{
+		eb:         eb,
+		busName:    &busName,
+		detailType: &detailType,
+	}, nil
+}
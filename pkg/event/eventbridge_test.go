@@ -0,0 +1,96 @@
+package event
+
+import (
+	gErrors "errors"
+	"math"
+	"testing"
+
+	"github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBridge(t *testing.T) {
+
+	type data struct {
+		Key string `json:"key"`
+	}
+
+	tests := []struct {
+		name            string
+		ebErr           error
+		ebOutput        *eventbridge.PutEventsOutput
+		event           interface{}
+		expectedErr     error
+		expectedMessage string
+	}{
+		{
+			name: "publish EventBridge event",
+			event: data{
+				Key: "value",
+			},
+			ebOutput:        &eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(0)},
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     nil,
+		},
+		{
+			name:  "publish EventBridge error",
+			ebErr: gErrors.New("error"),
+			event: data{
+				Key: "value",
+			},
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     errors.NewInternalServer("failed to publish message to EventBridge", nil),
+		},
+		{
+			name: "publish EventBridge failed entry",
+			event: data{
+				Key: "value",
+			},
+			ebOutput:        &eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(1)},
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     errors.NewInternalServer("failed to publish message to EventBridge", nil),
+		},
+		{
+			name:        "unmarshal error",
+			event:       math.Inf(1),
+			expectedErr: errors.NewInternalServer("unable to marshal response", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEb := &mocks.EventBridgeAPI{}
+			eventer, _ := NewEventBridgeEvent(mockEb, "dce-bus", LeaseCreatedType)
+
+			// Mock Publish call
+			mockEb.On("PutEvents",
+				&eventbridge.PutEventsInput{
+					Entries: []*eventbridge.PutEventsRequestEntry{
+						{
+							Detail:       &tt.expectedMessage,
+							DetailType:   aws.String(LeaseCreatedType),
+							EventBusName: aws.String("dce-bus"),
+							Source:       aws.String("dce"),
+						},
+					},
+				},
+			).Return(tt.ebOutput, tt.ebErr)
+
+			err := eventer.Publish(tt.event)
+			if tt.expectedMessage != "" {
+				mockEb.AssertExpectations(t)
+			}
+
+			if err != nil {
+				assert.Equal(t, tt.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, tt.expectedErr)
+			}
+
+		})
+	}
+
+}
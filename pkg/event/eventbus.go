@@ -1,8 +1,6 @@
 package event
 
 import (
-	"encoding/json"
-
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
@@ -21,9 +19,9 @@ type CloudWatchEvent struct {
 
 // Publish an event to the topic
 func (c *CloudWatchEvent) Publish(i interface{}) error {
-	bodyJSON, err := json.Marshal(i)
+	bodyJSON, err := Marshal(*c.detailType, i)
 	if err != nil {
-		return errors.NewInternalServer("unable to marshal response", err)
+		return err
 	}
 
 	// Send the message
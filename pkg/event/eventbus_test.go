@@ -31,7 +31,7 @@ func TestCwe(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessage: "{\"key\":\"value\"}",
+			expectedMessage: "{\"type\":\"AccountUpdated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
 			expectedErr:     nil,
 		},
 		{
@@ -40,7 +40,7 @@ func TestCwe(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessage: "{\"key\":\"value\"}",
+			expectedMessage: "{\"type\":\"AccountUpdated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
 			expectedErr:     errors.NewInternalServer("failed to publish message to CloudWatch Event Bus", nil),
 		},
 		{
@@ -54,7 +54,7 @@ func TestCwe(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCwe := &mocks.CloudWatchEventsAPI{}
-			eventer, _ := NewCloudWatchEvent(mockCwe, "emit")
+			eventer, _ := NewCloudWatchEvent(mockCwe, AccountUpdatedType)
 
 			// Mock Publish call
 			mockCwe.On("PutEvents",
@@ -62,7 +62,7 @@ func TestCwe(t *testing.T) {
 					Entries: []*cloudwatchevents.PutEventsRequestEntry{
 						{
 							Detail:     &tt.expectedMessage,
-							DetailType: aws.String("emit"),
+							DetailType: aws.String(AccountUpdatedType),
 							Source:     aws.String("dce"),
 						},
 					},
@@ -3,7 +3,6 @@
 package mocks
 
 import account "github.com/Optum/dce/pkg/account"
-
 import lease "github.com/Optum/dce/pkg/lease"
 import mock "github.com/stretchr/testify/mock"
 
@@ -40,6 +39,20 @@ func (_m *Servicer) AccountDelete(data *account.Account) error {
 	return r0
 }
 
+// AccountPoolLow provides a mock function with given fields: data
+func (_m *Servicer) AccountPoolLow(data *account.Pool) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.Pool) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AccountReset provides a mock function with given fields: data
 func (_m *Servicer) AccountReset(data *account.Account) error {
 	ret := _m.Called(data)
@@ -54,6 +67,20 @@ func (_m *Servicer) AccountReset(data *account.Account) error {
 	return r0
 }
 
+// AccountResetBatch provides a mock function with given fields: data
+func (_m *Servicer) AccountResetBatch(data []*account.Account) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*account.Account) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AccountUpdate provides a mock function with given fields: old, new
 func (_m *Servicer) AccountUpdate(old *account.Account, new *account.Account) error {
 	ret := _m.Called(old, new)
@@ -17,6 +17,10 @@ type Servicer interface {
 	AccountUpdate(old *account.Account, new *account.Account) error
 	// AccountReset publish events
 	AccountReset(data *account.Account) error
+	// AccountResetBatch publish events for several accounts
+	AccountResetBatch(data []*account.Account) error
+	// AccountPoolLow publish events
+	AccountPoolLow(data *account.Pool) error
 	// LeaseCreate publish events
 	LeaseCreate(data *lease.Lease) error
 	// LeaseEnd publish events
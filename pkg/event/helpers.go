@@ -1,6 +1,38 @@
 package event
 
+import "encoding/json"
+
 type updateEvent struct {
 	Old interface{} `json:"old"`
 	New interface{} `json:"new"`
 }
+
+// partitionKey is the ID of the account an event is about, so that streams
+// which are partitioned by key keep the events of each account in order.
+// Events which aren't about an account are keyed by their type
+func partitionKey(eventType string, bodyJSON []byte) string {
+	type ids struct {
+		ID        string `json:"id"`
+		AccountID string `json:"accountId"`
+	}
+	var envelope struct {
+		Data struct {
+			ids
+			New *ids `json:"new"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(bodyJSON, &envelope)
+
+	data := envelope.Data.ids
+	if envelope.Data.New != nil {
+		data = *envelope.Data.New
+	}
+	// Leases have their own ID, so prefer the account ID
+	if data.AccountID != "" {
+		return data.AccountID
+	}
+	if data.ID != "" {
+		return data.ID
+	}
+	return eventType
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// kafkaContentType is the content type of JSON records sent to the
+// Kafka REST Proxy API v2
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaEvent is for publishing events to a Kafka topic, such as an Amazon MSK
+// topic, through a Kafka REST Proxy.  Records are keyed by account ID, so the
+// events of each account stay in order
+type KafkaEvent struct {
+	httpClient *http.Client
+	// topicURL is the REST Proxy URL of the topic, eg.
+	// https://kafka-rest.example.com/topics/dce-events
+	topicURL  string
+	auth      string
+	eventType string
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish an event to the topic
+func (e *KafkaEvent) Publish(i interface{}) error {
+	bodyJSON, err := Marshal(e.eventType, i)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(kafkaRecords{
+		Records: []kafkaRecord{{
+			Key:   partitionKey(e.eventType, bodyJSON),
+			Value: bodyJSON,
+		}},
+	})
+	if err != nil {
+		return errors.NewInternalServer("unable to marshal response", err)
+	}
+
+	err = e.produce(payload)
+	if err != nil {
+		return errors.NewInternalServer("failed to publish message to Kafka", err)
+	}
+	return nil
+}
+
+// produce sends records to the REST Proxy, which reports the failure of
+// each record separately
+func (e *KafkaEvent) produce(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, e.topicURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if e.auth != "" {
+		parts := strings.SplitN(e.auth, ":", 2)
+		if len(parts) == 2 {
+			req.SetBasicAuth(parts[0], parts[1])
+		} else {
+			req.Header.Set("Authorization", "Bearer "+e.auth)
+		}
+	}
+
+	res, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("kafka POST %s failed (%s): %s", req.URL.Path, res.Status, resBody)
+	}
+
+	offsets := kafkaOffsets{}
+	err = json.Unmarshal(resBody, &offsets)
+	if err != nil {
+		return err
+	}
+	for _, offset := range offsets.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka record failed (%d): %s", *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
+
+// NewKafkaEvent creates a new Kafka publisher for the given REST Proxy topic
+// URL and event type.  auth is either "username:password" for basic
+// authentication, or a bearer token, and may be empty
+func NewKafkaEvent(topicURL string, auth string, eventType string) (*KafkaEvent, error) {
+
+	return &KafkaEvent{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		topicURL:  topicURL,
+		auth:      auth,
+		eventType: eventType,
+	}, nil
+}
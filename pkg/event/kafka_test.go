@@ -0,0 +1,74 @@
+package event
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafka(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "dce", user)
+		assert.Equal(t, "secret", pass)
+		reqBody, _ := ioutil.ReadAll(r.Body)
+		body = string(reqBody)
+		switch r.URL.Path {
+		case "/topics/dce-events":
+			_, _ = w.Write([]byte(`{"offsets": [{"partition": 0, "offset": 42}]}`))
+		case "/topics/full":
+			_, _ = w.Write([]byte(`{"offsets": [{"error_code": 50003, "error": "record too large"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40401, "message": "Topic not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		topic       string
+		expectedErr string
+	}{
+		{
+			name:  "publish event",
+			topic: "dce-events",
+		},
+		{
+			name:        "publish record error",
+			topic:       "full",
+			expectedErr: "failed to publish message to Kafka",
+		},
+		{
+			name:        "publish unknown topic",
+			topic:       "missing",
+			expectedErr: "failed to publish message to Kafka",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kafkaEvent, err := NewKafkaEvent(server.URL+"/topics/"+tt.topic, "dce:secret", LeaseCreatedType)
+			assert.Nil(t, err)
+
+			err = kafkaEvent.Publish(&lease.Lease{
+				ID:        aws.String("abc"),
+				AccountID: aws.String("123456789012"),
+			})
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, `{"records":[{"key":"123456789012","value":{"type":"LeaseCreated","version":"1","data":{"accountId":"123456789012","id":"abc"}}}]}`, body)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package event
+
+import (
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+)
+
+// KinesisEvent is for publishing events to a Kinesis data stream.  Records
+// are keyed by account ID, so the events of each account stay in order
+type KinesisEvent struct {
+	kinesis    kinesisiface.KinesisAPI
+	streamName *string
+	eventType  string
+}
+
+// Publish an event to the stream
+func (e *KinesisEvent) Publish(i interface{}) error {
+	bodyJSON, err := Marshal(e.eventType, i)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.kinesis.PutRecord(&kinesis.PutRecordInput{
+		StreamName:   e.streamName,
+		PartitionKey: aws.String(partitionKey(e.eventType, bodyJSON)),
+		Data:         bodyJSON,
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to publish message to Kinesis", err)
+	}
+	return nil
+}
+
+// NewKinesisEvent creates a new Kinesis publisher for the given stream and event type
+func NewKinesisEvent(kinesisClient kinesisiface.KinesisAPI, streamName string, eventType string) (*KinesisEvent, error) {
+
+	return &KinesisEvent{
+		kinesis:    kinesisClient,
+		streamName: &streamName,
+		eventType:  eventType,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package event
+
+import (
+	gErrors "errors"
+	"math"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestKinesis(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		kinesisErr      error
+		event           interface{}
+		expectedErr     error
+		expectedKey     string
+		expectedMessage string
+	}{
+		{
+			name: "publish lease event",
+			event: &lease.Lease{
+				ID:        aws.String("abc"),
+				AccountID: aws.String("123456789012"),
+			},
+			expectedKey:     "123456789012",
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"accountId\":\"123456789012\",\"id\":\"abc\"}}",
+		},
+		{
+			name: "publish account update event",
+			event: updateEvent{
+				Old: &account.Account{ID: aws.String("123456789012")},
+				New: &account.Account{ID: aws.String("123456789012")},
+			},
+			expectedKey:     "123456789012",
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"old\":{\"id\":\"123456789012\"},\"new\":{\"id\":\"123456789012\"}}}",
+		},
+		{
+			name:            "publish Kinesis error",
+			kinesisErr:      gErrors.New("error"),
+			event:           map[string]string{"key": "value"},
+			expectedKey:     "LeaseCreated",
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     errors.NewInternalServer("failed to publish message to Kinesis", nil),
+		},
+		{
+			name:        "unmarshal error",
+			event:       math.Inf(1),
+			expectedErr: errors.NewInternalServer("unable to marshal response", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockKinesis := &mocks.KinesisAPI{}
+			mockKinesis.On("PutRecord", mock.MatchedBy(func(input *kinesis.PutRecordInput) bool {
+				return *input.StreamName == "dce-events" &&
+					*input.PartitionKey == tt.expectedKey &&
+					string(input.Data) == tt.expectedMessage
+			})).Return(&kinesis.PutRecordOutput{}, tt.kinesisErr)
+
+			kinesisEvent, err := NewKinesisEvent(mockKinesis, "dce-events", LeaseCreatedType)
+			assert.Nil(t, err)
+
+			err = kinesisEvent.Publish(tt.event)
+			assert.True(t, errors.Is(err, tt.expectedErr), "actual error %q doesn't match expected error %q", err, tt.expectedErr)
+			if tt.expectedMessage != "" {
+				mockKinesis.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/google/uuid"
+)
+
+// S3ArchiveEvent is for archiving events to an S3 bucket.  Each event is
+// written to its own object, partitioned by event type and date, eg.
+// events/type=LeaseCreated/date=2020-01-02/150405.000000000-<uuid>.json
+type S3ArchiveEvent struct {
+	s3        s3iface.S3API
+	bucket    *string
+	eventType string
+	now       func() time.Time
+}
+
+// Publish an event to the archive
+func (e *S3ArchiveEvent) Publish(i interface{}) error {
+	bodyJSON, err := Marshal(e.eventType, i)
+	if err != nil {
+		return err
+	}
+
+	now := e.now().UTC()
+	key := fmt.Sprintf("events/type=%s/date=%s/%s-%s.json",
+		e.eventType, now.Format("2006-01-02"), now.Format("150405.000000000"), uuid.New().String())
+
+	_, err = e.s3.PutObject(&s3.PutObjectInput{
+		Bucket:      e.bucket,
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(bodyJSON),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to archive event to S3", err)
+	}
+	return nil
+}
+
+// NewS3ArchiveEvent creates a new S3 archive publisher for the given bucket and event type
+func NewS3ArchiveEvent(s3Client s3iface.S3API, bucket string, eventType string) (*S3ArchiveEvent, error) {
+
+	return &S3ArchiveEvent{
+		s3:        s3Client,
+		bucket:    &bucket,
+		eventType: eventType,
+		now:       time.Now,
+	}, nil
+}
+
+// Publishers publishes an event to each publisher in turn, stopping at the
+// first error
+type Publishers []Publisher
+
+// Publish an event to every publisher
+func (p Publishers) Publish(i interface{}) error {
+	for _, n := range p {
+		err := n.Publish(i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
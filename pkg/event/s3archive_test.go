@@ -0,0 +1,106 @@
+package event
+
+import (
+	gErrors "errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestS3Archive(t *testing.T) {
+
+	type data struct {
+		Key string `json:"key"`
+	}
+
+	tests := []struct {
+		name            string
+		s3Err           error
+		event           interface{}
+		expectedErr     error
+		expectedMessage string
+	}{
+		{
+			name: "archive event",
+			event: data{
+				Key: "value",
+			},
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     nil,
+		},
+		{
+			name:  "archive S3 error",
+			s3Err: gErrors.New("error"),
+			event: data{
+				Key: "value",
+			},
+			expectedMessage: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:     errors.NewInternalServer("failed to archive event to S3", nil),
+		},
+		{
+			name:        "unmarshal error",
+			event:       math.Inf(1),
+			expectedErr: errors.NewInternalServer("unable to marshal response", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockS3 := &mocks.S3API{}
+			mockS3.On("PutObject", mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+				_, _ = input.Body.Seek(0, io.SeekStart)
+				body, _ := ioutil.ReadAll(input.Body)
+				return *input.Bucket == "dce-events" &&
+					regexp.MustCompile(`^events/type=LeaseCreated/date=2020-01-02/150405\.000000007-[0-9a-f-]{36}\.json$`).MatchString(*input.Key) &&
+					*input.ContentType == "application/json" &&
+					string(body) == tt.expectedMessage
+			})).Return(&s3.PutObjectOutput{}, tt.s3Err)
+
+			archiveEvent, err := NewS3ArchiveEvent(mockS3, "dce-events", LeaseCreatedType)
+			assert.Nil(t, err)
+			archiveEvent.now = func() time.Time {
+				return time.Date(2020, 1, 2, 15, 4, 5, 7, time.UTC)
+			}
+
+			err = archiveEvent.Publish(tt.event)
+			assert.True(t, errors.Is(err, tt.expectedErr), "actual error %q doesn't match expected error %q", err, tt.expectedErr)
+			if tt.expectedMessage != "" {
+				mockS3.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestPublishers(t *testing.T) {
+	published := []string{}
+	publisher := func(name string, err error) Publisher {
+		p := &testPublisher{}
+		p.publish = func(i interface{}) error {
+			published = append(published, name)
+			return err
+		}
+		return p
+	}
+
+	err := Publishers{publisher("a", nil), publisher("b", gErrors.New("error")), publisher("c", nil)}.Publish("event")
+
+	assert.EqualError(t, err, "error")
+	assert.Equal(t, []string{"a", "b"}, published)
+}
+
+type testPublisher struct {
+	publish func(i interface{}) error
+}
+
+func (p *testPublisher) Publish(i interface{}) error {
+	return p.publish(i)
+}
@@ -1,27 +1,82 @@
 package event
 
 import (
+	"fmt"
+
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/activity"
+	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 )
 
+// Event sink drivers, used by the EVENT_SINK_DRIVER setting
+const (
+	EventSinkKinesis = "kinesis"
+	EventSinkKafka   = "kafka"
+)
+
 // Publisher interface defines anything that can publish an event
 type Publisher interface {
 	Publish(i interface{}) error
 }
 
+// BatchPublisher can publish several events at once
+type BatchPublisher interface {
+	PublishBatch(items []interface{}) error
+}
+
 // NewServiceInput are the items required to create a new Eventer service
 type NewServiceInput struct {
 	SnsClient              snsiface.SNSAPI
 	SqsClient              sqsiface.SQSAPI
 	CweClient              cloudwatcheventsiface.CloudWatchEventsAPI
+	EbClient               eventbridgeiface.EventBridgeAPI
 	AccountCreatedTopicArn string `env:"ACCOUNT_CREATED_TOPIC_ARN" envDefault:"arn:aws:sns:us-east-1:123456789012:account-create"`
 	AccountDeletedTopicArn string `env:"ACCOUNT_DELETED_TOPIC_ARN" envDefault:"arn:aws:sns:us-east-1:123456789012:account-delete"`
 	AccountResetQueueURL   string `env:"RESET_SQS_URL" envDefault:"DefaultResetSQSUrl"`
 	LeaseAddedTopicArn     string `env:"LEASE_ADDED_TOPIC" envDefault:"arn:aws:sns:us-east-1:123456789012:lease-added"`
+	// EventBusName is the EventBridge bus to publish domain events to.  Publishing
+	// to EventBridge is disabled when empty
+	EventBusName string `env:"EVENT_BUS_NAME" envDefault:""`
+	SfnClient    sfniface.SFNAPI
+	S3Client     s3iface.S3API
+	// EventArchiveBucket is the S3 bucket every domain event is archived to.
+	// Archiving is disabled when empty
+	EventArchiveBucket string `env:"EVENT_ARCHIVE_BUCKET" envDefault:""`
+	KinesisClient      kinesisiface.KinesisAPI
+	// EventSinkDriver mirrors every domain event to a Kinesis stream or Kafka
+	// topic.  Mirroring is disabled when empty
+	EventSinkDriver string `env:"EVENT_SINK_DRIVER" envDefault:""`
+	// EventSinkTarget is the name of the Kinesis stream, or the Kafka REST
+	// Proxy URL of the topic, eg. https://kafka-rest.example.com/topics/dce-events
+	EventSinkTarget string `env:"EVENT_SINK_TARGET" envDefault:""`
+	// EventSinkAuth authenticates with the Kafka REST Proxy, as
+	// "username:password" or a bearer token
+	EventSinkAuth string `env:"EVENT_SINK_AUTH" envDefault:""`
+	// LeaseProvisionStateMachineArn and LeaseTeardownStateMachineArn are the
+	// state machines started when a lease is created or ended.  Each is
+	// disabled when empty
+	LeaseProvisionStateMachineArn string `env:"LEASE_PROVISION_STATE_MACHINE_ARN" envDefault:""`
+	LeaseTeardownStateMachineArn  string `env:"LEASE_TEARDOWN_STATE_MACHINE_ARN" envDefault:""`
+	// AccountWarmUpStateMachineArn is the state machine started when an
+	// account is added to the pool.  It's disabled when empty
+	AccountWarmUpStateMachineArn string `env:"ACCOUNT_WARM_UP_STATE_MACHINE_ARN" envDefault:""`
+	DynamoDBClient               dynamodbiface.DynamoDBAPI
+	// EventTableName is the DynamoDB table of the activity feed, which every
+	// domain event is recorded in.  Recording is disabled when empty
+	EventTableName     string `env:"EVENT_TABLE_NAME" envDefault:""`
+	EventRetentionDays int    `env:"EVENT_RETENTION_DAYS" envDefault:"90"`
+	// Actor is the DCE component publishing events, recorded in the
+	// activity feed
+	Actor string `env:"AWS_LAMBDA_FUNCTION_NAME" envDefault:""`
 }
 
 // Service is the public interface for publishing events
@@ -30,6 +85,8 @@ type Service struct {
 	accountDelete []Publisher
 	accountUpdate []Publisher
 	accountReset  []Publisher
+	accountStatus []Publisher
+	accountPool   []Publisher
 	leaseCreate   []Publisher
 	leaseEnd      []Publisher
 	leaseUpdate   []Publisher
@@ -57,12 +114,20 @@ func (e *Service) AccountDelete(data *account.Account) error {
 
 // AccountUpdate publish events
 func (e *Service) AccountUpdate(old *account.Account, new *account.Account) error {
-	return e.publish(
-		updateEvent{
-			Old: old,
-			New: new,
-		}, e.accountUpdate...,
-	)
+	update := updateEvent{
+		Old: old,
+		New: new,
+	}
+	err := e.publish(update, e.accountUpdate...)
+	if err != nil {
+		return err
+	}
+
+	if old != nil && new != nil && old.Status != nil && new.Status != nil &&
+		*old.Status != *new.Status {
+		return e.publish(update, e.accountStatus...)
+	}
+	return nil
 }
 
 // AccountReset publish events
@@ -70,6 +135,41 @@ func (e *Service) AccountReset(data *account.Account) error {
 	return e.publish(data, e.accountReset...)
 }
 
+// AccountPoolLow publish events
+func (e *Service) AccountPoolLow(data *account.Pool) error {
+	return e.publish(data, e.accountPool...)
+}
+
+// publishBatch publishes several events, in batches when the publisher
+// supports them
+func (e *Service) publishBatch(items []interface{}, p ...Publisher) error {
+	for _, n := range p {
+		if b, ok := n.(BatchPublisher); ok {
+			err := b.PublishBatch(items)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, i := range items {
+			err := n.Publish(i)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AccountResetBatch publish events for several accounts
+func (e *Service) AccountResetBatch(data []*account.Account) error {
+	items := make([]interface{}, len(data))
+	for i, d := range data {
+		items[i] = d
+	}
+	return e.publishBatch(items, e.accountReset...)
+}
+
 // LeaseCreate publish events
 func (e *Service) LeaseCreate(data *lease.Lease) error {
 	return e.publish(data, e.leaseCreate...)
@@ -97,12 +197,12 @@ func NewService(input NewServiceInput) (*Service, error) {
 	//////////////////////////////////////////////////////////////////////
 	// Account Eventing - SNS
 	//////////////////////////////////////////////////////////////////////
-	createAccountSns, err := NewSnsEvent(input.SnsClient, input.AccountCreatedTopicArn)
+	createAccountSns, err := NewSnsEvent(input.SnsClient, input.AccountCreatedTopicArn, AccountCreatedType)
 	if err != nil {
 		return nil, err
 	}
 
-	deleteAccountSns, err := NewSnsEvent(input.SnsClient, input.AccountDeletedTopicArn)
+	deleteAccountSns, err := NewSnsEvent(input.SnsClient, input.AccountDeletedTopicArn, AccountDeletedType)
 	if err != nil {
 		return nil, err
 	}
@@ -111,17 +211,22 @@ func NewService(input NewServiceInput) (*Service, error) {
 	// Account Eventing - CloudWatch Events
 	//////////////////////////////////////////////////////////////////////
 
-	createAccountCwe, err := NewCloudWatchEvent(input.CweClient, "AccountCreated")
+	createAccountCwe, err := NewCloudWatchEvent(input.CweClient, AccountCreatedType)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteAccountCwe, err := NewCloudWatchEvent(input.CweClient, AccountDeletedType)
 	if err != nil {
 		return nil, err
 	}
 
-	deleteAccountCwe, err := NewCloudWatchEvent(input.CweClient, "AccountDeleted")
+	updateAccountCwe, err := NewCloudWatchEvent(input.CweClient, AccountUpdatedType)
 	if err != nil {
 		return nil, err
 	}
 
-	updateAccountCwe, err := NewCloudWatchEvent(input.CweClient, "AccountUpdated")
+	poolLowCwe, err := NewCloudWatchEvent(input.CweClient, AccountPoolLowType)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +234,7 @@ func NewService(input NewServiceInput) (*Service, error) {
 	//////////////////////////////////////////////////////////////////////
 	// Account Eventing - SQS
 	//////////////////////////////////////////////////////////////////////
-	resetAccount, err := NewSqsEvent(input.SqsClient, input.AccountResetQueueURL)
+	resetAccount, err := NewSqsEvent(input.SqsClient, input.AccountResetQueueURL, AccountResetRequestedType)
 	if err != nil {
 		return nil, err
 	}
@@ -148,11 +253,14 @@ func NewService(input NewServiceInput) (*Service, error) {
 	newEventer.accountUpdate = []Publisher{
 		updateAccountCwe,
 	}
+	newEventer.accountPool = []Publisher{
+		poolLowCwe,
+	}
 
 	//////////////////////////////////////////////////////////////////////
 	// Lease Eventing - SNS
 	//////////////////////////////////////////////////////////////////////
-	createLease, err := NewSnsEvent(input.SnsClient, input.LeaseAddedTopicArn)
+	createLease, err := NewSnsEvent(input.SnsClient, input.LeaseAddedTopicArn, LeaseCreatedType)
 	if err != nil {
 		return nil, err
 	}
@@ -161,17 +269,17 @@ func NewService(input NewServiceInput) (*Service, error) {
 	// Account Eventing - CloudWatch Events
 	//////////////////////////////////////////////////////////////////////
 
-	createLeaseCwe, err := NewCloudWatchEvent(input.CweClient, "LeaseCreated")
+	createLeaseCwe, err := NewCloudWatchEvent(input.CweClient, LeaseCreatedType)
 	if err != nil {
 		return nil, err
 	}
 
-	endLeaseCwe, err := NewCloudWatchEvent(input.CweClient, "LeaseEnded")
+	endLeaseCwe, err := NewCloudWatchEvent(input.CweClient, LeaseEndedType)
 	if err != nil {
 		return nil, err
 	}
 
-	updateLeaseCwe, err := NewCloudWatchEvent(input.CweClient, "LeaseUpdated")
+	updateLeaseCwe, err := NewCloudWatchEvent(input.CweClient, LeaseUpdatedType)
 	if err != nil {
 		return nil, err
 	}
@@ -187,5 +295,187 @@ func NewService(input NewServiceInput) (*Service, error) {
 		updateLeaseCwe,
 	}
 
+	//////////////////////////////////////////////////////////////////////
+	// Lease Workflows - Step Functions
+	//////////////////////////////////////////////////////////////////////
+	if input.LeaseProvisionStateMachineArn != "" {
+		provisionLease, err := newStepFunctionEvent(input.SfnClient, input.LeaseProvisionStateMachineArn, LeaseCreatedType)
+		if err != nil {
+			return nil, err
+		}
+		newEventer.leaseCreate = append(newEventer.leaseCreate, provisionLease)
+	}
+	if input.LeaseTeardownStateMachineArn != "" {
+		teardownLease, err := newStepFunctionEvent(input.SfnClient, input.LeaseTeardownStateMachineArn, LeaseEndedType)
+		if err != nil {
+			return nil, err
+		}
+		newEventer.leaseEnd = append(newEventer.leaseEnd, teardownLease)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Account Warm-Up - Step Functions
+	//////////////////////////////////////////////////////////////////////
+	if input.AccountWarmUpStateMachineArn != "" {
+		warmUpAccount, err := newStepFunctionEvent(input.SfnClient, input.AccountWarmUpStateMachineArn, AccountCreatedType)
+		if err != nil {
+			return nil, err
+		}
+		newEventer.accountCreate = append(newEventer.accountCreate, warmUpAccount)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Domain Eventing - EventBridge
+	//////////////////////////////////////////////////////////////////////
+	if input.EventBusName != "" {
+		err = newEventer.withEventBridge(input.EbClient, input.EventBusName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Domain Eventing - Kinesis or Kafka
+	//////////////////////////////////////////////////////////////////////
+	if input.EventSinkDriver != "" {
+		err = newEventer.withSink(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Domain Eventing - Activity Feed
+	//////////////////////////////////////////////////////////////////////
+	if input.EventTableName != "" {
+		err = newEventer.withActivity(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Domain Eventing - S3 Archive
+	//////////////////////////////////////////////////////////////////////
+	if input.EventArchiveBucket != "" {
+		err = newEventer.withArchive(input.S3Client, input.EventArchiveBucket)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return newEventer, nil
 }
+
+// newStepFunctionEvent makes sure there is a client to start executions with
+func newStepFunctionEvent(sfn sfniface.SFNAPI, stateMachineArn string, eventType string) (*StepFunctionEvent, error) {
+	if sfn == nil {
+		return nil, errors.NewInternalServer("a Step Functions client is required to start "+stateMachineArn, nil)
+	}
+	return NewStepFunctionEvent(sfn, stateMachineArn, eventType)
+}
+
+// withEventBridge adds EventBridge publishers for every domain event
+func (e *Service) withEventBridge(eb eventbridgeiface.EventBridgeAPI, busName string) error {
+	if eb == nil {
+		return errors.NewInternalServer("an EventBridge client is required to publish to bus "+busName, nil)
+	}
+
+	for detailType, to := range e.publishers() {
+		ebEvent, err := NewEventBridgeEvent(eb, busName, detailType)
+		if err != nil {
+			return err
+		}
+		*to = append(*to, ebEvent)
+	}
+	return nil
+}
+
+// withSink adds Kinesis or Kafka publishers for every domain event
+func (e *Service) withSink(input NewServiceInput) error {
+	for eventType, to := range e.publishers() {
+		sinkEvent, err := NewSinkEvent(input.KinesisClient, input.EventSinkDriver, input.EventSinkTarget, input.EventSinkAuth, eventType)
+		if err != nil {
+			return err
+		}
+		*to = append(*to, sinkEvent)
+	}
+	return nil
+}
+
+// NewSinkEvent creates a Kinesis or Kafka publisher for the given event
+// type, depending on the driver.  The Kinesis client is only required by
+// the Kinesis driver
+func NewSinkEvent(kinesisClient kinesisiface.KinesisAPI, driver string, target string, auth string, eventType string) (Publisher, error) {
+	if target == "" {
+		return nil, errors.NewValidation("event", fmt.Errorf("an event sink target is required by the %s driver", driver))
+	}
+
+	switch driver {
+	case EventSinkKinesis:
+		if kinesisClient == nil {
+			return nil, errors.NewInternalServer("a Kinesis client is required to publish to stream "+target, nil)
+		}
+		return NewKinesisEvent(kinesisClient, target, eventType)
+	case EventSinkKafka:
+		return NewKafkaEvent(target, auth, eventType)
+	}
+	return nil, errors.NewValidation("event", fmt.Errorf("unknown event sink driver %q", driver))
+}
+
+// withArchive adds S3 archive publishers for every domain event.  Events are
+// archived after they are published everywhere else, so only events which
+// were published are archived
+func (e *Service) withArchive(s3Client s3iface.S3API, bucket string) error {
+	if s3Client == nil {
+		return errors.NewInternalServer("an S3 client is required to archive events to bucket "+bucket, nil)
+	}
+
+	for eventType, to := range e.publishers() {
+		archiveEvent, err := NewS3ArchiveEvent(s3Client, bucket, eventType)
+		if err != nil {
+			return err
+		}
+		*to = append(*to, archiveEvent)
+	}
+	return nil
+}
+
+// withActivity adds activity feed publishers for every domain event
+func (e *Service) withActivity(input NewServiceInput) error {
+	if input.DynamoDBClient == nil {
+		return errors.NewInternalServer("a DynamoDB client is required to record events in table "+input.EventTableName, nil)
+	}
+	activitySvc, err := activity.NewService(activity.NewServiceInput{
+		DynamoDB:      input.DynamoDBClient,
+		TableName:     input.EventTableName,
+		RetentionDays: input.EventRetentionDays,
+	})
+	if err != nil {
+		return err
+	}
+
+	for eventType, to := range e.publishers() {
+		activityEvent, err := NewActivityEvent(activitySvc, eventType, input.Actor)
+		if err != nil {
+			return err
+		}
+		*to = append(*to, activityEvent)
+	}
+	return nil
+}
+
+// publishers are the publishers of each domain event, by event type
+func (e *Service) publishers() map[string]*[]Publisher {
+	return map[string]*[]Publisher{
+		AccountCreatedType:        &e.accountCreate,
+		AccountDeletedType:        &e.accountDelete,
+		AccountUpdatedType:        &e.accountUpdate,
+		AccountStatusChangedType:  &e.accountStatus,
+		AccountResetRequestedType: &e.accountReset,
+		AccountPoolLowType:        &e.accountPool,
+		LeaseCreatedType:          &e.leaseCreate,
+		LeaseEndedType:            &e.leaseEnd,
+		LeaseUpdatedType:          &e.leaseUpdate,
+	}
+}
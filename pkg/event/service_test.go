@@ -38,8 +38,9 @@ func TestNewEvent(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, []Publisher{
 			&SnsEvent{
-				sns:      mockSns,
-				topicArn: accountCreatedTopicArn,
+				sns:       mockSns,
+				topicArn:  accountCreatedTopicArn,
+				eventType: AccountCreatedType,
 			},
 			&CloudWatchEvent{
 				cw:         mockCwe,
@@ -48,8 +49,9 @@ func TestNewEvent(t *testing.T) {
 		}, eventer.accountCreate)
 		assert.Equal(t, []Publisher{
 			&SnsEvent{
-				sns:      mockSns,
-				topicArn: accountDeletedTopicArn,
+				sns:       mockSns,
+				topicArn:  accountDeletedTopicArn,
+				eventType: AccountDeletedType,
 			},
 			&CloudWatchEvent{
 				cw:         mockCwe,
@@ -65,8 +67,9 @@ func TestNewEvent(t *testing.T) {
 		}, eventer.accountUpdate)
 		assert.Equal(t, []Publisher{
 			&SnsEvent{
-				sns:      mockSns,
-				topicArn: leaseAddedTopicArn,
+				sns:       mockSns,
+				topicArn:  leaseAddedTopicArn,
+				eventType: LeaseCreatedType,
 			},
 			&CloudWatchEvent{
 				cw:         mockCwe,
@@ -85,6 +88,294 @@ func TestNewEvent(t *testing.T) {
 				detailType: aws.String("LeaseEnded"),
 			},
 		}, eventer.leaseEnd)
+		assert.Empty(t, eventer.accountStatus)
+	})
+
+	t.Run("New Eventer with EventBridge", func(t *testing.T) {
+		mockEb := &awsMocks.EventBridgeAPI{}
+
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			SqsClient:              &awsMocks.SQSAPI{},
+			CweClient:              &awsMocks.CloudWatchEventsAPI{},
+			EbClient:               mockEb,
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			AccountResetQueueURL:   "http://sqs.com/queue",
+			EventBusName:           "dce-bus",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []Publisher{
+			&EventBridgeEvent{
+				eb:         mockEb,
+				busName:    aws.String("dce-bus"),
+				detailType: aws.String("AccountStatusChanged"),
+			},
+		}, eventer.accountStatus)
+		assert.Contains(t, eventer.leaseEnd, &EventBridgeEvent{
+			eb:         mockEb,
+			busName:    aws.String("dce-bus"),
+			detailType: aws.String("LeaseEnded"),
+		})
+		assert.Len(t, eventer.accountReset, 2)
+	})
+
+	t.Run("New Eventer with EventBridge but no client", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			EventBusName:           "dce-bus",
+		})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("New Eventer with an event archive", func(t *testing.T) {
+		mockS3 := &awsMocks.S3API{}
+
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			SqsClient:              &awsMocks.SQSAPI{},
+			CweClient:              &awsMocks.CloudWatchEventsAPI{},
+			EbClient:               &awsMocks.EventBridgeAPI{},
+			S3Client:               mockS3,
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			AccountResetQueueURL:   "http://sqs.com/queue",
+			EventBusName:           "dce-bus",
+			EventArchiveBucket:     "dce-events",
+		})
+
+		assert.Nil(t, err)
+		// Events are archived after they are published everywhere else
+		for eventType, publishers := range eventer.publishers() {
+			archiveEvent, ok := (*publishers)[len(*publishers)-1].(*S3ArchiveEvent)
+			assert.True(t, ok, eventType)
+			assert.Equal(t, eventType, archiveEvent.eventType)
+			assert.Equal(t, "dce-events", *archiveEvent.bucket)
+			assert.Equal(t, mockS3, archiveEvent.s3)
+		}
+	})
+
+	t.Run("New Eventer with an event archive but no client", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			EventArchiveBucket:     "dce-events",
+		})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("New Eventer with an event sink", func(t *testing.T) {
+		mockKinesis := &awsMocks.KinesisAPI{}
+		mockS3 := &awsMocks.S3API{}
+
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			SqsClient:              &awsMocks.SQSAPI{},
+			CweClient:              &awsMocks.CloudWatchEventsAPI{},
+			KinesisClient:          mockKinesis,
+			S3Client:               mockS3,
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			AccountResetQueueURL:   "http://sqs.com/queue",
+			EventSinkDriver:        "kinesis",
+			EventSinkTarget:        "dce-events",
+			EventArchiveBucket:     "dce-events",
+		})
+
+		assert.Nil(t, err)
+		// Events are mirrored to the sink before they are archived
+		for eventType, publishers := range eventer.publishers() {
+			kinesisEvent, ok := (*publishers)[len(*publishers)-2].(*KinesisEvent)
+			assert.True(t, ok, eventType)
+			assert.Equal(t, eventType, kinesisEvent.eventType)
+			assert.Equal(t, "dce-events", *kinesisEvent.streamName)
+			assert.Equal(t, mockKinesis, kinesisEvent.kinesis)
+		}
+	})
+
+	t.Run("New Eventer with an activity feed", func(t *testing.T) {
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			DynamoDBClient:         &awsMocks.DynamoDBAPI{},
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			EventTableName:         "Events",
+			Actor:                  "leases",
+		})
+
+		assert.Nil(t, err)
+		for eventType, publishers := range eventer.publishers() {
+			activityEvent, ok := (*publishers)[len(*publishers)-1].(*ActivityEvent)
+			assert.True(t, ok, eventType)
+			assert.Equal(t, eventType, activityEvent.eventType)
+			assert.Equal(t, "leases", activityEvent.actor)
+		}
+
+		_, err = NewService(NewServiceInput{
+			SnsClient:      &awsMocks.SNSAPI{},
+			EventTableName: "Events",
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("New Eventer with a Kafka event sink", func(t *testing.T) {
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			EventSinkDriver:        "kafka",
+			EventSinkTarget:        "https://kafka-rest.example.com/topics/dce-events",
+			EventSinkAuth:          "token",
+		})
+
+		assert.Nil(t, err)
+		kafkaEvent, ok := eventer.leaseEnd[len(eventer.leaseEnd)-1].(*KafkaEvent)
+		assert.True(t, ok)
+		assert.Equal(t, LeaseEndedType, kafkaEvent.eventType)
+		assert.Equal(t, "https://kafka-rest.example.com/topics/dce-events", kafkaEvent.topicURL)
+		assert.Equal(t, "token", kafkaEvent.auth)
+	})
+
+	t.Run("New Eventer with an invalid event sink", func(t *testing.T) {
+		input := NewServiceInput{
+			SnsClient:              &awsMocks.SNSAPI{},
+			AccountCreatedTopicArn: "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn: "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:     "arn:aws:sns:us-east-1:123456789012:createLease",
+			EventSinkDriver:        "pubsub",
+			EventSinkTarget:        "dce-events",
+		}
+		_, err := NewService(input)
+		assert.EqualError(t, err, "event validation error: unknown event sink driver \"pubsub\"")
+
+		input.EventSinkDriver = "kafka"
+		input.EventSinkTarget = ""
+		_, err = NewService(input)
+		assert.EqualError(t, err, "event validation error: an event sink target is required by the kafka driver")
+
+		input.EventSinkDriver = "kinesis"
+		input.EventSinkTarget = "dce-events"
+		_, err = NewService(input)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("New Eventer with lease workflows", func(t *testing.T) {
+		mockSfn := &awsMocks.SFNAPI{}
+
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:                     &awsMocks.SNSAPI{},
+			SqsClient:                     &awsMocks.SQSAPI{},
+			CweClient:                     &awsMocks.CloudWatchEventsAPI{},
+			SfnClient:                     mockSfn,
+			AccountCreatedTopicArn:        "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn:        "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:            "arn:aws:sns:us-east-1:123456789012:createLease",
+			AccountResetQueueURL:          "http://sqs.com/queue",
+			LeaseProvisionStateMachineArn: "arn:aws:states:us-east-1:123456789012:stateMachine:provision",
+			LeaseTeardownStateMachineArn:  "arn:aws:states:us-east-1:123456789012:stateMachine:teardown",
+		})
+
+		assert.Nil(t, err)
+		assert.Contains(t, eventer.leaseCreate, &StepFunctionEvent{
+			sfn:             mockSfn,
+			stateMachineArn: aws.String("arn:aws:states:us-east-1:123456789012:stateMachine:provision"),
+			eventType:       LeaseCreatedType,
+		})
+		assert.Contains(t, eventer.leaseEnd, &StepFunctionEvent{
+			sfn:             mockSfn,
+			stateMachineArn: aws.String("arn:aws:states:us-east-1:123456789012:stateMachine:teardown"),
+			eventType:       LeaseEndedType,
+		})
+	})
+
+	t.Run("New Eventer with account warm-up", func(t *testing.T) {
+		mockSfn := &awsMocks.SFNAPI{}
+
+		eventer, err := NewService(NewServiceInput{
+			SnsClient:                    &awsMocks.SNSAPI{},
+			SqsClient:                    &awsMocks.SQSAPI{},
+			CweClient:                    &awsMocks.CloudWatchEventsAPI{},
+			SfnClient:                    mockSfn,
+			AccountCreatedTopicArn:       "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn:       "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:           "arn:aws:sns:us-east-1:123456789012:createLease",
+			AccountResetQueueURL:         "http://sqs.com/queue",
+			AccountWarmUpStateMachineArn: "arn:aws:states:us-east-1:123456789012:stateMachine:warm-up",
+		})
+
+		assert.Nil(t, err)
+		assert.Contains(t, eventer.accountCreate, &StepFunctionEvent{
+			sfn:             mockSfn,
+			stateMachineArn: aws.String("arn:aws:states:us-east-1:123456789012:stateMachine:warm-up"),
+			eventType:       AccountCreatedType,
+		})
+	})
+
+	t.Run("New Eventer with lease workflows but no client", func(t *testing.T) {
+		_, err := NewService(NewServiceInput{
+			SnsClient:                     &awsMocks.SNSAPI{},
+			AccountCreatedTopicArn:        "arn:aws:sns:us-east-1:123456789012:createAccount",
+			AccountDeletedTopicArn:        "arn:aws:sns:us-east-1:123456789012:deleteAccount",
+			LeaseAddedTopicArn:            "arn:aws:sns:us-east-1:123456789012:createLease",
+			LeaseProvisionStateMachineArn: "arn:aws:states:us-east-1:123456789012:stateMachine:provision",
+		})
+
+		assert.NotNil(t, err)
+	})
+
+}
+
+func TestEventAccountStatusChange(t *testing.T) {
+
+	t.Run("publish status change when the status differs", func(t *testing.T) {
+		old := &account.Account{Status: account.StatusNotReady.StatusPtr()}
+		new := &account.Account{Status: account.StatusReady.StatusPtr()}
+		update := updateEvent{Old: old, New: new}
+
+		mockUpdate := mocks.Publisher{}
+		mockUpdate.On("Publish", update).Return(nil)
+		mockStatus := mocks.Publisher{}
+		mockStatus.On("Publish", update).Return(nil)
+
+		eventSvc := Service{
+			accountUpdate: []Publisher{&mockUpdate},
+			accountStatus: []Publisher{&mockStatus},
+		}
+
+		err := eventSvc.AccountUpdate(old, new)
+		assert.Nil(t, err)
+		mockUpdate.AssertExpectations(t)
+		mockStatus.AssertExpectations(t)
+	})
+
+	t.Run("don't publish status change when the status is the same", func(t *testing.T) {
+		old := &account.Account{Status: account.StatusReady.StatusPtr()}
+		new := &account.Account{Status: account.StatusReady.StatusPtr()}
+
+		mockUpdate := mocks.Publisher{}
+		mockUpdate.On("Publish", updateEvent{Old: old, New: new}).Return(nil)
+		mockStatus := mocks.Publisher{}
+
+		eventSvc := Service{
+			accountUpdate: []Publisher{&mockUpdate},
+			accountStatus: []Publisher{&mockStatus},
+		}
+
+		err := eventSvc.AccountUpdate(old, new)
+		assert.Nil(t, err)
+		mockStatus.AssertNotCalled(t, "Publish", updateEvent{Old: old, New: new})
 	})
 
 }
@@ -168,6 +459,20 @@ func TestEventAccountPublishers(t *testing.T) {
 
 }
 
+func TestEventAccountPoolLow(t *testing.T) {
+	pool := &account.Pool{Ready: 2, NotReady: 5, Leased: 43}
+	mockPoolPublisher := mocks.Publisher{}
+	mockPoolPublisher.On("Publish", pool).Return(nil)
+
+	eventSvc := Service{
+		accountPool: []Publisher{&mockPoolPublisher},
+	}
+
+	err := eventSvc.AccountPoolLow(pool)
+	assert.Nil(t, err)
+	mockPoolPublisher.AssertExpectations(t)
+}
+
 func TestEventLeasePublishers(t *testing.T) {
 
 	tests := []struct {
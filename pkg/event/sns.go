@@ -13,15 +13,16 @@ import (
 
 // SnsEvent is for publishing events to SQS
 type SnsEvent struct {
-	sns      snsiface.SNSAPI
-	topicArn arn.ARN
+	sns       snsiface.SNSAPI
+	topicArn  arn.ARN
+	eventType string
 }
 
 // Publish an event to the topic
 func (s *SnsEvent) Publish(i interface{}) error {
-	bodyJSON, err := json.Marshal(i)
+	bodyJSON, err := Marshal(s.eventType, i)
 	if err != nil {
-		return errors.NewInternalServer("unable to marshal response", err)
+		return err
 	}
 
 	// Wrap the body in a SNS message object
@@ -46,7 +47,7 @@ func (s *SnsEvent) Publish(i interface{}) error {
 }
 
 // NewSnsEvent creates a new SNS eventing struct
-func NewSnsEvent(sns snsiface.SNSAPI, a string) (*SnsEvent, error) {
+func NewSnsEvent(sns snsiface.SNSAPI, a string, eventType string) (*SnsEvent, error) {
 
 	snsArn, err := arn.Parse(a)
 	if err != nil {
@@ -56,7 +57,8 @@ func NewSnsEvent(sns snsiface.SNSAPI, a string) (*SnsEvent, error) {
 		)
 	}
 	return &SnsEvent{
-		sns:      sns,
-		topicArn: snsArn,
+		sns:       sns,
+		topicArn:  snsArn,
+		eventType: eventType,
 	}, nil
 }
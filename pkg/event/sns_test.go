@@ -31,7 +31,7 @@ func TestSns(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessage: "{\"Body\":\"{\\\"key\\\":\\\"value\\\"}\",\"default\":\"{\\\"key\\\":\\\"value\\\"}\"}",
+			expectedMessage: "{\"Body\":\"{\\\"type\\\":\\\"AccountCreated\\\",\\\"version\\\":\\\"1\\\",\\\"data\\\":{\\\"key\\\":\\\"value\\\"}}\",\"default\":\"{\\\"type\\\":\\\"AccountCreated\\\",\\\"version\\\":\\\"1\\\",\\\"data\\\":{\\\"key\\\":\\\"value\\\"}}\"}",
 			expectedErr:     nil,
 		},
 		{
@@ -40,7 +40,7 @@ func TestSns(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessage: "{\"Body\":\"{\\\"key\\\":\\\"value\\\"}\",\"default\":\"{\\\"key\\\":\\\"value\\\"}\"}",
+			expectedMessage: "{\"Body\":\"{\\\"type\\\":\\\"AccountCreated\\\",\\\"version\\\":\\\"1\\\",\\\"data\\\":{\\\"key\\\":\\\"value\\\"}}\",\"default\":\"{\\\"type\\\":\\\"AccountCreated\\\",\\\"version\\\":\\\"1\\\",\\\"data\\\":{\\\"key\\\":\\\"value\\\"}}\"}",
 			expectedErr:     errors.NewInternalServer("failed to publish message to SNS topic", nil),
 		},
 		{
@@ -54,7 +54,7 @@ func TestSns(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSns := &mocks.SNSAPI{}
-			eventer, _ := NewSnsEvent(mockSns, "arn:aws:sns:us-east-1:123456789012:test")
+			eventer, _ := NewSnsEvent(mockSns, "arn:aws:sns:us-east-1:123456789012:test", AccountCreatedType)
 
 			// Mock Publish call
 			mockSns.On("Publish",
@@ -1,7 +1,8 @@
 package event
 
 import (
-	"encoding/json"
+	"fmt"
+	"strconv"
 
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,15 +12,16 @@ import (
 
 // SqsEvent is for publishing events to SQS
 type SqsEvent struct {
-	sqs sqsiface.SQSAPI
-	url string
+	sqs       sqsiface.SQSAPI
+	url       string
+	eventType string
 }
 
 // Publish an event to the topic
 func (s *SqsEvent) Publish(i interface{}) error {
-	bodyJSON, err := json.Marshal(i)
+	bodyJSON, err := Marshal(s.eventType, i)
 	if err != nil {
-		return errors.NewInternalServer("unable to marshal response", err)
+		return err
 	}
 
 	// Create the input
@@ -36,11 +38,73 @@ func (s *SqsEvent) Publish(i interface{}) error {
 	return nil
 }
 
+// sqsBatchMaxMessages is the most messages SQS accepts in one batch
+const sqsBatchMaxMessages = 10
+
+// sqsBatchMaxAttempts is how many times messages SQS fails to send are sent again
+const sqsBatchMaxAttempts = 3
+
+// PublishBatch publishes several events, in batches of as many messages as
+// SQS accepts.  Messages which fail to send are sent again in the next batch.
+func (s *SqsEvent) PublishBatch(items []interface{}) error {
+	entries := []*sqs.SendMessageBatchRequestEntry{}
+	for i, item := range items {
+		bodyJSON, err := Marshal(s.eventType, item)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &sqs.SendMessageBatchRequestEntry{
+			Id:          aws.String(strconv.Itoa(i)),
+			MessageBody: aws.String(string(bodyJSON)),
+		})
+	}
+
+	for attempt := 1; len(entries) > 0; attempt++ {
+		if attempt > sqsBatchMaxAttempts {
+			return errors.NewInternalServer(
+				fmt.Sprintf("unable to send %d messages to sqs after %d attempts", len(entries), sqsBatchMaxAttempts), nil)
+		}
+
+		failed := []*sqs.SendMessageBatchRequestEntry{}
+		for start := 0; start < len(entries); start += sqsBatchMaxMessages {
+			end := start + sqsBatchMaxMessages
+			if end > len(entries) {
+				end = len(entries)
+			}
+			batch := entries[start:end]
+
+			res, err := s.sqs.SendMessageBatch(&sqs.SendMessageBatchInput{
+				QueueUrl: aws.String(s.url),
+				Entries:  batch,
+			})
+			if err != nil {
+				return errors.NewInternalServer("unable to send messages to sqs", err)
+			}
+
+			byID := map[string]*sqs.SendMessageBatchRequestEntry{}
+			for _, entry := range batch {
+				byID[*entry.Id] = entry
+			}
+			for _, f := range res.Failed {
+				// Messages SQS rejected won't succeed when sent again
+				if aws.BoolValue(f.SenderFault) {
+					return errors.NewInternalServer(
+						fmt.Sprintf("unable to send message to sqs: %s", aws.StringValue(f.Message)), nil)
+				}
+				failed = append(failed, byID[aws.StringValue(f.Id)])
+			}
+		}
+		entries = failed
+	}
+	return nil
+}
+
 // NewSqsEvent creates a new SQS eventing struct
-func NewSqsEvent(sqs sqsiface.SQSAPI, url string) (*SqsEvent, error) {
+func NewSqsEvent(sqs sqsiface.SQSAPI, url string, eventType string) (*SqsEvent, error) {
 
 	return &SqsEvent{
-		sqs: sqs,
-		url: url,
+		sqs:       sqs,
+		url:       url,
+		eventType: eventType,
 	}, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestSqs(t *testing.T) {
@@ -31,7 +32,7 @@ func TestSqs(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessageBody: "{\"key\":\"value\"}",
+			expectedMessageBody: "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
 			expectedErr:         nil,
 		},
 		{
@@ -40,7 +41,7 @@ func TestSqs(t *testing.T) {
 			event: data{
 				Key: "value",
 			},
-			expectedMessageBody: "{\"key\":\"value\"}",
+			expectedMessageBody: "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
 			expectedErr:         errors.NewInternalServer("unable to send message to sqs", nil),
 		},
 		{
@@ -54,7 +55,7 @@ func TestSqs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSqs := &mocks.SQSAPI{}
-			eventer, _ := NewSqsEvent(mockSqs, "http://url.com")
+			eventer, _ := NewSqsEvent(mockSqs, "http://url.com", AccountResetRequestedType)
 
 			// Mock Publish call
 			mockSqs.On("SendMessage",
@@ -79,3 +80,74 @@ func TestSqs(t *testing.T) {
 	}
 
 }
+
+func TestSqsPublishBatch(t *testing.T) {
+
+	type data struct {
+		Key int `json:"key"`
+	}
+
+	items := []interface{}{}
+	for i := 0; i < 12; i++ {
+		items = append(items, data{Key: i})
+	}
+
+	t.Run("should send messages in batches, and send failed messages again", func(t *testing.T) {
+		mockSqs := &mocks.SQSAPI{}
+		eventer, _ := NewSqsEvent(mockSqs, "http://url.com", AccountResetRequestedType)
+
+		sent := []string{}
+		attempts := 0
+		mockSqs.On("SendMessageBatch", mock.Anything).Return(func(input *sqs.SendMessageBatchInput) *sqs.SendMessageBatchOutput {
+			assert.Equal(t, "http://url.com", *input.QueueUrl)
+			assert.True(t, len(input.Entries) <= 10)
+			attempts++
+			out := &sqs.SendMessageBatchOutput{}
+			for _, entry := range input.Entries {
+				// The first message fails the first time
+				if *entry.Id == "0" && attempts == 1 {
+					out.Failed = append(out.Failed, &sqs.BatchResultErrorEntry{
+						Id:          entry.Id,
+						SenderFault: aws.Bool(false),
+					})
+					continue
+				}
+				sent = append(sent, *entry.MessageBody)
+			}
+			return out
+		}, nil)
+
+		err := eventer.PublishBatch(items)
+
+		assert.Nil(t, err)
+		assert.Len(t, sent, 12)
+		assert.Equal(t, "{\"type\":\"AccountResetRequested\",\"version\":\"1\",\"data\":{\"key\":0}}", sent[11])
+		mockSqs.AssertNumberOfCalls(t, "SendMessageBatch", 3)
+	})
+
+	t.Run("should error when a message is rejected", func(t *testing.T) {
+		mockSqs := &mocks.SQSAPI{}
+		eventer, _ := NewSqsEvent(mockSqs, "http://url.com", AccountResetRequestedType)
+		mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{
+			Failed: []*sqs.BatchResultErrorEntry{{
+				Id:          aws.String("1"),
+				SenderFault: aws.Bool(true),
+				Message:     aws.String("invalid message"),
+			}},
+		}, nil)
+
+		err := eventer.PublishBatch(items)
+
+		assert.Equal(t, "unable to send message to sqs: invalid message", err.Error())
+	})
+
+	t.Run("should error when sending fails", func(t *testing.T) {
+		mockSqs := &mocks.SQSAPI{}
+		eventer, _ := NewSqsEvent(mockSqs, "http://url.com", AccountResetRequestedType)
+		mockSqs.On("SendMessageBatch", mock.Anything).Return(nil, gErrors.New("error"))
+
+		err := eventer.PublishBatch(items)
+
+		assert.Equal(t, "unable to send messages to sqs", err.Error())
+	})
+}
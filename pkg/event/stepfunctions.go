@@ -0,0 +1,44 @@
+package event
+
+import (
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+)
+
+// StepFunctionEvent is for starting a Step Functions state machine execution
+// for each event
+type StepFunctionEvent struct {
+	sfn             sfniface.SFNAPI
+	stateMachineArn *string
+	eventType       string
+}
+
+// Publish an event by starting an execution with the event as its input
+func (s *StepFunctionEvent) Publish(i interface{}) error {
+	bodyJSON, err := Marshal(s.eventType, i)
+	if err != nil {
+		return err
+	}
+
+	// Start the execution
+	_, err = s.sfn.StartExecution(&sfn.StartExecutionInput{
+		StateMachineArn: s.stateMachineArn,
+		Input:           aws.String(string(bodyJSON)),
+	})
+	if err != nil {
+		return errors.NewInternalServer("failed to start Step Functions execution", err)
+	}
+	return nil
+}
+
+// NewStepFunctionEvent creates a new Step Functions eventing struct
+func NewStepFunctionEvent(sfn sfniface.SFNAPI, stateMachineArn string, eventType string) (*StepFunctionEvent, error) {
+
+	return &StepFunctionEvent{
+		sfn:             sfn,
+		stateMachineArn: &stateMachineArn,
+		eventType:       eventType,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	gErrors "errors"
+	"math"
+	"testing"
+
+	"github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepFunction(t *testing.T) {
+
+	type data struct {
+		Key string `json:"key"`
+	}
+
+	tests := []struct {
+		name          string
+		sfnErr        error
+		event         interface{}
+		expectedErr   error
+		expectedInput string
+	}{
+		{
+			name: "start execution",
+			event: data{
+				Key: "value",
+			},
+			expectedInput: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:   nil,
+		},
+		{
+			name:   "start execution error",
+			sfnErr: gErrors.New("error"),
+			event: data{
+				Key: "value",
+			},
+			expectedInput: "{\"type\":\"LeaseCreated\",\"version\":\"1\",\"data\":{\"key\":\"value\"}}",
+			expectedErr:   errors.NewInternalServer("failed to start Step Functions execution", nil),
+		},
+		{
+			name:        "unmarshal error",
+			event:       math.Inf(1),
+			expectedErr: errors.NewInternalServer("unable to marshal response", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSfn := &mocks.SFNAPI{}
+			eventer, _ := NewStepFunctionEvent(mockSfn, "arn:aws:states:us-east-1:123456789012:stateMachine:test", LeaseCreatedType)
+
+			mockSfn.On("StartExecution",
+				&sfn.StartExecutionInput{
+					StateMachineArn: aws.String("arn:aws:states:us-east-1:123456789012:stateMachine:test"),
+					Input:           aws.String(tt.expectedInput),
+				},
+			).Return(&sfn.StartExecutionOutput{}, tt.sfnErr)
+
+			err := eventer.Publish(tt.event)
+			if tt.expectedInput != "" {
+				mockSfn.AssertExpectations(t)
+			}
+
+			if err != nil {
+				assert.Equal(t, tt.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, tt.expectedErr)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,244 @@
+// Package fault injects errors into the requests of AWS clients, so tests
+// can simulate eg. DynamoDB throttling, SNS publish failures and STS errors
+// at specific operations, and verify the retries, outbox and reconciliation
+// which handle them.  Faults replace the sending of a request, so the SDK's
+// retries and error handling run as they would for the real error.
+//
+// Faults are for tests only.  They're injected into the clients of a
+// process when FAULT_INJECTION is set, which DCE's Terraform never sets.
+package fault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/corehandlers"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// EnvKey is the environment variable of the faults injected into every AWS
+// client of a process, as a JSON list of rules, eg.
+// [{"service": "dynamodb", "operation": "PutItem", "code": "ProvisionedThroughputExceededException", "times": 3}]
+const EnvKey = "FAULT_INJECTION"
+
+// HandlerName is the name of the handler which sends requests, or injects
+// their faults
+const HandlerName = "fault.SendHandler"
+
+const defaultMessage = "injected fault"
+
+// Rule is a fault and the requests it's injected into
+type Rule struct {
+	// Service is the ID or name of the service, eg. "DynamoDB" or "sns".
+	// Requests to every service match when it's empty.
+	Service string `json:"service"`
+	// Operation is the operation of the requests, eg. "PutItem".  Every
+	// operation of the service matches when it's empty.
+	Operation string `json:"operation"`
+	// When further narrows the requests, eg. to a table
+	When func(*request.Request) bool `json:"-"`
+	// Code is the error code, eg. "ThrottlingException"
+	Code string `json:"code"`
+	// Message is the error message
+	Message string `json:"message"`
+	// StatusCode is the HTTP status of the error response, 400 by default
+	StatusCode int `json:"statusCode"`
+	// Skip is how many matching requests succeed before faults are
+	// injected
+	Skip int `json:"skip"`
+	// Times is how many faults are injected, or 0 to fail every matching
+	// request
+	Times int `json:"times"`
+}
+
+// Validate checks the rule can be injected
+func (r Rule) Validate() error {
+	if r.Code == "" {
+		return fmt.Errorf("a fault of %s %s must have an error code", r.Service, r.Operation)
+	}
+	if r.Skip < 0 || r.Times < 0 {
+		return fmt.Errorf("a fault of %s %s must skip and fail 0 or more requests", r.Service, r.Operation)
+	}
+	return nil
+}
+
+// DynamoDBThrottling fails the operation, eg. "PutItem", as though the
+// table's throughput was exceeded.  The SDK retries it.
+func DynamoDBThrottling(operation string, times int) Rule {
+	return Rule{
+		Service:    "DynamoDB",
+		Operation:  operation,
+		Code:       "ProvisionedThroughputExceededException",
+		Message:    "The level of configured provisioned throughput for the table was exceeded.",
+		StatusCode: http.StatusBadRequest,
+		Times:      times,
+	}
+}
+
+// SNSPublishFailure fails publishing to SNS with an internal error, which
+// the SDK retries
+func SNSPublishFailure(times int) Rule {
+	return Rule{
+		Service:    "SNS",
+		Operation:  "Publish",
+		Code:       "InternalError",
+		Message:    "The request processing has failed because of an unknown error, exception or failure.",
+		StatusCode: http.StatusInternalServerError,
+		Times:      times,
+	}
+}
+
+// STSError fails the operation, eg. "AssumeRole", with the error code, eg.
+// "AccessDenied" or "RegionDisabledException"
+func STSError(operation string, code string, times int) Rule {
+	return Rule{
+		Service:    "STS",
+		Operation:  operation,
+		Code:       code,
+		StatusCode: http.StatusForbidden,
+		Times:      times,
+	}
+}
+
+// rule counts the requests a Rule matched
+type rule struct {
+	Rule
+	matched  int
+	injected int
+}
+
+// Injector injects the faults of its rules into the requests of the AWS
+// clients it's applied to.  The first rule matching a request applies.
+type Injector struct {
+	mu    sync.Mutex
+	rules []*rule
+}
+
+// New creates an injector of the rules
+func New(rules ...Rule) (*Injector, error) {
+	injector := &Injector{}
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		injector.rules = append(injector.rules, &rule{Rule: r})
+	}
+	return injector, nil
+}
+
+// NewFromEnv creates an injector of the rules in FAULT_INJECTION, or returns
+// nil when it isn't set
+func NewFromEnv() (*Injector, error) {
+	value := os.Getenv(EnvKey)
+	if value == "" {
+		return nil, nil
+	}
+
+	rules := []Rule{}
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", EnvKey, err)
+	}
+	return New(rules...)
+}
+
+// Apply injects the faults into the requests of the handlers, eg. of a
+// session, which its clients copy, or of one client.  Requests without
+// faults are sent as usual.
+func (i *Injector) Apply(handlers *request.Handlers) {
+	handler := request.NamedHandler{
+		Name: HandlerName,
+		Fn: func(r *request.Request) {
+			if !i.inject(r) {
+				corehandlers.SendHandler.Fn(r)
+			}
+		},
+	}
+	// A second injector replaces the first
+	if !handlers.Send.Swap(corehandlers.SendHandler.Name, handler) &&
+		!handlers.Send.SwapNamed(handler) {
+		handlers.Send.PushBackNamed(handler)
+	}
+}
+
+// inject fails the request with the fault of the first matching rule
+func (i *Injector) inject(r *request.Request) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, rl := range i.rules {
+		if !rl.matches(r) {
+			continue
+		}
+		rl.matched++
+		if rl.matched <= rl.Skip || (rl.Times > 0 && rl.injected >= rl.Times) {
+			return false
+		}
+		rl.injected++
+
+		status := rl.StatusCode
+		if status == 0 {
+			status = http.StatusBadRequest
+		}
+		message := rl.Message
+		if message == "" {
+			message = defaultMessage
+		}
+		log.Printf("Injecting %s into %s %s", rl.Code, r.ClientInfo.ServiceID, r.Operation.Name)
+		r.HTTPResponse = &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(&bytes.Buffer{}),
+		}
+		r.Error = awserr.NewRequestFailure(awserr.New(rl.Code, message, nil), status, "")
+		return true
+	}
+	return false
+}
+
+func (rl *rule) matches(r *request.Request) bool {
+	if rl.Service != "" && !strings.EqualFold(rl.Service, r.ClientInfo.ServiceID) &&
+		!strings.EqualFold(rl.Service, r.ClientInfo.ServiceName) {
+		return false
+	}
+	if rl.Operation != "" && (r.Operation == nil || rl.Operation != r.Operation.Name) {
+		return false
+	}
+	return rl.When == nil || rl.When(r)
+}
+
+// Injected counts the faults injected into the operation of the service,
+// eg. to check the SDK retried them.  An empty operation counts every
+// operation of the service.
+func (i *Injector) Injected(service string, operation string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	injected := 0
+	for _, rl := range i.rules {
+		if strings.EqualFold(rl.Service, service) && (operation == "" || rl.Operation == operation) {
+			injected += rl.injected
+		}
+	}
+	return injected
+}
+
+// Reset clears the counts of the rules, so their requests are skipped and
+// failed again
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, rl := range i.rules {
+		rl.matched = 0
+		rl.injected = 0
+	}
+}
@@ -0,0 +1,208 @@
+package fault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSession creates a session of clients which send requests to the
+// server, and retry quickly
+func newSession(t *testing.T, server *httptest.Server) *session.Session {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		Retryer: client.DefaultRetryer{
+			NumMaxRetries:    3,
+			MinRetryDelay:    time.Millisecond,
+			MaxRetryDelay:    time.Millisecond,
+			MinThrottleDelay: time.Millisecond,
+			MaxThrottleDelay: time.Millisecond,
+		},
+	})
+	require.Nil(t, err)
+	return sess
+}
+
+// newServer answers every request successfully, and counts them
+func newServer(body string, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestDynamoDBThrottling(t *testing.T) {
+	tests := []struct {
+		name        string
+		times       int
+		expErr      bool
+		expInjected int
+		expRequests int32
+	}{
+		{
+			name:        "should succeed once the SDK retries past the throttling",
+			times:       2,
+			expInjected: 2,
+			expRequests: 1,
+		},
+		{
+			name:        "should fail once the SDK runs out of retries",
+			times:       0,
+			expErr:      true,
+			expInjected: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := newServer("{}", &requests)
+			defer server.Close()
+
+			injector, err := New(DynamoDBThrottling("PutItem", tt.times))
+			require.Nil(t, err)
+			sess := newSession(t, server)
+			injector.Apply(&sess.Handlers)
+			svc := dynamodb.New(sess)
+
+			_, err = svc.PutItem(&dynamodb.PutItemInput{
+				TableName: aws.String("Accounts"),
+				Item:      map[string]*dynamodb.AttributeValue{"Id": {S: aws.String("123456789012")}},
+			})
+			if tt.expErr {
+				require.NotNil(t, err)
+				assert.Equal(t, dynamodb.ErrCodeProvisionedThroughputExceededException, err.(awserr.Error).Code())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expInjected, injector.Injected("dynamodb", "PutItem"))
+			assert.Equal(t, tt.expRequests, atomic.LoadInt32(&requests))
+
+			// Other operations aren't throttled
+			_, err = svc.GetItem(&dynamodb.GetItemInput{
+				TableName: aws.String("Accounts"),
+				Key:       map[string]*dynamodb.AttributeValue{"Id": {S: aws.String("123456789012")}},
+			})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestSNSPublishFailure(t *testing.T) {
+	var requests int32
+	server := newServer(`<PublishResponse><PublishResult><MessageId>1</MessageId></PublishResult></PublishResponse>`, &requests)
+	defer server.Close()
+
+	rule := SNSPublishFailure(0)
+	rule.Skip = 1
+	injector, err := New(rule)
+	require.Nil(t, err)
+	sess := newSession(t, server)
+	injector.Apply(&sess.Handlers)
+	svc := sns.New(sess)
+	input := &sns.PublishInput{
+		TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:lease-updated"),
+		Message:  aws.String("{}"),
+	}
+
+	// The first publish is skipped
+	_, err = svc.Publish(input)
+	assert.Nil(t, err)
+
+	_, err = svc.Publish(input)
+	require.NotNil(t, err)
+	assert.Equal(t, "InternalError", err.(awserr.Error).Code())
+	assert.Equal(t, http.StatusInternalServerError, err.(awserr.RequestFailure).StatusCode())
+	assert.Equal(t, 4, injector.Injected("SNS", "Publish"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Reset starts skipping again
+	injector.Reset()
+	_, err = svc.Publish(input)
+	assert.Nil(t, err)
+}
+
+func TestSTSError(t *testing.T) {
+	var requests int32
+	server := newServer("", &requests)
+	defer server.Close()
+
+	injector, err := New(STSError("AssumeRole", "AccessDenied", 1))
+	require.Nil(t, err)
+	sess := newSession(t, server)
+	injector.Apply(&sess.Handlers)
+
+	_, err = sts.New(sess).AssumeRole(&sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::123456789012:role/DCEPrincipal"),
+		RoleSessionName: aws.String("dce"),
+	})
+	require.NotNil(t, err)
+	// Access denied isn't retried
+	assert.Equal(t, "AccessDenied", err.(awserr.Error).Code())
+	assert.Equal(t, 1, injector.Injected("sts", ""))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
+
+func TestWhen(t *testing.T) {
+	var requests int32
+	server := newServer("{}", &requests)
+	defer server.Close()
+
+	rule := DynamoDBThrottling("PutItem", 0)
+	rule.When = func(r *request.Request) bool {
+		return aws.StringValue(r.Params.(*dynamodb.PutItemInput).TableName) == "Leases"
+	}
+	injector, err := New(rule)
+	require.Nil(t, err)
+	sess := newSession(t, server)
+	injector.Apply(&sess.Handlers)
+	svc := dynamodb.New(sess)
+
+	item := map[string]*dynamodb.AttributeValue{"Id": {S: aws.String("123456789012")}}
+
+	_, err = svc.PutItem(&dynamodb.PutItemInput{TableName: aws.String("Accounts"), Item: item})
+	assert.Nil(t, err)
+	_, err = svc.PutItem(&dynamodb.PutItemInput{TableName: aws.String("Leases"), Item: item})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestNewFromEnv(t *testing.T) {
+	defer os.Unsetenv(EnvKey)
+
+	injector, err := NewFromEnv()
+	assert.Nil(t, err)
+	assert.Nil(t, injector)
+
+	os.Setenv(EnvKey, `[{"service": "dynamodb", "operation": "PutItem", "code": "ProvisionedThroughputExceededException", "times": 3}]`)
+	injector, err = NewFromEnv()
+	assert.Nil(t, err)
+	require.NotNil(t, injector)
+	assert.Equal(t, "ProvisionedThroughputExceededException", injector.rules[0].Code)
+	assert.Equal(t, 3, injector.rules[0].Times)
+
+	os.Setenv(EnvKey, `[{"service": "dynamodb", "operation": "PutItem"}]`)
+	_, err = NewFromEnv()
+	assert.EqualError(t, err, "a fault of dynamodb PutItem must have an error code")
+
+	os.Setenv(EnvKey, `{"service": "dynamodb"}`)
+	_, err = NewFromEnv()
+	assert.EqualError(t, err, "failed to parse FAULT_INJECTION: json: cannot unmarshal object into Go value of type []fault.Rule")
+}
@@ -0,0 +1,300 @@
+// Package identity provisions the identities the API's authorization expects:
+// the Admin and User Cognito groups, Cognito users in those groups, and IAM
+// roles allowed to call the API.  It lets the first users of a new deployment
+// be set up without the AWS console.
+package identity
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+// Kinds of identities
+const (
+	KindGroup       = "Group"
+	KindUser        = "User"
+	KindRoleMapping = "RoleMapping"
+)
+
+// Action is what the bootstrap did with an identity
+type Action string
+
+// Actions
+const (
+	// ActionCreated identities didn't exist before the bootstrap
+	ActionCreated Action = "Created"
+	// ActionLinked identities already existed, and were linked to the
+	// groups or policies the authorization expects
+	ActionLinked Action = "Linked"
+)
+
+// User is a Cognito user to create, or to add to the groups of its role
+type User struct {
+	Username string `json:"username"`
+	// Email is required to create the user, and is sent its temporary
+	// password
+	Email string `json:"email,omitempty"`
+	// Role is either "Admin" or "User"
+	Role string `json:"role"`
+	// Groups are other Cognito groups the user is added to, eg. the groups
+	// allowed to create leases
+	Groups []string `json:"groups,omitempty"`
+}
+
+// RoleMapping allows an IAM role in the master account to call the API as
+// an admin or user
+type RoleMapping struct {
+	RoleArn *arn.ARN `json:"roleArn"`
+	// Role is either "Admin" or "User"
+	Role string `json:"role"`
+}
+
+// Bootstrap is the identities to provision
+type Bootstrap struct {
+	Users        []User        `json:"users,omitempty"`
+	RoleMappings []RoleMapping `json:"roleMappings,omitempty"`
+}
+
+// Provisioned is an identity which was created or linked
+type Provisioned struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action Action `json:"action"`
+}
+
+// NewServiceInput are the items needed to create a new identity service
+type NewServiceInput struct {
+	// UserPoolID is the Cognito user pool of the API
+	UserPoolID string `env:"COGNITO_USER_POOL_ID" envDefault:""`
+	// AdminRoleArn and UserRoleArn are the roles of the Cognito identity
+	// pool, which the Admin and User groups are linked to
+	AdminRoleArn string `env:"IDENTITY_ADMIN_ROLE_ARN" envDefault:""`
+	UserRoleArn  string `env:"IDENTITY_USER_ROLE_ARN" envDefault:""`
+	// AdminPolicyArn and UserPolicyArn allow calling the API as an admin or
+	// user, and are attached to mapped IAM roles
+	AdminPolicyArn string `env:"IDENTITY_ADMIN_POLICY_ARN" envDefault:""`
+	UserPolicyArn  string `env:"IDENTITY_USER_POLICY_ARN" envDefault:""`
+	Cognito        cognitoidentityprovideriface.CognitoIdentityProviderAPI
+	IAM            iamiface.IAMAPI
+}
+
+// Service provisions the identities of the API
+type Service struct {
+	userPoolID string
+	roleArns   map[string]string
+	policyArns map[string]string
+	cognito    cognitoidentityprovideriface.CognitoIdentityProviderAPI
+	iam        iamiface.IAMAPI
+}
+
+// Bootstrap creates the users and role mappings, or links them if they
+// already exist.  The Admin and User groups are created first.  It can be
+// called again with the same identities, which are left as they are.
+func (s *Service) Bootstrap(input *Bootstrap) ([]*Provisioned, error) {
+	err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	provisioned := []*Provisioned{}
+	groups := map[string]bool{}
+	if len(input.Users) > 0 {
+		for _, role := range []string{api.AdminGroupName, api.UserGroupName} {
+			p, err := s.ensureGroup(role, s.roleArns[role])
+			if err != nil {
+				return provisioned, err
+			}
+			groups[role] = true
+			provisioned = append(provisioned, p)
+		}
+	}
+
+	for _, user := range input.Users {
+		p, err := s.ensureUser(user, groups)
+		if err != nil {
+			return provisioned, err
+		}
+		provisioned = append(provisioned, p...)
+	}
+
+	for _, mapping := range input.RoleMappings {
+		p, err := s.mapRole(mapping)
+		if err != nil {
+			return provisioned, err
+		}
+		provisioned = append(provisioned, p)
+	}
+
+	return provisioned, nil
+}
+
+func (s *Service) validate(input *Bootstrap) error {
+	if len(input.Users) == 0 && len(input.RoleMappings) == 0 {
+		return errors.NewValidation("identity", fmt.Errorf("users or roleMappings are required"))
+	}
+	if len(input.Users) > 0 && s.userPoolID == "" {
+		return errors.NewValidation("identity", fmt.Errorf("users can't be provisioned without a Cognito user pool"))
+	}
+	for _, user := range input.Users {
+		if strings.TrimSpace(user.Username) == "" {
+			return errors.NewValidation("identity", fmt.Errorf("users must have a username"))
+		}
+		if !isRole(user.Role) {
+			return errors.NewValidation("identity", fmt.Errorf("user %q must have the role %q or %q", user.Username, api.AdminGroupName, api.UserGroupName))
+		}
+		if user.Email != "" && !strings.Contains(user.Email, "@") {
+			return errors.NewValidation("identity", fmt.Errorf("user %q must have a valid email address", user.Username))
+		}
+	}
+	for _, mapping := range input.RoleMappings {
+		if mapping.RoleArn == nil || mapping.RoleArn.IAMResourceName() == nil || !strings.HasPrefix(mapping.RoleArn.Resource, "role/") {
+			return errors.NewValidation("identity", fmt.Errorf("role mappings must have the ARN of an IAM role"))
+		}
+		if !isRole(mapping.Role) {
+			return errors.NewValidation("identity", fmt.Errorf("role mapping %q must have the role %q or %q", mapping.RoleArn.String(), api.AdminGroupName, api.UserGroupName))
+		}
+		if s.policyArns[mapping.Role] == "" {
+			return errors.NewValidation("identity", fmt.Errorf("role mappings can't be provisioned without the %s API policy", mapping.Role))
+		}
+	}
+	return nil
+}
+
+// ensureGroup creates a Cognito group, or links an existing group to the
+// role, so the identity pool maps its members to the role
+func (s *Service) ensureGroup(name string, roleArn string) (*Provisioned, error) {
+	input := &cognitoidentityprovider.CreateGroupInput{
+		GroupName:  aws.String(name),
+		UserPoolId: aws.String(s.userPoolID),
+	}
+	if roleArn != "" {
+		input.RoleArn = aws.String(roleArn)
+	}
+	_, err := s.cognito.CreateGroup(input)
+	if err == nil {
+		log.Printf("Created Cognito group %s", name)
+		return &Provisioned{Kind: KindGroup, Name: name, Action: ActionCreated}, nil
+	}
+	if !isCode(err, cognitoidentityprovider.ErrCodeGroupExistsException) {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to create the Cognito group %s", name), err)
+	}
+
+	if roleArn != "" {
+		_, err = s.cognito.UpdateGroup(&cognitoidentityprovider.UpdateGroupInput{
+			GroupName:  aws.String(name),
+			UserPoolId: aws.String(s.userPoolID),
+			RoleArn:    aws.String(roleArn),
+		})
+		if err != nil {
+			return nil, errors.NewInternalServer(fmt.Sprintf("failed to link the Cognito group %s", name), err)
+		}
+	}
+	return &Provisioned{Kind: KindGroup, Name: name, Action: ActionLinked}, nil
+}
+
+// ensureUser creates a Cognito user, or finds the existing user, and adds
+// it to the groups of its role and any other groups.  Groups which weren't
+// provisioned yet are created, and added to groups.
+func (s *Service) ensureUser(user User, groups map[string]bool) ([]*Provisioned, error) {
+	provisioned := []*Provisioned{}
+	action := ActionLinked
+	_, err := s.cognito.AdminGetUser(&cognitoidentityprovider.AdminGetUserInput{
+		Username:   aws.String(user.Username),
+		UserPoolId: aws.String(s.userPoolID),
+	})
+	if isCode(err, cognitoidentityprovider.ErrCodeUserNotFoundException) {
+		if user.Email == "" {
+			return provisioned, errors.NewValidation("identity", fmt.Errorf("user %q must have an email address to be created", user.Username))
+		}
+		_, err = s.cognito.AdminCreateUser(&cognitoidentityprovider.AdminCreateUserInput{
+			Username:   aws.String(user.Username),
+			UserPoolId: aws.String(s.userPoolID),
+			UserAttributes: []*cognitoidentityprovider.AttributeType{
+				{Name: aws.String("email"), Value: aws.String(user.Email)},
+				{Name: aws.String("email_verified"), Value: aws.String("true")},
+			},
+			DesiredDeliveryMediums: []*string{aws.String(cognitoidentityprovider.DeliveryMediumTypeEmail)},
+		})
+		action = ActionCreated
+	}
+	if err != nil {
+		return provisioned, errors.NewInternalServer(fmt.Sprintf("failed to create the Cognito user %s", user.Username), err)
+	}
+	log.Printf("%s Cognito user %s with the role %s", action, user.Username, user.Role)
+
+	for _, group := range append([]string{user.Role}, user.Groups...) {
+		if !groups[group] {
+			p, err := s.ensureGroup(group, "")
+			if err != nil {
+				return provisioned, err
+			}
+			groups[group] = true
+			provisioned = append(provisioned, p)
+		}
+		_, err = s.cognito.AdminAddUserToGroup(&cognitoidentityprovider.AdminAddUserToGroupInput{
+			GroupName:  aws.String(group),
+			Username:   aws.String(user.Username),
+			UserPoolId: aws.String(s.userPoolID),
+		})
+		if err != nil {
+			return provisioned, errors.NewInternalServer(fmt.Sprintf("failed to add the Cognito user %s to the group %s", user.Username, group), err)
+		}
+	}
+
+	return append(provisioned, &Provisioned{Kind: KindUser, Name: user.Username, Action: action}), nil
+}
+
+// mapRole attaches the API policy of the role to an IAM role.  Attaching a
+// policy which is already attached does nothing.
+func (s *Service) mapRole(mapping RoleMapping) (*Provisioned, error) {
+	roleName := *mapping.RoleArn.IAMResourceName()
+	_, err := s.iam.AttachRolePolicy(&iam.AttachRolePolicyInput{
+		RoleName:  aws.String(roleName),
+		PolicyArn: aws.String(s.policyArns[mapping.Role]),
+	})
+	if isCode(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, errors.NewValidation("identity", fmt.Errorf("role %q wasn't found in the master account", mapping.RoleArn.String()))
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to map the role %s", roleName), err)
+	}
+	log.Printf("Mapped role %s to the %s API policy", roleName, mapping.Role)
+	return &Provisioned{Kind: KindRoleMapping, Name: mapping.RoleArn.String(), Action: ActionLinked}, nil
+}
+
+func isRole(role string) bool {
+	return role == api.AdminGroupName || role == api.UserGroupName
+}
+
+func isCode(err error, code string) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == code
+}
+
+// NewService creates a new identity service
+func NewService(input NewServiceInput) *Service {
+	return &Service{
+		userPoolID: input.UserPoolID,
+		roleArns: map[string]string{
+			api.AdminGroupName: input.AdminRoleArn,
+			api.UserGroupName:  input.UserRoleArn,
+		},
+		policyArns: map[string]string{
+			api.AdminGroupName: input.AdminPolicyArn,
+			api.UserGroupName:  input.UserPolicyArn,
+		},
+		cognito: input.Cognito,
+		iam:     input.IAM,
+	}
+}
@@ -0,0 +1,176 @@
+package identity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/arn"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(cognito *awsmocks.CognitoIdentityProviderAPI, iamSvc *awsmocks.IAM) *Service {
+	return NewService(NewServiceInput{
+		UserPoolID:     "us-east-1_pool",
+		AdminRoleArn:   "arn:aws:iam::123456789012:role/dce-admin",
+		UserRoleArn:    "arn:aws:iam::123456789012:role/dce-user",
+		AdminPolicyArn: "arn:aws:iam::123456789012:policy/dce-admin",
+		UserPolicyArn:  "arn:aws:iam::123456789012:policy/dce-user",
+		Cognito:        cognito,
+		IAM:            iamSvc,
+	})
+}
+
+func TestBootstrapUsers(t *testing.T) {
+	cognito := &awsmocks.CognitoIdentityProviderAPI{}
+	cognito.On("CreateGroup", &cognitoidentityprovider.CreateGroupInput{
+		GroupName:  aws.String("Admin"),
+		UserPoolId: aws.String("us-east-1_pool"),
+		RoleArn:    aws.String("arn:aws:iam::123456789012:role/dce-admin"),
+	}).Return(&cognitoidentityprovider.CreateGroupOutput{}, nil)
+	cognito.On("CreateGroup", &cognitoidentityprovider.CreateGroupInput{
+		GroupName:  aws.String("User"),
+		UserPoolId: aws.String("us-east-1_pool"),
+		RoleArn:    aws.String("arn:aws:iam::123456789012:role/dce-user"),
+	}).Return(nil, awserr.New(cognitoidentityprovider.ErrCodeGroupExistsException, "exists", nil))
+	cognito.On("UpdateGroup", &cognitoidentityprovider.UpdateGroupInput{
+		GroupName:  aws.String("User"),
+		UserPoolId: aws.String("us-east-1_pool"),
+		RoleArn:    aws.String("arn:aws:iam::123456789012:role/dce-user"),
+	}).Return(&cognitoidentityprovider.UpdateGroupOutput{}, nil)
+	cognito.On("CreateGroup", &cognitoidentityprovider.CreateGroupInput{
+		GroupName:  aws.String("Engineering"),
+		UserPoolId: aws.String("us-east-1_pool"),
+	}).Return(&cognitoidentityprovider.CreateGroupOutput{}, nil)
+
+	cognito.On("AdminGetUser", mock.MatchedBy(func(input *cognitoidentityprovider.AdminGetUserInput) bool {
+		return *input.Username == "jdoe"
+	})).Return(nil, awserr.New(cognitoidentityprovider.ErrCodeUserNotFoundException, "not found", nil))
+	cognito.On("AdminCreateUser", mock.MatchedBy(func(input *cognitoidentityprovider.AdminCreateUserInput) bool {
+		return *input.Username == "jdoe" && *input.UserAttributes[0].Value == "jdoe@example.com"
+	})).Return(&cognitoidentityprovider.AdminCreateUserOutput{}, nil)
+	cognito.On("AdminGetUser", mock.MatchedBy(func(input *cognitoidentityprovider.AdminGetUserInput) bool {
+		return *input.Username == "asmith"
+	})).Return(&cognitoidentityprovider.AdminGetUserOutput{}, nil)
+	cognito.On("AdminAddUserToGroup", mock.Anything).Return(&cognitoidentityprovider.AdminAddUserToGroupOutput{}, nil)
+
+	svc := newTestService(cognito, &awsmocks.IAM{})
+	provisioned, err := svc.Bootstrap(&Bootstrap{
+		Users: []User{
+			{Username: "jdoe", Email: "jdoe@example.com", Role: "Admin"},
+			{Username: "asmith", Role: "User", Groups: []string{"Engineering"}},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Provisioned{
+		{Kind: KindGroup, Name: "Admin", Action: ActionCreated},
+		{Kind: KindGroup, Name: "User", Action: ActionLinked},
+		{Kind: KindUser, Name: "jdoe", Action: ActionCreated},
+		{Kind: KindGroup, Name: "Engineering", Action: ActionCreated},
+		{Kind: KindUser, Name: "asmith", Action: ActionLinked},
+	}, provisioned)
+	cognito.AssertCalled(t, "AdminAddUserToGroup", &cognitoidentityprovider.AdminAddUserToGroupInput{
+		GroupName:  aws.String("Admin"),
+		Username:   aws.String("jdoe"),
+		UserPoolId: aws.String("us-east-1_pool"),
+	})
+	cognito.AssertCalled(t, "AdminAddUserToGroup", &cognitoidentityprovider.AdminAddUserToGroupInput{
+		GroupName:  aws.String("Engineering"),
+		Username:   aws.String("asmith"),
+		UserPoolId: aws.String("us-east-1_pool"),
+	})
+	cognito.AssertNumberOfCalls(t, "AdminAddUserToGroup", 3)
+}
+
+func TestBootstrapRoleMappings(t *testing.T) {
+	iamSvc := &awsmocks.IAM{}
+	iamSvc.On("AttachRolePolicy", &iam.AttachRolePolicyInput{
+		RoleName:  aws.String("Ops"),
+		PolicyArn: aws.String("arn:aws:iam::123456789012:policy/dce-admin"),
+	}).Return(&iam.AttachRolePolicyOutput{}, nil)
+	iamSvc.On("AttachRolePolicy", &iam.AttachRolePolicyInput{
+		RoleName:  aws.String("Missing"),
+		PolicyArn: aws.String("arn:aws:iam::123456789012:policy/dce-user"),
+	}).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+
+	svc := newTestService(&awsmocks.CognitoIdentityProviderAPI{}, iamSvc)
+	provisioned, err := svc.Bootstrap(&Bootstrap{
+		RoleMappings: []RoleMapping{
+			{RoleArn: arn.New("aws", "iam", "", "123456789012", "role/Ops"), Role: "Admin"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*Provisioned{
+		{Kind: KindRoleMapping, Name: "arn:aws:iam::123456789012:role/Ops", Action: ActionLinked},
+	}, provisioned)
+
+	_, err = svc.Bootstrap(&Bootstrap{
+		RoleMappings: []RoleMapping{
+			{RoleArn: arn.New("aws", "iam", "", "123456789012", "role/Missing"), Role: "User"},
+		},
+	})
+	assert.Equal(t, "identity validation error: role \"arn:aws:iam::123456789012:role/Missing\" wasn't found in the master account", err.Error())
+}
+
+func TestBootstrapValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *Bootstrap
+		expErr string
+	}{
+		{
+			name:   "no identities",
+			input:  &Bootstrap{},
+			expErr: "identity validation error: users or roleMappings are required",
+		},
+		{
+			name:   "no username",
+			input:  &Bootstrap{Users: []User{{Role: "Admin"}}},
+			expErr: "identity validation error: users must have a username",
+		},
+		{
+			name:   "unknown role",
+			input:  &Bootstrap{Users: []User{{Username: "jdoe", Role: "Owner"}}},
+			expErr: "identity validation error: user \"jdoe\" must have the role \"Admin\" or \"User\"",
+		},
+		{
+			name:   "invalid email",
+			input:  &Bootstrap{Users: []User{{Username: "jdoe", Role: "User", Email: "jdoe"}}},
+			expErr: "identity validation error: user \"jdoe\" must have a valid email address",
+		},
+		{
+			name: "mapping a user",
+			input: &Bootstrap{RoleMappings: []RoleMapping{
+				{RoleArn: arn.New("aws", "iam", "", "123456789012", "user/jdoe"), Role: "Admin"},
+			}},
+			expErr: "identity validation error: role mappings must have the ARN of an IAM role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(&awsmocks.CognitoIdentityProviderAPI{}, &awsmocks.IAM{})
+			_, err := svc.Bootstrap(tt.input)
+			assert.Equal(t, tt.expErr, fmt.Sprint(err))
+		})
+	}
+}
+
+func TestBootstrapNewUserWithoutEmail(t *testing.T) {
+	cognito := &awsmocks.CognitoIdentityProviderAPI{}
+	cognito.On("CreateGroup", mock.Anything).Return(&cognitoidentityprovider.CreateGroupOutput{}, nil)
+	cognito.On("AdminGetUser", mock.Anything).
+		Return(nil, awserr.New(cognitoidentityprovider.ErrCodeUserNotFoundException, "not found", nil))
+
+	svc := newTestService(cognito, &awsmocks.IAM{})
+	_, err := svc.Bootstrap(&Bootstrap{Users: []User{{Username: "jdoe", Role: "Admin"}}})
+
+	assert.Equal(t, "identity validation error: user \"jdoe\" must have an email address to be created", err.Error())
+	cognito.AssertNotCalled(t, "AdminCreateUser", mock.Anything)
+}
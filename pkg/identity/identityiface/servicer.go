@@ -0,0 +1,14 @@
+//
+
+package identityiface
+
+import (
+	"github.com/Optum/dce/pkg/identity"
+)
+
+// Servicer provisions the identities of the API
+type Servicer interface {
+	// Bootstrap creates the users and role mappings, or links them if they
+	// already exist
+	Bootstrap(input *identity.Bootstrap) ([]*identity.Provisioned, error)
+}
@@ -0,0 +1,195 @@
+package lease
+
+import (
+	"log"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/usage"
+)
+
+// Leaser lists the leases of principals, and creates the leases of requests
+type Leaser interface {
+	AcceptTerms(data *Lease, acceptedBy string) error
+	List(query *Lease) (*Leases, error)
+	CreateWithQuota(data *Lease, principalSpentAmount float64, quota *Quota) (*Lease, error)
+}
+
+// ReadyAccountGetter gets the Ready accounts which can be leased to principals
+type ReadyAccountGetter interface {
+	GetReadyAccount(principalID string) (*account.Account, error)
+	GetReadyAccountWithRequirements(principalID string, requirements *account.Requirements) (*account.Account, error)
+	GetLastLeasedAccount(principalID string, requirements *account.Requirements) (*account.Account, error)
+	PoolLeaseGroups(pool *string) []string
+}
+
+// PrincipalDirectory checks principals are active directory users, and gets
+// the lease quota of their groups
+type PrincipalDirectory interface {
+	PrincipalQuota(principalID string) (*Quota, error)
+}
+
+// Allocator leases Ready accounts to lease requests, with the same checks
+// whether the lease is requested with the API, with Slack, or waited for on
+// the waitlist
+type Allocator struct {
+	leaseSvc              Leaser
+	accountSvc            ReadyAccountGetter
+	directorySvc          PrincipalDirectory
+	usageSvc              usage.DBer
+	principalIDFormat     *principal.IDFormat
+	principalBudgetPeriod string
+}
+
+// Request checks the user may request the lease, and that it accepts the
+// current terms of use, and leases a Ready account to its principal.
+// Principals may ask for the account they last leased, to keep its quota
+// increases, when sameAccount is set.  Returns a nil lease when no Ready
+// account can be leased to the principal, so the request can wait for one.
+func (a *Allocator) Request(data *Lease, user *api.User, sameAccount bool) (*Lease, error) {
+	if data.PrincipalID == nil {
+		return nil, errors.NewBadRequest("invalid request parameters: missing principalId")
+	}
+
+	// The principal's quota and spend are looked up by their normalized ID
+	principalID, err := a.principalIDFormat.Parse(*data.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+	data.PrincipalID = &principalID
+
+	// If user is not an admin, they can't create leases for other users
+	err = user.Authorize(principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only members of the lease groups of the requested pool, or of any pool
+	// when none is requested, may lease its accounts
+	err = user.AuthorizeGroups(a.accountSvc.PoolLeaseGroups(data.AccountRequirements().Pool))
+	if err != nil {
+		return nil, err
+	}
+
+	// The requester must accept the current terms of use, when there are any
+	err = a.leaseSvc.AcceptTerms(data, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Allocate(data, user, sameAccount)
+}
+
+// Allocate leases a Ready account to a lease request which was already
+// checked by Request, eg. when it waited on the waitlist, and marks the
+// account Leased.  Only accounts from the pools the user's groups may lease
+// are leased.  Returns a nil lease when no Ready account can be leased to the
+// principal.
+func (a *Allocator) Allocate(data *Lease, user *api.User, sameAccount bool) (*Lease, error) {
+	principalID := *data.PrincipalID
+
+	// Check the principal against the directory, and get their group quota
+	quota, err := a.directorySvc.PrincipalQuota(principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalid requirements wouldn't be met by any account
+	requirements := data.AccountRequirements()
+	err = requirements.Validate()
+	if err != nil {
+		return nil, errors.NewValidation("lease", err)
+	}
+	// Users may only be leased accounts from the pools of their groups, when
+	// any pool has lease groups
+	if user.Role != api.AdminGroupName && a.accountSvc.PoolLeaseGroups(nil) != nil {
+		requirements.Groups = &user.Groups
+	}
+
+	// Get the Ready Account which best meets the requirements, isn't cooling
+	// down, and the account allocation policy allows
+	availableAccount, err := a.getReadyAccount(principalID, sameAccount, requirements)
+	if err != nil || availableAccount == nil {
+		return nil, err
+	}
+
+	// Get user principal's current spend
+	spent, err := PrincipalSpend(a.usageSvc, BillingPeriodStart(a.principalBudgetPeriod), principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A lease of the same account by the same principal replaces the
+	// principal's last lease of the account.  Since the primary key is
+	// queried, at most one lease matches.
+	foundLeases, err := a.leaseSvc.List(&Lease{
+		AccountID:   availableAccount.ID,
+		PrincipalID: data.PrincipalID,
+		Status:      StatusInactive.StatusPtr(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if foundLeases != nil && len(*foundLeases) == 1 {
+		data.LastModifiedOn = (*foundLeases)[0].LastModifiedOn
+		data.Version = (*foundLeases)[0].Version
+		data.CreatedOn = (*foundLeases)[0].CreatedOn
+	} else {
+		data.LastModifiedOn = nil
+		data.Version = nil
+		data.CreatedOn = nil
+	}
+
+	// Create the lease, and mark the account as Status=Leased in the same
+	// transaction
+	data.AccountID = availableAccount.ID
+	return a.leaseSvc.CreateWithQuota(data, spent, quota)
+}
+
+// getReadyAccount gets the Ready account which best meets the requirements to
+// lease to the principal.  When sameAccount is set, the account last leased to
+// the principal is preferred, and any other Ready account is leased when it
+// isn't available.
+func (a *Allocator) getReadyAccount(principalID string, sameAccount bool, requirements *account.Requirements) (*account.Account, error) {
+	if sameAccount {
+		lastLeased, err := a.accountSvc.GetLastLeasedAccount(principalID, requirements)
+		if err != nil {
+			return nil, err
+		}
+		if lastLeased != nil {
+			return lastLeased, nil
+		}
+		log.Printf("The account last leased to %s isn't available, leasing another", principalID)
+	}
+	if !requirements.IsEmpty() {
+		return a.accountSvc.GetReadyAccountWithRequirements(principalID, requirements)
+	}
+	return a.accountSvc.GetReadyAccount(principalID)
+}
+
+// NewAllocatorInput are the services and configuration lease requests are
+// checked and created with
+type NewAllocatorInput struct {
+	LeaseSvc     Leaser
+	AccountSvc   ReadyAccountGetter
+	DirectorySvc PrincipalDirectory
+	UsageSvc     usage.DBer
+	// PrincipalIDFormat is optional, and normalizes and validates the
+	// principal IDs of requests
+	PrincipalIDFormat     *principal.IDFormat
+	PrincipalBudgetPeriod string `env:"PRINCIPAL_BUDGET_PERIOD" envDefault:"Weekly"`
+}
+
+// NewAllocator creates a new instance of the Allocator
+func NewAllocator(input NewAllocatorInput) *Allocator {
+	return &Allocator{
+		leaseSvc:              input.LeaseSvc,
+		accountSvc:            input.AccountSvc,
+		directorySvc:          input.DirectorySvc,
+		usageSvc:              input.UsageSvc,
+		principalIDFormat:     input.PrincipalIDFormat,
+		principalBudgetPeriod: input.PrincipalBudgetPeriod,
+	}
+}
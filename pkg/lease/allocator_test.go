@@ -0,0 +1,206 @@
+package lease_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	accountmocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	"github.com/Optum/dce/pkg/api"
+	directorymocks "github.com/Optum/dce/pkg/directory/directoryiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	leasemocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/usage"
+	usageMocks "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAllocatorRequest(t *testing.T) {
+	readyAccount := &account.Account{ID: ptrString("123456789012"), Status: account.StatusReady.StatusPtr()}
+	lastLeased := &account.Account{ID: ptrString("210987654321"), Status: account.StatusReady.StatusPtr()}
+	quota := &lease.Quota{PrincipalBudgetAmount: 500}
+
+	tests := []struct {
+		name          string
+		principalID   *string
+		user          *api.User
+		sameAccount   bool
+		leaseGroups   []string
+		termsErr      error
+		quotaErr      error
+		readyAccount  *account.Account
+		inactiveLease *lease.Leases
+		expAccountID  *string
+		expVersion    *int64
+		expErr        error
+	}{
+		{
+			name:         "should lease a Ready account",
+			principalID:  ptrString("User1"),
+			user:         &api.User{Username: "user1", Role: api.UserGroupName},
+			readyAccount: readyAccount,
+			expAccountID: readyAccount.ID,
+		},
+		{
+			name:          "should replace the principal's inactive lease of the account",
+			principalID:   ptrString("user1"),
+			user:          &api.User{Username: "user1", Role: api.UserGroupName},
+			readyAccount:  readyAccount,
+			inactiveLease: &lease.Leases{{Version: aws.Int64(3), CreatedOn: aws.Int64(100), LastModifiedOn: aws.Int64(200)}},
+			expAccountID:  readyAccount.ID,
+			expVersion:    aws.Int64(3),
+		},
+		{
+			name:         "should lease the account last leased to the principal",
+			principalID:  ptrString("user1"),
+			user:         &api.User{Username: "user1", Role: api.UserGroupName},
+			sameAccount:  true,
+			readyAccount: readyAccount,
+			expAccountID: lastLeased.ID,
+		},
+		{
+			name:        "should not lease an account when none is Ready",
+			principalID: ptrString("user1"),
+			user:        &api.User{Username: "user1", Role: api.UserGroupName},
+		},
+		{
+			name:   "should require a principal",
+			user:   &api.User{Username: "user1", Role: api.UserGroupName},
+			expErr: errors.NewBadRequest("invalid request parameters: missing principalId"),
+		},
+		{
+			name:        "should not let users request leases for other principals",
+			principalID: ptrString("user2"),
+			user:        &api.User{Username: "user1", Role: api.UserGroupName},
+			expErr:      errors.NewUnathorizedError("User [user1] with role: [User] attempted to act on a lease for [user2], but was not authorized"),
+		},
+		{
+			name:         "should let admins request leases for other principals",
+			principalID:  ptrString("user2"),
+			user:         &api.User{Username: "admin", Role: api.AdminGroupName},
+			leaseGroups:  []string{"Engineering"},
+			readyAccount: readyAccount,
+			expAccountID: readyAccount.ID,
+		},
+		{
+			name:        "should only let members of the lease groups request leases",
+			principalID: ptrString("user1"),
+			user:        &api.User{Username: "user1", Role: api.UserGroupName, Groups: []string{"Data"}},
+			leaseGroups: []string{"Engineering"},
+			expErr:      errors.NewUnathorizedError("User [user1] is not a member of the groups allowed to create leases: [Engineering]"),
+		},
+		{
+			name:        "should require the terms of use to be accepted",
+			principalID: ptrString("user1"),
+			user:        &api.User{Username: "user1", Role: api.UserGroupName},
+			termsErr:    errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted, see GET /leases/terms.")),
+			expErr:      errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted, see GET /leases/terms.")),
+		},
+		{
+			name:        "should require an active directory user",
+			principalID: ptrString("user1"),
+			user:        &api.User{Username: "user1", Role: api.UserGroupName},
+			quotaErr:    errors.NewValidation("lease", fmt.Errorf("principal user1 is not an active directory user")),
+			expErr:      errors.NewValidation("lease", fmt.Errorf("principal user1 is not an active directory user")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseSvc := &leasemocks.Servicer{}
+			leaseSvc.On("AcceptTerms", mock.AnythingOfType("*lease.Lease"), tt.user.Username).Return(tt.termsErr)
+			leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(tt.inactiveLease, nil)
+			leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 12.5, quota).Return(
+				func(data *lease.Lease, spent float64, quota *lease.Quota) *lease.Lease {
+					return data
+				}, nil)
+
+			accountSvc := &accountmocks.Servicer{}
+			accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return(tt.leaseGroups)
+			accountSvc.On("GetReadyAccount", mock.Anything).Return(tt.readyAccount, nil)
+			accountSvc.On("GetLastLeasedAccount", mock.Anything, mock.Anything).Return(lastLeased, nil)
+
+			directorySvc := &directorymocks.Servicer{}
+			directorySvc.On("PrincipalQuota", mock.Anything).Return(quota, tt.quotaErr)
+
+			usageDB := &usageMocks.DBer{}
+			usageDB.On("GetUsageByPrincipal", mock.Anything, mock.Anything).Return([]*usage.Usage{
+				{CostAmount: aws.Float64(12.5)},
+			}, nil)
+
+			idFormat, err := principal.NewIDFormat(principal.NewIDFormatInput{Normalization: []string{principal.NormalizeLowercase}})
+			assert.Nil(t, err)
+
+			allocator := lease.NewAllocator(lease.NewAllocatorInput{
+				LeaseSvc:              leaseSvc,
+				AccountSvc:            accountSvc,
+				DirectorySvc:          directorySvc,
+				UsageSvc:              usageDB,
+				PrincipalIDFormat:     idFormat,
+				PrincipalBudgetPeriod: lease.Weekly,
+			})
+			created, err := allocator.Request(&lease.Lease{PrincipalID: tt.principalID}, tt.user, tt.sameAccount)
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expAccountID == nil {
+				assert.Nil(t, created)
+				leaseSvc.AssertNotCalled(t, "CreateWithQuota", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			if assert.NotNil(t, created) {
+				assert.Equal(t, tt.expAccountID, created.AccountID)
+				// Principals are leased accounts by their normalized ID
+				assert.Equal(t, strings.ToLower(*tt.principalID), *created.PrincipalID)
+				assert.Equal(t, tt.expVersion, created.Version)
+			}
+		})
+	}
+}
+
+func TestAllocatorAllocate(t *testing.T) {
+	readyAccount := &account.Account{ID: ptrString("123456789012"), Status: account.StatusReady.StatusPtr()}
+
+	leaseSvc := &leasemocks.Servicer{}
+	leaseSvc.On("List", mock.AnythingOfType("*lease.Lease")).Return(&lease.Leases{}, nil)
+	leaseSvc.On("CreateWithQuota", mock.AnythingOfType("*lease.Lease"), 0.0, (*lease.Quota)(nil)).Return(
+		func(data *lease.Lease, spent float64, quota *lease.Quota) *lease.Lease {
+			return data
+		}, nil)
+
+	accountSvc := &accountmocks.Servicer{}
+	accountSvc.On("PoolLeaseGroups", (*string)(nil)).Return([]string{"ML Engineers"})
+	// Only accounts from the pools of the user's groups may be leased
+	accountSvc.On("GetReadyAccountWithRequirements", "user1", mock.MatchedBy(func(r *account.Requirements) bool {
+		return *r.Pool == "ml" && r.Groups != nil && (*r.Groups)[0] == "ML Engineers"
+	})).Return(readyAccount, nil)
+
+	directorySvc := &directorymocks.Servicer{}
+	directorySvc.On("PrincipalQuota", "user1").Return(nil, nil)
+
+	usageDB := &usageMocks.DBer{}
+	usageDB.On("GetUsageByPrincipal", mock.Anything, "user1").Return(nil, nil)
+
+	allocator := lease.NewAllocator(lease.NewAllocatorInput{
+		LeaseSvc:     leaseSvc,
+		AccountSvc:   accountSvc,
+		DirectorySvc: directorySvc,
+		UsageSvc:     usageDB,
+	})
+	created, err := allocator.Allocate(&lease.Lease{
+		PrincipalID:  ptrString("user1"),
+		Requirements: &account.Requirements{Pool: ptrString("ml")},
+	}, &api.User{Username: "user1", Role: api.UserGroupName, Groups: []string{"ML Engineers"}}, false)
+
+	assert.Nil(t, err)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, readyAccount.ID, created.AccountID)
+	}
+	// Requests on the waitlist were checked when they were added to it
+	leaseSvc.AssertNotCalled(t, "AcceptTerms", mock.Anything, mock.Anything)
+	accountSvc.AssertNotCalled(t, "GetReadyAccount", mock.Anything)
+}
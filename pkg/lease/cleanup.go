@@ -0,0 +1,107 @@
+package lease
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// maxCleanupItems is the most resource types, or regions, a cleanup can have
+const maxCleanupItems = 50
+
+var resourceTypePattern = regexp.MustCompile("^[A-Za-z0-9]+$")
+var regionPattern = regexp.MustCompile("^[a-z]{2}(-[a-z]+)+-[0-9]$")
+
+// Cleanup removes resources of some types, eg. EC2Instance, in some regions
+// of a leased account, without ending the lease.  It's run by the account
+// reset build, as the principal of the lease.  All the regions the reset
+// covers are cleaned up when none are given.
+type Cleanup struct {
+	ResourceTypes []string `json:"resourceTypes" dynamodbav:"ResourceTypes"`
+	Regions       []string `json:"regions,omitempty" dynamodbav:"Regions,omitempty"`
+	RequestedBy   string   `json:"requestedBy,omitempty" dynamodbav:"RequestedBy,omitempty"`
+	RequestedOn   int64    `json:"requestedOn" dynamodbav:"RequestedOn"`
+	// BuildID is the reset build running the cleanup
+	BuildID string `json:"buildId,omitempty" dynamodbav:"BuildID,omitempty"`
+}
+
+// Validate the resource types and regions of the cleanup
+func (c *Cleanup) Validate() error {
+	err := validation.ValidateStruct(c,
+		validation.Field(&c.ResourceTypes,
+			validation.Required,
+			validation.Length(0, maxCleanupItems),
+			validation.By(matchesEach(resourceTypePattern, "must be aws-nuke resource types, eg. EC2Instance")),
+		),
+		validation.Field(&c.Regions,
+			validation.Length(0, maxCleanupItems),
+			validation.By(matchesEach(regionPattern, "must be AWS regions, eg. us-east-1")),
+		),
+	)
+	if err != nil {
+		return errors.NewValidation("cleanup", err)
+	}
+	return nil
+}
+
+// matchesEach checks every string of a list matches the pattern
+func matchesEach(pattern *regexp.Regexp, message string) validation.RuleFunc {
+	return func(value interface{}) error {
+		values, _ := value.([]string)
+		for _, v := range values {
+			if !pattern.MatchString(v) {
+				return fmt.Errorf("%s, not %q", message, v)
+			}
+		}
+		return nil
+	}
+}
+
+// RecordCleanup stores the cleanup last started in an Active lease's account
+func (a *Service) RecordCleanup(ID string, cleanup *Cleanup) (*Lease, error) {
+	err := cleanup.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+	err = validation.ValidateStruct(old,
+		validation.Field(&old.Status, validation.NotNil, validation.By(isLeaseActive)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("lease", ID, err)
+	}
+
+	now := time.Now().Unix()
+	updated := *old
+	updated.LastCleanup = &Cleanup{
+		ResourceTypes: cleanup.ResourceTypes,
+		Regions:       cleanup.Regions,
+		RequestedBy:   cleanup.RequestedBy,
+		RequestedOn:   now,
+		BuildID:       cleanup.BuildID,
+	}
+
+	// A cleanup doesn't change the lease status, so only the last modified
+	// date is updated
+	lastModifiedOn := old.LastModifiedOn
+	updated.LastModifiedOn = &now
+
+	err = a.write(&updated, lastModifiedOn, outbox.TypeLeaseUpdate, old)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.publish(outbox.TypeLeaseUpdate, old, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
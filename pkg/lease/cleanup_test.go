@@ -0,0 +1,84 @@
+package lease_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordCleanup(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		cleanup   *lease.Cleanup
+		getLease  *lease.Lease
+		expErr    error
+		expWrites int
+	}{
+		{
+			name:      "should record the cleanup",
+			cleanup:   &lease.Cleanup{ResourceTypes: []string{"EC2Instance"}, Regions: []string{"us-east-1"}, RequestedBy: "user1", BuildID: "reset:1"},
+			getLease:  &lease.Lease{Status: lease.StatusActive.StatusPtr(), LastModifiedOn: aws.Int64(1573592058)},
+			expWrites: 1,
+		},
+		{
+			name:     "should require resource types",
+			cleanup:  &lease.Cleanup{Regions: []string{"us-east-1"}},
+			getLease: &lease.Lease{Status: lease.StatusActive.StatusPtr(), LastModifiedOn: aws.Int64(1573592058)},
+			expErr:   errors.NewValidation("cleanup", fmt.Errorf("resourceTypes: cannot be blank.")),
+		},
+		{
+			name:     "should not allow invalid resource types",
+			cleanup:  &lease.Cleanup{ResourceTypes: []string{"EC2Instance", "*"}},
+			getLease: &lease.Lease{Status: lease.StatusActive.StatusPtr(), LastModifiedOn: aws.Int64(1573592058)},
+			expErr:   errors.NewValidation("cleanup", fmt.Errorf("resourceTypes: must be aws-nuke resource types, eg. EC2Instance, not \"*\".")),
+		},
+		{
+			name:     "should not allow invalid regions",
+			cleanup:  &lease.Cleanup{ResourceTypes: []string{"EC2Instance"}, Regions: []string{"us-east-1; rm"}},
+			getLease: &lease.Lease{Status: lease.StatusActive.StatusPtr(), LastModifiedOn: aws.Int64(1573592058)},
+			expErr:   errors.NewValidation("cleanup", fmt.Errorf("regions: must be AWS regions, eg. us-east-1, not \"us-east-1; rm\".")),
+		},
+		{
+			name:     "should not clean up the account of an inactive lease",
+			cleanup:  &lease.Cleanup{ResourceTypes: []string{"EC2Instance"}},
+			getLease: &lease.Lease{Status: lease.StatusInactive.StatusPtr(), LastModifiedOn: aws.Int64(1573592058)},
+			expErr:   errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be active lease.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(nil)
+			mocksEvent := &mocks.Eventer{}
+			mocksEvent.On("LeaseUpdate", tt.getLease, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc:  mocksRwd,
+				EventSvc: mocksEvent,
+			})
+
+			actualLease, err := leaseSvc.RecordCleanup("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.cleanup)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, []string{"EC2Instance"}, actualLease.LastCleanup.ResourceTypes)
+			assert.Equal(t, []string{"us-east-1"}, actualLease.LastCleanup.Regions)
+			assert.Equal(t, "user1", actualLease.LastCleanup.RequestedBy)
+			assert.Equal(t, "reset:1", actualLease.LastCleanup.BuildID)
+			assert.True(t, actualLease.LastCleanup.RequestedOn >= now)
+		})
+	}
+}
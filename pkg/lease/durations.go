@@ -0,0 +1,154 @@
+package lease
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Durations are how long leases last, in seconds.  Zero values are unset.
+type Durations struct {
+	// DefaultLeaseDuration is how long leases created without an expiresOn last
+	DefaultLeaseDuration int64 `json:"defaultLeaseDuration"`
+	// MaxLeaseDuration is the longest a lease may last from when it's created,
+	// including extensions
+	MaxLeaseDuration int64 `json:"maxLeaseDuration"`
+}
+
+// LeaseDurations are the durations leases are created and extended with.  The
+// account pool's durations override the system's, up to the system's maximum.
+type LeaseDurations struct {
+	Durations
+	System Durations  `json:"system"`
+	Pool   *Durations `json:"pool,omitempty"`
+}
+
+// systemDurations are the durations DCE is deployed with
+func (a *Service) systemDurations() Durations {
+	return Durations{
+		DefaultLeaseDuration: int64(a.defaultLeaseLengthInDays) * 24 * 60 * 60,
+		MaxLeaseDuration:     a.maxLeasePeriod,
+	}
+}
+
+// GetDurations returns the durations leases are created and extended with
+func (a *Service) GetDurations() (*LeaseDurations, error) {
+	pool, err := a.poolDurations()
+	if err != nil {
+		return nil, err
+	}
+	return newLeaseDurations(a.systemDurations(), pool), nil
+}
+
+// UpdatePoolDurations changes the durations of leases from the account pool,
+// within the system's maximum.  Unset durations use the system's.
+func (a *Service) UpdatePoolDurations(pool *Durations) (*LeaseDurations, error) {
+	if a.poolDurationsParameter == "" {
+		return nil, errors.NewServiceUnavailable("lease durations of the account pool are not configured")
+	}
+
+	system := a.systemDurations()
+	maxDuration := system.MaxLeaseDuration
+	if pool.MaxLeaseDuration > 0 {
+		maxDuration = pool.MaxLeaseDuration
+	}
+	err := validation.ValidateStruct(pool,
+		validation.Field(&pool.MaxLeaseDuration, validation.Min(int64(0)),
+			validation.Max(system.MaxLeaseDuration).Error(
+				fmt.Sprintf("must be at most %s, the system's maximum lease duration", formatDuration(system.MaxLeaseDuration)))),
+		validation.Field(&pool.DefaultLeaseDuration, validation.Min(int64(0)),
+			validation.Max(maxDuration).Error(
+				fmt.Sprintf("must be at most %s, the maximum lease duration", formatDuration(maxDuration)))),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("durations", err)
+	}
+
+	value, err := json.Marshal(pool)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal lease durations", err)
+	}
+	_, err = a.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(a.poolDurationsParameter),
+		Value:     aws.String(string(value)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to save lease durations", err)
+	}
+
+	return newLeaseDurations(system, pool), nil
+}
+
+// poolDurations reads the durations of the account pool, which are nil
+// until they're set
+func (a *Service) poolDurations() (*Durations, error) {
+	if a.poolDurationsParameter == "" {
+		return nil, nil
+	}
+
+	res, err := a.ssm.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(a.poolDurationsParameter),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get lease durations", err)
+	}
+
+	pool := &Durations{}
+	err = json.Unmarshal([]byte(aws.StringValue(res.Parameter.Value)), pool)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to parse lease durations", err)
+	}
+	return pool, nil
+}
+
+// newLeaseDurations overrides the system's durations with the pool's.  The
+// default duration is never longer than the maximum.
+func newLeaseDurations(system Durations, pool *Durations) *LeaseDurations {
+	durations := &LeaseDurations{
+		Durations: system,
+		System:    system,
+		Pool:      pool,
+	}
+	if pool != nil {
+		if pool.MaxLeaseDuration > 0 && pool.MaxLeaseDuration < system.MaxLeaseDuration {
+			durations.MaxLeaseDuration = pool.MaxLeaseDuration
+		}
+		if pool.DefaultLeaseDuration > 0 {
+			durations.DefaultLeaseDuration = pool.DefaultLeaseDuration
+		}
+	}
+	if durations.DefaultLeaseDuration > durations.MaxLeaseDuration {
+		durations.DefaultLeaseDuration = durations.MaxLeaseDuration
+	}
+	return durations
+}
+
+// formatDuration formats seconds in the largest whole unit, eg. "7 days"
+func formatDuration(seconds int64) string {
+	for _, unit := range []struct {
+		name    string
+		seconds int64
+	}{
+		{"day", 24 * 60 * 60},
+		{"hour", 60 * 60},
+		{"minute", 60},
+	} {
+		if seconds >= unit.seconds && seconds%unit.seconds == 0 {
+			if seconds == unit.seconds {
+				return fmt.Sprintf("1 %s", unit.name)
+			}
+			return fmt.Sprintf("%d %ss", seconds/unit.seconds, unit.name)
+		}
+	}
+	return fmt.Sprintf("%d seconds", seconds)
+}
@@ -0,0 +1,126 @@
+package lease_test
+
+import (
+	"fmt"
+	"testing"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const day = 24 * 60 * 60
+
+func newDurationsService(ssmAPI *awsMocks.SSMAPI) *lease.Service {
+	return lease.NewService(lease.NewServiceInput{
+		DefaultLeaseLengthInDays: 7,
+		MaxLeasePeriod:           14 * day,
+		SSM:                      ssmAPI,
+		PoolDurationsParameter:   "/dce/pool_lease_durations",
+	})
+}
+
+func TestGetDurations(t *testing.T) {
+	tests := []struct {
+		name         string
+		parameter    string
+		getErr       error
+		expDurations lease.Durations
+		expErr       error
+	}{
+		{
+			name:         "should use the system's durations until the pool's are set",
+			getErr:       awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil),
+			expDurations: lease.Durations{DefaultLeaseDuration: 7 * day, MaxLeaseDuration: 14 * day},
+		},
+		{
+			name:         "should use the pool's durations",
+			parameter:    `{"defaultLeaseDuration": 86400, "maxLeaseDuration": 259200}`,
+			expDurations: lease.Durations{DefaultLeaseDuration: day, MaxLeaseDuration: 3 * day},
+		},
+		{
+			name:         "should not use a pool maximum over the system's",
+			parameter:    `{"defaultLeaseDuration": 0, "maxLeaseDuration": 2592000}`,
+			expDurations: lease.Durations{DefaultLeaseDuration: 7 * day, MaxLeaseDuration: 14 * day},
+		},
+		{
+			name:         "should not default to more than the maximum",
+			parameter:    `{"defaultLeaseDuration": 0, "maxLeaseDuration": 259200}`,
+			expDurations: lease.Durations{DefaultLeaseDuration: 3 * day, MaxLeaseDuration: 3 * day},
+		},
+		{
+			name:   "should fail when the parameter can't be read",
+			getErr: fmt.Errorf("throttled"),
+			expErr: errors.NewInternalServer("failed to get lease durations", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssmAPI := &awsMocks.SSMAPI{}
+			ssmAPI.On("GetParameter", &ssm.GetParameterInput{
+				Name: aws.String("/dce/pool_lease_durations"),
+			}).Return(&ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: aws.String(tt.parameter)},
+			}, tt.getErr)
+
+			durations, err := newDurationsService(ssmAPI).GetDurations()
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expDurations, durations.Durations)
+			assert.Equal(t, lease.Durations{DefaultLeaseDuration: 7 * day, MaxLeaseDuration: 14 * day}, durations.System)
+		})
+	}
+}
+
+func TestUpdatePoolDurations(t *testing.T) {
+	tests := []struct {
+		name   string
+		pool   *lease.Durations
+		expErr error
+		expPut int
+	}{
+		{
+			name:   "should save the pool's durations",
+			pool:   &lease.Durations{DefaultLeaseDuration: day, MaxLeaseDuration: 3 * day},
+			expPut: 1,
+		},
+		{
+			name:   "should not allow a maximum over the system's",
+			pool:   &lease.Durations{MaxLeaseDuration: 30 * day},
+			expErr: errors.NewValidation("durations", fmt.Errorf("maxLeaseDuration: must be at most 14 days, the system's maximum lease duration.")),
+		},
+		{
+			name:   "should not allow a default over the maximum",
+			pool:   &lease.Durations{DefaultLeaseDuration: 5 * day, MaxLeaseDuration: 3 * day},
+			expErr: errors.NewValidation("durations", fmt.Errorf("defaultLeaseDuration: must be at most 3 days, the maximum lease duration.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssmAPI := &awsMocks.SSMAPI{}
+			ssmAPI.On("PutParameter", mock.MatchedBy(func(input *ssm.PutParameterInput) bool {
+				return *input.Name == "/dce/pool_lease_durations" && *input.Overwrite
+			})).Return(&ssm.PutParameterOutput{}, nil)
+
+			durations, err := newDurationsService(ssmAPI).UpdatePoolDurations(tt.pool)
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			ssmAPI.AssertNumberOfCalls(t, "PutParameter", tt.expPut)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, *tt.pool, durations.Durations)
+			assert.Equal(t, tt.pool, durations.Pool)
+		})
+	}
+}
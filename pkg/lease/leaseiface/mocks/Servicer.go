@@ -3,7 +3,6 @@
 package mocks
 
 import lease "github.com/Optum/dce/pkg/lease"
-
 import mock "github.com/stretchr/testify/mock"
 
 // Servicer is an autogenerated mock type for the Servicer type
@@ -11,6 +10,43 @@ type Servicer struct {
 	mock.Mock
 }
 
+// AcceptTerms provides a mock function with given fields: data, acceptedBy
+func (_m *Servicer) AcceptTerms(data *lease.Lease, acceptedBy string) error {
+	ret := _m.Called(data, acceptedBy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*lease.Lease, string) error); ok {
+		r0 = rf(data, acceptedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Archive provides a mock function with given fields: data, archiveKey
+func (_m *Servicer) Archive(data *lease.Lease, archiveKey string) (*lease.Lease, error) {
+	ret := _m.Called(data, archiveKey)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(*lease.Lease, string) *lease.Lease); ok {
+		r0 = rf(data, archiveKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*lease.Lease, string) error); ok {
+		r1 = rf(data, archiveKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: data, principalSpentAmount
 func (_m *Servicer) Create(data *lease.Lease, principalSpentAmount float64) (*lease.Lease, error) {
 	ret := _m.Called(data, principalSpentAmount)
@@ -34,6 +70,29 @@ func (_m *Servicer) Create(data *lease.Lease, principalSpentAmount float64) (*le
 	return r0, r1
 }
 
+// CreateWithQuota provides a mock function with given fields: data, principalSpentAmount, quota
+func (_m *Servicer) CreateWithQuota(data *lease.Lease, principalSpentAmount float64, quota *lease.Quota) (*lease.Lease, error) {
+	ret := _m.Called(data, principalSpentAmount, quota)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(*lease.Lease, float64, *lease.Quota) *lease.Lease); ok {
+		r0 = rf(data, principalSpentAmount, quota)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*lease.Lease, float64, *lease.Quota) error); ok {
+		r1 = rf(data, principalSpentAmount, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ID
 func (_m *Servicer) Delete(ID string) (*lease.Lease, error) {
 	ret := _m.Called(ID)
@@ -57,6 +116,112 @@ func (_m *Servicer) Delete(ID string) (*lease.Lease, error) {
 	return r0, r1
 }
 
+// DeleteTerms provides a mock function with given fields:
+func (_m *Servicer) DeleteTerms() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWithResetProfile provides a mock function with given fields: ID, resetProfile
+func (_m *Servicer) DeleteWithResetProfile(ID string, resetProfile string) (*lease.Lease, error) {
+	ret := _m.Called(ID, resetProfile)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, string) *lease.Lease); ok {
+		r0 = rf(ID, resetProfile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ID, resetProfile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// End provides a mock function with given fields: ID, reason
+func (_m *Servicer) End(ID string, reason lease.StatusReason) (*lease.Lease, error) {
+	ret := _m.Called(ID, reason)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, lease.StatusReason) *lease.Lease); ok {
+		r0 = rf(ID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, lease.StatusReason) error); ok {
+		r1 = rf(ID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndBatch provides a mock function with given fields: leases, reason
+func (_m *Servicer) EndBatch(leases []*lease.Lease, reason lease.StatusReason) ([]*lease.Lease, error) {
+	ret := _m.Called(leases, reason)
+
+	var r0 []*lease.Lease
+	if rf, ok := ret.Get(0).(func([]*lease.Lease, lease.StatusReason) []*lease.Lease); ok {
+		r0 = rf(leases, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*lease.Lease, lease.StatusReason) error); ok {
+		r1 = rf(leases, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Extend provides a mock function with given fields: ID, expiresOn
+func (_m *Servicer) Extend(ID string, expiresOn int64) (*lease.Lease, error) {
+	ret := _m.Called(ID, expiresOn)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, int64) *lease.Lease); ok {
+		r0 = rf(ID, expiresOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(ID, expiresOn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ID
 func (_m *Servicer) Get(ID string) (*lease.Lease, error) {
 	ret := _m.Called(ID)
@@ -103,6 +268,52 @@ func (_m *Servicer) GetByAccountIDAndPrincipalID(accountID string, principalID s
 	return r0, r1
 }
 
+// GetDurations provides a mock function with given fields:
+func (_m *Servicer) GetDurations() (*lease.LeaseDurations, error) {
+	ret := _m.Called()
+
+	var r0 *lease.LeaseDurations
+	if rf, ok := ret.Get(0).(func() *lease.LeaseDurations); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.LeaseDurations)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTerms provides a mock function with given fields:
+func (_m *Servicer) GetTerms() (*lease.Terms, error) {
+	ret := _m.Called()
+
+	var r0 *lease.Terms
+	if rf, ok := ret.Get(0).(func() *lease.Terms); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Terms)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: query
 func (_m *Servicer) List(query *lease.Lease) (*lease.Leases, error) {
 	ret := _m.Called(query)
@@ -139,3 +350,224 @@ func (_m *Servicer) ListPages(query *lease.Lease, fn func(*lease.Leases) bool) e
 
 	return r0
 }
+
+// RecordCleanup provides a mock function with given fields: ID, cleanup
+func (_m *Servicer) RecordCleanup(ID string, cleanup *lease.Cleanup) (*lease.Lease, error) {
+	ret := _m.Called(ID, cleanup)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, *lease.Cleanup) *lease.Lease); ok {
+		r0 = rf(ID, cleanup)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *lease.Cleanup) error); ok {
+		r1 = rf(ID, cleanup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordExecution provides a mock function with given fields: ID, workflow, executionArn
+func (_m *Servicer) RecordExecution(ID string, workflow string, executionArn string) (*lease.Lease, error) {
+	ret := _m.Called(ID, workflow, executionArn)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, string, string) *lease.Lease); ok {
+		r0 = rf(ID, workflow, executionArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(ID, workflow, executionArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordExpiryWarning provides a mock function with given fields: ID, hoursBefore
+func (_m *Servicer) RecordExpiryWarning(ID string, hoursBefore int64) (*lease.Lease, error) {
+	ret := _m.Called(ID, hoursBefore)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, int64) *lease.Lease); ok {
+		r0 = rf(ID, hoursBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(ID, hoursBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordStale provides a mock function with given fields: ID, stale
+func (_m *Servicer) RecordStale(ID string, stale bool) (*lease.Lease, error) {
+	ret := _m.Called(ID, stale)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, bool) *lease.Lease); ok {
+		r0 = rf(ID, stale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(ID, stale)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressAlerts provides a mock function with given fields: ID, suppression
+func (_m *Servicer) SuppressAlerts(ID string, suppression *lease.AlertSuppression) (*lease.Lease, error) {
+	ret := _m.Called(ID, suppression)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, *lease.AlertSuppression) *lease.Lease); ok {
+		r0 = rf(ID, suppression)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *lease.AlertSuppression) error); ok {
+		r1 = rf(ID, suppression)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ID, data
+func (_m *Servicer) Update(ID string, data *lease.Lease) (*lease.Lease, error) {
+	ret := _m.Called(ID, data)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, *lease.Lease) *lease.Lease); ok {
+		r0 = rf(ID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *lease.Lease) error); ok {
+		r1 = rf(ID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBudget provides a mock function with given fields: ID, change, spend
+func (_m *Servicer) UpdateBudget(ID string, change *lease.BudgetChange, spend float64) (*lease.Lease, error) {
+	ret := _m.Called(ID, change, spend)
+
+	var r0 *lease.Lease
+	if rf, ok := ret.Get(0).(func(string, *lease.BudgetChange, float64) *lease.Lease); ok {
+		r0 = rf(ID, change, spend)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Lease)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *lease.BudgetChange, float64) error); ok {
+		r1 = rf(ID, change, spend)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePoolDurations provides a mock function with given fields: pool
+func (_m *Servicer) UpdatePoolDurations(pool *lease.Durations) (*lease.LeaseDurations, error) {
+	ret := _m.Called(pool)
+
+	var r0 *lease.LeaseDurations
+	if rf, ok := ret.Get(0).(func(*lease.Durations) *lease.LeaseDurations); ok {
+		r0 = rf(pool)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.LeaseDurations)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*lease.Durations) error); ok {
+		r1 = rf(pool)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTerms provides a mock function with given fields: terms
+func (_m *Servicer) UpdateTerms(terms *lease.Terms) (*lease.Terms, error) {
+	ret := _m.Called(terms)
+
+	var r0 *lease.Terms
+	if rf, ok := ret.Get(0).(func(*lease.Terms) *lease.Terms); ok {
+		r0 = rf(terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lease.Terms)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*lease.Terms) error); ok {
+		r1 = rf(terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateEnd provides a mock function with given fields: data
+func (_m *Servicer) ValidateEnd(data *lease.Lease) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*lease.Lease) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -17,12 +17,73 @@ type Servicer interface {
 	// Save writes the record to the dataSvc
 	Create(data *lease.Lease, principalSpentAmount float64) (*lease.Lease, error)
 
+	// CreateWithQuota creates a lease within the budget limits of the quota
+	CreateWithQuota(data *lease.Lease, principalSpentAmount float64, quota *lease.Quota) (*lease.Lease, error)
+
+	// Update changes the notes and metadata of a lease
+	Update(ID string, data *lease.Lease) (*lease.Lease, error)
+
+	// UpdateBudget changes the budget of an Active lease
+	UpdateBudget(ID string, change *lease.BudgetChange, spend float64) (*lease.Lease, error)
+
+	// Extend changes when an Active lease expires
+	Extend(ID string, expiresOn int64) (*lease.Lease, error)
+
+	// SuppressAlerts acknowledges the budget alerts of an Active lease for a
+	// period, or lifts the suppression when it's nil
+	SuppressAlerts(ID string, suppression *lease.AlertSuppression) (*lease.Lease, error)
+
+	// GetDurations returns the durations leases are created and extended with
+	GetDurations() (*lease.LeaseDurations, error)
+
+	// UpdatePoolDurations changes the durations of leases from the account pool
+	UpdatePoolDurations(pool *lease.Durations) (*lease.LeaseDurations, error)
+
+	// GetTerms returns the terms of use leases must accept, or nil
+	GetTerms() (*lease.Terms, error)
+
+	// UpdateTerms changes the terms of use leases must accept
+	UpdateTerms(terms *lease.Terms) (*lease.Terms, error)
+
+	// DeleteTerms stops requiring leases to accept terms of use
+	DeleteTerms() error
+
+	// AcceptTerms checks the lease request accepts the current terms of use, and records the acceptance
+	AcceptTerms(data *lease.Lease, acceptedBy string) error
+
 	// Update the Lease record to status Inactive in DynamoDB
 	Delete(ID string) (*lease.Lease, error)
+	// DeleteWithResetProfile ends a lease like Delete, and resets its account with the reset profile instead of the pool's
+	DeleteWithResetProfile(ID string, resetProfile string) (*lease.Lease, error)
+
+	// End updates the Lease record to status Inactive with the given reason
+	End(ID string, reason lease.StatusReason) (*lease.Lease, error)
+
+	// ValidateEnd checks a lease can be ended, without ending it
+	ValidateEnd(data *lease.Lease) error
+
+	// EndBatch ends several active leases with the given reason
+	EndBatch(leases []*lease.Lease, reason lease.StatusReason) ([]*lease.Lease, error)
 
 	// List Get a list of lease based on Lease ID
 	List(query *lease.Lease) (*lease.Leases, error)
 
+	// RecordExecution stores the ARN of a Step Functions execution on the lease
+	RecordExecution(ID string, workflow string, executionArn string) (*lease.Lease, error)
+
+	// RecordExpiryWarning stores that an expiry warning was sent for the lease
+	RecordExpiryWarning(ID string, hoursBefore int64) (*lease.Lease, error)
+
+	// RecordCleanup stores the cleanup last started in an Active lease's account
+	RecordCleanup(ID string, cleanup *lease.Cleanup) (*lease.Lease, error)
+
+	// RecordStale flags a lease as unused, or clears the flag
+	RecordStale(ID string, stale bool) (*lease.Lease, error)
+
+	// Archive replaces an Inactive lease with its index record, once it's
+	// been written to the archive
+	Archive(data *lease.Lease, archiveKey string) (*lease.Lease, error)
+
 	// ListPages runs a function on each page in a list
 	ListPages(query *lease.Lease, fn func(*lease.Leases) bool) error
 }
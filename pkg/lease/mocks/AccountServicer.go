@@ -3,7 +3,6 @@
 package mocks
 
 import account "github.com/Optum/dce/pkg/account"
-
 import mock "github.com/stretchr/testify/mock"
 
 // AccountServicer is an autogenerated mock type for the AccountServicer type
@@ -11,13 +10,36 @@ type AccountServicer struct {
 	mock.Mock
 }
 
-// Reset provides a mock function with given fields: id
-func (_m *AccountServicer) Reset(id string) (*account.Account, error) {
-	ret := _m.Called(id)
+// ResetBatch provides a mock function with given fields: ids
+func (_m *AccountServicer) ResetBatch(ids []string) ([]*account.Account, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*account.Account
+	if rf, ok := ret.Get(0).(func([]string) []*account.Account); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*account.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetWithProfile provides a mock function with given fields: id, profile
+func (_m *AccountServicer) ResetWithProfile(id string, profile string) (*account.Account, error) {
+	ret := _m.Called(id, profile)
 
 	var r0 *account.Account
-	if rf, ok := ret.Get(0).(func(string) *account.Account); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) *account.Account); ok {
+		r0 = rf(id, profile)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*account.Account)
@@ -25,11 +47,25 @@ func (_m *AccountServicer) Reset(id string) (*account.Account, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, profile)
 	} else {
 		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
+
+// ValidatePolicyCustomization provides a mock function with given fields: data
+func (_m *AccountServicer) ValidatePolicyCustomization(data *account.PolicyCustomization) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*account.PolicyCustomization) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
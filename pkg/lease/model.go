@@ -5,6 +5,8 @@ import (
 	"github.com/google/uuid"
 	"strings"
 
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/db"
 	"github.com/Optum/dce/pkg/errors"
 	validation "github.com/go-ozzo/ozzo-validation"
 )
@@ -19,15 +21,43 @@ type Lease struct {
 	StatusReason             *StatusReason          `json:"leaseStatusReason,omitempty" dynamodbav:"LeaseStatusReason,omitempty" schema:"-"`                                                // Reason for the status of the lease
 	CreatedOn                *int64                 `json:"createdOn,omitempty" dynamodbav:"CreatedOn,omitempty" schema:"createdOn,omitempty"`                                              // Created Epoch Timestamp
 	LastModifiedOn           *int64                 `json:"lastModifiedOn,omitempty" dynamodbav:"LastModifiedOn,omitempty" schema:"lastModifiedOn,omitempty"`                               // Last Modified Epoch Timestamp
+	Version                  *int64                 `json:"version,omitempty" dynamodbav:"Version,omitempty" schema:"-"`                                                                    // Number of writes of the lease, which writes are conditional on
 	BudgetAmount             *float64               `json:"budgetAmount,omitempty" dynamodbav:"BudgetAmount,omitempty" schema:"budgetAmount,omitempty"`                                     // Budget Amount allocated for this lease
 	BudgetCurrency           *string                `json:"budgetCurrency,omitempty" dynamodbav:"BudgetCurrency,omitempty" schema:"budgetCurrency,omitempty"`                               // Budget currency
 	BudgetNotificationEmails *[]string              `json:"budgetNotificationEmails,omitempty" dynamodbav:"BudgetNotificationEmails,omitempty" schema:"budgetNotificationEmails,omitempty"` // Budget notification emails
 	StatusModifiedOn         *int64                 `json:"leaseStatusModifiedOn,omitempty" dynamodbav:"LeaseStatusModifiedOn,omitempty" schema:"leaseStatusModifiedOn,omitempty"`          // Last Modified Epoch Timestamp
 	ExpiresOn                *int64                 `json:"expiresOn,omitempty" dynamodbav:"ExpiresOn,omitempty" schema:"expiresOn,omitempty"`                                              // Lease expiration time as Epoch
 	Metadata                 map[string]interface{} `json:"metadata,omitempty"  dynamodbav:"Metadata,omitempty" schema:"-"`
+	Notes                    *string                `json:"notes,omitempty" dynamodbav:"Notes,omitempty" schema:"-"`                               // Freeform notes from the principal, eg. teardown instructions
+	ExecutionArns            map[string]string      `json:"executionArns,omitempty" dynamodbav:"ExecutionArns,omitempty" schema:"-"`               // Step Functions executions for the lease, by workflow
+	ExpiryWarningsSent       []int64                `json:"-" dynamodbav:"ExpiryWarningsSent,omitempty" schema:"-"`                                // Expiry warnings sent, by hours before the lease expires
+	StaleSince               *int64                 `json:"staleSince,omitempty" dynamodbav:"StaleSince,omitempty" schema:"-"`                     // When the lease was flagged as unused, and the principal notified
+	BudgetThresholdsSent     []float64              `json:"budgetThresholdsSent,omitempty" dynamodbav:"BudgetThresholdsSent,omitempty" schema:"-"` // Budget notifications sent, by percent of the budget
+	BudgetHistory            []BudgetChange         `json:"budgetHistory,omitempty" dynamodbav:"BudgetHistory,omitempty" schema:"-"`               // Changes to the budget after the lease was created
+	LastCheckedOn            *int64                 `json:"lastCheckedOn,omitempty" dynamodbav:"LastCheckedOn,omitempty" schema:"-"`               // When the budget of the lease was last checked
+	SharedWith               []string               `json:"sharedWith,omitempty" dynamodbav:"SharedWith,omitempty" schema:"-"`                     // Other principals who can get credentials for the leased account
+	AlertSuppression         *AlertSuppression      `json:"alertSuppression,omitempty" dynamodbav:"AlertSuppression,omitempty" schema:"-"`         // Budget alerts acknowledged by an admin, which aren't sent until it ends
+	ArchivedOn               *int64                 `json:"archivedOn,omitempty" dynamodbav:"ArchivedOn,omitempty" schema:"-"`                     // When the lease was moved to the archive, leaving only an index record
+	ArchiveKey               *string                `json:"archiveKey,omitempty" dynamodbav:"ArchiveKey,omitempty" schema:"-"`                     // S3 key of the archived lease
 	Limit                    *int64                 `json:"-" dynamodbav:"-" schema:"limit,omitempty"`
 	NextAccountID            *string                `json:"-" dynamodbav:"-" schema:"nextAccountId,omitempty"`
 	NextPrincipalID          *string                `json:"-" dynamodbav:"-" schema:"nextPrincipalId,omitempty"`
+	// PolicyCustomization changes the principal policy of the leased account, for the length of the lease
+	PolicyCustomization *account.PolicyCustomization `json:"policyCustomization,omitempty" dynamodbav:"PolicyCustomization,omitempty" schema:"-"`
+	// CategoryBudgets are parts of the budget for categories of spend, eg. compute, by category
+	CategoryBudgets map[string]CategoryBudget `json:"categoryBudgets,omitempty" dynamodbav:"CategoryBudgets,omitempty" schema:"-"`
+	// RequiredQuotas are the service quotas the leased account must have, eg. 32 vCPUs of P instances
+	RequiredQuotas []account.ServiceQuota `json:"requiredQuotas,omitempty" dynamodbav:"RequiredQuotas,omitempty" schema:"-"`
+	// Requirements are what the leased account must have, eg. its pool, regions or reset profile
+	Requirements *account.Requirements `json:"requirements,omitempty" dynamodbav:"Requirements,omitempty" schema:"-"`
+	// TermsAcceptance is the version of the terms of use accepted when the lease was requested
+	TermsAcceptance *TermsAcceptance `json:"termsAcceptance,omitempty" dynamodbav:"TermsAcceptance,omitempty" schema:"-"`
+	// RequestedOn is when the lease was requested, which is earlier than ProvisionedOn when the request waited for an account
+	RequestedOn *int64 `json:"requestedOn,omitempty" dynamodbav:"RequestedOn,omitempty" schema:"-"`
+	// ProvisionedOn is when the account was leased.  CreatedOn is older when the record of a past lease was reused.
+	ProvisionedOn *int64 `json:"provisionedOn,omitempty" dynamodbav:"ProvisionedOn,omitempty" schema:"-"`
+	// LastCleanup is the cleanup last started in the leased account
+	LastCleanup *Cleanup `json:"lastCleanup,omitempty" dynamodbav:"LastCleanup,omitempty" schema:"-"`
 }
 
 // Validate the lease data
@@ -46,6 +76,80 @@ func (l *Lease) Validate() error {
 	return nil
 }
 
+// IsSharedWith is true when the principal is one of the other principals the
+// lease is shared with, the same as for the lease's record
+func (l *Lease) IsSharedWith(principalID string) bool {
+	return (&db.Lease{SharedWith: l.SharedWith}).IsSharedWith(principalID)
+}
+
+// BudgetChange is a change to the budget of an Active lease
+type BudgetChange struct {
+	PreviousAmount float64 `json:"previousAmount" dynamodbav:"PreviousAmount"`
+	Amount         float64 `json:"amount" dynamodbav:"Amount"`
+	Reason         string  `json:"reason,omitempty" dynamodbav:"Reason,omitempty"`
+	ChangedBy      string  `json:"changedBy,omitempty" dynamodbav:"ChangedBy,omitempty"`
+	ChangedOn      int64   `json:"changedOn" dynamodbav:"ChangedOn"`
+}
+
+// CategoryBudget is the part of the lease's budget for a category of spend,
+// eg. AI services, with its own notification thresholds.  The lease ends when
+// an enforced category budget is exceeded, otherwise only its notifications
+// are sent.
+type CategoryBudget struct {
+	Amount float64 `json:"amount" dynamodbav:"Amount"`
+	// NotificationThresholds are the percents of the amount notified, which
+	// default to the lease budget's thresholds
+	NotificationThresholds []float64 `json:"notificationThresholds,omitempty" dynamodbav:"NotificationThresholds,omitempty"`
+	Enforce                bool      `json:"enforce" dynamodbav:"Enforce"`
+	// ThresholdsSent are the notifications sent, by percent of the amount
+	ThresholdsSent []float64 `json:"thresholdsSent,omitempty" dynamodbav:"ThresholdsSent,omitempty"`
+}
+
+// AlertSuppression acknowledges the budget alerts of a lease for a period,
+// eg. for an expected spike in spend during a load test.  Budget
+// notifications aren't sent until it ends, and are sent then if the lease's
+// spend is still past their threshold.
+type AlertSuppression struct {
+	Until        int64  `json:"until" dynamodbav:"Until"`
+	Reason       string `json:"reason" dynamodbav:"Reason"`
+	SuppressedBy string `json:"suppressedBy,omitempty" dynamodbav:"SuppressedBy,omitempty"`
+	SuppressedOn int64  `json:"suppressedOn" dynamodbav:"SuppressedOn"`
+}
+
+// IsActive is true until the suppression ends
+func (s *AlertSuppression) IsActive(now int64) bool {
+	return s != nil && now < s.Until
+}
+
+// IsArchived is true when only the index record of the lease is left, and
+// the full lease is in the archive
+func (l *Lease) IsArchived() bool {
+	return l.ArchivedOn != nil
+}
+
+// ArchiveIndex is the record of an archived lease which is kept in
+// DynamoDB, so the lease can still be found, and its full record read from
+// the archive
+func (l *Lease) ArchiveIndex(archiveKey string, archivedOn int64) *Lease {
+	return &Lease{
+		AccountID:        l.AccountID,
+		PrincipalID:      l.PrincipalID,
+		ID:               l.ID,
+		Status:           l.Status,
+		StatusReason:     l.StatusReason,
+		CreatedOn:        l.CreatedOn,
+		LastModifiedOn:   &archivedOn,
+		StatusModifiedOn: l.StatusModifiedOn,
+		ExpiresOn:        l.ExpiresOn,
+		BudgetAmount:     l.BudgetAmount,
+		BudgetCurrency:   l.BudgetCurrency,
+		RequestedOn:      l.RequestedOn,
+		ProvisionedOn:    l.ProvisionedOn,
+		ArchivedOn:       &archivedOn,
+		ArchiveKey:       &archiveKey,
+	}
+}
+
 // Leases is a list of type Lease
 type Leases []Lease
 
@@ -97,6 +201,8 @@ const (
 	StatusReasonExpired StatusReason = "Expired"
 	// StatusReasonOverBudget means the lease is over its budgeted amount and is therefore reset/reclaimed.
 	StatusReasonOverBudget StatusReason = "OverBudget"
+	// StatusReasonOverCategoryBudget means the lease is over an enforced category budget, eg. for AI services, and is therefore reset/reclaimed.
+	StatusReasonOverCategoryBudget StatusReason = "OverCategoryBudget"
 	// StatusReasonOverPrincipalBudget means the lease is over its principal budgeted amount and is therefore reset/reclaimed.
 	StatusReasonOverPrincipalBudget StatusReason = "OverPrincipalBudget"
 	// StatusReasonDestroyed means the lease has been deleted via an API call or other user action.
@@ -109,6 +215,10 @@ const (
 	// StatusReasonAccountOrphaned means that the health of the account was compromised.  The account has been orphaned
 	// which means the leases are also made Inactive
 	StatusReasonAccountOrphaned StatusReason = "LeaseAccountOrphaned"
+	// StatusReasonPrincipalDeactivated means the principal of the lease was deactivated in the directory
+	StatusReasonPrincipalDeactivated StatusReason = "PrincipalDeactivated"
+	// StatusReasonStale means the lease was unused for longer than the stale lease grace period
+	StatusReasonStale StatusReason = "Stale"
 )
 
 // StatusReasonPtr returns a pointer to the string value of StatusReason
@@ -125,7 +235,13 @@ type NewLeaseInput struct {
 	BudgetCurrency           string
 	BudgetNotificationEmails []string
 	Metadata                 map[string]interface{}
+	Notes                    *string
 	ExpiresOn                int64
+	PolicyCustomization      *account.PolicyCustomization
+	CategoryBudgets          map[string]CategoryBudget
+	RequiredQuotas           []account.ServiceQuota
+	Requirements             *account.Requirements
+	TermsAcceptance          *TermsAcceptance
 }
 
 // NewLease creates a new instance of lease
@@ -139,8 +255,20 @@ func NewLease(input NewLeaseInput) *Lease {
 		BudgetCurrency:           &input.BudgetCurrency,
 		BudgetNotificationEmails: &input.BudgetNotificationEmails,
 		Metadata:                 input.Metadata,
+		Notes:                    input.Notes,
 		Status:                   StatusActive.StatusPtr(),
 		StatusReason:             StatusReasonActive.StatusReasonPtr(),
 		ExpiresOn:                &input.ExpiresOn,
+		PolicyCustomization:      input.PolicyCustomization,
+		CategoryBudgets:          input.CategoryBudgets,
+		RequiredQuotas:           input.RequiredQuotas,
+		Requirements:             input.Requirements,
+		TermsAcceptance:          input.TermsAcceptance,
 	}
 }
+
+// AccountRequirements are everything the leased account must have: the
+// requirements, and the required quotas
+func (l *Lease) AccountRequirements() *account.Requirements {
+	return l.Requirements.WithQuotas(l.RequiredQuotas)
+}
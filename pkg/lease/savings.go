@@ -0,0 +1,28 @@
+package lease
+
+import (
+	"github.com/Optum/dce/pkg/usage"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// SavingsLease gets the lease as the savings report sees it.  Leases
+// provisioned before ProvisionedOn was recorded were provisioned when they
+// were created.
+func (l *Lease) SavingsLease() usage.SavingsLease {
+	savingsLease := usage.SavingsLease{
+		AccountID:     aws.StringValue(l.AccountID),
+		PrincipalID:   aws.StringValue(l.PrincipalID),
+		RequestedOn:   aws.Int64Value(l.RequestedOn),
+		ProvisionedOn: aws.Int64Value(l.CreatedOn),
+	}
+	if l.ProvisionedOn != nil {
+		savingsLease.ProvisionedOn = *l.ProvisionedOn
+	}
+	if l.Status != nil && *l.Status == StatusInactive {
+		savingsLease.EndedOn = aws.Int64Value(l.StatusModifiedOn)
+		if l.StatusReason != nil {
+			savingsLease.EndedReason = string(*l.StatusReason)
+		}
+	}
+	return savingsLease
+}
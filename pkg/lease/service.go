@@ -5,7 +5,14 @@ import (
 	"time"
 
 	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/budget"
 	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/policy"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/settings"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
@@ -14,6 +21,25 @@ type Writer interface {
 	Write(input *Lease, lastModifiedOn *int64) error
 }
 
+// OutboxWriter puts an item into the data store with the outbox entries of
+// its events, in one transaction
+type OutboxWriter interface {
+	WriteWithEvents(input *Lease, lastModifiedOn *int64, entries []*outbox.Entry) error
+}
+
+// Creator writes new leases, with the outbox entries of their events, and
+// sets their accounts Leased in the same transaction, only while their
+// principal has fewer than maxActive Active leases
+type Creator interface {
+	Create(input *Lease, lastModifiedOn *int64, maxActive int, entries []*outbox.Entry) error
+}
+
+// SettingsReader reads the system-wide settings, which override the
+// configuration of the service
+type SettingsReader interface {
+	Current() (*settings.Settings, error)
+}
+
 // SingleReader Reads an item information from the data store
 type SingleReader interface {
 	Get(leaseID string) (*Lease, error)
@@ -31,6 +57,12 @@ type Reader interface {
 	MultipleReader
 }
 
+// BatchEnder ends several leases at once, and sets their accounts NotReady.
+// It returns the error of each lease, which is nil when the lease was ended.
+type BatchEnder interface {
+	EndBatch(leases []*Lease, prevLastModifiedOn []*int64) []error
+}
+
 // ReaderWriter includes Reader and Writer interfaces
 type ReaderWriter interface {
 	Reader
@@ -48,20 +80,42 @@ type Eventer interface {
 // accountiface.Servicer interface, with only the methods
 // needed by the LeaseService
 type AccountServicer interface {
-	// EndLease indicates that the provided account is no longer leased.
-	Reset(id string) (*account.Account, error)
+	// ResetWithProfile indicates that the provided account is no longer
+	// leased, and resets it with the reset profile, or the pool's when it's empty
+	ResetWithProfile(id string, profile string) (*account.Account, error)
+	// ResetBatch queues resets of several accounts, which are already NotReady
+	ResetBatch(ids []string) ([]*account.Account, error)
+	// ValidatePolicyCustomization checks a customization of the principal policy
+	ValidatePolicyCustomization(data *account.PolicyCustomization) error
+}
+
+// PolicyEvaluator evaluates the deployer's lease approval policy
+type PolicyEvaluator interface {
+	Evaluate(document string, input interface{}) (*policy.Decision, error)
 }
 
 // Service is a type corresponding to a Lease table record
 type Service struct {
 	dataSvc                  ReaderWriter
+	batchSvc                 BatchEnder
 	eventSvc                 Eventer
+	outboxSvc                OutboxWriter
 	accountSvc               AccountServicer
+	policySvc                PolicyEvaluator
 	defaultLeaseLengthInDays int
 	principalBudgetAmount    float64
 	principalBudgetPeriod    string
 	maxLeaseBudgetAmount     float64
 	maxLeasePeriod           int64
+	budgetCurrency           string
+	budgetCategories         string
+	ssm                      ssmiface.SSMAPI
+	poolDurationsParameter   string
+	termsParameter           string
+	principalIDFormat        *principal.IDFormat
+	creatorSvc               Creator
+	maxLeasesPerPrincipal    int
+	settingsSvc              SettingsReader
 }
 
 // Weekly
@@ -82,7 +136,7 @@ func (a *Service) Get(ID string) (*Lease, error) {
 
 // GetByAccountIDAndPrincipalID gets the Lease record by AccountID and PrincipalID
 func (a *Service) GetByAccountIDAndPrincipalID(accountID string, principalID string) (*Lease, error) {
-	new, err := a.dataSvc.GetByAccountIDAndPrincipalID(accountID, principalID)
+	new, err := a.dataSvc.GetByAccountIDAndPrincipalID(accountID, a.principalIDFormat.Normalize(principalID))
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +145,12 @@ func (a *Service) GetByAccountIDAndPrincipalID(accountID string, principalID str
 
 // Save writes the record to the dataSvc
 func (a *Service) Save(data *Lease) error {
+	return a.save(data, "")
+}
+
+// save writes the record with the event of the change when eventType is
+// given
+func (a *Service) save(data *Lease, eventType string) error {
 	var lastModifiedOn *int64
 	now := time.Now().Unix()
 	if data.LastModifiedOn == nil {
@@ -108,42 +168,119 @@ func (a *Service) Save(data *Lease) error {
 	if err != nil {
 		return err
 	}
-	err = a.dataSvc.Write(data, lastModifiedOn)
+	err = a.write(data, lastModifiedOn, eventType, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// write writes the lease.  When there's an outbox, the event of the change
+// is written to it in the same transaction, to be published later.
+func (a *Service) write(data *Lease, lastModifiedOn *int64, eventType string, old *Lease) error {
+	entries := []*outbox.Entry{}
+	if a.outboxSvc != nil && eventType != "" {
+		var oldEvent interface{}
+		if old != nil {
+			oldEvent = old
+		}
+		entry, err := outbox.NewEntry(eventType, aws.StringValue(data.ID), oldEvent, data)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	// New leases are written with their accounts, and counted against their
+	// principal's quota, in one transaction, so accounts can't be leased
+	// twice and concurrent requests can't exceed the quota
+	if eventType == outbox.TypeLeaseCreate && a.creatorSvc != nil {
+		return a.creatorSvc.Create(data, lastModifiedOn, a.maxActiveLeases(), entries)
+	}
+	if len(entries) == 0 {
+		return a.dataSvc.Write(data, lastModifiedOn)
+	}
+	return a.outboxSvc.WriteWithEvents(data, lastModifiedOn, entries)
+}
+
+// maxActiveLeases is how many Active leases a principal may have at once
+func (a *Service) maxActiveLeases() int {
+	if a.maxLeasesPerPrincipal < 1 {
+		return 1
+	}
+	return a.maxLeasesPerPrincipal
+}
+
+// ActiveLeasesExceeded is the error of a lease which would give its principal
+// more Active leases than they may have
+func ActiveLeasesExceeded(principalID string, maxActive int) error {
+	return errors.NewConflict("lease", principalID,
+		fmt.Errorf("principal %s already has %d Active leases, the most they may have", principalID, maxActive))
+}
+
+// publish publishes the event of a change, unless it was written to the
+// outbox with the change
+func (a *Service) publish(eventType string, old *Lease, new *Lease) error {
+	if a.outboxSvc != nil {
+		return nil
+	}
+	switch eventType {
+	case outbox.TypeLeaseCreate:
+		return a.eventSvc.LeaseCreate(new)
+	case outbox.TypeLeaseEnd:
+		return a.eventSvc.LeaseEnd(new)
+	case outbox.TypeLeaseUpdate:
+		return a.eventSvc.LeaseUpdate(old, new)
+	}
+	return nil
+}
+
 // Delete finds a given lease and checks if it's active and then updates it to status `Inactive`. Returns the lease.
 func (a *Service) Delete(ID string) (*Lease, error) {
+	return a.end(ID, StatusReasonDestroyed, "")
+}
+
+// DeleteWithResetProfile ends a lease like Delete, and resets its account
+// with the reset profile, eg. compute-only, instead of the pool's
+func (a *Service) DeleteWithResetProfile(ID string, resetProfile string) (*Lease, error) {
+	return a.end(ID, StatusReasonDestroyed, resetProfile)
+}
+
+// End finds a given lease and checks if it's active and then updates it to status `Inactive`
+// with the given reason. Returns the lease.
+func (a *Service) End(ID string, reason StatusReason) (*Lease, error) {
+	return a.end(ID, reason, "")
+}
+
+func (a *Service) end(ID string, reason StatusReason, resetProfile string) (*Lease, error) {
+	err := account.ValidateResetProfile(resetProfile)
+	if err != nil {
+		return nil, errors.NewValidation("lease", fmt.Errorf("resetProfile: %s.", err))
+	}
 
 	data, err := a.dataSvc.Get(ID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = validation.ValidateStruct(data,
-		validation.Field(&data.Status, validation.NotNil, validation.By(isLeaseActive)),
-		validation.Field(&data.AccountID, validateAccountID...),
-	)
+	err = a.ValidateEnd(data)
 	if err != nil {
-		return nil, errors.NewConflict("lease", *data.ID, err)
+		return nil, err
 	}
 
 	data.Status = StatusInactive.StatusPtr()
-	data.StatusReason = StatusReasonDestroyed.StatusReasonPtr()
-	err = a.dataSvc.Write(data, data.LastModifiedOn)
+	data.StatusReason = reason.StatusReasonPtr()
+	err = a.write(data, data.LastModifiedOn, outbox.TypeLeaseEnd, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = a.accountSvc.Reset(*data.AccountID)
+	_, err = a.accountSvc.ResetWithProfile(*data.AccountID, resetProfile)
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.eventSvc.LeaseEnd(data)
+	err = a.publish(outbox.TypeLeaseEnd, nil, data)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +288,80 @@ func (a *Service) Delete(ID string) (*Lease, error) {
 	return data, nil
 }
 
+// ValidateEnd checks a lease can be ended, without ending it
+func (a *Service) ValidateEnd(data *Lease) error {
+	err := validation.ValidateStruct(data,
+		validation.Field(&data.Status, validation.NotNil, validation.By(isLeaseActive)),
+		validation.Field(&data.AccountID, validateAccountID...),
+	)
+	if err != nil {
+		return errors.NewConflict("lease", aws.StringValue(data.ID), err)
+	}
+	return nil
+}
+
+// EndBatch ends several active leases with the given reason, such as when
+// many leases expire at once.  Leases are ended in DynamoDB transactions with
+// their accounts, and the resets of their accounts are queued in batches.
+// Returns the leases which were ended, and the errors of the others.
+func (a *Service) EndBatch(leases []*Lease, reason StatusReason) ([]*Lease, error) {
+	if a.batchSvc == nil {
+		return nil, errors.NewInternalServer("ending leases in bulk is not supported", nil)
+	}
+
+	errs := []error{}
+	toEnd := []*Lease{}
+	prevLastModifiedOn := []*int64{}
+	now := time.Now().Unix()
+	for _, l := range leases {
+		// The leases passed in are left as they were
+		copied := *l
+		data := &copied
+		err := a.ValidateEnd(data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		prevLastModifiedOn = append(prevLastModifiedOn, data.LastModifiedOn)
+		data.Status = StatusInactive.StatusPtr()
+		data.StatusReason = reason.StatusReasonPtr()
+		data.LastModifiedOn = &now
+		data.StatusModifiedOn = &now
+		toEnd = append(toEnd, data)
+	}
+
+	ended := []*Lease{}
+	accountIDs := []string{}
+	for i, err := range a.batchSvc.EndBatch(toEnd, prevLastModifiedOn) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ended = append(ended, toEnd[i])
+		accountIDs = append(accountIDs, *toEnd[i].AccountID)
+	}
+
+	if len(accountIDs) > 0 {
+		_, err := a.accountSvc.ResetBatch(accountIDs)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, data := range ended {
+		err := a.publish(outbox.TypeLeaseEnd, nil, data)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return ended, errors.NewMultiError("failed to end leases", errs)
+	}
+	return ended, nil
+}
+
 // List Get a list of leases based on Principal ID
 func (a *Service) List(query *Lease) (*Leases, error) {
 	err := validation.ValidateStruct(query,
@@ -160,6 +371,7 @@ func (a *Service) List(query *Lease) (*Leases, error) {
 	if err != nil {
 		return nil, errors.NewValidation("lease", err)
 	}
+	a.normalizeQuery(query)
 
 	leases, err := a.dataSvc.List(query)
 	if err != nil {
@@ -169,12 +381,60 @@ func (a *Service) List(query *Lease) (*Leases, error) {
 	return leases, nil
 }
 
+// Quota overrides the budget limits of the service for a principal, eg. from
+// their directory groups. Zero values use the limits of the service.
+type Quota struct {
+	PrincipalBudgetAmount float64 `json:"principalBudgetAmount,omitempty"`
+	MaxLeaseBudgetAmount  float64 `json:"maxLeaseBudgetAmount,omitempty"`
+}
+
+// currentSettings returns the system-wide settings, which are empty without
+// a settings service
+func (a *Service) currentSettings() (*settings.Settings, error) {
+	if a.settingsSvc == nil {
+		return &settings.Settings{}, nil
+	}
+	return a.settingsSvc.Current()
+}
+
 // Create creates a new lease using the data provided. Returns the lease record
 func (a *Service) Create(data *Lease, principalSpentAmount float64) (*Lease, error) {
+	return a.CreateWithQuota(data, principalSpentAmount, nil)
+}
+
+// CreateWithQuota creates a new lease using the data provided, within the
+// budget limits of the quota. Returns the lease record
+func (a *Service) CreateWithQuota(data *Lease, principalSpentAmount float64, quota *Quota) (*Lease, error) {
+	current, err := a.currentSettings()
+	if err != nil {
+		return nil, err
+	}
+	limits := Quota{
+		PrincipalBudgetAmount: a.principalBudgetAmount,
+		MaxLeaseBudgetAmount:  a.maxLeaseBudgetAmount,
+	}
+	if current.PrincipalBudgetAmount > 0 {
+		limits.PrincipalBudgetAmount = current.PrincipalBudgetAmount
+	}
+	if current.MaxLeaseBudgetAmount > 0 {
+		limits.MaxLeaseBudgetAmount = current.MaxLeaseBudgetAmount
+	}
+	if quota != nil && quota.PrincipalBudgetAmount > 0 {
+		limits.PrincipalBudgetAmount = quota.PrincipalBudgetAmount
+	}
+	if quota != nil && quota.MaxLeaseBudgetAmount > 0 {
+		limits.MaxLeaseBudgetAmount = quota.MaxLeaseBudgetAmount
+	}
+
+	durations, err := a.GetDurations()
+	if err != nil {
+		return nil, err
+	}
 
 	// Set default expiresOn
+	now := time.Now().Unix()
 	if data.ExpiresOn == nil {
-		leaseExpires := time.Now().AddDate(0, 0, a.defaultLeaseLengthInDays).Unix()
+		leaseExpires := now + durations.DefaultLeaseDuration
 		data.ExpiresOn = &leaseExpires
 	}
 
@@ -185,42 +445,100 @@ func (a *Service) Create(data *Lease, principalSpentAmount float64) (*Lease, err
 
 	// Set default budget amount
 	if data.BudgetAmount == nil {
-		data.BudgetAmount = &a.maxLeaseBudgetAmount
+		data.BudgetAmount = &limits.MaxLeaseBudgetAmount
 	}
 
 	// Set default budget currency
 	if data.BudgetCurrency == nil {
-		currency := ""
+		currency := a.budgetCurrency
 		data.BudgetCurrency = &currency
 	}
 
 	// Set default budget notification emails
 	if data.BudgetNotificationEmails == nil {
 		notificationEmails := []string{""}
+		if len(current.BudgetNotificationEmails) > 0 {
+			notificationEmails = append([]string{}, current.BudgetNotificationEmails...)
+		}
 		data.BudgetNotificationEmails = &notificationEmails
 	}
 
+	if data.PrincipalID != nil {
+		principalID := a.principalIDFormat.Normalize(*data.PrincipalID)
+		data.PrincipalID = &principalID
+	}
+
 	// Validate the incoming record doesn't have unneeded fields
-	err := validation.ValidateStruct(data,
+	err = validation.ValidateStruct(data,
 		validation.Field(&data.AccountID, validateAccountID...),
-		validation.Field(&data.PrincipalID, validatePrincipalID...),
+		validation.Field(&data.PrincipalID, append(validatePrincipalID, validation.By(a.isPrincipalIDValid))...),
 		validation.Field(&data.ID, validation.By(isNil)),
 		validation.Field(&data.Status, validation.By(isNil)),
 		validation.Field(&data.StatusReason, validation.By(isNil)),
-		validation.Field(&data.ExpiresOn, validation.NotNil, validation.By(isExpiresOnValid(a))),
+		validation.Field(&data.ExpiresOn, validation.NotNil, validation.By(isExpiresOnValid(now, durations.MaxLeaseDuration, "from now"))),
+		validation.Field(&data.Notes, validateNotes...),
+		validation.Field(&data.RequiredQuotas, validation.By(isRequiredQuotasValid)),
+		validation.Field(&data.Requirements),
 	)
 	if err != nil {
 		return nil, errors.NewValidation("lease", err)
 	}
 
+	// The terms are accepted when the lease is requested, which may be before
+	// it's created from the waitlist
+	terms, err := a.GetTerms()
+	if err != nil {
+		return nil, err
+	}
 	err = validation.ValidateStruct(data,
-		validation.Field(&data.BudgetAmount, validation.By(isBudgetAmountValid(a, *data.PrincipalID, principalSpentAmount))),
+		validation.Field(&data.TermsAcceptance, validation.By(isTermsAccepted(terms))),
 	)
 	if err != nil {
 		return nil, errors.NewValidation("lease", err)
 	}
 
-	// Check if principal already has an active lease
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.BudgetAmount, validation.By(isBudgetAmountValid(limits, *data.PrincipalID, principalSpentAmount))),
+		validation.Field(&data.BudgetCurrency, validation.By(isBudgetCurrencyValid(a))),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("lease", err)
+	}
+
+	// Category budgets are checked against the categories configured for
+	// the account pool.  Notifications of a new lease are yet to be sent.
+	if len(data.CategoryBudgets) > 0 {
+		categories, err := budget.ParseCategories(a.budgetCategories)
+		if err != nil {
+			return nil, errors.NewInternalServer("failed to parse the budget categories", err)
+		}
+		err = validation.ValidateStruct(data,
+			validation.Field(&data.CategoryBudgets, validation.By(isCategoryBudgetsValid(categories, *data.BudgetAmount))),
+		)
+		if err != nil {
+			return nil, errors.NewValidation("lease", err)
+		}
+		for name, categoryBudget := range data.CategoryBudgets {
+			categoryBudget.ThresholdsSent = nil
+			data.CategoryBudgets[name] = categoryBudget
+		}
+	}
+
+	// The customization is applied to the principal policy once the lease starts
+	if !data.PolicyCustomization.IsEmpty() {
+		err = a.accountSvc.ValidatePolicyCustomization(data.PolicyCustomization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.evaluateCreatePolicy(data, principalSpentAmount, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if principal already has as many active leases as they may.
+	// The quota writer checks again as the lease is written.
 	query := &Lease{
 		PrincipalID: data.PrincipalID,
 		Status:      StatusActive.StatusPtr(),
@@ -230,7 +548,11 @@ func (a *Service) Create(data *Lease, principalSpentAmount float64) (*Lease, err
 	if err != nil {
 		return nil, errors.NewInternalServer("lease", err)
 	}
-	if existingLeases != nil && len(*existingLeases) > 0 {
+	maxActive := a.maxActiveLeases()
+	if existingLeases != nil && len(*existingLeases) >= maxActive {
+		if maxActive > 1 {
+			return nil, ActiveLeasesExceeded(*data.PrincipalID, maxActive)
+		}
 		message := fmt.Sprintf("with principal %s and account %s", *data.PrincipalID, *data.AccountID)
 		return nil, errors.NewAlreadyExists("lease", message)
 	}
@@ -241,34 +563,445 @@ func (a *Service) Create(data *Lease, principalSpentAmount float64) (*Lease, err
 		BudgetAmount:             *data.BudgetAmount,
 		BudgetCurrency:           *data.BudgetCurrency,
 		BudgetNotificationEmails: *data.BudgetNotificationEmails,
+		Metadata:                 data.Metadata,
+		Notes:                    data.Notes,
 		ExpiresOn:                *data.ExpiresOn,
+		PolicyCustomization:      data.PolicyCustomization,
+		CategoryBudgets:          data.CategoryBudgets,
+		RequiredQuotas:           data.RequiredQuotas,
+		Requirements:             data.Requirements,
+		TermsAcceptance:          data.TermsAcceptance,
 	})
 
+	// A lease of the same account by the same principal replaces the
+	// principal's last lease of the account
 	if data.LastModifiedOn != nil {
 		newLeaseRecord.LastModifiedOn = data.LastModifiedOn
+		newLeaseRecord.Version = data.Version
 	}
 	if data.CreatedOn != nil {
 		newLeaseRecord.CreatedOn = data.CreatedOn
 	}
+	// Leases from the waitlist were requested before they were provisioned
+	newLeaseRecord.ProvisionedOn = &now
+	newLeaseRecord.RequestedOn = &now
+	if data.RequestedOn != nil && *data.RequestedOn < now {
+		newLeaseRecord.RequestedOn = data.RequestedOn
+	}
+
+	err = a.save(newLeaseRecord, outbox.TypeLeaseCreate)
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.Save(newLeaseRecord)
+	err = a.publish(outbox.TypeLeaseCreate, nil, newLeaseRecord)
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.eventSvc.LeaseCreate(newLeaseRecord)
+	return newLeaseRecord, nil
+}
+
+// CreatePolicyInput is the input of the lease approval policy
+type CreatePolicyInput struct {
+	Lease                *Lease  `json:"lease"`
+	PrincipalSpentAmount float64 `json:"principalSpentAmount"`
+	Quota                Quota   `json:"quota"`
+}
+
+// evaluateCreatePolicy checks the deployer's lease approval policy allows the lease
+func (a *Service) evaluateCreatePolicy(data *Lease, principalSpentAmount float64, limits Quota) error {
+	if a.policySvc == nil {
+		return nil
+	}
+	decision, err := a.policySvc.Evaluate(policy.LeaseCreate, &CreatePolicyInput{
+		Lease:                data,
+		PrincipalSpentAmount: principalSpentAmount,
+		Quota:                limits,
+	})
+	if err != nil {
+		return err
+	}
+	if !decision.Allow {
+		return errors.NewValidation("lease", fmt.Errorf(decision.Summary()))
+	}
+	return nil
+}
+
+// Update changes the notes, metadata and sharing of a lease.  Empty notes
+// are removed, and metadata is merged into the lease's metadata, with null
+// values removing their keys.  sharedWith replaces the other principals an
+// Active lease is shared with, and an empty list stops sharing it.  Returns
+// the updated lease.
+func (a *Service) Update(ID string, data *Lease) (*Lease, error) {
+	for i, principalID := range data.SharedWith {
+		data.SharedWith[i] = a.principalIDFormat.Normalize(principalID)
+	}
+	err := validation.ValidateStruct(data,
+		validation.Field(&data.ID, validation.NilOrNotEmpty, validation.In(ID)),
+		validation.Field(&data.AccountID, validation.By(isNil)),
+		validation.Field(&data.PrincipalID, validation.By(isNil)),
+		validation.Field(&data.Status, validation.By(isNil)),
+		validation.Field(&data.StatusReason, validation.By(isNil)),
+		validation.Field(&data.CreatedOn, validation.By(isNil)),
+		validation.Field(&data.LastModifiedOn, validation.By(isNil)),
+		validation.Field(&data.Version, validation.By(isNil)),
+		validation.Field(&data.BudgetAmount, validation.By(isNil)),
+		validation.Field(&data.BudgetCurrency, validation.By(isNil)),
+		validation.Field(&data.BudgetNotificationEmails, validation.By(isNil)),
+		validation.Field(&data.ExpiresOn, validation.By(isNil)),
+		validation.Field(&data.PolicyCustomization, validation.By(isNil)),
+		validation.Field(&data.BudgetThresholdsSent, validation.By(isNil)),
+		validation.Field(&data.BudgetHistory, validation.By(isNil)),
+		validation.Field(&data.LastCheckedOn, validation.By(isNil)),
+		validation.Field(&data.AlertSuppression, validation.By(isNil)),
+		validation.Field(&data.Notes, validateNotes...),
+		validation.Field(&data.SharedWith, append(validateSharedWith, validation.By(a.isSharedWithPrincipalIDsValid))...),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("lease", err)
+	}
+
+	old, err := a.dataSvc.Get(ID)
 	if err != nil {
 		return nil, err
 	}
+	updated := *old
 
-	return newLeaseRecord, nil
+	if data.SharedWith != nil {
+		err = validation.ValidateStruct(old,
+			validation.Field(&old.Status, validation.NotNil, validation.By(isLeaseActive)),
+		)
+		if err != nil {
+			return nil, errors.NewConflict("lease", ID, err)
+		}
+		updated.SharedWith = data.SharedWith
+		if updated.IsSharedWith(aws.StringValue(old.PrincipalID)) {
+			return nil, errors.NewValidation("lease", fmt.Errorf("sharedWith: must not include the lease's principal"))
+		}
+		if len(data.SharedWith) == 0 {
+			updated.SharedWith = nil
+		}
+	}
+
+	if data.Notes != nil {
+		updated.Notes = data.Notes
+		if *data.Notes == "" {
+			updated.Notes = nil
+		}
+	}
+	if data.Metadata != nil {
+		updated.Metadata = map[string]interface{}{}
+		for key, value := range old.Metadata {
+			updated.Metadata[key] = value
+		}
+		for key, value := range data.Metadata {
+			if value == nil {
+				delete(updated.Metadata, key)
+				continue
+			}
+			updated.Metadata[key] = value
+		}
+	}
+
+	// Notes and metadata don't change the lease status, so only the last
+	// modified date is updated
+	lastModifiedOn := old.LastModifiedOn
+	now := time.Now().Unix()
+	updated.LastModifiedOn = &now
+
+	err = a.write(&updated, lastModifiedOn, outbox.TypeLeaseUpdate, old)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.publish(outbox.TypeLeaseUpdate, old, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// UpdateBudget changes the budget of an Active lease, without the limits on
+// new leases.  Budget notifications which were sent are kept only when the
+// lease's spend is still over their threshold of the new budget, so they're
+// sent again once it passes them.  The change is added to the lease's budget
+// history.
+func (a *Service) UpdateBudget(ID string, change *BudgetChange, spend float64) (*Lease, error) {
+	err := validation.ValidateStruct(change,
+		validation.Field(&change.Amount, validation.Required, validation.Min(0.0).Exclusive()),
+		validation.Field(&change.Reason, validation.RuneLength(0, maxNotesLength)),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("budget", err)
+	}
+
+	old, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+	err = validation.ValidateStruct(old,
+		validation.Field(&old.Status, validation.NotNil, validation.By(isLeaseActive)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("lease", ID, err)
+	}
+	updated := *old
+
+	now := time.Now().Unix()
+	updated.BudgetAmount = &change.Amount
+	updated.BudgetThresholdsSent = nil
+	for _, threshold := range old.BudgetThresholdsSent {
+		if spend >= change.Amount*threshold/100 {
+			updated.BudgetThresholdsSent = append(updated.BudgetThresholdsSent, threshold)
+		}
+	}
+	updated.BudgetHistory = append(append([]BudgetChange{}, old.BudgetHistory...), BudgetChange{
+		PreviousAmount: aws.Float64Value(old.BudgetAmount),
+		Amount:         change.Amount,
+		Reason:         change.Reason,
+		ChangedBy:      change.ChangedBy,
+		ChangedOn:      now,
+	})
+
+	// The budget doesn't change the lease status, so only the last modified
+	// date is updated
+	lastModifiedOn := old.LastModifiedOn
+	updated.LastModifiedOn = &now
+
+	err = a.write(&updated, lastModifiedOn, outbox.TypeLeaseUpdate, old)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.publish(outbox.TypeLeaseUpdate, old, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Extend changes when an Active lease expires.  It may last up to the maximum
+// lease duration from when it was created.  Expiry warnings are sent again
+// before the new expiry.
+func (a *Service) Extend(ID string, expiresOn int64) (*Lease, error) {
+	old, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+	err = validation.ValidateStruct(old,
+		validation.Field(&old.Status, validation.NotNil, validation.By(isLeaseActive)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("lease", ID, err)
+	}
+
+	durations, err := a.GetDurations()
+	if err != nil {
+		return nil, err
+	}
+	createdOn := aws.Int64Value(old.CreatedOn)
+	data := &Lease{ExpiresOn: &expiresOn}
+	err = validation.ValidateStruct(data,
+		validation.Field(&data.ExpiresOn,
+			validation.Min(aws.Int64Value(old.ExpiresOn)+1).Error("must be after the lease's current expiresOn"),
+			validation.By(isExpiresOnValid(createdOn, durations.MaxLeaseDuration, "after the lease was created")),
+		),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("lease", err)
+	}
+
+	updated := *old
+	updated.ExpiresOn = &expiresOn
+	updated.ExpiryWarningsSent = nil
+
+	// The expiry doesn't change the lease status, so only the last modified
+	// date is updated
+	lastModifiedOn := old.LastModifiedOn
+	now := time.Now().Unix()
+	updated.LastModifiedOn = &now
+
+	err = a.write(&updated, lastModifiedOn, outbox.TypeLeaseUpdate, old)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.publish(outbox.TypeLeaseUpdate, old, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SuppressAlerts acknowledges the budget alerts of an Active lease until the
+// suppression ends, recording why.  Budget notifications aren't sent while
+// it's active.  A nil suppression lifts the lease's suppression early.
+func (a *Service) SuppressAlerts(ID string, suppression *AlertSuppression) (*Lease, error) {
+	now := time.Now().Unix()
+	if suppression != nil {
+		err := validation.ValidateStruct(suppression,
+			validation.Field(&suppression.Until,
+				validation.Required,
+				validation.Min(now+1).Error("must be in the future"),
+				validation.Max(now+maxAlertSuppression).Error("must be within 30 days"),
+			),
+			validation.Field(&suppression.Reason, validation.Required, validation.RuneLength(0, maxNotesLength)),
+		)
+		if err != nil {
+			return nil, errors.NewValidation("alertSuppression", err)
+		}
+	}
+
+	old, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+	err = validation.ValidateStruct(old,
+		validation.Field(&old.Status, validation.NotNil, validation.By(isLeaseActive)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("lease", ID, err)
+	}
+
+	updated := *old
+	updated.AlertSuppression = nil
+	if suppression != nil {
+		updated.AlertSuppression = &AlertSuppression{
+			Until:        suppression.Until,
+			Reason:       suppression.Reason,
+			SuppressedBy: suppression.SuppressedBy,
+			SuppressedOn: now,
+		}
+	}
+
+	// Suppressing alerts doesn't change the lease status, so only the last
+	// modified date is updated
+	lastModifiedOn := old.LastModifiedOn
+	updated.LastModifiedOn = &now
+
+	err = a.write(&updated, lastModifiedOn, outbox.TypeLeaseUpdate, old)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.publish(outbox.TypeLeaseUpdate, old, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RecordExecution stores the ARN of a Step Functions execution running the
+// given workflow (eg. "provision") for the lease
+func (a *Service) RecordExecution(ID string, workflow string, executionArn string) (*Lease, error) {
+	data, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ExecutionArns == nil {
+		data.ExecutionArns = map[string]string{}
+	}
+	data.ExecutionArns[workflow] = executionArn
+
+	// Recording an execution doesn't change the lease status, so
+	// only the last modified date is updated
+	lastModifiedOn := data.LastModifiedOn
+	now := time.Now().Unix()
+	data.LastModifiedOn = &now
+
+	err = a.dataSvc.Write(data, lastModifiedOn)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RecordExpiryWarning stores that the warning sent the given number of hours
+// before the lease expires was sent, so it isn't sent again
+func (a *Service) RecordExpiryWarning(ID string, hoursBefore int64) (*Lease, error) {
+	data, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sent := range data.ExpiryWarningsSent {
+		if sent == hoursBefore {
+			return data, nil
+		}
+	}
+	data.ExpiryWarningsSent = append(data.ExpiryWarningsSent, hoursBefore)
+
+	// Warnings don't change the lease status, so only the last
+	// modified date is updated
+	lastModifiedOn := data.LastModifiedOn
+	now := time.Now().Unix()
+	data.LastModifiedOn = &now
+
+	err = a.dataSvc.Write(data, lastModifiedOn)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RecordStale flags a lease as stale, recording when it was first found
+// unused, or clears the flag once the lease is used again
+func (a *Service) RecordStale(ID string, stale bool) (*Lease, error) {
+	data, err := a.dataSvc.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if stale == (data.StaleSince != nil) {
+		return data, nil
+	}
+	if stale {
+		data.StaleSince = &now
+	} else {
+		data.StaleSince = nil
+	}
+
+	// Flagging a lease doesn't change its status, so only the last
+	// modified date is updated
+	lastModifiedOn := data.LastModifiedOn
+	data.LastModifiedOn = &now
+
+	err = a.dataSvc.Write(data, lastModifiedOn)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Archive replaces an Inactive lease with its index record, once the full
+// lease has been written to the archive under archiveKey.  The lease is only
+// replaced if it hasn't changed since it was archived.
+func (a *Service) Archive(data *Lease, archiveKey string) (*Lease, error) {
+	err := validation.ValidateStruct(data,
+		validation.Field(&data.Status, validation.NotNil, validation.By(isLeaseInactive)),
+		validation.Field(&data.ArchivedOn, validation.By(isNil)),
+	)
+	if err != nil {
+		return nil, errors.NewConflict("lease", aws.StringValue(data.ID), err)
+	}
+	if archiveKey == "" {
+		return nil, errors.NewValidation("lease", fmt.Errorf("archiveKey is required"))
+	}
+
+	index := data.ArchiveIndex(archiveKey, time.Now().Unix())
+	err = a.dataSvc.Write(index, data.LastModifiedOn)
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
 }
 
 // ListPages runs a function on each page in a list
 func (a *Service) ListPages(query *Lease, fn func(*Leases) bool) error {
+	a.normalizeQuery(query)
 
 	for {
 		records, err := a.dataSvc.List(query)
@@ -278,7 +1011,7 @@ func (a *Service) ListPages(query *Lease, fn func(*Leases) bool) error {
 		if !fn(records) {
 			break
 		}
-		if query.PrincipalID == nil {
+		if query.NextAccountID == nil {
 			break
 		}
 	}
@@ -288,26 +1021,110 @@ func (a *Service) ListPages(query *Lease, fn func(*Leases) bool) error {
 
 // NewServiceInput Input for creating a new Service
 type NewServiceInput struct {
-	DataSvc                  ReaderWriter
-	EventSvc                 Eventer
+	DataSvc  ReaderWriter
+	BatchSvc BatchEnder
+	EventSvc Eventer
+	// OutboxSvc is optional.  When it's set, events are written to the
+	// outbox with each change, instead of being published by EventSvc.
+	OutboxSvc                OutboxWriter
 	AccountSvc               AccountServicer
 	DefaultLeaseLengthInDays int     `env:"DEFAULT_LEASE_LENGTH_IN_DAYS" envDefault:"7"`
 	PrincipalBudgetAmount    float64 `env:"PRINCIPAL_BUDGET_AMOUNT" envDefault:"1000.00"`
 	PrincipalBudgetPeriod    string  `env:"PRINCIPAL_BUDGET_PERIOD" envDefault:"Weekly"`
 	MaxLeaseBudgetAmount     float64 `env:"MAX_LEASE_BUDGET_AMOUNT" envDefault:"1000.00"`
 	MaxLeasePeriod           int64   `env:"MAX_LEASE_PERIOD" envDefault:"704800"`
+	BudgetCurrency           string  `env:"BUDGET_CURRENCY" envDefault:"USD"`
+	SSM                      ssmiface.SSMAPI
+	// PoolDurationsParameter is the SSM parameter the account pool's lease
+	// durations are stored in.  Only the system's are used when it's empty.
+	PoolDurationsParameter string `env:"POOL_LEASE_DURATIONS_PARAMETER" envDefault:""`
+	// TermsParameter is the SSM parameter the terms of use leases must
+	// accept are stored in.  No terms are required when it's empty.
+	TermsParameter string `env:"LEASE_TERMS_PARAMETER" envDefault:""`
+	// BudgetCategories is the JSON object of the services in each category
+	// leases can have a budget for.  The default categories are used when
+	// it's empty.
+	BudgetCategories string `env:"BUDGET_CATEGORIES" envDefault:""`
+	// PolicySvc is optional, and evaluates the lease approval policy
+	PolicySvc PolicyEvaluator
+	// PrincipalIDFormat is optional, and normalizes and validates the
+	// principal IDs of leases
+	PrincipalIDFormat *principal.IDFormat
+	// CreatorSvc is optional.  When it's set, new leases are written with it,
+	// which sets their accounts Leased in the same transaction, so their
+	// principals can't exceed MaxLeasesPerPrincipal.  Otherwise the caller
+	// sets the account Leased.
+	CreatorSvc Creator
+	// MaxLeasesPerPrincipal is how many Active leases a principal may have
+	// at once
+	MaxLeasesPerPrincipal int `env:"MAX_LEASES_PER_PRINCIPAL" envDefault:"1"`
+	// SettingsSvc is optional, and overrides the default budgets and budget
+	// notification emails
+	SettingsSvc SettingsReader
+}
+
+// Validate the configuration of the lease service
+func (i *NewServiceInput) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.DefaultLeaseLengthInDays, validation.Required.Error("must be no less than 1"), validation.Min(1)),
+		validation.Field(&i.PrincipalBudgetAmount, validation.Min(0.0)),
+		validation.Field(&i.MaxLeaseBudgetAmount, validation.Min(0.0)),
+		validation.Field(&i.MaxLeasePeriod, validation.Required.Error("must be no less than 1"), validation.Min(int64(1))),
+	)
 }
 
 // NewService creates a new instance of the Service
 func NewService(input NewServiceInput) *Service {
 	return &Service{
 		dataSvc:                  input.DataSvc,
+		batchSvc:                 input.BatchSvc,
 		eventSvc:                 input.EventSvc,
+		outboxSvc:                input.OutboxSvc,
 		accountSvc:               input.AccountSvc,
+		policySvc:                input.PolicySvc,
 		defaultLeaseLengthInDays: input.DefaultLeaseLengthInDays,
 		principalBudgetAmount:    input.PrincipalBudgetAmount,
 		principalBudgetPeriod:    input.PrincipalBudgetPeriod,
 		maxLeaseBudgetAmount:     input.MaxLeaseBudgetAmount,
 		maxLeasePeriod:           input.MaxLeasePeriod,
+		budgetCurrency:           input.BudgetCurrency,
+		budgetCategories:         input.BudgetCategories,
+		ssm:                      input.SSM,
+		poolDurationsParameter:   input.PoolDurationsParameter,
+		termsParameter:           input.TermsParameter,
+		principalIDFormat:        input.PrincipalIDFormat,
+		creatorSvc:               input.CreatorSvc,
+		maxLeasesPerPrincipal:    input.MaxLeasesPerPrincipal,
+		settingsSvc:              input.SettingsSvc,
+	}
+}
+
+// normalizeQuery normalizes the principal ID leases are listed by, so they're
+// found however the principal is spelled
+func (a *Service) normalizeQuery(query *Lease) {
+	if query.PrincipalID != nil {
+		principalID := a.principalIDFormat.Normalize(*query.PrincipalID)
+		query.PrincipalID = &principalID
+	}
+}
+
+// isPrincipalIDValid checks a principal ID matches the configured format
+func (a *Service) isPrincipalIDValid(value interface{}) error {
+	principalID, _ := value.(*string)
+	if principalID == nil {
+		return nil
+	}
+	return a.principalIDFormat.Validate(*principalID)
+}
+
+// isSharedWithPrincipalIDsValid checks the principals a lease is shared with
+// match the configured format
+func (a *Service) isSharedWithPrincipalIDsValid(value interface{}) error {
+	sharedWith, _ := value.([]string)
+	for _, principalID := range sharedWith {
+		if err := a.principalIDFormat.Validate(principalID); err != nil {
+			return err
+		}
 	}
+	return nil
 }
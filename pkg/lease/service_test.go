@@ -2,15 +2,23 @@ package lease_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Optum/dce/pkg/account"
 	"github.com/Optum/dce/pkg/errors"
 	"github.com/Optum/dce/pkg/lease"
 	"github.com/Optum/dce/pkg/lease/mocks"
+	"github.com/Optum/dce/pkg/outbox"
+	"github.com/Optum/dce/pkg/policy"
+	"github.com/Optum/dce/pkg/principal"
+	"github.com/Optum/dce/pkg/settings"
+	settingsmocks "github.com/Optum/dce/pkg/settings/settingsiface/mocks"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func ptrString(s string) *string {
@@ -192,7 +200,7 @@ func TestDelete(t *testing.T) {
 			mocksRwd.On("Write", mock.Anything, mock.Anything).Return(tt.returnErr)
 
 			mocksAccountSvc := &mocks.AccountServicer{}
-			mocksAccountSvc.On("Reset", mock.AnythingOfType("string")).Return(nil, nil)
+			mocksAccountSvc.On("ResetWithProfile", mock.AnythingOfType("string"), "").Return(nil, nil)
 
 			mocksEvents := &mocks.Eventer{}
 			mocksEvents.On("LeaseEnd", mock.AnythingOfType("*lease.Lease")).Return(nil)
@@ -411,6 +419,42 @@ func TestGetLeases(t *testing.T) {
 
 }
 
+func TestListPages(t *testing.T) {
+	mocksRWD := &mocks.ReaderWriter{}
+	// The first page sets the key of the next page, and the second page clears it
+	mocksRWD.On("List", mock.AnythingOfType("*lease.Lease")).
+		Run(func(args mock.Arguments) {
+			query := args.Get(0).(*lease.Lease)
+			query.NextAccountID = aws.String("123456789012")
+			query.NextPrincipalID = aws.String("jdoe")
+		}).
+		Return(&lease.Leases{lease.Lease{ID: aws.String("1")}}, nil).Once()
+	mocksRWD.On("List", mock.AnythingOfType("*lease.Lease")).
+		Run(func(args mock.Arguments) {
+			query := args.Get(0).(*lease.Lease)
+			query.NextAccountID = nil
+			query.NextPrincipalID = nil
+		}).
+		Return(&lease.Leases{lease.Lease{ID: aws.String("2")}}, nil).Once()
+
+	leasesSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc: mocksRWD,
+		},
+	)
+
+	ids := []string{}
+	err := leasesSvc.ListPages(&lease.Lease{}, func(leases *lease.Leases) bool {
+		for _, l := range *leases {
+			ids = append(ids, *l.ID)
+		}
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+	mocksRWD.AssertExpectations(t)
+}
+
 func TestCreate(t *testing.T) {
 
 	type response struct {
@@ -456,6 +500,7 @@ func TestCreate(t *testing.T) {
 					LastModifiedOn:           &timeNow,
 					StatusModifiedOn:         &timeNow,
 					ExpiresOn:                &leaseExpiresAfterAWeek,
+					Metadata:                 map[string]interface{}{},
 				},
 				err: nil,
 			},
@@ -490,6 +535,22 @@ func TestCreate(t *testing.T) {
 			},
 			principalSpentAmount: 0.0,
 		},
+		{
+			name: "should fail on a budget in another currency",
+			req: &lease.Lease{
+				PrincipalID:              ptrString("User1"),
+				AccountID:                ptrString("123456789012"),
+				BudgetAmount:             ptrFloat(200.00),
+				BudgetCurrency:           ptrString("EUR"),
+				BudgetNotificationEmails: ptrArrayString([]string{"test1@test.com", "test2@test.com"}),
+				Metadata:                 map[string]interface{}{},
+			},
+			exp: response{
+				data: nil,
+				err:  errors.NewValidation("lease", fmt.Errorf("budgetCurrency: must be USD, the currency budgets are enforced in.")),
+			},
+			principalSpentAmount: 0.0,
+		},
 		{
 			name: "should fail on lease validation error caused by user already over principal budget amount",
 			req: &lease.Lease{
@@ -529,7 +590,7 @@ func TestCreate(t *testing.T) {
 			},
 			exp: response{
 				data: nil,
-				err:  errors.NewValidation("lease", fmt.Errorf("expiresOn: Requested lease has a desired expiry date less than today: %d.", leaseExpiresYesterday)),
+				err:  errors.NewValidation("lease", fmt.Errorf("expiresOn: must be in the future.")),
 			},
 			getResponse: &lease.Leases{
 				lease.Lease{
@@ -556,7 +617,7 @@ func TestCreate(t *testing.T) {
 			},
 			exp: response{
 				data: nil,
-				err:  errors.NewValidation("lease", fmt.Errorf("expiresOn: Requested lease has a budget expires on of %d, which is greater than max lease period of 704800.", leaseExpiresAfterAYear)),
+				err:  errors.NewValidation("lease", fmt.Errorf("expiresOn: must be at most 704800 seconds from now, the maximum lease duration.")),
 			},
 			getResponse: &lease.Leases{
 				lease.Lease{
@@ -694,6 +755,7 @@ func TestCreate(t *testing.T) {
 					PrincipalBudgetPeriod:    "Weekly",
 					MaxLeaseBudgetAmount:     1000.00,
 					MaxLeasePeriod:           704800,
+					BudgetCurrency:           "USD",
 				},
 			)
 
@@ -702,8 +764,1453 @@ func TestCreate(t *testing.T) {
 			assert.Truef(t, errors.Is(err, tt.exp.err), "actual error %q doesn't match expected error %q", err, tt.exp.err)
 			if result != nil {
 				result.ID = tt.exp.data.ID
+				// Leases which didn't wait are provisioned when they're requested
+				assert.NotNil(t, result.ProvisionedOn)
+				assert.Equal(t, result.ProvisionedOn, result.RequestedOn)
+				tt.exp.data.RequestedOn = result.RequestedOn
+				tt.exp.data.ProvisionedOn = result.ProvisionedOn
 			}
 			assert.Equal(t, tt.exp.data, result)
 		})
 	}
 }
+
+func TestCreateWithQuota(t *testing.T) {
+	tests := []struct {
+		name                 string
+		budgetAmount         *float64
+		quota                *lease.Quota
+		principalSpentAmount float64
+		expBudgetAmount      float64
+		expErr               error
+	}{
+		{
+			name:            "should allow budgets up to the quota",
+			budgetAmount:    ptrFloat(2000.00),
+			quota:           &lease.Quota{MaxLeaseBudgetAmount: 5000.00},
+			expBudgetAmount: 2000.00,
+		},
+		{
+			name:            "should default the budget to the quota",
+			quota:           &lease.Quota{MaxLeaseBudgetAmount: 5000.00},
+			expBudgetAmount: 5000.00,
+		},
+		{
+			name:                 "should use the principal budget of the quota",
+			budgetAmount:         ptrFloat(200.00),
+			quota:                &lease.Quota{PrincipalBudgetAmount: 100.00},
+			principalSpentAmount: 150.00,
+			expErr:               errors.NewValidation("lease", fmt.Errorf("budgetAmount: Unable to create lease: User principal User1 has already spent 150.00 of their 100.00 principal budget.")),
+		},
+		{
+			name:         "should use the limits of the service for unset quotas",
+			budgetAmount: ptrFloat(2000.00),
+			quota:        &lease.Quota{PrincipalBudgetAmount: 5000.00},
+			expErr:       errors.NewValidation("lease", fmt.Errorf("budgetAmount: Requested lease has a budget amount of 2000.000000, which is greater than max lease budget amount of 1000.000000.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.CreateWithQuota(&lease.Lease{
+				PrincipalID:  ptrString("User1"),
+				AccountID:    ptrString("123456789012"),
+				BudgetAmount: tt.budgetAmount,
+			}, tt.principalSpentAmount, tt.quota)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.expBudgetAmount, *result.BudgetAmount)
+			}
+		})
+	}
+}
+
+func TestCreateWithSettings(t *testing.T) {
+	tests := []struct {
+		name                 string
+		budgetAmount         *float64
+		emails               *[]string
+		quota                *lease.Quota
+		principalSpentAmount float64
+		expBudgetAmount      float64
+		expEmails            []string
+		expErr               error
+	}{
+		{
+			name:            "should default the budget and emails to the settings",
+			expBudgetAmount: 250.00,
+			expEmails:       []string{"finops@example.com"},
+		},
+		{
+			name:         "should use the max lease budget of the settings",
+			budgetAmount: ptrFloat(500.00),
+			expErr:       errors.NewValidation("lease", fmt.Errorf("budgetAmount: Requested lease has a budget amount of 500.000000, which is greater than max lease budget amount of 250.000000.")),
+		},
+		{
+			name:                 "should use the principal budget of the settings",
+			budgetAmount:         ptrFloat(200.00),
+			principalSpentAmount: 2500.00,
+			expErr:               errors.NewValidation("lease", fmt.Errorf("budgetAmount: Unable to create lease: User principal User1 has already spent 2500.00 of their 2000.00 principal budget.")),
+		},
+		{
+			name:            "should prefer the quota to the settings",
+			quota:           &lease.Quota{MaxLeaseBudgetAmount: 5000.00},
+			emails:          &[]string{"jdoe@example.com"},
+			expBudgetAmount: 5000.00,
+			expEmails:       []string{"jdoe@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+			mocksSettings := &settingsmocks.Servicer{}
+			mocksSettings.On("Current").Return(&settings.Settings{
+				PrincipalBudgetAmount:    2000.00,
+				MaxLeaseBudgetAmount:     250.00,
+				BudgetNotificationEmails: []string{"finops@example.com"},
+			}, nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					SettingsSvc:              mocksSettings,
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.CreateWithQuota(&lease.Lease{
+				PrincipalID:              ptrString("User1"),
+				AccountID:                ptrString("123456789012"),
+				BudgetAmount:             tt.budgetAmount,
+				BudgetNotificationEmails: tt.emails,
+			}, tt.principalSpentAmount, tt.quota)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.expBudgetAmount, *result.BudgetAmount)
+				assert.Equal(t, tt.expEmails, *result.BudgetNotificationEmails)
+			}
+		})
+	}
+}
+
+func TestCreateFromWaitlist(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriter{}
+	mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+	mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+	mocksEventer := &mocks.Eventer{}
+	mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+	leaseSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc:                  mocksRwd,
+			EventSvc:                 mocksEventer,
+			AccountSvc:               &mocks.AccountServicer{},
+			DefaultLeaseLengthInDays: 7,
+			PrincipalBudgetAmount:    1000.00,
+			PrincipalBudgetPeriod:    "Weekly",
+			MaxLeaseBudgetAmount:     1000.00,
+			MaxLeasePeriod:           704800,
+		},
+	)
+
+	requestedOn := time.Now().Unix() - 3600
+	result, err := leaseSvc.Create(&lease.Lease{
+		PrincipalID:  aws.String("User1"),
+		AccountID:    aws.String("123456789012"),
+		BudgetAmount: aws.Float64(200.00),
+		RequestedOn:  aws.Int64(requestedOn),
+	}, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, requestedOn, *result.RequestedOn)
+	assert.True(t, *result.ProvisionedOn > requestedOn)
+}
+
+func TestCreateWithPrincipalIDFormat(t *testing.T) {
+	format, err := principal.NewIDFormat(principal.NewIDFormatInput{
+		Patterns:      `["email"]`,
+		Normalization: []string{principal.NormalizeTrim, principal.NormalizeLowercaseEmails},
+	})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name           string
+		principalID    string
+		expPrincipalID string
+		expErr         error
+	}{
+		{
+			name:           "should normalize the principal ID",
+			principalID:    " Jane.Doe@Example.com",
+			expPrincipalID: "jane.doe@example.com",
+		},
+		{
+			name:        "should fail on principal IDs of other formats",
+			principalID: "jdoe",
+			expErr:      errors.NewValidation("lease", fmt.Errorf("principalId: \"jdoe\" is not a valid principal ID.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+					PrincipalIDFormat:        format,
+				},
+			)
+
+			result, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:  aws.String(tt.principalID),
+				AccountID:    aws.String("123456789012"),
+				BudgetAmount: aws.Float64(200.00),
+			}, 0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.expPrincipalID, *result.PrincipalID)
+			}
+		})
+	}
+}
+
+func TestListWithPrincipalIDFormat(t *testing.T) {
+	format, err := principal.NewIDFormat(principal.NewIDFormatInput{
+		Normalization: []string{principal.NormalizeLowercase},
+	})
+	require.Nil(t, err)
+
+	mocksRWD := &mocks.ReaderWriter{}
+	mocksRWD.On("List", &lease.Lease{PrincipalID: aws.String("jdoe")}).Return(&lease.Leases{}, nil)
+
+	leasesSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc:           mocksRWD,
+			PrincipalIDFormat: format,
+		},
+	)
+
+	_, err = leasesSvc.List(&lease.Lease{PrincipalID: aws.String("JDoe")})
+	assert.Nil(t, err)
+	mocksRWD.AssertExpectations(t)
+}
+
+func TestCreateWithMaxLeasesPerPrincipal(t *testing.T) {
+	activeLeases := func(n int) *lease.Leases {
+		leases := lease.Leases{}
+		for i := 0; i < n; i++ {
+			leases = append(leases, lease.Lease{ID: aws.String(fmt.Sprintf("lease-%d", i))})
+		}
+		return &leases
+	}
+
+	tests := []struct {
+		name      string
+		existing  *lease.Leases
+		maxLeases int
+		quotaErr  error
+		expErr    error
+	}{
+		{
+			name:      "should write the lease within the quota",
+			existing:  activeLeases(1),
+			maxLeases: 2,
+		},
+		{
+			name:      "should fail when the principal has as many Active leases as they may",
+			existing:  activeLeases(2),
+			maxLeases: 2,
+			expErr:    lease.ActiveLeasesExceeded("User1", 2),
+		},
+		{
+			name:      "should fail when the quota writer finds too many Active leases",
+			existing:  activeLeases(1),
+			maxLeases: 2,
+			quotaErr:  lease.ActiveLeasesExceeded("User1", 2),
+			expErr:    lease.ActiveLeasesExceeded("User1", 2),
+		},
+		{
+			name:      "should keep the error of a principal's second lease by default",
+			existing:  activeLeases(1),
+			maxLeases: 0,
+			expErr:    errors.NewAlreadyExists("lease", "with principal User1 and account 123456789012"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(tt.existing, nil)
+			mocksCreator := &mocks.Creator{}
+			mocksCreator.On("Create", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64"), tt.maxLeases, mock.Anything).Return(tt.quotaErr)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					CreatorSvc:               mocksCreator,
+					MaxLeasesPerPrincipal:    tt.maxLeases,
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			_, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:  aws.String("User1"),
+				AccountID:    aws.String("123456789012"),
+				BudgetAmount: aws.Float64(200.00),
+			}, 0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+			if len(*tt.existing) < tt.maxLeases {
+				mocksCreator.AssertExpectations(t)
+			} else {
+				mocksCreator.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateWithCategoryBudgets(t *testing.T) {
+	tests := []struct {
+		name            string
+		categoryBudgets map[string]lease.CategoryBudget
+		expErr          error
+	}{
+		{
+			name: "should create with category budgets",
+			categoryBudgets: map[string]lease.CategoryBudget{
+				"ai":      {Amount: 50, NotificationThresholds: []float64{50, 90}, Enforce: true, ThresholdsSent: []float64{50}},
+				"storage": {Amount: 20},
+			},
+		},
+		{
+			name: "should fail on a category which isn't configured",
+			categoryBudgets: map[string]lease.CategoryBudget{
+				"gpu": {Amount: 50},
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("categoryBudgets: gpu is not a budget category, must be one of ai, compute, storage.")),
+		},
+		{
+			name: "should fail on an invalid threshold",
+			categoryBudgets: map[string]lease.CategoryBudget{
+				"ai": {Amount: 50, NotificationThresholds: []float64{150}},
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("categoryBudgets: ai must have notification thresholds between 0 and 100.")),
+		},
+		{
+			name: "should fail on category budgets over the lease budget",
+			categoryBudgets: map[string]lease.CategoryBudget{
+				"ai":      {Amount: 150},
+				"compute": {Amount: 100},
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("categoryBudgets: must add up to at most the budget amount of 200.00.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:     ptrString("User1"),
+				AccountID:       ptrString("123456789012"),
+				BudgetAmount:    ptrFloat(200.00),
+				CategoryBudgets: tt.categoryBudgets,
+			}, 0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, map[string]lease.CategoryBudget{
+					"ai":      {Amount: 50, NotificationThresholds: []float64{50, 90}, Enforce: true},
+					"storage": {Amount: 20},
+				}, result.CategoryBudgets)
+			}
+		})
+	}
+}
+
+func TestCreateWithRequiredQuotas(t *testing.T) {
+	tests := []struct {
+		name           string
+		requiredQuotas []account.ServiceQuota
+		expErr         error
+	}{
+		{
+			name: "should create with required quotas",
+			requiredQuotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 32},
+			},
+		},
+		{
+			name: "should fail on an invalid quota",
+			requiredQuotas: []account.ServiceQuota{
+				{ServiceCode: "ec2", QuotaCode: "L-417A185B", Value: 0},
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("requiredQuotas: L-417A185B must have a value greater than 0.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:    ptrString("User1"),
+				AccountID:      ptrString("123456789012"),
+				BudgetAmount:   ptrFloat(200.00),
+				RequiredQuotas: tt.requiredQuotas,
+			}, 0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.requiredQuotas, result.RequiredQuotas)
+			}
+		})
+	}
+}
+
+func TestCreateWithPolicyCustomization(t *testing.T) {
+	tests := []struct {
+		name          string
+		customization *account.PolicyCustomization
+		validateErr   error
+		expErr        error
+	}{
+		{
+			name:          "should create leases with allowed customizations",
+			customization: &account.PolicyCustomization{Add: []string{"Bedrock"}},
+		},
+		{
+			name:          "should fail on customizations which aren't allowed",
+			customization: &account.PolicyCustomization{Add: []string{"Everything"}},
+			validateErr:   errors.NewValidation("policyCustomization", fmt.Errorf("add: statement \"Everything\" is not allowed")),
+			expErr:        errors.NewValidation("policyCustomization", fmt.Errorf("add: statement \"Everything\" is not allowed")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+			mocksAccountSvc := &mocks.AccountServicer{}
+			mocksAccountSvc.On("ValidatePolicyCustomization", tt.customization).Return(tt.validateErr)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               mocksAccountSvc,
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:         ptrString("User1"),
+				AccountID:           ptrString("123456789012"),
+				PolicyCustomization: tt.customization,
+			}, 0.0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.customization, result.PolicyCustomization)
+				mocksEventer.AssertCalled(t, "LeaseCreate", result)
+			} else {
+				mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateWithPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		decision  *policy.Decision
+		policyErr error
+		expErr    error
+	}{
+		{
+			name:     "should create leases the policy allows",
+			decision: &policy.Decision{Allow: true},
+		},
+		{
+			name:     "should fail on leases the policy denies",
+			decision: &policy.Decision{Allow: false, Reasons: []string{"budgets over 500 need approval"}},
+			expErr:   errors.NewValidation("lease", fmt.Errorf("denied by policy: budgets over 500 need approval")),
+		},
+		{
+			name:      "should fail when the policy can't be evaluated",
+			policyErr: errors.NewInternalServer("failure", fmt.Errorf("original error")),
+			expErr:    errors.NewInternalServer("failure", fmt.Errorf("original error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+			mocksPolicy := &mocks.PolicyEvaluator{}
+			mocksPolicy.On("Evaluate", policy.LeaseCreate, mock.AnythingOfType("*lease.CreatePolicyInput")).Return(tt.decision, tt.policyErr)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					PolicySvc:                mocksPolicy,
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			_, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:  ptrString("User1"),
+				AccountID:    ptrString("123456789012"),
+				BudgetAmount: ptrFloat(200.00),
+			}, 50.0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			input := mocksPolicy.Calls[0].Arguments.Get(1).(*lease.CreatePolicyInput)
+			assert.Equal(t, 200.00, *input.Lease.BudgetAmount)
+			assert.Equal(t, 50.0, input.PrincipalSpentAmount)
+			assert.Equal(t, lease.Quota{PrincipalBudgetAmount: 1000.00, MaxLeaseBudgetAmount: 1000.00}, input.Quota)
+			if tt.expErr != nil {
+				mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriter{}
+	mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(&lease.Lease{
+		ID:        ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+		AccountID: ptrString("123456789012"),
+		Status:    lease.StatusActive.StatusPtr(),
+	}, nil)
+	mocksRwd.On("Write", mock.Anything, mock.Anything).Return(nil)
+	mocksAccountSvc := &mocks.AccountServicer{}
+	mocksAccountSvc.On("ResetWithProfile", "123456789012", "").Return(nil, nil)
+	mocksEvents := &mocks.Eventer{}
+	mocksEvents.On("LeaseEnd", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+	leaseSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc:    mocksRwd,
+			EventSvc:   mocksEvents,
+			AccountSvc: mocksAccountSvc,
+		},
+	)
+	ended, err := leaseSvc.End("70c2d96d-7938-4ec9-917d-476f2b09cc04", lease.StatusReasonPrincipalDeactivated)
+
+	assert.Nil(t, err)
+	assert.Equal(t, lease.StatusInactive, *ended.Status)
+	assert.Equal(t, lease.StatusReasonPrincipalDeactivated, *ended.StatusReason)
+	mocksAccountSvc.AssertExpectations(t)
+}
+
+func TestEndWithOutbox(t *testing.T) {
+	mocksRwd := &mocks.ReaderWriter{}
+	mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(&lease.Lease{
+		ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+		AccountID:      ptrString("123456789012"),
+		Status:         lease.StatusActive.StatusPtr(),
+		LastModifiedOn: aws.Int64(1573592058),
+	}, nil)
+	mocksOutbox := &mocks.OutboxWriter{}
+	mocksOutbox.On("WriteWithEvents", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058),
+		mock.MatchedBy(func(entries []*outbox.Entry) bool {
+			return len(entries) == 1 &&
+				entries[0].Type == outbox.TypeLeaseEnd &&
+				entries[0].ResourceID == "70c2d96d-7938-4ec9-917d-476f2b09cc04" &&
+				len(entries[0].Old) == 0
+		})).Return(nil)
+	mocksAccountSvc := &mocks.AccountServicer{}
+	mocksAccountSvc.On("ResetWithProfile", "123456789012", "").Return(nil, nil)
+	mocksEvents := &mocks.Eventer{}
+
+	leaseSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc:    mocksRwd,
+			EventSvc:   mocksEvents,
+			OutboxSvc:  mocksOutbox,
+			AccountSvc: mocksAccountSvc,
+		},
+	)
+	ended, err := leaseSvc.End("70c2d96d-7938-4ec9-917d-476f2b09cc04", lease.StatusReasonExpired)
+
+	assert.Nil(t, err)
+	assert.Equal(t, lease.StatusInactive, *ended.Status)
+	mocksOutbox.AssertExpectations(t)
+	// The event is published from the outbox
+	mocksRwd.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+	mocksEvents.AssertNotCalled(t, "LeaseEnd", mock.Anything)
+}
+
+func TestEndBatch(t *testing.T) {
+	active := func(accountID string) *lease.Lease {
+		return &lease.Lease{
+			ID:             ptrString("lease-" + accountID),
+			AccountID:      ptrString(accountID),
+			PrincipalID:    ptrString("User1"),
+			Status:         lease.StatusActive.StatusPtr(),
+			LastModifiedOn: aws.Int64(1573592058),
+		}
+	}
+	inactive := active("333333333333")
+	inactive.Status = lease.StatusInactive.StatusPtr()
+	leases := []*lease.Lease{active("111111111111"), active("222222222222"), inactive}
+
+	mocksBatch := &mocks.BatchEnder{}
+	mocksBatch.On("EndBatch", mock.Anything, []*int64{aws.Int64(1573592058), aws.Int64(1573592058)}).
+		Return(func(leases []*lease.Lease, prev []*int64) []error {
+			for _, l := range leases {
+				assert.Equal(t, lease.StatusInactive, *l.Status)
+				assert.Equal(t, lease.StatusReasonExpired, *l.StatusReason)
+			}
+			// The second lease was modified since it was read
+			return []error{nil, errors.NewConflict("lease", "222222222222", fmt.Errorf("modified"))}
+		})
+	mocksAccountSvc := &mocks.AccountServicer{}
+	mocksAccountSvc.On("ResetBatch", []string{"111111111111"}).Return(nil, nil)
+	mocksEvents := &mocks.Eventer{}
+	mocksEvents.On("LeaseEnd", mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+	leaseSvc := lease.NewService(
+		lease.NewServiceInput{
+			DataSvc:    &mocks.ReaderWriter{},
+			BatchSvc:   mocksBatch,
+			EventSvc:   mocksEvents,
+			AccountSvc: mocksAccountSvc,
+		},
+	)
+	ended, err := leaseSvc.EndBatch(leases, lease.StatusReasonExpired)
+
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "111111111111", *ended[0].AccountID)
+	assert.Equal(t, lease.StatusInactive, *ended[0].Status)
+	// The inactive lease and the modified lease failed
+	merr, ok := err.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Len(t, merr.Errors, 2)
+	// The leases passed in are unchanged
+	assert.Equal(t, lease.StatusActive, *leases[0].Status)
+	mocksAccountSvc.AssertExpectations(t)
+	mocksEvents.AssertNumberOfCalls(t, "LeaseEnd", 1)
+}
+
+func TestRecordExecution(t *testing.T) {
+	executionArn := "arn:aws:states:us-east-1:123456789012:execution:lease-provision:abc"
+
+	tests := []struct {
+		name     string
+		getLease *lease.Lease
+		getErr   error
+		writeErr error
+		expErr   error
+		expArns  map[string]string
+	}{
+		{
+			name: "should record the execution",
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expArns: map[string]string{
+				"provision": executionArn,
+			},
+		},
+		{
+			name: "should keep executions of other workflows",
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+				ExecutionArns: map[string]string{
+					"teardown": "arn:aws:states:us-east-1:123456789012:execution:lease-teardown:abc",
+				},
+			},
+			expArns: map[string]string{
+				"provision": executionArn,
+				"teardown":  "arn:aws:states:us-east-1:123456789012:execution:lease-teardown:abc",
+			},
+		},
+		{
+			name:   "should error when the lease isn't found",
+			getErr: errors.NewNotFound("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			expErr: errors.NewNotFound("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+		},
+		{
+			name: "should error when the write fails",
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			writeErr: errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expErr:   errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, tt.getErr)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(tt.writeErr)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc: mocksRwd,
+			})
+
+			actualLease, err := leaseSvc.RecordExecution("70c2d96d-7938-4ec9-917d-476f2b09cc04", "provision", executionArn)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expArns, actualLease.ExecutionArns)
+			mocksRwd.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecordExpiryWarning(t *testing.T) {
+	tests := []struct {
+		name      string
+		getLease  *lease.Lease
+		getErr    error
+		writeErr  error
+		expErr    error
+		expWrites int
+		expSent   []int64
+	}{
+		{
+			name: "should record the warning",
+			getLease: &lease.Lease{
+				ID:                 ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn:     aws.Int64(1573592058),
+				ExpiryWarningsSent: []int64{72},
+			},
+			expWrites: 1,
+			expSent:   []int64{72, 24},
+		},
+		{
+			name: "should not record a warning twice",
+			getLease: &lease.Lease{
+				ID:                 ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn:     aws.Int64(1573592058),
+				ExpiryWarningsSent: []int64{72, 24},
+			},
+			expSent: []int64{72, 24},
+		},
+		{
+			name:   "should error when the lease isn't found",
+			getErr: errors.NewNotFound("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			expErr: errors.NewNotFound("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+		},
+		{
+			name: "should error when the write fails",
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			writeErr:  errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expErr:    errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expWrites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, tt.getErr)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(tt.writeErr)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc: mocksRwd,
+			})
+
+			actualLease, err := leaseSvc.RecordExpiryWarning("70c2d96d-7938-4ec9-917d-476f2b09cc04", 24)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expSent, actualLease.ExpiryWarningsSent)
+		})
+	}
+}
+
+func TestRecordStale(t *testing.T) {
+	tests := []struct {
+		name      string
+		stale     bool
+		getLease  *lease.Lease
+		writeErr  error
+		expErr    error
+		expWrites int
+		expStale  bool
+	}{
+		{
+			name:  "should flag the lease",
+			stale: true,
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expWrites: 1,
+			expStale:  true,
+		},
+		{
+			name:  "should not flag a lease twice",
+			stale: true,
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+				StaleSince:     aws.Int64(1573592000),
+			},
+			expStale: true,
+		},
+		{
+			name: "should clear the flag",
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+				StaleSince:     aws.Int64(1573592000),
+			},
+			expWrites: 1,
+		},
+		{
+			name:  "should error when the write fails",
+			stale: true,
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			writeErr:  errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expErr:    errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expWrites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(tt.writeErr)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc: mocksRwd,
+			})
+
+			actualLease, err := leaseSvc.RecordStale("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.stale)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expStale, actualLease.StaleSince != nil)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        *lease.Lease
+		getLease    *lease.Lease
+		writeErr    error
+		expErr      error
+		expWrites   int
+		expNotes    *string
+		expMetadata map[string]interface{}
+		expShared   []string
+	}{
+		{
+			name: "should update notes and merge metadata",
+			data: &lease.Lease{
+				Notes: ptrString("Delete the stack before the lease ends"),
+				Metadata: map[string]interface{}{
+					"purpose": "load testing",
+					"ticket":  nil,
+				},
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+				Metadata: map[string]interface{}{
+					"ticket": "https://tickets.example.com/123",
+					"team":   "platform",
+				},
+			},
+			expWrites: 1,
+			expNotes:  ptrString("Delete the stack before the lease ends"),
+			expMetadata: map[string]interface{}{
+				"purpose": "load testing",
+				"team":    "platform",
+			},
+		},
+		{
+			name: "should remove empty notes",
+			data: &lease.Lease{
+				Notes: ptrString(""),
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+				Notes:          ptrString("old notes"),
+				Metadata:       map[string]interface{}{"team": "platform"},
+			},
+			expWrites:   1,
+			expMetadata: map[string]interface{}{"team": "platform"},
+		},
+		{
+			name: "should not change other fields",
+			data: &lease.Lease{
+				BudgetAmount: aws.Float64(1000),
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("budgetAmount: must be empty.")),
+		},
+		{
+			name: "should not allow long notes",
+			data: &lease.Lease{
+				Notes: ptrString(strings.Repeat("a", 4097)),
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("notes: the length must be no more than 4096.")),
+		},
+		{
+			name: "should share an active lease",
+			data: &lease.Lease{
+				SharedWith: []string{"jdoe", "asmith"},
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				PrincipalID:    ptrString("owner"),
+				Status:         lease.StatusActive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expWrites: 1,
+			expShared: []string{"jdoe", "asmith"},
+		},
+		{
+			name: "should stop sharing with an empty list",
+			data: &lease.Lease{
+				SharedWith: []string{},
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				PrincipalID:    ptrString("owner"),
+				Status:         lease.StatusActive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+				SharedWith:     []string{"jdoe"},
+			},
+			expWrites: 1,
+		},
+		{
+			name: "should not share an inactive lease",
+			data: &lease.Lease{
+				SharedWith: []string{"jdoe"},
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				PrincipalID:    ptrString("owner"),
+				Status:         lease.StatusInactive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expErr: errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be active lease.")),
+		},
+		{
+			name: "should not share with the lease's principal",
+			data: &lease.Lease{
+				SharedWith: []string{"owner"},
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				PrincipalID:    ptrString("owner"),
+				Status:         lease.StatusActive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("sharedWith: must not include the lease's principal")),
+		},
+		{
+			name: "should not share with repeated principals",
+			data: &lease.Lease{
+				SharedWith: []string{"jdoe", "jdoe"},
+			},
+			expErr: errors.NewValidation("lease", fmt.Errorf("sharedWith: must not repeat the principal jdoe.")),
+		},
+		{
+			name: "should error when the write fails",
+			data: &lease.Lease{
+				Notes: ptrString("notes"),
+			},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			writeErr:  errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expErr:    errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("failure")),
+			expWrites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(tt.writeErr)
+			mocksEvent := &mocks.Eventer{}
+			mocksEvent.On("LeaseUpdate", tt.getLease, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc:  mocksRwd,
+				EventSvc: mocksEvent,
+			})
+
+			actualLease, err := leaseSvc.Update("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.data)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				mocksEvent.AssertNotCalled(t, "LeaseUpdate", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, tt.expNotes, actualLease.Notes)
+			assert.Equal(t, tt.expMetadata, actualLease.Metadata)
+			assert.Equal(t, tt.expShared, actualLease.SharedWith)
+			mocksEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateBudget(t *testing.T) {
+	activeLease := func() *lease.Lease {
+		return &lease.Lease{
+			ID:                   ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			Status:               lease.StatusActive.StatusPtr(),
+			BudgetAmount:         aws.Float64(100),
+			BudgetThresholdsSent: []float64{50, 75},
+			LastModifiedOn:       aws.Int64(1573592058),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		change        *lease.BudgetChange
+		spend         float64
+		getLease      *lease.Lease
+		expErr        error
+		expWrites     int
+		expThresholds []float64
+	}{
+		{
+			name:          "should raise the budget and forget thresholds no longer passed",
+			change:        &lease.BudgetChange{Amount: 200, Reason: "load test", ChangedBy: "admin"},
+			spend:         80,
+			getLease:      activeLease(),
+			expWrites:     1,
+			expThresholds: []float64{},
+		},
+		{
+			name:          "should lower the budget and keep thresholds still passed",
+			change:        &lease.BudgetChange{Amount: 120},
+			spend:         80,
+			getLease:      activeLease(),
+			expWrites:     1,
+			expThresholds: []float64{50},
+		},
+		{
+			name:   "should not allow an empty budget",
+			change: &lease.BudgetChange{},
+			expErr: errors.NewValidation("budget", fmt.Errorf("amount: cannot be blank.")),
+		},
+		{
+			name:   "should not change the budget of an inactive lease",
+			change: &lease.BudgetChange{Amount: 200},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				Status:         lease.StatusInactive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expErr: errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be active lease.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(nil)
+			mocksEvent := &mocks.Eventer{}
+			mocksEvent.On("LeaseUpdate", tt.getLease, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc:  mocksRwd,
+				EventSvc: mocksEvent,
+			})
+
+			actualLease, err := leaseSvc.UpdateBudget("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.change, tt.spend)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.change.Amount, *actualLease.BudgetAmount)
+			assert.ElementsMatch(t, tt.expThresholds, actualLease.BudgetThresholdsSent)
+			assert.Equal(t, []float64{50, 75}, tt.getLease.BudgetThresholdsSent)
+			assert.Len(t, actualLease.BudgetHistory, 1)
+			assert.Equal(t, 100.0, actualLease.BudgetHistory[0].PreviousAmount)
+			assert.Equal(t, tt.change.Amount, actualLease.BudgetHistory[0].Amount)
+			assert.Equal(t, tt.change.Reason, actualLease.BudgetHistory[0].Reason)
+			mocksEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExtend(t *testing.T) {
+	createdOn := time.Now().Add(-24 * time.Hour).Unix()
+	expiresOn := time.Now().Add(24 * time.Hour).Unix()
+	activeLease := func() *lease.Lease {
+		return &lease.Lease{
+			ID:                 ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			Status:             lease.StatusActive.StatusPtr(),
+			CreatedOn:          &createdOn,
+			ExpiresOn:          &expiresOn,
+			ExpiryWarningsSent: []int64{72, 24},
+			LastModifiedOn:     aws.Int64(1573592058),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		expiresOn int64
+		getLease  *lease.Lease
+		expErr    error
+		expWrites int
+	}{
+		{
+			name:      "should extend the lease",
+			expiresOn: createdOn + 5*24*60*60,
+			getLease:  activeLease(),
+			expWrites: 1,
+		},
+		{
+			name:      "should not extend the lease past the maximum lease duration",
+			expiresOn: createdOn + 8*24*60*60,
+			getLease:  activeLease(),
+			expErr:    errors.NewValidation("lease", fmt.Errorf("expiresOn: must be at most 7 days after the lease was created, the maximum lease duration.")),
+		},
+		{
+			name:      "should not shorten the lease",
+			expiresOn: expiresOn - 60,
+			getLease:  activeLease(),
+			expErr:    errors.NewValidation("lease", fmt.Errorf("expiresOn: must be after the lease's current expiresOn.")),
+		},
+		{
+			name:      "should not extend an inactive lease",
+			expiresOn: createdOn + 5*24*60*60,
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				Status:         lease.StatusInactive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expErr: errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be active lease.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(nil)
+			mocksEvent := &mocks.Eventer{}
+			mocksEvent.On("LeaseUpdate", tt.getLease, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc:                  mocksRwd,
+				EventSvc:                 mocksEvent,
+				DefaultLeaseLengthInDays: 7,
+				MaxLeasePeriod:           7 * 24 * 60 * 60,
+			})
+
+			actualLease, err := leaseSvc.Extend("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.expiresOn)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expiresOn, *actualLease.ExpiresOn)
+			assert.Nil(t, actualLease.ExpiryWarningsSent)
+			assert.Equal(t, expiresOn, *tt.getLease.ExpiresOn)
+			mocksEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSuppressAlerts(t *testing.T) {
+	now := time.Now().Unix()
+	activeLease := func() *lease.Lease {
+		return &lease.Lease{
+			ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			Status:         lease.StatusActive.StatusPtr(),
+			LastModifiedOn: aws.Int64(1573592058),
+			AlertSuppression: &lease.AlertSuppression{
+				Until:  now + 60,
+				Reason: "earlier load test",
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		suppression    *lease.AlertSuppression
+		getLease       *lease.Lease
+		expErr         error
+		expWrites      int
+		expSuppression bool
+	}{
+		{
+			name:           "should suppress the lease's alerts",
+			suppression:    &lease.AlertSuppression{Until: now + 3600, Reason: "load test", SuppressedBy: "admin1"},
+			getLease:       activeLease(),
+			expWrites:      1,
+			expSuppression: true,
+		},
+		{
+			name:      "should lift the suppression",
+			getLease:  activeLease(),
+			expWrites: 1,
+		},
+		{
+			name:        "should require a reason",
+			suppression: &lease.AlertSuppression{Until: now + 3600},
+			getLease:    activeLease(),
+			expErr:      errors.NewValidation("alertSuppression", fmt.Errorf("reason: cannot be blank.")),
+		},
+		{
+			name:        "should not suppress alerts in the past",
+			suppression: &lease.AlertSuppression{Until: now - 60, Reason: "load test"},
+			getLease:    activeLease(),
+			expErr:      errors.NewValidation("alertSuppression", fmt.Errorf("until: must be in the future.")),
+		},
+		{
+			name:        "should not suppress alerts for longer than 30 days",
+			suppression: &lease.AlertSuppression{Until: now + 31*24*60*60, Reason: "load test"},
+			getLease:    activeLease(),
+			expErr:      errors.NewValidation("alertSuppression", fmt.Errorf("until: must be within 30 days.")),
+		},
+		{
+			name:        "should not suppress the alerts of an inactive lease",
+			suppression: &lease.AlertSuppression{Until: now + 3600, Reason: "load test"},
+			getLease: &lease.Lease{
+				ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				Status:         lease.StatusInactive.StatusPtr(),
+				LastModifiedOn: aws.Int64(1573592058),
+			},
+			expErr: errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be active lease.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Get", "70c2d96d-7938-4ec9-917d-476f2b09cc04").Return(tt.getLease, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(nil)
+			mocksEvent := &mocks.Eventer{}
+			mocksEvent.On("LeaseUpdate", tt.getLease, mock.AnythingOfType("*lease.Lease")).Return(nil)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc:  mocksRwd,
+				EventSvc: mocksEvent,
+			})
+
+			actualLease, err := leaseSvc.SuppressAlerts("70c2d96d-7938-4ec9-917d-476f2b09cc04", tt.suppression)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			if tt.expSuppression {
+				assert.Equal(t, tt.suppression.Until, actualLease.AlertSuppression.Until)
+				assert.Equal(t, "load test", actualLease.AlertSuppression.Reason)
+				assert.Equal(t, "admin1", actualLease.AlertSuppression.SuppressedBy)
+				assert.True(t, actualLease.AlertSuppression.SuppressedOn >= now)
+			} else {
+				assert.Nil(t, actualLease.AlertSuppression)
+			}
+			mocksEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchive(t *testing.T) {
+	inactive := func() *lease.Lease {
+		return &lease.Lease{
+			ID:             ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+			AccountID:      ptrString("123456789012"),
+			PrincipalID:    ptrString("test"),
+			Status:         lease.StatusInactive.StatusPtr(),
+			StatusReason:   lease.StatusReasonExpired.StatusReasonPtr(),
+			LastModifiedOn: aws.Int64(1573592058),
+			Notes:          ptrString("teardown the stack first"),
+			Metadata:       map[string]interface{}{"ticket": "DCE-1"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		lease      *lease.Lease
+		archiveKey string
+		writeErr   error
+		expErr     error
+		expWrites  int
+	}{
+		{
+			name:       "should replace the lease with its index record",
+			lease:      inactive(),
+			archiveKey: "leases/ended=2019-11-12/70c2d96d-7938-4ec9-917d-476f2b09cc04.json",
+			expWrites:  1,
+		},
+		{
+			name: "should not archive an Active lease",
+			lease: &lease.Lease{
+				ID:     ptrString("70c2d96d-7938-4ec9-917d-476f2b09cc04"),
+				Status: lease.StatusActive.StatusPtr(),
+			},
+			archiveKey: "leases/ended=2019-11-12/70c2d96d-7938-4ec9-917d-476f2b09cc04.json",
+			expErr:     errors.NewConflict("lease", "70c2d96d-7938-4ec9-917d-476f2b09cc04", fmt.Errorf("leaseStatus: must be inactive lease.")),
+		},
+		{
+			name:   "should require an archive key",
+			lease:  inactive(),
+			expErr: errors.NewValidation("lease", fmt.Errorf("archiveKey is required")),
+		},
+		{
+			name:       "should error when the lease changed since it was archived",
+			lease:      inactive(),
+			archiveKey: "leases/ended=2019-11-12/70c2d96d-7938-4ec9-917d-476f2b09cc04.json",
+			writeErr:   errors.NewConflict("lease", "123456789012", fmt.Errorf("failure")),
+			expErr:     errors.NewConflict("lease", "123456789012", fmt.Errorf("failure")),
+			expWrites:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), aws.Int64(1573592058)).Return(tt.writeErr)
+
+			leaseSvc := lease.NewService(lease.NewServiceInput{
+				DataSvc: mocksRwd,
+			})
+
+			index, err := leaseSvc.Archive(tt.lease, tt.archiveKey)
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			mocksRwd.AssertNumberOfCalls(t, "Write", tt.expWrites)
+			if tt.expErr != nil {
+				return
+			}
+			assert.True(t, index.IsArchived())
+			assert.Equal(t, tt.archiveKey, *index.ArchiveKey)
+			assert.Equal(t, tt.lease.ID, index.ID)
+			assert.Equal(t, tt.lease.StatusReason, index.StatusReason)
+			assert.Nil(t, index.Notes)
+			assert.Nil(t, index.Metadata)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package lease
+
+import (
+	"time"
+
+	"github.com/Optum/dce/pkg/usage"
+)
+
+// BillingPeriodStart returns the start of the current billing period of
+// principal budgets, which is the last Sunday for Weekly budgets, and the
+// first of the month otherwise
+func BillingPeriodStart(period string) time.Time {
+	currentTime := time.Now()
+	if period == Weekly {
+
+		for currentTime.Weekday() != time.Sunday { // iterate back to Sunday
+			currentTime = currentTime.AddDate(0, 0, -1)
+		}
+
+		return time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(currentTime.Year(), currentTime.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PrincipalSpend gets the spend of a principal since the start of the
+// billing period, from its aggregate when spend is aggregated
+func PrincipalSpend(usageDB usage.DBer, periodStart time.Time, principalID string) (float64, error) {
+	if aggregator, ok := usageDB.(usage.Aggregator); ok && aggregator.AggregatesEnabled() {
+		spent, found, err := aggregator.GetPrincipalSpend(principalID, periodStart)
+		if err != nil || found {
+			return spent, err
+		}
+	}
+
+	usageRecords, err := usageDB.GetUsageByPrincipal(periodStart, principalID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Sum the spend of the principal's leases in the billing period
+	spent := 0.0
+	for _, usageItem := range usageRecords {
+		spent = spent + *usageItem.CostAmount
+	}
+	return spent, nil
+}
@@ -0,0 +1,72 @@
+package lease_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/usage"
+	usageMocks "github.com/Optum/dce/pkg/usage/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// aggregatingUsage is a usage DB which aggregates spend
+type aggregatingUsage struct {
+	*usageMocks.DBer
+	*usageMocks.Aggregator
+}
+
+func TestBillingPeriodStart(t *testing.T) {
+	weekly := lease.BillingPeriodStart(lease.Weekly)
+	assert.Equal(t, time.Sunday, weekly.Weekday())
+	assert.True(t, time.Since(weekly) < 8*24*time.Hour)
+
+	monthly := lease.BillingPeriodStart("MONTHLY")
+	assert.Equal(t, 1, monthly.Day())
+	assert.Equal(t, time.Now().Month(), monthly.Month())
+}
+
+func TestPrincipalSpend(t *testing.T) {
+	periodStart := lease.BillingPeriodStart(lease.Weekly)
+
+	t.Run("should sum the usage of the principal", func(t *testing.T) {
+		usageDB := &usageMocks.DBer{}
+		usageDB.On("GetUsageByPrincipal", periodStart, "User1").Return([]*usage.Usage{
+			{CostAmount: aws.Float64(10)},
+			{CostAmount: aws.Float64(2.5)},
+		}, nil)
+
+		spent, err := lease.PrincipalSpend(usageDB, periodStart, "User1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 12.5, spent)
+	})
+
+	t.Run("should get the aggregated spend of the principal", func(t *testing.T) {
+		usageDB := &aggregatingUsage{&usageMocks.DBer{}, &usageMocks.Aggregator{}}
+		usageDB.Aggregator.On("AggregatesEnabled").Return(true)
+		usageDB.Aggregator.On("GetPrincipalSpend", "User1", periodStart).Return(20.0, true, nil)
+
+		spent, err := lease.PrincipalSpend(usageDB, periodStart, "User1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 20.0, spent)
+		usageDB.DBer.AssertNotCalled(t, "GetUsageByPrincipal", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should sum the usage of a principal without an aggregate", func(t *testing.T) {
+		usageDB := &aggregatingUsage{&usageMocks.DBer{}, &usageMocks.Aggregator{}}
+		usageDB.Aggregator.On("AggregatesEnabled").Return(true)
+		usageDB.Aggregator.On("GetPrincipalSpend", "User1", periodStart).Return(0.0, false, nil)
+		usageDB.DBer.On("GetUsageByPrincipal", periodStart, "User1").Return([]*usage.Usage{
+			{CostAmount: aws.Float64(3)},
+		}, nil)
+
+		spent, err := lease.PrincipalSpend(usageDB, periodStart, "User1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3.0, spent)
+	})
+}
@@ -0,0 +1,151 @@
+package lease
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Terms are the terms of use principals accept to lease an account.  Each
+// change of the text is a new version, so leases record which text was
+// accepted.
+type Terms struct {
+	Version   string `json:"version"`
+	Text      string `json:"text"`
+	UpdatedOn int64  `json:"updatedOn"`
+}
+
+// TermsAcceptance records the version of the terms of use accepted when the
+// lease was requested
+type TermsAcceptance struct {
+	Version    string `json:"version" dynamodbav:"Version"`
+	AcceptedBy string `json:"acceptedBy,omitempty" dynamodbav:"AcceptedBy,omitempty"`
+	AcceptedOn int64  `json:"acceptedOn" dynamodbav:"AcceptedOn"`
+}
+
+// GetTerms returns the terms of use leases must accept, which are nil until
+// an admin sets them
+func (a *Service) GetTerms() (*Terms, error) {
+	if a.termsParameter == "" {
+		return nil, nil
+	}
+
+	res, err := a.ssm.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(a.termsParameter),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get lease terms", err)
+	}
+
+	terms := &Terms{}
+	err = json.Unmarshal([]byte(aws.StringValue(res.Parameter.Value)), terms)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to parse lease terms", err)
+	}
+	return terms, nil
+}
+
+// UpdateTerms changes the terms of use leases must accept.  Changes of the
+// text need a new version, so acceptances of the old text aren't mistaken
+// for the new.
+func (a *Service) UpdateTerms(terms *Terms) (*Terms, error) {
+	if a.termsParameter == "" {
+		return nil, errors.NewServiceUnavailable("lease terms are not configured")
+	}
+
+	err := validation.ValidateStruct(terms,
+		validation.Field(&terms.Version, validation.Required, validation.Length(1, 64)),
+		validation.Field(&terms.Text, validation.Required),
+	)
+	if err != nil {
+		return nil, errors.NewValidation("terms", err)
+	}
+
+	current, err := a.GetTerms()
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Version == terms.Version && current.Text != terms.Text {
+		return nil, errors.NewConflict("terms", terms.Version,
+			fmt.Errorf("version %s of the terms has a different text, change the version to change the text", terms.Version))
+	}
+
+	terms.UpdatedOn = time.Now().Unix()
+	value, err := json.Marshal(terms)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal lease terms", err)
+	}
+	// Terms longer than a standard parameter are stored as advanced
+	_, err = a.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(a.termsParameter),
+		Value:     aws.String(string(value)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Tier:      aws.String(ssm.ParameterTierIntelligentTiering),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to save lease terms", err)
+	}
+	return terms, nil
+}
+
+// DeleteTerms stops requiring leases to accept terms of use
+func (a *Service) DeleteTerms() error {
+	if a.termsParameter == "" {
+		return nil
+	}
+
+	_, err := a.ssm.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(a.termsParameter),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.NewInternalServer("failed to delete lease terms", err)
+	}
+	return nil
+}
+
+// AcceptTerms checks the lease request accepts the current version of the
+// terms of use, and records who accepted them and when.  Any acceptance is
+// removed when no terms are required.
+func (a *Service) AcceptTerms(data *Lease, acceptedBy string) error {
+	terms, err := a.GetTerms()
+	if err != nil {
+		return err
+	}
+	if terms == nil {
+		data.TermsAcceptance = nil
+		return nil
+	}
+
+	if data.TermsAcceptance == nil || data.TermsAcceptance.Version != terms.Version {
+		return errors.NewValidation("lease",
+			fmt.Errorf("termsAcceptance: version %s of the terms of use must be accepted, see GET /leases/terms.", terms.Version))
+	}
+	data.TermsAcceptance.AcceptedBy = acceptedBy
+	data.TermsAcceptance.AcceptedOn = time.Now().Unix()
+	return nil
+}
+
+// isTermsAccepted checks the lease's terms were accepted with AcceptTerms,
+// when terms are required
+func isTermsAccepted(terms *Terms) validation.RuleFunc {
+	return func(value interface{}) error {
+		acceptance, _ := value.(*TermsAcceptance)
+		if terms != nil && (acceptance == nil || acceptance.AcceptedOn == 0) {
+			return fmt.Errorf("version %s of the terms of use must be accepted", terms.Version)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+package lease_test
+
+import (
+	"fmt"
+	"testing"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const currentTerms = `{"version": "2", "text": "Don't mine bitcoin.", "updatedOn": 1580000000}`
+
+func newTermsService(parameter string, getErr error) (*lease.Service, *awsMocks.SSMAPI) {
+	ssmAPI := &awsMocks.SSMAPI{}
+	ssmAPI.On("GetParameter", &ssm.GetParameterInput{
+		Name: aws.String("/dce/lease_terms"),
+	}).Return(&ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{Value: aws.String(parameter)},
+	}, getErr)
+	ssmAPI.On("PutParameter", mock.MatchedBy(func(input *ssm.PutParameterInput) bool {
+		return *input.Name == "/dce/lease_terms" && *input.Overwrite
+	})).Return(&ssm.PutParameterOutput{}, nil)
+
+	return lease.NewService(lease.NewServiceInput{
+		SSM:            ssmAPI,
+		TermsParameter: "/dce/lease_terms",
+	}), ssmAPI
+}
+
+func TestUpdateTerms(t *testing.T) {
+	notFound := awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+	tests := []struct {
+		name   string
+		terms  *lease.Terms
+		expErr error
+		expPut int
+	}{
+		{
+			name:   "should save a new version",
+			terms:  &lease.Terms{Version: "3", Text: "Don't mine any cryptocurrency."},
+			expPut: 1,
+		},
+		{
+			name:   "should save the current version again",
+			terms:  &lease.Terms{Version: "2", Text: "Don't mine bitcoin."},
+			expPut: 1,
+		},
+		{
+			name:   "should not change the text of a version",
+			terms:  &lease.Terms{Version: "2", Text: "Don't mine any cryptocurrency."},
+			expErr: errors.NewConflict("terms", "2", fmt.Errorf("version 2 of the terms has a different text, change the version to change the text")),
+		},
+		{
+			name:   "should require a version",
+			terms:  &lease.Terms{Text: "Don't mine bitcoin."},
+			expErr: errors.NewValidation("terms", fmt.Errorf("version: cannot be blank.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, ssmAPI := newTermsService(currentTerms, nil)
+
+			terms, err := svc.UpdateTerms(tt.terms)
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			ssmAPI.AssertNumberOfCalls(t, "PutParameter", tt.expPut)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, tt.terms.Version, terms.Version)
+			assert.NotZero(t, terms.UpdatedOn)
+		})
+	}
+
+	t.Run("should save the first terms", func(t *testing.T) {
+		svc, ssmAPI := newTermsService("", notFound)
+
+		_, err := svc.UpdateTerms(&lease.Terms{Version: "1", Text: "Don't mine bitcoin."})
+		assert.Nil(t, err)
+		ssmAPI.AssertNumberOfCalls(t, "PutParameter", 1)
+	})
+
+	t.Run("should fail when terms aren't configured", func(t *testing.T) {
+		_, err := lease.NewService(lease.NewServiceInput{}).UpdateTerms(&lease.Terms{Version: "1", Text: "Don't mine bitcoin."})
+		assert.Equal(t, 503, errors.HTTPCodeForError(err))
+	})
+}
+
+func TestAcceptTerms(t *testing.T) {
+	notFound := awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+	tests := []struct {
+		name          string
+		parameter     string
+		getErr        error
+		acceptance    *lease.TermsAcceptance
+		expErr        error
+		expAcceptance bool
+	}{
+		{
+			name:          "should record the acceptance of the current version",
+			parameter:     currentTerms,
+			acceptance:    &lease.TermsAcceptance{Version: "2"},
+			expAcceptance: true,
+		},
+		{
+			name:       "should require the current version",
+			parameter:  currentTerms,
+			acceptance: &lease.TermsAcceptance{Version: "1"},
+			expErr:     errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted, see GET /leases/terms.")),
+		},
+		{
+			name:      "should require an acceptance",
+			parameter: currentTerms,
+			expErr:    errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted, see GET /leases/terms.")),
+		},
+		{
+			name:       "should remove the acceptance when there are no terms",
+			getErr:     notFound,
+			acceptance: &lease.TermsAcceptance{Version: "1"},
+		},
+		{
+			name:   "should fail when the terms can't be read",
+			getErr: fmt.Errorf("throttled"),
+			expErr: errors.NewInternalServer("failed to get lease terms", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTermsService(tt.parameter, tt.getErr)
+			data := &lease.Lease{TermsAcceptance: tt.acceptance}
+
+			err := svc.AcceptTerms(data, "jdoe")
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr != nil {
+				return
+			}
+			if !tt.expAcceptance {
+				assert.Nil(t, data.TermsAcceptance)
+				return
+			}
+			assert.Equal(t, "jdoe", data.TermsAcceptance.AcceptedBy)
+			assert.NotZero(t, data.TermsAcceptance.AcceptedOn)
+		})
+	}
+}
+
+func TestCreateWithTerms(t *testing.T) {
+	tests := []struct {
+		name       string
+		acceptance *lease.TermsAcceptance
+		expErr     error
+	}{
+		{
+			name:       "should create when the terms were accepted",
+			acceptance: &lease.TermsAcceptance{Version: "2", AcceptedBy: "User1", AcceptedOn: 1580000000},
+		},
+		{
+			name:       "should not create when the terms weren't accepted",
+			acceptance: &lease.TermsAcceptance{Version: "2"},
+			expErr:     errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted.")),
+		},
+		{
+			name:   "should not create without an acceptance",
+			expErr: errors.NewValidation("lease", fmt.Errorf("termsAcceptance: version 2 of the terms of use must be accepted.")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocksRwd := &mocks.ReaderWriter{}
+			mocksRwd.On("List", mock.AnythingOfType("*lease.Lease")).Return(nil, nil)
+			mocksRwd.On("Write", mock.AnythingOfType("*lease.Lease"), mock.AnythingOfType("*int64")).Return(nil)
+			mocksEventer := &mocks.Eventer{}
+			mocksEventer.On("LeaseCreate", mock.AnythingOfType("*lease.Lease")).Return(nil)
+			ssmAPI := &awsMocks.SSMAPI{}
+			ssmAPI.On("GetParameter", mock.Anything).Return(&ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: aws.String(currentTerms)},
+			}, nil)
+
+			leaseSvc := lease.NewService(
+				lease.NewServiceInput{
+					DataSvc:                  mocksRwd,
+					EventSvc:                 mocksEventer,
+					AccountSvc:               &mocks.AccountServicer{},
+					SSM:                      ssmAPI,
+					TermsParameter:           "/dce/lease_terms",
+					DefaultLeaseLengthInDays: 7,
+					PrincipalBudgetAmount:    1000.00,
+					PrincipalBudgetPeriod:    "Weekly",
+					MaxLeaseBudgetAmount:     1000.00,
+					MaxLeasePeriod:           704800,
+				},
+			)
+
+			result, err := leaseSvc.Create(&lease.Lease{
+				PrincipalID:     aws.String("User1"),
+				AccountID:       aws.String("123456789012"),
+				BudgetAmount:    aws.Float64(200.00),
+				TermsAcceptance: tt.acceptance,
+			}, 0)
+
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			if tt.expErr == nil {
+				assert.Equal(t, tt.acceptance, result.TermsAcceptance)
+			}
+		})
+	}
+}
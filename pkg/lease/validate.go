@@ -4,11 +4,15 @@ import (
 	"errors"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"fmt"
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/budget"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	"math"
+	"sort"
 	"time"
 )
 
@@ -33,6 +37,25 @@ var validateInt64 = []validation.Rule{
 	validation.NotNil.Error("must be an epoch timestamp"),
 }
 
+// maxNotesLength is the most characters of notes a lease can have
+const maxNotesLength = 4096
+
+var validateNotes = []validation.Rule{
+	validation.RuneLength(0, maxNotesLength),
+}
+
+// maxSharedWith is the most other principals a lease can be shared with
+const maxSharedWith = 10
+
+var validateSharedWith = []validation.Rule{
+	validation.Length(0, maxSharedWith),
+	validation.By(isSharedWithValid),
+}
+
+// maxAlertSuppression is the longest budget alerts can be suppressed for, in
+// seconds
+const maxAlertSuppression = 30 * 24 * 60 * 60
+
 var validateStatus = []validation.Rule{
 	validation.NotNil.Error("must be a valid lease status"),
 }
@@ -44,6 +67,23 @@ func isNil(value interface{}) error {
 	return nil
 }
 
+// isSharedWithValid checks the principals a lease is shared with aren't
+// empty or repeated
+func isSharedWithValid(value interface{}) error {
+	principals, _ := value.([]string)
+	seen := map[string]bool{}
+	for _, p := range principals {
+		if strings.TrimSpace(p) == "" {
+			return errors.New("must not have empty principals")
+		}
+		if seen[p] {
+			return fmt.Errorf("must not repeat the principal %s", p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
 func isLeaseActive(value interface{}) error {
 	s, _ := value.(*Status)
 	if s.String() != StatusActive.String() {
@@ -52,7 +92,29 @@ func isLeaseActive(value interface{}) error {
 	return nil
 }
 
-func isExpiresOnValid(a *Service) validation.RuleFunc {
+func isLeaseInactive(value interface{}) error {
+	s, _ := value.(*Status)
+	if s.String() != StatusInactive.String() {
+		return errors.New("must be inactive lease")
+	}
+	return nil
+}
+
+// isBudgetCurrencyValid checks the budget is in the currency budgets are
+// enforced in.  Any currency is allowed when none is configured.
+func isBudgetCurrencyValid(a *Service) validation.RuleFunc {
+	return func(value interface{}) error {
+		c, _ := value.(*string)
+		if a.budgetCurrency == "" || c == nil || strings.EqualFold(*c, a.budgetCurrency) {
+			return nil
+		}
+		return fmt.Errorf("must be %s, the currency budgets are enforced in", a.budgetCurrency)
+	}
+}
+
+// isExpiresOnValid checks a lease expires in the future, and lasts at most
+// the maximum lease duration from when it starts, eg. "from now"
+func isExpiresOnValid(startsOn int64, maxDuration int64, startsWhen string) validation.RuleFunc {
 
 	return func(value interface{}) error {
 		if !reflect.ValueOf(value).IsNil() {
@@ -61,35 +123,74 @@ func isExpiresOnValid(a *Service) validation.RuleFunc {
 
 			// Validate requested lease end date is greater than today
 			if *e <= time.Now().Unix() {
-				return fmt.Errorf("Requested lease has a desired expiry date less than today: %d", *e)
+				return fmt.Errorf("must be in the future")
 			}
 
-			// Validate requested lease budget period is less than MAX_LEASE_BUDGET_PERIOD
-			currentTime := time.Now()
-			maxLeaseExpiresOn := currentTime.Add(time.Second * time.Duration(a.maxLeasePeriod))
-			if *e > maxLeaseExpiresOn.Unix() {
-				return fmt.Errorf("Requested lease has a budget expires on of %d, which is greater than max lease period of %d", *e, a.maxLeasePeriod)
+			// Validate requested lease lasts at most the maximum lease duration
+			if *e > startsOn+maxDuration {
+				return fmt.Errorf("must be at most %s %s, the maximum lease duration", formatDuration(maxDuration), startsWhen)
+			}
+		}
+		return nil
+	}
+}
+
+// isRequiredQuotasValid checks the service quotas a lease requires are valid
+// quotas.  Each quota's value is the least the leased account must have.
+func isRequiredQuotasValid(value interface{}) error {
+	quotas, _ := value.([]account.ServiceQuota)
+	return account.ValidateServiceQuotas(quotas)
+}
+
+// isCategoryBudgetsValid checks the category budgets of a lease are for
+// configured categories, and add up to at most the lease's budget
+func isCategoryBudgetsValid(categories budget.Categories, budgetAmount float64) validation.RuleFunc {
+	return func(value interface{}) error {
+		budgets, _ := value.(map[string]CategoryBudget)
+		names := make([]string, 0, len(budgets))
+		for name := range budgets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		total := 0.0
+		for _, name := range names {
+			if !categories.Has(name) {
+				return fmt.Errorf("%s is not a budget category, must be one of %s", name, strings.Join(categories.Names(), ", "))
+			}
+			categoryBudget := budgets[name]
+			if categoryBudget.Amount <= 0 {
+				return fmt.Errorf("%s must have an amount greater than 0", name)
 			}
+			for _, threshold := range categoryBudget.NotificationThresholds {
+				if threshold <= 0 || threshold > 100 {
+					return fmt.Errorf("%s must have notification thresholds between 0 and 100", name)
+				}
+			}
+			total += categoryBudget.Amount
+		}
+		if total > budgetAmount {
+			return fmt.Errorf("must add up to at most the budget amount of %.2f", budgetAmount)
 		}
 		return nil
 	}
 }
 
-func isBudgetAmountValid(a *Service, principalId string, principalSpentAmount float64) validation.RuleFunc {
+func isBudgetAmountValid(limits Quota, principalId string, principalSpentAmount float64) validation.RuleFunc {
 	return func(value interface{}) error {
 		if !reflect.ValueOf(value).IsNil() {
 			b, _ := value.(*float64)
 
 			// Validate requested lease budget amount is less than MAX_LEASE_BUDGET_AMOUNT
-			if *b > a.maxLeaseBudgetAmount {
-				return fmt.Errorf("Requested lease has a budget amount of %f, which is greater than max lease budget amount of %f", math.Round(*b), math.Round(a.maxLeaseBudgetAmount))
+			if *b > limits.MaxLeaseBudgetAmount {
+				return fmt.Errorf("Requested lease has a budget amount of %f, which is greater than max lease budget amount of %f", math.Round(*b), math.Round(limits.MaxLeaseBudgetAmount))
 			}
 
 			// Validate requested lease budget amount is less than PRINCIPAL_BUDGET_AMOUNT for current principal billing period
-			if principalSpentAmount > a.principalBudgetAmount {
+			if principalSpentAmount > limits.PrincipalBudgetAmount {
 				return fmt.Errorf(
 					"Unable to create lease: User principal %s has already spent %.2f of their %.2f principal budget",
-					principalId, principalSpentAmount, a.principalBudgetAmount,
+					principalId, principalSpentAmount, limits.PrincipalBudgetAmount,
 				)
 			}
 
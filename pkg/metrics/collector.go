@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/account/accountiface"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/lease/leaseiface"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+)
+
+// resetDurationQuantiles are the quantiles of the reset duration summary
+var resetDurationQuantiles = []float64{0.5, 0.9, 0.99}
+
+// Collector collects the DCE metrics
+type Collector struct {
+	AccountSvc accountiface.Servicer
+	LeaseSvc   leaseiface.Servicer
+	// CodeBuild and ResetBuildName are optional, and used to collect the
+	// durations of recent account resets
+	CodeBuild      codebuildiface.CodeBuildAPI
+	ResetBuildName string
+}
+
+// Collect gets the current metrics
+func (c *Collector) Collect() ([]*Family, error) {
+	families := []*Family{}
+
+	accounts, err := c.collectAccounts()
+	if err != nil {
+		return nil, err
+	}
+	families = append(families, accounts)
+
+	leases, overBudget, err := c.collectLeases()
+	if err != nil {
+		return nil, err
+	}
+	families = append(families, leases, overBudget)
+
+	if c.CodeBuild != nil && c.ResetBuildName != "" {
+		resets, durations, err := c.collectResets()
+		if err != nil {
+			return nil, err
+		}
+		families = append(families, resets, durations)
+	}
+
+	return families, nil
+}
+
+func (c *Collector) collectAccounts() (*Family, error) {
+	counts := map[string]float64{
+		string(account.StatusReady):       0,
+		string(account.StatusNotReady):    0,
+		string(account.StatusLeased):      0,
+		string(account.StatusOrphaned):    0,
+		string(account.StatusUnreachable): 0,
+		string(account.StatusQuarantined): 0,
+	}
+	err := c.AccountSvc.ListPages(&account.Account{}, func(accounts *account.Accounts) bool {
+		for _, a := range *accounts {
+			if a.Status != nil {
+				counts[a.Status.String()]++
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Family{
+		Name:    "dce_accounts",
+		Help:    "Accounts in the account pool, by status.",
+		Type:    TypeGauge,
+		Samples: countSamples("status", counts),
+	}, nil
+}
+
+func (c *Collector) collectLeases() (*Family, *Family, error) {
+	counts := map[string]float64{
+		string(lease.StatusActive):   0,
+		string(lease.StatusInactive): 0,
+	}
+	overBudget := map[string]float64{
+		string(lease.StatusReasonOverBudget):          0,
+		string(lease.StatusReasonOverPrincipalBudget): 0,
+		string(lease.StatusReasonOverCategoryBudget):  0,
+	}
+	err := c.LeaseSvc.ListPages(&lease.Lease{}, func(leases *lease.Leases) bool {
+		for _, l := range *leases {
+			if l.Status == nil {
+				continue
+			}
+			counts[string(*l.Status)]++
+			if *l.Status == lease.StatusInactive && l.StatusReason != nil {
+				if _, ok := overBudget[string(*l.StatusReason)]; ok {
+					overBudget[string(*l.StatusReason)]++
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leases := &Family{
+		Name:    "dce_leases",
+		Help:    "Leases, by status.",
+		Type:    TypeGauge,
+		Samples: countSamples("status", counts),
+	}
+	overBudgetLeases := &Family{
+		Name:    "dce_leases_over_budget",
+		Help:    "Leases ended for going over budget, by reason.",
+		Type:    TypeGauge,
+		Samples: countSamples("reason", overBudget),
+	}
+	return leases, overBudgetLeases, nil
+}
+
+// collectResets gets the status and durations of the most recent reset builds
+func (c *Collector) collectResets() (*Family, *Family, error) {
+	ids, err := c.CodeBuild.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(c.ResetBuildName),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts := map[string]float64{}
+	durations := []float64{}
+	if len(ids.Ids) > 0 {
+		builds, err := c.CodeBuild.BatchGetBuilds(&codebuild.BatchGetBuildsInput{Ids: ids.Ids})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, build := range builds.Builds {
+			counts[aws.StringValue(build.BuildStatus)]++
+			if build.StartTime != nil && build.EndTime != nil {
+				durations = append(durations, build.EndTime.Sub(*build.StartTime).Seconds())
+			}
+		}
+	}
+
+	resets := &Family{
+		Name:    "dce_resets",
+		Help:    "Recent account reset builds, by status.",
+		Type:    TypeGauge,
+		Samples: countSamples("status", counts),
+	}
+	resetDurations := &Family{
+		Name:    "dce_reset_duration_seconds",
+		Help:    "Durations of recent account reset builds.",
+		Type:    TypeSummary,
+		Samples: summarySamples(durations),
+	}
+	return resets, resetDurations, nil
+}
+
+func countSamples(label string, counts map[string]float64) []Sample {
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	samples := make([]Sample, 0, len(values))
+	for _, value := range values {
+		samples = append(samples, Sample{
+			Labels: map[string]string{label: value},
+			Value:  counts[value],
+		})
+	}
+	return samples
+}
+
+func summarySamples(values []float64) []Sample {
+	sort.Float64s(values)
+	samples := []Sample{}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	for _, q := range resetDurationQuantiles {
+		value := math.NaN()
+		if len(values) > 0 {
+			// Nearest rank
+			value = values[int(math.Ceil(q*float64(len(values))))-1]
+		}
+		samples = append(samples, Sample{
+			Labels: map[string]string{"quantile": strconv.FormatFloat(q, 'g', -1, 64)},
+			Value:  value,
+		})
+	}
+	return append(samples,
+		Sample{Suffix: "_sum", Value: sum},
+		Sample{Suffix: "_count", Value: float64(len(values))},
+	)
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/account"
+	accountMocks "github.com/Optum/dce/pkg/account/accountiface/mocks"
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	leaseMocks "github.com/Optum/dce/pkg/lease/leaseiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCollect(t *testing.T) {
+	accountSvc := &accountMocks.Servicer{}
+	accountSvc.On("ListPages", &account.Account{}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*account.Accounts) bool)
+			fn(&account.Accounts{
+				{ID: aws.String("1"), Status: account.StatusReady.StatusPtr()},
+				{ID: aws.String("2"), Status: account.StatusReady.StatusPtr()},
+				{ID: aws.String("3"), Status: account.StatusLeased.StatusPtr()},
+			})
+		}).
+		Return(nil)
+
+	leaseSvc := &leaseMocks.Servicer{}
+	overBudget := lease.StatusReasonOverBudget
+	expired := lease.StatusReasonExpired
+	leaseSvc.On("ListPages", &lease.Lease{}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*lease.Leases) bool)
+			fn(&lease.Leases{
+				{ID: aws.String("1"), Status: lease.StatusActive.StatusPtr()},
+				{ID: aws.String("2"), Status: lease.StatusInactive.StatusPtr(), StatusReason: &overBudget},
+				{ID: aws.String("3"), Status: lease.StatusInactive.StatusPtr(), StatusReason: &expired},
+			})
+		}).
+		Return(nil)
+
+	start := time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC)
+	codeBuild := &awsMocks.CodeBuildAPI{}
+	codeBuild.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("ResetCodeBuild"),
+		SortOrder:   aws.String("DESCENDING"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: aws.StringSlice([]string{"b1", "b2", "b3"}),
+	}, nil)
+	codeBuild.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"b1", "b2", "b3"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{BuildStatus: aws.String("IN_PROGRESS"), StartTime: aws.Time(start)},
+			{BuildStatus: aws.String("SUCCEEDED"), StartTime: aws.Time(start), EndTime: aws.Time(start.Add(5 * time.Minute))},
+			{BuildStatus: aws.String("FAILED"), StartTime: aws.Time(start), EndTime: aws.Time(start.Add(10 * time.Minute))},
+		},
+	}, nil)
+
+	collector := &Collector{
+		AccountSvc:     accountSvc,
+		LeaseSvc:       leaseSvc,
+		CodeBuild:      codeBuild,
+		ResetBuildName: "ResetCodeBuild",
+	}
+	families, err := collector.Collect()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Family{
+		{
+			Name: "dce_accounts",
+			Help: "Accounts in the account pool, by status.",
+			Type: TypeGauge,
+			Samples: []Sample{
+				{Labels: map[string]string{"status": "Leased"}, Value: 1},
+				{Labels: map[string]string{"status": "NotReady"}, Value: 0},
+				{Labels: map[string]string{"status": "Orphaned"}, Value: 0},
+				{Labels: map[string]string{"status": "Quarantined"}, Value: 0},
+				{Labels: map[string]string{"status": "Ready"}, Value: 2},
+				{Labels: map[string]string{"status": "Unreachable"}, Value: 0},
+			},
+		},
+		{
+			Name: "dce_leases",
+			Help: "Leases, by status.",
+			Type: TypeGauge,
+			Samples: []Sample{
+				{Labels: map[string]string{"status": "Active"}, Value: 1},
+				{Labels: map[string]string{"status": "Inactive"}, Value: 2},
+			},
+		},
+		{
+			Name: "dce_leases_over_budget",
+			Help: "Leases ended for going over budget, by reason.",
+			Type: TypeGauge,
+			Samples: []Sample{
+				{Labels: map[string]string{"reason": "OverBudget"}, Value: 1},
+				{Labels: map[string]string{"reason": "OverCategoryBudget"}, Value: 0},
+				{Labels: map[string]string{"reason": "OverPrincipalBudget"}, Value: 0},
+			},
+		},
+		{
+			Name: "dce_resets",
+			Help: "Recent account reset builds, by status.",
+			Type: TypeGauge,
+			Samples: []Sample{
+				{Labels: map[string]string{"status": "FAILED"}, Value: 1},
+				{Labels: map[string]string{"status": "IN_PROGRESS"}, Value: 1},
+				{Labels: map[string]string{"status": "SUCCEEDED"}, Value: 1},
+			},
+		},
+		{
+			Name: "dce_reset_duration_seconds",
+			Help: "Durations of recent account reset builds.",
+			Type: TypeSummary,
+			Samples: []Sample{
+				{Labels: map[string]string{"quantile": "0.5"}, Value: 300},
+				{Labels: map[string]string{"quantile": "0.9"}, Value: 600},
+				{Labels: map[string]string{"quantile": "0.99"}, Value: 600},
+				{Suffix: "_sum", Value: 900},
+				{Suffix: "_count", Value: 2},
+			},
+		},
+	}, families)
+}
+
+func TestCollectError(t *testing.T) {
+	accountSvc := &accountMocks.Servicer{}
+	accountSvc.On("ListPages", mock.Anything, mock.Anything).Return(fmt.Errorf("throttled"))
+
+	collector := &Collector{AccountSvc: accountSvc}
+	_, err := collector.Collect()
+
+	assert.EqualError(t, err, "throttled")
+}
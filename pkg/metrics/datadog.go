@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDatadogURL is the Datadog API URL of the US site
+const DefaultDatadogURL = "https://api.datadoghq.com"
+
+// Datadog sends metrics and events to Datadog using the v1 API
+type Datadog struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+// NewDatadog creates a Datadog driver. The URL defaults to DefaultDatadogURL
+// when empty.
+func NewDatadog(apiKey string, apiURL string, httpClient *http.Client) *Datadog {
+	if apiURL == "" {
+		apiURL = DefaultDatadogURL
+	}
+	return &Datadog{
+		apiKey:     apiKey,
+		url:        strings.TrimRight(apiURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+type datadogSeries struct {
+	Metric string       `json:"metric"`
+	Points [][2]float64 `json:"points"`
+	Type   string       `json:"type"`
+	Tags   []string     `json:"tags"`
+}
+
+type datadogEvent struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	AlertType      string   `json:"alert_type"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
+	SourceTypeName string   `json:"source_type_name"`
+	Tags           []string `json:"tags"`
+}
+
+// SendMetrics submits the metrics as gauges. Metric names are converted to
+// the Datadog style, eg. dce_reset_duration_seconds_sum becomes
+// dce.reset_duration_seconds.sum
+func (d *Datadog) SendMetrics(families []*Family, timestamp time.Time, tags []string) error {
+	series := []*datadogSeries{}
+	for _, family := range families {
+		name := strings.Replace(family.Name, "dce_", "dce.", 1)
+		for _, sample := range family.Samples {
+			// Datadog can't store NaN, eg. a quantile with no observations
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+			series = append(series, &datadogSeries{
+				Metric: name + strings.Replace(sample.Suffix, "_", ".", 1),
+				Points: [][2]float64{{float64(timestamp.Unix()), sample.Value}},
+				Type:   "gauge",
+				Tags:   append(labelTags(sample.Labels), tags...),
+			})
+		}
+	}
+	if len(series) == 0 {
+		return nil
+	}
+	return d.post("/api/v1/series", map[string]interface{}{"series": series})
+}
+
+// SendEvent posts an event to the Datadog event stream
+func (d *Datadog) SendEvent(event *Event, tags []string) error {
+	return d.post("/api/v1/events", &datadogEvent{
+		Title:          event.Title,
+		Text:           event.Text,
+		AlertType:      string(event.AlertType),
+		AggregationKey: event.Name,
+		SourceTypeName: "dce",
+		Tags:           append(append(labelTags(event.Tags), "event:"+event.Name), tags...),
+	})
+}
+
+func (d *Datadog) post(path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, d.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", d.apiKey)
+
+	res, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("datadog POST %s failed (%s): %s", path, res.Status, resBody)
+	}
+	return nil
+}
+
+// labelTags converts labels to sorted "key:value" tags
+func labelTags(labels map[string]string) []string {
+	tags := make([]string, 0, len(labels))
+	for k, v := range labels {
+		tags = append(tags, k+":"+v)
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatadogSendMetrics(t *testing.T) {
+	var gotPath, gotKey string
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.Header.Get("DD-API-KEY")
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	driver := NewDatadog("api-key", server.URL+"/", server.Client())
+	err := driver.SendMetrics([]*Family{
+		{
+			Name:    "dce_accounts",
+			Type:    TypeGauge,
+			Samples: []Sample{{Labels: map[string]string{"status": "Ready"}, Value: 3}},
+		},
+		{
+			Name: "dce_reset_duration_seconds",
+			Type: TypeSummary,
+			Samples: []Sample{
+				{Labels: map[string]string{"quantile": "0.5"}, Value: math.NaN()},
+				{Suffix: "_count", Value: 0},
+			},
+		},
+	}, time.Unix(1583325000, 0), []string{"namespace:prod"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1/series", gotPath)
+	assert.Equal(t, "api-key", gotKey)
+	assert.Equal(t, map[string]interface{}{
+		"series": []interface{}{
+			map[string]interface{}{
+				"metric": "dce.accounts",
+				"points": []interface{}{[]interface{}{1583325000.0, 3.0}},
+				"type":   "gauge",
+				"tags":   []interface{}{"status:Ready", "namespace:prod"},
+			},
+			map[string]interface{}{
+				"metric": "dce.reset_duration_seconds.count",
+				"points": []interface{}{[]interface{}{1583325000.0, 0.0}},
+				"type":   "gauge",
+				"tags":   []interface{}{"namespace:prod"},
+			},
+		},
+	}, got)
+}
+
+func TestDatadogSendEvent(t *testing.T) {
+	var gotPath string
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	driver := NewDatadog("api-key", server.URL, server.Client())
+	err := driver.SendEvent(&Event{
+		Name:      "LeaseEnded",
+		Title:     "Lease ended for jdoe (OverBudget)",
+		Text:      "over budget",
+		AlertType: EventWarning,
+		Tags:      map[string]string{"reason": "OverBudget", "accountId": "123456789012"},
+	}, []string{"namespace:prod"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1/events", gotPath)
+	assert.Equal(t, map[string]interface{}{
+		"title":            "Lease ended for jdoe (OverBudget)",
+		"text":             "over budget",
+		"alert_type":       "warning",
+		"aggregation_key":  "LeaseEnded",
+		"source_type_name": "dce",
+		"tags":             []interface{}{"accountId:123456789012", "reason:OverBudget", "event:LeaseEnded", "namespace:prod"},
+	}, got)
+}
+
+func TestDatadogError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": ["Forbidden"]}`))
+	}))
+	defer server.Close()
+
+	driver := NewDatadog("api-key", server.URL, server.Client())
+	err := driver.SendEvent(&Event{Name: "LeaseCreated"}, nil)
+
+	assert.EqualError(t, err, `datadog POST /api/v1/events failed (403 Forbidden): {"errors": ["Forbidden"]}`)
+}
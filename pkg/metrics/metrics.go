@@ -0,0 +1,117 @@
+// Package metrics collects DCE operational metrics, such as the account pool
+// size, and exposes them in the Prometheus text format.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Type is the type of a metric
+type Type string
+
+// Metric types
+const (
+	TypeGauge   Type = "gauge"
+	TypeSummary Type = "summary"
+)
+
+// Sample is a value of a metric
+type Sample struct {
+	// Suffix is added to the metric name, eg. "_sum" for summaries
+	Suffix string
+	Labels map[string]string
+	Value  float64
+}
+
+// Family is a metric and its samples
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// WritePrometheus writes the metrics in the Prometheus text format
+func WritePrometheus(w io.Writer, families []*Family) error {
+	for _, family := range families {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", family.Name, escapeHelp(family.Help), family.Name, family.Type)
+		if err != nil {
+			return err
+		}
+		for _, sample := range family.Samples {
+			_, err = fmt.Fprintf(w, "%s%s%s %s\n", family.Name, sample.Suffix, formatLabels(sample.Labels),
+				strconv.FormatFloat(sample.Value, 'g', -1, 64))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabel(labels[name])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// Push replaces the metrics of a job in a Prometheus Pushgateway
+func Push(httpClient *http.Client, pushgatewayURL string, job string, families []*Family) error {
+	body := &bytes.Buffer{}
+	err := WritePrometheus(body, families)
+	if err != nil {
+		return err
+	}
+
+	pushURL := strings.TrimRight(pushgatewayURL, "/") + "/metrics/job/" + url.PathEscape(job)
+	req, err := http.NewRequest(http.MethodPut, pushURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("pushgateway PUT %s failed (%s): %s", req.URL.Path, res.Status, resBody)
+	}
+	return nil
+}
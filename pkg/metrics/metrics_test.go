@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFamilies = []*Family{
+	{
+		Name: "dce_accounts",
+		Help: "Accounts in the account pool, by status.",
+		Type: TypeGauge,
+		Samples: []Sample{
+			{Labels: map[string]string{"status": "Ready"}, Value: 3},
+			{Labels: map[string]string{"status": "Leased", "note": "a \"quoted\"\nvalue"}, Value: 1},
+		},
+	},
+	{
+		Name: "dce_reset_duration_seconds",
+		Help: "Durations of recent account reset builds.",
+		Type: TypeSummary,
+		Samples: []Sample{
+			{Labels: map[string]string{"quantile": "0.5"}, Value: 120.5},
+			{Suffix: "_sum", Value: 241},
+			{Suffix: "_count", Value: 2},
+		},
+	},
+}
+
+const expPrometheus = `# HELP dce_accounts Accounts in the account pool, by status.
+# TYPE dce_accounts gauge
+dce_accounts{status="Ready"} 3
+dce_accounts{note="a \"quoted\"\nvalue",status="Leased"} 1
+# HELP dce_reset_duration_seconds Durations of recent account reset builds.
+# TYPE dce_reset_duration_seconds summary
+dce_reset_duration_seconds{quantile="0.5"} 120.5
+dce_reset_duration_seconds_sum 241
+dce_reset_duration_seconds_count 2
+`
+
+func TestWritePrometheus(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WritePrometheus(buf, testFamilies)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expPrometheus, buf.String())
+}
+
+func TestPush(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		expErr string
+	}{
+		{
+			name:   "should push the metrics",
+			status: http.StatusOK,
+		},
+		{
+			name:   "should fail when the pushgateway fails",
+			status: http.StatusBadRequest,
+			expErr: "pushgateway PUT /metrics/job/dce-prod failed (400 Bad Request): invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath, gotContentType, gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotMethod = r.Method
+				gotPath = r.URL.Path
+				gotContentType = r.Header.Get("Content-Type")
+				body, _ := ioutil.ReadAll(r.Body)
+				gotBody = string(body)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("invalid"))
+			}))
+			defer server.Close()
+
+			err := Push(server.Client(), server.URL+"/", "dce-prod", testFamilies)
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, http.MethodPut, gotMethod)
+			assert.Equal(t, "/metrics/job/dce-prod", gotPath)
+			assert.Equal(t, ContentType, gotContentType)
+			assert.Equal(t, expPrometheus, gotBody)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//
+
+package metricsiface
+
+import (
+	"github.com/Optum/dce/pkg/metrics"
+)
+
+// Servicer sends metrics and events to a monitoring service
+type Servicer interface {
+	// SendMetrics sends the current values of the metrics
+	SendMetrics(families []*metrics.Family) error
+	// SendEvent sends a lifecycle event
+	SendEvent(event *metrics.Event) error
+}
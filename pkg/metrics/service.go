@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/caarlos0/env"
+)
+
+// Driver names, used by the METRICS_DRIVER setting
+const (
+	DriverDatadog = "datadog"
+)
+
+// EventAlertType is the severity of an event
+type EventAlertType string
+
+// Event alert types
+const (
+	EventInfo    EventAlertType = "info"
+	EventSuccess EventAlertType = "success"
+	EventWarning EventAlertType = "warning"
+	EventError   EventAlertType = "error"
+)
+
+// Event is a DCE lifecycle event, such as a lease ending
+type Event struct {
+	// Name identifies the kind of event, eg. "LeaseEnded"
+	Name      string
+	Title     string
+	Text      string
+	AlertType EventAlertType
+	Tags      map[string]string
+}
+
+// Driver ships metrics and events to a monitoring service
+type Driver interface {
+	SendMetrics(families []*Family, timestamp time.Time, tags []string) error
+	SendEvent(event *Event, tags []string) error
+}
+
+// NewServiceInput are the items needed to create a new metrics service
+type NewServiceInput struct {
+	// DriverName is the monitoring service to send metrics and events to,
+	// currently only "datadog". Sending is disabled when empty
+	DriverName string `env:"METRICS_DRIVER" envDefault:""`
+	// APIKey is the API key of the monitoring service
+	APIKey string `env:"METRICS_API_KEY" envDefault:""`
+	// APIURL overrides the API URL of the driver, eg. for the Datadog EU site
+	APIURL string `env:"METRICS_API_URL" envDefault:""`
+	// Tags are added to every metric and event, as "key:value"
+	Tags      []string `env:"METRICS_TAGS" envDefault:""`
+	Namespace string   `env:"NAMESPACE" envDefault:"dce"`
+	// Driver is optional, and overrides DriverName
+	Driver Driver
+}
+
+// Service sends metrics and events to a monitoring service
+type Service struct {
+	driver Driver
+	tags   []string
+}
+
+// SendMetrics sends the current values of the metrics
+func (s *Service) SendMetrics(families []*Family) error {
+	if s.driver == nil {
+		return nil
+	}
+	err := s.driver.SendMetrics(families, time.Now(), s.tags)
+	if err != nil {
+		return errors.NewInternalServer("failed to send metrics", err)
+	}
+	return nil
+}
+
+// SendEvent sends a lifecycle event
+func (s *Service) SendEvent(event *Event) error {
+	if s.driver == nil {
+		return nil
+	}
+	e := *event
+	if e.AlertType == "" {
+		e.AlertType = EventInfo
+	}
+	err := s.driver.SendEvent(&e, s.tags)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to send %s event", event.Name), err)
+	}
+	return nil
+}
+
+// NewService creates a new metrics service
+func NewService(input NewServiceInput) (*Service, error) {
+	driver := input.Driver
+	if driver == nil && input.DriverName != "" {
+		if input.APIKey == "" {
+			return nil, errors.NewValidation("metrics", fmt.Errorf("an API key is required for the %s driver", input.DriverName))
+		}
+		httpClient := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		switch strings.ToLower(input.DriverName) {
+		case DriverDatadog:
+			driver = NewDatadog(input.APIKey, input.APIURL, httpClient)
+		default:
+			return nil, errors.NewValidation("metrics", fmt.Errorf("unknown metrics driver %q", input.DriverName))
+		}
+	}
+
+	tags := []string{"namespace:" + input.Namespace}
+	for _, tag := range input.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return &Service{
+		driver: driver,
+		tags:   tags,
+	}, nil
+}
+
+// NewFromEnv creates a new metrics service configured from environment variables
+func NewFromEnv() (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(input)
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDriver struct {
+	families []*Family
+	events   []*Event
+	tags     []string
+	err      error
+}
+
+func (d *testDriver) SendMetrics(families []*Family, timestamp time.Time, tags []string) error {
+	d.families = append(d.families, families...)
+	d.tags = tags
+	return d.err
+}
+
+func (d *testDriver) SendEvent(event *Event, tags []string) error {
+	d.events = append(d.events, event)
+	d.tags = tags
+	return d.err
+}
+
+func TestServiceSend(t *testing.T) {
+	driver := &testDriver{}
+	svc, err := NewService(NewServiceInput{Namespace: "prod", Tags: []string{"team:cloud", " "}, Driver: driver})
+	assert.Nil(t, err)
+
+	families := []*Family{{Name: "dce_accounts", Type: TypeGauge}}
+	err = svc.SendMetrics(families)
+	assert.Nil(t, err)
+	assert.Equal(t, families, driver.families)
+	assert.Equal(t, []string{"namespace:prod", "team:cloud"}, driver.tags)
+
+	err = svc.SendEvent(&Event{Name: "LeaseCreated", Title: "Lease created"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*Event{{Name: "LeaseCreated", Title: "Lease created", AlertType: EventInfo}}, driver.events)
+}
+
+func TestServiceDriverError(t *testing.T) {
+	driver := &testDriver{err: fmt.Errorf("unavailable")}
+	svc, err := NewService(NewServiceInput{Namespace: "prod", Driver: driver})
+	assert.Nil(t, err)
+
+	err = svc.SendMetrics([]*Family{})
+	assert.EqualError(t, err, "failed to send metrics")
+	err = svc.SendEvent(&Event{Name: "LeaseEnded"})
+	assert.EqualError(t, err, "failed to send LeaseEnded event")
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NewServiceInput
+		expErr string
+		expNil bool
+	}{
+		{
+			name:   "should disable sending without a driver",
+			input:  NewServiceInput{},
+			expNil: true,
+		},
+		{
+			name:  "should create a Datadog driver",
+			input: NewServiceInput{DriverName: "Datadog", APIKey: "key"},
+		},
+		{
+			name:   "should require an API key",
+			input:  NewServiceInput{DriverName: "datadog"},
+			expErr: "metrics validation error: an API key is required for the datadog driver",
+		},
+		{
+			name:   "should reject unknown drivers",
+			input:  NewServiceInput{DriverName: "statsd", APIKey: "key"},
+			expErr: "metrics validation error: unknown metrics driver \"statsd\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := NewService(tt.input)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expNil, svc.driver == nil)
+			// A disabled service ignores metrics and events
+			if tt.expNil {
+				assert.Nil(t, svc.SendMetrics([]*Family{}))
+				assert.Nil(t, svc.SendEvent(&Event{Name: "LeaseCreated"}))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package model defines the legal transitions between the statuses of
+// accounts and leases, so every status change is checked against the same
+// rules
+package model
+
+import "fmt"
+
+// StatusGraph is the legal transitions between the statuses of a resource
+type StatusGraph struct {
+	resource string
+	next     map[string][]string
+}
+
+// AccountStatus is the legal transitions between account statuses
+var AccountStatus = StatusGraph{
+	resource: "account",
+	next: map[string][]string{
+		// Added to the pool, and reset before it's leased
+		"None": {"NotReady"},
+		// Reset again, reset done, the reset found the account was closed, or
+		// the account was stuck after its reset was retried
+		"NotReady": {"NotReady", "Ready", "Orphaned", "Unreachable", "Quarantined"},
+		"Ready":    {"Leased", "NotReady", "Orphaned"},
+		// Lease ended, or rolled back when the lease couldn't be created
+		"Leased": {"NotReady", "Ready", "Orphaned"},
+		// Reset after access to the account is restored
+		"Orphaned": {"Orphaned", "NotReady", "Unreachable"},
+		// Reset once its admin role can be assumed again, eg. after the account
+		// was reopened, or quarantined when it can't.  Otherwise closed or
+		// suspended accounts can only be deleted.
+		"Unreachable": {"NotReady", "Quarantined"},
+		// Quarantined accounts can only be deleted
+		"Quarantined": {},
+	},
+}
+
+// LeaseStatus is the legal transitions between lease statuses.  Leases
+// aren't reactivated once they end.
+var LeaseStatus = StatusGraph{
+	resource: "lease",
+	next: map[string][]string{
+		"":         {"Active"},
+		"Active":   {"Inactive"},
+		"Inactive": {},
+	},
+}
+
+// TransitionError means a status can't change to another
+type TransitionError struct {
+	Resource string
+	From     string
+	To       string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s status can't transition from %q to %q", e.Resource, e.From, e.To)
+}
+
+// Validate returns a TransitionError when the status can't change from one to
+// the other
+func (g StatusGraph) Validate(from string, to string) error {
+	for _, next := range g.next[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{
+		Resource: g.resource,
+		From:     from,
+		To:       to,
+	}
+}
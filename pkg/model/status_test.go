@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph StatusGraph
+		from  string
+		to    string
+		valid bool
+	}{
+		{name: "account reset done", graph: AccountStatus, from: "NotReady", to: "Ready", valid: true},
+		{name: "account leased", graph: AccountStatus, from: "Ready", to: "Leased", valid: true},
+		{name: "account lease ended", graph: AccountStatus, from: "Leased", to: "NotReady", valid: true},
+		{name: "account orphaned", graph: AccountStatus, from: "Leased", to: "Orphaned", valid: true},
+		{name: "account leased before reset", graph: AccountStatus, from: "NotReady", to: "Leased", valid: false},
+		{name: "orphaned account leased", graph: AccountStatus, from: "Orphaned", to: "Ready", valid: false},
+		{name: "account unreachable", graph: AccountStatus, from: "NotReady", to: "Unreachable", valid: true},
+		{name: "reopened account reset", graph: AccountStatus, from: "Unreachable", to: "NotReady", valid: true},
+		{name: "unreachable account leased", graph: AccountStatus, from: "Unreachable", to: "Ready", valid: false},
+		{name: "stuck account quarantined", graph: AccountStatus, from: "NotReady", to: "Quarantined", valid: true},
+		{name: "quarantined account reset", graph: AccountStatus, from: "Quarantined", to: "NotReady", valid: false},
+		{name: "unknown account status", graph: AccountStatus, from: "Decommissioned", to: "Ready", valid: false},
+		{name: "lease created", graph: LeaseStatus, from: "", to: "Active", valid: true},
+		{name: "lease ended", graph: LeaseStatus, from: "Active", to: "Inactive", valid: true},
+		{name: "lease reactivated", graph: LeaseStatus, from: "Inactive", to: "Active", valid: false},
+		{name: "lease ended twice", graph: LeaseStatus, from: "Inactive", to: "Inactive", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.graph.Validate(tt.from, tt.to)
+			if tt.valid {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, &TransitionError{Resource: tt.graph.resource, From: tt.from, To: tt.to}, err)
+		})
+	}
+}
+
+func TestTransitionError(t *testing.T) {
+	err := LeaseStatus.Validate("Inactive", "Active")
+	assert.EqualError(t, err, "lease status can't transition from \"Inactive\" to \"Active\"")
+}
@@ -0,0 +1,307 @@
+// Package monitoring creates and updates a standard set of CloudWatch alarms
+// for a DCE deployment, and a dashboard graphing each of them. Alarms and the
+// dashboard are named after the deployment namespace, so every deployment in
+// an AWS account gets its own.
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+// BudgetOverrunFilterPattern matches the update_lease_status log message
+// for a lease ended for being over its budget, or its principal's budget
+const BudgetOverrunFilterPattern = `"Budget.  Updating lease as ready to be reclaimed"`
+
+// Alarm is one of the standard DCE alarms, which is also graphed on the dashboard
+type Alarm struct {
+	Name               string
+	Title              string
+	Description        string
+	Namespace          string
+	MetricName         string
+	Dimensions         map[string]string
+	Statistic          string
+	Period             int64
+	EvaluationPeriods  int64
+	Threshold          float64
+	ComparisonOperator string
+	TreatMissingData   string
+}
+
+// NewServiceInput are the items needed to create a new monitoring service
+type NewServiceInput struct {
+	// Enabled turns on creating the alarms and dashboard
+	Enabled        bool   `env:"MONITORING_ENABLED" envDefault:"false"`
+	Namespace      string `env:"NAMESPACE" envDefault:"dce"`
+	Region         string `env:"AWS_CURRENT_REGION" envDefault:"us-east-1"`
+	AlarmTopicArn  string `env:"ALARM_TOPIC_ARN" envDefault:""`
+	APIName        string `env:"API_NAME" envDefault:""`
+	APIStageName   string `env:"API_STAGE_NAME" envDefault:""`
+	ResetBuildName string `env:"RESET_BUILD_NAME" envDefault:""`
+	// UpdateLeaseStatusLogGroup is the log group budget overruns are counted from
+	UpdateLeaseStatusLogGroup string `env:"UPDATE_LEASE_STATUS_LOG_GROUP" envDefault:""`
+	// MinReadyAccounts alarms when fewer accounts are Ready to lease
+	MinReadyAccounts int `env:"MONITORING_MIN_READY_ACCOUNTS" envDefault:"1"`
+	// API5xxThreshold, ResetFailureThreshold and BudgetOverrunThreshold alarm
+	// when there are at least that many errors in a period
+	API5xxThreshold        int `env:"MONITORING_API_5XX_THRESHOLD" envDefault:"5"`
+	ResetFailureThreshold  int `env:"MONITORING_RESET_FAILURE_THRESHOLD" envDefault:"1"`
+	BudgetOverrunThreshold int `env:"MONITORING_BUDGET_OVERRUN_THRESHOLD" envDefault:"1"`
+	CloudWatch             cloudwatchiface.CloudWatchAPI
+	CloudWatchLogs         cloudwatchlogsiface.CloudWatchLogsAPI
+}
+
+// Service creates and updates the standard DCE alarms and dashboard
+type Service struct {
+	input NewServiceInput
+}
+
+// Enabled is true when the alarms and dashboard should be created
+func (s *Service) Enabled() bool {
+	return s.input.Enabled
+}
+
+// DashboardName is the name of the dashboard of the standard alarms
+func (s *Service) DashboardName() string {
+	return fmt.Sprintf("DCE-%s-Alarms", s.input.Namespace)
+}
+
+// Alarms are the standard DCE alarms
+func (s *Service) Alarms() []*Alarm {
+	namespace := s.input.Namespace
+	return []*Alarm{
+		{
+			Name:        fmt.Sprintf("dce-pool-capacity-%s", namespace),
+			Title:       "Ready Accounts",
+			Description: fmt.Sprintf("Fewer than %d accounts are Ready to lease", s.input.MinReadyAccounts),
+			Namespace:   "DCE/AccountPool",
+			MetricName:  "ReadyAccounts",
+			Statistic:   cloudwatch.StatisticMinimum,
+			// Account pool metrics are published every 30 minutes by default
+			Period:             3600,
+			EvaluationPeriods:  1,
+			Threshold:          float64(s.input.MinReadyAccounts),
+			ComparisonOperator: cloudwatch.ComparisonOperatorLessThanThreshold,
+			TreatMissingData:   "missing",
+		},
+		{
+			Name:               fmt.Sprintf("dce-reset-failures-%s", namespace),
+			Title:              "Failed Account Resets",
+			Description:        "Account reset builds are failing",
+			Namespace:          "AWS/CodeBuild",
+			MetricName:         "FailedBuilds",
+			Dimensions:         map[string]string{"ProjectName": s.input.ResetBuildName},
+			Statistic:          cloudwatch.StatisticSum,
+			Period:             300,
+			EvaluationPeriods:  1,
+			Threshold:          float64(s.input.ResetFailureThreshold),
+			ComparisonOperator: cloudwatch.ComparisonOperatorGreaterThanOrEqualToThreshold,
+			TreatMissingData:   "notBreaching",
+		},
+		{
+			Name:               fmt.Sprintf("dce-api-5xx-%s", namespace),
+			Title:              "API 5XX Errors",
+			Description:        "The DCE API is returning server errors",
+			Namespace:          "AWS/ApiGateway",
+			MetricName:         "5XXError",
+			Dimensions:         map[string]string{"ApiName": s.input.APIName, "Stage": s.input.APIStageName},
+			Statistic:          cloudwatch.StatisticSum,
+			Period:             300,
+			EvaluationPeriods:  1,
+			Threshold:          float64(s.input.API5xxThreshold),
+			ComparisonOperator: cloudwatch.ComparisonOperatorGreaterThanOrEqualToThreshold,
+			TreatMissingData:   "notBreaching",
+		},
+		{
+			Name:               fmt.Sprintf("dce-budget-overruns-%s", namespace),
+			Title:              "Over Budget Leases",
+			Description:        "Leases are being ended for going over their budget",
+			Namespace:          s.leaseMetricsNamespace(),
+			MetricName:         "OverBudgetLeases",
+			Statistic:          cloudwatch.StatisticSum,
+			Period:             3600,
+			EvaluationPeriods:  1,
+			Threshold:          float64(s.input.BudgetOverrunThreshold),
+			ComparisonOperator: cloudwatch.ComparisonOperatorGreaterThanOrEqualToThreshold,
+			TreatMissingData:   "notBreaching",
+		},
+	}
+}
+
+// Provision creates or updates the budget overrun metric filter, the
+// standard alarms, and their dashboard
+func (s *Service) Provision() error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	err := s.putBudgetOverrunFilter()
+	if err != nil {
+		return err
+	}
+
+	alarms := s.Alarms()
+	for _, alarm := range alarms {
+		err = s.putAlarm(alarm)
+		if err != nil {
+			return err
+		}
+	}
+
+	body, err := s.dashboardBody(alarms)
+	if err != nil {
+		return err
+	}
+	_, err = s.input.CloudWatch.PutDashboard(&cloudwatch.PutDashboardInput{
+		DashboardName: aws.String(s.DashboardName()),
+		DashboardBody: aws.String(string(body)),
+	})
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to create dashboard %s", s.DashboardName()), err)
+	}
+	return nil
+}
+
+// leaseMetricsNamespace is the CloudWatch namespace of lease metrics. Metric
+// filters can't add dimensions, so each deployment has its own namespace.
+func (s *Service) leaseMetricsNamespace() string {
+	return fmt.Sprintf("DCE/%s/Leases", s.input.Namespace)
+}
+
+// putBudgetOverrunFilter counts over budget leases from the update_lease_status
+// logs. The log group is created if the lambda hasn't run yet.
+func (s *Service) putBudgetOverrunFilter() error {
+	input := &cloudwatchlogs.PutMetricFilterInput{
+		LogGroupName:  aws.String(s.input.UpdateLeaseStatusLogGroup),
+		FilterName:    aws.String(fmt.Sprintf("dce-budget-overruns-%s", s.input.Namespace)),
+		FilterPattern: aws.String(BudgetOverrunFilterPattern),
+		MetricTransformations: []*cloudwatchlogs.MetricTransformation{
+			{
+				MetricNamespace: aws.String(s.leaseMetricsNamespace()),
+				MetricName:      aws.String("OverBudgetLeases"),
+				MetricValue:     aws.String("1"),
+				DefaultValue:    aws.Float64(0),
+			},
+		},
+	}
+
+	_, err := s.input.CloudWatchLogs.PutMetricFilter(input)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+		_, err = s.input.CloudWatchLogs.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
+			LogGroupName: input.LogGroupName,
+		})
+		if err == nil {
+			_, err = s.input.CloudWatchLogs.PutMetricFilter(input)
+		}
+	}
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to create metric filter %s", *input.FilterName), err)
+	}
+	return nil
+}
+
+func (s *Service) putAlarm(alarm *Alarm) error {
+	input := &cloudwatch.PutMetricAlarmInput{
+		AlarmName:          aws.String(alarm.Name),
+		AlarmDescription:   aws.String(alarm.Description),
+		Namespace:          aws.String(alarm.Namespace),
+		MetricName:         aws.String(alarm.MetricName),
+		Statistic:          aws.String(alarm.Statistic),
+		Period:             aws.Int64(alarm.Period),
+		EvaluationPeriods:  aws.Int64(alarm.EvaluationPeriods),
+		Threshold:          aws.Float64(alarm.Threshold),
+		ComparisonOperator: aws.String(alarm.ComparisonOperator),
+		TreatMissingData:   aws.String(alarm.TreatMissingData),
+		ActionsEnabled:     aws.Bool(s.input.AlarmTopicArn != ""),
+	}
+	for _, name := range sortedKeys(alarm.Dimensions) {
+		input.Dimensions = append(input.Dimensions, &cloudwatch.Dimension{
+			Name:  aws.String(name),
+			Value: aws.String(alarm.Dimensions[name]),
+		})
+	}
+	if s.input.AlarmTopicArn != "" {
+		input.AlarmActions = aws.StringSlice([]string{s.input.AlarmTopicArn})
+		input.OKActions = aws.StringSlice([]string{s.input.AlarmTopicArn})
+	}
+
+	_, err := s.input.CloudWatch.PutMetricAlarm(input)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to create alarm %s", alarm.Name), err)
+	}
+	return nil
+}
+
+// dashboardBody graphs each alarm's metric, two to a row, with a line at
+// the alarm threshold
+func (s *Service) dashboardBody(alarms []*Alarm) ([]byte, error) {
+	const width, height = 12, 6
+
+	widgets := []map[string]interface{}{}
+	for i, alarm := range alarms {
+		metric := []interface{}{alarm.Namespace, alarm.MetricName}
+		for _, name := range sortedKeys(alarm.Dimensions) {
+			metric = append(metric, name, alarm.Dimensions[name])
+		}
+
+		widgets = append(widgets, map[string]interface{}{
+			"type":   "metric",
+			"x":      (i % 2) * width,
+			"y":      (i / 2) * height,
+			"width":  width,
+			"height": height,
+			"properties": map[string]interface{}{
+				"title":   alarm.Title,
+				"region":  s.input.Region,
+				"view":    "timeSeries",
+				"metrics": [][]interface{}{metric},
+				"stat":    alarm.Statistic,
+				"period":  alarm.Period,
+				"annotations": map[string]interface{}{
+					"horizontal": []map[string]interface{}{
+						{"label": alarm.Name, "value": alarm.Threshold},
+					},
+				},
+			},
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"widgets": widgets})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to create dashboard body", err)
+	}
+	return body, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewService creates a new monitoring service
+func NewService(input NewServiceInput) (*Service, error) {
+	if input.Enabled {
+		if input.APIName == "" || input.APIStageName == "" || input.ResetBuildName == "" || input.UpdateLeaseStatusLogGroup == "" {
+			return nil, errors.NewValidation("monitoring",
+				fmt.Errorf("the API name and stage, reset build name and update lease status log group are required"))
+		}
+	}
+
+	return &Service{
+		input: input,
+	}, nil
+}
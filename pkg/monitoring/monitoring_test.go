@@ -0,0 +1,133 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T, cw *awsmocks.CloudWatchAPI, cwl *awsmocks.CloudWatchLogsAPI) *Service {
+	svc, err := NewService(NewServiceInput{
+		Enabled:                   true,
+		Namespace:                 "test",
+		Region:                    "us-east-1",
+		AlarmTopicArn:             "arn:aws:sns:us-east-1:123456789012:alarms",
+		APIName:                   "dce-test",
+		APIStageName:              "api",
+		ResetBuildName:            "account-reset-test",
+		UpdateLeaseStatusLogGroup: "/aws/lambda/update_lease_status-test",
+		MinReadyAccounts:          10,
+		API5xxThreshold:           5,
+		ResetFailureThreshold:     1,
+		BudgetOverrunThreshold:    1,
+		CloudWatch:                cw,
+		CloudWatchLogs:            cwl,
+	})
+	assert.Nil(t, err)
+	return svc
+}
+
+func TestProvision(t *testing.T) {
+	cw := &awsmocks.CloudWatchAPI{}
+	cwl := &awsmocks.CloudWatchLogsAPI{}
+	svc := newTestService(t, cw, cwl)
+
+	cwl.On("PutMetricFilter", mock.Anything).Return(&cloudwatchlogs.PutMetricFilterOutput{}, nil)
+	cw.On("PutMetricAlarm", mock.Anything).Return(&cloudwatch.PutMetricAlarmOutput{}, nil)
+	cw.On("PutDashboard", mock.Anything).Return(&cloudwatch.PutDashboardOutput{}, nil)
+
+	err := svc.Provision()
+	assert.Nil(t, err)
+
+	cwl.AssertCalled(t, "PutMetricFilter", &cloudwatchlogs.PutMetricFilterInput{
+		LogGroupName:  aws.String("/aws/lambda/update_lease_status-test"),
+		FilterName:    aws.String("dce-budget-overruns-test"),
+		FilterPattern: aws.String(`"Budget.  Updating lease as ready to be reclaimed"`),
+		MetricTransformations: []*cloudwatchlogs.MetricTransformation{
+			{
+				MetricNamespace: aws.String("DCE/test/Leases"),
+				MetricName:      aws.String("OverBudgetLeases"),
+				MetricValue:     aws.String("1"),
+				DefaultValue:    aws.Float64(0),
+			},
+		},
+	})
+	cw.AssertNumberOfCalls(t, "PutMetricAlarm", 4)
+	cw.AssertCalled(t, "PutMetricAlarm", &cloudwatch.PutMetricAlarmInput{
+		AlarmName:        aws.String("dce-api-5xx-test"),
+		AlarmDescription: aws.String("The DCE API is returning server errors"),
+		Namespace:        aws.String("AWS/ApiGateway"),
+		MetricName:       aws.String("5XXError"),
+		Dimensions: []*cloudwatch.Dimension{
+			{Name: aws.String("ApiName"), Value: aws.String("dce-test")},
+			{Name: aws.String("Stage"), Value: aws.String("api")},
+		},
+		Statistic:          aws.String("Sum"),
+		Period:             aws.Int64(300),
+		EvaluationPeriods:  aws.Int64(1),
+		Threshold:          aws.Float64(5),
+		ComparisonOperator: aws.String("GreaterThanOrEqualToThreshold"),
+		TreatMissingData:   aws.String("notBreaching"),
+		ActionsEnabled:     aws.Bool(true),
+		AlarmActions:       aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:alarms"}),
+		OKActions:          aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:alarms"}),
+	})
+
+	dashboard := cw.Calls[len(cw.Calls)-1].Arguments.Get(0).(*cloudwatch.PutDashboardInput)
+	assert.Equal(t, "DCE-test-Alarms", *dashboard.DashboardName)
+	body := struct {
+		Widgets []struct {
+			X          int `json:"x"`
+			Y          int `json:"y"`
+			Properties struct {
+				Title   string          `json:"title"`
+				Metrics [][]interface{} `json:"metrics"`
+			} `json:"properties"`
+		} `json:"widgets"`
+	}{}
+	assert.Nil(t, json.Unmarshal([]byte(*dashboard.DashboardBody), &body))
+	assert.Len(t, body.Widgets, 4)
+	assert.Equal(t, "Failed Account Resets", body.Widgets[1].Properties.Title)
+	assert.Equal(t, 12, body.Widgets[1].X)
+	assert.Equal(t, 6, body.Widgets[2].Y)
+	assert.Equal(t, []interface{}{"AWS/CodeBuild", "FailedBuilds", "ProjectName", "account-reset-test"},
+		body.Widgets[1].Properties.Metrics[0])
+}
+
+func TestProvisionCreatesLogGroup(t *testing.T) {
+	cw := &awsmocks.CloudWatchAPI{}
+	cwl := &awsmocks.CloudWatchLogsAPI{}
+	svc := newTestService(t, cw, cwl)
+
+	notFound := awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "not found", nil)
+	cwl.On("PutMetricFilter", mock.Anything).Return(nil, notFound).Once()
+	cwl.On("CreateLogGroup", &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String("/aws/lambda/update_lease_status-test"),
+	}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwl.On("PutMetricFilter", mock.Anything).Return(&cloudwatchlogs.PutMetricFilterOutput{}, nil).Once()
+	cw.On("PutMetricAlarm", mock.Anything).Return(nil, fmt.Errorf("access denied"))
+
+	err := svc.Provision()
+
+	assert.EqualError(t, err, "failed to create alarm dce-pool-capacity-test")
+	cwl.AssertNumberOfCalls(t, "PutMetricFilter", 2)
+	cw.AssertNotCalled(t, "PutDashboard", mock.Anything)
+}
+
+func TestNewService(t *testing.T) {
+	svc, err := NewService(NewServiceInput{})
+	assert.Nil(t, err)
+	assert.False(t, svc.Enabled())
+	assert.Nil(t, svc.Provision())
+
+	_, err = NewService(NewServiceInput{Enabled: true, APIName: "dce-test"})
+	assert.EqualError(t, err, "monitoring validation error: the API name and stage, reset build name and update lease status log group are required")
+}
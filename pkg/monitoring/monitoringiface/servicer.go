@@ -0,0 +1,11 @@
+//
+
+package monitoringiface
+
+// Servicer creates and updates the standard DCE alarms and dashboard
+type Servicer interface {
+	// Enabled is true when the alarms and dashboard should be created
+	Enabled() bool
+	// Provision creates or updates the standard alarms and their dashboard
+	Provision() error
+}
@@ -0,0 +1,386 @@
+// Package notification renders the emails DCE sends about leases from
+// customizable templates, and sends them.
+//
+// Each template has a subject, HTML body and text body. Each part is
+// loaded from the first of:
+//   - the NOTIFICATION_TEMPLATES config, a JSON object of templates by
+//     "<template>/<part>", eg. {"LeaseCreated/subject": "Welcome to {{.Branding.Name}}"}
+//   - an S3 object at "<prefix>/<template>/<part>.tmpl", when the part is
+//     listed in NOTIFICATION_TEMPLATE_OBJECTS
+//   - the built-in default
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"log"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/pkg/email"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/caarlos0/env"
+)
+
+// Template is the name of an email template
+type Template string
+
+const (
+	// TemplateLeaseCreated is sent when a lease is created
+	TemplateLeaseCreated Template = "LeaseCreated"
+	// TemplateBudgetThreshold is sent when a lease passes a budget threshold
+	TemplateBudgetThreshold Template = "BudgetThreshold"
+	// TemplateCategoryBudgetThreshold is sent when a lease passes a threshold
+	// of one of its category budgets
+	TemplateCategoryBudgetThreshold Template = "CategoryBudgetThreshold"
+	// TemplateExpiryWarning is sent before a lease expires
+	TemplateExpiryWarning Template = "ExpiryWarning"
+	// TemplateLeaseEnded is sent when a lease ends
+	TemplateLeaseEnded Template = "LeaseEnded"
+	// TemplateStaleLease is sent when a lease is found unused
+	TemplateStaleLease Template = "StaleLease"
+	// TemplateQuarantineDigest is sent to operators when stuck accounts are
+	// quarantined
+	TemplateQuarantineDigest Template = "QuarantineDigest"
+	// TemplateWaitlistAllocated is sent when a lease request which was
+	// waiting for an account is leased one
+	TemplateWaitlistAllocated Template = "WaitlistAllocated"
+	// TemplatePrincipalOffboarded is sent to the manager of a principal who
+	// was deactivated in the directory, when their leases are ended
+	TemplatePrincipalOffboarded Template = "PrincipalOffboarded"
+	// TemplateLeaseDigest is sent daily or weekly to principals, summarizing
+	// their leases, and to admins, summarizing every lease
+	TemplateLeaseDigest Template = "LeaseDigest"
+	// TemplateSavingsReport is sent monthly to program owners, summarizing
+	// what the account pool saved versus the baseline
+	TemplateSavingsReport Template = "SavingsReport"
+)
+
+// Parts of an email template
+const (
+	PartSubject = "subject"
+	PartHTML    = "html"
+	PartText    = "text"
+)
+
+// Branding customizes the emails for a deployment
+type Branding struct {
+	// Name is the name of the service, eg. "Sandbox Accounts"
+	Name string
+	// LogoURL is the URL of an image shown at the top of HTML emails
+	LogoURL string
+	// SupportEmail is the address users can contact with questions
+	SupportEmail string
+	// Footer is text shown at the bottom of every email
+	Footer string
+}
+
+// Lease is the lease an email is about
+type Lease struct {
+	ID             string
+	AccountID      string
+	PrincipalID    string
+	Status         string
+	StatusReason   string
+	BudgetAmount   float64
+	BudgetCurrency string
+	ExpiresOn      time.Time
+	// Notes and Metadata are what the principal attached to the lease, eg.
+	// teardown instructions.  Only the text values of the metadata are kept.
+	Notes    string
+	Metadata map[string]string
+	// ActualSpend is the spend of the lease since its budget period began,
+	// set for lease digest emails
+	ActualSpend float64
+}
+
+// Account is an account an email is about
+type Account struct {
+	ID string
+	// Status is the status the account was stuck in
+	Status        string
+	RetriedOn     time.Time
+	QuarantinedOn time.Time
+}
+
+// Principal is the principal an email is about
+type Principal struct {
+	ID          string
+	DisplayName string
+	Email       string
+}
+
+// Savings is what the account pool saved in a period, versus how accounts
+// were managed before DCE
+type Savings struct {
+	StartDate                time.Time
+	EndDate                  time.Time
+	AccountsReclaimed        int
+	ReclaimedByReason        map[string]int
+	IdleSpendPrevented       float64
+	CostCurrency             string
+	LeasesProvisioned        int
+	AverageProvisioningHours float64
+	ProvisioningHoursSaved   float64
+	// BaselineProvisioningHours and BaselineAccountLifetimeDays are how long
+	// it took to get an account, and how long accounts were kept
+	BaselineProvisioningHours   float64
+	BaselineAccountLifetimeDays float64
+}
+
+// Data is the data available to the email templates
+type Data struct {
+	Lease Lease
+	// ActualSpend, IsOverBudget and ThresholdPercentile are set for budget
+	// threshold emails
+	ActualSpend         float64
+	IsOverBudget        bool
+	ThresholdPercentile int
+	// Category and CategoryBudgetAmount are set for category budget
+	// threshold emails, whose ActualSpend is the spend of the category
+	Category             string
+	CategoryBudgetAmount float64
+	// HoursRemaining and ExtensionURL are set for expiry warning emails.
+	// ExtensionURL is empty when the lease can't be extended.
+	HoursRemaining int
+	ExtensionURL   string
+	// IdleDays and EndsOn are set for stale lease emails.  EndsOn is zero
+	// when stale leases aren't ended.
+	IdleDays int
+	EndsOn   time.Time
+	// Accounts are set for quarantine digest emails
+	Accounts []Account
+	// WaitedSince is set for waitlist emails
+	WaitedSince time.Time
+	// Principal and Leases are set for principal offboarded emails
+	Principal Principal
+	Leases    []Lease
+	// Period, TotalSpend, ExpiringLeases and EndedLeases are set for lease
+	// digest emails, with the Active leases in Leases.  Principal is set for
+	// a principal's digest, and empty for the admins'.
+	Period         string
+	TotalSpend     float64
+	ExpiringLeases []Lease
+	EndedLeases    []Lease
+	// Savings is set for savings report emails
+	Savings Savings
+	// Branding is set by the service
+	Branding Branding
+}
+
+// Email is a rendered email
+type Email struct {
+	Subject  string
+	BodyHTML string
+	BodyText string
+}
+
+// NewServiceInput are the items needed to create a new notification service
+type NewServiceInput struct {
+	FromEmail string   `env:"NOTIFICATION_FROM_EMAIL" envDefault:""`
+	BCCEmails []string `env:"NOTIFICATION_BCC_EMAILS" envDefault:"" envSeparator:","`
+	// Templates is a JSON object of templates by "<template>/<part>"
+	Templates         string   `env:"NOTIFICATION_TEMPLATES" envDefault:""`
+	TemplatesBucket   string   `env:"NOTIFICATION_TEMPLATES_BUCKET" envDefault:""`
+	TemplatesPrefix   string   `env:"NOTIFICATION_TEMPLATES_PREFIX" envDefault:"notification_templates"`
+	TemplateObjects   []string `env:"NOTIFICATION_TEMPLATE_OBJECTS" envDefault:"" envSeparator:","`
+	BrandName         string   `env:"NOTIFICATION_BRAND_NAME" envDefault:"DCE"`
+	BrandLogoURL      string   `env:"NOTIFICATION_BRAND_LOGO_URL" envDefault:""`
+	BrandSupportEmail string   `env:"NOTIFICATION_BRAND_SUPPORT_EMAIL" envDefault:""`
+	BrandFooter       string   `env:"NOTIFICATION_BRAND_FOOTER" envDefault:""`
+	Storage           common.Storager
+	EmailSvc          email.Service
+}
+
+// Service renders and sends lease emails
+type Service struct {
+	storage         common.Storager
+	emailSvc        email.Service
+	fromEmail       string
+	bccEmails       []string
+	templates       map[string]string
+	templatesBucket string
+	templatesPrefix string
+	templateObjects map[string]bool
+	branding        Branding
+}
+
+// Render renders an email template
+func (s *Service) Render(name Template, data *Data) (*Email, error) {
+	d := *data
+	d.Branding = s.branding
+
+	subject, err := s.renderPart(name, PartSubject, d)
+	if err != nil {
+		return nil, err
+	}
+	bodyHTML, err := s.renderPart(name, PartHTML, d)
+	if err != nil {
+		return nil, err
+	}
+	bodyText, err := s.renderPart(name, PartText, d)
+	if err != nil {
+		return nil, err
+	}
+	return &Email{
+		Subject:  subject,
+		BodyHTML: bodyHTML,
+		BodyText: bodyText,
+	}, nil
+}
+
+// Send renders an email template and sends it to the addresses, and the
+// configured BCC addresses. The rendered email is returned, or nil if there
+// was no one to send it to.
+func (s *Service) Send(name Template, toAddresses []string, data *Data) (*Email, error) {
+	to := []string{}
+	for _, address := range toAddresses {
+		if address != "" {
+			to = append(to, address)
+		}
+	}
+	if len(to)+len(s.bccEmails) == 0 {
+		log.Printf("Skipping %s email for lease %s: no email addresses", name, data.Lease.ID)
+		return nil, nil
+	}
+	if s.emailSvc == nil || s.fromEmail == "" {
+		return nil, errors.NewValidation("notification", fmt.Errorf("a from email address is required to send %s emails", name))
+	}
+
+	rendered, err := s.Render(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.emailSvc.SendEmail(&email.SendEmailInput{
+		FromAddress:  s.fromEmail,
+		ToAddresses:  to,
+		BCCAddresses: s.bccEmails,
+		Subject:      rendered.Subject,
+		BodyHTML:     rendered.BodyHTML,
+		BodyText:     rendered.BodyText,
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to send %s email", name), err)
+	}
+	return rendered, nil
+}
+
+func (s *Service) renderPart(name Template, part string, data Data) (string, error) {
+	source, err := s.source(name, part)
+	if err != nil {
+		return "", err
+	}
+
+	id := string(name) + "/" + part
+	buf := &bytes.Buffer{}
+	if part == PartHTML {
+		var tmpl *htmlTemplate.Template
+		tmpl, err = htmlTemplate.New(id).Parse(source)
+		if err == nil {
+			err = tmpl.Execute(buf, data)
+		}
+	} else {
+		var tmpl *textTemplate.Template
+		tmpl, err = textTemplate.New(id).Parse(source)
+		if err == nil {
+			err = tmpl.Execute(buf, data)
+		}
+	}
+	if err != nil {
+		return "", errors.NewInternalServer(fmt.Sprintf("failed to render %s email template", id), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// source gets the template for a part, from config, S3 or the defaults
+func (s *Service) source(name Template, part string) (string, error) {
+	id := string(name) + "/" + part
+	if source, ok := s.templates[id]; ok {
+		return source, nil
+	}
+
+	if s.templateObjects[id] {
+		key := id + ".tmpl"
+		if s.templatesPrefix != "" {
+			key = s.templatesPrefix + "/" + key
+		}
+		source, err := s.storage.GetObject(s.templatesBucket, key)
+		if err != nil {
+			return "", errors.NewInternalServer(fmt.Sprintf("failed to load email template s3://%s/%s", s.templatesBucket, key), err)
+		}
+		// Templates don't change while the lambda is running
+		s.templates[id] = source
+		return source, nil
+	}
+
+	source, ok := defaultTemplates[id]
+	if !ok {
+		return "", errors.NewValidation("notification", fmt.Errorf("unknown email template %q", id))
+	}
+	return source, nil
+}
+
+// NewService creates a new notification service
+func NewService(input NewServiceInput) (*Service, error) {
+	templates := map[string]string{}
+	if input.Templates != "" {
+		err := json.Unmarshal([]byte(input.Templates), &templates)
+		if err != nil {
+			return nil, errors.NewValidation("notification", fmt.Errorf("invalid templates: %s", err))
+		}
+	}
+
+	templateObjects := map[string]bool{}
+	for _, id := range input.TemplateObjects {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			templateObjects[id] = true
+		}
+	}
+	if len(templateObjects) > 0 && (input.Storage == nil || input.TemplatesBucket == "") {
+		return nil, errors.NewValidation("notification", fmt.Errorf("a templates bucket is required to load templates from S3"))
+	}
+
+	bccEmails := []string{}
+	for _, address := range input.BCCEmails {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			bccEmails = append(bccEmails, address)
+		}
+	}
+
+	return &Service{
+		storage:         input.Storage,
+		emailSvc:        input.EmailSvc,
+		fromEmail:       input.FromEmail,
+		bccEmails:       bccEmails,
+		templates:       templates,
+		templatesBucket: input.TemplatesBucket,
+		templatesPrefix: strings.Trim(input.TemplatesPrefix, "/"),
+		templateObjects: templateObjects,
+		branding: Branding{
+			Name:         input.BrandName,
+			LogoURL:      input.BrandLogoURL,
+			SupportEmail: input.BrandSupportEmail,
+			Footer:       input.BrandFooter,
+		},
+	}, nil
+}
+
+// NewFromEnv creates a new notification service from environment variables,
+// using the storage and email services
+func NewFromEnv(storage common.Storager, emailSvc email.Service) (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	input.Storage = storage
+	input.EmailSvc = emailSvc
+	return NewService(input)
+}
@@ -0,0 +1,452 @@
+package notification
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	commonMocks "github.com/Optum/dce/pkg/common/mocks"
+	"github.com/Optum/dce/pkg/email"
+	emailMocks "github.com/Optum/dce/pkg/email/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testLease = Lease{
+	ID:             "abc",
+	AccountID:      "123456789012",
+	PrincipalID:    "jdoe",
+	BudgetAmount:   100,
+	BudgetCurrency: "USD",
+	ExpiresOn:      time.Date(2020, 3, 4, 12, 30, 0, 0, time.UTC),
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    NewServiceInput
+		objects  map[string]string
+		template Template
+		data     *Data
+		expEmail *Email
+		expErr   string
+	}{
+		{
+			name:     "should render the default templates",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateLeaseCreated,
+			data:     &Data{Lease: testLease},
+			expEmail: &Email{
+				Subject: "DCE lease created [123456789012]",
+				BodyHTML: "<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"has been created, with a budget of 100 USD.\n" +
+					"The lease expires on March 4, 2020 12:30 UTC.\n</p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"has been created, with a budget of 100 USD.\n" +
+					"The lease expires on March 4, 2020 12:30 UTC.",
+			},
+		},
+		{
+			name: "should render the branding",
+			input: NewServiceInput{
+				BrandName:         "Sandboxes",
+				BrandLogoURL:      "https://example.com/logo.png",
+				BrandSupportEmail: "help@example.com",
+				BrandFooter:       "Acme Cloud Team",
+			},
+			template: TemplateLeaseEnded,
+			data: &Data{Lease: Lease{
+				AccountID:    "123456789012",
+				PrincipalID:  "jdoe",
+				StatusReason: "Expired",
+			}},
+			expEmail: &Email{
+				Subject: "Sandboxes lease ended [123456789012]",
+				BodyHTML: "<p><img src=\"https://example.com/logo.png\" alt=\"Sandboxes\"></p>\n" +
+					"<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"has ended (Expired).\n" +
+					"The account will be reset, and any resources in it deleted.\n</p>\n" +
+					"<p>Acme Cloud Team</p>\n" +
+					"<p>Questions? Contact <a href=\"mailto:help@example.com\">help@example.com</a></p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"has ended (Expired).\n" +
+					"The account will be reset, and any resources in it deleted.\n\n" +
+					"Acme Cloud Team\n\n" +
+					"Questions? Contact help@example.com",
+			},
+		},
+		{
+			name:     "should render the notes and metadata of ended leases",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateLeaseEnded,
+			data: &Data{Lease: Lease{
+				AccountID:   "123456789012",
+				PrincipalID: "jdoe",
+				Notes:       "Snapshot the database first",
+				Metadata: map[string]string{
+					"purpose": "load testing",
+					"ticket":  "OPS-123",
+				},
+			}},
+			expEmail: &Email{
+				Subject: "DCE lease ended [123456789012]",
+				BodyHTML: "<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"has ended.\n" +
+					"The account will be reset, and any resources in it deleted.\n\n" +
+					"Notes: Snapshot the database first\n" +
+					"purpose: load testing\n" +
+					"ticket: OPS-123\n</p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"has ended.\n" +
+					"The account will be reset, and any resources in it deleted.\n\n" +
+					"Notes: Snapshot the database first\n" +
+					"purpose: load testing\n" +
+					"ticket: OPS-123",
+			},
+		},
+		{
+			name:     "should render the expiry warning with an extension link",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateExpiryWarning,
+			data:     &Data{Lease: testLease, HoursRemaining: 24, ExtensionURL: "https://example.com/leases/abc/extend?days=7&x=1"},
+			expEmail: &Email{
+				Subject: "DCE lease expiring [123456789012]",
+				BodyHTML: "<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"expires on March 4, 2020 12:30 UTC, in 24 hours.\n" +
+					"Any resources in the account will be deleted after the lease ends.\n</p>\n" +
+					"<p><a href=\"https://example.com/leases/abc/extend?days=7&amp;x=1\">Extend the lease</a></p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"expires on March 4, 2020 12:30 UTC, in 24 hours.\n" +
+					"Any resources in the account will be deleted after the lease ends.\n\n" +
+					"Extend the lease: https://example.com/leases/abc/extend?days=7&x=1",
+			},
+		},
+		{
+			name:     "should render the expiry warning without an extension link",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateExpiryWarning,
+			data:     &Data{Lease: testLease, HoursRemaining: 1},
+			expEmail: &Email{
+				Subject: "DCE lease expiring [123456789012]",
+				BodyHTML: "<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"expires on March 4, 2020 12:30 UTC, in 1 hour.\n" +
+					"Any resources in the account will be deleted after the lease ends.\n</p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"expires on March 4, 2020 12:30 UTC, in 1 hour.\n" +
+					"Any resources in the account will be deleted after the lease ends.",
+			},
+		},
+		{
+			name:     "should render the stale lease email",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateStaleLease,
+			data:     &Data{Lease: testLease, IdleDays: 7, EndsOn: time.Date(2020, 3, 4, 12, 30, 0, 0, time.UTC)},
+			expEmail: &Email{
+				Subject: "DCE lease unused [123456789012]",
+				BodyHTML: "<p>\nThe lease for principal jdoe in AWS Account 123456789012\n" +
+					"hasn't been used in 7 days.\n" +
+					"Unless the account is used, the lease will end on March 4, 2020 12:30 UTC,\n" +
+					"and any resources in the account will be deleted.\n</p>",
+				BodyText: "The lease for principal jdoe in AWS Account 123456789012\n" +
+					"hasn't been used in 7 days.\n" +
+					"Unless the account is used, the lease will end on March 4, 2020 12:30 UTC,\n" +
+					"and any resources in the account will be deleted.",
+			},
+		},
+		{
+			name:     "should render the quarantine digest",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateQuarantineDigest,
+			data: &Data{Accounts: []Account{
+				{ID: "123456789012", Status: "NotReady", RetriedOn: time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)},
+				{ID: "210987654321", Status: "Unreachable", RetriedOn: time.Date(2020, 3, 2, 8, 0, 0, 0, time.UTC)},
+			}},
+			expEmail: &Email{
+				Subject: "DCE accounts quarantined (2)",
+				BodyHTML: "<p>\n2 AWS Accounts were stuck after their remediation was retried, and quarantined.\n" +
+					"Quarantined accounts aren't reset or leased again, and can only be deleted from the account pool.\n</p>\n" +
+					"<ul>\n<li>123456789012: NotReady, retried on March 1, 2020 12:30 UTC</li>\n" +
+					"<li>210987654321: Unreachable, retried on March 2, 2020 08:00 UTC</li>\n</ul>",
+				BodyText: "2 AWS Accounts were stuck after their remediation was retried, and quarantined.\n" +
+					"Quarantined accounts aren't reset or leased again, and can only be deleted from the account pool.\n\n" +
+					"- 123456789012: NotReady, retried on March 1, 2020 12:30 UTC\n" +
+					"- 210987654321: Unreachable, retried on March 2, 2020 08:00 UTC",
+			},
+		},
+		{
+			name:     "should render the waitlist allocated email",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateWaitlistAllocated,
+			data:     &Data{Lease: testLease, WaitedSince: time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC)},
+			expEmail: &Email{
+				Subject: "DCE lease ready [123456789012]",
+				BodyHTML: "<p>\nAn AWS Account is now available for principal jdoe,\n" +
+					"who has been waiting since March 1, 2020 09:00 UTC.\n" +
+					"The lease for principal jdoe in AWS Account 123456789012\n" +
+					"has been created, with a budget of 100 USD.\n" +
+					"The lease expires on March 4, 2020 12:30 UTC.\n</p>",
+				BodyText: "An AWS Account is now available for principal jdoe,\n" +
+					"who has been waiting since March 1, 2020 09:00 UTC.\n" +
+					"The lease for principal jdoe in AWS Account 123456789012\n" +
+					"has been created, with a budget of 100 USD.\n" +
+					"The lease expires on March 4, 2020 12:30 UTC.",
+			},
+		},
+		{
+			name:     "should render the category budget threshold email",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateCategoryBudgetThreshold,
+			data:     &Data{Lease: testLease, Category: "ai", CategoryBudgetAmount: 40, ActualSpend: 30, ThresholdPercentile: 75},
+			expEmail: &Email{
+				Subject: "DCE lease at 75% of ai budget [123456789012]",
+				BodyHTML: "<p>\n\nLease for principal jdoe in AWS Account 123456789012\n" +
+					"has exceeded the 75% threshold limit for its ai budget of $40.\n" +
+					"Actual ai spend is $30\n\n</p>",
+				BodyText: "Lease for principal jdoe in AWS Account 123456789012\n" +
+					"has exceeded the 75% threshold limit for its ai budget of $40.\n" +
+					"Actual ai spend is $30",
+			},
+		},
+		{
+			name:     "should render the principal offboarded email",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplatePrincipalOffboarded,
+			data: &Data{
+				Principal: Principal{ID: "jdoe", DisplayName: "Jane Doe"},
+				Leases:    []Lease{testLease, {ID: "def", AccountID: "210987654321", Notes: "Keep the S3 data"}},
+			},
+			expEmail: &Email{
+				Subject: "DCE leases ended for deactivated principal jdoe",
+				BodyHTML: "<p>\nPrincipal jdoe (Jane Doe) has been deactivated in the directory.\n" +
+					"Their active leases have been ended, and the credentials issued for them revoked.\n" +
+					"The accounts will be reset, and any resources in them deleted.\n</p>\n" +
+					"<ul>\n<li>123456789012: lease abc</li>\n" +
+					"<li>210987654321: lease def, notes: Keep the S3 data</li>\n</ul>",
+				BodyText: "Principal jdoe (Jane Doe) has been deactivated in the directory.\n" +
+					"Their active leases have been ended, and the credentials issued for them revoked.\n" +
+					"The accounts will be reset, and any resources in them deleted.\n\n" +
+					"- 123456789012: lease abc\n" +
+					"- 210987654321: lease def, notes: Keep the S3 data",
+			},
+		},
+		{
+			name:     "should render the principal's lease digest",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateLeaseDigest,
+			data: &Data{
+				Principal:      Principal{ID: "jdoe"},
+				Period:         "weekly",
+				Leases:         []Lease{{AccountID: "123456789012", PrincipalID: "jdoe", BudgetAmount: 100, BudgetCurrency: "USD", ActualSpend: 12.5}},
+				TotalSpend:     12.5,
+				ExpiringLeases: []Lease{testLease},
+			},
+			expEmail: &Email{
+				Subject: "DCE weekly lease digest for jdoe",
+				BodyHTML: "<p>\nYour weekly summary of the leases of principal jdoe.\n" +
+					"1 active lease spent $12.50 of its budget.\n</p>\n" +
+					"<p>Active leases:</p>\n<ul>\n<li>123456789012: spent $12.50 of 100 USD</li>\n</ul>\n" +
+					"<p>Expiring in the next week:</p>\n<ul>\n<li>123456789012: expires on March 4, 2020 12:30 UTC</li>\n</ul>\n" +
+					"<p>Reset in the last week:</p>\n<ul>\n<li>None</li>\n</ul>",
+				BodyText: "Your weekly summary of the leases of principal jdoe.\n" +
+					"1 active lease spent $12.50 of its budget.\n\n" +
+					"Active leases:\n- 123456789012: spent $12.50 of 100 USD\n\n" +
+					"Expiring in the next week:\n- 123456789012: expires on March 4, 2020 12:30 UTC\n\n" +
+					"Reset in the last week:\n- None",
+			},
+		},
+		{
+			name:     "should render the admins' lease digest",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateLeaseDigest,
+			data: &Data{
+				Period:      "daily",
+				EndedLeases: []Lease{{ID: "def", AccountID: "210987654321", PrincipalID: "asmith", StatusReason: "Expired"}},
+			},
+			expEmail: &Email{
+				Subject: "DCE daily lease digest",
+				BodyHTML: "<p>\nThe daily summary of every lease.\n" +
+					"0 active leases spent $0.00 of their budgets.\n</p>\n" +
+					"<p>Active leases:</p>\n<ul>\n<li>None</li>\n</ul>\n" +
+					"<p>Expiring in the next day:</p>\n<ul>\n<li>None</li>\n</ul>\n" +
+					"<p>Reset in the last day:</p>\n<ul>\n<li>210987654321 (asmith): lease def ended (Expired)</li>\n</ul>",
+				BodyText: "The daily summary of every lease.\n" +
+					"0 active leases spent $0.00 of their budgets.\n\n" +
+					"Active leases:\n- None\n\n" +
+					"Expiring in the next day:\n- None\n\n" +
+					"Reset in the last day:\n- 210987654321 (asmith): lease def ended (Expired)",
+			},
+		},
+		{
+			name:     "should render the savings report",
+			input:    NewServiceInput{BrandName: "DCE"},
+			template: TemplateSavingsReport,
+			data: &Data{
+				Savings: Savings{
+					StartDate:                   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:                     time.Date(2020, 3, 31, 23, 59, 59, 0, time.UTC),
+					AccountsReclaimed:           3,
+					ReclaimedByReason:           map[string]int{"Stale": 1, "Expired": 2},
+					IdleSpendPrevented:          1250.5,
+					CostCurrency:                "USD",
+					LeasesProvisioned:           4,
+					AverageProvisioningHours:    1.5,
+					ProvisioningHoursSaved:      282,
+					BaselineProvisioningHours:   72,
+					BaselineAccountLifetimeDays: 90,
+				},
+			},
+			expEmail: &Email{
+				Subject: "DCE savings report for March 1, 2020 to March 31, 2020",
+				BodyHTML: "<p>\nSavings of the account pool from March 1, 2020 to March 31, 2020,\n" +
+					"versus a baseline of 72 hours to get an account, which was kept for 90 days.\n</p>\n" +
+					"<ul>\n<li>Accounts reclaimed: 3, Expired: 2, Stale: 1</li>\n" +
+					"<li>Idle spend prevented: 1250.50 USD</li>\n" +
+					"<li>Average time to provision: 1.5 hours over 4 leases, saving 282 hours</li>\n</ul>",
+				BodyText: "Savings of the account pool from March 1, 2020 to March 31, 2020,\n" +
+					"versus a baseline of 72 hours to get an account, which was kept for 90 days.\n\n" +
+					"- Accounts reclaimed: 3, Expired: 2, Stale: 1\n" +
+					"- Idle spend prevented: 1250.50 USD\n" +
+					"- Average time to provision: 1.5 hours over 4 leases, saving 282 hours",
+			},
+		},
+		{
+			name: "should render templates from config and S3",
+			input: NewServiceInput{
+				BrandName:       "DCE",
+				Templates:       `{"BudgetThreshold/subject": "{{.Branding.Name}}: {{.ThresholdPercentile}}% of budget"}`,
+				TemplatesBucket: "artifacts",
+				TemplatesPrefix: "templates/",
+				TemplateObjects: []string{"BudgetThreshold/html", "BudgetThreshold/text"},
+			},
+			objects: map[string]string{
+				"templates/BudgetThreshold/html.tmpl": "<b>{{.Lease.PrincipalID}}</b> spent ${{.ActualSpend}}",
+				"templates/BudgetThreshold/text.tmpl": "{{.Lease.PrincipalID}} spent ${{.ActualSpend}}",
+			},
+			template: TemplateBudgetThreshold,
+			data:     &Data{Lease: Lease{PrincipalID: "j&doe"}, ActualSpend: 75, ThresholdPercentile: 75},
+			expEmail: &Email{
+				Subject:  "DCE: 75% of budget",
+				BodyHTML: "<b>j&amp;doe</b> spent $75",
+				BodyText: "j&doe spent $75",
+			},
+		},
+		{
+			name:     "should fail on an invalid template",
+			input:    NewServiceInput{Templates: `{"ExpiryWarning/subject": "{{.Lease.Nope}}"}`},
+			template: TemplateExpiryWarning,
+			data:     &Data{Lease: testLease},
+			expErr:   "failed to render ExpiryWarning/subject email template",
+		},
+		{
+			name:     "should fail on an unknown template",
+			template: Template("Nope"),
+			data:     &Data{Lease: testLease},
+			expErr:   `notification validation error: unknown email template "Nope/subject"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &commonMocks.Storager{}
+			for key, object := range tt.objects {
+				storage.On("GetObject", "artifacts", key).Return(object, nil).Once()
+			}
+			tt.input.Storage = storage
+
+			svc, err := NewService(tt.input)
+			assert.Nil(t, err)
+
+			rendered, err := svc.Render(tt.template, tt.data)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expEmail, rendered)
+
+			// S3 templates are only loaded once
+			_, err = svc.Render(tt.template, tt.data)
+			assert.Nil(t, err)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    NewServiceInput
+		to       []string
+		sendErr  error
+		expInput *email.SendEmailInput
+		expErr   string
+	}{
+		{
+			name: "should send the email",
+			input: NewServiceInput{
+				FromEmail: "dce@example.com",
+				BCCEmails: []string{"audit@example.com", " "},
+				Templates: `{"LeaseCreated/subject": "Subject", "LeaseCreated/html": "HTML", "LeaseCreated/text": "Text"}`,
+			},
+			to: []string{"jdoe@example.com", ""},
+			expInput: &email.SendEmailInput{
+				FromAddress:  "dce@example.com",
+				ToAddresses:  []string{"jdoe@example.com"},
+				BCCAddresses: []string{"audit@example.com"},
+				Subject:      "Subject",
+				BodyHTML:     "HTML",
+				BodyText:     "Text",
+			},
+		},
+		{
+			name:  "should skip emails without addresses",
+			input: NewServiceInput{FromEmail: "dce@example.com"},
+			to:    []string{},
+		},
+		{
+			name:   "should require a from address",
+			to:     []string{"jdoe@example.com"},
+			expErr: "notification validation error: a from email address is required to send LeaseCreated emails",
+		},
+		{
+			name:    "should fail when SES fails",
+			input:   NewServiceInput{FromEmail: "dce@example.com"},
+			to:      []string{"jdoe@example.com"},
+			sendErr: fmt.Errorf("throttled"),
+			expErr:  "failed to send LeaseCreated email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailSvc := &emailMocks.Service{}
+			emailSvc.On("SendEmail", mock.Anything).Return(tt.sendErr)
+			tt.input.EmailSvc = emailSvc
+
+			svc, err := NewService(tt.input)
+			assert.Nil(t, err)
+
+			rendered, err := svc.Send(TemplateLeaseCreated, tt.to, &Data{Lease: testLease})
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			if tt.expInput == nil {
+				assert.Nil(t, rendered)
+				emailSvc.AssertNotCalled(t, "SendEmail", mock.Anything)
+				return
+			}
+			emailSvc.AssertCalled(t, "SendEmail", tt.expInput)
+			assert.Equal(t, tt.expInput.Subject, rendered.Subject)
+		})
+	}
+}
+
+func TestNewService(t *testing.T) {
+	_, err := NewService(NewServiceInput{Templates: "{"})
+	assert.EqualError(t, err, "notification validation error: invalid templates: unexpected end of JSON input")
+
+	_, err = NewService(NewServiceInput{TemplateObjects: []string{"LeaseCreated/html"}})
+	assert.EqualError(t, err, "notification validation error: a templates bucket is required to load templates from S3")
+}
@@ -0,0 +1,15 @@
+//
+
+package notificationiface
+
+import (
+	"github.com/Optum/dce/pkg/notification"
+)
+
+// Servicer renders and sends lease emails
+type Servicer interface {
+	// Render renders an email template
+	Render(name notification.Template, data *notification.Data) (*notification.Email, error)
+	// Send renders an email template and sends it
+	Send(name notification.Template, toAddresses []string, data *notification.Data) (*notification.Email, error)
+}
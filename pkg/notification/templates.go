@@ -0,0 +1,180 @@
+package notification
+
+// Branding shared by the default templates
+const (
+	htmlHeader = `{{with .Branding.LogoURL}}<p><img src="{{.}}" alt="{{$.Branding.Name}}"></p>{{end}}
+`
+	htmlFooter = `
+{{with .Branding.Footer}}<p>{{.}}</p>{{end}}
+{{with .Branding.SupportEmail}}<p>Questions? Contact <a href="mailto:{{.}}">{{.}}</a></p>{{end}}`
+	textFooter = `{{with .Branding.Footer}}
+{{.}}
+{{end}}{{with .Branding.SupportEmail}}
+Questions? Contact {{.}}
+{{end}}`
+	expiresOnFormat = `{{.Lease.ExpiresOn.Format "January 2, 2006 15:04 MST"}}`
+)
+
+const (
+	leaseCreatedBody = `The lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has been created, with a budget of {{.Lease.BudgetAmount}} {{.Lease.BudgetCurrency}}.
+The lease expires on ` + expiresOnFormat + `.
+`
+	budgetThresholdBody = `{{if .IsOverBudget}}
+Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has exceeded its budget of ${{.Lease.BudgetAmount}}. Actual spend is ${{.ActualSpend}}
+{{else}}
+Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has exceeded the {{.ThresholdPercentile}}% threshold limit for its budget of ${{.Lease.BudgetAmount}}.
+Actual spend is ${{.ActualSpend}}
+{{end}}`
+	categoryBudgetThresholdBody = `{{if .IsOverBudget}}
+Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has exceeded its {{.Category}} budget of ${{.CategoryBudgetAmount}}. Actual {{.Category}} spend is ${{.ActualSpend}}
+{{else}}
+Lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has exceeded the {{.ThresholdPercentile}}% threshold limit for its {{.Category}} budget of ${{.CategoryBudgetAmount}}.
+Actual {{.Category}} spend is ${{.ActualSpend}}
+{{end}}`
+	expiryWarningBody = `The lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+expires on ` + expiresOnFormat + `{{if .HoursRemaining}}, in {{.HoursRemaining}} {{if eq .HoursRemaining 1}}hour{{else}}hours{{end}}{{end}}.
+Any resources in the account will be deleted after the lease ends.
+`
+	extensionLinkHTML = `{{with .ExtensionURL}}<p><a href="{{.}}">Extend the lease</a></p>
+{{end}}`
+	extensionLinkText = `{{with .ExtensionURL}}
+Extend the lease: {{.}}
+{{end}}`
+	staleLeaseBody = `The lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+hasn't been used in {{.IdleDays}} days.
+{{if .EndsOn.IsZero}}Please end the lease if you no longer need the account, so others can use it.
+{{else}}Unless the account is used, the lease will end on {{.EndsOn.Format "January 2, 2006 15:04 MST"}},
+and any resources in the account will be deleted.
+{{end}}`
+	quarantineDigestBody = `{{len .Accounts}} AWS {{if eq (len .Accounts) 1}}Account was{{else}}Accounts were{{end}} stuck after {{if eq (len .Accounts) 1}}its{{else}}their{{end}} remediation was retried, and quarantined.
+Quarantined accounts aren't reset or leased again, and can only be deleted from the account pool.
+`
+	quarantineDigestListHTML = `<ul>
+{{range .Accounts}}<li>{{.ID}}: {{.Status}}, retried on {{.RetriedOn.Format "January 2, 2006 15:04 MST"}}</li>
+{{end}}</ul>`
+	quarantineDigestListText = `{{range .Accounts}}
+- {{.ID}}: {{.Status}}, retried on {{.RetriedOn.Format "January 2, 2006 15:04 MST"}}{{end}}
+`
+	waitlistAllocatedBody = `An AWS Account is now available for principal {{.Lease.PrincipalID}},
+who has been waiting since {{.WaitedSince.Format "January 2, 2006 15:04 MST"}}.
+` + leaseCreatedBody
+	leaseEndedBody = `The lease for principal {{.Lease.PrincipalID}} in AWS Account {{.Lease.AccountID}}
+has ended{{with .Lease.StatusReason}} ({{.}}){{end}}.
+The account will be reset, and any resources in it deleted.
+` + leaseNotesBody
+	principalOffboardedBody = `Principal {{.Principal.ID}}{{with .Principal.DisplayName}} ({{.}}){{end}} has been deactivated in the directory.
+Their active leases have been ended, and the credentials issued for them revoked.
+The accounts will be reset, and any resources in them deleted.
+`
+	principalOffboardedListHTML = `<ul>
+{{range .Leases}}<li>{{.AccountID}}: lease {{.ID}}{{with .Notes}}, notes: {{.}}{{end}}</li>
+{{end}}</ul>`
+	principalOffboardedListText = `{{range .Leases}}
+- {{.AccountID}}: lease {{.ID}}{{with .Notes}}, notes: {{.}}{{end}}{{end}}
+`
+	leaseDigestBody = `{{if .Principal.ID}}Your {{.Period}} summary of the leases of principal {{.Principal.ID}}.
+{{else}}The {{.Period}} summary of every lease.
+{{end}}{{len .Leases}} active {{if eq (len .Leases) 1}}lease{{else}}leases{{end}} spent ${{printf "%.2f" .TotalSpend}} of {{if eq (len .Leases) 1}}its budget{{else}}their budgets{{end}}.
+`
+	digestLeaseLine     = `{{.AccountID}}{{if not $.Principal.ID}} ({{.PrincipalID}}){{end}}: `
+	leaseDigestListHTML = `<p>Active leases:</p>
+<ul>
+{{range .Leases}}<li>` + digestLeaseLine + `spent ${{printf "%.2f" .ActualSpend}} of {{.BudgetAmount}} {{.BudgetCurrency}}</li>
+{{else}}<li>None</li>
+{{end}}</ul>
+<p>Expiring in the next {{if eq .Period "daily"}}day{{else}}week{{end}}:</p>
+<ul>
+{{range .ExpiringLeases}}<li>` + digestLeaseLine + `expires on {{.ExpiresOn.Format "January 2, 2006 15:04 MST"}}</li>
+{{else}}<li>None</li>
+{{end}}</ul>
+<p>Reset in the last {{if eq .Period "daily"}}day{{else}}week{{end}}:</p>
+<ul>
+{{range .EndedLeases}}<li>` + digestLeaseLine + `lease {{.ID}} ended{{with .StatusReason}} ({{.}}){{end}}</li>
+{{else}}<li>None</li>
+{{end}}</ul>`
+	leaseDigestListText = `
+Active leases:{{range .Leases}}
+- ` + digestLeaseLine + `spent ${{printf "%.2f" .ActualSpend}} of {{.BudgetAmount}} {{.BudgetCurrency}}{{else}}
+- None{{end}}
+
+Expiring in the next {{if eq .Period "daily"}}day{{else}}week{{end}}:{{range .ExpiringLeases}}
+- ` + digestLeaseLine + `expires on {{.ExpiresOn.Format "January 2, 2006 15:04 MST"}}{{else}}
+- None{{end}}
+
+Reset in the last {{if eq .Period "daily"}}day{{else}}week{{end}}:{{range .EndedLeases}}
+- ` + digestLeaseLine + `lease {{.ID}} ended{{with .StatusReason}} ({{.}}){{end}}{{else}}
+- None{{end}}
+`
+	savingsReportBody = `Savings of the account pool from {{.Savings.StartDate.Format "January 2, 2006"}} to {{.Savings.EndDate.Format "January 2, 2006"}},
+versus a baseline of {{.Savings.BaselineProvisioningHours}} hours to get an account, which was kept for {{.Savings.BaselineAccountLifetimeDays}} days.
+`
+	savingsReportReclaimed = `Accounts reclaimed: {{.Savings.AccountsReclaimed}}{{range $reason, $count := .Savings.ReclaimedByReason}}, {{$reason}}: {{$count}}{{end}}`
+	savingsReportIdleSpend = `Idle spend prevented: {{printf "%.2f" .Savings.IdleSpendPrevented}} {{.Savings.CostCurrency}}`
+	savingsReportProvision = `Average time to provision: {{if .Savings.LeasesProvisioned}}{{.Savings.AverageProvisioningHours}} hours over {{.Savings.LeasesProvisioned}} {{if eq .Savings.LeasesProvisioned 1}}lease{{else}}leases{{end}}, saving {{.Savings.ProvisioningHoursSaved}} hours{{else}}no leases provisioned{{end}}`
+	savingsReportListHTML  = `<ul>
+<li>` + savingsReportReclaimed + `</li>
+<li>` + savingsReportIdleSpend + `</li>
+<li>` + savingsReportProvision + `</li>
+</ul>`
+	savingsReportListText = `
+- ` + savingsReportReclaimed + `
+- ` + savingsReportIdleSpend + `
+- ` + savingsReportProvision + `
+`
+	leaseNotesBody = `{{with .Lease.Notes}}
+Notes: {{.}}
+{{end}}{{range $key, $value := .Lease.Metadata}}{{$key}}: {{$value}}
+{{end}}`
+)
+
+// defaultTemplates are the built-in templates, by "<template>/<part>"
+var defaultTemplates = map[string]string{
+	"LeaseCreated/subject": `{{.Branding.Name}} lease created [{{.Lease.AccountID}}]`,
+	"LeaseCreated/html":    htmlHeader + "<p>\n" + leaseCreatedBody + "</p>" + htmlFooter,
+	"LeaseCreated/text":    leaseCreatedBody + textFooter,
+
+	"BudgetThreshold/subject": `{{.Branding.Name}} lease {{if .IsOverBudget}}over budget{{else}}at {{.ThresholdPercentile}}% of budget{{end}} [{{.Lease.AccountID}}]`,
+	"BudgetThreshold/html":    htmlHeader + "<p>\n" + budgetThresholdBody + "\n</p>" + htmlFooter,
+	"BudgetThreshold/text":    budgetThresholdBody + textFooter,
+
+	"CategoryBudgetThreshold/subject": `{{.Branding.Name}} lease {{if .IsOverBudget}}over {{.Category}} budget{{else}}at {{.ThresholdPercentile}}% of {{.Category}} budget{{end}} [{{.Lease.AccountID}}]`,
+	"CategoryBudgetThreshold/html":    htmlHeader + "<p>\n" + categoryBudgetThresholdBody + "\n</p>" + htmlFooter,
+	"CategoryBudgetThreshold/text":    categoryBudgetThresholdBody + textFooter,
+
+	"ExpiryWarning/subject": `{{.Branding.Name}} lease expiring [{{.Lease.AccountID}}]`,
+	"ExpiryWarning/html":    htmlHeader + "<p>\n" + expiryWarningBody + "</p>\n" + extensionLinkHTML + htmlFooter,
+	"ExpiryWarning/text":    expiryWarningBody + extensionLinkText + textFooter,
+
+	"LeaseEnded/subject": `{{.Branding.Name}} lease ended [{{.Lease.AccountID}}]`,
+	"LeaseEnded/html":    htmlHeader + "<p>\n" + leaseEndedBody + "</p>" + htmlFooter,
+	"LeaseEnded/text":    leaseEndedBody + textFooter,
+
+	"StaleLease/subject": `{{.Branding.Name}} lease unused [{{.Lease.AccountID}}]`,
+	"StaleLease/html":    htmlHeader + "<p>\n" + staleLeaseBody + "</p>" + htmlFooter,
+	"StaleLease/text":    staleLeaseBody + textFooter,
+
+	"QuarantineDigest/subject": `{{.Branding.Name}} accounts quarantined ({{len .Accounts}})`,
+	"QuarantineDigest/html":    htmlHeader + "<p>\n" + quarantineDigestBody + "</p>\n" + quarantineDigestListHTML + htmlFooter,
+	"QuarantineDigest/text":    quarantineDigestBody + quarantineDigestListText + textFooter,
+
+	"WaitlistAllocated/subject": `{{.Branding.Name}} lease ready [{{.Lease.AccountID}}]`,
+	"WaitlistAllocated/html":    htmlHeader + "<p>\n" + waitlistAllocatedBody + "</p>" + htmlFooter,
+	"WaitlistAllocated/text":    waitlistAllocatedBody + textFooter,
+
+	"PrincipalOffboarded/subject": `{{.Branding.Name}} leases ended for deactivated principal {{.Principal.ID}}`,
+	"PrincipalOffboarded/html":    htmlHeader + "<p>\n" + principalOffboardedBody + "</p>\n" + principalOffboardedListHTML + htmlFooter,
+	"PrincipalOffboarded/text":    principalOffboardedBody + principalOffboardedListText + textFooter,
+
+	"LeaseDigest/subject": `{{.Branding.Name}} {{.Period}} lease digest{{with .Principal.ID}} for {{.}}{{end}}`,
+	"LeaseDigest/html":    htmlHeader + "<p>\n" + leaseDigestBody + "</p>\n" + leaseDigestListHTML + htmlFooter,
+	"LeaseDigest/text":    leaseDigestBody + leaseDigestListText + textFooter,
+
+	"SavingsReport/subject": `{{.Branding.Name}} savings report for {{.Savings.StartDate.Format "January 2, 2006"}} to {{.Savings.EndDate.Format "January 2, 2006"}}`,
+	"SavingsReport/html":    htmlHeader + "<p>\n" + savingsReportBody + "</p>\n" + savingsReportListHTML + htmlFooter,
+	"SavingsReport/text":    savingsReportBody + savingsReportListText + textFooter,
+}
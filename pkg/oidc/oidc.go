@@ -0,0 +1,308 @@
+// Package oidc verifies the ID tokens of a generic OpenID Connect provider,
+// eg. Okta, Azure AD or Keycloak, so deployments which don't use Cognito can
+// still identify the users making API requests.  The provider's signing keys
+// are read from the JWKS of its discovery document.
+package oidc
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/caarlos0/env"
+)
+
+// leeway is how far the clocks of the provider and DCE may drift, when
+// checking when tokens expire
+const leeway = 60 * time.Second
+
+// keysRefreshInterval is how often keys are read again, when a token is
+// signed with a key which isn't known yet, eg. after the provider rotated
+// its keys
+const keysRefreshInterval = time.Minute
+
+// Identity is the user a token was issued to
+type Identity struct {
+	Username string
+	Groups   []string
+}
+
+// NewVerifierInput are the items needed to create a new verifier
+type NewVerifierInput struct {
+	// Issuer is the provider's issuer URL, eg. "https://example.okta.com",
+	// which its discovery document is read from
+	Issuer string `env:"OIDC_ISSUER" envDefault:""`
+	// Audience is the client ID tokens must be issued for
+	Audience string `env:"OIDC_AUDIENCE" envDefault:""`
+	// UsernameClaim is the claim of the user's principal ID, eg. "email"
+	UsernameClaim string `env:"OIDC_USERNAME_CLAIM" envDefault:"sub"`
+	// GroupsClaim is the claim of the user's groups, which may be a list or
+	// a comma separated string
+	GroupsClaim string `env:"OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	// HTTPClient is optional
+	HTTPClient *http.Client
+}
+
+// Verifier verifies the signature and claims of ID tokens
+type Verifier struct {
+	issuer        string
+	audience      string
+	usernameClaim string
+	groupsClaim   string
+	httpClient    *http.Client
+
+	mu            sync.Mutex
+	keys          map[string]*rsa.PublicKey
+	keysFetchedOn time.Time
+}
+
+// Verify checks the token was signed by the provider, for the audience, and
+// hasn't expired, and returns the identity of its user
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.NewUnathorizedError("the token is not a JWT")
+	}
+
+	header := struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{}
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, errors.NewUnathorizedError("the token header is invalid")
+	}
+	// Only RS256 is accepted, so tokens can't pick a weaker algorithm
+	if header.Alg != "RS256" {
+		return nil, errors.NewUnathorizedError(fmt.Sprintf("the token algorithm %q is not supported", header.Alg))
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.NewUnathorizedError("the token signature is invalid")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	if err != nil {
+		return nil, errors.NewUnathorizedError("the token signature is invalid")
+	}
+
+	claims := map[string]interface{}{}
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, errors.NewUnathorizedError("the token claims are invalid")
+	}
+	err = v.checkClaims(claims, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	username, _ := claims[v.usernameClaim].(string)
+	if username == "" {
+		return nil, errors.NewUnathorizedError(fmt.Sprintf("the token has no %s claim", v.usernameClaim))
+	}
+	return &Identity{
+		Username: username,
+		Groups:   groupsOf(claims[v.groupsClaim]),
+	}, nil
+}
+
+// checkClaims checks the issuer, audience and validity period of the token
+func (v *Verifier) checkClaims(claims map[string]interface{}, now time.Time) error {
+	if iss, _ := claims["iss"].(string); iss != v.issuer {
+		return errors.NewUnathorizedError(fmt.Sprintf("the token was issued by %q", iss))
+	}
+
+	// The audience may be a string or a list
+	audiences := []string{}
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = append(audiences, aud)
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	if !contains(audiences, v.audience) {
+		return errors.NewUnathorizedError("the token was not issued for DCE")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.Add(-leeway).Unix() >= int64(exp) {
+		return errors.NewUnathorizedError("the token has expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(leeway).Unix() < int64(nbf) {
+		return errors.NewUnathorizedError("the token is not valid yet")
+	}
+	return nil
+}
+
+// key returns the signing key with the ID, reading the provider's keys when
+// it isn't known
+func (v *Verifier) key(kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(v.keysFetchedOn) > keysRefreshInterval {
+		keys, err := v.fetchKeys()
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		v.keysFetchedOn = time.Now()
+	}
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.NewUnathorizedError(fmt.Sprintf("the token was signed with an unknown key %q", kid))
+}
+
+// fetchKeys reads the RSA keys of the provider's JWKS
+func (v *Verifier) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	discovery := struct {
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	err := v.getJSON(strings.TrimSuffix(v.issuer, "/")+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}{}
+	err = v.getJSON(discovery.JWKSURI, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func (v *Verifier) getJSON(url string, out interface{}) error {
+	res, err := v.httpClient.Get(url)
+	if err != nil {
+		return errors.NewInternalServer("failed to read the oidc provider's keys", err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.NewInternalServer("failed to read the oidc provider's keys", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.NewInternalServer("failed to read the oidc provider's keys",
+			fmt.Errorf("%s returned %s", url, res.Status))
+	}
+	err = json.Unmarshal(body, out)
+	if err != nil {
+		return errors.NewInternalServer("failed to read the oidc provider's keys", err)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// groupsOf reads a groups claim, which may be a list or a comma separated
+// string
+func groupsOf(claim interface{}) []string {
+	groups := []string{}
+	switch c := claim.(type) {
+	case string:
+		for _, g := range strings.Split(c, ",") {
+			if g = strings.TrimSpace(g); g != "" {
+				groups = append(groups, g)
+			}
+		}
+	case []interface{}:
+		for _, g := range c {
+			if s, ok := g.(string); ok && s != "" {
+				groups = append(groups, s)
+			}
+		}
+	}
+	return groups
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewVerifier creates a new verifier
+func NewVerifier(input NewVerifierInput) *Verifier {
+	httpClient := input.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 5 * time.Second,
+		}
+	}
+	return &Verifier{
+		issuer:        input.Issuer,
+		audience:      input.Audience,
+		usernameClaim: input.UsernameClaim,
+		groupsClaim:   input.GroupsClaim,
+		httpClient:    httpClient,
+	}
+}
+
+// NewFromEnv creates a new verifier configured from environment variables
+func NewFromEnv() (*Verifier, error) {
+	input := NewVerifierInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	if input.Issuer == "" || input.Audience == "" {
+		return nil, fmt.Errorf("OIDC_ISSUER and OIDC_AUDIENCE are required")
+	}
+	return NewVerifier(input), nil
+}
@@ -0,0 +1,153 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(t *testing.T, key *rsa.PrivateKey, header map[string]interface{}, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.Nil(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.Nil(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	keyRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			keyRequests++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []map[string]string{{
+					"kid": "key1",
+					"kty": "RSA",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	header := map[string]interface{}{"alg": "RS256", "kid": "key1"}
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":    server.URL,
+			"aud":    "dce",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "jdoe@example.com",
+			"groups": []string{"developers", "dce-admins"},
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		expIdentity *Identity
+		expErr      error
+	}{
+		{
+			name:        "should return the identity of valid tokens",
+			token:       sign(t, key, header, claims(nil)),
+			expIdentity: &Identity{Username: "jdoe@example.com", Groups: []string{"developers", "dce-admins"}},
+		},
+		{
+			name:        "should accept audience lists and comma separated groups",
+			token:       sign(t, key, header, claims(map[string]interface{}{"aud": []string{"other", "dce"}, "groups": "developers, dce-admins"})),
+			expIdentity: &Identity{Username: "jdoe@example.com", Groups: []string{"developers", "dce-admins"}},
+		},
+		{
+			name:   "should fail on other signing keys",
+			token:  sign(t, otherKey, header, claims(nil)),
+			expErr: errors.NewUnathorizedError("the token signature is invalid"),
+		},
+		{
+			name:   "should fail on unknown keys",
+			token:  sign(t, key, map[string]interface{}{"alg": "RS256", "kid": "key2"}, claims(nil)),
+			expErr: errors.NewUnathorizedError("the token was signed with an unknown key \"key2\""),
+		},
+		{
+			name:   "should fail on other algorithms",
+			token:  sign(t, key, map[string]interface{}{"alg": "none", "kid": "key1"}, claims(nil)),
+			expErr: errors.NewUnathorizedError("the token algorithm \"none\" is not supported"),
+		},
+		{
+			name:   "should fail on other issuers",
+			token:  sign(t, key, header, claims(map[string]interface{}{"iss": "https://example.com"})),
+			expErr: errors.NewUnathorizedError("the token was issued by \"https://example.com\""),
+		},
+		{
+			name:   "should fail on other audiences",
+			token:  sign(t, key, header, claims(map[string]interface{}{"aud": "other"})),
+			expErr: errors.NewUnathorizedError("the token was not issued for DCE"),
+		},
+		{
+			name:   "should fail on expired tokens",
+			token:  sign(t, key, header, claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})),
+			expErr: errors.NewUnathorizedError("the token has expired"),
+		},
+		{
+			name:   "should fail without a username",
+			token:  sign(t, key, header, claims(map[string]interface{}{"email": ""})),
+			expErr: errors.NewUnathorizedError("the token has no email claim"),
+		},
+		{
+			name:   "should fail on malformed tokens",
+			token:  "abc.def",
+			expErr: errors.NewUnathorizedError("the token is not a JWT"),
+		},
+	}
+
+	verifier := NewVerifier(NewVerifierInput{
+		Issuer:        server.URL,
+		Audience:      "dce",
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		HTTPClient:    server.Client(),
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(tt.token)
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			assert.Equal(t, tt.expIdentity, identity)
+		})
+	}
+
+	// Keys are read once, even though a token had an unknown key
+	assert.Equal(t, 1, keyRequests)
+}
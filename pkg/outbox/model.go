@@ -0,0 +1,98 @@
+// Package outbox keeps the domain events of lease and account changes in a
+// DynamoDB table, written in the same transaction as the change itself, until
+// they are published.  An event is only published if its change was written,
+// and isn't lost when publishing fails.
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/google/uuid"
+)
+
+// Types of events in the outbox, named after the event service methods
+// which publish them
+const (
+	TypeAccountCreate = "AccountCreate"
+	TypeAccountReset  = "AccountReset"
+	TypeLeaseCreate   = "LeaseCreate"
+	TypeLeaseEnd      = "LeaseEnd"
+	TypeLeaseUpdate   = "LeaseUpdate"
+)
+
+// Entry is an event waiting to be published
+type Entry struct {
+	// ID sorts the entries by when they were created
+	ID   string `json:"id" dynamodbav:"Id"`
+	Type string `json:"type" dynamodbav:"Type"`
+	// ResourceID is the ID of the lease or account.  Its entries are
+	// published in order.
+	ResourceID string `json:"resourceId" dynamodbav:"ResourceId"`
+	// Old is the lease or account before an update
+	Old json.RawMessage `json:"old,omitempty" dynamodbav:"Old,omitempty"`
+	// New is the lease or account after the change
+	New       json.RawMessage `json:"new" dynamodbav:"New"`
+	CreatedOn int64           `json:"createdOn" dynamodbav:"CreatedOn"`
+	// Attempts is how many times publishing the entry failed
+	Attempts int `json:"attempts,omitempty" dynamodbav:"Attempts,omitempty"`
+}
+
+// NewEntry creates an entry for the event of a change to a lease or
+// account.  old is only given for updates.
+func NewEntry(eventType string, resourceID string, old interface{}, new interface{}) (*Entry, error) {
+	now := time.Now()
+	entry := &Entry{
+		ID:         fmt.Sprintf("%019d-%s", now.UnixNano(), uuid.New().String()),
+		Type:       eventType,
+		ResourceID: resourceID,
+		CreatedOn:  now.Unix(),
+	}
+
+	var err error
+	if old != nil {
+		entry.Old, err = json.Marshal(old)
+		if err != nil {
+			return nil, errors.NewInternalServer("unable to marshal outbox event", err)
+		}
+	}
+	entry.New, err = json.Marshal(new)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal outbox event", err)
+	}
+	return entry, nil
+}
+
+// Decode reads the lease or account of the entry into old and new.  old is
+// left as it is when the entry isn't for an update.
+func (e *Entry) Decode(old interface{}, new interface{}) error {
+	if len(e.Old) > 0 {
+		err := json.Unmarshal(e.Old, old)
+		if err != nil {
+			return errors.NewInternalServer(fmt.Sprintf("unable to read outbox event %s", e.ID), err)
+		}
+	}
+	err := json.Unmarshal(e.New, new)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("unable to read outbox event %s", e.ID), err)
+	}
+	return nil
+}
+
+// Put is the entry as a write in a DynamoDB transaction
+func (e *Entry) Put(tableName string) (*dynamodb.TransactWriteItem, error) {
+	item, err := dynamodbattribute.MarshalMap(e)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal outbox event", err)
+	}
+	return &dynamodb.TransactWriteItem{
+		Put: &dynamodb.Put{
+			TableName: &tableName,
+			Item:      item,
+		},
+	}, nil
+}
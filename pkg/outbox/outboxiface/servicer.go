@@ -0,0 +1,14 @@
+//
+
+package outboxiface
+
+import (
+	"github.com/Optum/dce/pkg/outbox"
+)
+
+// Servicer publishes the events waiting in the outbox
+type Servicer interface {
+	// Drain publishes every entry in the outbox, oldest first, and deletes
+	// the entries which were published
+	Drain(publish func(*outbox.Entry) error) (int, error)
+}
@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// NewServiceInput are the items needed to create a new outbox service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table of the outbox
+	TableName string `env:"OUTBOX_TABLE" envDefault:""`
+}
+
+// Service publishes the events waiting in the outbox
+type Service struct {
+	dynamodb  dynamodbiface.DynamoDBAPI
+	tableName string
+}
+
+// Drain publishes every entry in the outbox, oldest first, and deletes the
+// entries which were published.  When an entry fails to publish, its
+// attempts are counted, and the later entries of its lease or account are
+// left for the next drain, so they're published in order.  An entry may be
+// published again if it can't be deleted.  Nothing is published when an
+// entry can't be read.  Returns how many entries were published.
+func (s *Service) Drain(publish func(*Entry) error) (int, error) {
+	if s.tableName == "" {
+		return 0, errors.NewValidation("outbox", fmt.Errorf("an outbox table name is required"))
+	}
+
+	// Entries which can't be read may be older than the others of their
+	// lease or account
+	entries := []*Entry{}
+	var readErr error
+	err := s.dynamodb.ScanPages(&dynamodb.ScanInput{
+		TableName:      aws.String(s.tableName),
+		ConsistentRead: aws.Bool(true),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		items := []*Entry{}
+		readErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items)
+		if readErr != nil {
+			return false
+		}
+		entries = append(entries, items...)
+		return true
+	})
+	if err != nil {
+		return 0, errors.NewInternalServer("failed to scan the outbox", err)
+	}
+	if readErr != nil {
+		return 0, errors.NewInternalServer("failed to read outbox entries", readErr)
+	}
+	// IDs sort by when the entries were created
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	published := 0
+	held := map[string]bool{}
+	errs := []error{}
+	for _, entry := range entries {
+		if held[entry.ResourceID] {
+			continue
+		}
+		err = publish(entry)
+		if err != nil {
+			log.Printf("Failed to publish %s event %s of %s: %s", entry.Type, entry.ID, entry.ResourceID, err)
+			held[entry.ResourceID] = true
+			errs = append(errs, err)
+			s.countAttempt(entry)
+			continue
+		}
+		published++
+
+		_, err = s.dynamodb.DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String(s.tableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {S: aws.String(entry.ID)},
+			},
+		})
+		if err != nil {
+			errs = append(errs, errors.NewInternalServer(fmt.Sprintf("failed to delete outbox entry %s", entry.ID), err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return published, errors.NewMultiError("failed to drain the outbox", errs)
+	}
+	return published, nil
+}
+
+// countAttempt records a failure to publish the entry, so entries which
+// keep failing can be found
+func (s *Service) countAttempt(entry *Entry) {
+	_, err := s.dynamodb.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Id": {S: aws.String(entry.ID)},
+		},
+		UpdateExpression:    aws.String("ADD Attempts :one"),
+		ConditionExpression: aws.String("attribute_exists(Id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one": {N: aws.String("1")},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to count the attempt to publish outbox entry %s: %s", entry.ID, err)
+	}
+}
+
+// NewService creates a new outbox service
+func NewService(input NewServiceInput) *Service {
+	return &Service{
+		dynamodb:  input.DynamoDB,
+		tableName: input.TableName,
+	}
+}
@@ -0,0 +1,201 @@
+package outbox
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/fault"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testLease struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func TestEntryDecode(t *testing.T) {
+	entry, err := NewEntry(TypeLeaseUpdate, "lease-1",
+		&testLease{ID: "lease-1", Status: "Active"},
+		&testLease{ID: "lease-1", Status: "Inactive"})
+	assert.Nil(t, err)
+
+	old := &testLease{}
+	new := &testLease{}
+	err = entry.Decode(old, new)
+	assert.Nil(t, err)
+	assert.Equal(t, "Active", old.Status)
+	assert.Equal(t, "Inactive", new.Status)
+
+	entry, err = NewEntry(TypeLeaseCreate, "lease-1", nil, &testLease{ID: "lease-1"})
+	assert.Nil(t, err)
+	assert.Empty(t, entry.Old)
+}
+
+func TestDrain(t *testing.T) {
+	newItem := func(id string, resourceID string) map[string]*dynamodb.AttributeValue {
+		item, _ := dynamodbattribute.MarshalMap(&Entry{
+			ID:         id,
+			Type:       TypeLeaseUpdate,
+			ResourceID: resourceID,
+			New:        []byte(`{}`),
+		})
+		return item
+	}
+
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("ScanPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*dynamodb.ScanOutput, bool) bool)
+			// Scans aren't ordered
+			fn(&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{
+				newItem("3", "lease-1"),
+				newItem("1", "lease-1"),
+				newItem("2", "lease-2"),
+			}}, true)
+		}).
+		Return(nil)
+	mockDynamo.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Outbox"})
+	attempted := []string{}
+	published, err := svc.Drain(func(entry *Entry) error {
+		attempted = append(attempted, entry.ID)
+		if entry.ID == "1" {
+			return fmt.Errorf("unavailable")
+		}
+		return nil
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, published)
+	// The later entry of lease-1 waits for the one which failed
+	assert.Equal(t, []string{"1", "2"}, attempted)
+	mockDynamo.AssertNumberOfCalls(t, "DeleteItem", 1)
+	mockDynamo.AssertCalled(t, "DeleteItem", &dynamodb.DeleteItemInput{
+		TableName: aws.String("Outbox"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Id": {S: aws.String("2")},
+		},
+	})
+	mockDynamo.AssertNumberOfCalls(t, "UpdateItem", 1)
+}
+
+func TestDrainUnreadableEntries(t *testing.T) {
+	item, _ := dynamodbattribute.MarshalMap(&Entry{
+		ID:         "2",
+		Type:       TypeLeaseUpdate,
+		ResourceID: "lease-1",
+		New:        []byte(`{}`),
+	})
+
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("ScanPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*dynamodb.ScanOutput, bool) bool)
+			// The first page can't be read, so the scan stops before the
+			// later entry of lease-1
+			if fn(&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{
+				{"Id": {S: aws.String("1")}, "CreatedOn": {S: aws.String("yesterday")}},
+			}}, false) {
+				fn(&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{item}}, true)
+			}
+		}).
+		Return(nil)
+
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Outbox"})
+	attempted := []string{}
+	published, err := svc.Drain(func(entry *Entry) error {
+		attempted = append(attempted, entry.ID)
+		return nil
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, published)
+	assert.Empty(t, attempted)
+	mockDynamo.AssertNotCalled(t, "DeleteItem", mock.Anything)
+}
+
+func TestDrainWithFaults(t *testing.T) {
+	newItem := func(id string, resourceID string) string {
+		// The entry's new lease is {}
+		return fmt.Sprintf(`{"Id": {"S": %q}, "Type": {"S": "LeaseUpdate"}, "ResourceId": {"S": %q}, "New": {"B": "e30="}, "CreatedOn": {"N": "0"}}`, id, resourceID)
+	}
+
+	var lock sync.Mutex
+	operations := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		// DynamoDB requests are JSON, and SNS requests are queries
+		target := r.Header.Get("X-Amz-Target")
+		switch {
+		case strings.HasSuffix(target, ".Scan"):
+			_, _ = fmt.Fprintf(w, `{"Count": 3, "Items": [%s, %s, %s]}`,
+				newItem("3", "lease-1"), newItem("1", "lease-1"), newItem("2", "lease-2"))
+		case target != "":
+			_, _ = w.Write([]byte("{}"))
+		default:
+			target = "SNS.Publish"
+			_, _ = w.Write([]byte(`<PublishResponse><PublishResult><MessageId>1</MessageId></PublishResult></PublishResponse>`))
+		}
+		operations = append(operations, target[strings.Index(target, ".")+1:])
+	}))
+	defer server.Close()
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		Retryer: client.DefaultRetryer{
+			NumMaxRetries:    3,
+			MinRetryDelay:    time.Millisecond,
+			MaxRetryDelay:    time.Millisecond,
+			MinThrottleDelay: time.Millisecond,
+			MaxThrottleDelay: time.Millisecond,
+		},
+	})
+	require.Nil(t, err)
+	// The first publish fails, with the SDK's retries, and deleting the
+	// published entry is throttled before it succeeds
+	injector, err := fault.New(
+		fault.SNSPublishFailure(4),
+		fault.DynamoDBThrottling("DeleteItem", 2),
+	)
+	require.Nil(t, err)
+	injector.Apply(&sess.Handlers)
+	snsSvc := sns.New(sess)
+
+	svc := NewService(NewServiceInput{DynamoDB: dynamodb.New(sess), TableName: "Outbox"})
+	published, err := svc.Drain(func(entry *Entry) error {
+		_, err := snsSvc.Publish(&sns.PublishInput{
+			TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:lease-updated"),
+			Message:  aws.String(string(entry.New)),
+		})
+		return err
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 4, injector.Injected("SNS", "Publish"))
+	assert.Equal(t, 2, injector.Injected("DynamoDB", "DeleteItem"))
+	// The failed entry's attempt is counted, the later entry of lease-1
+	// waits for it, and the entry of lease-2 is published and deleted
+	assert.Equal(t, []string{"Scan", "UpdateItem", "Publish", "DeleteItem"}, operations)
+}
@@ -0,0 +1,145 @@
+// Package policy evaluates the lease approval and account allocation policies
+// of deployers, written as rego documents, with Open Policy Agent.  OPA loads
+// the policies from a bundle in S3, and DCE queries its Data API each time it
+// makes a decision.
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/caarlos0/env"
+)
+
+// Documents DCE queries.  Each is the path of a rule in the rego package,
+// eg. `dce/lease/create` is the `create` rule of `package dce.lease`.
+const (
+	// LeaseCreate decides whether a lease can be created.  Its input is the
+	// lease, after its defaults are set and it's been validated.
+	LeaseCreate = "dce/lease/create"
+	// AccountAllocate decides whether a Ready account can be leased to a
+	// principal.  Accounts which aren't allowed are skipped.
+	AccountAllocate = "dce/account/allocate"
+)
+
+// Decision of a policy.  Rules may be a boolean, or an object with `allow`
+// and the `reasons` the request is denied, which are returned to the user.
+type Decision struct {
+	Allow   bool     `json:"allow"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Summary describes why the decision was denied
+func (d *Decision) Summary() string {
+	if len(d.Reasons) == 0 {
+		return "denied by policy"
+	}
+	return fmt.Sprintf("denied by policy: %s", strings.Join(d.Reasons, "; "))
+}
+
+// NewServiceInput are the items needed to create a new policy service
+type NewServiceInput struct {
+	// OPAURL is the URL of the OPA server, eg. "http://opa.internal:8181".
+	// Policies aren't evaluated when empty, and every decision is allowed.
+	OPAURL string `env:"OPA_URL" envDefault:""`
+	// HTTPClient is optional
+	HTTPClient *http.Client
+}
+
+// Service evaluates policies with OPA
+type Service struct {
+	url        string
+	httpClient *http.Client
+}
+
+type dataRequest struct {
+	Input interface{} `json:"input"`
+}
+
+type dataResponse struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// Evaluate queries the document with the input.  Documents which are
+// undefined, because the deployer has no policy for the decision, are
+// allowed.  Requests fail closed, so an error is returned when OPA can't be
+// queried.
+func (s *Service) Evaluate(document string, input interface{}) (*Decision, error) {
+	if s.url == "" {
+		return &Decision{Allow: true}, nil
+	}
+
+	body, err := json.Marshal(&dataRequest{Input: input})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to marshal the policy input", err)
+	}
+	res, err := s.httpClient.Post(s.url+"/v1/data/"+document, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to evaluate the %s policy", document), err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to evaluate the %s policy", document), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.NewInternalServer(
+			fmt.Sprintf("failed to evaluate the %s policy", document),
+			fmt.Errorf("opa returned %s: %s", res.Status, resBody),
+		)
+	}
+
+	data := dataResponse{}
+	err = json.Unmarshal(resBody, &data)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to read the %s policy decision", document), err)
+	}
+	if len(data.Result) == 0 {
+		return &Decision{Allow: true}, nil
+	}
+
+	var allow bool
+	if json.Unmarshal(data.Result, &allow) == nil {
+		return &Decision{Allow: allow}, nil
+	}
+	decision := &Decision{}
+	err = json.Unmarshal(data.Result, decision)
+	if err != nil {
+		return nil, errors.NewInternalServer(
+			fmt.Sprintf("failed to read the %s policy decision", document),
+			fmt.Errorf("the decision must be a boolean or an object with allow and reasons: %s", data.Result),
+		)
+	}
+	return decision, nil
+}
+
+// NewService creates a new policy service
+func NewService(input NewServiceInput) *Service {
+	httpClient := input.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 5 * time.Second,
+		}
+	}
+	return &Service{
+		url:        strings.TrimSuffix(input.OPAURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// NewFromEnv creates a new policy service configured from environment variables
+func NewFromEnv() (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(input), nil
+}
@@ -0,0 +1,98 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expDecision *Decision
+		expErr      error
+	}{
+		{
+			name:        "should allow boolean decisions",
+			status:      http.StatusOK,
+			body:        `{"result": true}`,
+			expDecision: &Decision{Allow: true},
+		},
+		{
+			name:        "should deny boolean decisions",
+			status:      http.StatusOK,
+			body:        `{"result": false}`,
+			expDecision: &Decision{Allow: false},
+		},
+		{
+			name:        "should return the reasons of object decisions",
+			status:      http.StatusOK,
+			body:        `{"result": {"allow": false, "reasons": ["budget over 500", "no weekend leases"]}}`,
+			expDecision: &Decision{Allow: false, Reasons: []string{"budget over 500", "no weekend leases"}},
+		},
+		{
+			name:        "should allow undefined documents",
+			status:      http.StatusOK,
+			body:        `{}`,
+			expDecision: &Decision{Allow: true},
+		},
+		{
+			name:   "should fail on other decisions",
+			status: http.StatusOK,
+			body:   `{"result": "yes"}`,
+			expErr: errors.NewInternalServer("failed to read the dce/lease/create policy decision", fmt.Errorf("the decision must be a boolean or an object with allow and reasons: \"yes\"")),
+		},
+		{
+			name:   "should fail when OPA errors",
+			status: http.StatusInternalServerError,
+			body:   `{"code": "internal_error"}`,
+			expErr: errors.NewInternalServer("failed to evaluate the dce/lease/create policy", fmt.Errorf("opa returned 500 Internal Server Error: {\"code\": \"internal_error\"}")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			var got map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				body, _ := ioutil.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &got)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			svc := NewService(NewServiceInput{OPAURL: server.URL + "/", HTTPClient: server.Client()})
+			decision, err := svc.Evaluate(LeaseCreate, map[string]string{"principalId": "user"})
+
+			assert.True(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+			assert.Equal(t, tt.expDecision, decision)
+			assert.Equal(t, "/v1/data/dce/lease/create", gotPath)
+			assert.Equal(t, map[string]interface{}{
+				"input": map[string]interface{}{"principalId": "user"},
+			}, got)
+		})
+	}
+}
+
+func TestEvaluateDisabled(t *testing.T) {
+	svc := NewService(NewServiceInput{})
+	decision, err := svc.Evaluate(AccountAllocate, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Decision{Allow: true}, decision)
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "denied by policy", (&Decision{}).Summary())
+	assert.Equal(t, "denied by policy: too big; too long", (&Decision{Reasons: []string{"too big", "too long"}}).Summary())
+}
@@ -0,0 +1,13 @@
+//
+
+package policyiface
+
+import (
+	"github.com/Optum/dce/pkg/policy"
+)
+
+// Servicer evaluates the policies of deployers
+type Servicer interface {
+	// Evaluate queries a policy document with the input
+	Evaluate(document string, input interface{}) (*policy.Decision, error)
+}
@@ -0,0 +1,131 @@
+// Package principal validates and normalizes the IDs of lease principals.
+// Identity providers may name one person differently, eg. Jane.Doe@example.com
+// and jane.doe@example.com, which would otherwise create separate principal
+// records, each with its own budget and lease quota.
+package principal
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// Normalizations of principal IDs
+const (
+	// NormalizeTrim removes leading and trailing whitespace
+	NormalizeTrim = "trim"
+	// NormalizeLowercase lowercases every ID
+	NormalizeLowercase = "lowercase"
+	// NormalizeLowercaseEmails lowercases IDs which are email addresses,
+	// and leaves others, eg. ARNs, as they are
+	NormalizeLowercaseEmails = "lowercase-emails"
+)
+
+// Formats are named patterns principal IDs may match
+var Formats = map[string]string{
+	"email":       `^[^@\s]+@[^@\s]+\.[^@\s]+$`,
+	"iam-arn":     `^arn:aws[a-z-]*:(iam|sts)::[0-9]{12}:.+$`,
+	"employee-id": `^[A-Za-z]?[0-9]{4,10}$`,
+}
+
+var emailPattern = regexp.MustCompile(Formats["email"])
+
+// NewIDFormatInput configures the format of principal IDs
+type NewIDFormatInput struct {
+	// Patterns is a JSON list of the formats, or regular expressions,
+	// principal IDs must match one of, eg. ["email", "^svc-[a-z]+$"].  Any ID
+	// is valid when it's empty.
+	Patterns string `env:"PRINCIPAL_ID_PATTERNS" envDefault:""`
+	// Normalization are the normalizations of principal IDs, in order, eg.
+	// trim and lowercase-emails
+	Normalization []string `env:"PRINCIPAL_ID_NORMALIZATION" envSeparator:","`
+}
+
+// IDFormat validates and normalizes principal IDs
+type IDFormat struct {
+	patterns      []*regexp.Regexp
+	normalization []string
+}
+
+// NewIDFormat creates the format of principal IDs
+func NewIDFormat(input NewIDFormatInput) (*IDFormat, error) {
+	format := &IDFormat{}
+
+	if strings.TrimSpace(input.Patterns) != "" {
+		patterns := []string{}
+		err := json.Unmarshal([]byte(input.Patterns), &patterns)
+		if err != nil {
+			return nil, errors.NewValidation("principal ID patterns", fmt.Errorf("must be a JSON list: %s", err))
+		}
+		for _, pattern := range patterns {
+			if named, ok := Formats[pattern]; ok {
+				pattern = named
+			}
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.NewValidation("principal ID patterns", fmt.Errorf("%q must be a format or regular expression: %s", pattern, err))
+			}
+			format.patterns = append(format.patterns, compiled)
+		}
+	}
+
+	for _, normalization := range input.Normalization {
+		normalization = strings.TrimSpace(normalization)
+		switch normalization {
+		case "":
+			continue
+		case NormalizeTrim, NormalizeLowercase, NormalizeLowercaseEmails:
+			format.normalization = append(format.normalization, normalization)
+		default:
+			return nil, errors.NewValidation("principal ID normalization", fmt.Errorf("%q must be one of %s, %s, %s",
+				normalization, NormalizeTrim, NormalizeLowercase, NormalizeLowercaseEmails))
+		}
+	}
+
+	return format, nil
+}
+
+// Normalize returns the ID the principal is recorded with
+func (f *IDFormat) Normalize(id string) string {
+	if f == nil {
+		return id
+	}
+	for _, normalization := range f.normalization {
+		switch normalization {
+		case NormalizeTrim:
+			id = strings.TrimSpace(id)
+		case NormalizeLowercase:
+			id = strings.ToLower(id)
+		case NormalizeLowercaseEmails:
+			if emailPattern.MatchString(id) {
+				id = strings.ToLower(id)
+			}
+		}
+	}
+	return id
+}
+
+// Validate checks the ID matches one of the patterns
+func (f *IDFormat) Validate(id string) error {
+	if f == nil || len(f.patterns) == 0 {
+		return nil
+	}
+	for _, pattern := range f.patterns {
+		if pattern.MatchString(id) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a valid principal ID", id)
+}
+
+// Parse normalizes the ID, and checks it's valid
+func (f *IDFormat) Parse(id string) (string, error) {
+	id = f.Normalize(id)
+	if err := f.Validate(id); err != nil {
+		return "", errors.NewValidation("principal", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,95 @@
+package principal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIDFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NewIDFormatInput
+		expErr error
+	}{
+		{
+			name:  "should allow formats and regular expressions",
+			input: NewIDFormatInput{Patterns: `["email", "^svc-[a-z]+$"]`, Normalization: []string{"trim", "lowercase-emails"}},
+		},
+		{
+			name: "should allow no configuration",
+		},
+		{
+			name:   "should fail on patterns which aren't a list",
+			input:  NewIDFormatInput{Patterns: `"email"`},
+			expErr: errors.NewValidation("principal ID patterns", fmt.Errorf("must be a JSON list: json: cannot unmarshal string into Go value of type []string")),
+		},
+		{
+			name:   "should fail on invalid regular expressions",
+			input:  NewIDFormatInput{Patterns: `["^svc-[a-z+$"]`},
+			expErr: errors.NewValidation("principal ID patterns", fmt.Errorf("\"^svc-[a-z+$\" must be a format or regular expression: error parsing regexp: missing closing ]: `[a-z+$`")),
+		},
+		{
+			name:   "should fail on unknown normalizations",
+			input:  NewIDFormatInput{Normalization: []string{"uppercase"}},
+			expErr: errors.NewValidation("principal ID normalization", fmt.Errorf("\"uppercase\" must be one of trim, lowercase, lowercase-emails")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewIDFormat(tt.input)
+			assert.Truef(t, errors.Is(err, tt.expErr), "actual error %q doesn't match expected error %q", err, tt.expErr)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	format, err := NewIDFormat(NewIDFormatInput{
+		Patterns:      `["email", "iam-arn", "employee-id"]`,
+		Normalization: []string{NormalizeTrim, NormalizeLowercaseEmails},
+	})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name  string
+		id    string
+		expID string
+		expOK bool
+	}{
+		{name: "should lowercase emails", id: " Jane.Doe@Example.com ", expID: "jane.doe@example.com", expOK: true},
+		{name: "should keep the case of ARNs", id: "arn:aws:iam::123456789012:user/JDoe", expID: "arn:aws:iam::123456789012:user/JDoe", expOK: true},
+		{name: "should allow employee IDs", id: "E123456", expID: "E123456", expOK: true},
+		{name: "should fail on other formats", id: "jane doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := format.Parse(tt.id)
+			if !tt.expOK {
+				assert.EqualError(t, err, fmt.Sprintf("principal validation error: %q is not a valid principal ID", tt.id))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expID, id)
+		})
+	}
+}
+
+func TestNilIDFormat(t *testing.T) {
+	var format *IDFormat
+
+	id, err := format.Parse(" JDoe ")
+	assert.Nil(t, err)
+	assert.Equal(t, " JDoe ", id)
+}
+
+func TestNormalizeLowercase(t *testing.T) {
+	format, err := NewIDFormat(NewIDFormatInput{Normalization: []string{NormalizeLowercase}})
+	require.Nil(t, err)
+
+	assert.Equal(t, "arn:aws:iam::123456789012:user/jdoe", format.Normalize("arn:aws:iam::123456789012:user/JDoe"))
+}
@@ -0,0 +1,40 @@
+package reset
+
+import (
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ApplyCleanup narrows a rendered aws-nuke config to a cleanup of a leased
+// account, which only removes resources of the given types, in the given
+// regions.  Like reset profiles, a cleanup never removes more than the
+// config would, and regions not in the config aren't cleaned up.  All the
+// config's regions are cleaned up when none are given.
+func ApplyCleanup(config []byte, resourceTypes []string, regions []string) ([]byte, error) {
+	// aws-nuke removes every type when there are no targets
+	if len(resourceTypes) == 0 {
+		return nil, errors.New("A cleanup needs at least one resource type")
+	}
+
+	doc, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = narrowResourceTypes(doc, ResourceTypes{Targets: resourceTypes})
+	if err != nil {
+		return nil, err
+	}
+	if len(getStrings(getMap(doc, "resource-types"), "targets")) == 0 {
+		return nil, errors.Errorf("None of the cleanup's resource types %v are in the nuke config targets", resourceTypes)
+	}
+
+	if len(regions) > 0 {
+		configRegions := intersect(getStrings(doc, "regions"), regions)
+		if len(configRegions) == 0 {
+			return nil, errors.Errorf("None of the cleanup's regions %v are in the nuke config", regions)
+		}
+		doc = setStrings(doc, "regions", configRegions)
+	}
+
+	return yaml.Marshal(doc)
+}
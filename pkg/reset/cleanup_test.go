@@ -0,0 +1,52 @@
+package reset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestApplyCleanup(t *testing.T) {
+
+	t.Run("should target the resource types in the regions", func(t *testing.T) {
+		config, err := ApplyCleanup([]byte(testNukeConfig), []string{"EC2Instance", "EC2Volume"}, []string{"us-east-1"})
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Equal(t, []string{"EC2Instance", "EC2Volume"}, actual.ResourceTypes.Targets)
+		assert.Equal(t, []string{"S3Object"}, actual.ResourceTypes.Excludes)
+		assert.Equal(t, []string{"us-east-1"}, actual.Regions)
+		// The filters protecting DCE's roles are kept
+		assert.Equal(t, []string{"AdminRole"}, actual.Accounts["123456789012"].Filters["IAMRole"])
+	})
+
+	t.Run("should clean up every region of the config without regions", func(t *testing.T) {
+		config, err := ApplyCleanup([]byte(testNukeConfig), []string{"EC2Instance"}, nil)
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Equal(t, []string{"global", "us-east-1"}, actual.Regions)
+	})
+
+	t.Run("should not clean up regions outside the config", func(t *testing.T) {
+		_, err := ApplyCleanup([]byte(testNukeConfig), []string{"EC2Instance"}, []string{"eu-west-1"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should not remove every resource type", func(t *testing.T) {
+		_, err := ApplyCleanup([]byte(testNukeConfig), nil, []string{"us-east-1"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should not remove types outside the config's targets", func(t *testing.T) {
+		narrowed, err := ApplyProfile([]byte(testNukeConfig), "compute-only")
+		require.Nil(t, err)
+
+		_, err = ApplyCleanup(narrowed, []string{"S3Bucket"}, nil)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+// Package pipeline pauses and resumes the reset pipeline.
+//
+// The pipeline is paused by disabling the mapping of the reset queue to the
+// process_reset_queue Lambda, so accounts waiting to be reset stay in the
+// queue until the pipeline is resumed, instead of being dropped or retried
+// into the dead letter queue.
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// Event source mapping states which mean the pipeline is paused, or pausing
+var pausedStates = map[string]bool{
+	"Disabled":  true,
+	"Disabling": true,
+}
+
+// Status is whether the reset pipeline is paused
+type Status struct {
+	Paused bool `json:"paused"`
+	// State is the state of the reset queue's event source mapping,
+	// eg. "Disabling" while the pipeline is being paused
+	State          string `json:"state"`
+	LastModifiedOn int64  `json:"lastModifiedOn"`
+}
+
+// Pipeline pauses and resumes the reset pipeline
+type Pipeline struct {
+	Lambda lambdaiface.LambdaAPI
+	// MappingID is the UUID of the event source mapping from the reset
+	// queue to the process_reset_queue Lambda
+	MappingID string
+}
+
+// New creates a Pipeline for the event source mapping
+func New(lambdaSvc lambdaiface.LambdaAPI, mappingID string) (*Pipeline, error) {
+	if mappingID == "" {
+		return nil, errors.NewInternalServer("the reset queue's event source mapping is not configured", nil)
+	}
+	return &Pipeline{
+		Lambda:    lambdaSvc,
+		MappingID: mappingID,
+	}, nil
+}
+
+// Status gets whether the reset pipeline is paused
+func (p *Pipeline) Status() (*Status, error) {
+	res, err := p.Lambda.GetEventSourceMapping(&lambda.GetEventSourceMappingInput{
+		UUID: aws.String(p.MappingID),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to get the status of the reset pipeline", err)
+	}
+	return newStatus(res), nil
+}
+
+// Pause stops accounts in the reset queue from being reset
+func (p *Pipeline) Pause() (*Status, error) {
+	return p.setEnabled(false)
+}
+
+// Resume resets the accounts in the reset queue again
+func (p *Pipeline) Resume() (*Status, error) {
+	return p.setEnabled(true)
+}
+
+func (p *Pipeline) setEnabled(enabled bool) (*Status, error) {
+	res, err := p.Lambda.UpdateEventSourceMapping(&lambda.UpdateEventSourceMappingInput{
+		UUID:    aws.String(p.MappingID),
+		Enabled: aws.Bool(enabled),
+	})
+	if err != nil {
+		action := "resume"
+		if !enabled {
+			action = "pause"
+		}
+		return nil, errors.NewInternalServer(fmt.Sprintf("unable to %s the reset pipeline", action), err)
+	}
+	return newStatus(res), nil
+}
+
+func newStatus(mapping *lambda.EventSourceMappingConfiguration) *Status {
+	state := aws.StringValue(mapping.State)
+	status := &Status{
+		Paused: pausedStates[state],
+		State:  state,
+	}
+	if mapping.LastModified != nil {
+		status.LastModifiedOn = mapping.LastModified.Unix()
+	}
+	return status
+}
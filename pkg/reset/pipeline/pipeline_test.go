@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	awsMocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseAndResume(t *testing.T) {
+	modified := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name      string
+		pause     bool
+		state     string
+		updateErr error
+		expStatus *Status
+	}{
+		{
+			name:      "should pause",
+			pause:     true,
+			state:     "Disabling",
+			expStatus: &Status{Paused: true, State: "Disabling", LastModifiedOn: 1600000000},
+		},
+		{
+			name:      "should resume",
+			state:     "Enabling",
+			expStatus: &Status{Paused: false, State: "Enabling", LastModifiedOn: 1600000000},
+		},
+		{
+			name:      "should fail when the mapping can't be updated",
+			pause:     true,
+			updateErr: fmt.Errorf("failure"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lambdaSvc := &awsMocks.LambdaAPI{}
+			lambdaSvc.On("UpdateEventSourceMapping", &lambda.UpdateEventSourceMappingInput{
+				UUID:    aws.String("mapping-id"),
+				Enabled: aws.Bool(!tt.pause),
+			}).Return(&lambda.EventSourceMappingConfiguration{
+				State:        aws.String(tt.state),
+				LastModified: &modified,
+			}, tt.updateErr)
+
+			p, err := New(lambdaSvc, "mapping-id")
+			require.Nil(t, err)
+
+			var status *Status
+			if tt.pause {
+				status, err = p.Pause()
+			} else {
+				status, err = p.Resume()
+			}
+
+			assert.Equal(t, tt.expStatus, status)
+			assert.Equal(t, tt.updateErr != nil, err != nil)
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	lambdaSvc := &awsMocks.LambdaAPI{}
+	lambdaSvc.On("GetEventSourceMapping", &lambda.GetEventSourceMappingInput{
+		UUID: aws.String("mapping-id"),
+	}).Return(&lambda.EventSourceMappingConfiguration{
+		State: aws.String("Disabled"),
+	}, nil)
+
+	p, err := New(lambdaSvc, "mapping-id")
+	require.Nil(t, err)
+
+	status, err := p.Status()
+	require.Nil(t, err)
+	assert.Equal(t, &Status{Paused: true, State: "Disabled"}, status)
+
+	_, err = New(lambdaSvc, "")
+	assert.NotNil(t, err, "should require the event source mapping")
+}
@@ -0,0 +1,249 @@
+package reset
+
+import (
+	"github.com/Optum/dce/pkg/account"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ResourceTypes are the aws-nuke resource types a reset profile removes
+// (Targets), or leaves in place (Excludes).  No targets means every type.
+type ResourceTypes struct {
+	Targets  []string
+	Excludes []string
+}
+
+// networkResourceTypes are kept by the data-preserving profile, as databases
+// and file systems can't be kept without the VPCs they're in
+var networkResourceTypes = []string{
+	"EC2VPC",
+	"EC2Subnet",
+	"EC2SecurityGroup",
+	"EC2RouteTable",
+	"EC2InternetGateway",
+	"EC2InternetGatewayAttachment",
+	"EC2NetworkACL",
+	"EC2DHCPOption",
+	"EC2VPCEndpoint",
+	"EC2NetworkInterface",
+}
+
+// ResetProfileResourceTypes maps each reset profile to its resource types.
+// The full profile removes everything the nuke template allows.
+var ResetProfileResourceTypes = map[account.ResetProfile]ResourceTypes{
+	account.ResetProfileFull: {},
+	// Remove what costs money to leave running
+	account.ResetProfileComputeOnly: {
+		Targets: []string{
+			"EC2Instance",
+			"EC2SpotFleetRequest",
+			"EC2LaunchTemplate",
+			"AutoScalingGroup",
+			"LaunchConfiguration",
+			"LifecycleHook",
+			"LambdaFunction",
+			"LambdaEventSourceMapping",
+			"ECSCluster",
+			"ECSClusterInstance",
+			"ECSService",
+			"ECSTaskDefinition",
+			"EKSCluster",
+			"BatchComputeEnvironment",
+			"BatchComputeEnvironmentState",
+			"BatchJobQueue",
+			"BatchJobQueueState",
+			"EMRCluster",
+			"GlueDevEndpoint",
+			"GlueJob",
+			"SageMakerEndpoint",
+			"SageMakerEndpointConfig",
+			"SageMakerModel",
+			"SageMakerNotebookInstance",
+			"SageMakerNotebookInstanceState",
+			"LightsailInstance",
+			"ElasticBeanstalkEnvironment",
+			"Cloud9Environment",
+		},
+	},
+	// Remove everything but stored data, and the networking it depends on
+	account.ResetProfileDataPreserving: {
+		Excludes: append([]string{
+			"S3Bucket",
+			"S3Object",
+			"S3MultipartUpload",
+			"DynamoDBTable",
+			"DynamoDBTableItem",
+			"RDSInstance",
+			"RDSDBCluster",
+			"RDSSnapshot",
+			"RDSDBParameterGroup",
+			"RDSDBClusterParameterGroup",
+			"RDSDBSubnetGroup",
+			"EFSFileSystem",
+			"EFSMountTarget",
+			"EC2Volume",
+			"EC2Snapshot",
+			"EC2Image",
+			"FSxFileSystem",
+			"FSxBackup",
+			"AWSBackupVault",
+			"AWSBackupRecoveryPoint",
+			"AWSBackupPlan",
+			"AWSBackupSelection",
+			"RedshiftCluster",
+			"RedshiftSnapshot",
+			"NeptuneCluster",
+			"NeptuneInstance",
+			"NetpuneSnapshot",
+			"GlueDatabase",
+			"KinesisStream",
+			"SecretsManagerSecret",
+			"KMSKey",
+			"KMSAlias",
+			"ECRRepository",
+			"CodeCommitRepository",
+		}, networkResourceTypes...),
+	},
+}
+
+// ApplyProfile narrows the resource types of a rendered aws-nuke config to
+// those of the reset profile.  Targets already in the config are
+// intersected with the profile's, and the profile's excludes are added to
+// the config's, so a profile never removes more than the config would.
+func ApplyProfile(config []byte, profile account.ResetProfile) ([]byte, error) {
+	types, ok := ResetProfileResourceTypes[profile]
+	if !ok {
+		return nil, errors.Errorf("Unknown reset profile %q", profile)
+	}
+	if len(types.Targets) == 0 && len(types.Excludes) == 0 {
+		return config, nil
+	}
+
+	doc, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = narrowResourceTypes(doc, types)
+	if err != nil {
+		return nil, err
+	}
+	// aws-nuke removes every type when there are no targets
+	if len(types.Targets) > 0 && len(getStrings(getMap(doc, "resource-types"), "targets")) == 0 {
+		return nil, errors.Errorf("Reset profile %q has no resource types in the nuke config targets", profile)
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// parseConfig parses a rendered aws-nuke config, keeping the order of its
+// keys, so the result reads like the template it was rendered from
+func parseConfig(config []byte) (yaml.MapSlice, error) {
+	var doc yaml.MapSlice
+	err := yaml.Unmarshal(config, &doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse nuke config")
+	}
+	return doc, nil
+}
+
+// narrowResourceTypes intersects the targets of the config with the given
+// targets, and adds the given excludes to the config's
+func narrowResourceTypes(doc yaml.MapSlice, types ResourceTypes) (yaml.MapSlice, error) {
+	resourceTypes := yaml.MapSlice{}
+	index := -1
+	for i, item := range doc {
+		if item.Key == "resource-types" {
+			index = i
+			err := remarshal(item.Value, &resourceTypes)
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed to parse nuke config resource-types")
+			}
+		}
+	}
+
+	if len(types.Targets) > 0 {
+		targets := getStrings(resourceTypes, "targets")
+		if len(targets) > 0 {
+			targets = intersect(targets, types.Targets)
+		} else {
+			targets = types.Targets
+		}
+		resourceTypes = setStrings(resourceTypes, "targets", targets)
+	}
+	if len(types.Excludes) > 0 {
+		excludes := getStrings(resourceTypes, "excludes")
+		resourceTypes = setStrings(resourceTypes, "excludes", union(excludes, types.Excludes))
+	}
+
+	if index >= 0 {
+		doc[index].Value = resourceTypes
+	} else {
+		doc = append(doc, yaml.MapItem{Key: "resource-types", Value: resourceTypes})
+	}
+	return doc, nil
+}
+
+func getMap(m yaml.MapSlice, key string) yaml.MapSlice {
+	var value yaml.MapSlice
+	for _, item := range m {
+		if item.Key == key {
+			_ = remarshal(item.Value, &value)
+		}
+	}
+	return value
+}
+
+// remarshal converts a parsed yaml value to out
+func remarshal(in interface{}, out interface{}) error {
+	b, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(b, out)
+}
+
+func getStrings(m yaml.MapSlice, key string) []string {
+	var values []string
+	for _, item := range m {
+		if item.Key == key {
+			_ = remarshal(item.Value, &values)
+		}
+	}
+	return values
+}
+
+func setStrings(m yaml.MapSlice, key string, values []string) yaml.MapSlice {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = values
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: key, Value: values})
+}
+
+func intersect(a []string, b []string) []string {
+	in := map[string]bool{}
+	for _, v := range b {
+		in[v] = true
+	}
+	values := []string{}
+	for _, v := range a {
+		if in[v] {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func union(a []string, b []string) []string {
+	in := map[string]bool{}
+	values := []string{}
+	for _, v := range append(append([]string{}, a...), b...) {
+		if !in[v] {
+			in[v] = true
+			values = append(values, v)
+		}
+	}
+	return values
+}
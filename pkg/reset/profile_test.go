@@ -0,0 +1,112 @@
+package reset
+
+import (
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const testNukeConfig = `regions:
+- global
+- us-east-1
+account-blacklist:
+- "000000000000"
+resource-types:
+  excludes:
+  - S3Object
+accounts:
+  "123456789012":
+    filters:
+      IAMRole:
+      - AdminRole
+`
+
+type testConfig struct {
+	Regions       []string `yaml:"regions"`
+	ResourceTypes struct {
+		Targets  []string `yaml:"targets"`
+		Excludes []string `yaml:"excludes"`
+	} `yaml:"resource-types"`
+	Accounts map[string]struct {
+		Filters map[string][]string `yaml:"filters"`
+	} `yaml:"accounts"`
+}
+
+func TestApplyProfile(t *testing.T) {
+
+	t.Run("should leave the config as is for the full profile", func(t *testing.T) {
+		config, err := ApplyProfile([]byte(testNukeConfig), account.ResetProfileFull)
+		require.Nil(t, err)
+		assert.Equal(t, testNukeConfig, string(config))
+	})
+
+	t.Run("should target compute for the compute-only profile", func(t *testing.T) {
+		config, err := ApplyProfile([]byte(testNukeConfig), account.ResetProfileComputeOnly)
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Contains(t, actual.ResourceTypes.Targets, "EC2Instance")
+		assert.NotContains(t, actual.ResourceTypes.Targets, "S3Bucket")
+		assert.Equal(t, []string{"S3Object"}, actual.ResourceTypes.Excludes)
+		// The rest of the config is kept
+		assert.Equal(t, []string{"global", "us-east-1"}, actual.Regions)
+		assert.Equal(t, []string{"AdminRole"}, actual.Accounts["123456789012"].Filters["IAMRole"])
+	})
+
+	t.Run("should narrow the config's targets", func(t *testing.T) {
+		config, err := ApplyProfile([]byte("resource-types:\n  targets:\n  - EC2Instance\n  - S3Bucket\n"),
+			account.ResetProfileComputeOnly)
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Equal(t, []string{"EC2Instance"}, actual.ResourceTypes.Targets)
+	})
+
+	t.Run("should fail when the profile leaves no targets", func(t *testing.T) {
+		_, err := ApplyProfile([]byte("resource-types:\n  targets:\n  - S3Bucket\n"),
+			account.ResetProfileComputeOnly)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should exclude data for the data-preserving profile", func(t *testing.T) {
+		config, err := ApplyProfile([]byte(testNukeConfig), account.ResetProfileDataPreserving)
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Empty(t, actual.ResourceTypes.Targets)
+		assert.Equal(t, "S3Object", actual.ResourceTypes.Excludes[0])
+		assert.Contains(t, actual.ResourceTypes.Excludes, "S3Bucket")
+		assert.Contains(t, actual.ResourceTypes.Excludes, "EC2VPC")
+		assert.Equal(t, 1, countOf(actual.ResourceTypes.Excludes, "S3Object"))
+	})
+
+	t.Run("should add resource types to configs without them", func(t *testing.T) {
+		config, err := ApplyProfile([]byte("regions:\n- global\n"), account.ResetProfileDataPreserving)
+		require.Nil(t, err)
+
+		actual := testConfig{}
+		require.Nil(t, yaml.Unmarshal(config, &actual))
+		assert.Contains(t, actual.ResourceTypes.Excludes, "DynamoDBTable")
+	})
+
+	t.Run("should fail on unknown profiles", func(t *testing.T) {
+		_, err := ApplyProfile([]byte(testNukeConfig), account.ResetProfile("everything"))
+		assert.NotNil(t, err)
+	})
+}
+
+func countOf(values []string, value string) int {
+	count := 0
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,195 @@
+// Package routing sends DCE's notifications to the channels named by routing
+// rules, so where notifications go, eg. budget alerts for ML leases to the
+// #ml-infra Slack channel, is configuration rather than code.  Rules add
+// channels to the notifications DCE already sends; they don't replace them.
+package routing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+// Event types which are routed
+const (
+	// EventBudgetThreshold is routed when a lease passes a budget threshold
+	EventBudgetThreshold = "BudgetThreshold"
+	// EventResetFailed is routed when an account reset fails
+	EventResetFailed = "ResetFailed"
+	// EventAccountUnreachable is routed when a reset finds an account was
+	// closed or suspended
+	EventAccountUnreachable = "AccountUnreachable"
+	// EventResetBlackout is routed when a reset finds activity by principals
+	// outside DCE
+	EventResetBlackout = "ResetBlackout"
+)
+
+// EventTypes are the event types rules may route
+var EventTypes = []string{
+	EventBudgetThreshold,
+	EventResetFailed,
+	EventAccountUnreachable,
+	EventResetBlackout,
+}
+
+// contactEventTypes are the event types about the health of an account,
+// which are also sent to the account's contact
+var contactEventTypes = map[string]bool{
+	EventResetFailed:        true,
+	EventAccountUnreachable: true,
+	EventResetBlackout:      true,
+}
+
+// Channel types
+const (
+	// ChannelSlack posts to the Slack channel named by the target, eg. #ml-infra
+	ChannelSlack = "slack"
+	// ChannelEmail emails the address in the target
+	ChannelEmail = "email"
+	// ChannelAlert opens an incident in the on-call tool.  The target is the
+	// optional severity of the alert.
+	ChannelAlert = "alert"
+)
+
+// Channel is where a routed event is sent
+type Channel struct {
+	Type   string `json:"type" dynamodbav:"Type"`
+	Target string `json:"target,omitempty" dynamodbav:"Target,omitempty"`
+}
+
+// Validate the channel
+func (c Channel) Validate() error {
+	switch c.Type {
+	case ChannelSlack:
+		return validation.Validate(c.Target, validation.Required)
+	case ChannelEmail:
+		return validation.Validate(c.Target, validation.Required, is.Email)
+	case ChannelAlert:
+		return validation.Validate(c.Target, validation.In(
+			string(alert.SeverityCritical), string(alert.SeverityError), string(alert.SeverityWarning)))
+	}
+	return fmt.Errorf("type must be one of %s, %s or %s", ChannelSlack, ChannelEmail, ChannelAlert)
+}
+
+// Contact owns an account, or the account pool, and is sent the reset
+// failures and health alerts of its accounts
+type Contact struct {
+	Email string `json:"email,omitempty" dynamodbav:"Email,omitempty"`
+	Team  string `json:"team,omitempty" dynamodbav:"Team,omitempty"`
+	// EscalationChannel is the Slack channel to post to, eg. #ml-oncall
+	EscalationChannel string `json:"escalationChannel,omitempty" dynamodbav:"EscalationChannel,omitempty"`
+}
+
+// Validate the contact
+func (c Contact) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Email, is.Email),
+		validation.Field(&c.Team, validation.Length(0, 128)),
+		validation.Field(&c.EscalationChannel, validation.Length(0, 80)),
+	)
+}
+
+// IsEmpty is true when the contact has no fields, eg. when an account's
+// contact was cleared
+func (c *Contact) IsEmpty() bool {
+	return c == nil || (c.Email == "" && c.Team == "" && c.EscalationChannel == "")
+}
+
+// Channels returns the channels the contact is sent events on
+func (c *Contact) Channels() []Channel {
+	channels := []Channel{}
+	if c == nil {
+		return channels
+	}
+	if c.Email != "" {
+		channels = append(channels, Channel{Type: ChannelEmail, Target: c.Email})
+	}
+	if c.EscalationChannel != "" {
+		channels = append(channels, Channel{Type: ChannelSlack, Target: c.EscalationChannel})
+	}
+	return channels
+}
+
+// Rule routes the events of a type which match its filter to its channels
+type Rule struct {
+	ID        string `json:"id" dynamodbav:"Id"`
+	EventType string `json:"eventType" dynamodbav:"EventType"`
+	// Filter is matched against the attributes of the event, eg.
+	// {"pool": "ml"}.  Every key must have the given value.  A rule without
+	// a filter routes every event of its type.
+	Filter      map[string]string `json:"filter,omitempty" dynamodbav:"Filter,omitempty"`
+	Channels    []Channel         `json:"channels" dynamodbav:"Channels"`
+	Description string            `json:"description,omitempty" dynamodbav:"Description,omitempty"`
+	// Disabled rules aren't matched
+	Disabled       bool  `json:"disabled,omitempty" dynamodbav:"Disabled,omitempty"`
+	CreatedOn      int64 `json:"createdOn" dynamodbav:"CreatedOn"`
+	LastModifiedOn int64 `json:"lastModifiedOn" dynamodbav:"LastModifiedOn"`
+}
+
+// Validate the rule
+func (r *Rule) Validate() error {
+	eventTypes := []interface{}{}
+	for _, eventType := range EventTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	err := validation.ValidateStruct(r,
+		validation.Field(&r.EventType, validation.Required, validation.In(eventTypes...)),
+		validation.Field(&r.Channels, validation.Required),
+	)
+	if err != nil {
+		return errors.NewValidation("routing rule", err)
+	}
+	return nil
+}
+
+// Matches checks the rule routes the event
+func (r *Rule) Matches(event *Event) bool {
+	if r.Disabled || r.EventType != event.Type {
+		return false
+	}
+	for key, value := range r.Filter {
+		if event.Attributes[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Event is a notification which is routed to the channels of the rules it
+// matches
+type Event struct {
+	Type string
+	// Attributes are what rules filter on, eg. the account and lease IDs,
+	// and the metadata of the account and lease
+	Attributes map[string]string
+	// Contact owns the account the event is about.  The account pool's
+	// contact is used when it's nil.
+	Contact *Contact
+	// Summary is a single line, used as the email subject and alert summary
+	Summary string
+	// Text is the body of the notification
+	Text string
+}
+
+// Attributes merges the text values of the metadata of an account or lease
+// into the attributes of an event.  Attributes which are already set are
+// kept, so metadata can't override the IDs of the event.
+func Attributes(attributes map[string]string, metadata map[string]interface{}) map[string]string {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	for key, value := range metadata {
+		text, ok := value.(string)
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		if _, ok := attributes[key]; !ok {
+			attributes[key] = text
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,32 @@
+//
+
+package routingiface
+
+import (
+	"github.com/Optum/dce/pkg/routing"
+)
+
+// Servicer manages the notification routing rules, and routes events to
+// their channels
+type Servicer interface {
+	// Enabled returns whether events are routed
+	Enabled() bool
+	// List returns every rule
+	List() ([]*routing.Rule, error)
+	// Get returns a rule
+	Get(ID string) (*routing.Rule, error)
+	// Create adds a rule
+	Create(data *routing.Rule) (*routing.Rule, error)
+	// Update replaces a rule
+	Update(ID string, data *routing.Rule) (*routing.Rule, error)
+	// Delete removes a rule
+	Delete(ID string) (*routing.Rule, error)
+	// Route sends the event to the channels of every rule it matches
+	Route(event *routing.Event) error
+	// GetPoolContact returns the account pool's contact
+	GetPoolContact() (*routing.Contact, error)
+	// UpdatePoolContact changes the account pool's contact
+	UpdatePoolContact(contact *routing.Contact) (*routing.Contact, error)
+	// DeletePoolContact removes the account pool's contact
+	DeletePoolContact() error
+}
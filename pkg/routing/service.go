@@ -0,0 +1,439 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Optum/dce/pkg/alert"
+	"github.com/Optum/dce/pkg/alert/alertiface"
+	"github.com/Optum/dce/pkg/email"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/slack"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/caarlos0/env"
+	"github.com/google/uuid"
+)
+
+// rulesCacheTTL is how long the rules are reused for routing, so each event
+// doesn't scan the table
+const rulesCacheTTL = time.Minute
+
+// NewServiceInput are the items needed to create a new routing service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table of the routing rules.  Routing is
+	// disabled when empty.
+	TableName string `env:"NOTIFICATION_ROUTING_TABLE" envDefault:""`
+	SSM       ssmiface.SSMAPI
+	// PoolContactParameter is the SSM parameter the account pool's contact
+	// is stored in.  The pool has no contact when empty.
+	PoolContactParameter string `env:"POOL_CONTACT_PARAMETER" envDefault:""`
+	// FromEmail is the address routed emails are sent from
+	FromEmail string `env:"NOTIFICATION_FROM_EMAIL" envDefault:""`
+	// SlackBotToken is the bot token of the Slack app routed messages are
+	// posted with
+	SlackBotToken string `env:"SLACK_BOT_TOKEN" envDefault:""`
+	// Slack is optional, and overrides SlackBotToken
+	Slack    slack.Service
+	EmailSvc email.Service
+	AlertSvc alertiface.Servicer
+}
+
+// Service manages the routing rules, and routes events to their channels
+type Service struct {
+	dynamodb             dynamodbiface.DynamoDBAPI
+	tableName            string
+	ssm                  ssmiface.SSMAPI
+	poolContactParameter string
+	fromEmail            string
+	slack                slack.Service
+	emailSvc             email.Service
+	alertSvc             alertiface.Servicer
+	mutex                sync.Mutex
+	cached               []*Rule
+	cachedOn             time.Time
+}
+
+// Enabled returns whether events are routed
+func (s *Service) Enabled() bool {
+	return s.tableName != ""
+}
+
+// List returns every rule, ordered by event type then creation
+func (s *Service) List() ([]*Rule, error) {
+	if !s.Enabled() {
+		return []*Rule{}, nil
+	}
+
+	rules := []*Rule{}
+	var unmarshalErr error
+	err := s.dynamodb.ScanPages(&dynamodb.ScanInput{
+		TableName:      aws.String(s.tableName),
+		ConsistentRead: aws.Bool(true),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		items := []*Rule{}
+		unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items)
+		rules = append(rules, items...)
+		return unmarshalErr == nil
+	})
+	if err == nil {
+		err = unmarshalErr
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to scan the routing rules", err)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].EventType != rules[j].EventType {
+			return rules[i].EventType < rules[j].EventType
+		}
+		if rules[i].CreatedOn != rules[j].CreatedOn {
+			return rules[i].CreatedOn < rules[j].CreatedOn
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	return rules, nil
+}
+
+// Get returns a rule
+func (s *Service) Get(ID string) (*Rule, error) {
+	if !s.Enabled() {
+		return nil, errors.NewNotFound("routing rule", ID)
+	}
+
+	out, err := s.dynamodb.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(s.tableName),
+		Key:            map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(ID)}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to get routing rule %s", ID), err)
+	}
+	if len(out.Item) == 0 {
+		return nil, errors.NewNotFound("routing rule", ID)
+	}
+
+	rule := &Rule{}
+	err = dynamodbattribute.UnmarshalMap(out.Item, rule)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to read routing rule %s", ID), err)
+	}
+	return rule, nil
+}
+
+// Create adds a rule
+func (s *Service) Create(data *Rule) (*Rule, error) {
+	if !s.Enabled() {
+		return nil, errors.NewValidation("routing rule", fmt.Errorf("notification routing is not enabled"))
+	}
+	err := data.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	rule := *data
+	rule.ID = uuid.New().String()
+	rule.CreatedOn = now
+	rule.LastModifiedOn = now
+	err = s.put(&rule, aws.String("attribute_not_exists(Id)"))
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update replaces a rule
+func (s *Service) Update(ID string, data *Rule) (*Rule, error) {
+	existing, err := s.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+	err = data.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rule := *data
+	rule.ID = ID
+	rule.CreatedOn = existing.CreatedOn
+	rule.LastModifiedOn = time.Now().Unix()
+	err = s.put(&rule, aws.String("attribute_exists(Id)"))
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Delete removes a rule
+func (s *Service) Delete(ID string) (*Rule, error) {
+	if !s.Enabled() {
+		return nil, errors.NewNotFound("routing rule", ID)
+	}
+
+	out, err := s.dynamodb.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:    aws.String(s.tableName),
+		Key:          map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(ID)}},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to delete routing rule %s", ID), err)
+	}
+	if len(out.Attributes) == 0 {
+		return nil, errors.NewNotFound("routing rule", ID)
+	}
+
+	rule := &Rule{}
+	err = dynamodbattribute.UnmarshalMap(out.Attributes, rule)
+	if err != nil {
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to read routing rule %s", ID), err)
+	}
+	return rule, nil
+}
+
+func (s *Service) put(rule *Rule, condition *string) error {
+	item, err := dynamodbattribute.MarshalMap(rule)
+	if err != nil {
+		return errors.NewInternalServer("unable to marshal routing rule", err)
+	}
+	_, err = s.dynamodb.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                item,
+		ConditionExpression: condition,
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return errors.NewConflict("routing rule", rule.ID, fmt.Errorf("routing rule %s was changed", rule.ID))
+		}
+		return errors.NewInternalServer(fmt.Sprintf("failed to write routing rule %s", rule.ID), err)
+	}
+	return nil
+}
+
+// GetPoolContact returns the account pool's contact, which is nil until an
+// admin sets it
+func (s *Service) GetPoolContact() (*Contact, error) {
+	if s.poolContactParameter == "" {
+		return nil, nil
+	}
+
+	res, err := s.ssm.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(s.poolContactParameter),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get the pool contact", err)
+	}
+
+	contact := &Contact{}
+	err = json.Unmarshal([]byte(aws.StringValue(res.Parameter.Value)), contact)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to parse the pool contact", err)
+	}
+	return contact, nil
+}
+
+// UpdatePoolContact changes the account pool's contact, who is sent the
+// events of accounts without a contact
+func (s *Service) UpdatePoolContact(contact *Contact) (*Contact, error) {
+	if s.poolContactParameter == "" {
+		return nil, errors.NewServiceUnavailable("the pool contact is not configured")
+	}
+	err := contact.Validate()
+	if err != nil {
+		return nil, errors.NewValidation("contact", err)
+	}
+
+	value, err := json.Marshal(contact)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal the pool contact", err)
+	}
+	_, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(s.poolContactParameter),
+		Value:     aws.String(string(value)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to save the pool contact", err)
+	}
+	return contact, nil
+}
+
+// DeletePoolContact removes the account pool's contact
+func (s *Service) DeletePoolContact() error {
+	if s.poolContactParameter == "" {
+		return nil
+	}
+
+	_, err := s.ssm.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(s.poolContactParameter),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.NewInternalServer("failed to delete the pool contact", err)
+	}
+	return nil
+}
+
+// Route sends the event to the channels of every rule it matches.  Events
+// about the health of an account are also sent to the account's contact, or
+// the pool's when the account has none, and rules may filter on the
+// contact's team.  A channel named more than once is only sent the event
+// once.  Every channel is sent the event before the errors are returned.
+func (s *Service) Route(event *Event) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	sent := map[Channel]bool{}
+	errs := []error{}
+	route := func(channel Channel, by string) {
+		if sent[channel] {
+			return
+		}
+		sent[channel] = true
+		log.Printf("Routing %s event to %s %s, by %s", event.Type, channel.Type, channel.Target, by)
+		err := s.send(channel, event)
+		if err != nil {
+			log.Printf("Failed to route %s event to %s %s: %s", event.Type, channel.Type, channel.Target, err)
+			errs = append(errs, err)
+		}
+	}
+
+	if contactEventTypes[event.Type] {
+		contact := event.Contact
+		by := "the account's contact"
+		if contact.IsEmpty() {
+			var err error
+			contact, err = s.GetPoolContact()
+			if err != nil {
+				errs = append(errs, err)
+			}
+			by = "the pool's contact"
+		}
+		if contact != nil && contact.Team != "" {
+			event.Attributes = Attributes(event.Attributes, map[string]interface{}{"team": contact.Team})
+		}
+		for _, channel := range contact.Channels() {
+			route(channel, by)
+		}
+	}
+
+	rules, err := s.rules()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, rule := range rules {
+		if !rule.Matches(event) {
+			continue
+		}
+		for _, channel := range rule.Channels {
+			route(channel, "rule "+rule.ID)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewMultiError(fmt.Sprintf("failed to route %s event", event.Type), errs)
+	}
+	return nil
+}
+
+// rules returns the rules, reusing them for a while
+func (s *Service) rules() ([]*Rule, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cached != nil && time.Since(s.cachedOn) < rulesCacheTTL {
+		return s.cached, nil
+	}
+	rules, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	s.cached = rules
+	s.cachedOn = time.Now()
+	return rules, nil
+}
+
+func (s *Service) send(channel Channel, event *Event) error {
+	switch channel.Type {
+	case ChannelSlack:
+		if s.slack == nil {
+			return errors.NewValidation("routing rule", fmt.Errorf("a Slack bot token is required to post to %s", channel.Target))
+		}
+		return s.slack.PostMessage(&slack.Message{
+			Channel: channel.Target,
+			Text:    event.Summary + "\n\n" + event.Text,
+		})
+	case ChannelEmail:
+		if s.emailSvc == nil || s.fromEmail == "" {
+			return errors.NewValidation("routing rule", fmt.Errorf("a from email address is required to email %s", channel.Target))
+		}
+		return s.emailSvc.SendEmail(&email.SendEmailInput{
+			FromAddress: s.fromEmail,
+			ToAddresses: []string{channel.Target},
+			Subject:     event.Summary,
+			BodyHTML:    "<p>" + strings.ReplaceAll(html.EscapeString(event.Text), "\n", "<br>\n") + "</p>",
+			BodyText:    event.Text,
+		})
+	case ChannelAlert:
+		if s.alertSvc == nil {
+			return errors.NewValidation("routing rule", fmt.Errorf("an alert driver is required to open alerts"))
+		}
+		return s.alertSvc.Trigger(&alert.Alert{
+			Type:      alert.Type(event.Type),
+			AccountID: event.Attributes["accountId"],
+			Severity:  alert.Severity(channel.Target),
+			Summary:   event.Summary,
+			Details:   event.Attributes,
+		})
+	}
+	return errors.NewValidation("routing rule", fmt.Errorf("unknown channel type %q", channel.Type))
+}
+
+// NewService creates a new routing service
+func NewService(input NewServiceInput) *Service {
+	slackSvc := input.Slack
+	if slackSvc == nil && input.SlackBotToken != "" {
+		slackSvc = slack.NewClient(slack.NewClientInput{Token: input.SlackBotToken})
+	}
+	return &Service{
+		dynamodb:             input.DynamoDB,
+		tableName:            input.TableName,
+		ssm:                  input.SSM,
+		poolContactParameter: input.PoolContactParameter,
+		fromEmail:            input.FromEmail,
+		slack:                slackSvc,
+		emailSvc:             input.EmailSvc,
+		alertSvc:             input.AlertSvc,
+	}
+}
+
+// NewFromEnv creates a new routing service configured from environment variables
+func NewFromEnv(dynamodbSvc dynamodbiface.DynamoDBAPI, ssmSvc ssmiface.SSMAPI, emailSvc email.Service, alertSvc alertiface.Servicer) (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	input.DynamoDB = dynamodbSvc
+	input.SSM = ssmSvc
+	input.EmailSvc = emailSvc
+	input.AlertSvc = alertSvc
+	return NewService(input), nil
+}
@@ -0,0 +1,374 @@
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/alert"
+	alertmocks "github.com/Optum/dce/pkg/alert/alertiface/mocks"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/email"
+	emailmocks "github.com/Optum/dce/pkg/email/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/slack"
+	slackmocks "github.com/Optum/dce/pkg/slack/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func scanRules(mockDynamo *awsmocks.DynamoDBAPI, rules ...*Rule) {
+	items := []map[string]*dynamodb.AttributeValue{}
+	for _, rule := range rules {
+		item, _ := dynamodbattribute.MarshalMap(rule)
+		items = append(items, item)
+	}
+	mockDynamo.On("ScanPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*dynamodb.ScanOutput, bool) bool)
+			fn(&dynamodb.ScanOutput{Items: items}, true)
+		}).
+		Return(nil)
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		expErr string
+	}{
+		{
+			name: "valid",
+			rule: Rule{EventType: EventBudgetThreshold, Channels: []Channel{
+				{Type: ChannelSlack, Target: "#ml-infra"},
+				{Type: ChannelEmail, Target: "ml@example.com"},
+				{Type: ChannelAlert},
+			}},
+		},
+		{
+			name:   "unknown event type",
+			rule:   Rule{EventType: "LeaseCreated", Channels: []Channel{{Type: ChannelAlert}}},
+			expErr: "routing rule validation error: eventType: must be a valid value.",
+		},
+		{
+			name:   "no channels",
+			rule:   Rule{EventType: EventResetFailed},
+			expErr: "routing rule validation error: channels: cannot be blank.",
+		},
+		{
+			name:   "invalid email",
+			rule:   Rule{EventType: EventResetFailed, Channels: []Channel{{Type: ChannelEmail, Target: "on-call"}}},
+			expErr: "routing rule validation error: channels: (0: must be a valid email address.).",
+		},
+		{
+			name:   "unknown severity",
+			rule:   Rule{EventType: EventResetFailed, Channels: []Channel{{Type: ChannelAlert, Target: "page"}}},
+			expErr: "routing rule validation error: channels: (0: must be a valid value.).",
+		},
+		{
+			name:   "unknown channel type",
+			rule:   Rule{EventType: EventResetFailed, Channels: []Channel{{Type: "sms", Target: "555"}}},
+			expErr: "routing rule validation error: channels: (0: type must be one of slack, email or alert.).",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	event := &Event{Type: EventBudgetThreshold, Attributes: map[string]string{"accountId": "123456789012", "pool": "ml"}}
+
+	assert.True(t, (&Rule{EventType: EventBudgetThreshold}).Matches(event))
+	assert.True(t, (&Rule{EventType: EventBudgetThreshold, Filter: map[string]string{"pool": "ml"}}).Matches(event))
+	assert.False(t, (&Rule{EventType: EventBudgetThreshold, Filter: map[string]string{"pool": "web"}}).Matches(event))
+	assert.False(t, (&Rule{EventType: EventBudgetThreshold, Filter: map[string]string{"team": "ml"}}).Matches(event))
+	assert.False(t, (&Rule{EventType: EventResetFailed}).Matches(event))
+	assert.False(t, (&Rule{EventType: EventBudgetThreshold, Disabled: true}).Matches(event))
+}
+
+func TestAttributes(t *testing.T) {
+	attributes := Attributes(map[string]string{"accountId": "123456789012"}, map[string]interface{}{
+		"pool":      "ml",
+		"accountId": "999999999999",
+		"cost":      10,
+	})
+	assert.Equal(t, map[string]string{"accountId": "123456789012", "pool": "ml"}, attributes)
+}
+
+func TestCreate(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "NotificationRoutes" && *input.ConditionExpression == "attribute_not_exists(Id)"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "NotificationRoutes"})
+
+	rule, err := svc.Create(&Rule{
+		ID:        "ignored",
+		EventType: EventBudgetThreshold,
+		Filter:    map[string]string{"pool": "ml"},
+		Channels:  []Channel{{Type: ChannelSlack, Target: "#ml-infra"}},
+	})
+	require.Nil(t, err)
+	assert.NotEqual(t, "ignored", rule.ID)
+	assert.NotZero(t, rule.CreatedOn)
+	assert.Equal(t, rule.CreatedOn, rule.LastModifiedOn)
+
+	_, err = svc.Create(&Rule{EventType: EventBudgetThreshold})
+	assert.Equal(t, 400, errors.HTTPCodeForError(err))
+
+	_, err = NewService(NewServiceInput{}).Create(&Rule{})
+	assert.Equal(t, 400, errors.HTTPCodeForError(err))
+}
+
+func TestUpdate(t *testing.T) {
+	existing := &Rule{ID: "rule-1", EventType: EventResetFailed, Channels: []Channel{{Type: ChannelAlert}}, CreatedOn: 100}
+	item, _ := dynamodbattribute.MarshalMap(existing)
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+		return *input.Key["Id"].S == "rule-1"
+	})).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.ConditionExpression == "attribute_exists(Id)"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "NotificationRoutes"})
+
+	rule, err := svc.Update("rule-1", &Rule{EventType: EventResetFailed, Channels: []Channel{{Type: ChannelSlack, Target: "#on-call"}}})
+	require.Nil(t, err)
+	assert.Equal(t, "rule-1", rule.ID)
+	assert.Equal(t, int64(100), rule.CreatedOn)
+	assert.Equal(t, "#on-call", rule.Channels[0].Target)
+
+	_, err = svc.Update("missing", &Rule{EventType: EventResetFailed, Channels: []Channel{{Type: ChannelAlert}}})
+	assert.Equal(t, 404, errors.HTTPCodeForError(err))
+}
+
+func TestDelete(t *testing.T) {
+	item, _ := dynamodbattribute.MarshalMap(&Rule{ID: "rule-1", EventType: EventResetFailed})
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("DeleteItem", mock.MatchedBy(func(input *dynamodb.DeleteItemInput) bool {
+		return *input.Key["Id"].S == "rule-1"
+	})).Return(&dynamodb.DeleteItemOutput{Attributes: item}, nil)
+	mockDynamo.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "NotificationRoutes"})
+
+	rule, err := svc.Delete("rule-1")
+	require.Nil(t, err)
+	assert.Equal(t, "rule-1", rule.ID)
+
+	_, err = svc.Delete("missing")
+	assert.Equal(t, 404, errors.HTTPCodeForError(err))
+}
+
+func TestRoute(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	scanRules(mockDynamo,
+		&Rule{ID: "ml", EventType: EventBudgetThreshold, Filter: map[string]string{"pool": "ml"}, Channels: []Channel{
+			{Type: ChannelSlack, Target: "#ml-infra"},
+			{Type: ChannelEmail, Target: "ml@example.com"},
+		}},
+		&Rule{ID: "all", EventType: EventBudgetThreshold, Channels: []Channel{
+			{Type: ChannelSlack, Target: "#ml-infra"},
+			{Type: ChannelAlert, Target: "warning"},
+		}},
+		&Rule{ID: "web", EventType: EventBudgetThreshold, Filter: map[string]string{"pool": "web"}, Channels: []Channel{
+			{Type: ChannelSlack, Target: "#web"},
+		}},
+		&Rule{ID: "reset", EventType: EventResetFailed, Channels: []Channel{
+			{Type: ChannelAlert},
+		}},
+	)
+	slackSvc := &slackmocks.Service{}
+	slackSvc.On("PostMessage", mock.AnythingOfType("*slack.Message")).Return(nil)
+	emailSvc := &emailmocks.Service{}
+	emailSvc.On("SendEmail", mock.AnythingOfType("*email.SendEmailInput")).Return(fmt.Errorf("throttled"))
+	alertSvc := &alertmocks.Servicer{}
+	alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+
+	svc := NewService(NewServiceInput{
+		DynamoDB:  mockDynamo,
+		TableName: "NotificationRoutes",
+		FromEmail: "dce@example.com",
+		Slack:     slackSvc,
+		EmailSvc:  emailSvc,
+		AlertSvc:  alertSvc,
+	})
+
+	event := &Event{
+		Type:       EventBudgetThreshold,
+		Attributes: map[string]string{"accountId": "123456789012", "pool": "ml"},
+		Summary:    "DCE lease at 75% of budget [123456789012]",
+		Text:       "Lease for principal jdoe\nhas exceeded the 75% threshold",
+	}
+	err := svc.Route(event)
+
+	// Every channel is sent the event, even though the email failed
+	assert.EqualError(t, err, "failed to route BudgetThreshold event: throttled")
+	slackSvc.AssertNumberOfCalls(t, "PostMessage", 1)
+	slackSvc.AssertCalled(t, "PostMessage", &slack.Message{
+		Channel: "#ml-infra",
+		Text:    "DCE lease at 75% of budget [123456789012]\n\nLease for principal jdoe\nhas exceeded the 75% threshold",
+	})
+	emailSvc.AssertCalled(t, "SendEmail", &email.SendEmailInput{
+		FromAddress: "dce@example.com",
+		ToAddresses: []string{"ml@example.com"},
+		Subject:     "DCE lease at 75% of budget [123456789012]",
+		BodyHTML:    "<p>Lease for principal jdoe<br>\nhas exceeded the 75% threshold</p>",
+		BodyText:    "Lease for principal jdoe\nhas exceeded the 75% threshold",
+	})
+	alertSvc.AssertCalled(t, "Trigger", &alert.Alert{
+		Type:      alert.Type(EventBudgetThreshold),
+		AccountID: "123456789012",
+		Severity:  alert.SeverityWarning,
+		Summary:   "DCE lease at 75% of budget [123456789012]",
+		Details:   map[string]string{"accountId": "123456789012", "pool": "ml"},
+	})
+
+	// The rules are reused
+	err = svc.Route(&Event{Type: EventResetFailed, Attributes: map[string]string{"accountId": "123456789012"}})
+	assert.Nil(t, err)
+	mockDynamo.AssertNumberOfCalls(t, "ScanPages", 1)
+	alertSvc.AssertNumberOfCalls(t, "Trigger", 2)
+}
+
+func TestRouteDisabled(t *testing.T) {
+	svc := NewService(NewServiceInput{})
+	assert.False(t, svc.Enabled())
+	assert.Nil(t, svc.Route(&Event{Type: EventResetFailed}))
+
+	rules, err := svc.List()
+	assert.Nil(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestRouteChannelNotConfigured(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	scanRules(mockDynamo, &Rule{ID: "reset", EventType: EventResetFailed, Channels: []Channel{
+		{Type: ChannelSlack, Target: "#on-call"},
+	}})
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "NotificationRoutes"})
+
+	err := svc.Route(&Event{Type: EventResetFailed})
+	assert.EqualError(t, err, "failed to route ResetFailed event: routing rule validation error: a Slack bot token is required to post to #on-call")
+}
+
+func TestContactValidate(t *testing.T) {
+	assert.Nil(t, Contact{Email: "ml@example.com", Team: "ml-platform", EscalationChannel: "#ml-oncall"}.Validate())
+	assert.Nil(t, Contact{}.Validate())
+	assert.EqualError(t, Contact{Email: "ml-platform"}.Validate(), "email: must be a valid email address.")
+}
+
+func TestRouteToContact(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	scanRules(mockDynamo,
+		&Rule{ID: "ml", EventType: EventAccountUnreachable, Filter: map[string]string{"team": "ml-platform"}, Channels: []Channel{
+			{Type: ChannelSlack, Target: "#ml-oncall"},
+			{Type: ChannelAlert},
+		}},
+	)
+	mockSSM := &awsmocks.SSMAPI{}
+	mockSSM.On("GetParameter", mock.Anything).Return(&ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{Value: aws.String(`{"email": "cloud@example.com", "team": "cloud"}`)},
+	}, nil)
+	slackSvc := &slackmocks.Service{}
+	slackSvc.On("PostMessage", mock.AnythingOfType("*slack.Message")).Return(nil)
+	emailSvc := &emailmocks.Service{}
+	emailSvc.On("SendEmail", mock.AnythingOfType("*email.SendEmailInput")).Return(nil)
+	alertSvc := &alertmocks.Servicer{}
+	alertSvc.On("Trigger", mock.AnythingOfType("*alert.Alert")).Return(nil)
+
+	svc := NewService(NewServiceInput{
+		DynamoDB:             mockDynamo,
+		TableName:            "NotificationRoutes",
+		SSM:                  mockSSM,
+		PoolContactParameter: "/dce/pool_contact",
+		FromEmail:            "dce@example.com",
+		Slack:                slackSvc,
+		EmailSvc:             emailSvc,
+		AlertSvc:             alertSvc,
+	})
+
+	t.Run("should send the event to the account's contact", func(t *testing.T) {
+		err := svc.Route(&Event{
+			Type:       EventAccountUnreachable,
+			Attributes: map[string]string{"accountId": "123456789012"},
+			Contact:    &Contact{Team: "ml-platform", EscalationChannel: "#ml-oncall"},
+			Summary:    "DCE account 123456789012 is unreachable",
+		})
+		assert.Nil(t, err)
+		// The rule filters on the contact's team, but its channel is only
+		// sent the event once
+		slackSvc.AssertNumberOfCalls(t, "PostMessage", 1)
+		slackSvc.AssertCalled(t, "PostMessage", &slack.Message{Channel: "#ml-oncall", Text: "DCE account 123456789012 is unreachable\n\n"})
+		alertSvc.AssertNumberOfCalls(t, "Trigger", 1)
+		mockSSM.AssertNotCalled(t, "GetParameter", mock.Anything)
+	})
+
+	t.Run("should send the event to the pool's contact", func(t *testing.T) {
+		err := svc.Route(&Event{
+			Type:       EventResetFailed,
+			Attributes: map[string]string{"accountId": "123456789012"},
+			Contact:    &Contact{},
+			Summary:    "DCE failed to reset account 123456789012",
+		})
+		assert.Nil(t, err)
+		emailSvc.AssertCalled(t, "SendEmail", mock.MatchedBy(func(input *email.SendEmailInput) bool {
+			return input.ToAddresses[0] == "cloud@example.com"
+		}))
+		alertSvc.AssertNumberOfCalls(t, "Trigger", 1)
+	})
+
+	t.Run("should not send budget notifications to contacts", func(t *testing.T) {
+		err := svc.Route(&Event{
+			Type:    EventBudgetThreshold,
+			Contact: &Contact{Email: "ml@example.com"},
+		})
+		assert.Nil(t, err)
+		emailSvc.AssertNumberOfCalls(t, "SendEmail", 1)
+	})
+}
+
+func TestUpdatePoolContact(t *testing.T) {
+	mockSSM := &awsmocks.SSMAPI{}
+	mockSSM.On("PutParameter", mock.MatchedBy(func(input *ssm.PutParameterInput) bool {
+		return *input.Name == "/dce/pool_contact" && *input.Overwrite &&
+			*input.Value == `{"email":"cloud@example.com","escalationChannel":"#cloud-oncall"}`
+	})).Return(&ssm.PutParameterOutput{}, nil)
+	svc := NewService(NewServiceInput{SSM: mockSSM, PoolContactParameter: "/dce/pool_contact"})
+
+	contact, err := svc.UpdatePoolContact(&Contact{Email: "cloud@example.com", EscalationChannel: "#cloud-oncall"})
+	require.Nil(t, err)
+	assert.Equal(t, "cloud@example.com", contact.Email)
+
+	_, err = svc.UpdatePoolContact(&Contact{Email: "cloud"})
+	assert.Equal(t, 400, errors.HTTPCodeForError(err))
+	mockSSM.AssertNumberOfCalls(t, "PutParameter", 1)
+
+	_, err = NewService(NewServiceInput{}).UpdatePoolContact(&Contact{Email: "cloud@example.com"})
+	assert.Equal(t, 503, errors.HTTPCodeForError(err))
+}
+
+func TestGetFailure(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("GetItem", mock.Anything).Return(nil, awserr.New("InternalServerError", "failure", nil))
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "NotificationRoutes", SlackBotToken: "xoxb"})
+
+	_, err := svc.Get("rule-1")
+	assert.Equal(t, 500, errors.HTTPCodeForError(err))
+	_, ok := svc.slack.(*slack.Client)
+	assert.True(t, ok)
+}
@@ -0,0 +1,99 @@
+// Package settings stores system-wide configuration which operators can
+// change with the API, eg. the default budgets and the budget notification
+// thresholds, without redeploying DCE's Lambdas.  Settings which aren't set
+// fall back to the Lambdas' environment variables.
+package settings
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Optum/dce/pkg/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+var flagPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{0,63}$`)
+
+// FlagSlackBudgetNotifications turns the Slack messages sent with the
+// budget notifications of leases on and off.  They're on by default.
+const FlagSlackBudgetNotifications = "slackBudgetNotifications"
+
+// Settings are the system-wide configuration.  Zero values use the
+// configuration the Lambdas were deployed with.
+type Settings struct {
+	// PrincipalBudgetAmount is how much a principal may spend in each
+	// budget period, across their leases
+	PrincipalBudgetAmount float64 `json:"principalBudgetAmount,omitempty" dynamodbav:"PrincipalBudgetAmount,omitempty"`
+	// MaxLeaseBudgetAmount is the largest budget of a lease, and the budget
+	// of leases created without one
+	MaxLeaseBudgetAmount float64 `json:"maxLeaseBudgetAmount,omitempty" dynamodbav:"MaxLeaseBudgetAmount,omitempty"`
+	// BudgetNotificationThresholdPercentiles are the percentages of their
+	// budgets leases are sent budget notifications at, eg. [75, 100]
+	BudgetNotificationThresholdPercentiles []float64 `json:"budgetNotificationThresholdPercentiles,omitempty" dynamodbav:"BudgetNotificationThresholdPercentiles,omitempty"`
+	// BudgetNotificationEmails are sent the budget notifications of leases
+	// created without any budget notification emails
+	BudgetNotificationEmails []string `json:"budgetNotificationEmails,omitempty" dynamodbav:"BudgetNotificationEmails,omitempty"`
+	// FeatureFlags turn features of integrations on and off, eg.
+	// {"slackBudgetNotifications": false}
+	FeatureFlags   map[string]bool `json:"featureFlags,omitempty" dynamodbav:"FeatureFlags,omitempty"`
+	LastModifiedOn int64           `json:"lastModifiedOn,omitempty" dynamodbav:"LastModifiedOn,omitempty"`
+}
+
+// Validate the settings
+func (s *Settings) Validate() error {
+	err := validation.ValidateStruct(s,
+		validation.Field(&s.PrincipalBudgetAmount, validation.Min(0.0)),
+		validation.Field(&s.MaxLeaseBudgetAmount, validation.Min(0.0)),
+		validation.Field(&s.BudgetNotificationThresholdPercentiles, validation.By(isPercentiles)),
+		validation.Field(&s.BudgetNotificationEmails, validation.By(isEmails)),
+		validation.Field(&s.FeatureFlags, validation.By(isFlags)),
+	)
+	if err != nil {
+		return errors.NewValidation("settings", err)
+	}
+	return nil
+}
+
+// Flag returns whether the feature flag is on, or the default when it isn't
+// set
+func (s *Settings) Flag(name string, defaultValue bool) bool {
+	if s == nil {
+		return defaultValue
+	}
+	value, ok := s.FeatureFlags[name]
+	if !ok {
+		return defaultValue
+	}
+	return value
+}
+
+func isPercentiles(value interface{}) error {
+	percentiles, _ := value.([]float64)
+	for _, percentile := range percentiles {
+		if percentile <= 0 || percentile > 100 {
+			return fmt.Errorf("must be more than 0 and at most 100")
+		}
+	}
+	return nil
+}
+
+func isEmails(value interface{}) error {
+	emails, _ := value.([]string)
+	for _, email := range emails {
+		if err := is.Email.Validate(email); err != nil || email == "" {
+			return fmt.Errorf("%q must be a valid email address", email)
+		}
+	}
+	return nil
+}
+
+func isFlags(value interface{}) error {
+	flags, _ := value.(map[string]bool)
+	for name := range flags {
+		if !flagPattern.MatchString(name) {
+			return fmt.Errorf("%q must start with a letter, and have at most 64 letters, digits, '.', '_' and '-'", name)
+		}
+	}
+	return nil
+}
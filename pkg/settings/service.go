@@ -0,0 +1,149 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/caarlos0/env"
+)
+
+// cacheTTL is how long the settings are reused, so each lease and budget
+// check doesn't read the table.  Changes take up to this long to apply.
+const cacheTTL = time.Minute
+
+// settingsID is the key of the settings item
+const settingsID = "system"
+
+// NewServiceInput are the items needed to create a new settings service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table of the settings.  The settings can't
+	// be changed when empty.
+	TableName string `env:"SETTINGS_TABLE" envDefault:""`
+}
+
+// Service reads and changes the settings
+type Service struct {
+	dynamodb  dynamodbiface.DynamoDBAPI
+	tableName string
+	mutex     sync.Mutex
+	cached    *Settings
+	cachedOn  time.Time
+}
+
+// Enabled returns whether the settings can be changed
+func (s *Service) Enabled() bool {
+	return s.tableName != ""
+}
+
+// Get reads the settings, which are empty until an operator changes them
+func (s *Service) Get() (*Settings, error) {
+	if !s.Enabled() {
+		return &Settings{}, nil
+	}
+
+	out, err := s.dynamodb.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(s.tableName),
+		Key:            map[string]*dynamodb.AttributeValue{"Id": {S: aws.String(settingsID)}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to get the settings", err)
+	}
+
+	settings := &Settings{}
+	err = dynamodbattribute.UnmarshalMap(out.Item, settings)
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to read the settings", err)
+	}
+	return settings, nil
+}
+
+// Current returns the settings, reusing them for a minute after they're read
+func (s *Service) Current() (*Settings, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cached != nil && time.Since(s.cachedOn) < cacheTTL {
+		return s.cached, nil
+	}
+	settings, err := s.Get()
+	if err != nil {
+		return nil, err
+	}
+	s.cached = settings
+	s.cachedOn = time.Now()
+	return settings, nil
+}
+
+// Update replaces the settings.  When the settings have a lastModifiedOn,
+// they're only replaced if they haven't changed since, so operators don't
+// overwrite each other's changes.
+func (s *Service) Update(data *Settings) (*Settings, error) {
+	if !s.Enabled() {
+		return nil, errors.NewServiceUnavailable("the settings are not configured")
+	}
+	err := data.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := *data
+	settings.LastModifiedOn = time.Now().Unix()
+	item, err := dynamodbattribute.MarshalMap(&settings)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal the settings", err)
+	}
+	item["Id"] = &dynamodb.AttributeValue{S: aws.String(settingsID)}
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	}
+	if data.LastModifiedOn != 0 {
+		input.ConditionExpression = aws.String("LastModifiedOn = :lastModifiedOn")
+		input.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{
+			":lastModifiedOn": {N: aws.String(strconv.FormatInt(data.LastModifiedOn, 10))},
+		}
+	}
+	_, err = s.dynamodb.PutItem(input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, errors.NewConflict("settings", settingsID, fmt.Errorf("the settings were changed since %d", data.LastModifiedOn))
+		}
+		return nil, errors.NewInternalServer("failed to save the settings", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cached = &settings
+	s.cachedOn = time.Now()
+	return &settings, nil
+}
+
+// NewService creates a new settings service
+func NewService(input NewServiceInput) *Service {
+	return &Service{
+		dynamodb:  input.DynamoDB,
+		tableName: input.TableName,
+	}
+}
+
+// NewFromEnv creates a new settings service configured from environment
+// variables
+func NewFromEnv(dynamodbSvc dynamodbiface.DynamoDBAPI) (*Service, error) {
+	input := NewServiceInput{}
+	err := env.Parse(&input)
+	if err != nil {
+		return nil, err
+	}
+	input.DynamoDB = dynamodbSvc
+	return NewService(input), nil
+}
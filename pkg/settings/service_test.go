@@ -0,0 +1,166 @@
+package settings
+
+import (
+	"testing"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		expErr   string
+	}{
+		{
+			name: "valid",
+			settings: Settings{
+				PrincipalBudgetAmount:                  1000,
+				MaxLeaseBudgetAmount:                   250,
+				BudgetNotificationThresholdPercentiles: []float64{75, 100},
+				BudgetNotificationEmails:               []string{"finops@example.com"},
+				FeatureFlags:                           map[string]bool{FlagSlackBudgetNotifications: true},
+			},
+		},
+		{
+			name:     "negative budget",
+			settings: Settings{MaxLeaseBudgetAmount: -1},
+			expErr:   "settings validation error: maxLeaseBudgetAmount: must be no less than 0.",
+		},
+		{
+			name:     "threshold above 100",
+			settings: Settings{BudgetNotificationThresholdPercentiles: []float64{75, 150}},
+			expErr:   "settings validation error: budgetNotificationThresholdPercentiles: must be more than 0 and at most 100.",
+		},
+		{
+			name:     "invalid email",
+			settings: Settings{BudgetNotificationEmails: []string{"finops"}},
+			expErr:   "settings validation error: budgetNotificationEmails: \"finops\" must be a valid email address.",
+		},
+		{
+			name:     "invalid flag",
+			settings: Settings{FeatureFlags: map[string]bool{"slack buttons": true}},
+			expErr:   "settings validation error: featureFlags: \"slack buttons\" must start with a letter, and have at most 64 letters, digits, '.', '_' and '-'.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var none *Settings
+	assert.True(t, none.Flag(FlagSlackBudgetNotifications, true))
+
+	settings := &Settings{FeatureFlags: map[string]bool{FlagSlackBudgetNotifications: false}}
+	assert.False(t, settings.Flag(FlagSlackBudgetNotifications, true))
+	assert.True(t, settings.Flag("other", true))
+}
+
+func TestCurrent(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+		return *input.TableName == "Settings" && *input.Key["Id"].S == "system"
+	})).Return(&dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"Id":                    {S: aws.String("system")},
+		"PrincipalBudgetAmount": {N: aws.String("500")},
+	}}, nil).Once()
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Settings"})
+
+	settings, err := svc.Current()
+	require.Nil(t, err)
+	assert.Equal(t, 500.0, settings.PrincipalBudgetAmount)
+
+	// The settings are reused
+	settings, err = svc.Current()
+	require.Nil(t, err)
+	assert.Equal(t, 500.0, settings.PrincipalBudgetAmount)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestCurrentWithoutTable(t *testing.T) {
+	svc := NewService(NewServiceInput{})
+
+	settings, err := svc.Current()
+	require.Nil(t, err)
+	assert.Equal(t, &Settings{}, settings)
+
+	_, err = svc.Update(&Settings{})
+	assert.EqualError(t, err, "the settings are not configured")
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		settings     *Settings
+		dynamoErr    error
+		expCondition bool
+		expErr       string
+	}{
+		{
+			name:     "should replace the settings",
+			settings: &Settings{MaxLeaseBudgetAmount: 250},
+		},
+		{
+			name:         "should replace the settings when they haven't changed",
+			settings:     &Settings{MaxLeaseBudgetAmount: 250, LastModifiedOn: 1583053200},
+			expCondition: true,
+		},
+		{
+			name:         "should conflict when the settings have changed",
+			settings:     &Settings{MaxLeaseBudgetAmount: 250, LastModifiedOn: 1583053200},
+			dynamoErr:    awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "failed", nil),
+			expCondition: true,
+			expErr:       "operation cannot be fulfilled on settings \"system\": the settings were changed since 1583053200",
+		},
+		{
+			name:     "should fail on invalid settings",
+			settings: &Settings{MaxLeaseBudgetAmount: -250},
+			expErr:   "settings validation error: maxLeaseBudgetAmount: must be no less than 0.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDynamo := &awsmocks.DynamoDBAPI{}
+			mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+				if tt.expCondition != (input.ConditionExpression != nil) {
+					return false
+				}
+				return *input.TableName == "Settings" &&
+					*input.Item["Id"].S == "system" &&
+					*input.Item["MaxLeaseBudgetAmount"].N == "250"
+			})).Return(&dynamodb.PutItemOutput{}, tt.dynamoErr)
+			svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Settings"})
+
+			settings, err := svc.Update(tt.settings)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			require.Nil(t, err)
+			assert.NotZero(t, settings.LastModifiedOn)
+			mockDynamo.AssertExpectations(t)
+
+			// The settings are reused without reading them
+			current, err := svc.Current()
+			require.Nil(t, err)
+			assert.Equal(t, settings, current)
+			mockDynamo.AssertNotCalled(t, "GetItem", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//
+
+package settingsiface
+
+import (
+	"github.com/Optum/dce/pkg/settings"
+)
+
+// Servicer reads and changes the system-wide settings
+type Servicer interface {
+	// Enabled returns whether the settings can be changed
+	Enabled() bool
+	// Get reads the settings
+	Get() (*settings.Settings, error)
+	// Current returns the settings, reusing them for a while after they're
+	// read
+	Current() (*settings.Settings, error)
+	// Update replaces the settings
+	Update(data *settings.Settings) (*settings.Settings, error)
+}
@@ -0,0 +1,257 @@
+// Package simulation projects how the account pool behaves under a given
+// lease demand, for capacity planning.  The pool is modelled hour by hour:
+// lease requests take Ready accounts first come first served, leases end
+// after their average duration, and their accounts are reset, at most
+// ResetConcurrency at a time, before they're Ready again.
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	// MaxDays is the longest period which can be simulated
+	MaxDays = 365
+	// defaultDays is the period simulated when none is given
+	defaultDays = 90
+	// epsilon is the fraction of a request or account treated as none, as
+	// demand is modelled as a continuous flow
+	epsilon = 1e-6
+)
+
+// Input is the projected lease demand, and the pool it's simulated against
+type Input struct {
+	// RequestsPerDay is the number of leases requested each day
+	RequestsPerDay float64 `json:"requestsPerDay"`
+	// AverageLeaseDays is how long leases last, on average
+	AverageLeaseDays float64 `json:"averageLeaseDays"`
+	// MonthlyGrowthPercent grows RequestsPerDay every 30 days, eg. 10 for
+	// 10% more requests each month
+	MonthlyGrowthPercent float64 `json:"monthlyGrowthPercent,omitempty"`
+	// Days is the period to simulate, 90 days by default
+	Days int `json:"days,omitempty"`
+	// PoolSize overrides the number of accounts in the pool
+	PoolSize *int `json:"poolSize,omitempty"`
+	// ResetHours overrides how long an account takes to reset
+	ResetHours *float64 `json:"resetHours,omitempty"`
+	// ResetConcurrency overrides how many accounts are reset at once
+	ResetConcurrency *int `json:"resetConcurrency,omitempty"`
+}
+
+// Validate the simulation input
+func (i *Input) Validate() error {
+	err := validation.ValidateStruct(i,
+		validation.Field(&i.RequestsPerDay, validation.Required, validation.Min(0.0)),
+		validation.Field(&i.AverageLeaseDays, validation.Required, validation.Min(0.0)),
+		validation.Field(&i.MonthlyGrowthPercent, validation.Min(-100.0)),
+		validation.Field(&i.Days, validation.Min(0), validation.Max(MaxDays)),
+		validation.Field(&i.PoolSize, validation.Min(0)),
+		validation.Field(&i.ResetHours, validation.Min(0.0)),
+		validation.Field(&i.ResetConcurrency, validation.Min(1)),
+	)
+	if err != nil {
+		return errors.NewValidation("simulation", err)
+	}
+	return nil
+}
+
+// Pool is the state of the account pool the simulation starts from
+type Pool struct {
+	Ready    int `json:"ready"`
+	Leased   int `json:"leased"`
+	NotReady int `json:"notReady"`
+	// ResetHours is how long an account takes to reset
+	ResetHours float64 `json:"resetHours"`
+	// ResetConcurrency is how many accounts are reset at once
+	ResetConcurrency int `json:"resetConcurrency"`
+}
+
+// Size is the number of accounts which can be leased
+func (p *Pool) Size() int {
+	return p.Ready + p.Leased + p.NotReady
+}
+
+// Day is the state of the pool at the end of a simulated day
+type Day struct {
+	Date      string  `json:"date"`
+	Requests  float64 `json:"requests"`
+	Ready     float64 `json:"ready"`
+	Leased    float64 `json:"leased"`
+	Resetting float64 `json:"resetting"`
+	Waiting   float64 `json:"waiting"`
+}
+
+// Result is how the pool is expected to behave
+type Result struct {
+	// Pool is the pool which was simulated
+	Pool Pool `json:"pool"`
+	// ExhaustedOn is the first date a request has to wait for an account,
+	// or empty if none do
+	ExhaustedOn *string `json:"exhaustedOn"`
+	// AverageWaitHours is how long requests wait for an account, on average
+	AverageWaitHours float64 `json:"averageWaitHours"`
+	// MaxWaitHours is the longest a request waits for an account
+	MaxWaitHours float64 `json:"maxWaitHours"`
+	// UnservedRequests are still waiting at the end of the simulation
+	UnservedRequests float64 `json:"unservedRequests"`
+	// RequiredPoolSize is the fewest accounts which serve the peak demand
+	// without waiting
+	RequiredPoolSize int `json:"requiredPoolSize"`
+	// ResetCapacityPerDay is the most accounts which can be reset each day
+	ResetCapacityPerDay float64 `json:"resetCapacityPerDay"`
+	// ResetBound is true when the peak demand is more than the resets can
+	// keep up with, so more accounts only delay the pool being exhausted
+	ResetBound bool  `json:"resetBound"`
+	Days       []Day `json:"days"`
+}
+
+// Run simulates the pool under the projected demand, starting at the given
+// time.  Overrides in the input replace the pool's size and reset throughput.
+func Run(input *Input, pool Pool, start time.Time) (*Result, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	days := input.Days
+	if days == 0 {
+		days = defaultDays
+	}
+	if input.PoolSize != nil {
+		if *input.PoolSize < pool.Leased+pool.NotReady {
+			return nil, errors.NewValidation("simulation",
+				fmt.Errorf("poolSize: must be at least the %d accounts which are leased or resetting", pool.Leased+pool.NotReady))
+		}
+		// Accounts are added or removed as Ready
+		pool.Ready = *input.PoolSize - pool.Leased - pool.NotReady
+	}
+	if input.ResetHours != nil {
+		pool.ResetHours = *input.ResetHours
+	}
+	if input.ResetConcurrency != nil {
+		pool.ResetConcurrency = *input.ResetConcurrency
+	}
+	if pool.ResetConcurrency < 1 {
+		pool.ResetConcurrency = 1
+	}
+
+	leaseHours := int(math.Max(1, math.Round(input.AverageLeaseDays*24)))
+	resetHours := int(math.Max(1, math.Round(pool.ResetHours)))
+	hours := days * 24
+
+	// Accounts leased, and reset, in each hour finish leaseHours, and
+	// resetHours, later.  Leases which are already Active end evenly over
+	// the next leaseHours.
+	leaseEnds := make([]float64, hours+leaseHours+1)
+	for h := 0; h < leaseHours; h++ {
+		leaseEnds[h] += float64(pool.Leased) / float64(leaseHours)
+	}
+	resetEnds := make([]float64, hours+resetHours+1)
+	// Accounts which are resetting are assumed to be part way through
+	resetQueue := float64(pool.NotReady)
+
+	ready := float64(pool.Ready)
+	leased := float64(pool.Leased)
+	resetting := 0.0
+	waiting := 0.0
+	arrived := make([]float64, hours)
+	served := make([]float64, hours)
+	cumArrived, cumServed := 0.0, 0.0
+	peakRequestsPerDay := input.RequestsPerDay
+	result := &Result{Pool: pool, Days: []Day{}}
+	day := Day{}
+
+	for h := 0; h < hours; h++ {
+		// Leases end, and their accounts wait to be reset
+		leased -= leaseEnds[h]
+		resetQueue += leaseEnds[h]
+
+		// Resets finish, and new ones start as others finish
+		resetting -= resetEnds[h]
+		ready += resetEnds[h]
+		started := math.Min(resetQueue, float64(pool.ResetConcurrency)-resetting)
+		if started > 0 {
+			resetQueue -= started
+			resetting += started
+			resetEnds[h+resetHours] += started
+		}
+
+		// Requests take Ready accounts, oldest first
+		requestsPerDay := input.RequestsPerDay * math.Pow(1+input.MonthlyGrowthPercent/100, float64(h)/(30*24))
+		peakRequestsPerDay = math.Max(peakRequestsPerDay, requestsPerDay)
+		requests := requestsPerDay / 24
+		waiting += requests
+		leasing := math.Min(waiting, ready)
+		waiting -= leasing
+		ready -= leasing
+		leased += leasing
+		leaseEnds[h+leaseHours] += leasing
+		if waiting > epsilon && result.ExhaustedOn == nil {
+			exhaustedOn := start.Add(time.Duration(h) * time.Hour).UTC().Format("2006-01-02")
+			result.ExhaustedOn = &exhaustedOn
+		}
+
+		cumArrived += requests
+		cumServed += leasing
+		arrived[h] = cumArrived
+		served[h] = cumServed
+
+		day.Requests += requests
+		if h%24 == 23 {
+			day.Date = start.Add(time.Duration(h) * time.Hour).UTC().Format("2006-01-02")
+			day.Ready = round(ready)
+			day.Leased = round(leased)
+			day.Resetting = round(resetting + resetQueue)
+			day.Waiting = round(waiting)
+			day.Requests = round(day.Requests)
+			result.Days = append(result.Days, day)
+			day = Day{}
+		}
+	}
+
+	result.AverageWaitHours, result.MaxWaitHours = waitHours(arrived, served)
+	result.UnservedRequests = round(waiting)
+	result.ResetCapacityPerDay = round(float64(pool.ResetConcurrency) * 24 / float64(resetHours))
+	result.ResetBound = peakRequestsPerDay > result.ResetCapacityPerDay
+	// Each request holds an account for its lease, then its reset
+	result.RequiredPoolSize = int(math.Ceil(peakRequestsPerDay*(float64(leaseHours)+float64(resetHours))/24 - epsilon))
+	return result, nil
+}
+
+// waitHours is the average and longest time requests wait for an account,
+// first come first served.  Requests which arrive in an hour are served once
+// as many requests have been served as had arrived.  Requests which are never
+// served aren't counted.
+func waitHours(arrived []float64, served []float64) (float64, float64) {
+	total, count, longest := 0.0, 0.0, 0.0
+	s := 0
+	prevArrived := 0.0
+	for h, a := range arrived {
+		requests := a - prevArrived
+		prevArrived = a
+		for s < len(served) && served[s] < a-epsilon {
+			s++
+		}
+		if s == len(served) {
+			break
+		}
+		wait := float64(s - h)
+		total += wait * requests
+		count += requests
+		longest = math.Max(longest, wait)
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return round(total / count), longest
+}
+
+// round to 2 decimal places
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRun(t *testing.T) {
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	pool := Pool{Ready: 50, Leased: 0, NotReady: 0, ResetHours: 2, ResetConcurrency: 10}
+
+	t.Run("a large enough pool is never exhausted", func(t *testing.T) {
+		result, err := Run(&Input{RequestsPerDay: 5, AverageLeaseDays: 7, Days: 30}, pool, start)
+		require.Nil(t, err)
+		assert.Nil(t, result.ExhaustedOn)
+		assert.Equal(t, 0.0, result.AverageWaitHours)
+		assert.Equal(t, 0.0, result.UnservedRequests)
+		assert.Equal(t, 36, result.RequiredPoolSize)
+		assert.Equal(t, 120.0, result.ResetCapacityPerDay)
+		assert.False(t, result.ResetBound)
+		assert.Len(t, result.Days, 30)
+		assert.Equal(t, "2020-03-01", result.Days[0].Date)
+		assert.Equal(t, 5.0, result.Days[0].Requests)
+	})
+
+	t.Run("a small pool is exhausted and requests wait", func(t *testing.T) {
+		result, err := Run(&Input{RequestsPerDay: 10, AverageLeaseDays: 7, Days: 30}, pool, start)
+		require.Nil(t, err)
+		require.NotNil(t, result.ExhaustedOn)
+		assert.Equal(t, "2020-03-06", *result.ExhaustedOn)
+		assert.True(t, result.AverageWaitHours > 0)
+		assert.True(t, result.MaxWaitHours >= result.AverageWaitHours)
+		assert.Equal(t, 71, result.RequiredPoolSize)
+	})
+
+	t.Run("the required pool size isn't exhausted", func(t *testing.T) {
+		result, err := Run(&Input{RequestsPerDay: 10, AverageLeaseDays: 7, Days: 60, PoolSize: intPtr(71)}, pool, start)
+		require.Nil(t, err)
+		assert.Nil(t, result.ExhaustedOn)
+		assert.Equal(t, 71, result.Pool.Ready)
+	})
+
+	t.Run("demand past the reset capacity is reset bound", func(t *testing.T) {
+		slowResets := pool
+		slowResets.ResetHours = 24
+		slowResets.ResetConcurrency = 1
+		result, err := Run(&Input{RequestsPerDay: 2, AverageLeaseDays: 1, Days: 30}, slowResets, start)
+		require.Nil(t, err)
+		assert.True(t, result.ResetBound)
+		assert.Equal(t, 1.0, result.ResetCapacityPerDay)
+	})
+
+	t.Run("demand grows each month", func(t *testing.T) {
+		result, err := Run(&Input{RequestsPerDay: 5, AverageLeaseDays: 7, Days: 90, MonthlyGrowthPercent: 50}, pool, start)
+		require.Nil(t, err)
+		assert.True(t, result.Days[89].Requests > 2*result.Days[0].Requests)
+		assert.NotNil(t, result.ExhaustedOn)
+	})
+
+	t.Run("leases and resets in progress are finished", func(t *testing.T) {
+		busy := Pool{Ready: 0, Leased: 10, NotReady: 5, ResetHours: 1, ResetConcurrency: 10}
+		result, err := Run(&Input{RequestsPerDay: 0.1, AverageLeaseDays: 1, Days: 3}, busy, start)
+		require.Nil(t, err)
+		last := result.Days[2]
+		assert.Equal(t, 15.0, last.Ready+last.Leased+last.Resetting)
+		assert.Equal(t, 0.0, last.Waiting)
+	})
+
+	t.Run("the pool can't be smaller than the accounts in use", func(t *testing.T) {
+		busy := Pool{Ready: 0, Leased: 10, NotReady: 5, ResetHours: 1, ResetConcurrency: 10}
+		_, err := Run(&Input{RequestsPerDay: 1, AverageLeaseDays: 1, PoolSize: intPtr(10)}, busy, start)
+		assert.Equal(t, 400, errors.HTTPCodeForError(err))
+	})
+
+	t.Run("demand is required", func(t *testing.T) {
+		_, err := Run(&Input{AverageLeaseDays: 7}, pool, start)
+		assert.Equal(t, 400, errors.HTTPCodeForError(err))
+	})
+
+	t.Run("at most a year is simulated", func(t *testing.T) {
+		_, err := Run(&Input{RequestsPerDay: 1, AverageLeaseDays: 7, Days: 400}, pool, start)
+		assert.Equal(t, 400, errors.HTTPCodeForError(err))
+	})
+}
@@ -0,0 +1,233 @@
+// Package slack is a minimal client for the Slack Web API, used to send
+// messages from DCE and to verify requests sent to DCE by a Slack app.
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultBaseURL is the Slack Web API URL
+const DefaultBaseURL = "https://slack.com/api"
+
+//go:generate mockery -name Service
+
+// Service sends messages to Slack and looks up Slack users
+type Service interface {
+	PostMessage(msg *Message) error
+	Respond(responseURL string, msg *Message) error
+	GetUser(userID string) (*User, error)
+	LookupUserByEmail(email string) (*User, error)
+}
+
+// Message is a Slack message. Blocks are optional, and Text is used as the
+// notification fallback when they are set.
+type Message struct {
+	Channel         string   `json:"channel,omitempty"`
+	Text            string   `json:"text"`
+	Blocks          []*Block `json:"blocks,omitempty"`
+	ResponseType    string   `json:"response_type,omitempty"`
+	ReplaceOriginal bool     `json:"replace_original,omitempty"`
+}
+
+// Response types for slash command responses
+const (
+	ResponseTypeEphemeral = "ephemeral"
+	ResponseTypeInChannel = "in_channel"
+)
+
+// Block is a Slack layout block. Only section and actions blocks are used by DCE.
+type Block struct {
+	Type     string     `json:"type"`
+	BlockID  string     `json:"block_id,omitempty"`
+	Text     *Text      `json:"text,omitempty"`
+	Elements []*Element `json:"elements,omitempty"`
+}
+
+// Text is a Slack text object
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Element is an interactive element, such as a button
+type Element struct {
+	Type     string `json:"type"`
+	ActionID string `json:"action_id"`
+	Text     *Text  `json:"text"`
+	Value    string `json:"value,omitempty"`
+	Style    string `json:"style,omitempty"`
+}
+
+// NewSectionBlock creates a section block with markdown text
+func NewSectionBlock(text string) *Block {
+	return &Block{
+		Type: "section",
+		Text: &Text{Type: "mrkdwn", Text: text},
+	}
+}
+
+// NewActionsBlock creates an actions block with the given elements
+func NewActionsBlock(blockID string, elements ...*Element) *Block {
+	return &Block{
+		Type:     "actions",
+		BlockID:  blockID,
+		Elements: elements,
+	}
+}
+
+// NewButton creates a button element. Style may be empty, "primary" or "danger".
+func NewButton(actionID string, text string, value string, style string) *Element {
+	return &Element{
+		Type:     "button",
+		ActionID: actionID,
+		Text:     &Text{Type: "plain_text", Text: text},
+		Value:    value,
+		Style:    style,
+	}
+}
+
+// User is a Slack user
+type User struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Profile struct {
+		Email string `json:"email"`
+	} `json:"profile"`
+}
+
+// Client calls the Slack Web API with a bot token
+type Client struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClientInput are the items needed to create a new client
+type NewClientInput struct {
+	// Token is the bot token of the Slack app, eg. xoxb-...
+	Token string
+	// BaseURL is optional, and defaults to DefaultBaseURL
+	BaseURL string
+	// HTTPClient is optional, and defaults to a client with a 10 second timeout
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Slack client
+func NewClient(input NewClientInput) *Client {
+	baseURL := input.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	httpClient := input.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+	return &Client{
+		token:      input.Token,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// APIError is returned when Slack responds with "ok": false
+type APIError struct {
+	Method string
+	Code   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("slack %s failed: %s", e.Method, e.Code)
+}
+
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	User  *User  `json:"user"`
+}
+
+// PostMessage posts a message to a channel. Setting the channel to a user ID
+// sends the message as a direct message from the app.
+func (c *Client) PostMessage(msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	_, err = c.call("chat.postMessage", req)
+	return err
+}
+
+// Respond sends a message to the response URL of a slash command or
+// interaction. Response URLs don't need the bot token.
+func (c *Client) Respond(responseURL string, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(responseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return &APIError{Method: "response_url", Code: res.Status}
+	}
+	return nil
+}
+
+// GetUser returns the user with the given ID
+func (c *Client) GetUser(userID string) (*User, error) {
+	return c.getUser("users.info", url.Values{"user": {userID}})
+}
+
+// LookupUserByEmail returns the user with the given email address
+func (c *Client) LookupUserByEmail(email string) (*User, error) {
+	return c.getUser("users.lookupByEmail", url.Values{"email": {email}})
+}
+
+func (c *Client) getUser(method string, query url.Values) (*User, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/"+method+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.call(method, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.User, nil
+}
+
+func (c *Client) call(method string, req *http.Request) (*apiResponse, error) {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	out := &apiResponse{}
+	err = json.Unmarshal(body, out)
+	if err != nil {
+		return nil, fmt.Errorf("slack %s returned an invalid response (%s): %s", method, res.Status, err)
+	}
+	if !out.OK {
+		return nil, &APIError{Method: method, Code: out.Error}
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMessage(t *testing.T) {
+	var gotAuth, gotPath string
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewClientInput{Token: "xoxb-token", BaseURL: server.URL})
+	err := client.PostMessage(&Message{Channel: "U123", Text: "hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer xoxb-token", gotAuth)
+	assert.Equal(t, "/chat.postMessage", gotPath)
+	assert.Equal(t, Message{Channel: "U123", Text: "hello"}, got)
+}
+
+func TestPostMessageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewClientInput{Token: "xoxb-token", BaseURL: server.URL})
+	err := client.PostMessage(&Message{Channel: "U123", Text: "hello"})
+
+	assert.EqualError(t, err, "slack chat.postMessage failed: channel_not_found")
+}
+
+func TestLookupUserByEmail(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"ok": true, "user": {"id": "U123", "name": "jdoe", "profile": {"email": "jdoe@example.com"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewClientInput{Token: "xoxb-token", BaseURL: server.URL})
+	user, err := client.LookupUserByEmail("jdoe@example.com")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "email=jdoe%40example.com", gotQuery)
+	assert.Equal(t, "U123", user.ID)
+	assert.Equal(t, "jdoe", user.Name)
+	assert.Equal(t, "jdoe@example.com", user.Profile.Email)
+}
+
+func TestRespond(t *testing.T) {
+	var gotAuth string
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer server.Close()
+
+	client := NewClient(NewClientInput{Token: "xoxb-token"})
+	err := client.Respond(server.URL+"/commands/T123/456", &Message{Text: "done", ReplaceOriginal: true})
+
+	assert.Nil(t, err)
+	assert.Empty(t, gotAuth)
+	assert.Equal(t, Message{Text: "done", ReplaceOriginal: true}, got)
+}
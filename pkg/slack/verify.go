@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRequestAge is how old a signed request may be before it is rejected,
+// to prevent replay attacks
+const MaxRequestAge = 5 * time.Minute
+
+// VerifyRequest checks a request was sent by Slack, using the signing secret of
+// the Slack app. Headers are matched case-insensitively.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func VerifyRequest(signingSecret string, headers map[string]string, body string, now time.Time) error {
+	if signingSecret == "" {
+		return fmt.Errorf("no Slack signing secret is configured")
+	}
+
+	var timestamp, signature string
+	for k, v := range headers {
+		switch strings.ToLower(k) {
+		case "x-slack-request-timestamp":
+			timestamp = v
+		case "x-slack-signature":
+			signature = v
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing Slack signature headers")
+	}
+
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid Slack request timestamp %q", timestamp)
+	}
+	age := now.Sub(time.Unix(sent, 0))
+	if age > MaxRequestAge || age < -MaxRequestAge {
+		return fmt.Errorf("Slack request timestamp is too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	_, _ = mac.Write([]byte("v0:" + timestamp + ":" + body))
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("invalid Slack signature")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1577836800, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	oldTimestamp := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	body := "command=%2Fdce&text=lease"
+
+	tests := []struct {
+		name    string
+		secret  string
+		headers map[string]string
+		expErr  string
+	}{
+		{
+			name:   "should accept a valid signature",
+			secret: "secret",
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": timestamp,
+				"X-Slack-Signature":         sign("secret", timestamp, body),
+			},
+		},
+		{
+			name:   "should match headers case-insensitively",
+			secret: "secret",
+			headers: map[string]string{
+				"x-slack-request-timestamp": timestamp,
+				"x-slack-signature":         sign("secret", timestamp, body),
+			},
+		},
+		{
+			name:   "should reject a signature from another secret",
+			secret: "secret",
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": timestamp,
+				"X-Slack-Signature":         sign("other", timestamp, body),
+			},
+			expErr: "invalid Slack signature",
+		},
+		{
+			name:   "should reject old requests",
+			secret: "secret",
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": oldTimestamp,
+				"X-Slack-Signature":         sign("secret", oldTimestamp, body),
+			},
+			expErr: "Slack request timestamp is too old",
+		},
+		{
+			name:    "should reject unsigned requests",
+			secret:  "secret",
+			headers: map[string]string{},
+			expErr:  "missing Slack signature headers",
+		},
+		{
+			name:   "should reject requests when no secret is configured",
+			secret: "",
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": timestamp,
+				"X-Slack-Signature":         sign("", timestamp, body),
+			},
+			expErr: "no Slack signing secret is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRequest(tt.secret, tt.headers, body, now)
+			if tt.expErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package testutil builds fixtures for tests, with deterministic defaults,
+// and seeds and truncates the DynamoDB tables they are stored in
+package testutil
+
+import (
+	"github.com/Optum/dce/pkg/account"
+	"github.com/Optum/dce/pkg/arn"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// FixedTime is the epoch timestamp fixtures are created and modified at, so
+// tests don't depend on the clock
+const FixedTime int64 = 1573592058
+
+// DefaultAccountID is the ID of accounts built without one
+const DefaultAccountID = "123456789012"
+
+// AccountBuilder builds an account fixture
+type AccountBuilder struct {
+	account          account.Account
+	adminRoleArn     *arn.ARN
+	principalRoleArn *arn.ARN
+}
+
+// NewAccountBuilder creates a builder for a Ready account, with the default ID
+func NewAccountBuilder() *AccountBuilder {
+	return &AccountBuilder{
+		account: account.Account{
+			ID:             aws.String(DefaultAccountID),
+			Status:         account.StatusReady.StatusPtr(),
+			CreatedOn:      aws.Int64(FixedTime),
+			LastModifiedOn: aws.Int64(FixedTime),
+		},
+	}
+}
+
+// WithID sets the account ID
+func (b *AccountBuilder) WithID(id string) *AccountBuilder {
+	b.account.ID = aws.String(id)
+	return b
+}
+
+// WithStatus sets the account status
+func (b *AccountBuilder) WithStatus(status account.Status) *AccountBuilder {
+	b.account.Status = status.StatusPtr()
+	return b
+}
+
+// Ready makes the account Ready
+func (b *AccountBuilder) Ready() *AccountBuilder {
+	return b.WithStatus(account.StatusReady)
+}
+
+// NotReady makes the account NotReady
+func (b *AccountBuilder) NotReady() *AccountBuilder {
+	return b.WithStatus(account.StatusNotReady)
+}
+
+// Leased makes the account Leased
+func (b *AccountBuilder) Leased() *AccountBuilder {
+	return b.WithStatus(account.StatusLeased)
+}
+
+// Orphaned makes the account Orphaned
+func (b *AccountBuilder) Orphaned() *AccountBuilder {
+	return b.WithStatus(account.StatusOrphaned)
+}
+
+// WithMetadata sets the account metadata
+func (b *AccountBuilder) WithMetadata(metadata map[string]interface{}) *AccountBuilder {
+	b.account.Metadata = metadata
+	return b
+}
+
+// WithAdminRoleArn sets the admin role ARN.  It defaults to the AdminRole of
+// the account.
+func (b *AccountBuilder) WithAdminRoleArn(roleArn *arn.ARN) *AccountBuilder {
+	b.adminRoleArn = roleArn
+	return b
+}
+
+// WithPrincipalRoleArn sets the principal role ARN.  It defaults to the
+// DCEPrincipal role of the account.
+func (b *AccountBuilder) WithPrincipalRoleArn(roleArn *arn.ARN) *AccountBuilder {
+	b.principalRoleArn = roleArn
+	return b
+}
+
+// WithPrincipalPolicyHash sets the hash of the principal policy deployed
+func (b *AccountBuilder) WithPrincipalPolicyHash(hash string) *AccountBuilder {
+	b.account.PrincipalPolicyHash = aws.String(hash)
+	return b
+}
+
+// WithLastModifiedOn sets when the account was last modified
+func (b *AccountBuilder) WithLastModifiedOn(lastModifiedOn int64) *AccountBuilder {
+	b.account.LastModifiedOn = aws.Int64(lastModifiedOn)
+	return b
+}
+
+// Build creates the account.  Each call creates a new account, so a builder
+// can be reused for similar fixtures.
+func (b *AccountBuilder) Build() *account.Account {
+	acct := b.account
+	id := aws.StringValue(acct.ID)
+
+	acct.AdminRoleArn = b.adminRoleArn
+	if acct.AdminRoleArn == nil {
+		acct.AdminRoleArn = arn.New("aws", "iam", "", id, "role/AdminRole")
+	}
+	acct.PrincipalRoleArn = b.principalRoleArn
+	if acct.PrincipalRoleArn == nil {
+		acct.PrincipalRoleArn = arn.New("aws", "iam", "", id, "role/DCEPrincipal")
+	}
+	if b.account.Metadata != nil {
+		acct.Metadata = map[string]interface{}{}
+		for k, v := range b.account.Metadata {
+			acct.Metadata[k] = v
+		}
+	}
+	return &acct
+}
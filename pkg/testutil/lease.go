@@ -0,0 +1,115 @@
+package testutil
+
+import (
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// DefaultLeaseID is the ID of leases built without one
+const DefaultLeaseID = "00000000-0000-0000-0000-000000000001"
+
+// DefaultPrincipalID is the principal of leases built without one
+const DefaultPrincipalID = "User1"
+
+// defaultLeaseDuration is how long, in seconds, leases last by default
+const defaultLeaseDuration int64 = 7 * 24 * 60 * 60
+
+// LeaseBuilder builds a lease fixture
+type LeaseBuilder struct {
+	lease lease.Lease
+}
+
+// NewLeaseBuilder creates a builder for an Active lease of the default
+// account, expiring a week after it was created
+func NewLeaseBuilder() *LeaseBuilder {
+	return &LeaseBuilder{
+		lease: lease.Lease{
+			ID:                       aws.String(DefaultLeaseID),
+			AccountID:                aws.String(DefaultAccountID),
+			PrincipalID:              aws.String(DefaultPrincipalID),
+			Status:                   lease.StatusActive.StatusPtr(),
+			StatusReason:             lease.StatusReasonActive.StatusReasonPtr(),
+			BudgetAmount:             aws.Float64(500),
+			BudgetCurrency:           aws.String("USD"),
+			BudgetNotificationEmails: &[]string{"user1@example.com"},
+			CreatedOn:                aws.Int64(FixedTime),
+			LastModifiedOn:           aws.Int64(FixedTime),
+			StatusModifiedOn:         aws.Int64(FixedTime),
+			ExpiresOn:                aws.Int64(FixedTime + defaultLeaseDuration),
+		},
+	}
+}
+
+// WithID sets the lease ID
+func (b *LeaseBuilder) WithID(id string) *LeaseBuilder {
+	b.lease.ID = aws.String(id)
+	return b
+}
+
+// WithAccountID sets the account leased
+func (b *LeaseBuilder) WithAccountID(accountID string) *LeaseBuilder {
+	b.lease.AccountID = aws.String(accountID)
+	return b
+}
+
+// WithPrincipalID sets the principal the account is leased to
+func (b *LeaseBuilder) WithPrincipalID(principalID string) *LeaseBuilder {
+	b.lease.PrincipalID = aws.String(principalID)
+	return b
+}
+
+// Active makes the lease Active
+func (b *LeaseBuilder) Active() *LeaseBuilder {
+	b.lease.Status = lease.StatusActive.StatusPtr()
+	b.lease.StatusReason = lease.StatusReasonActive.StatusReasonPtr()
+	return b
+}
+
+// Inactive makes the lease Inactive, for the reason given
+func (b *LeaseBuilder) Inactive(reason lease.StatusReason) *LeaseBuilder {
+	b.lease.Status = lease.StatusInactive.StatusPtr()
+	b.lease.StatusReason = reason.StatusReasonPtr()
+	return b
+}
+
+// WithBudget sets the budget amount and currency
+func (b *LeaseBuilder) WithBudget(amount float64, currency string) *LeaseBuilder {
+	b.lease.BudgetAmount = aws.Float64(amount)
+	b.lease.BudgetCurrency = aws.String(currency)
+	return b
+}
+
+// WithBudgetNotificationEmails sets who is notified of the lease spend
+func (b *LeaseBuilder) WithBudgetNotificationEmails(emails ...string) *LeaseBuilder {
+	b.lease.BudgetNotificationEmails = &emails
+	return b
+}
+
+// ExpiresOn sets when the lease expires
+func (b *LeaseBuilder) ExpiresOn(expiresOn int64) *LeaseBuilder {
+	b.lease.ExpiresOn = aws.Int64(expiresOn)
+	return b
+}
+
+// WithMetadata sets the lease metadata
+func (b *LeaseBuilder) WithMetadata(metadata map[string]interface{}) *LeaseBuilder {
+	b.lease.Metadata = metadata
+	return b
+}
+
+// Build creates the lease.  Each call creates a new lease, so a builder can
+// be reused for similar fixtures.
+func (b *LeaseBuilder) Build() *lease.Lease {
+	l := b.lease
+	if b.lease.BudgetNotificationEmails != nil {
+		emails := append([]string{}, *b.lease.BudgetNotificationEmails...)
+		l.BudgetNotificationEmails = &emails
+	}
+	if b.lease.Metadata != nil {
+		l.Metadata = map[string]interface{}{}
+		for k, v := range b.lease.Metadata {
+			l.Metadata[k] = v
+		}
+	}
+	return &l
+}
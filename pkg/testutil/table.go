@@ -0,0 +1,87 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+)
+
+// maxBatchWriteItems is the most items DynamoDB writes in one batch
+const maxBatchWriteItems = 25
+
+// Key names of the DCE tables, for TruncateTable
+var (
+	AccountTableKeys = []string{"Id"}
+	LeaseTableKeys   = []string{"AccountId", "PrincipalId"}
+	UsageTableKeys   = []string{"StartDate", "PrincipalId"}
+)
+
+// TruncateTable removes all items from a table.  DynamoDB does not provide a
+// "truncate" method, so every item is scanned and deleted in batches, by the
+// key attributes named.
+func TruncateTable(t *testing.T, client dynamodbiface.DynamoDBAPI, tableName string, keyNames ...string) {
+	var deleteRequests []*dynamodb.WriteRequest
+	err := client.ScanPages(
+		&dynamodb.ScanInput{
+			TableName:      aws.String(tableName),
+			ConsistentRead: aws.Bool(true),
+		},
+		func(page *dynamodb.ScanOutput, lastPage bool) bool {
+			for _, item := range page.Items {
+				key := map[string]*dynamodb.AttributeValue{}
+				for _, name := range keyNames {
+					key[name] = item[name]
+				}
+				deleteRequests = append(deleteRequests, &dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{Key: key},
+				})
+			}
+			return true
+		},
+	)
+	require.Nil(t, err)
+
+	writeBatches(t, client, tableName, deleteRequests)
+}
+
+// SeedTable puts items into a table, such as accounts and leases from the
+// builders
+func SeedTable(t *testing.T, client dynamodbiface.DynamoDBAPI, tableName string, items ...interface{}) {
+	var putRequests []*dynamodb.WriteRequest
+	for _, item := range items {
+		av, err := dynamodbattribute.MarshalMap(item)
+		require.Nil(t, err)
+		putRequests = append(putRequests, &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{Item: av},
+		})
+	}
+
+	writeBatches(t, client, tableName, putRequests)
+}
+
+// writeBatches makes the write requests in as few batches as DynamoDB
+// allows, retrying any it leaves unprocessed
+func writeBatches(t *testing.T, client dynamodbiface.DynamoDBAPI, tableName string, requests []*dynamodb.WriteRequest) {
+	for len(requests) > 0 {
+		end := maxBatchWriteItems
+		if end > len(requests) {
+			end = len(requests)
+		}
+		batch := requests[:end]
+		requests = requests[end:]
+
+		output, err := client.BatchWriteItem(&dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{
+				tableName: batch,
+			},
+		})
+		require.Nil(t, err)
+		if output != nil {
+			requests = append(requests, output.UnprocessedItems[tableName]...)
+		}
+	}
+}
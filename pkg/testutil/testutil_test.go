@@ -0,0 +1,115 @@
+package testutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Optum/dce/pkg/account"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountBuilder(t *testing.T) {
+	builder := NewAccountBuilder().Leased().WithMetadata(map[string]interface{}{"team": "a"})
+	first := builder.Build()
+	second := builder.WithID("210987654321").Build()
+
+	assert.Equal(t, DefaultAccountID, *first.ID)
+	assert.Equal(t, account.StatusLeased, *first.Status)
+	assert.Equal(t, FixedTime, *first.LastModifiedOn)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/AdminRole", first.AdminRoleArn.String())
+	assert.Equal(t, "arn:aws:iam::210987654321:role/AdminRole", second.AdminRoleArn.String())
+	assert.Nil(t, first.Validate())
+
+	second.Metadata["team"] = "b"
+	assert.Equal(t, "a", first.Metadata["team"])
+}
+
+func TestLeaseBuilder(t *testing.T) {
+	l := NewLeaseBuilder().
+		WithAccountID("210987654321").
+		Inactive(lease.StatusReasonExpired).
+		ExpiresOn(FixedTime).
+		Build()
+
+	assert.Equal(t, DefaultLeaseID, *l.ID)
+	assert.Equal(t, "210987654321", *l.AccountID)
+	assert.Equal(t, lease.StatusInactive, *l.Status)
+	assert.Equal(t, lease.StatusReasonExpired, *l.StatusReason)
+	assert.Equal(t, FixedTime, *l.ExpiresOn)
+
+	active := NewLeaseBuilder().Build()
+	assert.Equal(t, lease.StatusActive, *active.Status)
+	assert.Equal(t, FixedTime+defaultLeaseDuration, *active.ExpiresOn)
+}
+
+func TestTruncateTable(t *testing.T) {
+	var items []map[string]*dynamodb.AttributeValue
+	for i := 0; i < 30; i++ {
+		items = append(items, map[string]*dynamodb.AttributeValue{
+			"AccountId":   {S: aws.String(fmt.Sprintf("%012d", i))},
+			"PrincipalId": {S: aws.String("User1")},
+			"LeaseStatus": {S: aws.String("Active")},
+		})
+	}
+
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("ScanPages", mock.MatchedBy(func(input *dynamodb.ScanInput) bool {
+		return *input.TableName == "Leases"
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*dynamodb.ScanOutput, bool) bool)
+			fn(&dynamodb.ScanOutput{Items: items[:20]}, false)
+			fn(&dynamodb.ScanOutput{Items: items[20:]}, true)
+		}).
+		Return(nil)
+
+	var deleted []map[string]*dynamodb.AttributeValue
+	mockDynamo.On("BatchWriteItem", mock.Anything).
+		Run(func(args mock.Arguments) {
+			input := args.Get(0).(*dynamodb.BatchWriteItemInput)
+			requests := input.RequestItems["Leases"]
+			assert.True(t, len(requests) <= maxBatchWriteItems)
+			for _, request := range requests {
+				deleted = append(deleted, request.DeleteRequest.Key)
+			}
+		}).
+		Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	TruncateTable(t, mockDynamo, "Leases", LeaseTableKeys...)
+
+	mockDynamo.AssertNumberOfCalls(t, "BatchWriteItem", 2)
+	assert.Len(t, deleted, 30)
+	assert.Len(t, deleted[0], 2)
+	assert.Equal(t, "000000000029", *deleted[29]["AccountId"].S)
+}
+
+func TestSeedTable(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	unprocessed := &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{}}
+	mockDynamo.On("BatchWriteItem", mock.Anything).
+		Return(&dynamodb.BatchWriteItemOutput{
+			UnprocessedItems: map[string][]*dynamodb.WriteRequest{"Accounts": {unprocessed}},
+		}, nil).Once()
+
+	var seeded []map[string]*dynamodb.AttributeValue
+	mockDynamo.On("BatchWriteItem", mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, request := range args.Get(0).(*dynamodb.BatchWriteItemInput).RequestItems["Accounts"] {
+				seeded = append(seeded, request.PutRequest.Item)
+			}
+		}).
+		Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	SeedTable(t, mockDynamo, "Accounts",
+		NewAccountBuilder().Build(),
+		NewAccountBuilder().WithID("210987654321").NotReady().Build(),
+	)
+
+	mockDynamo.AssertNumberOfCalls(t, "BatchWriteItem", 2)
+	assert.Len(t, seeded, 1)
+}
@@ -0,0 +1,107 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Jira creates Jira issues using the REST API v2. Issues are labelled with
+// their ticket key, which is used to find the open issue for an event.
+type Jira struct {
+	url        string
+	username   string
+	apiToken   string
+	project    string
+	issueType  string
+	httpClient *http.Client
+}
+
+// NewJira creates a Jira driver
+func NewJira(apiURL string, username string, apiToken string, project string, issueType string, httpClient *http.Client) *Jira {
+	return &Jira{
+		url:        apiURL,
+		username:   username,
+		apiToken:   apiToken,
+		project:    project,
+		issueType:  issueType,
+		httpClient: httpClient,
+	}
+}
+
+type jiraSearchResult struct {
+	Issues []struct {
+		Key string `json:"key"`
+	} `json:"issues"`
+}
+
+// CreateOrUpdate comments on the open issue with the key, or creates a new
+// issue when there isn't one
+func (j *Jira) CreateOrUpdate(key string, issue *Issue) error {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done ORDER BY created DESC`, j.project, key)
+	query := url.Values{
+		"jql":        {jql},
+		"fields":     {"key"},
+		"maxResults": {"1"},
+	}
+	result := &jiraSearchResult{}
+	err := j.call(http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, http.StatusOK, result)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Issues) > 0 {
+		path := "/rest/api/2/issue/" + url.PathEscape(result.Issues[0].Key) + "/comment"
+		return j.call(http.MethodPost, path, map[string]string{"body": issue.Description}, http.StatusCreated, nil)
+	}
+
+	fields := map[string]interface{}{}
+	for name, value := range issue.Fields {
+		fields[name] = value
+	}
+	fields["project"] = map[string]string{"key": j.project}
+	fields["issuetype"] = map[string]string{"name": j.issueType}
+	fields["summary"] = issue.Summary
+	fields["description"] = issue.Description
+	fields["labels"] = []string{"dce", key}
+	return j.call(http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, http.StatusCreated, nil)
+}
+
+func (j *Jira) call(method string, path string, payload interface{}, expStatus int, out interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, j.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(j.username, j.apiToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := j.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != expStatus {
+		return fmt.Errorf("jira %s %s failed (%s): %s", method, req.URL.Path, res.Status, resBody)
+	}
+	if out != nil {
+		return json.Unmarshal(resBody, out)
+	}
+	return nil
+}
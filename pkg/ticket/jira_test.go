@@ -0,0 +1,89 @@
+package ticket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jiraServer responds to issue searches with the given issues, and records
+// the other requests
+func jiraServer(t *testing.T, searchResult string, requests map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "bot@example.com", user)
+		assert.Equal(t, "token", pass)
+
+		if r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search" {
+			assert.Equal(t, `project = "OPS" AND labels = "dce-prod-ResetFailed-123" AND statusCategory != Done ORDER BY created DESC`,
+				r.URL.Query().Get("jql"))
+			_, _ = w.Write([]byte(searchResult))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]interface{}{}
+		_ = json.Unmarshal(body, &payload)
+		requests[r.Method+" "+r.URL.Path] = payload
+		w.WriteHeader(http.StatusCreated)
+	}))
+}
+
+func TestJiraCreate(t *testing.T) {
+	requests := map[string]map[string]interface{}{}
+	server := jiraServer(t, `{"issues": []}`, requests)
+	defer server.Close()
+
+	driver := NewJira(server.URL, "bot@example.com", "token", "OPS", "Task", server.Client())
+	err := driver.CreateOrUpdate("dce-prod-ResetFailed-123", &Issue{
+		Summary:     "Reset failed",
+		Description: "Account 123 failed to reset",
+		Fields:      map[string]string{"customfield_10010": "Cloud"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"POST /rest/api/2/issue": {
+			"fields": map[string]interface{}{
+				"project":           map[string]interface{}{"key": "OPS"},
+				"issuetype":         map[string]interface{}{"name": "Task"},
+				"summary":           "Reset failed",
+				"description":       "Account 123 failed to reset",
+				"labels":            []interface{}{"dce", "dce-prod-ResetFailed-123"},
+				"customfield_10010": "Cloud",
+			},
+		},
+	}, requests)
+}
+
+func TestJiraUpdate(t *testing.T) {
+	requests := map[string]map[string]interface{}{}
+	server := jiraServer(t, `{"issues": [{"key": "OPS-42"}]}`, requests)
+	defer server.Close()
+
+	driver := NewJira(server.URL, "bot@example.com", "token", "OPS", "Task", server.Client())
+	err := driver.CreateOrUpdate("dce-prod-ResetFailed-123", &Issue{
+		Summary:     "Reset failed",
+		Description: "Account 123 failed to reset",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"POST /rest/api/2/issue/OPS-42/comment": {"body": "Account 123 failed to reset"},
+	}, requests)
+}
+
+func TestJiraError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	driver := NewJira(server.URL, "bot@example.com", "token", "OPS", "Task", server.Client())
+	err := driver.CreateOrUpdate("dce-prod-ResetFailed-123", &Issue{})
+
+	assert.EqualError(t, err, "jira GET /rest/api/2/search failed (401 Unauthorized): ")
+}
@@ -0,0 +1,105 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ServiceNow creates ServiceNow records, eg. incidents, using the Table API.
+// The ticket key is stored as the correlation ID of the record, which is used
+// to find the active record for an event.
+type ServiceNow struct {
+	url        string
+	username   string
+	password   string
+	table      string
+	httpClient *http.Client
+}
+
+// NewServiceNow creates a ServiceNow driver
+func NewServiceNow(apiURL string, username string, password string, table string, httpClient *http.Client) *ServiceNow {
+	return &ServiceNow{
+		url:        apiURL,
+		username:   username,
+		password:   password,
+		table:      table,
+		httpClient: httpClient,
+	}
+}
+
+type serviceNowResult struct {
+	Result []struct {
+		SysID string `json:"sys_id"`
+	} `json:"result"`
+}
+
+// CreateOrUpdate adds a work note to the active record with the key, or
+// creates a new record when there isn't one
+func (s *ServiceNow) CreateOrUpdate(key string, issue *Issue) error {
+	tablePath := "/api/now/table/" + url.PathEscape(s.table)
+	query := url.Values{
+		"sysparm_query":  {"correlation_id=" + key + "^active=true"},
+		"sysparm_fields": {"sys_id"},
+		"sysparm_limit":  {"1"},
+	}
+	result := &serviceNowResult{}
+	err := s.call(http.MethodGet, tablePath+"?"+query.Encode(), nil, http.StatusOK, result)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Result) > 0 {
+		path := tablePath + "/" + url.PathEscape(result.Result[0].SysID)
+		return s.call(http.MethodPatch, path, map[string]string{"work_notes": issue.Description}, http.StatusOK, nil)
+	}
+
+	record := map[string]string{}
+	for name, value := range issue.Fields {
+		record[name] = value
+	}
+	record["short_description"] = issue.Summary
+	record["description"] = issue.Description
+	record["correlation_id"] = key
+	record["correlation_display"] = "DCE"
+	return s.call(http.MethodPost, tablePath, record, http.StatusCreated, nil)
+}
+
+func (s *ServiceNow) call(method string, path string, payload interface{}, expStatus int, out interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.username, s.password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != expStatus {
+		return fmt.Errorf("servicenow %s %s failed (%s): %s", method, req.URL.Path, res.Status, resBody)
+	}
+	if out != nil {
+		return json.Unmarshal(resBody, out)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package ticket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serviceNowServer responds to record queries with the given records, and
+// records the other requests
+func serviceNowServer(t *testing.T, queryResult string, requests map[string]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "dce", user)
+		assert.Equal(t, "password", pass)
+
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/api/now/table/incident", r.URL.Path)
+			assert.Equal(t, "correlation_id=dce-prod-ResetFailed-123^active=true", r.URL.Query().Get("sysparm_query"))
+			_, _ = w.Write([]byte(queryResult))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]string{}
+		_ = json.Unmarshal(body, &payload)
+		requests[r.Method+" "+r.URL.Path] = payload
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+}
+
+func TestServiceNowCreate(t *testing.T) {
+	requests := map[string]map[string]string{}
+	server := serviceNowServer(t, `{"result": []}`, requests)
+	defer server.Close()
+
+	driver := NewServiceNow(server.URL, "dce", "password", "incident", server.Client())
+	err := driver.CreateOrUpdate("dce-prod-ResetFailed-123", &Issue{
+		Summary:     "Reset failed",
+		Description: "Account 123 failed to reset",
+		Fields:      map[string]string{"assignment_group": "Cloud Ops"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"POST /api/now/table/incident": {
+			"short_description":   "Reset failed",
+			"description":         "Account 123 failed to reset",
+			"correlation_id":      "dce-prod-ResetFailed-123",
+			"correlation_display": "DCE",
+			"assignment_group":    "Cloud Ops",
+		},
+	}, requests)
+}
+
+func TestServiceNowUpdate(t *testing.T) {
+	requests := map[string]map[string]string{}
+	server := serviceNowServer(t, `{"result": [{"sys_id": "a1b2"}]}`, requests)
+	defer server.Close()
+
+	driver := NewServiceNow(server.URL, "dce", "password", "incident", server.Client())
+	err := driver.CreateOrUpdate("dce-prod-ResetFailed-123", &Issue{
+		Summary:     "Reset failed",
+		Description: "Account 123 failed to reset",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"PATCH /api/now/table/incident/a1b2": {"work_notes": "Account 123 failed to reset"},
+	}, requests)
+}
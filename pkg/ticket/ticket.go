@@ -0,0 +1,271 @@
+// Package ticket creates tickets in an issue tracker, such as Jira or
+// ServiceNow, when lifecycle events occur which need a person to follow up.
+package ticket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Optum/dce/pkg/errors"
+)
+
+// Event is the kind of lifecycle event a ticket is for
+type Event string
+
+const (
+	// EventAccountQuarantined is when an account is Orphaned, and taken out of
+	// the pool until someone investigates it
+	EventAccountQuarantined Event = "AccountQuarantined"
+	// EventLeaseOverBudget is when a lease is ended for being over budget
+	EventLeaseOverBudget Event = "LeaseOverBudget"
+	// EventResetFailed is when an account reset fails
+	EventResetFailed Event = "ResetFailed"
+)
+
+// Driver names, used by the TICKET_DRIVER setting
+const (
+	DriverJira       = "jira"
+	DriverServiceNow = "servicenow"
+)
+
+// DefaultSummaryTemplate is the default template for the ticket summary
+const DefaultSummaryTemplate = "[DCE {{.Namespace}}] {{.Event}} for account {{.AccountID}}" +
+	"{{with .PrincipalID}} ({{.}}){{end}}"
+
+// DefaultDescriptionTemplate is the default template for the ticket description
+const DefaultDescriptionTemplate = "{{.Event}} for account {{.AccountID}}" +
+	"{{with .LeaseID}}, lease {{.}}{{end}}{{with .PrincipalID}} of {{.}}{{end}}\n" +
+	"{{range $k, $v := .Details}}\n{{$k}}: {{$v}}{{end}}\n" +
+	"{{with .Link}}\n{{.}}{{end}}"
+
+// Ticket is a lifecycle event to create a ticket for
+type Ticket struct {
+	Event       Event
+	AccountID   string
+	LeaseID     string
+	PrincipalID string
+	Details     map[string]string
+}
+
+// Issue is a ticket rendered from the templates, ready to send to a driver
+type Issue struct {
+	Summary     string
+	Description string
+	// Fields are additional fields to set on the issue, eg. a ServiceNow assignment group
+	Fields map[string]string
+}
+
+// Driver creates and updates tickets in an issue tracker. Tickets are
+// identified by a key, so an open ticket is updated instead of a new one
+// being created when the same event occurs again.
+type Driver interface {
+	CreateOrUpdate(key string, issue *Issue) error
+}
+
+// NewServiceInput are the items needed to create a new ticket service
+type NewServiceInput struct {
+	// DriverName is the issue tracker to create tickets in, either "jira"
+	// or "servicenow".  Tickets are disabled when empty
+	DriverName string `env:"TICKET_DRIVER" envDefault:""`
+	// APIURL is the URL of the Jira or ServiceNow instance, eg. https://example.atlassian.net
+	APIURL string `env:"TICKET_API_URL" envDefault:""`
+	// Username and APIToken are used for basic authentication. For Jira
+	// Cloud, these are the email and API token of the user.
+	Username string `env:"TICKET_USERNAME" envDefault:""`
+	APIToken string `env:"TICKET_API_TOKEN" envDefault:""`
+	// Project and IssueType are the Jira project key and issue type
+	Project   string `env:"TICKET_PROJECT" envDefault:""`
+	IssueType string `env:"TICKET_ISSUE_TYPE" envDefault:"Task"`
+	// Table is the ServiceNow table to create records in
+	Table string `env:"TICKET_TABLE" envDefault:"incident"`
+	// Events are the events to create tickets for
+	Events              []string `env:"TICKET_EVENTS" envDefault:"AccountQuarantined,LeaseOverBudget,ResetFailed" envSeparator:","`
+	SummaryTemplate     string   `env:"TICKET_SUMMARY_TEMPLATE" envDefault:""`
+	DescriptionTemplate string   `env:"TICKET_DESCRIPTION_TEMPLATE" envDefault:""`
+	// FieldTemplates is a JSON object of additional fields to set, whose
+	// values are templates, eg. {"assignment_group": "Cloud Ops"}
+	FieldTemplates string `env:"TICKET_FIELDS" envDefault:""`
+	// LinkBaseURL is the DCE API URL, used to link tickets to the DCE resource
+	LinkBaseURL string `env:"TICKET_LINK_BASE_URL" envDefault:""`
+	Namespace   string `env:"NAMESPACE" envDefault:"dce"`
+	// Driver is optional, and overrides DriverName
+	Driver Driver
+}
+
+// Service creates tickets for lifecycle events
+type Service struct {
+	driver      Driver
+	events      map[Event]bool
+	summary     *template.Template
+	description *template.Template
+	fields      map[string]*template.Template
+	linkBaseURL string
+	namespace   string
+}
+
+// templateData is the data available to the ticket templates
+type templateData struct {
+	Ticket
+	Namespace string
+	Link      string
+}
+
+// Enabled checks tickets are created for the event
+func (s *Service) Enabled(event Event) bool {
+	return s.driver != nil && s.events[event]
+}
+
+// Submit creates a ticket for the event, or updates the open ticket for the
+// same event and resource.  Events which aren't enabled are ignored.
+func (s *Service) Submit(ticket *Ticket) error {
+	if !s.Enabled(ticket.Event) {
+		return nil
+	}
+
+	issue, err := s.render(ticket)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to render %s ticket", ticket.Event), err)
+	}
+
+	err = s.driver.CreateOrUpdate(s.Key(ticket), issue)
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to create %s ticket", ticket.Event), err)
+	}
+	return nil
+}
+
+// Key is the key identifying the ticket for an event and resource, eg.
+// "dce-prod-LeaseOverBudget-<lease ID>"
+func (s *Service) Key(ticket *Ticket) string {
+	resourceID := ticket.AccountID
+	if ticket.LeaseID != "" {
+		resourceID = ticket.LeaseID
+	}
+	return strings.Join([]string{"dce", s.namespace, string(ticket.Event), resourceID}, "-")
+}
+
+// Link is the DCE API URL of the lease or account of the ticket
+func (s *Service) Link(ticket *Ticket) string {
+	if s.linkBaseURL == "" {
+		return ""
+	}
+	if ticket.LeaseID != "" {
+		return s.linkBaseURL + "/leases/" + ticket.LeaseID
+	}
+	return s.linkBaseURL + "/accounts/" + ticket.AccountID
+}
+
+func (s *Service) render(ticket *Ticket) (*Issue, error) {
+	data := templateData{
+		Ticket:    *ticket,
+		Namespace: s.namespace,
+		Link:      s.Link(ticket),
+	}
+
+	summary, err := execute(s.summary, data)
+	if err != nil {
+		return nil, err
+	}
+	description, err := execute(s.description, data)
+	if err != nil {
+		return nil, err
+	}
+	issue := &Issue{
+		Summary:     summary,
+		Description: description,
+		Fields:      map[string]string{},
+	}
+	for name, tmpl := range s.fields {
+		issue.Fields[name], err = execute(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return issue, nil
+}
+
+func execute(tmpl *template.Template, data templateData) (string, error) {
+	buf := &bytes.Buffer{}
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// NewService creates a new ticket service
+func NewService(input NewServiceInput) (*Service, error) {
+	summaryTemplate := input.SummaryTemplate
+	if summaryTemplate == "" {
+		summaryTemplate = DefaultSummaryTemplate
+	}
+	summary, err := template.New("summary").Parse(summaryTemplate)
+	if err != nil {
+		return nil, errors.NewValidation("ticket", fmt.Errorf("invalid summary template: %s", err))
+	}
+	descriptionTemplate := input.DescriptionTemplate
+	if descriptionTemplate == "" {
+		descriptionTemplate = DefaultDescriptionTemplate
+	}
+	description, err := template.New("description").Parse(descriptionTemplate)
+	if err != nil {
+		return nil, errors.NewValidation("ticket", fmt.Errorf("invalid description template: %s", err))
+	}
+
+	fields := map[string]*template.Template{}
+	if input.FieldTemplates != "" {
+		fieldTemplates := map[string]string{}
+		err = json.Unmarshal([]byte(input.FieldTemplates), &fieldTemplates)
+		if err != nil {
+			return nil, errors.NewValidation("ticket", fmt.Errorf("invalid fields: %s", err))
+		}
+		for name, fieldTemplate := range fieldTemplates {
+			fields[name], err = template.New(name).Parse(fieldTemplate)
+			if err != nil {
+				return nil, errors.NewValidation("ticket", fmt.Errorf("invalid template for field %s: %s", name, err))
+			}
+		}
+	}
+
+	events := map[Event]bool{}
+	for _, e := range input.Events {
+		events[Event(strings.TrimSpace(e))] = true
+	}
+
+	driver := input.Driver
+	if driver == nil && input.DriverName != "" {
+		if input.APIURL == "" {
+			return nil, errors.NewValidation("ticket", fmt.Errorf("an API URL is required for the %s driver", input.DriverName))
+		}
+		httpClient := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		apiURL := strings.TrimRight(input.APIURL, "/")
+		switch strings.ToLower(input.DriverName) {
+		case DriverJira:
+			if input.Project == "" {
+				return nil, errors.NewValidation("ticket", fmt.Errorf("a project is required for the jira driver"))
+			}
+			driver = NewJira(apiURL, input.Username, input.APIToken, input.Project, input.IssueType, httpClient)
+		case DriverServiceNow:
+			driver = NewServiceNow(apiURL, input.Username, input.APIToken, input.Table, httpClient)
+		default:
+			return nil, errors.NewValidation("ticket", fmt.Errorf("unknown ticket driver %q", input.DriverName))
+		}
+	}
+
+	return &Service{
+		driver:      driver,
+		events:      events,
+		summary:     summary,
+		description: description,
+		fields:      fields,
+		linkBaseURL: strings.TrimRight(input.LinkBaseURL, "/"),
+		namespace:   input.Namespace,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package ticket
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDriver struct {
+	keys   []string
+	issues []*Issue
+	err    error
+}
+
+func (d *testDriver) CreateOrUpdate(key string, issue *Issue) error {
+	d.keys = append(d.keys, key)
+	d.issues = append(d.issues, issue)
+	return d.err
+}
+
+func TestSubmit(t *testing.T) {
+	overBudget := &Ticket{
+		Event:       EventLeaseOverBudget,
+		AccountID:   "123456789012",
+		LeaseID:     "abc",
+		PrincipalID: "jdoe",
+		Details:     map[string]string{"reason": "OverBudget"},
+	}
+
+	tests := []struct {
+		name     string
+		input    NewServiceInput
+		ticket   *Ticket
+		expKey   string
+		expIssue *Issue
+	}{
+		{
+			name: "should render the default templates",
+			input: NewServiceInput{
+				Events:      []string{"LeaseOverBudget"},
+				LinkBaseURL: "https://dce.example.com/api/",
+			},
+			ticket: overBudget,
+			expKey: "dce-prod-LeaseOverBudget-abc",
+			expIssue: &Issue{
+				Summary: "[DCE prod] LeaseOverBudget for account 123456789012 (jdoe)",
+				Description: "LeaseOverBudget for account 123456789012, lease abc of jdoe\n\n" +
+					"reason: OverBudget\n\n" +
+					"https://dce.example.com/api/leases/abc",
+				Fields: map[string]string{},
+			},
+		},
+		{
+			name: "should render custom templates",
+			input: NewServiceInput{
+				Events:              []string{"ResetFailed"},
+				SummaryTemplate:     "Reset failed: {{.AccountID}}",
+				DescriptionTemplate: "See {{.Link}}",
+				FieldTemplates:      `{"assignment_group": "Cloud Ops", "u_account": "{{.AccountID}}"}`,
+				LinkBaseURL:         "https://dce.example.com/api",
+			},
+			ticket: &Ticket{Event: EventResetFailed, AccountID: "123456789012"},
+			expKey: "dce-prod-ResetFailed-123456789012",
+			expIssue: &Issue{
+				Summary:     "Reset failed: 123456789012",
+				Description: "See https://dce.example.com/api/accounts/123456789012",
+				Fields: map[string]string{
+					"assignment_group": "Cloud Ops",
+					"u_account":        "123456789012",
+				},
+			},
+		},
+		{
+			name: "should ignore events which aren't enabled",
+			input: NewServiceInput{
+				Events: []string{"ResetFailed"},
+			},
+			ticket: overBudget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := &testDriver{}
+			tt.input.Namespace = "prod"
+			tt.input.Driver = driver
+			svc, err := NewService(tt.input)
+			assert.Nil(t, err)
+
+			err = svc.Submit(tt.ticket)
+
+			assert.Nil(t, err)
+			if tt.expIssue == nil {
+				assert.Empty(t, driver.issues)
+				return
+			}
+			assert.Equal(t, []string{tt.expKey}, driver.keys)
+			assert.Equal(t, []*Issue{tt.expIssue}, driver.issues)
+		})
+	}
+}
+
+func TestSubmitError(t *testing.T) {
+	driver := &testDriver{err: fmt.Errorf("unavailable")}
+	svc, err := NewService(NewServiceInput{Events: []string{"ResetFailed"}, Driver: driver})
+	assert.Nil(t, err)
+
+	err = svc.Submit(&Ticket{Event: EventResetFailed, AccountID: "123456789012"})
+
+	assert.EqualError(t, err, "failed to create ResetFailed ticket")
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NewServiceInput
+		expErr string
+	}{
+		{
+			name:  "should disable tickets without a driver",
+			input: NewServiceInput{},
+		},
+		{
+			name:  "should create a Jira driver",
+			input: NewServiceInput{DriverName: "jira", APIURL: "https://example.atlassian.net", Project: "OPS"},
+		},
+		{
+			name:  "should create a ServiceNow driver",
+			input: NewServiceInput{DriverName: "ServiceNow", APIURL: "https://example.service-now.com"},
+		},
+		{
+			name:   "should require an API URL",
+			input:  NewServiceInput{DriverName: "jira", Project: "OPS"},
+			expErr: "ticket validation error: an API URL is required for the jira driver",
+		},
+		{
+			name:   "should require a Jira project",
+			input:  NewServiceInput{DriverName: "jira", APIURL: "https://example.atlassian.net"},
+			expErr: "ticket validation error: a project is required for the jira driver",
+		},
+		{
+			name:   "should reject unknown drivers",
+			input:  NewServiceInput{DriverName: "trello", APIURL: "https://trello.com"},
+			expErr: "ticket validation error: unknown ticket driver \"trello\"",
+		},
+		{
+			name:   "should reject invalid templates",
+			input:  NewServiceInput{SummaryTemplate: "{{.AccountID"},
+			expErr: "ticket validation error: invalid summary template: template: summary:1: unclosed action",
+		},
+		{
+			name:   "should reject invalid fields",
+			input:  NewServiceInput{FieldTemplates: "assignment_group"},
+			expErr: "ticket validation error: invalid fields: invalid character 'a' looking for beginning of value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := NewService(tt.input)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.input.DriverName != "", svc.driver != nil)
+		})
+	}
+}
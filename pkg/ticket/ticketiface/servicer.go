@@ -0,0 +1,13 @@
+//
+
+package ticketiface
+
+import (
+	"github.com/Optum/dce/pkg/ticket"
+)
+
+// Servicer creates tickets for lifecycle events
+type Servicer interface {
+	// Submit creates or updates the ticket for the event
+	Submit(data *ticket.Ticket) error
+}
@@ -0,0 +1,229 @@
+package usage
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+/*
+Aggregates are the rolling spend of each lease and principal, for their
+current budget period. They're updated with the difference to the previous
+value of a daily usage record whenever it's written, so budget checks read a
+single item instead of summing every daily record of the period.
+
+An aggregate which doesn't exist yet, such as at the start of a budget
+period, is created from the daily records of the period.
+*/
+
+// Aggregator records usage, and keeps the spend aggregates of leases and principals
+type Aggregator interface {
+	// AggregatesEnabled is true when an aggregate table is configured
+	AggregatesEnabled() bool
+	// PutUsageAggregated adds a daily usage record, and gets the updated spend
+	// of the lease and principal the usage is for
+	PutUsageAggregated(input Usage, periods AggregatePeriods) (*Spend, error)
+	// GetPrincipalSpend gets the aggregated spend of a principal. The spend is
+	// not found when no usage was recorded for the principal in the period.
+	GetPrincipalSpend(principalID string, periodStart time.Time) (float64, bool, error)
+}
+
+// AggregatePeriods are the start of the current budget periods of a lease
+// and its principal
+type AggregatePeriods struct {
+	Lease     time.Time
+	Principal time.Time
+}
+
+// Spend is the aggregated spend of a lease and its principal
+type Spend struct {
+	Lease     float64
+	Principal float64
+}
+
+// Aggregate is the spend of a lease or principal for a budget period
+type Aggregate struct {
+	ID             string  `dynamodbav:"Id"`
+	PeriodStart    int64   `dynamodbav:"PeriodStart"`
+	CostAmount     float64 `dynamodbav:"CostAmount"`
+	CostCurrency   string  `dynamodbav:"CostCurrency"`
+	LastModifiedOn int64   `dynamodbav:"LastModifiedOn"`
+	TimeToLive     int64   `dynamodbav:"TimeToLive"`
+}
+
+// LeaseAggregateID is the ID of the spend aggregate of a lease
+func LeaseAggregateID(accountID string, principalID string, periodStart time.Time) string {
+	return fmt.Sprintf("lease/%s/%s/%d", accountID, principalID, periodStart.Unix())
+}
+
+// PrincipalAggregateID is the ID of the spend aggregate of a principal
+func PrincipalAggregateID(principalID string, periodStart time.Time) string {
+	return fmt.Sprintf("principal/%s/%d", principalID, periodStart.Unix())
+}
+
+// AggregatesEnabled is true when an aggregate table is configured
+func (db *DB) AggregatesEnabled() bool {
+	return db.AggregateTableName != ""
+}
+
+// PutUsageAggregated adds a daily usage record, and adds the change in its
+// cost to the aggregates of the lease and principal
+func (db *DB) PutUsageAggregated(input Usage, periods AggregatePeriods) (*Spend, error) {
+	item, err := dynamodbattribute.MarshalMap(input)
+	if err != nil {
+		return nil, err
+	}
+	common.UsageItemSchema.Stamp(item)
+	res, err := db.Client.PutItem(&dynamodb.PutItemInput{
+		TableName:    aws.String(db.UsageTableName),
+		Item:         item,
+		ReturnValues: aws.String("ALL_OLD"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The record for a day is replaced as the day's cost grows, so the
+	// aggregates change by the difference to the previous cost. Daily records
+	// are per principal, so the lease only had the previous cost if it was
+	// recorded for the same account.
+	leaseDelta := *input.CostAmount
+	principalDelta := *input.CostAmount
+	if len(res.Attributes) > 0 {
+		prev, err := unmarshalUsageRecord(res.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		if prev.CostAmount != nil {
+			principalDelta -= *prev.CostAmount
+			if aws.StringValue(prev.AccountID) == aws.StringValue(input.AccountID) {
+				leaseDelta -= *prev.CostAmount
+			}
+		}
+	}
+
+	spend := &Spend{}
+	spend.Lease, err = db.addToAggregate(
+		LeaseAggregateID(*input.AccountID, *input.PrincipalID, periods.Lease),
+		periods.Lease, input, leaseDelta,
+		func(u *Usage) bool {
+			return aws.StringValue(u.PrincipalID) == *input.PrincipalID &&
+				aws.StringValue(u.AccountID) == *input.AccountID
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	spend.Principal, err = db.addToAggregate(
+		PrincipalAggregateID(*input.PrincipalID, periods.Principal),
+		periods.Principal, input, principalDelta,
+		func(u *Usage) bool {
+			return aws.StringValue(u.PrincipalID) == *input.PrincipalID
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return spend, nil
+}
+
+// GetPrincipalSpend gets the aggregated spend of a principal
+func (db *DB) GetPrincipalSpend(principalID string, periodStart time.Time) (float64, bool, error) {
+	res, err := db.Client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(db.AggregateTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Id": {S: aws.String(PrincipalAggregateID(principalID, periodStart))},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	if len(res.Item) == 0 {
+		return 0, false, nil
+	}
+
+	aggregate := Aggregate{}
+	err = dynamodbattribute.UnmarshalMap(res.Item, &aggregate)
+	if err != nil {
+		return 0, false, err
+	}
+	return aggregate.CostAmount, true, nil
+}
+
+// addToAggregate adds to the cost of an aggregate, and gets its new cost. An
+// aggregate which doesn't exist is created from the daily records of the
+// period which match, up to the day of the usage.
+func (db *DB) addToAggregate(id string, periodStart time.Time, input Usage, delta float64, matches func(*Usage) bool) (float64, error) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// Retry once, if another update created the aggregate at the same time
+	for attempt := 0; attempt < 2; attempt++ {
+		res, err := db.Client.UpdateItem(&dynamodb.UpdateItemInput{
+			TableName: aws.String(db.AggregateTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"Id": {S: aws.String(id)},
+			},
+			UpdateExpression:    aws.String("add CostAmount :delta set LastModifiedOn=:now, TimeToLive=:ttl"),
+			ConditionExpression: aws.String("attribute_exists(Id)"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":delta": {N: aws.String(strconv.FormatFloat(delta, 'f', -1, 64))},
+				":now":   {N: aws.String(now)},
+				":ttl":   {N: aws.String(strconv.FormatInt(*input.TimeToLive, 10))},
+			},
+			ReturnValues: aws.String("UPDATED_NEW"),
+		})
+		if err == nil {
+			return strconv.ParseFloat(aws.StringValue(res.Attributes["CostAmount"].N), 64)
+		}
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+			return 0, err
+		}
+
+		// Daily records are eventually consistent, so the usage being added is
+		// used instead of the record of its day
+		cost := *input.CostAmount
+		usageDay := time.Unix(*input.StartDate, 0).UTC()
+		records, err := db.GetUsageByDateRange(periodStart, usageDay.AddDate(0, 0, -1))
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range records {
+			if matches(r) && r.CostAmount != nil {
+				cost += *r.CostAmount
+			}
+		}
+		log.Printf("Creating spend aggregate %s with %.2f", id, cost)
+
+		item, err := dynamodbattribute.MarshalMap(Aggregate{
+			ID:             id,
+			PeriodStart:    periodStart.Unix(),
+			CostAmount:     cost,
+			CostCurrency:   aws.StringValue(input.CostCurrency),
+			LastModifiedOn: time.Now().Unix(),
+			TimeToLive:     *input.TimeToLive,
+		})
+		if err != nil {
+			return 0, err
+		}
+		_, err = db.Client.PutItem(&dynamodb.PutItemInput{
+			TableName:           aws.String(db.AggregateTableName),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(Id)"),
+		})
+		if err == nil {
+			return cost, nil
+		}
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("failed to update spend aggregate %s: it was modified concurrently", id)
+}
@@ -0,0 +1,153 @@
+package usage
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func usageItem(principalID string, accountID string, cost float64) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"PrincipalId": {S: aws.String(principalID)},
+		"AccountId":   {S: aws.String(accountID)},
+		"StartDate":   {N: aws.String("0")},
+		"CostAmount":  {N: aws.String(strconv.FormatFloat(cost, 'f', -1, 64))},
+	}
+}
+
+func TestPutUsageAggregated(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	periods := AggregatePeriods{Lease: yesterday, Principal: yesterday}
+	leaseID := LeaseAggregateID("123456789012", "jdoe", yesterday)
+	principalID := PrincipalAggregateID("jdoe", yesterday)
+
+	tests := []struct {
+		name       string
+		prevRecord map[string]*dynamodb.AttributeValue
+		exists     bool
+		expDeltas  map[string]string
+		expCreated map[string]string
+		expSpend   *Spend
+		records    []map[string]*dynamodb.AttributeValue
+		aggregates map[string]string
+	}{
+		{
+			name:       "should add the change in cost to the aggregates",
+			prevRecord: usageItem("jdoe", "123456789012", 4),
+			exists:     true,
+			expDeltas:  map[string]string{leaseID: "6", principalID: "6"},
+			aggregates: map[string]string{leaseID: "56", principalID: "106"},
+			expSpend:   &Spend{Lease: 56, Principal: 106},
+		},
+		{
+			name:       "should add the whole cost to the lease when the previous cost was of another account",
+			prevRecord: usageItem("jdoe", "999999999999", 4),
+			exists:     true,
+			expDeltas:  map[string]string{leaseID: "10", principalID: "6"},
+			aggregates: map[string]string{leaseID: "60", principalID: "106"},
+			expSpend:   &Spend{Lease: 60, Principal: 106},
+		},
+		{
+			name:   "should create missing aggregates from the daily records",
+			exists: false,
+			records: []map[string]*dynamodb.AttributeValue{
+				usageItem("jdoe", "123456789012", 3),
+				usageItem("jdoe", "999999999999", 2),
+				usageItem("other", "123456789012", 7),
+			},
+			expCreated: map[string]string{leaseID: "13", principalID: "15"},
+			expSpend:   &Spend{Lease: 13, Principal: 15},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDynamo := &awsmocks.DynamoDBAPI{}
+			mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+				return *input.TableName == "Usage"
+			})).Return(&dynamodb.PutItemOutput{Attributes: tt.prevRecord}, nil)
+
+			if tt.exists {
+				mockDynamo.On("UpdateItem", mock.Anything).Return(func(input *dynamodb.UpdateItemInput) *dynamodb.UpdateItemOutput {
+					id := *input.Key["Id"].S
+					assert.Equal(t, tt.expDeltas[id], *input.ExpressionAttributeValues[":delta"].N, id)
+					return &dynamodb.UpdateItemOutput{
+						Attributes: map[string]*dynamodb.AttributeValue{
+							"CostAmount": {N: aws.String(tt.aggregates[id])},
+						},
+					}
+				}, nil)
+			} else {
+				mockDynamo.On("UpdateItem", mock.Anything).Return(nil,
+					awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "not found", nil))
+				mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{Items: tt.records}, nil)
+				mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+					return *input.TableName == "UsageAggregates"
+				})).Return(&dynamodb.PutItemOutput{}, nil)
+			}
+
+			db := &DB{Client: mockDynamo, UsageTableName: "Usage", AggregateTableName: "UsageAggregates"}
+			usage, err := NewUsage(NewUsageInput{
+				PrincipalID:  "jdoe",
+				AccountID:    "123456789012",
+				StartDate:    today.Unix(),
+				EndDate:      today.Add(24*time.Hour - time.Second).Unix(),
+				CostAmount:   10,
+				CostCurrency: "USD",
+				TimeToLive:   today.AddDate(0, 0, 30).Unix(),
+			})
+			assert.Nil(t, err)
+
+			spend, err := db.PutUsageAggregated(*usage, periods)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expSpend, spend)
+			for id, cost := range tt.expCreated {
+				mockDynamo.AssertCalled(t, "PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+					return *input.TableName == "UsageAggregates" &&
+						*input.Item["Id"].S == id &&
+						*input.Item["CostAmount"].N == cost &&
+						*input.ConditionExpression == "attribute_not_exists(Id)"
+				}))
+			}
+			if tt.exists {
+				mockDynamo.AssertNotCalled(t, "Query", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetPrincipalSpend(t *testing.T) {
+	periodStart := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+		return *input.Key["Id"].S == PrincipalAggregateID("jdoe", periodStart)
+	})).Return(&dynamodb.GetItemOutput{
+		Item: map[string]*dynamodb.AttributeValue{
+			"Id":         {S: aws.String(PrincipalAggregateID("jdoe", periodStart))},
+			"CostAmount": {N: aws.String("42.5")},
+		},
+	}, nil)
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+	db := &DB{Client: mockDynamo, AggregateTableName: "UsageAggregates"}
+	assert.True(t, db.AggregatesEnabled())
+
+	spend, found, err := db.GetPrincipalSpend("jdoe", periodStart)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 42.5, spend)
+
+	_, found, err = db.GetPrincipalSpend("other", periodStart)
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,96 @@
+package usage
+
+import (
+	"math"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// Percentiles of the daily spend of past leases used for the cost range
+// of an estimate
+const (
+	estimateLowPercentile      = 25
+	estimateExpectedPercentile = 50
+	estimateHighPercentile     = 90
+)
+
+// LeaseCost is the spend of a past lease
+type LeaseCost struct {
+	// Days the lease was active, at least one
+	Days       float64
+	CostAmount float64
+}
+
+// LeaseCostOf sums the daily usage records of a lease, from the day it
+// started until the day it ended
+func LeaseCostOf(records []*Usage, accountID string, principalID string, startDate int64, endDate int64) LeaseCost {
+	startDay := startDate - startDate%86400
+	cost := LeaseCost{Days: math.Max(1, float64(endDate-startDate)/86400)}
+	for _, u := range records {
+		if aws.StringValue(u.AccountID) != accountID || aws.StringValue(u.PrincipalID) != principalID {
+			continue
+		}
+		day := aws.Int64Value(u.StartDate)
+		if day < startDay || day > endDate {
+			continue
+		}
+		cost.CostAmount += aws.Float64Value(u.CostAmount)
+	}
+	return cost
+}
+
+// Estimate is the range a lease is expected to cost, extrapolated from the
+// daily spend of past leases
+type Estimate struct {
+	DurationDays float64 `json:"durationDays"`
+	// CostLow is what a quarter of past leases spent less than
+	CostLow float64 `json:"costLow"`
+	// CostExpected is what half of past leases spent less than
+	CostExpected float64 `json:"costExpected"`
+	// CostHigh is what most (90%) past leases spent less than
+	CostHigh     float64 `json:"costHigh"`
+	CostCurrency string  `json:"costCurrency"`
+	// SampleSize is the number of past leases the estimate is based on
+	SampleSize int `json:"sampleSize"`
+	// SimilarLeases is true when the estimate is based only on leases similar
+	// to the requested one, rather than all leases in the pool
+	SimilarLeases bool `json:"similarLeases"`
+}
+
+// NewEstimate estimates the cost of a lease of the given duration from the
+// daily spend of past leases. The costs are zero when there are no past leases.
+func NewEstimate(durationDays float64, costs []LeaseCost, currency string) *Estimate {
+	estimate := &Estimate{
+		DurationDays: durationDays,
+		CostCurrency: currency,
+		SampleSize:   len(costs),
+	}
+	if len(costs) == 0 {
+		return estimate
+	}
+
+	daily := make([]float64, 0, len(costs))
+	for _, c := range costs {
+		daily = append(daily, c.CostAmount/math.Max(1, c.Days))
+	}
+	sort.Float64s(daily)
+
+	estimate.CostLow = roundCost(percentile(daily, estimateLowPercentile) * durationDays)
+	estimate.CostExpected = roundCost(percentile(daily, estimateExpectedPercentile) * durationDays)
+	estimate.CostHigh = roundCost(percentile(daily, estimateHighPercentile) * durationDays)
+	return estimate
+}
+
+// percentile of sorted values, by the nearest rank
+func percentile(sorted []float64, p int) float64 {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func roundCost(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,48 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaseCostOf(t *testing.T) {
+	day := int64(86400)
+	records := []*Usage{
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(0), CostAmount: aws.Float64(5)},
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(day), CostAmount: aws.Float64(3)},
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(5 * day), CostAmount: aws.Float64(100)},
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("asmith"), StartDate: aws.Int64(day), CostAmount: aws.Float64(7)},
+	}
+
+	// The lease started part way through its first day
+	cost := LeaseCostOf(records, "123456789012", "jdoe", 3600, 2*day+3600)
+	assert.Equal(t, LeaseCost{Days: 2, CostAmount: 8}, cost)
+
+	// Leases shorter than a day count as a day
+	cost = LeaseCostOf(records, "123456789012", "asmith", day, day+60)
+	assert.Equal(t, LeaseCost{Days: 1, CostAmount: 7}, cost)
+}
+
+func TestNewEstimate(t *testing.T) {
+	costs := []LeaseCost{}
+	for i := 1; i <= 10; i++ {
+		costs = append(costs, LeaseCost{Days: 2, CostAmount: float64(i * 2)})
+	}
+
+	estimate := NewEstimate(7, costs, "USD")
+	assert.Equal(t, &Estimate{
+		DurationDays: 7,
+		CostLow:      21,
+		CostExpected: 35,
+		CostHigh:     63,
+		CostCurrency: "USD",
+		SampleSize:   10,
+	}, estimate)
+
+	t.Run("without past leases", func(t *testing.T) {
+		estimate := NewEstimate(7, nil, "USD")
+		assert.Equal(t, &Estimate{DurationDays: 7, CostCurrency: "USD"}, estimate)
+	})
+}
@@ -0,0 +1,117 @@
+package usage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// Ways usage can be grouped in a report
+const (
+	ReportGroupByPrincipal = "principal"
+	ReportGroupByPool      = "pool"
+	ReportGroupByMonth     = "month"
+)
+
+// ReportPool is the group of all usage, when a report is grouped by pool.
+// DCE manages a single account pool.
+const ReportPool = "default"
+
+// Report is the spend of daily usage records, aggregated by group
+type Report struct {
+	GroupBy   string        `json:"groupBy"`
+	StartDate int64         `json:"startDate"`
+	EndDate   int64         `json:"endDate"`
+	Groups    []ReportGroup `json:"groups"`
+}
+
+// ReportGroup is the spend of a group in one currency
+type ReportGroup struct {
+	Key          string  `json:"key"`
+	CostAmount   float64 `json:"costAmount"`
+	CostCurrency string  `json:"costCurrency"`
+	// Records is the number of daily usage records in the group
+	Records int `json:"records"`
+}
+
+// NewReport aggregates daily usage records by principal, pool or month.
+// Groups are split by currency, so costs in different currencies aren't added.
+func NewReport(groupBy string, startDate time.Time, endDate time.Time, records []*Usage) (*Report, error) {
+	var keyOf func(u *Usage) string
+	switch groupBy {
+	case ReportGroupByPrincipal:
+		keyOf = func(u *Usage) string { return aws.StringValue(u.PrincipalID) }
+	case ReportGroupByPool:
+		keyOf = func(u *Usage) string { return ReportPool }
+	case ReportGroupByMonth:
+		keyOf = func(u *Usage) string {
+			return time.Unix(aws.Int64Value(u.StartDate), 0).UTC().Format("2006-01")
+		}
+	default:
+		return nil, fmt.Errorf("groupBy must be one of %s, %s or %s",
+			ReportGroupByPrincipal, ReportGroupByPool, ReportGroupByMonth)
+	}
+
+	type groupKey struct {
+		key      string
+		currency string
+	}
+	groups := map[groupKey]*ReportGroup{}
+	for _, u := range records {
+		if u.CostAmount == nil {
+			continue
+		}
+		k := groupKey{key: keyOf(u), currency: aws.StringValue(u.CostCurrency)}
+		group, ok := groups[k]
+		if !ok {
+			group = &ReportGroup{Key: k.key, CostCurrency: k.currency}
+			groups[k] = group
+		}
+		group.CostAmount += *u.CostAmount
+		group.Records++
+	}
+
+	report := &Report{
+		GroupBy:   groupBy,
+		StartDate: startDate.Unix(),
+		EndDate:   endDate.Unix(),
+		Groups:    []ReportGroup{},
+	}
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Key != report.Groups[j].Key {
+			return report.Groups[i].Key < report.Groups[j].Key
+		}
+		return report.Groups[i].CostCurrency < report.Groups[j].CostCurrency
+	})
+	return report, nil
+}
+
+// WriteCSV writes the groups of the report as CSV, with a header row
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{r.GroupBy, "costAmount", "costCurrency", "records"})
+	if err != nil {
+		return err
+	}
+	for _, group := range r.Groups {
+		err = writer.Write([]string{
+			group.Key,
+			strconv.FormatFloat(group.CostAmount, 'f', 2, 64),
+			group.CostCurrency,
+			strconv.Itoa(group.Records),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
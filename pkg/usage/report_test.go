@@ -0,0 +1,84 @@
+package usage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	jan := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC).Unix()
+	feb := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC).Unix()
+	records := []*Usage{
+		{PrincipalID: aws.String("jdoe"), StartDate: &jan, CostAmount: aws.Float64(10), CostCurrency: aws.String("USD")},
+		{PrincipalID: aws.String("jdoe"), StartDate: &feb, CostAmount: aws.Float64(5.5), CostCurrency: aws.String("USD")},
+		{PrincipalID: aws.String("asmith"), StartDate: &feb, CostAmount: aws.Float64(2), CostCurrency: aws.String("USD")},
+		{PrincipalID: aws.String("asmith"), StartDate: &feb, CostAmount: aws.Float64(3), CostCurrency: aws.String("EUR")},
+	}
+	startDate := time.Unix(jan, 0)
+	endDate := time.Unix(feb, 0)
+
+	tests := []struct {
+		groupBy   string
+		expGroups []ReportGroup
+	}{
+		{
+			groupBy: "principal",
+			expGroups: []ReportGroup{
+				{Key: "asmith", CostAmount: 3, CostCurrency: "EUR", Records: 1},
+				{Key: "asmith", CostAmount: 2, CostCurrency: "USD", Records: 1},
+				{Key: "jdoe", CostAmount: 15.5, CostCurrency: "USD", Records: 2},
+			},
+		},
+		{
+			groupBy: "pool",
+			expGroups: []ReportGroup{
+				{Key: "default", CostAmount: 3, CostCurrency: "EUR", Records: 1},
+				{Key: "default", CostAmount: 17.5, CostCurrency: "USD", Records: 3},
+			},
+		},
+		{
+			groupBy: "month",
+			expGroups: []ReportGroup{
+				{Key: "2020-01", CostAmount: 10, CostCurrency: "USD", Records: 1},
+				{Key: "2020-02", CostAmount: 3, CostCurrency: "EUR", Records: 1},
+				{Key: "2020-02", CostAmount: 7.5, CostCurrency: "USD", Records: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			report, err := NewReport(tt.groupBy, startDate, endDate, records)
+			require.Nil(t, err)
+			assert.Equal(t, &Report{
+				GroupBy:   tt.groupBy,
+				StartDate: jan,
+				EndDate:   feb,
+				Groups:    tt.expGroups,
+			}, report)
+		})
+	}
+
+	t.Run("unknown group", func(t *testing.T) {
+		_, err := NewReport("lease", startDate, endDate, records)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestReportWriteCSV(t *testing.T) {
+	report := &Report{
+		GroupBy: "principal",
+		Groups: []ReportGroup{
+			{Key: "jdoe", CostAmount: 15.5, CostCurrency: "USD", Records: 2},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	require.Nil(t, report.WriteCSV(out))
+	assert.Equal(t, "principal,costAmount,costCurrency,records\njdoe,15.50,USD,2\n", out.String())
+}
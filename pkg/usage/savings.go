@@ -0,0 +1,125 @@
+package usage
+
+import (
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// SavingsLease is a lease as the savings report sees it
+type SavingsLease struct {
+	AccountID   string
+	PrincipalID string
+	// RequestedOn is when the lease was requested, which is before it was
+	// provisioned when the request waited for an account.  It's zero for
+	// leases created before requests were recorded.
+	RequestedOn int64
+	// ProvisionedOn is when the account was leased
+	ProvisionedOn int64
+	// EndedOn is when the lease ended, and its account was reclaimed.  It's
+	// zero while the lease is active.
+	EndedOn     int64
+	EndedReason string
+}
+
+// SavingsBaseline is how accounts were managed before DCE, which the
+// savings are measured against
+type SavingsBaseline struct {
+	// ProvisioningHours is how long it took to get an account
+	ProvisioningHours float64 `json:"provisioningHours"`
+	// AccountLifetimeDays is how long accounts were kept before they were
+	// cleaned up
+	AccountLifetimeDays float64 `json:"accountLifetimeDays"`
+}
+
+// SavingsReport is what the account pool saved in a period, versus the
+// baseline
+type SavingsReport struct {
+	StartDate int64           `json:"startDate"`
+	EndDate   int64           `json:"endDate"`
+	Baseline  SavingsBaseline `json:"baseline"`
+	// AccountsReclaimed is the number of leases which ended in the period,
+	// whose accounts were reset for the next lease
+	AccountsReclaimed int            `json:"accountsReclaimed"`
+	ReclaimedByReason map[string]int `json:"reclaimedByReason"`
+	// IdleSpendPrevented is what the reclaimed accounts would have spent at
+	// their daily spend, had they been kept for the baseline lifetime
+	IdleSpendPrevented float64 `json:"idleSpendPrevented"`
+	CostCurrency       string  `json:"costCurrency"`
+	// LeasesProvisioned is the number of leases provisioned in the period,
+	// whose requests were recorded
+	LeasesProvisioned        int     `json:"leasesProvisioned"`
+	AverageProvisioningHours float64 `json:"averageProvisioningHours"`
+	// ProvisioningHoursSaved is the time principals didn't wait for accounts
+	// versus the baseline, summed over the leases provisioned
+	ProvisioningHoursSaved float64 `json:"provisioningHoursSaved"`
+}
+
+// SavingsUsageStartDate is the earliest day usage is needed for the savings
+// report, when the first of the reclaimed leases was provisioned
+func SavingsUsageStartDate(leases []SavingsLease, startDate time.Time, endDate time.Time) time.Time {
+	usageStart := startDate.Unix()
+	for _, l := range leases {
+		if isReclaimedIn(l, startDate.Unix(), endDate.Unix()) && l.ProvisionedOn < usageStart {
+			usageStart = l.ProvisionedOn
+		}
+	}
+	return time.Unix(usageStart, 0)
+}
+
+// NewSavingsReport measures the accounts reclaimed, the idle spend prevented,
+// and the time taken to provision leases in a period, against the baseline.  Usage records must cover the reclaimed leases from when they
+// were provisioned.  Spend in other currencies than the budget currency isn't
+// compared.
+func NewSavingsReport(leases []SavingsLease, records []*Usage, baseline SavingsBaseline,
+	startDate time.Time, endDate time.Time, currency string) *SavingsReport {
+	report := &SavingsReport{
+		StartDate:         startDate.Unix(),
+		EndDate:           endDate.Unix(),
+		Baseline:          baseline,
+		ReclaimedByReason: map[string]int{},
+		CostCurrency:      currency,
+	}
+
+	budgetRecords := []*Usage{}
+	for _, u := range records {
+		if aws.StringValue(u.CostCurrency) == currency {
+			budgetRecords = append(budgetRecords, u)
+		}
+	}
+
+	var provisioningHours float64
+	for _, l := range leases {
+		if isReclaimedIn(l, report.StartDate, report.EndDate) {
+			report.AccountsReclaimed++
+			report.ReclaimedByReason[l.EndedReason]++
+
+			cost := LeaseCostOf(budgetRecords, l.AccountID, l.PrincipalID, l.ProvisionedOn, l.EndedOn)
+			idleDays := baseline.AccountLifetimeDays - cost.Days
+			if idleDays > 0 {
+				report.IdleSpendPrevented += cost.CostAmount / cost.Days * idleDays
+			}
+		}
+
+		if l.RequestedOn > 0 && l.ProvisionedOn >= report.StartDate && l.ProvisionedOn <= report.EndDate {
+			report.LeasesProvisioned++
+			provisioningHours += math.Max(0, float64(l.ProvisionedOn-l.RequestedOn)) / 3600
+		}
+	}
+
+	report.IdleSpendPrevented = roundCost(report.IdleSpendPrevented)
+	if report.LeasesProvisioned > 0 {
+		report.AverageProvisioningHours = roundHours(provisioningHours / float64(report.LeasesProvisioned))
+		report.ProvisioningHoursSaved = roundHours(baseline.ProvisioningHours*float64(report.LeasesProvisioned) - provisioningHours)
+	}
+	return report
+}
+
+func isReclaimedIn(l SavingsLease, startDate int64, endDate int64) bool {
+	return l.EndedOn > 0 && l.EndedOn >= startDate && l.EndedOn <= endDate
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*10) / 10
+}
@@ -0,0 +1,54 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSavingsReport(t *testing.T) {
+	day := int64(86400)
+	startDate := 30 * day
+	endDate := 60 * day
+	records := []*Usage{
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(25 * day), CostAmount: aws.Float64(10), CostCurrency: aws.String("USD")},
+		{AccountID: aws.String("123456789012"), PrincipalID: aws.String("jdoe"), StartDate: aws.Int64(34 * day), CostAmount: aws.Float64(10), CostCurrency: aws.String("USD")},
+		{AccountID: aws.String("123456789013"), PrincipalID: aws.String("asmith"), StartDate: aws.Int64(40 * day), CostAmount: aws.Float64(50), CostCurrency: aws.String("EUR")},
+	}
+	leases := []SavingsLease{
+		// Reclaimed after 10 days at 2 a day, 20 days before the baseline
+		{AccountID: "123456789012", PrincipalID: "jdoe", ProvisionedOn: 25 * day, EndedOn: 35 * day, EndedReason: "Expired"},
+		// Reclaimed after the baseline lifetime, having spent in another currency
+		{AccountID: "123456789013", PrincipalID: "asmith", RequestedOn: 31 * day, ProvisionedOn: 31*day + 3600, EndedOn: 65 * day, EndedReason: "Stale"},
+		{AccountID: "123456789014", PrincipalID: "bjones", RequestedOn: 40 * day, ProvisionedOn: 40*day + 5*3600, EndedOn: 41 * day, EndedReason: "Stale"},
+		// Still active, and provisioned before requests were recorded
+		{AccountID: "123456789015", PrincipalID: "cdoe", ProvisionedOn: 45 * day},
+	}
+
+	baseline := SavingsBaseline{ProvisioningHours: 72, AccountLifetimeDays: 30}
+	report := NewSavingsReport(leases, records, baseline, time.Unix(startDate, 0), time.Unix(endDate, 0), "USD")
+	assert.Equal(t, &SavingsReport{
+		StartDate:                startDate,
+		EndDate:                  endDate,
+		Baseline:                 baseline,
+		AccountsReclaimed:        2,
+		ReclaimedByReason:        map[string]int{"Expired": 1, "Stale": 1},
+		IdleSpendPrevented:       40,
+		CostCurrency:             "USD",
+		LeasesProvisioned:        2,
+		AverageProvisioningHours: 3,
+		ProvisioningHoursSaved:   138,
+	}, report)
+
+	usageStart := SavingsUsageStartDate(leases, time.Unix(startDate, 0), time.Unix(endDate, 0))
+	assert.Equal(t, 25*day, usageStart.Unix())
+}
+
+func TestNewSavingsReportWithoutLeases(t *testing.T) {
+	report := NewSavingsReport(nil, nil, SavingsBaseline{ProvisioningHours: 72}, time.Unix(0, 0), time.Unix(86400, 0), "USD")
+	assert.Equal(t, 0, report.AccountsReclaimed)
+	assert.Equal(t, float64(0), report.AverageProvisioningHours)
+	assert.Equal(t, float64(0), report.ProvisioningHoursSaved)
+}
@@ -9,9 +9,9 @@ import (
 
 	"github.com/Optum/dce/pkg/common"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 )
 
 /*
@@ -22,11 +22,14 @@ with the DynamoDB usage table
 // DB contains DynamoDB client and table names
 type DB struct {
 	// DynamoDB Client
-	Client *dynamodb.DynamoDB
+	Client dynamodbiface.DynamoDBAPI
 	// Name of the Usage table
 	UsageTableName   string
 	PartitionKeyName string
 	SortKeyName      string
+	// Name of the table of lease and principal spend aggregates. Spend is
+	// not aggregated when empty
+	AggregateTableName string
 	// Use Consistent Reads when scanning or querying.  When possbile.
 	ConsistentRead bool
 }
@@ -47,6 +50,7 @@ func (db *DB) PutUsage(input Usage) error {
 		log.Print(errorMessage)
 		return err
 	}
+	common.UsageItemSchema.Stamp(item)
 
 	_, err = db.Client.PutItem(
 		&dynamodb.PutItemInput{
@@ -148,7 +152,10 @@ func (db *DB) GetUsageByPrincipal(startDate time.Time, principalID string) ([]*U
 		if len(resp.Item) > 0 {
 			item := Usage{}
 
-			err = dynamodbattribute.UnmarshalMap(resp.Item, &item)
+			err = common.UsageItemSchema.Decode(resp.Item)
+			if err == nil {
+				err = dynamodbattribute.UnmarshalMap(resp.Item, &item)
+			}
 			if err != nil {
 				errorMessage := fmt.Sprintf("Failed to unmarshal Record, %v", err)
 				log.Print(errorMessage)
@@ -245,6 +252,12 @@ func (db *DB) GetUsage(input GetUsageInput) (GetUsageOutput, error) {
 	var results []*Usage
 
 	if len(output.Items) > 0 {
+		for _, item := range output.Items {
+			err = common.UsageItemSchema.Decode(item)
+			if err != nil {
+				return GetUsageOutput{}, err
+			}
+		}
 		err = dynamodbattribute.UnmarshalListOfMaps(output.Items, &results)
 		if err != nil {
 			return GetUsageOutput{}, err
@@ -286,26 +299,30 @@ Requires env vars for:
 
 - AWS_CURRENT_REGION
 - USAGE_CACHE_DB
+
+Spend is aggregated when USAGE_AGGREGATE_DB is set.
 */
 func NewFromEnv() (*DB, error) {
-	awsSession, err := session.NewSession()
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
 	if err != nil {
 		return nil, err
 	}
-	return New(
-		dynamodb.New(
-			awsSession,
-			aws.NewConfig().WithRegion(common.RequireEnv("AWS_CURRENT_REGION")),
-		),
+	db := New(
+		dynamodb.New(awsSession, common.EndpointConfig("DynamoDB")),
 		common.RequireEnv("USAGE_CACHE_DB"),
 		"StartDate",
 		"PrincipalId",
-	), nil
+	)
+	db.AggregateTableName = common.GetEnv("USAGE_AGGREGATE_DB", "")
+	return db, nil
 }
 
 func unmarshalUsageRecord(dbResult map[string]*dynamodb.AttributeValue) (*Usage, error) {
 	usageRecord := Usage{}
-	err := dynamodbattribute.UnmarshalMap(dbResult, &usageRecord)
+	err := common.UsageItemSchema.Decode(dbResult)
+	if err == nil {
+		err = dynamodbattribute.UnmarshalMap(dbResult, &usageRecord)
+	}
 
 	if err != nil {
 		errorMessage := fmt.Sprintf("Failed to unmarshal usage record \"%v\": %s.", dbResult, err)
@@ -0,0 +1,48 @@
+// Package waitlist keeps lease requests which were made while no accounts
+// were Ready, so they can be leased an account, first come first served, as
+// accounts become Ready.
+package waitlist
+
+import (
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/lease"
+)
+
+// Entry is a lease request waiting for a Ready account.  A principal has at
+// most one entry.
+type Entry struct {
+	PrincipalID string `json:"principalId" dynamodbav:"PrincipalId"`
+	// Lease is the lease which was requested
+	Lease lease.Lease `json:"lease" dynamodbav:"Lease"`
+	// RequestedBy is the user who made the request
+	RequestedBy string `json:"requestedBy,omitempty" dynamodbav:"RequestedBy,omitempty"`
+	// RequestedByRole and RequestedByGroups are the role and groups of the
+	// user who made the request, which limit the pools it may be leased an
+	// account from
+	RequestedByRole   string   `json:"requestedByRole,omitempty" dynamodbav:"RequestedByRole,omitempty"`
+	RequestedByGroups []string `json:"requestedByGroups,omitempty" dynamodbav:"RequestedByGroups,omitempty"`
+	CreatedOn         int64    `json:"createdOn" dynamodbav:"CreatedOn"`
+	// ExpiresOn is when the request stops waiting, in epoch seconds
+	ExpiresOn int64 `json:"expiresOn" dynamodbav:"ExpiresOn"`
+	// Position is the place of the entry in the waitlist, starting at 1
+	Position int `json:"position" dynamodbav:"-"`
+}
+
+// Requester returns the user who made the request.  Requests added before
+// their requester's role was recorded were made by users.
+func (e *Entry) Requester() *api.User {
+	role := e.RequestedByRole
+	if role == "" {
+		role = api.UserGroupName
+	}
+	return &api.User{
+		Username: e.RequestedBy,
+		Role:     role,
+		Groups:   e.RequestedByGroups,
+	}
+}
+
+// IsExpired returns whether the request has stopped waiting
+func (e *Entry) IsExpired(now int64) bool {
+	return e.ExpiresOn <= now
+}
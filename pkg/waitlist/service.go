@@ -0,0 +1,186 @@
+package waitlist
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// NewServiceInput are the items needed to create a new waitlist service
+type NewServiceInput struct {
+	DynamoDB dynamodbiface.DynamoDBAPI
+	// TableName is the DynamoDB table of the waitlist.  The waitlist is
+	// disabled when empty.
+	TableName string `env:"LEASE_WAITLIST_TABLE" envDefault:""`
+	// MaxWaitHours is how long a request waits for an account
+	MaxWaitHours int `env:"LEASE_WAITLIST_MAX_WAIT_HOURS" envDefault:"24"`
+}
+
+// Validate the configuration of the waitlist
+func (i *NewServiceInput) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.MaxWaitHours, validation.Required.Error("must be no less than 1"), validation.Min(1)),
+	)
+}
+
+// Service keeps the lease requests waiting for Ready accounts
+type Service struct {
+	dynamodb     dynamodbiface.DynamoDBAPI
+	tableName    string
+	maxWaitHours int
+}
+
+// Enabled returns whether lease requests may wait for an account
+func (s *Service) Enabled() bool {
+	return s.tableName != ""
+}
+
+// Add puts a lease request at the end of the waitlist.  A principal may only
+// wait for one lease at a time.
+func (s *Service) Add(data *lease.Lease, requestedBy *api.User) (*Entry, error) {
+	if !s.Enabled() {
+		return nil, errors.NewValidation("waitlist", fmt.Errorf("the lease waitlist is not enabled"))
+	}
+	if data.PrincipalID == nil || *data.PrincipalID == "" {
+		return nil, errors.NewValidation("waitlist", fmt.Errorf("principalId is required"))
+	}
+
+	now := time.Now()
+	entry := &Entry{
+		PrincipalID: *data.PrincipalID,
+		Lease:       *data,
+		CreatedOn:   now.Unix(),
+		ExpiresOn:   now.Add(time.Duration(s.maxWaitHours) * time.Hour).Unix(),
+	}
+	if requestedBy != nil {
+		entry.RequestedBy = requestedBy.Username
+		entry.RequestedByRole = requestedBy.Role
+		entry.RequestedByGroups = requestedBy.Groups
+	}
+	item, err := dynamodbattribute.MarshalMap(entry)
+	if err != nil {
+		return nil, errors.NewInternalServer("unable to marshal waitlist entry", err)
+	}
+
+	// An expired entry may not have been removed yet
+	_, err = s.dynamodb.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PrincipalId) OR ExpiresOn <= :now"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":now": {N: aws.String(fmt.Sprintf("%d", now.Unix()))},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, errors.NewConflict("waitlist", entry.PrincipalID,
+				fmt.Errorf("principal %s is already waiting for a lease", entry.PrincipalID))
+		}
+		return nil, errors.NewInternalServer(fmt.Sprintf("failed to add principal %s to the waitlist", entry.PrincipalID), err)
+	}
+
+	added, err := s.Get(entry.PrincipalID)
+	if err != nil || added == nil {
+		// The entry was added, but its position isn't known
+		return entry, err
+	}
+	return added, nil
+}
+
+// List returns the requests which are still waiting, oldest first
+func (s *Service) List() ([]*Entry, error) {
+	if !s.Enabled() {
+		return []*Entry{}, nil
+	}
+
+	now := time.Now().Unix()
+	entries := []*Entry{}
+	err := s.dynamodb.ScanPages(&dynamodb.ScanInput{
+		TableName:      aws.String(s.tableName),
+		ConsistentRead: aws.Bool(true),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		items := []*Entry{}
+		err := dynamodbattribute.UnmarshalListOfMaps(page.Items, &items)
+		if err != nil {
+			log.Printf("Failed to read waitlist entries: %s", err)
+			return true
+		}
+		for _, entry := range items {
+			if !entry.IsExpired(now) {
+				entries = append(entries, entry)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.NewInternalServer("failed to scan the waitlist", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].CreatedOn != entries[j].CreatedOn {
+			return entries[i].CreatedOn < entries[j].CreatedOn
+		}
+		return entries[i].PrincipalID < entries[j].PrincipalID
+	})
+	for i, entry := range entries {
+		entry.Position = i + 1
+	}
+	return entries, nil
+}
+
+// Get returns the waiting request of a principal, or nil if they aren't
+// waiting
+func (s *Service) Get(principalID string) (*Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.PrincipalID == principalID {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// Remove takes the request of a principal off the waitlist
+func (s *Service) Remove(principalID string) error {
+	if !s.Enabled() {
+		return errors.NewNotFound("waitlist", principalID)
+	}
+
+	out, err := s.dynamodb.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"PrincipalId": {S: aws.String(principalID)},
+		},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return errors.NewInternalServer(fmt.Sprintf("failed to remove principal %s from the waitlist", principalID), err)
+	}
+	if len(out.Attributes) == 0 {
+		return errors.NewNotFound("waitlist", principalID)
+	}
+	return nil
+}
+
+// NewService creates a new waitlist service
+func NewService(input NewServiceInput) *Service {
+	return &Service{
+		dynamodb:     input.DynamoDB,
+		tableName:    input.TableName,
+		maxWaitHours: input.MaxWaitHours,
+	}
+}
@@ -0,0 +1,126 @@
+package waitlist
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Optum/dce/pkg/api"
+	awsmocks "github.com/Optum/dce/pkg/awsiface/mocks"
+	"github.com/Optum/dce/pkg/errors"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func scanEntries(mockDynamo *awsmocks.DynamoDBAPI, entries ...*Entry) {
+	items := []map[string]*dynamodb.AttributeValue{}
+	for _, entry := range entries {
+		item, _ := dynamodbattribute.MarshalMap(entry)
+		items = append(items, item)
+	}
+	mockDynamo.On("ScanPages", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*dynamodb.ScanOutput, bool) bool)
+			fn(&dynamodb.ScanOutput{Items: items}, true)
+		}).
+		Return(nil)
+}
+
+func TestList(t *testing.T) {
+	now := time.Now().Unix()
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	// Scans aren't ordered
+	scanEntries(mockDynamo,
+		&Entry{PrincipalID: "user3", CreatedOn: now - 10, ExpiresOn: now + 100},
+		&Entry{PrincipalID: "user1", CreatedOn: now - 30, ExpiresOn: now + 100},
+		&Entry{PrincipalID: "expired", CreatedOn: now - 40, ExpiresOn: now - 1},
+		&Entry{PrincipalID: "user2", CreatedOn: now - 20, ExpiresOn: now + 100},
+	)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Waitlist", MaxWaitHours: 24})
+
+	entries, err := svc.List()
+	require.Nil(t, err)
+	principals := []string{}
+	for _, entry := range entries {
+		principals = append(principals, fmt.Sprintf("%d:%s", entry.Position, entry.PrincipalID))
+	}
+	assert.Equal(t, []string{"1:user1", "2:user2", "3:user3"}, principals)
+
+	entry, err := svc.Get("user2")
+	require.Nil(t, err)
+	assert.Equal(t, 2, entry.Position)
+
+	entry, err = svc.Get("expired")
+	require.Nil(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestEntryRequester(t *testing.T) {
+	entry := &Entry{RequestedBy: "admin", RequestedByRole: api.AdminGroupName, RequestedByGroups: []string{"Ops"}}
+	assert.Equal(t, &api.User{Username: "admin", Role: api.AdminGroupName, Groups: []string{"Ops"}}, entry.Requester())
+
+	// Requests added before roles were recorded were made by users
+	entry = &Entry{RequestedBy: "user1"}
+	assert.Equal(t, &api.User{Username: "user1", Role: api.UserGroupName}, entry.Requester())
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("should add the request", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return *input.TableName == "Waitlist" && *input.Item["PrincipalId"].S == "user1" &&
+				*input.Item["RequestedBy"].S == "user1" && *input.Item["RequestedByGroups"].L[0].S == "Engineering"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+		now := time.Now().Unix()
+		scanEntries(mockDynamo,
+			&Entry{PrincipalID: "user0", CreatedOn: now - 30, ExpiresOn: now + 100},
+			&Entry{PrincipalID: "user1", CreatedOn: now, ExpiresOn: now + 24*60*60},
+		)
+		svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Waitlist", MaxWaitHours: 24})
+
+		entry, err := svc.Add(&lease.Lease{PrincipalID: aws.String("user1")},
+			&api.User{Username: "user1", Role: api.UserGroupName, Groups: []string{"Engineering"}})
+		require.Nil(t, err)
+		assert.Equal(t, "user1", entry.PrincipalID)
+		assert.Equal(t, 2, entry.Position)
+		mockDynamo.AssertExpectations(t)
+	})
+
+	t.Run("should conflict when the principal is already waiting", func(t *testing.T) {
+		mockDynamo := &awsmocks.DynamoDBAPI{}
+		mockDynamo.On("PutItem", mock.Anything).
+			Return(nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "failed", nil))
+		svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Waitlist", MaxWaitHours: 24})
+
+		_, err := svc.Add(&lease.Lease{PrincipalID: aws.String("user1")}, &api.User{Username: "user1", Role: api.UserGroupName})
+		assert.Equal(t, "operation cannot be fulfilled on waitlist \"user1\": principal user1 is already waiting for a lease", err.Error())
+		assert.IsType(t, &errors.StatusError{}, err)
+	})
+
+	t.Run("should fail when the waitlist isn't enabled", func(t *testing.T) {
+		svc := NewService(NewServiceInput{})
+
+		_, err := svc.Add(&lease.Lease{PrincipalID: aws.String("user1")}, &api.User{Username: "user1", Role: api.UserGroupName})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	mockDynamo := &awsmocks.DynamoDBAPI{}
+	mockDynamo.On("DeleteItem", mock.MatchedBy(func(input *dynamodb.DeleteItemInput) bool {
+		return *input.Key["PrincipalId"].S == "user1"
+	})).Return(&dynamodb.DeleteItemOutput{
+		Attributes: map[string]*dynamodb.AttributeValue{"PrincipalId": {S: aws.String("user1")}},
+	}, nil)
+	mockDynamo.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	svc := NewService(NewServiceInput{DynamoDB: mockDynamo, TableName: "Waitlist"})
+
+	assert.Nil(t, svc.Remove("user1"))
+	assert.Equal(t, "waitlist \"user2\" not found", svc.Remove("user2").Error())
+}
@@ -0,0 +1,24 @@
+//
+
+package waitlistiface
+
+import (
+	"github.com/Optum/dce/pkg/api"
+	"github.com/Optum/dce/pkg/lease"
+	"github.com/Optum/dce/pkg/waitlist"
+)
+
+// Servicer keeps the lease requests waiting for Ready accounts
+type Servicer interface {
+	// Enabled returns whether lease requests may wait for an account
+	Enabled() bool
+	// Add puts a lease request at the end of the waitlist
+	Add(data *lease.Lease, requestedBy *api.User) (*waitlist.Entry, error)
+	// List returns the requests which are still waiting, oldest first
+	List() ([]*waitlist.Entry, error)
+	// Get returns the waiting request of a principal, or nil if they aren't
+	// waiting
+	Get(principalID string) (*waitlist.Entry, error)
+	// Remove takes the request of a principal off the waitlist
+	Remove(principalID string) error
+}
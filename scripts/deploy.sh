@@ -51,6 +51,17 @@ if [[ -f "$FILE" ]]; then
       s3://${artifactBucket}/codebuild/reset.zip \
       --sse
 
+    # Create or update the standard CloudWatch alarms and dashboard
+    functionError=$(aws lambda invoke \
+      --function-name monitoring-${namespace} \
+      --query FunctionError \
+      --output text \
+      __artifacts__/monitoring.json)
+    if [[ "${functionError}" != "None" ]]; then
+        cat __artifacts__/monitoring.json
+        exit 1
+    fi
+
     # Delete the '__artifacts__/' directory after uploading to the s3 artifact bucket 
     rm -rf __artifacts__
 else 
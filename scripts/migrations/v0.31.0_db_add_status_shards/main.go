@@ -0,0 +1,120 @@
+// This script sets the sharded status of every account and lease, after
+// STATUS_SHARD_COUNT is changed. Records are only updated when their status
+// hasn't changed since they were read, and the sharded status is removed
+// when STATUS_SHARD_COUNT is 1.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Optum/dce/pkg/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+type shardStatusesInput struct {
+	tableName  string
+	statusName string
+	keyNames   []string
+	// shardKey gets the key an item is sharded by
+	shardKey func(item map[string]*dynamodb.AttributeValue) string
+	shards   int
+	dynDB    *dynamodb.DynamoDB
+}
+
+// shardStatuses updates the sharded status of every item in a table
+func shardStatuses(input *shardStatusesInput) (int, error) {
+	shardName := input.statusName + "Shard"
+	updated := 0
+	var updateErr error
+
+	err := input.dynDB.ScanPages(&dynamodb.ScanInput{
+		TableName: aws.String(input.tableName),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, item := range page.Items {
+			status, ok := item[input.statusName]
+			if !ok || status.S == nil {
+				continue
+			}
+
+			key := map[string]*dynamodb.AttributeValue{}
+			for _, name := range input.keyNames {
+				key[name] = item[name]
+			}
+			update := &dynamodb.UpdateItemInput{
+				TableName:           aws.String(input.tableName),
+				Key:                 key,
+				UpdateExpression:    aws.String(fmt.Sprintf("remove %s", shardName)),
+				ConditionExpression: aws.String(fmt.Sprintf("%s = :status", input.statusName)),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":status": status,
+				},
+			}
+			if input.shards > 1 {
+				update.UpdateExpression = aws.String(fmt.Sprintf("set %s = :shard", shardName))
+				update.ExpressionAttributeValues[":shard"] = &dynamodb.AttributeValue{
+					S: aws.String(common.StatusShard(*status.S, input.shardKey(item), input.shards)),
+				}
+			}
+
+			_, err := input.dynDB.UpdateItem(update)
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+				// The status changed, which also set its shard
+				continue
+			}
+			if err != nil {
+				updateErr = err
+				return false
+			}
+			updated++
+		}
+		return true
+	})
+	if err != nil {
+		return updated, err
+	}
+	return updated, updateErr
+}
+
+// main is triggered
+func main() {
+	awsSession, err := common.SharedSession(common.RequireEnv("AWS_CURRENT_REGION"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	dynDB := dynamodb.New(awsSession)
+	shards := common.GetEnvInt("STATUS_SHARD_COUNT", 1)
+
+	updated, err := shardStatuses(&shardStatusesInput{
+		tableName:  common.RequireEnv("ACCOUNT_DB"),
+		statusName: "AccountStatus",
+		keyNames:   []string{"Id"},
+		shardKey: func(item map[string]*dynamodb.AttributeValue) string {
+			return aws.StringValue(item["Id"].S)
+		},
+		shards: shards,
+		dynDB:  dynDB,
+	})
+	if err != nil {
+		log.Fatalf("failed to shard account statuses: %s", err)
+	}
+	log.Printf("Updated %d accounts", updated)
+
+	updated, err = shardStatuses(&shardStatusesInput{
+		tableName:  common.RequireEnv("LEASE_DB"),
+		statusName: "LeaseStatus",
+		keyNames:   []string{"AccountId", "PrincipalId"},
+		shardKey: func(item map[string]*dynamodb.AttributeValue) string {
+			return common.LeaseShardKey(aws.StringValue(item["AccountId"].S), aws.StringValue(item["PrincipalId"].S))
+		},
+		shards: shards,
+		dynDB:  dynDB,
+	})
+	if err != nil {
+		log.Fatalf("failed to shard lease statuses: %s", err)
+	}
+	log.Printf("Updated %d leases", updated)
+}
@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+#
+# Runs each suite of the acceptance tests in its own ephemeral namespace,
+# deployed before and destroyed after the suite, so the suites run in
+# parallel without sharing tables, queues and topics.
+#
+# Usage:
+#   ./scripts/test_acceptance.sh [<suite regexp>]
+#
+# Example:
+#   DCE_TEST_PARALLEL=2 DCE_TEST_ARTIFACTS=bin/build_artifacts.zip \
+#     ./scripts/test_acceptance.sh 'TestApi|TestUsageDb'
+#
+# See tests/harness for the configuration of the namespaces.
+
+set -euo pipefail
+
+filter=${1:-.}
+parallel=${DCE_TEST_PARALLEL:-4}
+export DCE_TEST_EPHEMERAL=true
+
+mkdir -p junit-report
+
+suites=$(go test -list "${filter}" ./tests/acceptance | grep '^Test' || true)
+if [[ -z "${suites}" ]]; then
+  echo "No acceptance test suites match ${filter}"
+  exit 1
+fi
+
+# Run every suite, and report on all of them, even when some fail
+echo "${suites}" | xargs -P "${parallel}" -I {} bash -c '
+  go test -v ./tests/acceptance -run "^{}\$" -test.timeout 60m > "junit-report/{}.log" 2>&1
+  status=$?
+  go-junit-report < "junit-report/{}.log" > "junit-report/{}.xml" || true
+  echo "{}: $([[ ${status} -eq 0 ]] && echo ok || echo FAIL)"
+  exit ${status}
+'
@@ -37,18 +37,17 @@ import (
 	sigv4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/aws/aws-sdk-go/service/iam"
 	aws2 "github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Optum/dce/pkg/db"
 	"github.com/Optum/dce/pkg/usage"
+	"github.com/Optum/dce/tests/harness"
 	"github.com/Optum/dce/tests/testutils"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
 
 func TestMain(m *testing.M) {
-	code := m.Run()
-	os.Exit(code)
+	os.Exit(harness.Main(m))
 }
 
 var (
@@ -62,10 +61,7 @@ var (
 
 func TestApi(t *testing.T) {
 	// Grab the API url from Terraform output
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 	apiURL := tfOut["api_url"].(string)
 	require.NotNil(t, apiURL)
 
@@ -75,7 +71,7 @@ func TestApi(t *testing.T) {
 	dbSvc = db.New(
 		dynamodb.New(
 			awsSession,
-			aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+			testutils.AWSConfig(tfOut["aws_region"].(string), "DynamoDB"),
 		),
 		tfOut["accounts_table_name"].(string),
 		tfOut["leases_table_name"].(string),
@@ -87,7 +83,7 @@ func TestApi(t *testing.T) {
 	usageSvc = usage.New(
 		dynamodb.New(
 			awsSession,
-			aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+			testutils.AWSConfig(tfOut["aws_region"].(string), "DynamoDB"),
 		),
 		tfOut["usage_table_name"].(string),
 		"StartDate",
@@ -96,11 +92,11 @@ func TestApi(t *testing.T) {
 
 	sqsSvc = sqs.New(
 		awsSession,
-		aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+		testutils.AWSConfig(tfOut["aws_region"].(string), "SQS"),
 	)
 	codeBuildSvc = codebuild.New(
 		awsSession,
-		aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+		testutils.AWSConfig(tfOut["aws_region"].(string), "CodeBuild"),
 	)
 	sqsResetURL = tfOut["sqs_reset_queue_url"].(string)
 	codeBuildResetName = tfOut["codebuild_reset_name"].(string)
@@ -177,7 +173,7 @@ func TestApi(t *testing.T) {
 			}
 
 			// Grab policy name from Terraform outputs
-			policyArn := terraform.Output(t, tfOpts, "api_access_policy_arn")
+			policyArn := harness.Output(t, "api_access_policy_arn")
 			require.NotNil(t, policyArn)
 
 			// Configure IAM service
@@ -260,7 +256,7 @@ func TestApi(t *testing.T) {
 		}
 
 		// Grab policy name from Terraform outputs
-		policyArn := terraform.Output(t, tfOpts, "role_user_policy")
+		policyArn := harness.Output(t, "role_user_policy")
 		require.NotNil(t, policyArn)
 
 		// Configure IAM service
@@ -479,7 +475,7 @@ func TestApi(t *testing.T) {
 				AdminRoleArn:     adminRoleArn,
 				PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountID),
 				LastModifiedOn:   timeNow,
-			})
+			}, nil)
 			require.Nil(t, err)
 
 			//////////////////
@@ -814,7 +810,7 @@ func TestApi(t *testing.T) {
 				LastModifiedOn:   timeNow,
 				AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", acctID),
 				PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", acctID),
-			})
+			}, nil)
 			require.Nil(t, err)
 
 			// Create an Lease Entry
@@ -826,7 +822,7 @@ func TestApi(t *testing.T) {
 				CreatedOn:             timeNow,
 				LastModifiedOn:        timeNow,
 				LeaseStatusModifiedOn: timeNow,
-			})
+			}, nil)
 			require.Nil(t, err)
 
 			// Create the Provision Request Body
@@ -870,7 +866,7 @@ func TestApi(t *testing.T) {
 				LastModifiedOn:   timeNow,
 				AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", acctID),
 				PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", acctID),
-			})
+			}, nil)
 			require.Nil(t, err)
 
 			// Create an Lease Entry
@@ -882,7 +878,7 @@ func TestApi(t *testing.T) {
 				CreatedOn:             timeNow,
 				LastModifiedOn:        timeNow,
 				LeaseStatusModifiedOn: timeNow,
-			})
+			}, nil)
 			require.Nil(t, err)
 
 			// Create the Provision Request Body
@@ -1095,7 +1091,7 @@ func TestApi(t *testing.T) {
 						AdminRoleArn:     adminRoleArn,
 						PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", testAccount),
 						LastModifiedOn:   timeNow,
-					})
+					}, nil)
 					require.Nil(t, error)
 
 					// Create a lease
@@ -1775,7 +1771,7 @@ func TestApi(t *testing.T) {
 			PrincipalID:       principalIDOne,
 			LeaseStatus:       db.Active,
 			LeaseStatusReason: db.LeaseActive,
-		})
+		}, nil)
 
 		assert.Nil(t, err)
 
@@ -1785,7 +1781,7 @@ func TestApi(t *testing.T) {
 			PrincipalID:       principalIDTwo,
 			LeaseStatus:       db.Active,
 			LeaseStatusReason: db.LeaseActive,
-		})
+		}, nil)
 
 		assert.Nil(t, err)
 
@@ -1795,7 +1791,7 @@ func TestApi(t *testing.T) {
 			PrincipalID:       principalIDThree,
 			LeaseStatus:       db.Inactive,
 			LeaseStatusReason: db.LeaseActive,
-		})
+		}, nil)
 
 		assert.Nil(t, err)
 
@@ -1805,7 +1801,7 @@ func TestApi(t *testing.T) {
 			PrincipalID:       principalIDFour,
 			LeaseStatus:       db.Active,
 			LeaseStatusReason: db.LeaseActive,
-		})
+		}, nil)
 
 		assert.Nil(t, err)
 
@@ -1815,7 +1811,7 @@ func TestApi(t *testing.T) {
 			PrincipalID:       principalIDOne,
 			LeaseStatus:       db.Inactive,
 			LeaseStatusReason: db.LeaseActive,
-		})
+		}, nil)
 
 		assert.Nil(t, err)
 
@@ -1973,7 +1969,7 @@ func TestApi(t *testing.T) {
 			AdminRoleArn:     adminRoleArn,
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountID),
 			LastModifiedOn:   timeNow,
-		})
+		}, nil)
 		require.Nil(t, error)
 
 		t.Run("Should validate requested lease has a desired expiry date less than today", func(t *testing.T) {
@@ -2006,8 +2002,7 @@ func TestApi(t *testing.T) {
 			// Get nested json in response json
 			err := data["error"].(map[string]interface{})
 			require.Equal(t, "RequestValidationError", err["code"].(string))
-			errStr := fmt.Sprintf("lease validation error: expiresOn: Requested lease has a desired expiry date less than today: %d.", expiresOn)
-			require.Equal(t, errStr, err["message"].(string))
+			require.Equal(t, "lease validation error: expiresOn: must be in the future.", err["message"].(string))
 		})
 
 		t.Run("Should validate requested budget amount", func(t *testing.T) {
@@ -2074,9 +2069,8 @@ func TestApi(t *testing.T) {
 			// Verify error response json
 			// Get nested json in response json
 			err := data["error"].(map[string]interface{})
-			errStr := fmt.Sprintf("Requested lease has a budget expires on of %d, which is greater than max lease period of", expiresOnAfterOneYear)
 			require.Equal(t, "RequestValidationError", err["code"].(string))
-			require.Contains(t, err["message"].(string), errStr)
+			require.Contains(t, err["message"].(string), "from now, the maximum lease duration")
 
 		})
 
@@ -2150,7 +2144,7 @@ func TestApi(t *testing.T) {
 			AccountStatus:    db.Ready,
 			AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", accountIDOne),
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountIDOne),
-		})
+		}, nil)
 		assert.Nil(t, err)
 
 		err = dbSvc.PutAccount(db.Account{
@@ -2158,7 +2152,7 @@ func TestApi(t *testing.T) {
 			AccountStatus:    db.Ready,
 			AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", accountIDTwo),
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountIDTwo),
-		})
+		}, nil)
 		assert.Nil(t, err)
 
 		err = dbSvc.PutAccount(db.Account{
@@ -2166,7 +2160,7 @@ func TestApi(t *testing.T) {
 			AccountStatus:    db.Ready,
 			AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", accountIDThree),
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountIDThree),
-		})
+		}, nil)
 		assert.Nil(t, err)
 
 		err = dbSvc.PutAccount(db.Account{
@@ -2174,7 +2168,7 @@ func TestApi(t *testing.T) {
 			AccountStatus:    db.Ready,
 			AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", accountIDFour),
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountIDFour),
-		})
+		}, nil)
 		assert.Nil(t, err)
 
 		err = dbSvc.PutAccount(db.Account{
@@ -2182,7 +2176,7 @@ func TestApi(t *testing.T) {
 			AccountStatus:    db.NotReady,
 			AdminRoleArn:     fmt.Sprintf("arn:aws:iam::%s:role/adminRole", accountIDFive),
 			PrincipalRoleArn: fmt.Sprintf("arn:aws:iam::%s:role/principalRole", accountIDFive),
-		})
+		}, nil)
 		assert.Nil(t, err)
 
 		t.Run("When there are no query parameters", func(t *testing.T) {
@@ -4,11 +4,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 
+	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/tests/harness"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 )
 
 func TestArtifactsBucket(t *testing.T) {
@@ -17,13 +18,10 @@ func TestArtifactsBucket(t *testing.T) {
 		Region: aws.String(endpoints.UsEast1RegionID),
 	})
 	require.Nil(t, err)
-	s3Client := s3.New(awsSession)
+	s3Client := s3.New(awsSession, common.EndpointConfig("S3"))
 
 	// Grab the bucket name from Terraform output
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	bucketName := terraform.Output(t, tfOpts, "artifacts_bucket_name")
+	bucketName := harness.Output(t, "artifacts_bucket_name")
 
 	t.Run("should be encrypted by default", func(t *testing.T) {
 		// Check that the bucket is encrypted
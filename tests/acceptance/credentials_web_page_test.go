@@ -2,9 +2,9 @@ package tests
 
 import (
 	"bytes"
+	"github.com/Optum/dce/tests/harness"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	sigv4 "github.com/aws/aws-sdk-go/aws/signer/v4"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"testing"
@@ -14,10 +14,7 @@ import (
 func TestCredentialsWebPageLoads(t *testing.T) {
 
 	// Load Terraform outputs
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 	apiURL := tfOut["api_url"].(string)
 
 	var chainCredentials = credentials.NewChainCredentials([]credentials.Provider{
@@ -6,15 +6,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/Optum/dce/tests/harness"
 )
 
 func TestTerraformOutputs(t *testing.T) {
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 
 	assert.Regexp(t,
 		regexp.MustCompile("^Accounts"),
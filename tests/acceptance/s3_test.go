@@ -8,20 +8,17 @@ import (
 	"testing"
 
 	"github.com/Optum/dce/pkg/common"
+	"github.com/Optum/dce/tests/harness"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/require"
 )
 
 func TestS3(t *testing.T) {
 	// Load Terraform outputs
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 
 	// Initialize variables
 	artifactsFilePath := "testdata/s3-test-file.txt"
@@ -36,8 +33,8 @@ func TestS3(t *testing.T) {
 		aws.NewConfig().WithRegion(tfOut["aws_region"].(string)))
 	require.Nil(t, err)
 	s3Svc := &common.S3{
-		Client:  s3.New(awsSession),
-		Manager: s3manager.NewDownloader(awsSession),
+		Client:  s3.New(awsSession, common.EndpointConfig("S3")),
+		Manager: s3manager.NewDownloaderWithClient(s3.New(awsSession, common.EndpointConfig("S3"))),
 	}
 
 	// Test GetObject
@@ -5,10 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Optum/dce/tests/harness"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/require"
 
 	"encoding/json"
@@ -52,10 +52,7 @@ type getItemsResponse struct {
 func TestUpdateLeaseStatusLambda(t *testing.T) {
 
 	// Load Terraform outputs
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 	apiURL := tfOut["api_url"].(string)
 
 	// Configure the DB service
@@ -67,7 +64,7 @@ func TestUpdateLeaseStatusLambda(t *testing.T) {
 	dbSvc = db.New(
 		dynamodb.New(
 			awsSession,
-			aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+			testutils.AWSConfig(tfOut["aws_region"].(string), "DynamoDB"),
 		),
 		tfOut["accounts_table_name"].(string),
 		tfOut["leases_table_name"].(string),
@@ -79,7 +76,7 @@ func TestUpdateLeaseStatusLambda(t *testing.T) {
 	usageSvc = usage.New(
 		dynamodb.New(
 			awsSession,
-			aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+			testutils.AWSConfig(tfOut["aws_region"].(string), "DynamoDB"),
 		),
 		tfOut["usage_table_name"].(string),
 		"StartDate",
@@ -88,11 +85,11 @@ func TestUpdateLeaseStatusLambda(t *testing.T) {
 
 	sqsSvc = sqs.New(
 		awsSession,
-		aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+		testutils.AWSConfig(tfOut["aws_region"].(string), "SQS"),
 	)
 	codeBuildSvc = codebuild.New(
 		awsSession,
-		aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+		testutils.AWSConfig(tfOut["aws_region"].(string), "CodeBuild"),
 	)
 	sqsResetURL = tfOut["sqs_reset_queue_url"].(string)
 	codeBuildResetName = tfOut["codebuild_reset_name"].(string)
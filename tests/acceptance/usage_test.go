@@ -5,22 +5,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Optum/dce/pkg/testutil"
 	"github.com/Optum/dce/pkg/usage"
+	"github.com/Optum/dce/tests/harness"
 	"github.com/Optum/dce/tests/testutils"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestUsageDb(t *testing.T) {
 	// Load Terraform outputs
-	tfOpts := &terraform.Options{
-		TerraformDir: "../../modules",
-	}
-	tfOut := terraform.OutputAll(t, tfOpts)
+	tfOut := harness.Outputs(t)
 
 	// Configure the Usage DB service
 	awsSession, err := session.NewSession()
@@ -28,7 +25,7 @@ func TestUsageDb(t *testing.T) {
 	dbSvc := usage.New(
 		dynamodb.New(
 			awsSession,
-			aws.NewConfig().WithRegion(tfOut["aws_region"].(string)),
+			testutils.AWSConfig(tfOut["aws_region"].(string), "DynamoDB"),
 		),
 		tfOut["usage_table_name"].(string),
 		"StartDate",
@@ -179,47 +176,7 @@ func TestUsageDb(t *testing.T) {
 
 // Remove all records from the Usage table
 func truncateUsageTable(t *testing.T, dbSvc *usage.DB) {
-	/*
-		DynamoDB does not provide a "truncate" method.
-		Instead, we need to find all records in the DB table,
-		and remove them in a "BatchWrite" requests.
-	*/
-
-	// Find all records in the Usage table
-	scanResult, err := dbSvc.Client.Scan(
-		&dynamodb.ScanInput{
-			TableName:      aws.String(dbSvc.UsageTableName),
-			ConsistentRead: aws.Bool(true),
-		},
-	)
-	require.Nil(t, err)
-
-	if len(scanResult.Items) < 1 {
-		return
-	}
-
-	// Populate a list of `DeleteRequests` for each item we found in the table
-	var deleteRequests []*dynamodb.WriteRequest
-	for _, item := range scanResult.Items {
-		deleteRequests = append(deleteRequests, &dynamodb.WriteRequest{
-			DeleteRequest: &dynamodb.DeleteRequest{
-				Key: map[string]*dynamodb.AttributeValue{
-					"StartDate":   item["StartDate"],
-					"PrincipalId": item["PrincipalId"],
-				},
-			},
-		})
-	}
-
-	// Execute Batch requests, to remove all items
-	_, err = dbSvc.Client.BatchWriteItem(
-		&dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]*dynamodb.WriteRequest{
-				dbSvc.UsageTableName: deleteRequests,
-			},
-		},
-	)
-	require.Nil(t, err)
+	testutil.TruncateTable(t, dbSvc.Client, dbSvc.UsageTableName, testutil.UsageTableKeys...)
 }
 
 func getAllUsage(dbSvc *usage.DB, input usage.GetUsageInput) ([]*usage.Usage, error) {
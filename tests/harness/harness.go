@@ -0,0 +1,211 @@
+// Package harness provides the infrastructure the acceptance tests run
+// against.  By default, that's the long-lived DCE deployment of the
+// Terraform module in modules.  With DCE_TEST_EPHEMERAL=true, each test
+// binary instead applies the module with a namespace of its own, eg.
+// test-k3x9q2, runs its tests and destroys the namespace, so suites can run
+// concurrently without sharing tables, queues and topics.  Set
+// DCE_TEST_TERRAFORM_BINARY=tflocal and AWS_ENDPOINT_URL to deploy the
+// namespace to LocalStack.
+package harness
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/caarlos0/env"
+)
+
+// Config configures the infrastructure of the acceptance tests
+type Config struct {
+	// Ephemeral deploys a namespace for the tests, instead of using the
+	// existing deployment
+	Ephemeral bool `env:"DCE_TEST_EPHEMERAL" envDefault:"false"`
+	// NamespacePrefix starts the names of ephemeral namespaces
+	NamespacePrefix string `env:"DCE_TEST_NAMESPACE_PREFIX" envDefault:"test"`
+	// TerraformBinary runs Terraform, eg. tflocal for LocalStack
+	TerraformBinary string `env:"DCE_TEST_TERRAFORM_BINARY" envDefault:"terraform"`
+	// TerraformDir is the DCE module, relative to the test package
+	TerraformDir string `env:"DCE_TEST_TERRAFORM_DIR" envDefault:"../../modules"`
+	// VarFile sets the variables of ephemeral namespaces, besides the
+	// namespace, eg. the budget notification emails
+	VarFile string `env:"DCE_TEST_VAR_FILE"`
+	// Artifacts are the build artifacts deployed to ephemeral namespaces,
+	// eg. bin/build_artifacts.zip.  The lambdas keep their stub code without
+	// them.
+	Artifacts string `env:"DCE_TEST_ARTIFACTS"`
+	// Keep leaves ephemeral namespaces deployed after the tests, eg. to
+	// debug failures
+	Keep bool `env:"DCE_TEST_KEEP" envDefault:"false"`
+}
+
+// NewConfigFromEnv reads the configuration from the environment
+func NewConfigFromEnv() (*Config, error) {
+	config := Config{}
+	if err := env.Parse(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+var current *Stack
+
+// Main runs the tests of a package against the configured infrastructure,
+// deploying an ephemeral namespace before and destroying it after, and
+// returns the exit code, eg.
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(harness.Main(m))
+//	}
+func Main(m *testing.M) int {
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		log.Printf("Failed to configure the test harness: %s", err)
+		return 1
+	}
+
+	stack, err := Up(config)
+	if err != nil {
+		log.Printf("Failed to deploy the test infrastructure: %s", err)
+		if stack != nil {
+			teardown(stack)
+		}
+		return 1
+	}
+	current = stack
+
+	code := m.Run()
+	teardown(stack)
+	return code
+}
+
+func teardown(stack *Stack) {
+	if err := stack.Down(); err != nil {
+		log.Printf("Failed to destroy namespace %q, destroy it manually from %s: %s", stack.Namespace, stack.terraform.Dir, err)
+	}
+}
+
+// Outputs are the Terraform outputs of the infrastructure the tests run
+// against, eg. "accounts_table_name"
+func Outputs(t *testing.T) map[string]interface{} {
+	if current == nil {
+		t.Fatal("The tests must be run by harness.Main")
+	}
+	outputs, err := current.Outputs()
+	if err != nil {
+		t.Fatalf("Failed to read the Terraform outputs: %s", err)
+	}
+	return outputs
+}
+
+// Output is one of the Terraform outputs of the infrastructure the tests run
+// against
+func Output(t *testing.T, name string) string {
+	value, ok := Outputs(t)[name]
+	if !ok {
+		t.Fatalf("There is no %q Terraform output", name)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Stack is the deployment of the DCE module the tests run against
+type Stack struct {
+	// Namespace of the deployment, eg. test-k3x9q2
+	Namespace string
+	// Ephemeral is true when the tests deployed the namespace, and will
+	// destroy it
+	Ephemeral bool
+
+	config      *Config
+	terraform   *terraform
+	readOutputs sync.Once
+	outputs     map[string]interface{}
+	outputsErr  error
+}
+
+// Up deploys an ephemeral namespace when configured to.  Otherwise, the
+// tests use the existing deployment.  A failed deployment returns the stack,
+// so what was deployed can be destroyed.
+func Up(config *Config) (*Stack, error) {
+	if !config.Ephemeral {
+		return &Stack{
+			config:    config,
+			terraform: &terraform{Binary: config.TerraformBinary, Dir: config.TerraformDir},
+		}, nil
+	}
+
+	namespace, err := NewNamespace(config.NamespacePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Terraform runs in a copy of the module, so concurrent namespaces don't
+	// share its state
+	dir, err := copyModule(config.TerraformDir)
+	if err != nil {
+		return nil, err
+	}
+	varFile := config.VarFile
+	if varFile != "" {
+		if varFile, err = filepath.Abs(varFile); err != nil {
+			return nil, err
+		}
+	}
+
+	stack := &Stack{
+		Namespace: namespace,
+		Ephemeral: true,
+		config:    config,
+		terraform: &terraform{
+			Binary:  config.TerraformBinary,
+			Dir:     dir,
+			Vars:    map[string]string{"namespace": namespace},
+			VarFile: varFile,
+		},
+	}
+
+	log.Printf("Deploying namespace %q from %s", namespace, dir)
+	if err := stack.terraform.initAndApply(); err != nil {
+		return stack, err
+	}
+
+	if config.Artifacts != "" {
+		bucket, err := stack.terraform.output("artifacts_bucket_name")
+		if err != nil {
+			return stack, err
+		}
+		if err := deployArtifacts(config.TerraformDir, config.Artifacts, namespace, bucket); err != nil {
+			return stack, err
+		}
+	}
+
+	return stack, nil
+}
+
+// Outputs are the Terraform outputs of the stack
+func (s *Stack) Outputs() (map[string]interface{}, error) {
+	s.readOutputs.Do(func() {
+		s.outputs, s.outputsErr = s.terraform.outputAll()
+	})
+	return s.outputs, s.outputsErr
+}
+
+// Down destroys the namespace of the stack, when the tests deployed it
+func (s *Stack) Down() error {
+	if !s.Ephemeral {
+		return nil
+	}
+	if s.config.Keep {
+		log.Printf("Keeping namespace %q deployed from %s", s.Namespace, s.terraform.Dir)
+		return nil
+	}
+
+	log.Printf("Destroying namespace %q", s.Namespace)
+	if err := s.terraform.destroy(); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Dir(s.terraform.Dir))
+}
@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceWithID(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		id           []byte
+		expNamespace string
+		expErr       string
+	}{
+		{
+			name:         "should prefix the id",
+			prefix:       "test",
+			id:           []byte{10, 3, 33, 45, 0, 255},
+			expNamespace: "test-kd7jad",
+		},
+		{
+			name:         "should allow no prefix",
+			id:           []byte{0, 1, 2, 3, 4, 5},
+			expNamespace: "abcdef",
+		},
+		{
+			name:   "should fail on uppercase prefixes",
+			prefix: "Test",
+			id:     []byte{0, 1, 2, 3, 4, 5},
+			expErr: "namespace prefix \"Test\" must be lowercase letters, numbers and -",
+		},
+		{
+			name:   "should fail on long prefixes",
+			prefix: "acceptance-tests",
+			id:     []byte{0, 1, 2, 3, 4, 5},
+			expErr: "namespace prefix \"acceptance-tests\" must be at most 13 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, err := namespaceWithID(tt.prefix, tt.id)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expNamespace, namespace)
+		})
+	}
+}
+
+func TestNewNamespace(t *testing.T) {
+	first, err := NewNamespace("test")
+	require.Nil(t, err)
+	second, err := NewNamespace("test")
+	require.Nil(t, err)
+
+	assert.Regexp(t, "^test-[a-z0-9]{6}$", first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestParseOutputs(t *testing.T) {
+	outputs, err := parseOutputs([]byte(`{
+		"accounts_table_name": {"sensitive": false, "type": "string", "value": "Accountstest"},
+		"api_url": {"sensitive": false, "type": "string", "value": "https://abc123.execute-api.us-east-1.amazonaws.com/api"}
+	}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"accounts_table_name": "Accountstest",
+		"api_url":             "https://abc123.execute-api.us-east-1.amazonaws.com/api",
+	}, outputs)
+
+	_, err = parseOutputs([]byte("The state file either has no outputs defined"))
+	assert.EqualError(t, err, "failed to parse the Terraform outputs: invalid character 'T' looking for beginning of value")
+}
+
+func TestVarArgs(t *testing.T) {
+	tf := &terraform{
+		Vars:    map[string]string{"namespace": "test-k3x9q2", "aws_region": "us-east-1"},
+		VarFile: "/tmp/test.tfvars",
+	}
+
+	assert.Equal(t, []string{
+		"-var", "aws_region=us-east-1",
+		"-var", "namespace=test-k3x9q2",
+		"-var-file", "/tmp/test.tfvars",
+	}, tf.varArgs())
+}
+
+func TestUpWithoutEphemeral(t *testing.T) {
+	stack, err := Up(&Config{TerraformBinary: "terraform", TerraformDir: "../../modules"})
+
+	assert.Nil(t, err)
+	assert.False(t, stack.Ephemeral)
+	assert.Equal(t, "../../modules", stack.terraform.Dir)
+	// The existing deployment is never destroyed
+	assert.Nil(t, stack.Down())
+}
@@ -0,0 +1,170 @@
+package harness
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+)
+
+// maxNamespaceLength keeps the names of the namespace's resources within
+// AWS limits, eg. the 63 characters of the artifacts bucket name
+const maxNamespaceLength = 20
+
+const namespaceIDLength = 6
+
+var namespacePattern = regexp.MustCompile("^[a-z0-9-]+$")
+
+// NewNamespace returns a unique namespace starting with the prefix, eg.
+// test-k3x9q2
+func NewNamespace(prefix string) (string, error) {
+	id := make([]byte, namespaceIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return namespaceWithID(prefix, id)
+}
+
+func namespaceWithID(prefix string, id []byte) (string, error) {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	suffix := make([]byte, len(id))
+	for i, b := range id {
+		suffix[i] = chars[int(b)%len(chars)]
+	}
+
+	namespace := string(suffix)
+	if prefix != "" {
+		namespace = prefix + "-" + namespace
+	}
+	if !namespacePattern.MatchString(namespace) {
+		return "", fmt.Errorf("namespace prefix %q must be lowercase letters, numbers and -", prefix)
+	}
+	if len(namespace) > maxNamespaceLength {
+		return "", fmt.Errorf("namespace prefix %q must be at most %d characters", prefix, maxNamespaceLength-namespaceIDLength-1)
+	}
+	return namespace, nil
+}
+
+// terraform runs the Terraform commands of a module
+type terraform struct {
+	Binary  string
+	Dir     string
+	Vars    map[string]string
+	VarFile string
+}
+
+func (tf *terraform) initAndApply() error {
+	if _, err := tf.run("init", "-input=false", "-no-color"); err != nil {
+		return err
+	}
+	_, err := tf.run(append([]string{"apply", "-input=false", "-no-color", "-auto-approve"}, tf.varArgs()...)...)
+	return err
+}
+
+func (tf *terraform) destroy() error {
+	_, err := tf.run(append([]string{"destroy", "-input=false", "-no-color", "-auto-approve"}, tf.varArgs()...)...)
+	return err
+}
+
+func (tf *terraform) output(name string) (string, error) {
+	out, err := tf.run("output", "-no-color", name)
+	return strings.TrimSpace(out), err
+}
+
+// outputAll returns the values of the outputs, like terratest's
+// terraform.OutputAll
+func (tf *terraform) outputAll() (map[string]interface{}, error) {
+	out, err := tf.run("output", "-no-color", "-json")
+	if err != nil {
+		return nil, err
+	}
+	return parseOutputs([]byte(out))
+}
+
+func parseOutputs(out []byte) (map[string]interface{}, error) {
+	outputs := map[string]struct {
+		Value interface{} `json:"value"`
+	}{}
+	if err := json.Unmarshal(out, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse the Terraform outputs: %s", err)
+	}
+
+	values := make(map[string]interface{}, len(outputs))
+	for name, output := range outputs {
+		values[name] = output.Value
+	}
+	return values, nil
+}
+
+func (tf *terraform) varArgs() []string {
+	names := make([]string, 0, len(tf.Vars))
+	for name := range tf.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := []string{}
+	for _, name := range names {
+		args = append(args, "-var", fmt.Sprintf("%s=%s", name, tf.Vars[name]))
+	}
+	if tf.VarFile != "" {
+		args = append(args, "-var-file", tf.VarFile)
+	}
+	return args
+}
+
+func (tf *terraform) run(args ...string) (string, error) {
+	cmd := exec.Command(tf.Binary, args...)
+	cmd.Dir = tf.Dir
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), fmt.Errorf("%s %s failed in %s: %s", tf.Binary, args[0], tf.Dir, err)
+	}
+	return stdout.String(), nil
+}
+
+// copyModule copies the module, without its state and variables, to a
+// directory of its own in the temporary directory
+func copyModule(dir string) (string, error) {
+	return files.CopyTerraformFolderToTemp(dir, "dce-tests-")
+}
+
+// deployArtifacts deploys the lambda and CodeBuild code to the namespace,
+// with scripts/deploy.sh.  The script unzips the artifacts in its working
+// directory, so each namespace gets its own.  moduleDir is the DCE module,
+// next to the scripts.
+func deployArtifacts(moduleDir string, artifacts string, namespace string, bucket string) error {
+	artifacts, err := filepath.Abs(artifacts)
+	if err != nil {
+		return err
+	}
+	script, err := filepath.Abs(filepath.Join(moduleDir, "..", "scripts", "deploy.sh"))
+	if err != nil {
+		return err
+	}
+	workDir, err := ioutil.TempDir("", "dce-artifacts-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	cmd := exec.Command(script, artifacts, namespace, bucket)
+	cmd.Dir = workDir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to deploy %s to namespace %q: %s", artifacts, namespace, err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Optum/dce/pkg/common"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/codebuild"
@@ -86,3 +87,10 @@ func GivenCodeBuildIsEmpty(t *testing.T, svc codebuildiface.CodeBuildAPI, codeBu
 
 	require.Nil(t, p.Err())
 }
+
+// AWSConfig configures the client of an AWS service for the tests, using the
+// custom endpoint of the service when one is set, eg. to run the tests
+// against LocalStack
+func AWSConfig(region string, service string) *aws.Config {
+	return common.EndpointConfig(service).WithRegion(region)
+}